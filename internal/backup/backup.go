@@ -2,12 +2,14 @@
 package backup
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	gosync "sync"
 	"time"
 
 	"github.com/klauern/skillsync/internal/util"
@@ -22,17 +24,24 @@ const (
 
 // Options configures backup behavior
 type Options struct {
-	Platform    string            // Platform identifier (claude-code, cursor, codex)
+	Platform    string            // Platform identifier (claude-code, cursor, codex, aider)
 	Description string            // Human-readable description
 	Metadata    map[string]string // Additional metadata
 	Tags        []string          // Tags for categorization
+	SessionID   string            // Sync session that created the backup, if any
+	Lineage     string            // Logical skill the backup belongs to (default: the "skill" metadata or the source file's skill name)
+	SnapshotID  string            // Snapshot the backup is part of, if any
 }
 
+// indexMu serializes index updates so concurrent sync workers can back up
+// files without losing each other's index entries.
+var indexMu gosync.Mutex
+
 // CreateBackup creates a backup of the specified file or directory
 func CreateBackup(sourcePath string, opts Options) (*Metadata, error) {
 	// Ensure backups directory exists
 	backupsDir := util.SkillsyncBackupsPath()
-	if err := os.MkdirAll(backupsDir, BackupDirPerm); err != nil {
+	if err := util.EnsureDataDir(backupsDir, BackupDirPerm); err != nil {
 		return nil, fmt.Errorf("failed to create backups directory: %w", err)
 	}
 
@@ -53,8 +62,24 @@ func CreateBackup(sourcePath string, opts Options) (*Metadata, error) {
 	hash := sha256.Sum256(content)
 	hashStr := hex.EncodeToString(hash[:])
 
-	// Generate backup ID (timestamp-based)
+	indexMu.Lock()
+	defer indexMu.Unlock()
+
+	index, err := LoadIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backup index: %w", err)
+	}
+
+	// Generate backup ID (timestamp-based), keeping it unique when identical
+	// content is backed up more than once within the same second
 	backupID := time.Now().Format("20060102-150405-") + hashStr[:8]
+	if _, exists := index.Backups[backupID]; exists {
+		base := backupID
+		for n := 2; exists; n++ {
+			backupID = base + "-" + strconv.Itoa(n)
+			_, exists = index.Backups[backupID]
+		}
+	}
 
 	// Create platform-specific backup directory
 	platformDir := filepath.Join(backupsDir, opts.Platform)
@@ -84,12 +109,17 @@ func CreateBackup(sourcePath string, opts Options) (*Metadata, error) {
 		Description: opts.Description,
 		Metadata:    opts.Metadata,
 		Tags:        opts.Tags,
+		SessionID:   opts.SessionID,
+		Lineage:     opts.Lineage,
+		SnapshotID:  opts.SnapshotID,
+	}
+	if metadata.Lineage == "" {
+		metadata.Lineage = metadata.LineageKey()
 	}
 
-	// Load index and add backup
-	index, err := LoadIndex()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load backup index: %w", err)
+	// Record metadata next to the blob so the index can be rebuilt from disk
+	if err := writeSidecar(metadata); err != nil {
+		return nil, err
 	}
 
 	if err := index.AddBackup(*metadata); err != nil {
@@ -165,6 +195,9 @@ func ListBackups(platform string) ([]Metadata, error) {
 
 // DeleteBackup deletes a backup and removes it from the index
 func DeleteBackup(backupID string) error {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+
 	// Load index
 	index, err := LoadIndex()
 	if err != nil {
@@ -181,6 +214,9 @@ func DeleteBackup(backupID string) error {
 	if err := os.Remove(metadata.BackupPath); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to delete backup file: %w", err)
 	}
+	if err := os.Remove(sidecarPath(metadata.BackupPath)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete backup metadata: %w", err)
+	}
 
 	// Remove from index
 	if err := index.RemoveBackup(backupID); err != nil {
@@ -235,31 +271,6 @@ func VerifyBackup(backupID string) error {
 		return fmt.Errorf("backup %q not found", backupID)
 	}
 
-	// Check if file exists
-	if _, err := os.Stat(metadata.BackupPath); os.IsNotExist(err) {
-		return fmt.Errorf("backup file missing: %s", metadata.BackupPath)
-	}
-
-	// Read and hash file
-	file, err := os.Open(metadata.BackupPath)
-	if err != nil {
-		return fmt.Errorf("failed to open backup file: %w", err)
-	}
-	defer func() {
-		if closeErr := file.Close(); closeErr != nil {
-			err = fmt.Errorf("failed to close backup file: %w", closeErr)
-		}
-	}()
-
-	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return fmt.Errorf("failed to read backup file: %w", err)
-	}
-
-	hashStr := hex.EncodeToString(hash.Sum(nil))
-	if hashStr != metadata.Hash {
-		return fmt.Errorf("backup file corrupted: hash mismatch (expected %s, got %s)", metadata.Hash, hashStr)
-	}
-
-	return nil
+	_, err = verifyFile(context.Background(), metadata)
+	return err
 }
@@ -3,18 +3,40 @@ package backup
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
 	"time"
 )
 
+// Default retention of automatic cleanup, used unless the config sets
+// backup.max_count and backup.max_age_days.
+const (
+	// DefaultMaxBackups is how many backups are kept per lineage on each platform
+	DefaultMaxBackups = 10
+	// DefaultMaxAgeDays is how many days backups are kept
+	DefaultMaxAgeDays = 30
+)
+
 // CleanupOptions configures backup cleanup behavior
 type CleanupOptions struct {
-	// MaxBackups limits the number of backups to keep per platform (0 = unlimited)
+	// MaxBackups limits the number of backups to keep per lineage on each
+	// platform (0 = unlimited)
 	MaxBackups int
 
+	// LineageLimits overrides MaxBackups for individual lineages, so rarely
+	// changed but important skills can keep a longer history (0 = unlimited)
+	LineageLimits map[string]int
+
 	// MaxAge is the maximum age of backups to keep (0 = unlimited)
 	MaxAge time.Duration
 
-	// KeepAtLeastOne ensures at least one backup is kept per source file
+	// MaxTotalSize limits the total size in bytes of each platform's
+	// backups; the oldest are removed first (0 = unlimited)
+	MaxTotalSize int64
+
+	// KeepAtLeastOne ensures at least one backup is kept per lineage, even
+	// past MaxAge and MaxTotalSize
 	KeepAtLeastOne bool
 
 	// Platform filters cleanup to a specific platform (empty = all platforms)
@@ -24,29 +46,51 @@ type CleanupOptions struct {
 // DefaultCleanupOptions returns sensible defaults for cleanup
 func DefaultCleanupOptions() CleanupOptions {
 	return CleanupOptions{
-		MaxBackups:     10,                  // Keep last 10 backups per platform
-		MaxAge:         30 * 24 * time.Hour, // Keep backups for 30 days
+		MaxBackups:     DefaultMaxBackups,                  // Keep last 10 backups per skill
+		MaxAge:         DefaultMaxAgeDays * 24 * time.Hour, // Keep backups for 30 days
 		KeepAtLeastOne: true,
 		Platform:       "",
 	}
 }
 
-// CleanupBackups removes old backups based on the specified options
+// CleanupBackups removes old backups based on the specified options.
+// Limits apply to each lineage on each platform separately, so frequently
+// synced skills never push out the backups of other skills.
 func CleanupBackups(opts CleanupOptions) ([]string, error) {
+	toDelete, err := PlanCleanup(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete backups
+	var deleted []string
+	for _, backup := range toDelete {
+		if err := DeleteBackup(backup.ID); err != nil {
+			return deleted, fmt.Errorf("failed to delete backup %q: %w", backup.ID, err)
+		}
+		deleted = append(deleted, backup.ID)
+	}
+
+	return deleted, nil
+}
+
+// PlanCleanup returns the backups CleanupBackups would remove with opts,
+// newest first within each platform, without removing them.
+func PlanCleanup(opts CleanupOptions) ([]Metadata, error) {
 	// Load index
 	index, err := LoadIndex()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load backup index: %w", err)
 	}
 
-	// Group backups by platform and source path
+	// Group backups by platform and lineage
 	type backupGroup struct {
-		platform   string
-		sourcePath string
-		backups    []Metadata
+		lineage string
+		backups []Metadata
 	}
 
 	groups := make(map[string]*backupGroup)
+	platforms := make(map[string][]Metadata)
 
 	for _, backup := range index.Backups {
 		// Filter by platform if specified
@@ -54,73 +98,86 @@ func CleanupBackups(opts CleanupOptions) ([]string, error) {
 			continue
 		}
 
-		key := backup.Platform + ":" + backup.SourcePath
+		lineage := backup.LineageKey()
+		key := backup.Platform + ":" + lineage
 		if _, exists := groups[key]; !exists {
 			groups[key] = &backupGroup{
-				platform:   backup.Platform,
-				sourcePath: backup.SourcePath,
-				backups:    make([]Metadata, 0),
+				lineage: lineage,
+				backups: make([]Metadata, 0),
 			}
 		}
 		groups[key].backups = append(groups[key].backups, backup)
+		platforms[backup.Platform] = append(platforms[backup.Platform], backup)
 	}
 
 	// Sort backups in each group by creation time (newest first)
 	for _, group := range groups {
-		for i := 0; i < len(group.backups)-1; i++ {
-			for j := i + 1; j < len(group.backups); j++ {
-				if group.backups[i].CreatedAt.Before(group.backups[j].CreatedAt) {
-					group.backups[i], group.backups[j] = group.backups[j], group.backups[i]
-				}
-			}
-		}
+		sortNewestFirst(group.backups)
 	}
 
 	// Determine which backups to delete
-	var toDelete []string
+	deleting := make(map[string]bool)
+	newest := make(map[string]bool)
 	now := time.Now()
 
 	for _, group := range groups {
-		keepCount := 0
-		for idx, backup := range group.backups {
-			shouldDelete := false
+		maxBackups := opts.MaxBackups
+		if limit, ok := opts.LineageLimits[group.lineage]; ok {
+			maxBackups = limit
+		}
 
+		var groupDeletes []string
+		for idx, backup := range group.backups {
 			// Check age
-			if opts.MaxAge > 0 && now.Sub(backup.CreatedAt) > opts.MaxAge {
-				shouldDelete = true
-			}
-
+			tooOld := opts.MaxAge > 0 && now.Sub(backup.CreatedAt) > opts.MaxAge
 			// Check count limit
-			if opts.MaxBackups > 0 && idx >= opts.MaxBackups {
-				shouldDelete = true
-			}
-
-			if !shouldDelete {
-				keepCount++
-			}
+			tooMany := maxBackups > 0 && idx >= maxBackups
 
-			if shouldDelete {
-				toDelete = append(toDelete, backup.ID)
+			if tooOld || tooMany {
+				groupDeletes = append(groupDeletes, backup.ID)
 			}
 		}
 
 		// If KeepAtLeastOne is true and we're deleting everything, keep the newest
-		if opts.KeepAtLeastOne && keepCount == 0 && len(toDelete) > 0 {
-			// Remove the first item from toDelete (newest backup)
-			toDelete = toDelete[1:]
+		if opts.KeepAtLeastOne && len(groupDeletes) == len(group.backups) && len(groupDeletes) > 0 {
+			groupDeletes = groupDeletes[1:]
+		}
+		for _, id := range groupDeletes {
+			deleting[id] = true
+		}
+		if opts.KeepAtLeastOne && len(group.backups) > 0 {
+			newest[group.backups[0].ID] = true
 		}
 	}
 
-	// Delete backups
-	var deleted []string
-	for _, backupID := range toDelete {
-		if err := DeleteBackup(backupID); err != nil {
-			return deleted, fmt.Errorf("failed to delete backup %q: %w", backupID, err)
+	// Keep the newest of each platform's remaining backups that fit in the
+	// size limit; the newest backup of a lineage counts but is never removed
+	// while KeepAtLeastOne is set
+	var toDelete []Metadata
+	for _, platform := range slices.Sorted(maps.Keys(platforms)) {
+		backups := platforms[platform]
+		sortNewestFirst(backups)
+		var total int64
+		for _, backup := range backups {
+			if !deleting[backup.ID] && opts.MaxTotalSize > 0 && total+backup.Size > opts.MaxTotalSize && !newest[backup.ID] {
+				deleting[backup.ID] = true
+			}
+			if deleting[backup.ID] {
+				toDelete = append(toDelete, backup)
+				continue
+			}
+			total += backup.Size
 		}
-		deleted = append(deleted, backupID)
 	}
 
-	return deleted, nil
+	return toDelete, nil
+}
+
+// sortNewestFirst sorts backups by creation time, newest first.
+func sortNewestFirst(backups []Metadata) {
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
 }
 
 // GetStats returns statistics about backups
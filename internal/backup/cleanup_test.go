@@ -552,6 +552,74 @@ func TestCleanupBackups_CombinedAgeAndCount(t *testing.T) {
 	util.AssertEqual(t, len(remaining), 3)
 }
 
+func TestPlanCleanup_MaxTotalSize(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	index, err := LoadIndex()
+	if err != nil {
+		t.Fatalf("LoadIndex failed: %v", err)
+	}
+
+	// Two lineages of 100-byte backups on one platform, and one on another
+	now := time.Now()
+	backups := []Metadata{
+		{ID: "lint-1", Platform: "claude-code", SourcePath: "/test/lint.md", CreatedAt: now.Add(-1 * time.Hour), Size: 100},
+		{ID: "test-1", Platform: "claude-code", SourcePath: "/test/test.md", CreatedAt: now.Add(-2 * time.Hour), Size: 100},
+		{ID: "lint-2", Platform: "claude-code", SourcePath: "/test/lint.md", CreatedAt: now.Add(-3 * time.Hour), Size: 100},
+		{ID: "test-2", Platform: "claude-code", SourcePath: "/test/test.md", CreatedAt: now.Add(-4 * time.Hour), Size: 100},
+		{ID: "cursor-1", Platform: "cursor", SourcePath: "/test/lint.md", CreatedAt: now.Add(-5 * time.Hour), Size: 100},
+	}
+	for _, backup := range backups {
+		if err := index.AddBackup(backup); err != nil {
+			t.Fatalf("AddBackup failed: %v", err)
+		}
+	}
+
+	tests := map[string]struct {
+		opts CleanupOptions
+		want []string
+	}{
+		"oldest past the limit": {
+			opts: CleanupOptions{MaxTotalSize: 250},
+			want: []string{"lint-2", "test-2"},
+		},
+		"newest of each lineage is kept": {
+			opts: CleanupOptions{MaxTotalSize: 50, KeepAtLeastOne: true},
+			want: []string{"lint-2", "test-2"},
+		},
+		"limit applies per platform": {
+			opts: CleanupOptions{MaxTotalSize: 50},
+			want: []string{"lint-1", "test-1", "lint-2", "test-2", "cursor-1"},
+		},
+		"combined with count": {
+			opts: CleanupOptions{MaxBackups: 1, MaxTotalSize: 150, Platform: "claude-code"},
+			want: []string{"test-1", "lint-2", "test-2"},
+		},
+		"unlimited": {
+			opts: CleanupOptions{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			planned, err := PlanCleanup(tt.opts)
+			util.AssertNoError(t, err)
+
+			var ids []string
+			for _, b := range planned {
+				ids = append(ids, b.ID)
+			}
+			util.AssertEqual(t, fmt.Sprint(ids), fmt.Sprint(tt.want))
+		})
+	}
+
+	// Planning removes nothing
+	remaining, err := ListBackups("")
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(remaining), len(backups))
+}
+
 func TestGetStats_EmptyIndex(t *testing.T) {
 	tempHome := util.CreateTempDir(t)
 	t.Setenv("SKILLSYNC_HOME", tempHome)
@@ -704,3 +772,114 @@ func TestGetStats_OldestNewestTracking(t *testing.T) {
 		t.Errorf("NewestBackup = %v, want %v", stats.NewestBackup, newest)
 	}
 }
+
+func TestCleanupBackups_PerLineage(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	index, err := LoadIndex()
+	if err != nil {
+		t.Fatalf("LoadIndex failed: %v", err)
+	}
+	backupDir := filepath.Join(tempHome, "backups")
+	if err := os.MkdirAll(backupDir, 0o750); err != nil {
+		t.Fatalf("failed to create backup dir: %v", err)
+	}
+
+	// "lint" is synced often, from two paths after a move; "release" rarely
+	now := time.Now()
+	add := func(id, sourcePath, lineage string, age time.Duration) {
+		backup := Metadata{
+			ID:         id,
+			Platform:   "claude-code",
+			SourcePath: sourcePath,
+			Lineage:    lineage,
+			CreatedAt:  now.Add(-age),
+			BackupPath: filepath.Join(backupDir, id+".md"),
+		}
+		if err := os.WriteFile(backup.BackupPath, []byte("content"), 0o600); err != nil {
+			t.Fatalf("failed to create backup file: %v", err)
+		}
+		if err := index.AddBackup(backup); err != nil {
+			t.Fatalf("AddBackup failed: %v", err)
+		}
+	}
+	for i := range 3 {
+		add(fmt.Sprintf("lint-old-%d", i), "/old/lint.md", "lint", time.Duration(10+i)*time.Hour)
+		add(fmt.Sprintf("lint-new-%d", i), "/new/lint/SKILL.md", "lint", time.Duration(i)*time.Hour)
+	}
+	for i := range 3 {
+		add(fmt.Sprintf("release-%d", i), "/release.md", "release", time.Duration(100+i)*time.Hour)
+	}
+
+	deleted, err := CleanupBackups(CleanupOptions{
+		MaxBackups:    2,
+		LineageLimits: map[string]int{"release": 0},
+	})
+	if err != nil {
+		t.Fatalf("CleanupBackups failed: %v", err)
+	}
+
+	// Both paths count towards one lineage; release is unlimited
+	util.AssertEqual(t, len(deleted), 4)
+	lineages := Lineages(mustListBackups(t))
+	util.AssertEqual(t, lineages[0].Lineage, "lint")
+	util.AssertEqual(t, lineages[0].Backups, 2)
+	util.AssertEqual(t, lineages[0].Oldest.Equal(now.Add(-time.Hour)), true)
+	util.AssertEqual(t, lineages[1].Backups, 3)
+}
+
+func TestCleanupBackups_KeepAtLeastOnePerLineage(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	index, err := LoadIndex()
+	if err != nil {
+		t.Fatalf("LoadIndex failed: %v", err)
+	}
+	backupDir := filepath.Join(tempHome, "backups")
+	if err := os.MkdirAll(backupDir, 0o750); err != nil {
+		t.Fatalf("failed to create backup dir: %v", err)
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	for _, lineage := range []string{"a", "b"} {
+		for i := range 2 {
+			backup := Metadata{
+				ID:         fmt.Sprintf("%s-%d", lineage, i),
+				Platform:   "cursor",
+				Lineage:    lineage,
+				CreatedAt:  old.Add(-time.Duration(i) * time.Hour),
+				BackupPath: filepath.Join(backupDir, fmt.Sprintf("%s-%d.md", lineage, i)),
+			}
+			if err := os.WriteFile(backup.BackupPath, []byte("content"), 0o600); err != nil {
+				t.Fatalf("failed to create backup file: %v", err)
+			}
+			if err := index.AddBackup(backup); err != nil {
+				t.Fatalf("AddBackup failed: %v", err)
+			}
+		}
+	}
+
+	deleted, err := CleanupBackups(CleanupOptions{MaxAge: time.Hour, KeepAtLeastOne: true})
+	if err != nil {
+		t.Fatalf("CleanupBackups failed: %v", err)
+	}
+
+	// Each lineage keeps its newest backup
+	util.AssertEqual(t, len(deleted), 2)
+	remaining := make(map[string]bool)
+	for _, b := range mustListBackups(t) {
+		remaining[b.ID] = true
+	}
+	util.AssertEqual(t, remaining["a-0"] && remaining["b-0"], true)
+}
+
+func mustListBackups(t *testing.T) []Metadata {
+	t.Helper()
+	backups, err := ListBackups("")
+	if err != nil {
+		t.Fatalf("ListBackups failed: %v", err)
+	}
+	return backups
+}
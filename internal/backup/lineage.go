@@ -0,0 +1,120 @@
+package backup
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A lineage groups all backups of the same logical skill, whatever file or
+// platform they were taken from, so retention can be applied per skill and a
+// skill's history followed across renames.
+
+// LineageKey returns the lineage of the backup: its recorded lineage, or for
+// backups made before lineages were recorded, the skill named in its metadata
+// or derived from its source path.
+func (m Metadata) LineageKey() string {
+	if m.Lineage != "" {
+		return m.Lineage
+	}
+	if skill := m.Metadata["skill"]; skill != "" {
+		return skill
+	}
+	return skillNameFromPath(m.SourcePath)
+}
+
+// skillNameFromPath returns the skill name a file path implies: the directory
+// of a SKILL.md, otherwise the file name without its extension.
+func skillNameFromPath(path string) string {
+	base := filepath.Base(path)
+	if strings.EqualFold(base, "SKILL.md") {
+		return filepath.Base(filepath.Dir(path))
+	}
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// LineageSummary describes the backups of one lineage.
+type LineageSummary struct {
+	Lineage   string    `json:"lineage"`
+	Backups   int       `json:"backups"`
+	Platforms []string  `json:"platforms"`
+	Size      int64     `json:"size"`
+	Oldest    time.Time `json:"oldest"`
+	Newest    time.Time `json:"newest"`
+}
+
+// Lineages summarizes backups by lineage, sorted by lineage name.
+func Lineages(backups []Metadata) []LineageSummary {
+	byLineage := make(map[string]*LineageSummary)
+	platforms := make(map[string]map[string]bool)
+	for _, b := range backups {
+		key := b.LineageKey()
+		summary, ok := byLineage[key]
+		if !ok {
+			summary = &LineageSummary{Lineage: key, Oldest: b.CreatedAt, Newest: b.CreatedAt}
+			byLineage[key] = summary
+			platforms[key] = make(map[string]bool)
+		}
+		summary.Backups++
+		summary.Size += b.Size
+		if b.CreatedAt.Before(summary.Oldest) {
+			summary.Oldest = b.CreatedAt
+		}
+		if b.CreatedAt.After(summary.Newest) {
+			summary.Newest = b.CreatedAt
+		}
+		if !platforms[key][b.Platform] {
+			platforms[key][b.Platform] = true
+			summary.Platforms = append(summary.Platforms, b.Platform)
+		}
+	}
+
+	summaries := make([]LineageSummary, 0, len(byLineage))
+	for _, summary := range byLineage {
+		sort.Strings(summary.Platforms)
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Lineage < summaries[j].Lineage
+	})
+	return summaries
+}
+
+// RenameLineage moves the backups of lineage from to lineage to, so a renamed
+// skill keeps its backup history. An empty platform renames the lineage on
+// all platforms. It returns the number of backups moved.
+func RenameLineage(platform, from, to string) (int, error) {
+	if from == "" || to == "" || from == to {
+		return 0, nil
+	}
+
+	indexMu.Lock()
+	defer indexMu.Unlock()
+
+	index, err := LoadIndex()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load backup index: %w", err)
+	}
+
+	moved := 0
+	for id, b := range index.Backups {
+		if (platform != "" && b.Platform != platform) || b.LineageKey() != from {
+			continue
+		}
+		b.Lineage = to
+		index.Backups[id] = b
+		// Sidecars keep the lineage through a reindex; a missing one is not fatal
+		_ = writeSidecar(&b)
+		moved++
+	}
+	if moved == 0 {
+		return 0, nil
+	}
+
+	if err := SaveIndex(index); err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
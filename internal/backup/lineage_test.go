@@ -0,0 +1,113 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestMetadata_LineageKey(t *testing.T) {
+	tests := map[string]struct {
+		metadata Metadata
+		want     string
+	}{
+		"recorded lineage": {
+			metadata: Metadata{Lineage: "lint", Metadata: map[string]string{"skill": "other"}},
+			want:     "lint",
+		},
+		"skill metadata": {
+			metadata: Metadata{SourcePath: "/x/old.md", Metadata: map[string]string{"skill": "lint"}},
+			want:     "lint",
+		},
+		"skill directory": {
+			metadata: Metadata{SourcePath: "/x/skills/lint/SKILL.md"},
+			want:     "lint",
+		},
+		"file name": {
+			metadata: Metadata{SourcePath: "/x/rules/lint.mdc"},
+			want:     "lint",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, tt.metadata.LineageKey(), tt.want)
+		})
+	}
+}
+
+func TestCreateBackup_RecordsLineage(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	sourcePath := filepath.Join(tempHome, "skills", "lint", "SKILL.md")
+	util.WriteFile(t, sourcePath, "lint")
+
+	derived, err := CreateBackup(sourcePath, Options{Platform: "claude-code"})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, derived.Lineage, "lint")
+
+	explicit, err := CreateBackup(sourcePath, Options{Platform: "claude-code", Lineage: "linter"})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, explicit.Lineage, "linter")
+}
+
+func TestLineages(t *testing.T) {
+	now := time.Now()
+	backups := []Metadata{
+		{ID: "1", Platform: "cursor", SourcePath: "/c/lint.md", Size: 10, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "2", Platform: "claude-code", SourcePath: "/a/lint/SKILL.md", Size: 5, CreatedAt: now},
+		{ID: "3", Platform: "claude-code", SourcePath: "/a/review.md", Size: 1, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	got := Lineages(backups)
+
+	util.AssertEqual(t, len(got), 2)
+	util.AssertEqual(t, got[0].Lineage, "lint")
+	util.AssertEqual(t, got[0].Backups, 2)
+	util.AssertEqual(t, got[0].Size, int64(15))
+	util.AssertEqual(t, got[0].Newest, now)
+	util.AssertEqual(t, got[0].Oldest, now.Add(-2*time.Hour))
+	util.AssertEqual(t, len(got[0].Platforms), 2)
+	util.AssertEqual(t, got[1].Lineage, "review")
+}
+
+func TestRenameLineage(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	skillsDir := filepath.Join(tempHome, "skills")
+	for _, platform := range []string{"claude-code", "cursor"} {
+		path := filepath.Join(skillsDir, platform, "old.md")
+		util.WriteFile(t, path, platform)
+		_, err := CreateBackup(path, Options{Platform: platform})
+		util.AssertNoError(t, err)
+	}
+
+	moved, err := RenameLineage("claude-code", "old", "new")
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, moved, 1)
+
+	backups, err := ListBackups("")
+	util.AssertNoError(t, err)
+	lineages := make(map[string]string)
+	for _, b := range backups {
+		lineages[b.Platform] = b.LineageKey()
+	}
+	util.AssertEqual(t, lineages["claude-code"], "new")
+	util.AssertEqual(t, lineages["cursor"], "old")
+
+	// The new lineage survives a reindex through the sidecars
+	if err := os.Remove(filepath.Join(tempHome, "metadata", IndexFilename)); err != nil {
+		t.Fatalf("failed to remove index: %v", err)
+	}
+	_ = os.Remove(filepath.Join(tempHome, "metadata", IndexBackupFilename))
+	_, err = Reindex()
+	util.AssertNoError(t, err)
+	backups, err = ListBackups("claude-code")
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, backups[0].LineageKey(), "new")
+}
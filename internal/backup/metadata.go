@@ -3,11 +3,13 @@ package backup
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/klauern/skillsync/internal/logging"
 	"github.com/klauern/skillsync/internal/util"
 )
 
@@ -16,7 +18,7 @@ type Metadata struct {
 	ID          string            `json:"id"`          // Unique backup identifier (timestamp-based)
 	SourcePath  string            `json:"source_path"` // Original file/directory path
 	BackupPath  string            `json:"backup_path"` // Path to backup file
-	Platform    string            `json:"platform"`    // Platform (claude-code, cursor, codex)
+	Platform    string            `json:"platform"`    // Platform (claude-code, cursor, codex, aider)
 	CreatedAt   time.Time         `json:"created_at"`  // Backup creation timestamp
 	ModifiedAt  time.Time         `json:"modified_at"` // Source modification timestamp
 	Hash        string            `json:"hash"`        // SHA256 hash of content
@@ -24,6 +26,9 @@ type Metadata struct {
 	Description string            `json:"description,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"` // Additional metadata
 	Tags        []string          `json:"tags,omitempty"`
+	SessionID   string            `json:"session_id,omitempty"`  // Sync run that created the backup
+	Lineage     string            `json:"lineage,omitempty"`     // Logical skill the backup belongs to
+	SnapshotID  string            `json:"snapshot_id,omitempty"` // Whole-platform snapshot the backup is part of
 }
 
 // Index maintains an index of all backups
@@ -31,6 +36,10 @@ type Index struct {
 	Version string              `json:"version"`
 	Updated time.Time           `json:"updated"`
 	Backups map[string]Metadata `json:"backups"` // Key: backup ID
+
+	// Recovered is set when index.json was unreadable and the index was
+	// restored from the last good copy in index.json.bak.
+	Recovered bool `json:"-"`
 }
 
 const (
@@ -38,30 +47,76 @@ const (
 	IndexVersion = "1.0"
 	// IndexFilename is the name of the index file
 	IndexFilename = "index.json"
+	// IndexBackupFilename is the name of the last known good copy of the index
+	IndexBackupFilename = IndexFilename + ".bak"
 )
 
-// LoadIndex loads the backup index from disk
+// ErrIndexCorrupt is returned when index.json cannot be parsed and no usable
+// index.json.bak exists. Run "skillsync backup reindex" to rebuild the index.
+var ErrIndexCorrupt = errors.New("backup index is corrupted")
+
+// LoadIndex loads the backup index from disk. If index.json is truncated or
+// invalid, the last good copy in index.json.bak is restored and returned with
+// Recovered set.
 func LoadIndex() (*Index, error) {
-	indexPath := filepath.Join(util.SkillsyncMetadataPath(), IndexFilename)
+	metadataDir := util.SkillsyncMetadataPath()
+	indexPath := filepath.Join(metadataDir, IndexFilename)
 
 	// If index doesn't exist, return empty index
 	if _, err := os.Stat(indexPath); os.IsNotExist(err) {
-		return &Index{
-			Version: IndexVersion,
-			Updated: time.Now(),
-			Backups: make(map[string]Metadata),
-		}, nil
+		return newIndex(), nil
 	}
 
-	// #nosec G304 - indexPath is constructed from trusted util.SkillsyncMetadataPath()
-	data, err := os.ReadFile(indexPath)
+	index, err := readIndexFile(indexPath)
+	if err == nil {
+		return index, nil
+	}
+	if !errors.Is(err, ErrIndexCorrupt) {
+		return nil, err
+	}
+
+	backupPath := filepath.Join(metadataDir, IndexBackupFilename)
+	recovered, bakErr := readIndexFile(backupPath)
+	if bakErr != nil {
+		return nil, fmt.Errorf("%w: %s (run 'skillsync backup reindex' to rebuild it)", ErrIndexCorrupt, indexPath)
+	}
+
+	logging.Warn("backup index is corrupted, restored last good copy",
+		logging.Path(indexPath),
+		logging.Err(err),
+	)
+	if err := SaveIndex(recovered); err != nil {
+		return nil, fmt.Errorf("failed to restore index from %s: %w", IndexBackupFilename, err)
+	}
+	recovered.Recovered = true
+
+	return recovered, nil
+}
+
+// newIndex returns an empty index at the current version.
+func newIndex() *Index {
+	return &Index{
+		Version: IndexVersion,
+		Updated: time.Now(),
+		Backups: make(map[string]Metadata),
+	}
+}
+
+// readIndexFile reads and parses an index file. Parse failures, including an
+// empty file, wrap ErrIndexCorrupt.
+func readIndexFile(path string) (*Index, error) {
+	// #nosec G304 - path is constructed from trusted util.SkillsyncMetadataPath()
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read index file: %w", err)
 	}
 
 	var index Index
 	if err := json.Unmarshal(data, &index); err != nil {
-		return nil, fmt.Errorf("failed to parse index file: %w", err)
+		return nil, fmt.Errorf("%w: failed to parse index file: %w", ErrIndexCorrupt, err)
+	}
+	if index.Backups == nil {
+		index.Backups = make(map[string]Metadata)
 	}
 
 	return &index, nil
@@ -72,7 +127,7 @@ func SaveIndex(index *Index) error {
 	metadataDir := util.SkillsyncMetadataPath()
 
 	// Ensure metadata directory exists
-	if err := os.MkdirAll(metadataDir, 0o750); err != nil {
+	if err := util.EnsureDataDir(metadataDir, 0o750); err != nil {
 		return fmt.Errorf("failed to create metadata directory: %w", err)
 	}
 
@@ -84,8 +139,25 @@ func SaveIndex(index *Index) error {
 	}
 
 	indexPath := filepath.Join(metadataDir, IndexFilename)
+
+	// Keep the current index as the last known good copy, but never let a
+	// corrupted index overwrite a good .bak.
+	// #nosec G304 - indexPath is constructed from trusted util.SkillsyncMetadataPath()
+	if current, err := os.ReadFile(indexPath); err == nil && json.Unmarshal(current, &Index{}) == nil {
+		// #nosec G306 - index.json.bak is metadata and can be group-readable
+		if err := os.WriteFile(filepath.Join(metadataDir, IndexBackupFilename), current, 0o640); err != nil {
+			return fmt.Errorf("failed to back up index file: %w", err)
+		}
+	}
+
+	// Write to a temp file and rename so a crash never leaves a truncated index.
+	tmpPath := indexPath + ".tmp"
 	// #nosec G306 - index.json is metadata and can be group-readable
-	if err := os.WriteFile(indexPath, data, 0o640); err != nil {
+	if err := os.WriteFile(tmpPath, data, 0o640); err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+	if err := os.Rename(tmpPath, indexPath); err != nil {
+		_ = os.Remove(tmpPath)
 		return fmt.Errorf("failed to write index file: %w", err)
 	}
 
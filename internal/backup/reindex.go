@@ -0,0 +1,171 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// SidecarSuffix is appended to a backup file's path to name the JSON file
+// holding its metadata.
+const SidecarSuffix = ".meta.json"
+
+// backupIDPattern matches IDs generated by CreateBackup: a timestamp followed
+// by the first 8 hex characters of the content hash.
+var backupIDPattern = regexp.MustCompile(`^(\d{8}-\d{6})-([0-9a-f]{8})`)
+
+// ReindexResult summarizes a rebuild of the backup index.
+type ReindexResult struct {
+	Indexed     int      `json:"indexed"`      // Backups in the rebuilt index
+	FromIndex   int      `json:"from_index"`   // Metadata kept from the previous index
+	FromSidecar int      `json:"from_sidecar"` // Metadata read from sidecar files
+	Derived     int      `json:"derived"`      // Metadata derived from the file name and content
+	Skipped     []string `json:"skipped,omitempty"`
+}
+
+// sidecarPath returns the metadata sidecar path for a backup file.
+func sidecarPath(backupPath string) string {
+	return backupPath + SidecarSuffix
+}
+
+// writeSidecar writes metadata next to its backup file.
+func writeSidecar(metadata *Metadata) error {
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal backup metadata: %w", err)
+	}
+	if err := os.WriteFile(sidecarPath(metadata.BackupPath), data, BackupFilePerm); err != nil {
+		return fmt.Errorf("failed to write backup metadata: %w", err)
+	}
+	return nil
+}
+
+// Reindex rebuilds the backup index by scanning backup files on disk. Each
+// file's SHA256 is recomputed; metadata comes from the previous index when it
+// is still readable, then from the file's sidecar, and is otherwise derived
+// from the backup ID in the file name. Files whose content does not match the
+// hash embedded in their name are skipped.
+func Reindex() (*ReindexResult, error) {
+	previous := make(map[string]Metadata)
+	metadataDir := util.SkillsyncMetadataPath()
+	for _, name := range []string{IndexFilename, IndexBackupFilename} {
+		if idx, err := readIndexFile(filepath.Join(metadataDir, name)); err == nil {
+			previous = idx.Backups
+			break
+		}
+	}
+
+	index := newIndex()
+	result := &ReindexResult{}
+
+	platformDirs, err := os.ReadDir(util.SkillsyncBackupsPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read backups directory: %w", err)
+	}
+
+	for _, platformDir := range platformDirs {
+		if !platformDir.IsDir() {
+			continue
+		}
+		platform := platformDir.Name()
+		dir := filepath.Join(util.SkillsyncBackupsPath(), platform)
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup directory %q: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() || strings.HasSuffix(entry.Name(), SidecarSuffix) {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+
+			metadata, source, err := recoverMetadata(path, platform, previous)
+			if err != nil {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %v", path, err))
+				continue
+			}
+
+			index.Backups[metadata.ID] = *metadata
+			switch source {
+			case "index":
+				result.FromIndex++
+			case "sidecar":
+				result.FromSidecar++
+			default:
+				result.Derived++
+			}
+		}
+	}
+
+	if err := SaveIndex(index); err != nil {
+		return nil, err
+	}
+	result.Indexed = len(index.Backups)
+
+	return result, nil
+}
+
+// recoverMetadata rebuilds the metadata for a single backup file and reports
+// where it came from: "index", "sidecar", or "derived".
+func recoverMetadata(path, platform string, previous map[string]Metadata) (*Metadata, string, error) {
+	match := backupIDPattern.FindStringSubmatch(filepath.Base(path))
+	if match == nil {
+		return nil, "", fmt.Errorf("not a backup file")
+	}
+	id := match[0]
+
+	// #nosec G304 - path is a file inside the skillsync backups directory
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+	if !strings.HasPrefix(hash, match[2]) {
+		return nil, "", fmt.Errorf("content does not match hash in file name")
+	}
+
+	if m, ok := previous[id]; ok && m.Hash == hash {
+		m.BackupPath = path
+		return &m, "index", nil
+	}
+
+	// #nosec G304 - sidecar lives next to the backup file
+	if data, err := os.ReadFile(sidecarPath(path)); err == nil {
+		var m Metadata
+		if err := json.Unmarshal(data, &m); err == nil && m.ID == id && m.Hash == hash {
+			m.BackupPath = path
+			return &m, "sidecar", nil
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to stat: %w", err)
+	}
+	createdAt, err := time.ParseInLocation("20060102-150405", match[1], time.Local)
+	if err != nil {
+		createdAt = info.ModTime()
+	}
+
+	return &Metadata{
+		ID:          id,
+		BackupPath:  path,
+		Platform:    platform,
+		CreatedAt:   createdAt,
+		ModifiedAt:  info.ModTime(),
+		Hash:        hash,
+		Size:        int64(len(content)),
+		Description: "Recovered by reindex",
+	}, "derived", nil
+}
@@ -0,0 +1,182 @@
+package backup
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// createTestBackups creates one backup per content string and returns their metadata.
+func createTestBackups(t *testing.T, home string, contents ...string) []Metadata {
+	t.Helper()
+	backups := make([]Metadata, 0, len(contents))
+	for i, content := range contents {
+		source := filepath.Join(home, "skills", string(rune('a'+i))+".md")
+		util.WriteFile(t, source, content)
+		m, err := CreateBackup(source, Options{Platform: "claude-code", Description: "test"})
+		if err != nil {
+			t.Fatalf("CreateBackup failed: %v", err)
+		}
+		backups = append(backups, *m)
+	}
+	return backups
+}
+
+func corruptIndex(t *testing.T, home, name string) {
+	t.Helper()
+	util.WriteFile(t, filepath.Join(home, "metadata", name), `{"version": "1.0", "backu`)
+}
+
+func TestLoadIndex_RecoversFromBak(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	backups := createTestBackups(t, tempHome, "first", "second")
+	corruptIndex(t, tempHome, IndexFilename)
+
+	index, err := LoadIndex()
+	util.AssertNoError(t, err)
+	if !index.Recovered {
+		t.Error("expected Recovered to be set")
+	}
+	// The .bak predates the second backup.
+	if _, ok := index.Backups[backups[0].ID]; !ok {
+		t.Errorf("expected backup %s in recovered index", backups[0].ID)
+	}
+
+	// The recovered index is written back, so the next load is clean.
+	index, err = LoadIndex()
+	util.AssertNoError(t, err)
+	if index.Recovered {
+		t.Error("expected restored index to load without recovery")
+	}
+}
+
+func TestLoadIndex_CorruptWithoutBak(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	corruptIndex(t, tempHome, IndexFilename)
+	corruptIndex(t, tempHome, IndexBackupFilename)
+
+	_, err := LoadIndex()
+	if !errors.Is(err, ErrIndexCorrupt) {
+		t.Fatalf("expected ErrIndexCorrupt, got %v", err)
+	}
+}
+
+func TestSaveIndex_KeepsGoodBak(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	createTestBackups(t, tempHome, "first", "second")
+	bakPath := filepath.Join(tempHome, "metadata", IndexBackupFilename)
+	good, err := os.ReadFile(bakPath)
+	util.AssertNoError(t, err)
+
+	corruptIndex(t, tempHome, IndexFilename)
+	util.AssertNoError(t, SaveIndex(newIndex()))
+
+	got, err := os.ReadFile(bakPath)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(got), string(good))
+}
+
+func TestReindex(t *testing.T) {
+	tests := map[string]struct {
+		setup       func(t *testing.T, home string, backups []Metadata)
+		wantIndexed int
+		wantIndex   int
+		wantSidecar int
+		wantDerived int
+		wantSkipped int
+	}{
+		"valid index": {
+			setup:       func(*testing.T, string, []Metadata) {},
+			wantIndexed: 2,
+			wantIndex:   2,
+		},
+		"corrupt index uses sidecars": {
+			setup: func(t *testing.T, home string, _ []Metadata) {
+				corruptIndex(t, home, IndexFilename)
+				corruptIndex(t, home, IndexBackupFilename)
+			},
+			wantIndexed: 2,
+			wantSidecar: 2,
+		},
+		"missing sidecar is derived": {
+			setup: func(t *testing.T, home string, backups []Metadata) {
+				corruptIndex(t, home, IndexFilename)
+				corruptIndex(t, home, IndexBackupFilename)
+				if err := os.Remove(sidecarPath(backups[0].BackupPath)); err != nil {
+					t.Fatalf("failed to remove sidecar: %v", err)
+				}
+			},
+			wantIndexed: 2,
+			wantSidecar: 1,
+			wantDerived: 1,
+		},
+		"tampered blob is skipped": {
+			setup: func(t *testing.T, home string, backups []Metadata) {
+				corruptIndex(t, home, IndexFilename)
+				corruptIndex(t, home, IndexBackupFilename)
+				util.WriteFile(t, backups[1].BackupPath, "tampered")
+			},
+			wantIndexed: 1,
+			wantSidecar: 1,
+			wantSkipped: 1,
+		},
+		"unrelated file is skipped": {
+			setup: func(t *testing.T, home string, _ []Metadata) {
+				util.WriteFile(t, filepath.Join(home, "backups", "claude-code", "notes.txt"), "x")
+			},
+			wantIndexed: 2,
+			wantIndex:   2,
+			wantSkipped: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempHome := util.CreateTempDir(t)
+			t.Setenv("SKILLSYNC_HOME", tempHome)
+
+			backups := createTestBackups(t, tempHome, "first", "second")
+			tt.setup(t, tempHome, backups)
+
+			result, err := Reindex()
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, result.Indexed, tt.wantIndexed)
+			util.AssertEqual(t, result.FromIndex, tt.wantIndex)
+			util.AssertEqual(t, result.FromSidecar, tt.wantSidecar)
+			util.AssertEqual(t, result.Derived, tt.wantDerived)
+			util.AssertEqual(t, len(result.Skipped), tt.wantSkipped)
+
+			index, err := LoadIndex()
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(index.Backups), tt.wantIndexed)
+			for id := range index.Backups {
+				util.AssertNoError(t, VerifyBackup(id))
+			}
+		})
+	}
+}
+
+func TestDeleteBackup_RemovesSidecar(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	backups := createTestBackups(t, tempHome, "content")
+	sidecar := sidecarPath(backups[0].BackupPath)
+	if _, err := os.Stat(sidecar); err != nil {
+		t.Fatalf("expected sidecar to exist: %v", err)
+	}
+
+	util.AssertNoError(t, DeleteBackup(backups[0].ID))
+	if _, err := os.Stat(sidecar); !os.IsNotExist(err) {
+		t.Errorf("expected sidecar to be removed, got %v", err)
+	}
+}
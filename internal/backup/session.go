@@ -0,0 +1,81 @@
+package backup
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// NewSessionID returns a new identifier linking the backups of one sync run.
+func NewSessionID() string {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return time.Now().Format("20060102-150405-") + hex.EncodeToString(b[:])
+}
+
+// SessionBackups returns the backups created by a sync session, oldest first.
+func SessionBackups(sessionID string) ([]Metadata, error) {
+	index, err := LoadIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backup index: %w", err)
+	}
+
+	var backups []Metadata
+	for _, metadata := range index.Backups {
+		if metadata.SessionID == sessionID {
+			backups = append(backups, metadata)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].CreatedAt.Equal(backups[j].CreatedAt) {
+			return backups[i].CreatedAt.Before(backups[j].CreatedAt)
+		}
+		return backups[i].ID < backups[j].ID
+	})
+	return backups, nil
+}
+
+// RollbackPlan returns the backups to restore to undo a sync session: the
+// earliest backup of each source path, which holds its content from before the
+// session first wrote it. Files the session created are not backed up and are
+// left in place.
+func RollbackPlan(sessionID string) ([]Metadata, error) {
+	backups, err := SessionBackups(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == 0 {
+		return nil, fmt.Errorf("no backups found for session %q", sessionID)
+	}
+
+	plan := make([]Metadata, 0, len(backups))
+	seen := make(map[string]bool)
+	for _, metadata := range backups {
+		if seen[metadata.SourcePath] {
+			continue
+		}
+		seen[metadata.SourcePath] = true
+		plan = append(plan, metadata)
+	}
+	return plan, nil
+}
+
+// RollbackSession restores every file backed up by a sync session to its
+// original path and returns the backups that were restored.
+func RollbackSession(sessionID string) ([]Metadata, error) {
+	plan, err := RollbackPlan(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := make([]Metadata, 0, len(plan))
+	for _, metadata := range plan {
+		if err := RestoreBackup(metadata.ID, metadata.SourcePath); err != nil {
+			return restored, fmt.Errorf("failed to restore %q: %w", metadata.SourcePath, err)
+		}
+		restored = append(restored, metadata)
+	}
+	return restored, nil
+}
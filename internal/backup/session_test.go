@@ -0,0 +1,84 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestRollbackSession(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	alpha := filepath.Join(tempHome, "alpha.md")
+	beta := filepath.Join(tempHome, "beta.md")
+	util.WriteFile(t, alpha, "alpha v1")
+	util.WriteFile(t, beta, "same content")
+
+	session := NewSessionID()
+	opts := Options{Platform: "cursor", SessionID: session}
+
+	// alpha is backed up twice in the session; rollback must use the first copy
+	if _, err := CreateBackup(alpha, opts); err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	util.WriteFile(t, alpha, "alpha v2")
+	if _, err := CreateBackup(alpha, opts); err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	if _, err := CreateBackup(beta, opts); err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+
+	// A backup from another session is never restored
+	other := filepath.Join(tempHome, "other.md")
+	util.WriteFile(t, other, "same content")
+	if _, err := CreateBackup(other, Options{Platform: "cursor", SessionID: NewSessionID()}); err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+
+	backups, err := SessionBackups(session)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(backups), 3)
+
+	util.WriteFile(t, alpha, "alpha v3")
+	util.WriteFile(t, beta, "changed")
+	util.WriteFile(t, other, "changed")
+
+	restored, err := RollbackSession(session)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(restored), 2)
+
+	for path, want := range map[string]string{alpha: "alpha v1", beta: "same content", other: "changed"} {
+		got, err := os.ReadFile(path)
+		util.AssertNoError(t, err)
+		util.AssertEqual(t, string(got), want)
+	}
+
+	if _, err := RollbackSession("missing"); err == nil {
+		t.Error("expected error for unknown session")
+	}
+}
+
+func TestCreateBackup_UniqueIDs(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	ids := make(map[string]bool)
+	for _, name := range []string{"a.md", "b.md", "c.md"} {
+		path := filepath.Join(tempHome, name)
+		util.WriteFile(t, path, "identical")
+		metadata, err := CreateBackup(path, Options{Platform: "cursor"})
+		util.AssertNoError(t, err)
+		if ids[metadata.ID] {
+			t.Errorf("duplicate backup ID %q", metadata.ID)
+		}
+		ids[metadata.ID] = true
+	}
+
+	index, err := LoadIndex()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(index.Backups), 3)
+}
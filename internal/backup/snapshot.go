@@ -0,0 +1,152 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// NewSnapshotID returns a new identifier grouping the backups of one
+// whole-platform snapshot.
+func NewSnapshotID() string {
+	return NewSessionID()
+}
+
+// SnapshotBackups returns the backups that make up a snapshot, sorted by
+// source path.
+func SnapshotBackups(snapshotID string) ([]Metadata, error) {
+	index, err := LoadIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backup index: %w", err)
+	}
+
+	var backups []Metadata
+	for _, metadata := range index.Backups {
+		if metadata.SnapshotID == snapshotID {
+			backups = append(backups, metadata)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].SourcePath < backups[j].SourcePath
+	})
+	return backups, nil
+}
+
+// snapshotFile is one file of a snapshot being restored.
+type snapshotFile struct {
+	metadata Metadata
+	staged   string // Temporary file holding the backup content next to the target
+	previous []byte // Target content before the restore, nil if it did not exist
+	existed  bool
+	renamed  bool
+}
+
+// RestoreSnapshot restores every file of a snapshot to its original path and
+// returns the backups that were restored. All backups are read and verified
+// and staged next to their targets before any file is replaced, and files
+// already replaced are put back if a later one fails, so the platform is left
+// either fully restored or unchanged. Files created since the snapshot are
+// left in place.
+func RestoreSnapshot(snapshotID string) ([]Metadata, error) {
+	backups, err := SnapshotBackups(snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == 0 {
+		return nil, fmt.Errorf("no backups found for snapshot %q", snapshotID)
+	}
+
+	files := make([]*snapshotFile, 0, len(backups))
+	defer func() {
+		for _, f := range files {
+			if f.staged != "" && !f.renamed {
+				_ = os.Remove(f.staged)
+			}
+		}
+	}()
+
+	for _, metadata := range backups {
+		f, err := stageSnapshotFile(metadata)
+		if f != nil {
+			files = append(files, f)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to stage %q: %w", metadata.SourcePath, err)
+		}
+	}
+
+	for i, f := range files {
+		if err := os.Rename(f.staged, f.metadata.SourcePath); err != nil {
+			restoreErr := revertSnapshotFiles(files[:i])
+			return nil, errors.Join(fmt.Errorf("failed to restore %q: %w", f.metadata.SourcePath, err), restoreErr)
+		}
+		f.renamed = true
+	}
+
+	return backups, nil
+}
+
+// stageSnapshotFile verifies a snapshot backup and writes its content to a
+// temporary file in the target's directory, so the final rename cannot fail
+// for lack of space or cross a filesystem boundary.
+func stageSnapshotFile(metadata Metadata) (*snapshotFile, error) {
+	content, err := os.ReadFile(metadata.BackupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup file: %w", err)
+	}
+	hash := sha256.Sum256(content)
+	if hex.EncodeToString(hash[:]) != metadata.Hash {
+		return nil, fmt.Errorf("backup file corrupted: hash mismatch")
+	}
+
+	f := &snapshotFile{metadata: metadata}
+	// #nosec G304 - SourcePath comes from the backup index
+	if previous, err := os.ReadFile(metadata.SourcePath); err == nil {
+		f.previous, f.existed = previous, true
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read target file: %w", err)
+	}
+
+	targetDir := filepath.Dir(metadata.SourcePath)
+	if err := os.MkdirAll(targetDir, BackupDirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create target directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(targetDir, ".skillsync-restore-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	f.staged = tmp.Name()
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return f, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return f, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := os.Chmod(f.staged, BackupFilePerm); err != nil {
+		return f, fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	return f, nil
+}
+
+// revertSnapshotFiles puts back the content the given files had before the
+// restore, removing those that did not exist.
+func revertSnapshotFiles(files []*snapshotFile) error {
+	var errs []error
+	for _, f := range files {
+		var err error
+		if f.existed {
+			err = os.WriteFile(f.metadata.SourcePath, f.previous, BackupFilePerm)
+		} else {
+			err = os.Remove(f.metadata.SourcePath)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to revert %q: %w", f.metadata.SourcePath, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,95 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestRestoreSnapshot(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	alpha := filepath.Join(tempHome, "skills", "alpha", "SKILL.md")
+	script := filepath.Join(tempHome, "skills", "alpha", "scripts", "run.sh")
+	beta := filepath.Join(tempHome, "skills", "beta.md")
+	util.WriteFile(t, alpha, "alpha v1")
+	util.WriteFile(t, script, "echo v1")
+	util.WriteFile(t, beta, "beta v1")
+
+	snapshot := NewSnapshotID()
+	opts := Options{Platform: "cursor", SnapshotID: snapshot}
+	if _, err := Directory(filepath.Join(tempHome, "skills", "alpha"), opts); err != nil {
+		t.Fatalf("Directory failed: %v", err)
+	}
+	if _, err := CreateBackup(beta, opts); err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	// A backup outside the snapshot is never restored
+	if _, err := CreateBackup(beta, Options{Platform: "cursor"}); err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+
+	backups, err := SnapshotBackups(snapshot)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(backups), 3)
+
+	added := filepath.Join(tempHome, "skills", "gamma.md")
+	util.WriteFile(t, alpha, "alpha v2")
+	util.WriteFile(t, beta, "beta v2")
+	util.WriteFile(t, added, "gamma")
+	if err := os.Remove(script); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := RestoreSnapshot(snapshot)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(restored), 3)
+
+	for path, want := range map[string]string{alpha: "alpha v1", script: "echo v1", beta: "beta v1", added: "gamma"} {
+		got, err := os.ReadFile(path)
+		util.AssertNoError(t, err)
+		util.AssertEqual(t, string(got), want)
+	}
+
+	if _, err := RestoreSnapshot("missing"); err == nil {
+		t.Error("expected error for unknown snapshot")
+	}
+}
+
+func TestRestoreSnapshot_CorruptBackupChangesNothing(t *testing.T) {
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	alpha := filepath.Join(tempHome, "skills", "alpha.md")
+	beta := filepath.Join(tempHome, "skills", "beta.md")
+	util.WriteFile(t, alpha, "alpha v1")
+	util.WriteFile(t, beta, "beta v1")
+
+	snapshot := NewSnapshotID()
+	opts := Options{Platform: "cursor", SnapshotID: snapshot}
+	if _, err := CreateBackup(alpha, opts); err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	corrupt, err := CreateBackup(beta, opts)
+	util.AssertNoError(t, err)
+	util.WriteFile(t, corrupt.BackupPath, "tampered")
+
+	util.WriteFile(t, alpha, "alpha v2")
+	util.WriteFile(t, beta, "beta v2")
+
+	if _, err := RestoreSnapshot(snapshot); err == nil {
+		t.Fatal("expected error for corrupted snapshot backup")
+	}
+
+	for path, want := range map[string]string{alpha: "alpha v2", beta: "beta v2"} {
+		got, err := os.ReadFile(path)
+		util.AssertNoError(t, err)
+		util.AssertEqual(t, string(got), want)
+	}
+	entries, err := os.ReadDir(filepath.Join(tempHome, "skills"))
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(entries), 2)
+}
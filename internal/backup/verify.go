@@ -0,0 +1,198 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	gosync "sync"
+	"time"
+)
+
+// ErrVerifySkipped is the error of backups left unverified because
+// verification stopped early, after a failure with FailFast or because the
+// context was canceled.
+var ErrVerifySkipped = errors.New("not verified")
+
+// VerifyOptions configures VerifyBackups.
+type VerifyOptions struct {
+	// Concurrency is the number of backups verified in parallel (values
+	// below 1 verify one at a time).
+	Concurrency int
+	// FailFast stops verification at the first backup that fails.
+	FailFast bool
+	// Progress, if set, is called once per backup as it finishes.
+	Progress func(VerifyProgress)
+}
+
+// VerifyResult is the outcome of verifying one backup.
+type VerifyResult struct {
+	ID       string
+	Platform string
+	// Bytes is the number of bytes hashed.
+	Bytes int64
+	// Err is nil for an intact backup, wraps ErrVerifySkipped for a backup
+	// that was not verified, and describes the failure otherwise.
+	Err error
+}
+
+// Skipped returns true if the backup was not verified.
+func (r VerifyResult) Skipped() bool {
+	return errors.Is(r.Err, ErrVerifySkipped)
+}
+
+// VerifyProgress reports that a single backup finished verifying.
+type VerifyProgress struct {
+	Result VerifyResult
+	// Completed is the number of backups finished so far, including this one.
+	Completed int
+	// Total is the number of backups being verified.
+	Total int
+	// Bytes is the number of bytes hashed so far.
+	Bytes int64
+}
+
+// VerifyReport summarizes a VerifyBackups run.
+type VerifyReport struct {
+	// Results holds one result per requested backup, in request order.
+	Results  []VerifyResult
+	OK       int
+	Failed   int
+	Skipped  int
+	Bytes    int64
+	Duration time.Duration
+}
+
+// Throughput returns the bytes hashed per second.
+func (r VerifyReport) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Bytes) / r.Duration.Seconds()
+}
+
+// VerifyBackups verifies the given backups against their stored hashes with
+// a bounded pool of workers. When ctx is canceled, or a backup fails with
+// FailFast, the remaining backups are reported as skipped; a canceled run
+// also returns the context error along with the partial report.
+func VerifyBackups(ctx context.Context, ids []string, opts VerifyOptions) (*VerifyReport, error) {
+	index, err := LoadIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backup index: %w", err)
+	}
+
+	// runCtx is also canceled by a fail-fast stop, which is not an error.
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+	report := &VerifyReport{Results: make([]VerifyResult, len(ids))}
+	var mu gosync.Mutex
+	completed := 0
+	verify := func(i int) {
+		result := VerifyResult{ID: ids[i]}
+		metadata, exists := index.Backups[ids[i]]
+		switch {
+		case !exists:
+			result.Err = fmt.Errorf("backup %q not found", ids[i])
+		case runCtx.Err() != nil:
+			result.Platform = metadata.Platform
+			result.Err = fmt.Errorf("%w: %w", ErrVerifySkipped, runCtx.Err())
+		default:
+			result.Platform = metadata.Platform
+			result.Bytes, result.Err = verifyFile(runCtx, metadata)
+			if result.Err != nil && runCtx.Err() != nil {
+				result.Err = fmt.Errorf("%w: %w", ErrVerifySkipped, runCtx.Err())
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		report.Results[i] = result
+		report.Bytes += result.Bytes
+		completed++
+		if result.Err != nil && !result.Skipped() && opts.FailFast {
+			cancel()
+		}
+		if opts.Progress != nil {
+			opts.Progress(VerifyProgress{Result: result, Completed: completed, Total: len(ids), Bytes: report.Bytes})
+		}
+	}
+
+	workers := min(max(opts.Concurrency, 1), len(ids))
+	jobs := make(chan int)
+	var wg gosync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				verify(i)
+			}
+		}()
+	}
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	report.Duration = time.Since(start)
+	for _, result := range report.Results {
+		switch {
+		case result.Err == nil:
+			report.OK++
+		case result.Skipped():
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// verifyFile hashes a backup file and compares it with its stored hash,
+// returning the number of bytes hashed. Reading stops when ctx is canceled.
+func verifyFile(ctx context.Context, metadata Metadata) (int64, error) {
+	// #nosec G304 - BackupPath comes from the backup index
+	file, err := os.Open(metadata.BackupPath)
+	if os.IsNotExist(err) {
+		return 0, fmt.Errorf("backup file missing: %s", metadata.BackupPath)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	n, err := io.Copy(hash, contextReader{ctx: ctx, r: file})
+	if err != nil {
+		return n, fmt.Errorf("failed to read backup file: %w", err)
+	}
+
+	hashStr := hex.EncodeToString(hash.Sum(nil))
+	if hashStr != metadata.Hash {
+		return n, fmt.Errorf("backup file corrupted: hash mismatch (expected %s, got %s)", metadata.Hash, hashStr)
+	}
+	return n, nil
+}
+
+// contextReader is a reader that fails once its context is canceled, so
+// hashing a large file can be interrupted.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
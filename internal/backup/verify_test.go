@@ -0,0 +1,101 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// createVerifyBackups creates n backups of distinct content and returns
+// their IDs.
+func createVerifyBackups(t *testing.T, n int) []string {
+	t.Helper()
+	tempHome := util.CreateTempDir(t)
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	ids := make([]string, 0, n)
+	for i := range n {
+		testFile := filepath.Join(tempHome, fmt.Sprintf("skill-%d.md", i))
+		util.AssertNoError(t, os.WriteFile(testFile, []byte(fmt.Sprintf("content %d", i)), 0o600))
+		metadata, err := CreateBackup(testFile, Options{Platform: "claude-code"})
+		util.AssertNoError(t, err)
+		ids = append(ids, metadata.ID)
+	}
+	return ids
+}
+
+func TestVerifyBackups(t *testing.T) {
+	tests := map[string]struct {
+		opts        VerifyOptions
+		corrupt     bool
+		wantOK      int
+		wantFailed  int
+		wantSkipped int
+	}{
+		"all intact": {
+			opts:   VerifyOptions{Concurrency: 4},
+			wantOK: 6,
+		},
+		"one corrupt": {
+			opts:       VerifyOptions{Concurrency: 4},
+			corrupt:    true,
+			wantOK:     5,
+			wantFailed: 1,
+		},
+		"fail fast": {
+			opts:        VerifyOptions{Concurrency: 1, FailFast: true},
+			corrupt:     true,
+			wantFailed:  1,
+			wantSkipped: 5,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ids := createVerifyBackups(t, 6)
+			if tt.corrupt {
+				index, err := LoadIndex()
+				util.AssertNoError(t, err)
+				util.AssertNoError(t, os.WriteFile(index.Backups[ids[0]].BackupPath, []byte("corrupted"), 0o600))
+			}
+
+			var events int
+			tt.opts.Progress = func(p VerifyProgress) {
+				events++
+				util.AssertEqual(t, p.Completed, events)
+				util.AssertEqual(t, p.Total, len(ids))
+			}
+			report, err := VerifyBackups(context.Background(), ids, tt.opts)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, events, len(ids))
+			util.AssertEqual(t, report.OK, tt.wantOK)
+			util.AssertEqual(t, report.Failed, tt.wantFailed)
+			util.AssertEqual(t, report.Skipped, tt.wantSkipped)
+			for i, result := range report.Results {
+				util.AssertEqual(t, result.ID, ids[i])
+				util.AssertEqual(t, result.Platform, "claude-code")
+			}
+			if tt.wantOK > 0 && report.Bytes == 0 {
+				t.Error("report.Bytes = 0, want the bytes hashed")
+			}
+		})
+	}
+}
+
+func TestVerifyBackups_Canceled(t *testing.T) {
+	ids := createVerifyBackups(t, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := VerifyBackups(ctx, append(ids, "missing-id"), VerifyOptions{Concurrency: 2})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("VerifyBackups() error = %v, want context.Canceled", err)
+	}
+	util.AssertEqual(t, report.Skipped, 3)
+	util.AssertEqual(t, report.Failed, 1)
+}
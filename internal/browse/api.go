@@ -0,0 +1,154 @@
+package browse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// platformInfo is an entry of the /platforms API.
+type platformInfo struct {
+	Name      model.Platform `json:"name"`
+	ConfigDir string         `json:"config_dir"`
+	Skills    int            `json:"skills"`
+}
+
+// handleAPISkillList serves GET /skills: all skills, filtered by the q,
+// platform, and scope query parameters and sorted by name and platform.
+func (s *Server) handleAPISkillList(w http.ResponseWriter, r *http.Request) {
+	skills, err := s.opts.Skills()
+	if err != nil {
+		s.apiError(w, http.StatusInternalServerError, fmt.Errorf("failed to load skills: %w", err))
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	platform := r.URL.Query().Get("platform")
+	scope := r.URL.Query().Get("scope")
+	filtered := make([]model.Skill, 0, len(skills))
+	for _, skill := range skills {
+		if platform != "" && string(skill.Platform) != platform {
+			continue
+		}
+		if scope != "" && string(skill.Scope) != scope {
+			continue
+		}
+		if matchesQuery(skill, query) {
+			filtered = append(filtered, skill)
+		}
+	}
+	sortSkills(filtered)
+	s.writeJSON(w, filtered)
+}
+
+// handleAPISkill serves GET /skills/{name}: every copy of the named skill,
+// optionally limited to one platform.
+func (s *Server) handleAPISkill(w http.ResponseWriter, r *http.Request) {
+	skills, err := s.opts.Skills()
+	if err != nil {
+		s.apiError(w, http.StatusInternalServerError, fmt.Errorf("failed to load skills: %w", err))
+		return
+	}
+
+	name := r.PathValue("name")
+	platform := r.URL.Query().Get("platform")
+	var copies []model.Skill
+	for _, skill := range skills {
+		if skill.Name == name && (platform == "" || string(skill.Platform) == platform) {
+			copies = append(copies, skill)
+		}
+	}
+	if len(copies) == 0 {
+		s.apiError(w, http.StatusNotFound, fmt.Errorf("skill %q not found", name))
+		return
+	}
+	sortSkills(copies)
+	s.writeJSON(w, copies)
+}
+
+// handleAPIPlatforms serves GET /platforms: the supported platforms with the
+// number of skills discovered on each.
+func (s *Server) handleAPIPlatforms(w http.ResponseWriter, _ *http.Request) {
+	skills, err := s.opts.Skills()
+	if err != nil {
+		s.apiError(w, http.StatusInternalServerError, fmt.Errorf("failed to load skills: %w", err))
+		return
+	}
+
+	counts := make(map[model.Platform]int)
+	for _, skill := range skills {
+		counts[skill.Platform]++
+	}
+	platforms := make([]platformInfo, 0, len(model.AllPlatforms()))
+	for _, platform := range model.AllPlatforms() {
+		platforms = append(platforms, platformInfo{Name: platform, ConfigDir: platform.ConfigDir(), Skills: counts[platform]})
+	}
+	s.writeJSON(w, platforms)
+}
+
+// handleAPIBackups serves GET /backups: backup metadata newest first,
+// filtered by the platform query parameter.
+func (s *Server) handleAPIBackups(w http.ResponseWriter, r *http.Request) {
+	backups := []backup.Metadata{}
+	if s.opts.Backups != nil {
+		all, err := s.opts.Backups()
+		if err != nil {
+			s.apiError(w, http.StatusInternalServerError, fmt.Errorf("failed to load backups: %w", err))
+			return
+		}
+		platform := r.URL.Query().Get("platform")
+		for _, b := range all {
+			if platform == "" || b.Platform == platform {
+				backups = append(backups, b)
+			}
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	s.writeJSON(w, backups)
+}
+
+// sortSkills sorts skills by name, then platform.
+func sortSkills(skills []model.Skill) {
+	sort.SliceStable(skills, func(i, j int) bool {
+		if skills[i].Name != skills[j].Name {
+			return skills[i].Name < skills[j].Name
+		}
+		return skills[i].Platform < skills[j].Platform
+	})
+}
+
+// writeJSON writes v as an indented JSON response.
+func (s *Server) writeJSON(w http.ResponseWriter, v any) {
+	s.apiHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		logging.Warn("failed to write API response", logging.Err(err))
+	}
+}
+
+// apiError writes a JSON error response. Server errors are logged.
+func (s *Server) apiError(w http.ResponseWriter, code int, err error) {
+	if code >= http.StatusInternalServerError {
+		logging.Error("API request failed", logging.Err(err))
+	}
+	s.apiHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// apiHeaders sets the CORS header of API responses when an origin is allowed.
+func (s *Server) apiHeaders(w http.ResponseWriter) {
+	if s.opts.AllowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", s.opts.AllowOrigin)
+		w.Header().Set("Vary", "Origin")
+	}
+}
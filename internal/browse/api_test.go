@@ -0,0 +1,150 @@
+package browse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func TestServerAPI(t *testing.T) {
+	s := testServer(t)
+
+	tests := map[string]struct {
+		target   string
+		wantCode int
+		want     string // names, platforms, or IDs in response order
+	}{
+		"skills": {
+			target:   "/skills",
+			wantCode: http.StatusOK,
+			want:     "plan/codex review/claude-code review/cursor review-lite/codex",
+		},
+		"skills by platform": {
+			target:   "/skills?platform=codex",
+			wantCode: http.StatusOK,
+			want:     "plan/codex review-lite/codex",
+		},
+		"skills by query": {
+			target:   "/skills?q=docs",
+			wantCode: http.StatusOK,
+			want:     "review/cursor",
+		},
+		"skill copies": {
+			target:   "/skills/review",
+			wantCode: http.StatusOK,
+			want:     "review/claude-code review/cursor",
+		},
+		"skill copy on a platform": {
+			target:   "/skills/review?platform=cursor",
+			wantCode: http.StatusOK,
+			want:     "review/cursor",
+		},
+		"unknown skill": {
+			target:   "/skills/missing",
+			wantCode: http.StatusNotFound,
+		},
+		"platforms": {
+			target:   "/platforms",
+			wantCode: http.StatusOK,
+			want:     "claude-code:1 cursor:1 codex:2 aider:0",
+		},
+		"backups": {
+			target:   "/backups",
+			wantCode: http.StatusOK,
+			want:     "other new old",
+		},
+		"backups by platform": {
+			target:   "/backups?platform=claude-code",
+			wantCode: http.StatusOK,
+			want:     "new old",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			code, body := get(t, s, tt.target)
+			if code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", code, tt.wantCode, body)
+			}
+			if code != http.StatusOK {
+				var apiErr map[string]string
+				if err := json.Unmarshal([]byte(body), &apiErr); err != nil || apiErr["error"] == "" {
+					t.Errorf("error body = %q, want a JSON error", body)
+				}
+				return
+			}
+
+			var got []string
+			switch {
+			case strings.HasPrefix(tt.target, "/platforms"):
+				var platforms []platformInfo
+				if err := json.Unmarshal([]byte(body), &platforms); err != nil {
+					t.Fatalf("invalid JSON: %v", err)
+				}
+				for _, p := range platforms {
+					got = append(got, fmt.Sprintf("%s:%d", p.Name, p.Skills))
+				}
+			case strings.HasPrefix(tt.target, "/backups"):
+				var backups []backup.Metadata
+				if err := json.Unmarshal([]byte(body), &backups); err != nil {
+					t.Fatalf("invalid JSON: %v", err)
+				}
+				for _, b := range backups {
+					got = append(got, b.ID)
+				}
+			default:
+				var skills []model.Skill
+				if err := json.Unmarshal([]byte(body), &skills); err != nil {
+					t.Fatalf("invalid JSON: %v", err)
+				}
+				for _, skill := range skills {
+					got = append(got, skill.Name+"/"+string(skill.Platform))
+				}
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("%s = %q, want %q", tt.target, strings.Join(got, " "), tt.want)
+			}
+		})
+	}
+}
+
+func TestServerAPIAllowOrigin(t *testing.T) {
+	s, err := New(Options{
+		Skills:      func() ([]model.Skill, error) { return nil, nil },
+		AllowOrigin: "http://localhost:3000",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/backups", nil))
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != "[]" {
+		t.Errorf("backups without a source = %q, want []", body)
+	}
+
+	rec = httptest.NewRecorder()
+	testServer(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/skills", nil))
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q without AllowOrigin", got)
+	}
+}
+
+func TestServerAPISkillsError(t *testing.T) {
+	s, err := New(Options{Skills: func() ([]model.Skill, error) { return nil, errors.New("boom") }})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if code, body := get(t, s, "/skills"); code != http.StatusInternalServerError || !strings.Contains(body, `"error"`) {
+		t.Errorf("status = %d, body = %q, want a 500 JSON error", code, body)
+	}
+}
@@ -0,0 +1,339 @@
+// Package browse serves a read-only local web UI for the skill inventory.
+//
+// The UI lists discovered skills with search, renders skill Markdown, shows
+// diffs between copies of a skill on different platforms or scopes, lists
+// similar skills, and shows backup history. A JSON API over the same data
+// (/skills, /skills/{name}, /platforms, /backups) serves dashboards and
+// editor extensions. It never modifies files.
+package browse
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/similarity"
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+// maxSimilar caps the number of similar skills shown on a skill page.
+const maxSimilar = 5
+
+// Options configures the browse server.
+type Options struct {
+	// Skills returns the current skill inventory. It is called on every request
+	// so the UI reflects edits without a restart.
+	Skills func() ([]model.Skill, error)
+	// Backups returns backup history. Optional.
+	Backups func() ([]backup.Metadata, error)
+	// SimilarityThreshold is the minimum content similarity (0.0-1.0) for a
+	// skill to be listed as similar. Default: 0.6
+	SimilarityThreshold float64
+	// AllowOrigin, if set, is sent as Access-Control-Allow-Origin on JSON API
+	// responses so browser dashboards on another origin can read them.
+	AllowOrigin string
+}
+
+// Server is an http.Handler for the browse UI.
+type Server struct {
+	opts    Options
+	mux     *http.ServeMux
+	pages   map[string]*template.Template
+	matcher *similarity.ContentMatcher
+}
+
+// New creates a browse server.
+func New(opts Options) (*Server, error) {
+	if opts.Skills == nil {
+		return nil, fmt.Errorf("browse: Skills source is required")
+	}
+	if opts.SimilarityThreshold <= 0 || opts.SimilarityThreshold > 1 {
+		opts.SimilarityThreshold = similarity.DefaultContentMatcherConfig().Threshold
+	}
+
+	pages, err := parsePages()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := similarity.DefaultContentMatcherConfig()
+	cfg.Threshold = opts.SimilarityThreshold
+
+	s := &Server{
+		opts:    opts,
+		mux:     http.NewServeMux(),
+		pages:   pages,
+		matcher: similarity.NewContentMatcher(cfg),
+	}
+	s.mux.HandleFunc("GET /{$}", s.handleIndex)
+	s.mux.HandleFunc("GET /skill", s.handleSkill)
+	s.mux.HandleFunc("GET /diff", s.handleDiff)
+	s.mux.HandleFunc("GET /api/skills", s.handleAPISkills)
+	s.mux.HandleFunc("GET /skills", s.handleAPISkillList)
+	s.mux.HandleFunc("GET /skills/{name}", s.handleAPISkill)
+	s.mux.HandleFunc("GET /platforms", s.handleAPIPlatforms)
+	s.mux.HandleFunc("GET /backups", s.handleAPIBackups)
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler. Only GET and HEAD requests are routed.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; style-src 'unsafe-inline'")
+	s.mux.ServeHTTP(w, r)
+}
+
+// skillLink returns the URL of a skill's detail page. Skills are identified by
+// path so copies with the same name on different platforms stay distinct.
+func skillLink(skill model.Skill) string {
+	return "/skill?" + url.Values{"path": {skill.Path}}.Encode()
+}
+
+// diffLink returns the URL of the diff page between two skills.
+func diffLink(a, b model.Skill) string {
+	return "/diff?" + url.Values{"a": {a.Path}, "b": {b.Path}}.Encode()
+}
+
+// matchesQuery reports whether a skill matches a case-insensitive search query.
+func matchesQuery(skill model.Skill, query string) bool {
+	if query == "" {
+		return true
+	}
+	query = strings.ToLower(query)
+	return strings.Contains(strings.ToLower(skill.Name), query) ||
+		strings.Contains(strings.ToLower(skill.Description), query) ||
+		strings.Contains(strings.ToLower(skill.Content), query)
+}
+
+// skillRow is a skill list entry.
+type skillRow struct {
+	Skill model.Skill
+	Link  string
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	skills, err := s.opts.Skills()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	platform := r.URL.Query().Get("platform")
+
+	rows := make([]skillRow, 0, len(skills))
+	for _, skill := range skills {
+		if platform != "" && string(skill.Platform) != platform {
+			continue
+		}
+		if !matchesQuery(skill, query) {
+			continue
+		}
+		rows = append(rows, skillRow{Skill: skill, Link: skillLink(skill)})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Skill.Name != rows[j].Skill.Name {
+			return rows[i].Skill.Name < rows[j].Skill.Name
+		}
+		return rows[i].Skill.Platform < rows[j].Skill.Platform
+	})
+
+	s.render(w, "index", map[string]any{
+		"Title":     "Skills",
+		"Query":     query,
+		"Platform":  platform,
+		"Platforms": model.AllPlatforms(),
+		"Rows":      rows,
+		"Total":     len(skills),
+	})
+}
+
+// counterpart is another copy of a skill with the same name.
+type counterpart struct {
+	Skill     model.Skill
+	Link      string
+	DiffLink  string
+	Identical bool
+}
+
+// similarSkill is a skill with similar content.
+type similarSkill struct {
+	Skill   model.Skill
+	Link    string
+	Percent int
+}
+
+func (s *Server) handleSkill(w http.ResponseWriter, r *http.Request) {
+	skills, err := s.opts.Skills()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	skill, ok := findByPath(skills, r.URL.Query().Get("path"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	detector := sync.NewConflictDetector()
+	var counterparts []counterpart
+	var similar []similarSkill
+	for _, other := range skills {
+		if other.Path == skill.Path {
+			continue
+		}
+		if other.Name == skill.Name {
+			counterparts = append(counterparts, counterpart{
+				Skill:     other,
+				Link:      skillLink(other),
+				DiffLink:  diffLink(skill, other),
+				Identical: detector.DetectConflict(skill, other) == nil,
+			})
+			continue
+		}
+		if score := s.matcher.Compare(skill.Content, other.Content); score >= s.opts.SimilarityThreshold {
+			similar = append(similar, similarSkill{Skill: other, Link: skillLink(other), Percent: int(score * 100)})
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool { return similar[i].Percent > similar[j].Percent })
+	if len(similar) > maxSimilar {
+		similar = similar[:maxSimilar]
+	}
+
+	backups, err := s.skillBackups(skill)
+	if err != nil {
+		logging.Warn("failed to load backups for browse", logging.Err(err))
+	}
+
+	s.render(w, "skill", map[string]any{
+		"Title":        skill.Name,
+		"Skill":        skill,
+		"Body":         RenderMarkdown(skill.Content),
+		"Counterparts": counterparts,
+		"Similar":      similar,
+		"Backups":      backups,
+	})
+}
+
+// skillBackups returns backups of a skill's file, newest first.
+func (s *Server) skillBackups(skill model.Skill) ([]backup.Metadata, error) {
+	if s.opts.Backups == nil {
+		return nil, nil
+	}
+	all, err := s.opts.Backups()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []backup.Metadata
+	for _, b := range all {
+		if b.SourcePath == skill.Path ||
+			(b.Platform == string(skill.Platform) && b.Metadata["skill"] == skill.Name) {
+			matched = append(matched, b)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+	return matched, nil
+}
+
+// diffLineView is a diff line with its CSS class.
+type diffLineView struct {
+	Class string
+	Text  string
+}
+
+func (s *Server) handleDiff(w http.ResponseWriter, r *http.Request) {
+	skills, err := s.opts.Skills()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	a, okA := findByPath(skills, r.URL.Query().Get("a"))
+	b, okB := findByPath(skills, r.URL.Query().Get("b"))
+	if !okA || !okB {
+		http.NotFound(w, r)
+		return
+	}
+
+	result := similarity.ComputeDiff(a, b, 0, 0)
+	var lines []diffLineView
+	for _, hunk := range result.Hunks {
+		lines = append(lines, diffLineView{
+			Class: "hunk",
+			Text:  fmt.Sprintf("@@ -%d +%d @@", hunk.SourceStart, hunk.TargetStart),
+		})
+		for _, line := range hunk.Lines {
+			class := "context"
+			switch line.Type {
+			case sync.DiffLineAdded:
+				class = "added"
+			case sync.DiffLineRemoved:
+				class = "removed"
+			}
+			lines = append(lines, diffLineView{Class: class, Text: line.String()})
+		}
+	}
+
+	s.render(w, "diff", map[string]any{
+		"Title":   "diff " + a.Name,
+		"A":       a,
+		"ALink":   skillLink(a),
+		"B":       b,
+		"BLink":   skillLink(b),
+		"Lines":   lines,
+		"Added":   result.LinesAdded,
+		"Removed": result.LinesRemoved,
+	})
+}
+
+func (s *Server) handleAPISkills(w http.ResponseWriter, r *http.Request) {
+	skills, err := s.opts.Skills()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	filtered := make([]model.Skill, 0, len(skills))
+	for _, skill := range skills {
+		if matchesQuery(skill, query) {
+			filtered = append(filtered, skill)
+		}
+	}
+
+	s.writeJSON(w, filtered)
+}
+
+// findByPath returns the discovered skill at path. Only discovered skills can
+// be looked up, so request parameters never reach the filesystem.
+func findByPath(skills []model.Skill, path string) (model.Skill, bool) {
+	if path == "" {
+		return model.Skill{}, false
+	}
+	for _, skill := range skills {
+		if skill.Path == path {
+			return skill, true
+		}
+	}
+	return model.Skill{}, false
+}
+
+func (s *Server) render(w http.ResponseWriter, page string, data map[string]any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.pages[page].ExecuteTemplate(w, "layout", data); err != nil {
+		logging.Warn("failed to render browse page", logging.Err(err))
+	}
+}
+
+func (s *Server) serverError(w http.ResponseWriter, err error) {
+	logging.Error("browse request failed", logging.Err(err))
+	http.Error(w, "failed to load skills: "+err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,158 @@
+package browse
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func testServer(t *testing.T) *Server {
+	t.Helper()
+	skills := []model.Skill{
+		{Name: "review", Platform: model.ClaudeCode, Path: "/claude/review/SKILL.md", Description: "Review code", Content: "# Review\n\nCheck tests.\nCheck style."},
+		{Name: "review", Platform: model.Cursor, Path: "/cursor/review.md", Content: "# Review\n\nCheck tests.\nCheck docs."},
+		{Name: "review-lite", Platform: model.Codex, Path: "/codex/review-lite/SKILL.md", Content: "# Review\n\nCheck tests.\nCheck style."},
+		{Name: "plan", Platform: model.Codex, Path: "/codex/plan/SKILL.md", Content: "Plan <work>."},
+	}
+	backups := []backup.Metadata{
+		{ID: "old", SourcePath: "/claude/review/SKILL.md", Platform: "claude-code", CreatedAt: time.Unix(100, 0)},
+		{ID: "new", Platform: "claude-code", Metadata: map[string]string{"skill": "review"}, CreatedAt: time.Unix(200, 0)},
+		{ID: "other", SourcePath: "/codex/plan/SKILL.md", Platform: "codex", CreatedAt: time.Unix(300, 0)},
+	}
+
+	s, err := New(Options{
+		Skills:  func() ([]model.Skill, error) { return skills, nil },
+		Backups: func() ([]backup.Metadata, error) { return backups, nil },
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return s
+}
+
+func get(t *testing.T, h http.Handler, target string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	body, _ := io.ReadAll(rec.Body)
+	return rec.Code, string(body)
+}
+
+func TestServerPages(t *testing.T) {
+	s := testServer(t)
+
+	tests := map[string]struct {
+		target   string
+		wantCode int
+		want     []string
+		deny     []string
+	}{
+		"index lists skills": {
+			target:   "/",
+			wantCode: http.StatusOK,
+			want:     []string{"4 of 4 skills", "review", "plan"},
+		},
+		"index search": {
+			target:   "/?q=docs",
+			wantCode: http.StatusOK,
+			want:     []string{"1 of 4 skills"},
+		},
+		"index platform filter": {
+			target:   "/?platform=codex",
+			wantCode: http.StatusOK,
+			want:     []string{"2 of 4 skills"},
+		},
+		"skill page": {
+			target:   "/skill?path=" + url.QueryEscape("/claude/review/SKILL.md"),
+			wantCode: http.StatusOK,
+			want:     []string{"<h1>Review</h1>", "differs", "review-lite", "100% similar", "<td>new</td>"},
+			deny:     []string{"<td>other</td>"},
+		},
+		"skill content escaped": {
+			target:   "/skill?path=" + url.QueryEscape("/codex/plan/SKILL.md"),
+			wantCode: http.StatusOK,
+			want:     []string{"Plan &lt;work&gt;."},
+		},
+		"unknown skill": {
+			target:   "/skill?path=/etc/passwd",
+			wantCode: http.StatusNotFound,
+		},
+		"diff page": {
+			target:   "/diff?a=" + url.QueryEscape("/claude/review/SKILL.md") + "&b=" + url.QueryEscape("/cursor/review.md"),
+			wantCode: http.StatusOK,
+			want:     []string{`class="removed">-Check style.`, `class="added">&#43;Check docs.`},
+		},
+		"unknown path": {
+			target:   "/nope",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			code, body := get(t, s, tt.target)
+			if code != tt.wantCode {
+				t.Fatalf("status = %d, want %d\n%s", code, tt.wantCode, body)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("body missing %q", want)
+				}
+			}
+			for _, deny := range tt.deny {
+				if strings.Contains(body, deny) {
+					t.Errorf("body contains %q", deny)
+				}
+			}
+		})
+	}
+}
+
+func TestServerIsReadOnly(t *testing.T) {
+	s := testServer(t)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("x")))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestServerAPISkills(t *testing.T) {
+	s := testServer(t)
+	code, body := get(t, s, "/api/skills?q=plan")
+	if code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	var skills []model.Skill
+	if err := json.Unmarshal([]byte(body), &skills); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(skills) != 1 || skills[0].Name != "plan" {
+		t.Errorf("unexpected skills: %+v", skills)
+	}
+}
+
+func TestServerSkillsError(t *testing.T) {
+	s, err := New(Options{Skills: func() ([]model.Skill, error) { return nil, errors.New("boom") }})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if code, _ := get(t, s, "/"); code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", code)
+	}
+}
+
+func TestNewRequiresSkills(t *testing.T) {
+	if _, err := New(Options{}); err == nil {
+		t.Error("expected error without Skills source")
+	}
+}
@@ -0,0 +1,182 @@
+package browse
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	orderedPattern   = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	unorderedPattern = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	rulePattern      = regexp.MustCompile(`^(\*\s*){3,}$|^(-\s*){3,}$|^(_\s*){3,}$`)
+	boldPattern      = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	italicPattern    = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	linkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// RenderMarkdown converts skill Markdown to HTML.
+//
+// It supports the subset skills use in practice: headings, paragraphs, fenced
+// code blocks, ordered and unordered lists, blockquotes, horizontal rules,
+// inline code, bold, italic, and links. All text is HTML-escaped and links
+// are limited to http(s), mailto, and relative URLs, so the output is safe to
+// embed in a page.
+func RenderMarkdown(src string) template.HTML {
+	r := &markdownRenderer{}
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		r.line(line)
+	}
+	r.flush()
+	// #nosec G203 - all text is escaped by renderInline/html.EscapeString
+	return template.HTML(r.sb.String())
+}
+
+// markdownRenderer accumulates block state while rendering line by line.
+type markdownRenderer struct {
+	sb        strings.Builder
+	paragraph []string
+	listTag   string
+	inCode    bool
+	quote     []string
+}
+
+func (r *markdownRenderer) line(line string) {
+	trimmed := strings.TrimSpace(line)
+
+	if r.inCode {
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			r.sb.WriteString("</code></pre>\n")
+			r.inCode = false
+			return
+		}
+		r.sb.WriteString(html.EscapeString(line))
+		r.sb.WriteString("\n")
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+		r.flush()
+		lang := strings.TrimSpace(strings.TrimLeft(trimmed, "`~"))
+		if lang != "" {
+			r.sb.WriteString(`<pre><code class="language-` + html.EscapeString(lang) + `">`)
+		} else {
+			r.sb.WriteString("<pre><code>")
+		}
+		r.inCode = true
+	case trimmed == "":
+		r.flush()
+	case rulePattern.MatchString(trimmed):
+		r.flush()
+		r.sb.WriteString("<hr>\n")
+	case headingPattern.MatchString(trimmed):
+		r.flush()
+		m := headingPattern.FindStringSubmatch(trimmed)
+		level := string(rune('0' + len(m[1])))
+		r.sb.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+	case strings.HasPrefix(trimmed, ">"):
+		r.flushParagraph()
+		r.flushList()
+		r.quote = append(r.quote, strings.TrimSpace(strings.TrimPrefix(trimmed, ">")))
+	case unorderedPattern.MatchString(trimmed):
+		r.listItem("ul", unorderedPattern.FindStringSubmatch(trimmed)[1])
+	case orderedPattern.MatchString(trimmed):
+		r.listItem("ol", orderedPattern.FindStringSubmatch(trimmed)[1])
+	default:
+		r.flushList()
+		r.flushQuote()
+		r.paragraph = append(r.paragraph, trimmed)
+	}
+}
+
+func (r *markdownRenderer) listItem(tag, text string) {
+	r.flushParagraph()
+	r.flushQuote()
+	if r.listTag != tag {
+		r.flushList()
+		r.sb.WriteString("<" + tag + ">\n")
+		r.listTag = tag
+	}
+	r.sb.WriteString("<li>" + renderInline(text) + "</li>\n")
+}
+
+// flush closes any open block. An unterminated code fence is closed too.
+func (r *markdownRenderer) flush() {
+	r.flushParagraph()
+	r.flushList()
+	r.flushQuote()
+	if r.inCode {
+		r.sb.WriteString("</code></pre>\n")
+		r.inCode = false
+	}
+}
+
+func (r *markdownRenderer) flushParagraph() {
+	if len(r.paragraph) == 0 {
+		return
+	}
+	r.sb.WriteString("<p>" + renderInline(strings.Join(r.paragraph, " ")) + "</p>\n")
+	r.paragraph = nil
+}
+
+func (r *markdownRenderer) flushList() {
+	if r.listTag == "" {
+		return
+	}
+	r.sb.WriteString("</" + r.listTag + ">\n")
+	r.listTag = ""
+}
+
+func (r *markdownRenderer) flushQuote() {
+	if len(r.quote) == 0 {
+		return
+	}
+	r.sb.WriteString("<blockquote><p>" + renderInline(strings.Join(r.quote, " ")) + "</p></blockquote>\n")
+	r.quote = nil
+}
+
+// renderInline escapes text and applies inline formatting outside code spans.
+func renderInline(text string) string {
+	parts := strings.Split(text, "`")
+	var sb strings.Builder
+	for i, part := range parts {
+		// Odd segments are inside a code span, unless the closing backtick is missing.
+		if i%2 == 1 && i < len(parts)-1 {
+			sb.WriteString("<code>" + html.EscapeString(part) + "</code>")
+			continue
+		}
+		if i%2 == 1 {
+			sb.WriteString("`")
+		}
+		sb.WriteString(formatInline(html.EscapeString(part)))
+	}
+	return sb.String()
+}
+
+// formatInline applies links, bold, and italic to already-escaped text.
+func formatInline(escaped string) string {
+	escaped = linkPattern.ReplaceAllStringFunc(escaped, func(m string) string {
+		parts := linkPattern.FindStringSubmatch(m)
+		if !isSafeURL(html.UnescapeString(parts[2])) {
+			return parts[1]
+		}
+		return `<a href="` + parts[2] + `">` + parts[1] + `</a>`
+	})
+	escaped = boldPattern.ReplaceAllString(escaped, "<strong>$1</strong>")
+	return italicPattern.ReplaceAllString(escaped, "<em>$1</em>")
+}
+
+// isSafeURL allows http(s), mailto, fragment, and relative URLs.
+func isSafeURL(u string) bool {
+	lower := strings.ToLower(u)
+	for _, prefix := range []string{"http://", "https://", "mailto:", "#", "/", "./", "../"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	// Relative paths without a scheme are fine; anything with a scheme is not.
+	return !strings.Contains(strings.SplitN(lower, "/", 2)[0], ":")
+}
@@ -0,0 +1,69 @@
+package browse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  []string
+		deny  []string
+	}{
+		"heading and paragraph": {
+			input: "# Title\n\nSome text\ncontinued.",
+			want:  []string{"<h1>Title</h1>", "<p>Some text continued.</p>"},
+		},
+		"lists": {
+			input: "- one\n- two\n\n1. first\n2. second",
+			want:  []string{"<ul>\n<li>one</li>\n<li>two</li>\n</ul>", "<ol>\n<li>first</li>"},
+		},
+		"fenced code is escaped verbatim": {
+			input: "```go\nif a < b && **x** {\n```",
+			want:  []string{`<pre><code class="language-go">if a &lt; b &amp;&amp; **x** {`},
+			deny:  []string{"<strong>"},
+		},
+		"inline formatting": {
+			input: "Use `a<b` with **bold** and *em*.",
+			want:  []string{"<code>a&lt;b</code>", "<strong>bold</strong>", "<em>em</em>"},
+		},
+		"html is escaped": {
+			input: "<script>alert(1)</script>",
+			want:  []string{"&lt;script&gt;"},
+			deny:  []string{"<script>"},
+		},
+		"safe link": {
+			input: "[docs](https://example.com/a?b=1&c=2)",
+			want:  []string{`<a href="https://example.com/a?b=1&amp;c=2">docs</a>`},
+		},
+		"unsafe link dropped": {
+			input: "[click](javascript:alert(1))",
+			deny:  []string{"<a ", "javascript"},
+		},
+		"blockquote and rule": {
+			input: "> quoted\n\n---",
+			want:  []string{"<blockquote><p>quoted</p></blockquote>", "<hr>"},
+		},
+		"unterminated fence is closed": {
+			input: "```\ncode",
+			want:  []string{"<pre><code>code\n</code></pre>"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := string(RenderMarkdown(tt.input))
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q:\n%s", want, got)
+				}
+			}
+			for _, deny := range tt.deny {
+				if strings.Contains(got, deny) {
+					t.Errorf("output contains %q:\n%s", deny, got)
+				}
+			}
+		})
+	}
+}
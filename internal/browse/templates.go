@@ -0,0 +1,134 @@
+package browse
+
+import (
+	"fmt"
+	"html/template"
+	"time"
+)
+
+const layoutTemplate = `{{define "layout"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} · skillsync</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 0; color: #1f2328; }
+header { background: #24292f; color: #fff; padding: 0.75rem 1.5rem; }
+header a { color: #fff; text-decoration: none; font-weight: 600; }
+main { max-width: 960px; margin: 0 auto; padding: 1.5rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.4rem 0.6rem; border-bottom: 1px solid #d0d7de; vertical-align: top; }
+.muted { color: #656d76; font-size: 0.9em; }
+.badge { display: inline-block; padding: 0 0.4rem; border-radius: 0.5rem; background: #ddf4ff; font-size: 0.85em; }
+.markdown { border: 1px solid #d0d7de; border-radius: 6px; padding: 0 1rem; }
+pre { background: #f6f8fa; padding: 0.75rem; overflow-x: auto; }
+.diff { font-family: ui-monospace, monospace; white-space: pre-wrap; background: #f6f8fa; padding: 0.5rem; }
+.diff .added { background: #dafbe1; }
+.diff .removed { background: #ffebe9; }
+.diff .hunk { color: #0969da; }
+form input[type=search] { width: 60%; padding: 0.3rem; }
+</style>
+</head>
+<body>
+<header><a href="/">skillsync</a> <span class="muted">read-only</span></header>
+<main>{{template "content" .}}</main>
+</body>
+</html>{{end}}`
+
+const indexTemplate = `{{define "content"}}
+<form method="get" action="/">
+<input type="search" name="q" value="{{.Query}}" placeholder="Search name, description, content">
+<select name="platform">
+<option value="">All platforms</option>
+{{range .Platforms}}<option value="{{.}}"{{if eq (print .) $.Platform}} selected{{end}}>{{.}}</option>{{end}}
+</select>
+<button type="submit">Search</button>
+</form>
+<p class="muted">{{len .Rows}} of {{.Total}} skills</p>
+<table>
+<thead><tr><th>Name</th><th>Platform</th><th>Scope</th><th>Description</th></tr></thead>
+<tbody>
+{{range .Rows}}<tr>
+<td><a href="{{.Link}}">{{.Skill.Name}}</a></td>
+<td>{{.Skill.Platform}}</td>
+<td>{{.Skill.DisplayScope}}</td>
+<td>{{.Skill.Description}}</td>
+</tr>{{else}}<tr><td colspan="4" class="muted">No skills found.</td></tr>{{end}}
+</tbody>
+</table>
+{{end}}`
+
+const skillTemplate = `{{define "content"}}
+<h1>{{.Skill.Name}} <span class="badge">{{.Skill.Platform}}</span></h1>
+{{with .Skill.Description}}<p>{{.}}</p>{{end}}
+<p class="muted">{{.Skill.Path}} · {{.Skill.DisplayScope}}{{if not .Skill.ModifiedAt.IsZero}} · modified {{formatTime .Skill.ModifiedAt}}{{end}}</p>
+{{with .Skill.Tools}}<p class="muted">Tools: {{range $i, $t := .}}{{if $i}}, {{end}}{{$t}}{{end}}</p>{{end}}
+<div class="markdown">{{.Body}}</div>
+
+<h2>Other copies</h2>
+{{if .Counterparts}}<table>
+<thead><tr><th>Platform</th><th>Scope</th><th>Status</th><th></th></tr></thead>
+<tbody>
+{{range .Counterparts}}<tr>
+<td><a href="{{.Link}}">{{.Skill.Platform}}</a></td>
+<td>{{.Skill.DisplayScope}}</td>
+<td>{{if .Identical}}identical{{else}}differs{{end}}</td>
+<td>{{if not .Identical}}<a href="{{.DiffLink}}">diff</a>{{end}}</td>
+</tr>{{end}}
+</tbody>
+</table>{{else}}<p class="muted">This skill exists in only one place.</p>{{end}}
+
+<h2>Similar skills</h2>
+{{if .Similar}}<ul>
+{{range .Similar}}<li><a href="{{.Link}}">{{.Skill.Name}}</a> <span class="muted">{{.Skill.Platform}} · {{.Percent}}% similar</span></li>{{end}}
+</ul>{{else}}<p class="muted">No similar skills.</p>{{end}}
+
+<h2>Backup history</h2>
+{{if .Backups}}<table>
+<thead><tr><th>ID</th><th>Created</th><th>Size</th><th>Description</th></tr></thead>
+<tbody>
+{{range .Backups}}<tr>
+<td>{{.ID}}</td>
+<td>{{formatTime .CreatedAt}}</td>
+<td>{{.Size}} B</td>
+<td>{{.Description}}</td>
+</tr>{{end}}
+</tbody>
+</table>{{else}}<p class="muted">No backups.</p>{{end}}
+{{end}}`
+
+const diffTemplate = `{{define "content"}}
+<h1>diff {{.A.Name}}</h1>
+<p><a href="{{.ALink}}">{{.A.Platform}}</a> <span class="muted">{{.A.Path}}</span><br>
+<a href="{{.BLink}}">{{.B.Platform}}</a> <span class="muted">{{.B.Path}}</span></p>
+{{if .Lines}}<p class="muted">{{.Removed}} line(s) only in {{.A.Platform}}, {{.Added}} line(s) only in {{.B.Platform}}</p>
+<div class="diff">{{range .Lines}}<div class="{{.Class}}">{{.Text}}</div>{{end}}</div>
+{{else}}<p>Content is identical.</p>{{end}}
+{{end}}`
+
+var templateFuncs = template.FuncMap{
+	"formatTime": func(t time.Time) string { return t.Local().Format("2006-01-02 15:04") },
+}
+
+// parsePages builds one template set per page, each sharing the layout.
+func parsePages() (map[string]*template.Template, error) {
+	contents := map[string]string{
+		"index": indexTemplate,
+		"skill": skillTemplate,
+		"diff":  diffTemplate,
+	}
+
+	pages := make(map[string]*template.Template, len(contents))
+	for name, content := range contents {
+		tmpl, err := template.New(name).Funcs(templateFuncs).Parse(layoutTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse layout template: %w", err)
+		}
+		if _, err := tmpl.Parse(content); err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", name, err)
+		}
+		pages[name] = tmpl
+	}
+	return pages, nil
+}
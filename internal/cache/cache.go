@@ -3,10 +3,14 @@ package cache
 
 import (
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/klauern/skillsync/internal/logging"
 	"github.com/klauern/skillsync/internal/model"
 	"github.com/klauern/skillsync/internal/util"
 )
@@ -19,53 +23,88 @@ type Entry struct {
 	SourceMod  time.Time   `json:"source_mod"`
 }
 
-// Cache manages cached skills for a specific source type
+// Cache manages cached skills for a specific source type. It is safe for
+// concurrent use, and Save and Clear lock the cache file so that several
+// skillsync processes can refresh the same cache at once.
 type Cache struct {
 	Version string           `json:"version"`
 	Entries map[string]Entry `json:"entries"`
 	path    string
+	mu      sync.Mutex
 }
 
 const (
+	// cacheVersion identifies the format of cache files. Bump it whenever
+	// Entry changes; files written with another version are discarded
+	// without parsing their entries.
 	cacheVersion = "1.0"
 	// DefaultTTL is the default time-to-live for cache entries
 	DefaultTTL = 1 * time.Hour
+	// RacyWindow is how long after a file or directory changes before its
+	// modification time can be trusted to identify its state. Filesystems
+	// with coarse timestamps can give one changed again within this window
+	// the modification time already cached.
+	RacyWindow = 2 * time.Second
 )
 
-// New creates or loads a cache for the given source name (e.g., "plugins")
+// New creates or loads a cache for the given source name (e.g., "plugins").
+// With --no-persist the cache is still read, but Save does not write it.
 func New(sourceName string) (*Cache, error) {
-	cacheDir := filepath.Join(util.SkillsyncConfigPath(), "cache")
-	if err := os.MkdirAll(cacheDir, 0o750); err != nil {
-		return nil, err
+	cacheDir := util.SkillsyncCachePath()
+	if !util.NoPersist() {
+		if err := util.EnsureDataDir(cacheDir, 0o750); err != nil {
+			return nil, err
+		}
 	}
 
-	cachePath := filepath.Join(cacheDir, sourceName+".json")
 	cache := &Cache{
 		Version: cacheVersion,
 		Entries: make(map[string]Entry),
-		path:    cachePath,
+		path:    filepath.Join(cacheDir, sourceName+".json"),
 	}
 
 	// Try to load existing cache
-	// #nosec G304 - cachePath is constructed from trusted configuration path
-	if data, err := os.ReadFile(cachePath); err == nil {
-		if err := json.Unmarshal(data, cache); err != nil {
-			// Corrupted cache, start fresh
-			cache.Entries = make(map[string]Entry)
-		}
-		// Version mismatch, invalidate cache
-		if cache.Version != cacheVersion {
-			cache.Entries = make(map[string]Entry)
-			cache.Version = cacheVersion
-		}
+	// #nosec G304 - the path is constructed from trusted configuration path
+	if data, err := os.ReadFile(cache.path); err == nil {
+		cache.Entries = decodeEntries(cache.path, data)
 	}
 
-	cache.path = cachePath
 	return cache, nil
 }
 
+// decodeEntries returns the entries of a cache file. Files of another
+// version, or that cannot be parsed, yield an empty cache.
+func decodeEntries(path string, data []byte) map[string]Entry {
+	var header struct {
+		Version any `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		logging.Info("discarding unreadable cache", logging.Path(path), logging.Err(err))
+		return make(map[string]Entry)
+	}
+	if header.Version != cacheVersion {
+		logging.Debug("discarding cache of another version",
+			logging.Path(path),
+			slog.Any("version", header.Version),
+		)
+		return make(map[string]Entry)
+	}
+
+	var stored struct {
+		Entries map[string]Entry `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Entries == nil {
+		logging.Info("discarding unreadable cache", logging.Path(path), logging.Err(err))
+		return make(map[string]Entry)
+	}
+	return stored.Entries
+}
+
 // Get retrieves a cached skill if it exists and is still valid
 func (c *Cache) Get(key string) (model.Skill, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	entry, exists := c.Entries[key]
 	if !exists {
 		return model.Skill{}, false
@@ -90,6 +129,8 @@ func (c *Cache) Set(key string, skill model.Skill) {
 		sourceMod = info.ModTime()
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.Entries[key] = Entry{
 		Skill:      skill,
 		CachedAt:   time.Now(),
@@ -98,29 +139,97 @@ func (c *Cache) Set(key string, skill model.Skill) {
 	}
 }
 
-// Save persists the cache to disk
+// Save persists the cache to disk. The file is replaced atomically while
+// holding the cache lock, so readers never see a partial write.
 func (c *Cache) Save() error {
+	if util.NoPersist() {
+		return nil
+	}
+	c.mu.Lock()
 	data, err := json.MarshalIndent(c, "", "  ")
+	c.mu.Unlock()
 	if err != nil {
 		return err
 	}
-	// #nosec G306 - cache files should be readable by user
-	return os.WriteFile(c.path, data, 0o644)
+	return writeFile(c.path, data)
+}
+
+// writeFile replaces the cache file at path with data while holding its lock.
+func writeFile(path string, data []byte) error {
+	lock, err := acquireLock(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	// Write to a unique temp file and rename so a crash or a concurrent
+	// writer never leaves a truncated cache.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	// #nosec G302 - cache files should be readable by user
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return nil
 }
 
 // Clear removes all entries from the cache
 func (c *Cache) Clear() error {
+	c.mu.Lock()
 	c.Entries = make(map[string]Entry)
-	return os.Remove(c.path)
+	c.mu.Unlock()
+
+	return removeFile(c.path)
+}
+
+// removeFile deletes the cache file at path while holding its lock.
+func removeFile(path string) error {
+	lock, err := acquireLock(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+	return os.Remove(path)
 }
 
 // Size returns the number of entries in the cache
 func (c *Cache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return len(c.Entries)
 }
 
+// Skills returns the cached skills in no particular order.
+func (c *Cache) Skills() []model.Skill {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	skills := make([]model.Skill, 0, len(c.Entries))
+	for _, entry := range c.Entries {
+		skills = append(skills, entry.Skill)
+	}
+	return skills
+}
+
 // IsStale checks if any cache entry has expired based on TTL
 func (c *Cache) IsStale(ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	for _, entry := range c.Entries {
 		if time.Since(entry.CachedAt) > ttl {
 			return true
@@ -131,6 +240,8 @@ func (c *Cache) IsStale(ttl time.Duration) bool {
 
 // Prune removes stale entries based on TTL
 func (c *Cache) Prune(ttl time.Duration) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	pruned := 0
 	for key, entry := range c.Entries {
 		if time.Since(entry.CachedAt) > ttl {
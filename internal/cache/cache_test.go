@@ -1,8 +1,11 @@
 package cache
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -293,3 +296,136 @@ func TestCacheStaleSourceFile(t *testing.T) {
 		t.Error("cache.Get() should return false when source file is modified")
 	}
 }
+
+func TestCacheConcurrentSave(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", tmpDir)
+
+	// Separate caches stand in for separate skillsync processes
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := New("concurrent")
+			if err != nil {
+				errs <- err
+				return
+			}
+			for j := range 20 {
+				name := fmt.Sprintf("skill-%d-%d", i, j)
+				c.Set(name, model.Skill{Name: name, Path: filepath.Join(tmpDir, name+".md")})
+			}
+			errs <- c.Save()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "cache", "concurrent.json"))
+	if err != nil {
+		t.Fatalf("failed to read cache: %v", err)
+	}
+	var stored Cache
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("cache file is corrupt: %v", err)
+	}
+	// The last writer wins, along with whatever it loaded from earlier writers
+	if len(stored.Entries) < 20 {
+		t.Errorf("cache holds %d entries, want at least the 20 of the last writer", len(stored.Entries))
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(tmpDir, "cache", "concurrent.json.*"))
+	if len(leftovers) > 0 {
+		t.Errorf("lock or temp files left behind: %v", leftovers)
+	}
+}
+
+func TestCacheConcurrentSet(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	c, err := New("shared")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("skill-%d", i)
+			c.Set(name, model.Skill{Name: name})
+			c.Get(name)
+			_ = c.Size()
+		}()
+	}
+	wg.Wait()
+
+	if c.Size() != 16 {
+		t.Errorf("Size() = %d, want 16", c.Size())
+	}
+	if len(c.Skills()) != 16 {
+		t.Errorf("len(Skills()) = %d, want 16", len(c.Skills()))
+	}
+}
+
+func TestCacheStaleLockIsBroken(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", tmpDir)
+	c, err := New("locked")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	lockPath := filepath.Join(tmpDir, "cache", "locked.json.lock")
+	if err := os.WriteFile(lockPath, []byte("12345\n"), 0o600); err != nil {
+		t.Fatalf("failed to write lock: %v", err)
+	}
+	old := time.Now().Add(-2 * staleLockAge)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatalf("failed to age lock: %v", err)
+	}
+
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() should break a stale lock, got %v", err)
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Error("lock should be released after Save()")
+	}
+}
+
+func TestNew_DiscardsOtherVersions(t *testing.T) {
+	tests := map[string]string{
+		"older version":         `{"version": "0.9", "entries": {"a": {"skill": {"name": "a"}}}}`,
+		"incompatible format":   `{"version": 2, "entries": [{"name": "a"}]}`,
+		"corrupt file":          `{"version": "1.0", "entries": {`,
+		"entries of wrong type": `{"version": "1.0", "entries": ["a"]}`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			t.Setenv("SKILLSYNC_HOME", tmpDir)
+			if err := os.MkdirAll(filepath.Join(tmpDir, "cache"), 0o750); err != nil {
+				t.Fatalf("failed to create cache dir: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tmpDir, "cache", "old.json"), []byte(content), 0o600); err != nil {
+				t.Fatalf("failed to write cache: %v", err)
+			}
+
+			c, err := New("old")
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if c.Size() != 0 || c.Version != cacheVersion {
+				t.Errorf("cache = version %q with %d entries, want an empty %q cache", c.Version, c.Size(), cacheVersion)
+			}
+		})
+	}
+}
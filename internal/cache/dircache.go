@@ -0,0 +1,215 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// Fingerprint identifies the state of a directory tree.
+type Fingerprint struct {
+	// Hash covers the path, size, and modification time of every file and
+	// directory in the tree
+	Hash string
+	// Newest is the most recent modification time in the tree
+	Newest time.Time
+}
+
+// cachedSkill is the stored form of a skill. Frontmatter is not part of a
+// skill's JSON, but sync needs it to write the skill elsewhere unchanged.
+type cachedSkill struct {
+	model.Skill
+	Frontmatter string `json:"frontmatter,omitempty"`
+}
+
+// DirEntry is the cached parse of one skills directory.
+type DirEntry struct {
+	Skills      []cachedSkill `json:"skills"`
+	Fingerprint string        `json:"fingerprint"`
+	CachedAt    time.Time     `json:"cached_at"`
+}
+
+// DirCache caches the skills parsed from directories, each reused while the
+// directory's fingerprint is unchanged. Like Cache, it is safe for
+// concurrent use and locks its file while saving.
+type DirCache struct {
+	Version string              `json:"version"`
+	Entries map[string]DirEntry `json:"entries"`
+	path    string
+	dirty   bool
+	mu      sync.Mutex
+}
+
+// NewDirCache creates or loads the directory cache with the given name
+// (e.g., "skills-cursor").
+func NewDirCache(name string) (*DirCache, error) {
+	cacheDir := util.SkillsyncCachePath()
+	if !util.NoPersist() {
+		if err := util.EnsureDataDir(cacheDir, 0o750); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &DirCache{
+		Version: cacheVersion,
+		Entries: make(map[string]DirEntry),
+		path:    filepath.Join(cacheDir, name+".json"),
+	}
+	// #nosec G304 - the path is constructed from trusted configuration path
+	if data, err := os.ReadFile(c.path); err == nil {
+		c.Entries = decodeDirEntries(c.path, data)
+	}
+	return c, nil
+}
+
+// decodeDirEntries returns the entries of a directory cache file. Files of
+// another version, or that cannot be parsed, yield an empty cache.
+func decodeDirEntries(path string, data []byte) map[string]DirEntry {
+	var stored struct {
+		Version any                 `json:"version"`
+		Entries map[string]DirEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Entries == nil {
+		logging.Info("discarding unreadable cache", logging.Path(path), logging.Err(err))
+		return make(map[string]DirEntry)
+	}
+	if stored.Version != cacheVersion {
+		logging.Debug("discarding cache of another version", logging.Path(path))
+		return make(map[string]DirEntry)
+	}
+	return stored.Entries
+}
+
+// Get returns the skills cached under key if they were cached with the same
+// fingerprint no longer than ttl ago (0 means no limit).
+func (c *DirCache) Get(key string, fp Fingerprint, ttl time.Duration) ([]model.Skill, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.Entries[key]
+	if !ok || entry.Fingerprint != fp.Hash || Expired(entry.CachedAt, ttl) {
+		return nil, false
+	}
+	skills := make([]model.Skill, len(entry.Skills))
+	for i, cached := range entry.Skills {
+		skills[i] = cached.Skill
+		skills[i].Frontmatter = cached.Frontmatter
+	}
+	return skills, true
+}
+
+// Set caches the skills parsed from a directory with fingerprint fp. It does
+// nothing when the directory changed too recently for the fingerprint to be
+// trusted.
+func (c *DirCache) Set(key string, fp Fingerprint, skills []model.Skill) {
+	if time.Since(fp.Newest) < RacyWindow {
+		return
+	}
+	cached := make([]cachedSkill, len(skills))
+	for i, skill := range skills {
+		cached[i] = cachedSkill{Skill: skill, Frontmatter: skill.Frontmatter}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Entries[key] = DirEntry{Skills: cached, Fingerprint: fp.Hash, CachedAt: time.Now()}
+	c.dirty = true
+}
+
+// Save persists the cache to disk if it changed since it was loaded.
+func (c *DirCache) Save() error {
+	if util.NoPersist() {
+		return nil
+	}
+	c.mu.Lock()
+	if !c.dirty {
+		c.mu.Unlock()
+		return nil
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	c.dirty = false
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return writeFile(c.path, data)
+}
+
+// Size returns the number of directories in the cache.
+func (c *DirCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.Entries)
+}
+
+// FingerprintDir fingerprints the tree rooted at dir, following symbolic
+// links to directories once. Directories for which ignore returns true are
+// skipped, as discovery skips them.
+func FingerprintDir(dir string, ignore func(name string) bool) (Fingerprint, error) {
+	var lines []string
+	var newest time.Time
+	visited := make(map[string]bool)
+
+	var walk func(root, prefix string) error
+	walk = func(root, prefix string) error {
+		real, err := filepath.EvalSymlinks(root)
+		if err != nil {
+			return err
+		}
+		if visited[real] {
+			return nil
+		}
+		visited[real] = true
+
+		return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(filepath.Join(prefix, rel))
+			if path != root && d.IsDir() && ignore != nil && ignore(d.Name()) {
+				return filepath.SkipDir
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				// A dangling link changes nothing discovery can read
+				return nil
+			}
+			if info.ModTime().After(newest) {
+				newest = info.ModTime()
+			}
+			lines = append(lines, fmt.Sprintf("%s\x00%t\x00%d\x00%d", rel, info.IsDir(), info.Size(), info.ModTime().UnixNano()))
+
+			if d.Type()&fs.ModeSymlink != 0 && info.IsDir() {
+				return walk(path, rel)
+			}
+			return nil
+		})
+	}
+	if err := walk(dir, ""); err != nil {
+		return Fingerprint{}, fmt.Errorf("failed to fingerprint %q: %w", dir, err)
+	}
+
+	sort.Strings(lines)
+	hash := sha256.New()
+	for _, line := range lines {
+		hash.Write([]byte(line))
+		hash.Write([]byte{'\n'})
+	}
+	return Fingerprint{Hash: hex.EncodeToString(hash.Sum(nil)), Newest: newest}, nil
+}
@@ -0,0 +1,227 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// ageTree sets the modification time of everything under dir to the same
+// time in the past, outside the racy window.
+func ageTree(t *testing.T, dir string) {
+	t.Helper()
+	old := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := filepath.Walk(dir, func(path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	})
+	if err != nil {
+		t.Fatalf("failed to age %s: %v", dir, err)
+	}
+}
+
+func TestDirCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tmpDir, "home"))
+	skillsDir := filepath.Join(tmpDir, "skills")
+	util.WriteFile(t, filepath.Join(skillsDir, "lint", "SKILL.md"), "lint")
+	util.WriteFile(t, filepath.Join(skillsDir, "node_modules", "dep.md"), "dep")
+	ageTree(t, skillsDir)
+
+	ignore := func(name string) bool { return name == "node_modules" }
+	skills := []model.Skill{{Name: "lint", Path: filepath.Join(skillsDir, "lint", "SKILL.md"), Frontmatter: "name: lint"}}
+
+	fp, err := FingerprintDir(skillsDir, ignore)
+	util.AssertNoError(t, err)
+	c, err := NewDirCache("skills-test")
+	util.AssertNoError(t, err)
+	c.Set("key", fp, skills)
+	util.AssertNoError(t, c.Save())
+
+	tests := map[string]struct {
+		change  func()
+		ttl     time.Duration
+		wantHit bool
+	}{
+		"unchanged": {
+			change:  func() {},
+			wantHit: true,
+		},
+		"ignored directory changed": {
+			change: func() {
+				util.WriteFile(t, filepath.Join(skillsDir, "node_modules", "dep.md"), "changed dep")
+			},
+			wantHit: true,
+		},
+		"file changed": {
+			change: func() {
+				util.WriteFile(t, filepath.Join(skillsDir, "lint", "SKILL.md"), "changed lint")
+			},
+		},
+		"file added": {
+			change: func() {
+				util.WriteFile(t, filepath.Join(skillsDir, "lint", "scripts", "run.sh"), "run")
+			},
+		},
+		"expired": {
+			change: func() {},
+			ttl:    time.Nanosecond,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			backup := filepath.Join(tmpDir, "backup")
+			util.AssertNoError(t, os.CopyFS(backup, os.DirFS(skillsDir)))
+			t.Cleanup(func() {
+				_ = os.RemoveAll(skillsDir)
+				util.AssertNoError(t, os.Rename(backup, skillsDir))
+				ageTree(t, skillsDir)
+			})
+
+			tt.change()
+			fp, err := FingerprintDir(skillsDir, ignore)
+			util.AssertNoError(t, err)
+			loaded, err := NewDirCache("skills-test")
+			util.AssertNoError(t, err)
+
+			got, hit := loaded.Get("key", fp, tt.ttl)
+			util.AssertEqual(t, hit, tt.wantHit)
+			if hit {
+				util.AssertEqual(t, len(got), 1)
+				util.AssertEqual(t, got[0].Frontmatter, "name: lint")
+			}
+		})
+	}
+}
+
+func TestDirCache_SkipsRecentChanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tmpDir, "home"))
+	skillsDir := filepath.Join(tmpDir, "skills")
+	util.WriteFile(t, filepath.Join(skillsDir, "lint.md"), "lint")
+
+	fp, err := FingerprintDir(skillsDir, nil)
+	util.AssertNoError(t, err)
+	c, err := NewDirCache("skills-test")
+	util.AssertNoError(t, err)
+	c.Set("key", fp, []model.Skill{{Name: "lint"}})
+
+	util.AssertEqual(t, c.Size(), 0)
+}
+
+func TestListAndRemove(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", tmpDir)
+
+	plugins, err := New("plugins")
+	util.AssertNoError(t, err)
+	plugins.Set("lint", model.Skill{Name: "lint"})
+	plugins.Set("fmt", model.Skill{Name: "fmt"})
+	util.AssertNoError(t, plugins.Save())
+
+	skillsDir := filepath.Join(tmpDir, "skills")
+	util.WriteFile(t, filepath.Join(skillsDir, "lint.md"), "lint")
+	ageTree(t, skillsDir)
+	fp, err := FingerprintDir(skillsDir, nil)
+	util.AssertNoError(t, err)
+	dirs, err := NewDirCache("skills-cursor")
+	util.AssertNoError(t, err)
+	dirs.Set(skillsDir, fp, []model.Skill{{Name: "lint"}})
+	util.AssertNoError(t, dirs.Save())
+
+	infos, err := List()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(infos), 2)
+	util.AssertEqual(t, infos[0].Name, "plugins")
+	util.AssertEqual(t, infos[0].Entries, 2)
+	util.AssertEqual(t, infos[1].Name, "skills-cursor")
+	util.AssertEqual(t, infos[1].Entries, 1)
+
+	util.AssertNoError(t, Remove("plugins"))
+	util.AssertNoError(t, Remove("missing"))
+	if err := Remove("../config"); err == nil {
+		t.Error("expected error for a name outside the cache directory")
+	}
+
+	infos, err = List()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(infos), 1)
+}
+
+func TestFileCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tmpDir, "home"))
+	skillFile := filepath.Join(tmpDir, "lint.md")
+
+	tests := map[string]struct {
+		// fresh caches the file while it is within the racy window
+		fresh     bool
+		change    func()
+		wantParse bool
+	}{
+		"unchanged": {
+			change: func() {},
+		},
+		"touched": {
+			change: func() {
+				now := time.Now().Add(-time.Hour)
+				util.AssertNoError(t, os.Chtimes(skillFile, now, now))
+			},
+		},
+		"changed": {
+			change: func() {
+				util.WriteFile(t, skillFile, "changed lint")
+				ageTree(t, tmpDir)
+			},
+			wantParse: true,
+		},
+		"changed within the racy window": {
+			fresh: true,
+			change: func() {
+				// Same size and modification time, different content
+				info, err := os.Stat(skillFile)
+				util.AssertNoError(t, err)
+				util.WriteFile(t, skillFile, "LINT")
+				util.AssertNoError(t, os.Chtimes(skillFile, info.ModTime(), info.ModTime()))
+			},
+			wantParse: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.WriteFile(t, skillFile, "lint")
+			if !tt.fresh {
+				ageTree(t, tmpDir)
+			}
+			util.AssertNoError(t, Remove("files-test"))
+
+			parses := 0
+			parse := func() ([]model.Skill, error) {
+				parses++
+				return []model.Skill{{Name: "lint", Path: skillFile, Frontmatter: "name: lint"}}, nil
+			}
+			c, err := NewFileCache("files-test")
+			util.AssertNoError(t, err)
+			_, err = c.Parse("key", skillFile, parse)
+			util.AssertNoError(t, err)
+			util.AssertNoError(t, c.Save())
+
+			tt.change()
+			loaded, err := NewFileCache("files-test")
+			util.AssertNoError(t, err)
+			skills, err := loaded.Parse("key", skillFile, parse)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(skills), 1)
+			util.AssertEqual(t, skills[0].Frontmatter, "name: lint")
+			util.AssertEqual(t, parses == 2, tt.wantParse)
+		})
+	}
+}
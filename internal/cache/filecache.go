@@ -0,0 +1,219 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// FileEntry is the cached parse of one skill file.
+type FileEntry struct {
+	Path     string    `json:"path"`
+	ModTime  time.Time `json:"mod_time"`
+	Size     int64     `json:"size"`
+	Hash     string    `json:"hash"`
+	CachedAt time.Time `json:"cached_at"`
+	// Skills holds the encoded skills, decoded afresh on every hit so callers
+	// never share maps or slices with the cache
+	Skills json.RawMessage `json:"skills"`
+}
+
+// FileCache caches the skills parsed from individual files, so discovery
+// only parses the files that changed since the last run. A file is unchanged
+// while its modification time and size are, or failing that, its SHA256. It
+// is safe for concurrent use and locks its file while saving.
+type FileCache struct {
+	Version string                `json:"version"`
+	Entries map[string]*FileEntry `json:"entries"`
+	path    string
+	dirty   bool
+	mu      sync.Mutex
+}
+
+// NewFileCache creates or loads the file cache with the given name.
+func NewFileCache(name string) (*FileCache, error) {
+	cacheDir := util.SkillsyncCachePath()
+	if !util.NoPersist() {
+		if err := util.EnsureDataDir(cacheDir, 0o750); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &FileCache{
+		Version: cacheVersion,
+		Entries: make(map[string]*FileEntry),
+		path:    filepath.Join(cacheDir, name+".json"),
+	}
+	// #nosec G304 - the path is constructed from trusted configuration path
+	if data, err := os.ReadFile(c.path); err == nil {
+		var stored struct {
+			Version any                   `json:"version"`
+			Entries map[string]*FileEntry `json:"entries"`
+		}
+		switch err := json.Unmarshal(data, &stored); {
+		case err != nil || stored.Entries == nil:
+			logging.Info("discarding unreadable cache", logging.Path(c.path), logging.Err(err))
+		case stored.Version != cacheVersion:
+			logging.Debug("discarding cache of another version", logging.Path(c.path))
+		default:
+			c.Entries = stored.Entries
+		}
+	}
+	return c, nil
+}
+
+// Parse returns the skills parse yields for the file at path, reusing those
+// cached under key while the file is unchanged and the entry is no older
+// than the policy's SkillsTTL. Parse errors are not cached.
+func (c *FileCache) Parse(key, path string, parse func() ([]model.Skill, error)) ([]model.Skill, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return parse()
+	}
+
+	c.mu.Lock()
+	entry, ok := c.Entries[key]
+	if ok && Expired(entry.CachedAt, CurrentPolicy().SkillsTTL) {
+		ok = false
+	}
+	// A file changed within RacyWindow of being cached may have changed
+	// again without its modification time moving, so only its hash is
+	// trusted.
+	if ok && entry.ModTime.Equal(info.ModTime()) && entry.Size == info.Size() &&
+		entry.CachedAt.Sub(entry.ModTime) >= RacyWindow {
+		raw := entry.Skills
+		c.mu.Unlock()
+		if skills, err := decodeSkills(raw); err == nil {
+			return skills, nil
+		}
+		return c.parseAndStore(key, path, info, "", parse)
+	}
+	c.mu.Unlock()
+
+	// #nosec G304 - path was discovered by a parser
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return parse()
+	}
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	if ok && entry.Hash == hash {
+		skills, err := decodeSkills(entry.Skills)
+		if err == nil {
+			// Verified by content: remember the new modification time, and
+			// trust it from now on
+			c.mu.Lock()
+			entry.ModTime, entry.Size, entry.CachedAt = info.ModTime(), info.Size(), time.Now()
+			c.dirty = true
+			c.mu.Unlock()
+			for i := range skills {
+				if skills[i].Path == path {
+					skills[i].ModifiedAt = info.ModTime()
+				}
+			}
+			return skills, nil
+		}
+	}
+	return c.parseAndStore(key, path, info, hash, parse)
+}
+
+// parseAndStore parses the file and caches the result under key. An empty
+// hash is computed from the file.
+func (c *FileCache) parseAndStore(key, path string, info os.FileInfo, hash string, parse func() ([]model.Skill, error)) ([]model.Skill, error) {
+	skills, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	if hash == "" {
+		// #nosec G304 - path was discovered by a parser
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return skills, nil
+		}
+		sum := sha256.Sum256(content)
+		hash = hex.EncodeToString(sum[:])
+	}
+	raw, err := encodeSkills(skills)
+	if err != nil {
+		return skills, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Entries[key] = &FileEntry{
+		Path:     path,
+		ModTime:  info.ModTime(),
+		Size:     info.Size(),
+		Hash:     hash,
+		CachedAt: time.Now(),
+		Skills:   raw,
+	}
+	c.dirty = true
+	return skills, nil
+}
+
+// Save persists the cache to disk if it changed since it was loaded,
+// dropping the entries of files that no longer exist.
+func (c *FileCache) Save() error {
+	if util.NoPersist() {
+		return nil
+	}
+	c.mu.Lock()
+	if !c.dirty {
+		c.mu.Unlock()
+		return nil
+	}
+	for key, entry := range c.Entries {
+		if _, err := os.Stat(entry.Path); errors.Is(err, os.ErrNotExist) {
+			delete(c.Entries, key)
+		}
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	c.dirty = false
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+	return writeFile(c.path, data)
+}
+
+// Size returns the number of files in the cache.
+func (c *FileCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.Entries)
+}
+
+// encodeSkills encodes skills with their frontmatter.
+func encodeSkills(skills []model.Skill) (json.RawMessage, error) {
+	cached := make([]cachedSkill, len(skills))
+	for i, skill := range skills {
+		cached[i] = cachedSkill{Skill: skill, Frontmatter: skill.Frontmatter}
+	}
+	return json.Marshal(cached)
+}
+
+// decodeSkills decodes skills encoded by encodeSkills.
+func decodeSkills(raw json.RawMessage) ([]model.Skill, error) {
+	var cached []cachedSkill
+	if err := json.Unmarshal(raw, &cached); err != nil {
+		return nil, err
+	}
+	skills := make([]model.Skill, len(cached))
+	for i, cs := range cached {
+		skills[i] = cs.Skill
+		skills[i].Frontmatter = cs.Frontmatter
+	}
+	return skills, nil
+}
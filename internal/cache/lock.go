@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// lockTimeout is how long to wait for another process to release a cache lock.
+	lockTimeout = 5 * time.Second
+	// lockRetryInterval is how often a held lock is retried.
+	lockRetryInterval = 20 * time.Millisecond
+	// staleLockAge is the age after which a lock is assumed to have been left
+	// behind by a crashed process and is broken.
+	staleLockAge = 30 * time.Second
+)
+
+// errLockTimeout is returned when a cache lock cannot be acquired in time.
+var errLockTimeout = errors.New("timed out waiting for cache lock")
+
+// fileLock is an advisory lock held by creating a file exclusively. It works
+// across processes on every platform, unlike flock.
+type fileLock struct {
+	path string
+}
+
+// acquireLock creates the lock file at path, waiting up to lockTimeout for
+// another holder to release it.
+func acquireLock(path string) (*fileLock, error) {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		// #nosec G304 - path is constructed from the trusted cache directory
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, _ = f.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+			_ = f.Close()
+			return &fileLock{path: path}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create cache lock: %w", err)
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w %s", errLockTimeout, path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// release removes the lock file.
+func (l *fileLock) release() {
+	_ = os.Remove(l.path)
+}
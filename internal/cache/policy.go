@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Policy controls whether caches are used and how long their entries live.
+type Policy struct {
+	// Enabled turns the skill and plugin caches on
+	Enabled bool
+	// SkillsTTL is how long a directory's parsed skills are reused, even
+	// while the directory is unchanged; 0 means no limit
+	SkillsTTL time.Duration
+	// PluginsTTL is how long discovered plugin skills are reused; 0 means
+	// no limit
+	PluginsTTL time.Duration
+	// Incremental caches the parse of each skill file, so a changed
+	// directory only re-parses the files that changed
+	Incremental bool
+}
+
+// DefaultPolicy returns the default cache policy.
+func DefaultPolicy() Policy {
+	return Policy{
+		Enabled:     true,
+		SkillsTTL:   DefaultTTL,
+		PluginsTTL:  DefaultTTL,
+		Incremental: true,
+	}
+}
+
+var policy atomic.Pointer[Policy]
+
+func init() {
+	SetPolicy(DefaultPolicy())
+}
+
+// SetPolicy sets the policy used by every later cache lookup.
+func SetPolicy(p Policy) {
+	policy.Store(&p)
+}
+
+// CurrentPolicy returns the cache policy in effect.
+func CurrentPolicy() Policy {
+	return *policy.Load()
+}
+
+// Expired reports whether an entry cached at cachedAt is older than ttl.
+// A ttl of 0 never expires.
+func Expired(cachedAt time.Time, ttl time.Duration) bool {
+	return ttl > 0 && time.Since(cachedAt) > ttl
+}
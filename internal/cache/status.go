@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// Info describes one cache file.
+type Info struct {
+	Name    string    `json:"name"`
+	Path    string    `json:"path"`
+	Entries int       `json:"entries"`
+	Size    int64     `json:"size"`
+	Oldest  time.Time `json:"oldest,omitzero"`
+	Newest  time.Time `json:"newest,omitzero"`
+}
+
+// List returns the cache files in the cache directory, sorted by name.
+func List() ([]Info, error) {
+	cacheDir := util.SkillsyncCachePath()
+	files, err := os.ReadDir(cacheDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var infos []Info
+	for _, file := range files {
+		name, ok := strings.CutSuffix(file.Name(), ".json")
+		if !ok || file.IsDir() {
+			continue
+		}
+		path := filepath.Join(cacheDir, file.Name())
+		fileInfo, err := file.Info()
+		if err != nil {
+			continue
+		}
+		info := Info{Name: name, Path: path, Size: fileInfo.Size()}
+
+		// Both kinds of cache record when each entry was cached
+		var stored struct {
+			Entries map[string]struct {
+				CachedAt time.Time `json:"cached_at"`
+			} `json:"entries"`
+		}
+		// #nosec G304 - path is in the trusted cache directory
+		if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &stored) == nil {
+			info.Entries = len(stored.Entries)
+			for _, entry := range stored.Entries {
+				if info.Oldest.IsZero() || entry.CachedAt.Before(info.Oldest) {
+					info.Oldest = entry.CachedAt
+				}
+				if entry.CachedAt.After(info.Newest) {
+					info.Newest = entry.CachedAt
+				}
+			}
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos, nil
+}
+
+// Remove deletes the named cache file. Removing a cache that does not exist
+// is not an error.
+func Remove(name string) error {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid cache name %q", name)
+	}
+	err := removeFile(filepath.Join(util.SkillsyncCachePath(), name+".json"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove cache %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// walkCacheDir is the directory, relative to a repository root, that holds
+// the repository's walk cache. It is ignored by git.
+const walkCacheDir = ".skillsync/cache"
+
+// walkCacheFileName is the name of the walk cache in walkCacheDir.
+const walkCacheFileName = "discovery.json"
+
+// walkCacheMu serializes reads and writes of walk caches within the process;
+// the file lock taken while saving covers other processes.
+var walkCacheMu sync.Mutex
+
+// WalkCache caches the directory walks of discovery inside one git
+// repository, in the repository's own .skillsync/cache directory, so
+// repo-scope discovery only walks again after a directory changed.
+type WalkCache struct {
+	root string
+	path string
+}
+
+// Walk is the result of one walk. Paths are relative to the repository root,
+// so the cache survives the checkout moving.
+type Walk struct {
+	// Dirs maps each directory walked to its modification time
+	Dirs map[string]time.Time `json:"dirs"`
+	// Matches are the files the walk found
+	Matches []string `json:"matches"`
+}
+
+// walkCacheFile is the stored form of a walk cache.
+type walkCacheFile struct {
+	Version string          `json:"version"`
+	Walks   map[string]Walk `json:"walks"`
+}
+
+// OpenWalkCache returns the walk cache of the git repository of the working
+// directory when dir is inside it, as repo-scope search paths are, or nil
+// otherwise or when --no-persist is set. A nil cache finds nothing and
+// stores nothing.
+func OpenWalkCache(dir string) *WalkCache {
+	if util.NoPersist() {
+		return nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	root := util.GetRepoRoot(wd)
+	if root == "" {
+		return nil
+	}
+	c := &WalkCache{root: root, path: filepath.Join(root, filepath.FromSlash(walkCacheDir), walkCacheFileName)}
+	if rel, ok := c.rel(dir); !ok || rel == ".." || strings.HasPrefix(rel, "../") {
+		return nil
+	}
+	return c
+}
+
+// Lookup returns the files of the walk of baseDir cached under query, if no
+// directory it walked has changed since. query identifies what the walk
+// matched and anything else that changes what it finds.
+func (c *WalkCache) Lookup(baseDir, query string) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+	key, ok := c.key(baseDir, query)
+	if !ok {
+		return nil, false
+	}
+
+	walkCacheMu.Lock()
+	walk, ok := c.load().Walks[key]
+	walkCacheMu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	for dir, modTime := range walk.Dirs {
+		info, err := os.Stat(c.abs(dir))
+		if err != nil || !info.IsDir() || !info.ModTime().Equal(modTime) {
+			logging.Debug("walk cache is stale", logging.Path(c.abs(dir)))
+			return nil, false
+		}
+	}
+
+	matches := make([]string, len(walk.Matches))
+	for i, match := range walk.Matches {
+		matches[i] = c.abs(match)
+	}
+	logging.Debug("discovery: using repository cache", logging.Path(baseDir), logging.Count(len(matches)))
+	return matches, true
+}
+
+// Store caches the walk of baseDir under query: the modification time of
+// each directory walked and the files found. Walks of a directory changed
+// within RacyWindow are not stored.
+func (c *WalkCache) Store(baseDir, query string, dirs map[string]time.Time, matches []string) {
+	if c == nil {
+		return
+	}
+	key, ok := c.key(baseDir, query)
+	if !ok {
+		return
+	}
+
+	walk := Walk{Dirs: make(map[string]time.Time, len(dirs)), Matches: make([]string, 0, len(matches))}
+	racy := time.Now().Add(-RacyWindow)
+	for dir, modTime := range dirs {
+		if modTime.After(racy) {
+			return
+		}
+		rel, ok := c.rel(dir)
+		if !ok {
+			return
+		}
+		walk.Dirs[rel] = modTime
+	}
+	for _, match := range matches {
+		rel, ok := c.rel(match)
+		if !ok {
+			return
+		}
+		walk.Matches = append(walk.Matches, rel)
+	}
+
+	walkCacheMu.Lock()
+	defer walkCacheMu.Unlock()
+	file := c.load()
+	file.Walks[key] = walk
+	if err := c.save(file); err != nil {
+		logging.Warn("failed to save walk cache", logging.Path(c.path), logging.Err(err))
+	}
+}
+
+// key identifies the walk of baseDir under query.
+func (c *WalkCache) key(baseDir, query string) (string, bool) {
+	rel, ok := c.rel(baseDir)
+	if !ok {
+		return "", false
+	}
+	return rel + "|" + query, true
+}
+
+// load reads the cache file. A missing file, or one of another version or
+// that cannot be parsed, yields an empty cache.
+func (c *WalkCache) load() walkCacheFile {
+	empty := walkCacheFile{Version: cacheVersion, Walks: make(map[string]Walk)}
+	// #nosec G304 - the path is built from the repository root
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return empty
+	}
+	var stored struct {
+		Version any             `json:"version"`
+		Walks   map[string]Walk `json:"walks"`
+	}
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Walks == nil {
+		logging.Info("discarding unreadable cache", logging.Path(c.path), logging.Err(err))
+		return empty
+	}
+	if stored.Version != cacheVersion {
+		logging.Debug("discarding cache of another version", logging.Path(c.path))
+		return empty
+	}
+	return walkCacheFile{Version: cacheVersion, Walks: stored.Walks}
+}
+
+// save replaces the cache file, creating the cache directory with a
+// .gitignore that keeps it out of the repository.
+func (c *WalkCache) save(file walkCacheFile) error {
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	gitignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(gitignore); os.IsNotExist(err) {
+		// #nosec G306 - .gitignore is meant to be read by git and the user
+		if err := os.WriteFile(gitignore, []byte("*\n"), 0o644); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	return writeFile(c.path, data)
+}
+
+// rel returns path relative to the repository root, with forward slashes.
+func (c *WalkCache) rel(path string) (string, bool) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(c.root, abs)
+	if err != nil {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// abs returns the absolute path of a path relative to the repository root.
+func (c *WalkCache) abs(rel string) string {
+	return filepath.Join(c.root, filepath.FromSlash(rel))
+}
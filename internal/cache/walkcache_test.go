@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestWalkCache(t *testing.T) {
+	repo := t.TempDir()
+	util.AssertNoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o750))
+	skills := filepath.Join(repo, "skills")
+	lint := filepath.Join(skills, "lint", "SKILL.md")
+	util.WriteFile(t, lint, "lint")
+	ageTree(t, skills)
+	t.Chdir(repo)
+
+	dirs := func() map[string]time.Time {
+		t.Helper()
+		info, err := os.Stat(skills)
+		util.AssertNoError(t, err)
+		return map[string]time.Time{skills: info.ModTime()}
+	}
+	cacheFile := filepath.Join(repo, ".skillsync", "cache", "discovery.json")
+
+	c := OpenWalkCache(skills)
+	if c == nil {
+		t.Fatal("OpenWalkCache() = nil inside the repository")
+	}
+	c.Store(skills, "**/SKILL.md", dirs(), []string{lint})
+
+	matches, ok := OpenWalkCache(skills).Lookup(skills, "**/SKILL.md")
+	util.AssertEqual(t, ok, true)
+	util.AssertEqual(t, len(matches), 1)
+	util.AssertEqual(t, matches[0], lint)
+	_, ok = OpenWalkCache(skills).Lookup(skills, "**/*.md")
+	util.AssertEqual(t, ok, false)
+	data, err := os.ReadFile(filepath.Join(repo, ".skillsync", "cache", ".gitignore"))
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), "*\n")
+
+	t.Run("changed directory", func(t *testing.T) {
+		now := time.Now()
+		util.AssertNoError(t, os.Chtimes(skills, now, now))
+		t.Cleanup(func() { ageTree(t, skills) })
+		_, ok := OpenWalkCache(skills).Lookup(skills, "**/SKILL.md")
+		util.AssertEqual(t, ok, false)
+
+		// A walk of a directory changed just now is not stored
+		OpenWalkCache(skills).Store(skills, "**/*.md", dirs(), []string{lint})
+		_, ok = OpenWalkCache(skills).Lookup(skills, "**/*.md")
+		util.AssertEqual(t, ok, false)
+	})
+
+	t.Run("other version", func(t *testing.T) {
+		util.WriteFile(t, cacheFile, `{"version": 1, "walks": {"skills|**/SKILL.md": {"dirs": {}, "matches": []}}}`)
+		_, ok := OpenWalkCache(skills).Lookup(skills, "**/SKILL.md")
+		util.AssertEqual(t, ok, false)
+	})
+
+	t.Run("outside the repository", func(t *testing.T) {
+		if c := OpenWalkCache(t.TempDir()); c != nil {
+			t.Errorf("OpenWalkCache() = %v outside the repository, want nil", c)
+		}
+	})
+
+	t.Run("no persist", func(t *testing.T) {
+		util.SetNoPersist(true)
+		t.Cleanup(func() { util.SetNoPersist(false) })
+		if c := OpenWalkCache(skills); c != nil {
+			t.Errorf("OpenWalkCache() = %v with --no-persist, want nil", c)
+		}
+	})
+}
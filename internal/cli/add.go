@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+)
+
+// maxDerivedDescription is the length at which a description taken from the
+// first line of the content is cut off.
+const maxDerivedDescription = 120
+
+func addCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "add",
+		Usage: "Create a skill from content piped on stdin",
+		UsageText: `skillsync add --name <name> [options] < content.md
+   cat prompt.md | skillsync add --name quick-fix
+   pbpaste | skillsync add --name review-checklist --platform cursor:repo`,
+		Description: `Capture a prompt as a skill without opening an editor. The content read
+   from stdin is wrapped in frontmatter and written as <name>/SKILL.md in the
+   platform's skills directory for the chosen scope (user by default).
+
+   If the content already has frontmatter, its fields are kept and name (and
+   description, when --description is given) are replaced. Without a
+   description, the first line of the content is used.
+
+   The result must parse as a skill with a valid name and satisfy the
+   frontmatter schema at validation.schema_path, if one is configured.
+   Existing skills are never overwritten unless --force is given.
+
+   Examples:
+     cat prompt.md | skillsync add --name quick-fix
+     skillsync add --name quick-fix --platform claudecode:user < prompt.md
+     pbpaste | skillsync add -n triage -d "Triage a failing test" -p codex:repo
+     cat prompt.md | skillsync add --name quick-fix --dry-run   # Print the skill`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Aliases:  []string{"n"},
+				Required: true,
+				Usage:    "Name of the new skill",
+			},
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Value:   string(model.ClaudeCode),
+				Usage:   "Platform and scope to write to, as platform[:scope] (scope is repo or user, default user)",
+			},
+			&cli.StringFlag{
+				Name:    "description",
+				Aliases: []string{"d"},
+				Usage:   "Skill description (default: the first line of the content)",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Overwrite an existing skill",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the skill without writing it",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return runAdd(cmd)
+		},
+	}
+}
+
+func runAdd(cmd *cli.Command) error {
+	name := cmd.String("name")
+	if err := validateNewSkillName(name); err != nil {
+		return err
+	}
+
+	spec, err := model.ParsePlatformSpec(cmd.String("platform"))
+	if err != nil {
+		return fmt.Errorf("invalid platform: %w", err)
+	}
+	if len(spec.Scopes) > 1 {
+		return fmt.Errorf("add writes to a single scope, got %s", spec)
+	}
+	scope := model.ScopeUser
+	if len(spec.Scopes) == 1 {
+		scope = spec.Scopes[0]
+	}
+	if scope != model.ScopeRepo && scope != model.ScopeUser {
+		return fmt.Errorf("scope %q is not writable (only repo and user are supported)", scope)
+	}
+
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New("add reads the skill content from stdin (e.g. cat prompt.md | skillsync add --name " + name + ")")
+	}
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read stdin: %w", err)
+	}
+	if len(bytes.TrimSpace(input)) == 0 {
+		return errors.New("no skill content on stdin")
+	}
+
+	content, err := wrapSkillContent(input, name, cmd.String("description"))
+	if err != nil {
+		return err
+	}
+	if err := validateRenderedSkill(content, name, spec.Platform); err != nil {
+		return err
+	}
+	if err := validateAddSchema(content, name); err != nil {
+		return err
+	}
+
+	targetPath, err := getSkillPathForScope(spec.Platform, scope, name)
+	if err != nil {
+		return fmt.Errorf("failed to determine target path: %w", err)
+	}
+
+	if cmd.Bool("dry-run") {
+		fmt.Printf("Would create %s\n\n", targetPath)
+		fmt.Print(string(content))
+		return nil
+	}
+
+	if _, err := os.Stat(targetPath); err == nil && !cmd.Bool("force") {
+		return fmt.Errorf("skill already exists at %s (use --force to overwrite)", targetPath)
+	}
+
+	// #nosec G301 - skill directories need to be readable by the platform
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0o750); err != nil {
+		return fmt.Errorf("failed to create skill directory: %w", err)
+	}
+	// #nosec G306 - skill files should be readable
+	if err := os.WriteFile(targetPath, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write skill: %w", err)
+	}
+
+	fmt.Printf("✓ Added %s skill %q at %s\n", spec.Platform, name, targetPath)
+	return nil
+}
+
+// wrapSkillContent puts frontmatter with name and description in front of
+// input. Frontmatter already in input is kept, with its name replaced and its
+// description replaced when one is given.
+func wrapSkillContent(input []byte, name, description string) ([]byte, error) {
+	split := parser.SplitFrontmatter(input)
+	body := strings.TrimLeft(split.Content, "\r\n")
+
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	if split.HasFrontmatter {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(split.Frontmatter, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse frontmatter on stdin: %w", err)
+		}
+		if len(doc.Content) > 0 {
+			if doc.Content[0].Kind != yaml.MappingNode {
+				return nil, errors.New("frontmatter on stdin is not a mapping")
+			}
+			mapping = doc.Content[0]
+		}
+	}
+
+	scalar := func(v string) *yaml.Node { return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v} }
+	existingDescription := ""
+	var rest []*yaml.Node
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		switch key.Value {
+		case "name":
+			continue
+		case "description":
+			existingDescription = value.Value
+			continue
+		}
+		rest = append(rest, key, value)
+	}
+	if description == "" {
+		description = existingDescription
+	}
+	if description == "" {
+		description = describeFromContent(body)
+	}
+	mapping.Content = append([]*yaml.Node{scalar("name"), scalar(name), scalar("description"), scalar(description)}, rest...)
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(mapping); err != nil {
+		return nil, fmt.Errorf("failed to write frontmatter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write frontmatter: %w", err)
+	}
+	buf.WriteString("---\n\n")
+	buf.WriteString(body)
+	if !strings.HasSuffix(body, "\n") {
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// describeFromContent returns the first line of body with heading markers
+// removed, cut off at maxDerivedDescription characters.
+func describeFromContent(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if line == "" || strings.HasPrefix(line, "```") {
+			continue
+		}
+		if utf8.RuneCountInString(line) > maxDerivedDescription {
+			line = string([]rune(line)[:maxDerivedDescription-1]) + "…"
+		}
+		return line
+	}
+	return "Captured from stdin"
+}
+
+// validateAddSchema checks the new skill against the configured frontmatter schema.
+func validateAddSchema(content []byte, name string) error {
+	appConfig, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	schema, err := loadFrontmatterSchema(appConfig)
+	if err != nil || schema == nil {
+		return err
+	}
+	fields, err := parser.ParseYAMLFrontmatter(parser.SplitFrontmatter(content).Frontmatter)
+	if err != nil {
+		return err
+	}
+	if violations := schema.Validate(fields); len(violations) > 0 {
+		return fmt.Errorf("skill %q does not match %s: %s", name, schema.Path(), strings.Join(violations, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser/skills"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// withStdin replaces os.Stdin with a file holding content for the rest of the test.
+func withStdin(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	util.WriteFile(t, path, content)
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open stdin file: %v", err)
+	}
+	old := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = old
+		_ = f.Close()
+	})
+}
+
+func TestWrapSkillContent(t *testing.T) {
+	tests := map[string]struct {
+		input       string
+		description string
+		want        string
+	}{
+		"plain content": {
+			input: "# Fix the flaky test\n\nRerun it with -count=10.",
+			want:  "---\nname: quick-fix\ndescription: Fix the flaky test\n---\n\n# Fix the flaky test\n\nRerun it with -count=10.\n",
+		},
+		"description flag": {
+			input:       "Rerun it.\n",
+			description: "Deflake tests",
+			want:        "---\nname: quick-fix\ndescription: Deflake tests\n---\n\nRerun it.\n",
+		},
+		"existing frontmatter is kept": {
+			input: "---\nname: old\ndescription: Existing\ntools: [Read]\n---\nBody\n",
+			want:  "---\nname: quick-fix\ndescription: Existing\ntools: [Read]\n---\n\nBody\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := wrapSkillContent([]byte(tt.input), "quick-fix", tt.description)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, string(got), tt.want)
+		})
+	}
+}
+
+func TestAddCommand(t *testing.T) {
+	home := t.TempDir()
+	userSkills := filepath.Join(home, "claude-skills")
+	t.Setenv("SKILLSYNC_HOME", home)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", userSkills)
+	ctx := context.Background()
+
+	t.Run("writes the piped content", func(t *testing.T) {
+		withStdin(t, "Rerun the failing test with -count=10.\n")
+		if err := Run(ctx, []string{"skillsync", "add", "--name", "quick-fix", "--platform", "claudecode:user"}); err != nil {
+			t.Fatalf("add failed: %v", err)
+		}
+		skill, err := skills.ParseSkillFile(filepath.Join(userSkills, "quick-fix", "SKILL.md"), model.ClaudeCode)
+		if err != nil {
+			t.Fatalf("added skill does not parse: %v", err)
+		}
+		util.AssertEqual(t, skill.Description, "Rerun the failing test with -count=10.")
+	})
+
+	t.Run("refuses to overwrite without force", func(t *testing.T) {
+		withStdin(t, "Something else\n")
+		if err := Run(ctx, []string{"skillsync", "add", "--name", "quick-fix"}); err == nil {
+			t.Error("expected error for existing skill")
+		}
+	})
+
+	t.Run("empty stdin", func(t *testing.T) {
+		withStdin(t, "\n\n")
+		err := Run(ctx, []string{"skillsync", "add", "--name", "empty"})
+		if err == nil || !strings.Contains(err.Error(), "no skill content") {
+			t.Errorf("expected empty stdin error, got %v", err)
+		}
+	})
+
+	t.Run("non-writable scope", func(t *testing.T) {
+		withStdin(t, "Body\n")
+		if err := Run(ctx, []string{"skillsync", "add", "--name", "x", "--platform", "codex:admin"}); err == nil {
+			t.Error("expected error for admin scope")
+		}
+	})
+
+	t.Run("frontmatter schema", func(t *testing.T) {
+		util.WriteFile(t, config.FilePath(), "validation:\n  schema_path: schema.json\n")
+		util.WriteFile(t, filepath.Join(filepath.Dir(config.FilePath()), "schema.json"), `{"required": ["owner"]}`)
+		t.Cleanup(func() { _ = os.Remove(config.FilePath()) })
+
+		withStdin(t, "Body\n")
+		err := Run(ctx, []string{"skillsync", "add", "--name", "unowned"})
+		if err == nil || !strings.Contains(err.Error(), "owner") {
+			t.Errorf("expected schema error, got %v", err)
+		}
+
+		withStdin(t, "---\nowner: platform\n---\nBody\n")
+		if err := Run(ctx, []string{"skillsync", "add", "--name", "owned"}); err != nil {
+			t.Errorf("add with owner failed: %v", err)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/browse"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func browseCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "browse",
+		Usage: "Browse skills in a read-only local web UI",
+		UsageText: `skillsync browse [options]
+   skillsync browse
+   skillsync browse --addr 127.0.0.1:9000
+   skillsync browse --include-plugins`,
+		Description: `Start a local web server for exploring your skill inventory.
+
+   The UI lists every discovered skill with search and platform filters, renders
+   skill Markdown, shows diffs between copies of a skill across platforms or
+   scopes, lists skills with similar content, and shows backup history.
+
+   The server is read-only and reloads skills on every request, so edits made
+   on disk show up on refresh. It listens on localhost by default; press
+   Ctrl+C to stop it.
+
+   Examples:
+     skillsync browse                        # Serve on http://127.0.0.1:7420
+     skillsync browse --addr 127.0.0.1:9000  # Use another port`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "addr",
+				Value: "127.0.0.1:7420",
+				Usage: "Address to listen on",
+			},
+			&cli.BoolFlag{
+				Name:  "include-plugins",
+				Usage: "Include Claude Code plugin skills",
+			},
+			&cli.FloatFlag{
+				Name:  "threshold",
+				Value: 0.6,
+				Usage: "Content similarity threshold for listing similar skills (0.0-1.0)",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runBrowse(ctx, cmd)
+		},
+	}
+}
+
+func runBrowse(ctx context.Context, cmd *cli.Command) error {
+	includePlugins := cmd.Bool("include-plugins")
+	server, err := browse.New(browse.Options{
+		Skills: func() ([]model.Skill, error) {
+			return discoverBrowseSkills(includePlugins)
+		},
+		Backups:             func() ([]backup.Metadata, error) { return backup.ListBackups("") },
+		SimilarityThreshold: cmd.Float("threshold"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return listenAndServe(ctx, cmd.String("addr"), server, "Serving skills at http://%s (read-only, Ctrl+C to stop)\n")
+}
+
+// listenAndServe serves handler on addr until ctx is done or Ctrl+C is
+// pressed, announcing the listening address with banner.
+func listenAndServe(ctx context.Context, addr string, handler http.Handler, banner string) error {
+	if host, _, err := net.SplitHostPort(addr); err == nil && !isLoopbackHost(host) {
+		stderrWarnf("Warning: %s is not a loopback address; skills will be visible to other machines\n", addr)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	httpServer := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	out.Printf(banner, listener.Addr())
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server failed: %w", err)
+	}
+	return nil
+}
+
+// discoverBrowseSkills discovers skills across all platforms and scopes.
+// Platforms that fail to parse are skipped so one bad directory does not
+// take the whole UI down.
+func discoverBrowseSkills(includePlugins bool) ([]model.Skill, error) {
+	var all []model.Skill
+	for _, platform := range model.AllPlatforms() {
+		skills, err := parsePlatformSkillsWithScope(platform, nil, includePlugins)
+		if err != nil {
+			stderrWarnf("Warning: failed to parse %s: %v\n", platform, err)
+			continue
+		}
+		all = append(all, skills...)
+	}
+	return all, nil
+}
+
+// isLoopbackHost reports whether host refers to the local machine only.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,26 @@
+package cli
+
+import "testing"
+
+func TestIsLoopbackHost(t *testing.T) {
+	tests := map[string]struct {
+		host string
+		want bool
+	}{
+		"localhost":   {host: "localhost", want: true},
+		"ipv4":        {host: "127.0.0.1", want: true},
+		"ipv6":        {host: "::1", want: true},
+		"all ipv4":    {host: "0.0.0.0", want: false},
+		"empty host":  {host: "", want: false},
+		"remote ip":   {host: "192.168.1.10", want: false},
+		"remote name": {host: "example.com", want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isLoopbackHost(tt.host); got != tt.want {
+				t.Errorf("isLoopbackHost(%q) = %v, want %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/cache"
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// pluginsCacheName is the cache of discovered plugin skills.
+const pluginsCacheName = "plugins"
+
+// filesCacheName is the cache of parsed skill files.
+const filesCacheName = "files"
+
+// skillsCacheName returns the name of the cache of a platform's parsed skills.
+func skillsCacheName(platform model.Platform) string {
+	return "skills-" + string(platform)
+}
+
+// openSkillCache returns the cache of the platform's parsed skills, or nil
+// when caching is disabled or the cache cannot be opened. Custom platforms
+// are not cached: their parsers may read more than the skills directory.
+func openSkillCache(platform model.Platform) *cache.DirCache {
+	if !cache.CurrentPolicy().Enabled || platform.IsCustom() {
+		return nil
+	}
+	skillCache, err := cache.NewDirCache(skillsCacheName(platform))
+	if err != nil {
+		logging.Warn("failed to open skill cache", logging.Platform(string(platform)), logging.Err(err))
+		return nil
+	}
+	return skillCache
+}
+
+// parseSkillsCached parses the skills of one search path with parse,
+// reusing the skills cached for it while nothing under the path changed.
+func parseSkillsCached(skillCache *cache.DirCache, path string, parse func() ([]model.Skill, error)) ([]model.Skill, error) {
+	if skillCache == nil {
+		return parse()
+	}
+
+	limits := parser.CurrentDiscoveryLimits()
+	fp, err := cache.FingerprintDir(path, limits.Ignored)
+	if err != nil {
+		logging.Debug("skill cache bypassed", logging.Path(path), logging.Err(err))
+		return parse()
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	key := fmt.Sprintf("%s|depth=%d,files=%d,ignore=%s", abs, limits.MaxDepth, limits.MaxFiles, strings.Join(limits.Ignore, ","))
+
+	if skills, ok := skillCache.Get(key, fp, cache.CurrentPolicy().SkillsTTL); ok {
+		logging.Debug("skill cache hit", logging.Path(path), logging.Count(len(skills)))
+		return skills, nil
+	}
+	skills, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	skillCache.Set(key, fp, skills)
+	return skills, nil
+}
+
+// saveSkillCache writes the skill cache, logging rather than failing when it
+// cannot be saved.
+func saveSkillCache(skillCache *cache.DirCache) {
+	if skillCache == nil {
+		return
+	}
+	if err := skillCache.Save(); err != nil {
+		logging.Warn("failed to save skill cache", logging.Err(err))
+	}
+	fileCache.Load().save()
+}
+
+// fileCache is the file cache of the current run.
+var fileCache atomic.Pointer[lazyFileCache]
+
+func init() {
+	fileCache.Store(&lazyFileCache{})
+}
+
+// lazyFileCache is the parser's file cache. It opens the cache of parsed
+// skill files the first time a file is parsed while caching is enabled and
+// incremental.
+type lazyFileCache struct {
+	once  sync.Once
+	cache *cache.FileCache
+}
+
+// resetFileCache gives the run a file cache of its own and makes it the
+// parser's.
+func resetFileCache() {
+	c := &lazyFileCache{}
+	fileCache.Store(c)
+	parser.SetFileCache(c)
+}
+
+// Parse implements parser.FileCache.
+func (c *lazyFileCache) Parse(key, path string, parse func() ([]model.Skill, error)) ([]model.Skill, error) {
+	if policy := cache.CurrentPolicy(); !policy.Enabled || !policy.Incremental {
+		return parse()
+	}
+	c.once.Do(func() {
+		fc, err := cache.NewFileCache(filesCacheName)
+		if err != nil {
+			logging.Warn("failed to open file cache", logging.Err(err))
+			return
+		}
+		c.cache = fc
+	})
+	if c.cache == nil {
+		return parse()
+	}
+	return c.cache.Parse(key, path, parse)
+}
+
+// save writes the file cache if it was opened, logging rather than failing
+// when it cannot be saved.
+func (c *lazyFileCache) save() {
+	c.once.Do(func() {})
+	if c.cache == nil {
+		return
+	}
+	if err := c.cache.Save(); err != nil {
+		logging.Warn("failed to save file cache", logging.Err(err))
+	}
+}
+
+// cacheStatusOutput is the JSON representation of cache status.
+type cacheStatusOutput struct {
+	Enabled           bool         `json:"enabled"`
+	SkillsTTLMinutes  int          `json:"skills_ttl_minutes"`
+	PluginsTTLMinutes int          `json:"plugins_ttl_minutes"`
+	Incremental       bool         `json:"incremental"`
+	Caches            []cache.Info `json:"caches"`
+}
+
+// cacheWarmOutput is the JSON representation of the skills cache warm parsed.
+type cacheWarmOutput struct {
+	Platform string `json:"platform"`
+	Skills   int    `json:"skills"`
+}
+
+func cacheCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "Inspect, clear, and warm the skill and plugin caches",
+		UsageText: `skillsync cache <command>
+   skillsync cache status
+   skillsync cache clear
+   skillsync cache clear plugins
+   skillsync cache warm`,
+		Description: `Manage the caches that spare discovery from re-parsing skills.
+
+   The parsed skills of each search path are cached per platform and
+   reused until a file or directory under the path changes, or until
+   cache.skills_ttl_minutes (60) passes. Discovered plugin skills are reused
+   for cache.plugins_ttl_minutes (60). Set cache.enabled to false, or pass
+   --no-cache to discover, to parse everything again.
+
+   When a search path changes, only its changed files are parsed again: the
+   parse of each file is cached by its modification time, size, and SHA256
+   in the "files" cache. Set cache.incremental to false to re-parse the
+   whole search path instead.
+
+   Caches live in ~/.skillsync/cache and are safe to clear at any time.`,
+		Commands: []*cli.Command{
+			{
+				Name:      "status",
+				Usage:     "Show the caches, their entries, and the cache policy",
+				UsageText: `skillsync cache status`,
+				Action: func(_ context.Context, _ *cli.Command) error {
+					return cacheStatus()
+				},
+			},
+			{
+				Name:  "clear",
+				Usage: "Remove all caches, or the named ones",
+				UsageText: `skillsync cache clear [name...]
+   skillsync cache clear
+   skillsync cache clear plugins skills-cursor`,
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					return clearCaches(cmd.Args().Slice())
+				},
+			},
+			{
+				Name:  "warm",
+				Usage: "Parse every platform's skills and plugins to fill the caches",
+				UsageText: `skillsync cache warm
+   skillsync cache warm --platform cursor`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "platform",
+						Aliases: []string{"p"},
+						Usage:   "Only warm the cache of this platform (claude-code, cursor, codex, aider)",
+					},
+				},
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					return warmCaches(strings.TrimSpace(cmd.String("platform")))
+				},
+			},
+		},
+		Action: func(_ context.Context, _ *cli.Command) error {
+			return cacheStatus()
+		},
+	}
+}
+
+// cacheStatus prints the cache policy and every cache file.
+func cacheStatus() error {
+	infos, err := cache.List()
+	if err != nil {
+		return err
+	}
+	policy := cache.CurrentPolicy()
+	result := cacheStatusOutput{
+		Enabled:           policy.Enabled,
+		SkillsTTLMinutes:  int(policy.SkillsTTL / time.Minute),
+		PluginsTTLMinutes: int(policy.PluginsTTL / time.Minute),
+		Incremental:       policy.Incremental,
+		Caches:            infos,
+	}
+	if result.Caches == nil {
+		result.Caches = []cache.Info{}
+	}
+
+	return out.Render(result, func() error {
+		state := "enabled"
+		if !policy.Enabled {
+			state = "disabled"
+		}
+		incremental := ""
+		if policy.Incremental {
+			incremental = ", incremental"
+		}
+		fmt.Printf("Caching %s (skills: %s, plugins: %s%s)\n", state, formatTTL(policy.SkillsTTL), formatTTL(policy.PluginsTTL), incremental)
+		if len(infos) == 0 {
+			fmt.Println("No caches found.")
+			return nil
+		}
+
+		fmt.Printf("\n%s %s %s %s\n",
+			ui.Header(fmt.Sprintf("%-22s", "CACHE")),
+			ui.Header(fmt.Sprintf("%-8s", "ENTRIES")),
+			ui.Header(fmt.Sprintf("%-10s", "SIZE")),
+			ui.Header("UPDATED"))
+		for _, info := range infos {
+			updated := "-"
+			if !info.Newest.IsZero() {
+				updated = info.Newest.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-22s %-8d %-10s %s\n", info.Name, info.Entries, formatSize(info.Size), updated)
+		}
+		return nil
+	})
+}
+
+// formatTTL describes a cache TTL, where 0 means entries never expire.
+func formatTTL(ttl time.Duration) string {
+	if ttl <= 0 {
+		return "no expiry"
+	}
+	return ttl.String()
+}
+
+// clearCaches removes the named caches, or every cache when names is empty.
+func clearCaches(names []string) error {
+	if len(names) == 0 {
+		infos, err := cache.List()
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			names = append(names, info.Name)
+		}
+	}
+
+	for _, name := range names {
+		if err := cache.Remove(name); err != nil {
+			return err
+		}
+	}
+
+	return out.Render(map[string][]string{"cleared": names}, func() error {
+		if len(names) == 0 {
+			fmt.Println("No caches to clear.")
+			return nil
+		}
+		fmt.Printf("✓ Cleared %d cache(s): %s\n", len(names), strings.Join(names, ", "))
+		return nil
+	})
+}
+
+// warmCaches parses the skills of every platform, or only of platformStr,
+// and the plugin skills, so later commands find them cached.
+func warmCaches(platformStr string) error {
+	if !cache.CurrentPolicy().Enabled {
+		return errors.New("caching is disabled (cache.enabled is false)")
+	}
+
+	platforms := model.AllPlatforms()
+	if platformStr != "" && platformStr != "all" {
+		platform, err := model.ParsePlatform(platformStr)
+		if err != nil {
+			return err
+		}
+		platforms = []model.Platform{platform}
+	}
+
+	warmed := make([]cacheWarmOutput, 0, len(platforms)+1)
+	for _, platform := range platforms {
+		skills, err := parsePlatformSkillVariants(platform, nil, false)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s skills: %w", platform, err)
+		}
+		warmed = append(warmed, cacheWarmOutput{Platform: string(platform), Skills: len(skills)})
+	}
+	if len(platforms) > 1 {
+		pluginSkills, err := discoverPluginSkills("", true)
+		if err != nil {
+			warnf("Warning: failed to discover plugins: %v\n", err)
+		} else {
+			warmed = append(warmed, cacheWarmOutput{Platform: pluginsCacheName, Skills: len(pluginSkills)})
+		}
+	}
+
+	return out.Render(warmed, func() error {
+		for _, w := range warmed {
+			fmt.Printf("  %-12s %d skill(s)\n", w.Platform, w.Skills)
+		}
+		fmt.Println("\n✓ Caches warmed")
+		return nil
+	})
+}
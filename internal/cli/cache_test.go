@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/cache"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// ageSkills moves the modification times under dir out of the skill cache's
+// racy window, so parsing it is cached.
+func ageSkills(t *testing.T, dir string) {
+	t.Helper()
+	old := time.Now().Add(-time.Hour)
+	err := filepath.Walk(dir, func(path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	})
+	util.AssertNoError(t, err)
+}
+
+func TestCacheCommands(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	skillFile := filepath.Join(claudeSkills, "lint", "SKILL.md")
+	util.WriteFile(t, skillFile, "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+	ageSkills(t, claudeSkills)
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "cache", "warm", "--platform", "claude-code"})
+	})
+	util.AssertNoError(t, runErr)
+	if !strings.Contains(output, "claude-code  1 skill(s)") {
+		t.Errorf("warm output missing skill count:\n%s", output)
+	}
+
+	output = captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "cache", "status"})
+	})
+	util.AssertNoError(t, runErr)
+	if !strings.Contains(output, "skills-claude-code") || !strings.Contains(output, "Caching enabled (skills: 1h0m0s") {
+		t.Errorf("status output missing the skill cache:\n%s", output)
+	}
+
+	// A cached skill is parsed again once its file changes
+	util.WriteFile(t, skillFile, "---\nname: lint\ndescription: Lint all code\n---\nRun the linter.\n")
+	ageSkills(t, claudeSkills)
+	skills, err := parsePlatformSkillsWithScope(model.ClaudeCode, nil, false)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(skills), 1)
+	util.AssertEqual(t, skills[0].Description, "Lint all code")
+
+	output = captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "cache", "clear"})
+	})
+	util.AssertNoError(t, runErr)
+	if !strings.Contains(output, "skills-claude-code") {
+		t.Errorf("clear output missing the skill cache:\n%s", output)
+	}
+	infos, err := cache.List()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(infos), 0)
+}
+
+func TestParseSkillsCached(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+	ageSkills(t, claudeSkills)
+
+	tests := map[string]struct {
+		enabled   bool
+		wantParse int
+	}{
+		"cached":   {enabled: true, wantParse: 1},
+		"disabled": {enabled: false, wantParse: 2},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SKILLSYNC_HOME", t.TempDir())
+			policy := cache.DefaultPolicy()
+			policy.Enabled = tt.enabled
+			cache.SetPolicy(policy)
+			t.Cleanup(func() { cache.SetPolicy(cache.DefaultPolicy()) })
+
+			parses := 0
+			parse := func() ([]model.Skill, error) {
+				parses++
+				return []model.Skill{{Name: "lint", Frontmatter: "name: lint"}}, nil
+			}
+			for range 2 {
+				skillCache := openSkillCache(model.ClaudeCode)
+				skills, err := parseSkillsCached(skillCache, claudeSkills, parse)
+				util.AssertNoError(t, err)
+				util.AssertEqual(t, skills[0].Frontmatter, "name: lint")
+				saveSkillCache(skillCache)
+			}
+			util.AssertEqual(t, parses, tt.wantParse)
+		})
+	}
+}
+
+func TestIncrementalDiscovery(t *testing.T) {
+	tests := map[string]struct {
+		incremental bool
+		wantFiles   int
+	}{
+		"incremental": {incremental: true, wantFiles: 2},
+		"disabled":    {incremental: false, wantFiles: 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, _ := setupStore(t)
+			lintFile := filepath.Join(claudeSkills, "lint", "SKILL.md")
+			util.WriteFile(t, lintFile, "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+			util.WriteFile(t, filepath.Join(claudeSkills, "fmt", "SKILL.md"), "---\nname: fmt\ndescription: Format code\n---\nRun the formatter.\n")
+			ageSkills(t, claudeSkills)
+
+			policy := cache.DefaultPolicy()
+			policy.Incremental = tt.incremental
+			cache.SetPolicy(policy)
+			resetFileCache()
+			t.Cleanup(func() {
+				cache.SetPolicy(cache.DefaultPolicy())
+				parser.SetFileCache(nil)
+			})
+
+			_, err := parsePlatformSkillsWithScope(model.ClaudeCode, nil, false)
+			util.AssertNoError(t, err)
+
+			// Changing one skill re-parses the directory, reusing the other's parse
+			util.WriteFile(t, lintFile, "---\nname: lint\ndescription: Lint all code\n---\nRun the linter.\n")
+			ageSkills(t, claudeSkills)
+			resetFileCache()
+			skills, err := parsePlatformSkillsWithScope(model.ClaudeCode, nil, false)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(skills), 2)
+			for _, skill := range skills {
+				if skill.Name == "lint" {
+					util.AssertEqual(t, skill.Description, "Lint all code")
+				}
+			}
+
+			files := 0
+			infos, err := cache.List()
+			util.AssertNoError(t, err)
+			for _, info := range infos {
+				if info.Name == filesCacheName {
+					files = info.Entries
+				}
+			}
+			util.AssertEqual(t, files, tt.wantFiles)
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// syncHop is one step of a chained sync such as "sync claudecode cursor
+// codex": the skills the previous step wrote to source, synced to target.
+type syncHop struct {
+	source         model.PlatformSpec
+	target         model.PlatformSpec
+	strategy       sync.Strategy
+	strategySource string // Where the strategy came from when --strategy was not given
+	maxSkills      int    // The target platform's max_skills, 0 for no limit
+}
+
+// String returns the hop as shown in the plan, e.g. "cursor:user -> codex:user".
+func (h syncHop) String() string {
+	return fmt.Sprintf("%s -> %s", h.source, h.target)
+}
+
+// chainSyncOutput is the JSON representation of one hop of a chained sync.
+type chainSyncOutput struct {
+	Source string           `json:"source"`
+	Target string           `json:"target"`
+	Result syncResultOutput `json:"result"`
+}
+
+// parseSyncChain parses the targets after the first of a chained sync. Each
+// hop syncs from the scope the previous hop wrote to, and no platform may
+// appear twice in the chain.
+func parseSyncChain(
+	cmd *cli.Command,
+	appConfig *config.Config,
+	sourceSpec model.PlatformSpec,
+	targetSpec model.PlatformSpec,
+	args []string,
+) ([]syncHop, error) {
+	seen := []model.Platform{sourceSpec.Platform, targetSpec.Platform}
+	previous := targetSpec
+	hops := make([]syncHop, 0, len(args))
+	for _, arg := range args {
+		target, err := model.ParsePlatformSpec(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target %q: %w", arg, err)
+		}
+		if err := target.ValidateAsTarget(); err != nil {
+			return nil, fmt.Errorf("invalid target %q: %w", arg, err)
+		}
+		if slices.Contains(seen, target.Platform) {
+			return nil, fmt.Errorf("%s appears more than once in the sync chain", target.Platform)
+		}
+		seen = append(seen, target.Platform)
+
+		hop := syncHop{
+			source: model.PlatformSpec{Platform: previous.Platform, Scopes: []model.SkillScope{previous.TargetScope()}},
+			target: target,
+		}
+		hop.strategy, hop.strategySource = resolveSyncStrategy(cmd, appConfig, target.TargetScope())
+		if !hop.strategy.IsValid() {
+			return nil, fmt.Errorf("invalid strategy %q for %s (valid: overwrite, skip, newer, merge, three-way, interactive)", hop.strategy, target)
+		}
+		if platformConfig, ok := appConfig.Platforms.Platform(target.Platform); ok {
+			hop.maxSkills = platformConfig.MaxSkills
+		}
+		hops = append(hops, hop)
+		previous = target
+	}
+	return hops, nil
+}
+
+// syncHops returns every hop of a chained sync, starting with source -> target.
+func syncHops(cfg *syncConfig) []syncHop {
+	first := syncHop{
+		source:         cfg.sourceSpec,
+		target:         cfg.targetSpec,
+		strategy:       cfg.strategy,
+		strategySource: cfg.strategySource,
+		maxSkills:      cfg.maxSkills,
+	}
+	return append([]syncHop{first}, cfg.chain...)
+}
+
+// runChainSync syncs the already-parsed source skills along the chain of
+// targets, each hop reading the skills the previous one wrote. The whole
+// chain is confirmed once and backed up in one session.
+func runChainSync(cfg *syncConfig) error {
+	hops := syncHops(cfg)
+	for _, hop := range hops {
+		if hop.strategy == sync.StrategyInteractive {
+			return errors.New("interactive strategy is not supported with chained targets")
+		}
+	}
+
+	if !cfg.skipValidation {
+		if err := validateSourceSkills(cfg); err != nil {
+			return err
+		}
+		for _, hop := range cfg.chain {
+			if err := validateTargetPath(hop.target.Platform); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, hop := range hops {
+		hopCfg := *cfg
+		hopCfg.targetSpec, hopCfg.maxSkills = hop.target, hop.maxSkills
+		if err := checkScopeSkillLimit(&hopCfg, hop.target.TargetScope(), cfg.sourceSkills); err != nil {
+			return err
+		}
+	}
+
+	if !cfg.dryRun && !cfg.yesFlag {
+		out.Println("\nSync chain:")
+		for i, hop := range hops {
+			line := fmt.Sprintf("  %d. %s (%s", i+1, hop, hop.strategy)
+			if hop.strategySource != "" {
+				line += ", " + hop.strategySource
+			}
+			out.Println(line + ")")
+		}
+		confirmed, err := showSyncSummaryAndConfirm(cfg)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Sync cancelled by user")
+			return nil
+		}
+	}
+
+	// One session covers every hop so the whole chain can be rolled back
+	var sessionID string
+	if !cfg.dryRun {
+		sessionID = backup.NewSessionID()
+		if !cfg.skipBackup {
+			for _, hop := range hops {
+				prepareBackup(hop.target.Platform)
+			}
+		}
+	}
+	startedAt := time.Now()
+
+	results := make([]*sync.Result, 0, len(hops))
+	failed := false
+	skills := cfg.sourceSkills
+	for i, hop := range hops {
+		if i > 0 {
+			next, err := chainHopSkills(hop, skills, results[i-1], cfg.dryRun)
+			if err != nil {
+				recordHistory(sessionID, "sync", startedAt, results...)
+				return err
+			}
+			skills = next
+		}
+
+		opts := sync.Options{
+			DryRun:            cfg.dryRun,
+			Strategy:          hop.strategy,
+			TargetScope:       hop.target.TargetScope(),
+			Concurrency:       cfg.concurrency,
+			Hooks:             cfg.hooks,
+			Backup:            sessionID != "" && !cfg.skipBackup,
+			SessionID:         sessionID,
+			TrashRetention:    cfg.trashRetention,
+			RewriteReferences: cfg.rewriteReferences,
+			Metrics:           cfg.metrics,
+			Preview:           cfg.showDiff,
+			RenameThreshold:   cfg.renameThreshold,
+			Mode:              cfg.mode,
+			Vars:              cfg.vars,
+		}
+		runStarted := time.Now()
+		progress, doneProgress := syncProgress(cfg.progress, "Syncing to "+string(hop.target.Platform), len(skills))
+		opts.Progress = progress
+		result, err := sync.New().SyncWithSkills(skills, hop.target.Platform, opts)
+		doneProgress()
+		if err != nil {
+			recordHistory(sessionID, "sync", startedAt, results...)
+			return fmt.Errorf("sync %s failed: %w", hop, err)
+		}
+		if !result.Success() {
+			failed = true
+		}
+		if i == 0 {
+			result.Skills = append(result.Skills, cfg.quarantined...)
+		}
+		cfg.recordRun(hop.String(), runStarted, result)
+		results = append(results, result)
+	}
+	recordHistory(sessionID, "sync", startedAt, results...)
+
+	outputs := make([]chainSyncOutput, 0, len(results))
+	for i, result := range results {
+		recordSyncWarnings(result)
+		outputs = append(outputs, chainSyncOutput{
+			Source: hops[i].source.String(),
+			Target: hops[i].target.String(),
+			Result: newSyncResultOutput(result),
+		})
+	}
+	err := out.Render(outputs, func() error {
+		for i, result := range results {
+			fmt.Printf("\n%s\n", ui.Header(hops[i].String()))
+			printSyncResults(result)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return errors.New("sync completed with errors")
+	}
+	return cfg.failOn.check(results...)
+}
+
+// chainHopSkills returns the skills a hop syncs: those of the previous hop,
+// as the previous hop left them in the hop's source scope. A dry run wrote
+// nothing, so skills the previous hop would have created, updated, or merged
+// are planned from what it would have written.
+func chainHopSkills(hop syncHop, previous []model.Skill, previousResult *sync.Result, dryRun bool) ([]model.Skill, error) {
+	parsed, err := parsePlatformSkillsWithScope(hop.source.Platform, hop.source.Scopes, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s skills: %w", hop.source, err)
+	}
+	byName := make(map[string]model.Skill, len(parsed))
+	for _, skill := range parsed {
+		byName[skill.Name] = skill
+	}
+	actions := make(map[string]sync.Action, len(previousResult.Skills))
+	for _, sr := range previousResult.Skills {
+		actions[sr.Skill.Name] = sr.Action
+	}
+
+	skills := make([]model.Skill, 0, len(previous))
+	var missing []string
+	for _, skill := range previous {
+		synced, ok := byName[skill.Name]
+		if !dryRun {
+			if ok {
+				skills = append(skills, synced)
+			} else {
+				missing = append(missing, skill.Name)
+			}
+			continue
+		}
+		switch action := actions[skill.Name]; {
+		case action == sync.ActionMerged && ok:
+			synced.Content = sync.NewTransformer().MergeContent(skill.Content, synced.Content, skill.Name)
+			skills = append(skills, synced)
+		case ok && action != sync.ActionCreated && action != sync.ActionUpdated && action != sync.ActionRenamed:
+			skills = append(skills, synced)
+		default:
+			skills = append(skills, skill)
+		}
+	}
+	if len(missing) > 0 {
+		warnf("Warning: not in %s, left out of the sync to %s: %s\n", hop.source, hop.target, strings.Join(missing, ", "))
+	}
+	return skills, nil
+}
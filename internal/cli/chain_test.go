@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSyncChain(t *testing.T) {
+	tests := map[string]struct {
+		args       []string
+		wantCodex  bool
+		wantCursor bool
+		wantOutput []string
+	}{
+		"syncs every hop": {
+			args:       []string{"--yes", "--skip-backup"},
+			wantCodex:  true,
+			wantCursor: true,
+			wantOutput: []string{"claude-code -> cursor", "cursor:user -> codex"},
+		},
+		"dry run plans later hops from earlier ones": {
+			args:       []string{"--dry-run"},
+			wantOutput: []string{"cursor:user -> codex", "lint"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, cursorSkills := setupStore(t)
+			codexSkills := filepath.Join(filepath.Dir(claudeSkills), "codex")
+			t.Setenv("SKILLSYNC_CODEX_SKILLS_PATHS", codexSkills)
+			t.Setenv("SKILLSYNC_CODEX_PATH", codexSkills)
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+
+			var runErr error
+			output := captureOutput(t, func() {
+				args := append([]string{"skillsync", "sync"}, tt.args...)
+				runErr = Run(context.Background(), append(args, "claudecode", "cursor", "codex"))
+			})
+			util.AssertNoError(t, runErr)
+
+			for dir, want := range map[string]bool{cursorSkills: tt.wantCursor, codexSkills: tt.wantCodex} {
+				entries, _ := os.ReadDir(dir)
+				if got := len(entries) > 0; got != want {
+					t.Errorf("%s has skills = %v, want %v\n%s", dir, got, want, output)
+				}
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncChain_Rejected(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"repeated platform": {
+			args:    []string{"sync", "--dry-run", "claudecode", "cursor", "claudecode"},
+			wantErr: "claude-code appears more than once",
+		},
+		"read-only target scope": {
+			args:    []string{"sync", "--dry-run", "claudecode", "cursor", "codex:system"},
+			wantErr: "invalid target",
+		},
+		"with map": {
+			args:    []string{"sync", "--dry-run", "--map", "repo=repo", "claudecode", "cursor", "codex"},
+			wantErr: "cannot be used with --workspace, --map, or --watch",
+		},
+		"interactive strategy": {
+			args:    []string{"sync", "--dry-run", "--strategy", "interactive", "claudecode", "cursor", "codex"},
+			wantErr: "interactive strategy is not supported with chained targets",
+		},
+		"delete": {
+			args:    []string{"delete", "--dry-run", "claudecode", "cursor", "codex"},
+			wantErr: "exactly 2 arguments",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, _ := setupStore(t)
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+
+			var runErr error
+			captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync"}, tt.args...))
+			})
+			if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+				t.Errorf("Run() error = %v, want %q", runErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChainHopSkills_DryRun(t *testing.T) {
+	tests := map[string]struct {
+		action      sync.Action
+		wantContent []string
+		dontWant    string
+	}{
+		"updated":   {action: sync.ActionUpdated, wantContent: []string{"Run the new linter."}, dontWant: "Run the old linter."},
+		"merged":    {action: sync.ActionMerged, wantContent: []string{"Run the old linter.", "Run the new linter."}},
+		"skipped":   {action: sync.ActionSkipped, wantContent: []string{"Run the old linter."}, dontWant: "Run the new linter."},
+		"unchanged": {action: sync.ActionUnchanged, wantContent: []string{"Run the old linter."}, dontWant: "Run the new linter."},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, cursorSkills := setupStore(t)
+			util.WriteFile(t, filepath.Join(cursorSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the old linter.\n")
+
+			hop := syncHop{
+				source: model.PlatformSpec{Platform: model.Cursor, Scopes: []model.SkillScope{model.ScopeUser}},
+				target: model.PlatformSpec{Platform: model.Codex},
+			}
+			planned := model.Skill{Name: "lint", Platform: model.Cursor, Content: "Run the new linter."}
+			result := &sync.Result{Skills: []sync.SkillResult{{Skill: planned, Action: tt.action}}}
+
+			skills, err := chainHopSkills(hop, []model.Skill{planned}, result, true)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(skills), 1)
+			for _, want := range tt.wantContent {
+				if !strings.Contains(skills[0].Content, want) {
+					t.Errorf("content missing %q:\n%s", want, skills[0].Content)
+				}
+			}
+			if tt.dontWant != "" && strings.Contains(skills[0].Content, tt.dontWant) {
+				t.Errorf("content has %q:\n%s", tt.dontWant, skills[0].Content)
+			}
+		})
+	}
+}
@@ -3,13 +3,21 @@ package cli
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/klauern/skillsync/internal/cache"
 	"github.com/klauern/skillsync/internal/config"
 	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/parser/external"
+	"github.com/klauern/skillsync/internal/sync"
 	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/util"
 )
 
 var (
@@ -19,18 +27,42 @@ var (
 	Commit = "unknown"
 	// BuildDate is the date and time of the build.
 	BuildDate = "unknown"
+	// UpdatePublicKey is the base64 Ed25519 key that signs release
+	// checksums, set by the release build. self-update refuses releases
+	// not signed by it, and builds without one refuse to update unless
+	// told to skip the signature.
+	UpdatePublicKey = ""
 )
 
+// logFile is the log file of the running command, set by log.file.
+var logFile *os.File
+
 // Run executes the CLI application with the given context and arguments.
 func Run(ctx context.Context, args []string) error {
+	defer closeLogFile()
+
+	// -v is --verbose, so --version goes without its short alias
+	cli.VersionFlag = &cli.BoolFlag{
+		Name:        "version",
+		Usage:       "print the version",
+		HideDefault: true,
+		Local:       true,
+	}
+
 	app := &cli.Command{
 		Name:    "skillsync",
 		Usage:   "Synchronize agent skills across AI coding platforms",
 		Version: Version,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:  "verbose",
-				Usage: "Enable verbose output (info level logging)",
+				Name:    "verbose",
+				Aliases: []string{"v"},
+				Usage:   "Enable verbose output (info level logging, e.g. each skill a sync writes)",
+			},
+			&cli.BoolFlag{
+				Name:    "quiet",
+				Aliases: []string{"q"},
+				Usage:   "Print only results and errors: no warnings, progress bars, or log messages below error level",
 			},
 			&cli.BoolFlag{
 				Name:  "debug",
@@ -40,29 +72,96 @@ func Run(ctx context.Context, args []string) error {
 				Name:  "no-color",
 				Usage: "Disable colored output",
 			},
+			&cli.StringFlag{
+				Name:    "output",
+				Usage:   "Result format for scripting: text or json (json writes results to stdout, messages to stderr)",
+				Value:   "text",
+				Sources: cli.EnvVars("SKILLSYNC_OUTPUT"),
+				Local:   true,
+			},
+			&cli.BoolFlag{
+				Name:    "wrap",
+				Usage:   "Wrap long table cells onto multiple lines instead of truncating them",
+				Sources: cli.EnvVars("SKILLSYNC_TABLE_WRAP"),
+			},
+			&cli.IntFlag{
+				Name:    "max-width",
+				Usage:   "Table width in columns (default: terminal width, or 120 when not a terminal)",
+				Sources: cli.EnvVars("SKILLSYNC_TABLE_MAX_WIDTH"),
+			},
+			&cli.BoolFlag{
+				Name:    "no-persist",
+				Usage:   "Write only to sync targets: no config, backups, history, trash, cache, or plugin installs",
+				Sources: cli.EnvVars("SKILLSYNC_NO_PERSIST"),
+			},
+			strictFlag(),
+			specFlag(),
 		},
 		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			if err := configureOutput(cmd); err != nil {
+				return ctx, err
+			}
+			configureStrict(cmd)
+			util.SetNoPersist(cmd.Bool("no-persist"))
 			configureColors(cmd)
-			return ctx, configureLogging(cmd)
+			if err := configureLogging(cmd); err != nil {
+				return ctx, err
+			}
+			configureEngine()
+			return ctx, nil
 		},
 		Commands: []*cli.Command{
 			versionCommand(),
 			onboardCommand(),
+			initCommand(),
+			newCommand(),
+			addCommand(),
 			configCommand(),
 			syncCommand(),
+			reconcileCommand(),
 			deleteCommand(),
 			discoveryCommand(),
+			validateCommand(),
+			fmtCommand(),
+			sedCommand(),
 			compareCommand(),
+			diffCommand(),
+			conflictsCommand(),
 			dedupeCommand(),
 			exportCommand(),
+			importCommand(),
+			rpcCommand(),
+			mcpCommand(),
+			refreshCommand(),
+			inspectCommand(),
+			pluginsCommand(),
 			backupCommand(),
+			cacheCommand(),
+			historyCommand(),
+			undoCommand(),
 			promoteCommand(),
 			demoteCommand(),
 			scopeCommand(),
+			collectionCommand(),
+			skillCommand(),
+			pushCommand(),
+			pullCommand(),
+			platformsCommand(),
+			docsCommand(),
+			selfUpdateCommand(),
 			tuiCommand(),
+			browseCommand(),
+			serveCommand(),
 		},
 	}
-	return app.Run(ctx, args)
+	err := app.Run(ctx, args)
+	if errors.Is(err, errSpecPrinted) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return strictError()
 }
 
 // configureColors sets up color output based on CLI flags and config.
@@ -86,21 +185,98 @@ func configureColors(cmd *cli.Command) {
 	ui.ConfigureColors(cfg.Output.Color)
 }
 
+// configureEngine applies the configured discovery limits, cache policy,
+// custom platforms, and diff options, and starts the run with a fresh file cache.
+// If the config fails to load, the defaults stay in place.
+func configureEngine() {
+	resetFileCache()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	parser.SetDiscoveryLimits(cfg.Discovery.Limits())
+	cache.SetPolicy(cfg.Cache.Policy())
+	external.SetDefinitions(cfg.Platforms.CustomDefinitions())
+
+	diffOpts, err := cfg.Diff.Options()
+	if err != nil {
+		logging.Warn("ignoring diff config", logging.Err(err))
+		diffOpts = sync.DefaultDiffOptions()
+	}
+	sync.SetDiffOptions(diffOpts)
+}
+
 // configureLogging sets up the logging level based on CLI flags.
 func configureLogging(cmd *cli.Command) error {
 	opts := logging.DefaultOptions()
+	quietMode.Store(cmd.Bool("quiet"))
 
-	if cmd.Bool("debug") {
+	switch {
+	case quiet() && (cmd.Bool("verbose") || cmd.Bool("debug")):
+		return errors.New("--quiet cannot be combined with --verbose or --debug")
+	case cmd.Bool("debug"):
 		opts.Level = slog.LevelDebug
 		opts.AddSource = true
-	} else if cmd.Bool("verbose") {
+	case cmd.Bool("verbose"):
 		opts.Level = slog.LevelInfo
+	case quiet():
+		opts.Level = slog.LevelError
+	default:
+		opts.Level = slog.LevelWarn
 	}
 
-	logger := logging.New(opts)
-	logging.SetDefault(logger)
+	logging.SetDefault(logging.New(opts))
+
+	// Console logging is in place first, so problems with the log file
+	// are reported
+	fileOpts, err := logFileOptions(cmd.Bool("debug"))
+	if err != nil {
+		logging.Warn("not writing the log file", logging.Err(err))
+	} else if fileOpts.Output != nil {
+		logging.SetDefault(logging.NewTee(opts, fileOpts))
+	}
 
 	logging.Debug("logging configured", slog.String("level", opts.Level.String()))
 
 	return nil
 }
+
+// logFileOptions opens the log file set by log.file and returns the options
+// it is written with, or options without an Output when none is set. The
+// file keeps log.level records, or debug ones with --debug, regardless of
+// --quiet. A config that fails to load writes no log file, as commands
+// that load it report the error.
+func logFileOptions(debug bool) (logging.Options, error) {
+	cfg, err := config.Load()
+	if err != nil || cfg.Log.File == "" || util.NoPersist() {
+		return logging.Options{}, nil
+	}
+	level, err := logging.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		return logging.Options{}, fmt.Errorf("log.level: %w", err)
+	}
+	jsonFormat, err := logging.ParseFormat(cfg.Log.Format)
+	if err != nil {
+		return logging.Options{}, fmt.Errorf("log.format: %w", err)
+	}
+	if debug {
+		level = slog.LevelDebug
+	}
+
+	closeLogFile()
+	f, err := logging.OpenFile(util.ExpandPath(cfg.Log.File, ""))
+	if err != nil {
+		return logging.Options{}, err
+	}
+	logFile = f
+	return logging.Options{Level: level, Output: f, JSON: jsonFormat, AddSource: debug}, nil
+}
+
+// closeLogFile closes the log file of the running command, if any.
+func closeLogFile() {
+	if logFile != nil {
+		_ = logFile.Close()
+		logFile = nil
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/klauern/skillsync/internal/backup"
 	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/parser/external"
+	"github.com/klauern/skillsync/internal/trash"
 	"github.com/klauern/skillsync/internal/util"
 )
 
@@ -36,10 +38,11 @@ func TestConfigureLogging(t *testing.T) {
 		args       []string
 		wantLevel  slog.Level
 		wantSource bool
+		wantErr    bool
 	}{
-		"no flags uses default info level": {
+		"no flags uses default warn level": {
 			args:       []string{"skillsync", "version"},
-			wantLevel:  slog.LevelInfo,
+			wantLevel:  slog.LevelWarn,
 			wantSource: false,
 		},
 		"verbose flag enables info level": {
@@ -47,6 +50,18 @@ func TestConfigureLogging(t *testing.T) {
 			wantLevel:  slog.LevelInfo,
 			wantSource: false,
 		},
+		"short verbose flag": {
+			args:      []string{"skillsync", "-v", "version"},
+			wantLevel: slog.LevelInfo,
+		},
+		"quiet flag logs only errors": {
+			args:      []string{"skillsync", "-q", "version"},
+			wantLevel: slog.LevelError,
+		},
+		"quiet conflicts with verbose": {
+			args:    []string{"skillsync", "--quiet", "--verbose", "version"},
+			wantErr: true,
+		},
 		"debug flag enables debug level": {
 			args:       []string{"skillsync", "--debug", "version"},
 			wantLevel:  slog.LevelDebug,
@@ -105,21 +120,59 @@ func TestConfigureLogging(t *testing.T) {
 				t.Fatalf("failed to close stdout pipe reader: %v", err)
 			}
 
-			if err != nil {
-				t.Fatalf("Run() error = %v", err)
+			t.Cleanup(func() { quietMode.Store(false) })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
 			}
 
-			// Verify log level by checking if debug messages would be logged
+			// Verify the log level: enabled at it, and disabled just below it
 			logger := slog.Default()
-			if logger.Enabled(context.Background(), slog.LevelDebug) != (tt.wantLevel == slog.LevelDebug) {
-				t.Errorf("Logger debug enabled = %v, want %v",
-					logger.Enabled(context.Background(), slog.LevelDebug),
-					tt.wantLevel == slog.LevelDebug)
+			if !logger.Enabled(context.Background(), tt.wantLevel) {
+				t.Errorf("Logger not enabled at %v", tt.wantLevel)
+			}
+			if logger.Enabled(context.Background(), tt.wantLevel-1) {
+				t.Errorf("Logger enabled below %v", tt.wantLevel)
 			}
 		})
 	}
 }
 
+func TestLogFile(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+	logPath := filepath.Join(t.TempDir(), "logs", "skillsync.log")
+	t.Setenv("SKILLSYNC_LOG_FILE", logPath)
+	t.Setenv("SKILLSYNC_LOG_FORMAT", "json")
+	t.Cleanup(func() { logging.SetDefault(logging.New(logging.DefaultOptions())) })
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "--quiet", "sync", "--yes", "--skip-backup", "claudecode", "cursor"})
+	})
+	util.AssertNoError(t, runErr)
+	if strings.Contains(output, "processed skill") {
+		t.Errorf("--quiet printed log records:\n%s", output)
+	}
+
+	data, err := os.ReadFile(logPath)
+	util.AssertNoError(t, err)
+	if !strings.Contains(string(data), `"msg":"processed skill","skill":"lint"`) {
+		t.Errorf("log file missing the info record of the synced skill:\n%s", data)
+	}
+
+	t.Run("invalid level writes no file", func(t *testing.T) {
+		util.AssertNoError(t, os.Remove(logPath))
+		t.Setenv("SKILLSYNC_LOG_LEVEL", "trace")
+		util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "--quiet", "version"}))
+		if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+			t.Errorf("log file written with an invalid level: %v", err)
+		}
+	})
+}
+
 func TestSyncCommand(t *testing.T) {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -214,6 +267,106 @@ func TestSyncCommand(t *testing.T) {
 	}
 }
 
+func TestSyncCommand_Rename(t *testing.T) {
+	tests := map[string]struct {
+		args       []string
+		wantOutput string
+		wantOld    bool
+	}{
+		"renames after confirmation": {
+			args:       []string{"claudecode:user", "cursor:user"},
+			wantOutput: "linter -> lint",
+		},
+		"no-rename keeps the old skill": {
+			args:       []string{"--no-rename", "claudecode:user", "cursor:user"},
+			wantOutput: "lint: created",
+			wantOld:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			claudeSkills := filepath.Join(tempDir, "claude")
+			cursorSkills := filepath.Join(tempDir, "cursor")
+			content := "Run the linter.\nFix what it reports.\nRun it again.\n"
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\n"+content)
+			util.WriteFile(t, filepath.Join(cursorSkills, "linter", "SKILL.md"), "---\nname: linter\ndescription: Lint code\n---\n"+content)
+			t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+			t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+			t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+			withStdin(t, "y\n")
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync", "sync", "--skip-validation"}, tt.args...))
+			})
+			util.AssertNoError(t, runErr)
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output missing %q:\n%s", tt.wantOutput, output)
+			}
+			if _, err := os.Stat(filepath.Join(cursorSkills, "lint", "SKILL.md")); err != nil {
+				t.Errorf("renamed skill not written: %v", err)
+			}
+			_, err := os.Stat(filepath.Join(cursorSkills, "linter"))
+			util.AssertEqual(t, err == nil, tt.wantOld)
+		})
+	}
+}
+
+func TestSyncCommand_Link(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"links the skill": {
+			args: []string{"claudecode:user", "cursor:user"},
+		},
+		"merging strategy": {
+			args:    []string{"--strategy", "three-way", "claudecode:user", "cursor:user"},
+			wantErr: "--link cannot be used with the three-way strategy",
+		},
+		"aider target": {
+			args:    []string{"claudecode:user", "aider:user"},
+			wantErr: "--link: aider reads skills from CONVENTIONS.md",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			claudeSkills := filepath.Join(tempDir, "claude")
+			cursorSkills := filepath.Join(tempDir, "cursor")
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+			t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+			t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+			t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+			t.Setenv("SKILLSYNC_AIDER_PATH", filepath.Join(tempDir, "aider"))
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync", "sync", "--yes", "--link"}, tt.args...))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("sync error = %v, want %q", runErr, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, runErr)
+			link, err := os.Readlink(filepath.Join(cursorSkills, "lint"))
+			if err != nil {
+				t.Fatalf("cursor skill is not a link: %v\n%s", err, output)
+			}
+			util.AssertEqual(t, link, filepath.Join(claudeSkills, "lint"))
+		})
+	}
+}
+
 func TestDeleteCommand(t *testing.T) {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -280,6 +433,120 @@ func TestDeleteCommand(t *testing.T) {
 	}
 }
 
+func TestDeleteCommand_TrashAndResurrect(t *testing.T) {
+	tempDir := t.TempDir()
+	cursorSkills := filepath.Join(tempDir, "home", ".cursor", "skills")
+	claudeSkills := filepath.Join(tempDir, "home", ".claude", "skills")
+	targetFile := filepath.Join(claudeSkills, "lint", "SKILL.md")
+	targetContent := "---\nname: lint\ndescription: lint skill\n---\nTarget-only edits.\n"
+
+	util.WriteFile(t, filepath.Join(cursorSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: lint skill\n---\nRun the linter.\n")
+	util.WriteFile(t, targetFile, targetContent)
+
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+
+	ctx := context.Background()
+	captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "delete", "--yes", "cursor:user", "claudecode:user"}); err != nil {
+			t.Errorf("delete failed: %v", err)
+		}
+	})
+	if _, err := os.Stat(targetFile); !os.IsNotExist(err) {
+		t.Fatalf("expected target to be deleted, stat err = %v", err)
+	}
+	entries, err := trash.List()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(entries), 1)
+
+	// The skill reappears in the source: the trashed target comes back, so the
+	// skip strategy leaves its target-only edits alone
+	output := captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "sync", "--yes", "--skip-validation", "--strategy", "skip", "cursor:user", "claudecode:user"}); err != nil {
+			t.Errorf("sync failed: %v", err)
+		}
+	})
+	if !strings.Contains(output, "restored from trash") {
+		t.Errorf("sync output missing resurrection note:\n%s", output)
+	}
+	got, err := os.ReadFile(targetFile)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(got), targetContent)
+}
+
+func TestNoPersist(t *testing.T) {
+	tempDir := t.TempDir()
+	home := filepath.Join(tempDir, "skillsync")
+	cursorSkills := filepath.Join(tempDir, "home", ".cursor", "skills")
+	claudeSkills := filepath.Join(tempDir, "home", ".claude", "skills")
+	targetFile := filepath.Join(claudeSkills, "lint", "SKILL.md")
+
+	util.WriteFile(t, filepath.Join(cursorSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: lint skill\n---\nRun the linter.\n")
+	util.WriteFile(t, filepath.Join(cursorSkills, "review", "SKILL.md"), "---\nname: review\ndescription: review skill\n---\nReview it.\n")
+	util.WriteFile(t, targetFile, "---\nname: lint\ndescription: lint skill\n---\nOld steps.\n")
+
+	t.Setenv("SKILLSYNC_HOME", home)
+	t.Cleanup(func() { util.SetNoPersist(false) })
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+
+	ctx := context.Background()
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "--no-persist", "sync", "--yes", "--skip-validation", "cursor:user", "claudecode:user"}))
+	})
+	got, err := os.ReadFile(targetFile)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, strings.Contains(string(got), "Run the linter."), true)
+
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "--no-persist", "delete", "--yes", "cursor:user", "claudecode:user"}))
+	})
+	if _, err := os.Stat(targetFile); !os.IsNotExist(err) {
+		t.Errorf("expected target to be deleted, stat err = %v", err)
+	}
+	if _, err := os.Stat(home); !os.IsNotExist(err) {
+		t.Errorf("--no-persist wrote to %s (stat err = %v)", home, err)
+	}
+
+	tests := map[string]struct {
+		args    []string
+		env     map[string]string
+		wantErr string
+	}{
+		"no-persist": {
+			args:    []string{"skillsync", "--no-persist", "config", "set", "sync.default_strategy", "skip"},
+			wantErr: "--no-persist is set",
+		},
+		"no-persist from environment": {
+			args:    []string{"skillsync", "config", "set", "sync.default_strategy", "skip"},
+			env:     map[string]string{"SKILLSYNC_NO_PERSIST": "true"},
+			wantErr: "--no-persist is set",
+		},
+		"HOME unset": {
+			args:    []string{"skillsync", "config", "set", "sync.default_strategy", "skip"},
+			env:     map[string]string{"HOME": "", "SKILLSYNC_HOME": ""},
+			wantErr: "set SKILLSYNC_HOME to a writable directory",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			err := Run(ctx, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestBackupListCommand(t *testing.T) {
 	tests := map[string]struct {
 		args       []string
@@ -594,3 +861,41 @@ func TestBackupDeleteCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomPlatform(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	util.WriteFile(t, filepath.Join(claudeSkills, "lint.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+
+	tempDir := t.TempDir()
+	windsurfRules := filepath.Join(tempDir, "windsurf")
+	util.WriteFile(t, filepath.Join(windsurfRules, "style.txt"), "Use tabs.\n")
+	script := filepath.Join(tempDir, "windsurf-skills.sh")
+	util.WriteFile(t, script, `echo '{"skills": [{"name": "style", "description": "House style", "path": "style.txt", "content": "Use tabs."}]}'`+"\n")
+	util.WriteFile(t, filepath.Join(os.Getenv("SKILLSYNC_HOME"), "config.yaml"), `platforms:
+  custom:
+    - name: windsurf
+      command: sh `+script+`
+      skills_paths: [`+windsurfRules+`]
+      file_name: "{{.Name}}.txt"
+      write_template: "# {{.Description}}\n\n{{.Content}}\n"
+`)
+	t.Setenv("SKILLSYNC_WINDSURF_PATH", windsurfRules)
+	t.Cleanup(func() { external.SetDefinitions(nil) })
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "discover", "--platform", "windsurf"})
+	})
+	util.AssertNoError(t, runErr)
+	if !strings.Contains(output, "style") {
+		t.Errorf("discover output missing the custom platform's skill:\n%s", output)
+	}
+
+	captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "sync", "--yes", "--skip-backup", "claudecode", "windsurf"})
+	})
+	util.AssertNoError(t, runErr)
+	data, err := os.ReadFile(filepath.Join(windsurfRules, "lint.txt"))
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), "# Lint code\n\nRun the linter.\n")
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// syncCollection is a collection selected for a sync.
+type syncCollection struct {
+	name string
+	config.Collection
+}
+
+// syncCollections returns the collections named by --collection, or by the
+// profile when the flag is not given.
+func syncCollections(cmd *cli.Command, appConfig *config.Config, profile config.Profile) ([]syncCollection, error) {
+	names := []string{profile.Collection}
+	if cmd.IsSet("collection") {
+		names = strings.Split(cmd.String("collection"), ",")
+	}
+	var collections []syncCollection
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		collection, err := appConfig.Collection(name)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, syncCollection{name: name, Collection: collection})
+	}
+	return collections, nil
+}
+
+// missingSkills returns the names not among skills.
+func missingSkills(skills []model.Skill, names []string) []string {
+	var missing []string
+	for _, name := range names {
+		if !slices.ContainsFunc(skills, func(s model.Skill) bool { return s.Name == name }) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// skillSelection describes the --skill and --collection selection of a sync
+// for error messages.
+func skillSelection(cfg *syncConfig) string {
+	var parts []string
+	if len(cfg.skillNames) > 0 {
+		parts = append(parts, "--skill "+strings.Join(cfg.skillNames, ","))
+	}
+	if len(cfg.collections) > 0 {
+		names := make([]string, len(cfg.collections))
+		for i, collection := range cfg.collections {
+			names[i] = collection.name
+		}
+		parts = append(parts, "--collection "+strings.Join(names, ","))
+	}
+	return strings.Join(parts, " or ")
+}
+
+// collectionInfo describes a configured collection.
+type collectionInfo struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Skills      []collectionSkillInfo `json:"skills"`
+}
+
+// collectionSkillInfo is a skill of a collection and the platforms it was
+// found on.
+type collectionSkillInfo struct {
+	Name      string   `json:"name"`
+	Platforms []string `json:"platforms,omitempty"`
+}
+
+func collectionCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "collection",
+		Aliases: []string{"collections"},
+		Usage:   "List and inspect collections, named groups of skills",
+		UsageText: `skillsync collection list
+   skillsync collection show <name>`,
+		Description: `Collections group related skills, such as the linting, testing, and
+   packaging skills of a language stack, so they can be synced as a unit with
+   sync --collection NAME.
+
+   Define them under collections in the config, in collections.yaml next to
+   it, or in a project's .skillsync.yaml. config.yaml takes precedence over
+   collections.yaml, and the project config over both:
+
+     python-stack:
+       description: Linting, testing, and packaging for Python
+       skills: [ruff, pytest, packaging]
+     go-stack: [golangci-lint, go-test]   # skills only
+
+   Examples:
+     skillsync collection list
+     skillsync collection show python-stack
+     skillsync sync --collection python-stack claudecode cursor`,
+		Commands: []*cli.Command{
+			{
+				Name:      "list",
+				Usage:     "List configured collections",
+				UsageText: "skillsync collection list",
+				Action: func(_ context.Context, _ *cli.Command) error {
+					appConfig, err := config.Load()
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+					return listCollections(appConfig)
+				},
+			},
+			{
+				Name:      "show",
+				Usage:     "Show the skills of a collection and the platforms they are on",
+				UsageText: "skillsync collection show <name>",
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					if cmd.Args().Len() != 1 {
+						return errors.New("collection show requires exactly 1 argument: <name>")
+					}
+					appConfig, err := config.Load()
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+					return showCollection(appConfig, cmd.Args().First())
+				},
+			},
+		},
+	}
+}
+
+// listCollections prints the configured collections.
+func listCollections(appConfig *config.Config) error {
+	infos := make([]collectionInfo, 0, len(appConfig.Collections))
+	for _, name := range slices.Sorted(maps.Keys(appConfig.Collections)) {
+		collection := appConfig.Collections[name]
+		info := collectionInfo{Name: name, Description: collection.Description, Skills: []collectionSkillInfo{}}
+		for _, skill := range collection.Skills {
+			info.Skills = append(info.Skills, collectionSkillInfo{Name: skill})
+		}
+		infos = append(infos, info)
+	}
+
+	return out.Render(infos, func() error {
+		if len(infos) == 0 {
+			fmt.Printf("No collections configured. Define them in %s.\n", config.CollectionsFilePath())
+			return nil
+		}
+		fmt.Printf("%s %s %s\n",
+			ui.Header(fmt.Sprintf("%-20s", "COLLECTION")),
+			ui.Header(fmt.Sprintf("%-7s", "SKILLS")),
+			ui.Header("DESCRIPTION"))
+		for _, info := range infos {
+			fmt.Printf("%-20s %-7d %s\n", info.Name, len(info.Skills), info.Description)
+		}
+		return nil
+	})
+}
+
+// showCollection prints the skills of a collection with the platforms each
+// is discovered on (plugin skills excluded).
+func showCollection(appConfig *config.Config, name string) error {
+	collection, err := appConfig.Collection(name)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string][]string)
+	for _, platform := range model.AllPlatforms() {
+		skills, err := parsePlatformSkillsWithScope(platform, nil, false)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s skills: %w", platform, err)
+		}
+		for _, skill := range skills {
+			if slices.Contains(collection.Skills, skill.Name) && !slices.Contains(found[skill.Name], string(platform)) {
+				found[skill.Name] = append(found[skill.Name], string(platform))
+			}
+		}
+	}
+
+	info := collectionInfo{Name: name, Description: collection.Description, Skills: []collectionSkillInfo{}}
+	for _, skill := range collection.Skills {
+		info.Skills = append(info.Skills, collectionSkillInfo{Name: skill, Platforms: found[skill]})
+	}
+
+	return out.Render(info, func() error {
+		fmt.Println(ui.Header(info.Name))
+		if info.Description != "" {
+			fmt.Println(info.Description)
+		}
+		fmt.Println()
+		for _, skill := range info.Skills {
+			platforms := strings.Join(skill.Platforms, ", ")
+			if platforms == "" {
+				platforms = ui.Dim("(not found)")
+			}
+			fmt.Printf("  %-24s %s\n", skill.Name, platforms)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// setupCollections creates Claude Code skills ruff, pytest, and eslint, an
+// empty Cursor skills directory, and a collections.yaml, and returns the
+// Cursor skills directory.
+func setupCollections(t *testing.T) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, "claude")
+	cursorSkills := filepath.Join(tempDir, "cursor")
+	home := filepath.Join(tempDir, "skillsync")
+	for _, name := range []string{"ruff", "pytest", "eslint"} {
+		util.WriteFile(t, filepath.Join(claudeSkills, name, "SKILL.md"),
+			"---\nname: "+name+"\ndescription: Run "+name+"\n---\nRun "+name+".\n")
+	}
+	util.WriteFile(t, filepath.Join(home, "collections.yaml"), `python-stack:
+  description: Python tooling
+  skills: [ruff, pytest, packaging]
+web: [eslint]
+`)
+	if err := os.MkdirAll(cursorSkills, 0o750); err != nil {
+		t.Fatalf("failed to create cursor skills: %v", err)
+	}
+	t.Chdir(tempDir)
+	t.Setenv("SKILLSYNC_HOME", home)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	return cursorSkills
+}
+
+func TestSyncCommand_Collection(t *testing.T) {
+	tests := map[string]struct {
+		args       []string
+		want       []string
+		wantOutput string
+		wantErr    string
+	}{
+		"syncs the collection's skills": {
+			args:       []string{"--collection", "python-stack"},
+			want:       []string{"pytest", "ruff"},
+			wantOutput: "collection python-stack: not in the source: packaging",
+		},
+		"several collections": {
+			args: []string{"--collection", "python-stack,web"},
+			want: []string{"eslint", "pytest", "ruff"},
+		},
+		"with --skill": {
+			args: []string{"--collection", "web", "--skill", "ruff"},
+			want: []string{"eslint", "ruff"},
+		},
+		"unknown collection": {
+			args:    []string{"--collection", "rust"},
+			wantErr: `unknown collection "rust" (configured: python-stack, web)`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cursorSkills := setupCollections(t)
+
+			var runErr error
+			output := captureOutput(t, func() {
+				args := append([]string{"skillsync", "sync", "--yes", "--skip-backup"}, tt.args...)
+				runErr = Run(context.Background(), append(args, "claudecode:user", "cursor:user"))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("sync error = %v, want %q", runErr, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, runErr)
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output missing %q:\n%s", tt.wantOutput, output)
+			}
+
+			entries, err := os.ReadDir(cursorSkills)
+			util.AssertNoError(t, err)
+			var got []string
+			for _, entry := range entries {
+				got = append(got, entry.Name())
+			}
+			util.AssertEqual(t, strings.Join(got, ","), strings.Join(tt.want, ","))
+		})
+	}
+}
+
+func TestCollectionCommand(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		want []string
+	}{
+		"list": {
+			args: []string{"collection", "list"},
+			want: []string{"python-stack", "3", "Python tooling", "web"},
+		},
+		"show": {
+			args: []string{"collection", "show", "python-stack"},
+			want: []string{"Python tooling", "ruff", "claude-code", "packaging", "(not found)"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			setupCollections(t)
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync"}, tt.args...))
+			})
+			util.AssertNoError(t, runErr)
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
@@ -3,12 +3,16 @@ package cli
 
 import (
 	"bufio"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"reflect"
 	"slices"
 	"sort"
 	"strings"
@@ -21,15 +25,23 @@ import (
 	"github.com/klauern/skillsync/internal/backup"
 	"github.com/klauern/skillsync/internal/cache"
 	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/docs"
 	"github.com/klauern/skillsync/internal/export"
+	"github.com/klauern/skillsync/internal/history"
+	"github.com/klauern/skillsync/internal/logging"
 	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/parser/aider"
 	"github.com/klauern/skillsync/internal/parser/claude"
 	"github.com/klauern/skillsync/internal/parser/codex"
 	"github.com/klauern/skillsync/internal/parser/cursor"
+	"github.com/klauern/skillsync/internal/parser/external"
 	"github.com/klauern/skillsync/internal/parser/plugin"
 	"github.com/klauern/skillsync/internal/parser/tiered"
+	"github.com/klauern/skillsync/internal/promote"
 	"github.com/klauern/skillsync/internal/similarity"
 	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/trash"
 	"github.com/klauern/skillsync/internal/ui"
 	"github.com/klauern/skillsync/internal/ui/tui"
 	"github.com/klauern/skillsync/internal/util"
@@ -49,12 +61,18 @@ func configCommand() *cli.Command {
      skillsync config show           # Show current configuration
      skillsync config init           # Create default config file
      skillsync config path           # Show config file path
-     skillsync config edit           # Edit config file (opens in $EDITOR)`,
+     skillsync config edit           # Edit config file (opens in $EDITOR)
+     skillsync config get sync.default_strategy
+     skillsync config set sync.default_strategy newer
+     skillsync config env            # List SKILLSYNC_* overrides and their sources`,
 		Commands: []*cli.Command{
 			configShowCommand(),
 			configInitCommand(),
 			configPathCommand(),
 			configEditCommand(),
+			configGetCommand(),
+			configSetCommand(),
+			configEnvCommand(),
 		},
 		Action: func(_ context.Context, _ *cli.Command) error {
 			// Default action: show configuration
@@ -120,6 +138,89 @@ func configEditCommand() *cli.Command {
 	}
 }
 
+func configGetCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "get",
+		Usage:     "Print one configuration setting",
+		UsageText: "skillsync config get <key>",
+		Description: `Print the effective value of one setting, including environment
+   overrides. Keys are dot-separated YAML paths into the config file; a key
+   naming a section prints the whole section as YAML.
+
+   Examples:
+     skillsync config get sync.default_strategy
+     skillsync config get sync.scope_strategies.repo
+     skillsync config get platforms.cursor`,
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("config get requires exactly 1 argument: <key>")
+			}
+			return getConfigValue(cmd.Args().First())
+		},
+	}
+}
+
+func configSetCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "set",
+		Usage:     "Change one configuration setting",
+		UsageText: "skillsync config set <key> <value>",
+		Description: `Set one setting in ~/.skillsync/config.yaml, creating the file if needed.
+   Only that key is changed; other settings and comments are kept as written.
+
+   The value is parsed as YAML into the setting's type, and the change is
+   refused if the result would not load (unknown key, wrong type, invalid
+   strategy, ...). Lists take comma-separated values or YAML flow syntax.
+
+   Examples:
+     skillsync config set sync.default_strategy newer
+     skillsync config set sync.scope_strategies.repo three-way
+     skillsync config set platforms.cursor.max_skills 80
+     skillsync config set workspace.repos ~/src/api,~/src/web`,
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 2 {
+				return errors.New("config set requires exactly 2 arguments: <key> <value>")
+			}
+			return setConfigValue(cmd.Args().Get(0), cmd.Args().Get(1))
+		},
+	}
+}
+
+// getConfigValue prints the effective value of one setting: scalars as-is,
+// sections and lists as YAML.
+func getConfigValue(key string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	value, err := cfg.Get(key)
+	if err != nil {
+		return err
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice:
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", key, err)
+		}
+		fmt.Print(string(data))
+	default:
+		fmt.Println(value)
+	}
+	return nil
+}
+
+// setConfigValue sets one setting in the config file.
+func setConfigValue(key, value string) error {
+	configPath := config.FilePath()
+	if err := config.SetInFile(configPath, key, value); err != nil {
+		return err
+	}
+	fmt.Printf("Set %s = %s in %s\n", key, value, configPath)
+	return nil
+}
+
 // showConfig displays the current configuration.
 func showConfig() error {
 	return showConfigWithFormat("yaml")
@@ -187,18 +288,36 @@ func showConfigPaths() error {
 	} else {
 		fmt.Println(" (not found)")
 	}
-	fmt.Printf("  Config dir:      %s\n", util.SkillsyncConfigPath())
+	fmt.Printf("  Config dir:      %s", util.Paths().SkillsyncHome())
+	if err := util.Paths().CheckSkillsyncHome(); err != nil {
+		fmt.Printf(" (%s)\n", ui.Warning(err.Error()))
+	} else {
+		fmt.Println(" (writable)")
+	}
+	if projectFile := cfg.ProjectFile(); projectFile != "" {
+		fmt.Printf("  Project config:  %s\n", projectFile)
+	}
 
 	fmt.Println("\nPlatform paths:")
 	fmt.Printf("  Claude Code:     %v\n", cfg.Platforms.ClaudeCode.SkillsPaths)
 	fmt.Printf("  Cursor:          %v\n", cfg.Platforms.Cursor.SkillsPaths)
 	fmt.Printf("  Codex:           %v\n", cfg.Platforms.Codex.SkillsPaths)
+	fmt.Printf("  Aider:           %v\n", cfg.Platforms.Aider.SkillsPaths)
+
+	paths := util.Paths()
+	fmt.Println("\nUser skills paths:")
+	fmt.Printf("  Claude Code:     %s\n", paths.UserSkillsPath(model.ClaudeCode))
+	fmt.Printf("  Cursor:          %s\n", paths.UserSkillsPath(model.Cursor))
+	fmt.Printf("  Codex:           %s\n", paths.UserSkillsPath(model.Codex))
+	fmt.Printf("  Aider:           %s\n", paths.UserSkillsPath(model.Aider))
 
 	fmt.Println("\nData paths:")
-	fmt.Printf("  Backups:         %s\n", util.SkillsyncBackupsPath())
-	fmt.Printf("  Cache:           %s\n", filepath.Join(util.SkillsyncConfigPath(), "cache"))
-	fmt.Printf("  Plugins:         %s\n", util.SkillsyncPluginsPath())
-	fmt.Printf("  Metadata:        %s\n", util.SkillsyncMetadataPath())
+	fmt.Printf("  Backups:         %s\n", paths.BackupsPath())
+	fmt.Printf("  Cache:           %s\n", paths.CachePath())
+	fmt.Printf("  Plugins:         %s\n", paths.PluginsPath())
+	fmt.Printf("  Claude plugins:  %s\n", paths.ClaudePluginsPath())
+	fmt.Printf("  Metadata:        %s\n", paths.MetadataPath())
+	fmt.Printf("  Templates:       %s\n", paths.TemplatesPath())
 
 	return nil
 }
@@ -215,13 +334,9 @@ func editConfig() error {
 		}
 	}
 
-	// Find editor
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = os.Getenv("VISUAL")
-	}
-	if editor == "" {
-		return fmt.Errorf("no editor found - set $EDITOR or $VISUAL environment variable")
+	editor, err := findEditor()
+	if err != nil {
+		return err
 	}
 
 	fmt.Printf("Opening %s in %s...\n", configPath, editor)
@@ -233,6 +348,19 @@ func editConfig() error {
 	return nil
 }
 
+// findEditor returns the user's editor command, from $EDITOR or $VISUAL.
+// Blank values are ignored.
+func findEditor() (string, error) {
+	editor := strings.TrimSpace(os.Getenv("EDITOR"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("VISUAL"))
+	}
+	if editor == "" {
+		return "", fmt.Errorf("no editor found - set $EDITOR or $VISUAL environment variable")
+	}
+	return editor, nil
+}
+
 func discoveryCommand() *cli.Command {
 	return &cli.Command{
 		Name:    "discover",
@@ -242,22 +370,37 @@ func discoveryCommand() *cli.Command {
    skillsync discover --platform claude-code
    skillsync discover --no-plugins
    skillsync discover --repo https://github.com/user/plugins
-   skillsync discover --format json`,
+   skillsync discover --format json
+   skillsync discover --workspace
+   skillsync discover --show-shadowed`,
 		Description: `Discover and list skills from all supported AI coding platforms.
 
-   Supported platforms: claude-code, cursor, codex
+   Supported platforms: claude-code, cursor, codex, aider
 
    Plugin discovery: By default, skills from installed Claude Code plugins
    are included from ~/.skillsync/plugins/. Use --no-plugins to exclude them,
    or specify a Git repository with --repo to fetch plugins from.
 
+   Workspace discovery: --workspace lists repo-scope skills from every
+   repository in workspace.repos (config) or SKILLSYNC_WORKSPACE_REPOS,
+   grouped by repository.
+
+   Shadowing: a skill in a higher-precedence scope hides same-named skills
+   in lower ones (repo shadows user, user shadows admin and system). The
+   SHADOWS column lists the scopes a skill hides; --show-shadowed also lists
+   the hidden versions, marked "shadowed by <scope>".
+
+   Origins: skills imported with 'skillsync import --git' or '--url' show
+   where they came from in an ORIGIN column; 'skillsync refresh <skill>'
+   fetches them again.
+
    Output formats: table (default), json, yaml
    For interactive browsing, use: skillsync tui`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Filter by platform (claude-code, cursor, codex)",
+				Usage:   "Filter by platform (claude-code, cursor, codex, aider)",
 			},
 			&cli.StringFlag{
 				Name:    "scope",
@@ -280,22 +423,36 @@ func discoveryCommand() *cli.Command {
 			},
 			&cli.BoolFlag{
 				Name:  "no-cache",
-				Usage: "Disable plugin skill caching",
+				Usage: "Parse every skill and plugin again instead of using the caches",
 			},
 			&cli.StringFlag{
 				Name:    "type",
 				Aliases: []string{"t"},
 				Usage:   "Filter by skill type (skill, prompt). Comma-separated for multiple.",
 			},
+			&cli.BoolFlag{
+				Name:    "workspace",
+				Aliases: []string{"w"},
+				Usage:   "Aggregate repo-scope skills across all configured workspace repositories",
+			},
+			&cli.BoolFlag{
+				Name:  "show-shadowed",
+				Usage: "Also list skill versions hidden by a same-named skill in a higher-precedence scope",
+			},
 		},
 		Action: func(_ context.Context, cmd *cli.Command) error {
 			platform := cmd.String("platform")
 			scopeStr := cmd.String("scope")
-			format := cmd.String("format")
+			format := out.Format(cmd.String("format"))
 			excludePlugins := cmd.Bool("no-plugins")
 			repoURL := cmd.String("repo")
 			noCache := cmd.Bool("no-cache")
 			typeStr := cmd.String("type")
+			if noCache {
+				policy := cache.CurrentPolicy()
+				policy.Enabled = false
+				cache.SetPolicy(policy)
+			}
 
 			// Include plugins by default unless --no-plugins is set
 			includePlugins := !excludePlugins
@@ -330,14 +487,26 @@ func discoveryCommand() *cli.Command {
 				platforms = model.AllPlatforms()
 			}
 
+			if cmd.Bool("workspace") {
+				results, err := discoverWorkspaceSkills(platforms, typeFilter)
+				if err != nil {
+					return err
+				}
+				return outputWorkspaceSkills(results, format)
+			}
+
 			// Discover skills from each platform
 			// Note: plugins are handled separately by discoverPluginSkills below
+			parse := parsePlatformSkillsWithScope
+			if cmd.Bool("show-shadowed") {
+				parse = parsePlatformSkillsWithShadowed
+			}
 			var allSkills []model.Skill
 			for _, p := range platforms {
-				skills, err := parsePlatformSkillsWithScope(p, scopeFilter, false)
+				skills, err := parse(p, scopeFilter, false)
 				if err != nil {
 					// Log error but continue with other platforms
-					fmt.Printf("Warning: failed to parse %s: %v\n", p, err)
+					warnf("Warning: failed to parse %s: %v\n", p, err)
 					continue
 				}
 				allSkills = append(allSkills, skills...)
@@ -347,7 +516,7 @@ func discoveryCommand() *cli.Command {
 			if includePlugins {
 				pluginSkills, err := discoverPluginSkills(repoURL, !noCache)
 				if err != nil {
-					fmt.Printf("Warning: failed to discover plugins: %v\n", err)
+					warnf("Warning: failed to discover plugins: %v\n", err)
 				} else {
 					allSkills = append(allSkills, pluginSkills...)
 				}
@@ -363,6 +532,17 @@ func discoveryCommand() *cli.Command {
 	}
 }
 
+// filterSkillsByName keeps only skills whose name is in names.
+func filterSkillsByName(skills []model.Skill, names []string) []model.Skill {
+	filtered := make([]model.Skill, 0, len(names))
+	for _, skill := range skills {
+		if slices.Contains(names, skill.Name) {
+			filtered = append(filtered, skill)
+		}
+	}
+	return filtered
+}
+
 // filterBySkillType filters skills by their type.
 // Skills with empty type are treated as SkillTypeSkill (the default).
 func filterBySkillType(skills []model.Skill, typeFilter []model.SkillType) []model.Skill {
@@ -475,15 +655,12 @@ func discoverPluginSkills(repoURL string, useCache bool) ([]model.Skill, error)
 	}
 
 	// Try to use cache for local plugins (not for remote repos which need git pull)
+	policy := cache.CurrentPolicy()
+	useCache = useCache && policy.Enabled
 	if useCache && repoURL == "" {
-		skillCache, err := cache.New("plugins")
-		if err == nil && skillCache.Size() > 0 && !skillCache.IsStale(cache.DefaultTTL) {
-			// Return cached skills
-			var skills []model.Skill
-			for _, entry := range skillCache.Entries {
-				skills = append(skills, entry.Skill)
-			}
-			return skills, nil
+		skillCache, err := cache.New(pluginsCacheName)
+		if err == nil && skillCache.Size() > 0 && (policy.PluginsTTL <= 0 || !skillCache.IsStale(policy.PluginsTTL)) {
+			return skillCache.Skills(), nil
 		}
 	}
 
@@ -497,19 +674,25 @@ func discoverPluginSkills(repoURL string, useCache bool) ([]model.Skill, error)
 	// Only do this for local discovery (not when fetching from a specific repo)
 	if repoURL == "" {
 		cacheSkills, err := discoverClaudePluginCacheSkills(skills)
-		if err == nil {
+		if err != nil {
+			logging.Warn("failed to discover skills of installed Claude plugins", logging.Err(err))
+		} else {
 			skills = append(skills, cacheSkills...)
 		}
 	}
 
 	// Cache the results for local plugins
 	if useCache && repoURL == "" && len(skills) > 0 {
-		skillCache, err := cache.New("plugins")
-		if err == nil {
+		skillCache, err := cache.New(pluginsCacheName)
+		if err != nil {
+			logging.Warn("failed to open plugin cache", logging.Err(err))
+		} else {
 			for _, skill := range skills {
 				skillCache.Set(skill.Name, skill)
 			}
-			_ = skillCache.Save()
+			if err := skillCache.Save(); err != nil {
+				logging.Warn("failed to save plugin cache", logging.Err(err))
+			}
 		}
 	}
 
@@ -631,6 +814,8 @@ type columnWidths struct {
 	name     int
 	platform int
 	source   int
+	shadows  int // 0 when no skill shadows or is shadowed by another
+	origin   int // 0 when no skill has a recorded import origin
 	desc     int
 }
 
@@ -657,8 +842,10 @@ func clamp(value, minVal, maxVal int) int {
 // calculateColumnWidths determines optimal column widths based on content and terminal size
 func calculateColumnWidths(skills []model.Skill, termWidth int) columnWidths {
 	// Find max content width for each column
-	maxName, maxSource, maxDesc := 0, 0, 0
+	maxName, maxSource, maxDesc, maxShadows, maxOrigin := 0, 0, 0, 0, 0
 	for _, s := range skills {
+		maxShadows = max(maxShadows, len(shadowsCell(s)))
+		maxOrigin = max(maxOrigin, len(originCell(s)))
 		if len(s.Name) > maxName {
 			maxName = len(s.Name)
 		}
@@ -677,9 +864,27 @@ func calculateColumnWidths(skills []model.Skill, termWidth int) columnWidths {
 	name := clamp(maxName, 15, 35)
 	source := clamp(maxSource, 20, 60)
 
+	// SHADOWS is only shown when a skill shadows or is shadowed by another
+	shadows := 0
+	if maxShadows > 0 {
+		shadows = clamp(maxShadows, len("SHADOWS"), 24)
+	}
+
+	// ORIGIN is only shown when a skill was imported from a URL or repository
+	origin := 0
+	if maxOrigin > 0 {
+		origin = clamp(maxOrigin, len("ORIGIN"), 40)
+	}
+
 	// Allocate remaining space to description (minimum 20)
 	// 6 accounts for spacing between columns (2 spaces each gap × 3 gaps)
 	used := name + platform + source + 6
+	if shadows > 0 {
+		used += shadows + 2
+	}
+	if origin > 0 {
+		used += origin + 2
+	}
 	desc := termWidth - used
 	if desc < 20 {
 		desc = 20
@@ -689,10 +894,43 @@ func calculateColumnWidths(skills []model.Skill, termWidth int) columnWidths {
 		name:     name,
 		platform: platform,
 		source:   source,
+		shadows:  shadows,
+		origin:   origin,
 		desc:     desc,
 	}
 }
 
+// shadowsCell returns the SHADOWS cell of a skill: the scopes of the
+// versions it hides, or the scope of the skill hiding it.
+func shadowsCell(skill model.Skill) string {
+	if skill.ShadowedBy != "" {
+		return "shadowed by " + string(skill.ShadowedBy)
+	}
+	scopes := make([]string, len(skill.Shadows))
+	for i, scope := range skill.Shadows {
+		scopes[i] = string(scope)
+	}
+	return strings.Join(scopes, ", ")
+}
+
+// originCell returns the ORIGIN cell of a skill: the repository or URL it was
+// imported from, without the scheme, and the ref. Plugin skills already show
+// their plugin in SOURCE.
+func originCell(skill model.Skill) string {
+	origin, ok := skill.Origin()
+	if !ok || origin.Type == model.OriginPlugin {
+		return ""
+	}
+	cell := origin.URL
+	if _, rest, found := strings.Cut(cell, "://"); found {
+		cell = rest
+	}
+	if origin.Ref != "" {
+		cell += "@" + origin.Ref
+	}
+	return cell
+}
+
 // outputTable prints skills in a table format with colored output
 func outputTable(skills []model.Skill) error {
 	if len(skills) == 0 {
@@ -700,35 +938,53 @@ func outputTable(skills []model.Skill) error {
 		return nil
 	}
 
-	// Sort skills alphabetically by name (case-insensitive)
+	// Sort skills alphabetically by name (case-insensitive), shadowed
+	// versions after the skill that hides them, highest precedence first
 	sort.Slice(skills, func(i, j int) bool {
-		return strings.ToLower(skills[i].Name) < strings.ToLower(skills[j].Name)
+		a, b := strings.ToLower(skills[i].Name), strings.ToLower(skills[j].Name)
+		if a != b {
+			return a < b
+		}
+		if (skills[i].ShadowedBy == "") != (skills[j].ShadowedBy == "") {
+			return skills[i].ShadowedBy == ""
+		}
+		return skills[i].IsHigherPrecedence(skills[j])
 	})
 
 	// Calculate dynamic column widths based on content and terminal size
-	termWidth := getTerminalWidth()
-	widths := calculateColumnWidths(skills, termWidth)
+	widths := calculateColumnWidths(skills, tableWidth())
 
 	// Print colored headers
 	// SOURCE shows where skills come from: ~/.claude/skills (user), .claude/skills (repo),
 	// or with plugin info: ~/.claude/skills (plugin: name@marketplace)
-	fmt.Printf("%s %s %s %s\n",
+	// SHADOWS, when shown, lists the lower-precedence scopes a skill hides
+	shadowsHeader, shadowsRule := "", ""
+	if widths.shadows > 0 {
+		shadowsHeader = ui.Header(fmt.Sprintf("%-*s", widths.shadows, "SHADOWS")) + " "
+		shadowsRule = fmt.Sprintf("%-*s ", widths.shadows, "-------")
+	}
+	// ORIGIN, when shown, is where an imported skill was fetched from
+	originHeader, originRule := "", ""
+	if widths.origin > 0 {
+		originHeader = ui.Header(fmt.Sprintf("%-*s", widths.origin, "ORIGIN")) + " "
+		originRule = fmt.Sprintf("%-*s ", widths.origin, "------")
+	}
+	fmt.Printf("%s %s %s %s%s%s\n",
 		ui.Header(fmt.Sprintf("%-*s", widths.name, "NAME")),
 		ui.Header(fmt.Sprintf("%-*s", widths.platform, "PLATFORM")),
 		ui.Header(fmt.Sprintf("%-*s", widths.source, "SOURCE")),
+		shadowsHeader,
+		originHeader,
 		ui.Header(fmt.Sprintf("%-*s", widths.desc, "DESCRIPTION")))
-	fmt.Printf("%-*s %-*s %-*s %-*s\n",
+	fmt.Printf("%-*s %-*s %-*s %s%s%-*s\n",
 		widths.name, "----",
 		widths.platform, "--------",
 		widths.source, "------",
+		shadowsRule,
+		originRule,
 		widths.desc, "-----------")
 
 	for _, skill := range skills {
-		name := skill.Name
-		if len(name) > widths.name {
-			name = name[:widths.name-3] + "..."
-		}
-
 		// Sanitize description: replace newlines with spaces for table display
 		desc := strings.ReplaceAll(skill.Description, "\n", " ")
 		desc = strings.ReplaceAll(desc, "\r", "")
@@ -737,20 +993,54 @@ func outputTable(skills []model.Skill) error {
 			desc = strings.ReplaceAll(desc, "  ", " ")
 		}
 		desc = strings.TrimSpace(desc)
-		if len(desc) > widths.desc {
-			desc = desc[:widths.desc-3] + "..."
+		if skill.IsDeprecated() {
+			desc = deprecationLabel(skill) + " " + desc
 		}
 
-		// Color platform names for visual distinction
-		platform := colorPlatform(string(skill.Platform), widths.platform)
-
-		// Color source for visual distinction by scope type
-		source := colorSource(skill, widths.source)
+		names := fitCell(skill.Name, widths.name)
+		sources := fitCell(skill.DisplayScope(), widths.source)
+		descs := fitCell(desc, widths.desc)
+		var shadows, origins []string
+		if widths.shadows > 0 {
+			shadows = fitCell(shadowsCell(skill), widths.shadows)
+		}
+		if widths.origin > 0 {
+			origins = fitCell(originCell(skill), widths.origin)
+		}
+		rows := max(len(names), len(sources), len(descs), len(shadows), len(origins))
 
-		fmt.Printf("%-*s %s %s %-*s\n", widths.name, name, platform, source, widths.desc, desc)
+		for i := range rows {
+			// Color platform and source for visual distinction; continuation
+			// lines of a wrapped row leave the platform blank
+			platform := strings.Repeat(" ", widths.platform)
+			if i == 0 {
+				platform = colorPlatform(string(skill.Platform), widths.platform)
+			}
+			shadowsCol := ""
+			if widths.shadows > 0 {
+				shadowsCol = padCell(cellLine(shadows, i), widths.shadows) + " "
+				if skill.ShadowedBy != "" {
+					shadowsCol = ui.Dim(shadowsCol)
+				}
+			}
+			originCol := ""
+			if widths.origin > 0 {
+				originCol = padCell(cellLine(origins, i), widths.origin) + " "
+			}
+			fmt.Printf("%s %s %s %s%s%s\n",
+				padCell(cellLine(names, i), widths.name),
+				platform,
+				colorSource(skill, cellLine(sources, i), widths.source),
+				shadowsCol,
+				originCol,
+				padCell(cellLine(descs, i), widths.desc))
+		}
 	}
 
 	fmt.Printf("\nTotal: %d skill(s)\n", len(skills))
+	if deprecated := countDeprecated(skills); deprecated > 0 {
+		fmt.Printf("%s\n", ui.Warning(fmt.Sprintf("%d deprecated skill(s); run 'skillsync cleanup --deprecated' once replacements are synced", deprecated)))
+	}
 	return nil
 }
 
@@ -770,19 +1060,16 @@ func colorPlatform(platform string, width int) string {
 	}
 }
 
-// colorSource returns a colored source string based on the skill's scope and plugin info.
+// colorSource pads source (one line of the skill's SOURCE cell) to width and
+// colors it based on the skill's scope and plugin info.
 // Colors:
 //   - user (~/.xxx) = cyan
 //   - repo (.xxx) = green
 //   - plugin (installed) = yellow
 //   - plugin (dev symlink) = magenta
 //   - system/admin/builtin = dim
-func colorSource(skill model.Skill, width int) string {
-	source := skill.DisplayScope()
-	if len(source) > width {
-		source = source[:width-3] + "..."
-	}
-	formatted := fmt.Sprintf("%-*s", width, source)
+func colorSource(skill model.Skill, source string, width int) string {
+	formatted := padCell(source, width)
 
 	// Check for plugin symlinks first (more specific than scope)
 	if skill.PluginInfo != nil {
@@ -816,8 +1103,7 @@ func syncFlags() []cli.Flag {
 		&cli.StringFlag{
 			Name:    "strategy",
 			Aliases: []string{"s"},
-			Value:   "overwrite",
-			Usage:   "Conflict resolution strategy: overwrite, skip, newer, merge, three-way, interactive",
+			Usage:   "Conflict resolution strategy: overwrite, skip, newer, merge, three-way, interactive (default: from config, else overwrite)",
 		},
 		&cli.BoolFlag{
 			Name:  "skip-backup",
@@ -848,14 +1134,17 @@ func syncFlags() []cli.Flag {
 	}
 }
 
+// defaultSyncConcurrency is the default number of skills sync writes in parallel.
+const defaultSyncConcurrency = 4
+
 func syncCommand() *cli.Command {
 	return &cli.Command{
 		Name:      "sync",
 		Usage:     "Synchronize skills across platforms",
-		UsageText: "skillsync sync [options] <source> <target>",
+		UsageText: "skillsync sync [options] (<source> <target> [<target>...] | --profile <name>)",
 		Description: `Synchronize skills between AI coding platforms.
 
-   Supported platforms: claudecode, cursor, codex
+   Supported platforms: claudecode, cursor, codex, aider
 
    Platform spec format: platform[:scope[,scope2,...]]
      - cursor           All scopes from cursor (source), user scope (target)
@@ -877,12 +1166,14 @@ func syncCommand() *cli.Command {
      command/prompt artifacts.
 
    Strategies:
-     overwrite   - Replace target skills unconditionally (default)
-     skip        - Skip skills that already exist in target
-     newer       - Copy only if source is newer than target
-     merge       - Merge source and target content
-     three-way   - Intelligent merge with conflict detection
-     interactive - Prompt for each conflict
+` + docs.FormatEntries(docs.StrategyEntries(), "     ") + `
+   Without --strategy, the target scope's entry in sync.scope_strategies is
+   used, then sync.default_strategy, then overwrite:
+
+     sync:
+       scope_strategies:
+         repo: three-way   # shared with the team
+         user: overwrite   # mine
 
    Examples:
      skillsync sync cursor claudecode             # All cursor skills to claudecode user scope
@@ -890,16 +1181,303 @@ func syncCommand() *cli.Command {
      skillsync sync cursor:repo,user codex:repo   # Multiple source scopes to repo
      skillsync tui                                # Interactive dashboard mode
      skillsync sync --dry-run cursor codex        # Preview changes
+     skillsync sync --dry-run --show-diff cursor codex  # Review the exact file changes
+     skillsync sync --dry-run --fail-on changes claudecode cursor  # CI drift check
+     skillsync sync --map repo=repo --map user=user claudecode cursor  # Keep scopes apart
+     skillsync sync claudecode cursor codex       # Chain: claudecode -> cursor -> codex
+     skillsync sync --watch --notify-only claudecode cursor  # Report changes, apply by hand
+     skillsync sync --watch --yes claudecode cursor  # Keep cursor in step with claudecode
      skillsync sync --strategy=skip cursor codex
+     skillsync sync --force --include-plugins claudecode cursor  # Past the skill limit
      skillsync sync --include-plugins claudecode cursor  # Include plugin skills
      skillsync sync claudecode:plugin cursor      # Sync only plugin skills
      skillsync sync --include-prompts claudecode codex   # Include prompts/commands
      skillsync sync --type prompt claudecode codex       # Prompts only
+     skillsync sync --skip-deprecated claudecode cursor  # Leave deprecated skills behind
+     skillsync sync --concurrency 1 claudecode cursor    # Write skills one at a time
+     skillsync sync --progress --yes claudecode cursor   # Progress bar for large syncs
+     skillsync sync --yes --report report.json claudecode cursor  # Write a JSON run report
+     skillsync sync --workspace claudecode:user claudecode  # Fan user skills out to every repo
+     skillsync sync --workspace --skill lint claudecode claudecode
+     skillsync sync --collection python-stack claudecode cursor  # Sync a group of skills
+     skillsync sync --no-hooks claudecode cursor  # Skip configured hooks
+     skillsync sync --quarantine cursor claudecode   # Sync the valid skills, report the rest
+     skillsync sync --rewrite-references cursor:repo claudecode  # Keep @docs/... mentions working
+     skillsync sync --profile team               # Run the team profile of .skillsync.yaml
+     skillsync sync --no-rename claudecode cursor  # Keep renamed skills' old copies
+     skillsync sync --link claudecode cursor       # One canonical file, linked from cursor
+     skillsync sync --var project=acme claudecode cursor  # Fill in a template variable
+     skillsync sync --accept-suggestion claudecode cursor # Use the strategy that fits the changes
+
+   Renames:
+     When a source skill is missing from the target but a target skill that
+     is missing from the source has at least 90% of the same content, the
+     skill was most likely renamed: sync writes it under the new name and
+     removes the old one (after backing it up) instead of leaving both. The
+     summary lists the renames before asking to proceed; --no-rename creates
+     the skill as new instead. Configure with sync.rename_detection.enabled
+     and sync.rename_detection.threshold.
+
+   Strategy suggestions:
+     Before syncing, sync compares each source skill with its target copy
+     and the time the last sync wrote it (from sync history). When another
+     strategy fits better it is suggested with the reason, e.g. three-way
+     when both sides of a skill changed since the last sync, newer when
+     only targets were edited, or overwrite when targets are untouched.
+     --accept-suggestion syncs with the suggested strategy.
+
+   Collections:
+     A collection is a named group of related skills, defined under
+     collections in the config or in collections.yaml next to it.
+     --collection NAME syncs only its skills (together with any --skill),
+     and warns about those missing from the source:
+
+     python-stack:
+       description: Linting, testing, and packaging for Python
+       skills: [ruff, pytest, packaging]
+
+     See skillsync collection list.
+
+   Linking:
+     --link creates each target skill as a symlink to the source skill
+     instead of a copy, so edits on either side change the same file. A skill
+     can only be linked if the target platform reads it as it is: directory
+     skills (SKILL.md) always, single-file skills only when the target would
+     not change their file name extension or frontmatter. Others fail with
+     the reason and can be synced without --link. Skills aggregated into
+     Codex's AGENTS.md and Aider targets cannot be linked, and --link only
+     works with the overwrite, skip, and newer strategies.
+
+   Chained targets:
+     With more than one target, sync runs a chain of syncs: the source to
+     the first target, then the skills it wrote there to the next target,
+     and so on. The whole chain is shown and confirmed once and backed up as
+     one session, which backup rollback --session undoes as a whole. Each hop
+     uses the strategy for its target scope, a platform may appear only once,
+     and the interactive strategy, --workspace, --map, and --watch are not
+     supported.
+
+   Template variables:
+     A skill can declare variables in its frontmatter and use {{name}}
+     placeholders, which sync fills in on the target:
+
+     variables:
+       project:
+         description: Name of the project
+       registry: ghcr.io        # default value
+
+     Values come from --var NAME=VALUE, then vars in the config or the
+     project's .skillsync.yaml, then the defaults. Sync asks for any still
+     missing and saves the answers under vars in .skillsync.yaml; with
+     --no-input it fails with the list instead. Templated skills cannot be
+     linked.
+
+   Hooks:
+     Commands under hooks.pre_sync, hooks.post_sync, hooks.pre_skill and
+     hooks.post_skill in the config run through the shell around each sync
+     and skill write (not during --dry-run). Skill metadata is exposed as
+     SKILLSYNC_SKILL_NAME, SKILLSYNC_SKILL_PATH, SKILLSYNC_ACTION, etc.;
+     post_sync also receives counts such as SKILLSYNC_CREATED and
+     SKILLSYNC_FAILED. A failing pre_sync hook aborts the sync and a failing
+     pre_skill hook fails that skill.
+
+   Scope mapping:
+     By default every source skill goes to a single target scope. --map
+     source=target sends the skills of each source scope to its own target
+     scope instead, in one run (and one backup session). The target is then
+     given without a scope, and each mapping uses the configured strategy of
+     its target scope unless --strategy is set.
+
+   Watching:
+     --watch syncs once, then checks the source every --interval (default
+     2s) and syncs again whenever a skill is added, removed, or edited, until
+     interrupted. Because nothing is confirmed it needs --yes. With
+     --notify-only nothing is written: each change is reported as a dry run
+     with diffs, and you apply it by running the sync without --watch.
+     --metrics-addr (or sync.metrics_addr) serves Prometheus metrics of the
+     watch's syncs at /metrics: syncs by outcome, failures, conflicts, skills
+     by action, durations, and the source's skill count.
+
+     Two watches syncing in opposite directions, such as claudecode to cursor
+     and cursor to claudecode, on one machine or on machines sharing the
+     skills directories, fence their writes: each locks the target directory
+     while it syncs and records the write in .skillsync-fence.json there. A
+     source change recorded as written by another watch less than
+     --fence-window (default 30s) ago is that watch's sync, and is left alone
+     rather than synced back. --fence-window 0 turns fencing off.
+
+   Profiles and project config:
+     A .skillsync.yaml at the repository root is merged over the user config
+     for commands run inside the repository. It can add skills paths (relative
+     to the repository root), set sync strategies and types, restrict the
+     scopes syncs may write to, and define profiles, named syncs run with
+     --profile NAME. Flags given with --profile override the profile:
+
+     platforms:
+       cursor:
+         skills_paths: [tools/cursor-skills]
+     sync:
+       writable_scopes: [repo]   # never write user skills from this repo
+     profiles:
+       team:
+         source: claudecode:repo
+         target: cursor:repo
+         strategy: three-way
+
+   Workspaces:
+     --workspace syncs into the repo scope of every repository listed in
+     workspace.repos (config) or SKILLSYNC_WORKSPACE_REPOS. Source and target
+     may be the same platform; the source then defaults to user scope.
+
+   References:
+     @path mentions (See @docs/style.md) and relative Markdown links that
+     resolve from the source skill but not from the target are listed after
+     the sync. Cursor resolves @ mentions from the workspace root; Claude Code
+     and Codex from the skill file. --rewrite-references replaces them with
+     absolute paths to the source files (single-file skills only).
+
+   Skill limits:
+     Syncing more skills into a scope than its platform's max_skills soft
+     limit (claude_code 100, cursor 50, codex 50, aider 25 by default) stops
+     the sync before anything is written; --force syncs anyway and --dry-run
+     only warns. Raise or disable (0) a limit in the config or with
+     SKILLSYNC_<PLATFORM>_MAX_SKILLS:
+
+     platforms:
+       cursor:
+         max_skills: 80
+
+   Exit codes:
+     0  success
+     1  error, or skills that failed to sync
+     2  skills changed (or would change with --dry-run); only with --fail-on changes
+     3  conflicts left to resolve; only with --fail-on conflicts
+
+     Use --dry-run --fail-on changes in CI to fail when skills have drifted
+     without writing anything.
 
    See also:
      skillsync delete <source> <target>           # Remove skills from target`,
-		Flags: syncFlags(),
-		Action: func(_ context.Context, cmd *cli.Command) error {
+		Flags: append(syncFlags(),
+			&cli.BoolFlag{
+				Name:  "skip-deprecated",
+				Usage: "Do not propagate skills marked deprecated in their frontmatter",
+			},
+			&cli.BoolFlag{
+				Name:    "workspace",
+				Aliases: []string{"w"},
+				Usage:   "Sync into the repo scope of every configured workspace repository",
+			},
+			&cli.StringFlag{
+				Name:  "skill",
+				Usage: "Only sync the named skill(s). Comma-separated for multiple.",
+			},
+			&cli.StringFlag{
+				Name:  "collection",
+				Usage: "Only sync the skills of the named collection(s). Comma-separated for multiple.",
+			},
+			&cli.IntFlag{
+				Name:    "concurrency",
+				Aliases: []string{"j"},
+				Value:   defaultSyncConcurrency,
+				Usage:   "Number of skills to write in parallel (1 disables parallelism)",
+			},
+			&cli.BoolFlag{
+				Name:  "progress",
+				Usage: "Show a live progress bar while skills are written (terminals only)",
+			},
+			&cli.StringFlag{
+				Name:  "report",
+				Usage: "Write a JSON run report (per-skill actions, durations, bytes written, conflicts, backup IDs) to `FILE`",
+			},
+			&cli.BoolFlag{
+				Name:  "no-hooks",
+				Usage: "Do not run hooks configured in the hooks section of the config",
+			},
+			&cli.BoolFlag{
+				Name:  "quarantine",
+				Usage: "Leave skills that fail validation out of the sync instead of aborting it",
+			},
+			&cli.BoolFlag{
+				Name:  "no-rename",
+				Usage: "Create source skills missing from the target as new skills, even when a target skill looks like their old name",
+			},
+			&cli.BoolFlag{
+				Name:  "link",
+				Usage: "Symlink target skills to the source skills instead of copying them",
+			},
+			&cli.StringSliceFlag{
+				Name:  "var",
+				Usage: "Set a template variable of the synced skills, e.g. --var project=acme (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-input",
+				Usage: "Fail instead of prompting when template variables have no value",
+			},
+			&cli.BoolFlag{
+				Name:  "rewrite-references",
+				Usage: "Point @path mentions and relative links that would break on the target at the source files",
+			},
+			&cli.BoolFlag{
+				Name:  "show-diff",
+				Usage: "With --dry-run, print a unified diff of each file that would be created, updated, or merged",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Sync even when the target would go over its platform's max_skills soft limit",
+			},
+			&cli.BoolFlag{
+				Name:  "accept-suggestion",
+				Usage: "Sync with the strategy suggested from how the source and target diverged",
+			},
+			&cli.StringFlag{
+				Name:  "profile",
+				Usage: "Run the named sync profile from the config or the project's .skillsync.yaml",
+			},
+			&cli.StringSliceFlag{
+				Name:  "map",
+				Usage: "Sync each source scope into a target scope, e.g. --map repo=repo --map user=user (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "watch",
+				Usage: "Keep running and sync again whenever the source skills change (requires --yes or --notify-only)",
+			},
+			&cli.BoolFlag{
+				Name:  "notify-only",
+				Usage: "With --watch, print what each change would sync, with diffs, instead of applying it",
+			},
+			&cli.DurationFlag{
+				Name:  "interval",
+				Value: defaultWatchInterval,
+				Usage: "With --watch, how often to check the source for changes",
+			},
+			&cli.DurationFlag{
+				Name:  "fence-window",
+				Value: defaultFenceWindow,
+				Usage: "With --watch, how long to leave alone source changes synced by another watch (0 to disable)",
+			},
+			&cli.StringFlag{
+				Name:  "metrics-addr",
+				Usage: "With --watch, serve Prometheus metrics at http://ADDR/metrics (default: sync.metrics_addr)",
+			},
+			failOnFlag(),
+		),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Bool("watch") {
+				if cmd.IsSet("report") {
+					return errors.New("--report cannot be used with --watch")
+				}
+				return runSyncWatch(ctx, cmd)
+			}
+			if cmd.Bool("notify-only") {
+				return errors.New("--notify-only requires --watch")
+			}
+			if cmd.IsSet("metrics-addr") {
+				return errors.New("--metrics-addr requires --watch")
+			}
+			if cmd.IsSet("fence-window") {
+				return errors.New("--fence-window requires --watch")
+			}
 			return runSyncCommand(cmd, false)
 		},
 	}
@@ -912,7 +1490,7 @@ func deleteCommand() *cli.Command {
 		UsageText: "skillsync delete [options] <source> <target>",
 		Description: `Delete skills from the target platform that also exist in the source.
 
-   Supported platforms: claudecode, cursor, codex
+   Supported platforms: claudecode, cursor, codex, aider
 
    Platform spec format: platform[:scope[,scope2,...]]
      - cursor           All scopes from cursor (source), user scope (target)
@@ -936,6 +1514,12 @@ func deleteCommand() *cli.Command {
    Flags:
      Delete supports the same flags as sync (including --dry-run).
 
+   Trash:
+     Deleted skills are moved to ~/.skillsync/trash and kept for
+     sync.trash_retention_days (default 7) before being purged. A later sync
+     whose source contains the skill again restores it from the trash first.
+     Set sync.trash_retention_days to 0 to delete permanently.
+
    Examples:
      skillsync delete cursor claudecode           # Remove cursor skills from claudecode
      skillsync delete cursor:repo claudecode:user # Remove repo skills from user scope
@@ -955,6 +1539,43 @@ func runSyncCommand(cmd *cli.Command, deleteMode bool) error {
 		return err
 	}
 
+	deprecated, err := loadSyncSourceSkills(cfg)
+	if err != nil {
+		return err
+	}
+	if len(deprecated) > 0 {
+		out.Printf("Skipping %d deprecated skill(s)\n", len(deprecated))
+	}
+
+	if !cfg.dryRun {
+		purgeExpiredTrash()
+	}
+
+	// Delete mode has different flow
+	if cfg.deleteMode {
+		return syncDeleteMode(cfg)
+	}
+
+	if cfg.reportPath == "" {
+		return runSync(cfg)
+	}
+	startedAt := time.Now()
+	err = runSync(cfg)
+	if reportErr := writeSyncReport(cfg, startedAt, err); reportErr != nil {
+		if err != nil {
+			stderrWarnf("Warning: %v\n", reportErr)
+			return err
+		}
+		return reportErr
+	}
+	return err
+}
+
+// loadSyncSourceSkills parses the source skills of a sync into
+// cfg.sourceSkills, applying the type, name, and deprecation filters. It
+// returns the deprecated skills left out by --skip-deprecated.
+func loadSyncSourceSkills(cfg *syncConfig) ([]model.Skill, error) {
+	var err error
 	// Always parse source skills (use tiered parser for scope filtering)
 	// Plugin scope skills are excluded by default unless --include-plugins is set
 	// or the plugin scope is explicitly in the source spec (e.g., "claudecode:plugin")
@@ -966,15 +1587,51 @@ func runSyncCommand(cmd *cli.Command, deleteMode bool) error {
 		cfg.sourceSkills, err = parsePlatformSkillsWithScope(cfg.sourceSpec.Platform, nil, cfg.includePlugins)
 	}
 	if err != nil {
-		return fmt.Errorf("failed to parse source skills: %w", err)
+		return nil, fmt.Errorf("failed to parse source skills: %w", err)
 	}
 
 	// Apply artifact type filter policy for sync/delete commands.
 	cfg.sourceSkills = filterBySkillType(cfg.sourceSkills, cfg.typeFilter)
 
-	// Delete mode has different flow
-	if cfg.deleteMode {
-		return syncDeleteMode(cfg)
+	if len(cfg.skillNames) > 0 || len(cfg.collections) > 0 {
+		names := slices.Clone(cfg.skillNames)
+		for _, collection := range cfg.collections {
+			names = append(names, collection.Skills...)
+			if missing := missingSkills(cfg.sourceSkills, collection.Skills); len(missing) > 0 {
+				warnf("Warning: collection %s: not in the source: %s\n", collection.name, strings.Join(missing, ", "))
+			}
+		}
+		cfg.sourceSkills = filterSkillsByName(cfg.sourceSkills, names)
+		if len(cfg.sourceSkills) == 0 {
+			return nil, fmt.Errorf("no source skills match %s", skillSelection(cfg))
+		}
+	}
+
+	var deprecated []model.Skill
+	if cfg.skipDeprecated {
+		cfg.sourceSkills, deprecated = filterDeprecated(cfg.sourceSkills)
+	}
+	if !cfg.deleteMode {
+		if err := resolveSkillVariables(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return deprecated, nil
+}
+
+// runSync syncs the loaded source skills into the target: into every
+// workspace repository, per scope mapping, or into the target scope.
+func runSync(cfg *syncConfig) error {
+	if cfg.workspace {
+		return runWorkspaceSync(cfg)
+	}
+
+	if len(cfg.scopeMappings) > 0 {
+		return runMappedSync(cfg)
+	}
+
+	if len(cfg.chain) > 0 {
+		return runChainSync(cfg)
 	}
 
 	// Validate source skills before sync (unless skipped)
@@ -984,6 +1641,14 @@ func runSyncCommand(cmd *cli.Command, deleteMode bool) error {
 		}
 	}
 
+	if err := checkScopeSkillLimit(cfg, cfg.targetSpec.TargetScope(), cfg.sourceSkills); err != nil {
+		return err
+	}
+
+	if cfg.suggest {
+		suggestSyncStrategy(cfg)
+	}
+
 	// Show summary and request confirmation (unless --yes or --dry-run)
 	if !cfg.dryRun && !cfg.yesFlag {
 		confirmed, err := showSyncSummaryAndConfirm(cfg)
@@ -991,53 +1656,56 @@ func runSyncCommand(cmd *cli.Command, deleteMode bool) error {
 			return fmt.Errorf("confirmation error: %w", err)
 		}
 		if !confirmed {
-			fmt.Println("Sync cancelled by user")
+			out.Println("Sync cancelled by user")
 			return nil
 		}
 	}
 
-	// Create backup before sync (unless skipped or dry-run)
-	if !cfg.dryRun && !cfg.skipBackup {
-		prepareBackup(cfg.targetSpec.Platform)
-		created, err := backupExistingTargetSkills(
-			cfg.targetSpec.Platform,
-			cfg.targetSpec.TargetScope(),
-			cfg.sourceSkills,
-			"pre-sync backup",
-			[]string{"sync"},
-		)
-		if err != nil {
-			return err
-		}
-		if created > 0 {
-			fmt.Printf("✓ Created %d backup(s)\n", created)
-		}
-	}
-
-	// Create sync options and execute
+	// Create sync options and execute. The engine backs up each target file
+	// right before overwriting it (unless skipped or dry-run).
 	opts := sync.Options{
-		DryRun:      cfg.dryRun,
-		Strategy:    cfg.strategy,
-		TargetScope: cfg.targetSpec.TargetScope(),
-	}
-
+		DryRun:            cfg.dryRun,
+		Strategy:          cfg.strategy,
+		TargetScope:       cfg.targetSpec.TargetScope(),
+		Concurrency:       cfg.concurrency,
+		Hooks:             cfg.hooks,
+		TrashRetention:    cfg.trashRetention,
+		RewriteReferences: cfg.rewriteReferences,
+		Metrics:           cfg.metrics,
+		Preview:           cfg.showDiff,
+		RenameThreshold:   cfg.renameThreshold,
+		Mode:              cfg.mode,
+		Vars:              cfg.vars,
+	}
+	if !cfg.dryRun {
+		opts.SessionID = backup.NewSessionID()
+		if !cfg.skipBackup {
+			prepareBackup(cfg.targetSpec.Platform)
+			opts.Backup = true
+		}
+	}
+
+	startedAt := time.Now()
+	progress, doneProgress := syncProgress(cfg.progress, "Syncing", len(cfg.sourceSkills))
+	opts.Progress = progress
 	syncer := sync.New()
 	result, err := syncer.SyncWithSkills(cfg.sourceSkills, cfg.targetSpec.Platform, opts)
+	doneProgress()
 	if err != nil {
 		return fmt.Errorf("sync failed: %w", err)
 	}
+	cfg.result = result
+	defer cfg.recordRun("", startedAt, result)
 
-	// Handle conflicts if interactive strategy is used
-	if result.HasConflicts() && cfg.strategy == sync.StrategyInteractive {
-		resolver := NewConflictResolver()
-
-		// Gather conflicts
-		var conflicts []*sync.Conflict
-		for _, sr := range result.Conflicts() {
-			if sr.Conflict != nil {
-				conflicts = append(conflicts, sr.Conflict)
-			}
+	// Handle conflicts with the preference for the platform pair, or
+	// interactively if the interactive strategy is used
+	if result.HasConflicts() && cfg.resolution != "" {
+		if err := applyResolutionPreference(cfg, result, opts.Backup); err != nil {
+			return err
 		}
+	} else if result.HasConflicts() && cfg.strategy == sync.StrategyInteractive {
+		resolver := NewConflictResolver()
+		conflicts := resultConflicts(result)
 
 		// Display summary and resolve
 		resolver.DisplayConflictSummary(conflicts)
@@ -1048,53 +1716,152 @@ func runSyncCommand(cmd *cli.Command, deleteMode bool) error {
 
 		// Apply resolved content
 		if !cfg.dryRun {
-			if err := applyResolvedConflicts(result, resolved); err != nil {
+			if err := applyResolvedConflicts(result, resolved, opts.Backup); err != nil {
 				return fmt.Errorf("failed to apply resolved conflicts: %w", err)
 			}
 		}
 
-		fmt.Printf("\nResolved %d conflict(s)\n", len(resolved))
+		out.Printf("\nResolved %d conflict(s)\n", len(resolved))
 	}
 
-	displaySyncResults(result)
+	result.Skills = append(result.Skills, cfg.quarantined...)
+	recordHistory(opts.SessionID, "sync", startedAt, result)
+
+	if err := displaySyncResults(result); err != nil {
+		return err
+	}
 
 	if !result.Success() {
 		return errors.New("sync completed with errors")
 	}
 
-	return nil
+	return cfg.failOn.check(result)
+}
+
+// suggestSyncStrategy prints the strategy that fits how the source and
+// target skills diverged, with its rationale, when it is not the one in use,
+// and switches to it with --accept-suggestion. Divergence is judged from the sync history; when the
+// target or history cannot be read, no suggestion is made.
+func suggestSyncStrategy(cfg *syncConfig) {
+	targets, err := parsePlatformSkillsWithScope(cfg.targetSpec.Platform, []model.SkillScope{cfg.targetSpec.TargetScope()}, false)
+	if err != nil {
+		return
+	}
+	lastSynced, err := history.LastWritten()
+	if err != nil {
+		stderrWarnf("Warning: no strategy suggestion, failed to read sync history: %v\n", err)
+		return
+	}
+	suggestion := sync.SuggestStrategy(cfg.sourceSkills, targets, lastSynced)
+	if suggestion == nil || suggestion.Strategy == cfg.strategy {
+		return
+	}
+
+	out.Printf("Suggested strategy: %s (%s)\n", suggestion.Strategy, suggestion.Reason)
+	if !cfg.acceptSuggestion {
+		out.Printf("  Run with --accept-suggestion to use it instead of %s\n", cfg.strategy)
+		return
+	}
+	if cfg.mode == sync.ModeLink {
+		if err := checkLinkSync(cfg.targetSpec.Platform, suggestion.Strategy, nil); err != nil {
+			warnf("Warning: keeping strategy %s: %v\n", cfg.strategy, err)
+			return
+		}
+	}
+
+	cfg.strategy, cfg.strategySource = suggestion.Strategy, "suggested"
+	if suggestion.Strategy == sync.StrategyThreeWay && cfg.resolution == "" {
+		if appConfig, err := config.Load(); err == nil {
+			cfg.resolution, cfg.resolutionKey, _ = appConfig.ResolutionPreference(cfg.sourceSpec.Platform, cfg.targetSpec.Platform)
+		}
+	}
 }
 
 // syncConfig holds the parsed configuration for a sync command
 type syncConfig struct {
-	sourceSpec     model.PlatformSpec
-	targetSpec     model.PlatformSpec
-	dryRun         bool
-	strategy       sync.Strategy
-	skipBackup     bool
-	skipValidation bool
-	yesFlag        bool
-	deleteMode     bool
-	includePlugins bool
-	typeFilter     []model.SkillType
-	sourceSkills   []model.Skill
+	sourceSpec        model.PlatformSpec
+	targetSpec        model.PlatformSpec
+	dryRun            bool
+	strategy          sync.Strategy
+	strategySource    string // Where the strategy came from when --strategy was not given
+	suggest           bool   // Suggest a strategy from how the source and target diverged
+	acceptSuggestion  bool   // Sync with the suggested strategy (--accept-suggestion)
+	skipBackup        bool
+	skipValidation    bool
+	yesFlag           bool
+	deleteMode        bool
+	includePlugins    bool
+	skipDeprecated    bool
+	workspace         bool
+	skillNames        []string
+	collections       []syncCollection // Collections from --collection, whose skills are synced along with skillNames
+	typeFilter        []model.SkillType
+	concurrency       int
+	progress          bool   // Draw a progress bar while the engine writes skills
+	reportPath        string // File sync --report writes the run report to
+	hooks             sync.Hooks
+	trashRetention    time.Duration
+	quarantine        bool
+	rewriteReferences bool
+	renameThreshold   float64               // Content similarity at which a target skill counts as renamed, 0 to disable
+	mode              sync.Mode             // Copy skills to the target or link them to the source (--link)
+	vars              map[string]string     // Template variable values from the config and --var; prompted values are added
+	noInput           bool                  // Fail on template variables without a value instead of prompting
+	resolution        sync.ResolutionChoice // Resolution of three-way and interactive conflicts from sync.resolution_preferences
+	resolutionKey     string                // The sync.resolution_preferences entry resolution comes from
+	showDiff          bool                  // Print unified diffs of the files a dry run would write
+	failOn            failOnConditions      // Sync outcomes besides failed skills that exit non-zero
+	scopeMappings     []scopeMapping        // Source scope to target scope pairs from --map
+	chain             []syncHop             // Further targets of a chained sync, after source -> target
+	metrics           bool                  // Maintain the skillsync-metrics frontmatter block (sync.metrics)
+	metricsAddr       string                // Address sync --watch serves Prometheus metrics on (sync.metrics_addr)
+	maxSkills         int                   // Soft limit on skills per target scope (platforms.<name>.max_skills)
+	force             bool                  // Sync past maxSkills
+	schema            *validation.Schema    // Frontmatter schema checked during validation, if configured
+	sourceSkills      []model.Skill
+	// quarantined holds source skills excluded by --quarantine, reported
+	// alongside the sync result.
+	quarantined []sync.SkillResult
+	// result is set by runSync to the result of the sync, once it has run.
+	result *sync.Result
+	// runs are the syncs run so far, for the run report (see recordRun).
+	runs []syncRun
 }
 
 // parseSyncConfig parses and validates sync command arguments and flags
 func parseSyncConfig(cmd *cli.Command, commandName string, deleteMode bool) (*syncConfig, error) {
-	args := cmd.Args()
-	if args.Len() != 2 {
-		return nil, fmt.Errorf("%s requires exactly 2 arguments: <source> <target>", commandName)
-	}
-
-	// Parse source platform spec (e.g., "cursor", "cursor:repo", "cursor:repo,user")
-	sourceSpec, err := model.ParsePlatformSpec(args.Get(0))
+	appConfig, err := config.Load()
 	if err != nil {
-		return nil, fmt.Errorf("invalid source: %w", err)
+		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Parse target platform spec (e.g., "claudecode", "claudecode:user")
-	targetSpec, err := model.ParsePlatformSpec(args.Get(1))
+	profile, err := syncProfile(cmd, appConfig, deleteMode)
+	if err != nil {
+		return nil, err
+	}
+	sourceArg, targetArg := profile.Source, profile.Target
+	var chainArgs []string
+	if args := cmd.Args(); profile.Source == "" {
+		switch {
+		case deleteMode && args.Len() != 2:
+			return nil, fmt.Errorf("%s requires exactly 2 arguments: <source> <target>", commandName)
+		case args.Len() < 2:
+			return nil, fmt.Errorf("%s requires at least 2 arguments: <source> <target> [<target>...]", commandName)
+		}
+		sourceArg, targetArg = args.Get(0), args.Get(1)
+		chainArgs = args.Slice()[2:]
+	} else if args.Len() != 0 {
+		return nil, fmt.Errorf("--profile sets the source and target, remove the %s arguments", commandName)
+	}
+
+	// Parse source platform spec (e.g., "cursor", "cursor:repo", "cursor:repo,user")
+	sourceSpec, err := model.ParsePlatformSpec(sourceArg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source: %w", err)
+	}
+
+	// Parse target platform spec (e.g., "claudecode", "claudecode:user")
+	targetSpec, err := model.ParsePlatformSpec(targetArg)
 	if err != nil {
 		return nil, fmt.Errorf("invalid target: %w", err)
 	}
@@ -1104,105 +1871,385 @@ func parseSyncConfig(cmd *cli.Command, commandName string, deleteMode bool) (*sy
 		return nil, fmt.Errorf("invalid target: %w", err)
 	}
 
-	if sourceSpec.Platform == targetSpec.Platform {
+	workspace := !deleteMode && cmd.Bool("workspace")
+	if workspace {
+		if err := resolveWorkspaceSyncSpecs(&sourceSpec, &targetSpec); err != nil {
+			return nil, err
+		}
+	} else if sourceSpec.Platform == targetSpec.Platform {
 		return nil, fmt.Errorf("source and target platforms cannot be the same: %s", sourceSpec.Platform)
 	}
 
+	showDiff := !deleteMode && cmd.Bool("show-diff")
+	if showDiff && !cmd.Bool("dry-run") {
+		return nil, errors.New("--show-diff requires --dry-run")
+	}
+
+	renameThreshold := appConfig.RenameThreshold()
+	if deleteMode || cmd.Bool("no-rename") {
+		renameThreshold = 0
+	}
+
+	var failOn failOnConditions
+	var scopeMappings []scopeMapping
+	if !deleteMode {
+		if failOn, err = parseFailOn(cmd.String("fail-on")); err != nil {
+			return nil, err
+		}
+		mapFlags := cmd.StringSlice("map")
+		if !cmd.IsSet("map") {
+			mapFlags = profile.Map
+		}
+		if scopeMappings, err = parseScopeMappings(mapFlags); err != nil {
+			return nil, err
+		}
+		if workspace && len(scopeMappings) > 0 {
+			return nil, errors.New("--map cannot be combined with --workspace")
+		}
+	}
+
+	var skillNames []string
+	if !deleteMode && !cmd.IsSet("skill") {
+		skillNames = profile.Skills
+	} else if !deleteMode {
+		for _, name := range strings.Split(cmd.String("skill"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				skillNames = append(skillNames, name)
+			}
+		}
+	}
+	var collections []syncCollection
+	if !deleteMode {
+		if collections, err = syncCollections(cmd, appConfig, profile); err != nil {
+			return nil, err
+		}
+	}
+
 	typeFilter, err := resolveSyncTypeFilter(cmd)
 	if err != nil {
 		return nil, err
 	}
 
-	strategyStr := cmd.String("strategy")
-	strategy := sync.Strategy(strategyStr)
+	concurrency := 1
+	if !deleteMode {
+		concurrency = int(cmd.Int("concurrency"))
+		if concurrency < 1 {
+			return nil, fmt.Errorf("--concurrency must be at least 1, got %d", concurrency)
+		}
+	}
+
+	strategy, strategySource := resolveSyncStrategy(cmd, appConfig, targetSpec.TargetScope())
 	if !strategy.IsValid() {
-		return nil, fmt.Errorf("invalid strategy %q (valid: overwrite, skip, newer, merge, three-way, interactive)", strategyStr)
+		return nil, fmt.Errorf("invalid strategy %q (valid: overwrite, skip, newer, merge, three-way, interactive)", strategy)
+	}
+	if len(scopeMappings) > 0 {
+		if err := resolveScopeMappings(cmd, appConfig, scopeMappings, &sourceSpec, targetSpec); err != nil {
+			return nil, err
+		}
+	}
+
+	var chain []syncHop
+	if len(chainArgs) > 0 {
+		switch {
+		case workspace || len(scopeMappings) > 0 || cmd.Bool("watch"):
+			return nil, errors.New("chained targets cannot be used with --workspace, --map, or --watch")
+		case cmd.Bool("accept-suggestion"):
+			return nil, errors.New("--accept-suggestion cannot be used with chained targets")
+		}
+		if chain, err = parseSyncChain(cmd, appConfig, sourceSpec, targetSpec, chainArgs); err != nil {
+			return nil, err
+		}
+	}
+
+	targetScopes := syncTargetScopes(targetSpec, scopeMappings)
+	for _, hop := range chain {
+		targetScopes = append(targetScopes, hop.target.TargetScope())
+	}
+	if err := checkWritableScopes(appConfig, targetScopes); err != nil {
+		return nil, err
+	}
+
+	mode := sync.ModeCopy
+	if !deleteMode && cmd.Bool("link") {
+		if err := checkLinkSync(targetSpec.Platform, strategy, scopeMappings); err != nil {
+			return nil, err
+		}
+		for _, hop := range chain {
+			if err := checkLinkSync(hop.target.Platform, hop.strategy, nil); err != nil {
+				return nil, err
+			}
+		}
+		mode = sync.ModeLink
+	}
+
+	acceptSuggestion := !deleteMode && cmd.Bool("accept-suggestion")
+	if acceptSuggestion {
+		switch {
+		case cmd.IsSet("strategy"):
+			return nil, errors.New("--accept-suggestion chooses the strategy, remove --strategy")
+		case workspace || len(scopeMappings) > 0 || cmd.Bool("watch"):
+			return nil, errors.New("--accept-suggestion cannot be used with --workspace, --map, or --watch")
+		}
+	}
+
+	vars, err := syncVars(cmd, appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolution sync.ResolutionChoice
+	var resolutionKey string
+	if strategy == sync.StrategyThreeWay || strategy == sync.StrategyInteractive {
+		resolution, resolutionKey, _ = appConfig.ResolutionPreference(sourceSpec.Platform, targetSpec.Platform)
+	}
+
+	var maxSkills int
+	if platformConfig, ok := appConfig.Platforms.Platform(targetSpec.Platform); ok && !deleteMode {
+		maxSkills = platformConfig.MaxSkills
+	}
+
+	var hooks sync.Hooks
+	if !deleteMode && !cmd.Bool("no-hooks") {
+		hooks = appConfig.Hooks
+	}
+
+	var schema *validation.Schema
+	if !deleteMode && !cmd.Bool("skip-validation") {
+		if schema, err = loadFrontmatterSchema(appConfig); err != nil {
+			return nil, err
+		}
 	}
 
 	return &syncConfig{
-		sourceSpec:     sourceSpec,
-		targetSpec:     targetSpec,
-		dryRun:         cmd.Bool("dry-run"),
-		strategy:       strategy,
-		skipBackup:     cmd.Bool("skip-backup"),
-		skipValidation: cmd.Bool("skip-validation"),
-		yesFlag:        cmd.Bool("yes"),
-		deleteMode:     deleteMode,
-		includePlugins: cmd.Bool("include-plugins"),
-		typeFilter:     typeFilter,
-		sourceSkills:   make([]model.Skill, 0),
+		sourceSpec:        sourceSpec,
+		targetSpec:        targetSpec,
+		dryRun:            cmd.Bool("dry-run"),
+		strategy:          strategy,
+		strategySource:    strategySource,
+		suggest:           !deleteMode && !cmd.Bool("watch"),
+		acceptSuggestion:  acceptSuggestion,
+		skipBackup:        skipBackup(cmd),
+		skipValidation:    cmd.Bool("skip-validation"),
+		yesFlag:           cmd.Bool("yes"),
+		deleteMode:        deleteMode,
+		includePlugins:    cmd.Bool("include-plugins") || profile.IncludePlugins,
+		skipDeprecated:    !deleteMode && cmd.Bool("skip-deprecated"),
+		workspace:         workspace,
+		skillNames:        skillNames,
+		collections:       collections,
+		typeFilter:        typeFilter,
+		concurrency:       concurrency,
+		progress:          cmd.Bool("progress"),
+		reportPath:        cmd.String("report"),
+		hooks:             hooks,
+		trashRetention:    appConfig.TrashRetention(),
+		quarantine:        !deleteMode && cmd.Bool("quarantine"),
+		rewriteReferences: !deleteMode && cmd.Bool("rewrite-references"),
+		renameThreshold:   renameThreshold,
+		mode:              mode,
+		vars:              vars,
+		noInput:           cmd.Bool("no-input"),
+		resolution:        resolution,
+		resolutionKey:     resolutionKey,
+		showDiff:          showDiff,
+		failOn:            failOn,
+		scopeMappings:     scopeMappings,
+		chain:             chain,
+		metrics:           appConfig.Sync.Metrics,
+		metricsAddr:       cmp.Or(cmd.String("metrics-addr"), appConfig.Sync.MetricsAddr),
+		maxSkills:         maxSkills,
+		force:             !deleteMode && cmd.Bool("force"),
+		schema:            schema,
+		sourceSkills:      make([]model.Skill, 0),
 	}, nil
 }
 
+// checkLinkSync refuses --link syncs into platforms that do not read linked
+// skills and with strategies that would write merged content.
+func checkLinkSync(target model.Platform, strategy sync.Strategy, mappings []scopeMapping) error {
+	if err := sync.CheckLinkTarget(target); err != nil {
+		return fmt.Errorf("--link: %w", err)
+	}
+	strategies := []sync.Strategy{strategy}
+	for _, m := range mappings {
+		strategies = append(strategies, m.strategy)
+	}
+	for _, s := range strategies {
+		switch s {
+		case sync.StrategyOverwrite, sync.StrategySkip, sync.StrategyNewer:
+		default:
+			return fmt.Errorf("--link cannot be used with the %s strategy, which writes merged content (use overwrite, skip, or newer)", s)
+		}
+	}
+	return nil
+}
+
+// resolveSyncStrategy returns the strategy for a sync into targetScope and,
+// when --strategy was not given, a note on which config default supplied it.
+func resolveSyncStrategy(cmd *cli.Command, appConfig *config.Config, targetScope model.SkillScope) (sync.Strategy, string) {
+	if cmd.IsSet("strategy") {
+		return sync.Strategy(cmd.String("strategy")), ""
+	}
+	if name := cmd.String("profile"); name != "" && appConfig.Profiles[name].Strategy != "" {
+		return sync.Strategy(appConfig.Profiles[name].Strategy), "profile " + name
+	}
+	strategy, scoped := appConfig.StrategyForScope(string(targetScope))
+	if scoped {
+		return strategy, fmt.Sprintf("default for %s scope", targetScope)
+	}
+	return strategy, "default"
+}
+
 // validateSourceSkills validates source skills (assumes skills are already parsed in cfg.sourceSkills)
 func validateSourceSkills(cfg *syncConfig) error {
-	fmt.Println("Validating source skills...")
+	out.Println("Validating source skills...")
 
 	// Validate skill formats
-	formatResult, err := validation.ValidateSkillsFormat(cfg.sourceSkills, cfg.sourceSpec.Platform)
+	formatResult, err := validateSkillFormats(cfg)
 	if err != nil {
-		return fmt.Errorf("validation error: %w", err)
+		return err
 	}
 
 	// Show warnings
 	for _, warning := range formatResult.Warnings {
-		fmt.Printf("  Warning: %s\n", warning)
+		warnf("  Warning: %s\n", warning)
 	}
 
 	// Check for validation errors
-	if formatResult.HasErrors() {
-		fmt.Println("\nValidation failed - the following issues were found:")
+	if formatResult.HasErrors() && cfg.quarantine {
+		if err := quarantineInvalidSkills(cfg, formatResult); err != nil {
+			return err
+		}
+	} else if formatResult.HasErrors() && canReviewValidation(cfg) {
+		if err := reviewValidationErrors(cfg, formatResult); err != nil {
+			return err
+		}
+	} else if formatResult.HasErrors() {
+		out.Println("\nValidation failed - the following issues were found:")
 		for i, e := range formatResult.Errors {
-			fmt.Printf("  %d. %s\n", i+1, formatValidationError(e, cfg.sourceSkills))
+			out.Printf("  %d. %s\n", i+1, formatValidationError(e, cfg.sourceSkills))
 		}
 		return errors.New("skill validation failed - fix the issues above and try again")
 	}
 
 	if len(cfg.sourceSkills) == 0 {
-		fmt.Println("  No skills found in source directory")
+		out.Println("  No skills found in source directory")
 	} else {
-		fmt.Printf("  Found %d valid skill(s)\n", len(cfg.sourceSkills))
+		out.Printf("  Found %d valid skill(s)\n", len(cfg.sourceSkills))
 	}
 
 	// Validate target path and permissions
 	// Note: Skip source path validation since skills were already successfully parsed
 	// from potentially multiple scopes (project, user, admin, system). The primary
 	// platform path may not exist, but that's fine if other scopes have skills.
-	if err := validateTargetPath(cfg.targetSpec.Platform); err != nil {
-		return err
+	// Workspace syncs write into each repository instead of the platform path.
+	if !cfg.workspace {
+		if err := validateTargetPath(cfg.targetSpec.Platform); err != nil {
+			return err
+		}
 	}
 
-	fmt.Println("Validation passed")
+	out.Println("Validation passed")
+	return nil
+}
+
+// quarantineInvalidSkills moves the skills that failed validation from
+// cfg.sourceSkills into cfg.quarantined so the valid ones can still be synced.
+// Errors that do not belong to a single skill still abort the sync.
+func quarantineInvalidSkills(cfg *syncConfig, formatResult *validation.Result) error {
+	grouped := validation.SkillErrors(formatResult)
+	if general := grouped[-1]; len(general) > 0 {
+		out.Println("\nValidation failed - the following issues were found:")
+		for i, e := range general {
+			out.Printf("  %d. %s\n", i+1, formatValidationError(e, cfg.sourceSkills))
+		}
+		return errors.New("skill validation failed - fix the issues above and try again")
+	}
+
+	valid := make([]model.Skill, 0, len(cfg.sourceSkills))
+	out.Println("\nQuarantined skills (failed validation, will not be synced):")
+	for i, skill := range cfg.sourceSkills {
+		errs, invalid := grouped[i]
+		if !invalid {
+			valid = append(valid, skill)
+			continue
+		}
+
+		reasons := make([]string, 0, len(errs))
+		for _, e := range errs {
+			var vErr *validation.Error
+			if errors.As(e, &vErr) {
+				reasons = append(reasons, vErr.Message)
+			} else {
+				reasons = append(reasons, e.Error())
+			}
+		}
+		// Nameless skills are reported by path so they can be found and fixed
+		if skill.Name == "" {
+			skill.Name = skill.Path
+		}
+		message := strings.Join(reasons, "; ")
+		out.Printf("  - %s (%s): %s\n", skill.Name, skill.Path, message)
+		cfg.quarantined = append(cfg.quarantined, sync.SkillResult{
+			Skill:   skill,
+			Action:  sync.ActionQuarantined,
+			Message: message,
+		})
+	}
+
+	if len(valid) == 0 {
+		return errors.New("every source skill failed validation - nothing left to sync")
+	}
+	cfg.sourceSkills = valid
 	return nil
 }
 
 // showSyncSummaryAndConfirm shows sync summary and requests user confirmation
 func showSyncSummaryAndConfirm(cfg *syncConfig) (bool, error) {
-	fmt.Printf("\n=== Sync Summary ===\n")
-	fmt.Printf("Source: %s\n", cfg.sourceSpec)
-	fmt.Printf("Target: %s\n", cfg.targetSpec)
-	fmt.Printf("Strategy: %s (%s)\n", cfg.strategy, cfg.strategy.Description())
+	out.Printf("\n=== Sync Summary ===\n")
+	out.Printf("Source: %s\n", cfg.sourceSpec)
+	out.Printf("Target: %s\n", cfg.targetSpec)
+	if cfg.strategySource != "" {
+		out.Printf("Strategy: %s (%s) [%s]\n", cfg.strategy, cfg.strategy.Description(), cfg.strategySource)
+	} else {
+		out.Printf("Strategy: %s (%s)\n", cfg.strategy, cfg.strategy.Description())
+	}
 	if len(cfg.typeFilter) > 0 {
 		typeNames := make([]string, 0, len(cfg.typeFilter))
 		for _, t := range cfg.typeFilter {
 			typeNames = append(typeNames, t.String())
 		}
-		fmt.Printf("Types: %s\n", strings.Join(typeNames, ", "))
+		out.Printf("Types: %s\n", strings.Join(typeNames, ", "))
 	}
 
 	if len(cfg.sourceSkills) > 0 {
-		fmt.Printf("Skills to sync: %d\n", len(cfg.sourceSkills))
+		out.Printf("Skills to sync: %d\n", len(cfg.sourceSkills))
 		for i, skill := range cfg.sourceSkills {
 			scopeStr := string(skill.Scope)
 			if scopeStr == "" {
 				scopeStr = "-"
 			}
-			fmt.Printf("  %d. %s [%s]\n", i+1, skill.Name, scopeStr)
+			out.Printf("  %d. %s [%s]\n", i+1, skill.Name, scopeStr)
+		}
+	}
+
+	if cfg.resolution != "" {
+		out.Printf("Conflicts: resolved with %s (sync.resolution_preferences %s)\n", cfg.resolution, cfg.resolutionKey)
+	}
+
+	if len(cfg.quarantined) > 0 {
+		out.Printf("Quarantined (not synced): %d\n", len(cfg.quarantined))
+	}
+
+	if renames := plannedRenames(cfg); len(renames) > 0 {
+		out.Printf("Renames detected: %d (--no-rename creates them as new skills instead)\n", len(renames))
+		for _, sr := range renames {
+			out.Printf("  %s -> %s\n", sr.RenamedFrom, sr.Skill.Name)
 		}
 	}
 
 	if cfg.skipBackup {
-		fmt.Println("Warning: Backup will be skipped (--skip-backup flag)")
+		out.Println("Warning: Backup will be skipped (--skip-backup flag)")
 	}
 
 	// Determine risk level
@@ -1214,22 +2261,65 @@ func showSyncSummaryAndConfirm(cfg *syncConfig) (bool, error) {
 	return confirmAction("Proceed with sync?", level)
 }
 
+// plannedRenames returns the source skills a sync would write over a target
+// skill under another name, found by a dry run of the sync.
+func plannedRenames(cfg *syncConfig) []sync.SkillResult {
+	if cfg.renameThreshold <= 0 || len(cfg.scopeMappings) > 0 || cfg.workspace {
+		return nil
+	}
+	result, err := sync.New().SyncWithSkills(cfg.sourceSkills, cfg.targetSpec.Platform, sync.Options{
+		DryRun:          true,
+		Strategy:        cfg.strategy,
+		TargetScope:     cfg.targetSpec.TargetScope(),
+		RenameThreshold: cfg.renameThreshold,
+		Vars:            cfg.vars,
+	})
+	if err != nil {
+		return nil
+	}
+	return result.Renamed()
+}
+
+// purgeExpiredTrash permanently removes trashed skills whose retention period
+// has passed. The trash is left alone with --no-persist.
+func purgeExpiredTrash() {
+	if util.NoPersist() {
+		return
+	}
+	purged, err := trash.Purge(time.Now())
+	if err != nil {
+		warnf("Warning: trash cleanup failed: %v\n", err)
+	} else if len(purged) > 0 {
+		out.Printf("Purged %d expired skill(s) from trash\n", len(purged))
+	}
+}
+
+// skipBackup reports whether a command should not back up what it changes,
+// either because --skip-backup was given or because --no-persist leaves
+// nowhere to keep backups.
+func skipBackup(cmd *cli.Command) bool {
+	return cmd.Bool("skip-backup") || util.NoPersist()
+}
+
 // prepareBackup runs backup cleanup before sync
 func prepareBackup(targetPlatform model.Platform) {
-	fmt.Println("\nPreparing backups...")
+	out.Println("\nPreparing backups...")
 
-	// Run automatic cleanup to maintain retention policy
+	// Run automatic cleanup to maintain the retention policy of the config
 	cleanupOpts := backup.DefaultCleanupOptions()
 	cleanupOpts.Platform = string(targetPlatform)
+	if cfg, err := config.Load(); err == nil {
+		cleanupOpts = cfg.BackupRetention(string(targetPlatform))
+	}
 
 	deleted, err := backup.CleanupBackups(cleanupOpts)
 	if err != nil {
-		fmt.Printf("Warning: backup cleanup failed: %v\n", err)
+		warnf("Warning: backup cleanup failed: %v\n", err)
 	} else if len(deleted) > 0 {
-		fmt.Printf("Cleaned up %d old backup(s)\n", len(deleted))
+		out.Printf("Cleaned up %d old backup(s)\n", len(deleted))
 	}
 
-	fmt.Println("Backup cleanup complete")
+	out.Println("Backup cleanup complete")
 }
 
 func createBackupsForSkills(platform model.Platform, skills []model.Skill, description string, tags []string) (int, error) {
@@ -1262,6 +2352,77 @@ func createBackupsForSkills(platform model.Platform, skills []model.Skill, descr
 	return created, nil
 }
 
+// createSnapshotBackups backs up every file of the given skills as part of
+// one snapshot: the skill file itself, and for directory skills every file
+// under the skill directory. Each file keeps its own lineage so retention
+// treats a skill's scripts separately from its SKILL.md.
+func createSnapshotBackups(platform model.Platform, skills []model.Skill, snapshotID string) (int, error) {
+	created := 0
+	seen := make(map[string]bool)
+	backupFile := func(skill model.Skill, path, lineage string) error {
+		if seen[path] {
+			return nil
+		}
+		seen[path] = true
+
+		metadata := map[string]string{
+			"skill": skill.Name,
+		}
+		if skill.Scope != "" {
+			metadata["scope"] = string(skill.Scope)
+		}
+		opts := backup.Options{
+			Platform:    string(platform),
+			Description: "snapshot",
+			Metadata:    metadata,
+			Tags:        []string{"snapshot"},
+			Lineage:     lineage,
+			SnapshotID:  snapshotID,
+		}
+		if _, err := backup.CreateBackup(path, opts); err != nil {
+			return fmt.Errorf("failed to back up %q: %w", path, err)
+		}
+		created++
+		return nil
+	}
+
+	for _, skill := range skills {
+		if skill.Path == "" {
+			continue
+		}
+		if skill.PluginInfo != nil || !strings.EqualFold(filepath.Base(skill.Path), "SKILL.md") {
+			if err := backupFile(skill, skill.Path, ""); err != nil {
+				return created, err
+			}
+			continue
+		}
+
+		skillDir := filepath.Dir(skill.Path)
+		err := filepath.WalkDir(skillDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			lineage := ""
+			if path != skill.Path {
+				rel, err := filepath.Rel(skillDir, path)
+				if err != nil {
+					return err
+				}
+				lineage = skill.Name + "/" + filepath.ToSlash(rel)
+			}
+			return backupFile(skill, path, lineage)
+		})
+		if err != nil {
+			return created, fmt.Errorf("failed to snapshot %s: %w", skill.Name, err)
+		}
+	}
+
+	return created, nil
+}
+
 func backupExistingTargetSkills(
 	targetPlatform model.Platform,
 	targetScope model.SkillScope,
@@ -1278,6 +2439,17 @@ func backupExistingTargetSkills(
 		return 0, fmt.Errorf("failed to parse target skills for backup: %w", err)
 	}
 
+	return backupMatchingSkills(targetPlatform, targetSkills, sourceSkills, description, tags)
+}
+
+// backupMatchingSkills backs up the target skills that would be overwritten by source skills of the same name.
+func backupMatchingSkills(
+	targetPlatform model.Platform,
+	targetSkills []model.Skill,
+	sourceSkills []model.Skill,
+	description string,
+	tags []string,
+) (int, error) {
 	targetByName := make(map[string]model.Skill)
 	for _, skill := range targetSkills {
 		if skill.Name != "" {
@@ -1303,7 +2475,16 @@ func backupExistingTargetSkills(
 }
 
 // displaySyncResults shows the results of a sync operation
-func displaySyncResults(result *sync.Result) {
+func displaySyncResults(result *sync.Result) error {
+	recordSyncWarnings(result)
+	return out.Render(newSyncResultOutput(result), func() error {
+		printSyncResults(result)
+		return nil
+	})
+}
+
+// printSyncResults prints the human-readable summary and per-skill details of a sync.
+func printSyncResults(result *sync.Result) {
 	fmt.Println()
 	fmt.Print(result.Summary())
 
@@ -1316,6 +2497,10 @@ func displaySyncResults(result *sync.Result) {
 				status = "✗"
 			case sync.ActionSkipped:
 				status = "-"
+			case sync.ActionUnchanged:
+				status = "="
+			case sync.ActionQuarantined:
+				status = "!"
 			default:
 				status = "✓"
 			}
@@ -1329,6 +2514,41 @@ func displaySyncResults(result *sync.Result) {
 			fmt.Println()
 		}
 	}
+
+	printSyncPreviews(result)
+
+	if backups := result.BackupIDs(); len(backups) > 0 {
+		fmt.Printf("\nBacked up %d file(s) before writing. Undo with:\n  skillsync backup rollback --session %s\n",
+			len(backups), result.SessionID)
+	}
+}
+
+// printSyncPreviews prints a colored unified diff of each file a dry run
+// would write, as recorded by sync --show-diff.
+func printSyncPreviews(result *sync.Result) {
+	opts := sync.CurrentDiffOptions()
+	for _, sr := range result.Skills {
+		if sr.Preview == nil {
+			continue
+		}
+		hunks := sr.Preview.Hunks(opts)
+		if len(hunks) == 0 {
+			continue
+		}
+		before := sr.Preview.Path
+		if !sr.Preview.Exists {
+			before = "/dev/null"
+		}
+		fmt.Printf("\n%s\n", ui.Header(fmt.Sprintf("diff %s (%s)", sr.Skill.Name, sr.Action)))
+		fmt.Println(ui.Error("--- " + before))
+		fmt.Println(ui.Success("+++ " + sr.Preview.Path))
+		for _, hunk := range hunks {
+			fmt.Println(ui.Info(newDiffHunkOutput(hunk).header()))
+			for _, line := range hunk.Lines {
+				fmt.Println(formatDiffLine(line))
+			}
+		}
+	}
 }
 
 // syncDeleteMode handles the delete sync mode: removing skills from target that exist in source.
@@ -1380,7 +2600,7 @@ func selectSourceSkillsForDelete(sourceSkills, selectedTargets []model.Skill) []
 
 func executeDeleteForSkills(cfg *syncConfig, skills []model.Skill, confirmed bool) error {
 	if len(skills) == 0 {
-		fmt.Println("No skills selected.")
+		out.Println("No skills selected.")
 		return nil
 	}
 
@@ -1391,60 +2611,55 @@ func executeDeleteForSkills(cfg *syncConfig, skills []model.Skill, confirmed boo
 	}
 
 	// Show what will be deleted
-	fmt.Printf("Delete mode: Will remove %d skill(s) from %s that exist in %s\n",
+	out.Printf("Delete mode: Will remove %d skill(s) from %s that exist in %s\n",
 		len(skills), cfg.targetSpec.Platform, cfg.sourceSpec.Platform)
-	fmt.Println("\nSkills to delete:")
+	out.Println("\nSkills to delete:")
 	for _, name := range skillNames {
-		fmt.Printf("  - %s\n", name)
+		out.Printf("  - %s\n", name)
 	}
 
 	// Request confirmation (unless already confirmed, --yes, or --dry-run)
 	if !confirmed && !cfg.dryRun && !cfg.yesFlag {
-		confirmed, err := confirmAction(
+		confirmed, err := confirmDestructive(
 			fmt.Sprintf("Delete %d skill(s) from %s?", len(skills), cfg.targetSpec.Platform),
-			riskLevelDangerous,
+			"delete", len(skills), riskLevelDangerous,
 		)
 		if err != nil {
 			return fmt.Errorf("confirmation error: %w", err)
 		}
 		if !confirmed {
-			fmt.Println("Delete cancelled by user")
+			out.Println("Delete cancelled by user")
 			return nil
 		}
 	}
 
-	// Create backup before deletion (unless skipped or dry-run)
-	if !cfg.dryRun && !cfg.skipBackup {
-		prepareBackup(cfg.targetSpec.Platform)
-		created, err := backupExistingTargetSkills(
-			cfg.targetSpec.Platform,
-			cfg.targetSpec.TargetScope(),
-			skills,
-			"pre-delete backup",
-			[]string{"delete"},
-		)
-		if err != nil {
-			return err
-		}
-		if created > 0 {
-			fmt.Printf("✓ Created %d backup(s)\n", created)
-		}
-	}
-
-	// Create options and execute delete
+	// Create options and execute delete. The engine backs up each skill file
+	// right before removing it (unless skipped or dry-run).
 	opts := sync.Options{
-		DryRun:      cfg.dryRun,
-		TargetScope: cfg.targetSpec.TargetScope(),
-		DeleteMode:  true,
+		DryRun:         cfg.dryRun,
+		TargetScope:    cfg.targetSpec.TargetScope(),
+		DeleteMode:     true,
+		TrashRetention: cfg.trashRetention,
+	}
+	if !cfg.dryRun {
+		opts.SessionID = backup.NewSessionID()
+		if !cfg.skipBackup {
+			prepareBackup(cfg.targetSpec.Platform)
+			opts.Backup = true
+		}
 	}
 
+	startedAt := time.Now()
 	syncer := sync.New()
 	result, err := syncer.DeleteWithSkills(skills, cfg.targetSpec.Platform, opts)
 	if err != nil {
 		return fmt.Errorf("delete sync failed: %w", err)
 	}
+	recordHistory(opts.SessionID, "delete", startedAt, result)
 
-	displaySyncResults(result)
+	if err := displaySyncResults(result); err != nil {
+		return err
+	}
 
 	if !result.Success() {
 		return errors.New("delete sync completed with errors")
@@ -1454,15 +2669,34 @@ func executeDeleteForSkills(cfg *syncConfig, skills []model.Skill, confirmed boo
 }
 
 // applyResolvedConflicts writes the resolved conflict content to the target files.
-func applyResolvedConflicts(result *sync.Result, resolved map[string]string) error {
+// With backups enabled, each target is first backed up under the sync's session.
+func applyResolvedConflicts(result *sync.Result, resolved map[string]string, backupEnabled bool) error {
 	for i := range result.Skills {
 		sr := &result.Skills[i]
 		if sr.Action == sync.ActionConflict {
 			if content, ok := resolved[sr.Skill.Name]; ok {
+				path, content, err := resolvedTarget(sr, content)
+				if err != nil {
+					return err
+				}
+				if backupEnabled {
+					metadata, err := backup.CreateBackup(path, backup.Options{
+						Platform:    string(result.Target),
+						Description: "pre-sync backup",
+						Metadata:    map[string]string{"skill": sr.Skill.Name},
+						Tags:        []string{"sync"},
+						SessionID:   result.SessionID,
+					})
+					if err != nil {
+						return fmt.Errorf("failed to back up %s: %w", sr.Skill.Name, err)
+					}
+					sr.BackupIDs = append(sr.BackupIDs, metadata.ID)
+				}
 				// #nosec G306 - skill files should be readable
-				if err := os.WriteFile(sr.TargetPath, []byte(content), 0o644); err != nil {
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
 					return fmt.Errorf("failed to write resolved content for %s: %w", sr.Skill.Name, err)
 				}
+				sr.BytesWritten = int64(len(content))
 				// Update the action to indicate it was resolved
 				sr.Action = sync.ActionMerged
 				sr.Message = "conflict resolved by user"
@@ -1472,6 +2706,29 @@ func applyResolvedConflicts(result *sync.Result, resolved map[string]string) err
 	return nil
 }
 
+// resolvedTarget returns the file the resolved content of a conflict is
+// written to, and its content. Directory skills write their skill file, with
+// the frontmatter of the target file kept.
+func resolvedTarget(sr *sync.SkillResult, content string) (string, string, error) {
+	info, err := os.Stat(sr.TargetPath)
+	if err != nil || !info.IsDir() {
+		return sr.TargetPath, content, nil
+	}
+	path := filepath.Join(sr.TargetPath, filepath.Base(sr.Skill.Path))
+	// #nosec G304 - path is the skill file of a sync target
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	split := parser.SplitFrontmatter(existing)
+	if split.HasFrontmatter {
+		if i := strings.LastIndex(string(existing), split.Content); split.Content != "" && i >= 0 {
+			content = string(existing[:i]) + content
+		}
+	}
+	return path, content, nil
+}
+
 func parseScopeFilter(scopeStr string) ([]model.SkillScope, error) {
 	if scopeStr == "" || scopeStr == "all" {
 		return nil, nil
@@ -1511,8 +2768,14 @@ func parsePlatformSkills(platform model.Platform) ([]model.Skill, error) {
 		parser = cursor.New(basePath)
 	case model.Codex:
 		parser = codex.New(basePath)
+	case model.Aider:
+		parser = aider.New(basePath)
 	default:
-		return nil, fmt.Errorf("unsupported platform: %s", platform)
+		def, ok := external.Lookup(platform)
+		if !ok {
+			return nil, fmt.Errorf("unsupported platform: %s", platform)
+		}
+		parser = external.New(def, basePath)
 	}
 
 	return parser.Parse()
@@ -1522,6 +2785,27 @@ func parsePlatformSkills(platform model.Platform) ([]model.Skill, error) {
 // If scopeFilter is nil or empty, all scopes are included. Plugin scope skills are excluded by
 // default unless includePlugins is true or the plugin scope is explicitly in scopeFilter.
 func parsePlatformSkillsWithScope(platform model.Platform, scopeFilter []model.SkillScope, includePlugins bool) ([]model.Skill, error) {
+	variants, err := parsePlatformSkillVariants(platform, scopeFilter, includePlugins)
+	if err != nil {
+		return nil, err
+	}
+	return resolveShadowing(variants, false), nil
+}
+
+// parsePlatformSkillsWithShadowed is parsePlatformSkillsWithScope keeping the
+// versions of skills hidden by a same-named skill in a higher-precedence
+// scope, marked with ShadowedBy.
+func parsePlatformSkillsWithShadowed(platform model.Platform, scopeFilter []model.SkillScope, includePlugins bool) ([]model.Skill, error) {
+	variants, err := parsePlatformSkillVariants(platform, scopeFilter, includePlugins)
+	if err != nil {
+		return nil, err
+	}
+	return resolveShadowing(variants, true), nil
+}
+
+// parsePlatformSkillVariants parses the skills of platform from its
+// configured paths, keeping every scope's version of a skill.
+func parsePlatformSkillVariants(platform model.Platform, scopeFilter []model.SkillScope, includePlugins bool) ([]model.Skill, error) {
 	cfg, err := config.Load()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
@@ -1535,7 +2819,7 @@ func parsePlatformSkillsWithScope(platform model.Platform, scopeFilter []model.S
 		return []model.Skill{}, nil
 	}
 
-	return parsePlatformSkillsFromPaths(platform, paths, repoRoot, scopeFilter, includePlugins), nil
+	return parseSkillVariantsFromPaths(platform, paths, repoRoot, scopeFilter, includePlugins), nil
 }
 
 func platformSkillsPaths(cfg *config.Config, platform model.Platform) ([]string, string, error) {
@@ -1545,6 +2829,16 @@ func platformSkillsPaths(cfg *config.Config, platform model.Platform) ([]string,
 	}
 	repoRoot := util.GetRepoRoot(cwd)
 
+	rawPaths, err := platformRawSkillsPaths(cfg, platform)
+	if err != nil {
+		return nil, repoRoot, err
+	}
+
+	return resolveSkillsPaths(rawPaths, cwd, repoRoot), repoRoot, nil
+}
+
+// platformRawSkillsPaths returns the configured (unexpanded) skills paths for a platform.
+func platformRawSkillsPaths(cfg *config.Config, platform model.Platform) ([]string, error) {
 	var rawPaths []string
 	switch platform {
 	case model.ClaudeCode:
@@ -1562,29 +2856,31 @@ func platformSkillsPaths(cfg *config.Config, platform model.Platform) ([]string,
 		if len(rawPaths) == 0 && cfg.Platforms.Codex.SkillsPath != "" { //nolint:staticcheck // backward compatibility
 			rawPaths = []string{cfg.Platforms.Codex.SkillsPath} //nolint:staticcheck // backward compatibility
 		}
+	case model.Aider:
+		rawPaths = cfg.Platforms.Aider.SkillsPaths
+		if len(rawPaths) == 0 && cfg.Platforms.Aider.SkillsPath != "" { //nolint:staticcheck // backward compatibility
+			rawPaths = []string{cfg.Platforms.Aider.SkillsPath} //nolint:staticcheck // backward compatibility
+		}
 	default:
-		return nil, repoRoot, fmt.Errorf("unsupported platform: %s", platform)
+		pc, ok := cfg.Platforms.Platform(platform)
+		if !ok {
+			return nil, fmt.Errorf("unsupported platform: %s", platform)
+		}
+		rawPaths = pc.SkillsPaths
+		if len(rawPaths) == 0 && pc.SkillsPath != "" { //nolint:staticcheck // backward compatibility
+			rawPaths = []string{pc.SkillsPath} //nolint:staticcheck // backward compatibility
+		}
 	}
 
-	paths := resolveSkillsPaths(rawPaths, cwd, repoRoot)
-
 	// Backward compatibility: older config files may only include .claude/skills
 	// paths and omit .claude/commands paths. Always include command paths for
 	// Claude Code so prompt artifacts can be discovered when requested.
+	// Duplicates are removed by resolveSkillsPaths.
 	if platform == model.ClaudeCode {
-		commandPaths := resolveSkillsPaths([]string{".claude/commands", "~/.claude/commands"}, cwd, repoRoot)
-		seen := make(map[string]bool, len(paths))
-		for _, p := range paths {
-			seen[p] = true
-		}
-		for _, p := range commandPaths {
-			if !seen[p] {
-				paths = append(paths, p)
-			}
-		}
+		rawPaths = append(append([]string{}, rawPaths...), ".claude/commands", "~/.claude/commands")
 	}
 
-	return paths, repoRoot, nil
+	return rawPaths, nil
 }
 
 func resolveSkillsPaths(rawPaths []string, cwd, repoRoot string) []string {
@@ -1624,9 +2920,23 @@ func parsePlatformSkillsFromPaths(
 	repoRoot string,
 	scopeFilter []model.SkillScope,
 	includePlugins bool,
+) []model.Skill {
+	return resolveShadowing(parseSkillVariantsFromPaths(platform, paths, repoRoot, scopeFilter, includePlugins), false)
+}
+
+// parseSkillVariantsFromPaths parses the skills in paths, in order, keeping
+// every version of a skill that is found under more than one path.
+func parseSkillVariantsFromPaths(
+	platform model.Platform,
+	paths []string,
+	repoRoot string,
+	scopeFilter []model.SkillScope,
+	includePlugins bool,
 ) []model.Skill {
 	parserFactory := tiered.ParserFactoryFor(platform)
-	skillsByName := make(map[string]model.Skill)
+	skillCache := openSkillCache(platform)
+	defer saveSkillCache(skillCache)
+	var variants []model.Skill
 
 	scopeSet := make(map[model.SkillScope]bool)
 	for _, s := range scopeFilter {
@@ -1643,21 +2953,14 @@ func parsePlatformSkillsFromPaths(
 			continue
 		}
 
-		pathParser := parserFactory(path)
-		skills, err := pathParser.Parse()
+		skills, err := parseSkillsCached(skillCache, path, parserFactory(path).Parse)
 		if err != nil {
 			continue
 		}
 
 		for _, skill := range skills {
 			skill.Scope = scope
-			if existing, exists := skillsByName[skill.Name]; exists {
-				if shouldOverrideSkill(existing, skill) {
-					skillsByName[skill.Name] = skill
-				}
-				continue
-			}
-			skillsByName[skill.Name] = skill
+			variants = append(variants, skill)
 		}
 	}
 
@@ -1667,23 +2970,50 @@ func parsePlatformSkillsFromPaths(
 	// Note: plugin scope is excluded by default when no scope filter is specified
 	pluginExplicitlyRequested := scopeSet[model.ScopePlugin]
 	if platform == model.ClaudeCode && (pluginExplicitlyRequested || includePlugins) {
-		pluginSkills := parseClaudePluginCacheSkills()
-		for _, skill := range pluginSkills {
-			if existing, exists := skillsByName[skill.Name]; exists {
-				if shouldOverrideSkill(existing, skill) {
-					skillsByName[skill.Name] = skill
-				}
-				continue
+		variants = append(variants, parseClaudePluginCacheSkills()...)
+	}
+
+	return variants
+}
+
+// resolveShadowing collapses the versions of each skill into the one that
+// takes precedence, recording in its Shadows the scopes of the versions it
+// hides. With keepShadowed, the hidden versions are returned as well, with
+// ShadowedBy set to the scope of the skill that hides them.
+func resolveShadowing(variants []model.Skill, keepShadowed bool) []model.Skill {
+	winners := make(map[string]int)
+	for i, skill := range variants {
+		if existing, exists := winners[skill.Name]; exists {
+			if shouldOverrideSkill(variants[existing], skill) {
+				winners[skill.Name] = i
 			}
-			skillsByName[skill.Name] = skill
+			continue
 		}
+		winners[skill.Name] = i
 	}
 
-	result := make([]model.Skill, 0, len(skillsByName))
-	for _, skill := range skillsByName {
+	result := make([]model.Skill, 0, len(winners))
+	var shadowed []model.Skill
+	shadows := make(map[string][]model.SkillScope)
+	for i, skill := range variants {
+		winner := variants[winners[skill.Name]]
+		if i == winners[skill.Name] {
+			continue
+		}
+		if !slices.Contains(shadows[skill.Name], skill.Scope) {
+			shadows[skill.Name] = append(shadows[skill.Name], skill.Scope)
+		}
+		if keepShadowed {
+			skill.ShadowedBy = winner.Scope
+			shadowed = append(shadowed, skill)
+		}
+	}
+	for name, i := range winners {
+		skill := variants[i]
+		skill.Shadows = shadows[name]
 		result = append(result, skill)
 	}
-	return result
+	return append(result, shadowed...)
 }
 
 func shouldOverrideSkill(existing, candidate model.Skill) bool {
@@ -1730,14 +3060,16 @@ func inferScopeForPath(path, repoRoot string) model.SkillScope {
 		}
 	}
 
+	paths := util.Paths()
+
 	// Check if path is within Claude plugin cache (must check before home directory)
-	pluginCachePath := filepath.Clean(util.ClaudePluginCachePath())
+	pluginCachePath := filepath.Clean(paths.ClaudePluginCachePath())
 	pluginCacheWithSep := pluginCachePath + string(os.PathSeparator)
 	if cleaned == pluginCachePath || strings.HasPrefix(cleaned, pluginCacheWithSep) {
 		return model.ScopePlugin
 	}
 
-	home := filepath.Clean(util.HomeDir())
+	home := filepath.Clean(paths.HomeDir())
 	homeWithSep := home + string(os.PathSeparator)
 	if home != "" && (cleaned == home || strings.HasPrefix(cleaned, homeWithSep)) {
 		return model.ScopeUser
@@ -1769,6 +3101,8 @@ func formatValidationError(err error, skills []model.Skill) string {
 			msg += " (rename one of the conflicting skills)"
 		case strings.Contains(msg, "cannot access skill file"):
 			msg += " (check file path and permissions)"
+		case strings.Contains(msg, "frontmatter schema"):
+			msg += " (required by validation.schema_path in config)"
 		}
 		return fmt.Sprintf("%s: %s", vErr.Field, msg)
 	}
@@ -1886,7 +3220,7 @@ func confirmAction(message string, level riskLevel) (bool, error) {
 		defaultYes = false
 	}
 
-	fmt.Printf("\n%s ", prompt)
+	out.Printf("\n%s ", prompt)
 
 	// Read user input
 	reader := bufio.NewReader(os.Stdin)
@@ -1907,36 +3241,109 @@ func confirmAction(message string, level riskLevel) (bool, error) {
 	return response == "y" || response == "yes", nil
 }
 
+// confirmDestructive confirms a destructive operation affecting count
+// skills or files. Past the threshold of the confirmation policy, the user
+// has to type "<verb> <count>", e.g. "delete 12", instead of answering y/N.
+func confirmDestructive(message, verb string, count int, level riskLevel) (bool, error) {
+	policy := config.Default().Confirmation
+	if appConfig, err := config.Load(); err == nil {
+		policy = appConfig.Confirmation
+	}
+	if !policy.RequiresTyped(count) {
+		return confirmAction(message, level)
+	}
+
+	phrase := fmt.Sprintf("%s %d", verb, count)
+	out.Printf("\n⚠️  %s\nType %q to confirm: ", message, phrase)
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read input: %w", err)
+	}
+	if strings.EqualFold(strings.Join(strings.Fields(response), " "), phrase) {
+		return true, nil
+	}
+	out.Println("Confirmation did not match.")
+	return false, nil
+}
+
 func exportCommand() *cli.Command {
 	return &cli.Command{
 		Name:      "export",
 		Usage:     "Export skills to different formats",
 		UsageText: "skillsync export [options]",
-		Description: `Export skills to JSON, YAML, or Markdown formats.
-
-   Supported formats: json (default), yaml, markdown
+		Description: `Export skills to JSON, YAML, Markdown, bundle, inventory, CSV, or Excel formats,
+   as a directory of skill files, or as a static HTML site.
+
+   Supported formats: json (default), yaml, markdown, bundle, inventory, csv, xlsx, files, html
+
+   The bundle format is a tar.gz archive with a manifest.json and one file
+   per skill. Restore it on any platform with 'skillsync import --bundle'.
+
+   The inventory format lists every third-party skill installed through
+   Claude Code plugins, symlinks into plugin checkouts, or skillsync plugin
+   repositories, with its origin repository, version, commit, license, and
+   content checksum. Use it to audit where installed prompts came from.
+
+   The csv and xlsx formats write one row per skill for auditing skill
+   inventories in a spreadsheet. --columns picks the columns and their order
+   from: name, platform, scope, type, description, tags, tools, license,
+   path, modified (default: name,platform,scope,description,tags,modified).
+
+   The files format writes each skill to the --output directory as
+   <name>/SKILL.md with a normalized frontmatter, next to a metadata.json
+   recording the platforms, scopes, and paths it came from (omitted with
+   --no-metadata). The layout is the same for every platform, ready to be
+   committed as a standalone skills repository and imported back with
+   'skillsync import --git'. A skill found on several platforms is written
+   once if its content is the same everywhere; otherwise pick one with
+   --platform. Files of exported skills are replaced; other files in the
+   directory are left alone.
+
+   The html format writes a browsable static site to the --output directory:
+   an index.html listing the skills by platform and by tag, and one page per
+   skill at skills/<platform>/<name>.html with the rendered Markdown. Publish
+   the directory on any static file host to share a skill catalog.
+
+   --incremental exports only the skills added or changed since the previous
+   incremental export, plus a "removed" list of skills deleted since, and
+   then records what was exported. Each format and platform filter keeps its
+   own record in ~/.skillsync/exports unless --manifest names another file.
+   The first incremental export includes every skill.
 
    Examples:
      skillsync export
      skillsync export --format yaml
      skillsync export --platform claude-code --format markdown
-     skillsync export --output skills.json`,
+     skillsync export --output skills.json
+     skillsync export --format bundle --output skills.tar.gz
+     skillsync export --format inventory --output inventory.json
+     skillsync export --format csv --columns name,platform,scope,tags,modified
+     skillsync export --format xlsx --output skills.xlsx
+     skillsync export --format files --output ./skills-export/
+     skillsync export --format html --output ./skills-site/
+     skillsync export --incremental --output changes.json
+     skillsync export --incremental --manifest ./crm.manifest.json`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Filter by platform (claude-code, cursor, codex)",
+				Usage:   "Filter by platform (claude-code, cursor, codex, aider)",
 			},
 			&cli.StringFlag{
 				Name:    "format",
 				Aliases: []string{"f"},
 				Value:   "json",
-				Usage:   "Output format: json, yaml, markdown",
+				Usage:   "Output format: json, yaml, markdown, bundle, inventory, csv, xlsx, files, html",
+			},
+			&cli.StringFlag{
+				Name:  "columns",
+				Usage: "Comma-separated columns of the csv and xlsx formats (name, platform, scope, type, description, tags, tools, license, path, modified)",
 			},
 			&cli.StringFlag{
 				Name:    "output",
 				Aliases: []string{"o"},
-				Usage:   "Output file path (default: stdout)",
+				Usage:   "Output file path (default: stdout), or directory for the files and html formats",
 			},
 			&cli.BoolFlag{
 				Name:  "no-metadata",
@@ -1946,6 +3353,14 @@ func exportCommand() *cli.Command {
 				Name:  "compact",
 				Usage: "Compact output (no pretty-printing)",
 			},
+			&cli.BoolFlag{
+				Name:  "incremental",
+				Usage: "Only export skills changed since the previous incremental export, with a list of removed skills",
+			},
+			&cli.StringFlag{
+				Name:  "manifest",
+				Usage: "Export manifest recording the previous incremental export (default: ~/.skillsync/exports/<format>.json)",
+			},
 		},
 		Action: func(_ context.Context, cmd *cli.Command) error {
 			return runExport(cmd)
@@ -1973,30 +3388,76 @@ func runExport(cmd *cli.Command) error {
 		platform = p
 	}
 
+	columns, err := export.ParseColumns(cmd.String("columns"))
+	if err != nil {
+		return err
+	}
+	tabular := format == export.FormatCSV || format == export.FormatXLSX
+	if columns != nil && !tabular {
+		return errors.New("--columns only applies to the csv and xlsx formats")
+	}
+
 	// Build export options
 	opts := export.Options{
 		Format:          format,
 		Pretty:          !cmd.Bool("compact"),
 		IncludeMetadata: !cmd.Bool("no-metadata"),
 		Platform:        platform,
+		Columns:         columns,
+	}
+
+	incremental := cmd.Bool("incremental")
+	directory := format == export.FormatFiles || format == export.FormatHTML
+	if incremental && (format == export.FormatInventory || directory || tabular) {
+		return fmt.Errorf("--incremental is not supported for the %s format", format)
+	}
+	if directory && cmd.String("output") == "" {
+		return fmt.Errorf("the %s format requires --output DIR", format)
+	}
+	if cmd.String("manifest") != "" && !incremental {
+		return errors.New("--manifest requires --incremental")
 	}
 
 	// Discover skills
-	skills, err := discoverSkillsForExport(platform)
+	discover := discoverSkillsForExport
+	if format == export.FormatInventory {
+		discover = discoverSkillsForInventory
+	}
+	skills, err := discover(platform)
 	if err != nil {
 		return fmt.Errorf("failed to discover skills: %w", err)
 	}
 
+	// Create exporter
+	exporter := export.New(opts)
+
+	if incremental {
+		return runIncrementalExport(cmd, exporter, format, skills, exportManifestPath(cmd.String("manifest"), format, platform))
+	}
+
 	if len(skills) == 0 {
 		fmt.Fprintln(os.Stderr, "No skills found to export.")
 		return nil
 	}
 
-	// Create exporter
-	exporter := export.New(opts)
-
 	// Determine output destination
 	outputPath := cmd.String("output")
+	if format == export.FormatFiles {
+		written, err := exporter.ExportFiles(skills, outputPath)
+		if err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d skill(s) to %s\n", len(written), outputPath)
+		return nil
+	}
+	if format == export.FormatHTML {
+		pages, err := exporter.ExportHTML(skills, outputPath)
+		if err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d skill page(s) to %s\n", len(pages)-1, outputPath)
+		return nil
+	}
 	if outputPath != "" {
 		// Write to file
 		// #nosec G304 - outputPath is provided by user
@@ -2014,8 +3475,15 @@ func runExport(cmd *cli.Command) error {
 			return fmt.Errorf("failed to close output file: %w", err)
 		}
 
-		fmt.Fprintf(os.Stderr, "Exported %d skill(s) to %s\n", len(skills), outputPath)
+		count := len(skills)
+		if format == export.FormatInventory {
+			count = len(export.NewInventory(skills).Skills)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d skill(s) to %s\n", count, outputPath)
 	} else {
+		if (format == export.FormatBundle || format == export.FormatXLSX) && term.IsTerminal(int(os.Stdout.Fd())) {
+			return fmt.Errorf("refusing to write a binary %s to a terminal (use --output or redirect stdout)", format)
+		}
 		// Write to stdout
 		if err := exporter.Export(skills, os.Stdout); err != nil {
 			return fmt.Errorf("export failed: %w", err)
@@ -2025,6 +3493,70 @@ func runExport(cmd *cli.Command) error {
 	return nil
 }
 
+// runIncrementalExport exports the skills changed since the export recorded
+// at manifestPath, then records the current skills there.
+func runIncrementalExport(cmd *cli.Command, exporter *export.Exporter, format export.Format, skills []model.Skill, manifestPath string) error {
+	previous, err := export.LoadExportManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	changes := previous.Changes(skills)
+
+	outputPath := cmd.String("output")
+	if outputPath != "" {
+		// #nosec G304 - outputPath is provided by user
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		if err := exporter.ExportChanges(changes, file); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("export failed: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to close output file: %w", err)
+		}
+	} else {
+		if format == export.FormatBundle && term.IsTerminal(int(os.Stdout.Fd())) {
+			return fmt.Errorf("refusing to write a binary bundle to a terminal (use --output or redirect stdout)")
+		}
+		if err := exporter.ExportChanges(changes, os.Stdout); err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+	}
+
+	// Only record the export once it has been written, so a failed run is
+	// repeated in full next time
+	if err := export.NewExportManifest(skills).Save(manifestPath); err != nil {
+		return err
+	}
+
+	since := "first export"
+	if !changes.Since.IsZero() {
+		since = "since " + changes.Since.Local().Format("2006-01-02 15:04:05")
+	}
+	destination := ""
+	if outputPath != "" {
+		destination = " to " + outputPath
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d changed and %d removed skill(s)%s (%s)\n",
+		len(changes.Skills), len(changes.Removed), destination, since)
+	return nil
+}
+
+// exportManifestPath returns the manifest for an incremental export: the
+// given path, or one per format and platform under ~/.skillsync/exports.
+func exportManifestPath(path string, format export.Format, platform model.Platform) string {
+	if path != "" {
+		return path
+	}
+	name := string(format)
+	if platform != "" {
+		name += "-" + string(platform)
+	}
+	return filepath.Join(util.SkillsyncExportsPath(), name+".json")
+}
+
 // discoverSkillsForExport discovers skills optionally filtered by platform.
 func discoverSkillsForExport(platform model.Platform) ([]model.Skill, error) {
 	var platforms []model.Platform
@@ -2039,7 +3571,7 @@ func discoverSkillsForExport(platform model.Platform) ([]model.Skill, error) {
 		skills, err := parsePlatformSkills(p)
 		if err != nil {
 			// Log warning but continue with other platforms
-			fmt.Fprintf(os.Stderr, "Warning: failed to parse %s: %v\n", p, err)
+			stderrWarnf("Warning: failed to parse %s: %v\n", p, err)
 			continue
 		}
 
@@ -2049,13 +3581,46 @@ func discoverSkillsForExport(platform model.Platform) ([]model.Skill, error) {
 	return allSkills, nil
 }
 
+// discoverSkillsForInventory discovers skills for a provenance inventory,
+// adding every installed plugin skill, including those shadowed by
+// same-named user or repo skills.
+func discoverSkillsForInventory(platform model.Platform) ([]model.Skill, error) {
+	skills, err := discoverSkillsForExport(platform)
+	if err != nil {
+		return nil, err
+	}
+	if platform != "" && platform != model.ClaudeCode {
+		return skills, nil
+	}
+
+	pluginSkills := parseClaudePluginCacheSkills()
+	repoSkills, err := plugin.New("").Parse()
+	if err != nil {
+		stderrWarnf("Warning: failed to parse plugin repositories: %v\n", err)
+	}
+	pluginSkills = append(pluginSkills, repoSkills...)
+
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		seen[skill.Path] = true
+	}
+	for _, skill := range pluginSkills {
+		if !seen[skill.Path] {
+			seen[skill.Path] = true
+			skills = append(skills, skill)
+		}
+	}
+	return skills, nil
+}
+
 func backupCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "backup",
 		Usage: "Manage skillsync backups",
 		Description: `Manage backups of skill files.
 
-   Backups are automatically created before sync operations.
+   Backups are automatically created before sync operations overwrite or
+   delete a file, and are grouped by a sync session ID.
    Use these commands to view, verify, and manage backups.
 
    Examples:
@@ -2063,17 +3628,23 @@ func backupCommand() *cli.Command {
      skillsync backup create --platform cursor # Create backups for Cursor skills
      skillsync backup list --platform claude-code
      skillsync backup list --format json
-     skillsync backup restore <backup-id>     # Restore a backup`,
+     skillsync backup restore <backup-id>     # Restore a backup
+     skillsync backup rollback --session <id> # Undo a sync run
+     skillsync backup prune --dry-run         # Preview the retention policy
+     skillsync backup reindex                 # Rebuild a corrupted backup index`,
 		Commands: []*cli.Command{
 			backupCreateCommand(),
 			backupListCommand(),
 			backupRestoreCommand(),
+			backupRollbackCommand(),
 			backupDeleteCommand(),
+			backupPruneCommand(),
 			backupVerifyCommand(),
+			backupReindexCommand(),
 		},
 		Action: func(_ context.Context, _ *cli.Command) error {
 			// Default action: list backups
-			return listBackups("", "table", 0)
+			return listBackups("", "", "table", 0)
 		},
 	}
 }
@@ -2086,16 +3657,22 @@ func backupCreateCommand() *cli.Command {
 		UsageText: `skillsync backup create [options]
    skillsync backup create --platform cursor
    skillsync backup create --platform claude-code --scope repo
-   skillsync backup create --platform all`,
+   skillsync backup create --platform all
+   skillsync backup create --platform claude-code --all`,
 		Description: `Create backups for skills across platforms.
 
    By default, backs up all platforms. Use --platform to limit results.
-   Use --scope to filter which skill scopes are included.`,
+   Use --scope to filter which skill scopes are included.
+
+   Use --all to take a snapshot: every file of every selected skill,
+   including the scripts and references of directory skills, is backed up
+   under one snapshot ID. Restore the whole set at once with:
+     skillsync backup restore --snapshot <snapshot-id>`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Platform to back up (claude-code, cursor, codex, all)",
+				Usage:   "Platform to back up (claude-code, cursor, codex, aider, all)",
 			},
 			&cli.StringFlag{
 				Name:    "scope",
@@ -2106,11 +3683,16 @@ func backupCreateCommand() *cli.Command {
 				Name:  "include-plugins",
 				Usage: "Include skills from installed Claude Code plugins",
 			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Snapshot every skill file as one set restorable with restore --snapshot",
+			},
 		},
 		Action: func(_ context.Context, cmd *cli.Command) error {
 			platformStr := strings.TrimSpace(cmd.String("platform"))
 			scopeStr := strings.TrimSpace(cmd.String("scope"))
 			includePlugins := cmd.Bool("include-plugins")
+			snapshot := cmd.Bool("all")
 
 			scopeFilter, err := parseScopeFilter(scopeStr)
 			if err != nil {
@@ -2128,6 +3710,11 @@ func backupCreateCommand() *cli.Command {
 				platforms = []model.Platform{platform}
 			}
 
+			var snapshotID string
+			if snapshot {
+				snapshotID = backup.NewSnapshotID()
+			}
+
 			totalCreated := 0
 			for _, platform := range platforms {
 				skills, err := parsePlatformSkillsWithScope(platform, scopeFilter, includePlugins)
@@ -2139,20 +3726,35 @@ func backupCreateCommand() *cli.Command {
 				}
 
 				prepareBackup(platform)
-				created, err := createBackupsForSkills(platform, skills, "manual backup", []string{"manual"})
+				var created int
+				if snapshot {
+					created, err = createSnapshotBackups(platform, skills, snapshotID)
+				} else {
+					created, err = createBackupsForSkills(platform, skills, "manual backup", []string{"manual"})
+				}
 				if err != nil {
 					return err
 				}
 				totalCreated += created
 			}
-
 			if totalCreated == 0 {
-				fmt.Println("No skills found to back up.")
-				return nil
+				snapshotID = ""
 			}
 
-			fmt.Printf("\n✓ Created %d backup(s)\n", totalCreated)
-			return nil
+			result := backupActionOutput{Action: "create", Count: totalCreated, Snapshot: snapshotID}
+			return out.Render(result, func() error {
+				if totalCreated == 0 {
+					fmt.Println("No skills found to back up.")
+					return nil
+				}
+
+				fmt.Printf("\n✓ Created %d backup(s)\n", totalCreated)
+				if snapshotID != "" {
+					fmt.Printf("  Snapshot: %s\n", snapshotID)
+					fmt.Printf("  Restore with: skillsync backup restore --snapshot %s\n", snapshotID)
+				}
+				return nil
+			})
 		},
 	}
 }
@@ -2165,18 +3767,27 @@ func backupListCommand() *cli.Command {
 		UsageText: `skillsync backup list [options]
    skillsync backup list --platform claude-code
    skillsync backup list --format json
-   skillsync backup list --limit 10`,
+   skillsync backup list --limit 10
+   skillsync backup list --lineage my-skill
+   skillsync backup list --by-lineage`,
 		Description: `List all backups with their metadata including timestamp, size, and platform.
 
    Output includes: ID, Platform, Source File, Created At, Size
 
+   Every backup belongs to a lineage: the logical skill it was taken from,
+   across platforms and renames. Use --lineage to list the history of one
+   skill and --by-lineage to summarize backups per skill. Cleanup keeps the
+   newest backups of each lineage (backup.max_count, or backup.lineage_limits
+   in the config), so frequently synced skills never crowd out the backups
+   of others.
+
    Formats: table (default), json, yaml
    For interactive backup management, use: skillsync tui`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Filter by platform (claude-code, cursor, codex)",
+				Usage:   "Filter by platform (claude-code, cursor, codex, aider)",
 			},
 			&cli.StringFlag{
 				Name:    "format",
@@ -2190,12 +3801,25 @@ func backupListCommand() *cli.Command {
 				Value:   0,
 				Usage:   "Limit results to N most recent backups (0 = unlimited)",
 			},
+			&cli.StringFlag{
+				Name:    "lineage",
+				Aliases: []string{"l"},
+				Usage:   "Only list backups of this lineage (skill name)",
+			},
+			&cli.BoolFlag{
+				Name:  "by-lineage",
+				Usage: "Summarize backups per lineage instead of listing them",
+			},
 		},
 		Action: func(_ context.Context, cmd *cli.Command) error {
 			platform := cmd.String("platform")
+			lineage := cmd.String("lineage")
 			format := cmd.String("format")
 			limit := cmd.Int("limit")
-			return listBackups(platform, format, int(limit))
+			if cmd.Bool("by-lineage") {
+				return listBackupLineages(platform, lineage, format)
+			}
+			return listBackups(platform, lineage, format, int(limit))
 		},
 	}
 }
@@ -2207,12 +3831,18 @@ func backupRestoreCommand() *cli.Command {
 		UsageText: `skillsync backup restore <backup-id> [options]
    skillsync backup restore 20240125-120000-abc12345
    skillsync backup restore 20240125-120000-abc12345 --target /path/to/restore
-   skillsync backup restore 20240125-120000-abc12345 --force`,
+   skillsync backup restore 20240125-120000-abc12345 --force
+   skillsync backup restore --snapshot 20240125-120000-1a2b3c4d`,
 		Description: `Restore a skill file from a backup.
 
    By default, restores to the original source path. Use --target to specify
    a different location.
 
+   Use --snapshot to restore every file of a snapshot taken with
+   "backup create --all" to its original path. All backups are verified and
+   staged before any file is replaced, so either the whole set is restored
+   or nothing changes. Skills added since the snapshot are left in place.
+
    The restore operation verifies backup integrity using SHA256 hash before
    restoring. Use --force to skip the confirmation prompt.`,
 		Flags: []cli.Flag{
@@ -2226,9 +3856,19 @@ func backupRestoreCommand() *cli.Command {
 				Aliases: []string{"f"},
 				Usage:   "Skip confirmation prompt before overwriting",
 			},
+			&cli.StringFlag{
+				Name:  "snapshot",
+				Usage: "Restore every file of the snapshot with this ID",
+			},
 		},
 		Action: func(_ context.Context, cmd *cli.Command) error {
 			args := cmd.Args()
+			if snapshotID := cmd.String("snapshot"); snapshotID != "" {
+				if args.Len() > 0 || cmd.String("target") != "" {
+					return errors.New("--snapshot cannot be combined with a backup ID or --target")
+				}
+				return restoreSnapshot(snapshotID, cmd.Bool("force"))
+			}
 			if args.Len() < 1 {
 				return errors.New("backup ID is required")
 			}
@@ -2243,10 +3883,10 @@ func backupRestoreCommand() *cli.Command {
 }
 
 // listBackups retrieves and displays backups based on filters
-func listBackups(platform, format string, limit int) error {
-	backups, err := backup.ListBackups(platform)
+func listBackups(platform, lineage, format string, limit int) error {
+	backups, err := listLineageBackups(platform, lineage)
 	if err != nil {
-		return fmt.Errorf("failed to list backups: %w", err)
+		return err
 	}
 
 	// Apply limit if specified
@@ -2254,7 +3894,79 @@ func listBackups(platform, format string, limit int) error {
 		backups = backups[:limit]
 	}
 
-	return outputBackups(backups, format)
+	return outputBackups(backups, out.Format(format))
+}
+
+// listBackupLineages displays a summary of backups per lineage.
+func listBackupLineages(platform, lineage, format string) error {
+	backups, err := listLineageBackups(platform, lineage)
+	if err != nil {
+		return err
+	}
+	lineages := backup.Lineages(backups)
+
+	switch out.Format(format) {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(lineages)
+	case "yaml":
+		data, err := yaml.Marshal(lineages)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		fmt.Print(string(data))
+		return nil
+	case "table":
+		return outputLineagesTable(lineages)
+	default:
+		return fmt.Errorf("unsupported format: %s (use table, json, or yaml)", format)
+	}
+}
+
+// listLineageBackups returns the backups of a platform and lineage, either
+// of which may be empty to include all.
+func listLineageBackups(platform, lineage string) ([]backup.Metadata, error) {
+	backups, err := backup.ListBackups(platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+	if lineage == "" {
+		return backups, nil
+	}
+
+	filtered := make([]backup.Metadata, 0)
+	for _, b := range backups {
+		if b.LineageKey() == lineage {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered, nil
+}
+
+// outputLineagesTable prints a lineage summary as a table.
+func outputLineagesTable(lineages []backup.LineageSummary) error {
+	if len(lineages) == 0 {
+		fmt.Println("No backups found.")
+		return nil
+	}
+
+	fmt.Printf("%s %s %s %s %s\n",
+		ui.Header(fmt.Sprintf("%-30s", "LINEAGE")),
+		ui.Header(fmt.Sprintf("%-8s", "BACKUPS")),
+		ui.Header(fmt.Sprintf("%-20s", "LATEST")),
+		ui.Header(fmt.Sprintf("%-9s", "SIZE")),
+		ui.Header("PLATFORMS"))
+	fmt.Printf("%-30s %-8s %-20s %-9s %s\n", "-------", "-------", "------", "----", "---------")
+
+	for _, l := range lineages {
+		fmt.Printf("%-30s %-8d %-20s %-9s %s\n",
+			truncateCell(l.Lineage, 30), l.Backups, l.Newest.Format("2006-01-02 15:04:05"),
+			formatSize(l.Size), strings.Join(l.Platforms, ", "))
+	}
+
+	fmt.Printf("\nTotal: %d lineage(s)\n", len(lineages))
+	return nil
 }
 
 // listBackupsInteractive runs the interactive TUI for backup management
@@ -2284,78 +3996,202 @@ func listBackupsInteractive(platform string) error {
 		return deleteBackupsByID([]string{result.BackupID}, true) // force=true since already confirmed in TUI
 	case tui.ActionVerify:
 		fmt.Printf("\nVerifying backup: %s\n", result.BackupID)
-		return verifyBackupsByID([]string{result.BackupID})
+		return verifyBackupsByID(context.Background(), []string{result.BackupID}, backup.VerifyOptions{})
 	case tui.ActionNone:
 		// User quit without action
 		return nil
 	}
-
-	return nil
+
+	return nil
+}
+
+// restoreBackup restores a backup to the original or specified target path
+func restoreBackup(backupID, targetPath string, force bool) error {
+	// Load index to get backup metadata
+	index, err := backup.LoadIndex()
+	if err != nil {
+		return fmt.Errorf("failed to load backup index: %w", err)
+	}
+
+	// Find the backup
+	metadata, exists := index.Backups[backupID]
+	if !exists {
+		return fmt.Errorf("backup %q not found", backupID)
+	}
+
+	// Use original source path if no target specified
+	if targetPath == "" {
+		targetPath = metadata.SourcePath
+	}
+
+	// Check if target file exists
+	targetExists := false
+	if _, err := os.Stat(targetPath); err == nil {
+		targetExists = true
+	}
+
+	// Display restore details
+	out.Println("\nBackup Details:")
+	out.Printf("  ID:       %s\n", metadata.ID)
+	out.Printf("  Platform: %s\n", metadata.Platform)
+	out.Printf("  Size:     %s\n", formatSize(metadata.Size))
+	out.Printf("  Created:  %s\n", metadata.CreatedAt.Format("2006-01-02 15:04:05"))
+	out.Printf("  Source:   %s\n", metadata.SourcePath)
+	out.Printf("  Target:   %s\n", targetPath)
+
+	if targetExists {
+		out.Println("\n⚠️  Target file already exists and will be overwritten.")
+	}
+
+	// Confirm unless force flag is set
+	if !force {
+		message := fmt.Sprintf("Restore backup to %s?", targetPath)
+		level := riskLevelInfo
+		if targetExists {
+			level = riskLevelWarning
+		}
+
+		confirmed, err := confirmAction(message, level)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Restore cancelled.")
+			return nil
+		}
+	}
+
+	// Perform the restore
+	if err := backup.RestoreBackup(backupID, targetPath); err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	result := backupActionOutput{Action: "restore", Count: 1, Backups: []backup.Metadata{metadata}, Target: targetPath}
+	return out.Render(result, func() error {
+		fmt.Printf("\n✓ Successfully restored backup to %s\n", targetPath)
+		return nil
+	})
+}
+
+func backupRollbackCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "rollback",
+		Usage: "Restore every file backed up by a sync session",
+		UsageText: `skillsync backup rollback --session <session-id> [options]
+   skillsync backup rollback --session 20240125-120000-1a2b3c4d
+   skillsync backup rollback --session 20240125-120000-1a2b3c4d --dry-run
+   skillsync backup rollback --session 20240125-120000-1a2b3c4d --force`,
+		Description: `Undo a sync by restoring the files it modified or deleted.
+
+   Each sync records a session ID on the backups it takes before writing; the
+   ID is printed at the end of the sync and included in --output json results.
+   Rollback restores the oldest backup of each file in the session. Skills the
+   sync newly created had nothing to back up and are left in place.
+
+   Use --dry-run to list the files that would be restored and --force to skip
+   the confirmation prompt.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "session",
+				Aliases:  []string{"s"},
+				Usage:    "Sync session ID to roll back",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the files that would be restored without restoring them",
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Skip confirmation prompt",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return rollbackSession(cmd.String("session"), cmd.Bool("dry-run"), cmd.Bool("force"))
+		},
+	}
 }
 
-// restoreBackup restores a backup to the original or specified target path
-func restoreBackup(backupID, targetPath string, force bool) error {
-	// Load index to get backup metadata
-	index, err := backup.LoadIndex()
+// rollbackSession restores the files backed up by a sync session.
+func rollbackSession(sessionID string, dryRun, force bool) error {
+	plan, err := backup.RollbackPlan(sessionID)
 	if err != nil {
-		return fmt.Errorf("failed to load backup index: %w", err)
+		return err
 	}
 
-	// Find the backup
-	metadata, exists := index.Backups[backupID]
-	if !exists {
-		return fmt.Errorf("backup %q not found", backupID)
+	out.Printf("Session %s backed up %d file(s):\n", sessionID, len(plan))
+	for _, metadata := range plan {
+		out.Printf("  %s  %s\n", metadata.ID, metadata.SourcePath)
 	}
 
-	// Use original source path if no target specified
-	if targetPath == "" {
-		targetPath = metadata.SourcePath
+	if dryRun {
+		result := backupActionOutput{Action: "rollback", Count: 0, Backups: plan}
+		return out.Render(result, func() error {
+			fmt.Println("\nDry run: no files were restored")
+			return nil
+		})
 	}
 
-	// Check if target file exists
-	targetExists := false
-	if _, err := os.Stat(targetPath); err == nil {
-		targetExists = true
+	if !force {
+		confirmed, err := confirmDestructive(fmt.Sprintf("Restore %d file(s) from session %s?", len(plan), sessionID), "restore", len(plan), riskLevelWarning)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Rollback cancelled.")
+			return nil
+		}
 	}
 
-	// Display restore details
-	fmt.Println("\nBackup Details:")
-	fmt.Printf("  ID:       %s\n", metadata.ID)
-	fmt.Printf("  Platform: %s\n", metadata.Platform)
-	fmt.Printf("  Size:     %s\n", formatSize(metadata.Size))
-	fmt.Printf("  Created:  %s\n", metadata.CreatedAt.Format("2006-01-02 15:04:05"))
-	fmt.Printf("  Source:   %s\n", metadata.SourcePath)
-	fmt.Printf("  Target:   %s\n", targetPath)
+	restored, err := backup.RollbackSession(sessionID)
+	if err != nil {
+		return fmt.Errorf("rollback failed after restoring %d file(s): %w", len(restored), err)
+	}
 
-	if targetExists {
-		fmt.Println("\n⚠️  Target file already exists and will be overwritten.")
+	result := backupActionOutput{Action: "rollback", Count: len(restored), Backups: restored}
+	return out.Render(result, func() error {
+		fmt.Printf("\n✓ Restored %d file(s) from session %s\n", len(restored), sessionID)
+		return nil
+	})
+}
+
+// restoreSnapshot restores every file of a snapshot to its original path.
+func restoreSnapshot(snapshotID string, force bool) error {
+	backups, err := backup.SnapshotBackups(snapshotID)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		return fmt.Errorf("no backups found for snapshot %q", snapshotID)
 	}
 
-	// Confirm unless force flag is set
-	if !force {
-		message := fmt.Sprintf("Restore backup to %s?", targetPath)
-		level := riskLevelInfo
-		if targetExists {
-			level = riskLevelWarning
-		}
+	out.Printf("Snapshot %s holds %d file(s):\n", snapshotID, len(backups))
+	for _, metadata := range backups {
+		out.Printf("  %s  %s\n", metadata.Platform, metadata.SourcePath)
+	}
 
-		confirmed, err := confirmAction(message, level)
+	if !force {
+		confirmed, err := confirmDestructive(fmt.Sprintf("Restore %d file(s) from snapshot %s?", len(backups), snapshotID), "restore", len(backups), riskLevelWarning)
 		if err != nil {
 			return fmt.Errorf("confirmation error: %w", err)
 		}
 		if !confirmed {
-			fmt.Println("Restore cancelled.")
+			out.Println("Restore cancelled.")
 			return nil
 		}
 	}
 
-	// Perform the restore
-	if err := backup.RestoreBackup(backupID, targetPath); err != nil {
-		return fmt.Errorf("restore failed: %w", err)
+	restored, err := backup.RestoreSnapshot(snapshotID)
+	if err != nil {
+		return fmt.Errorf("snapshot restore failed: %w", err)
 	}
 
-	fmt.Printf("\n✓ Successfully restored backup to %s\n", targetPath)
-	return nil
+	result := backupActionOutput{Action: "restore", Count: len(restored), Backups: restored, Snapshot: snapshotID}
+	return out.Render(result, func() error {
+		fmt.Printf("\n✓ Restored %d file(s) from snapshot %s\n", len(restored), snapshotID)
+		return nil
+	})
 }
 
 func backupDeleteCommand() *cli.Command {
@@ -2395,7 +4231,7 @@ func backupDeleteCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Filter by platform (claude-code, cursor, codex)",
+				Usage:   "Filter by platform (claude-code, cursor, codex, aider)",
 			},
 			&cli.BoolFlag{
 				Name:    "force",
@@ -2430,6 +4266,132 @@ func backupDeleteCommand() *cli.Command {
 	}
 }
 
+func backupPruneCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "prune",
+		Usage: "Delete backups outside the configured retention policy",
+		UsageText: `skillsync backup prune [options]
+   skillsync backup prune --dry-run                 # List what would be deleted
+   skillsync backup prune --platform cursor --force`,
+		Description: `Delete the backups the retention policy in the config no longer keeps.
+   Sync applies the same policy to the target platform before backing up files.
+
+   backup:
+     max_count: 10      # backups kept of each skill on each platform (0 = all)
+     max_age_days: 30   # days backups are kept (0 = regardless of age)
+     max_size_mb: 500   # total size of each platform's backups (0 = unlimited)
+     lineage_limits:
+       release-checklist: 0   # max_count for individual skills
+
+   The newest backup of each skill is always kept, even past max_age_days and
+   max_size_mb; past max_size_mb the oldest backups go first.
+   Use --force to skip the confirmation prompt.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Usage:   "Only prune backups of a platform (claude-code, cursor, codex, aider)",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List the backups that would be deleted without deleting them",
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Skip confirmation prompt",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return pruneBackups(cmd.String("platform"), cmd.Bool("dry-run"), cmd.Bool("force"))
+		},
+	}
+}
+
+// pruneBackups deletes the backups outside the retention policy of the config
+func pruneBackups(platform string, dryRun, force bool) error {
+	appConfig, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	opts := appConfig.BackupRetention(platform)
+
+	toDelete, err := backup.PlanCleanup(opts)
+	if err != nil {
+		return err
+	}
+	out.Printf("Retention policy: %s\n", formatRetention(opts))
+
+	if len(toDelete) == 0 {
+		return out.Render(backupActionOutput{Action: "prune", DryRun: dryRun}, func() error {
+			fmt.Println("No backups are outside the retention policy.")
+			return nil
+		})
+	}
+
+	var totalSize int64
+	out.Printf("\nBackups to delete (%d):\n", len(toDelete))
+	for _, b := range toDelete {
+		out.Printf("  - %s (%s, %s, %s)\n",
+			b.ID, b.Platform, formatSize(b.Size), b.CreatedAt.Format("2006-01-02"))
+		totalSize += b.Size
+	}
+	out.Printf("\nTotal space to free: %s\n", formatSize(totalSize))
+
+	if dryRun {
+		result := backupActionOutput{Action: "prune", Count: len(toDelete), Backups: toDelete, Freed: totalSize, DryRun: true}
+		return out.Render(result, func() error {
+			fmt.Printf("\nDry run: would delete %d backup(s), freeing %s\n", len(toDelete), formatSize(totalSize))
+			return nil
+		})
+	}
+
+	if !force {
+		message := fmt.Sprintf("Delete %d backup(s)?", len(toDelete))
+		confirmed, err := confirmAction(message, riskLevelWarning)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Prune cancelled.")
+			return nil
+		}
+	}
+
+	var deleted int
+	for _, b := range toDelete {
+		if err := backup.DeleteBackup(b.ID); err != nil {
+			return fmt.Errorf("failed to delete backup %q: %w", b.ID, err)
+		}
+		deleted++
+	}
+
+	result := backupActionOutput{Action: "prune", Count: deleted, Backups: toDelete, Freed: totalSize}
+	return out.Render(result, func() error {
+		fmt.Printf("\n✓ Deleted %d backup(s), freed %s\n", deleted, formatSize(totalSize))
+		return nil
+	})
+}
+
+// formatRetention describes a retention policy, e.g.
+// "10 per skill, 30 days, 500.0 MB per platform".
+func formatRetention(opts backup.CleanupOptions) string {
+	count, age, size := "all per skill", "any age", "unlimited size"
+	if opts.MaxBackups > 0 {
+		count = fmt.Sprintf("%d per skill", opts.MaxBackups)
+	}
+	if opts.MaxAge > 0 {
+		age = fmt.Sprintf("%d days", int(opts.MaxAge.Hours()/24))
+	}
+	if opts.MaxTotalSize > 0 {
+		size = formatSize(opts.MaxTotalSize) + " per platform"
+	}
+	return strings.Join([]string{count, age, size}, ", ")
+}
+
+// defaultVerifyConcurrency is the default number of backups verified in parallel.
+const defaultVerifyConcurrency = 4
+
 func backupVerifyCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "verify",
@@ -2437,102 +4399,193 @@ func backupVerifyCommand() *cli.Command {
 		UsageText: `skillsync backup verify [backup-id...]
    skillsync backup verify                           # Verify all backups
    skillsync backup verify 20240125-120000-abc12345  # Verify specific backup
-   skillsync backup verify --platform claude-code    # Verify backups for a platform`,
+   skillsync backup verify --platform claude-code    # Verify backups for a platform
+   skillsync backup verify --fail-fast -j 8          # Stop at the first failure`,
 		Description: `Verify backup integrity by comparing file content against stored SHA256 checksums.
 
    Without arguments, verifies all backups. Pass one or more backup IDs to verify
    specific backups. Use --platform to filter verification to a specific platform.
 
+   Backups are verified in parallel (--concurrency, default 4) with a progress
+   bar on the terminal. --fail-fast stops at the first backup that fails, and
+   Ctrl+C stops early; backups left unverified are reported as SKIPPED. The
+   summary shows the bytes verified and the throughput.
+
    The command reports:
      ✓ OK       - Backup file is intact and matches stored checksum
      ✗ CORRUPT  - Backup file has been modified or corrupted
      ✗ MISSING  - Backup file no longer exists on disk
+     - SKIPPED  - Backup was not verified because verification stopped early
 
    Exit codes:
      0 - All verified backups are intact
-     1 - One or more backups failed verification`,
+     1 - One or more backups failed verification, or verification was interrupted`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Filter by platform (claude-code, cursor, codex)",
+				Usage:   "Filter by platform (claude-code, cursor, codex, aider)",
+			},
+			&cli.IntFlag{
+				Name:    "concurrency",
+				Aliases: []string{"j"},
+				Value:   defaultVerifyConcurrency,
+				Usage:   "Number of backups to verify in parallel",
+			},
+			&cli.BoolFlag{
+				Name:  "fail-fast",
+				Usage: "Stop at the first backup that fails verification",
 			},
 		},
-		Action: func(_ context.Context, cmd *cli.Command) error {
+		Action: func(ctx context.Context, cmd *cli.Command) error {
 			args := cmd.Args()
 			platform := cmd.String("platform")
 
+			concurrency := int(cmd.Int("concurrency"))
+			if concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1, got %d", concurrency)
+			}
+			opts := backup.VerifyOptions{Concurrency: concurrency, FailFast: cmd.Bool("fail-fast")}
+
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+			defer stop()
+
 			if args.Len() > 0 {
 				// Verify specific backup IDs
 				ids := make([]string, args.Len())
 				for i := 0; i < args.Len(); i++ {
 					ids[i] = args.Get(i)
 				}
-				return verifyBackupsByID(ids)
+				return verifyBackupsByID(ctx, ids, opts)
 			}
 
 			// Verify all backups (optionally filtered by platform)
-			return verifyAllBackups(platform)
+			return verifyAllBackups(ctx, platform, opts)
 		},
 	}
 }
 
-// verifyBackupsByID verifies specific backups by their IDs
-func verifyBackupsByID(ids []string) error {
-	fmt.Printf("Verifying %d backup(s)...\n\n", len(ids))
+func backupReindexCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "reindex",
+		Usage:     "Rebuild the backup index from backup files on disk",
+		UsageText: `skillsync backup reindex`,
+		Description: `Rebuild the backup index by scanning backup files and their metadata.
 
-	var failed int
-	for _, id := range ids {
-		if err := backup.VerifyBackup(id); err != nil {
-			fmt.Printf("✗ %-28s FAILED: %v\n", id, err)
-			failed++
-		} else {
-			fmt.Printf("✓ %-28s OK\n", id)
-		}
-	}
+   Use this when index.json is truncated or invalid and no good copy is left in
+   index.json.bak. Every backup file is re-hashed; metadata is taken from the
+   previous index or the file's .meta.json sidecar when available, and is
+   otherwise derived from the backup ID in the file name. Files whose content
+   does not match the hash in their name are skipped.
 
-	fmt.Println()
-	if failed > 0 {
-		fmt.Printf("Verification complete: %d OK, %d FAILED\n", len(ids)-failed, failed)
-		return fmt.Errorf("%d backup(s) failed verification", failed)
+   Examples:
+     skillsync backup reindex
+     skillsync backup reindex --format json`,
+		Action: func(_ context.Context, _ *cli.Command) error {
+			return reindexBackups()
+		},
 	}
+}
 
-	fmt.Printf("Verification complete: %d OK\n", len(ids))
-	return nil
+// reindexBackups rebuilds the backup index and reports what was recovered
+func reindexBackups() error {
+	result, err := backup.Reindex()
+	if err != nil {
+		return fmt.Errorf("failed to rebuild backup index: %w", err)
+	}
+
+	return out.Render(result, func() error {
+		fmt.Printf("✓ Rebuilt backup index with %d backup(s)\n", result.Indexed)
+		fmt.Printf("  From previous index: %d\n", result.FromIndex)
+		fmt.Printf("  From sidecar files:  %d\n", result.FromSidecar)
+		fmt.Printf("  Derived from files:  %d\n", result.Derived)
+		if len(result.Skipped) > 0 {
+			fmt.Printf("\nSkipped %d file(s):\n", len(result.Skipped))
+			for _, s := range result.Skipped {
+				fmt.Printf("  ✗ %s\n", s)
+			}
+		}
+		return nil
+	})
+}
+
+// verifyBackupsByID verifies specific backups by their IDs
+func verifyBackupsByID(ctx context.Context, ids []string, opts backup.VerifyOptions) error {
+	return runBackupVerify(ctx, ids, opts)
 }
 
 // verifyAllBackups verifies all backups, optionally filtered by platform
-func verifyAllBackups(platform string) error {
+func verifyAllBackups(ctx context.Context, platform string, opts backup.VerifyOptions) error {
 	backups, err := backup.ListBackups(platform)
 	if err != nil {
 		return fmt.Errorf("failed to list backups: %w", err)
 	}
 
 	if len(backups) == 0 {
-		fmt.Println("No backups found to verify.")
-		return nil
+		return out.Render([]backupVerifyOutput{}, func() error {
+			fmt.Println("No backups found to verify.")
+			return nil
+		})
+	}
+
+	ids := make([]string, len(backups))
+	for i, b := range backups {
+		ids[i] = b.ID
 	}
+	return runBackupVerify(ctx, ids, opts)
+}
 
-	fmt.Printf("Verifying %d backup(s)...\n\n", len(backups))
+// runBackupVerify verifies backups with a progress bar, then reports each
+// result in order and a summary with the bytes verified and throughput.
+func runBackupVerify(ctx context.Context, ids []string, opts backup.VerifyOptions) error {
+	out.Printf("Verifying %d backup(s)...\n\n", len(ids))
 
-	var ok, failed int
-	for _, b := range backups {
-		if err := backup.VerifyBackup(b.ID); err != nil {
-			fmt.Printf("✗ %-28s %-12s FAILED: %v\n", b.ID, b.Platform, err)
-			failed++
-		} else {
-			fmt.Printf("✓ %-28s %-12s OK\n", b.ID, b.Platform)
-			ok++
+	bar := newProgressBar("Verifying", len(ids))
+	opts.Progress = func(p backup.VerifyProgress) {
+		bar.Update(p.Completed, formatSize(p.Bytes))
+	}
+	report, verifyErr := backup.VerifyBackups(ctx, ids, opts)
+	bar.Done()
+	if report == nil {
+		return verifyErr
+	}
+
+	results := make([]backupVerifyOutput, 0, len(report.Results))
+	for _, r := range report.Results {
+		switch {
+		case r.Err == nil:
+			out.Printf("✓ %-28s %-12s OK\n", r.ID, r.Platform)
+			results = append(results, backupVerifyOutput{ID: r.ID, Platform: r.Platform, OK: true, Bytes: r.Bytes})
+		case r.Skipped():
+			out.Printf("- %-28s %-12s SKIPPED\n", r.ID, r.Platform)
+			results = append(results, backupVerifyOutput{ID: r.ID, Platform: r.Platform, Skipped: true})
+		default:
+			out.Printf("✗ %-28s %-12s FAILED: %v\n", r.ID, r.Platform, r.Err)
+			results = append(results, backupVerifyOutput{ID: r.ID, Platform: r.Platform, Bytes: r.Bytes, Error: r.Err.Error()})
 		}
 	}
 
-	fmt.Println()
-	if failed > 0 {
-		fmt.Printf("Verification complete: %d OK, %d FAILED\n", ok, failed)
-		return fmt.Errorf("%d backup(s) failed verification", failed)
+	if err := out.Render(results, nil); err != nil {
+		return err
+	}
+
+	out.Println()
+	summary := fmt.Sprintf("Verification complete: %d OK", report.OK)
+	if report.Failed > 0 {
+		summary += fmt.Sprintf(", %d FAILED", report.Failed)
+	}
+	if report.Skipped > 0 {
+		summary += fmt.Sprintf(", %d SKIPPED", report.Skipped)
 	}
+	out.Println(summary)
+	out.Printf("Verified %s in %s (%s/s)\n", formatSize(report.Bytes), report.Duration.Round(time.Millisecond), formatSize(int64(report.Throughput())))
 
-	fmt.Printf("Verification complete: %d OK\n", ok)
+	switch {
+	case report.Failed > 0:
+		return fmt.Errorf("%d backup(s) failed verification", report.Failed)
+	case verifyErr != nil:
+		return fmt.Errorf("verification interrupted: %w", verifyErr)
+	}
 	return nil
 }
 
@@ -2555,9 +4608,9 @@ func deleteBackupsByID(ids []string, force bool) error {
 	}
 
 	// Display what will be deleted
-	fmt.Printf("\nBackups to delete (%d):\n", len(backupsToDelete))
+	out.Printf("\nBackups to delete (%d):\n", len(backupsToDelete))
 	for _, b := range backupsToDelete {
-		fmt.Printf("  - %s (%s, %s)\n", b.ID, b.Platform, formatSize(b.Size))
+		out.Printf("  - %s (%s, %s)\n", b.ID, b.Platform, formatSize(b.Size))
 	}
 
 	// Confirm unless force flag is set
@@ -2568,7 +4621,7 @@ func deleteBackupsByID(ids []string, force bool) error {
 			return fmt.Errorf("confirmation error: %w", err)
 		}
 		if !confirmed {
-			fmt.Println("Delete cancelled.")
+			out.Println("Delete cancelled.")
 			return nil
 		}
 	}
@@ -2582,8 +4635,10 @@ func deleteBackupsByID(ids []string, force bool) error {
 		deleted++
 	}
 
-	fmt.Printf("\n✓ Deleted %d backup(s)\n", deleted)
-	return nil
+	return out.Render(backupActionOutput{Action: "delete", Count: deleted, Backups: backupsToDelete}, func() error {
+		fmt.Printf("\n✓ Deleted %d backup(s)\n", deleted)
+		return nil
+	})
 }
 
 // deleteBackupsByPolicy deletes backups based on age or count retention
@@ -2605,7 +4660,7 @@ func deleteBackupsByPolicy(olderThan string, keepLatest int, platform string, fo
 	}
 
 	if len(backups) == 0 {
-		fmt.Println("No backups found.")
+		out.Println("No backups found.")
 		return nil
 	}
 
@@ -2632,23 +4687,23 @@ func deleteBackupsByPolicy(olderThan string, keepLatest int, platform string, fo
 	}
 
 	if len(toDelete) == 0 {
-		fmt.Println("No backups match the deletion criteria.")
+		out.Println("No backups match the deletion criteria.")
 		return nil
 	}
 
 	// Display what will be deleted
 	var totalSize int64
-	fmt.Printf("\nBackups to delete (%d):\n", len(toDelete))
+	out.Printf("\nBackups to delete (%d):\n", len(toDelete))
 	for _, b := range toDelete {
-		fmt.Printf("  - %s (%s, %s, %s)\n",
+		out.Printf("  - %s (%s, %s, %s)\n",
 			b.ID, b.Platform, formatSize(b.Size), b.CreatedAt.Format("2006-01-02"))
 		totalSize += b.Size
 	}
-	fmt.Printf("\nTotal space to free: %s\n", formatSize(totalSize))
+	out.Printf("\nTotal space to free: %s\n", formatSize(totalSize))
 
 	// Show what will be kept
 	keptCount := len(backups) - len(toDelete)
-	fmt.Printf("Backups remaining: %d\n", keptCount)
+	out.Printf("Backups remaining: %d\n", keptCount)
 
 	// Confirm unless force flag is set
 	if !force {
@@ -2658,7 +4713,7 @@ func deleteBackupsByPolicy(olderThan string, keepLatest int, platform string, fo
 			return fmt.Errorf("confirmation error: %w", err)
 		}
 		if !confirmed {
-			fmt.Println("Delete cancelled.")
+			out.Println("Delete cancelled.")
 			return nil
 		}
 	}
@@ -2672,8 +4727,11 @@ func deleteBackupsByPolicy(olderThan string, keepLatest int, platform string, fo
 		deleted++
 	}
 
-	fmt.Printf("\n✓ Deleted %d backup(s), freed %s\n", deleted, formatSize(totalSize))
-	return nil
+	result := backupActionOutput{Action: "delete", Count: deleted, Backups: toDelete, Freed: totalSize}
+	return out.Render(result, func() error {
+		fmt.Printf("\n✓ Deleted %d backup(s), freed %s\n", deleted, formatSize(totalSize))
+		return nil
+	})
 }
 
 // parseDuration parses a duration string with support for day and week units
@@ -2748,20 +4806,24 @@ func outputBackupsTable(backups []backup.Metadata) error {
 		return backups[i].CreatedAt.After(backups[j].CreatedAt)
 	})
 
+	// SOURCE takes the width left over by the fixed-width columns
+	// (ID 28, PLATFORM 12, CREATED 20, SIZE 9, plus 4 gaps)
+	sourceWidth := max(tableWidth()-73, 20)
+
 	// Print colored headers
 	fmt.Printf("%s %s %s %s %s\n",
 		ui.Header(fmt.Sprintf("%-28s", "ID")),
 		ui.Header(fmt.Sprintf("%-12s", "PLATFORM")),
-		ui.Header(fmt.Sprintf("%-45s", "SOURCE")),
+		ui.Header(fmt.Sprintf("%-*s", sourceWidth, "SOURCE")),
 		ui.Header(fmt.Sprintf("%-20s", "CREATED")),
 		ui.Header("SIZE"))
-	fmt.Printf("%-28s %-12s %-45s %-20s %s\n", "--", "--------", "------", "-------", "----")
+	fmt.Printf("%-28s %-12s %-*s %-20s %s\n", "--", "--------", sourceWidth, "------", "-------", "----")
 
 	for _, b := range backups {
 		// Truncate source path if too long (use left-truncation to preserve the meaningful end)
-		source := b.SourcePath
-		if len(source) > 45 {
-			source = "..." + source[len(source)-42:]
+		sources := []string{truncateCellLeft(b.SourcePath, sourceWidth)}
+		if out.wrap {
+			sources = wrapCell(b.SourcePath, sourceWidth)
 		}
 
 		// Format size
@@ -2773,7 +4835,10 @@ func outputBackupsTable(backups []backup.Metadata) error {
 		// Color platform names for visual distinction
 		platform := colorPlatform(string(b.Platform), 12)
 
-		fmt.Printf("%-28s %s %-45s %-20s %s\n", b.ID, platform, source, created, size)
+		fmt.Printf("%-28s %s %s %-20s %s\n", b.ID, platform, padCell(sources[0], sourceWidth), created, size)
+		for _, source := range sources[1:] {
+			fmt.Printf("%-28s %-12s %s\n", "", "", source)
+		}
 	}
 
 	fmt.Printf("\nTotal: %d backup(s)\n", len(backups))
@@ -2817,7 +4882,10 @@ func tuiCommand() *cli.Command {
    - Scope and promote/demote management
    - Configuration settings
 
-   Use arrow keys to navigate, Enter to select, and q to quit.`,
+   Use arrow keys to navigate, Enter to select, and q to quit.
+
+   Syncs, deletes, promotions, and conflict resolutions are recorded in the
+   sync history (skillsync history) and summarized when the TUI exits.`,
 		Action: func(_ context.Context, _ *cli.Command) error {
 			return runTUI()
 		},
@@ -2825,7 +4893,11 @@ func tuiCommand() *cli.Command {
 }
 
 // runTUI launches the interactive TUI dashboard and handles view navigation.
+// The actions taken are recorded in the sync history and summarized on exit.
 func runTUI() error {
+	session := newTUISession()
+	defer session.printSummary()
+
 	for {
 		result, err := tui.RunDashboard()
 		if err != nil {
@@ -2848,7 +4920,7 @@ func runTUI() error {
 			}
 
 		case tui.DashboardViewSync:
-			if err := runSyncTUI(); err != nil {
+			if err := runSyncTUI(session); err != nil {
 				return err
 			}
 
@@ -2878,17 +4950,17 @@ func runTUI() error {
 			}
 
 		case tui.DashboardViewPromote:
-			if err := runPromoteDemoteTUI(); err != nil {
+			if err := runPromoteDemoteTUI(session); err != nil {
 				return err
 			}
 
 		case tui.DashboardViewDelete:
-			if err := runDeleteTUI(); err != nil {
+			if err := runDeleteTUI(session); err != nil {
 				return err
 			}
 
 		case tui.DashboardViewConflicts:
-			if err := runConflictsTUI(); err != nil {
+			if err := runConflictsTUI(session); err != nil {
 				return err
 			}
 		}
@@ -2928,7 +5000,7 @@ func runBackupsTUI() error {
 }
 
 // runSyncTUI runs the sync TUI view.
-func runSyncTUI() error {
+func runSyncTUI(session *tuiSession) error {
 	// Step 1: Pick source/target platform and scope
 	pickerResult, err := tui.RunSyncPicker()
 	if err != nil {
@@ -2984,38 +5056,40 @@ func runSyncTUI() error {
 		return nil
 	}
 
-	// Create backup before sync
-	prepareBackup(targetPlatform)
-	created, err := backupExistingTargetSkills(
-		targetPlatform,
-		targetScope,
-		syncResult.SelectedSkills,
-		"pre-sync backup",
-		[]string{"sync"},
-	)
-	if err != nil {
-		return err
+	// Perform sync, backing up each target file before it is overwritten
+	backupTargets := !util.NoPersist()
+	if backupTargets {
+		prepareBackup(targetPlatform)
 	}
-	if created > 0 {
-		fmt.Printf("✓ Created %d backup(s)\n", created)
-	}
-
-	// Perform sync
 	syncer := sync.New()
 	opts := sync.Options{
 		Strategy:    sync.StrategyOverwrite,
 		TargetScope: targetScope,
+		Backup:      backupTargets,
+		SessionID:   backup.NewSessionID(),
 	}
+	if appConfig, err := config.Load(); err == nil {
+		opts.TrashRetention = appConfig.TrashRetention()
+		opts.Metrics = appConfig.Sync.Metrics
+	}
+	startedAt := time.Now()
 	result, err := syncer.SyncWithSkills(syncResult.SelectedSkills, targetPlatform, opts)
 	if err != nil {
 		return fmt.Errorf("sync failed: %w", err)
 	}
+	session.recordResult(opts.SessionID, "sync", startedAt, result)
 
 	// Display results
 	changed := result.TotalChanged()
 	ui.Success(fmt.Sprintf("Synced %d skills from %s to %s", changed, sourcePlatform, targetPlatform))
+	if len(result.Unchanged()) > 0 {
+		ui.Info(fmt.Sprintf("%d skills already up to date", len(result.Unchanged())))
+	}
 	if len(result.Skipped()) > 0 {
-		ui.Info(fmt.Sprintf("Skipped %d skills (already up to date)", len(result.Skipped())))
+		ui.Info(fmt.Sprintf("Skipped %d skills", len(result.Skipped())))
+	}
+	if backups := result.BackupIDs(); len(backups) > 0 {
+		ui.Info(fmt.Sprintf("Backed up %d file(s); undo with 'skillsync backup rollback --session %s'", len(backups), result.SessionID))
 	}
 	if result.HasConflicts() {
 		ui.Warning(fmt.Sprintf("%d conflicts detected - use 'Resolve Conflicts' to handle them", len(result.Conflicts())))
@@ -3026,7 +5100,7 @@ func runSyncTUI() error {
 
 // runConfigTUI runs the configuration editor TUI view.
 func runConfigTUI() error {
-	cfg, err := config.Load()
+	cfg, err := config.LoadUser()
 	if err != nil {
 		ui.Warning(fmt.Sprintf("Could not load config: %v", err))
 		cfg = config.Default()
@@ -3154,7 +5228,7 @@ func executeImport(result tui.ImportListResult) error {
 		switch skill.Action {
 		case sync.ActionCreated, sync.ActionUpdated:
 			imported++
-		case sync.ActionSkipped:
+		case sync.ActionSkipped, sync.ActionUnchanged:
 			skipped++
 		case sync.ActionFailed:
 			failed++
@@ -3176,7 +5250,7 @@ func executeImport(result tui.ImportListResult) error {
 }
 
 // runDeleteTUI runs the delete skills TUI view.
-func runDeleteTUI() error {
+func runDeleteTUI(session *tuiSession) error {
 	// Discover skills from all platforms
 	var allSkills []model.Skill
 	for _, p := range model.AllPlatforms() {
@@ -3204,19 +5278,27 @@ func runDeleteTUI() error {
 	}
 
 	if result.Action == tui.DeleteActionDelete {
-		return executeDelete(result)
+		return executeDelete(session, result)
 	}
 
 	return nil
 }
 
 // executeDelete performs the actual deletion based on TUI result.
-func executeDelete(result tui.DeleteListResult) error {
+func executeDelete(session *tuiSession, result tui.DeleteListResult) error {
 	if len(result.SelectedSkills) == 0 {
 		ui.Info("No skills selected for deletion")
 		return nil
 	}
 
+	run := history.Run{
+		Command:   "delete",
+		Target:    skillPlatforms(result.SelectedSkills),
+		StartedAt: time.Now(),
+		Success:   true,
+	}
+	defer func() { session.record(run) }()
+
 	// Delete each selected skill
 	var deleted int
 	var errors []string
@@ -3230,8 +5312,15 @@ func executeDelete(result tui.DeleteListResult) error {
 		// Delete the skill file
 		if err := os.Remove(skill.Path); err != nil {
 			errors = append(errors, fmt.Sprintf("%s: %v", skill.Name, err))
+			run.Success = false
+			run.Skills = append(run.Skills, history.SkillRecord{
+				Name: skill.Name, Action: string(sync.ActionFailed), TargetPath: skill.Path, Error: err.Error(),
+			})
 			continue
 		}
+		run.Skills = append(run.Skills, history.SkillRecord{
+			Name: skill.Name, Action: string(sync.ActionDeleted), TargetPath: skill.Path,
+		})
 
 		// Try to remove the parent directory if it's empty (for directory-based skills)
 		if strings.HasSuffix(skill.Path, "/SKILL.md") {
@@ -3312,18 +5401,9 @@ func runScopeTUI() error {
 
 // runConflictsTUI runs the conflict resolution TUI view.
 // This scans for potential conflicts across platforms and shows them for resolution.
-func runConflictsTUI() error {
+func runConflictsTUI(session *tuiSession) error {
 	// Discover skills from all platforms to find potential conflicts
-	platformSkills := make(map[model.Platform][]model.Skill)
-	for _, p := range model.AllPlatforms() {
-		skills, err := parsePlatformSkillsWithScope(p, nil, false)
-		if err != nil {
-			continue
-		}
-		if len(skills) > 0 {
-			platformSkills[p] = skills
-		}
-	}
+	platformSkills := discoverPlatformSkills()
 
 	// Need at least 2 platforms with skills to have potential conflicts
 	if len(platformSkills) < 2 {
@@ -3333,30 +5413,7 @@ func runConflictsTUI() error {
 	}
 
 	// Find skills that exist on multiple platforms with different content
-	detector := sync.NewConflictDetector()
-	var conflicts []*sync.Conflict
-
-	// Build map of skill name -> skills across platforms
-	skillMap := make(map[string][]model.Skill)
-	for _, skills := range platformSkills {
-		for _, skill := range skills {
-			skillMap[skill.Name] = append(skillMap[skill.Name], skill)
-		}
-	}
-
-	// Check each skill that exists on multiple platforms
-	for _, skills := range skillMap {
-		if len(skills) < 2 {
-			continue
-		}
-		// Compare first skill with others
-		for i := 1; i < len(skills); i++ {
-			conflict := detector.DetectConflict(skills[0], skills[i])
-			if conflict != nil {
-				conflicts = append(conflicts, conflict)
-			}
-		}
-	}
+	conflicts, skillMap := crossPlatformConflicts(platformSkills)
 
 	if len(conflicts) == 0 {
 		ui.Success("No conflicts found across platforms")
@@ -3376,6 +5433,14 @@ func runConflictsTUI() error {
 
 	// Apply resolutions
 	if result.Action == tui.ConflictActionResolve {
+		run := history.Run{
+			Command:   history.CommandResolve,
+			Target:    "cross-platform",
+			StartedAt: time.Now(),
+			Success:   true,
+		}
+		defer func() { session.record(run) }()
+
 		applied := 0
 		for _, resolution := range result.Resolutions {
 			if resolution.Resolution == sync.ResolutionSkip {
@@ -3408,10 +5473,20 @@ func runConflictsTUI() error {
 					continue // Already has the resolved content
 				}
 				if skill.Path != "" {
+					record := history.SkillRecord{
+						Name:       skill.Name,
+						Action:     history.ActionResolved,
+						TargetPath: skill.Path,
+						Message:    fmt.Sprintf("%s on %s", resolution.Resolution, skill.Platform),
+					}
 					if err := os.WriteFile(skill.Path, []byte(content), 0o600); err != nil {
 						ui.Warning(fmt.Sprintf("Failed to update %s on %s: %v", skill.Name, skill.Platform, err))
+						record.Action, record.Error = string(sync.ActionFailed), err.Error()
+						run.Skills = append(run.Skills, record)
+						run.Success = false
 						continue
 					}
+					run.Skills = append(run.Skills, record)
 					applied++
 				}
 			}
@@ -3480,6 +5555,12 @@ func findDuplicatesForTUI(skills []model.Skill, cfg *config.Config) ([]*similari
 	var results []*similarity.ComparisonResult
 	comparedPairs := make(map[string]bool)
 
+	contentMatcher, err := newContentScorer(cfg.Similarity.Embeddings, cfg.Similarity.ContentThreshold,
+		cfg.Similarity.Embeddings.Threshold, cfg.Similarity.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
 	// Name similarity matching
 	nameConfig := similarity.NameMatcherConfig{
 		Threshold: cfg.Similarity.NameThreshold,
@@ -3497,12 +5578,6 @@ func findDuplicatesForTUI(skills []model.Skill, cfg *config.Config) ([]*similari
 		comparedPairs[pairKey] = true
 
 		// Compute content score
-		contentConfig := similarity.ContentMatcherConfig{
-			Threshold: 0, // Don't filter, we want the score
-			Algorithm: cfg.Similarity.Algorithm,
-			LineMode:  true,
-		}
-		contentMatcher := similarity.NewContentMatcher(contentConfig)
 		contentScore := contentMatcher.Compare(match.Skill1.Content, match.Skill2.Content)
 
 		result := similarity.ComputeDiff(match.Skill1, match.Skill2, match.Score, contentScore)
@@ -3510,12 +5585,6 @@ func findDuplicatesForTUI(skills []model.Skill, cfg *config.Config) ([]*similari
 	}
 
 	// Content similarity matching
-	contentConfig := similarity.ContentMatcherConfig{
-		Threshold: cfg.Similarity.ContentThreshold,
-		Algorithm: cfg.Similarity.Algorithm,
-		LineMode:  true,
-	}
-	contentMatcher := similarity.NewContentMatcher(contentConfig)
 	contentMatches := contentMatcher.FindSimilar(skills)
 
 	for _, match := range contentMatches {
@@ -3552,7 +5621,7 @@ func makeDupePairKey(s1, s2 model.Skill) string {
 }
 
 // runPromoteDemoteTUI runs the promote/demote skills TUI view.
-func runPromoteDemoteTUI() error {
+func runPromoteDemoteTUI(session *tuiSession) error {
 	// Discover skills from all platforms
 	var allSkills []model.Skill
 	for _, p := range model.AllPlatforms() {
@@ -3579,11 +5648,11 @@ func runPromoteDemoteTUI() error {
 		return nil
 	}
 
-	return executePromoteDemote(result)
+	return executePromoteDemote(session, result)
 }
 
 // executePromoteDemote performs the actual promote/demote based on TUI result.
-func executePromoteDemote(result tui.PromoteDemoteListResult) error {
+func executePromoteDemote(session *tuiSession, result tui.PromoteDemoteListResult) error {
 	if len(result.SelectedSkills) == 0 {
 		ui.Info("No skills selected")
 		return nil
@@ -3591,72 +5660,66 @@ func executePromoteDemote(result tui.PromoteDemoteListResult) error {
 
 	isPromotion := result.Action == tui.PromoteDemoteActionPromote
 	operation := "Demote"
+	run := history.Run{
+		ID:        backup.NewSessionID(),
+		Command:   history.CommandDemote,
+		Source:    string(model.ScopeUser),
+		Target:    string(model.ScopeRepo),
+		StartedAt: time.Now(),
+		Success:   true,
+	}
 	if isPromotion {
 		operation = "Promote"
+		run.Command, run.Source, run.Target = history.CommandPromote, run.Target, run.Source
 	}
+	defer func() { session.record(run) }()
 
 	var processed int
 	var errors []string
+	fail := func(skill model.Skill, format string, err error) {
+		errors = append(errors, fmt.Sprintf("%s: "+format, skill.Name, err))
+		run.Success = false
+		run.Skills = append(run.Skills, history.SkillRecord{
+			Name: skill.Name, Action: string(sync.ActionFailed), TargetPath: skill.Path, Error: err.Error(),
+		})
+	}
 
 	for _, skill := range result.SelectedSkills {
-		// Determine source and target scopes based on operation type
-		var fromScope, toScope model.SkillScope
+		// Promote moves repo skills to user scope, demote user skills to repo scope
+		fromScope, toScope := model.ScopeUser, model.ScopeRepo
 		if isPromotion {
-			// Promote: repo -> user
-			if skill.Scope != model.ScopeRepo {
-				continue // Skip skills that can't be promoted
-			}
-			fromScope = model.ScopeRepo
-			toScope = model.ScopeUser
-		} else {
-			// Demote: user -> repo
-			if skill.Scope != model.ScopeUser {
-				continue // Skip skills that can't be demoted
-			}
-			fromScope = model.ScopeUser
-			toScope = model.ScopeRepo
+			fromScope, toScope = toScope, fromScope
+		}
+		if skill.Scope != fromScope {
+			continue // Skip skills that can't be promoted or demoted
 		}
 
 		// Get target path
 		targetPath, err := getSkillPathForScope(skill.Platform, toScope, skill.Name)
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("%s: failed to determine target path: %v", skill.Name, err))
+			fail(skill, "failed to determine target path: %v", err)
 			continue
 		}
 
-		// Ensure target directory exists
-		// #nosec G301 - skill directories need to be readable by the platform
-		targetDir := filepath.Dir(targetPath)
-		if err := os.MkdirAll(targetDir, 0o750); err != nil {
-			errors = append(errors, fmt.Sprintf("%s: failed to create target directory: %v", skill.Name, err))
-			continue
+		moved, err := promote.Move(skill, targetPath, promote.Options{
+			KeepSource: !result.RemoveSource,
+			SkipBackup: util.NoPersist(),
+			SessionID:  run.ID,
+		})
+		if moved != nil {
+			run.Skills = append(run.Skills, moved.Records...)
 		}
-
-		// Read source content
-		// #nosec G304 - skill.Path comes from parsed skill files
-		content, err := os.ReadFile(skill.Path)
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("%s: failed to read source: %v", skill.Name, err))
-			continue
-		}
-
-		// Write to target
-		// #nosec G306 - skill files should be readable
-		if err := os.WriteFile(targetPath, content, 0o644); err != nil {
-			errors = append(errors, fmt.Sprintf("%s: failed to write to target: %v", skill.Name, err))
-			continue
-		}
-
-		// Remove source if requested
-		if result.RemoveSource {
-			if err := os.Remove(skill.Path); err != nil {
-				errors = append(errors, fmt.Sprintf("%s: copied but failed to remove source: %v", skill.Name, err))
-				// Don't continue - the copy was successful
+			if moved == nil {
+				fail(skill, "%v", err)
+				continue
 			}
+			// The copy was successful
+			errors = append(errors, fmt.Sprintf("%s: %v", skill.Name, err))
+			run.Success = false
 		}
 
 		processed++
-		_ = fromScope // Used for clarity in logic above
 	}
 
 	if processed > 0 {
@@ -3,15 +3,25 @@ package cli
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/urfave/cli/v3"
+
 	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/export"
 	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
 	"github.com/klauern/skillsync/internal/validation"
 )
 
@@ -195,6 +205,76 @@ func TestConfigInitCommand(t *testing.T) {
 	}
 }
 
+func TestConfigGetSetCommands(t *testing.T) {
+	tests := map[string]struct {
+		args       [][]string // commands run in order; the last one is checked
+		env        map[string]string
+		wantErr    string
+		wantOutput string
+	}{
+		"get default": {
+			args:       [][]string{{"config", "get", "sync.default_strategy"}},
+			wantOutput: "overwrite\n",
+		},
+		"get section as yaml": {
+			args:       [][]string{{"config", "get", "diff"}},
+			wantOutput: "algorithm: myers\ncontext: 3\n",
+		},
+		"set then get": {
+			args: [][]string{
+				{"config", "set", "sync.default_strategy", "newer"},
+				{"config", "get", "sync.default_strategy"},
+			},
+			wantOutput: "newer\n",
+		},
+		"get reflects environment": {
+			args: [][]string{
+				{"config", "set", "platforms.cursor.max_skills", "80"},
+				{"config", "get", "platforms.cursor.max_skills"},
+			},
+			env:        map[string]string{"SKILLSYNC_CURSOR_MAX_SKILLS": "10"},
+			wantOutput: "10\n",
+		},
+		"set rejects invalid value": {
+			args:    [][]string{{"config", "set", "sync.default_strategy", "bogus"}},
+			wantErr: "invalid strategy",
+		},
+		"get requires a key": {
+			args:    [][]string{{"config", "get"}},
+			wantErr: "exactly 1 argument",
+		},
+		"set requires a value": {
+			args:    [][]string{{"config", "set", "sync.default_strategy"}},
+			wantErr: "exactly 2 arguments",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SKILLSYNC_HOME", t.TempDir())
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			var runErr error
+			var output string
+			for _, args := range tt.args {
+				output = captureOutput(t, func() {
+					runErr = Run(context.Background(), append([]string{"skillsync"}, args...))
+				})
+			}
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want %q", runErr, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, runErr)
+			util.AssertEqual(t, output, tt.wantOutput)
+		})
+	}
+}
+
 func TestConfigEditCommand(t *testing.T) {
 	tests := map[string]struct {
 		setup      func(t *testing.T)
@@ -482,6 +562,31 @@ func TestExportCommand(t *testing.T) {
 			wantErr:    false,
 			wantOutput: "No skills found",
 		},
+		"export csv format with columns": {
+			args:       []string{"skillsync", "export", "--format", "csv", "--columns", "name,tags"},
+			wantErr:    false,
+			wantOutput: "No skills found",
+		},
+		"export unknown column": {
+			args:    []string{"skillsync", "export", "--format", "csv", "--columns", "name,owner"},
+			wantErr: true,
+		},
+		"export columns with json format": {
+			args:    []string{"skillsync", "export", "--format", "json", "--columns", "name"},
+			wantErr: true,
+		},
+		"export files without output": {
+			args:    []string{"skillsync", "export", "--format", "files"},
+			wantErr: true,
+		},
+		"export html without output": {
+			args:    []string{"skillsync", "export", "--format", "html"},
+			wantErr: true,
+		},
+		"export xlsx incremental": {
+			args:    []string{"skillsync", "export", "--format", "xlsx", "--incremental"},
+			wantErr: true,
+		},
 		"export invalid format": {
 			args:    []string{"skillsync", "export", "--format", "invalid"},
 			wantErr: true,
@@ -589,6 +694,172 @@ func TestExportToFile(t *testing.T) {
 	}
 }
 
+func TestExportInventory(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, ".skillsync"))
+	pluginsDir := filepath.Join(tempDir, ".claude", "plugins")
+	t.Setenv("SKILLSYNC_CLAUDE_PLUGINS_PATH", pluginsDir)
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(originalDir)
+	})
+
+	installPath := filepath.Join(pluginsDir, "cache", "acme", "review", "1.0.0")
+	files := map[string]string{
+		filepath.Join(installPath, ".claude-plugin", "plugin.json"):     `{"name": "review", "license": "MIT"}`,
+		filepath.Join(installPath, "skills", "review", "SKILL.md"):      "---\nname: review\ndescription: Review code\n---\nCheck it.",
+		filepath.Join(pluginsDir, "known_marketplaces.json"):            `{"acme": {"source": {"source": "github", "repo": "acme/skills"}}}`,
+		filepath.Join(pluginsDir, "installed_plugins.json"):             `{"version": 2, "plugins": {"review@acme": [{"scope": "user", "installPath": "` + installPath + `", "version": "1.0.0", "gitCommitSha": "abc123"}]}}`,
+		filepath.Join(tempDir, ".claude", "skills", "mine", "SKILL.md"): "---\nname: mine\ndescription: Local\n---\nMine.",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	outputFile := filepath.Join(tempDir, "inventory.json")
+	captureOutput(t, func() {
+		if err := Run(context.Background(), []string{"skillsync", "export", "--format", "inventory", "--output", outputFile}); err != nil {
+			t.Errorf("export --format inventory failed: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("inventory not written: %v", err)
+	}
+	var inv export.Inventory
+	if err := json.Unmarshal(data, &inv); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+	if len(inv.Skills) != 1 {
+		t.Fatalf("expected 1 inventory entry, got %+v", inv.Skills)
+	}
+	got := inv.Skills[0]
+	if got.Name != "review" || got.Plugin != "review@acme" || got.Repository != "https://github.com/acme/skills" ||
+		got.Version != "1.0.0" || got.Commit != "abc123" || got.License != "MIT" {
+		t.Errorf("unexpected inventory entry: %+v", got)
+	}
+}
+
+func TestExportFiles(t *testing.T) {
+	claudeSkills, cursorSkills := setupStore(t)
+	util.WriteFile(t, filepath.Join(claudeSkills, "review", "SKILL.md"), "---\nname: review\ndescription: Review code\n---\nCheck tests.\n")
+	util.WriteFile(t, filepath.Join(cursorSkills, "lint.md"), "---\nname: lint\ndescription: Lint code\nglobs: '*.go'\n---\nRun the linter.\n")
+	dir := filepath.Join(t.TempDir(), "skills-export")
+
+	util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "export", "--format", "files", "--output", dir}))
+
+	var metadata export.FileMetadata
+	data, err := os.ReadFile(filepath.Join(dir, "lint", export.FilesMetadataName))
+	util.AssertNoError(t, err)
+	util.AssertNoError(t, json.Unmarshal(data, &metadata))
+	util.AssertEqual(t, metadata.Sources[0].Platform, string(model.Cursor))
+	util.AssertEqual(t, metadata.Metadata["globs"], "*.go")
+	util.AssertEqual(t, metadata.Metadata["description"], "")
+
+	// The export reads back as a repository of SKILL.md files
+	skills, err := parseImportedRepoSkills(dir)
+	util.AssertNoError(t, err)
+	names := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		names = append(names, skill.Name+": "+skill.Description)
+	}
+	sort.Strings(names)
+	util.AssertEqual(t, strings.Join(names, ", "), "lint: Lint code, review: Review code")
+}
+
+func TestExportHTML(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	util.WriteFile(t, filepath.Join(claudeSkills, "review", "SKILL.md"), "---\nname: review\ndescription: Review code\ntags: [quality]\n---\nCheck **tests**.\n")
+	dir := filepath.Join(t.TempDir(), "skills-site")
+
+	util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "export", "--format", "html", "--output", dir}))
+
+	index, err := os.ReadFile(filepath.Join(dir, export.HTMLIndexName))
+	util.AssertNoError(t, err)
+	if !strings.Contains(string(index), `href="skills/claude-code/review.html"`) || !strings.Contains(string(index), `id="tag-quality"`) {
+		t.Errorf("index does not list review by platform and tag:\n%s", index)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "skills", "claude-code", "review.html"))
+	util.AssertNoError(t, err)
+	if !strings.Contains(string(page), "<p>Check <strong>tests</strong>.</p>") {
+		t.Errorf("skill page does not render the Markdown body:\n%s", page)
+	}
+}
+
+func TestExportIncremental(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, ".skillsync"))
+	skillsDir := filepath.Join(tempDir, "skills")
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", skillsDir)
+
+	util.WriteFile(t, filepath.Join(skillsDir, "keep", "SKILL.md"), "---\nname: keep\n---\nSame.")
+	util.WriteFile(t, filepath.Join(skillsDir, "edit", "SKILL.md"), "---\nname: edit\n---\nBefore.")
+	util.WriteFile(t, filepath.Join(skillsDir, "gone", "SKILL.md"), "---\nname: gone\n---\nBye.")
+
+	type document struct {
+		Skills []struct {
+			Name string `json:"name"`
+		} `json:"skills"`
+		Removed []export.Tombstone `json:"removed"`
+	}
+	exportChanges := func() document {
+		t.Helper()
+		outputFile := filepath.Join(tempDir, "changes.json")
+		captureOutput(t, func() {
+			args := []string{"skillsync", "export", "--incremental", "--platform", "claude-code", "--output", outputFile}
+			if err := Run(context.Background(), args); err != nil {
+				t.Fatalf("export --incremental failed: %v", err)
+			}
+		})
+		data, err := os.ReadFile(outputFile)
+		util.AssertNoError(t, err)
+		var doc document
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, data)
+		}
+		return doc
+	}
+
+	first := exportChanges()
+	util.AssertEqual(t, len(first.Skills), 3)
+	util.AssertEqual(t, len(first.Removed), 0)
+	if _, err := os.Stat(filepath.Join(tempDir, ".skillsync", "exports", "json-claude-code.json")); err != nil {
+		t.Fatalf("export manifest not recorded: %v", err)
+	}
+
+	util.WriteFile(t, filepath.Join(skillsDir, "edit", "SKILL.md"), "---\nname: edit\n---\nAfter.")
+	util.AssertNoError(t, os.RemoveAll(filepath.Join(skillsDir, "gone")))
+
+	second := exportChanges()
+	util.AssertEqual(t, len(second.Skills), 1)
+	util.AssertEqual(t, second.Skills[0].Name, "edit")
+	util.AssertEqual(t, len(second.Removed), 1)
+	util.AssertEqual(t, second.Removed[0].Name, "gone")
+
+	third := exportChanges()
+	util.AssertEqual(t, len(third.Skills), 0)
+	util.AssertEqual(t, len(third.Removed), 0)
+
+	if err := Run(context.Background(), []string{"skillsync", "export", "--incremental", "--format", "inventory"}); err == nil {
+		t.Error("expected --incremental to be rejected for the inventory format")
+	}
+}
+
 func TestBackupRestoreCommand(t *testing.T) {
 	tests := map[string]struct {
 		args    []string
@@ -636,6 +907,10 @@ func TestBackupVerifyCommand(t *testing.T) {
 			args:    []string{"skillsync", "backup", "verify", "non-existent-id"},
 			wantErr: true,
 		},
+		"verify with zero concurrency": {
+			args:    []string{"skillsync", "backup", "verify", "--concurrency", "0"},
+			wantErr: true,
+		},
 	}
 
 	for name, tt := range tests {
@@ -954,6 +1229,80 @@ func TestFormatValidationError(t *testing.T) {
 	}
 }
 
+func TestValidateSourceSkills_Quarantine(t *testing.T) {
+	skills := []model.Skill{
+		{Name: "lint", Platform: model.Cursor, Content: "a"},
+		{Name: "", Path: "/skills/unnamed.md", Platform: model.Cursor, Content: "b"},
+		{Name: "lint", Platform: model.Cursor, Content: "c"},
+		{Name: "test", Platform: model.Cursor, Content: "d"},
+	}
+
+	tests := map[string]struct {
+		skills          []model.Skill
+		quarantine      bool
+		wantErr         bool
+		wantSynced      []string
+		wantQuarantined []string
+	}{
+		"without quarantine invalid skills abort": {
+			skills:  skills,
+			wantErr: true,
+		},
+		"quarantine keeps valid skills": {
+			skills:          skills,
+			quarantine:      true,
+			wantSynced:      []string{"lint", "test"},
+			wantQuarantined: []string{"/skills/unnamed.md", "lint"},
+		},
+		"quarantine with nothing valid fails": {
+			skills:     skills[1:2],
+			quarantine: true,
+			wantErr:    true,
+		},
+		"quarantine with all valid is a no-op": {
+			skills:     []model.Skill{skills[0], skills[3]},
+			quarantine: true,
+			wantSynced: []string{"lint", "test"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &syncConfig{
+				sourceSpec:   model.PlatformSpec{Platform: model.Cursor},
+				targetSpec:   model.PlatformSpec{Platform: model.ClaudeCode},
+				workspace:    true, // skip target path checks
+				quarantine:   tt.quarantine,
+				sourceSkills: append([]model.Skill(nil), tt.skills...),
+			}
+
+			var err error
+			captureOutput(t, func() {
+				err = validateSourceSkills(cfg)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateSourceSkills() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var synced, quarantined []string
+			for _, skill := range cfg.sourceSkills {
+				synced = append(synced, skill.Name)
+			}
+			for _, sr := range cfg.quarantined {
+				if sr.Action != sync.ActionQuarantined || sr.Message == "" {
+					t.Errorf("unexpected quarantine result: %+v", sr)
+				}
+				quarantined = append(quarantined, sr.Skill.Name)
+			}
+			util.AssertEqual(t, strings.Join(synced, ","), strings.Join(tt.wantSynced, ","))
+			util.AssertEqual(t, strings.Join(quarantined, ","), strings.Join(tt.wantQuarantined, ","))
+		})
+	}
+}
+
 func TestCheckWritePermission(t *testing.T) {
 	tests := map[string]struct {
 		setup   func(t *testing.T) string
@@ -1032,6 +1381,99 @@ func TestSelectSourceSkillsForDelete(t *testing.T) {
 	}
 }
 
+func TestBackupReindexCommand(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+
+	source := filepath.Join(tempHome, "skill.md")
+	util.WriteFile(t, source, "# Skill")
+	created, err := backup.CreateBackup(source, backup.Options{Platform: "claude-code"})
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+
+	for _, name := range []string{backup.IndexFilename, backup.IndexBackupFilename} {
+		util.WriteFile(t, filepath.Join(tempHome, "metadata", name), "{")
+	}
+	if _, err := backup.ListBackups(""); !errors.Is(err, backup.ErrIndexCorrupt) {
+		t.Fatalf("expected corrupt index, got %v", err)
+	}
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "backup", "reindex"})
+	})
+	if runErr != nil {
+		t.Fatalf("backup reindex failed: %v", runErr)
+	}
+	if !strings.Contains(output, "Rebuilt backup index with 1 backup(s)") {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+
+	backups, err := backup.ListBackups("")
+	if err != nil {
+		t.Fatalf("ListBackups after reindex failed: %v", err)
+	}
+	if len(backups) != 1 || backups[0].ID != created.ID || backups[0].SourcePath != source {
+		t.Errorf("reindexed backups = %+v, want %s from %s", backups, created.ID, source)
+	}
+}
+
+func TestBackupRollbackCommand(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		wantErr     bool
+		wantOutput  string
+		wantContent string
+	}{
+		"unknown session": {
+			args:        []string{"--session", "missing", "--force"},
+			wantErr:     true,
+			wantContent: "# Synced",
+		},
+		"dry run": {
+			args:        []string{"--dry-run"},
+			wantOutput:  "no files were restored",
+			wantContent: "# Synced",
+		},
+		"restores": {
+			args:        []string{"--force"},
+			wantOutput:  "Restored 1 file(s)",
+			wantContent: "# Original",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempHome := t.TempDir()
+			t.Setenv("SKILLSYNC_HOME", tempHome)
+
+			source := filepath.Join(tempHome, "skill.md")
+			util.WriteFile(t, source, "# Original")
+			session := backup.NewSessionID()
+			if _, err := backup.CreateBackup(source, backup.Options{Platform: "cursor", SessionID: session}); err != nil {
+				t.Fatalf("CreateBackup failed: %v", err)
+			}
+			util.WriteFile(t, source, "# Synced")
+
+			args := []string{"skillsync", "backup", "rollback", "--session", session}
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append(args, tt.args...))
+			})
+			if (runErr != nil) != tt.wantErr {
+				t.Fatalf("backup rollback error = %v, wantErr %v", runErr, tt.wantErr)
+			}
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output missing %q:\n%s", tt.wantOutput, output)
+			}
+			got, err := os.ReadFile(source)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, string(got), tt.wantContent)
+		})
+	}
+}
+
 func TestListBackups(t *testing.T) {
 	tests := map[string]struct {
 		platform   string
@@ -1083,7 +1525,7 @@ func TestListBackups(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
-			err := listBackups(tt.platform, tt.format, tt.limit)
+			err := listBackups(tt.platform, "", tt.format, tt.limit)
 
 			// Restore stdout
 			if err := w.Close(); err != nil {
@@ -1137,7 +1579,7 @@ func TestVerifyAllBackups(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
-			err := verifyAllBackups(tt.platform)
+			err := verifyAllBackups(context.Background(), tt.platform, backup.VerifyOptions{})
 
 			// Restore stdout
 			if err := w.Close(); err != nil {
@@ -1166,6 +1608,26 @@ func TestVerifyAllBackups(t *testing.T) {
 	}
 }
 
+func TestVerifyAllBackups_Summary(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", tempHome)
+	for i := range 3 {
+		file := filepath.Join(tempHome, fmt.Sprintf("skill-%d.md", i))
+		util.WriteFile(t, file, fmt.Sprintf("content %d", i))
+		_, err := backup.CreateBackup(file, backup.Options{Platform: "cursor"})
+		util.AssertNoError(t, err)
+	}
+
+	output := captureOutput(t, func() {
+		util.AssertNoError(t, verifyAllBackups(context.Background(), "", backup.VerifyOptions{Concurrency: 2}))
+	})
+	for _, want := range []string{"Verification complete: 3 OK", "Verified 27 B in"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
 func TestVerifyBackupsByID(t *testing.T) {
 	tests := map[string]struct {
 		ids     []string
@@ -1188,7 +1650,7 @@ func TestVerifyBackupsByID(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
-			err := verifyBackupsByID(tt.ids)
+			err := verifyBackupsByID(context.Background(), tt.ids, backup.VerifyOptions{})
 
 			// Restore stdout
 			if err := w.Close(); err != nil {
@@ -1277,6 +1739,113 @@ func TestDeleteBackupsByPolicy(t *testing.T) {
 	}
 }
 
+func TestPruneBackups(t *testing.T) {
+	tests := map[string]struct {
+		env           map[string]string
+		dryRun        bool
+		wantOutput    string
+		wantRemaining int
+	}{
+		"count limit": {
+			env:           map[string]string{"SKILLSYNC_BACKUP_MAX_COUNT": "1"},
+			wantOutput:    "Deleted 2 backup(s)",
+			wantRemaining: 1,
+		},
+		"dry run keeps backups": {
+			env:           map[string]string{"SKILLSYNC_BACKUP_MAX_COUNT": "1"},
+			dryRun:        true,
+			wantOutput:    "would delete 2 backup(s)",
+			wantRemaining: 3,
+		},
+		"within the size limit": {
+			env:           map[string]string{"SKILLSYNC_BACKUP_MAX_SIZE_MB": "1"},
+			wantOutput:    "No backups are outside the retention policy",
+			wantRemaining: 3,
+		},
+		"within the defaults": {
+			wantOutput:    "Retention policy: 10 per skill, 30 days, unlimited size",
+			wantRemaining: 3,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempHome := t.TempDir()
+			t.Setenv("SKILLSYNC_HOME", tempHome)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			file := filepath.Join(tempHome, "lint.md")
+			for i := range 3 {
+				util.WriteFile(t, file, fmt.Sprintf("version %d", i))
+				_, err := backup.CreateBackup(file, backup.Options{Platform: "cursor"})
+				util.AssertNoError(t, err)
+			}
+
+			output := captureOutput(t, func() {
+				util.AssertNoError(t, pruneBackups("", tt.dryRun, true))
+			})
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output missing %q:\n%s", tt.wantOutput, output)
+			}
+			remaining, err := backup.ListBackups("cursor")
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(remaining), tt.wantRemaining)
+		})
+	}
+}
+
+func TestBackupSnapshot(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	skill := filepath.Join(claudeSkills, "lint", "SKILL.md")
+	script := filepath.Join(claudeSkills, "lint", "scripts", "lint.sh")
+	util.WriteFile(t, skill, "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+	util.WriteFile(t, script, "golangci-lint run\n")
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "backup", "create", "--platform", "claude-code", "--all"})
+	})
+	util.AssertNoError(t, runErr)
+
+	backups, err := backup.ListBackups("claude-code")
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(backups), 2)
+	snapshotID := backups[0].SnapshotID
+	if snapshotID == "" || backups[1].SnapshotID != snapshotID {
+		t.Fatalf("backups not grouped in one snapshot: %+v", backups)
+	}
+	if !strings.Contains(output, "backup restore --snapshot "+snapshotID) {
+		t.Errorf("output missing restore hint:\n%s", output)
+	}
+
+	util.WriteFile(t, skill, "---\nname: lint\ndescription: Changed\n---\nChanged.\n")
+	util.AssertNoError(t, os.Remove(script))
+
+	captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "backup", "restore", "--snapshot", snapshotID, "--force"})
+	})
+	util.AssertNoError(t, runErr)
+
+	for path, want := range map[string]string{skill: "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n", script: "golangci-lint run\n"} {
+		got, err := os.ReadFile(path)
+		util.AssertNoError(t, err)
+		util.AssertEqual(t, string(got), want)
+	}
+
+	for _, args := range [][]string{
+		{"--snapshot", snapshotID, "--target", skill},
+		{"--snapshot", "missing", "--force"},
+	} {
+		captureOutput(t, func() {
+			runErr = Run(context.Background(), append([]string{"skillsync", "backup", "restore"}, args...))
+		})
+		if runErr == nil {
+			t.Errorf("restore %v: expected error", args)
+		}
+	}
+}
+
 func TestDeleteBackupsByID(t *testing.T) {
 	tests := map[string]struct {
 		ids     []string
@@ -1313,6 +1882,10 @@ func TestSyncCommandArguments(t *testing.T) {
 			args:    []string{"skillsync", "sync", "--strategy", "invalid", "cursor", "codex"},
 			wantErr: true,
 		},
+		"invalid concurrency": {
+			args:    []string{"skillsync", "sync", "--concurrency", "0", "cursor", "codex"},
+			wantErr: true,
+		},
 		"invalid source scope in spec": {
 			args:    []string{"skillsync", "sync", "cursor:invalid", "codex"},
 			wantErr: true,
@@ -1651,6 +2224,174 @@ Skill content.`
 	}
 }
 
+func TestResolveShadowing(t *testing.T) {
+	variants := []model.Skill{
+		{Name: "lint", Scope: model.ScopeUser, Path: "/home/.claude/skills/lint/SKILL.md"},
+		{Name: "lint", Scope: model.ScopeRepo, Path: "/repo/.claude/skills/lint/SKILL.md"},
+		{Name: "lint", Scope: model.ScopeSystem, Path: "/etc/claude/skills/lint/SKILL.md"},
+		{Name: "test", Scope: model.ScopeUser, Path: "/home/.claude/skills/test/SKILL.md"},
+	}
+
+	tests := map[string]struct {
+		keepShadowed bool
+		want         []string // scope, shadows, and shadowed by of each skill, sorted
+	}{
+		"collapsed": {
+			want: []string{"lint repo [user system] ", "test user [] "},
+		},
+		"keep shadowed": {
+			keepShadowed: true,
+			want:         []string{"lint repo [user system] ", "lint system [] repo", "lint user [] repo", "test user [] "},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, skill := range resolveShadowing(variants, tt.keepShadowed) {
+				got = append(got, fmt.Sprintf("%s %s %v %s", skill.Name, skill.Scope, skill.Shadows, skill.ShadowedBy))
+			}
+			sort.Strings(got)
+			util.AssertEqual(t, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		})
+	}
+}
+
+func TestDiscover_ShowShadowed(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o750); err != nil {
+		t.Fatalf("failed to create repo: %v", err)
+	}
+	t.Chdir(repo)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", ".claude/skills:"+claudeSkills)
+	util.WriteFile(t, filepath.Join(repo, ".claude", "skills", "lint", "SKILL.md"), "---\nname: lint\ndescription: Team lint\n---\nLint.\n")
+	util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: My lint\n---\nLint.\n")
+
+	tests := map[string]struct {
+		args        []string
+		wantOutput  []string
+		wantMissing string
+	}{
+		"shadows column": {
+			wantOutput:  []string{"SHADOWS", "user", "Team lint"},
+			wantMissing: "My lint",
+		},
+		"show shadowed": {
+			args:       []string{"--show-shadowed"},
+			wantOutput: []string{"SHADOWS", "Team lint", "shadowed by repo", "My lint", "Total: 2 skill(s)"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync", "discover", "--platform", "claudecode", "--no-plugins"}, tt.args...))
+			})
+			util.AssertNoError(t, runErr)
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+			if tt.wantMissing != "" && strings.Contains(output, tt.wantMissing) {
+				t.Errorf("output includes shadowed %q:\n%s", tt.wantMissing, output)
+			}
+		})
+	}
+}
+
+func TestResolveSyncStrategy(t *testing.T) {
+	tests := map[string]struct {
+		args            []string
+		scopeStrategies map[string]string
+		targetScope     model.SkillScope
+		wantStrategy    sync.Strategy
+		wantSource      string
+	}{
+		"explicit flag wins": {
+			args:            []string{"sync", "--strategy", "skip"},
+			scopeStrategies: map[string]string{"repo": "three-way"},
+			targetScope:     model.ScopeRepo,
+			wantStrategy:    sync.StrategySkip,
+		},
+		"scope default": {
+			args:            []string{"sync"},
+			scopeStrategies: map[string]string{"repo": "three-way", "user": "overwrite"},
+			targetScope:     model.ScopeRepo,
+			wantStrategy:    sync.StrategyThreeWay,
+			wantSource:      "default for repo scope",
+		},
+		"global default": {
+			args:            []string{"sync"},
+			scopeStrategies: map[string]string{"repo": "three-way"},
+			targetScope:     model.ScopeUser,
+			wantStrategy:    sync.StrategyOverwrite,
+			wantSource:      "default",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			appConfig := config.Default()
+			appConfig.Sync.ScopeStrategies = tt.scopeStrategies
+
+			var gotStrategy sync.Strategy
+			var gotSource string
+			cmd := &cli.Command{
+				Name:  "sync",
+				Flags: syncFlags(),
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					gotStrategy, gotSource = resolveSyncStrategy(cmd, appConfig, tt.targetScope)
+					return nil
+				},
+			}
+			util.AssertNoError(t, cmd.Run(context.Background(), tt.args))
+
+			util.AssertEqual(t, gotStrategy, tt.wantStrategy)
+			util.AssertEqual(t, gotSource, tt.wantSource)
+		})
+	}
+}
+
+func TestSyncFrontmatterSchema(t *testing.T) {
+	tempDir := t.TempDir()
+	cursorSkills := filepath.Join(tempDir, ".cursor", "skills")
+	claudeSkills := filepath.Join(tempDir, ".claude", "skills")
+	util.WriteFile(t, filepath.Join(cursorSkills, "owned.md"), "---\nname: owned\ndescription: Owned\nowner: platform\n---\nBody.\n")
+	util.WriteFile(t, filepath.Join(cursorSkills, "orphan.md"), "---\nname: orphan\ndescription: No owner\n---\nBody.\n")
+	schemaPath := filepath.Join(tempDir, "schema.json")
+	util.WriteFile(t, schemaPath, `{"required": ["owner"], "properties": {"owner": {"type": "string"}}}`)
+
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_VALIDATION_SCHEMA_PATH", schemaPath)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+
+	ctx := context.Background()
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(ctx, []string{"skillsync", "sync", "--yes", "--skip-backup", "cursor", "claudecode"})
+	})
+	if runErr == nil || !strings.Contains(output, "owner: required field is missing") {
+		t.Fatalf("expected schema violation to fail sync, got err = %v\n%s", runErr, output)
+	}
+
+	captureOutput(t, func() {
+		runErr = Run(ctx, []string{"skillsync", "sync", "--yes", "--skip-backup", "--quarantine", "cursor", "claudecode"})
+	})
+	util.AssertNoError(t, runErr)
+	if _, err := os.Stat(filepath.Join(claudeSkills, "owned.md")); err != nil {
+		t.Errorf("expected owned skill to be synced: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(claudeSkills, "orphan.md")); !os.IsNotExist(err) {
+		t.Errorf("expected orphan skill to be quarantined, stat err = %v", err)
+	}
+}
+
 func TestSyncDefaultExcludesPromptArtifacts(t *testing.T) {
 	tempDir := t.TempDir()
 	claudeCommands := filepath.Join(tempDir, ".claude", "commands")
@@ -1790,3 +2531,146 @@ sync:
 		t.Fatalf("expected prompt artifact to be synced from .claude/commands: %v", err)
 	}
 }
+
+func TestSyncShowDiff(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		"prints diff of updated skill": {
+			args: []string{"--dry-run", "--show-diff"},
+			want: []string{"diff lint (updated)", "-Run the linter.", "+Run the linter twice."},
+		},
+		"requires dry run": {
+			args:    []string{"--yes", "--show-diff"},
+			wantErr: "--show-diff requires --dry-run",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			claudeSkills := filepath.Join(tempDir, "claude", "skills")
+			cursorSkills := filepath.Join(tempDir, "cursor", "skills")
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"),
+				"---\nname: lint\ndescription: Run the linter\n---\nRun the linter twice.\n")
+			targetFile := filepath.Join(cursorSkills, "lint", "SKILL.md")
+			util.WriteFile(t, targetFile, "---\nname: lint\ndescription: Run the linter\n---\nRun the linter.\n")
+
+			t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+			t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+			t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+
+			args := append([]string{"skillsync", "sync", "--skip-validation"}, tt.args...)
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append(args, "claudecode:user", "cursor"))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want %q", runErr, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, runErr)
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+
+			written, err := os.ReadFile(targetFile)
+			util.AssertNoError(t, err)
+			if !strings.Contains(string(written), "Run the linter.\n") {
+				t.Errorf("dry run modified the target:\n%s", written)
+			}
+		})
+	}
+}
+
+func TestConfirmDestructive(t *testing.T) {
+	tests := map[string]struct {
+		env   map[string]string
+		count int
+		input string
+		want  bool
+	}{
+		"y/N below the threshold":         {count: 3, input: "y\n", want: true},
+		"y is not enough past it":         {count: 12, input: "y\n", want: false},
+		"typed phrase past the threshold": {count: 12, input: "  Delete   12\n", want: true},
+		"wrong count":                     {count: 12, input: "delete 11\n", want: false},
+		"lower threshold": {
+			env:   map[string]string{"SKILLSYNC_CONFIRMATION_THRESHOLD": "2"},
+			count: 3,
+			input: "y\n",
+			want:  false,
+		},
+		"typed never": {
+			env:   map[string]string{"SKILLSYNC_CONFIRMATION_TYPED": "never"},
+			count: 50,
+			input: "y\n",
+			want:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SKILLSYNC_HOME", t.TempDir())
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			withStdin(t, tt.input)
+
+			got, err := confirmDestructive("Delete skills?", "delete", tt.count, riskLevelDangerous)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestSyncStrategySuggestion(t *testing.T) {
+	claudeSkills, cursorSkills := setupStore(t)
+	source := filepath.Join(claudeSkills, "lint", "SKILL.md")
+	target := filepath.Join(cursorSkills, "lint", "SKILL.md")
+	util.WriteFile(t, source, "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+	ctx := context.Background()
+
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "sync", "--yes", "--skip-backup", "claudecode", "cursor"}))
+	})
+
+	// Edit both copies after the recorded sync
+	edit := func(path, body string) {
+		util.WriteFile(t, path, "---\nname: lint\ndescription: Lint code\n---\n"+body+"\n")
+		later := time.Now().Add(time.Minute)
+		util.AssertNoError(t, os.Chtimes(path, later, later))
+	}
+	edit(source, "Run the linter.\nFix what it finds.")
+	edit(target, "Run the linter quietly.")
+
+	output := captureOutput(t, func() {
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "sync", "--dry-run", "claudecode", "cursor"}))
+	})
+	if !strings.Contains(output, "Suggested strategy: three-way (both sides changed for 1 skill") ||
+		!strings.Contains(output, "--accept-suggestion") {
+		t.Errorf("expected a three-way suggestion, got:\n%s", output)
+	}
+
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "sync", "--yes", "--skip-backup", "--accept-suggestion", "claudecode", "cursor"}))
+	})
+	data, err := os.ReadFile(target)
+	util.AssertNoError(t, err)
+	// Three-way merges both edits or leaves the conflict; unlike overwrite,
+	// it never drops the target edit
+	if !strings.Contains(string(data), "Run the linter quietly.") {
+		t.Errorf("the target edit was overwritten:\n%s", data)
+	}
+
+	if err := Run(ctx, []string{"skillsync", "sync", "--accept-suggestion", "--strategy", "skip", "claudecode", "cursor"}); err == nil {
+		t.Error("expected an error for --accept-suggestion with --strategy")
+	}
+}
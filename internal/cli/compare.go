@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 	"gopkg.in/yaml.v3"
@@ -41,6 +42,9 @@ func compareCommand() *cli.Command {
    Similarity matching:
    - Name similarity: Compares skill names using Levenshtein and Jaro-Winkler algorithms
    - Content similarity: Compares skill content using LCS and Jaccard algorithms
+   - Semantic similarity: With similarity.embeddings configured, content is
+     compared by embedding vectors instead, catching reworded skills. The
+     config's embeddings threshold applies unless --content-threshold is set.
 
    Output formats:
    - table: Summary table of similar skill pairs (default)
@@ -65,7 +69,7 @@ func compareCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Filter by platform (claude-code, cursor, codex)",
+				Usage:   "Filter by platform (claude-code, cursor, codex, aider)",
 			},
 			&cli.StringFlag{
 				Name:    "format",
@@ -108,6 +112,10 @@ type compareConfig struct {
 	contentOnly      bool
 	algorithm        string
 	samePlatform     bool
+	// embeddings is the configured embeddings backend, if any
+	embeddings config.EmbeddingsConfig
+	// semanticThreshold is the content threshold used with embeddings
+	semanticThreshold float64
 }
 
 func parseCompareConfig(cmd *cli.Command) (*compareConfig, error) {
@@ -122,7 +130,7 @@ func parseCompareConfig(cmd *cli.Command) (*compareConfig, error) {
 		nameThreshold:    cmd.Float64("name-threshold"),
 		contentThreshold: cmd.Float64("content-threshold"),
 		platform:         cmd.String("platform"),
-		format:           cmd.String("format"),
+		format:           out.Format(cmd.String("format")),
 		nameOnly:         cmd.Bool("name-only"),
 		contentOnly:      cmd.Bool("content-only"),
 		algorithm:        cmd.String("algorithm"),
@@ -134,6 +142,13 @@ func parseCompareConfig(cmd *cli.Command) (*compareConfig, error) {
 	if cfg.nameThreshold == 0 {
 		cfg.nameThreshold = appConfig.Similarity.NameThreshold
 	}
+	// Embedding scores run higher than string metrics, so an explicit
+	// --content-threshold applies to them but the config default does not
+	cfg.embeddings = appConfig.Similarity.Embeddings
+	cfg.semanticThreshold = cfg.contentThreshold
+	if cfg.semanticThreshold == 0 {
+		cfg.semanticThreshold = cfg.embeddings.Threshold
+	}
 	if cfg.contentThreshold == 0 {
 		cfg.contentThreshold = appConfig.Similarity.ContentThreshold
 	}
@@ -179,8 +194,10 @@ func runCompare(cmd *cli.Command) error {
 	}
 
 	if len(skills) < 2 {
-		fmt.Println("Not enough skills to compare (need at least 2).")
-		return nil
+		return out.Render([]comparisonOutput{}, func() error {
+			fmt.Println("Not enough skills to compare (need at least 2).")
+			return nil
+		})
 	}
 
 	// Find similar skills
@@ -190,8 +207,10 @@ func runCompare(cmd *cli.Command) error {
 	}
 
 	if len(results) == 0 {
-		fmt.Println("No similar skills found matching the criteria.")
-		return nil
+		return out.Render([]comparisonOutput{}, func() error {
+			fmt.Println("No similar skills found matching the criteria.")
+			return nil
+		})
 	}
 
 	// Sort by content similarity descending (highest similarity first)
@@ -220,12 +239,12 @@ func discoverSkillsForCompare(platform string) ([]model.Skill, error) {
 	for _, p := range platforms {
 		parser, err := tiered.NewForPlatform(p)
 		if err != nil {
-			fmt.Printf("Warning: failed to create parser for %s: %v\n", p, err)
+			warnf("Warning: failed to create parser for %s: %v\n", p, err)
 			continue
 		}
 		skills, err := parser.Parse()
 		if err != nil {
-			fmt.Printf("Warning: failed to parse %s: %v\n", p, err)
+			warnf("Warning: failed to parse %s: %v\n", p, err)
 			continue
 		}
 		allSkills = append(allSkills, skills...)
@@ -241,6 +260,15 @@ func findSimilarSkills(skills []model.Skill, cfg *compareConfig) ([]*similarity.
 	// Track pairs we've already compared to avoid duplicates
 	comparedPairs := make(map[string]bool)
 
+	var contentMatcher similarity.ContentScorer
+	if !cfg.nameOnly {
+		var err error
+		contentMatcher, err = newContentScorer(cfg.embeddings, cfg.contentThreshold, cfg.semanticThreshold, cfg.algorithm)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Name similarity matching
 	if !cfg.contentOnly {
 		nameConfig := similarity.NameMatcherConfig{
@@ -261,12 +289,6 @@ func findSimilarSkills(skills []model.Skill, cfg *compareConfig) ([]*similarity.
 			// Compute content score if not name-only
 			var contentScore float64
 			if !cfg.nameOnly {
-				contentConfig := similarity.ContentMatcherConfig{
-					Threshold: 0, // Don't filter, we want the score
-					Algorithm: cfg.algorithm,
-					LineMode:  true,
-				}
-				contentMatcher := similarity.NewContentMatcher(contentConfig)
 				contentScore = contentMatcher.Compare(match.Skill1.Content, match.Skill2.Content)
 			}
 
@@ -277,12 +299,6 @@ func findSimilarSkills(skills []model.Skill, cfg *compareConfig) ([]*similarity.
 
 	// Content similarity matching
 	if !cfg.nameOnly {
-		contentConfig := similarity.ContentMatcherConfig{
-			Threshold: cfg.contentThreshold,
-			Algorithm: cfg.algorithm,
-			LineMode:  true,
-		}
-		contentMatcher := similarity.NewContentMatcher(contentConfig)
 		contentMatches := contentMatcher.FindSimilar(skills)
 
 		for _, match := range contentMatches {
@@ -323,6 +339,36 @@ func findSimilarSkills(skills []model.Skill, cfg *compareConfig) ([]*similarity.
 	return results, nil
 }
 
+// newContentScorer returns a content matcher that compares embeddings when
+// a backend is configured and string metrics otherwise. The string metrics
+// use threshold and the embeddings use semanticThreshold.
+func newContentScorer(embeddings config.EmbeddingsConfig, threshold, semanticThreshold float64, algorithm string) (similarity.ContentScorer, error) {
+	matcher := similarity.NewContentMatcher(similarity.ContentMatcherConfig{
+		Threshold: threshold,
+		Algorithm: algorithm,
+		LineMode:  true,
+	})
+
+	apiKeyEnv := embeddings.APIKeyEnv
+	if apiKeyEnv == "" {
+		apiKeyEnv = "OPENAI_API_KEY"
+	}
+	embedder, err := similarity.NewEmbedder(similarity.EmbedderConfig{
+		Provider: embeddings.Provider,
+		URL:      embeddings.URL,
+		Model:    embeddings.Model,
+		APIKey:   os.Getenv(apiKeyEnv),
+		Command:  strings.Fields(embeddings.Command),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid similarity.embeddings config: %w", err)
+	}
+	if embedder == nil {
+		return matcher, nil
+	}
+	return similarity.NewSemanticMatcher(embedder, semanticThreshold, matcher), nil
+}
+
 // makePairKey creates a consistent key for a skill pair regardless of order.
 func makePairKey(s1, s2 model.Skill) string {
 	key1 := fmt.Sprintf("%s:%s:%s", s1.Platform, s1.Scope, s1.Name)
@@ -6,8 +6,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/klauern/skillsync/internal/config"
 	"github.com/klauern/skillsync/internal/model"
 	"github.com/klauern/skillsync/internal/similarity"
+	"github.com/klauern/skillsync/internal/util"
 )
 
 func TestMakePairKey(t *testing.T) {
@@ -411,3 +413,35 @@ func TestSamePlatformConfigParsing(t *testing.T) {
 		t.Errorf("validateCompareConfig() should accept samePlatform=true, got error: %v", err)
 	}
 }
+
+func TestNewContentScorer(t *testing.T) {
+	tests := map[string]struct {
+		embeddings   config.EmbeddingsConfig
+		wantSemantic bool
+		wantErr      bool
+	}{
+		"string metrics without a provider": {},
+		"semantic with a provider": {
+			embeddings:   config.EmbeddingsConfig{Provider: "command", Command: "embed --json"},
+			wantSemantic: true,
+		},
+		"invalid provider": {
+			embeddings: config.EmbeddingsConfig{Provider: "magic"},
+			wantErr:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scorer, err := newContentScorer(tt.embeddings, 0.6, 0, "combined")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newContentScorer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			_, semantic := scorer.(*similarity.SemanticMatcher)
+			util.AssertEqual(t, semantic, tt.wantSemantic)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// configEnvOutput describes one SKILLSYNC_* environment variable.
+type configEnvOutput struct {
+	Name string `json:"name"`
+	// Setting is the config key or command-line flag the variable sets
+	Setting    string `json:"setting"`
+	Value      string `json:"value"`
+	Source     string `json:"source"`
+	Deprecated bool   `json:"deprecated,omitempty"`
+}
+
+func configEnvCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "env",
+		Usage:     "List SKILLSYNC_* environment variables with their effective values",
+		UsageText: "skillsync config env [--export]",
+		Description: `List every SKILLSYNC_* environment variable skillsync reads, the config
+   setting or flag it overrides, its effective value, and where that value
+   comes from: default, file (~/.skillsync/config.yaml), project (the
+   repository's .skillsync.yaml), or env.
+
+   --export prints the config settings as shell export statements instead,
+   for pinning the current configuration in a CI job or dotfile.
+
+   Examples:
+     skillsync config env
+     skillsync --output json config env
+     skillsync config env --export > skillsync.env`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "export",
+				Usage: "Print export statements for the config settings instead of a table",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return showConfigEnv(cmd.Root(), cmd.Bool("export"))
+		},
+	}
+}
+
+// showConfigEnv lists the environment variables of the config and of the
+// flags of root and its subcommands.
+func showConfigEnv(root *cli.Command, export bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	vars := make([]configEnvOutput, 0, len(config.EnvVars()))
+	for _, env := range config.EnvVars() {
+		vars = append(vars, configEnvOutput{
+			Name:       env.Name,
+			Setting:    env.Key,
+			Value:      env.Format(cfg),
+			Source:     string(cfg.Source(env.Key)),
+			Deprecated: env.Deprecated,
+		})
+	}
+
+	if export {
+		// Unset settings are skipped; an empty variable would not override them
+		for _, v := range vars {
+			if !v.Deprecated && v.Value != "" {
+				fmt.Printf("export %s=%s\n", v.Name, shellQuote(v.Value))
+			}
+		}
+		return nil
+	}
+
+	paths := util.Paths()
+	vars = append(vars,
+		envOutput("SKILLSYNC_HOME", "data directory", paths.SkillsyncHome()),
+		envOutput("SKILLSYNC_CLAUDE_PLUGINS_PATH", "Claude Code plugins directory", paths.ClaudePluginsPath()))
+	vars = append(vars, flagEnvVars(root)...)
+
+	return out.Render(vars, func() error {
+		settings := make([]string, len(vars))
+		nameWidth, settingWidth := len("VARIABLE"), len("SETTING")
+		for i, v := range vars {
+			settings[i] = v.Setting
+			if v.Deprecated {
+				settings[i] += " (deprecated)"
+			}
+			nameWidth = max(nameWidth, len(v.Name))
+			settingWidth = max(settingWidth, len(settings[i]))
+		}
+
+		fmt.Printf("%s %s %s %s\n",
+			ui.Header(fmt.Sprintf("%-*s", nameWidth, "VARIABLE")),
+			ui.Header(fmt.Sprintf("%-7s", "SOURCE")),
+			ui.Header(fmt.Sprintf("%-*s", settingWidth, "SETTING")),
+			ui.Header("VALUE"))
+		for i, v := range vars {
+			fmt.Printf("%-*s %s %-*s %s\n",
+				nameWidth, v.Name,
+				colorEnvSource(v.Source),
+				settingWidth, settings[i],
+				v.Value)
+		}
+		return nil
+	})
+}
+
+// flagEnvVars returns the SKILLSYNC_* variables of the flags of cmd and its
+// subcommands, each listed once.
+func flagEnvVars(cmd *cli.Command) []configEnvOutput {
+	seen := make(map[string]bool)
+	var vars []configEnvOutput
+	var walk func(*cli.Command)
+	walk = func(cmd *cli.Command) {
+		for _, flag := range cmd.Flags {
+			docFlag, ok := flag.(cli.DocGenerationFlag)
+			if !ok {
+				continue
+			}
+			for _, name := range docFlag.GetEnvVars() {
+				if !strings.HasPrefix(name, "SKILLSYNC_") || seen[name] {
+					continue
+				}
+				seen[name] = true
+				vars = append(vars, envOutput(name, "--"+flag.Names()[0], strings.Trim(docFlag.GetValue(), `"`)))
+			}
+		}
+		for _, sub := range cmd.Commands {
+			walk(sub)
+		}
+	}
+	walk(cmd)
+
+	slices.SortFunc(vars, func(a, b configEnvOutput) int { return strings.Compare(a.Name, b.Name) })
+	return vars
+}
+
+// envOutput describes a variable that is read directly rather than through
+// the config, with the value used when it is unset.
+func envOutput(name, setting, fallback string) configEnvOutput {
+	if v := os.Getenv(name); v != "" {
+		return configEnvOutput{Name: name, Setting: setting, Value: v, Source: string(config.SourceEnv)}
+	}
+	return configEnvOutput{Name: name, Setting: setting, Value: fallback, Source: string(config.SourceDefault)}
+}
+
+// colorEnvSource pads and colors a setting source.
+func colorEnvSource(source string) string {
+	formatted := fmt.Sprintf("%-7s", source)
+	switch config.Source(source) {
+	case config.SourceEnv:
+		return ui.Warning(formatted)
+	case config.SourceProject:
+		return ui.Magenta(formatted)
+	case config.SourceFile:
+		return ui.Info(formatted)
+	default:
+		return ui.Dim(formatted)
+	}
+}
+
+// shellQuote quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestConfigEnvCommand(t *testing.T) {
+	home := t.TempDir()
+	util.WriteFile(t, filepath.Join(home, "config.yaml"), "sync:\n  default_strategy: newer\ndiff:\n  context: 1\n")
+	t.Setenv("SKILLSYNC_HOME", home)
+	t.Setenv("SKILLSYNC_DIFF_CONTEXT", "5")
+	t.Setenv("SKILLSYNC_FAIL_ON", "changes")
+	ctx := context.Background()
+
+	var vars []configEnvOutput
+	output := captureOutput(t, func() {
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "--output", "json", "config", "env"}))
+	})
+	if err := json.Unmarshal([]byte(output), &vars); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, output)
+	}
+	byName := make(map[string]configEnvOutput, len(vars))
+	for _, v := range vars {
+		byName[v.Name] = v
+	}
+
+	tests := map[string]configEnvOutput{
+		"SKILLSYNC_SYNC_STRATEGY":     {Setting: "sync.default_strategy", Value: "newer", Source: "file"},
+		"SKILLSYNC_DIFF_CONTEXT":      {Setting: "diff.context", Value: "5", Source: "env"},
+		"SKILLSYNC_DIFF_ALGORITHM":    {Setting: "diff.algorithm", Value: "myers", Source: "default"},
+		"SKILLSYNC_WORKSPACE_REPOS":   {Setting: "workspace.repos", Value: "", Source: "default"},
+		"SKILLSYNC_HOME":              {Setting: "data directory", Value: home, Source: "env"},
+		"SKILLSYNC_FAIL_ON":           {Setting: "--fail-on", Value: "changes", Source: "env"},
+		"SKILLSYNC_OUTPUT":            {Setting: "--output", Value: "text", Source: "default"},
+		"SKILLSYNC_CURSOR_MAX_SKILLS": {Setting: "platforms.cursor.max_skills", Value: "50", Source: "default"},
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := byName[name]
+			if !ok {
+				t.Fatalf("%s not listed", name)
+			}
+			want.Name = name
+			util.AssertEqual(t, got, want)
+		})
+	}
+
+	output = captureOutput(t, func() {
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "config", "env", "--export"}))
+	})
+	for _, want := range []string{"export SKILLSYNC_SYNC_STRATEGY='newer'\n", "export SKILLSYNC_DIFF_CONTEXT='5'\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("export output missing %q:\n%s", want, output)
+		}
+	}
+	for _, unwanted := range []string{"SKILLSYNC_WORKSPACE_REPOS", "SKILLSYNC_CURSOR_PATH", "SKILLSYNC_FAIL_ON"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("export output should not contain %s:\n%s", unwanted, output)
+		}
+	}
+}
@@ -28,15 +28,15 @@ func NewConflictResolver() *ConflictResolver {
 func (cr *ConflictResolver) ResolveConflicts(conflicts []*sync.Conflict) (map[string]string, error) {
 	resolved := make(map[string]string)
 
-	fmt.Printf("\n=== Conflict Resolution ===\n")
-	fmt.Printf("Found %d conflict(s) that require resolution.\n\n", len(conflicts))
+	out.Printf("\n=== Conflict Resolution ===\n")
+	out.Printf("Found %d conflict(s) that require resolution.\n\n", len(conflicts))
 
 	merger := sync.NewMerger()
 
 	for i, conflict := range conflicts {
-		fmt.Printf("--- Conflict %d of %d: %s ---\n", i+1, len(conflicts), conflict.SkillName)
-		fmt.Printf("Type: %s\n", conflict.Type)
-		fmt.Printf("Changes: %s\n\n", conflict.DiffSummary())
+		out.Printf("--- Conflict %d of %d: %s ---\n", i+1, len(conflicts), conflict.SkillName)
+		out.Printf("Type: %s\n", conflict.Type)
+		out.Printf("Changes: %s\n\n", conflict.DiffSummary())
 
 		// Show diff preview
 		cr.showDiffPreview(conflict)
@@ -53,7 +53,7 @@ func (cr *ConflictResolver) ResolveConflicts(conflicts []*sync.Conflict) (map[st
 		conflict.Resolution = choice
 		conflict.ResolvedContent = resolvedContent
 
-		fmt.Printf("✓ Resolved %s with: %s\n\n", conflict.SkillName, choice)
+		out.Printf("✓ Resolved %s with: %s\n\n", conflict.SkillName, choice)
 	}
 
 	return resolved, nil
@@ -61,35 +61,35 @@ func (cr *ConflictResolver) ResolveConflicts(conflicts []*sync.Conflict) (map[st
 
 // showDiffPreview displays a preview of the differences with colored output.
 func (cr *ConflictResolver) showDiffPreview(conflict *sync.Conflict) {
-	fmt.Println("Preview of changes:")
-	fmt.Println(strings.Repeat("-", 50))
+	out.Println("Preview of changes:")
+	out.Println(strings.Repeat("-", 50))
 
 	maxLines := 10 // Limit preview length
 	shown := 0
 
 	for _, hunk := range conflict.Hunks {
 		if shown >= maxLines {
-			fmt.Printf("... (%d more hunks not shown)\n", len(conflict.Hunks)-1)
+			out.Printf("... (%d more hunks not shown)\n", len(conflict.Hunks)-1)
 			break
 		}
 
 		// Hunk header in cyan
-		fmt.Println(ui.Info(fmt.Sprintf("@@ -%d,%d +%d,%d @@",
+		out.Println(ui.Info(fmt.Sprintf("@@ -%d,%d +%d,%d @@",
 			hunk.SourceStart, hunk.SourceCount,
 			hunk.TargetStart, hunk.TargetCount)))
 
 		for _, line := range hunk.Lines {
 			if shown >= maxLines {
-				fmt.Println("... (truncated)")
+				out.Println("... (truncated)")
 				break
 			}
 			// Color diff lines based on type
-			fmt.Println(formatDiffLine(line))
+			out.Println(formatDiffLine(line))
 			shown++
 		}
 	}
 
-	fmt.Println(strings.Repeat("-", 50))
+	out.Println(strings.Repeat("-", 50))
 }
 
 // formatDiffLine returns a colored string representation of a diff line.
@@ -107,14 +107,14 @@ func formatDiffLine(line sync.DiffLine) string {
 
 // promptResolution asks the user to choose how to resolve a conflict.
 func (cr *ConflictResolver) promptResolution(conflict *sync.Conflict) (sync.ResolutionChoice, error) {
-	fmt.Println("\nHow would you like to resolve this conflict?")
-	fmt.Println("  1. Use source version (overwrite target)")
-	fmt.Println("  2. Keep target version (discard source changes)")
-	fmt.Println("  3. Attempt automatic merge (may have conflict markers)")
-	fmt.Println("  4. Skip this skill")
-	fmt.Println("  5. Show full source content")
-	fmt.Println("  6. Show full target content")
-	fmt.Print("\nEnter choice [1-6]: ")
+	out.Println("\nHow would you like to resolve this conflict?")
+	out.Println("  1. Use source version (overwrite target)")
+	out.Println("  2. Keep target version (discard source changes)")
+	out.Println("  3. Attempt automatic merge (may have conflict markers)")
+	out.Println("  4. Skip this skill")
+	out.Println("  5. Show full source content")
+	out.Println("  6. Show full target content")
+	out.Print("\nEnter choice [1-6]: ")
 
 	for {
 		response, err := cr.reader.ReadString('\n')
@@ -125,7 +125,7 @@ func (cr *ConflictResolver) promptResolution(conflict *sync.Conflict) (sync.Reso
 		response = strings.TrimSpace(response)
 		choice, err := strconv.Atoi(response)
 		if err != nil || choice < 1 || choice > 6 {
-			fmt.Print("Invalid choice. Enter 1-6: ")
+			out.Print("Invalid choice. Enter 1-6: ")
 			continue
 		}
 
@@ -140,37 +140,37 @@ func (cr *ConflictResolver) promptResolution(conflict *sync.Conflict) (sync.Reso
 			return sync.ResolutionSkip, nil
 		case 5:
 			cr.showFullContent("SOURCE", conflict.Source.Content)
-			fmt.Print("\nEnter choice [1-6]: ")
+			out.Print("\nEnter choice [1-6]: ")
 		case 6:
 			cr.showFullContent("TARGET", conflict.Target.Content)
-			fmt.Print("\nEnter choice [1-6]: ")
+			out.Print("\nEnter choice [1-6]: ")
 		}
 	}
 }
 
 // showFullContent displays the full content of a version.
 func (cr *ConflictResolver) showFullContent(label, content string) {
-	fmt.Printf("\n=== %s CONTENT ===\n", label)
-	fmt.Println(strings.Repeat("-", 50))
+	out.Printf("\n=== %s CONTENT ===\n", label)
+	out.Println(strings.Repeat("-", 50))
 
 	lines := strings.Split(content, "\n")
 	for i, line := range lines {
-		fmt.Printf("%4d | %s\n", i+1, line)
+		out.Printf("%4d | %s\n", i+1, line)
 	}
 
-	fmt.Println(strings.Repeat("-", 50))
+	out.Println(strings.Repeat("-", 50))
 }
 
 // PromptForConflictMode asks the user how they want to handle conflicts.
 func (cr *ConflictResolver) PromptForConflictMode(conflictCount int) (ConflictMode, error) {
-	fmt.Printf("\n%d skill(s) have conflicts with existing target files.\n", conflictCount)
-	fmt.Println("\nHow would you like to handle these conflicts?")
-	fmt.Println("  1. Resolve each conflict interactively")
-	fmt.Println("  2. Use source for all (overwrite)")
-	fmt.Println("  3. Keep target for all (skip changes)")
-	fmt.Println("  4. Auto-merge all (may leave conflict markers)")
-	fmt.Println("  5. Abort sync")
-	fmt.Print("\nEnter choice [1-5]: ")
+	out.Printf("\n%d skill(s) have conflicts with existing target files.\n", conflictCount)
+	out.Println("\nHow would you like to handle these conflicts?")
+	out.Println("  1. Resolve each conflict interactively")
+	out.Println("  2. Use source for all (overwrite)")
+	out.Println("  3. Keep target for all (skip changes)")
+	out.Println("  4. Auto-merge all (may leave conflict markers)")
+	out.Println("  5. Abort sync")
+	out.Print("\nEnter choice [1-5]: ")
 
 	response, err := cr.reader.ReadString('\n')
 	if err != nil {
@@ -246,18 +246,65 @@ func (cr *ConflictResolver) ResolveAllWithMode(conflicts []*sync.Conflict, mode
 	return resolved, nil
 }
 
+// resultConflicts returns the conflicts of a sync result.
+func resultConflicts(result *sync.Result) []*sync.Conflict {
+	var conflicts []*sync.Conflict
+	for _, sr := range result.Conflicts() {
+		if sr.Conflict != nil {
+			conflicts = append(conflicts, sr.Conflict)
+		}
+	}
+	return conflicts
+}
+
+// applyResolutionPreference resolves the conflicts of a sync with the
+// resolution preferred for its platform pair, without prompting. Keeping
+// the target leaves its file alone and reports the skill as skipped.
+func applyResolutionPreference(cfg *syncConfig, result *sync.Result, backup bool) error {
+	merger := sync.NewMerger()
+	resolved := make(map[string]string)
+	for _, conflict := range resultConflicts(result) {
+		resolvedContent := merger.ResolveWithChoice(conflict, cfg.resolution)
+		conflict.Resolution = cfg.resolution
+		conflict.ResolvedContent = resolvedContent
+		if cfg.resolution != sync.ResolutionUseTarget {
+			resolved[conflict.SkillName] = resolvedContent
+		}
+	}
+
+	if !cfg.dryRun {
+		if err := applyResolvedConflicts(result, resolved, backup); err != nil {
+			return fmt.Errorf("failed to apply resolved conflicts: %w", err)
+		}
+	}
+	count := 0
+	for i := range result.Skills {
+		sr := &result.Skills[i]
+		if sr.Conflict == nil || sr.Conflict.Resolution != cfg.resolution {
+			continue
+		}
+		count++
+		sr.Message = fmt.Sprintf("conflict resolved with %s (resolution preference %s)", cfg.resolution, cfg.resolutionKey)
+		if cfg.resolution == sync.ResolutionUseTarget && !cfg.dryRun {
+			sr.Action = sync.ActionSkipped
+		}
+	}
+	out.Printf("\nResolved %d conflict(s) with %s (sync.resolution_preferences %s)\n", count, cfg.resolution, cfg.resolutionKey)
+	return nil
+}
+
 // DisplayConflictSummary shows a summary of all conflicts.
 func (cr *ConflictResolver) DisplayConflictSummary(conflicts []*sync.Conflict) {
-	fmt.Println("\n=== Conflict Summary ===")
-	fmt.Printf("%-30s %-15s %-20s\n", "SKILL", "TYPE", "CHANGES")
-	fmt.Printf("%-30s %-15s %-20s\n", "-----", "----", "-------")
+	out.Println("\n=== Conflict Summary ===")
+	out.Printf("%-30s %-15s %-20s\n", "SKILL", "TYPE", "CHANGES")
+	out.Printf("%-30s %-15s %-20s\n", "-----", "----", "-------")
 
 	for _, conflict := range conflicts {
-		name := conflict.SkillName
-		if len(name) > 30 {
-			name = name[:27] + "..."
+		names := fitCell(conflict.SkillName, 30)
+		out.Printf("%s %-15s %-20s\n", padCell(names[0], 30), conflict.Type, conflict.DiffSummary())
+		for _, name := range names[1:] {
+			out.Printf("%s\n", name)
 		}
-		fmt.Printf("%-30s %-15s %-20s\n", name, conflict.Type, conflict.DiffSummary())
 	}
-	fmt.Println()
+	out.Println()
 }
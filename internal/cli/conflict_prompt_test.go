@@ -3,14 +3,18 @@ package cli
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/klauern/skillsync/internal/config"
 	"github.com/klauern/skillsync/internal/model"
 	"github.com/klauern/skillsync/internal/sync"
 	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/util"
 )
 
 // newConflictResolverWithReader creates a ConflictResolver with a custom reader for testing.
@@ -30,6 +34,15 @@ func captureOutput(t *testing.T, f func()) string {
 	}
 	os.Stdout = w
 
+	// Drain the pipe while f runs so output larger than the pipe buffer
+	// does not block it
+	var buf bytes.Buffer
+	copied := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(&buf, r)
+		copied <- err
+	}()
+
 	f()
 
 	if err := w.Close(); err != nil {
@@ -37,8 +50,7 @@ func captureOutput(t *testing.T, f func()) string {
 	}
 	os.Stdout = old
 
-	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, r); err != nil {
+	if err := <-copied; err != nil {
 		t.Fatalf("failed to read captured output: %v", err)
 	}
 	return buf.String()
@@ -835,3 +847,43 @@ func TestConflictModeConstants(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncResolutionPreferences(t *testing.T) {
+	tests := map[string]struct {
+		preference string
+		want       string
+		wantOutput string
+	}{
+		"prefer source": {preference: "prefer-source", want: "Then fix A.", wantOutput: "merged (conflict resolved with source"},
+		"prefer target": {preference: "prefer-target", want: "Then fix B.", wantOutput: "skipped (conflict resolved with target"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, cursorSkills := setupStore(t)
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\nThen fix A.\n")
+			target := filepath.Join(cursorSkills, "lint", "SKILL.md")
+			util.WriteFile(t, target, "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\nThen fix B.\n")
+			util.WriteFile(t, config.FilePath(),
+				"sync:\n  resolution_preferences:\n    claudecode->cursor: "+tt.preference+"\n")
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), []string{"skillsync", "sync", "--yes", "--strategy", "three-way", "claudecode:user", "cursor:user"})
+			})
+			util.AssertNoError(t, runErr)
+			if !strings.Contains(output, "sync.resolution_preferences claudecode->cursor") {
+				t.Errorf("output does not name the preference:\n%s", output)
+			}
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output does not contain %q:\n%s", tt.wantOutput, output)
+			}
+
+			data, err := os.ReadFile(target)
+			util.AssertNoError(t, err)
+			if !strings.HasPrefix(string(data), "---\nname: lint\n") || !strings.Contains(string(data), tt.want) {
+				t.Errorf("target = %q, want frontmatter and %q", data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// conflictReport is one conflicting pair of a skill found on two platforms.
+type conflictReport struct {
+	Skill      string            `json:"skill"`
+	Platforms  []model.Platform  `json:"platforms"`
+	Type       sync.ConflictType `json:"type"`
+	Added      int               `json:"added"`
+	Removed    int               `json:"removed"`
+	Hunks      int               `json:"hunks"`
+	Suggestion string            `json:"suggestion"`
+}
+
+func conflictsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "conflicts",
+		Usage: "List skills whose content differs across platforms",
+		UsageText: `skillsync conflicts [options]
+   skillsync conflicts --format digest
+   skillsync conflicts --format json`,
+		Description: `Find skills that exist on more than one platform with different content
+   or metadata, without resolving them. Use 'skillsync tui' to resolve
+   conflicts interactively.
+
+   Each conflict is reported with the platforms involved, a diffstat of the
+   changes, and a suggested resolution:
+   - keep <platform> (superset): one version only adds lines to the other
+   - keep <platform> (newer):    the most recently modified version
+   - merge by hand:              both versions changed and neither is newer
+
+   Output formats:
+   - table:  Aligned columns (default)
+   - digest: Compact plain text for pasting into chat or standup notes
+   - json:   Machine-readable conflict list
+
+   Examples:
+     skillsync conflicts
+     skillsync conflicts --format digest | pbcopy`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Value:   "table",
+				Usage:   "Output format: table, digest, json",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			format := out.Format(cmd.String("format"))
+			switch format {
+			case "table", "digest", "json":
+			default:
+				return fmt.Errorf("unsupported format: %s (use table, digest, or json)", format)
+			}
+
+			conflicts, _ := crossPlatformConflicts(discoverPlatformSkills())
+			reports := make([]conflictReport, 0, len(conflicts))
+			for _, c := range conflicts {
+				reports = append(reports, newConflictReport(c))
+			}
+
+			switch format {
+			case "json":
+				return out.Render(reports, nil)
+			case "digest":
+				fmt.Print(formatConflictDigest(reports, time.Now()))
+				return nil
+			default:
+				return outputConflictsTable(reports)
+			}
+		},
+	}
+}
+
+// discoverPlatformSkills returns the skills of every platform that has any.
+func discoverPlatformSkills() map[model.Platform][]model.Skill {
+	platformSkills := make(map[model.Platform][]model.Skill)
+	for _, p := range model.AllPlatforms() {
+		skills, err := parsePlatformSkillsWithScope(p, nil, false)
+		if err != nil {
+			continue
+		}
+		if len(skills) > 0 {
+			platformSkills[p] = skills
+		}
+	}
+	return platformSkills
+}
+
+// crossPlatformConflicts compares the first instance of each skill name with
+// its other instances, in platform order, and returns the differing pairs
+// sorted by skill name along with the instances of each name.
+func crossPlatformConflicts(platformSkills map[model.Platform][]model.Skill) ([]*sync.Conflict, map[string][]model.Skill) {
+	skillMap := make(map[string][]model.Skill)
+	for _, p := range model.AllPlatforms() {
+		for _, skill := range platformSkills[p] {
+			skillMap[skill.Name] = append(skillMap[skill.Name], skill)
+		}
+	}
+
+	detector := sync.NewConflictDetector()
+	var conflicts []*sync.Conflict
+	for _, skills := range skillMap {
+		for i := 1; i < len(skills); i++ {
+			if conflict := detector.DetectConflict(skills[0], skills[i]); conflict != nil {
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].SkillName != conflicts[j].SkillName {
+			return conflicts[i].SkillName < conflicts[j].SkillName
+		}
+		return conflicts[i].Target.Platform < conflicts[j].Target.Platform
+	})
+	return conflicts, skillMap
+}
+
+func newConflictReport(c *sync.Conflict) conflictReport {
+	report := conflictReport{
+		Skill:     c.SkillName,
+		Platforms: []model.Platform{c.Source.Platform, c.Target.Platform},
+		Type:      c.Type,
+		Hunks:     len(c.Hunks),
+	}
+	for _, hunk := range c.Hunks {
+		for _, line := range hunk.Lines {
+			switch line.Type {
+			case sync.DiffLineAdded:
+				report.Added++
+			case sync.DiffLineRemoved:
+				report.Removed++
+			}
+		}
+	}
+	report.Suggestion = suggestConflictResolution(c, report.Added, report.Removed)
+	return report
+}
+
+// suggestConflictResolution proposes which version to keep: one that only
+// adds to the other, else the newer one, else a manual merge.
+func suggestConflictResolution(c *sync.Conflict, added, removed int) string {
+	if c.Type != sync.ConflictTypeMetadata {
+		switch {
+		case removed == 0 && added > 0:
+			return fmt.Sprintf("keep %s (superset)", c.Target.Platform)
+		case added == 0 && removed > 0:
+			return fmt.Sprintf("keep %s (superset)", c.Source.Platform)
+		}
+	}
+	switch {
+	case c.Source.ModifiedAt.After(c.Target.ModifiedAt):
+		return fmt.Sprintf("keep %s (newer)", c.Source.Platform)
+	case c.Target.ModifiedAt.After(c.Source.ModifiedAt):
+		return fmt.Sprintf("keep %s (newer)", c.Target.Platform)
+	}
+	return "merge by hand"
+}
+
+// diffstat summarizes a report's changes on one line, e.g. "+3/-1 in 2 hunks".
+func (r conflictReport) diffstat() string {
+	if r.Type == sync.ConflictTypeMetadata {
+		return "metadata only"
+	}
+	unit := "hunks"
+	if r.Hunks == 1 {
+		unit = "hunk"
+	}
+	stat := fmt.Sprintf("+%d/-%d in %d %s", r.Added, r.Removed, r.Hunks, unit)
+	if r.Type == sync.ConflictTypeBoth {
+		stat += ", metadata"
+	}
+	return stat
+}
+
+func (r conflictReport) platformList() string {
+	names := make([]string, len(r.Platforms))
+	for i, p := range r.Platforms {
+		names[i] = string(p)
+	}
+	return strings.Join(names, " vs ")
+}
+
+// formatConflictDigest renders reports as plain text with one line per
+// conflict, free of color and column alignment so it pastes cleanly.
+func formatConflictDigest(reports []conflictReport, now time.Time) string {
+	var sb strings.Builder
+	date := now.Format("2006-01-02")
+	if len(reports) == 0 {
+		fmt.Fprintf(&sb, "Skill conflicts (%s): none\n", date)
+		return sb.String()
+	}
+
+	skills := make(map[string]bool)
+	for _, r := range reports {
+		skills[r.Skill] = true
+	}
+	fmt.Fprintf(&sb, "Skill conflicts (%s): %d conflict(s) in %d skill(s)\n", date, len(reports), len(skills))
+	for _, r := range reports {
+		fmt.Fprintf(&sb, "- %s: %s, %s -> %s\n", r.Skill, r.platformList(), r.diffstat(), r.Suggestion)
+	}
+	return sb.String()
+}
+
+func outputConflictsTable(reports []conflictReport) error {
+	if len(reports) == 0 {
+		ui.Success("No conflicts found across platforms")
+		return nil
+	}
+
+	fmt.Printf("%s %s %s %s\n",
+		ui.Header(fmt.Sprintf("%-30s", "SKILL")),
+		ui.Header(fmt.Sprintf("%-26s", "PLATFORMS")),
+		ui.Header(fmt.Sprintf("%-28s", "CHANGES")),
+		ui.Header("SUGGESTION"))
+	fmt.Printf("%-30s %-26s %-28s %s\n", "-----", "---------", "-------", "----------")
+
+	for _, r := range reports {
+		fmt.Printf("%-30s %-26s %-28s %s\n",
+			truncateCell(r.Skill, 30), truncateCell(r.platformList(), 26), truncateCell(r.diffstat(), 28), r.Suggestion)
+	}
+
+	fmt.Printf("\nTotal: %d conflict(s)\n", len(reports))
+	return nil
+}
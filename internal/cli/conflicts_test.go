@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestCrossPlatformConflicts(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	platformSkills := map[model.Platform][]model.Skill{
+		model.ClaudeCode: {
+			{Name: "lint", Platform: model.ClaudeCode, Content: "a\nb", ModifiedAt: older},
+			{Name: "review", Platform: model.ClaudeCode, Content: "a\nb", ModifiedAt: older},
+			{Name: "same", Platform: model.ClaudeCode, Content: "x"},
+		},
+		model.Cursor: {
+			{Name: "lint", Platform: model.Cursor, Content: "a\nb\nc", ModifiedAt: older},
+			{Name: "review", Platform: model.Cursor, Content: "a\nB", ModifiedAt: newer},
+			{Name: "same", Platform: model.Cursor, Content: "x"},
+		},
+		model.Codex: {
+			{Name: "review", Platform: model.Codex, Content: "A\nb", ModifiedAt: older},
+		},
+	}
+
+	conflicts, _ := crossPlatformConflicts(platformSkills)
+
+	var got []conflictReport
+	for _, c := range conflicts {
+		got = append(got, newConflictReport(c))
+	}
+	util.AssertEqual(t, len(got), 3)
+
+	tests := []struct {
+		skill      string
+		platforms  string
+		diffstat   string
+		suggestion string
+	}{
+		{"lint", "claude-code vs cursor", "+1/-0 in 1 hunk", "keep cursor (superset)"},
+		{"review", "claude-code vs codex", "+1/-1 in 1 hunk", "merge by hand"},
+		{"review", "claude-code vs cursor", "+1/-1 in 1 hunk", "keep cursor (newer)"},
+	}
+	for i, tt := range tests {
+		util.AssertEqual(t, got[i].Skill, tt.skill)
+		util.AssertEqual(t, got[i].platformList(), tt.platforms)
+		util.AssertEqual(t, got[i].diffstat(), tt.diffstat)
+		util.AssertEqual(t, got[i].Suggestion, tt.suggestion)
+	}
+}
+
+func TestFormatConflictDigest(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		reports []conflictReport
+		want    string
+	}{
+		"no conflicts": {
+			want: "Skill conflicts (2026-10-17): none\n",
+		},
+		"conflicts": {
+			reports: []conflictReport{
+				{
+					Skill: "lint", Platforms: []model.Platform{model.ClaudeCode, model.Cursor},
+					Type: "content", Added: 3, Removed: 1, Hunks: 2, Suggestion: "keep cursor (newer)",
+				},
+				{
+					Skill: "review", Platforms: []model.Platform{model.ClaudeCode, model.Codex},
+					Type: "metadata", Suggestion: "merge by hand",
+				},
+			},
+			want: "Skill conflicts (2026-10-17): 2 conflict(s) in 2 skill(s)\n" +
+				"- lint: claude-code vs cursor, +3/-1 in 2 hunks -> keep cursor (newer)\n" +
+				"- review: claude-code vs codex, metadata only -> merge by hand\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, formatConflictDigest(tt.reports, now), tt.want)
+		})
+	}
+}
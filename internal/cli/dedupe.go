@@ -9,6 +9,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/klauern/skillsync/internal/backup"
 	"github.com/klauern/skillsync/internal/model"
 )
 
@@ -23,11 +24,82 @@ func dedupeCommand() *cli.Command {
 
    Subcommands:
      delete  - Remove a duplicate skill
-     rename  - Rename a skill to differentiate it`,
+     rename  - Rename a skill to differentiate it
+
+   Duplicates:
+     --apply finds skills on the same platform whose content is at least
+     --threshold similar (default 0.9) and keeps one of each pair. You are
+     asked which copy to keep; with --yes the user-scope copy is kept, else
+     the longer, then the newer one. The other copy is backed up and deleted,
+     and references to it (/name, @name, inline code, replaced_by) in the
+     platform's other skills are renamed. With --action symlink the duplicate
+     is replaced by a symlink to the kept file instead.
+
+   Deprecated skills:
+     Skills marked with 'deprecated: true' and 'replaced_by: <name>' in their
+     frontmatter can be removed with --deprecated once the replacement skill
+     is present on every platform that still has the deprecated one.
+
+   Examples:
+     skillsync dedupe --apply --dry-run
+     skillsync dedupe --apply --platform cursor --threshold 0.95 --yes
+     skillsync dedupe --apply --action symlink
+     skillsync cleanup --deprecated --dry-run
+     skillsync cleanup --deprecated --platform cursor --yes`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "deprecated",
+				Usage: "Remove deprecated skills whose replacement is present everywhere",
+			},
+			&cli.BoolFlag{
+				Name:  "apply",
+				Usage: "Merge near-duplicate skills on each platform, keeping one copy",
+			},
+			&cli.StringFlag{
+				Name:  "action",
+				Value: string(dedupeActionDelete),
+				Usage: "What --apply does with the duplicate: delete, symlink",
+			},
+			&cli.Float64Flag{
+				Name:  "threshold",
+				Usage: "Minimum content similarity for --apply (0.0-1.0, default 0.9)",
+			},
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Usage:   "Limit deprecated or duplicate cleanup to a platform (claude-code, cursor, codex, aider)",
+			},
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"d"},
+				Usage:   "Preview what would be removed without making changes",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Skip confirmation prompt",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-backup",
+				Usage: "Skip backup of removed and updated skills",
+			},
+		},
 		Commands: []*cli.Command{
 			dedupeDeleteCommand(),
 			dedupeRenameCommand(),
 		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			switch {
+			case cmd.Bool("deprecated") && cmd.Bool("apply"):
+				return errors.New("--deprecated and --apply cannot be used together")
+			case cmd.Bool("apply"):
+				return runDedupeApply(cmd)
+			case cmd.Bool("deprecated"):
+				return runCleanupDeprecated(cmd)
+			default:
+				return cli.ShowSubcommandHelp(cmd)
+			}
+		},
 	}
 }
 
@@ -49,7 +121,7 @@ func dedupeDeleteCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:     "platform",
 				Aliases:  []string{"p"},
-				Usage:    "Platform where the skill exists (claude-code, cursor, codex). Required.",
+				Usage:    "Platform where the skill exists (claude-code, cursor, codex, aider). Required.",
 				Required: true,
 			},
 			&cli.StringFlag{
@@ -99,7 +171,7 @@ func dedupeRenameCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:     "platform",
 				Aliases:  []string{"p"},
-				Usage:    "Platform where the skill exists (claude-code, cursor, codex). Required.",
+				Usage:    "Platform where the skill exists (claude-code, cursor, codex, aider). Required.",
 				Required: true,
 			},
 			&cli.StringFlag{
@@ -315,6 +387,11 @@ func runDedupeRename(cmd *cli.Command, oldName, newName string) error {
 		_ = os.Remove(oldParentDir) // Ignore error - directory may not be empty
 	}
 
+	// Keep the skill's backup history under its new name
+	if _, err := backup.RenameLineage(string(platform), oldName, newName); err != nil {
+		warnf("Warning: failed to move backups to the new name: %v\n", err)
+	}
+
 	fmt.Printf("\n✓ Renamed skill %q to %q in %s scope\n", oldName, newName, scope)
 	return nil
 }
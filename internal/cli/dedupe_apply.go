@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/similarity"
+)
+
+// defaultDedupeThreshold is the content similarity at which two skills on the
+// same platform are treated as duplicates by dedupe --apply. It is stricter
+// than the compare threshold because the duplicate is removed.
+const defaultDedupeThreshold = 0.9
+
+// dedupeAction is what happens to the duplicate of a pair.
+type dedupeAction string
+
+const (
+	dedupeActionDelete  dedupeAction = "delete"
+	dedupeActionSymlink dedupeAction = "symlink"
+)
+
+// dedupeCandidate is a pair of near-duplicate skills on one platform.
+type dedupeCandidate struct {
+	Canonical model.Skill
+	Duplicate model.Skill
+	Score     float64
+}
+
+// findDedupeCandidates returns near-duplicate pairs within each platform,
+// most similar first. A skill is the duplicate of at most one pair, and a
+// skill chosen as a duplicate is never another pair's canonical.
+func findDedupeCandidates(skillsByPlatform map[model.Platform][]model.Skill, matcher similarity.ContentScorer) []dedupeCandidate {
+	var matches []similarity.ContentMatch
+	for _, platform := range model.AllPlatforms() {
+		matches = append(matches, matcher.FindSimilar(skillsByPlatform[platform])...)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	var candidates []dedupeCandidate
+	removed := make(map[string]bool)
+	kept := make(map[string]bool)
+	for _, match := range matches {
+		if match.Skill1.Path == match.Skill2.Path || removed[match.Skill1.Path] || removed[match.Skill2.Path] {
+			continue
+		}
+		canonical, duplicate := chooseCanonical(match.Skill1, match.Skill2)
+		if kept[duplicate.Path] {
+			canonical, duplicate = duplicate, canonical
+			if kept[duplicate.Path] {
+				continue
+			}
+		}
+		removed[duplicate.Path] = true
+		kept[canonical.Path] = true
+		candidates = append(candidates, dedupeCandidate{Canonical: canonical, Duplicate: duplicate, Score: match.Score})
+	}
+	return candidates
+}
+
+// chooseCanonical picks which of two duplicates to keep: the user scope copy
+// over a repo copy, then the longer content, then the more recently modified,
+// then the shorter name.
+func chooseCanonical(a, b model.Skill) (canonical, duplicate model.Skill) {
+	switch {
+	case a.Scope != b.Scope && (a.Scope == model.ScopeUser || b.Scope == model.ScopeUser):
+		if a.Scope == model.ScopeUser {
+			return a, b
+		}
+		return b, a
+	case len(a.Content) != len(b.Content):
+		if len(a.Content) > len(b.Content) {
+			return a, b
+		}
+		return b, a
+	case !a.ModifiedAt.Equal(b.ModifiedAt):
+		if a.ModifiedAt.After(b.ModifiedAt) {
+			return a, b
+		}
+		return b, a
+	case len(a.Name) <= len(b.Name):
+		return a, b
+	default:
+		return b, a
+	}
+}
+
+// rewriteSkillReferences replaces references to the skill from with to in
+// content: slash commands (/from), mentions (@from), inline code (`from`),
+// and replaced_by frontmatter. It returns the new content and the number of
+// references replaced.
+func rewriteSkillReferences(content, from, to string) (string, int) {
+	name := regexp.QuoteMeta(from)
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(`(^|[\s(\[])([/@])` + name + `($|[^A-Za-z0-9_\-:/.]|\.(?:\s|$))`),
+		regexp.MustCompile("(`)()" + name + "(`)"),
+		regexp.MustCompile(`(?m)(^replaced_by:[ \t]*)()["']?` + name + `["']?([ \t]*)$`),
+	}
+
+	count := 0
+	for _, re := range patterns {
+		// Matches consume the character after the name, so adjacent
+		// references need another pass
+		for pass := 0; pass < 10 && re.MatchString(content); pass++ {
+			content = re.ReplaceAllStringFunc(content, func(match string) string {
+				count++
+				groups := re.FindStringSubmatch(match)
+				return groups[1] + groups[2] + to + groups[3]
+			})
+		}
+	}
+	return content, count
+}
+
+// runDedupeApply merges near-duplicate skills on each platform, keeping one
+// canonical copy of each pair.
+func runDedupeApply(cmd *cli.Command) error {
+	dryRun := cmd.Bool("dry-run")
+	yes := cmd.Bool("yes")
+
+	action := dedupeAction(cmd.String("action"))
+	if action != dedupeActionDelete && action != dedupeActionSymlink {
+		return fmt.Errorf("invalid action %q (use delete or symlink)", action)
+	}
+
+	platforms := model.AllPlatforms()
+	if platformStr := cmd.String("platform"); platformStr != "" {
+		platform, err := model.ParsePlatform(platformStr)
+		if err != nil {
+			return fmt.Errorf("invalid platform: %w", err)
+		}
+		platforms = []model.Platform{platform}
+	}
+
+	appConfig, err := config.Load()
+	if err != nil {
+		appConfig = config.Default()
+	}
+	threshold := cmd.Float64("threshold")
+	if threshold == 0 {
+		threshold = defaultDedupeThreshold
+	}
+	if threshold < 0 || threshold > 1 {
+		return fmt.Errorf("threshold must be between 0.0 and 1.0, got %v", threshold)
+	}
+
+	skillsByPlatform, err := collectWritableSkills(platforms)
+	if err != nil {
+		return err
+	}
+	// The threshold applies to embedding scores too, since a removal needs
+	// the same confidence whichever way similarity is measured
+	matcher, err := newContentScorer(appConfig.Similarity.Embeddings, threshold, threshold, appConfig.Similarity.Algorithm)
+	if err != nil {
+		return err
+	}
+	candidates := findDedupeCandidates(skillsByPlatform, matcher)
+	if len(candidates) == 0 {
+		fmt.Printf("No duplicate skills found (content similarity ≥ %.0f%%).\n", threshold*100)
+		return nil
+	}
+
+	fmt.Printf("Duplicate skills (%d pair(s)):\n", len(candidates))
+	for _, c := range candidates {
+		printDedupeCandidate(c, action)
+	}
+
+	if dryRun {
+		fmt.Println("\n[Dry run - no changes made]")
+		return nil
+	}
+
+	if !yes {
+		candidates, err = chooseDedupeCandidates(candidates, bufio.NewReader(os.Stdin))
+		if err != nil {
+			return err
+		}
+		if len(candidates) == 0 {
+			fmt.Println("Dedupe cancelled.")
+			return nil
+		}
+	}
+
+	merged := 0
+	for _, c := range candidates {
+		if err := applyDedupeCandidate(c, action, skillsByPlatform[c.Duplicate.Platform], skipBackup(cmd)); err != nil {
+			warnf("Warning: failed to merge %s into %s: %v\n", c.Duplicate.Name, c.Canonical.Name, err)
+			continue
+		}
+		merged++
+	}
+
+	fmt.Printf("\n✓ Merged %d duplicate skill(s)\n", merged)
+	return nil
+}
+
+func printDedupeCandidate(c dedupeCandidate, action dedupeAction) {
+	fmt.Printf("  - %s [%s, %s] ≈ %s [%s] (%.0f%% similar)\n",
+		c.Duplicate.Name, c.Duplicate.Platform, c.Duplicate.Scope, c.Canonical.Name, c.Canonical.Scope, c.Score*100)
+	fmt.Printf("    Keep:    %s\n", c.Canonical.Path)
+	if action == dedupeActionSymlink {
+		fmt.Printf("    Symlink: %s\n", c.Duplicate.Path)
+	} else {
+		fmt.Printf("    Remove:  %s\n", c.Duplicate.Path)
+	}
+}
+
+// chooseDedupeCandidates asks which skill of each pair to keep, returning
+// the pairs to merge with the user's choice as canonical.
+func chooseDedupeCandidates(candidates []dedupeCandidate, reader *bufio.Reader) ([]dedupeCandidate, error) {
+	var chosen []dedupeCandidate
+	for i, c := range candidates {
+		out.Printf("\n--- Pair %d of %d (%.0f%% similar) ---\n", i+1, len(candidates), c.Score*100)
+		out.Printf("  [1] %s (%s) %s\n", c.Canonical.Name, c.Canonical.Scope, c.Canonical.Path)
+		out.Printf("  [2] %s (%s) %s\n", c.Duplicate.Name, c.Duplicate.Scope, c.Duplicate.Path)
+		out.Printf("Keep which? [1/2/s(kip)/q(uit)] (default 1): ")
+
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		switch strings.TrimSpace(strings.ToLower(response)) {
+		case "", "1":
+			chosen = append(chosen, c)
+		case "2":
+			chosen = append(chosen, dedupeCandidate{Canonical: c.Duplicate, Duplicate: c.Canonical, Score: c.Score})
+		case "q", "quit":
+			return chosen, nil
+		default:
+			out.Println("Skipped.")
+		}
+	}
+	return chosen, nil
+}
+
+// applyDedupeCandidate backs up and removes (or symlinks) the duplicate of a
+// pair and, when it is deleted under a different name, points references to
+// it in the platform's other skills at the canonical skill.
+func applyDedupeCandidate(c dedupeCandidate, action dedupeAction, platformSkills []model.Skill, skipBackup bool) error {
+	type rewrite struct {
+		skill   model.Skill
+		content string
+	}
+	var rewrites []rewrite
+	if action == dedupeActionDelete && c.Duplicate.Name != c.Canonical.Name {
+		for _, skill := range platformSkills {
+			if skill.Path == c.Duplicate.Path {
+				continue
+			}
+			// #nosec G304 - skill paths come from discovery
+			data, err := os.ReadFile(skill.Path)
+			if err != nil {
+				continue
+			}
+			if updated, n := rewriteSkillReferences(string(data), c.Duplicate.Name, c.Canonical.Name); n > 0 {
+				rewrites = append(rewrites, rewrite{skill: skill, content: updated})
+			}
+		}
+	}
+
+	if !skipBackup {
+		toBackup := []model.Skill{c.Duplicate}
+		for _, r := range rewrites {
+			toBackup = append(toBackup, r.skill)
+		}
+		if _, err := createBackupsForSkills(c.Duplicate.Platform, toBackup, "pre-dedupe backup", []string{"dedupe"}); err != nil {
+			return err
+		}
+	}
+
+	switch action {
+	case dedupeActionSymlink:
+		if err := os.Remove(c.Duplicate.Path); err != nil {
+			return err
+		}
+		if err := os.Symlink(c.Canonical.Path, c.Duplicate.Path); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Linked %s → %s\n", c.Duplicate.Path, c.Canonical.Path)
+	default:
+		if err := removeSkillArtifact(c.Duplicate); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Removed %s (%s), keeping %s\n", c.Duplicate.Name, c.Duplicate.Path, c.Canonical.Name)
+	}
+
+	for _, r := range rewrites {
+		// #nosec G306 - skill files should be readable
+		if err := os.WriteFile(r.skill.Path, []byte(r.content), 0o644); err != nil {
+			warnf("Warning: failed to update references in %s: %v\n", r.skill.Path, err)
+			continue
+		}
+		fmt.Printf("  Updated references in %s\n", r.skill.Path)
+	}
+	return nil
+}
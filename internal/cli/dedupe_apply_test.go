@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/similarity"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestChooseCanonical(t *testing.T) {
+	now := time.Now()
+	tests := map[string]struct {
+		a, b model.Skill
+		want string
+	}{
+		"user scope over repo": {
+			a:    model.Skill{Name: "repo-copy", Scope: model.ScopeRepo, Content: "longer content"},
+			b:    model.Skill{Name: "user-copy", Scope: model.ScopeUser, Content: "short"},
+			want: "user-copy",
+		},
+		"longer content": {
+			a:    model.Skill{Name: "a", Content: "short"},
+			b:    model.Skill{Name: "b", Content: "longer content"},
+			want: "b",
+		},
+		"newer": {
+			a:    model.Skill{Name: "a", Content: "same", ModifiedAt: now},
+			b:    model.Skill{Name: "b", Content: "same", ModifiedAt: now.Add(-time.Hour)},
+			want: "a",
+		},
+		"shorter name": {
+			a:    model.Skill{Name: "lint-copy", Content: "same"},
+			b:    model.Skill{Name: "lint", Content: "same"},
+			want: "lint",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			canonical, duplicate := chooseCanonical(tt.a, tt.b)
+			util.AssertEqual(t, canonical.Name, tt.want)
+			if duplicate.Name == canonical.Name {
+				t.Errorf("duplicate and canonical are both %q", canonical.Name)
+			}
+		})
+	}
+}
+
+func TestRewriteSkillReferences(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    string
+		count   int
+	}{
+		"slash command and mention": {
+			content: "Run /lint-old then @lint-old.\n",
+			want:    "Run /lint then @lint.\n",
+			count:   2,
+		},
+		"adjacent references": {
+			content: "/lint-old /lint-old",
+			want:    "/lint /lint",
+			count:   2,
+		},
+		"inline code": {
+			content: "Use the `lint-old` skill",
+			want:    "Use the `lint` skill",
+			count:   1,
+		},
+		"replaced_by frontmatter": {
+			content: "---\nreplaced_by: \"lint-old\"\n---\n",
+			want:    "---\nreplaced_by: lint\n---\n",
+			count:   1,
+		},
+		"longer names and paths untouched": {
+			content: "/lint-old-v2 docs/lint-old lint-old",
+			want:    "/lint-old-v2 docs/lint-old lint-old",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, count := rewriteSkillReferences(tt.content, "lint-old", "lint")
+			util.AssertEqual(t, got, tt.want)
+			util.AssertEqual(t, count, tt.count)
+		})
+	}
+}
+
+func TestFindDedupeCandidates(t *testing.T) {
+	body := strings.Repeat("Check every function for error handling.\n", 20)
+	skills := map[model.Platform][]model.Skill{
+		model.ClaudeCode: {
+			{Name: "review", Platform: model.ClaudeCode, Path: "/c/review/SKILL.md", Content: body + "Extra line.\n"},
+			{Name: "review-copy", Platform: model.ClaudeCode, Path: "/c/review-copy/SKILL.md", Content: body},
+			{Name: "deploy", Platform: model.ClaudeCode, Path: "/c/deploy/SKILL.md", Content: "Deploy to production."},
+		},
+		// The same skill synced to another platform is not a duplicate
+		model.Cursor: {
+			{Name: "review", Platform: model.Cursor, Path: "/u/review.md", Content: body},
+		},
+	}
+
+	matcher := similarity.NewContentMatcher(similarity.ContentMatcherConfig{Threshold: 0.8, Algorithm: "combined", LineMode: true})
+	candidates := findDedupeCandidates(skills, matcher)
+
+	util.AssertEqual(t, len(candidates), 1)
+	util.AssertEqual(t, candidates[0].Canonical.Name, "review")
+	util.AssertEqual(t, candidates[0].Duplicate.Name, "review-copy")
+}
+
+func TestChooseDedupeCandidates(t *testing.T) {
+	pair := dedupeCandidate{Canonical: model.Skill{Name: "a"}, Duplicate: model.Skill{Name: "b"}}
+	candidates := []dedupeCandidate{pair, pair, pair, pair}
+
+	var chosen []dedupeCandidate
+	captureOutput(t, func() {
+		var err error
+		chosen, err = chooseDedupeCandidates(candidates, bufio.NewReader(strings.NewReader("\n2\ns\nq\n")))
+		util.AssertNoError(t, err)
+	})
+
+	util.AssertEqual(t, len(chosen), 2)
+	util.AssertEqual(t, chosen[0].Canonical.Name, "a")
+	util.AssertEqual(t, chosen[1].Canonical.Name, "b")
+}
+
+func TestDedupeApplyCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, ".claude", "skills")
+	body := strings.Repeat("Check every function for error handling.\n", 20)
+	util.WriteFile(t, filepath.Join(claudeSkills, "review", "SKILL.md"), "---\nname: review\n---\n"+body+"Extra line.\n")
+	util.WriteFile(t, filepath.Join(claudeSkills, "review-copy", "SKILL.md"), "---\nname: review-copy\n---\n"+body)
+	caller := filepath.Join(claudeSkills, "ship", "SKILL.md")
+	util.WriteFile(t, caller, "---\nname: ship\n---\nRun /review-copy before shipping.\n")
+
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", filepath.Join(tempDir, ".cursor", "skills"))
+	t.Setenv("SKILLSYNC_CODEX_SKILLS_PATHS", filepath.Join(tempDir, ".codex", "skills"))
+	t.Setenv("SKILLSYNC_AIDER_SKILLS_PATHS", filepath.Join(tempDir, ".aider", "skills"))
+
+	ctx := context.Background()
+	if err := Run(ctx, []string{"skillsync", "dedupe", "--apply", "--deprecated"}); err == nil {
+		t.Error("expected an error combining --apply and --deprecated")
+	}
+
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "dedupe", "--apply", "--dry-run"}))
+	})
+	if _, err := os.Stat(filepath.Join(claudeSkills, "review-copy")); err != nil {
+		t.Fatalf("dry run removed the duplicate: %v", err)
+	}
+
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "dedupe", "--apply", "--yes"}))
+	})
+	if _, err := os.Stat(filepath.Join(claudeSkills, "review-copy")); !os.IsNotExist(err) {
+		t.Errorf("expected duplicate to be removed, got %v", err)
+	}
+	data, err := os.ReadFile(caller)
+	util.AssertNoError(t, err)
+	if !strings.Contains(string(data), "Run /review before shipping.") {
+		t.Errorf("reference not rewritten:\n%s", data)
+	}
+}
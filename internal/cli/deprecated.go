@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// deprecationLabel returns a short marker describing a deprecated skill for table output.
+func deprecationLabel(skill model.Skill) string {
+	if replacement := skill.ReplacedBy(); replacement != "" {
+		return fmt.Sprintf("[deprecated → %s]", replacement)
+	}
+	return "[deprecated]"
+}
+
+// countDeprecated returns the number of deprecated skills in the list.
+func countDeprecated(skills []model.Skill) int {
+	count := 0
+	for _, skill := range skills {
+		if skill.IsDeprecated() {
+			count++
+		}
+	}
+	return count
+}
+
+// filterDeprecated splits skills into active and deprecated lists, preserving order.
+func filterDeprecated(skills []model.Skill) (active, deprecated []model.Skill) {
+	active = make([]model.Skill, 0, len(skills))
+	for _, skill := range skills {
+		if skill.IsDeprecated() {
+			deprecated = append(deprecated, skill)
+			continue
+		}
+		active = append(active, skill)
+	}
+	return active, deprecated
+}
+
+// deprecatedCleanupPlan describes which deprecated skills can be removed and which must wait.
+type deprecatedCleanupPlan struct {
+	// Remove lists every installed copy of a deprecated skill whose replacement is present
+	// on each platform that still carries the deprecated skill. A same-named copy is only
+	// included when it is marked deprecated itself or carries the deprecated content.
+	Remove []model.Skill
+
+	// Pending maps deprecated skill names to the reason they are being kept.
+	Pending map[string]string
+}
+
+// planDeprecatedCleanup decides which deprecated skills are safe to remove.
+// A deprecated skill is removed only when it declares replaced_by and the replacement
+// exists on every platform where the deprecated skill is still installed. Same-named
+// skills that are neither deprecated nor copies of a deprecated skill's content, such as
+// an unrelated repo-local skill, are left alone.
+func planDeprecatedCleanup(skillsByPlatform map[model.Platform][]model.Skill) deprecatedCleanupPlan {
+	plan := deprecatedCleanupPlan{Pending: make(map[string]string)}
+
+	names := make(map[model.Platform]map[string]bool)
+	replacements := make(map[string]string)
+	deprecatedContent := make(map[string]map[string]bool)
+	holders := make(map[string][]model.Platform)
+
+	platforms := make([]model.Platform, 0, len(skillsByPlatform))
+	for platform := range skillsByPlatform {
+		platforms = append(platforms, platform)
+	}
+	sort.Slice(platforms, func(i, j int) bool { return platforms[i] < platforms[j] })
+
+	for _, platform := range platforms {
+		names[platform] = make(map[string]bool)
+		for _, skill := range skillsByPlatform[platform] {
+			names[platform][skill.Name] = true
+			if !skill.IsDeprecated() {
+				continue
+			}
+			if deprecatedContent[skill.Name] == nil {
+				deprecatedContent[skill.Name] = make(map[string]bool)
+			}
+			deprecatedContent[skill.Name][strings.TrimSpace(skill.Content)] = true
+			if replacement := skill.ReplacedBy(); replacement != "" {
+				replacements[skill.Name] = replacement
+			} else if _, ok := replacements[skill.Name]; !ok {
+				replacements[skill.Name] = ""
+			}
+		}
+	}
+
+	// removable reports whether skill is a copy of a deprecated skill: marked deprecated
+	// itself, or synced from one without its metadata.
+	removable := func(skill model.Skill) bool {
+		return skill.IsDeprecated() || deprecatedContent[skill.Name][strings.TrimSpace(skill.Content)]
+	}
+
+	for _, platform := range platforms {
+		held := make(map[string]bool)
+		for _, skill := range skillsByPlatform[platform] {
+			if removable(skill) && !held[skill.Name] {
+				held[skill.Name] = true
+				holders[skill.Name] = append(holders[skill.Name], platform)
+			}
+		}
+	}
+
+	ready := make(map[string]bool)
+	for name, replacement := range replacements {
+		if replacement == "" {
+			plan.Pending[name] = "no replaced_by declared"
+			continue
+		}
+		if replacement == name {
+			plan.Pending[name] = "replaced_by refers to itself"
+			continue
+		}
+
+		var missing []string
+		for _, platform := range holders[name] {
+			if !names[platform][replacement] {
+				missing = append(missing, string(platform))
+			}
+		}
+		if len(missing) > 0 {
+			plan.Pending[name] = fmt.Sprintf("replacement %q missing on %s", replacement, strings.Join(missing, ", "))
+			continue
+		}
+		ready[name] = true
+	}
+
+	for _, platform := range platforms {
+		for _, skill := range skillsByPlatform[platform] {
+			if ready[skill.Name] && removable(skill) {
+				plan.Remove = append(plan.Remove, skill)
+			}
+		}
+	}
+
+	sort.SliceStable(plan.Remove, func(i, j int) bool {
+		if plan.Remove[i].Name != plan.Remove[j].Name {
+			return plan.Remove[i].Name < plan.Remove[j].Name
+		}
+		return plan.Remove[i].Platform < plan.Remove[j].Platform
+	})
+
+	return plan
+}
+
+// collectWritableSkills parses repo and user scope skills for each platform without
+// collapsing same-name skills across scopes, so every installed copy can be cleaned up.
+func collectWritableSkills(platforms []model.Platform) (map[model.Platform][]model.Skill, error) {
+	result := make(map[model.Platform][]model.Skill, len(platforms))
+	for _, platform := range platforms {
+		for _, scope := range []model.SkillScope{model.ScopeRepo, model.ScopeUser} {
+			skills, err := parsePlatformSkillsWithScope(platform, []model.SkillScope{scope}, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s skills: %w", platform, err)
+			}
+			result[platform] = append(result[platform], skills...)
+		}
+	}
+	return result, nil
+}
+
+// removeSkillArtifact deletes a skill from disk. Agent Skills Standard skills live in
+// their own directory, which is removed along with any bundled resources.
+func removeSkillArtifact(skill model.Skill) error {
+	if strings.EqualFold(filepath.Base(skill.Path), "SKILL.md") {
+		dir := filepath.Dir(skill.Path)
+		if filepath.Base(dir) == skill.Name {
+			return os.RemoveAll(dir)
+		}
+	}
+	return os.Remove(skill.Path)
+}
+
+// runCleanupDeprecated removes deprecated skills whose replacements are present everywhere.
+func runCleanupDeprecated(cmd *cli.Command) error {
+	platformStr := cmd.String("platform")
+	dryRun := cmd.Bool("dry-run")
+	yes := cmd.Bool("yes")
+	skipBackup := skipBackup(cmd)
+
+	platforms := model.AllPlatforms()
+	if platformStr != "" {
+		platform, err := model.ParsePlatform(platformStr)
+		if err != nil {
+			return fmt.Errorf("invalid platform: %w", err)
+		}
+		platforms = []model.Platform{platform}
+	}
+
+	skillsByPlatform, err := collectWritableSkills(platforms)
+	if err != nil {
+		return err
+	}
+
+	plan := planDeprecatedCleanup(skillsByPlatform)
+
+	if len(plan.Pending) > 0 {
+		pendingNames := make([]string, 0, len(plan.Pending))
+		for name := range plan.Pending {
+			pendingNames = append(pendingNames, name)
+		}
+		sort.Strings(pendingNames)
+
+		fmt.Println("Deprecated skills kept for now:")
+		for _, name := range pendingNames {
+			fmt.Printf("  - %s (%s)\n", name, plan.Pending[name])
+		}
+		fmt.Println()
+	}
+
+	if len(plan.Remove) == 0 {
+		fmt.Println("No deprecated skills are ready for cleanup.")
+		return nil
+	}
+
+	fmt.Printf("Deprecated skills to remove (%d):\n", len(plan.Remove))
+	for _, skill := range plan.Remove {
+		fmt.Printf("  - %s [%s, %s] → %s\n", skill.Name, skill.Platform, skill.Scope, skill.ReplacedBy())
+		fmt.Printf("    Remove: %s\n", skill.Path)
+	}
+
+	if dryRun {
+		fmt.Println("\n[Dry run - no changes made]")
+		return nil
+	}
+
+	if !yes {
+		confirmed, err := confirmDestructive(fmt.Sprintf("Remove %d deprecated skill(s)?", len(plan.Remove)), "remove", len(plan.Remove), riskLevelWarning)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cleanup cancelled.")
+			return nil
+		}
+	}
+
+	if !skipBackup {
+		byPlatform := make(map[model.Platform][]model.Skill)
+		for _, skill := range plan.Remove {
+			byPlatform[skill.Platform] = append(byPlatform[skill.Platform], skill)
+		}
+		for platform, skills := range byPlatform {
+			if _, err := createBackupsForSkills(platform, skills, "pre-cleanup backup", []string{"cleanup", "deprecated"}); err != nil {
+				return err
+			}
+		}
+	}
+
+	removed := 0
+	for _, skill := range plan.Remove {
+		if err := removeSkillArtifact(skill); err != nil {
+			warnf("Warning: failed to remove %s: %v\n", skill.Path, err)
+			continue
+		}
+		removed++
+	}
+
+	fmt.Printf("\n✓ Removed %d deprecated skill(s)\n", removed)
+	return nil
+}
@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func deprecatedSkill(name, replacement string, platform model.Platform) model.Skill {
+	metadata := map[string]string{"deprecated": "true"}
+	if replacement != "" {
+		metadata["replaced_by"] = replacement
+	}
+	return model.Skill{Name: name, Platform: platform, Metadata: metadata}
+}
+
+func TestPlanDeprecatedCleanup(t *testing.T) {
+	tests := map[string]struct {
+		skills      map[model.Platform][]model.Skill
+		wantRemove  []string
+		wantPending []string
+	}{
+		"replacement present everywhere": {
+			skills: map[model.Platform][]model.Skill{
+				model.ClaudeCode: {deprecatedSkill("old", "new", model.ClaudeCode), {Name: "new", Platform: model.ClaudeCode}},
+				model.Cursor:     {deprecatedSkill("old", "new", model.Cursor), {Name: "new", Platform: model.Cursor}},
+			},
+			wantRemove: []string{"claude-code/old", "cursor/old"},
+		},
+		"replacement missing on one platform": {
+			skills: map[model.Platform][]model.Skill{
+				model.ClaudeCode: {deprecatedSkill("old", "new", model.ClaudeCode), {Name: "new", Platform: model.ClaudeCode}},
+				model.Cursor:     {deprecatedSkill("old", "new", model.Cursor)},
+			},
+			wantPending: []string{"old"},
+		},
+		"platform without deprecated skill does not need replacement": {
+			skills: map[model.Platform][]model.Skill{
+				model.ClaudeCode: {deprecatedSkill("old", "new", model.ClaudeCode), {Name: "new", Platform: model.ClaudeCode}},
+				model.Codex:      {{Name: "unrelated", Platform: model.Codex}},
+			},
+			wantRemove: []string{"claude-code/old"},
+		},
+		"no replacement declared": {
+			skills: map[model.Platform][]model.Skill{
+				model.Cursor: {deprecatedSkill("old", "", model.Cursor)},
+			},
+			wantPending: []string{"old"},
+		},
+		"replacement declared on another platform copy": {
+			skills: map[model.Platform][]model.Skill{
+				model.ClaudeCode: {deprecatedSkill("old", "new", model.ClaudeCode), {Name: "new", Platform: model.ClaudeCode}},
+				model.Cursor:     {{Name: "old", Platform: model.Cursor}, {Name: "new", Platform: model.Cursor}},
+			},
+			wantRemove: []string{"claude-code/old", "cursor/old"},
+		},
+		"same-named skill with other content survives": {
+			skills: map[model.Platform][]model.Skill{
+				model.ClaudeCode: {
+					deprecatedSkill("old", "new", model.ClaudeCode),
+					{Name: "new", Platform: model.ClaudeCode},
+					{Name: "old", Platform: model.ClaudeCode, Scope: model.ScopeRepo, Content: "Repo-local instructions."},
+				},
+				model.Cursor: {{Name: "old", Platform: model.Cursor, Content: "Cursor instructions."}},
+			},
+			wantRemove: []string{"claude-code/old"},
+		},
+		"no deprecated skills": {
+			skills: map[model.Platform][]model.Skill{
+				model.Cursor: {{Name: "active", Platform: model.Cursor}},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			plan := planDeprecatedCleanup(tt.skills)
+
+			var gotRemove []string
+			for _, skill := range plan.Remove {
+				gotRemove = append(gotRemove, string(skill.Platform)+"/"+skill.Name)
+			}
+			if len(gotRemove) != len(tt.wantRemove) {
+				t.Fatalf("Remove = %v, want %v", gotRemove, tt.wantRemove)
+			}
+			for i := range gotRemove {
+				if gotRemove[i] != tt.wantRemove[i] {
+					t.Errorf("Remove[%d] = %q, want %q", i, gotRemove[i], tt.wantRemove[i])
+				}
+			}
+
+			if len(plan.Pending) != len(tt.wantPending) {
+				t.Fatalf("Pending = %v, want %v", plan.Pending, tt.wantPending)
+			}
+			for _, name := range tt.wantPending {
+				if _, ok := plan.Pending[name]; !ok {
+					t.Errorf("expected %q to be pending, got %v", name, plan.Pending)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterDeprecated(t *testing.T) {
+	skills := []model.Skill{
+		{Name: "a"},
+		deprecatedSkill("b", "a", model.Cursor),
+		{Name: "c"},
+	}
+
+	active, deprecated := filterDeprecated(skills)
+	if len(active) != 2 || active[0].Name != "a" || active[1].Name != "c" {
+		t.Errorf("active = %v, want [a c]", active)
+	}
+	if len(deprecated) != 1 || deprecated[0].Name != "b" {
+		t.Errorf("deprecated = %v, want [b]", deprecated)
+	}
+	if got := countDeprecated(skills); got != 1 {
+		t.Errorf("countDeprecated() = %d, want 1", got)
+	}
+}
+
+func TestDeprecationLabel(t *testing.T) {
+	if got := deprecationLabel(deprecatedSkill("old", "new", model.Cursor)); got != "[deprecated → new]" {
+		t.Errorf("deprecationLabel() = %q", got)
+	}
+	if got := deprecationLabel(deprecatedSkill("old", "", model.Cursor)); got != "[deprecated]" {
+		t.Errorf("deprecationLabel() = %q", got)
+	}
+}
+
+func TestCleanupDeprecatedCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, ".claude", "skills")
+	cursorSkills := filepath.Join(tempDir, ".cursor", "skills")
+
+	writeSkill := func(base, name, frontmatter string) string {
+		t.Helper()
+		dir := filepath.Join(base, name)
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			t.Fatalf("failed to create skill dir: %v", err)
+		}
+		content := "---\nname: " + name + "\n" + frontmatter + "---\nBody of " + name + "\n"
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write skill: %v", err)
+		}
+		return dir
+	}
+
+	oldClaude := writeSkill(claudeSkills, "old-skill", "deprecated: true\nreplaced_by: new-skill\n")
+	writeSkill(claudeSkills, "new-skill", "")
+	oldCursor := writeSkill(cursorSkills, "old-skill", "deprecated: true\nreplaced_by: new-skill\n")
+
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CODEX_SKILLS_PATHS", filepath.Join(tempDir, ".codex", "skills"))
+
+	ctx := context.Background()
+
+	// Replacement missing on cursor: nothing is removed.
+	if err := Run(ctx, []string{"skillsync", "cleanup", "--deprecated", "--yes", "--skip-backup"}); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+	if _, err := os.Stat(oldClaude); err != nil {
+		t.Fatalf("expected claude copy to be kept while replacement is missing: %v", err)
+	}
+
+	writeSkill(cursorSkills, "new-skill", "")
+
+	if err := Run(ctx, []string{"skillsync", "cleanup", "--deprecated", "--dry-run"}); err != nil {
+		t.Fatalf("cleanup dry-run failed: %v", err)
+	}
+	if _, err := os.Stat(oldCursor); err != nil {
+		t.Fatalf("dry run should not remove skills: %v", err)
+	}
+
+	if err := Run(ctx, []string{"skillsync", "cleanup", "--deprecated", "--yes", "--skip-backup"}); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+	for _, dir := range []string{oldClaude, oldCursor} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", dir)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cursorSkills, "new-skill", "SKILL.md")); err != nil {
+		t.Errorf("replacement skill should be kept: %v", err)
+	}
+}
+
+func TestSyncSkipDeprecated(t *testing.T) {
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, ".claude", "skills")
+	cursorSkills := filepath.Join(tempDir, ".cursor", "skills")
+	if err := os.MkdirAll(claudeSkills, 0o750); err != nil {
+		t.Fatalf("failed to create claude dir: %v", err)
+	}
+	if err := os.MkdirAll(cursorSkills, 0o750); err != nil {
+		t.Fatalf("failed to create cursor dir: %v", err)
+	}
+
+	files := map[string]string{
+		"legacy.md":  "---\nname: legacy\ndeprecated: true\nreplaced_by: current\n---\nOld.",
+		"current.md": "---\nname: current\n---\nNew.",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(claudeSkills, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+
+	ctx := context.Background()
+	err := Run(ctx, []string{"skillsync", "sync", "--yes", "--skip-backup", "--skip-validation", "--skip-deprecated", "claudecode", "cursor"})
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(cursorSkills, "current.md")); err != nil {
+		t.Errorf("expected active skill to be synced: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cursorSkills, "legacy.md")); !os.IsNotExist(err) {
+		t.Errorf("expected deprecated skill to be skipped")
+	}
+}
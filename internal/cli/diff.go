@@ -0,0 +1,358 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// skillDiffStatus describes how a skill differs between two platform specs.
+type skillDiffStatus string
+
+const (
+	diffStatusIdentical  skillDiffStatus = "identical"
+	diffStatusModified   skillDiffStatus = "modified"
+	diffStatusOnlySource skillDiffStatus = "only-source"
+	diffStatusOnlyTarget skillDiffStatus = "only-target"
+)
+
+// diffHunkOutput is the JSON representation of a diff hunk.
+type diffHunkOutput struct {
+	SourceStart int      `json:"source_start"`
+	SourceCount int      `json:"source_count"`
+	TargetStart int      `json:"target_start"`
+	TargetCount int      `json:"target_count"`
+	Lines       []string `json:"lines"`
+}
+
+// skillDiff is the comparison of a single skill between source and target.
+type skillDiff struct {
+	Name            string           `json:"name"`
+	Status          skillDiffStatus  `json:"status"`
+	SourcePath      string           `json:"source_path,omitempty"`
+	TargetPath      string           `json:"target_path,omitempty"`
+	MetadataDiffers bool             `json:"metadata_differs,omitempty"`
+	Hunks           []diffHunkOutput `json:"hunks,omitempty"`
+
+	conflict *sync.Conflict
+}
+
+func diffCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "diff",
+		Usage: "Show differences for skills between two platforms",
+		UsageText: `skillsync diff [options] <source> <target> [skill...]
+   skillsync diff claudecode cursor
+   skillsync diff claudecode cursor my-skill
+   skillsync diff claudecode:repo claudecode:user
+   skillsync diff --format patch claudecode codex > skills.patch`,
+		Description: `Compare skills between two platform specs without syncing.
+
+   Skills are matched by name. Content differences are shown as unified diff
+   hunks computed with the same algorithm sync uses for conflict detection.
+   Lines prefixed with - come from the source, + from the target.
+
+   The diff algorithm and context size come from the diff section of the
+   config (default myers with 3 lines of context). Patience and histogram
+   keep hunks readable when sections of a skill were re-ordered.
+
+   Platform spec format: platform[:scope[,scope2,...]] (same as sync).
+   Source and target may be the same platform with different scopes.
+
+   Output formats:
+   - text:  Colored diff with a summary (default)
+   - patch: Plain unified diff suitable for saving or piping
+   - json:  Machine-readable per-skill results with hunks
+
+   Examples:
+     skillsync diff claudecode cursor              # All differing skills
+     skillsync diff claudecode cursor commit       # A single skill
+     skillsync diff --all claudecode cursor        # Include identical/missing skills
+     skillsync diff --algorithm patience -U 1 claudecode cursor`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Value:   "text",
+				Usage:   "Output format: text, patch, json",
+			},
+			&cli.BoolFlag{
+				Name:    "all",
+				Aliases: []string{"a"},
+				Usage:   "Also list identical skills and skills present on only one side",
+			},
+			&cli.StringFlag{
+				Name:    "type",
+				Aliases: []string{"t"},
+				Usage:   "Filter by skill type (skill, prompt). Comma-separated for multiple.",
+			},
+			&cli.StringFlag{
+				Name:  "algorithm",
+				Usage: "Diff algorithm: myers, patience, histogram (default from config)",
+			},
+			&cli.IntFlag{
+				Name:    "context",
+				Aliases: []string{"U"},
+				Value:   -1,
+				Usage:   "Lines of context around each change (default from config)",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return runDiff(cmd)
+		},
+	}
+}
+
+func runDiff(cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() < 2 {
+		return fmt.Errorf("diff requires at least 2 arguments: <source> <target> [skill...]")
+	}
+
+	sourceSpec, err := model.ParsePlatformSpec(args.Get(0))
+	if err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+	targetSpec, err := model.ParsePlatformSpec(args.Get(1))
+	if err != nil {
+		return fmt.Errorf("invalid target: %w", err)
+	}
+
+	format := out.Format(cmd.String("format"))
+	switch format {
+	case "text", "patch", "json":
+	default:
+		return fmt.Errorf("unsupported format: %s (use text, patch, or json)", format)
+	}
+
+	typeFilter, err := parseTypeFilter(cmd.String("type"))
+	if err != nil {
+		return fmt.Errorf("invalid type: %w", err)
+	}
+
+	diffOpts, err := diffOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	sourceSkills, err := parsePlatformSkillsWithScope(sourceSpec.Platform, sourceSpec.Scopes, false)
+	if err != nil {
+		return fmt.Errorf("failed to parse source skills: %w", err)
+	}
+	targetSkills, err := parsePlatformSkillsWithScope(targetSpec.Platform, targetSpec.Scopes, false)
+	if err != nil {
+		return fmt.Errorf("failed to parse target skills: %w", err)
+	}
+	sourceSkills = filterBySkillType(sourceSkills, typeFilter)
+	targetSkills = filterBySkillType(targetSkills, typeFilter)
+
+	names := args.Slice()[2:]
+	if len(names) > 0 {
+		sourceSkills = filterSkillsByName(sourceSkills, names)
+		targetSkills = filterSkillsByName(targetSkills, names)
+		if len(sourceSkills) == 0 && len(targetSkills) == 0 {
+			return fmt.Errorf("skill(s) not found in %s or %s: %s", sourceSpec, targetSpec, strings.Join(names, ", "))
+		}
+	}
+
+	diffs := diffSkills(sourceSkills, targetSkills, diffOpts)
+	if !cmd.Bool("all") && len(names) == 0 {
+		diffs = slices.DeleteFunc(diffs, func(d skillDiff) bool {
+			return d.Status != diffStatusModified
+		})
+	}
+
+	switch format {
+	case "json":
+		return outputAnyJSON(diffs)
+	case "patch":
+		fmt.Print(formatPatch(diffs))
+		return nil
+	default:
+		return outputDiffText(diffs, sourceSpec, targetSpec)
+	}
+}
+
+// diffOptionsFromFlags returns the configured diff options overridden by the
+// --algorithm and --context flags.
+func diffOptionsFromFlags(cmd *cli.Command) (sync.DiffOptions, error) {
+	opts := sync.CurrentDiffOptions()
+	if name := cmd.String("algorithm"); name != "" {
+		algorithm, err := sync.ParseDiffAlgorithm(name)
+		if err != nil {
+			return opts, err
+		}
+		opts.Algorithm = algorithm
+	}
+	if n := cmd.Int("context"); n >= 0 {
+		opts.Context = n
+	}
+	return opts, nil
+}
+
+// diffSkills compares skills by name, returning results sorted by name.
+func diffSkills(sourceSkills, targetSkills []model.Skill, opts sync.DiffOptions) []skillDiff {
+	sourceByName := make(map[string]model.Skill, len(sourceSkills))
+	for _, skill := range sourceSkills {
+		sourceByName[skill.Name] = skill
+	}
+	targetByName := make(map[string]model.Skill, len(targetSkills))
+	for _, skill := range targetSkills {
+		targetByName[skill.Name] = skill
+	}
+
+	names := make([]string, 0, len(sourceByName)+len(targetByName))
+	for name := range sourceByName {
+		names = append(names, name)
+	}
+	for name := range targetByName {
+		if _, ok := sourceByName[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	detector := sync.NewConflictDetectorWithOptions(opts)
+	diffs := make([]skillDiff, 0, len(names))
+	for _, name := range names {
+		source, inSource := sourceByName[name]
+		target, inTarget := targetByName[name]
+
+		d := skillDiff{Name: name, SourcePath: source.Path, TargetPath: target.Path}
+		switch {
+		case !inTarget:
+			d.Status = diffStatusOnlySource
+		case !inSource:
+			d.Status = diffStatusOnlyTarget
+		default:
+			conflict := detector.DetectConflict(source, target)
+			if conflict == nil {
+				d.Status = diffStatusIdentical
+				break
+			}
+			d.Status = diffStatusModified
+			d.MetadataDiffers = conflict.Type != sync.ConflictTypeContent
+			d.conflict = conflict
+			for _, hunk := range conflict.Hunks {
+				d.Hunks = append(d.Hunks, newDiffHunkOutput(hunk))
+			}
+		}
+		diffs = append(diffs, d)
+	}
+
+	return diffs
+}
+
+// newDiffHunkOutput converts a conflict hunk into unified diff coordinates.
+func newDiffHunkOutput(hunk sync.DiffHunk) diffHunkOutput {
+	h := diffHunkOutput{
+		SourceStart: hunk.SourceStart,
+		TargetStart: hunk.TargetStart,
+		Lines:       make([]string, 0, len(hunk.Lines)),
+	}
+	for _, line := range hunk.Lines {
+		switch line.Type {
+		case sync.DiffLineRemoved:
+			h.SourceCount++
+		case sync.DiffLineAdded:
+			h.TargetCount++
+		default:
+			h.SourceCount++
+			h.TargetCount++
+		}
+		h.Lines = append(h.Lines, line.String())
+	}
+	// An empty side starts at the line before the hunk, per unified diff convention.
+	if h.SourceCount == 0 {
+		h.SourceStart--
+	}
+	if h.TargetCount == 0 {
+		h.TargetStart--
+	}
+	return h
+}
+
+// header returns the unified diff hunk header.
+func (h diffHunkOutput) header() string {
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.SourceStart, h.SourceCount, h.TargetStart, h.TargetCount)
+}
+
+// formatPatch renders modified skills as a plain unified diff.
+func formatPatch(diffs []skillDiff) string {
+	var sb strings.Builder
+	for _, d := range diffs {
+		if len(d.Hunks) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "--- %s\n", d.SourcePath)
+		fmt.Fprintf(&sb, "+++ %s\n", d.TargetPath)
+		for _, hunk := range d.Hunks {
+			sb.WriteString(hunk.header())
+			sb.WriteString("\n")
+			for _, line := range hunk.Lines {
+				sb.WriteString(line)
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// outputDiffText prints a colored diff for each skill followed by a summary.
+func outputDiffText(diffs []skillDiff, sourceSpec, targetSpec model.PlatformSpec) error {
+	if len(diffs) == 0 {
+		fmt.Printf("No differences between %s and %s\n", sourceSpec, targetSpec)
+		return nil
+	}
+
+	counts := make(map[skillDiffStatus]int)
+	for _, d := range diffs {
+		counts[d.Status]++
+
+		switch d.Status {
+		case diffStatusIdentical:
+			fmt.Printf("%s %s\n", ui.Dim("="), d.Name)
+		case diffStatusOnlySource:
+			fmt.Printf("%s %s %s\n", ui.Error("-"), d.Name, ui.Dim("(only in "+sourceSpec.String()+")"))
+		case diffStatusOnlyTarget:
+			fmt.Printf("%s %s %s\n", ui.Success("+"), d.Name, ui.Dim("(only in "+targetSpec.String()+")"))
+		case diffStatusModified:
+			fmt.Printf("\n%s\n", ui.Header("diff "+d.Name))
+			fmt.Println(ui.Error("--- " + d.SourcePath))
+			fmt.Println(ui.Success("+++ " + d.TargetPath))
+			if d.MetadataDiffers {
+				fmt.Println(ui.Warning("metadata differs (description, tools, or frontmatter)"))
+			}
+			for i, hunk := range d.Hunks {
+				fmt.Println(ui.Info(hunk.header()))
+				for _, line := range d.conflict.Hunks[i].Lines {
+					fmt.Println(formatDiffLine(line))
+				}
+			}
+		}
+	}
+
+	var parts []string
+	if n := counts[diffStatusModified]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d modified", n))
+	}
+	if n := counts[diffStatusIdentical]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d identical", n))
+	}
+	if n := counts[diffStatusOnlySource]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d only in %s", n, sourceSpec))
+	}
+	if n := counts[diffStatusOnlyTarget]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d only in %s", n, targetSpec))
+	}
+	fmt.Printf("\n%s\n", strings.Join(parts, ", "))
+	return nil
+}
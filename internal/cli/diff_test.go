@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+func TestDiffSkills(t *testing.T) {
+	source := []model.Skill{
+		{Name: "same", Content: "a\nb", Path: "/src/same.md"},
+		{Name: "changed", Content: "a\nb\nc", Path: "/src/changed.md"},
+		{Name: "meta", Content: "x", Description: "old", Path: "/src/meta.md"},
+		{Name: "source-only", Content: "s"},
+	}
+	target := []model.Skill{
+		{Name: "same", Content: "a\nb"},
+		{Name: "changed", Content: "a\nB\nc"},
+		{Name: "meta", Content: "x", Description: "new"},
+		{Name: "target-only", Content: "t"},
+	}
+
+	diffs := diffSkills(source, target, sync.DefaultDiffOptions())
+
+	want := map[string]skillDiffStatus{
+		"changed":     diffStatusModified,
+		"meta":        diffStatusModified,
+		"same":        diffStatusIdentical,
+		"source-only": diffStatusOnlySource,
+		"target-only": diffStatusOnlyTarget,
+	}
+	if len(diffs) != len(want) {
+		t.Fatalf("expected %d diffs, got %d", len(want), len(diffs))
+	}
+	for i, d := range diffs {
+		if i > 0 && diffs[i-1].Name > d.Name {
+			t.Errorf("diffs not sorted: %q before %q", diffs[i-1].Name, d.Name)
+		}
+		if d.Status != want[d.Name] {
+			t.Errorf("%s: status = %s, want %s", d.Name, d.Status, want[d.Name])
+		}
+		switch d.Name {
+		case "changed":
+			if len(d.Hunks) != 1 || d.MetadataDiffers {
+				t.Errorf("changed: expected one content hunk, got %+v", d)
+			}
+		case "meta":
+			if len(d.Hunks) != 0 || !d.MetadataDiffers {
+				t.Errorf("meta: expected metadata-only difference, got %+v", d)
+			}
+		}
+	}
+}
+
+func TestNewDiffHunkOutput(t *testing.T) {
+	tests := map[string]struct {
+		hunk sync.DiffHunk
+		want string
+	}{
+		"replacement with context": {
+			hunk: sync.DiffHunk{SourceStart: 2, TargetStart: 2, Lines: []sync.DiffLine{
+				{Type: sync.DiffLineRemoved, Content: "b"},
+				{Type: sync.DiffLineAdded, Content: "B"},
+				{Type: sync.DiffLineContext, Content: "c"},
+			}},
+			want: "@@ -2,2 +2,2 @@",
+		},
+		"pure addition": {
+			hunk: sync.DiffHunk{SourceStart: 3, TargetStart: 3, Lines: []sync.DiffLine{
+				{Type: sync.DiffLineAdded, Content: "new"},
+			}},
+			want: "@@ -2,0 +3,1 @@",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := newDiffHunkOutput(tt.hunk)
+			if got.header() != tt.want {
+				t.Errorf("header() = %q, want %q", got.header(), tt.want)
+			}
+			if len(got.Lines) != len(tt.hunk.Lines) {
+				t.Errorf("expected %d lines, got %d", len(tt.hunk.Lines), len(got.Lines))
+			}
+		})
+	}
+}
+
+func TestFormatPatch(t *testing.T) {
+	diffs := diffSkills(
+		[]model.Skill{{Name: "s", Content: "a\nb\nc", Path: "/src/s.md"}},
+		[]model.Skill{{Name: "s", Content: "a\nB\nc", Path: "/dst/s.md"}},
+		sync.DefaultDiffOptions(),
+	)
+
+	want := "--- /src/s.md\n+++ /dst/s.md\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n"
+	if got := formatPatch(diffs); got != want {
+		t.Errorf("formatPatch() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDiffCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, ".claude", "skills")
+	cursorSkills := filepath.Join(tempDir, ".cursor", "skills")
+	for _, dir := range []string{claudeSkills, cursorSkills} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	files := map[string]string{
+		filepath.Join(claudeSkills, "review.md"): "---\nname: review\n---\nCheck style.\nCheck tests.",
+		filepath.Join(cursorSkills, "review.md"): "---\nname: review\n---\nCheck style.\nCheck docs.",
+		filepath.Join(claudeSkills, "plan.md"):   "---\nname: plan\n---\nPlan work.",
+		filepath.Join(cursorSkills, "plan.md"):   "---\nname: plan\n---\nPlan work.",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+
+	ctx := context.Background()
+
+	t.Run("json lists only modified skills", func(t *testing.T) {
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(ctx, []string{"skillsync", "diff", "--format", "json", "claudecode", "cursor"})
+		})
+		if runErr != nil {
+			t.Fatalf("diff failed: %v", runErr)
+		}
+
+		var diffs []skillDiff
+		if err := json.Unmarshal([]byte(output), &diffs); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, output)
+		}
+		if len(diffs) != 1 || diffs[0].Name != "review" || diffs[0].Status != diffStatusModified {
+			t.Errorf("unexpected diffs: %+v", diffs)
+		}
+	})
+
+	t.Run("all includes identical skills", func(t *testing.T) {
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(ctx, []string{"skillsync", "diff", "--all", "claudecode", "cursor"})
+		})
+		if runErr != nil {
+			t.Fatalf("diff failed: %v", runErr)
+		}
+		for _, want := range []string{"plan", "-Check tests.", "+Check docs.", "1 modified, 1 identical"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output missing %q:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("patch for named skill", func(t *testing.T) {
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(ctx, []string{"skillsync", "diff", "--format", "patch", "claudecode", "cursor", "review"})
+		})
+		if runErr != nil {
+			t.Fatalf("diff failed: %v", runErr)
+		}
+		if !strings.HasPrefix(output, "--- "+filepath.Join(claudeSkills, "review.md")) {
+			t.Errorf("unexpected patch output:\n%s", output)
+		}
+	})
+
+	t.Run("unknown skill", func(t *testing.T) {
+		err := Run(ctx, []string{"skillsync", "diff", "claudecode", "cursor", "missing"})
+		if err == nil {
+			t.Error("expected error for unknown skill")
+		}
+	})
+}
@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/docs"
+)
+
+func docsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "docs",
+		Usage: "Generate man pages and read long-form help topics",
+		UsageText: `skillsync docs man [--dir <dir>]
+   skillsync docs topic [<name>]`,
+		Description: `Generate documentation from the command metadata.
+
+   docs man writes a section 1 man page for skillsync and each of its
+   commands, and a section 7 page per help topic, for packaging. Set
+   SOURCE_DATE_EPOCH for reproducible page dates.
+
+   docs topic prints a help topic, or lists the topics.
+
+   Examples:
+     skillsync docs man --dir ./man
+     skillsync docs topic strategies`,
+		Commands: []*cli.Command{
+			{
+				Name:      "man",
+				Usage:     "Write man pages for every command and help topic",
+				UsageText: "skillsync docs man [--dir <dir>]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "dir",
+						Aliases: []string{"d"},
+						Value:   "man",
+						Usage:   "Directory to write the pages to",
+					},
+				},
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					return runDocsMan(cmd.Root(), cmd.String("dir"))
+				},
+			},
+			{
+				Name:      "topic",
+				Usage:     "Print a help topic, or list the topics",
+				UsageText: "skillsync docs topic [<name>]",
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					return runDocsTopic(cmd.Args().First())
+				},
+			},
+		},
+	}
+}
+
+// runDocsMan writes the man pages of root and the help topics to dir.
+func runDocsMan(root *cli.Command, dir string) error {
+	date, err := manDate()
+	if err != nil {
+		return err
+	}
+	header := docs.Header{
+		Source: root.Name + " " + Version,
+		Manual: root.Name + " Manual",
+		Date:   date,
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	pages := manPages(root)
+	files := make([]string, 0, len(pages))
+	for _, page := range pages {
+		path := filepath.Join(dir, page.Filename())
+		// #nosec G306 - man pages are meant to be world-readable
+		if err := os.WriteFile(path, []byte(page.Roff(header)), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		files = append(files, path)
+	}
+
+	return out.Render(files, func() error {
+		out.Printf("Wrote %d man pages to %s\n", len(files), dir)
+		return nil
+	})
+}
+
+// manDate is the date printed on the pages: SOURCE_DATE_EPOCH when set, so
+// packaged builds are reproducible, or today.
+func manDate() (time.Time, error) {
+	epoch := os.Getenv("SOURCE_DATE_EPOCH")
+	if epoch == "" {
+		return time.Now(), nil
+	}
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", epoch, err)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+// manPages returns a page for root and every visible command beneath it,
+// followed by the help topic pages.
+func manPages(root *cli.Command) []docs.Page {
+	spec := newCommandSpec(root, root.Name)
+	topics := docs.Topics()
+	topicRefs := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		topicRefs = append(topicRefs, root.Name+"-"+topic.Name+"(7)")
+	}
+
+	pages := commandPages(spec, "", topicRefs)
+	for _, topic := range topics {
+		pages = append(pages, docs.TopicPage(root.Name, topic))
+	}
+	return pages
+}
+
+// commandPages returns the page of spec and its visible subcommands.
+// parent is the page name of the parent command, empty for the root.
+func commandPages(spec commandSpec, parent string, topicRefs []string) []docs.Page {
+	name := manPageName(spec.Path)
+	page := docs.Page{
+		Name:        name,
+		Section:     1,
+		Summary:     spec.Usage,
+		Synopsis:    commandSynopsis(spec),
+		Description: spec.Description,
+	}
+	if parent != "" {
+		page.SeeAlso = append(page.SeeAlso, parent+"(1)")
+	} else {
+		page.SeeAlso = append(page.SeeAlso, topicRefs...)
+	}
+
+	var subcommands []docs.Entry
+	var subpages []docs.Page
+	for _, sub := range spec.Commands {
+		if sub.Hidden {
+			continue
+		}
+		subcommands = append(subcommands, docs.Entry{Term: strings.Join(append([]string{sub.Name}, sub.Aliases...), ", "), Text: sub.Usage})
+		page.SeeAlso = append(page.SeeAlso, manPageName(sub.Path)+"(1)")
+		subpages = append(subpages, commandPages(sub, name, topicRefs)...)
+	}
+	if len(subcommands) > 0 {
+		page.Sections = append(page.Sections, docs.Section{Heading: "Commands", Entries: subcommands})
+	}
+	if len(spec.Flags) > 0 {
+		options := make([]docs.Entry, 0, len(spec.Flags))
+		for _, flag := range spec.Flags {
+			options = append(options, flagEntry(flag))
+		}
+		page.Sections = append(page.Sections, docs.Section{Heading: "Options", Entries: options})
+	}
+	return append([]docs.Page{page}, subpages...)
+}
+
+// manPageName turns a command path, "skillsync backup list", into a page
+// name, "skillsync-backup-list".
+func manPageName(path string) string {
+	return strings.ReplaceAll(path, " ", "-")
+}
+
+// commandSynopsis returns the usage lines of a command: its UsageText, or
+// one built from its path when it has none.
+func commandSynopsis(spec commandSpec) []string {
+	if spec.UsageText != "" {
+		lines := strings.Split(spec.UsageText, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSpace(line)
+		}
+		return lines
+	}
+	usage := spec.Path
+	if len(spec.Flags) > 0 {
+		usage += " [options]"
+	}
+	if len(spec.Commands) > 0 {
+		usage += " <command>"
+	}
+	if spec.ArgsUsage != "" {
+		usage += " " + spec.ArgsUsage
+	}
+	return []string{usage}
+}
+
+// flagEntry describes a flag as "--strategy, -s string" with its usage,
+// default, and environment variables.
+func flagEntry(flag flagSpec) docs.Entry {
+	names := make([]string, 0, 1+len(flag.Aliases))
+	for _, name := range append([]string{flag.Name}, flag.Aliases...) {
+		if len(name) == 1 {
+			names = append(names, "-"+name)
+		} else {
+			names = append(names, "--"+name)
+		}
+	}
+	term := strings.Join(names, ", ")
+	if flag.Type != "" && flag.Type != "bool" {
+		term += " " + flag.Type
+	}
+
+	text := flag.Usage
+	if flag.Default != nil {
+		if value := fmt.Sprint(flag.Default); value != "false" && value != "0" {
+			text += " (default: " + value + ")"
+		}
+	}
+	if len(flag.EnvVars) > 0 {
+		text += " [$" + strings.Join(flag.EnvVars, ", $") + "]"
+	}
+	return docs.Entry{Term: term, Text: text}
+}
+
+// runDocsTopic prints the named help topic, or lists the topics when name
+// is empty.
+func runDocsTopic(name string) error {
+	if name == "" {
+		topics := docs.Topics()
+		return out.Render(topics, func() error {
+			entries := make([]docs.Entry, 0, len(topics))
+			for _, topic := range topics {
+				entries = append(entries, docs.Entry{Term: topic.Name, Text: topic.Summary})
+			}
+			out.Println("Help topics:")
+			out.Print(docs.FormatEntries(entries, "  "))
+			out.Println("\nRun 'skillsync docs topic <name>' to read one.")
+			return nil
+		})
+	}
+
+	topic, err := docs.Lookup(name)
+	if err != nil {
+		return err
+	}
+	return out.Render(topic, func() error {
+		out.Print(topic.Text())
+		return nil
+	})
+}
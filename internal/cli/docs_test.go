@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestDocsMan(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SOURCE_DATE_EPOCH", "1767225600")
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "docs", "man", "--dir", dir}))
+	})
+
+	tests := map[string][]string{
+		"skillsync.1": {
+			`.TH "SKILLSYNC" "1" "January 2026"`,
+			"\\fBsync\\fR\nSynchronize skills across platforms",
+			"\\fB\\-\\-output string\\fR\nResult format for scripting",
+			"(default: text) [$SKILLSYNC_OUTPUT]",
+			"\\fBskillsync\\-strategies\\fR(7)",
+		},
+		"skillsync-sync.1": {
+			"skillsync\\-sync \\- Synchronize skills across platforms",
+			"three\\-way   \\- Intelligent three\\-way merge",
+			"\\fB\\-\\-strategy, \\-s string\\fR",
+			"\\fBskillsync\\fR(1)",
+		},
+		"skillsync-backup-rollback.1": {
+			"\\fBskillsync\\-backup\\fR(1)",
+		},
+		"skillsync-scopes.7": {
+			".SH SOURCES AND TARGETS",
+		},
+	}
+	for name, wants := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			util.AssertNoError(t, err)
+			for _, want := range wants {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("%s missing %q", name, want)
+				}
+			}
+		})
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "skillsync-help.1")); err == nil {
+		t.Error("the help command should not get a page")
+	}
+}
+
+func TestDocsTopic(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		"list": {
+			args: []string{"skillsync", "docs", "topic"},
+			want: "  platform-specs - How commands name a platform",
+		},
+		"topic": {
+			args: []string{"skillsync", "docs", "topic", "platform-specs"},
+			want: "     claude-code - Also accepted as claudecode, claude",
+		},
+		"json": {
+			args: []string{"skillsync", "--output", "json", "docs", "topic", "scopes"},
+			want: `"heading": "Sources and targets"`,
+		},
+		"unknown": {
+			args:    []string{"skillsync", "docs", "topic", "nope"},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), tt.args)
+			})
+			if (runErr != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", runErr, tt.wantErr)
+			}
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, output)
+			}
+		})
+	}
+}
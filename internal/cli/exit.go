@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+// Exit codes returned by the skillsync binary.
+const (
+	// ExitOK means the command succeeded.
+	ExitOK = 0
+	// ExitError means the command failed, or a sync had failed skills.
+	ExitError = 1
+	// ExitChanges means a sync run with --fail-on changes created, updated,
+	// merged, or deleted skills (or would have, with --dry-run).
+	ExitChanges = 2
+	// ExitConflicts means a sync run with --fail-on conflicts left conflicts
+	// to resolve.
+	ExitConflicts = 3
+)
+
+// exitError is an error that exits with a specific code. It deliberately does
+// not implement cli.ExitCoder, which would make the CLI library exit the
+// process from inside Run.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// ExitCode returns the process exit code for an error returned by Run.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return ExitError
+}
+
+// failOnConditions are the sync outcomes that --fail-on turns into a non-zero
+// exit. Failed skills always exit non-zero.
+type failOnConditions struct {
+	conflicts bool
+	changes   bool
+}
+
+func failOnFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "fail-on",
+		Usage:   "Exit non-zero when the sync has: errors (default), conflicts (exit 3), or changes (exit 2). Comma-separated for multiple.",
+		Value:   "errors",
+		Sources: cli.EnvVars("SKILLSYNC_FAIL_ON"),
+	}
+}
+
+// parseFailOn parses a comma-separated --fail-on value.
+func parseFailOn(value string) (failOnConditions, error) {
+	var conditions failOnConditions
+	for _, name := range strings.Split(value, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "", "errors":
+			// Failed skills always fail the sync
+		case "conflicts":
+			conditions.conflicts = true
+		case "changes":
+			conditions.changes = true
+		default:
+			return conditions, fmt.Errorf("invalid --fail-on %q (valid: errors, conflicts, changes)", name)
+		}
+	}
+	return conditions, nil
+}
+
+// check returns an exitError when the sync results meet one of the
+// conditions. Conflicts take precedence over changes.
+func (c failOnConditions) check(results ...*sync.Result) error {
+	var changed, conflicts int
+	dryRun := false
+	for _, result := range results {
+		changed += result.TotalChanged()
+		conflicts += len(result.Conflicts())
+		dryRun = dryRun || result.DryRun
+	}
+
+	if (c.conflicts || c.changes) && conflicts > 0 {
+		code, condition := ExitConflicts, "conflicts"
+		if !c.conflicts {
+			code, condition = ExitChanges, "changes"
+		}
+		return &exitError{code: code, err: fmt.Errorf("%d skill(s) have conflicts (--fail-on %s)", conflicts, condition)}
+	}
+	if c.changes && changed > 0 {
+		verb := "changed"
+		if dryRun {
+			verb = "would change"
+		}
+		return &exitError{code: ExitChanges, err: fmt.Errorf("%d skill(s) %s (--fail-on changes)", changed, verb)}
+	}
+	return nil
+}
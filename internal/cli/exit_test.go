@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want int
+	}{
+		"nil":           {err: nil, want: ExitOK},
+		"plain error":   {err: errors.New("boom"), want: ExitError},
+		"exit error":    {err: &exitError{code: ExitChanges, err: errors.New("drift")}, want: ExitChanges},
+		"wrapped error": {err: fmt.Errorf("sync: %w", &exitError{code: ExitConflicts, err: errors.New("conflict")}), want: ExitConflicts},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, ExitCode(tt.err), tt.want)
+		})
+	}
+}
+
+func TestFailOnConditions_Check(t *testing.T) {
+	changed := &sync.Result{DryRun: true, Skills: []sync.SkillResult{{Action: sync.ActionUpdated}, {Action: sync.ActionUnchanged}}}
+	conflicted := &sync.Result{Skills: []sync.SkillResult{{Action: sync.ActionConflict}}}
+	unchanged := &sync.Result{Skills: []sync.SkillResult{{Action: sync.ActionUnchanged}, {Action: sync.ActionSkipped}}}
+
+	tests := map[string]struct {
+		failOn  string
+		results []*sync.Result
+		want    int
+	}{
+		"errors ignores changes":          {failOn: "errors", results: []*sync.Result{changed, conflicted}, want: ExitOK},
+		"changes":                         {failOn: "changes", results: []*sync.Result{changed}, want: ExitChanges},
+		"conflicts count as changes":      {failOn: "changes", results: []*sync.Result{conflicted}, want: ExitChanges},
+		"conflicts":                       {failOn: "conflicts", results: []*sync.Result{conflicted}, want: ExitConflicts},
+		"conflicts ignores changes":       {failOn: "conflicts", results: []*sync.Result{changed}, want: ExitOK},
+		"conflicts take precedence":       {failOn: "changes,conflicts", results: []*sync.Result{changed, conflicted}, want: ExitConflicts},
+		"no drift":                        {failOn: "changes,conflicts", results: []*sync.Result{unchanged}, want: ExitOK},
+		"counts across workspace results": {failOn: "changes", results: []*sync.Result{unchanged, changed}, want: ExitChanges},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			conditions, err := parseFailOn(tt.failOn)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, ExitCode(conditions.check(tt.results...)), tt.want)
+		})
+	}
+}
+
+func TestParseFailOn_Invalid(t *testing.T) {
+	if _, err := parseFailOn("errors,drift"); err == nil {
+		t.Error("parseFailOn() should reject unknown conditions")
+	}
+}
+
+func TestSyncFailOnChanges(t *testing.T) {
+	tests := map[string]struct {
+		target string // cursor copy of the skill; empty for none
+		want   int
+	}{
+		"missing skill fails":    {want: ExitChanges},
+		"changed skill fails":    {target: "---\nname: lint\ndescription: Run the linter\n---\nRun it once.\n", want: ExitChanges},
+		"identical skill passes": {target: "---\nname: lint\ndescription: Run the linter\n---\nRun it.\n", want: ExitOK},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			claudeSkills := filepath.Join(tempDir, "claude", "skills")
+			cursorSkills := filepath.Join(tempDir, "cursor", "skills")
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Run the linter\n---\nRun it.\n")
+			if tt.target != "" {
+				util.WriteFile(t, filepath.Join(cursorSkills, "lint", "SKILL.md"), tt.target)
+			}
+
+			t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+			t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+			t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), []string{
+					"skillsync", "sync", "--dry-run", "--fail-on", "changes", "claudecode:user", "cursor",
+				})
+			})
+			if got := ExitCode(runErr); got != tt.want {
+				t.Errorf("exit code = %d (%v), want %d\n%s", got, runErr, tt.want, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+func fmtCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "fmt",
+		Usage: "Refresh computed metrics in skill frontmatter",
+		UsageText: `skillsync fmt [options]
+   skillsync fmt --platform claude-code --scope repo
+   skillsync fmt --check`,
+		Description: `Recompute the skillsync-metrics block in the frontmatter of discovered
+   skills, giving platform users the size of a skill without running skillsync:
+
+     ---
+     name: lint
+     description: Run the linter
+     skillsync-metrics:
+       words: 412
+       tokens: 550
+       last-synced: "2026-10-17T09:30:00Z"
+     ---
+
+   words counts the words after the frontmatter and tokens estimates them at
+   four characters per token. last-synced is only set by sync, which keeps
+   the whole block up to date on the skills it writes when sync.metrics is
+   enabled in the config (or SKILLSYNC_SYNC_METRICS=true).
+
+   Skills without frontmatter and plugin skills are left alone.
+
+   Examples:
+     skillsync fmt                          # All platforms and scopes
+     skillsync fmt --platform cursor --scope repo
+     skillsync fmt --check                  # Fail if any metrics are out of date`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Usage:   "Only format one platform (claude-code, cursor, codex, aider)",
+			},
+			&cli.StringFlag{
+				Name:    "scope",
+				Aliases: []string{"s"},
+				Usage:   "Only format these scopes (repo, user, admin, system, builtin, all). Comma-separated for multiple.",
+			},
+			&cli.BoolFlag{
+				Name:  "check",
+				Usage: "Report skills with outdated metrics without writing them, exiting non-zero if there are any",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			scopeFilter, err := parseScopeFilter(cmd.String("scope"))
+			if err != nil {
+				return err
+			}
+
+			platforms := model.AllPlatforms()
+			if name := cmd.String("platform"); name != "" {
+				platform, err := model.ParsePlatform(name)
+				if err != nil {
+					return fmt.Errorf("invalid platform: %w", err)
+				}
+				platforms = []model.Platform{platform}
+			}
+
+			var skills []model.Skill
+			for _, platform := range platforms {
+				found, err := parsePlatformSkillsWithScope(platform, scopeFilter, false)
+				if err != nil {
+					warnf("Warning: failed to parse %s: %v\n", platform, err)
+					continue
+				}
+				skills = append(skills, found...)
+			}
+
+			outdated, err := refreshSkillMetrics(skills, cmd.Bool("check"))
+			if err != nil {
+				return err
+			}
+
+			if cmd.Bool("check") {
+				for _, path := range outdated {
+					fmt.Printf("outdated: %s\n", path)
+				}
+				if len(outdated) > 0 {
+					return fmt.Errorf("%d skill file(s) have outdated metrics (run skillsync fmt)", len(outdated))
+				}
+				fmt.Println("All metrics are up to date")
+				return nil
+			}
+
+			for _, path := range outdated {
+				fmt.Printf("formatted: %s\n", path)
+			}
+			fmt.Printf("Updated metrics in %d skill file(s)\n", len(outdated))
+			return nil
+		},
+	}
+}
+
+// refreshSkillMetrics recomputes the metrics block of each skill file and
+// returns the paths whose metrics were out of date. With checkOnly, nothing
+// is written.
+func refreshSkillMetrics(skills []model.Skill, checkOnly bool) ([]string, error) {
+	var outdated []string
+	seen := make(map[string]bool)
+	for _, skill := range skills {
+		if skill.Scope == model.ScopePlugin || seen[skill.Path] {
+			continue
+		}
+		seen[skill.Path] = true
+
+		// #nosec G304 - skill paths come from discovery
+		content, err := os.ReadFile(skill.Path)
+		if err != nil {
+			return outdated, fmt.Errorf("failed to read %s: %w", skill.Path, err)
+		}
+		updated, ok := sync.RefreshMetrics(string(content))
+		if !ok || updated == string(content) {
+			continue
+		}
+
+		outdated = append(outdated, skill.Path)
+		if checkOnly {
+			continue
+		}
+		// #nosec G306 - skill files should be readable
+		if err := os.WriteFile(skill.Path, []byte(updated), 0o644); err != nil {
+			return outdated, fmt.Errorf("failed to write %s: %w", skill.Path, err)
+		}
+	}
+	return outdated, nil
+}
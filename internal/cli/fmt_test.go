@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestFmtCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, "claude", "skills")
+	skillPath := filepath.Join(claudeSkills, "lint", "SKILL.md")
+	util.WriteFile(t, skillPath, "---\nname: lint\ndescription: Run the linter\n---\nRun the linter now.\n")
+	util.WriteFile(t, filepath.Join(claudeSkills, "plain.md"), "No frontmatter here.\n")
+
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	args := []string{"skillsync", "fmt", "--platform", "claude-code", "--scope", "user"}
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), append(args, "--check"))
+	})
+	if runErr == nil || !strings.Contains(output, "outdated: "+skillPath) {
+		t.Fatalf("fmt --check should report the outdated skill, err = %v\n%s", runErr, output)
+	}
+
+	output = captureOutput(t, func() {
+		runErr = Run(context.Background(), args)
+	})
+	util.AssertNoError(t, runErr)
+	if !strings.Contains(output, "Updated metrics in 1 skill file(s)") {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+	content, err := os.ReadFile(skillPath)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(content), "---\nname: lint\ndescription: Run the linter\nskillsync-metrics:\n  words: 4\n  tokens: 5\n---\nRun the linter now.\n")
+
+	output = captureOutput(t, func() {
+		runErr = Run(context.Background(), append(args, "--check"))
+	})
+	if runErr != nil {
+		t.Errorf("fmt --check after fmt error = %v\n%s", runErr, output)
+	}
+}
@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/history"
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/versions"
+)
+
+func historyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "history",
+		Usage: "List, inspect, and undo past sync runs",
+		Description: `Every sync and delete run that changes files is recorded in
+   ~/.skillsync/history.jsonl with its source, target, strategy, timestamps,
+   and the action taken for each skill. Dry runs are not recorded. Syncs,
+   deletes, promotions, and conflict resolutions made in the TUI are recorded
+   too, tagged with the TUI session they belong to.
+
+   Examples:
+     skillsync history                        # List recent runs
+     skillsync history show <run-id>          # Show per-skill actions
+     skillsync history undo <run-id>          # Reverse the files a run changed`,
+		Commands: []*cli.Command{
+			historyListCommand(),
+			historyShowCommand(),
+			historyUndoCommand(),
+		},
+		Action: func(_ context.Context, _ *cli.Command) error {
+			return listHistory(20)
+		},
+	}
+}
+
+func historyListCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls"},
+		Usage:   "List recorded sync runs, newest first",
+		UsageText: `skillsync history list [options]
+   skillsync history list --limit 5
+   skillsync --output json history list`,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"n"},
+				Value:   20,
+				Usage:   "Show at most N runs (0 = unlimited)",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return listHistory(int(cmd.Int("limit")))
+		},
+	}
+}
+
+func historyShowCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "show",
+		Usage:     "Show the per-skill actions of a sync run",
+		ArgsUsage: "<run-id>",
+		UsageText: `skillsync history show <run-id>
+   skillsync history show 20240125-120000-1a2b3c4d
+   skillsync history show 20240125-1200          # unique ID prefix`,
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() < 1 {
+				return errors.New("run ID is required")
+			}
+			run, err := history.Get(cmd.Args().First())
+			if err != nil {
+				return err
+			}
+			return out.Render(run, func() error {
+				printHistoryRun(run)
+				return nil
+			})
+		},
+	}
+}
+
+func historyUndoCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "undo",
+		Usage:     "Restore the files a sync run overwrote or deleted and remove the ones it created",
+		ArgsUsage: "<run-id>",
+		UsageText: `skillsync history undo <run-id> [options]
+   skillsync history undo 20240125-120000-1a2b3c4d --dry-run
+   skillsync history undo 20240125-120000-1a2b3c4d --force`,
+		Description: `Reverse a recorded run, the same as skillsync undo <run-id>.
+
+   Runs made with --skip-backup cannot restore the files they overwrote.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"d"},
+				Usage:   "Show what would be restored and removed without changing files",
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Skip confirmation prompt",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() < 1 {
+				return errors.New("run ID is required")
+			}
+			run, err := history.Get(cmd.Args().First())
+			if err != nil {
+				return err
+			}
+			return undoRun(run, cmd.Bool("dry-run"), cmd.Bool("force"))
+		},
+	}
+}
+
+// recordHistory saves a finished run to the sync history and records the
+// content of the skills it wrote as new skill versions. Failing to record
+// never fails the sync itself.
+func recordHistory(id, command string, startedAt time.Time, results ...*sync.Result) {
+	if id == "" || len(results) == 0 {
+		return
+	}
+	if err := history.Record(history.NewRun(id, command, startedAt, results...)); err != nil {
+		logging.Warn("failed to record sync history", logging.Err(err))
+		warnf("Warning: failed to record sync history: %v\n", err)
+	}
+	if err := versions.RecordResults(id, results...); err != nil {
+		logging.Warn("failed to record skill versions", logging.Err(err))
+		warnf("Warning: failed to record skill versions: %v\n", err)
+	}
+}
+
+// listHistory prints the most recent runs.
+func listHistory(limit int) error {
+	runs, err := history.List()
+	if err != nil {
+		return err
+	}
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	if runs == nil {
+		runs = []history.Run{}
+	}
+
+	return out.Render(runs, func() error {
+		if len(runs) == 0 {
+			fmt.Println("No sync runs recorded.")
+			return nil
+		}
+
+		fmt.Printf("%s %s %s %s %s %s\n",
+			ui.Header(fmt.Sprintf("%-24s", "ID")),
+			ui.Header(fmt.Sprintf("%-7s", "COMMAND")),
+			ui.Header(fmt.Sprintf("%-28s", "SOURCE -> TARGET")),
+			ui.Header(fmt.Sprintf("%-19s", "STARTED")),
+			ui.Header(fmt.Sprintf("%-7s", "CHANGED")),
+			ui.Header("STATUS"))
+		fmt.Printf("%-24s %-7s %-28s %-19s %-7s %s\n", "--", "-------", "----------------", "-------", "-------", "------")
+
+		for _, run := range runs {
+			status := ui.Success("ok")
+			if !run.Success {
+				status = ui.Error("failed")
+			}
+			fmt.Printf("%-24s %-7s %-28s %-19s %-7d %s\n",
+				run.ID,
+				run.Command,
+				truncateCell(run.Source+" -> "+run.Target, 28),
+				run.StartedAt.Local().Format("2006-01-02 15:04:05"),
+				changedCount(run),
+				status)
+		}
+		return nil
+	})
+}
+
+// changedCount returns the number of skills a run wrote or deleted.
+func changedCount(run history.Run) int {
+	counts := run.Counts()
+	changed := 0
+	for _, action := range []sync.Action{sync.ActionCreated, sync.ActionUpdated, sync.ActionMerged, sync.ActionDeleted} {
+		changed += counts[string(action)]
+	}
+	return changed + counts[history.ActionRestored] + counts[history.ActionRemoved] + counts[history.ActionResolved]
+}
+
+// printHistoryRun prints the details of a recorded run.
+func printHistoryRun(run *history.Run) {
+	fmt.Printf("Run:      %s\n", run.ID)
+	fmt.Printf("Command:  %s\n", run.Command)
+	fmt.Printf("Source:   %s\n", run.Source)
+	fmt.Printf("Target:   %s\n", run.Target)
+	if run.Strategy != "" {
+		fmt.Printf("Strategy: %s\n", run.Strategy)
+	}
+	fmt.Printf("Started:  %s\n", run.StartedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Printf("Duration: %s\n", run.FinishedAt.Sub(run.StartedAt).Round(time.Millisecond))
+	if run.Undoes != "" {
+		fmt.Printf("Undoes:   %s\n", run.Undoes)
+	}
+	if run.Session != "" {
+		fmt.Printf("Session:  %s (TUI)\n", run.Session)
+	}
+
+	if len(run.Skills) > 0 {
+		fmt.Println("\nSkills:")
+		for _, skill := range run.Skills {
+			line := fmt.Sprintf("  %s: %s", skill.Name, skill.Action)
+			if skill.TargetPath != "" {
+				line += " -> " + skill.TargetPath
+			}
+			if skill.Error != "" {
+				line += " - Error: " + skill.Error
+			} else if skill.Message != "" {
+				line += " (" + skill.Message + ")"
+			}
+			fmt.Println(line)
+		}
+	}
+
+	if backups := run.BackupIDs(); len(backups) > 0 {
+		fmt.Printf("\nBackups: %s\n", strings.Join(backups, ", "))
+	}
+	if run.Command != history.CommandUndo {
+		fmt.Printf("\nUndo with: skillsync history undo %s\n", run.ID)
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/history"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestHistoryCommands(t *testing.T) {
+	tempDir := t.TempDir()
+	cursorSkills := filepath.Join(tempDir, "home", ".cursor", "skills")
+	claudeSkills := filepath.Join(tempDir, "home", ".claude", "skills")
+	targetFile := filepath.Join(claudeSkills, "lint", "SKILL.md")
+	original := "---\nname: lint\ndescription: old lint\n---\nOld body.\n"
+
+	util.WriteFile(t, filepath.Join(cursorSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: lint skill\n---\nRun the linter.\n")
+	util.WriteFile(t, targetFile, original)
+
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+
+	ctx := context.Background()
+	captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "sync", "--yes", "--skip-validation", "cursor:user", "claudecode:user"}); err != nil {
+			t.Errorf("sync failed: %v", err)
+		}
+		if err := Run(ctx, []string{"skillsync", "sync", "--dry-run", "--skip-validation", "cursor:user", "claudecode:user"}); err != nil {
+			t.Errorf("dry-run sync failed: %v", err)
+		}
+	})
+	if data, _ := os.ReadFile(targetFile); string(data) == original {
+		t.Fatal("sync did not update the target skill")
+	}
+
+	var runs []history.Run
+	output := captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "--output", "json", "history", "list"}); err != nil {
+			t.Errorf("history list failed: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(output), &runs); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, output)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("expected 1 recorded run (dry runs are not recorded), got %d", len(runs))
+	}
+	run := runs[0]
+	if run.Source != "cursor" || run.Target != "claude-code" || !run.Success || len(run.BackupIDs()) == 0 {
+		t.Errorf("unexpected run: %+v", run)
+	}
+
+	output = captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "history", "show", run.ID}); err != nil {
+			t.Errorf("history show failed: %v", err)
+		}
+	})
+	for _, want := range []string{run.ID, "lint: updated", "history undo"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("history show output missing %q:\n%s", want, output)
+		}
+	}
+
+	captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "history", "undo", "--force", run.ID}); err != nil {
+			t.Errorf("history undo failed: %v", err)
+		}
+	})
+	got, err := os.ReadFile(targetFile)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(got), original)
+
+	if err := Run(ctx, []string{"skillsync", "history", "show", "missing"}); err == nil {
+		t.Error("expected error for unknown run")
+	}
+}
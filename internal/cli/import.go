@@ -0,0 +1,640 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/export"
+	"github.com/klauern/skillsync/internal/gitfetch"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser/claudedesktop"
+	"github.com/klauern/skillsync/internal/parser/plugin"
+	skillsparser "github.com/klauern/skillsync/internal/parser/skills"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// importOutput is the JSON representation of an import run.
+type importOutput struct {
+	Source  string             `json:"source"`
+	Results []syncResultOutput `json:"results"`
+}
+
+func importCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "import",
+		Usage: "Import skills from a bundle, Git repository, URL, or stdin onto a platform",
+		UsageText: `skillsync import --bundle <file.tar.gz> [options] [target]
+   skillsync import --stdin (--yes | --dry-run) [options] [target]
+   skillsync import --git <url> [--ref <ref>] [--path <subdir>] [options] [target]
+   skillsync import --url <url> [options] [target]
+   skillsync import --claude-desktop <export> [options] [target]
+   skillsync import --bundle skills.tar.gz
+   skillsync import --bundle skills.tar.gz cursor
+   skillsync import --bundle skills.tar.gz codex:repo --skill review
+   skillsync import --git https://github.com/acme/skills --path skills cursor`,
+		Description: `Import skills from one of these sources:
+
+   --bundle  A bundle created with 'skillsync export --format bundle'
+   --git     A Git repository, shallow-cloned to a temporary directory. Gists
+             are Git repositories too (https://gist.github.com/<user>/<id>.git)
+   --url     A single skill file downloaded over HTTP(S); a gist page URL
+             downloads the gist's file
+   --claude-desktop
+             Custom instructions of Claude Desktop / claude.ai projects, from a
+             data export (Settings → Privacy → Export data): its projects.json,
+             the unzipped directory, or the zip. Use "app" to look in the Claude
+             Desktop data directory. Each project becomes a skill named after it.
+   --stdin   The output of 'skillsync export' piped on stdin, as JSON, YAML,
+             or a bundle (detected from the data). Since stdin carries the
+             skills, it cannot answer the confirmation prompt: use --yes or
+             --dry-run.
+
+   Git repositories are searched for platform skill directories (.claude/skills,
+   .cursor/skills, .codex/skills, ...) under --path. If none are found, a Claude
+   Code plugin repository is parsed as plugins; otherwise every SKILL.md under
+   --path is imported as a Claude Code skill.
+
+   Without a target, each skill is written to the platform it came from
+   (Claude Code for Claude Desktop projects). With a
+   target platform spec (platform[:scope]), given as the argument or with
+   --platform, every skill is transformed for and
+   written to that platform instead. The default scope is user.
+
+   Skills imported with --git or --url record where they came from in the
+   source_type, source_url, source_ref, source_commit, and source_path
+   frontmatter fields. 'skillsync discover' and 'skillsync skill show' show
+   the origin, and 'skillsync refresh <skill>' fetches the skill from it
+   again.
+
+   When validation.schema_path is set in the config, every imported skill's
+   frontmatter must satisfy that JSON Schema (skip with --skip-validation).
+
+   Existing skills with the same name are backed up before being replaced,
+   and conflicts are handled with --strategy just like sync.
+
+   Examples:
+     skillsync export --format bundle -o skills.tar.gz   # Create a bundle
+     skillsync import --bundle skills.tar.gz --dry-run   # Preview a restore
+     skillsync import --bundle skills.tar.gz cursor:repo # Restore into this repo's Cursor skills
+     skillsync import --git git@github.com:acme/skills.git --ref v1.2.0 claudecode
+     skillsync import --url https://gist.github.com/octocat/9f2c4e1a cursor
+     skillsync import --claude-desktop ~/Downloads/claude-export.zip cursor
+     skillsync export --format json | skillsync import --stdin --platform cursor --yes
+     ssh devbox skillsync export --format bundle | skillsync import --stdin --yes`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "bundle",
+				Aliases: []string{"b"},
+				Usage:   "Path to a tar.gz bundle created by export --format bundle",
+			},
+			&cli.StringFlag{
+				Name:  "git",
+				Usage: "Git repository URL to import skills from",
+			},
+			&cli.StringFlag{
+				Name:  "ref",
+				Usage: "Branch, tag, or commit to check out (with --git)",
+			},
+			&cli.StringFlag{
+				Name:  "path",
+				Usage: "Subdirectory of the repository to import from (with --git)",
+			},
+			&cli.StringFlag{
+				Name:  "url",
+				Usage: "HTTP(S) URL of a single skill file (or a gist page) to import",
+			},
+			&cli.StringFlag{
+				Name:  "claude-desktop",
+				Usage: "Claude data export (projects.json, export directory, or .zip), or \"app\" for the Claude Desktop data directory",
+			},
+			&cli.BoolFlag{
+				Name:  "stdin",
+				Usage: "Read skills exported as JSON, YAML, or a bundle from stdin",
+			},
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Usage:   "Target platform spec (platform[:scope]), instead of the target argument",
+			},
+			&cli.StringSliceFlag{
+				Name:  "skill",
+				Usage: "Only import the named skill (repeatable)",
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "Only import skills exported from this platform",
+			},
+			&cli.StringFlag{
+				Name:    "strategy",
+				Aliases: []string{"s"},
+				Value:   "overwrite",
+				Usage:   "Conflict resolution strategy: overwrite, skip, newer, merge, three-way",
+			},
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"d"},
+				Usage:   "Preview changes without modifying files",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-backup",
+				Usage: "Skip automatic backup before import",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-validation",
+				Usage: "Skip checking frontmatter against validation.schema_path",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Skip confirmation prompt",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return runImport(cmd)
+		},
+	}
+}
+
+func runImport(cmd *cli.Command) error {
+	if cmd.Args().Len() > 1 {
+		return errors.New("import accepts at most one target platform")
+	}
+
+	strategy := sync.Strategy(cmd.String("strategy"))
+	if !strategy.IsValid() || strategy == sync.StrategyInteractive {
+		return fmt.Errorf("invalid strategy %q (valid: overwrite, skip, newer, merge, three-way)", strategy)
+	}
+
+	target := cmd.Args().First()
+	if platform := cmd.String("platform"); platform != "" {
+		if target != "" {
+			return errors.New("give the target either as an argument or with --platform, not both")
+		}
+		target = platform
+	}
+	targetSpec, targetScope, err := parseImportTarget(target)
+	if err != nil {
+		return err
+	}
+
+	source, skills, err := loadImportSkills(cmd)
+	if err != nil {
+		return err
+	}
+
+	if from := cmd.String("from"); from != "" {
+		platform, err := model.ParsePlatform(from)
+		if err != nil {
+			return fmt.Errorf("invalid --from platform: %w", err)
+		}
+		skills = filterSkillsByPlatform(skills, platform)
+	}
+	if names := cmd.StringSlice("skill"); len(names) > 0 {
+		skills = filterSkillsByName(skills, names)
+	}
+	if len(skills) == 0 {
+		return fmt.Errorf("no matching skills in %s", source)
+	}
+	if !cmd.Bool("skip-validation") {
+		if err := validateImportSchema(skills); err != nil {
+			return err
+		}
+	}
+
+	groups, err := groupImportSkills(skills, targetSpec)
+	if err != nil {
+		return err
+	}
+
+	dryRun := cmd.Bool("dry-run")
+	if !dryRun && !cmd.Bool("yes") {
+		out.Printf("\nImporting %d skill(s) from %s:\n", len(skills), source)
+		for _, g := range groups {
+			out.Printf("  → %s (%s): %d skill(s)\n", g.target, targetScope, len(g.skills))
+		}
+		confirmed, err := confirmAction("Proceed with import?", riskLevelWarning)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Import cancelled by user")
+			return nil
+		}
+	}
+
+	results := make([]*sync.Result, 0, len(groups))
+	failed := false
+	for _, g := range groups {
+		if !dryRun && !skipBackup(cmd) {
+			created, err := backupExistingTargetSkills(g.target, targetScope, g.skills, "pre-import backup", []string{"import"})
+			if err != nil {
+				return err
+			}
+			if created > 0 {
+				out.Printf("✓ Created %d backup(s) for %s\n", created, g.target)
+			}
+		}
+
+		opts := sync.Options{
+			DryRun:      dryRun,
+			Strategy:    strategy,
+			TargetScope: targetScope,
+		}
+		result, err := sync.New().SyncWithSkills(g.skills, g.target, opts)
+		if err != nil {
+			return fmt.Errorf("import to %s failed: %w", g.target, err)
+		}
+		if !result.Success() {
+			failed = true
+		}
+		results = append(results, result)
+	}
+
+	output := importOutput{Source: source, Results: make([]syncResultOutput, 0, len(results))}
+	for _, result := range results {
+		output.Results = append(output.Results, newSyncResultOutput(result))
+	}
+	err = out.Render(output, func() error {
+		for _, result := range results {
+			fmt.Printf("\n%s\n", ui.Header(fmt.Sprintf("%s → %s", source, result.Target)))
+			printSyncResults(result)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return errors.New("import completed with errors")
+	}
+	return nil
+}
+
+// parseImportTarget parses the optional target platform spec of an import.
+// Without one, skills go to their own platform; the scope defaults to user.
+func parseImportTarget(arg string) (*model.PlatformSpec, model.SkillScope, error) {
+	var targetSpec *model.PlatformSpec
+	if arg != "" {
+		spec, err := model.ParsePlatformSpec(arg)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid target: %w", err)
+		}
+		if len(spec.Scopes) > 1 {
+			return nil, "", errors.New("import target accepts a single scope")
+		}
+		targetSpec = &spec
+	}
+	targetScope := model.ScopeUser
+	if targetSpec != nil && len(targetSpec.Scopes) == 1 {
+		targetScope = targetSpec.Scopes[0]
+	}
+	if targetScope != model.ScopeUser && targetScope != model.ScopeRepo {
+		return nil, "", fmt.Errorf("scope %q is not writable (only repo and user are supported)", targetScope)
+	}
+	return targetSpec, targetScope, nil
+}
+
+// validateImportSchema checks imported skills against the configured
+// frontmatter schema and fails, listing the violations, if any do not match.
+func validateImportSchema(skills []model.Skill) error {
+	appConfig, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	schema, err := loadFrontmatterSchema(appConfig)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	invalid := 0
+	for _, skill := range skills {
+		violations := schema.ValidateSkill(skill)
+		if len(violations) == 0 {
+			continue
+		}
+		if invalid == 0 {
+			out.Printf("Frontmatter does not match %s:\n", schema.Path())
+		}
+		invalid++
+		out.Printf("  %s: %s\n", skill.Name, strings.Join(violations, "; "))
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d skill(s) do not match the frontmatter schema", invalid)
+	}
+	return nil
+}
+
+// loadImportSkills reads skills from the import source selected by flags and
+// returns a label describing the source.
+func loadImportSkills(cmd *cli.Command) (string, []model.Skill, error) {
+	bundlePath := cmd.String("bundle")
+	gitURL := cmd.String("git")
+	fileURL := cmd.String("url")
+	desktopPath := cmd.String("claude-desktop")
+	stdin := cmd.Bool("stdin")
+
+	sources := 0
+	for _, source := range []string{bundlePath, gitURL, fileURL, desktopPath} {
+		if source != "" {
+			sources++
+		}
+	}
+	if stdin {
+		sources++
+	}
+	switch {
+	case sources > 1:
+		return "", nil, errors.New("only one of --bundle, --git, --url, --claude-desktop, and --stdin can be used")
+	case bundlePath != "":
+		return loadBundleSkills(bundlePath)
+	case gitURL != "":
+		return loadGitSkills(gitURL, cmd.String("ref"), cmd.String("path"))
+	case fileURL != "":
+		return loadURLSkills(fileURL)
+	case desktopPath != "":
+		return loadClaudeDesktopSkills(desktopPath)
+	case stdin:
+		if !cmd.Bool("yes") && !cmd.Bool("dry-run") {
+			return "", nil, errors.New("--stdin cannot be confirmed interactively (use --yes or --dry-run)")
+		}
+		return loadStdinSkills()
+	default:
+		return "", nil, errors.New("an import source is required (use --bundle, --git, --url, --claude-desktop, or --stdin)")
+	}
+}
+
+// loadStdinSkills reads skills piped on stdin in any format export can read
+// back.
+func loadStdinSkills() (string, []model.Skill, error) {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", nil, errors.New("--stdin reads exported skills from a pipe (e.g. skillsync export --format json | skillsync import --stdin --yes)")
+	}
+	format, skills, err := export.Read(os.Stdin)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read skills from stdin: %w", err)
+	}
+	out.Printf("Read %d skill(s) as %s from stdin\n", len(skills), format)
+	return "stdin", skills, nil
+}
+
+// loadBundleSkills reads skills from a bundle file.
+func loadBundleSkills(bundlePath string) (string, []model.Skill, error) {
+	// #nosec G304 - bundlePath is provided by user
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	manifest, skills, err := export.ReadBundle(file)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read bundle %s: %w", bundlePath, err)
+	}
+	out.Printf("Read %d skill(s) from bundle created %s\n", len(skills), manifest.CreatedAt.Format("2006-01-02 15:04:05"))
+	return bundlePath, skills, nil
+}
+
+// loadClaudeDesktopSkills reads Claude project instructions from a data
+// export, or from the Claude Desktop data directory when path is "app".
+func loadClaudeDesktopSkills(path string) (string, []model.Skill, error) {
+	if path == "app" {
+		path = ""
+	} else {
+		path = util.ExpandPath(path, "")
+	}
+	desktopParser := claudedesktop.New(path)
+	skills, err := desktopParser.Parse()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read Claude projects: %w", err)
+	}
+	out.Printf("Read %d project(s) with custom instructions\n", len(skills))
+	if len(skills) == 0 {
+		return "", nil, errors.New("no Claude projects with custom instructions found")
+	}
+	return desktopParser.Path(), skills, nil
+}
+
+// loadGitSkills shallow-clones a repository and parses skills under subdir,
+// recording the repository, ref, commit, and file of each as its origin.
+// The clone is removed before returning; skill content is kept in memory.
+func loadGitSkills(url, ref, subdir string) (string, []model.Skill, error) {
+	label := url
+	if ref != "" {
+		label += "@" + ref
+	}
+
+	out.Printf("Cloning %s...\n", label)
+	dir, cleanup, err := gitfetch.CloneTemp(url, gitfetch.Options{Ref: ref})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to clone %s: %w", url, err)
+	}
+	defer cleanup()
+
+	root := dir
+	if subdir != "" {
+		cleaned := filepath.Clean(subdir)
+		if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+			return "", nil, fmt.Errorf("--path must be relative to the repository: %s", subdir)
+		}
+		root = filepath.Join(dir, cleaned)
+		if stat, err := os.Stat(root); err != nil || !stat.IsDir() {
+			return "", nil, fmt.Errorf("path %q not found in %s", subdir, label)
+		}
+		label += "//" + filepath.ToSlash(cleaned)
+	}
+
+	skills, err := parseImportedRepoSkills(root)
+	if err != nil {
+		return "", nil, err
+	}
+
+	commit, err := gitfetch.Head(dir)
+	if err != nil {
+		stderrWarnf("Warning: could not read the commit of %s: %v\n", label, err)
+	}
+	for i, skill := range skills {
+		origin := model.Origin{Type: model.OriginGit, URL: url, Ref: ref, Commit: commit}
+		if rel, err := filepath.Rel(dir, skill.Path); err == nil && filepath.IsLocal(rel) {
+			origin.Path = filepath.ToSlash(rel)
+		}
+		skills[i] = skill.WithOrigin(origin)
+	}
+	return label, skills, nil
+}
+
+// loadURLSkills downloads a single skill file and records the URL as its
+// origin. The skill is named by its frontmatter, or after the file.
+func loadURLSkills(rawURL string) (string, []model.Skill, error) {
+	out.Printf("Downloading %s...\n", rawURL)
+	skill, err := fetchURLSkill(rawURL, model.ClaudeCode)
+	if err != nil {
+		return "", nil, err
+	}
+	return rawURL, []model.Skill{skill}, nil
+}
+
+// fetchURLSkill downloads and parses the skill file at rawURL for platform,
+// with rawURL recorded as its origin.
+func fetchURLSkill(rawURL string, platform model.Platform) (model.Skill, error) {
+	content, err := fetchURL(rawURL)
+	if err != nil {
+		return model.Skill{}, err
+	}
+	skill, err := skillsparser.ParseSkillContent(content, skillNameFromURL(rawURL), platform)
+	if err != nil {
+		return model.Skill{}, fmt.Errorf("failed to parse skill from %s: %w", rawURL, err)
+	}
+	// Name the download like a skill directory so each platform lays it out
+	// by skill name.
+	skill.Path = path.Join(skill.Name, "SKILL.md")
+	return skill.WithOrigin(model.Origin{Type: model.OriginURL, URL: rawURL}), nil
+}
+
+// maxURLSkillSize caps the size of a skill file downloaded with --url.
+const maxURLSkillSize = 1 << 20
+
+// urlFetchTimeout bounds a --url download.
+const urlFetchTimeout = 30 * time.Second
+
+// fetchURL downloads an HTTP(S) URL, reading gist pages from their raw file.
+func fetchURL(rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q: only http and https URLs are supported", rawURL)
+	}
+
+	client := &http.Client{Timeout: urlFetchTimeout}
+	resp, err := client.Get(rawGistURL(u).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", rawURL, resp.Status)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxURLSkillSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	if len(content) > maxURLSkillSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", rawURL, maxURLSkillSize)
+	}
+	return content, nil
+}
+
+// rawGistURL maps a gist page (https://gist.github.com/<user>/<id>) to the
+// raw content of its file. Other URLs are returned unchanged.
+func rawGistURL(u *url.URL) *url.URL {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if u.Host != "gist.github.com" || len(parts) != 2 {
+		return u
+	}
+	return &url.URL{Scheme: "https", Host: "gist.githubusercontent.com", Path: "/" + parts[0] + "/" + parts[1] + "/raw"}
+}
+
+// skillNameFromURL derives a skill name from the file a URL points to:
+// ".../review.md" and ".../review/SKILL.md" are both named review.
+func skillNameFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	p := strings.TrimSuffix(u.Path, "/")
+	name := path.Base(p)
+	if strings.EqualFold(name, "SKILL.md") || name == "raw" {
+		name = path.Base(path.Dir(p))
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(name, ".mdc"), ".md")
+}
+
+// parseImportedRepoSkills finds skills in a checked-out repository using the
+// parser that matches its layout: platform skill directories first, then
+// Claude Code plugin manifests, then bare SKILL.md files.
+func parseImportedRepoSkills(root string) ([]model.Skill, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var skills []model.Skill
+	for _, platform := range model.AllPlatforms() {
+		platformSkills, err := parseWorkspaceRepoSkills(cfg, platform, root)
+		if err != nil {
+			return nil, err
+		}
+		skills = append(skills, platformSkills...)
+	}
+	if len(skills) > 0 {
+		return skills, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(root, ".claude-plugin")); err == nil {
+		return plugin.New(root).Parse()
+	}
+
+	return skillsparser.New(root, model.ClaudeCode).Parse()
+}
+
+// importGroup is a set of skills written to one target platform.
+type importGroup struct {
+	target model.Platform
+	skills []model.Skill
+}
+
+// groupImportSkills groups skills by destination platform: the explicit target
+// when given, otherwise each skill's original platform. Skill names must be
+// unique within a destination.
+func groupImportSkills(skills []model.Skill, targetSpec *model.PlatformSpec) ([]importGroup, error) {
+	byTarget := make(map[model.Platform][]model.Skill)
+	seen := make(map[model.Platform]map[string]model.Platform)
+	for _, skill := range skills {
+		target := skill.Platform
+		if targetSpec != nil {
+			target = targetSpec.Platform
+		}
+		if seen[target] == nil {
+			seen[target] = make(map[string]model.Platform)
+		}
+		if prev, ok := seen[target][skill.Name]; ok {
+			return nil, fmt.Errorf("skill %q is in the bundle for both %s and %s (use --from to choose one)", skill.Name, prev, skill.Platform)
+		}
+		seen[target][skill.Name] = skill.Platform
+		byTarget[target] = append(byTarget[target], skill)
+	}
+
+	groups := make([]importGroup, 0, len(byTarget))
+	for target, groupSkills := range byTarget {
+		groups = append(groups, importGroup{target: target, skills: groupSkills})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].target < groups[j].target })
+	return groups, nil
+}
+
+// filterSkillsByPlatform returns skills from the given platform.
+func filterSkillsByPlatform(skills []model.Skill, platform model.Platform) []model.Skill {
+	filtered := make([]model.Skill, 0, len(skills))
+	for _, skill := range skills {
+		if skill.Platform == platform {
+			filtered = append(filtered, skill)
+		}
+	}
+	return filtered
+}
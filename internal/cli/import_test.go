@@ -0,0 +1,432 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestGroupImportSkills(t *testing.T) {
+	skills := []model.Skill{
+		{Name: "a", Platform: model.Cursor},
+		{Name: "b", Platform: model.ClaudeCode},
+		{Name: "a", Platform: model.ClaudeCode},
+	}
+
+	tests := map[string]struct {
+		skills  []model.Skill
+		target  *model.PlatformSpec
+		want    map[model.Platform]int
+		wantErr bool
+	}{
+		"original platforms": {
+			skills: skills,
+			want:   map[model.Platform]int{model.ClaudeCode: 2, model.Cursor: 1},
+		},
+		"explicit target": {
+			skills: skills[:2],
+			target: &model.PlatformSpec{Platform: model.Codex},
+			want:   map[model.Platform]int{model.Codex: 2},
+		},
+		"duplicate names into one target": {
+			skills:  skills,
+			target:  &model.PlatformSpec{Platform: model.Codex},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			groups, err := groupImportSkills(tt.skills, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("groupImportSkills() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(groups) != len(tt.want) {
+				t.Fatalf("expected %d groups, got %d", len(tt.want), len(groups))
+			}
+			for i, g := range groups {
+				if i > 0 && groups[i-1].target > g.target {
+					t.Errorf("groups not sorted by target")
+				}
+				if len(g.skills) != tt.want[g.target] {
+					t.Errorf("%s: expected %d skills, got %d", g.target, tt.want[g.target], len(g.skills))
+				}
+			}
+		})
+	}
+}
+
+func TestImportBundleCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, ".claude", "skills")
+	cursorSkills := filepath.Join(tempDir, ".cursor", "skills")
+	codexSkills := filepath.Join(tempDir, ".codex", "skills")
+	if err := os.MkdirAll(filepath.Join(claudeSkills, "review"), 0o750); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	skillFile := filepath.Join(claudeSkills, "review", "SKILL.md")
+	if err := os.WriteFile(skillFile, []byte("---\nname: review\ndescription: Review code\n---\nCheck tests."), 0o600); err != nil {
+		t.Fatalf("failed to write skill: %v", err)
+	}
+
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CODEX_SKILLS_PATHS", codexSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	t.Setenv("SKILLSYNC_CODEX_PATH", codexSkills)
+
+	ctx := context.Background()
+	bundle := filepath.Join(tempDir, "skills.tar.gz")
+
+	if err := Run(ctx, []string{"skillsync", "export", "--format", "bundle", "--platform", "claude-code", "--output", bundle}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		output := captureOutput(t, func() {
+			if err := Run(ctx, []string{"skillsync", "import", "--bundle", bundle, "--dry-run", "cursor"}); err != nil {
+				t.Errorf("import --dry-run failed: %v", err)
+			}
+		})
+		if !strings.Contains(output, "review") {
+			t.Errorf("dry run output missing skill:\n%s", output)
+		}
+		if _, err := os.Stat(cursorSkills); !os.IsNotExist(err) {
+			t.Errorf("dry run created %s", cursorSkills)
+		}
+	})
+
+	t.Run("restore onto another platform", func(t *testing.T) {
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(ctx, []string{"skillsync", "--output", "json", "import", "--bundle", bundle, "--yes", "codex"})
+		})
+		if runErr != nil {
+			t.Fatalf("import failed: %v", runErr)
+		}
+
+		var result importOutput
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, output)
+		}
+		if len(result.Results) != 1 || result.Results[0].Target != string(model.Codex) {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+
+		data, err := os.ReadFile(filepath.Join(codexSkills, "review", "SKILL.md"))
+		if err != nil {
+			t.Fatalf("imported skill not written: %v", err)
+		}
+		if !strings.Contains(string(data), "Check tests.") || !strings.Contains(string(data), "description: Review code") {
+			t.Errorf("unexpected imported content:\n%s", data)
+		}
+	})
+
+	t.Run("frontmatter schema", func(t *testing.T) {
+		schemaPath := filepath.Join(tempDir, "schema.json")
+		if err := os.WriteFile(schemaPath, []byte(`{"required": ["owner"]}`), 0o600); err != nil {
+			t.Fatalf("failed to write schema: %v", err)
+		}
+		t.Setenv("SKILLSYNC_VALIDATION_SCHEMA_PATH", schemaPath)
+
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(ctx, []string{"skillsync", "import", "--bundle", bundle, "--dry-run", "cursor"})
+		})
+		if runErr == nil || !strings.Contains(output, "owner: required field is missing") {
+			t.Errorf("expected schema violation, got err = %v\n%s", runErr, output)
+		}
+
+		captureOutput(t, func() {
+			runErr = Run(ctx, []string{"skillsync", "import", "--bundle", bundle, "--dry-run", "--skip-validation", "cursor"})
+		})
+		if runErr != nil {
+			t.Errorf("import --skip-validation failed: %v", runErr)
+		}
+	})
+
+	t.Run("unknown skill filter", func(t *testing.T) {
+		err := Run(ctx, []string{"skillsync", "import", "--bundle", bundle, "--skill", "missing", "--yes"})
+		if err == nil {
+			t.Error("expected error when no skills match")
+		}
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		if err := Run(ctx, []string{"skillsync", "import", "cursor"}); err == nil {
+			t.Error("expected error without --bundle")
+		}
+	})
+}
+
+// newImportGitRepo creates a committed Git repository from relative path/content pairs.
+func newImportGitRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	repo := t.TempDir()
+	for rel, content := range files {
+		path := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", rel, err)
+		}
+	}
+	for _, args := range [][]string{{"init", "-q"}, {"add", "."}, {"commit", "-q", "-m", "skills"}} {
+		if output, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	return "file://" + repo
+}
+
+func TestImportGitCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	codexSkills := filepath.Join(tempDir, ".codex", "skills")
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CODEX_PATH", codexSkills)
+	t.Setenv("SKILLSYNC_CODEX_SKILLS_PATHS", codexSkills)
+
+	ctx := context.Background()
+
+	t.Run("platform directories", func(t *testing.T) {
+		url := newImportGitRepo(t, map[string]string{
+			".cursor/skills/lint/SKILL.md": "---\nname: lint\ndescription: Lint code\n---\nRun the linter.",
+		})
+		if err := Run(ctx, []string{"skillsync", "import", "--git", url, "--yes", "codex"}); err != nil {
+			t.Fatalf("import --git failed: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(codexSkills, "lint", "SKILL.md"))
+		if err != nil {
+			t.Fatalf("imported skill not written: %v", err)
+		}
+		if !strings.Contains(string(data), "Run the linter.") {
+			t.Errorf("unexpected content:\n%s", data)
+		}
+		for _, want := range []string{"source_type: git", "source_url: " + url, "source_path: .cursor/skills/lint/SKILL.md", "source_commit: "} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("imported skill missing origin %q:\n%s", want, data)
+			}
+		}
+	})
+
+	t.Run("bare SKILL.md files under path", func(t *testing.T) {
+		url := newImportGitRepo(t, map[string]string{
+			"collection/docs/SKILL.md": "---\nname: docs\ndescription: Write docs\n---\nDocument it.",
+			"other/skip/SKILL.md":      "---\nname: skip\ndescription: Skip me\n---\nNo.",
+		})
+		output := captureOutput(t, func() {
+			if err := Run(ctx, []string{"skillsync", "import", "--git", url, "--path", "collection", "--dry-run", "codex"}); err != nil {
+				t.Errorf("import --git --dry-run failed: %v", err)
+			}
+		})
+		if !strings.Contains(output, "docs") || strings.Contains(output, "skip") {
+			t.Errorf("unexpected dry run output:\n%s", output)
+		}
+	})
+
+	t.Run("path outside repository", func(t *testing.T) {
+		url := newImportGitRepo(t, map[string]string{"README.md": "x"})
+		if err := Run(ctx, []string{"skillsync", "import", "--git", url, "--path", "../..", "codex"}); err == nil {
+			t.Error("expected error for --path outside repository")
+		}
+	})
+
+	t.Run("both sources", func(t *testing.T) {
+		if err := Run(ctx, []string{"skillsync", "import", "--git", "x", "--bundle", "y"}); err == nil {
+			t.Error("expected error for --git with --bundle")
+		}
+	})
+}
+
+func TestImportURLCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	cursorSkills := filepath.Join(tempDir, ".cursor", "skills")
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/skills/review/SKILL.md" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("---\ndescription: Review code\n---\nReview the change."))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	t.Run("single file", func(t *testing.T) {
+		skillURL := server.URL + "/skills/review/SKILL.md"
+		captureOutput(t, func() {
+			if err := Run(ctx, []string{"skillsync", "import", "--url", skillURL, "--yes", "--skip-backup", "cursor"}); err != nil {
+				t.Errorf("import --url failed: %v", err)
+			}
+		})
+		data, err := os.ReadFile(filepath.Join(cursorSkills, "review.md"))
+		if err != nil {
+			t.Fatalf("imported skill not written: %v", err)
+		}
+		for _, want := range []string{"Review the change.", "source_type: url", "source_url: " + skillURL} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("imported skill missing %q:\n%s", want, data)
+			}
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if err := Run(ctx, []string{"skillsync", "import", "--url", server.URL + "/missing.md", "--yes", "cursor"}); err == nil {
+			t.Error("expected error for missing file")
+		}
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		if err := Run(ctx, []string{"skillsync", "import", "--url", "file:///etc/passwd", "--yes", "cursor"}); err == nil {
+			t.Error("expected error for file URL")
+		}
+	})
+}
+
+func TestSkillNameFromURL(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want string
+	}{
+		"markdown file":    {url: "https://example.com/skills/review.md", want: "review"},
+		"cursor rule":      {url: "https://example.com/rules/lint.mdc", want: "lint"},
+		"skill directory":  {url: "https://example.com/skills/review/SKILL.md", want: "review"},
+		"raw gist":         {url: "https://gist.githubusercontent.com/user/abc123/raw", want: "abc123"},
+		"trailing slash":   {url: "https://example.com/skills/review.md/", want: "review"},
+		"query is ignored": {url: "https://example.com/review.md?token=x", want: "review"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, skillNameFromURL(tt.url), tt.want)
+		})
+	}
+}
+
+func TestRawGistURL(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want string
+	}{
+		"gist page":     {url: "https://gist.github.com/user/abc123", want: "https://gist.githubusercontent.com/user/abc123/raw"},
+		"gist git url":  {url: "https://gist.github.com/user/abc123.git/x", want: "https://gist.github.com/user/abc123.git/x"},
+		"other host":    {url: "https://example.com/user/abc123", want: "https://example.com/user/abc123"},
+		"raw gist file": {url: "https://gist.githubusercontent.com/user/abc123/raw", want: "https://gist.githubusercontent.com/user/abc123/raw"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, rawGistURL(u).String(), tt.want)
+		})
+	}
+}
+
+func TestImportClaudeDesktopCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	cursorSkills := filepath.Join(tempDir, ".cursor", "skills")
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+
+	projects := filepath.Join(tempDir, "projects.json")
+	if err := os.WriteFile(projects, []byte(`[{"name": "Release Notes", "description": "Draft release notes", "prompt_template": "Group changes by area."}]`), 0o600); err != nil {
+		t.Fatalf("failed to write projects: %v", err)
+	}
+
+	ctx := context.Background()
+	captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "import", "--claude-desktop", projects, "--yes", "--skip-backup", "cursor"}); err != nil {
+			t.Errorf("import failed: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(filepath.Join(cursorSkills, "release-notes.md"))
+	if err != nil {
+		t.Fatalf("imported skill not written: %v", err)
+	}
+	if !strings.Contains(string(data), "Group changes by area.") || !strings.Contains(string(data), "description: Draft release notes") {
+		t.Errorf("unexpected imported content:\n%s", data)
+	}
+
+	err = Run(ctx, []string{"skillsync", "import", "--claude-desktop", projects, "--git", "https://example.com/x.git"})
+	if err == nil || !strings.Contains(err.Error(), "only one of") {
+		t.Errorf("expected conflicting sources error, got %v", err)
+	}
+}
+
+func TestImportStdinCommand(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		format   string
+		args     []string
+		wantFile string // the imported skill, relative to the Cursor skills directory
+		wantErr  string
+	}{
+		"json":             {format: "json", args: []string{"--platform", "cursor", "--yes"}, wantFile: "review/SKILL.md"},
+		"yaml":             {format: "yaml", args: []string{"--platform", "cursor", "--yes"}, wantFile: "review/SKILL.md"},
+		"bundle":           {format: "bundle", args: []string{"--yes", "cursor"}, wantFile: "review.md"},
+		"without --yes":    {format: "json", args: []string{"--platform", "cursor"}, wantErr: "use --yes or --dry-run"},
+		"two targets":      {format: "json", args: []string{"--platform", "cursor", "--yes", "codex"}, wantErr: "not both"},
+		"with another one": {format: "json", args: []string{"--bundle", "x", "--yes"}, wantErr: "only one of"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, cursorSkills := setupStore(t)
+			util.WriteFile(t, filepath.Join(claudeSkills, "review", "SKILL.md"), "---\nname: review\ndescription: Review code\n---\nCheck tests.\n")
+
+			exported := filepath.Join(t.TempDir(), "skills."+tt.format)
+			util.AssertNoError(t, Run(ctx, []string{"skillsync", "export", "--format", tt.format, "--platform", "claude-code", "--output", exported}))
+			data, err := os.ReadFile(exported)
+			util.AssertNoError(t, err)
+			withStdin(t, string(data))
+
+			var runErr error
+			captureOutput(t, func() {
+				runErr = Run(ctx, append([]string{"skillsync", "import", "--stdin"}, tt.args...))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("import --stdin: error = %v, want %q", runErr, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, runErr)
+			imported, err := os.ReadFile(filepath.Join(cursorSkills, tt.wantFile))
+			util.AssertNoError(t, err)
+			if !strings.Contains(string(imported), "Check tests.") || !strings.Contains(string(imported), "description: Review code") {
+				t.Errorf("unexpected imported content:\n%s", imported)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/gitfetch"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// samplesScopeSandbox installs the sample skills into the sandbox, a
+// repository of its own in the skillsync home, instead of a real scope.
+const samplesScopeSandbox = "sandbox"
+
+// sampleSkill is an example skill installed by init --with-samples.
+type sampleSkill struct {
+	Name    string
+	Content string
+}
+
+// sampleSkills are the skills init --with-samples installs. Each shows a
+// well-structured skill: frontmatter with a name and a description saying
+// when to use it, then sections with instructions.
+var sampleSkills = []sampleSkill{
+	{
+		Name: "commit-message",
+		Content: `---
+name: commit-message
+description: Write a Conventional Commits message for the staged changes. Use when committing or when asked to describe a change.
+allowed-tools:
+  - Bash
+  - Read
+---
+
+# commit-message
+
+## When to use
+
+Use this skill when the user asks for a commit, or to summarize what the
+staged changes do.
+
+## Instructions
+
+1. Run ` + "`git diff --staged`" + ` and read the whole diff.
+2. Pick a type: feat, fix, refactor, docs, test, or chore.
+3. Write a subject of at most 72 characters in the imperative mood, with an
+   optional scope: ` + "`fix(parser): handle empty frontmatter`" + `.
+4. In the body, explain why the change was made, not how.
+5. Note breaking changes in a ` + "`BREAKING CHANGE:`" + ` footer.
+`,
+	},
+	{
+		Name: "code-review",
+		Content: `---
+name: code-review
+description: Review a change for correctness, readability, and tests. Use when asked to review a diff, branch, or pull request.
+---
+
+# code-review
+
+## When to use
+
+Use this skill to review a diff, a branch, or a pull request before it is
+merged.
+
+## Instructions
+
+1. Read the description of the change first, then the diff.
+2. Check that the change does what it says, including edge cases and errors.
+3. Check that names, comments, and structure match the surrounding code.
+4. Check that new behavior has tests, and that the tests would fail without it.
+5. Group your comments by severity: blocking, suggestion, and nit.
+
+## Checklist
+
+- [ ] Errors are handled or returned, not ignored
+- [ ] No secrets, credentials, or debug output are added
+- [ ] Public behavior changes are documented
+`,
+	},
+	{
+		Name: "write-tests",
+		Content: `---
+name: write-tests
+description: Write focused unit tests for a function or change. Use when adding behavior or fixing a bug without a failing test.
+---
+
+# write-tests
+
+## When to use
+
+Use this skill when new code has no tests, or to reproduce a bug with a
+failing test before fixing it.
+
+## Instructions
+
+1. Find the project's existing tests and follow their layout and helpers.
+2. List the cases: the normal path, boundaries, and each error.
+3. Prefer one table-driven test with a named case per behavior.
+4. Assert on behavior the caller sees, not on implementation details.
+5. Run the tests and make sure a new test fails before the fix.
+`,
+	},
+}
+
+// sampleDrift is an older copy of a sample skill that init puts on a second
+// platform in the sandbox, so that compare and sync have a difference to show.
+var sampleDrift = sampleSkill{
+	Name: "commit-message",
+	Content: `---
+name: commit-message
+description: Write a commit message for the staged changes.
+---
+
+# commit-message
+
+Read ` + "`git diff --staged`" + ` and write a short commit message describing it.
+`,
+}
+
+// initOutput is the JSON representation of what init set up.
+type initOutput struct {
+	ConfigPath    string         `json:"config_path"`
+	ConfigCreated bool           `json:"config_created"`
+	Samples       *samplesOutput `json:"samples,omitempty"`
+}
+
+// samplesOutput is the JSON representation of the sample skills init installed.
+type samplesOutput struct {
+	Scope     string   `json:"scope"`
+	Sandbox   string   `json:"sandbox,omitempty"`
+	Installed []string `json:"installed"`
+	Skipped   []string `json:"skipped,omitempty"`
+}
+
+func initCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "init",
+		Usage:     "Set up skillsync, optionally with sample skills to explore",
+		UsageText: "skillsync init [--with-samples [--scope sandbox|user] [--platform PLATFORM] [--yes]]",
+		Description: `Create the config file, ~/.skillsync/config.yaml, if it does not exist
+   yet. An existing config file is left as it is.
+
+   --with-samples installs a few example skills (commit-message, code-review,
+   and write-tests) to try discover, sync, and compare on. By default they go
+   into the sandbox, ~/.skillsync/sandbox: a Git repository of its own, so
+   the samples are in its repo scope and never reach your real skills. The
+   samples are written for --platform, and an older copy of commit-message
+   for a second platform, giving sync and compare a difference to show.
+
+   --scope user installs the samples into the platform's user scope instead,
+   where your tools pick them up, after asking for confirmation. Existing
+   skills of the same name are never overwritten.
+
+   Examples:
+     skillsync init
+     skillsync init --with-samples
+     cd ~/.skillsync/sandbox && skillsync discover --scope repo
+     skillsync init --with-samples --scope user --platform cursor`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "with-samples",
+				Usage: "Install sample skills to explore",
+			},
+			&cli.StringFlag{
+				Name:  "scope",
+				Value: samplesScopeSandbox,
+				Usage: "Where to install the samples: sandbox or user",
+			},
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Value:   string(model.ClaudeCode),
+				Usage:   "Platform to install the samples for (claude-code, cursor, codex, aider)",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Install into the user scope without asking",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return runInit(cmd)
+		},
+	}
+}
+
+// runInit creates the config file when missing and installs the sample
+// skills when asked to.
+func runInit(cmd *cli.Command) error {
+	withSamples := cmd.Bool("with-samples")
+	if !withSamples && (cmd.IsSet("scope") || cmd.IsSet("platform")) {
+		return errors.New("--scope and --platform require --with-samples")
+	}
+	platform, err := model.ParsePlatform(cmd.String("platform"))
+	if err != nil {
+		return fmt.Errorf("invalid platform: %w", err)
+	}
+	scope := cmd.String("scope")
+	if scope != samplesScopeSandbox && scope != string(model.ScopeUser) {
+		return fmt.Errorf("invalid --scope %q: samples go into the sandbox or the user scope", scope)
+	}
+
+	result := initOutput{ConfigPath: config.FilePath()}
+	if !config.Exists() {
+		if err := config.Default().Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		result.ConfigCreated = true
+	}
+
+	if withSamples {
+		var samples *samplesOutput
+		if scope == samplesScopeSandbox {
+			samples, err = installSandboxSamples(platform)
+		} else {
+			samples, err = installUserSamples(platform, cmd.Bool("yes"))
+		}
+		if err != nil {
+			return err
+		}
+		result.Samples = samples
+	}
+
+	return out.Render(result, func() error {
+		if result.ConfigCreated {
+			fmt.Printf("✓ Created config file: %s\n", result.ConfigPath)
+		} else {
+			fmt.Printf("Config file already exists: %s\n", result.ConfigPath)
+		}
+		if result.Samples != nil {
+			printSamples(*result.Samples, platform)
+		}
+		return nil
+	})
+}
+
+// sandboxPath returns the sandbox repository init --with-samples installs
+// the samples into.
+func sandboxPath() string {
+	return filepath.Join(util.Paths().SkillsyncHome(), samplesScopeSandbox)
+}
+
+// installSandboxSamples installs the samples into the repo scope of platform
+// in the sandbox, creating the sandbox repository if needed, and the older
+// copy of one on a second platform.
+func installSandboxSamples(platform model.Platform) (*samplesOutput, error) {
+	if err := util.Paths().CheckSkillsyncHome(); err != nil {
+		return nil, err
+	}
+	dir := sandboxPath()
+	// #nosec G301 - the sandbox is a repository the user works in
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create sandbox: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		// The repo scope is found by its .git directory
+		if err := gitfetch.Init(dir); err != nil {
+			return nil, fmt.Errorf("failed to create sandbox repository %s: %w (use --scope user to install the samples without one)", dir, err)
+		}
+	}
+
+	samples := &samplesOutput{Scope: samplesScopeSandbox, Sandbox: dir, Installed: []string{}}
+	if err := writeSamples(samples, util.RepoSkillsPath(platform, dir), sampleSkills); err != nil {
+		return nil, err
+	}
+	if err := writeSamples(samples, util.RepoSkillsPath(driftPlatform(platform), dir), []sampleSkill{sampleDrift}); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// driftPlatform is the second platform of the sandbox, which gets the older
+// copy of a sample.
+func driftPlatform(platform model.Platform) model.Platform {
+	if platform == model.Cursor {
+		return model.ClaudeCode
+	}
+	return model.Cursor
+}
+
+// installUserSamples installs the samples into the user scope of platform
+// after confirmation.
+func installUserSamples(platform model.Platform, yes bool) (*samplesOutput, error) {
+	root := util.Paths().UserSkillsPath(platform)
+	if !yes {
+		confirmed, err := confirmAction(
+			fmt.Sprintf("Install %d sample skills into %s (%s)?", len(sampleSkills), platform, root), riskLevelWarning)
+		if err != nil {
+			return nil, fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			return nil, errors.New("sample skills not installed")
+		}
+	}
+
+	samples := &samplesOutput{Scope: string(model.ScopeUser), Installed: []string{}}
+	if err := writeSamples(samples, root, sampleSkills); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// writeSamples writes each sample as <root>/<name>/SKILL.md, skipping those
+// that would replace an existing skill.
+func writeSamples(samples *samplesOutput, root string, skills []sampleSkill) error {
+	for _, sample := range skills {
+		path := filepath.Join(root, sample.Name, "SKILL.md")
+		if _, err := os.Stat(filepath.Dir(path)); err == nil {
+			samples.Skipped = append(samples.Skipped, path)
+			continue
+		}
+		// #nosec G301 - skill directories need to be readable by the platform
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return fmt.Errorf("failed to create skill directory: %w", err)
+		}
+		// #nosec G306 - skill files should be readable
+		if err := os.WriteFile(path, []byte(sample.Content), 0o644); err != nil {
+			return fmt.Errorf("failed to write sample skill: %w", err)
+		}
+		samples.Installed = append(samples.Installed, path)
+	}
+	return nil
+}
+
+// printSamples prints the installed samples and what to try with them.
+func printSamples(samples samplesOutput, platform model.Platform) {
+	fmt.Printf("✓ Installed %d sample skill file(s)\n", len(samples.Installed))
+	for _, path := range samples.Installed {
+		fmt.Printf("  %s\n", path)
+	}
+	for _, path := range samples.Skipped {
+		fmt.Printf("  %s\n", ui.Dim(path+" (exists, kept)"))
+	}
+
+	fmt.Println("\nNext steps:")
+	if samples.Scope == samplesScopeSandbox {
+		other := driftPlatform(platform)
+		steps := []string{
+			"cd " + samples.Sandbox,
+			"skillsync discover --scope repo",
+			"skillsync compare",
+			fmt.Sprintf("skillsync sync --dry-run %s:repo %s:repo", platform, other),
+		}
+		fmt.Println("  " + strings.Join(steps, "\n  "))
+		return
+	}
+	fmt.Printf("  skillsync discover --platform %s --scope user\n", platform)
+	fmt.Printf("  skillsync sync --dry-run %s <other-platform>\n", platform)
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSampleSkills(t *testing.T) {
+	for _, sample := range append(sampleSkills, sampleDrift) {
+		t.Run(sample.Name, func(t *testing.T) {
+			util.AssertNoError(t, validateRenderedSkill([]byte(sample.Content), sample.Name, model.ClaudeCode))
+		})
+	}
+}
+
+func TestInit(t *testing.T) {
+	tests := map[string]struct {
+		args       []string
+		stdin      string
+		needsGit   bool
+		wantErr    string
+		wantOutput string
+		wantFiles  []string // relative to the skillsync home, or ~ for the Claude Code user skills
+	}{
+		"config only": {
+			wantOutput: "Created config file",
+		},
+		"sandbox samples": {
+			args:       []string{"--with-samples"},
+			needsGit:   true,
+			wantOutput: "skillsync sync --dry-run claude-code:repo cursor:repo",
+			wantFiles: []string{
+				"sandbox/.git",
+				"sandbox/.claude/skills/code-review/SKILL.md",
+				"sandbox/.claude/skills/write-tests/SKILL.md",
+				"sandbox/.cursor/skills/commit-message/SKILL.md",
+			},
+		},
+		"sandbox samples for cursor": {
+			args:      []string{"--with-samples", "--platform", "cursor"},
+			needsGit:  true,
+			wantFiles: []string{"sandbox/.cursor/skills/code-review/SKILL.md", "sandbox/.claude/skills/commit-message/SKILL.md"},
+		},
+		"user samples when confirmed": {
+			args:       []string{"--with-samples", "--scope", "user"},
+			stdin:      "y\n",
+			wantOutput: "Install 3 sample skills into claude-code",
+			wantFiles:  []string{"~/code-review/SKILL.md", "~/commit-message/SKILL.md"},
+		},
+		"user samples declined": {
+			args:    []string{"--with-samples", "--scope", "user"},
+			stdin:   "n\n",
+			wantErr: "sample skills not installed",
+		},
+		"scope without samples": {
+			args:    []string{"--scope", "user"},
+			wantErr: "--scope and --platform require --with-samples",
+		},
+		"invalid scope": {
+			args:    []string{"--with-samples", "--scope", "repo"},
+			wantErr: `invalid --scope "repo"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := exec.LookPath("git"); err != nil && tt.needsGit {
+				t.Skip("git not available")
+			}
+			claudeSkills, _ := setupStore(t)
+			if tt.stdin != "" {
+				withStdin(t, tt.stdin)
+			}
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync", "init"}, tt.args...))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("init error = %v, want %q\n%s", runErr, tt.wantErr, output)
+				}
+				return
+			}
+			util.AssertNoError(t, runErr)
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output missing %q:\n%s", tt.wantOutput, output)
+			}
+			if !config.Exists() {
+				t.Errorf("init did not create %s", config.FilePath())
+			}
+			for _, file := range tt.wantFiles {
+				path := filepath.Join(util.Paths().SkillsyncHome(), file)
+				if rel, ok := strings.CutPrefix(file, "~/"); ok {
+					path = filepath.Join(claudeSkills, rel)
+				}
+				if !pathExists(path) {
+					t.Errorf("init did not create %s:\n%s", path, output)
+				}
+			}
+		})
+	}
+}
+
+func TestInit_KeepsExisting(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	util.WriteFile(t, config.FilePath(), "sync:\n  default_strategy: newer\n")
+	mine := filepath.Join(claudeSkills, "code-review", "SKILL.md")
+	util.WriteFile(t, mine, "---\nname: code-review\ndescription: Mine\n---\nMy review.\n")
+
+	output := captureOutput(t, func() {
+		util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "init", "--with-samples", "--scope", "user", "--yes"}))
+	})
+	if !strings.Contains(output, "Config file already exists") || !strings.Contains(output, "(exists, kept)") {
+		t.Errorf("output does not report the existing config and skill:\n%s", output)
+	}
+	cfg, err := config.Load()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, cfg.Sync.DefaultStrategy, "newer")
+
+	skill, err := resolveSkill("code-review", "claudecode")
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, skill.Description, "Mine")
+}
@@ -0,0 +1,325 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/export"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// inspectOutput is the JSON representation of an inspected export.
+type inspectOutput struct {
+	Source    string               `json:"source"`
+	Format    string               `json:"format"`
+	CreatedAt string               `json:"created_at,omitempty"`
+	Skills    []inspectSkillOutput `json:"skills"`
+	Removed   []export.Tombstone   `json:"removed,omitempty"`
+	Summary   inspectSummary       `json:"summary"`
+}
+
+// inspectSkillOutput describes one skill of an inspected export and what
+// importing it would do.
+type inspectSkillOutput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Platform    string `json:"platform"`
+	Target      string `json:"target"`
+	Size        int    `json:"size"`
+	// Exists is set when the target already has a skill with this name
+	Exists bool `json:"exists"`
+	// Action is what an import would do: created, updated, unchanged, ...
+	Action   string   `json:"action"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// inspectSummary counts the skills of an inspected export.
+type inspectSummary struct {
+	Skills    int            `json:"skills"`
+	New       int            `json:"new"`
+	Overlaps  int            `json:"overlaps"`
+	Conflicts int            `json:"conflicts"`
+	Problems  int            `json:"problems"`
+	Actions   map[string]int `json:"actions"`
+}
+
+func inspectCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "inspect",
+		Usage:     "Analyze an exported bundle or JSON export without importing it",
+		UsageText: "skillsync inspect [options] <bundle|export.json> [target]",
+		Description: `List the skills of a bundle (export --format bundle) or JSON export
+   (export --format json), check that it is well formed, and estimate what
+   'skillsync import' would change: which skills are new, which overlap
+   skills you already have, and which would be updated, skipped, or left in
+   conflict. Nothing is written.
+
+   The bundle is read in full first: a corrupt archive, a missing or
+   unsupported manifest, unsafe paths, or checksum mismatches are reported
+   as errors. Skills that would not import cleanly (empty content, frontmatter
+   that does not match validation.schema_path, or a failed transform) are
+   listed as problems.
+
+   The target and --strategy mean the same as for import, so the estimate
+   matches what the same import would do.
+
+   Examples:
+     skillsync inspect skills.tar.gz
+     skillsync inspect skills.tar.gz cursor:repo
+     skillsync inspect --strategy three-way team-skills.json claudecode
+     skillsync --output json inspect skills.tar.gz`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "strategy",
+				Aliases: []string{"s"},
+				Value:   "overwrite",
+				Usage:   "Conflict resolution strategy the estimate assumes: overwrite, skip, newer, merge, three-way",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return runInspect(cmd)
+		},
+	}
+}
+
+func runInspect(cmd *cli.Command) error {
+	if cmd.Args().Len() < 1 || cmd.Args().Len() > 2 {
+		return errors.New("inspect requires a bundle or JSON export and at most one target platform")
+	}
+	path := cmd.Args().Get(0)
+
+	strategy := sync.Strategy(cmd.String("strategy"))
+	if !strategy.IsValid() || strategy == sync.StrategyInteractive {
+		return fmt.Errorf("invalid strategy %q (valid: overwrite, skip, newer, merge, three-way)", strategy)
+	}
+	targetSpec, targetScope, err := parseImportTarget(cmd.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	output, skills, err := readInspectFile(path)
+	if err != nil {
+		return err
+	}
+	groups, err := groupImportSkills(skills, targetSpec)
+	if err != nil {
+		return fmt.Errorf("%s cannot be imported as is: %w", path, err)
+	}
+
+	problems, err := inspectProblems(skills)
+	if err != nil {
+		return err
+	}
+
+	output.Summary.Actions = make(map[string]int)
+	for _, g := range groups {
+		existing, err := parsePlatformSkillsWithScope(g.target, []model.SkillScope{targetScope}, false)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s skills: %w", g.target, err)
+		}
+		exists := make(map[string]bool, len(existing))
+		for _, skill := range existing {
+			exists[skill.Name] = true
+		}
+
+		result, err := sync.New().SyncWithSkills(g.skills, g.target, sync.Options{
+			DryRun:      true,
+			Strategy:    strategy,
+			TargetScope: targetScope,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to estimate import to %s: %w", g.target, err)
+		}
+		actions := make(map[string]sync.SkillResult, len(result.Skills))
+		for _, sr := range result.Skills {
+			actions[sr.Skill.Name] = sr
+		}
+
+		for _, skill := range g.skills {
+			row := inspectSkillOutput{
+				Name:        skill.Name,
+				Description: skill.Description,
+				Platform:    string(skill.Platform),
+				Target:      fmt.Sprintf("%s:%s", g.target, targetScope),
+				Size:        len(skill.Content),
+				Exists:      exists[skill.Name],
+				Problems:    problems[skill.Platform][skill.Name],
+			}
+			if sr, ok := actions[skill.Name]; ok {
+				row.Action = string(sr.Action)
+				if sr.Error != nil {
+					row.Problems = append(row.Problems, sr.Error.Error())
+				}
+			}
+			output.Skills = append(output.Skills, row)
+			output.Summary.count(row)
+		}
+	}
+
+	return out.Render(output, func() error {
+		printInspection(output)
+		return nil
+	})
+}
+
+// count adds a skill to the summary.
+func (s *inspectSummary) count(row inspectSkillOutput) {
+	s.Skills++
+	if row.Exists {
+		s.Overlaps++
+	} else {
+		s.New++
+	}
+	if row.Action == string(sync.ActionConflict) {
+		s.Conflicts++
+	}
+	if len(row.Problems) > 0 {
+		s.Problems++
+	}
+	if row.Action != "" {
+		s.Actions[row.Action]++
+	}
+}
+
+// readInspectFile reads the skills of a bundle or JSON export, telling the
+// two apart by the gzip header of bundles.
+func readInspectFile(path string) (inspectOutput, []model.Skill, error) {
+	output := inspectOutput{Source: path}
+
+	// #nosec G304 - path is provided by user
+	file, err := os.Open(path)
+	if err != nil {
+		return output, nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	reader := bufio.NewReader(file)
+	header, _ := reader.Peek(2)
+	if len(header) == 2 && header[0] == 0x1f && header[1] == 0x8b {
+		output.Format = string(export.FormatBundle)
+		manifest, skills, err := export.ReadBundle(reader)
+		if err != nil {
+			return output, nil, fmt.Errorf("invalid bundle %s: %w", path, err)
+		}
+		output.CreatedAt = manifest.CreatedAt.Format("2006-01-02 15:04:05")
+		output.Removed = manifest.Removed
+		return output, skills, nil
+	}
+
+	output.Format = string(export.FormatJSON)
+	skills, removed, err := export.ReadJSON(reader)
+	if err != nil {
+		return output, nil, fmt.Errorf("%s is neither a bundle nor a JSON export: %w", path, err)
+	}
+	output.Removed = removed
+	return output, skills, nil
+}
+
+// inspectProblems returns the problems of each skill that would keep it from
+// importing cleanly, keyed by platform and name.
+func inspectProblems(skills []model.Skill) (map[model.Platform]map[string][]string, error) {
+	appConfig, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	schema, err := loadFrontmatterSchema(appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := make(map[model.Platform]map[string][]string)
+	for _, skill := range skills {
+		var found []string
+		if strings.TrimSpace(skill.Content) == "" {
+			found = append(found, "empty content")
+		}
+		if schema != nil {
+			for _, violation := range schema.ValidateSkill(skill) {
+				found = append(found, "frontmatter: "+violation)
+			}
+		}
+		if len(found) > 0 {
+			if problems[skill.Platform] == nil {
+				problems[skill.Platform] = make(map[string][]string)
+			}
+			problems[skill.Platform][skill.Name] = found
+		}
+	}
+	return problems, nil
+}
+
+// printInspection prints an inspected export as a table followed by its
+// problems and a summary of what an import would do.
+func printInspection(output inspectOutput) {
+	title := fmt.Sprintf("%s (%s", output.Source, output.Format)
+	if output.CreatedAt != "" {
+		title += ", created " + output.CreatedAt
+	}
+	fmt.Println(ui.Header(title + ")"))
+
+	nameWidth, targetWidth := len("NAME"), len("TARGET")
+	for _, s := range output.Skills {
+		nameWidth = max(nameWidth, len(s.Name))
+		targetWidth = max(targetWidth, len(s.Target))
+	}
+	fmt.Printf("%s %s %s %s\n",
+		ui.Header(fmt.Sprintf("%-*s", nameWidth, "NAME")),
+		ui.Header(fmt.Sprintf("%-*s", targetWidth, "TARGET")),
+		ui.Header(fmt.Sprintf("%-8s", "LOCAL")),
+		ui.Header("IMPORT WOULD"))
+	for _, s := range output.Skills {
+		local := ui.Success(fmt.Sprintf("%-8s", "new"))
+		if s.Exists {
+			local = ui.Warning(fmt.Sprintf("%-8s", "exists"))
+		}
+		fmt.Printf("%-*s %-*s %s %s\n", nameWidth, s.Name, targetWidth, s.Target, local, colorInspectAction(s.Action))
+	}
+
+	if output.Summary.Problems > 0 {
+		fmt.Println()
+		fmt.Println(ui.Header("Problems:"))
+		for _, s := range output.Skills {
+			for _, problem := range s.Problems {
+				fmt.Printf("  %s: %s\n", s.Name, problem)
+			}
+		}
+	}
+	if len(output.Removed) > 0 {
+		fmt.Printf("\n%d skill(s) listed as removed (import does not delete them)\n", len(output.Removed))
+	}
+
+	summary := output.Summary
+	fmt.Printf("\n%d skill(s): %d new, %d overlap existing skills", summary.Skills, summary.New, summary.Overlaps)
+	if summary.Conflicts > 0 {
+		fmt.Printf(", %d would conflict", summary.Conflicts)
+	}
+	fmt.Println()
+	fmt.Printf("Import would create %d, update %d, merge %d, skip %d, and leave %d unchanged\n",
+		summary.Actions[string(sync.ActionCreated)], summary.Actions[string(sync.ActionUpdated)],
+		summary.Actions[string(sync.ActionMerged)], summary.Actions[string(sync.ActionSkipped)],
+		summary.Actions[string(sync.ActionUnchanged)])
+	fmt.Println(ui.Dim("Nothing was imported."))
+}
+
+// colorInspectAction colors the action an import would take.
+func colorInspectAction(action string) string {
+	switch sync.Action(action) {
+	case sync.ActionCreated:
+		return ui.Success(action)
+	case sync.ActionUpdated, sync.ActionMerged:
+		return ui.Warning(action)
+	case sync.ActionConflict, sync.ActionFailed:
+		return ui.Error(action)
+	default:
+		return ui.Dim(action)
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestInspectCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, ".claude", "skills")
+	cursorSkills := filepath.Join(tempDir, ".cursor", "skills")
+	util.WriteFile(t, filepath.Join(claudeSkills, "review", "SKILL.md"),
+		"---\nname: review\ndescription: Review code\n---\nCheck tests.\n")
+	util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"),
+		"---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+	util.WriteFile(t, filepath.Join(cursorSkills, "review", "SKILL.md"),
+		"---\nname: review\ndescription: My review\n---\nMy own review steps.\n")
+
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+
+	ctx := context.Background()
+	bundle := filepath.Join(tempDir, "team.skillpack")
+	jsonExport := filepath.Join(tempDir, "team.json")
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "export", "--format", "bundle", "--platform", "claude-code", "--output", bundle}))
+		util.AssertNoError(t, Run(ctx, []string{"skillsync", "export", "--platform", "claude-code", "--output", jsonExport}))
+	})
+
+	tests := map[string]struct {
+		path       string
+		wantFormat string
+	}{
+		"bundle":      {path: bundle, wantFormat: "bundle"},
+		"JSON export": {path: jsonExport, wantFormat: "json"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(ctx, []string{"skillsync", "--output", "json", "inspect", tt.path, "cursor"})
+			})
+			util.AssertNoError(t, runErr)
+
+			var result inspectOutput
+			if err := json.Unmarshal([]byte(output), &result); err != nil {
+				t.Fatalf("invalid JSON: %v\n%s", err, output)
+			}
+			util.AssertEqual(t, result.Format, tt.wantFormat)
+
+			actions := make(map[string]string)
+			for _, skill := range result.Skills {
+				actions[skill.Name] = skill.Action
+				util.AssertEqual(t, skill.Exists, skill.Name == "review")
+				util.AssertEqual(t, skill.Target, "cursor:user")
+			}
+			util.AssertEqual(t, actions["review"], "updated")
+			util.AssertEqual(t, actions["lint"], "created")
+			util.AssertEqual(t, result.Summary.New, 1)
+			util.AssertEqual(t, result.Summary.Overlaps, 1)
+
+			if _, err := os.Stat(filepath.Join(cursorSkills, "lint")); !os.IsNotExist(err) {
+				t.Errorf("inspect imported a skill")
+			}
+		})
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(ctx, []string{"skillsync", "inspect", "--strategy", "skip", bundle, "cursor"})
+		})
+		util.AssertNoError(t, runErr)
+		for _, want := range []string{"IMPORT WOULD", "1 new, 1 overlap existing skills", "create 1, update 0, merge 0, skip 1", "Nothing was imported."} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output missing %q:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("not an export", func(t *testing.T) {
+		path := filepath.Join(tempDir, "notes.txt")
+		util.WriteFile(t, path, "just some notes\n")
+		err := Run(ctx, []string{"skillsync", "inspect", path})
+		if err == nil || !strings.Contains(err.Error(), "neither a bundle nor a JSON export") {
+			t.Errorf("Run() error = %v, want format error", err)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// projectedSkillCount returns how many skills a target holds after syncing
+// incoming into it: the existing skills plus the incoming ones it lacks.
+func projectedSkillCount(existing, incoming []model.Skill) int {
+	names := make(map[string]bool, len(existing)+len(incoming))
+	for _, skill := range existing {
+		names[skill.Name] = true
+	}
+	before := len(names)
+	for _, skill := range incoming {
+		names[skill.Name] = true
+	}
+	if len(names) == before {
+		// Only updates; a target already over its limit is not made worse
+		return 0
+	}
+	return len(names)
+}
+
+// checkSkillLimit enforces the platform's max_skills soft limit on a sync of
+// incoming skills into a target (described by where) that holds existing.
+// Going over the limit is an error unless --force or --dry-run is given, in
+// which case it is reported as a warning.
+func checkSkillLimit(cfg *syncConfig, where string, existing, incoming []model.Skill) error {
+	if cfg.maxSkills <= 0 {
+		return nil
+	}
+	total := projectedSkillCount(existing, incoming)
+	if total <= cfg.maxSkills {
+		return nil
+	}
+
+	platform := cfg.targetSpec.Platform
+	msg := fmt.Sprintf("syncing would leave %d skills in %s, over the %s soft limit of %d (platforms.%s.max_skills)",
+		total, where, platform, cfg.maxSkills, strings.ReplaceAll(string(platform), "-", "_"))
+	if cfg.force || cfg.dryRun {
+		warnf("Warning: %s\n", msg)
+		return nil
+	}
+	return fmt.Errorf("%s; use --force to sync anyway", msg)
+}
+
+// checkScopeSkillLimit checks the soft limit for a sync of incoming skills
+// into scope of the target platform.
+func checkScopeSkillLimit(cfg *syncConfig, scope model.SkillScope, incoming []model.Skill) error {
+	if cfg.maxSkills <= 0 {
+		return nil
+	}
+	existing, err := parsePlatformSkillsWithScope(cfg.targetSpec.Platform, []model.SkillScope{scope}, false)
+	if err != nil {
+		return fmt.Errorf("failed to parse target skills: %w", err)
+	}
+	return checkSkillLimit(cfg, string(scope)+" scope", existing, incoming)
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSyncSkillLimit(t *testing.T) {
+	tests := map[string]struct {
+		limit       string
+		args        []string
+		wantErr     bool
+		wantWarning bool
+		wantWritten bool
+	}{
+		"over the limit stops the sync": {
+			limit:   "2",
+			args:    []string{"--yes"},
+			wantErr: true,
+		},
+		"force syncs past the limit": {
+			limit:       "2",
+			args:        []string{"--yes", "--force"},
+			wantWarning: true,
+			wantWritten: true,
+		},
+		"dry run only warns": {
+			limit:       "2",
+			args:        []string{"--dry-run"},
+			wantWarning: true,
+		},
+		"within the limit": {
+			limit:       "3",
+			args:        []string{"--yes"},
+			wantWritten: true,
+		},
+		"zero disables the limit": {
+			limit:       "0",
+			args:        []string{"--yes"},
+			wantWritten: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			claudeSkills := filepath.Join(tempDir, "claude", "skills")
+			cursorSkills := filepath.Join(tempDir, "cursor", "skills")
+			for _, skill := range []string{"lint", "test"} {
+				util.WriteFile(t, filepath.Join(claudeSkills, skill, "SKILL.md"),
+					"---\nname: "+skill+"\ndescription: Run "+skill+"\n---\nRun it.\n")
+			}
+			util.WriteFile(t, filepath.Join(cursorSkills, "deploy", "SKILL.md"),
+				"---\nname: deploy\ndescription: Deploy\n---\nDeploy it.\n")
+
+			t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+			t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+			t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+			t.Setenv("SKILLSYNC_CURSOR_MAX_SKILLS", tt.limit)
+
+			args := append([]string{"skillsync", "sync", "--skip-validation"}, tt.args...)
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append(args, "claudecode:user", "cursor"))
+			})
+			if (runErr != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v\n%s", runErr, tt.wantErr, output)
+			}
+			if tt.wantErr && !strings.Contains(runErr.Error(), "use --force") {
+				t.Errorf("Run() error = %v, want --force hint", runErr)
+			}
+			if got := strings.Contains(output, "soft limit of 2"); got != tt.wantWarning {
+				t.Errorf("warning printed = %v, want %v:\n%s", got, tt.wantWarning, output)
+			}
+
+			_, err := os.Stat(filepath.Join(cursorSkills, "lint", "SKILL.md"))
+			if written := err == nil; written != tt.wantWritten {
+				t.Errorf("lint written = %v, want %v", written, tt.wantWritten)
+			}
+		})
+	}
+}
+
+func TestProjectedSkillCount(t *testing.T) {
+	skills := func(names ...string) []model.Skill {
+		result := make([]model.Skill, 0, len(names))
+		for _, name := range names {
+			result = append(result, model.Skill{Name: name})
+		}
+		return result
+	}
+
+	tests := map[string]struct {
+		existing []string
+		incoming []string
+		want     int
+	}{
+		"new skills add up":         {existing: []string{"a"}, incoming: []string{"b", "c"}, want: 3},
+		"existing names count once": {existing: []string{"a", "b"}, incoming: []string{"b", "c"}, want: 3},
+		"updates only":              {existing: []string{"a", "b"}, incoming: []string{"a"}, want: 0},
+		"empty target":              {incoming: []string{"a"}, want: 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, projectedSkillCount(skills(tt.existing...), skills(tt.incoming...)), tt.want)
+		})
+	}
+}
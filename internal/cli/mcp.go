@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/mcp"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/rpc"
+	"github.com/klauern/skillsync/internal/validation"
+)
+
+// mcpResourceScheme is the URI scheme of skill resources:
+// skill://<platform>/<scope>/<name>.
+const mcpResourceScheme = "skill"
+
+// mcpInstructions tell the model how the skillsync server is meant to be used.
+const mcpInstructions = `skillsync manages agent skills across AI coding platforms (claude-code, cursor, codex, ...).
+Use list_skills to find skills, read_skill to read one, and diff_skills before sync_skills to see what a sync would change.
+Platform specs are platform[:scope], e.g. "cursor:repo". Each skill is also a resource at skill://<platform>/<scope>/<name>.`
+
+func mcpCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "mcp",
+		Usage:     "Serve skills to AI agents over the Model Context Protocol",
+		UsageText: "skillsync mcp [--read-only]",
+		Description: `Run a Model Context Protocol (MCP) server on stdin and stdout, so agents
+   can list, read, and sync skills directly.
+
+   Tools:
+     list_skills   List skills ({"platform": "cursor:repo"})
+     read_skill    Read a skill with its frontmatter ({"name", "platform"})
+     diff_skills   Compare two platform specs ({"source", "target"})
+     sync_skills   Sync skills between platform specs ({"source", "target"})
+
+   Every skill is also a resource, skill://<platform>/<scope>/<name>, whose
+   text is the skill file. Syncs are backed up and recorded in history like
+   skillsync sync; --read-only leaves out sync_skills.
+
+   Register the server with an MCP client, e.g. for Claude Code:
+     claude mcp add skillsync -- skillsync mcp`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "read-only",
+				Usage: "Offer only the tools that do not write skills",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runMCP(ctx, cmd.Bool("read-only"))
+		},
+	}
+}
+
+// runMCP serves MCP on stdin and stdout, which like runRPC carries only
+// responses while the server runs.
+func runMCP(ctx context.Context, readOnly bool) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	responses := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = responses }()
+	mode := out.mode
+	out.mode = outputModeJSON
+	defer func() { out.mode = mode }()
+
+	server := mcp.New(mcp.Options{
+		Name:          "skillsync",
+		Version:       Version,
+		Instructions:  mcpInstructions,
+		Tools:         mcpTools(readOnly),
+		ListResources: mcpListResources,
+		ReadResource:  mcpReadResource,
+	})
+	return server.Serve(ctx, os.Stdin, responses)
+}
+
+// mcpReadSkillArgs are the arguments of read_skill.
+type mcpReadSkillArgs struct {
+	Name     string `json:"name"`
+	Platform string `json:"platform,omitempty"`
+}
+
+// mcpTools returns the tools of the server; sync_skills is left out when
+// readOnly is set. Listing, diffing, and syncing use the rpc operations.
+func mcpTools(readOnly bool) []mcp.Tool {
+	closedWorld := false
+	tools := []mcp.Tool{
+		{
+			Name:        "list_skills",
+			Title:       "List skills",
+			Description: "List the skills installed on one platform spec, or on every platform. Returns each skill's name, platform, scope, path, and description.",
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "platform": {"type": "string", "description": "Platform spec, platform[:scope], e.g. \"cursor\" or \"claude-code:repo\". Omit for every platform."},
+    "include_plugins": {"type": "boolean", "description": "Include skills installed by plugins"}
+  }
+}`),
+			Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true, OpenWorldHint: &closedWorld},
+			Call:        rpc.Typed(rpcDiscover),
+		},
+		{
+			Name:        "read_skill",
+			Title:       "Read a skill",
+			Description: "Read a skill: its frontmatter and content, from the given platform or the first platform that has it.",
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "description": "Skill name"},
+    "platform": {"type": "string", "description": "Platform to read the skill from, e.g. \"cursor\""}
+  },
+  "required": ["name"]
+}`),
+			Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true, OpenWorldHint: &closedWorld},
+			Call:        rpc.Typed(mcpReadSkill),
+		},
+		{
+			Name:        "diff_skills",
+			Title:       "Diff skills",
+			Description: "Compare the skills of two platform specs. Returns modified skills with unified diff hunks; set all to include identical and one-sided skills.",
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "source": {"type": "string", "description": "Source platform spec, e.g. \"claude-code\""},
+    "target": {"type": "string", "description": "Target platform spec, e.g. \"cursor:repo\""},
+    "skills": {"type": "array", "items": {"type": "string"}, "description": "Only compare these skills"},
+    "all": {"type": "boolean", "description": "Include identical skills and skills on one side only"}
+  },
+  "required": ["source", "target"]
+}`),
+			Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true, OpenWorldHint: &closedWorld},
+			Call:        rpc.Typed(rpcDiff),
+		},
+	}
+	if readOnly {
+		return tools
+	}
+
+	destructive := true
+	return append(tools, mcp.Tool{
+		Name:        "sync_skills",
+		Title:       "Sync skills",
+		Description: "Sync skills from a source platform spec to a target. Replaced skills are backed up. Conflicts the strategy cannot settle are reported, not written; use dry_run to preview.",
+		InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "source": {"type": "string", "description": "Source platform spec, e.g. \"claude-code\""},
+    "target": {"type": "string", "description": "Target platform spec, e.g. \"cursor:repo\""},
+    "skills": {"type": "array", "items": {"type": "string"}, "description": "Only sync these skills"},
+    "strategy": {"type": "string", "enum": ["overwrite", "skip", "newer", "merge", "three-way", "interactive"], "description": "Sync strategy; defaults to the configured strategy"},
+    "dry_run": {"type": "boolean", "description": "Report what would change without writing"},
+    "skip_backup": {"type": "boolean", "description": "Do not back up replaced skills"}
+  },
+  "required": ["source", "target"]
+}`),
+		Annotations: &mcp.ToolAnnotations{DestructiveHint: &destructive, OpenWorldHint: &closedWorld},
+		Call:        rpc.Typed(rpcSync),
+	})
+}
+
+// mcpReadSkill returns a skill like skillsync skill show --json.
+func mcpReadSkill(_ context.Context, args mcpReadSkillArgs) (*skillShowOutput, error) {
+	if args.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	skill, err := resolveSkill(args.Name, args.Platform)
+	if err != nil {
+		return nil, err
+	}
+	output := &skillShowOutput{
+		Name:        skill.Name,
+		Platform:    skill.Platform,
+		Scope:       skill.Scope,
+		Path:        skill.Path,
+		Frontmatter: validation.SkillFrontmatter(skill),
+		Content:     skill.Content,
+	}
+	if origin, ok := skill.Origin(); ok {
+		output.Origin = &origin
+	}
+	return output, nil
+}
+
+// mcpListResources lists every skill, except plugin skills, as a resource.
+func mcpListResources(context.Context) ([]mcp.Resource, error) {
+	skills, err := discoverBrowseSkills(false)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]mcp.Resource, 0, len(skills))
+	for _, skill := range skills {
+		resources = append(resources, mcp.Resource{
+			URI:         skillResourceURI(skill),
+			Name:        skill.Name,
+			Title:       fmt.Sprintf("%s (%s, %s)", skill.Name, skill.Platform, skill.Scope),
+			Description: skill.Description,
+			MIMEType:    "text/markdown",
+		})
+	}
+	return resources, nil
+}
+
+// mcpReadResource returns the skill file of a skill resource.
+func mcpReadResource(_ context.Context, uri string) (*mcp.ResourceContents, error) {
+	platform, scope, name, err := parseSkillResourceURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := parsePlatformSkillsWithScope(platform, []model.SkillScope{scope}, scope == model.ScopePlugin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s skills: %w", platform, err)
+	}
+	for _, skill := range skills {
+		if skill.Name == name && skill.Scope == scope {
+			return &mcp.ResourceContents{URI: uri, MIMEType: "text/markdown", Text: formatSkill(skill)}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", mcp.ErrResourceNotFound, uri)
+}
+
+// skillResourceURI returns the resource URI of a skill.
+func skillResourceURI(skill model.Skill) string {
+	u := url.URL{
+		Scheme: mcpResourceScheme,
+		Host:   string(skill.Platform),
+		Path:   "/" + string(skill.Scope) + "/" + skill.Name,
+	}
+	return u.String()
+}
+
+// parseSkillResourceURI parses a skill://<platform>/<scope>/<name> URI.
+func parseSkillResourceURI(uri string) (model.Platform, model.SkillScope, string, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != mcpResourceScheme {
+		return "", "", "", fmt.Errorf("%w: %s", mcp.ErrResourceNotFound, uri)
+	}
+	scopeName, name, ok := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if !ok || name == "" {
+		return "", "", "", fmt.Errorf("%w: %s", mcp.ErrResourceNotFound, uri)
+	}
+	platform, err := model.ParsePlatform(u.Host)
+	if err != nil {
+		return "", "", "", fmt.Errorf("%w: %s: %v", mcp.ErrResourceNotFound, uri, err)
+	}
+	scope, err := model.ParseScope(scopeName)
+	if err != nil {
+		return "", "", "", fmt.Errorf("%w: %s: %v", mcp.ErrResourceNotFound, uri, err)
+	}
+	return platform, scope, name, nil
+}
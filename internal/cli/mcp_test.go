@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/rpc"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestMCPCommand(t *testing.T) {
+	claudeSkills, cursorSkills := setupStore(t)
+	util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+
+	// runMCPSession serves the requests and returns the responses by ID.
+	runMCPSession := func(t *testing.T, args []string, requests ...string) map[string]rpc.Response {
+		t.Helper()
+		withStdin(t, strings.Join(requests, "\n"))
+		output := captureOutput(t, func() {
+			if err := Run(context.Background(), args); err != nil {
+				t.Errorf("mcp failed: %v", err)
+			}
+		})
+		responses := make(map[string]rpc.Response)
+		for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+			var response rpc.Response
+			if err := json.Unmarshal([]byte(line), &response); err != nil {
+				t.Fatalf("stdout has a line that is not a response: %q", line)
+			}
+			responses[string(response.ID)] = response
+		}
+		return responses
+	}
+
+	// toolResult decodes a tools/call result.
+	type toolResult struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+		StructuredContent json.RawMessage `json:"structuredContent"`
+		IsError           bool            `json:"isError"`
+	}
+
+	t.Run("tools and resources", func(t *testing.T) {
+		responses := runMCPSession(t, []string{"skillsync", "mcp"},
+			`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2025-06-18", "capabilities": {}, "clientInfo": {"name": "test", "version": "1"}}}`,
+			`{"jsonrpc": "2.0", "method": "notifications/initialized"}`,
+			`{"jsonrpc": "2.0", "id": 2, "method": "tools/list"}`,
+			`{"jsonrpc": "2.0", "id": 3, "method": "tools/call", "params": {"name": "read_skill", "arguments": {"name": "lint"}}}`,
+			`{"jsonrpc": "2.0", "id": 4, "method": "tools/call", "params": {"name": "sync_skills", "arguments": {"source": "claudecode", "target": "cursor", "skip_backup": true}}}`,
+			`{"jsonrpc": "2.0", "id": 5, "method": "resources/list"}`,
+			`{"jsonrpc": "2.0", "id": 6, "method": "resources/read", "params": {"uri": "skill://cursor/user/lint"}}`,
+			`{"jsonrpc": "2.0", "id": 7, "method": "tools/call", "params": {"name": "read_skill", "arguments": {"name": "missing"}}}`,
+		)
+		util.AssertEqual(t, len(responses), 7)
+
+		if !strings.Contains(string(responses["1"].Result), `"serverInfo":{"name":"skillsync"`) {
+			t.Errorf("unexpected initialize result: %s", responses["1"].Result)
+		}
+		for _, tool := range []string{"list_skills", "read_skill", "diff_skills", "sync_skills"} {
+			if !strings.Contains(string(responses["2"].Result), `"name":"`+tool+`"`) {
+				t.Errorf("tools/list is missing %s: %s", tool, responses["2"].Result)
+			}
+		}
+
+		var read toolResult
+		util.AssertNoError(t, json.Unmarshal(responses["3"].Result, &read))
+		if read.IsError || !strings.Contains(string(read.StructuredContent), "Run the linter.") {
+			t.Errorf("unexpected read_skill result: %s", responses["3"].Result)
+		}
+
+		var synced toolResult
+		util.AssertNoError(t, json.Unmarshal(responses["4"].Result, &synced))
+		if synced.IsError {
+			t.Errorf("sync_skills failed: %s", responses["4"].Result)
+		}
+		if _, err := os.Stat(filepath.Join(cursorSkills, "lint", "SKILL.md")); err != nil {
+			t.Errorf("sync_skills did not write lint: %v", err)
+		}
+
+		// Resources are listed after the sync, so both copies are there
+		for _, uri := range []string{"skill://claude-code/user/lint", "skill://cursor/user/lint"} {
+			if !strings.Contains(string(responses["5"].Result), uri) {
+				t.Errorf("resources/list is missing %s: %s", uri, responses["5"].Result)
+			}
+		}
+		if !strings.Contains(string(responses["6"].Result), "Run the linter.") {
+			t.Errorf("unexpected resources/read result: %s", responses["6"].Result)
+		}
+
+		var missing toolResult
+		util.AssertNoError(t, json.Unmarshal(responses["7"].Result, &missing))
+		if !missing.IsError || len(missing.Content) == 0 || !strings.Contains(missing.Content[0].Text, "not found") {
+			t.Errorf("expected a tool error, got: %s", responses["7"].Result)
+		}
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		responses := runMCPSession(t, []string{"skillsync", "mcp", "--read-only"},
+			`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`,
+		)
+		if strings.Contains(string(responses["1"].Result), "sync_skills") {
+			t.Errorf("read-only server offers sync_skills: %s", responses["1"].Result)
+		}
+	})
+}
+
+func TestParseSkillResourceURI(t *testing.T) {
+	tests := map[string]struct {
+		uri     string
+		want    string
+		wantErr bool
+	}{
+		"valid":            {uri: "skill://cursor/repo/lint", want: "cursor repo lint"},
+		"platform alias":   {uri: "skill://claudecode/user/lint", want: "claude-code user lint"},
+		"other scheme":     {uri: "file:///tmp/lint", wantErr: true},
+		"missing name":     {uri: "skill://cursor/user/", wantErr: true},
+		"unknown platform": {uri: "skill://nowhere/user/lint", wantErr: true},
+		"unknown scope":    {uri: "skill://cursor/galaxy/lint", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			platform, scope, skill, err := parseSkillResourceURI(tt.uri)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error for %s", tt.uri)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, string(platform)+" "+string(scope)+" "+skill, tt.want)
+		})
+	}
+}
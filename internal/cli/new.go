@@ -0,0 +1,483 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/parser/skills"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// defaultTemplateName is the template used when --template is not given.
+const defaultTemplateName = "skill"
+
+// builtinTemplates are always available and can be shadowed by a user template
+// of the same name in the templates directory.
+var builtinTemplates = map[string]string{
+	"skill": `---
+name: {{.Name}}
+description: {{printf "%q" .Description}}
+---
+
+# {{.Name}}
+
+## When to use
+
+Describe the situations where this skill applies.
+
+## Instructions
+
+1. First step
+2. Second step
+`,
+	"prompt": `---
+name: {{.Name}}
+description: {{printf "%q" .Description}}
+type: prompt
+trigger: /{{.Name}}
+---
+
+# {{.Name}}
+
+Describe what /{{.Name}} should do. Use $ARGUMENTS for any text passed after the command.
+`,
+}
+
+// skillTemplateData is the data available to skill templates.
+type skillTemplateData struct {
+	Name        string
+	Description string
+	Platform    string
+	Scope       string
+}
+
+// skillTemplateInfo describes an available template.
+type skillTemplateInfo struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Path   string `json:"path,omitempty"`
+}
+
+func newCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "new",
+		Usage: "Scaffold a new skill from a template",
+		UsageText: `skillsync new [options] <name>
+   skillsync new code-review
+   skillsync new --platform cursor --scope repo lint-rules
+   skillsync new --template prompt --description "Summarize the diff" summarize
+   skillsync new --from code-review security-review
+   skillsync new --list-templates`,
+		Description: `Create a new skill with correct frontmatter for the chosen platform.
+
+   The skill is written as <name>/SKILL.md in the platform's skills directory
+   for the selected scope (user or repo). Existing skills are never overwritten
+   unless --force is given.
+
+   Templates are Go text/template files named <template>.md in
+   ~/.skillsync/templates (or $SKILLSYNC_HOME/templates). They can use
+   {{.Name}}, {{.Description}}, {{.Platform}}, and {{.Scope}}. A user template
+   with the same name as a built-in one (skill, prompt) replaces it.
+
+   Rendered output must contain YAML frontmatter with a valid name.
+
+   With --from, the new skill copies the structure of an existing skill
+   instead of using a template: its frontmatter fields (with name and
+   description replaced) and its section headings, without the section
+   content. --from takes a skill name on --platform or a path to a skill
+   file or directory.
+
+   Examples:
+     skillsync new my-skill                        # User-scope Claude Code skill
+     skillsync new -p codex --scope repo my-skill  # Repo-scope Codex skill
+     skillsync new -t team-standard my-skill       # Use ~/.skillsync/templates/team-standard.md
+     skillsync new --from code-review my-review    # Same frontmatter and sections as code-review
+     skillsync new --dry-run my-skill              # Print the rendered skill`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Value:   string(model.ClaudeCode),
+				Usage:   "Platform to create the skill for (claude-code, cursor, codex, aider)",
+			},
+			&cli.StringFlag{
+				Name:  "scope",
+				Value: string(model.ScopeUser),
+				Usage: "Scope to create the skill in (repo, user)",
+			},
+			&cli.StringFlag{
+				Name:    "template",
+				Aliases: []string{"t"},
+				Value:   defaultTemplateName,
+				Usage:   "Template name (built-in or from the templates directory)",
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "Copy frontmatter and section structure from an existing skill (name or path)",
+			},
+			&cli.StringFlag{
+				Name:    "description",
+				Aliases: []string{"d"},
+				Usage:   "Skill description for the frontmatter",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Overwrite an existing skill",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the rendered skill without writing it",
+			},
+			&cli.BoolFlag{
+				Name:  "list-templates",
+				Usage: "List available templates and exit",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Bool("list-templates") {
+				return runListTemplates()
+			}
+			return runNew(cmd)
+		},
+	}
+}
+
+func runNew(cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return fmt.Errorf("new requires exactly one argument: <name>")
+	}
+	name := cmd.Args().First()
+	if err := validateNewSkillName(name); err != nil {
+		return err
+	}
+
+	platform, err := model.ParsePlatform(cmd.String("platform"))
+	if err != nil {
+		return fmt.Errorf("invalid platform: %w", err)
+	}
+	scope, err := model.ParseScope(cmd.String("scope"))
+	if err != nil {
+		return fmt.Errorf("invalid scope: %w", err)
+	}
+	if scope != model.ScopeRepo && scope != model.ScopeUser {
+		return fmt.Errorf("scope %q is not writable (only repo and user are supported)", scope)
+	}
+
+	description := cmd.String("description")
+	if description == "" {
+		description = "TODO: describe what " + name + " does and when to use it"
+	}
+
+	var content []byte
+	if from := cmd.String("from"); from != "" {
+		if cmd.IsSet("template") {
+			return errors.New("--from and --template cannot be used together")
+		}
+		sourcePath, sourceName, err := resolveScaffoldSource(from, platform)
+		if err != nil {
+			return err
+		}
+		// #nosec G304 - sourcePath is a skill file chosen by the user
+		source, err := os.ReadFile(sourcePath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", sourcePath, err)
+		}
+		content, err = scaffoldFromSkill(source, sourceName, name, description)
+		if err != nil {
+			return fmt.Errorf("--from %s: %w", from, err)
+		}
+		if err := validateRenderedSkill(content, name, platform); err != nil {
+			return fmt.Errorf("--from %s: %w", from, err)
+		}
+	} else {
+		content, err = renderSkillTemplate(cmd.String("template"), skillTemplateData{
+			Name:        name,
+			Description: description,
+			Platform:    string(platform),
+			Scope:       string(scope),
+		})
+		if err != nil {
+			return err
+		}
+		if err := validateRenderedSkill(content, name, platform); err != nil {
+			return fmt.Errorf("template %q: %w", cmd.String("template"), err)
+		}
+	}
+
+	targetPath, err := getSkillPathForScope(platform, scope, name)
+	if err != nil {
+		return fmt.Errorf("failed to determine target path: %w", err)
+	}
+
+	if cmd.Bool("dry-run") {
+		fmt.Printf("Would create %s\n\n", targetPath)
+		fmt.Print(string(content))
+		return nil
+	}
+
+	if _, err := os.Stat(targetPath); err == nil && !cmd.Bool("force") {
+		return fmt.Errorf("skill already exists at %s (use --force to overwrite)", targetPath)
+	}
+
+	// #nosec G301 - skill directories need to be readable by the platform
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0o750); err != nil {
+		return fmt.Errorf("failed to create skill directory: %w", err)
+	}
+	// #nosec G306 - skill files should be readable
+	if err := os.WriteFile(targetPath, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write skill: %w", err)
+	}
+
+	fmt.Printf("✓ Created %s skill %q at %s\n", platform, name, targetPath)
+	return nil
+}
+
+// validateNewSkillName rejects names that cannot be used as a skill directory.
+func validateNewSkillName(name string) error {
+	if strings.ContainsAny(name, `/\:`) || name == "." || name == ".." {
+		return fmt.Errorf("invalid skill name %q: must not contain path separators", name)
+	}
+	if err := parser.ValidateSkillName(name); err != nil {
+		return fmt.Errorf("invalid skill name: %w", err)
+	}
+	return nil
+}
+
+// validateRenderedSkill checks that rendered template output is a parseable skill
+// whose frontmatter name matches the requested name.
+func validateRenderedSkill(content []byte, name string, platform model.Platform) error {
+	if !bytes.HasPrefix(bytes.TrimLeft(content, "\n"), []byte("---")) {
+		return fmt.Errorf("rendered skill has no YAML frontmatter")
+	}
+	skill, err := skills.ParseSkillContent(content, name, platform)
+	if err != nil {
+		return err
+	}
+	if skill.Name != name {
+		return fmt.Errorf("frontmatter name %q does not match %q", skill.Name, name)
+	}
+	return nil
+}
+
+// resolveScaffoldSource returns the skill file and name for --from. from is
+// either a path to a skill file or directory, or the name of a skill on platform.
+func resolveScaffoldSource(from string, platform model.Platform) (path, name string, err error) {
+	if info, statErr := os.Stat(from); statErr == nil {
+		path = from
+		if info.IsDir() {
+			path = filepath.Join(from, "SKILL.md")
+		}
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if strings.EqualFold(filepath.Base(path), "SKILL.md") {
+			name = filepath.Base(filepath.Dir(path))
+		}
+		return path, name, nil
+	}
+
+	existing, err := parsePlatformSkillsWithScope(platform, nil, true)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to discover %s skills: %w", platform, err)
+	}
+	for _, skill := range existing {
+		if skill.Name == from {
+			return skill.Path, skill.Name, nil
+		}
+	}
+	// Also look where new itself writes skills, which can differ from the
+	// configured discovery paths when a platform path override is set.
+	for _, scope := range []model.SkillScope{model.ScopeRepo, model.ScopeUser} {
+		if path, err := getSkillPathForScope(platform, scope, from); err == nil {
+			if _, err := os.Stat(path); err == nil {
+				return path, from, nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("skill %q not found on %s (pass a path to use a skill from elsewhere)", from, platform)
+}
+
+// scaffoldFromSkill builds a new skill from the structure of an existing one.
+// Frontmatter keys are kept in order with name and description replaced and
+// deprecation markers dropped; the body keeps only the markdown headings, with
+// the old skill name replaced by the new one.
+func scaffoldFromSkill(source []byte, oldName, name, description string) ([]byte, error) {
+	split := parser.SplitFrontmatter(source)
+	if !split.HasFrontmatter {
+		return nil, errors.New("source skill has no YAML frontmatter")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(split.Frontmatter, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse source frontmatter: %w", err)
+	}
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	if len(doc.Content) > 0 {
+		if doc.Content[0].Kind != yaml.MappingNode {
+			return nil, errors.New("source frontmatter is not a mapping")
+		}
+		mapping = doc.Content[0]
+	}
+
+	scalar := func(v string) *yaml.Node { return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v} }
+	fields := []*yaml.Node{scalar("name"), scalar(name), scalar("description"), scalar(description)}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		switch key.Value {
+		case "name", "description", model.MetadataDeprecated, model.MetadataReplacedBy:
+			continue
+		}
+		fields = append(fields, key, value)
+	}
+	mapping.Content = fields
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(mapping); err != nil {
+		return nil, fmt.Errorf("failed to write frontmatter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write frontmatter: %w", err)
+	}
+	buf.WriteString("---\n")
+
+	headings := markdownHeadings(split.Content)
+	if len(headings) == 0 {
+		headings = []string{"# " + name}
+	}
+	for _, heading := range headings {
+		if oldName != "" {
+			heading = strings.ReplaceAll(heading, oldName, name)
+		}
+		buf.WriteString("\n" + heading + "\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// markdownHeadings returns the ATX headings in body, ignoring fenced code blocks.
+func markdownHeadings(body string) []string {
+	var headings []string
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+		if level <= 6 && len(trimmed) > level && trimmed[level] == ' ' {
+			headings = append(headings, trimmed)
+		}
+	}
+	return headings
+}
+
+// loadSkillTemplate returns the template text for name, preferring the user's
+// templates directory over built-in templates.
+func loadSkillTemplate(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid template name %q", name)
+	}
+
+	path := filepath.Join(util.Paths().TemplatesPath(), name+".md")
+	// #nosec G304 - path is built from the templates directory and a validated name
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return string(data), nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to read template %s: %w", path, err)
+	}
+
+	if text, ok := builtinTemplates[name]; ok {
+		return text, nil
+	}
+	return "", fmt.Errorf("template %q not found (see skillsync new --list-templates)", name)
+}
+
+// renderSkillTemplate renders the named template with data.
+func renderSkillTemplate(name string, data skillTemplateData) ([]byte, error) {
+	text, err := loadSkillTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %q: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render template %q: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// listSkillTemplates returns built-in and user templates sorted by name.
+// User templates shadow built-in templates of the same name.
+func listSkillTemplates() ([]skillTemplateInfo, error) {
+	byName := make(map[string]skillTemplateInfo, len(builtinTemplates))
+	for name := range builtinTemplates {
+		byName[name] = skillTemplateInfo{Name: name, Source: "builtin"}
+	}
+
+	dir := util.Paths().TemplatesPath()
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".md")
+		byName[name] = skillTemplateInfo{Name: name, Source: "user", Path: filepath.Join(dir, entry.Name())}
+	}
+
+	infos := make([]skillTemplateInfo, 0, len(byName))
+	for _, info := range byName {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+func runListTemplates() error {
+	infos, err := listSkillTemplates()
+	if err != nil {
+		return err
+	}
+	return out.Render(infos, func() error {
+		fmt.Printf("Templates (%s):\n", util.Paths().TemplatesPath())
+		for _, info := range infos {
+			label := info.Name
+			if info.Name == defaultTemplateName {
+				label += " " + ui.Dim("(default)")
+			}
+			if info.Path != "" {
+				fmt.Printf("  %-20s %s\n", label, ui.Dim(info.Path))
+			} else {
+				fmt.Printf("  %-20s %s\n", label, ui.Dim(info.Source))
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,304 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser/skills"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestRenderSkillTemplate(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", home)
+
+	templatesDir := filepath.Join(home, "templates")
+	if err := os.MkdirAll(templatesDir, 0o750); err != nil {
+		t.Fatalf("failed to create templates dir: %v", err)
+	}
+	userTemplates := map[string]string{
+		"team.md":   "---\nname: {{.Name}}\ndescription: team\nplatform: {{.Platform}}\n---\nTeam body",
+		"skill.md":  "---\nname: {{.Name}}\ndescription: overridden\n---\nCustom default",
+		"broken.md": "---\nname: {{.Name}\n---\n",
+	}
+	for file, content := range userTemplates {
+		if err := os.WriteFile(filepath.Join(templatesDir, file), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write template: %v", err)
+		}
+	}
+
+	data := skillTemplateData{Name: "demo", Description: "Demo: a skill", Platform: "cursor", Scope: "user"}
+
+	tests := map[string]struct {
+		template string
+		want     []string
+		wantErr  bool
+	}{
+		"builtin prompt": {
+			template: "prompt",
+			want:     []string{"name: demo", `description: "Demo: a skill"`, "type: prompt", "trigger: /demo"},
+		},
+		"user template with platform": {
+			template: "team",
+			want:     []string{"platform: cursor", "Team body"},
+		},
+		"user template shadows builtin": {
+			template: "skill",
+			want:     []string{"Custom default"},
+		},
+		"unknown template": {
+			template: "missing",
+			wantErr:  true,
+		},
+		"parse error": {
+			template: "broken",
+			wantErr:  true,
+		},
+		"path traversal rejected": {
+			template: "../skill",
+			wantErr:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := renderSkillTemplate(tt.template, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderSkillTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(got), want) {
+					t.Errorf("rendered output missing %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBuiltinTemplatesProduceValidSkills(t *testing.T) {
+	data := skillTemplateData{Name: "demo", Description: "TODO: describe demo", Platform: "claude-code", Scope: "user"}
+	for name, text := range builtinTemplates {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SKILLSYNC_HOME", t.TempDir())
+			content, err := renderSkillTemplate(name, data)
+			if err != nil {
+				t.Fatalf("renderSkillTemplate() error = %v", err)
+			}
+			if err := validateRenderedSkill(content, "demo", model.ClaudeCode); err != nil {
+				t.Errorf("validateRenderedSkill() error = %v\n%s", err, text)
+			}
+			if !skills.IsAgentSkillsFormat(content) {
+				t.Errorf("builtin template %q is not Agent Skills format", name)
+			}
+		})
+	}
+}
+
+func TestValidateRenderedSkill(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		wantErr bool
+	}{
+		"valid":            {content: "---\nname: demo\ndescription: d\n---\nbody"},
+		"no frontmatter":   {content: "# demo\n", wantErr: true},
+		"name mismatch":    {content: "---\nname: other\n---\nbody", wantErr: true},
+		"invalid yaml":     {content: "---\nname: [\n---\nbody", wantErr: true},
+		"name from caller": {content: "---\ndescription: d\n---\nbody"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateRenderedSkill([]byte(tt.content), "demo", model.Cursor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateRenderedSkill() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScaffoldFromSkill(t *testing.T) {
+	tests := map[string]struct {
+		source  string
+		want    string
+		wantErr bool
+	}{
+		"keeps frontmatter keys and headings": {
+			source: `---
+name: code-review
+description: Review code carefully
+tools: [Read, Grep]
+deprecated: true
+replaced_by: review-v2
+metadata:
+  team: platform
+---
+
+# code-review
+
+Look at the diff.
+
+## Checklist
+
+- correctness
+
+` + "```" + `
+# not a heading
+` + "```" + `
+
+### Notes for code-review
+#hashtag
+`,
+			want: `---
+name: security-review
+description: TODO
+tools: [Read, Grep]
+metadata:
+  team: platform
+---
+
+# security-review
+
+## Checklist
+
+### Notes for security-review
+`,
+		},
+		"adds title when source has no headings": {
+			source: "---\nname: code-review\ntype: prompt\n---\nJust text.\n",
+			want:   "---\nname: security-review\ndescription: TODO\ntype: prompt\n---\n\n# security-review\n",
+		},
+		"no frontmatter": {
+			source:  "# code-review\n",
+			wantErr: true,
+		},
+		"frontmatter is not a mapping": {
+			source:  "---\n- a\n- b\n---\n",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := scaffoldFromSkill([]byte(tt.source), "code-review", "security-review", "TODO")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("scaffoldFromSkill() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("scaffoldFromSkill() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	home := t.TempDir()
+	userSkills := filepath.Join(home, "claude-skills")
+	t.Setenv("SKILLSYNC_HOME", home)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", userSkills)
+
+	ctx := context.Background()
+
+	t.Run("creates skill in user scope", func(t *testing.T) {
+		if err := Run(ctx, []string{"skillsync", "new", "-d", "Review code", "review"}); err != nil {
+			t.Fatalf("new failed: %v", err)
+		}
+		path := filepath.Join(userSkills, "review", "SKILL.md")
+		skill, err := skills.ParseSkillFile(path, model.ClaudeCode)
+		if err != nil {
+			t.Fatalf("created skill does not parse: %v", err)
+		}
+		if skill.Name != "review" || skill.Description != "Review code" {
+			t.Errorf("unexpected skill: name=%q description=%q", skill.Name, skill.Description)
+		}
+	})
+
+	t.Run("refuses to overwrite without force", func(t *testing.T) {
+		if err := Run(ctx, []string{"skillsync", "new", "review"}); err == nil {
+			t.Error("expected error for existing skill")
+		}
+		if err := Run(ctx, []string{"skillsync", "new", "--force", "-t", "prompt", "review"}); err != nil {
+			t.Errorf("new --force failed: %v", err)
+		}
+	})
+
+	t.Run("dry run does not write", func(t *testing.T) {
+		output := captureOutput(t, func() {
+			if err := Run(ctx, []string{"skillsync", "new", "--dry-run", "draft"}); err != nil {
+				t.Errorf("new --dry-run failed: %v", err)
+			}
+		})
+		if !strings.Contains(output, "name: draft") {
+			t.Errorf("dry run output missing frontmatter:\n%s", output)
+		}
+		if _, err := os.Stat(filepath.Join(userSkills, "draft")); !os.IsNotExist(err) {
+			t.Errorf("dry run created skill directory")
+		}
+	})
+
+	t.Run("from existing skill by name", func(t *testing.T) {
+		util.WriteFile(t, filepath.Join(userSkills, "base", "SKILL.md"),
+			"---\nname: base\ndescription: Base skill\nallowed-tools: Read\n---\n\n# base\n\nBody.\n\n## Steps\n\n1. Do it\n")
+		if err := Run(ctx, []string{"skillsync", "new", "--from", "base", "-d", "Derived skill", "derived"}); err != nil {
+			t.Fatalf("new --from failed: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(userSkills, "derived", "SKILL.md"))
+		if err != nil {
+			t.Fatalf("failed to read scaffolded skill: %v", err)
+		}
+		want := "---\nname: derived\ndescription: Derived skill\nallowed-tools: Read\n---\n\n# derived\n\n## Steps\n"
+		if string(data) != want {
+			t.Errorf("scaffolded skill =\n%s\nwant:\n%s", data, want)
+		}
+	})
+
+	t.Run("from path", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "team-standard")
+		util.WriteFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: team-standard\nversion: 1\n---\n# team-standard\n## Usage\n")
+		output := captureOutput(t, func() {
+			if err := Run(ctx, []string{"skillsync", "new", "--dry-run", "--from", dir, "mine"}); err != nil {
+				t.Errorf("new --from path failed: %v", err)
+			}
+		})
+		if !strings.Contains(output, "version: 1") || !strings.Contains(output, "# mine") {
+			t.Errorf("unexpected scaffold:\n%s", output)
+		}
+	})
+
+	t.Run("from errors", func(t *testing.T) {
+		if err := Run(ctx, []string{"skillsync", "new", "--from", "missing-skill", "other"}); err == nil {
+			t.Error("expected error for unknown --from skill")
+		}
+		if err := Run(ctx, []string{"skillsync", "new", "--from", "base", "-t", "prompt", "other"}); err == nil {
+			t.Error("expected error for --from with --template")
+		}
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		if err := Run(ctx, []string{"skillsync", "new", "../escape"}); err == nil {
+			t.Error("expected error for name with path separator")
+		}
+	})
+
+	t.Run("list templates as json", func(t *testing.T) {
+		output := captureOutput(t, func() {
+			if err := Run(ctx, []string{"skillsync", "--output", "json", "new", "--list-templates"}); err != nil {
+				t.Errorf("new --list-templates failed: %v", err)
+			}
+		})
+		var infos []skillTemplateInfo
+		if err := json.Unmarshal([]byte(output), &infos); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, output)
+		}
+		if len(infos) != len(builtinTemplates) {
+			t.Errorf("expected %d templates, got %+v", len(builtinTemplates), infos)
+		}
+	})
+}
@@ -30,13 +30,13 @@ func onboardGuide() string {
 - Keep skills consistent, deduplicate, and back up before changes.
 
 ## Key concepts
-- Platform: claude-code, cursor, codex.
+- Platform: claude-code, cursor, codex, aider.
 - Scope: repo, user, admin, system, builtin, plugin.
 - Writable scopes: repo and user.
 - Sync is one-way: source -> target.
 
 ## Quick start
-1. skillsync config init
+1. skillsync init (--with-samples for a sandbox of example skills)
 2. skillsync discover --format table
 3. skillsync sync --dry-run cursor claude-code
 4. skillsync sync cursor claude-code
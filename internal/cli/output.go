@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+// outputMode selects how command results are rendered.
+type outputMode string
+
+const (
+	// outputModeText renders tables and prose for humans (default).
+	outputModeText outputMode = "text"
+
+	// outputModeJSON renders a single JSON document per command on stdout.
+	outputModeJSON outputMode = "json"
+)
+
+// parseOutputMode parses the --output flag / SKILLSYNC_OUTPUT value.
+func parseOutputMode(s string) (outputMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "text", "table":
+		return outputModeText, nil
+	case "json":
+		return outputModeJSON, nil
+	default:
+		return "", fmt.Errorf("invalid output mode %q (valid: text, json)", s)
+	}
+}
+
+// renderer is the shared sink for command output.
+//
+// In text mode, results and prose go to stdout as before. In JSON mode, each
+// command writes exactly one JSON document to stdout and everything else
+// (progress messages, warnings, confirmation prompts) goes to stderr so stdout
+// stays machine-parseable. Writers are resolved on every call so tests that
+// swap os.Stdout keep capturing output.
+type renderer struct {
+	mode outputMode
+
+	// wrap renders long table cells on several lines instead of truncating them.
+	wrap bool
+
+	// maxWidth overrides the detected terminal width for tables (0 = detect).
+	maxWidth int
+}
+
+// out is the process-wide renderer configured from the global --output flag.
+var out = &renderer{mode: outputModeText}
+
+// configureOutput sets the renderer mode from the global --output flag (or
+// SKILLSYNC_OUTPUT) and table layout from --wrap and --max-width.
+func configureOutput(cmd *cli.Command) error {
+	mode, err := parseOutputMode(cmd.String("output"))
+	if err != nil {
+		return err
+	}
+	out.mode = mode
+
+	maxWidth := cmd.Int("max-width")
+	if maxWidth < 0 {
+		return fmt.Errorf("--max-width must not be negative, got %d", maxWidth)
+	}
+	out.maxWidth = int(maxWidth)
+	out.wrap = cmd.Bool("wrap")
+	return nil
+}
+
+// JSON returns true when results should be emitted as JSON.
+func (r *renderer) JSON() bool {
+	return r.mode == outputModeJSON
+}
+
+// prose returns the writer used for human-oriented messages.
+func (r *renderer) prose() io.Writer {
+	if r.JSON() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// Printf writes a human-oriented message.
+func (r *renderer) Printf(format string, a ...any) {
+	_, _ = fmt.Fprintf(r.prose(), format, a...)
+}
+
+// Println writes a human-oriented message followed by a newline.
+func (r *renderer) Println(a ...any) {
+	_, _ = fmt.Fprintln(r.prose(), a...)
+}
+
+// Print writes a human-oriented message.
+func (r *renderer) Print(a ...any) {
+	_, _ = fmt.Fprint(r.prose(), a...)
+}
+
+// Render emits v as a JSON document in JSON mode, or calls text to render the
+// human-readable form otherwise. A nil text function renders nothing in text
+// mode, for commands that already streamed their prose.
+func (r *renderer) Render(v any, text func() error) error {
+	if r.JSON() {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	if text == nil {
+		return nil
+	}
+	return text()
+}
+
+// Format returns "json" in JSON mode, otherwise the command-specific format.
+// Commands with their own --format flag use this so the global mode wins.
+func (r *renderer) Format(format string) string {
+	if r.JSON() {
+		return "json"
+	}
+	return format
+}
+
+// syncSkillOutput is the JSON representation of a single skill sync result.
+type syncSkillOutput struct {
+	Name             string   `json:"name"`
+	Action           string   `json:"action"`
+	TargetPath       string   `json:"target_path,omitempty"`
+	Message          string   `json:"message,omitempty"`
+	Error            string   `json:"error,omitempty"`
+	Conflict         string   `json:"conflict,omitempty"`
+	Backups          []string `json:"backups,omitempty"`
+	BrokenReferences []string `json:"broken_references,omitempty"`
+	Warnings         []string `json:"warnings,omitempty"`
+	// Diff holds the hunks of the file a dry run would write (sync --show-diff)
+	Diff []diffHunkOutput `json:"diff,omitempty"`
+}
+
+// syncResultOutput is the JSON representation of a sync or delete run.
+type syncResultOutput struct {
+	Source     string            `json:"source"`
+	Target     string            `json:"target"`
+	Strategy   string            `json:"strategy,omitempty"`
+	DryRun     bool              `json:"dry_run"`
+	Success    bool              `json:"success"`
+	Counts     map[string]int    `json:"counts"`
+	Skills     []syncSkillOutput `json:"skills"`
+	HookErrors []string          `json:"hook_errors,omitempty"`
+	SessionID  string            `json:"session_id,omitempty"`
+}
+
+// newSyncResultOutput converts a sync result into its JSON representation.
+func newSyncResultOutput(result *sync.Result) syncResultOutput {
+	output := syncResultOutput{
+		Source:     string(result.Source),
+		Target:     string(result.Target),
+		Strategy:   string(result.Strategy),
+		DryRun:     result.DryRun,
+		Success:    result.Success(),
+		Counts:     make(map[string]int),
+		Skills:     make([]syncSkillOutput, 0, len(result.Skills)),
+		HookErrors: result.HookErrors,
+		SessionID:  result.SessionID,
+	}
+
+	for _, sr := range result.Skills {
+		output.Counts[string(sr.Action)]++
+		skill := syncSkillOutput{
+			Name:             sr.Skill.Name,
+			Action:           string(sr.Action),
+			TargetPath:       sr.TargetPath,
+			Message:          sr.Message,
+			Backups:          sr.BackupIDs,
+			BrokenReferences: sr.BrokenReferences,
+			Warnings:         sr.Warnings,
+		}
+		if sr.Error != nil {
+			skill.Error = sr.Error.Error()
+		}
+		if sr.Conflict != nil {
+			skill.Conflict = sr.Conflict.DiffSummary()
+		}
+		if sr.Preview != nil {
+			for _, hunk := range sr.Preview.Hunks(sync.CurrentDiffOptions()) {
+				skill.Diff = append(skill.Diff, newDiffHunkOutput(hunk))
+			}
+		}
+		output.Skills = append(output.Skills, skill)
+	}
+
+	return output
+}
+
+// backupVerifyOutput is the JSON representation of a single backup verification.
+type backupVerifyOutput struct {
+	ID       string `json:"id"`
+	Platform string `json:"platform,omitempty"`
+	OK       bool   `json:"ok"`
+	Skipped  bool   `json:"skipped,omitempty"`
+	Bytes    int64  `json:"bytes,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// undoOutput is the JSON representation of an undo run.
+type undoOutput struct {
+	RunID    string   `json:"run_id"`
+	DryRun   bool     `json:"dry_run"`
+	Restored []string `json:"restored"`
+	Removed  []string `json:"removed"`
+	Kept     []string `json:"kept,omitempty"` // Created files left in place because they changed after the run
+}
+
+// backupActionOutput is the JSON representation of backup create/delete/restore results.
+type backupActionOutput struct {
+	Action   string            `json:"action"`
+	Count    int               `json:"count"`
+	Backups  []backup.Metadata `json:"backups,omitempty"`
+	Target   string            `json:"target,omitempty"`
+	Freed    int64             `json:"freed_bytes,omitempty"`
+	DryRun   bool              `json:"dry_run,omitempty"`
+	Snapshot string            `json:"snapshot_id,omitempty"`
+}
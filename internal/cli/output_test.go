@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+func TestParseOutputMode(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    outputMode
+		wantErr bool
+	}{
+		"empty defaults to text": {input: "", want: outputModeText},
+		"text":                   {input: "text", want: outputModeText},
+		"table alias":            {input: "table", want: outputModeText},
+		"json":                   {input: "json", want: outputModeJSON},
+		"json uppercase":         {input: " JSON ", want: outputModeJSON},
+		"invalid":                {input: "xml", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseOutputMode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOutputMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseOutputMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRendererRender(t *testing.T) {
+	tests := map[string]struct {
+		mode     outputMode
+		wantJSON bool
+	}{
+		"text mode calls text renderer": {mode: outputModeText},
+		"json mode encodes value":       {mode: outputModeJSON, wantJSON: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &renderer{mode: tt.mode}
+			textCalled := false
+			output := captureOutput(t, func() {
+				err := r.Render(map[string]int{"count": 2}, func() error {
+					textCalled = true
+					return nil
+				})
+				if err != nil {
+					t.Errorf("Render() error = %v", err)
+				}
+			})
+
+			if textCalled == tt.wantJSON {
+				t.Errorf("text renderer called = %v, want %v", textCalled, !tt.wantJSON)
+			}
+			if tt.wantJSON {
+				var decoded map[string]int
+				if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+					t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+				}
+				if decoded["count"] != 2 {
+					t.Errorf("decoded count = %d, want 2", decoded["count"])
+				}
+			}
+		})
+	}
+}
+
+func TestRendererProseGoesToStderrInJSONMode(t *testing.T) {
+	r := &renderer{mode: outputModeJSON}
+	output := captureOutput(t, func() {
+		r.Printf("progress %d\n", 1)
+		r.Println("more progress")
+	})
+	if output != "" {
+		t.Errorf("expected no stdout in JSON mode, got %q", output)
+	}
+}
+
+func TestNewSyncResultOutput(t *testing.T) {
+	result := &sync.Result{
+		Source:   model.ClaudeCode,
+		Target:   model.Cursor,
+		Strategy: sync.StrategyOverwrite,
+		Skills: []sync.SkillResult{
+			{Skill: model.Skill{Name: "a"}, Action: sync.ActionCreated, TargetPath: "/t/a.md"},
+			{Skill: model.Skill{Name: "b"}, Action: sync.ActionFailed, Error: errors.New("boom")},
+		},
+	}
+
+	output := newSyncResultOutput(result)
+	if output.Success {
+		t.Error("expected Success = false when a skill failed")
+	}
+	if output.Counts["created"] != 1 || output.Counts["failed"] != 1 {
+		t.Errorf("unexpected counts: %v", output.Counts)
+	}
+	if output.Skills[1].Error != "boom" {
+		t.Errorf("expected error message to be preserved, got %q", output.Skills[1].Error)
+	}
+}
+
+func TestGlobalOutputJSON(t *testing.T) {
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, ".claude", "skills")
+	cursorSkills := filepath.Join(tempDir, ".cursor", "skills")
+	if err := os.MkdirAll(claudeSkills, 0o750); err != nil {
+		t.Fatalf("failed to create claude dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(claudeSkills, "hello.md"), []byte("---\nname: hello\n---\nHi."), 0o600); err != nil {
+		t.Fatalf("failed to write skill: %v", err)
+	}
+
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CODEX_SKILLS_PATHS", filepath.Join(tempDir, ".codex", "skills"))
+
+	t.Run("sync dry run", func(t *testing.T) {
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(context.Background(), []string{"skillsync", "--output", "json", "sync", "--dry-run", "claudecode", "cursor"})
+		})
+		if runErr != nil {
+			t.Fatalf("sync failed: %v", runErr)
+		}
+
+		var decoded syncResultOutput
+		if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+			t.Fatalf("stdout is not a single JSON document: %v\n%s", err, output)
+		}
+		if !decoded.DryRun || decoded.Target != "cursor" || len(decoded.Skills) != 1 {
+			t.Errorf("unexpected sync output: %+v", decoded)
+		}
+	})
+
+	t.Run("platforms via env", func(t *testing.T) {
+		t.Setenv("SKILLSYNC_OUTPUT", "json")
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(context.Background(), []string{"skillsync", "platforms"})
+		})
+		if runErr != nil {
+			t.Fatalf("platforms failed: %v", runErr)
+		}
+
+		var decoded []platformInfo
+		if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+			t.Fatalf("stdout is not valid JSON: %v\n%s", err, output)
+		}
+		if len(decoded) != len(model.AllPlatforms()) {
+			t.Errorf("expected %d platforms, got %d", len(model.AllPlatforms()), len(decoded))
+		}
+		if decoded[0].SkillCount != 1 {
+			t.Errorf("expected 1 claude-code skill, got %d", decoded[0].SkillCount)
+		}
+	})
+
+	t.Run("backup list", func(t *testing.T) {
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(context.Background(), []string{"skillsync", "--output", "json", "backup", "list"})
+		})
+		if runErr != nil {
+			t.Fatalf("backup list failed: %v", runErr)
+		}
+		var decoded []any
+		if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+			t.Fatalf("stdout is not valid JSON: %v\n%s", err, output)
+		}
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		if err := Run(context.Background(), []string{"skillsync", "--output", "xml", "platforms"}); err == nil {
+			t.Error("expected error for invalid output mode")
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/validation"
+)
+
+// platformInfo describes a supported platform and where its skills live.
+type platformInfo struct {
+	Name        string   `json:"name"`
+	Short       string   `json:"short"`
+	UserPath    string   `json:"user_path"`
+	UserExists  bool     `json:"user_path_exists"`
+	SkillsPaths []string `json:"skills_paths"`
+	SkillCount  int      `json:"skill_count"`
+}
+
+func platformsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "platforms",
+		Usage: "List supported platforms and their skill locations",
+		UsageText: `skillsync platforms
+   skillsync --output json platforms`,
+		Description: `List the AI coding platforms skillsync supports, the directories searched
+   for each one, and how many skills are currently discovered there.
+
+   Plugin skills are not counted.`,
+		Action: func(_ context.Context, _ *cli.Command) error {
+			infos, err := collectPlatformInfo()
+			if err != nil {
+				return err
+			}
+			return out.Render(infos, func() error {
+				return outputPlatformsTable(infos)
+			})
+		},
+	}
+}
+
+// collectPlatformInfo gathers paths and skill counts for every supported platform.
+func collectPlatformInfo() ([]platformInfo, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	infos := make([]platformInfo, 0, len(model.AllPlatforms()))
+	for _, platform := range model.AllPlatforms() {
+		info := platformInfo{
+			Name:  string(platform),
+			Short: platform.Short(),
+		}
+
+		if userPath, err := validation.GetPlatformPath(platform); err == nil {
+			info.UserPath = userPath
+			if stat, err := os.Stat(userPath); err == nil && stat.IsDir() {
+				info.UserExists = true
+			}
+		}
+
+		paths, repoRoot, err := platformSkillsPaths(cfg, platform)
+		if err != nil {
+			return nil, err
+		}
+		info.SkillsPaths = paths
+		info.SkillCount = len(parsePlatformSkillsFromPaths(platform, paths, repoRoot, nil, false))
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// outputPlatformsTable prints platform information as a table.
+func outputPlatformsTable(infos []platformInfo) error {
+	fmt.Printf("%s %s %s %s\n",
+		ui.Header(fmt.Sprintf("%-12s", "PLATFORM")),
+		ui.Header(fmt.Sprintf("%-6s", "SHORT")),
+		ui.Header(fmt.Sprintf("%-7s", "SKILLS")),
+		ui.Header("USER PATH"))
+	fmt.Printf("%-12s %-6s %-7s %s\n", "--------", "-----", "------", "---------")
+
+	for _, info := range infos {
+		userPath := info.UserPath
+		if !info.UserExists {
+			userPath += ui.Dim(" (missing)")
+		}
+		fmt.Printf("%s %-6s %-7d %s\n", colorPlatform(info.Name, 12), info.Short, info.SkillCount, userPath)
+	}
+
+	return nil
+}
@@ -0,0 +1,440 @@
+package cli
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/cache"
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/gitfetch"
+	"github.com/klauern/skillsync/internal/parser/claude"
+	"github.com/klauern/skillsync/internal/parser/plugin"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// pluginListingOutput is the JSON representation of a search result.
+type pluginListingOutput struct {
+	plugin.Listing
+	Install string `json:"install"`
+}
+
+func pluginsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "plugins",
+		Usage: "Search marketplaces for plugins and install them",
+		Description: `Find and install Claude Code plugins from marketplace indexes.
+
+   Marketplaces are the ones added to Claude Code (known_marketplaces.json)
+   plus Git repositories listed in the config, which are cloned to
+   ~/.skillsync/marketplaces and updated on every search:
+
+     plugins:
+       marketplaces:
+         - name: acme
+           url: https://github.com/acme/skill-marketplace
+
+   Installed plugins are placed in ~/.skillsync/plugins, where discover and
+   sync pick up their skills.
+
+   Examples:
+     skillsync plugins search commit          # Plugins or skills matching "commit"
+     skillsync plugins search                 # Everything on offer
+     skillsync plugins install commits        # Install by name
+     skillsync plugins install commits@acme   # Pick a marketplace
+     skillsync plugins update                 # Update every installed plugin`,
+		Commands: []*cli.Command{
+			pluginsSearchCommand(),
+			pluginsInstallCommand(),
+			pluginsUpdateCommand(),
+		},
+	}
+}
+
+func pluginsSearchCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "search",
+		Usage:     "Search marketplaces for plugins and skills",
+		UsageText: "skillsync plugins search [options] [query]",
+		Description: `List plugins whose name or description, or any of whose skills' names
+   or descriptions, contain the query (case-insensitive), with the command
+   to install each.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "marketplace",
+				Aliases: []string{"m"},
+				Usage:   "Only search this marketplace",
+			},
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Value:   "table",
+				Usage:   "Output format: table, json",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			format := out.Format(cmd.String("format"))
+			if format != "table" && format != "json" {
+				return fmt.Errorf("unsupported format: %s (use table or json)", format)
+			}
+
+			listings, err := loadMarketplaceListings(cmd.String("marketplace"))
+			if err != nil {
+				return err
+			}
+			matches := plugin.Search(listings, strings.Join(cmd.Args().Slice(), " "))
+
+			results := make([]pluginListingOutput, 0, len(matches))
+			for _, l := range matches {
+				results = append(results, pluginListingOutput{Listing: l, Install: installHint(l, listings)})
+			}
+			if format == "json" {
+				return out.Render(results, nil)
+			}
+			return outputPluginListings(results)
+		},
+	}
+}
+
+func pluginsInstallCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "install",
+		Usage:     "Install a plugin from a marketplace",
+		UsageText: "skillsync plugins install <plugin>[@marketplace]",
+		Description: `Install a plugin found by 'skillsync plugins search'. A plugin hosted in
+   its own repository is cloned; one inside its marketplace is copied.
+   Qualify the name with @marketplace when several marketplaces offer it.`,
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("install requires exactly one argument: <plugin>[@marketplace]")
+			}
+			name, marketplace, _ := strings.Cut(cmd.Args().First(), "@")
+
+			listings, err := loadMarketplaceListings(marketplace)
+			if err != nil {
+				return err
+			}
+			listing, err := findListing(listings, name)
+			if err != nil {
+				return err
+			}
+
+			dir, err := plugin.Install(listing, util.SkillsyncPluginsPath())
+			if err != nil {
+				return err
+			}
+			// Make the new skills visible to the next discover
+			if pluginCache, err := cache.New(pluginsCacheName); err == nil {
+				_ = pluginCache.Clear()
+			}
+
+			out.Printf("✓ Installed %s@%s to %s\n", listing.Plugin, listing.Marketplace, dir)
+			if len(listing.Skills) > 0 {
+				out.Printf("  Skills: %s\n", strings.Join(listing.Skills, ", "))
+			}
+			return nil
+		},
+	}
+}
+
+// pluginUpdate is the outcome of updating one installed plugin.
+type pluginUpdate struct {
+	Plugin  string                `json:"plugin"`
+	Path    string                `json:"path"`
+	Changes plugin.VersionChanges `json:"changes"`
+	// BackupsMoved is the number of backups that now follow renamed skills
+	BackupsMoved int `json:"backups_moved,omitempty"`
+}
+
+func pluginsUpdateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "update",
+		Usage:     "Update installed plugins and report how their skills changed",
+		UsageText: "skillsync plugins update [plugin...]",
+		Description: `Update the plugins installed with 'skillsync plugins install', or only
+   the named ones: cloned plugins are pulled and copied ones are copied again
+   from their marketplace.
+
+   The summary lists the skills each update added, removed, changed, or
+   renamed. A removed skill whose content a new skill has unchanged was
+   renamed: its backups move to the new name, and the next sync renames its
+   synced copies instead of adding a second one.
+
+   Examples:
+     skillsync plugins update              # Every installed plugin
+     skillsync plugins update commits      # One plugin`,
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			dirs, err := installedPluginDirs(cmd.Args().Slice())
+			if err != nil {
+				return err
+			}
+
+			var listings []plugin.Listing
+			updates := make([]pluginUpdate, 0, len(dirs))
+			for _, dir := range dirs {
+				name := filepath.Base(dir)
+				var listing *plugin.Listing
+				if !plugin.IsCheckout(dir) {
+					if listings == nil {
+						if listings, err = loadMarketplaceListings(""); err != nil {
+							return err
+						}
+					}
+					l, err := findListing(listings, name)
+					if err != nil {
+						return err
+					}
+					listing = &l
+				}
+
+				update, err := updatePlugin(dir, listing)
+				if err != nil {
+					return err
+				}
+				updates = append(updates, update)
+			}
+			// Make the updated skills visible to the next discover
+			if pluginCache, err := cache.New(pluginsCacheName); err == nil {
+				_ = pluginCache.Clear()
+			}
+
+			return out.Render(updates, func() error {
+				outputPluginUpdates(updates)
+				return nil
+			})
+		},
+	}
+}
+
+// installedPluginDirs returns the directories of the installed plugins
+// named, or of all installed plugins.
+func installedPluginDirs(names []string) ([]string, error) {
+	pluginsDir := util.SkillsyncPluginsPath()
+	if len(names) > 0 {
+		dirs := make([]string, 0, len(names))
+		for _, name := range names {
+			dir := filepath.Join(pluginsDir, name)
+			if stat, err := os.Stat(dir); err != nil || !stat.IsDir() || filepath.Base(dir) != name {
+				return nil, fmt.Errorf("plugin %q is not installed in %s", name, pluginsDir)
+			}
+			dirs = append(dirs, dir)
+		}
+		return dirs, nil
+	}
+
+	entries, err := os.ReadDir(pluginsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", pluginsDir, err)
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") && !strings.HasSuffix(entry.Name(), ".update") {
+			dirs = append(dirs, filepath.Join(pluginsDir, entry.Name()))
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no plugins installed in %s (see skillsync plugins install)", pluginsDir)
+	}
+	return dirs, nil
+}
+
+// updatePlugin updates the plugin at dir, compares its skills before and
+// after, and moves the backups of renamed skills to their new names.
+func updatePlugin(dir string, listing *plugin.Listing) (pluginUpdate, error) {
+	update := pluginUpdate{Plugin: filepath.Base(dir), Path: dir}
+	before, err := plugin.New(dir).Parse()
+	if err != nil {
+		return update, fmt.Errorf("failed to read skills of %s: %w", update.Plugin, err)
+	}
+	if err := plugin.Update(dir, listing); err != nil {
+		return update, err
+	}
+	after, err := plugin.New(dir).Parse()
+	if err != nil {
+		return update, fmt.Errorf("failed to read skills of %s: %w", update.Plugin, err)
+	}
+
+	update.Changes = plugin.CompareVersions(before, after)
+	for _, r := range update.Changes.Renamed {
+		if util.NoPersist() {
+			break
+		}
+		moved, err := backup.RenameLineage("", r.From, r.To)
+		if err != nil {
+			warnf("Warning: failed to move backups of %s to %s: %v\n", r.From, r.To, err)
+		}
+		update.BackupsMoved += moved
+	}
+	return update, nil
+}
+
+// outputPluginUpdates prints the skill changes of each updated plugin.
+func outputPluginUpdates(updates []pluginUpdate) {
+	for _, u := range updates {
+		fmt.Printf("✓ Updated %s\n", u.Plugin)
+		c := u.Changes
+		if c.Empty() {
+			fmt.Println("  No skill changes")
+			continue
+		}
+		for _, r := range c.Renamed {
+			fmt.Printf("  Renamed: %s -> %s\n", r.From, r.To)
+		}
+		for _, v := range c.Versions {
+			fmt.Printf("  Version: %s %s -> %s\n", v.Skill, cmp.Or(v.From, "(none)"), v.To)
+		}
+		for _, line := range []struct {
+			label string
+			names []string
+		}{
+			{"Added", c.Added},
+			{"Updated", c.Updated},
+			{"Removed", c.Removed},
+		} {
+			if len(line.names) > 0 {
+				fmt.Printf("  %s: %s\n", line.label, strings.Join(line.names, ", "))
+			}
+		}
+		if u.BackupsMoved > 0 {
+			fmt.Printf("  Moved %d backup(s) to the new skill names\n", u.BackupsMoved)
+		}
+	}
+}
+
+// configuredMarketplaces returns the Claude Code marketplaces with a local
+// checkout, sorted by name, followed by the marketplaces in the config, which
+// are cloned or updated first. Config marketplaces that fail to clone are
+// skipped with a warning.
+func configuredMarketplaces() ([]plugin.Marketplace, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var marketplaces []plugin.Marketplace
+	for name, known := range claude.LoadKnownMarketplaces() {
+		if known.InstallLocation != "" {
+			marketplaces = append(marketplaces, plugin.Marketplace{Name: name, Path: known.InstallLocation})
+		}
+	}
+	sort.Slice(marketplaces, func(i, j int) bool {
+		return marketplaces[i].Name < marketplaces[j].Name
+	})
+
+	for _, mc := range cfg.Plugins.Marketplaces {
+		if mc.URL == "" {
+			continue
+		}
+		name := mc.Name
+		if name == "" {
+			name = gitfetch.RepoName(mc.URL)
+		}
+		path, err := plugin.EnsureRepo(util.SkillsyncMarketplacesPath(), mc.URL)
+		if err != nil {
+			warnf("Warning: skipping marketplace %s: %v\n", name, err)
+			continue
+		}
+		marketplaces = append(marketplaces, plugin.Marketplace{Name: name, Path: path})
+	}
+	return marketplaces, nil
+}
+
+// loadMarketplaceListings returns the plugins offered by every marketplace,
+// or only by the one named only.
+func loadMarketplaceListings(only string) ([]plugin.Listing, error) {
+	marketplaces, err := configuredMarketplaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var listings []plugin.Listing
+	found := false
+	for _, m := range marketplaces {
+		if only != "" && m.Name != only {
+			continue
+		}
+		found = true
+		marketListings, err := plugin.LoadListings(m)
+		if err != nil {
+			warnf("Warning: skipping marketplace %s: %v\n", m.Name, err)
+			continue
+		}
+		listings = append(listings, marketListings...)
+	}
+
+	switch {
+	case only != "" && !found:
+		return nil, fmt.Errorf("marketplace %q not found", only)
+	case len(marketplaces) == 0:
+		return nil, errors.New("no marketplaces found (add one to Claude Code or under plugins.marketplaces in the config)")
+	}
+	return listings, nil
+}
+
+// findListing returns the single listing for plugin name.
+func findListing(listings []plugin.Listing, name string) (plugin.Listing, error) {
+	var matches []plugin.Listing
+	for _, l := range listings {
+		if l.Plugin == name {
+			matches = append(matches, l)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return plugin.Listing{}, fmt.Errorf("plugin %q not found (try skillsync plugins search %s)", name, name)
+	case 1:
+		return matches[0], nil
+	}
+	qualified := make([]string, len(matches))
+	for i, l := range matches {
+		qualified[i] = l.Plugin + "@" + l.Marketplace
+	}
+	return plugin.Listing{}, fmt.Errorf("plugin %q is offered by several marketplaces, pick one of: %s",
+		name, strings.Join(qualified, ", "))
+}
+
+// installHint returns the command that installs l, qualified with its
+// marketplace only when another marketplace offers a plugin of the same name.
+func installHint(l plugin.Listing, all []plugin.Listing) string {
+	target := l.Plugin
+	for _, other := range all {
+		if other.Plugin == l.Plugin && other.Marketplace != l.Marketplace {
+			target += "@" + l.Marketplace
+			break
+		}
+	}
+	return "skillsync plugins install " + target
+}
+
+func outputPluginListings(results []pluginListingOutput) error {
+	if len(results) == 0 {
+		fmt.Println("No matching plugins found.")
+		return nil
+	}
+
+	fmt.Printf("%s %s %s %s\n",
+		ui.Header(fmt.Sprintf("%-24s", "PLUGIN")),
+		ui.Header(fmt.Sprintf("%-20s", "MARKETPLACE")),
+		ui.Header(fmt.Sprintf("%-40s", "DESCRIPTION")),
+		ui.Header("SKILLS"))
+	fmt.Printf("%-24s %-20s %-40s %s\n", "------", "-----------", "-----------", "------")
+
+	for _, r := range results {
+		fmt.Printf("%-24s %-20s %-40s %s\n",
+			truncateCell(r.Plugin, 24), truncateCell(r.Marketplace, 20),
+			truncateCell(r.Description, 40), strings.Join(r.Skills, ", "))
+		fmt.Printf("  %s\n", ui.Dim(r.Install))
+	}
+
+	fmt.Printf("\nTotal: %d plugin(s)\n", len(results))
+	return nil
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/parser/plugin"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// setupMarketplace registers a Claude Code marketplace offering a
+// "commits" plugin with a "conventional-commit" skill.
+func setupMarketplace(t *testing.T) {
+	t.Helper()
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	claudePlugins := t.TempDir()
+	t.Setenv("SKILLSYNC_CLAUDE_PLUGINS_PATH", claudePlugins)
+
+	marketDir := filepath.Join(claudePlugins, "marketplaces", "acme")
+	util.WriteFile(t, filepath.Join(marketDir, ".claude-plugin", "marketplace.json"),
+		`{"name": "acme", "plugins": [{"name": "commits", "description": "Git helpers", "source": "./commits"}]}`)
+	util.WriteFile(t, filepath.Join(marketDir, "commits", "skills", "conventional-commit", "SKILL.md"),
+		"---\nname: conventional-commit\ndescription: Write commit messages\n---\n\nBody\n")
+
+	known, err := json.Marshal(map[string]any{
+		"acme": map[string]any{
+			"source":          map[string]string{"source": "github", "repo": "acme/marketplace"},
+			"installLocation": marketDir,
+		},
+	})
+	util.AssertNoError(t, err)
+	util.WriteFile(t, filepath.Join(claudePlugins, "known_marketplaces.json"), string(known))
+}
+
+func TestPluginsSearchCommand(t *testing.T) {
+	setupMarketplace(t)
+
+	tests := map[string]struct {
+		args []string
+		want []string
+	}{
+		"matches skill": {
+			args: []string{"skillsync", "plugins", "search", "commit"},
+			want: []string{"commits", "acme", "conventional-commit", "skillsync plugins install commits"},
+		},
+		"no match": {
+			args: []string{"skillsync", "plugins", "search", "deploy"},
+			want: []string{"No matching plugins found."},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			output := captureOutput(t, func() {
+				util.AssertNoError(t, Run(context.Background(), tt.args))
+			})
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestPluginsInstallCommand(t *testing.T) {
+	setupMarketplace(t)
+
+	err := Run(context.Background(), []string{"skillsync", "plugins", "install", "commits@acme"})
+	util.AssertNoError(t, err)
+
+	manifest := filepath.Join(util.SkillsyncPluginsPath(), "commits", ".claude-plugin", "plugin.json")
+	if _, err := os.Stat(manifest); err != nil {
+		t.Errorf("expected installed plugin manifest: %v", err)
+	}
+
+	err = Run(context.Background(), []string{"skillsync", "plugins", "install", "missing"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestFindListing(t *testing.T) {
+	listings := []plugin.Listing{
+		{Plugin: "commits", Marketplace: "acme"},
+		{Plugin: "lint", Marketplace: "acme"},
+		{Plugin: "lint", Marketplace: "other"},
+	}
+
+	got, err := findListing(listings, "commits")
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, got.Marketplace, "acme")
+	util.AssertEqual(t, installHint(got, listings), "skillsync plugins install commits")
+	util.AssertEqual(t, installHint(listings[2], listings), "skillsync plugins install lint@other")
+
+	if _, err := findListing(listings, "lint"); err == nil || !strings.Contains(err.Error(), "lint@acme, lint@other") {
+		t.Errorf("expected ambiguity error, got %v", err)
+	}
+}
+
+func TestPluginsUpdateCommand(t *testing.T) {
+	setupMarketplace(t)
+	util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "plugins", "install", "commits"}))
+
+	// A backup of a synced copy of the skill, made before the rename
+	synced := filepath.Join(t.TempDir(), "conventional-commit", "SKILL.md")
+	util.WriteFile(t, synced, "---\nname: conventional-commit\n---\n\nBody\n")
+	_, err := backup.CreateBackup(synced, backup.Options{Platform: "cursor", Metadata: map[string]string{"skill": "conventional-commit"}})
+	util.AssertNoError(t, err)
+
+	// The marketplace's new version renames the skill and adds another
+	skillsDir := filepath.Join(os.Getenv("SKILLSYNC_CLAUDE_PLUGINS_PATH"), "marketplaces", "acme", "commits", "skills")
+	util.AssertNoError(t, os.RemoveAll(filepath.Join(skillsDir, "conventional-commit")))
+	util.WriteFile(t, filepath.Join(skillsDir, "commit-message", "SKILL.md"),
+		"---\nname: commit-message\ndescription: Write commit messages\n---\n\nBody\n")
+	util.WriteFile(t, filepath.Join(skillsDir, "changelog", "SKILL.md"),
+		"---\nname: changelog\ndescription: Update the changelog\n---\n\nList the changes.\n")
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "plugins", "update"})
+	})
+	util.AssertNoError(t, runErr)
+	for _, want := range []string{
+		"Updated commits",
+		"Renamed: conventional-commit -> commit-message",
+		"Added: changelog",
+		"Moved 1 backup(s)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	backups, err := backup.ListBackups("cursor")
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(backups), 1)
+	util.AssertEqual(t, backups[0].LineageKey(), "commit-message")
+
+	err = Run(context.Background(), []string{"skillsync", "plugins", "update", "missing"})
+	if err == nil || !strings.Contains(err.Error(), `plugin "missing" is not installed`) {
+		t.Errorf("update of a missing plugin: got %v", err)
+	}
+}
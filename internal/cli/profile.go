@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// syncProfile returns the sync profile named by --profile, or an empty
+// profile if none was given.
+func syncProfile(cmd *cli.Command, appConfig *config.Config, deleteMode bool) (config.Profile, error) {
+	name := cmd.String("profile")
+	if deleteMode || name == "" {
+		return config.Profile{}, nil
+	}
+	profile, ok := appConfig.Profiles[name]
+	if !ok {
+		names := slices.Sorted(maps.Keys(appConfig.Profiles))
+		if len(names) == 0 {
+			return config.Profile{}, fmt.Errorf("unknown profile %q: no profiles are configured", name)
+		}
+		return config.Profile{}, fmt.Errorf("unknown profile %q (configured: %s)", name, strings.Join(names, ", "))
+	}
+	if profile.Source == "" || profile.Target == "" {
+		return config.Profile{}, fmt.Errorf("profile %q must set both source and target", name)
+	}
+	return profile, nil
+}
+
+// syncTargetScopes returns the scopes a sync into targetSpec writes to.
+func syncTargetScopes(targetSpec model.PlatformSpec, mappings []scopeMapping) []model.SkillScope {
+	if len(mappings) == 0 {
+		return []model.SkillScope{targetSpec.TargetScope()}
+	}
+	scopes := make([]model.SkillScope, 0, len(mappings))
+	for _, m := range mappings {
+		scopes = append(scopes, m.target)
+	}
+	return scopes
+}
+
+// checkWritableScopes refuses syncs into scopes that sync.writable_scopes
+// does not allow.
+func checkWritableScopes(appConfig *config.Config, scopes []model.SkillScope) error {
+	for _, scope := range scopes {
+		if appConfig.ScopeWritable(string(scope)) {
+			continue
+		}
+		where := "the config"
+		switch appConfig.Source("sync.writable_scopes") {
+		case config.SourceProject:
+			where = appConfig.ProjectFile()
+		case config.SourceEnv:
+			where = "SKILLSYNC_SYNC_WRITABLE_SCOPES"
+		}
+		return fmt.Errorf("%s scope is not writable: sync.writable_scopes in %s allows only %s",
+			scope, where, strings.Join(appConfig.Sync.WritableScopes, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// setupProjectRepo creates a repository with a repo-scope Claude Code skill
+// and the given .skillsync.yaml, and makes it the working directory.
+func setupProjectRepo(t *testing.T, project string) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	repo := filepath.Join(tempDir, "repo")
+	util.WriteFile(t, filepath.Join(repo, ".claude", "skills", "shared", "SKILL.md"),
+		"---\nname: shared\ndescription: Team skill\n---\nFor the team.\n")
+	util.WriteFile(t, filepath.Join(repo, ".skillsync.yaml"), project)
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o750); err != nil {
+		t.Fatalf("failed to create repo: %v", err)
+	}
+	t.Chdir(repo)
+
+	t.Setenv("HOME", filepath.Join(tempDir, "home"))
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	return repo
+}
+
+func TestSyncProfile(t *testing.T) {
+	repo := setupProjectRepo(t, `profiles:
+  team:
+    source: claudecode:repo
+    target: cursor:repo
+    strategy: skip
+`)
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "sync", "--yes", "--skip-backup", "--profile", "team"})
+	})
+	util.AssertNoError(t, runErr)
+
+	if _, err := os.Stat(filepath.Join(repo, ".cursor", "skills", "shared")); err != nil {
+		t.Errorf("profile sync did not create the cursor skill: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "using skip strategy") {
+		t.Errorf("sync did not use the profile strategy:\n%s", output)
+	}
+}
+
+func TestSyncProfile_Errors(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"unknown profile": {
+			args:    []string{"--profile", "missing"},
+			wantErr: `unknown profile "missing" (configured: team)`,
+		},
+		"profile with arguments": {
+			args:    []string{"--profile", "team", "claudecode", "cursor"},
+			wantErr: "--profile sets the source and target",
+		},
+		"scope not writable": {
+			args:    []string{"claudecode:repo", "cursor:user"},
+			wantErr: "user scope is not writable: sync.writable_scopes in ",
+		},
+		"mapped scope not writable": {
+			args:    []string{"--map", "repo=user", "claudecode", "cursor"},
+			wantErr: "user scope is not writable",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			setupProjectRepo(t, "sync:\n  writable_scopes: [repo]\nprofiles:\n  team:\n    source: claudecode:repo\n    target: cursor:repo\n")
+
+			err := Run(context.Background(), append([]string{"skillsync", "sync", "--dry-run"}, tt.args...))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
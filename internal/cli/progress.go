@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+// progressBarWidth is the number of cells in a progress bar.
+const progressBarWidth = 30
+
+// progressBar redraws a single-line progress bar in place. It writes nothing
+// unless its writer is a terminal, so piped and captured output stays clean.
+type progressBar struct {
+	w     io.Writer
+	label string
+	total int
+	shown bool
+}
+
+// newProgressBar returns a progress bar on stderr, or a silent one when
+// stderr is not a terminal.
+func newProgressBar(label string, total int) *progressBar {
+	bar := &progressBar{label: label, total: total}
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		bar.w = os.Stderr
+	}
+	return bar
+}
+
+// Update redraws the bar for completed of total items, followed by detail.
+func (b *progressBar) Update(completed int, detail string) {
+	if b.w == nil {
+		return
+	}
+	b.shown = true
+	_, _ = fmt.Fprintf(b.w, "\r\033[K%s", renderProgressBar(b.label, completed, b.total, detail))
+}
+
+// Done clears the bar so the next output starts on a clean line.
+func (b *progressBar) Done() {
+	if b.w == nil || !b.shown {
+		return
+	}
+	_, _ = fmt.Fprint(b.w, "\r\033[K")
+}
+
+// renderProgressBar formats a progress line such as
+// "Verifying [=====>    ] 12/40 4.0 KB".
+func renderProgressBar(label string, completed, total int, detail string) string {
+	filled := progressBarWidth
+	if total > 0 {
+		filled = min(completed*progressBarWidth/total, progressBarWidth)
+	}
+	cells := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		cells += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+	line := fmt.Sprintf("%s [%s] %d/%d", label, cells, completed, total)
+	if detail != "" {
+		line += " " + detail
+	}
+	return line
+}
+
+// syncProgress returns a sync.Options Progress callback that draws a bar
+// labeled label for total skills, and a function that clears the bar. Both
+// do nothing unless show is set and output is not quiet.
+func syncProgress(show bool, label string, total int) (progress func(sync.ProgressEvent), done func()) {
+	if !show || quiet() {
+		return nil, func() {}
+	}
+	bar := newProgressBar(label, total)
+	return func(e sync.ProgressEvent) {
+		bar.Update(e.Completed, e.Result.Skill.Name)
+	}, bar.Done
+}
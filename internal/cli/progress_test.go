@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestRenderProgressBar(t *testing.T) {
+	tests := map[string]struct {
+		completed int
+		total     int
+		detail    string
+		want      string
+	}{
+		"start": {
+			completed: 0,
+			total:     10,
+			want:      "Verifying [>" + strings.Repeat(" ", 29) + "] 0/10",
+		},
+		"halfway with detail": {
+			completed: 5,
+			total:     10,
+			detail:    "2.0 KB",
+			want:      "Verifying [" + strings.Repeat("=", 15) + ">" + strings.Repeat(" ", 14) + "] 5/10 2.0 KB",
+		},
+		"done": {
+			completed: 10,
+			total:     10,
+			want:      "Verifying [" + strings.Repeat("=", 30) + "] 10/10",
+		},
+		"empty": {
+			want: "Verifying [" + strings.Repeat("=", 30) + "] 0/0",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, renderProgressBar("Verifying", tt.completed, tt.total, tt.detail), tt.want)
+		})
+	}
+}
+
+func TestSyncProgress(t *testing.T) {
+	tests := map[string]struct {
+		show         bool
+		quiet        bool
+		wantCallback bool
+	}{
+		"off by default":       {},
+		"--progress":           {show: true, wantCallback: true},
+		"--quiet overrides it": {show: true, quiet: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			quietMode.Store(tt.quiet)
+			t.Cleanup(func() { quietMode.Store(false) })
+
+			progress, done := syncProgress(tt.show, "Syncing", 2)
+			util.AssertEqual(t, progress != nil, tt.wantCallback)
+			if progress != nil {
+				// Not a terminal under test, so the bar draws nothing
+				progress(sync.ProgressEvent{Result: sync.SkillResult{Skill: model.Skill{Name: "lint"}}, Completed: 1, Total: 2})
+			}
+			done()
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/reconcile"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// reconcileOutput is the JSON representation of a reconcile run.
+type reconcileOutput struct {
+	DryRun  bool               `json:"dry_run"`
+	Plan    reconcile.Plan     `json:"plan"`
+	Results []syncResultOutput `json:"results,omitempty"`
+}
+
+func reconcileCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "reconcile",
+		Usage: "Converge skills across several platforms at once (experimental)",
+		UsageText: `skillsync reconcile [options] [platform...]
+   skillsync reconcile --dry-run
+   skillsync reconcile claudecode cursor codex
+   skillsync reconcile --prefer cursor --yes`,
+		Description: `Reconcile every skill across the given platforms (default: all) in one
+   pass, instead of running pairwise syncs that can undo each other.
+
+   Each skill has a version vector: one counter per platform, incremented
+   when that platform's copy changed since the last reconcile. The copy
+   whose vector dominates the others is written to every platform that
+   differs or lacks the skill. Copies changed on more than one platform
+   since the last reconcile are a conflict and are left alone, unless
+   --prefer names the platform whose copy wins.
+
+   Copies are compared by their content; frontmatter, which platforms
+   transform, is not compared. The first reconcile has no history, so
+   skills that differ between platforms are conflicts until they are
+   resolved once with --prefer or a sync. Skills removed from a platform
+   are re-created there; deletions are not propagated.
+
+   Each platform's copy is the one it resolves; platforms without the
+   skill get it in the user scope. Vectors are kept in
+   ~/.skillsync/metadata/reconcile.json.
+
+   Examples:
+     skillsync reconcile --dry-run               # Show the convergence plan
+     skillsync reconcile claudecode cursor codex # Reconcile three platforms
+     skillsync reconcile --prefer claudecode     # Settle conflicts with Claude Code`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"d"},
+				Usage:   "Show the plan without writing skills or recording vectors",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Apply the plan without asking",
+			},
+			&cli.StringFlag{
+				Name:  "prefer",
+				Usage: "Platform whose copy wins conflicts",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-backup",
+				Usage: "Skip the backup of the copies the plan replaces",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return runReconcile(cmd)
+		},
+	}
+}
+
+// runReconcile plans the convergence of the given platforms and, after
+// confirmation, writes the winning copies and records the new vectors.
+func runReconcile(cmd *cli.Command) error {
+	platforms := model.AllPlatforms()
+	if cmd.Args().Len() > 0 {
+		platforms = platforms[:0:0]
+		for _, arg := range cmd.Args().Slice() {
+			platform, err := model.ParsePlatform(arg)
+			if err != nil {
+				return fmt.Errorf("invalid platform: %w", err)
+			}
+			platforms = append(platforms, platform)
+		}
+	}
+	if len(platforms) < 2 {
+		return errors.New("reconcile requires at least 2 platforms")
+	}
+	var prefer model.Platform
+	if name := cmd.String("prefer"); name != "" {
+		platform, err := model.ParsePlatform(name)
+		if err != nil {
+			return fmt.Errorf("invalid --prefer platform: %w", err)
+		}
+		prefer = platform
+	}
+
+	copies, err := resolvedCopies(platforms)
+	if err != nil {
+		return err
+	}
+	statePath := reconcile.StatePath()
+	state, err := reconcile.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	plan := reconcile.NewPlan(platforms, copies, state, reconcile.Options{Prefer: prefer})
+	output := reconcileOutput{DryRun: cmd.Bool("dry-run"), Plan: plan}
+	updates := 0
+	for _, sp := range plan.Skills {
+		updates += len(sp.Updates)
+	}
+
+	if output.DryRun {
+		return out.Render(output, func() error {
+			printReconcilePlan(plan)
+			return nil
+		})
+	}
+	if !out.JSON() {
+		printReconcilePlan(plan)
+	}
+	if updates > 0 && !cmd.Bool("yes") {
+		confirmed, err := confirmAction(fmt.Sprintf("Write %d update(s) across %d platform(s)?", updates, len(platforms)), riskLevelWarning)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Reconcile cancelled.")
+			return nil
+		}
+	}
+
+	results, err := applyReconcilePlan(plan, copies, !skipBackup(cmd))
+	for _, result := range results {
+		output.Results = append(output.Results, newSyncResultOutput(result))
+	}
+	if err != nil {
+		return err
+	}
+
+	// Record what the platforms hold now, so the next run only counts new changes
+	if copies, err = resolvedCopies(platforms); err != nil {
+		return err
+	}
+	state.Record(plan, copies)
+	if err := state.Save(statePath); err != nil {
+		return err
+	}
+
+	failed := false
+	for _, result := range results {
+		recordSyncWarnings(result)
+		failed = failed || !result.Success()
+	}
+	if err := out.Render(output, func() error {
+		out.Printf("\nWrote %d update(s)\n", updates)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if failed {
+		return errors.New("reconcile completed with errors")
+	}
+	return nil
+}
+
+// resolvedCopies returns, per platform, the copy of each skill the platform
+// resolves: the one in its highest-precedence scope.
+func resolvedCopies(platforms []model.Platform) (map[model.Platform][]model.Skill, error) {
+	copies := make(map[model.Platform][]model.Skill, len(platforms))
+	for _, platform := range platforms {
+		skills, err := parsePlatformSkillsWithScope(platform, nil, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s skills: %w", platform, err)
+		}
+		seen := make(map[string]bool, len(skills))
+		for _, skill := range skills {
+			if !seen[skill.Name] {
+				seen[skill.Name] = true
+				copies[platform] = append(copies[platform], skill)
+			}
+		}
+	}
+	return copies, nil
+}
+
+// reconcileTarget is a platform scope the plan writes skills to.
+type reconcileTarget struct {
+	platform model.Platform
+	scope    model.SkillScope
+}
+
+// applyReconcilePlan writes the source copy of each planned skill to the
+// platforms it updates: into the scope of the copy it replaces, or the user
+// scope for new copies. It returns one sync result per platform scope.
+func applyReconcilePlan(plan reconcile.Plan, copies map[model.Platform][]model.Skill, backupEnabled bool) ([]*sync.Result, error) {
+	find := func(platform model.Platform, name string) (model.Skill, bool) {
+		for _, skill := range copies[platform] {
+			if skill.Name == name {
+				return skill, true
+			}
+		}
+		return model.Skill{}, false
+	}
+
+	var order []reconcileTarget
+	batches := make(map[reconcileTarget][]model.Skill)
+	for _, sp := range plan.Skills {
+		source, ok := find(sp.Source, sp.Name)
+		if !ok {
+			continue
+		}
+		for _, update := range sp.Updates {
+			target := reconcileTarget{platform: update.Platform, scope: model.ScopeUser}
+			if existing, ok := find(update.Platform, sp.Name); ok {
+				target.scope = existing.Scope
+			}
+			if _, ok := batches[target]; !ok {
+				order = append(order, target)
+			}
+			batches[target] = append(batches[target], source)
+		}
+	}
+	if len(order) == 0 {
+		return nil, nil
+	}
+
+	startedAt := time.Now()
+	sessionID := backup.NewSessionID()
+	results := make([]*sync.Result, 0, len(order))
+	for _, target := range order {
+		opts := sync.Options{
+			Strategy:    sync.StrategyOverwrite,
+			TargetScope: target.scope,
+			SessionID:   sessionID,
+			Backup:      backupEnabled,
+		}
+		if backupEnabled {
+			prepareBackup(target.platform)
+		}
+		result, err := sync.New().SyncWithSkills(batches[target], target.platform, opts)
+		if err != nil {
+			recordHistory(sessionID, "reconcile", startedAt, results...)
+			return results, fmt.Errorf("reconcile into %s:%s failed: %w", target.platform, target.scope, err)
+		}
+		results = append(results, result)
+	}
+	recordHistory(sessionID, "reconcile", startedAt, results...)
+	return results, nil
+}
+
+// printReconcilePlan prints the plan as a table followed by a summary.
+func printReconcilePlan(plan reconcile.Plan) {
+	if len(plan.Skills) == 0 {
+		fmt.Println("No skills found on " + platformList(plan.Platforms))
+		return
+	}
+
+	fmt.Printf("%s %s %s %s %s\n",
+		ui.Header(fmt.Sprintf("%-28s", "SKILL")),
+		ui.Header(fmt.Sprintf("%-10s", "STATUS")),
+		ui.Header(fmt.Sprintf("%-12s", "SOURCE")),
+		ui.Header(fmt.Sprintf("%-28s", "UPDATES")),
+		ui.Header("VECTOR"))
+	fmt.Printf("%-28s %-10s %-12s %-28s %s\n", "-----", "------", "------", "-------", "------")
+
+	counts := make(map[reconcile.Status]int)
+	for _, sp := range plan.Skills {
+		counts[sp.Status]++
+		status := string(sp.Status)
+		switch sp.Status {
+		case reconcile.StatusConflict:
+			status = ui.Error(fmt.Sprintf("%-10s", status))
+		case reconcile.StatusPropagate:
+			status = ui.Warning(fmt.Sprintf("%-10s", status))
+		default:
+			status = ui.Dim(fmt.Sprintf("%-10s", status))
+		}
+
+		updates := make([]string, 0, len(sp.Updates))
+		for _, update := range sp.Updates {
+			label := string(update.Platform)
+			if update.Create {
+				label += " (new)"
+			}
+			updates = append(updates, label)
+		}
+		source := string(sp.Source)
+		if sp.Status == reconcile.StatusConflict {
+			source = "changed: " + platformList(sp.Changed)
+		}
+		fmt.Printf("%-28s %s %-12s %-28s %s\n",
+			truncateCell(sp.Name, 28), status, truncateCell(source, 12), truncateCell(strings.Join(updates, ", "), 28), sp.Vector)
+	}
+
+	fmt.Printf("\n%d converged, %d to propagate, %d conflict(s)\n",
+		counts[reconcile.StatusConverged], counts[reconcile.StatusPropagate], counts[reconcile.StatusConflict])
+	if counts[reconcile.StatusConflict] > 0 {
+		fmt.Println(ui.Dim("Conflicts are left alone; settle them with --prefer <platform>"))
+	}
+}
+
+// platformList joins platform names with commas.
+func platformList(platforms []model.Platform) string {
+	names := make([]string, len(platforms))
+	for i, platform := range platforms {
+		names[i] = string(platform)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestReconcileCommand(t *testing.T) {
+	claudeSkills, cursorSkills := setupStore(t)
+	codexSkills := filepath.Join(filepath.Dir(claudeSkills), "codex")
+	t.Setenv("SKILLSYNC_CODEX_SKILLS_PATHS", codexSkills)
+	t.Setenv("SKILLSYNC_CODEX_PATH", codexSkills)
+
+	skillFile := func(dir string) string { return filepath.Join(dir, "lint", "SKILL.md") }
+	write := func(dir, body string) {
+		util.WriteFile(t, skillFile(dir), "---\nname: lint\ndescription: Lint code\n---\n"+body+"\n")
+	}
+	body := func(dir string) string {
+		data, err := os.ReadFile(skillFile(dir))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", skillFile(dir), err)
+		}
+		return string(data)
+	}
+	reconcileArgs := func(extra ...string) []string {
+		return append([]string{"skillsync", "reconcile", "--yes", "--skip-backup", "claudecode", "cursor", "codex"}, extra...)
+	}
+	ctx := context.Background()
+
+	write(claudeSkills, "Run the linter.")
+	write(cursorSkills, "Run the linter.")
+
+	t.Run("creates missing copies", func(t *testing.T) {
+		captureOutput(t, func() {
+			util.AssertNoError(t, Run(ctx, reconcileArgs()))
+		})
+		if !strings.Contains(body(codexSkills), "Run the linter.") {
+			t.Errorf("codex copy not created:\n%s", body(codexSkills))
+		}
+	})
+
+	t.Run("propagates a change from one platform", func(t *testing.T) {
+		write(cursorSkills, "Run the linter twice.")
+		captureOutput(t, func() {
+			util.AssertNoError(t, Run(ctx, reconcileArgs()))
+		})
+		for _, dir := range []string{claudeSkills, codexSkills} {
+			if !strings.Contains(body(dir), "Run the linter twice.") {
+				t.Errorf("change not propagated to %s:\n%s", dir, body(dir))
+			}
+		}
+	})
+
+	t.Run("leaves concurrent changes alone", func(t *testing.T) {
+		write(claudeSkills, "Claude change.")
+		write(codexSkills, "Codex change.")
+		output := captureOutput(t, func() {
+			util.AssertNoError(t, Run(ctx, reconcileArgs()))
+		})
+		if !strings.Contains(output, "1 conflict(s)") {
+			t.Errorf("expected a conflict, got:\n%s", output)
+		}
+		if !strings.Contains(body(cursorSkills), "Run the linter twice.") {
+			t.Errorf("conflicting skill was written:\n%s", body(cursorSkills))
+		}
+	})
+
+	t.Run("dry run with a preferred platform", func(t *testing.T) {
+		output := captureOutput(t, func() {
+			util.AssertNoError(t, Run(ctx, reconcileArgs("--dry-run", "--prefer", "codex")))
+		})
+		if !strings.Contains(output, "propagate") || strings.Contains(body(cursorSkills), "Codex change.") {
+			t.Errorf("unexpected dry run:\n%s", output)
+		}
+	})
+
+	t.Run("preferred platform settles the conflict", func(t *testing.T) {
+		captureOutput(t, func() {
+			util.AssertNoError(t, Run(ctx, reconcileArgs("--prefer", "codex")))
+		})
+		for _, dir := range []string{claudeSkills, cursorSkills} {
+			if !strings.Contains(body(dir), "Codex change.") {
+				t.Errorf("preferred copy not written to %s:\n%s", dir, body(dir))
+			}
+		}
+	})
+
+	t.Run("one platform", func(t *testing.T) {
+		if err := Run(ctx, []string{"skillsync", "reconcile", "cursor"}); err == nil {
+			t.Error("expected an error for a single platform")
+		}
+	})
+}
@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/gitfetch"
+	"github.com/klauern/skillsync/internal/model"
+	skillsparser "github.com/klauern/skillsync/internal/parser/skills"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// refreshOutput is the JSON representation of a refresh run.
+type refreshOutput struct {
+	Name     string            `json:"name"`
+	Platform string            `json:"platform"`
+	Scope    string            `json:"scope"`
+	Origin   model.Origin      `json:"origin"`
+	UpToDate bool              `json:"up_to_date"`
+	DryRun   bool              `json:"dry_run,omitempty"`
+	Hunks    []diffHunkOutput  `json:"hunks,omitempty"`
+	Result   *syncResultOutput `json:"result,omitempty"`
+}
+
+func refreshCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "refresh",
+		Usage: "Fetch an imported skill again from where it came from",
+		UsageText: `skillsync refresh <skill> [--platform PLATFORM] [--dry-run] [--yes]
+   skillsync refresh review
+   skillsync refresh review --platform cursor --dry-run`,
+		Description: `Fetch a skill imported with 'skillsync import --git' or '--url' again
+   from its recorded origin, show how it changed, and update it.
+
+   Git origins are cloned at the recorded ref (the default branch when none
+   was given) and the recorded file is read; the new commit is recorded.
+   The local copy is backed up before it is replaced. Plugin skills are
+   updated with 'skillsync plugins update' instead.
+
+   Examples:
+     skillsync refresh review              # Show the changes and update
+     skillsync refresh review --dry-run    # Only show the changes
+     skillsync refresh review --yes        # Update without asking`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Usage:   "Platform whose copy of the skill to refresh (default: the first platform that has it)",
+			},
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"d"},
+				Usage:   "Show the changes without updating the skill",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Update the skill without asking",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-backup",
+				Usage: "Skip the backup of the replaced skill",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("refresh requires exactly 1 argument: <skill>")
+			}
+			return runRefresh(cmd, cmd.Args().First())
+		},
+	}
+}
+
+// runRefresh re-fetches a skill from its recorded origin and, after
+// confirmation, overwrites the local copy with it.
+func runRefresh(cmd *cli.Command, name string) error {
+	local, err := resolveSkill(name, cmd.String("platform"))
+	if err != nil {
+		return err
+	}
+	origin, ok := local.Origin()
+	if !ok {
+		return fmt.Errorf("skill %q has no recorded origin (import it with --git or --url)", name)
+	}
+	if origin.Type == model.OriginPlugin || local.Scope == model.ScopePlugin {
+		return fmt.Errorf("skill %q comes from a plugin; update it with skillsync plugins update", name)
+	}
+
+	fetched, err := fetchOrigin(origin, local.Platform)
+	if err != nil {
+		return err
+	}
+	fetched = refreshedSkill(local, fetched)
+
+	// Compare with the old commit recorded so only content changes count.
+	diffs := diffSkills([]model.Skill{local}, []model.Skill{fetched.WithOrigin(origin)}, sync.CurrentDiffOptions())
+	newOrigin, _ := fetched.Origin()
+	output := refreshOutput{
+		Name:     local.Name,
+		Platform: string(local.Platform),
+		Scope:    string(local.Scope),
+		Origin:   newOrigin,
+		UpToDate: len(diffs) == 1 && diffs[0].Status == diffStatusIdentical,
+		DryRun:   cmd.Bool("dry-run"),
+	}
+	if !output.UpToDate {
+		output.Hunks = diffs[0].Hunks
+	}
+
+	if output.UpToDate || output.DryRun {
+		return out.Render(output, func() error {
+			printRefreshDiff(output, diffs[0])
+			return nil
+		})
+	}
+
+	if !out.JSON() {
+		printRefreshDiff(output, diffs[0])
+	}
+	if !cmd.Bool("yes") {
+		confirmed, err := confirmAction(fmt.Sprintf("Update %s on %s:%s?", local.Name, local.Platform, local.Scope), riskLevelWarning)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Refresh cancelled.")
+			return nil
+		}
+	}
+
+	startedAt := time.Now()
+	opts := sync.Options{
+		Strategy:    sync.StrategyOverwrite,
+		TargetScope: local.Scope,
+		SessionID:   backup.NewSessionID(),
+		Backup:      !skipBackup(cmd),
+	}
+	if opts.Backup {
+		prepareBackup(local.Platform)
+	}
+	result, err := sync.New().SyncWithSkills([]model.Skill{fetched}, local.Platform, opts)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", local.Name, err)
+	}
+	recordHistory(opts.SessionID, "refresh", startedAt, result)
+	recordSyncWarnings(result)
+
+	resultOutput := newSyncResultOutput(result)
+	output.Result = &resultOutput
+	if err := out.Render(output, func() error {
+		out.Printf("Updated %s from %s\n", local.Name, newOrigin)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if !result.Success() {
+		return fmt.Errorf("failed to update %s", local.Name)
+	}
+	return nil
+}
+
+// refreshedSkill returns the local skill updated with the fetched content,
+// description, tools, and frontmatter. Frontmatter keys only the local copy
+// has, such as platform-specific ones, are kept.
+func refreshedSkill(local, fetched model.Skill) model.Skill {
+	updated := local
+	updated.Content = fetched.Content
+	updated.Tools = fetched.Tools
+	updated.Metadata = make(map[string]string, len(local.Metadata)+len(fetched.Metadata))
+	for key, value := range local.Metadata {
+		updated.Metadata[key] = value
+	}
+	for key, value := range fetched.Metadata {
+		updated.Metadata[key] = value
+	}
+	// Some parsers, like Cursor's, keep the description as frontmatter
+	if _, ok := local.Metadata["description"]; ok && local.Description == "" {
+		updated.Metadata["description"] = fetched.Description
+	} else {
+		updated.Description = fetched.Description
+	}
+	return updated
+}
+
+// fetchOrigin downloads a skill again from where it was imported, with the
+// commit it was read at recorded in its origin.
+func fetchOrigin(origin model.Origin, platform model.Platform) (model.Skill, error) {
+	switch origin.Type {
+	case model.OriginURL:
+		out.Printf("Downloading %s...\n", origin.URL)
+		return fetchURLSkill(origin.URL, platform)
+	case model.OriginGit:
+		return fetchGitOrigin(origin, platform)
+	default:
+		return model.Skill{}, fmt.Errorf("cannot refresh from a %q origin", origin.Type)
+	}
+}
+
+// fetchGitOrigin clones the origin repository at its ref and parses the
+// recorded skill file.
+func fetchGitOrigin(origin model.Origin, platform model.Platform) (model.Skill, error) {
+	if origin.Path == "" || !filepath.IsLocal(filepath.FromSlash(origin.Path)) {
+		return model.Skill{}, fmt.Errorf("origin %s has no valid skill path", origin)
+	}
+
+	out.Printf("Cloning %s...\n", origin.URL)
+	dir, cleanup, err := gitfetch.CloneTemp(origin.URL, gitfetch.Options{Ref: origin.Ref})
+	if err != nil {
+		return model.Skill{}, fmt.Errorf("failed to clone %s: %w", origin.URL, err)
+	}
+	defer cleanup()
+
+	file := filepath.Join(dir, filepath.FromSlash(origin.Path))
+	// #nosec G304 - file is inside the temporary clone
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return model.Skill{}, fmt.Errorf("%s not found in %s: %w", origin.Path, origin.URL, err)
+	}
+	skill, err := skillsparser.ParseSkillContent(content, filepath.Base(filepath.Dir(file)), platform)
+	if err != nil {
+		return model.Skill{}, fmt.Errorf("failed to parse %s: %w", origin.Path, err)
+	}
+
+	origin.Commit, err = gitfetch.Head(dir)
+	if err != nil {
+		stderrWarnf("Warning: could not read the commit of %s: %v\n", origin.URL, err)
+	}
+	return skill.WithOrigin(origin), nil
+}
+
+// printRefreshDiff prints how the fetched skill differs from the local copy.
+func printRefreshDiff(output refreshOutput, d skillDiff) {
+	if output.UpToDate {
+		fmt.Printf("%s is up to date with %s\n", output.Name, output.Origin)
+		return
+	}
+	fmt.Printf("\n%s\n", ui.Header("refresh "+output.Name))
+	fmt.Println(ui.Error("--- " + d.SourcePath))
+	fmt.Println(ui.Success("+++ " + output.Origin.String()))
+	if d.MetadataDiffers {
+		fmt.Println(ui.Warning("metadata differs (description, tools, or frontmatter)"))
+	}
+	for i, hunk := range d.Hunks {
+		fmt.Println(ui.Info(hunk.header()))
+		for _, line := range d.conflict.Hunks[i].Lines {
+			fmt.Println(formatDiffLine(line))
+		}
+	}
+	if output.DryRun {
+		fmt.Println(ui.Dim("\nDry run: the skill was not updated"))
+	}
+}
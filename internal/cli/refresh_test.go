@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestRefreshCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	cursorSkills := filepath.Join(tempDir, ".cursor", "skills")
+	t.Setenv("SKILLSYNC_HOME", tempDir)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+
+	var content atomic.Value
+	content.Store("---\ndescription: Review code\n---\nReview the change.")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(content.Load().(string)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	skillURL := server.URL + "/review.md"
+	skillPath := filepath.Join(cursorSkills, "review.md")
+	captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "import", "--url", skillURL, "--yes", "--skip-backup", "cursor"}); err != nil {
+			t.Errorf("import --url failed: %v", err)
+		}
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		output := captureOutput(t, func() {
+			util.AssertNoError(t, Run(ctx, []string{"skillsync", "refresh", "review", "--platform", "cursor", "--yes"}))
+		})
+		if !strings.Contains(output, "review is up to date") {
+			t.Errorf("expected up to date, got:\n%s", output)
+		}
+	})
+
+	content.Store("---\ndescription: Review code\n---\nReview the change carefully.")
+
+	t.Run("dry run shows the change", func(t *testing.T) {
+		output := captureOutput(t, func() {
+			util.AssertNoError(t, Run(ctx, []string{"skillsync", "refresh", "review", "--platform", "cursor", "--dry-run"}))
+		})
+		if !strings.Contains(output, "+Review the change carefully.") {
+			t.Errorf("expected diff in output, got:\n%s", output)
+		}
+		data, err := os.ReadFile(skillPath)
+		util.AssertNoError(t, err)
+		if strings.Contains(string(data), "carefully") {
+			t.Errorf("dry run updated the skill:\n%s", data)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		captureOutput(t, func() {
+			util.AssertNoError(t, Run(ctx, []string{"skillsync", "refresh", "review", "--platform", "cursor", "--yes", "--skip-backup"}))
+		})
+		data, err := os.ReadFile(skillPath)
+		util.AssertNoError(t, err)
+		for _, want := range []string{"Review the change carefully.", "source_url: " + skillURL} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("refreshed skill missing %q:\n%s", want, data)
+			}
+		}
+	})
+
+	t.Run("no origin", func(t *testing.T) {
+		util.AssertNoError(t, os.WriteFile(filepath.Join(cursorSkills, "local.md"), []byte("---\nname: local\ndescription: Local\n---\nBody"), 0o600))
+		err := Run(ctx, []string{"skillsync", "refresh", "local", "--platform", "cursor", "--yes"})
+		if err == nil || !strings.Contains(err.Error(), "no recorded origin") {
+			t.Errorf("expected no recorded origin error, got %v", err)
+		}
+	})
+}
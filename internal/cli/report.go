@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+// syncReportVersion is bumped when the report format changes incompatibly.
+const syncReportVersion = 1
+
+// syncReport is the run report sync --report writes: what an automated sync
+// did, for CI artifacts and audits. It is written only to the given file.
+type syncReport struct {
+	ReportVersion int              `json:"report_version"`
+	Version       string           `json:"skillsync_version"`
+	StartedAt     time.Time        `json:"started_at"`
+	FinishedAt    time.Time        `json:"finished_at"`
+	DurationMS    int64            `json:"duration_ms"`
+	DryRun        bool             `json:"dry_run"`
+	Success       bool             `json:"success"`
+	Error         string           `json:"error,omitempty"`
+	Totals        syncReportTotals `json:"totals"`
+	Runs          []syncReportRun  `json:"runs"`
+}
+
+// syncReportTotals sums the runs of a report.
+type syncReportTotals struct {
+	Skills       int            `json:"skills"`
+	Counts       map[string]int `json:"counts"`
+	BytesWritten int64          `json:"bytes_written"`
+	Conflicts    int            `json:"conflicts"`
+	Backups      []string       `json:"backups"`
+}
+
+// syncReportRun is one source -> target sync of a report; workspace and
+// --map syncs have one per repository or scope pair.
+type syncReportRun struct {
+	Label        string            `json:"label,omitempty"`
+	Source       string            `json:"source"`
+	Target       string            `json:"target"`
+	Strategy     string            `json:"strategy,omitempty"`
+	SessionID    string            `json:"session_id,omitempty"`
+	Success      bool              `json:"success"`
+	DurationMS   int64             `json:"duration_ms"`
+	BytesWritten int64             `json:"bytes_written"`
+	Counts       map[string]int    `json:"counts"`
+	Conflicts    []string          `json:"conflicts"`
+	Backups      []string          `json:"backups"`
+	HookErrors   []string          `json:"hook_errors,omitempty"`
+	Skills       []syncReportSkill `json:"skills"`
+}
+
+// syncReportSkill is the outcome of one skill, as in the JSON output of
+// sync, with its duration and the bytes written for it.
+type syncReportSkill struct {
+	syncSkillOutput
+	DurationMS   float64 `json:"duration_ms"`
+	BytesWritten int64   `json:"bytes_written"`
+}
+
+// syncRun is a sync whose result goes into the report.
+type syncRun struct {
+	label    string
+	result   *sync.Result
+	duration time.Duration
+}
+
+// recordRun keeps the result of a sync for the run report, if one was asked for.
+func (cfg *syncConfig) recordRun(label string, startedAt time.Time, result *sync.Result) {
+	if cfg.reportPath == "" || result == nil {
+		return
+	}
+	cfg.runs = append(cfg.runs, syncRun{label: label, result: result, duration: time.Since(startedAt)})
+}
+
+// newSyncReport builds the report of the recorded runs. runErr is the error
+// the command ends with, if any.
+func newSyncReport(cfg *syncConfig, startedAt, finishedAt time.Time, runErr error) syncReport {
+	report := syncReport{
+		ReportVersion: syncReportVersion,
+		Version:       Version,
+		StartedAt:     startedAt.UTC(),
+		FinishedAt:    finishedAt.UTC(),
+		DurationMS:    finishedAt.Sub(startedAt).Milliseconds(),
+		DryRun:        cfg.dryRun,
+		Success:       runErr == nil,
+		Totals:        syncReportTotals{Counts: make(map[string]int), Backups: []string{}},
+		Runs:          make([]syncReportRun, 0, len(cfg.runs)),
+	}
+	if runErr != nil {
+		report.Error = runErr.Error()
+	}
+
+	for _, run := range cfg.runs {
+		output := newSyncResultOutput(run.result)
+		reportRun := syncReportRun{
+			Label:      run.label,
+			Source:     output.Source,
+			Target:     output.Target,
+			Strategy:   output.Strategy,
+			SessionID:  output.SessionID,
+			Success:    output.Success,
+			DurationMS: run.duration.Milliseconds(),
+			Counts:     output.Counts,
+			Conflicts:  []string{},
+			Backups:    []string{},
+			HookErrors: output.HookErrors,
+			Skills:     make([]syncReportSkill, 0, len(output.Skills)),
+		}
+		for i, skill := range output.Skills {
+			sr := run.result.Skills[i]
+			reportRun.Skills = append(reportRun.Skills, syncReportSkill{
+				syncSkillOutput: skill,
+				DurationMS:      float64(sr.Duration.Microseconds()) / 1000,
+				BytesWritten:    sr.BytesWritten,
+			})
+			reportRun.BytesWritten += sr.BytesWritten
+			reportRun.Backups = append(reportRun.Backups, sr.BackupIDs...)
+			if sr.Action == sync.ActionConflict {
+				reportRun.Conflicts = append(reportRun.Conflicts, sr.Skill.Name)
+			}
+		}
+
+		report.Totals.Skills += len(reportRun.Skills)
+		for action, n := range reportRun.Counts {
+			report.Totals.Counts[action] += n
+		}
+		report.Totals.BytesWritten += reportRun.BytesWritten
+		report.Totals.Conflicts += len(reportRun.Conflicts)
+		report.Totals.Backups = append(report.Totals.Backups, reportRun.Backups...)
+		report.Runs = append(report.Runs, reportRun)
+	}
+	return report
+}
+
+// writeSyncReport writes the report of the recorded runs to cfg.reportPath.
+func writeSyncReport(cfg *syncConfig, startedAt time.Time, runErr error) error {
+	report := newSyncReport(cfg, startedAt, time.Now(), runErr)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+	if dir := filepath.Dir(cfg.reportPath); dir != "." {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("failed to write run report: %w", err)
+		}
+	}
+	// #nosec G306 - the report is meant to be attached to CI artifacts
+	if err := os.WriteFile(cfg.reportPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write run report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSyncReport(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		wantErr     string
+		wantSuccess bool
+		wantCounts  map[string]int
+		wantBackups int
+	}{
+		"sync": {
+			args:        []string{"--yes"},
+			wantSuccess: true,
+			wantCounts:  map[string]int{"created": 1, "updated": 1},
+			wantBackups: 1,
+		},
+		"dry run": {
+			args:        []string{"--dry-run"},
+			wantSuccess: true,
+			wantCounts:  map[string]int{"created": 1, "updated": 1},
+		},
+		"failing sync is reported": {
+			args:       []string{"--yes", "--skip-backup", "--fail-on", "changes"},
+			wantErr:    "changes",
+			wantCounts: map[string]int{"created": 1, "updated": 1},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, cursorSkills := setupStore(t)
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+			util.WriteFile(t, filepath.Join(claudeSkills, "review", "SKILL.md"), "---\nname: review\ndescription: Review code\n---\nReview the diff.\n")
+			util.WriteFile(t, filepath.Join(cursorSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun an old linter.\n")
+			reportPath := filepath.Join(t.TempDir(), "artifacts", "report.json")
+
+			var runErr error
+			captureOutput(t, func() {
+				args := append([]string{"skillsync", "sync", "--skip-validation", "--report", reportPath}, tt.args...)
+				runErr = Run(context.Background(), append(args, "claudecode", "cursor"))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("sync error = %v, want %q", runErr, tt.wantErr)
+				}
+			} else {
+				util.AssertNoError(t, runErr)
+			}
+
+			data, err := os.ReadFile(reportPath)
+			util.AssertNoError(t, err)
+			var report syncReport
+			util.AssertNoError(t, json.Unmarshal(data, &report))
+
+			util.AssertEqual(t, report.Success, tt.wantSuccess)
+			util.AssertEqual(t, report.Error != "", tt.wantErr != "")
+			util.AssertEqual(t, len(report.Runs), 1)
+			util.AssertEqual(t, report.Runs[0].Source, "claude-code")
+			util.AssertEqual(t, report.Totals.Skills, 2)
+			for action, want := range tt.wantCounts {
+				util.AssertEqual(t, report.Totals.Counts[action], want)
+			}
+			util.AssertEqual(t, len(report.Totals.Backups), tt.wantBackups)
+			if wrote := report.Totals.BytesWritten > 0; wrote == report.DryRun {
+				t.Errorf("bytes written = %d with dry run %v", report.Totals.BytesWritten, report.DryRun)
+			}
+			for _, skill := range report.Runs[0].Skills {
+				if skill.DurationMS <= 0 {
+					t.Errorf("skill %s has no duration", skill.Name)
+				}
+			}
+		})
+	}
+
+	t.Run("watch", func(t *testing.T) {
+		setupStore(t)
+		err := Run(context.Background(), []string{"skillsync", "sync", "--watch", "--yes", "--report", "report.json", "claudecode", "cursor"})
+		if err == nil || !strings.Contains(err.Error(), "--report cannot be used with --watch") {
+			t.Fatalf("sync --watch --report error = %v", err)
+		}
+	})
+}
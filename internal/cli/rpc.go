@@ -0,0 +1,355 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/rpc"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func rpcCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "rpc",
+		Usage:     "Serve skillsync operations over JSON-RPC on stdin and stdout",
+		UsageText: "skillsync rpc",
+		Description: `Run a long-lived JSON-RPC 2.0 server for editor integrations, so an IDE
+   plugin can keep one skillsync process instead of running a command per
+   operation.
+
+   Each request is one line of JSON on stdin and each response one line on
+   stdout; messages and logs go to stderr. Requests are handled in order.
+   The server stops at the end of stdin or after a shutdown request.
+
+   Methods:
+     initialize       Server version, protocol version, and methods
+     discover         List skills ({"platform": "cursor:repo"})
+     diff             Compare two platform specs ({"source", "target"})
+     sync             Sync without prompting ({"source", "target", "strategy"})
+     resolveConflict  Sync one skill, resolving its conflict
+                      ({"source", "target", "skill", "resolution"})
+     shutdown         Stop the server
+
+   Platform specs use the CLI syntax, platform[:scope]. Writes are backed up
+   and recorded in history like the matching commands.
+
+   Example:
+     echo '{"jsonrpc": "2.0", "id": 1, "method": "discover"}' | skillsync rpc`,
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			return runRPC(ctx)
+		},
+	}
+}
+
+// runRPC serves JSON-RPC on stdin and stdout. Stdout carries only
+// responses: it is swapped for stderr while serving so the messages the
+// operations print cannot corrupt the stream.
+func runRPC(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	responses := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = responses }()
+	mode := out.mode
+	out.mode = outputModeJSON
+	defer func() { out.mode = mode }()
+
+	server := rpc.New(rpc.Options{
+		Version:         Version,
+		Discover:        rpcDiscover,
+		Diff:            rpcDiff,
+		Sync:            rpcSync,
+		ResolveConflict: rpcResolveConflict,
+	})
+	return server.Serve(ctx, os.Stdin, responses)
+}
+
+// rpcDiscover lists the skills of one platform spec, or of every platform.
+func rpcDiscover(_ context.Context, params rpc.DiscoverParams) (*rpc.DiscoverResult, error) {
+	var skills []model.Skill
+	if params.Platform == "" {
+		var err error
+		if skills, err = discoverBrowseSkills(params.IncludePlugins); err != nil {
+			return nil, err
+		}
+	} else {
+		spec, err := model.ParsePlatformSpec(params.Platform)
+		if err != nil {
+			return nil, fmt.Errorf("invalid platform: %w", err)
+		}
+		if skills, err = parsePlatformSkillsWithScope(spec.Platform, spec.Scopes, params.IncludePlugins); err != nil {
+			return nil, fmt.Errorf("failed to parse %s skills: %w", spec.Platform, err)
+		}
+	}
+
+	result := &rpc.DiscoverResult{Skills: make([]rpc.Skill, 0, len(skills))}
+	for _, skill := range skills {
+		info := rpc.Skill{
+			Name:        skill.Name,
+			Platform:    string(skill.Platform),
+			Scope:       string(skill.Scope),
+			Path:        skill.Path,
+			Type:        string(skill.Type),
+			Description: skill.Description,
+			Tools:       skill.Tools,
+		}
+		if origin, ok := skill.Origin(); ok {
+			info.Origin = &origin
+		}
+		result.Skills = append(result.Skills, info)
+	}
+	return result, nil
+}
+
+// rpcDiff compares two platform specs like the diff command.
+func rpcDiff(_ context.Context, params rpc.DiffParams) (*rpc.DiffResult, error) {
+	sourceSpec, targetSpec, err := parseRPCSpecs(params.Source, params.Target)
+	if err != nil {
+		return nil, err
+	}
+	sourceSkills, err := parsePlatformSkillsWithScope(sourceSpec.Platform, sourceSpec.Scopes, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source skills: %w", err)
+	}
+	targetSkills, err := parsePlatformSkillsWithScope(targetSpec.Platform, targetSpec.Scopes, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse target skills: %w", err)
+	}
+	if len(params.Skills) > 0 {
+		sourceSkills = filterSkillsByName(sourceSkills, params.Skills)
+		targetSkills = filterSkillsByName(targetSkills, params.Skills)
+	}
+
+	diffs := diffSkills(sourceSkills, targetSkills, sync.CurrentDiffOptions())
+	if !params.All && len(params.Skills) == 0 {
+		diffs = slices.DeleteFunc(diffs, func(d skillDiff) bool {
+			return d.Status != diffStatusModified
+		})
+	}
+
+	result := &rpc.DiffResult{Diffs: make([]rpc.SkillDiff, 0, len(diffs))}
+	for _, d := range diffs {
+		result.Diffs = append(result.Diffs, rpc.SkillDiff{
+			Name:            d.Name,
+			Status:          string(d.Status),
+			SourcePath:      d.SourcePath,
+			TargetPath:      d.TargetPath,
+			MetadataDiffers: d.MetadataDiffers,
+			Hunks:           rpcHunks(d.Hunks),
+		})
+	}
+	return result, nil
+}
+
+// rpcSync syncs two platform specs without prompting. Conflicts the
+// strategy leaves are reported for resolveConflict.
+func rpcSync(_ context.Context, params rpc.SyncParams) (*rpc.SyncResult, error) {
+	sourceSpec, targetSpec, err := parseRPCSpecs(params.Source, params.Target)
+	if err != nil {
+		return nil, err
+	}
+	appConfig, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	strategy := sync.Strategy(params.Strategy)
+	if params.Strategy == "" {
+		strategy, _ = appConfig.StrategyForScope(string(targetSpec.TargetScope()))
+	}
+	if !strategy.IsValid() {
+		return nil, fmt.Errorf("invalid strategy %q (valid: overwrite, skip, newer, merge, three-way, interactive)", strategy)
+	}
+	if err := checkWritableScopes(appConfig, []model.SkillScope{targetSpec.TargetScope()}); err != nil {
+		return nil, err
+	}
+
+	skills, err := parsePlatformSkillsWithScope(sourceSpec.Platform, sourceSpec.Scopes, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source skills: %w", err)
+	}
+	if len(params.Skills) > 0 {
+		skills = filterSkillsByName(skills, params.Skills)
+		if len(skills) == 0 {
+			return nil, fmt.Errorf("skill(s) not found in %s: %v", sourceSpec, params.Skills)
+		}
+	}
+
+	opts := rpcSyncOptions(targetSpec, strategy, params.DryRun, params.SkipBackup)
+	startedAt := time.Now()
+	result, err := sync.New().SyncWithSkills(skills, targetSpec.Platform, opts)
+	if err != nil {
+		return nil, fmt.Errorf("sync failed: %w", err)
+	}
+	recordHistory(opts.SessionID, "rpc sync", startedAt, result)
+	return newRPCSyncResult(result), nil
+}
+
+// rpcResolveConflict syncs one skill with the interactive strategy and
+// writes the requested resolution of its conflict, if it has one.
+func rpcResolveConflict(_ context.Context, params rpc.ResolveConflictParams) (*rpc.ResolveConflictResult, error) {
+	sourceSpec, targetSpec, err := parseRPCSpecs(params.Source, params.Target)
+	if err != nil {
+		return nil, err
+	}
+	if params.Skill == "" {
+		return nil, errors.New("skill is required")
+	}
+	choice := sync.ResolutionChoice(params.Resolution)
+	if params.Content != "" {
+		choice = "content"
+	} else {
+		switch choice {
+		case sync.ResolutionUseSource, sync.ResolutionUseTarget, sync.ResolutionMerge:
+		default:
+			return nil, fmt.Errorf("invalid resolution %q (use source, target, or merge, or give content)", params.Resolution)
+		}
+	}
+	appConfig, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := checkWritableScopes(appConfig, []model.SkillScope{targetSpec.TargetScope()}); err != nil {
+		return nil, err
+	}
+
+	skills, err := parsePlatformSkillsWithScope(sourceSpec.Platform, sourceSpec.Scopes, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source skills: %w", err)
+	}
+	skills = filterSkillsByName(skills, []string{params.Skill})
+	if len(skills) == 0 {
+		return nil, fmt.Errorf("skill %q not found in %s", params.Skill, sourceSpec)
+	}
+
+	opts := rpcSyncOptions(targetSpec, sync.StrategyInteractive, params.DryRun, params.SkipBackup)
+	startedAt := time.Now()
+	result, err := sync.New().SyncWithSkills(skills, targetSpec.Platform, opts)
+	if err != nil {
+		return nil, fmt.Errorf("sync failed: %w", err)
+	}
+
+	response := &rpc.ResolveConflictResult{}
+	if conflicts := resultConflicts(result); len(conflicts) > 0 {
+		conflict := conflicts[0]
+		response.Resolution = string(choice)
+		switch choice {
+		case "content":
+			response.Content = params.Content
+		case sync.ResolutionUseTarget:
+		default:
+			response.Content = sync.NewMerger().ResolveWithChoice(conflict, choice)
+		}
+		conflict.Resolution = choice
+		conflict.ResolvedContent = response.Content
+
+		switch {
+		case params.DryRun:
+		case choice == sync.ResolutionUseTarget:
+			for i := range result.Skills {
+				if result.Skills[i].Conflict == conflict {
+					result.Skills[i].Action = sync.ActionSkipped
+					result.Skills[i].Message = "conflict resolved by keeping the target"
+				}
+			}
+		default:
+			if err := applyResolvedConflicts(result, map[string]string{params.Skill: response.Content}, opts.Backup); err != nil {
+				return nil, fmt.Errorf("failed to apply resolved conflict: %w", err)
+			}
+		}
+	}
+	recordHistory(opts.SessionID, "rpc resolveConflict", startedAt, result)
+
+	if synced := newRPCSyncResult(result).Skills; len(synced) > 0 {
+		response.Skill = synced[0]
+	}
+	return response, nil
+}
+
+// parseRPCSpecs parses the source and target platform specs of a request.
+func parseRPCSpecs(source, target string) (model.PlatformSpec, model.PlatformSpec, error) {
+	sourceSpec, err := model.ParsePlatformSpec(source)
+	if err != nil {
+		return model.PlatformSpec{}, model.PlatformSpec{}, fmt.Errorf("invalid source: %w", err)
+	}
+	targetSpec, err := model.ParsePlatformSpec(target)
+	if err != nil {
+		return model.PlatformSpec{}, model.PlatformSpec{}, fmt.Errorf("invalid target: %w", err)
+	}
+	return sourceSpec, targetSpec, nil
+}
+
+// rpcSyncOptions returns the options of a sync run over RPC, with the
+// target backed up unless skipped or a dry run.
+func rpcSyncOptions(targetSpec model.PlatformSpec, strategy sync.Strategy, dryRun, skipBackup bool) sync.Options {
+	opts := sync.Options{
+		DryRun:      dryRun,
+		Strategy:    strategy,
+		TargetScope: targetSpec.TargetScope(),
+	}
+	if !dryRun {
+		opts.SessionID = backup.NewSessionID()
+		if !skipBackup && !util.NoPersist() {
+			prepareBackup(targetSpec.Platform)
+			opts.Backup = true
+		}
+	}
+	return opts
+}
+
+// newRPCSyncResult converts a sync result to its RPC schema.
+func newRPCSyncResult(result *sync.Result) *rpc.SyncResult {
+	output := newSyncResultOutput(result)
+	converted := &rpc.SyncResult{
+		Source:    output.Source,
+		Target:    output.Target,
+		Strategy:  output.Strategy,
+		DryRun:    output.DryRun,
+		Success:   output.Success,
+		Counts:    output.Counts,
+		Skills:    make([]rpc.SyncSkill, 0, len(result.Skills)),
+		SessionID: output.SessionID,
+	}
+	for i, sr := range result.Skills {
+		skill := rpc.SyncSkill{
+			Name:       output.Skills[i].Name,
+			Action:     output.Skills[i].Action,
+			TargetPath: output.Skills[i].TargetPath,
+			Message:    output.Skills[i].Message,
+			Error:      output.Skills[i].Error,
+			Backups:    output.Skills[i].Backups,
+		}
+		if sr.Conflict != nil {
+			hunks := make([]diffHunkOutput, 0, len(sr.Conflict.Hunks))
+			for _, hunk := range sr.Conflict.Hunks {
+				hunks = append(hunks, newDiffHunkOutput(hunk))
+			}
+			skill.Conflict = &rpc.Conflict{Type: string(sr.Conflict.Type), Hunks: rpcHunks(hunks)}
+		}
+		converted.Skills = append(converted.Skills, skill)
+	}
+	return converted
+}
+
+// rpcHunks converts diff hunks to their RPC schema.
+func rpcHunks(hunks []diffHunkOutput) []rpc.DiffHunk {
+	if len(hunks) == 0 {
+		return nil
+	}
+	converted := make([]rpc.DiffHunk, 0, len(hunks))
+	for _, h := range hunks {
+		converted = append(converted, rpc.DiffHunk(h))
+	}
+	return converted
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/rpc"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestRPCCommand(t *testing.T) {
+	claudeSkills, cursorSkills := setupStore(t)
+	util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\nFix issues.\n")
+	util.WriteFile(t, filepath.Join(claudeSkills, "docs", "SKILL.md"), "---\nname: docs\ndescription: Write docs\n---\nDocument it.\n")
+	util.WriteFile(t, filepath.Join(cursorSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\nIgnore issues.\n")
+
+	withStdin(t, strings.Join([]string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "discover", "params": {"platform": "claudecode"}}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "diff", "params": {"source": "claudecode", "target": "cursor"}}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "sync", "params": {"source": "claudecode", "target": "cursor", "skills": ["docs"], "skip_backup": true}}`,
+		`{"jsonrpc": "2.0", "id": 4, "method": "resolveConflict", "params": {"source": "claudecode", "target": "cursor", "skill": "lint", "resolution": "source", "skip_backup": true}}`,
+		`{"jsonrpc": "2.0", "id": 5, "method": "diff", "params": {"source": "nowhere", "target": "cursor"}}`,
+		`{"jsonrpc": "2.0", "id": 6, "method": "shutdown"}`,
+	}, "\n"))
+
+	output := captureOutput(t, func() {
+		if err := Run(context.Background(), []string{"skillsync", "rpc"}); err != nil {
+			t.Errorf("rpc failed: %v", err)
+		}
+	})
+
+	var responses []rpc.Response
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var response rpc.Response
+		if err := json.Unmarshal([]byte(line), &response); err != nil {
+			t.Fatalf("stdout has a line that is not a response: %q", line)
+		}
+		responses = append(responses, response)
+	}
+	if len(responses) != 6 {
+		t.Fatalf("got %d responses, want 6:\n%s", len(responses), output)
+	}
+
+	var discovered rpc.DiscoverResult
+	util.AssertNoError(t, json.Unmarshal(responses[0].Result, &discovered))
+	util.AssertEqual(t, len(discovered.Skills), 2)
+
+	var diffed rpc.DiffResult
+	util.AssertNoError(t, json.Unmarshal(responses[1].Result, &diffed))
+	if len(diffed.Diffs) != 1 || diffed.Diffs[0].Name != "lint" || len(diffed.Diffs[0].Hunks) == 0 {
+		t.Errorf("unexpected diff result: %+v", diffed)
+	}
+
+	var synced rpc.SyncResult
+	util.AssertNoError(t, json.Unmarshal(responses[2].Result, &synced))
+	if len(synced.Skills) != 1 || synced.Skills[0].Action != "created" {
+		t.Errorf("unexpected sync result: %+v", synced)
+	}
+	if _, err := os.Stat(filepath.Join(cursorSkills, "docs", "SKILL.md")); err != nil {
+		t.Errorf("sync did not write docs: %v", err)
+	}
+
+	var resolved rpc.ResolveConflictResult
+	util.AssertNoError(t, json.Unmarshal(responses[3].Result, &resolved))
+	util.AssertEqual(t, resolved.Resolution, "source")
+	data, err := os.ReadFile(filepath.Join(cursorSkills, "lint", "SKILL.md"))
+	util.AssertNoError(t, err)
+	if !strings.Contains(string(data), "Fix issues.") || strings.Contains(string(data), "Ignore issues.") {
+		t.Errorf("conflict not resolved with the source:\n%s", data)
+	}
+
+	if responses[4].Error == nil || responses[4].Error.Code != rpc.CodeFailed {
+		t.Errorf("expected an operation error for an unknown platform, got %+v", responses[4])
+	}
+	if responses[5].Error != nil {
+		t.Errorf("shutdown failed: %v", responses[5].Error)
+	}
+}
@@ -8,13 +8,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/urfave/cli/v3"
 	"gopkg.in/yaml.v3"
 
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/history"
 	"github.com/klauern/skillsync/internal/model"
 	"github.com/klauern/skillsync/internal/parser/plugin"
 	"github.com/klauern/skillsync/internal/parser/tiered"
+	"github.com/klauern/skillsync/internal/promote"
 	"github.com/klauern/skillsync/internal/ui"
 	"github.com/klauern/skillsync/internal/util"
 )
@@ -27,19 +31,22 @@ func promoteCommand() *cli.Command {
    skillsync promote my-skill                     # Promote from repo to user
    skillsync promote my-skill --from repo --to user
    skillsync promote my-skill --platform cursor
-   skillsync promote my-skill --remove-source     # Move instead of copy`,
-		Description: `Promote (copy) a skill from a lower scope to a higher scope.
+   skillsync promote my-skill --keep-source       # Copy instead of move
+   skillsync promote my-skill --dry-run`,
+		Description: `Promote (move) a skill from a lower scope to a higher scope.
 
    By default, promotes from repo (project-local) to user (global) scope.
-   The original skill is preserved unless --remove-source is specified.
+   The original skill is backed up and removed unless --keep-source is
+   specified, which copies the skill instead.
 
-   Use --force to overwrite if a skill with the same name exists at the target scope.
-   Use --rename to specify a new name if there's a conflict.`,
+   Use --force to overwrite if a skill with the same name exists at the target
+   scope; the replaced skill is backed up first. Use --rename to specify a new
+   name if there's a conflict. Undo a promote with 'skillsync undo'.`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Platform to promote from (claude-code, cursor, codex)",
+				Usage:   "Platform to promote from (claude-code, cursor, codex, aider)",
 			},
 			&cli.StringFlag{
 				Name:  "from",
@@ -61,14 +68,23 @@ func promoteCommand() *cli.Command {
 				Usage: "Rename skill at target (avoids conflicts)",
 			},
 			&cli.BoolFlag{
-				Name:  "remove-source",
-				Usage: "Remove skill from source scope after promotion (move instead of copy)",
+				Name:  "keep-source",
+				Usage: "Keep skill in source scope after promotion (copy instead of move)",
+			},
+			&cli.BoolFlag{
+				Name:   "remove-source",
+				Usage:  "Deprecated: moving is the default",
+				Hidden: true,
 			},
 			&cli.BoolFlag{
 				Name:    "dry-run",
 				Aliases: []string{"d"},
 				Usage:   "Preview changes without modifying files",
 			},
+			&cli.BoolFlag{
+				Name:  "skip-backup",
+				Usage: "Skip the backup of the removed source and the replaced target",
+			},
 		},
 		Action: func(_ context.Context, cmd *cli.Command) error {
 			args := cmd.Args()
@@ -90,19 +106,22 @@ func demoteCommand() *cli.Command {
    skillsync demote my-skill                     # Demote from user to repo
    skillsync demote my-skill --from user --to repo
    skillsync demote my-skill --platform cursor
-   skillsync demote my-skill --remove-source     # Move instead of copy`,
-		Description: `Demote (copy) a skill from a higher scope to a lower scope.
+   skillsync demote my-skill --keep-source       # Copy instead of move
+   skillsync demote my-skill --dry-run`,
+		Description: `Demote (move) a skill from a higher scope to a lower scope.
 
    By default, demotes from user (global) to repo (project-local) scope.
-   The original skill is preserved unless --remove-source is specified.
+   The original skill is backed up and removed unless --keep-source is
+   specified, which copies the skill instead.
 
-   Use --force to overwrite if a skill with the same name exists at the target scope.
-   Use --rename to specify a new name if there's a conflict.`,
+   Use --force to overwrite if a skill with the same name exists at the target
+   scope; the replaced skill is backed up first. Use --rename to specify a new
+   name if there's a conflict. Undo a demote with 'skillsync undo'.`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Platform to demote from (claude-code, cursor, codex)",
+				Usage:   "Platform to demote from (claude-code, cursor, codex, aider)",
 			},
 			&cli.StringFlag{
 				Name:  "from",
@@ -124,14 +143,23 @@ func demoteCommand() *cli.Command {
 				Usage: "Rename skill at target (avoids conflicts)",
 			},
 			&cli.BoolFlag{
-				Name:  "remove-source",
-				Usage: "Remove skill from source scope after demotion (move instead of copy)",
+				Name:  "keep-source",
+				Usage: "Keep skill in source scope after demotion (copy instead of move)",
+			},
+			&cli.BoolFlag{
+				Name:   "remove-source",
+				Usage:  "Deprecated: moving is the default",
+				Hidden: true,
 			},
 			&cli.BoolFlag{
 				Name:    "dry-run",
 				Aliases: []string{"d"},
 				Usage:   "Preview changes without modifying files",
 			},
+			&cli.BoolFlag{
+				Name:  "skip-backup",
+				Usage: "Skip the backup of the removed source and the replaced target",
+			},
 		},
 		Action: func(_ context.Context, cmd *cli.Command) error {
 			args := cmd.Args()
@@ -182,7 +210,7 @@ func scopeListCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Filter by platform (claude-code, cursor, codex)",
+				Usage:   "Filter by platform (claude-code, cursor, codex, aider)",
 			},
 			&cli.BoolFlag{
 				Name:  "all",
@@ -231,7 +259,7 @@ func scopePruneCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:    "platform",
 				Aliases: []string{"p"},
-				Usage:   "Platform to prune (claude-code, cursor, codex). Required.",
+				Usage:   "Platform to prune (claude-code, cursor, codex, aider). Required.",
 			},
 			&cli.StringFlag{
 				Name:  "scope",
@@ -262,6 +290,15 @@ func scopePruneCommand() *cli.Command {
 	}
 }
 
+// scopeMoveOutput is the JSON representation of a promote or demote.
+type scopeMoveOutput struct {
+	*promote.Result
+	Platform model.Platform `json:"platform"`
+	Skill    string         `json:"skill"`
+	DryRun   bool           `json:"dry_run,omitempty"`
+	RunID    string         `json:"run_id,omitempty"`
+}
+
 // runPromote executes the promote command.
 func runPromote(cmd *cli.Command, skillName string) error {
 	return runScopeMove(cmd, skillName, true)
@@ -279,8 +316,11 @@ func runScopeMove(cmd *cli.Command, skillName string, isPromotion bool) error {
 	toStr := cmd.String("to")
 	force := cmd.Bool("force")
 	rename := cmd.String("rename")
-	removeSource := cmd.Bool("remove-source")
+	keepSource := cmd.Bool("keep-source")
 	dryRun := cmd.Bool("dry-run")
+	if keepSource && cmd.Bool("remove-source") {
+		return errors.New("--keep-source and --remove-source cannot be used together")
+	}
 
 	// Parse scopes
 	fromScope, err := model.ParseScope(fromStr)
@@ -293,14 +333,15 @@ func runScopeMove(cmd *cli.Command, skillName string, isPromotion bool) error {
 		return fmt.Errorf("invalid target scope: %w", err)
 	}
 
-	// Validate scope direction
+	// Validate scope direction: promotion widens a skill's reach, from a
+	// scope that overrides others (repo) to one that is overridden (user)
 	if isPromotion {
-		if !toScope.IsHigherPrecedence(fromScope) && toScope != fromScope {
-			return fmt.Errorf("promotion requires target scope (%s) to have higher precedence than source scope (%s)", toScope, fromScope)
+		if !fromScope.IsHigherPrecedence(toScope) && toScope != fromScope {
+			return fmt.Errorf("promotion requires target scope (%s) to be broader than source scope (%s)", toScope, fromScope)
 		}
 	} else {
-		if !fromScope.IsHigherPrecedence(toScope) && fromScope != toScope {
-			return fmt.Errorf("demotion requires source scope (%s) to have higher precedence than target scope (%s)", fromScope, toScope)
+		if !toScope.IsHigherPrecedence(fromScope) && fromScope != toScope {
+			return fmt.Errorf("demotion requires target scope (%s) to be narrower than source scope (%s)", toScope, fromScope)
 		}
 	}
 
@@ -354,59 +395,64 @@ func runScopeMove(cmd *cli.Command, skillName string, isPromotion bool) error {
 		}
 
 		// Display operation details
+		run := history.Run{
+			ID:        backup.NewSessionID(),
+			Command:   history.CommandPromote,
+			Source:    string(fromScope),
+			Target:    string(toScope),
+			StartedAt: time.Now(),
+			Success:   true,
+		}
 		operation := "Promote"
 		if !isPromotion {
 			operation = "Demote"
+			run.Command = history.CommandDemote
 		}
 
-		fmt.Printf("\n%s Details:\n", operation)
-		fmt.Printf("  Platform:     %s\n", platform)
-		fmt.Printf("  Skill:        %s\n", skillName)
-		fmt.Printf("  Source:       %s (%s)\n", skill.Path, fromScope)
-		fmt.Printf("  Target:       %s (%s)\n", targetPath, toScope)
+		out.Printf("\n%s Details:\n", operation)
+		out.Printf("  Platform:     %s\n", platform)
+		out.Printf("  Skill:        %s\n", skillName)
+		out.Printf("  Source:       %s (%s)\n", skill.Path, fromScope)
+		out.Printf("  Target:       %s (%s)\n", targetPath, toScope)
 		if rename != "" {
-			fmt.Printf("  Renamed to:   %s\n", targetName)
+			out.Printf("  Renamed to:   %s\n", targetName)
 		}
-		if removeSource {
-			fmt.Println("  Remove source: yes")
+		if keepSource {
+			out.Println("  Keep source:  yes")
 		}
 
-		if dryRun {
-			fmt.Println("\n[Dry run - no changes made]")
-			return nil
+		moved, err := promote.Move(*skill, targetPath, promote.Options{
+			KeepSource: keepSource,
+			DryRun:     dryRun,
+			SkipBackup: skipBackup(cmd),
+			SessionID:  run.ID,
+		})
+		if moved == nil {
+			return err
 		}
-
-		// Ensure target directory exists
-		// #nosec G301 - skill directories need to be readable by the platform
-		targetDir := filepath.Dir(targetPath)
-		if err := os.MkdirAll(targetDir, 0o750); err != nil {
-			return fmt.Errorf("failed to create target directory: %w", err)
+		result := scopeMoveOutput{Result: moved, Platform: platform, Skill: skillName, DryRun: dryRun}
+		if !dryRun {
+			result.RunID = run.ID
+			run.Skills = moved.Records
+			run.Success = err == nil
+			recordSkillRun(run)
 		}
-
-		// Read source content
-		// #nosec G304 - skill.Path comes from parsed skill files
-		content, err := os.ReadFile(skill.Path)
 		if err != nil {
-			return fmt.Errorf("failed to read source skill: %w", err)
-		}
-
-		// Write to target
-		// #nosec G306 - skill files should be readable
-		if err := os.WriteFile(targetPath, content, 0o644); err != nil {
-			return fmt.Errorf("failed to write skill to target: %w", err)
+			return err
 		}
 
-		fmt.Printf("\n✓ Copied skill to %s\n", targetPath)
-
-		// Remove source if requested
-		if removeSource {
-			if err := os.Remove(skill.Path); err != nil {
-				return fmt.Errorf("failed to remove source skill: %w", err)
+		return out.Render(result, func() error {
+			if dryRun {
+				fmt.Println("\n[Dry run - no changes made]")
+				return nil
 			}
-			fmt.Printf("✓ Removed source skill from %s\n", skill.Path)
-		}
-
-		return nil
+			fmt.Printf("\n✓ Copied skill to %s\n", targetPath)
+			if !moved.KeptSource {
+				fmt.Printf("✓ Removed source skill from %s\n", skill.Path)
+			}
+			fmt.Println(ui.Dim("Undo with: skillsync undo " + run.ID))
+			return nil
+		})
 	}
 
 	// No skill found in any platform
@@ -621,13 +667,13 @@ func runScopeListAll(cmd *cli.Command) error {
 		fmt.Printf("%-12s %-8s %-30s %s\n", "--------", "-----", "----", "----")
 
 		for _, s := range allSkills {
-			name := s.Name
-			if len(name) > 30 {
-				name = name[:27] + "..."
-			}
+			names := fitCell(s.Name, 30)
 			// Color platform names for visual distinction
 			platform := colorPlatform(string(s.Platform), 12)
-			fmt.Printf("%s %-8s %-30s %s\n", platform, s.Scope, name, s.Path)
+			fmt.Printf("%s %-8s %s %s\n", platform, s.Scope, padCell(names[0], 30), s.Path)
+			for _, name := range names[1:] {
+				fmt.Printf("%-12s %-8s %s\n", "", "", name)
+			}
 		}
 		fmt.Printf("\nTotal: %d skill(s)\n", len(allSkills))
 	default:
@@ -758,7 +804,7 @@ func runScopePrune(cmd *cli.Command) error {
 	var deleted int
 	for _, skill := range toPrune {
 		if err := os.Remove(skill.Path); err != nil {
-			fmt.Printf("Warning: failed to remove %s: %v\n", skill.Path, err)
+			warnf("Warning: failed to remove %s: %v\n", skill.Path, err)
 			continue
 		}
 		deleted++
@@ -802,7 +848,7 @@ func getSkillPathForScope(platform model.Platform, scope model.SkillScope, skill
 		}
 		basePath = util.RepoSkillsPath(platform, wd)
 	case model.ScopeUser:
-		basePath = util.PlatformSkillsPath(platform)
+		basePath = util.Paths().UserSkillsPath(platform)
 	default:
 		return "", fmt.Errorf("scope %q is not writable", scope)
 	}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
 )
 
 func TestPromoteCommand(t *testing.T) {
@@ -102,6 +103,69 @@ func TestDemoteCommand(t *testing.T) {
 	}
 }
 
+func TestPromote_MovesSkill(t *testing.T) {
+	const lint = "---\nname: lint\n---\nRun the linter.\n"
+
+	tests := map[string]struct {
+		args       []string
+		wantOutput string
+		wantSource bool // the repo skill is left
+		wantTarget bool // the user skill is written
+	}{
+		"move": {
+			wantOutput: "Removed source skill",
+			wantTarget: true,
+		},
+		"keep source": {
+			args:       []string{"--keep-source"},
+			wantOutput: "Keep source:  yes",
+			wantSource: true,
+			wantTarget: true,
+		},
+		"dry run": {
+			args:       []string{"--dry-run"},
+			wantOutput: "[Dry run - no changes made]",
+			wantSource: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, _ := setupStore(t)
+			repo := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o750); err != nil {
+				t.Fatalf("failed to create repo: %v", err)
+			}
+			t.Chdir(repo)
+			source := filepath.Join(repo, ".claude", "skills", "lint", "SKILL.md")
+			target := filepath.Join(claudeSkills, "lint", "SKILL.md")
+			util.WriteFile(t, source, lint)
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync", "promote", "lint", "--platform", "claudecode"}, tt.args...))
+			})
+			util.AssertNoError(t, runErr)
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output missing %q:\n%s", tt.wantOutput, output)
+			}
+			util.AssertEqual(t, pathExists(source), tt.wantSource)
+			util.AssertEqual(t, pathExists(target), tt.wantTarget)
+			if !tt.wantTarget {
+				return
+			}
+
+			captureOutput(t, func() {
+				util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "undo", "--force"}))
+			})
+			util.AssertEqual(t, pathExists(target), false)
+			data, err := os.ReadFile(source)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, string(data), lint)
+		})
+	}
+}
+
 func TestScopeListCommand(t *testing.T) {
 	tests := map[string]struct {
 		args       []string
@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// scopeMapping sends the source skills of one scope to a target scope.
+type scopeMapping struct {
+	source         model.SkillScope
+	target         model.SkillScope
+	strategy       sync.Strategy
+	strategySource string // Where the strategy came from when --strategy was not given
+}
+
+// String returns the mapping as given on the command line, e.g. "repo=repo".
+func (m scopeMapping) String() string {
+	return string(m.source) + "=" + string(m.target)
+}
+
+// scopeMapSyncOutput is the JSON representation of the sync of one scope mapping.
+type scopeMapSyncOutput struct {
+	Source model.SkillScope `json:"source_scope"`
+	Target model.SkillScope `json:"target_scope"`
+	Result syncResultOutput `json:"result"`
+}
+
+// parseScopeMappings parses --map values of the form source=target. Each
+// source scope may be mapped once and target scopes must be writable.
+func parseScopeMappings(values []string) ([]scopeMapping, error) {
+	mappings := make([]scopeMapping, 0, len(values))
+	for _, value := range values {
+		sourceName, targetName, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --map %q (want source=target, e.g. repo=repo)", value)
+		}
+		source, err := model.ParseScope(sourceName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --map %q: %w", value, err)
+		}
+		target, err := model.ParseScope(targetName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --map %q: %w", value, err)
+		}
+		if target != model.ScopeRepo && target != model.ScopeUser {
+			return nil, fmt.Errorf("invalid --map %q: target scope must be 'repo' or 'user'", value)
+		}
+		if slices.ContainsFunc(mappings, func(m scopeMapping) bool { return m.source == source }) {
+			return nil, fmt.Errorf("invalid --map %q: %s scope is already mapped", value, source)
+		}
+		mappings = append(mappings, scopeMapping{source: source, target: target})
+	}
+	return mappings, nil
+}
+
+// resolveScopeMappings checks the mappings against the platform specs and
+// limits the source to the mapped scopes. The strategy of each mapping is
+// --strategy, or the configured default of its target scope.
+func resolveScopeMappings(
+	cmd *cli.Command,
+	appConfig *config.Config,
+	mappings []scopeMapping,
+	sourceSpec *model.PlatformSpec,
+	targetSpec model.PlatformSpec,
+) error {
+	if targetSpec.HasScopes() {
+		return fmt.Errorf("--map sets the target scopes, remove the scope from target %s", targetSpec)
+	}
+
+	sources := make([]model.SkillScope, 0, len(mappings))
+	for i, m := range mappings {
+		if sourceSpec.HasScopes() && !slices.Contains(sourceSpec.Scopes, m.source) {
+			return fmt.Errorf("--map %s: source %s does not include %s scope", m, sourceSpec, m.source)
+		}
+		sources = append(sources, m.source)
+
+		strategy, strategySource := resolveSyncStrategy(cmd, appConfig, m.target)
+		if !strategy.IsValid() {
+			return fmt.Errorf("invalid strategy %q for %s scope (valid: overwrite, skip, newer, merge, three-way, interactive)", strategy, m.target)
+		}
+		mappings[i].strategy, mappings[i].strategySource = strategy, strategySource
+	}
+	sourceSpec.Scopes = sources
+	return nil
+}
+
+// runMappedSync syncs the already-parsed source skills of each mapped source
+// scope into its target scope.
+func runMappedSync(cfg *syncConfig) error {
+	for _, m := range cfg.scopeMappings {
+		if m.strategy == sync.StrategyInteractive {
+			return errors.New("interactive strategy is not supported with --map")
+		}
+	}
+
+	if !cfg.skipValidation {
+		if err := validateSourceSkills(cfg); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range cfg.scopeMappings {
+		if err := checkScopeSkillLimit(cfg, m.target, filterSkillsByScope(cfg.sourceSkills, m.source)); err != nil {
+			return err
+		}
+	}
+
+	if !cfg.dryRun && !cfg.yesFlag {
+		out.Println("\nScope mapping:")
+		for _, m := range cfg.scopeMappings {
+			line := fmt.Sprintf("  %s -> %s (%s", m.source, m.target, m.strategy)
+			if m.strategySource != "" {
+				line += ", " + m.strategySource
+			}
+			out.Println(line + ")")
+		}
+		confirmed, err := showSyncSummaryAndConfirm(cfg)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Sync cancelled by user")
+			return nil
+		}
+	}
+
+	// One session covers every mapping so the whole run can be rolled back
+	var sessionID string
+	if !cfg.dryRun {
+		sessionID = backup.NewSessionID()
+		if !cfg.skipBackup {
+			prepareBackup(cfg.targetSpec.Platform)
+		}
+	}
+	startedAt := time.Now()
+
+	results := make([]*sync.Result, 0, len(cfg.scopeMappings))
+	mapped := make([]scopeMapping, 0, len(cfg.scopeMappings))
+	failed := false
+	for _, m := range cfg.scopeMappings {
+		skills := filterSkillsByScope(cfg.sourceSkills, m.source)
+		var quarantined []sync.SkillResult
+		for _, sr := range cfg.quarantined {
+			if sr.Skill.Scope == m.source {
+				quarantined = append(quarantined, sr)
+			}
+		}
+		if len(skills) == 0 && len(quarantined) == 0 {
+			continue
+		}
+
+		opts := sync.Options{
+			DryRun:            cfg.dryRun,
+			Strategy:          m.strategy,
+			TargetScope:       m.target,
+			Concurrency:       cfg.concurrency,
+			Hooks:             cfg.hooks,
+			Backup:            sessionID != "" && !cfg.skipBackup,
+			SessionID:         sessionID,
+			TrashRetention:    cfg.trashRetention,
+			RewriteReferences: cfg.rewriteReferences,
+			Metrics:           cfg.metrics,
+			Preview:           cfg.showDiff,
+			Mode:              cfg.mode,
+			Vars:              cfg.vars,
+		}
+		runStarted := time.Now()
+		progress, doneProgress := syncProgress(cfg.progress, "Syncing "+string(m.source), len(skills))
+		opts.Progress = progress
+		result, err := sync.New().SyncWithSkills(skills, cfg.targetSpec.Platform, opts)
+		doneProgress()
+		if err != nil {
+			recordHistory(sessionID, "sync", startedAt, results...)
+			return fmt.Errorf("sync of %s scope failed: %w", m.source, err)
+		}
+		if !result.Success() {
+			failed = true
+		}
+		result.Skills = append(result.Skills, quarantined...)
+		cfg.recordRun(fmt.Sprintf("%s -> %s", m.source, m.target), runStarted, result)
+		results = append(results, result)
+		mapped = append(mapped, m)
+	}
+	recordHistory(sessionID, "sync", startedAt, results...)
+
+	outputs := make([]scopeMapSyncOutput, 0, len(results))
+	for i, result := range results {
+		recordSyncWarnings(result)
+		outputs = append(outputs, scopeMapSyncOutput{Source: mapped[i].source, Target: mapped[i].target, Result: newSyncResultOutput(result)})
+	}
+	err := out.Render(outputs, func() error {
+		if len(results) == 0 {
+			fmt.Printf("No source skills in the mapped scopes (%s)\n", formatScopeMappings(cfg.scopeMappings))
+			return nil
+		}
+		for i, result := range results {
+			fmt.Printf("\n%s\n", ui.Header(fmt.Sprintf("%s -> %s", mapped[i].source, mapped[i].target)))
+			printSyncResults(result)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return errors.New("sync completed with errors")
+	}
+	return cfg.failOn.check(results...)
+}
+
+// filterSkillsByScope returns the skills of the given scope.
+func filterSkillsByScope(skills []model.Skill, scope model.SkillScope) []model.Skill {
+	var filtered []model.Skill
+	for _, skill := range skills {
+		if skill.Scope == scope {
+			filtered = append(filtered, skill)
+		}
+	}
+	return filtered
+}
+
+// formatScopeMappings returns the mappings comma-separated, e.g. "repo=repo, user=user".
+func formatScopeMappings(mappings []scopeMapping) string {
+	parts := make([]string, 0, len(mappings))
+	for _, m := range mappings {
+		parts = append(parts, m.String())
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestParseScopeMappings(t *testing.T) {
+	tests := map[string]struct {
+		values  []string
+		want    string
+		wantErr string
+	}{
+		"pairs":             {values: []string{"repo=repo", "user=user"}, want: "repo=repo, user=user"},
+		"aliases":           {values: []string{"project=global"}, want: "repo=user"},
+		"missing target":    {values: []string{"repo"}, wantErr: "want source=target"},
+		"unknown scope":     {values: []string{"team=repo"}, wantErr: "invalid --map"},
+		"read-only target":  {values: []string{"repo=system"}, wantErr: "must be 'repo' or 'user'"},
+		"duplicate source":  {values: []string{"repo=repo", "repo=user"}, wantErr: "already mapped"},
+		"plugin to user ok": {values: []string{"plugin=user"}, want: "plugin=user"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseScopeMappings(tt.values)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseScopeMappings() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, formatScopeMappings(got), tt.want)
+		})
+	}
+}
+
+func TestSyncScopeMap(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := filepath.Join(tempDir, "repo")
+	claudeUser := filepath.Join(tempDir, "home", ".claude", "skills")
+	cursorUser := filepath.Join(tempDir, "home", ".cursor", "skills")
+	util.WriteFile(t, filepath.Join(repo, ".claude", "skills", "shared", "SKILL.md"),
+		"---\nname: shared\ndescription: Team skill\n---\nFor the team.\n")
+	util.WriteFile(t, filepath.Join(claudeUser, "personal", "SKILL.md"),
+		"---\nname: personal\ndescription: My skill\n---\nFor me.\n")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o750); err != nil {
+		t.Fatalf("failed to create repo: %v", err)
+	}
+	t.Chdir(repo)
+
+	t.Setenv("HOME", filepath.Join(tempDir, "home"))
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeUser)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorUser)
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{
+			"skillsync", "sync", "--yes", "--skip-backup", "--map", "repo=repo", "--map", "user=user", "claudecode", "cursor",
+		})
+	})
+	util.AssertNoError(t, runErr)
+
+	for path, want := range map[string]bool{
+		filepath.Join(repo, ".cursor", "skills", "shared"):   true,
+		filepath.Join(cursorUser, "personal"):                true,
+		filepath.Join(repo, ".cursor", "skills", "personal"): false,
+		filepath.Join(cursorUser, "shared"):                  false,
+	} {
+		_, err := os.Stat(path)
+		if got := err == nil; got != want {
+			t.Errorf("%s exists = %v, want %v\n%s", path, got, want, output)
+		}
+	}
+	for _, want := range []string{"repo -> repo", "user -> user"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestSyncScopeMap_RejectsTargetScope(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	err := Run(context.Background(), []string{
+		"skillsync", "sync", "--dry-run", "--map", "repo=repo", "claudecode", "cursor:" + string(model.ScopeUser),
+	})
+	if err == nil || !strings.Contains(err.Error(), "--map sets the target scopes") {
+		t.Errorf("Run() error = %v, want target scope error", err)
+	}
+}
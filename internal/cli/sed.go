@@ -0,0 +1,403 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/history"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/parser/codex"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// sedFileChange is the JSON representation of a skill file edited by sed.
+type sedFileChange struct {
+	Name         string           `json:"name"`
+	Platform     model.Platform   `json:"platform"`
+	Scope        model.SkillScope `json:"scope"`
+	Path         string           `json:"path"`
+	Replacements int              `json:"replacements"`
+	BackupID     string           `json:"backup_id,omitempty"`
+	Diff         []diffHunkOutput `json:"diff,omitempty"`
+}
+
+// sedOutput is the JSON representation of a sed run.
+type sedOutput struct {
+	Pattern      string          `json:"pattern"`
+	Replacement  string          `json:"replacement"`
+	Regex        bool            `json:"regex,omitempty"`
+	DryRun       bool            `json:"dry_run,omitempty"`
+	RunID        string          `json:"run_id,omitempty"`
+	Replacements int             `json:"replacements"`
+	Files        []sedFileChange `json:"files"`
+}
+
+// sedEdit is a skill file sed changes.
+type sedEdit struct {
+	skill        model.Skill
+	names        []string // The skills in the file that sed changes
+	before       string
+	after        string
+	replacements int
+}
+
+func sedCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "sed",
+		Usage:     "Search and replace text in the bodies of skills",
+		UsageText: "skillsync sed <pattern> <replacement> [--regex] [--platform PLATFORM] [--scope SCOPE] [--skill NAME...] [--dry-run] [--yes]",
+		Description: `Replace every occurrence of pattern with replacement in the body of
+   each discovered skill, after its frontmatter, which is left as it is.
+
+   The pattern is literal text unless --regex is given, in which case it is
+   a Go regular expression (RE2 syntax) and the replacement can refer to its
+   groups as $1 or ${name}. --ignore-case matches either way regardless of
+   case.
+
+   A diff of each file to be changed is shown before asking for
+   confirmation. Each file is backed up before it is written, so the whole
+   run can be reversed with 'skillsync undo'. Plugin skills are left alone.
+
+   Examples:
+     skillsync sed old-api.example.com new-api.example.com --dry-run
+     skillsync sed old-api.example.com new-api.example.com --platform cursor --yes
+     skillsync sed --regex 'v(\d+)\.example\.com' 'api-v$1.example.com'
+     skillsync sed --skill lint --scope repo 'npm run' 'pnpm run'`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "regex",
+				Aliases: []string{"E"},
+				Usage:   "Treat the pattern as a regular expression; $1 in the replacement is its first group",
+			},
+			&cli.BoolFlag{
+				Name:    "ignore-case",
+				Aliases: []string{"i"},
+				Usage:   "Match regardless of case",
+			},
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Usage:   "Only edit one platform (claude-code, cursor, codex, aider)",
+			},
+			&cli.StringFlag{
+				Name:    "scope",
+				Aliases: []string{"s"},
+				Usage:   "Only edit these scopes (repo, user, admin, system, all). Comma-separated for multiple.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "skill",
+				Usage: "Only edit the named skill; repeat for several",
+			},
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"d"},
+				Usage:   "Show the diffs without changing files",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Apply the replacements without asking",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-backup",
+				Usage: "Skip the backup of the edited files",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 2 {
+				return errors.New("sed requires exactly 2 arguments: <pattern> <replacement>")
+			}
+			return runSed(cmd, cmd.Args().Get(0), cmd.Args().Get(1))
+		},
+	}
+}
+
+// sedReplacer returns a function replacing pattern with replacement in a
+// string and reporting the number of replacements.
+func sedReplacer(pattern, replacement string, regex, ignoreCase bool) (func(string) (string, int), error) {
+	if pattern == "" {
+		return nil, errors.New("pattern must not be empty")
+	}
+	expr := pattern
+	if !regex {
+		expr = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return func(s string) (string, int) {
+		n := len(re.FindAllStringIndex(s, -1))
+		if n == 0 {
+			return s, 0
+		}
+		if regex {
+			return re.ReplaceAllString(s, replacement), n
+		}
+		return re.ReplaceAllLiteralString(s, replacement), n
+	}, nil
+}
+
+// planSedEdits applies replace to the body of each skill file, returning
+// the files it changes. Each file is edited once, however many skills it
+// holds.
+func planSedEdits(skills []model.Skill, replace func(string) (string, int)) ([]sedEdit, error) {
+	var paths []string
+	byPath := make(map[string][]model.Skill)
+	for _, skill := range skills {
+		if skill.Scope == model.ScopePlugin {
+			continue
+		}
+		if _, ok := byPath[skill.Path]; !ok {
+			paths = append(paths, skill.Path)
+		}
+		byPath[skill.Path] = append(byPath[skill.Path], skill)
+	}
+
+	var edits []sedEdit
+	for _, path := range paths {
+		// #nosec G304 - skill paths come from discovery
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		before := string(content)
+		body := parser.SplitFrontmatter(content).Content
+		if !strings.HasSuffix(before, body) {
+			body = before
+		}
+		replaced, names, n := replaceSkillText(byPath[path], body, replace)
+		if n == 0 {
+			continue
+		}
+		edits = append(edits, sedEdit{
+			skill:        byPath[path][0],
+			names:        names,
+			before:       before,
+			after:        before[:len(before)-len(body)] + replaced,
+			replacements: n,
+		})
+	}
+	slices.SortFunc(edits, func(a, b sedEdit) int { return strings.Compare(a.skill.Path, b.skill.Path) })
+	return edits, nil
+}
+
+// replaceSkillText applies replace to the text of skills in body, the body
+// of the file they share, returning the names of the skills it changed. A
+// skill aggregated into a Codex AGENTS.md or Aider CONVENTIONS.md owns only
+// its marked section, or the text outside sections; any other file belongs
+// to its skill as a whole.
+func replaceSkillText(skills []model.Skill, body string, replace func(string) (string, int)) (string, []string, int) {
+	if skills[0].Platform != model.Codex && skills[0].Platform != model.Aider {
+		replaced, n := replace(body)
+		return replaced, []string{skills[0].Name}, n
+	}
+
+	sectionOf := func(skill model.Skill) string {
+		if skill.Metadata[codex.SectionMetadataKey] != "" {
+			return skill.Name
+		}
+		return ""
+	}
+	owned := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		owned[sectionOf(skill)] = true
+	}
+	replaced, counts := codex.ReplaceInSections(body, func(name string) bool { return owned[name] }, replace)
+
+	var names []string
+	total := 0
+	for _, skill := range skills {
+		if n := counts[sectionOf(skill)]; n > 0 {
+			names = append(names, skill.Name)
+			total += n
+		}
+	}
+	return replaced, names, total
+}
+
+// runSed replaces a pattern in the bodies of the discovered skills after
+// showing the diffs and asking for confirmation, backing up each file so
+// that undo can restore it.
+func runSed(cmd *cli.Command, pattern, replacement string) error {
+	replace, err := sedReplacer(pattern, replacement, cmd.Bool("regex"), cmd.Bool("ignore-case"))
+	if err != nil {
+		return err
+	}
+	scopeFilter, err := parseScopeFilter(cmd.String("scope"))
+	if err != nil {
+		return err
+	}
+	platforms := model.AllPlatforms()
+	if name := cmd.String("platform"); name != "" {
+		platform, err := model.ParsePlatform(name)
+		if err != nil {
+			return fmt.Errorf("invalid platform: %w", err)
+		}
+		platforms = []model.Platform{platform}
+	}
+
+	var skills []model.Skill
+	for _, platform := range platforms {
+		found, err := parsePlatformSkillsWithScope(platform, scopeFilter, false)
+		if err != nil {
+			warnf("Warning: failed to parse %s: %v\n", platform, err)
+			continue
+		}
+		skills = append(skills, found...)
+	}
+	if names := cmd.StringSlice("skill"); len(names) > 0 {
+		skills = filterSkillsByName(skills, names)
+	}
+
+	edits, err := planSedEdits(skills, replace)
+	if err != nil {
+		return err
+	}
+	result := sedOutput{
+		Pattern:     pattern,
+		Replacement: replacement,
+		Regex:       cmd.Bool("regex"),
+		DryRun:      cmd.Bool("dry-run"),
+		Files:       make([]sedFileChange, 0, len(edits)),
+	}
+	for _, edit := range edits {
+		result.Replacements += edit.replacements
+		result.Files = append(result.Files, newSedFileChange(edit))
+	}
+	if len(edits) == 0 {
+		return out.Render(result, func() error {
+			fmt.Printf("No matches for %q in %d skill(s)\n", pattern, len(skills))
+			return nil
+		})
+	}
+	summary := fmt.Sprintf("%d replacement(s) in %d file(s)", result.Replacements, len(edits))
+
+	if !out.JSON() {
+		printSedDiffs(edits)
+		fmt.Printf("\n%s\n", summary)
+	}
+	if result.DryRun {
+		return out.Render(result, func() error {
+			fmt.Println("\nDry run: no files were changed")
+			return nil
+		})
+	}
+
+	if !cmd.Bool("yes") {
+		confirmed, err := confirmAction(fmt.Sprintf("Apply %s?", summary), riskLevelWarning)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Replace cancelled.")
+			return nil
+		}
+	}
+
+	run := history.Run{
+		ID:        backup.NewSessionID(),
+		Command:   "sed",
+		Target:    strings.Join(sedPlatforms(edits), ","),
+		StartedAt: time.Now(),
+		Success:   true,
+	}
+	result.RunID = run.ID
+	defer func() { recordSkillRun(run) }()
+
+	for i, edit := range edits {
+		record := history.SkillRecord{
+			Name:       edit.name(),
+			Action:     string(sync.ActionUpdated),
+			TargetPath: edit.skill.Path,
+			Message:    fmt.Sprintf("%d replacement(s) of %q", edit.replacements, pattern),
+		}
+		if !skipBackup(cmd) {
+			if result.Files[i].BackupID, err = backupCopy(edit.skill, "pre-sed backup", run.ID); err != nil {
+				run.Success = false
+				return err
+			}
+			record.Backups = []string{result.Files[i].BackupID}
+		}
+		if err := writeFileAtomic(edit.skill.Path, []byte(edit.after)); err != nil {
+			run.Success = false
+			return fmt.Errorf("failed to write %s: %w", edit.skill.Path, err)
+		}
+		run.Skills = append(run.Skills, record)
+	}
+
+	return out.Render(result, func() error {
+		fmt.Printf("\n✓ Made %s\n", summary)
+		if !skipBackup(cmd) {
+			fmt.Println(ui.Dim("Undo with: skillsync undo " + run.ID))
+		}
+		return nil
+	})
+}
+
+// newSedFileChange returns the JSON representation of an edit.
+func newSedFileChange(edit sedEdit) sedFileChange {
+	change := sedFileChange{
+		Name:         edit.name(),
+		Platform:     edit.skill.Platform,
+		Scope:        edit.skill.Scope,
+		Path:         edit.skill.Path,
+		Replacements: edit.replacements,
+	}
+	for _, hunk := range edit.preview().Hunks(sync.CurrentDiffOptions()) {
+		change.Diff = append(change.Diff, newDiffHunkOutput(hunk))
+	}
+	return change
+}
+
+// name returns the names of the skills the edit changes.
+func (e sedEdit) name() string {
+	return strings.Join(e.names, ", ")
+}
+
+// preview returns the edit as a file preview, to diff.
+func (e sedEdit) preview() *sync.FilePreview {
+	return &sync.FilePreview{Path: e.skill.Path, Exists: true, Before: e.before, After: e.after}
+}
+
+// printSedDiffs prints a colored unified diff of each file sed changes.
+func printSedDiffs(edits []sedEdit) {
+	opts := sync.CurrentDiffOptions()
+	for _, edit := range edits {
+		fmt.Printf("\n%s\n", ui.Header(fmt.Sprintf("diff %s (%s:%s, %d replacement(s))",
+			edit.name(), edit.skill.Platform, edit.skill.Scope, edit.replacements)))
+		fmt.Println(ui.Error("--- " + edit.skill.Path))
+		fmt.Println(ui.Success("+++ " + edit.skill.Path))
+		for _, hunk := range edit.preview().Hunks(opts) {
+			fmt.Println(ui.Info(newDiffHunkOutput(hunk).header()))
+			for _, line := range hunk.Lines {
+				fmt.Println(formatDiffLine(line))
+			}
+		}
+	}
+}
+
+// sedPlatforms returns the platforms of the edited skills, in order.
+func sedPlatforms(edits []sedEdit) []string {
+	var platforms []string
+	for _, edit := range edits {
+		if !slices.Contains(platforms, string(edit.skill.Platform)) {
+			platforms = append(platforms, string(edit.skill.Platform))
+		}
+	}
+	return platforms
+}
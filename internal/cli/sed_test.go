@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSedReplacer(t *testing.T) {
+	tests := map[string]struct {
+		pattern     string
+		replacement string
+		regex       bool
+		ignoreCase  bool
+		input       string
+		want        string
+		wantCount   int
+		wantErr     bool
+	}{
+		"literal": {
+			pattern: "api.example.com", replacement: "api.example.org",
+			input: "Call api.example.com or apiXexample.com", want: "Call api.example.org or apiXexample.com", wantCount: 1,
+		},
+		"literal keeps dollar signs": {
+			pattern: "cost", replacement: "$1 fee",
+			input: "the cost", want: "the $1 fee", wantCount: 1,
+		},
+		"regex with group": {
+			pattern: `v(\d+)\.example\.com`, replacement: "api-v$1.example.com", regex: true,
+			input: "v1.example.com and v22.example.com", want: "api-v1.example.com and api-v22.example.com", wantCount: 2,
+		},
+		"ignore case": {
+			pattern: "npm", replacement: "pnpm", ignoreCase: true,
+			input: "Run NPM install", want: "Run pnpm install", wantCount: 1,
+		},
+		"no match": {
+			pattern: "yarn", replacement: "pnpm",
+			input: "Run npm install", want: "Run npm install",
+		},
+		"invalid regex": {
+			pattern: "(", regex: true, wantErr: true,
+		},
+		"empty pattern": {
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			replace, err := sedReplacer(tt.pattern, tt.replacement, tt.regex, tt.ignoreCase)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			got, n := replace(tt.input)
+			util.AssertEqual(t, got, tt.want)
+			util.AssertEqual(t, n, tt.wantCount)
+		})
+	}
+}
+
+func TestSed(t *testing.T) {
+	const (
+		lint   = "---\nname: lint\ndescription: Lint old-api.example.com\n---\nCall old-api.example.com, then old-api.example.com/v2.\n"
+		linted = "---\nname: lint\ndescription: Lint old-api.example.com\n---\nCall new-api.example.com, then new-api.example.com/v2.\n"
+		review = "---\nname: review\ndescription: Review code\n---\nRead old-api.example.com docs.\n"
+	)
+
+	tests := map[string]struct {
+		args       []string
+		stdin      string
+		wantOutput []string
+		wantClaude string // content of the Claude Code lint skill afterwards
+		wantCursor string // content of the Cursor review skill afterwards
+	}{
+		"every platform": {
+			args:       []string{"--yes"},
+			wantOutput: []string{"+Call new-api.example.com", "3 replacement(s) in 2 file(s)", "Undo with: skillsync undo"},
+			wantClaude: linted,
+			wantCursor: strings.Replace(review, "old-api", "new-api", 1),
+		},
+		"one platform": {
+			args:       []string{"--platform", "cursor", "--yes"},
+			wantOutput: []string{"1 replacement(s) in 1 file(s)"},
+			wantClaude: lint,
+			wantCursor: strings.Replace(review, "old-api", "new-api", 1),
+		},
+		"one skill": {
+			args:       []string{"--skill", "lint", "--yes"},
+			wantOutput: []string{"2 replacement(s) in 1 file(s)"},
+			wantClaude: linted,
+			wantCursor: review,
+		},
+		"dry run": {
+			args:       []string{"--dry-run"},
+			wantOutput: []string{"-Call old-api.example.com", "Dry run: no files were changed"},
+			wantClaude: lint,
+			wantCursor: review,
+		},
+		"declined": {
+			stdin:      "n\n",
+			wantOutput: []string{"Replace cancelled."},
+			wantClaude: lint,
+			wantCursor: review,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, cursorSkills := setupStore(t)
+			claudeFile := filepath.Join(claudeSkills, "lint", "SKILL.md")
+			cursorFile := filepath.Join(cursorSkills, "review", "SKILL.md")
+			util.WriteFile(t, claudeFile, lint)
+			util.WriteFile(t, cursorFile, review)
+			if tt.stdin != "" {
+				withStdin(t, tt.stdin)
+			}
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync", "sed", "old-api.example.com", "new-api.example.com"}, tt.args...))
+			})
+			util.AssertNoError(t, runErr)
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+			for path, want := range map[string]string{claudeFile: tt.wantClaude, cursorFile: tt.wantCursor} {
+				data, err := os.ReadFile(path)
+				util.AssertNoError(t, err)
+				util.AssertEqual(t, string(data), want)
+			}
+		})
+	}
+}
+
+func TestSed_Undo(t *testing.T) {
+	const lint = "---\nname: lint\n---\nCall old-api.example.com.\n"
+	claudeSkills, _ := setupStore(t)
+	path := filepath.Join(claudeSkills, "lint", "SKILL.md")
+	util.WriteFile(t, path, lint)
+
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "sed", "old-api", "new-api", "--yes"}))
+	})
+	data, err := os.ReadFile(path)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), "---\nname: lint\n---\nCall new-api.example.com.\n")
+
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "undo", "--force"}))
+	})
+	data, err = os.ReadFile(path)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), lint)
+}
+
+func TestSed_AgentsSection(t *testing.T) {
+	const agents = `<!-- skillsync:begin name="lint" -->
+Call old-api.example.com.
+<!-- skillsync:end name="lint" -->
+
+<!-- skillsync:begin name="review" -->
+Read old-api.example.com docs.
+<!-- skillsync:end name="review" -->
+`
+	claudeSkills, _ := setupStore(t)
+	codexSkills := filepath.Join(filepath.Dir(claudeSkills), "codex")
+	t.Setenv("SKILLSYNC_CODEX_SKILLS_PATHS", codexSkills)
+	t.Setenv("SKILLSYNC_CODEX_PATH", codexSkills)
+	path := filepath.Join(codexSkills, "AGENTS.md")
+	util.WriteFile(t, path, agents)
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "sed", "old-api", "new-api", "--platform", "codex", "--skill", "lint", "--yes", "--skip-backup"})
+	})
+	util.AssertNoError(t, runErr)
+	if !strings.Contains(output, "1 replacement(s) in 1 file(s)") {
+		t.Errorf("output missing the replacement count:\n%s", output)
+	}
+
+	data, err := os.ReadFile(path)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), strings.Replace(agents, "Call old-api", "Call new-api", 1))
+}
@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/selfupdate"
+)
+
+// newUpdateClient returns the client self-update reads releases with.
+// Tests replace it to serve releases locally.
+var newUpdateClient = selfupdate.NewClient
+
+// executablePath returns the path of the running binary.
+var executablePath = os.Executable
+
+func selfUpdateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "self-update",
+		Usage:     "Update skillsync to the latest release",
+		UsageText: "skillsync self-update [--check-only] [--channel stable|prerelease] [--yes] [--force] [--insecure-skip-signature]",
+		Description: `Check GitHub for a newer release and replace the running binary with it.
+
+   The release archive is checked against the checksums published with the
+   release, and the checksums against their signature by the release key built
+   into skillsync, before the binary is replaced. Builds without a release key,
+   such as development builds, refuse to update unless
+   --insecure-skip-signature is given, which trusts the checksums as published.
+   A failed update leaves the current binary in place.
+
+   Installs managed by Homebrew or Scoop are left to the package manager;
+   use brew upgrade or scoop update instead, or --force to replace anyway.
+
+   The channel defaults to update.channel in the config (stable). The
+   prerelease channel also considers release candidates:
+
+     update:
+       channel: prerelease
+
+   Examples:
+     skillsync self-update --check-only
+     skillsync self-update --yes
+     skillsync self-update --channel prerelease`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "check-only",
+				Usage: "Report whether a newer release is available without installing it",
+			},
+			&cli.StringFlag{
+				Name:  "channel",
+				Usage: "Release channel: stable or prerelease (default: update.channel)",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Skip the confirmation prompt",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Install even when up to date, on a development build, or when a package manager owns the binary",
+			},
+			&cli.BoolFlag{
+				Name:  "insecure-skip-signature",
+				Usage: "Install without verifying the release signature, checking only the published checksums",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runSelfUpdate(ctx, selfUpdateOptions{
+				checkOnly:     cmd.Bool("check-only"),
+				channel:       cmd.String("channel"),
+				yes:           cmd.Bool("yes"),
+				force:         cmd.Bool("force"),
+				skipSignature: cmd.Bool("insecure-skip-signature"),
+			})
+		},
+	}
+}
+
+// selfUpdateOptions are the flags of self-update.
+type selfUpdateOptions struct {
+	checkOnly     bool
+	channel       string
+	yes           bool
+	force         bool
+	skipSignature bool
+}
+
+// selfUpdateOutput is the result of self-update.
+type selfUpdateOutput struct {
+	Current         string `json:"current"`
+	Latest          string `json:"latest"`
+	Channel         string `json:"channel"`
+	URL             string `json:"url,omitempty"`
+	UpdateAvailable bool   `json:"update_available"`
+	Updated         bool   `json:"updated"`
+	Path            string `json:"path,omitempty"`
+}
+
+func runSelfUpdate(ctx context.Context, opts selfUpdateOptions) error {
+	channelName := opts.channel
+	if channelName == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		channelName = cfg.Update.Channel
+	}
+	channel, err := selfupdate.ParseChannel(channelName)
+	if err != nil {
+		return err
+	}
+
+	client := newUpdateClient()
+	release, err := client.Latest(ctx, channel)
+	if err != nil {
+		return err
+	}
+	result := selfUpdateOutput{
+		Current:         Version,
+		Latest:          release.Version(),
+		Channel:         string(channel),
+		URL:             release.URL,
+		UpdateAvailable: selfupdate.Newer(release.Version(), Version),
+	}
+
+	if opts.checkOnly {
+		return out.Render(result, func() error {
+			printUpdateStatus(result)
+			return nil
+		})
+	}
+	if !result.UpdateAvailable && !opts.force {
+		if !selfupdate.IsRelease(Version) {
+			return fmt.Errorf("this is a development build (%s); use --force to replace it with %s", Version, result.Latest)
+		}
+		return out.Render(result, func() error {
+			printUpdateStatus(result)
+			return nil
+		})
+	}
+
+	exe, err := executablePath()
+	if err != nil {
+		return fmt.Errorf("failed to locate the skillsync binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	if manager, upgrade := selfupdate.ManagedBy(exe); manager != "" && !opts.force {
+		return fmt.Errorf("%s is managed by %s; run '%s' instead, or use --force", exe, manager, upgrade)
+	}
+	result.Path = exe
+
+	var publicKey ed25519.PublicKey
+	switch {
+	case opts.skipSignature:
+	case UpdatePublicKey == "":
+		return errors.New("this build has no release key to verify the release signature with; " +
+			"use --insecure-skip-signature to install with only the published checksums verified")
+	default:
+		if publicKey, err = selfupdate.ParsePublicKey(UpdatePublicKey); err != nil {
+			return err
+		}
+	}
+
+	if !opts.yes {
+		confirmed, err := confirmAction(fmt.Sprintf("Replace %s (%s) with skillsync %s?", exe, Version, result.Latest), riskLevelWarning)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			out.Println("Update cancelled.")
+			return nil
+		}
+	}
+
+	out.Printf("Downloading skillsync %s for %s/%s...\n", result.Latest, runtime.GOOS, runtime.GOARCH)
+	binary, err := client.Download(ctx, release, runtime.GOOS, runtime.GOARCH, publicKey)
+	if err != nil {
+		return err
+	}
+	if publicKey == nil {
+		stderrWarnf("Warning: the release signature was not verified (--insecure-skip-signature)\n")
+	}
+	if err := selfupdate.Replace(exe, binary); err != nil {
+		return err
+	}
+	result.Updated = true
+
+	return out.Render(result, func() error {
+		out.Printf("✓ Updated %s from %s to %s\n", exe, result.Current, result.Latest)
+		return nil
+	})
+}
+
+// printUpdateStatus reports whether a newer release is available.
+func printUpdateStatus(result selfUpdateOutput) {
+	switch {
+	case result.UpdateAvailable:
+		out.Printf("skillsync %s is available (current: %s, channel: %s)\n", result.Latest, result.Current, result.Channel)
+		if result.URL != "" {
+			out.Printf("  %s\n", result.URL)
+		}
+		out.Println("Run 'skillsync self-update' to install it.")
+	case !selfupdate.IsRelease(result.Current):
+		out.Printf("Latest %s release: %s (current: development build %s)\n", result.Channel, result.Latest, result.Current)
+	default:
+		out.Printf("skillsync %s is up to date (channel: %s)\n", result.Current, result.Channel)
+	}
+}
@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/selfupdate"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// serveRelease serves a single release, tag, with a checksummed archive of
+// binary for the running platform and checksums signed by a new release key,
+// and points self-update at it, built with that key.
+func serveRelease(t *testing.T, tag, binary string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("release archives for Windows are zip files")
+	}
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	util.AssertNoError(t, tw.WriteHeader(&tar.Header{Name: "skillsync", Mode: 0o755, Size: int64(len(binary)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte(binary))
+	util.AssertNoError(t, err)
+	util.AssertNoError(t, tw.Close())
+	util.AssertNoError(t, gz.Close())
+
+	version := strings.TrimPrefix(tag, "v")
+	archiveName := selfupdate.ArchiveName(version, runtime.GOOS, runtime.GOARCH)
+	sum := sha256.Sum256(archive.Bytes())
+	checksums := []byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), archiveName))
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	util.AssertNoError(t, err)
+	assets := map[string][]byte{
+		archiveName:         archive.Bytes(),
+		"checksums.txt":     checksums,
+		"checksums.txt.sig": []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, checksums))),
+	}
+	withUpdateKey(t, base64.StdEncoding.EncodeToString(publicKey))
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/releases") {
+			release := selfupdate.Release{Tag: tag, URL: "https://example.com/releases/" + tag}
+			for name := range assets {
+				release.Assets = append(release.Assets, selfupdate.Asset{Name: name, URL: server.URL + "/assets/" + name})
+			}
+			_ = json.NewEncoder(w).Encode([]selfupdate.Release{release})
+			return
+		}
+		_, _ = w.Write(assets[filepath.Base(r.URL.Path)])
+	}))
+	t.Cleanup(server.Close)
+
+	newUpdateClient = func() *selfupdate.Client {
+		return &selfupdate.Client{HTTP: server.Client(), APIURL: server.URL, Repo: selfupdate.DefaultRepo}
+	}
+	t.Cleanup(func() { newUpdateClient = selfupdate.NewClient })
+}
+
+// withExecutable makes self-update replace path instead of the test binary.
+func withExecutable(t *testing.T, path string) {
+	t.Helper()
+	executablePath = func() (string, error) { return path, nil }
+	t.Cleanup(func() { executablePath = os.Executable })
+}
+
+// withUpdateKey sets the release key the running build verifies with.
+func withUpdateKey(t *testing.T, key string) {
+	t.Helper()
+	previous := UpdatePublicKey
+	UpdatePublicKey = key
+	t.Cleanup(func() { UpdatePublicKey = previous })
+}
+
+// withVersion sets the running version.
+func withVersion(t *testing.T, version string) {
+	t.Helper()
+	previous := Version
+	Version = version
+	t.Cleanup(func() { Version = previous })
+}
+
+func TestSelfUpdate(t *testing.T) {
+	tests := map[string]struct {
+		version    string
+		exeDir     string
+		updateKey  string // Replaces the release key when set; "none" for no key
+		args       []string
+		wantErr    string
+		wantOutput string
+		wantBinary string
+	}{
+		"check only": {
+			version:    "1.0.0",
+			args:       []string{"--check-only"},
+			wantOutput: "skillsync 1.1.0 is available (current: 1.0.0, channel: stable)",
+			wantBinary: "old",
+		},
+		"up to date": {
+			version:    "1.1.0",
+			args:       []string{"--yes"},
+			wantOutput: "skillsync 1.1.0 is up to date",
+			wantBinary: "old",
+		},
+		"update": {
+			version:    "1.0.0",
+			args:       []string{"--yes"},
+			wantOutput: "Updated",
+			wantBinary: "new",
+		},
+		"development build": {
+			version:    "dev",
+			args:       []string{"--yes"},
+			wantErr:    "use --force",
+			wantBinary: "old",
+		},
+		"development build forced": {
+			version:    "dev",
+			args:       []string{"--yes", "--force"},
+			wantBinary: "new",
+		},
+		"no release key": {
+			version:    "1.0.0",
+			updateKey:  "none",
+			args:       []string{"--yes"},
+			wantErr:    "use --insecure-skip-signature",
+			wantBinary: "old",
+		},
+		"no release key skipped": {
+			version:    "1.0.0",
+			updateKey:  "none",
+			args:       []string{"--yes", "--insecure-skip-signature"},
+			wantOutput: "Updated",
+			wantBinary: "new",
+		},
+		"signed by another key": {
+			version:    "1.0.0",
+			updateKey:  "dGhpcyBpcyBub3QgdGhlIHJlbGVhc2Uga2V5ISEhISE=",
+			args:       []string{"--yes"},
+			wantErr:    "release signature verification failed",
+			wantBinary: "old",
+		},
+		"homebrew": {
+			version:    "1.0.0",
+			exeDir:     filepath.Join("Cellar", "skillsync", "1.0.0", "bin"),
+			args:       []string{"--yes"},
+			wantErr:    "brew upgrade skillsync",
+			wantBinary: "old",
+		},
+		"invalid channel": {
+			version:    "1.0.0",
+			args:       []string{"--channel", "nightly"},
+			wantErr:    "unknown release channel",
+			wantBinary: "old",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			setupStore(t)
+			serveRelease(t, "v1.1.0", "new")
+			switch tt.updateKey {
+			case "":
+			case "none":
+				withUpdateKey(t, "")
+			default:
+				withUpdateKey(t, tt.updateKey)
+			}
+			withVersion(t, tt.version)
+			exe := filepath.Join(t.TempDir(), tt.exeDir, "skillsync")
+			util.WriteFile(t, exe, "old")
+			withExecutable(t, exe)
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync", "self-update"}, tt.args...))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want %q", runErr, tt.wantErr)
+				}
+			} else {
+				util.AssertNoError(t, runErr)
+			}
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output missing %q:\n%s", tt.wantOutput, output)
+			}
+			data, err := os.ReadFile(exe)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, string(data), tt.wantBinary)
+		})
+	}
+}
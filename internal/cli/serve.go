@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/browse"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func serveCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "Serve a read-only JSON API and web UI for the skill catalog",
+		UsageText: `skillsync serve [options]
+   skillsync serve
+   skillsync serve --addr 127.0.0.1:9000
+   skillsync serve --allow-origin http://localhost:3000`,
+		Description: `Start a local HTTP server exposing the skill catalog to dashboards and
+   editor extensions, using the same discovery as the other commands.
+
+   JSON API:
+     GET /skills           All skills (filter with ?q=, ?platform=, ?scope=)
+     GET /skills/{name}    Every copy of a skill (filter with ?platform=)
+     GET /platforms        Supported platforms with their skill counts
+     GET /backups          Backup metadata, newest first (filter with ?platform=)
+
+   The web UI of 'skillsync browse' is served at /. Errors are returned as
+   {"error": "..."} with a 4xx or 5xx status.
+
+   The server is read-only and reloads skills on every request. It listens on
+   localhost by default; --allow-origin lets a browser app on another origin
+   call the API. Press Ctrl+C to stop it.
+
+   Examples:
+     skillsync serve                                      # http://127.0.0.1:7420
+     curl http://127.0.0.1:7420/skills?platform=cursor    # Cursor skills as JSON`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "addr",
+				Value: "127.0.0.1:7420",
+				Usage: "Address to listen on",
+			},
+			&cli.BoolFlag{
+				Name:  "include-plugins",
+				Usage: "Include Claude Code plugin skills",
+			},
+			&cli.StringFlag{
+				Name:  "allow-origin",
+				Usage: "Origin allowed to call the API from a browser (e.g. http://localhost:3000, or * for any)",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			includePlugins := cmd.Bool("include-plugins")
+			server, err := browse.New(browse.Options{
+				Skills: func() ([]model.Skill, error) {
+					return discoverBrowseSkills(includePlugins)
+				},
+				Backups:     func() ([]backup.Metadata, error) { return backup.ListBackups("") },
+				AllowOrigin: cmd.String("allow-origin"),
+			})
+			if err != nil {
+				return err
+			}
+			return listenAndServe(ctx, cmd.String("addr"), server, "Serving the skill catalog at http://%s (read-only, Ctrl+C to stop)\n")
+		},
+	}
+}
@@ -0,0 +1,307 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/validation"
+	"github.com/klauern/skillsync/internal/versions"
+)
+
+// skillShowOutput is the JSON representation of a resolved skill.
+type skillShowOutput struct {
+	Name        string           `json:"name"`
+	Platform    model.Platform   `json:"platform"`
+	Scope       model.SkillScope `json:"scope,omitempty"`
+	Path        string           `json:"path"`
+	Origin      *model.Origin    `json:"origin,omitempty"`
+	Frontmatter map[string]any   `json:"frontmatter"`
+	Content     string           `json:"content"`
+}
+
+// skillFieldOutput is the JSON representation of one field of a skill.
+type skillFieldOutput struct {
+	Name  string `json:"name"`
+	Field string `json:"field"`
+	Value any    `json:"value"`
+}
+
+// skillVersionOutput is the JSON representation of a recorded skill version.
+type skillVersionOutput struct {
+	Name string `json:"name"`
+	versions.Version
+	Content string `json:"content,omitempty"`
+}
+
+func skillCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "skill",
+		Usage: "Print, edit, remove, and rename individual skills and see their version history",
+		UsageText: `skillsync skill show <name> [--platform PLATFORM] [--field FIELD]
+   skillsync skill edit <name> [--platform PLATFORM]
+   skillsync skill rm <name> --platform PLATFORM [--scope SCOPE]
+   skillsync skill mv <old> <new> [--platform PLATFORM...] [--scope SCOPE]
+   skillsync skill history <name>
+   skillsync skill show <name>@<version>`,
+		Description: `skill show prints a skill as the platform resolves it: of the copies in
+   its scopes, the one with the highest precedence, with its frontmatter and
+   content. --field prints a single frontmatter value, or the skill's content,
+   path, platform, scope, or origin (where it was imported from), for scripts.
+
+   skill edit opens the same file in $EDITOR and waits for it to close. If
+   the file changed, the skill is validated, and you are asked whether to
+   sync it over the copies of the other platforms that have the skill.
+
+   skill rm removes a skill from the given platforms, and skill mv renames
+   it on the given platforms, or every platform that has it: the file or
+   skill directory is moved and the frontmatter name updated, and if any
+   copy cannot be renamed none are. Both ask first, back up the files they
+   change, and record a run that skillsync undo reverses. Without --scope,
+   each platform's copy is the one it resolves; --scope picks repo or user.
+
+   Every sync that writes a skill records the synced content as a new
+   version of the skill when it differs from the last one, in
+   ~/.skillsync/metadata/versions. The history follows a skill by name across
+   platforms and syncs, independent of backups.
+
+   A version is given by its number, by at least 4 characters of its hash, or
+   as latest.
+
+   Examples:
+     skillsync skill show lint
+     skillsync skill show lint --platform cursor
+     skillsync skill show lint --field description
+     skillsync skill edit lint --platform claudecode
+     skillsync skill rm lint --platform cursor --scope user
+     skillsync skill mv lint lint-go --dry-run
+     skillsync skill history lint
+     skillsync skill show lint@3
+     skillsync skill show lint@9f2c4e1a
+     skillsync skill show lint@latest > lint.md`,
+		Commands: []*cli.Command{
+			skillEditCommand(),
+			skillRmCommand(),
+			skillMvCommand(),
+			{
+				Name:      "history",
+				Usage:     "List the recorded versions of a skill",
+				UsageText: "skillsync skill history <name>",
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					if cmd.Args().Len() != 1 {
+						return errors.New("skill history requires exactly 1 argument: <name>")
+					}
+					return showSkillHistory(cmd.Args().First())
+				},
+			},
+			{
+				Name:    "show",
+				Aliases: []string{"cat"},
+				Usage:   "Print a skill, or a recorded version of it",
+				UsageText: `skillsync skill show <name> [--platform PLATFORM] [--field FIELD]
+   skillsync skill show <name>@<version>`,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "platform",
+						Aliases: []string{"p"},
+						Usage:   "Platform to read the skill from (default: the first platform that has it)",
+					},
+					&cli.StringFlag{
+						Name:  "field",
+						Usage: "Print only one field: a frontmatter key, or content, path, platform, scope, origin",
+					},
+				},
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					if cmd.Args().Len() != 1 {
+						return errors.New("skill show requires exactly 1 argument: <name> or <name>@<version>")
+					}
+					name, ref, ok := strings.Cut(cmd.Args().First(), "@")
+					if !ok {
+						return showResolvedSkill(cmd, name)
+					}
+					if name == "" || ref == "" {
+						return fmt.Errorf("skill show requires a version: %s@<version> (see skill history %s)", name, name)
+					}
+					if cmd.IsSet("platform") || cmd.IsSet("field") {
+						return errors.New("--platform and --field do not apply to recorded versions")
+					}
+					return showSkillVersion(name, ref)
+				},
+			},
+		},
+	}
+}
+
+// showSkillHistory prints the recorded versions of a skill, oldest first.
+func showSkillHistory(name string) error {
+	list, err := versions.List(name)
+	if err != nil && !errors.Is(err, versions.ErrNoHistory) {
+		return err
+	}
+	outputs := make([]skillVersionOutput, 0, len(list))
+	for _, v := range list {
+		outputs = append(outputs, skillVersionOutput{Name: name, Version: v})
+	}
+
+	return out.Render(outputs, func() error {
+		if len(outputs) == 0 {
+			fmt.Printf("No versions of %s recorded. Versions are recorded when a sync writes the skill.\n", name)
+			return nil
+		}
+		fmt.Printf("%s %s %s %s %s\n",
+			ui.Header(fmt.Sprintf("%-7s", "VERSION")),
+			ui.Header(fmt.Sprintf("%-12s", "HASH")),
+			ui.Header(fmt.Sprintf("%-19s", "RECORDED")),
+			ui.Header(fmt.Sprintf("%-28s", "SOURCE -> TARGET")),
+			ui.Header("SIZE"))
+		for _, v := range list {
+			fmt.Printf("%-7d %-12s %-19s %-28s %d\n",
+				v.Number,
+				v.ShortHash(),
+				v.RecordedAt.Local().Format("2006-01-02 15:04:05"),
+				truncateCell(v.Source+" -> "+v.Target, 28),
+				v.Size)
+		}
+		return nil
+	})
+}
+
+// showSkillVersion prints the content of a recorded version of a skill.
+func showSkillVersion(name, ref string) error {
+	v, content, err := versions.Get(name, ref)
+	if err != nil {
+		return err
+	}
+	output := skillVersionOutput{Name: name, Version: v, Content: string(content)}
+	return out.Render(output, func() error {
+		fmt.Print(output.Content)
+		return nil
+	})
+}
+
+// resolveSkill returns the skill with the given name as the platform resolves
+// it, from the highest-precedence scope that has it. Without a platform, the
+// platforms are searched in order and the first that has the skill is used.
+func resolveSkill(name, platformName string) (model.Skill, error) {
+	platforms := model.AllPlatforms()
+	if platformName != "" {
+		platform, err := model.ParsePlatform(platformName)
+		if err != nil {
+			return model.Skill{}, fmt.Errorf("invalid platform: %w", err)
+		}
+		platforms = []model.Platform{platform}
+	}
+	for _, platform := range platforms {
+		skills, err := parsePlatformSkillsWithScope(platform, nil, true)
+		if err != nil {
+			continue
+		}
+		for _, skill := range skills {
+			if skill.Name == name {
+				return skill, nil
+			}
+		}
+	}
+	if platformName != "" {
+		return model.Skill{}, fmt.Errorf("skill %q not found on %s", name, platforms[0])
+	}
+	return model.Skill{}, fmt.Errorf("skill %q not found on any platform", name)
+}
+
+// skillFieldValue returns a field of a skill: content, path, platform,
+// scope, origin, or a frontmatter key.
+func skillFieldValue(skill model.Skill, frontmatter map[string]any, field string) (any, error) {
+	switch field {
+	case "content":
+		return skill.Content, nil
+	case "path":
+		return skill.Path, nil
+	case "platform":
+		return string(skill.Platform), nil
+	case "scope":
+		return string(skill.Scope), nil
+	case "origin":
+		if origin, ok := skill.Origin(); ok {
+			return origin.String(), nil
+		}
+		return nil, fmt.Errorf("skill %q has no recorded origin", skill.Name)
+	}
+	if value, ok := frontmatter[field]; ok {
+		return value, nil
+	}
+	switch field {
+	case "name":
+		return skill.Name, nil
+	case "description":
+		return skill.Description, nil
+	}
+	return nil, fmt.Errorf("skill %q has no field %q", skill.Name, field)
+}
+
+// formatSkill returns the skill with its frontmatter, as in a skill file.
+func formatSkill(skill model.Skill) string {
+	var b strings.Builder
+	if skill.Frontmatter != "" {
+		b.WriteString("---\n")
+		b.WriteString(strings.TrimRight(skill.Frontmatter, "\n"))
+		b.WriteString("\n---\n")
+	}
+	b.WriteString(skill.Content)
+	if !strings.HasSuffix(skill.Content, "\n") {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// showResolvedSkill prints the resolved skill, or one field of it.
+func showResolvedSkill(cmd *cli.Command, name string) error {
+	skill, err := resolveSkill(name, cmd.String("platform"))
+	if err != nil {
+		return err
+	}
+	frontmatter := validation.SkillFrontmatter(skill)
+
+	if field := cmd.String("field"); field != "" {
+		value, err := skillFieldValue(skill, frontmatter, field)
+		if err != nil {
+			return err
+		}
+		return out.Render(skillFieldOutput{Name: skill.Name, Field: field, Value: value}, func() error {
+			switch v := value.(type) {
+			case string:
+				fmt.Println(strings.TrimRight(v, "\n"))
+			case map[string]any, []any:
+				data, err := yaml.Marshal(v)
+				if err != nil {
+					return err
+				}
+				fmt.Print(string(data))
+			default:
+				fmt.Println(v)
+			}
+			return nil
+		})
+	}
+
+	output := skillShowOutput{
+		Name:        skill.Name,
+		Platform:    skill.Platform,
+		Scope:       skill.Scope,
+		Path:        skill.Path,
+		Frontmatter: frontmatter,
+		Content:     skill.Content,
+	}
+	if origin, ok := skill.Origin(); ok {
+		output.Origin = &origin
+	}
+	return out.Render(output, func() error {
+		fmt.Print(formatSkill(skill))
+		return nil
+	})
+}
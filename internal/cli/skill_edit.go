@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/validation"
+)
+
+func skillEditCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "edit",
+		Usage:     "Open a skill's file in $EDITOR, then validate and sync the change",
+		UsageText: "skillsync skill edit <name> [--platform PLATFORM] [--no-validate] [--no-sync] [--yes]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Usage:   "Platform whose copy of the skill to edit (default: the first platform that has it)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-validate",
+				Usage: "Do not validate the skill after editing",
+			},
+			&cli.BoolFlag{
+				Name:  "no-sync",
+				Usage: "Do not offer to sync the edited skill to the other platforms that have it",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Sync the edited skill to the other platforms without asking",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-backup",
+				Usage: "Skip the backup of the copies the sync replaces",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("skill edit requires exactly 1 argument: <name>")
+			}
+			return runSkillEdit(cmd, cmd.Args().First())
+		},
+	}
+}
+
+// runSkillEdit opens the resolved skill in the user's editor. When the file
+// changed, the skill is validated and, after confirmation, synced over the
+// copies of the other platforms.
+func runSkillEdit(cmd *cli.Command, name string) error {
+	skill, err := resolveSkill(name, cmd.String("platform"))
+	if err != nil {
+		return err
+	}
+	if skill.Path == "" {
+		return fmt.Errorf("skill %q has no file to edit", name)
+	}
+	if skill.Scope == model.ScopePlugin {
+		warnf("Warning: %s comes from a plugin; edits are lost when the plugin is updated\n", skill.Path)
+	}
+
+	// #nosec G304 - skill.Path is a discovered skill file
+	before, err := os.ReadFile(skill.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", skill.Path, err)
+	}
+	if err := openInEditor(skill.Path); err != nil {
+		return err
+	}
+	// #nosec G304 - skill.Path is a discovered skill file
+	after, err := os.ReadFile(skill.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", skill.Path, err)
+	}
+	if bytes.Equal(before, after) {
+		out.Println("No changes to " + skill.Path)
+		return nil
+	}
+	out.Println("Saved " + skill.Path)
+
+	edited, err := reloadSkill(skill)
+	if err != nil {
+		return err
+	}
+	if !cmd.Bool("no-validate") {
+		if err := validateEditedSkill(edited); err != nil {
+			return err
+		}
+	}
+	if cmd.Bool("no-sync") {
+		return nil
+	}
+	return syncEditedSkill(cmd, edited)
+}
+
+// openInEditor runs the user's editor on path, attached to the terminal, and
+// waits for it to exit. The editor command may include arguments, as in
+// "code --wait".
+func openInEditor(path string) error {
+	editor, err := findEditor()
+	if err != nil {
+		return err
+	}
+	args := strings.Fields(editor)
+	// #nosec G204 - the editor is chosen by the user
+	editorCmd := exec.Command(args[0], append(args[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", args[0], err)
+	}
+	return nil
+}
+
+// reloadSkill parses a skill again after its file was edited, finding it by
+// path since the edit may have renamed it.
+func reloadSkill(skill model.Skill) (model.Skill, error) {
+	skills, err := parsePlatformSkillsWithScope(skill.Platform, []model.SkillScope{skill.Scope}, skill.Scope == model.ScopePlugin)
+	if err != nil {
+		return model.Skill{}, fmt.Errorf("failed to parse %s skills: %w", skill.Platform, err)
+	}
+	for _, s := range skills {
+		if s.Path == skill.Path {
+			return s, nil
+		}
+	}
+	return model.Skill{}, fmt.Errorf("%s no longer parses as a %s skill; fix the file and check it with skillsync validate", skill.Path, skill.Platform)
+}
+
+// validateEditedSkill runs the validation rules against an edited skill,
+// printing the issues found. It fails when any is an error.
+func validateEditedSkill(skill model.Skill) error {
+	appConfig, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	severities, err := validation.ParseRuleSeverities(appConfig.Validation.Rules)
+	if err != nil {
+		return fmt.Errorf("invalid validation.rules config: %w", err)
+	}
+	schema, err := loadFrontmatterSchema(appConfig)
+	if err != nil {
+		return err
+	}
+
+	report := validation.CheckSkills([]model.Skill{skill}, validation.CheckOptions{Severities: severities, Schema: schema})
+	if len(report.Issues) > 0 {
+		printValidationReport(report)
+	}
+	if errs := report.Errors(); errs > 0 {
+		return fmt.Errorf("validation found %d error(s) in %s; run skill edit again to fix them", errs, skill.Name)
+	}
+	recordWarnings(report.Warnings())
+	return nil
+}
+
+// otherCopies returns the copy of the skill on each other platform that has
+// one, in the scope each platform resolves it from. Plugin copies are left
+// out since they are not synced to.
+func otherCopies(skill model.Skill) []model.Skill {
+	var copies []model.Skill
+	for _, platform := range model.AllPlatforms() {
+		if platform == skill.Platform {
+			continue
+		}
+		skills, err := parsePlatformSkillsWithScope(platform, nil, false)
+		if err != nil {
+			continue
+		}
+		for _, s := range skills {
+			if s.Name == skill.Name {
+				copies = append(copies, s)
+				break
+			}
+		}
+	}
+	return copies
+}
+
+// syncEditedSkill offers to overwrite the other platforms' copies of an
+// edited skill with it.
+func syncEditedSkill(cmd *cli.Command, skill model.Skill) error {
+	copies := otherCopies(skill)
+	if len(copies) == 0 {
+		return nil
+	}
+	labels := make([]string, len(copies))
+	for i, c := range copies {
+		labels[i] = fmt.Sprintf("%s:%s", c.Platform, c.Scope)
+	}
+
+	if !cmd.Bool("yes") {
+		confirmed, err := confirmAction(fmt.Sprintf("Sync %s to %s?", skill.Name, strings.Join(labels, ", ")), riskLevelInfo)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Not synced; run skillsync sync to sync the change later")
+			return nil
+		}
+	}
+
+	startedAt := time.Now()
+	opts := sync.Options{
+		Strategy:  sync.StrategyOverwrite,
+		SessionID: backup.NewSessionID(),
+		Backup:    !skipBackup(cmd),
+	}
+	results := make([]*sync.Result, 0, len(copies))
+	var syncErr error
+	for _, c := range copies {
+		if opts.Backup {
+			prepareBackup(c.Platform)
+		}
+		opts.TargetScope = c.Scope
+		result, err := sync.New().SyncWithSkills([]model.Skill{skill}, c.Platform, opts)
+		if err != nil {
+			syncErr = fmt.Errorf("sync to %s failed: %w", c.Platform, err)
+			break
+		}
+		results = append(results, result)
+	}
+	recordHistory(opts.SessionID, "skill edit", startedAt, results...)
+	if syncErr != nil {
+		return syncErr
+	}
+	return outputStoreSync(labels, results)
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// fakeEditor sets $EDITOR to a script running the shell command body, with
+// the edited file as $1.
+func fakeEditor(t *testing.T, body string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "editor.sh")
+	util.WriteFile(t, path, "#!/bin/sh\n"+body+"\n")
+	if err := os.Chmod(path, 0o700); err != nil {
+		t.Fatalf("failed to make editor executable: %v", err)
+	}
+	t.Setenv("EDITOR", path)
+}
+
+func TestSkillEdit(t *testing.T) {
+	const original = "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n"
+
+	tests := map[string]struct {
+		editor      string
+		args        []string
+		stdin       string
+		wantErr     string
+		wantOutput  string
+		wantCursor  string // excerpt of the Cursor copy afterwards
+		wantCopyOld bool   // the Cursor copy is left as it was
+	}{
+		"syncs the edit with yes": {
+			editor:     `echo "Then fix it." >> "$1"`,
+			args:       []string{"--yes"},
+			wantOutput: "Saved ",
+			wantCursor: "Then fix it.",
+		},
+		"syncs the edit when confirmed": {
+			editor:     `echo "Then fix it." >> "$1"`,
+			stdin:      "y\n",
+			wantOutput: "Sync lint to cursor:user?",
+			wantCursor: "Then fix it.",
+		},
+		"declined sync": {
+			editor:      `echo "Then fix it." >> "$1"`,
+			stdin:       "n\n",
+			wantOutput:  "Not synced",
+			wantCopyOld: true,
+		},
+		"no sync": {
+			editor:      `echo "Then fix it." >> "$1"`,
+			args:        []string{"--no-sync"},
+			wantCopyOld: true,
+		},
+		"unchanged file": {
+			editor:      "true",
+			wantOutput:  "No changes to ",
+			wantCopyOld: true,
+		},
+		"validation error": {
+			editor:      `printf -- "---\nname: lint\n---\nRun it.\n" > "$1"`,
+			args:        []string{"--yes"},
+			wantErr:     "validation found 1 error(s) in lint",
+			wantCopyOld: true,
+		},
+		"no validate": {
+			editor:     `printf -- "---\nname: lint\n---\nRun it.\n" > "$1"`,
+			args:       []string{"--yes", "--no-validate"},
+			wantCursor: "Run it.",
+		},
+		"failing editor": {
+			editor:      "exit 3",
+			wantErr:     "failed: exit status 3",
+			wantCopyOld: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, cursorSkills := setupStore(t)
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), original)
+			cursorCopy := filepath.Join(cursorSkills, "lint", "SKILL.md")
+			util.WriteFile(t, cursorCopy, original)
+			util.WriteFile(t, config.FilePath(), "validation:\n  rules:\n    require-description: error\n")
+			fakeEditor(t, tt.editor)
+			if tt.stdin != "" {
+				withStdin(t, tt.stdin)
+			}
+
+			var runErr error
+			output := captureOutput(t, func() {
+				args := append([]string{"skillsync", "skill", "edit", "lint", "--platform", "claudecode"}, tt.args...)
+				runErr = Run(context.Background(), args)
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("skill edit error = %v, want %q\n%s", runErr, tt.wantErr, output)
+				}
+			} else {
+				util.AssertNoError(t, runErr)
+			}
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output missing %q:\n%s", tt.wantOutput, output)
+			}
+
+			data, err := os.ReadFile(cursorCopy)
+			util.AssertNoError(t, err)
+			if tt.wantCopyOld {
+				util.AssertEqual(t, string(data), original)
+			} else if !strings.Contains(string(data), tt.wantCursor) {
+				t.Errorf("cursor copy = %q, want it to contain %q", data, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestSkillEdit_NoEditor(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+
+	for name, editor := range map[string]string{"unset": "", "blank": "  \t"} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("EDITOR", editor)
+			t.Setenv("VISUAL", "")
+
+			err := Run(context.Background(), []string{"skillsync", "skill", "edit", "lint"})
+			if err == nil || !strings.Contains(err.Error(), "no editor found") {
+				t.Fatalf("skill edit error = %v, want no editor found", err)
+			}
+		})
+	}
+}
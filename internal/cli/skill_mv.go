@@ -0,0 +1,282 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/history"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+func skillMvCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "mv",
+		Aliases:   []string{"rename"},
+		Usage:     "Rename a skill's file and frontmatter name on one or more platforms",
+		UsageText: "skillsync skill mv <old> <new> [--platform PLATFORM...] [--scope SCOPE] [--yes] [--dry-run]",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Usage:   "Platform to rename the skill on; repeat for several (default: every platform that has it)",
+			},
+			&cli.StringFlag{
+				Name:    "scope",
+				Aliases: []string{"s"},
+				Usage:   "Scope to rename the skill in, repo or user (default: the scope each platform resolves it from)",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Rename without asking",
+			},
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"d"},
+				Usage:   "Show what would be renamed without changing files",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-backup",
+				Usage: "Skip the backup of the renamed files",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 2 {
+				return errors.New("skill mv requires exactly 2 arguments: <old> <new>")
+			}
+			return runSkillMv(cmd, cmd.Args().Get(0), cmd.Args().Get(1))
+		},
+	}
+}
+
+// skillMove is the rename of one copy of a skill: its file or directory moves
+// from, to, and its file's original content is replaced by content, which
+// has the new name.
+type skillMove struct {
+	skill             model.Skill
+	from, to          string // The skill's directory for SKILL.md skills, else its file
+	newPath           string // The skill's file after the move
+	original, content []byte
+}
+
+// planSkillMove works out where a copy of a skill moves when renamed to
+// newName. It fails when something is already there.
+func planSkillMove(skill model.Skill, newName string) (skillMove, error) {
+	// #nosec G304 - skill.Path is a discovered skill file
+	content, err := os.ReadFile(skill.Path)
+	if err != nil {
+		return skillMove{}, fmt.Errorf("failed to read %s: %w", skill.Path, err)
+	}
+	move := skillMove{skill: skill, original: content, content: renameFrontmatter(content, newName)}
+	if strings.EqualFold(filepath.Base(skill.Path), "SKILL.md") {
+		move.from = filepath.Dir(skill.Path)
+		move.to = filepath.Join(filepath.Dir(move.from), newName)
+		move.newPath = filepath.Join(move.to, filepath.Base(skill.Path))
+	} else {
+		move.from = skill.Path
+		move.to = filepath.Join(filepath.Dir(skill.Path), newName+filepath.Ext(skill.Path))
+		move.newPath = move.to
+	}
+	if _, err := os.Lstat(move.to); err == nil {
+		return skillMove{}, fmt.Errorf("cannot rename %s on %s: %s already exists", skill.Name, skill.Platform, move.to)
+	}
+	return move, nil
+}
+
+// frontmatterName matches the top-level name key of YAML frontmatter.
+var frontmatterName = regexp.MustCompile(`(?m)^name:[ \t]*.*$`)
+
+// renameFrontmatter sets the name key of a skill file's frontmatter, keeping
+// the rest of the file as it is. Files whose frontmatter has no name, and so
+// are named by their path, are returned unchanged.
+func renameFrontmatter(content []byte, newName string) []byte {
+	fm := parser.SplitFrontmatter(content)
+	if !fm.HasFrontmatter || len(fm.Frontmatter) == 0 {
+		return content
+	}
+	start := strings.IndexByte(string(content), '\n') + 1
+	end := start + len(fm.Frontmatter)
+	loc := frontmatterName.FindIndex(content[start:end])
+	if loc == nil {
+		return content
+	}
+	renamed := make([]byte, 0, len(content)+len(newName))
+	renamed = append(renamed, content[:start+loc[0]]...)
+	renamed = append(renamed, "name: "+newName...)
+	return append(renamed, content[start+loc[1]:]...)
+}
+
+// apply renames the copy: the new content is written in place, then the
+// file or directory is moved. A failed move puts the original content back.
+func (m skillMove) apply() error {
+	if err := writeFileAtomic(m.skill.Path, m.content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", m.skill.Path, err)
+	}
+	if err := os.Rename(m.from, m.to); err != nil {
+		_ = writeFileAtomic(m.skill.Path, m.original)
+		return fmt.Errorf("failed to move %s to %s: %w", m.from, m.to, err)
+	}
+	return nil
+}
+
+// revert undoes apply, moving the copy back and restoring its old name.
+func (m skillMove) revert() error {
+	if err := os.Rename(m.to, m.from); err != nil {
+		return err
+	}
+	return writeFileAtomic(m.skill.Path, m.original)
+}
+
+// writeFileAtomic replaces path with data through a temporary file in the
+// same directory, so readers see either the old or the new content.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// #nosec G302 - skill files should be readable
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// runSkillMv renames a skill on the chosen platforms. Every copy is renamed
+// or, when one fails, none are: the copies already renamed are moved back.
+func runSkillMv(cmd *cli.Command, oldName, newName string) error {
+	if err := parser.ValidateSkillName(newName); err != nil {
+		return fmt.Errorf("invalid new name: %w", err)
+	}
+	if oldName == newName {
+		return errors.New("new name must be different from old name")
+	}
+	copies, err := skillCopies(oldName, cmd.StringSlice("platform"), cmd.String("scope"))
+	if err != nil {
+		return err
+	}
+	labels := copyLabels(copies)
+
+	moves := make([]skillMove, 0, len(copies))
+	for _, c := range copies {
+		if existing, _ := findSkillInScope(c.Platform, newName, c.Scope); existing != nil {
+			return fmt.Errorf("skill %q already exists in %s:%s", newName, c.Platform, c.Scope)
+		}
+		move, err := planSkillMove(c, newName)
+		if err != nil {
+			return err
+		}
+		moves = append(moves, move)
+	}
+
+	result := skillChangeOutput{
+		Name:    oldName,
+		NewName: newName,
+		DryRun:  cmd.Bool("dry-run"),
+		Copies:  make([]skillCopyChange, 0, len(moves)),
+	}
+	out.Printf("Rename %s to %s on:\n", oldName, newName)
+	for i, m := range moves {
+		out.Printf("  %-20s %s -> %s\n", labels[i], m.from, m.to)
+	}
+	if result.DryRun {
+		for _, m := range moves {
+			result.Copies = append(result.Copies, skillCopyChange{
+				Platform: m.skill.Platform, Scope: m.skill.Scope, Path: m.skill.Path, NewPath: m.newPath,
+			})
+		}
+		return out.Render(result, func() error {
+			fmt.Println("\nDry run: no files were changed")
+			return nil
+		})
+	}
+
+	if !cmd.Bool("yes") {
+		confirmed, err := confirmAction(fmt.Sprintf("Rename %s to %s on %s?", oldName, newName, strings.Join(labels, ", ")), riskLevelWarning)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Rename cancelled.")
+			return nil
+		}
+	}
+
+	run := history.Run{
+		ID:        backup.NewSessionID(),
+		Command:   "skill mv",
+		Target:    strings.Join(labels, ","),
+		StartedAt: time.Now(),
+		Success:   true,
+	}
+	result.RunID = run.ID
+
+	var done []skillMove
+	var moveErr error
+	for _, m := range moves {
+		change := skillCopyChange{Platform: m.skill.Platform, Scope: m.skill.Scope, Path: m.skill.Path, NewPath: m.newPath}
+		removed := history.SkillRecord{Name: oldName, Action: string(sync.ActionDeleted), TargetPath: m.skill.Path}
+		if !skipBackup(cmd) {
+			if change.BackupID, moveErr = backupCopy(m.skill, "pre-rename backup", run.ID); moveErr != nil {
+				break
+			}
+			removed.Backups = []string{change.BackupID}
+		}
+		if moveErr = m.apply(); moveErr != nil {
+			break
+		}
+		done = append(done, m)
+		run.Skills = append(run.Skills, removed, history.SkillRecord{
+			Name: newName, Action: string(sync.ActionCreated), TargetPath: m.newPath,
+		})
+		result.Copies = append(result.Copies, change)
+	}
+	if moveErr != nil {
+		for i := len(done) - 1; i >= 0; i-- {
+			if err := done[i].revert(); err != nil {
+				warnf("Warning: failed to move %s back to %s: %v\n", done[i].to, done[i].from, err)
+			}
+		}
+		return fmt.Errorf("%w; no copies were renamed", moveErr)
+	}
+	recordSkillRun(run)
+
+	// Keep the skill's backup history under its new name
+	renamed := make(map[model.Platform]bool)
+	for _, m := range moves {
+		if renamed[m.skill.Platform] {
+			continue
+		}
+		renamed[m.skill.Platform] = true
+		if _, err := backup.RenameLineage(string(m.skill.Platform), oldName, newName); err != nil {
+			warnf("Warning: failed to move backups to the new name: %v\n", err)
+		}
+	}
+
+	return out.Render(result, func() error {
+		fmt.Printf("\n✓ Renamed %s to %s on %s\n", oldName, newName, strings.Join(labels, ", "))
+		if !skipBackup(cmd) {
+			fmt.Println(ui.Dim("Undo it with: skillsync undo " + run.ID))
+		}
+		return nil
+	})
+}
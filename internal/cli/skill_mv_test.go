@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestRenameFrontmatter(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    string
+	}{
+		"name field": {
+			content: "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n",
+			want:    "---\nname: lint-go\ndescription: Lint code\n---\nRun the linter.\n",
+		},
+		"quoted name": {
+			content: "---\ndescription: Lint code\nname: \"lint\"\n---\nname: lint\n",
+			want:    "---\ndescription: Lint code\nname: lint-go\n---\nname: lint\n",
+		},
+		"nested name is kept": {
+			content: "---\nauthor:\n  name: lint\n---\nBody\n",
+			want:    "---\nauthor:\n  name: lint\n---\nBody\n",
+		},
+		"no frontmatter": {
+			content: "name: lint\n",
+			want:    "name: lint\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, string(renameFrontmatter([]byte(tt.content), "lint-go")), tt.want)
+		})
+	}
+}
+
+func TestSkillMv(t *testing.T) {
+	const lint = "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n"
+	const renamed = "---\nname: lint-go\ndescription: Lint code\n---\nRun the linter.\n"
+
+	tests := map[string]struct {
+		args       []string
+		stdin      string
+		existing   bool // cursor already has a lint-go skill
+		wantErr    string
+		wantOutput string
+		wantClaude bool // the Claude Code copy is renamed
+		wantCursor bool // the Cursor copy is renamed
+	}{
+		"every platform": {
+			args:       []string{"--yes"},
+			wantOutput: "Renamed lint to lint-go on claude-code:user, cursor:user",
+			wantClaude: true,
+			wantCursor: true,
+		},
+		"one platform when confirmed": {
+			args:       []string{"--platform", "cursor"},
+			stdin:      "y\n",
+			wantOutput: "Rename lint to lint-go on cursor:user?",
+			wantCursor: true,
+		},
+		"declined": {
+			stdin:      "n\n",
+			wantOutput: "Rename cancelled.",
+		},
+		"dry run": {
+			args:       []string{"--dry-run"},
+			wantOutput: "Dry run: no files were changed",
+		},
+		"new name taken": {
+			args:     []string{"--yes"},
+			existing: true,
+			wantErr:  `skill "lint-go" already exists in cursor:user`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, cursorSkills := setupStore(t)
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), lint)
+			util.WriteFile(t, filepath.Join(cursorSkills, "lint", "SKILL.md"), lint)
+			if tt.existing {
+				util.WriteFile(t, filepath.Join(cursorSkills, "lint-go", "SKILL.md"), renamed)
+			}
+			if tt.stdin != "" {
+				withStdin(t, tt.stdin)
+			}
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync", "skill", "mv", "lint", "lint-go"}, tt.args...))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("skill mv error = %v, want %q\n%s", runErr, tt.wantErr, output)
+				}
+			} else {
+				util.AssertNoError(t, runErr)
+			}
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output missing %q:\n%s", tt.wantOutput, output)
+			}
+
+			for dir, wantRenamed := range map[string]bool{claudeSkills: tt.wantClaude, cursorSkills: tt.wantCursor} {
+				util.AssertEqual(t, pathExists(filepath.Join(dir, "lint", "SKILL.md")), !wantRenamed)
+				if !wantRenamed {
+					continue
+				}
+				data, err := os.ReadFile(filepath.Join(dir, "lint-go", "SKILL.md"))
+				util.AssertNoError(t, err)
+				util.AssertEqual(t, string(data), renamed)
+			}
+		})
+	}
+}
+
+func TestSkillMv_Undo(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	util.WriteFile(t, filepath.Join(claudeSkills, "lint.md"), "---\nname: lint\n---\nRun the linter.\n")
+
+	output := captureOutput(t, func() {
+		util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "skill", "mv", "lint", "lint-go", "--yes"}))
+	})
+	if !pathExists(filepath.Join(claudeSkills, "lint-go.md")) {
+		t.Fatalf("lint.md was not renamed to lint-go.md:\n%s", output)
+	}
+
+	captureOutput(t, func() {
+		util.AssertNoError(t, Run(context.Background(), []string{"skillsync", "undo", "--force"}))
+	})
+	util.AssertEqual(t, pathExists(filepath.Join(claudeSkills, "lint-go.md")), false)
+	data, err := os.ReadFile(filepath.Join(claudeSkills, "lint.md"))
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), "---\nname: lint\n---\nRun the linter.\n")
+}
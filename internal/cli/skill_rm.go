@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/history"
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// skillCopyChange is the JSON representation of one copy of a skill removed
+// or renamed by skill rm or skill mv.
+type skillCopyChange struct {
+	Platform model.Platform   `json:"platform"`
+	Scope    model.SkillScope `json:"scope"`
+	Path     string           `json:"path"`
+	NewPath  string           `json:"new_path,omitempty"`
+	BackupID string           `json:"backup_id,omitempty"`
+}
+
+// skillChangeOutput is the JSON representation of a skill rm or skill mv run.
+type skillChangeOutput struct {
+	Name    string            `json:"name"`
+	NewName string            `json:"new_name,omitempty"`
+	DryRun  bool              `json:"dry_run,omitempty"`
+	RunID   string            `json:"run_id,omitempty"`
+	Copies  []skillCopyChange `json:"copies"`
+}
+
+func skillRmCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "rm",
+		Aliases:   []string{"remove"},
+		Usage:     "Remove a skill from one or more platforms, backing it up first",
+		UsageText: "skillsync skill rm <name> --platform PLATFORM [--platform PLATFORM...] [--scope SCOPE] [--yes] [--dry-run]",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:     "platform",
+				Aliases:  []string{"p"},
+				Usage:    "Platform to remove the skill from; repeat for several. Required.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:    "scope",
+				Aliases: []string{"s"},
+				Usage:   "Scope to remove the skill from, repo or user (default: the scope each platform resolves it from)",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Remove without asking",
+			},
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"d"},
+				Usage:   "Show what would be removed without changing files",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-backup",
+				Usage: "Skip the backup of the removed files",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("skill rm requires exactly 1 argument: <name>")
+			}
+			return runSkillRm(cmd, cmd.Args().First())
+		},
+	}
+}
+
+// skillCopies returns the copies of a skill to remove or rename: on each of
+// the named platforms, or every platform when none are named, the copy in
+// scope, or the one the platform resolves when scope is empty. A named
+// platform without the skill is an error, as is a copy in a scope skillsync
+// does not write to.
+func skillCopies(name string, platformNames []string, scopeName string) ([]model.Skill, error) {
+	platforms := model.AllPlatforms()
+	if len(platformNames) > 0 {
+		platforms = make([]model.Platform, 0, len(platformNames))
+		for _, platformName := range platformNames {
+			platform, err := model.ParsePlatform(platformName)
+			if err != nil {
+				return nil, fmt.Errorf("invalid platform: %w", err)
+			}
+			platforms = append(platforms, platform)
+		}
+	}
+	var scope model.SkillScope
+	if scopeName != "" {
+		var err error
+		if scope, err = model.ParseScope(scopeName); err != nil {
+			return nil, fmt.Errorf("invalid scope: %w", err)
+		}
+		if scope != model.ScopeRepo && scope != model.ScopeUser {
+			return nil, fmt.Errorf("scope %q is not writable (only repo and user are supported)", scope)
+		}
+	}
+
+	var copies []model.Skill
+	for _, platform := range platforms {
+		skill, err := platformCopy(platform, name, scope)
+		if err != nil && len(platformNames) > 0 {
+			return nil, err
+		}
+		if skill == nil {
+			if len(platformNames) > 0 {
+				return nil, fmt.Errorf("skill %q not found on %s", name, platform)
+			}
+			continue
+		}
+		if skill.Scope != model.ScopeRepo && skill.Scope != model.ScopeUser {
+			return nil, fmt.Errorf("%s resolves %q from the %s scope, which is not writable; pass --scope repo or --scope user",
+				platform, name, skill.Scope)
+		}
+		copies = append(copies, *skill)
+	}
+	if len(copies) == 0 {
+		return nil, fmt.Errorf("skill %q not found on any platform", name)
+	}
+	return copies, nil
+}
+
+// platformCopy returns the platform's copy of a skill in scope, or the copy
+// it resolves when scope is empty, or nil when it has none.
+func platformCopy(platform model.Platform, name string, scope model.SkillScope) (*model.Skill, error) {
+	if scope != "" {
+		return findSkillInScope(platform, name, scope)
+	}
+	skills, err := parsePlatformSkillsWithScope(platform, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s skills: %w", platform, err)
+	}
+	for _, skill := range skills {
+		if skill.Name == name {
+			return &skill, nil
+		}
+	}
+	return nil, nil
+}
+
+// copyLabels returns the platform:scope label of each copy of a skill.
+func copyLabels(copies []model.Skill) []string {
+	labels := make([]string, len(copies))
+	for i, c := range copies {
+		labels[i] = fmt.Sprintf("%s:%s", c.Platform, c.Scope)
+	}
+	return labels
+}
+
+// backupCopy backs up a copy of a skill before skill rm or skill mv changes
+// it, returning the backup's ID.
+func backupCopy(skill model.Skill, description, sessionID string) (string, error) {
+	metadata, err := backup.CreateBackup(skill.Path, backup.Options{
+		Platform:    string(skill.Platform),
+		Description: description,
+		Metadata:    map[string]string{"skill": skill.Name, "scope": string(skill.Scope)},
+		Tags:        []string{"skill"},
+		SessionID:   sessionID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", skill.Path, err)
+	}
+	return metadata.ID, nil
+}
+
+// recordSkillRun saves a skill rm or skill mv run to the sync history, so
+// that undo can reverse it.
+func recordSkillRun(run history.Run) {
+	run.FinishedAt = time.Now()
+	if err := history.Record(run); err != nil {
+		logging.Warn("failed to record sync history", logging.Err(err))
+		warnf("Warning: failed to record sync history: %v\n", err)
+	}
+}
+
+// runSkillRm removes a skill's copies on the chosen platforms after
+// confirmation, backing each up so that undo can restore it.
+func runSkillRm(cmd *cli.Command, name string) error {
+	copies, err := skillCopies(name, cmd.StringSlice("platform"), cmd.String("scope"))
+	if err != nil {
+		return err
+	}
+	labels := copyLabels(copies)
+
+	result := skillChangeOutput{
+		Name:   name,
+		DryRun: cmd.Bool("dry-run"),
+		Copies: make([]skillCopyChange, 0, len(copies)),
+	}
+	out.Printf("Remove %s from:\n", name)
+	for i, c := range copies {
+		out.Printf("  %-20s %s\n", labels[i], c.Path)
+	}
+	if result.DryRun {
+		for _, c := range copies {
+			result.Copies = append(result.Copies, skillCopyChange{Platform: c.Platform, Scope: c.Scope, Path: c.Path})
+		}
+		return out.Render(result, func() error {
+			fmt.Println("\nDry run: no files were changed")
+			return nil
+		})
+	}
+
+	if !cmd.Bool("yes") {
+		confirmed, err := confirmAction(fmt.Sprintf("Remove %s from %s?", name, strings.Join(labels, ", ")), riskLevelDangerous)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Remove cancelled.")
+			return nil
+		}
+	}
+
+	run := history.Run{
+		ID:        backup.NewSessionID(),
+		Command:   "skill rm",
+		Target:    strings.Join(labels, ","),
+		StartedAt: time.Now(),
+		Success:   true,
+	}
+	result.RunID = run.ID
+	defer func() { recordSkillRun(run) }()
+
+	for _, c := range copies {
+		change := skillCopyChange{Platform: c.Platform, Scope: c.Scope, Path: c.Path}
+		record := history.SkillRecord{Name: name, Action: string(sync.ActionDeleted), TargetPath: c.Path}
+		if !skipBackup(cmd) {
+			if change.BackupID, err = backupCopy(c, "pre-remove backup", run.ID); err != nil {
+				run.Success = false
+				return err
+			}
+			record.Backups = []string{change.BackupID}
+		}
+		if err := os.Remove(c.Path); err != nil {
+			run.Success = false
+			return fmt.Errorf("failed to remove %s: %w", c.Path, err)
+		}
+		if util.RemoveEmptySkillDir(c.Path) {
+			warnf("Warning: kept %s: it holds other files\n", filepath.Dir(c.Path))
+		}
+		run.Skills = append(run.Skills, record)
+		result.Copies = append(result.Copies, change)
+	}
+
+	return out.Render(result, func() error {
+		fmt.Printf("\n✓ Removed %s from %s\n", name, strings.Join(labels, ", "))
+		if !skipBackup(cmd) {
+			fmt.Println(ui.Dim("Restore it with: skillsync undo " + run.ID))
+		}
+		return nil
+	})
+}
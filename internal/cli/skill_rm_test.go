@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// pathExists reports whether a file or directory exists at path.
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestSkillRm(t *testing.T) {
+	const lint = "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n"
+
+	tests := map[string]struct {
+		args         []string
+		stdin        string
+		wantErr      string
+		wantOutput   string
+		wantClaude   bool // the Claude Code copy is left
+		wantCursor   bool // the Cursor copy is left
+		wantUndoable bool
+	}{
+		"removes with yes": {
+			args:         []string{"--platform", "cursor", "--yes"},
+			wantOutput:   "Removed lint from cursor:user",
+			wantClaude:   true,
+			wantUndoable: true,
+		},
+		"removes when confirmed": {
+			args:         []string{"--platform", "cursor", "--scope", "user"},
+			stdin:        "y\n",
+			wantOutput:   "Remove lint from cursor:user?",
+			wantClaude:   true,
+			wantUndoable: true,
+		},
+		"several platforms": {
+			args:         []string{"-p", "cursor", "-p", "claudecode", "--yes"},
+			wantOutput:   "Removed lint from cursor:user, claude-code:user",
+			wantUndoable: true,
+		},
+		"declined": {
+			args:       []string{"--platform", "cursor"},
+			stdin:      "n\n",
+			wantOutput: "Remove cancelled.",
+			wantClaude: true,
+			wantCursor: true,
+		},
+		"dry run": {
+			args:       []string{"--platform", "cursor", "--dry-run"},
+			wantOutput: "Dry run: no files were changed",
+			wantClaude: true,
+			wantCursor: true,
+		},
+		"skip backup": {
+			args:       []string{"--platform", "cursor", "--yes", "--skip-backup"},
+			wantClaude: true,
+		},
+		"missing on a platform": {
+			args:       []string{"--platform", "codex", "--yes"},
+			wantErr:    `skill "lint" not found on codex`,
+			wantClaude: true,
+			wantCursor: true,
+		},
+		"unwritable scope": {
+			args:       []string{"--platform", "cursor", "--scope", "plugin", "--yes"},
+			wantErr:    "is not writable",
+			wantClaude: true,
+			wantCursor: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, cursorSkills := setupStore(t)
+			t.Setenv("SKILLSYNC_CODEX_SKILLS_PATHS", filepath.Join(t.TempDir(), "codex"))
+			claudeCopy := filepath.Join(claudeSkills, "lint", "SKILL.md")
+			cursorCopy := filepath.Join(cursorSkills, "lint", "SKILL.md")
+			util.WriteFile(t, claudeCopy, lint)
+			util.WriteFile(t, cursorCopy, lint)
+			if tt.stdin != "" {
+				withStdin(t, tt.stdin)
+			}
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync", "skill", "rm", "lint"}, tt.args...))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("skill rm error = %v, want %q\n%s", runErr, tt.wantErr, output)
+				}
+			} else {
+				util.AssertNoError(t, runErr)
+			}
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("output missing %q:\n%s", tt.wantOutput, output)
+			}
+			util.AssertEqual(t, pathExists(claudeCopy), tt.wantClaude)
+			util.AssertEqual(t, pathExists(cursorCopy), tt.wantCursor)
+			if !tt.wantCursor && pathExists(filepath.Dir(cursorCopy)) {
+				t.Errorf("skill directory %s was left behind", filepath.Dir(cursorCopy))
+			}
+
+			if !tt.wantUndoable {
+				return
+			}
+			output = captureOutput(t, func() {
+				runErr = Run(context.Background(), []string{"skillsync", "undo", "--force"})
+			})
+			util.AssertNoError(t, runErr)
+			data, err := os.ReadFile(cursorCopy)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, string(data), lint)
+		})
+	}
+}
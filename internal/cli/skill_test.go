@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSkillHistoryAndShow(t *testing.T) {
+	claudeSkills, _ := setupStore(t)
+	skillFile := filepath.Join(claudeSkills, "lint", "SKILL.md")
+	syncSkill := func(content string) {
+		t.Helper()
+		util.WriteFile(t, skillFile, content)
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(context.Background(), []string{"skillsync", "sync", "--yes", "claudecode:user", "cursor:user"})
+		})
+		if runErr != nil {
+			t.Fatalf("sync failed: %v\n%s", runErr, output)
+		}
+	}
+	first := "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n"
+	second := "---\nname: lint\ndescription: Lint code\n---\nRun the linter twice.\n"
+	syncSkill(first)
+	syncSkill(first) // unchanged: no new version
+	syncSkill(second)
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "skill", "history", "lint"})
+	})
+	util.AssertNoError(t, runErr)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	util.AssertEqual(t, len(lines), 3) // header and two versions
+	if !strings.Contains(lines[2], "claude-code -> cursor") {
+		t.Errorf("history line = %q", lines[2])
+	}
+
+	tests := map[string]struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		"first version":  {ref: "lint@1", want: first},
+		"latest version": {ref: "lint@latest", want: second},
+		"current skill":  {ref: "lint", want: second},
+		"no version":     {ref: "lint@", wantErr: "skill show requires a version: lint@<version>"},
+		"unknown skill":  {ref: "review@1", wantErr: "review: no recorded versions"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), []string{"skillsync", "skill", "show", tt.ref})
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("skill show error = %v, want %q", runErr, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, runErr)
+			util.AssertEqual(t, output, tt.want)
+		})
+	}
+}
+
+func TestSkillShowResolved(t *testing.T) {
+	repo := setupProjectRepo(t, "")
+	home := os.Getenv("HOME")
+	util.WriteFile(t, filepath.Join(home, ".claude", "skills", "shared", "SKILL.md"),
+		"---\nname: shared\ndescription: Personal skill\n---\nFor me.\n")
+	util.WriteFile(t, filepath.Join(home, ".cursor", "skills", "shared", "SKILL.md"),
+		"---\nname: shared\ndescription: Cursor skill\ntags: [a, b]\nsource_type: url\nsource_url: https://example.com/shared.md\n---\nFor Cursor.\n")
+
+	tests := map[string]struct {
+		args    []string
+		want    string
+		wantErr string
+	}{
+		"repo scope wins": {
+			args: []string{"shared"},
+			want: "---\nname: shared\ndescription: Team skill\n---\nFor the team.\n",
+		},
+		"field":         {args: []string{"shared", "--field", "description"}, want: "Team skill\n"},
+		"path field":    {args: []string{"shared", "--field", "path"}, want: filepath.Join(repo, ".claude", "skills", "shared", "SKILL.md")},
+		"platform":      {args: []string{"shared", "--platform", "cursor", "--field", "description"}, want: "Cursor skill\n"},
+		"list field":    {args: []string{"shared", "-p", "cursor", "--field", "tags"}, want: "- a\n- b\n"},
+		"unknown field": {args: []string{"shared", "--field", "owner"}, wantErr: `skill "shared" has no field "owner"`},
+		"origin field":  {args: []string{"shared", "-p", "cursor", "--field", "origin"}, want: "https://example.com/shared.md\n"},
+		"no origin":     {args: []string{"shared", "--field", "origin"}, wantErr: `skill "shared" has no recorded origin`},
+		"unknown skill": {args: []string{"review"}, wantErr: `skill "review" not found on any platform`},
+		"version flags": {args: []string{"shared@1", "--field", "description"}, wantErr: "do not apply to recorded versions"},
+		"bad platform":  {args: []string{"shared", "--platform", "vim"}, wantErr: "invalid platform"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), append([]string{"skillsync", "skill", "show"}, tt.args...))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("skill show error = %v, want %q", runErr, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, runErr)
+			if !strings.HasPrefix(output, tt.want) {
+				t.Errorf("skill show output = %q, want %q", output, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// errSpecPrinted stops command execution after --spec has written the spec.
+// Run treats it as success.
+var errSpecPrinted = errors.New("command spec printed")
+
+// commandSpec is the machine-readable description of a command, its flags,
+// and its subcommands emitted by --spec json.
+type commandSpec struct {
+	Name        string        `json:"name"`
+	Path        string        `json:"path"`
+	Aliases     []string      `json:"aliases,omitempty"`
+	Usage       string        `json:"usage,omitempty"`
+	UsageText   string        `json:"usage_text,omitempty"`
+	ArgsUsage   string        `json:"args_usage,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Version     string        `json:"version,omitempty"`
+	Hidden      bool          `json:"hidden,omitempty"`
+	Flags       []flagSpec    `json:"flags"`
+	Commands    []commandSpec `json:"commands,omitempty"`
+}
+
+// flagSpec describes a single command-line flag.
+type flagSpec struct {
+	Name     string   `json:"name"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Type     string   `json:"type"`
+	Usage    string   `json:"usage,omitempty"`
+	Default  any      `json:"default,omitempty"`
+	EnvVars  []string `json:"env_vars,omitempty"`
+	Required bool     `json:"required,omitempty"`
+	Local    bool     `json:"local,omitempty"`
+}
+
+// specFlag returns the global --spec flag. Passed to any command, it prints
+// that command's subtree as a spec and exits without running the command.
+func specFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "spec",
+		Usage: "Print the command tree with flags, types, and defaults in the given format (json) and exit",
+		Action: func(_ context.Context, cmd *cli.Command, format string) error {
+			return printSpec(cmd, format)
+		},
+	}
+}
+
+// printSpec writes the spec for cmd and its subcommands to stdout.
+func printSpec(cmd *cli.Command, format string) error {
+	if strings.ToLower(strings.TrimSpace(format)) != "json" {
+		return fmt.Errorf("unsupported --spec format %q (supported: json)", format)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(newCommandSpec(cmd, cmd.FullName())); err != nil {
+		return fmt.Errorf("failed to write command spec: %w", err)
+	}
+	return errSpecPrinted
+}
+
+// newCommandSpec describes cmd, whose full command path is path.
+func newCommandSpec(cmd *cli.Command, path string) commandSpec {
+	spec := commandSpec{
+		Name:        cmd.Name,
+		Path:        path,
+		Aliases:     cmd.Aliases,
+		Usage:       cmd.Usage,
+		UsageText:   cmd.UsageText,
+		ArgsUsage:   cmd.ArgsUsage,
+		Description: cmd.Description,
+		Version:     cmd.Version,
+		Hidden:      cmd.Hidden,
+		Flags:       make([]flagSpec, 0, len(cmd.Flags)),
+	}
+
+	for _, flag := range cmd.Flags {
+		// The help flag is only attached to commands on the executed path
+		if flag.Names()[0] == cli.HelpFlag.Names()[0] {
+			continue
+		}
+		spec.Flags = append(spec.Flags, newFlagSpec(flag))
+	}
+
+	for _, sub := range cmd.Commands {
+		if sub.Name == "help" {
+			continue
+		}
+		spec.Commands = append(spec.Commands, newCommandSpec(sub, path+" "+sub.Name))
+	}
+
+	return spec
+}
+
+// newFlagSpec describes a flag with its default in its native JSON type.
+func newFlagSpec(flag cli.Flag) flagSpec {
+	names := flag.Names()
+	spec := flagSpec{Name: names[0], Aliases: names[1:]}
+
+	switch f := flag.(type) {
+	case *cli.BoolFlag:
+		spec.Default = f.Value
+	case *cli.StringFlag:
+		if f.Value != "" {
+			spec.Default = f.Value
+		}
+	case *cli.IntFlag:
+		spec.Default = f.Value
+	case *cli.FloatFlag:
+		spec.Default = f.Value
+	case *cli.StringSliceFlag:
+		if len(f.Value) > 0 {
+			spec.Default = f.Value
+		}
+	default:
+		if df, ok := flag.(cli.DocGenerationFlag); ok && df.GetValue() != "" {
+			spec.Default = df.GetValue()
+		}
+	}
+
+	if df, ok := flag.(cli.DocGenerationFlag); ok {
+		spec.Type = df.TypeName()
+		spec.Usage = df.GetUsage()
+		spec.EnvVars = df.GetEnvVars()
+	}
+	if rf, ok := flag.(cli.RequiredFlag); ok {
+		spec.Required = rf.IsRequired()
+	}
+	if lf, ok := flag.(cli.LocalFlag); ok {
+		spec.Local = lf.IsLocal()
+	}
+
+	return spec
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// findCommandSpec returns the spec at path within root, or nil.
+func findCommandSpec(root *commandSpec, path string) *commandSpec {
+	if root.Path == path {
+		return root
+	}
+	for i := range root.Commands {
+		if found := findCommandSpec(&root.Commands[i], path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func TestSpecFlag(t *testing.T) {
+	tests := map[string]struct {
+		args     []string
+		wantErr  bool
+		wantPath string
+		wantFlag string
+		check    func(t *testing.T, flag flagSpec)
+	}{
+		"root tree": {
+			args:     []string{"skillsync", "--spec", "json"},
+			wantPath: "skillsync backup rollback",
+			wantFlag: "session",
+			check: func(t *testing.T, flag flagSpec) {
+				if flag.Type != "string" || !flag.Required || len(flag.Aliases) != 1 {
+					t.Errorf("session flag = %+v, want required string with alias", flag)
+				}
+			},
+		},
+		"global flag defaults and env": {
+			args:     []string{"skillsync", "--spec", "json"},
+			wantPath: "skillsync",
+			wantFlag: "output",
+			check: func(t *testing.T, flag flagSpec) {
+				if flag.Default != "text" || len(flag.EnvVars) != 1 || flag.EnvVars[0] != "SKILLSYNC_OUTPUT" {
+					t.Errorf("output flag = %+v, want default text from SKILLSYNC_OUTPUT", flag)
+				}
+			},
+		},
+		"subcommand does not run": {
+			args:     []string{"skillsync", "backup", "rollback", "--spec", "json"},
+			wantPath: "skillsync backup rollback",
+			wantFlag: "dry-run",
+			check: func(t *testing.T, flag flagSpec) {
+				if flag.Type != "bool" || flag.Default != false {
+					t.Errorf("dry-run flag = %+v, want bool defaulting to false", flag)
+				}
+			},
+		},
+		"unsupported format": {
+			args:    []string{"skillsync", "--spec", "yaml"},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), tt.args)
+			})
+			if (runErr != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", runErr, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var spec commandSpec
+			if err := json.Unmarshal([]byte(output), &spec); err != nil {
+				t.Fatalf("output is not a JSON spec: %v\n%s", err, output)
+			}
+			cmd := findCommandSpec(&spec, tt.wantPath)
+			if cmd == nil {
+				t.Fatalf("spec has no command %q", tt.wantPath)
+			}
+			for _, flag := range cmd.Flags {
+				if flag.Name == "help" {
+					t.Errorf("spec for %q should not list the help flag", cmd.Path)
+				}
+				if flag.Name == tt.wantFlag {
+					tt.check(t, flag)
+					return
+				}
+			}
+			t.Errorf("spec for %q has no flag %q", cmd.Path, tt.wantFlag)
+		})
+	}
+}
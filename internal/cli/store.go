@@ -0,0 +1,325 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/store"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// storeSyncOutput is the JSON representation of one sync of a push or pull.
+type storeSyncOutput struct {
+	Platform string           `json:"platform"`
+	Result   syncResultOutput `json:"result"`
+}
+
+// storeFlags are the flags shared by push and pull.
+func storeFlags(strategyDefault string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "platform",
+			Aliases: []string{"p"},
+			Usage:   "Comma-separated platforms to sync with (default: every platform with a user skills directory)",
+		},
+		&cli.BoolFlag{
+			Name:    "dry-run",
+			Aliases: []string{"d"},
+			Usage:   "Preview changes without modifying files",
+		},
+		&cli.StringFlag{
+			Name:    "strategy",
+			Aliases: []string{"s"},
+			Usage:   "Conflict resolution strategy: overwrite, skip, newer, merge, three-way (default: " + strategyDefault + ")",
+		},
+		&cli.BoolFlag{
+			Name:    "yes",
+			Aliases: []string{"y"},
+			Usage:   "Skip confirmation prompts (use with caution)",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-backup",
+			Usage: "Skip automatic backup before sync",
+		},
+	}
+}
+
+func pushCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "push",
+		Usage:     "Sync the canonical skill store out to every platform",
+		UsageText: "skillsync push [--platform LIST] [--strategy STRATEGY] [--dry-run] [--yes]",
+		Description: `Push the skills of the canonical store into the user scope of each
+   platform. The store is an optional directory of skills kept as the source of
+   truth, by default ~/.skillsync/skills ($SKILLSYNC_HOME/skills), in Claude
+   Code's layout. Edit skills there and push, or edit them on a platform and
+   pull them back, instead of syncing every pair of platforms.
+
+   Pushes to every platform with a user skills directory unless --platform is
+   given. The strategy defaults to the one configured for the user scope.
+
+   Examples:
+     skillsync push --dry-run
+     skillsync push --platform cursor,codex
+     skillsync push --strategy newer --yes`,
+		Flags: storeFlags("from config, else overwrite"),
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return runPush(cmd)
+		},
+	}
+}
+
+func pullCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "pull",
+		Usage:     "Collect skills changed on the platforms into the canonical skill store",
+		UsageText: "skillsync pull [--platform LIST] [--strategy STRATEGY] [--dry-run] [--yes]",
+		Description: `Pull the user-scope skills of each platform into the canonical store,
+   creating it on first use. When several platforms have a skill of the same
+   name, the most recently modified copy is pulled.
+
+   Pulls from every platform with a user skills directory unless --platform is
+   given. The strategy defaults to newer, so a skill in the store is only
+   replaced by a copy changed after it; use --strategy overwrite to take the
+   platforms' copies regardless.
+
+   Examples:
+     skillsync pull --dry-run
+     skillsync pull --platform cursor
+     skillsync pull --strategy overwrite --yes`,
+		Flags: storeFlags("newer"),
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return runPull(cmd)
+		},
+	}
+}
+
+// storePlatforms returns the platforms named by --platform, or the detected
+// ones.
+func storePlatforms(cmd *cli.Command) ([]model.Platform, error) {
+	if !cmd.IsSet("platform") {
+		platforms := store.DetectPlatforms()
+		if len(platforms) == 0 {
+			return nil, errors.New("no platform has a user skills directory; name one with --platform")
+		}
+		return platforms, nil
+	}
+	var platforms []model.Platform
+	for name := range strings.SplitSeq(cmd.String("platform"), ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		platform, err := model.ParsePlatform(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid platform: %w", err)
+		}
+		platforms = append(platforms, platform)
+	}
+	if len(platforms) == 0 {
+		return nil, errors.New("--platform names no platforms")
+	}
+	return platforms, nil
+}
+
+// checkStoreStrategy rejects strategies push and pull cannot run.
+func checkStoreStrategy(strategy sync.Strategy) error {
+	if !strategy.IsValid() {
+		return fmt.Errorf("invalid strategy %q (valid: overwrite, skip, newer, merge, three-way)", strategy)
+	}
+	if strategy == sync.StrategyInteractive {
+		return errors.New("interactive strategy is not supported with push and pull")
+	}
+	return nil
+}
+
+// storeSyncOptions returns the sync options of a push or pull run from the
+// command's flags.
+func storeSyncOptions(cmd *cli.Command, appConfig *config.Config, strategy sync.Strategy) sync.Options {
+	return sync.Options{
+		DryRun:          cmd.Bool("dry-run"),
+		Strategy:        strategy,
+		RenameThreshold: appConfig.RenameThreshold(),
+	}
+}
+
+// confirmStoreSync prints the changes a dry run found and asks whether to go
+// ahead. It reports false when there is nothing to change.
+func confirmStoreSync(cmd *cli.Command, verb string, labels []string, results []*sync.Result) (bool, error) {
+	total := 0
+	out.Printf("\n=== %s Summary ===\n", strings.ToUpper(verb[:1])+verb[1:])
+	for i, result := range results {
+		out.Printf("  %-14s %d change(s)\n", labels[i], result.TotalChanged())
+		total += result.TotalChanged()
+	}
+	if total == 0 {
+		out.Println("Nothing to " + verb)
+		return false, nil
+	}
+	level := riskLevelInfo
+	if skipBackup(cmd) {
+		out.Println("Warning: Backup will be skipped (--skip-backup flag)")
+		level = riskLevelWarning
+	}
+	confirmed, err := confirmAction(fmt.Sprintf("Proceed with %s?", verb), level)
+	if err != nil {
+		return false, fmt.Errorf("confirmation error: %w", err)
+	}
+	if !confirmed {
+		out.Println(strings.ToUpper(verb[:1]) + verb[1:] + " cancelled by user")
+	}
+	return confirmed, nil
+}
+
+func runPush(cmd *cli.Command) error {
+	appConfig, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	platforms, err := storePlatforms(cmd)
+	if err != nil {
+		return err
+	}
+	strategy, _ := resolveSyncStrategy(cmd, appConfig, model.ScopeUser)
+	if err := checkStoreStrategy(strategy); err != nil {
+		return err
+	}
+
+	s := store.New("")
+	if !s.Exists() {
+		return fmt.Errorf("no skill store at %s; create it with skillsync pull", s.Path())
+	}
+	opts := storeSyncOptions(cmd, appConfig, strategy)
+	labels := make([]string, len(platforms))
+	for i, platform := range platforms {
+		labels[i] = string(platform)
+	}
+
+	if !opts.DryRun && !cmd.Bool("yes") {
+		preview := opts
+		preview.DryRun = true
+		results, err := s.Push(platforms, preview)
+		if err != nil {
+			return err
+		}
+		if ok, err := confirmStoreSync(cmd, "push", labels, results); !ok || err != nil {
+			return err
+		}
+	}
+
+	startedAt := time.Now()
+	if !opts.DryRun {
+		opts.SessionID = backup.NewSessionID()
+		opts.Backup = !skipBackup(cmd)
+		if opts.Backup {
+			for _, platform := range platforms {
+				prepareBackup(platform)
+			}
+		}
+	}
+	results, err := s.Push(platforms, opts)
+	recordHistory(opts.SessionID, "push", startedAt, results...)
+	if err != nil {
+		return err
+	}
+	return outputStoreSync(labels, results)
+}
+
+func runPull(cmd *cli.Command) error {
+	appConfig, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	platforms, err := storePlatforms(cmd)
+	if err != nil {
+		return err
+	}
+	strategy := sync.StrategyNewer
+	if cmd.IsSet("strategy") {
+		strategy = sync.Strategy(cmd.String("strategy"))
+	}
+	if err := checkStoreStrategy(strategy); err != nil {
+		return err
+	}
+
+	platformSkills := make(map[model.Platform][]model.Skill, len(platforms))
+	for _, platform := range platforms {
+		skills, err := parsePlatformSkillsWithScope(platform, []model.SkillScope{model.ScopeUser}, false)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s skills: %w", platform, err)
+		}
+		platformSkills[platform] = skills
+	}
+
+	s := store.New("")
+	opts := storeSyncOptions(cmd, appConfig, strategy)
+	labels := []string{"store"}
+
+	if !opts.DryRun && !cmd.Bool("yes") {
+		preview := opts
+		preview.DryRun = true
+		result, err := s.Pull(platformSkills, preview)
+		if err != nil {
+			return err
+		}
+		if ok, err := confirmStoreSync(cmd, "pull", labels, []*sync.Result{result}); !ok || err != nil {
+			return err
+		}
+	}
+
+	startedAt := time.Now()
+	if !opts.DryRun {
+		opts.SessionID = backup.NewSessionID()
+		opts.Backup = !skipBackup(cmd) && s.Exists()
+		if opts.Backup {
+			prepareBackup(store.Format)
+		}
+	}
+	result, err := s.Pull(platformSkills, opts)
+	if result != nil {
+		recordHistory(opts.SessionID, "pull", startedAt, result)
+	}
+	if err != nil {
+		return err
+	}
+	return outputStoreSync(labels, []*sync.Result{result})
+}
+
+// outputStoreSync prints the results of a push or pull, one per label.
+func outputStoreSync(labels []string, results []*sync.Result) error {
+	outputs := make([]storeSyncOutput, 0, len(results))
+	failed := false
+	for i, result := range results {
+		recordSyncWarnings(result)
+		outputs = append(outputs, storeSyncOutput{Platform: labels[i], Result: newSyncResultOutput(result)})
+		if !result.Success() {
+			failed = true
+		}
+	}
+	err := out.Render(outputs, func() error {
+		for i, result := range results {
+			fmt.Printf("\n%s\n", ui.Header(labels[i]))
+			if len(result.Skills) == 0 {
+				fmt.Println(ui.Dim("No skills to sync"))
+				continue
+			}
+			printSyncResults(result)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if failed {
+		return errors.New("sync completed with errors")
+	}
+	return nil
+}
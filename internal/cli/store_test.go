@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// setupStore points the skillsync home and the Claude Code and Cursor user
+// skills at temp directories and returns the Claude Code and Cursor ones.
+func setupStore(t *testing.T) (claudeSkills, cursorSkills string) {
+	t.Helper()
+	tempDir := t.TempDir()
+	claudeSkills = filepath.Join(tempDir, "claude")
+	cursorSkills = filepath.Join(tempDir, "cursor")
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	return claudeSkills, cursorSkills
+}
+
+func TestPushPullCommands(t *testing.T) {
+	claudeSkills, cursorSkills := setupStore(t)
+	util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+
+	err := Run(context.Background(), []string{"skillsync", "push", "--yes", "--platform", "cursor"})
+	if err == nil || !strings.Contains(err.Error(), "no skill store at") {
+		t.Fatalf("push without a store: got %v", err)
+	}
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "pull", "--yes", "--platform", "claudecode"})
+	})
+	util.AssertNoError(t, runErr)
+	storeSkill := filepath.Join(util.SkillsyncStorePath(), "lint", "SKILL.md")
+	if _, err := os.Stat(storeSkill); err != nil {
+		t.Fatalf("skill not pulled into the store: %v\n%s", err, output)
+	}
+
+	output = captureOutput(t, func() {
+		runErr = Run(context.Background(), []string{"skillsync", "push", "--yes", "--platform", "cursor"})
+	})
+	util.AssertNoError(t, runErr)
+	data, err := os.ReadFile(filepath.Join(cursorSkills, "lint", "SKILL.md"))
+	if err != nil {
+		t.Fatalf("skill not pushed to cursor: %v\n%s", err, output)
+	}
+	if !strings.Contains(string(data), "Run the linter.") {
+		t.Errorf("pushed skill = %q", data)
+	}
+
+	err = Run(context.Background(), []string{"skillsync", "pull", "--yes", "--strategy", "interactive"})
+	if err == nil || !strings.Contains(err.Error(), "interactive strategy is not supported") {
+		t.Errorf("pull with the interactive strategy: got %v", err)
+	}
+}
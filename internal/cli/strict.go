@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+var (
+	// strictMode is set by --strict for the running command
+	strictMode atomic.Bool
+	// warningCount counts the warnings the running command printed itself;
+	// warnings logged through the logging package are counted there
+	warningCount atomic.Int64
+	// quietMode is set by --quiet: warnings are counted but not printed
+	quietMode atomic.Bool
+)
+
+// quiet reports whether --quiet is set for the running command.
+func quiet() bool {
+	return quietMode.Load()
+}
+
+func strictFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "strict",
+		Usage:   "Treat warnings (parse failures, validation warnings, lossy transforms, shadowed skills) as errors",
+		Sources: cli.EnvVars("SKILLSYNC_STRICT"),
+	}
+}
+
+// configureStrict resets the warning counts and applies --strict.
+func configureStrict(cmd *cli.Command) {
+	strictMode.Store(cmd.Bool("strict"))
+	warningCount.Store(0)
+	logging.ResetWarnings()
+	logging.SetStrict(strictMode.Load())
+}
+
+// warnf prints a warning with out and records it for --strict.
+func warnf(format string, a ...any) {
+	warningCount.Add(1)
+	recordWarning(format, a...)
+	if quiet() {
+		return
+	}
+	out.Printf(format, a...)
+}
+
+// stderrWarnf prints a warning to stderr and records it for --strict, for
+// commands whose stdout carries data.
+func stderrWarnf(format string, a ...any) {
+	warningCount.Add(1)
+	recordWarning(format, a...)
+	if quiet() {
+		return
+	}
+	fmt.Fprintf(os.Stderr, format, a...)
+}
+
+// recordWarning writes a printed warning to the log file, without the
+// "Warning:" prefix and trailing newline meant for the terminal.
+func recordWarning(format string, a ...any) {
+	msg := strings.TrimSpace(fmt.Sprintf(format, a...))
+	logging.Record(logging.LevelWarn, strings.TrimPrefix(msg, "Warning: "))
+}
+
+// recordWarnings records n warnings that were already reported.
+func recordWarnings(n int) {
+	warningCount.Add(int64(n))
+}
+
+// recordSyncWarnings records the lossy mappings and broken references of a
+// sync result.
+func recordSyncWarnings(result *sync.Result) {
+	for _, sr := range result.Skills {
+		recordWarnings(len(sr.Warnings))
+		if len(sr.BrokenReferences) > 0 {
+			recordWarnings(1)
+		}
+	}
+}
+
+// strictError returns an error if --strict is set and the command raised
+// any warnings.
+func strictError() error {
+	if !strictMode.Load() {
+		return nil
+	}
+	if n := warningCount.Load() + logging.Warnings(); n > 0 {
+		return fmt.Errorf("strict mode: %d warning(s) treated as errors", n)
+	}
+	return nil
+}
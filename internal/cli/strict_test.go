@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestStrictMode(t *testing.T) {
+	tests := map[string]struct {
+		skill   string
+		args    []string
+		wantErr bool
+	}{
+		"warnings tolerated by default": {
+			skill: "---\nname: lint\n---\nRun it.\n",
+			args:  []string{"skillsync", "validate"},
+		},
+		"warnings fail in strict mode": {
+			skill:   "---\nname: lint\n---\nRun it.\n",
+			args:    []string{"skillsync", "--strict", "validate"},
+			wantErr: true,
+		},
+		"clean skills pass in strict mode": {
+			skill: "---\nname: lint\ndescription: Run the linter\n---\nRun it.\n",
+			args:  []string{"skillsync", "--strict", "validate"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			claudeSkills := filepath.Join(tempDir, "claude", "skills")
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), tt.skill)
+
+			t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+			t.Cleanup(func() { strictMode.Store(false) })
+
+			args := append(tt.args, "--platform", "claude-code", "--scope", "user")
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), args)
+			})
+			if (runErr != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v\n%s", runErr, tt.wantErr, output)
+			}
+			if tt.wantErr && !strings.Contains(runErr.Error(), "strict mode") {
+				t.Errorf("Run() error = %v, want strict mode error", runErr)
+			}
+		})
+	}
+}
+
+func TestQuietWarnings(t *testing.T) {
+	quietMode.Store(true)
+	t.Cleanup(func() { quietMode.Store(false) })
+	warningCount.Store(0)
+
+	output := captureOutput(t, func() {
+		warnf("Warning: skill %s is shadowed\n", "lint")
+	})
+
+	util.AssertEqual(t, output, "")
+	// Hidden warnings still count for --strict
+	util.AssertEqual(t, warningCount.Load(), int64(1))
+}
+
+func TestWarningsRecordedInLogFile(t *testing.T) {
+	var console, file bytes.Buffer
+	logging.SetDefault(logging.NewTee(
+		logging.Options{Level: logging.LevelWarn, Output: &console},
+		logging.Options{Level: logging.LevelInfo, Output: &file},
+	))
+	t.Cleanup(func() { logging.SetDefault(logging.New(logging.DefaultOptions())) })
+
+	captureOutput(t, func() {
+		warnf("Warning: skill %s is shadowed\n", "lint")
+	})
+
+	util.AssertEqual(t, console.String(), "")
+	if !strings.Contains(file.String(), `level=WARN msg="skill lint is shadowed"`) {
+		t.Errorf("log file = %q", file.String())
+	}
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// tableWidth returns the width available to tables: the --max-width override
+// when set, otherwise the terminal width.
+func tableWidth() int {
+	if out.maxWidth > 0 {
+		return out.maxWidth
+	}
+	return getTerminalWidth()
+}
+
+// fitCell returns the display lines for value in a column of width. With
+// --wrap, value is word-wrapped onto as many lines as needed; otherwise it is
+// truncated to a single line ending in "...".
+func fitCell(value string, width int) []string {
+	if out.wrap {
+		return wrapCell(value, width)
+	}
+	return []string{truncateCell(value, width)}
+}
+
+// truncateCell shortens value to width display columns, ending in "...".
+func truncateCell(value string, width int) string {
+	if runewidth.StringWidth(value) <= width {
+		return value
+	}
+	return runewidth.Truncate(value, width, "...")
+}
+
+// truncateCellLeft shortens value to width display columns from the left,
+// keeping the end, which is the meaningful part of a path.
+func truncateCellLeft(value string, width int) string {
+	if runewidth.StringWidth(value) <= width {
+		return value
+	}
+	runes := []rune(value)
+	for i := range runes {
+		if tail := string(runes[i:]); runewidth.StringWidth(tail)+3 <= width {
+			return "..." + tail
+		}
+	}
+	return runewidth.Truncate(value, width, "")
+}
+
+// wrapCell word-wraps value into lines of at most width display columns.
+// Words longer than width, such as paths, are split across lines.
+func wrapCell(value string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+	flush := func() {
+		lines = append(lines, line.String())
+		line.Reset()
+		lineWidth = 0
+	}
+
+	for _, word := range strings.Fields(value) {
+		wordWidth := runewidth.StringWidth(word)
+		if lineWidth > 0 && lineWidth+1+wordWidth > width {
+			flush()
+		}
+		if lineWidth > 0 {
+			line.WriteByte(' ')
+			lineWidth++
+		}
+		for wordWidth > width-lineWidth {
+			head := runewidth.Truncate(word, width-lineWidth, "")
+			if head == "" {
+				// A single wide rune does not fit on a partial line
+				flush()
+				head = runewidth.Truncate(word, width, "")
+				if head == "" {
+					head = string([]rune(word)[0])
+				}
+			}
+			line.WriteString(head)
+			flush()
+			word = word[len(head):]
+			wordWidth = runewidth.StringWidth(word)
+		}
+		line.WriteString(word)
+		lineWidth += wordWidth
+	}
+	if lineWidth > 0 || len(lines) == 0 {
+		flush()
+	}
+	return lines
+}
+
+// padCell pads value with spaces to width display columns.
+func padCell(value string, width int) string {
+	return runewidth.FillRight(value, width)
+}
+
+// cellLine returns line i of a fitted cell, or "" past its last line.
+func cellLine(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// setTableOptions sets the global table options for the duration of a test
+// and disables colors so widths can be measured on the plain output.
+func setTableOptions(t *testing.T, wrap bool, maxWidth int) {
+	t.Helper()
+	oldWrap, oldWidth, oldColor := out.wrap, out.maxWidth, ui.IsColorEnabled()
+	out.wrap, out.maxWidth = wrap, maxWidth
+	ui.DisableColors()
+	t.Cleanup(func() {
+		out.wrap, out.maxWidth = oldWrap, oldWidth
+		if oldColor {
+			ui.EnableColors()
+		}
+	})
+}
+
+func TestWrapCell(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		width int
+		want  []string
+	}{
+		"fits":             {value: "short text", width: 20, want: []string{"short text"}},
+		"empty":            {value: "", width: 10, want: []string{""}},
+		"word wrap":        {value: "the quick brown fox jumps", width: 10, want: []string{"the quick", "brown fox", "jumps"}},
+		"collapses spaces": {value: "a   b\n c", width: 10, want: []string{"a b c"}},
+		"long word splits": {value: "/very/long/path/to/skill", width: 10, want: []string{"/very/long", "/path/to/s", "kill"}},
+		"wide runes":       {value: "日本語のテキスト", width: 6, want: []string{"日本語", "のテキ", "スト"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := wrapCell(tt.value, tt.width)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("wrapCell(%q, %d) = %q, want %q", tt.value, tt.width, got, tt.want)
+			}
+			for _, line := range got {
+				if runewidth.StringWidth(line) > tt.width {
+					t.Errorf("line %q exceeds width %d", line, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestTruncateCell(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		width    int
+		want     string
+		wantLeft string
+	}{
+		"fits":      {value: "short", width: 10, want: "short", wantLeft: "short"},
+		"truncated": {value: "abcdefghijkl", width: 8, want: "abcde...", wantLeft: "...hijkl"},
+		"exact":     {value: "abcdefgh", width: 8, want: "abcdefgh", wantLeft: "abcdefgh"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := truncateCell(tt.value, tt.width); got != tt.want {
+				t.Errorf("truncateCell() = %q, want %q", got, tt.want)
+			}
+			if got := truncateCellLeft(tt.value, tt.width); got != tt.wantLeft {
+				t.Errorf("truncateCellLeft() = %q, want %q", got, tt.wantLeft)
+			}
+		})
+	}
+}
+
+func TestOutputTable_Wrap(t *testing.T) {
+	description := "This description is long enough that it would be truncated in a narrow table but must stay readable"
+	skills := []model.Skill{
+		{Name: "a-skill-with-a-name-longer-than-the-column", Platform: model.Cursor, Scope: model.ScopeUser, Description: description},
+	}
+
+	tests := map[string]struct {
+		wrap          bool
+		maxWidth      int
+		wantTruncated bool
+		wantOneLine   bool
+	}{
+		"truncates by default": {maxWidth: 100, wantTruncated: true},
+		"wraps":                {wrap: true, maxWidth: 100},
+		"wide max width":       {maxWidth: 300, wantOneLine: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			setTableOptions(t, tt.wrap, tt.maxWidth)
+
+			output := captureOutput(t, func() {
+				if err := outputTable(skills); err != nil {
+					t.Errorf("outputTable() error = %v", err)
+				}
+			})
+
+			if tt.wantTruncated {
+				if strings.Contains(output, "readable") {
+					t.Errorf("expected truncated description:\n%s", output)
+				}
+				return
+			}
+			if got := strings.Contains(output, description); got != tt.wantOneLine {
+				t.Errorf("description on one line = %v, want %v\n%s", got, tt.wantOneLine, output)
+			}
+			for _, word := range append(strings.Fields(description), "-column") {
+				if !strings.Contains(output, word) && tt.wrap {
+					t.Errorf("output is missing %q:\n%s", word, output)
+				}
+			}
+			if strings.Contains(output, "...") && tt.wrap {
+				t.Errorf("wrapped table should not truncate:\n%s", output)
+			}
+			for _, line := range strings.Split(output, "\n") {
+				if w := runewidth.StringWidth(strings.TrimRight(line, " ")); w > tt.maxWidth {
+					t.Errorf("line exceeds --max-width %d (%d): %q", tt.maxWidth, w, line)
+				}
+			}
+		})
+	}
+}
+
+func TestOutputBackupsTable_Wrap(t *testing.T) {
+	setTableOptions(t, true, 100)
+	source := "/home/user/projects/example/.claude/skills/some-skill/SKILL.md"
+
+	output := captureOutput(t, func() {
+		if err := outputBackupsTable([]backup.Metadata{{ID: "20240101-120000-abcd1234", Platform: "cursor", SourcePath: source}}); err != nil {
+			t.Errorf("outputBackupsTable() error = %v", err)
+		}
+	})
+
+	if strings.Contains(output, "...") {
+		t.Errorf("expected wrapped source path, got truncation:\n%s", output)
+	}
+	var rebuilt strings.Builder
+	for _, line := range strings.Split(output, "\n")[2:] {
+		if len(line) > 42 && !strings.HasPrefix(line, "Total") {
+			rebuilt.WriteString(strings.Fields(line[42:])[0])
+		}
+	}
+	if rebuilt.String() != source {
+		t.Errorf("wrapped source = %q, want %q\n%s", rebuilt.String(), source, output)
+	}
+}
+
+func TestTableFlags(t *testing.T) {
+	t.Cleanup(func() { out.wrap, out.maxWidth = false, 0 })
+
+	if err := Run(context.Background(), []string{"skillsync", "--max-width", "-1", "version"}); err == nil {
+		t.Error("expected error for negative --max-width")
+	}
+
+	captureOutput(t, func() {
+		if err := Run(context.Background(), []string{"skillsync", "--wrap", "--max-width", "90", "version"}); err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	})
+	if !out.wrap || out.maxWidth != 90 {
+		t.Errorf("table options = wrap %v, max width %d; want true, 90", out.wrap, out.maxWidth)
+	}
+}
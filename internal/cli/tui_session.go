@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/history"
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+)
+
+// tuiSession records the actions taken during one TUI session in the sync
+// history and summarizes them when the TUI exits.
+type tuiSession struct {
+	id   string
+	runs []history.Run
+}
+
+// newTUISession starts a TUI session.
+func newTUISession() *tuiSession {
+	return &tuiSession{id: backup.NewSessionID()}
+}
+
+// record appends run to the sync history as part of the session. Runs that
+// touched no skills are not recorded.
+func (s *tuiSession) record(run history.Run) {
+	if len(run.Skills) == 0 {
+		return
+	}
+	if run.ID == "" {
+		run.ID = backup.NewSessionID()
+	}
+	if run.FinishedAt.IsZero() {
+		run.FinishedAt = time.Now()
+	}
+	run.Session = s.id
+	s.runs = append(s.runs, run)
+
+	if err := history.Record(run); err != nil {
+		logging.Warn("failed to record TUI action in sync history", logging.Err(err))
+		warnf("Warning: failed to record TUI %s in sync history: %v\n", run.Command, err)
+	}
+}
+
+// recordResult records a sync run made from the TUI.
+func (s *tuiSession) recordResult(id, command string, startedAt time.Time, result *sync.Result) {
+	s.record(history.NewRun(id, command, startedAt, result))
+}
+
+// printSummary prints the runs of the session, or nothing if no action was taken.
+func (s *tuiSession) printSummary() {
+	if len(s.runs) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%s\n", ui.Header("TUI session "+s.id))
+	for _, run := range s.runs {
+		target := run.Target
+		if run.Source != "" {
+			target = run.Source + " -> " + run.Target
+		}
+		status := ""
+		if !run.Success {
+			status = " " + ui.Error("(with errors)")
+		}
+		fmt.Printf("  %-7s  %-28s  %s%s\n", run.Command, truncateCell(target, 28), formatRunCounts(run), status)
+	}
+	fmt.Println("\nInspect a run with 'skillsync history show <run-id>':")
+	for _, run := range s.runs {
+		fmt.Printf("  %s  %s\n", run.ID, run.Command)
+	}
+}
+
+// formatRunCounts returns the skills per action of a run, e.g. "2 created, 1 updated".
+func formatRunCounts(run history.Run) string {
+	counts := run.Counts()
+	parts := make([]string, 0, len(counts))
+	for _, action := range slices.Sorted(maps.Keys(counts)) {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[action], action))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// skillPlatforms returns the distinct platforms of skills, comma-separated.
+func skillPlatforms(skills []model.Skill) string {
+	var platforms []string
+	for _, skill := range skills {
+		if !slices.Contains(platforms, string(skill.Platform)) {
+			platforms = append(platforms, string(skill.Platform))
+		}
+	}
+	return strings.Join(platforms, ",")
+}
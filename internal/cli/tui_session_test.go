@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/history"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/ui/tui"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestTUISession_RecordsActions(t *testing.T) {
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, "claude", "skills")
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+
+	repoSkill := filepath.Join(tempDir, "repo", ".claude", "skills", "lint", "SKILL.md")
+	util.WriteFile(t, repoSkill, "---\nname: lint\n---\nRun the linter.\n")
+	stale := filepath.Join(tempDir, "cursor", "old.md")
+	util.WriteFile(t, stale, "---\nname: old\n---\nOld.\n")
+
+	session := newTUISession()
+	err := executePromoteDemote(session, tui.PromoteDemoteListResult{
+		Action:         tui.PromoteDemoteActionPromote,
+		SelectedSkills: []model.Skill{{Name: "lint", Platform: model.ClaudeCode, Scope: model.ScopeRepo, Path: repoSkill}},
+	})
+	util.AssertNoError(t, err)
+	err = executeDelete(session, tui.DeleteListResult{
+		Action:         tui.DeleteActionDelete,
+		SelectedSkills: []model.Skill{{Name: "old", Platform: model.Cursor, Scope: model.ScopeUser, Path: stale}},
+	})
+	util.AssertNoError(t, err)
+	// Actions that touch no skills are left out of the log
+	session.record(history.Run{Command: history.CommandResolve})
+
+	runs, err := history.List()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(runs), 2)
+	commands := make(map[string]history.Run)
+	for _, run := range runs {
+		util.AssertEqual(t, run.Session, session.id)
+		commands[run.Command] = run
+	}
+
+	promoted := commands[history.CommandPromote]
+	util.AssertEqual(t, promoted.Source+" -> "+promoted.Target, "repo -> user")
+	util.AssertEqual(t, promoted.Skills[0].TargetPath, filepath.Join(claudeSkills, "lint", "SKILL.md"))
+	util.AssertEqual(t, promoted.Skills[0].Action, "created")
+	deleted := commands["delete"]
+	util.AssertEqual(t, deleted.Target, "cursor")
+	util.AssertEqual(t, deleted.Skills[0].Action, "deleted")
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("deleted skill still exists: %v", err)
+	}
+
+	output := captureOutput(t, session.printSummary)
+	for _, want := range []string{"TUI session " + session.id, "promote", "1 created", "delete", "1 deleted", promoted.ID} {
+		if !strings.Contains(output, want) {
+			t.Errorf("summary missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestTUISession_EmptySummary(t *testing.T) {
+	output := captureOutput(t, newTUISession().printSummary)
+	util.AssertEqual(t, output, "")
+}
@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/history"
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func undoCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "undo",
+		Usage:     "Reverse the most recent sync run",
+		ArgsUsage: "[run-id]",
+		UsageText: `skillsync undo [options] [run-id]
+   skillsync undo --dry-run
+   skillsync undo 20240125-120000-1a2b3c4d`,
+		Description: `Reverse a sync or delete run recorded in the history: files the run
+   overwrote or deleted are restored from the backups taken during the run,
+   and files it newly created are removed. Without a run ID the most recent
+   run that has not been undone yet is reversed, so running undo again walks
+   further back.
+
+   Created files that were modified after the run are kept. Runs made with
+   --skip-backup cannot restore the files they overwrote.
+
+   Examples:
+     skillsync undo --dry-run         # Preview what would be restored and removed
+     skillsync undo                   # Undo the last sync
+     skillsync history                # Find the ID of an older run`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"d"},
+				Usage:   "Show what would be restored and removed without changing files",
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Skip confirmation prompt",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			var run *history.Run
+			var err error
+			if cmd.Args().Len() > 0 {
+				run, err = history.Get(cmd.Args().First())
+			} else {
+				run, err = history.LastUndoable()
+			}
+			if err != nil {
+				return err
+			}
+			return undoRun(run, cmd.Bool("dry-run"), cmd.Bool("force"))
+		},
+	}
+}
+
+// undoRun reverses a recorded run by restoring the backups it took and
+// removing the files it created. The undo is recorded in the history so the
+// same run is not undone twice.
+func undoRun(run *history.Run, dryRun, force bool) error {
+	if run.Command == history.CommandUndo {
+		return fmt.Errorf("run %s is an undo and cannot be undone", run.ID)
+	}
+	undoneBy, err := history.UndoneBy(run.ID)
+	if err != nil {
+		return err
+	}
+	if undoneBy != "" {
+		return fmt.Errorf("sync run %s was already undone by %s", run.ID, undoneBy)
+	}
+
+	var restore []backup.Metadata
+	if len(run.BackupIDs()) > 0 {
+		if restore, err = backup.RollbackPlan(run.ID); err != nil {
+			return err
+		}
+	}
+	remove, kept := createdPaths(run)
+	if len(restore) == 0 && len(remove) == 0 {
+		return fmt.Errorf("sync run %s has nothing to undo (runs made with --skip-backup cannot be restored)", run.ID)
+	}
+
+	out.Printf("Undo %s run %s (%s -> %s):\n", run.Command, run.ID, run.Source, run.Target)
+	for _, metadata := range restore {
+		out.Printf("  restore  %s\n", metadata.SourcePath)
+	}
+	for _, path := range remove {
+		out.Printf("  remove   %s\n", path)
+	}
+	for _, path := range kept {
+		out.Printf("  keep     %s (modified since the run)\n", path)
+	}
+
+	result := undoOutput{
+		RunID:    run.ID,
+		DryRun:   dryRun,
+		Restored: make([]string, 0, len(restore)),
+		Removed:  make([]string, 0, len(remove)),
+		Kept:     kept,
+	}
+
+	if dryRun {
+		for _, metadata := range restore {
+			result.Restored = append(result.Restored, metadata.SourcePath)
+		}
+		result.Removed = append(result.Removed, remove...)
+		return out.Render(result, func() error {
+			fmt.Println("\nDry run: no files were changed")
+			return nil
+		})
+	}
+
+	if !force {
+		confirmed, err := confirmDestructive(
+			fmt.Sprintf("Restore %d and remove %d file(s)?", len(restore), len(remove)), "undo", len(restore)+len(remove), riskLevelWarning)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Undo cancelled.")
+			return nil
+		}
+	}
+
+	record := history.Run{
+		ID:        backup.NewSessionID(),
+		Command:   history.CommandUndo,
+		Source:    run.Source,
+		Target:    run.Target,
+		StartedAt: time.Now(),
+		Skills:    make([]history.SkillRecord, 0, len(restore)+len(remove)),
+	}
+	names := make(map[string]string, len(run.Skills))
+	for _, skill := range run.Skills {
+		names[skill.TargetPath] = skill.Name
+	}
+
+	undoErr := func() error {
+		if len(restore) > 0 {
+			restored, err := backup.RollbackSession(run.ID)
+			for _, metadata := range restored {
+				result.Restored = append(result.Restored, metadata.SourcePath)
+				record.Skills = append(record.Skills, history.SkillRecord{
+					Name:       names[metadata.SourcePath],
+					Action:     history.ActionRestored,
+					TargetPath: metadata.SourcePath,
+					Message:    "from backup " + metadata.ID,
+				})
+			}
+			if err != nil {
+				return fmt.Errorf("undo failed after restoring %d file(s): %w", len(restored), err)
+			}
+		}
+		for _, path := range remove {
+			if err := removeCreated(path); err != nil {
+				return fmt.Errorf("undo failed to remove %s: %w", path, err)
+			}
+			result.Removed = append(result.Removed, path)
+			record.Skills = append(record.Skills, history.SkillRecord{
+				Name:       names[path],
+				Action:     history.ActionRemoved,
+				TargetPath: path,
+			})
+		}
+		return nil
+	}()
+
+	// Only a complete undo marks the run as undone, so a failed one can be retried
+	record.FinishedAt = time.Now()
+	record.Success = undoErr == nil
+	if undoErr == nil {
+		record.Undoes = run.ID
+	}
+	if err := history.Record(record); err != nil {
+		logging.Warn("failed to record undo in sync history", logging.Err(err))
+		warnf("Warning: failed to record undo in sync history: %v\n", err)
+	}
+	if undoErr != nil {
+		return undoErr
+	}
+
+	return out.Render(result, func() error {
+		fmt.Printf("\n✓ Undid run %s: restored %d file(s), removed %d file(s)\n",
+			run.ID, len(result.Restored), len(result.Removed))
+		return nil
+	})
+}
+
+// createdPaths returns the targets a run created that still exist. Targets
+// modified after the run finished are returned separately and left in place.
+func createdPaths(run *history.Run) (remove, kept []string) {
+	seen := make(map[string]bool)
+	for _, skill := range run.Skills {
+		if skill.Action != string(sync.ActionCreated) || skill.TargetPath == "" || seen[skill.TargetPath] {
+			continue
+		}
+		seen[skill.TargetPath] = true
+
+		info, err := os.Lstat(skill.TargetPath)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(run.FinishedAt) {
+			kept = append(kept, skill.TargetPath)
+			continue
+		}
+		remove = append(remove, skill.TargetPath)
+	}
+	return remove, kept
+}
+
+// removeCreated removes a file, directory, or symlink created by a sync. The
+// per-skill directory of a created SKILL.md is removed too once it is empty.
+func removeCreated(path string) error {
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+	util.RemoveEmptySkillDir(path)
+	return nil
+}
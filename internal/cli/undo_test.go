@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestUndoCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	cursorSkills := filepath.Join(tempDir, "home", ".cursor", "skills")
+	claudeSkills := filepath.Join(tempDir, "home", ".claude", "skills")
+	updatedFile := filepath.Join(claudeSkills, "lint", "SKILL.md")
+	original := "---\nname: lint\ndescription: old lint\n---\nOld body.\n"
+
+	util.WriteFile(t, filepath.Join(cursorSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: lint skill\n---\nRun the linter.\n")
+	util.WriteFile(t, filepath.Join(cursorSkills, "format", "SKILL.md"), "---\nname: format\ndescription: format skill\n---\nRun the formatter.\n")
+	util.WriteFile(t, updatedFile, original)
+
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+
+	ctx := context.Background()
+	captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "sync", "--yes", "--skip-validation", "cursor:user", "claudecode:user"}); err != nil {
+			t.Errorf("sync failed: %v", err)
+		}
+	})
+	if _, err := os.Stat(filepath.Join(claudeSkills, "format")); err != nil {
+		t.Fatalf("sync did not create the new skill: %v", err)
+	}
+
+	var preview undoOutput
+	output := captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "--output", "json", "undo", "--dry-run"}); err != nil {
+			t.Errorf("undo --dry-run failed: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(output), &preview); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, output)
+	}
+	if !preview.DryRun || len(preview.Restored) != 1 || len(preview.Removed) != 1 {
+		t.Errorf("unexpected undo preview: %+v", preview)
+	}
+	if data, _ := os.ReadFile(updatedFile); string(data) == original {
+		t.Fatal("dry run restored the target skill")
+	}
+
+	captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "undo", "--force"}); err != nil {
+			t.Errorf("undo failed: %v", err)
+		}
+	})
+	got, err := os.ReadFile(updatedFile)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(got), original)
+	if _, err := os.Stat(filepath.Join(claudeSkills, "format")); !os.IsNotExist(err) {
+		t.Errorf("undo did not remove the created skill: %v", err)
+	}
+
+	// The only sync run has been undone, so there is nothing left
+	captureOutput(t, func() {
+		if err := Run(ctx, []string{"skillsync", "undo", "--force"}); err == nil {
+			t.Error("expected error when every run is already undone")
+		}
+	})
+}
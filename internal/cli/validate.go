@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/validation"
+)
+
+func validateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "validate",
+		Usage: "Check discovered skills for problems",
+		UsageText: `skillsync validate [options]
+   skillsync validate --platform claude-code
+   skillsync validate --scope repo --format json`,
+		Description: `Check every discovered skill without syncing anything:
+
+     frontmatter  unparsable YAML, non-string name/description, missing
+                  description, and names that break the naming convention
+     duplicate    two skills with the same name in one platform scope
+     reference    scripts, references, assets, or relative Markdown links
+                  that point to missing files
+     permission   skill files that cannot be read or are world-writable
+
+   With validation.schema_path set in the config, frontmatter must also satisfy
+   that JSON Schema (for example to require owner and review_date fields).
+   sync and import enforce the same schema.
+
+   Each check is made of named rules. Set a rule's severity to error, warning,
+   or ignore under validation.rules in the config; --list-rules shows them:
+
+     validation:
+       rules:
+         require-description: error
+         max-content-size: ignore
+
+   Exits with a non-zero status when any error is found; warnings alone do
+   not fail.
+
+   Examples:
+     skillsync validate                             # All platforms and scopes
+     skillsync validate --platform cursor --scope user
+     skillsync validate --list-rules
+     skillsync validate --format json | jq '.issues[] | select(.severity == "error")'`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "platform",
+				Aliases: []string{"p"},
+				Usage:   "Only check one platform (claude-code, cursor, codex, aider)",
+			},
+			&cli.StringFlag{
+				Name:    "scope",
+				Aliases: []string{"s"},
+				Usage:   "Only check these scopes (repo, user, admin, system, builtin, plugin, all). Comma-separated for multiple.",
+			},
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Value:   "table",
+				Usage:   "Output format: table, json",
+			},
+			&cli.BoolFlag{
+				Name:  "list-rules",
+				Usage: "List the validation rules and their configured severity",
+			},
+			&cli.BoolFlag{
+				Name:  "include-plugins",
+				Usage: "Also check skills from Claude Code plugins (excluded by default)",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			format := out.Format(cmd.String("format"))
+			if format != "table" && format != "json" {
+				return fmt.Errorf("unsupported format: %s (use table or json)", format)
+			}
+
+			appConfig, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			severities, err := validation.ParseRuleSeverities(appConfig.Validation.Rules)
+			if err != nil {
+				return fmt.Errorf("invalid validation.rules config: %w", err)
+			}
+
+			schema, err := loadFrontmatterSchema(appConfig)
+			if err != nil {
+				return err
+			}
+
+			if cmd.Bool("list-rules") {
+				return printValidationRules(severities)
+			}
+
+			scopeFilter, err := parseScopeFilter(cmd.String("scope"))
+			if err != nil {
+				return err
+			}
+
+			platforms := model.AllPlatforms()
+			if name := cmd.String("platform"); name != "" {
+				platform, err := model.ParsePlatform(name)
+				if err != nil {
+					return fmt.Errorf("invalid platform: %w", err)
+				}
+				platforms = []model.Platform{platform}
+			}
+
+			var skills []model.Skill
+			for _, platform := range platforms {
+				found, err := parsePlatformSkillsWithScope(platform, scopeFilter, cmd.Bool("include-plugins"))
+				if err != nil {
+					warnf("Warning: failed to parse %s: %v\n", platform, err)
+					continue
+				}
+				skills = append(skills, found...)
+			}
+
+			report := validation.CheckSkills(skills, validation.CheckOptions{Severities: severities, Schema: schema})
+			if format == "json" {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(report); err != nil {
+					return err
+				}
+			} else {
+				printValidationReport(report)
+			}
+
+			if errs := report.Errors(); errs > 0 {
+				return fmt.Errorf("validation found %d error(s)", errs)
+			}
+			recordWarnings(report.Warnings())
+			return nil
+		},
+	}
+}
+
+// printValidationReport prints the issues of a report grouped by skill file.
+func printValidationReport(report *validation.Report) {
+	fmt.Printf("Checked %d skill(s)\n", report.Checked)
+
+	lastPath := ""
+	for _, issue := range report.Issues {
+		if issue.Path != lastPath {
+			lastPath = issue.Path
+			location := issue.Platform
+			if issue.Scope != "" {
+				location += " " + issue.Scope
+			}
+			fmt.Printf("\n%s (%s, %s)\n", ui.Bold(issue.Path), issue.Skill, location)
+		}
+
+		status := ui.StatusWarning(fmt.Sprintf("%-7s", issue.Severity))
+		if issue.Severity == validation.SeverityError {
+			status = ui.StatusError(fmt.Sprintf("%-7s", issue.Severity))
+		}
+		label := issue.Check
+		if issue.Rule != "" {
+			label = issue.Rule
+		}
+		fmt.Printf("  %s [%s] %s\n", status, label, issue.Message)
+	}
+
+	fmt.Println()
+	if len(report.Issues) == 0 {
+		fmt.Println(ui.StatusSuccess("No problems found"))
+		return
+	}
+	fmt.Printf("%d error(s), %d warning(s)\n", report.Errors(), report.Warnings())
+}
+
+// printValidationRules lists the registered rules with their effective severity.
+func printValidationRules(severities validation.RuleSeverities) error {
+	type ruleOutput struct {
+		Name        string `json:"name"`
+		Check       string `json:"check"`
+		Severity    string `json:"severity"`
+		Description string `json:"description"`
+	}
+
+	rules := validation.Rules()
+	list := make([]ruleOutput, 0, len(rules))
+	for _, rule := range rules {
+		list = append(list, ruleOutput{
+			Name:        rule.Name,
+			Check:       rule.Check,
+			Severity:    string(severities.SeverityOf(rule)),
+			Description: rule.Description,
+		})
+	}
+
+	return out.Render(list, func() error {
+		fmt.Printf("%s %s %s %s\n",
+			ui.Header(fmt.Sprintf("%-22s", "RULE")),
+			ui.Header(fmt.Sprintf("%-12s", "CHECK")),
+			ui.Header(fmt.Sprintf("%-8s", "SEVERITY")),
+			ui.Header("DESCRIPTION"))
+		for _, rule := range list {
+			fmt.Printf("%-22s %-12s %-8s %s\n", rule.Name, rule.Check, rule.Severity, rule.Description)
+		}
+		return nil
+	})
+}
+
+// loadFrontmatterSchema loads the JSON Schema configured at
+// validation.schema_path, or returns nil when none is configured.
+func loadFrontmatterSchema(appConfig *config.Config) (*validation.Schema, error) {
+	path := appConfig.FrontmatterSchemaPath()
+	if path == "" {
+		return nil, nil
+	}
+	return validation.LoadSchema(path)
+}
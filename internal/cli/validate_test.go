@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/util"
+	"github.com/klauern/skillsync/internal/validation"
+)
+
+func TestValidateCommand(t *testing.T) {
+	tests := map[string]struct {
+		skills     map[string]string // relative path -> content
+		config     string
+		schema     string // written next to the config file as schema.json
+		wantErr    bool
+		wantChecks []string
+	}{
+		"valid skills": {
+			skills: map[string]string{
+				"lint/SKILL.md": "---\nname: lint\ndescription: Run the linter\n---\nRun it.\n",
+			},
+		},
+		"broken reference": {
+			skills: map[string]string{
+				"lint/SKILL.md": "---\nname: lint\ndescription: Run the linter\n---\nSee [rules](references/rules.md).\n",
+			},
+			wantErr:    true,
+			wantChecks: []string{validation.CheckReference},
+		},
+		"warnings only": {
+			skills: map[string]string{
+				"lint/SKILL.md": "---\nname: lint\n---\nRun it.\n",
+			},
+			wantChecks: []string{validation.CheckFrontmatter},
+		},
+		"rule raised to error": {
+			skills: map[string]string{
+				"lint/SKILL.md": "---\nname: lint\n---\nRun it.\n",
+			},
+			config:     "validation:\n  rules:\n    require-description: error\n",
+			wantErr:    true,
+			wantChecks: []string{validation.CheckFrontmatter},
+		},
+		"rule ignored": {
+			skills: map[string]string{
+				"lint/SKILL.md": "---\nname: lint\ndescription: Run the linter\n---\nSee [rules](references/rules.md).\n",
+			},
+			config: "validation:\n  rules:\n    broken-reference: ignore\n",
+		},
+		"frontmatter schema": {
+			skills: map[string]string{
+				"lint/SKILL.md":   "---\nname: lint\ndescription: Run the linter\nowner: platform\n---\nRun it.\n",
+				"format/SKILL.md": "---\nname: format\ndescription: Format code\n---\nRun it.\n",
+			},
+			config:     "validation:\n  schema_path: schema.json\n",
+			schema:     `{"required": ["owner"]}`,
+			wantErr:    true,
+			wantChecks: []string{validation.CheckFrontmatter},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			claudeSkills := filepath.Join(tempDir, "claude", "skills")
+			for rel, content := range tt.skills {
+				util.WriteFile(t, filepath.Join(claudeSkills, rel), content)
+			}
+
+			t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+			if tt.config != "" {
+				util.WriteFile(t, config.FilePath(), tt.config)
+			}
+			if tt.schema != "" {
+				util.WriteFile(t, filepath.Join(filepath.Dir(config.FilePath()), "schema.json"), tt.schema)
+			}
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(context.Background(), []string{"skillsync", "validate", "--platform", "claude-code", "--scope", "user", "--format", "json"})
+			})
+			if (runErr != nil) != tt.wantErr {
+				t.Fatalf("validate error = %v, wantErr %v\n%s", runErr, tt.wantErr, output)
+			}
+
+			var report validation.Report
+			if err := json.Unmarshal([]byte(output), &report); err != nil {
+				t.Fatalf("failed to decode report: %v\n%s", err, output)
+			}
+			util.AssertEqual(t, report.Checked, len(tt.skills))
+
+			got := make([]string, 0, len(report.Issues))
+			for _, issue := range report.Issues {
+				got = append(got, issue.Check)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantChecks, ",") {
+				t.Errorf("issues = %+v, want checks %v", report.Issues, tt.wantChecks)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui/tui"
+	"github.com/klauern/skillsync/internal/validation"
+)
+
+// runValidationReview shows the validation review screen. Tests replace it
+// to script the user's choices.
+var runValidationReview = tui.RunValidationReview
+
+// editSkillFile opens a skill file in the user's editor.
+var editSkillFile = openInEditor
+
+// errValidationAborted is returned when the user leaves the validation review
+// without fixing or skipping the invalid skills.
+var errValidationAborted = errors.New("skill validation failed - sync aborted from the validation review")
+
+// canReviewValidation reports whether validation errors should be reviewed in
+// the TUI rather than printed: an interactive sync attached to a terminal.
+func canReviewValidation(cfg *syncConfig) bool {
+	if cfg.strategy != sync.StrategyInteractive || cfg.noInput || out.JSON() {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// validateSkillFormats checks the format of the source skills, and their
+// frontmatter schema if one is configured.
+func validateSkillFormats(cfg *syncConfig) (*validation.Result, error) {
+	formatResult, err := validation.ValidateSkillsFormat(cfg.sourceSkills, cfg.sourceSpec.Platform)
+	if err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+	if cfg.schema != nil {
+		cfg.schema.ValidateSkills(cfg.sourceSkills, formatResult)
+	}
+	return formatResult, nil
+}
+
+// reviewValidationErrors lets the user fix the skills that failed validation
+// from the TUI: each edit reloads and revalidates the skills, until none fail,
+// the invalid skills are skipped as with --quarantine, or the user aborts.
+func reviewValidationErrors(cfg *syncConfig, formatResult *validation.Result) error {
+	// Skills that no longer parse after an edit keep their previous version,
+	// with the parse error shown alongside their validation errors.
+	reloadErrs := make(map[string]string)
+
+	for formatResult.HasErrors() {
+		issues, general := validationIssues(formatResult, cfg.sourceSkills, reloadErrs)
+		review, err := runValidationReview(issues, general)
+		if err != nil {
+			return fmt.Errorf("validation review TUI error: %w", err)
+		}
+
+		var reload []int
+		switch review.Action {
+		case tui.ValidationReviewActionEdit:
+			if err := editSkillFile(review.Issue.Path); err != nil {
+				stderrWarnf("Warning: %v\n", err)
+			}
+			for i, skill := range cfg.sourceSkills {
+				if skill.Path == review.Issue.Path {
+					reload = append(reload, i)
+				}
+			}
+		case tui.ValidationReviewActionRevalidate:
+			for i := range validation.SkillErrors(formatResult) {
+				if i >= 0 {
+					reload = append(reload, i)
+				}
+			}
+		case tui.ValidationReviewActionSkip:
+			return quarantineInvalidSkills(cfg, formatResult)
+		default:
+			return errValidationAborted
+		}
+
+		for _, i := range reload {
+			skill := cfg.sourceSkills[i]
+			reloaded, err := reloadSkill(skill)
+			if err != nil {
+				reloadErrs[skill.Path] = err.Error()
+				continue
+			}
+			delete(reloadErrs, skill.Path)
+			cfg.sourceSkills[i] = reloaded
+		}
+
+		if formatResult, err = validateSkillFormats(cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validationIssues groups validation errors per skill for the review screen,
+// returning the errors that do not belong to a single skill separately.
+func validationIssues(formatResult *validation.Result, skills []model.Skill, reloadErrs map[string]string) ([]tui.ValidationIssue, []string) {
+	grouped := validation.SkillErrors(formatResult)
+
+	general := make([]string, 0, len(grouped[-1]))
+	for _, e := range grouped[-1] {
+		general = append(general, formatValidationError(e, skills))
+	}
+
+	issues := make([]tui.ValidationIssue, 0, len(grouped))
+	for i, skill := range skills {
+		errs, invalid := grouped[i]
+		if !invalid {
+			continue
+		}
+		issue := tui.ValidationIssue{Name: skill.Name, Path: skill.Path}
+		if msg, ok := reloadErrs[skill.Path]; ok {
+			issue.Messages = append(issue.Messages, msg)
+		}
+		for _, e := range errs {
+			var vErr *validation.Error
+			if errors.As(e, &vErr) {
+				issue.Messages = append(issue.Messages, vErr.Message)
+			} else {
+				issue.Messages = append(issue.Messages, e.Error())
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, general
+}
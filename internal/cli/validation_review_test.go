@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/ui/tui"
+	"github.com/klauern/skillsync/internal/util"
+	"github.com/klauern/skillsync/internal/validation"
+)
+
+func TestReviewValidationErrors(t *testing.T) {
+	const fixed = "---\nname: format\ndescription: Format code\nowner: tools\n---\nRun the formatter.\n"
+
+	tests := map[string]struct {
+		actions         []tui.ValidationReviewAction
+		edit            string // content the editor writes
+		wantErr         error
+		wantReviews     int
+		wantSynced      []string
+		wantQuarantined []string
+	}{
+		"edit fixes the skill": {
+			actions:     []tui.ValidationReviewAction{tui.ValidationReviewActionEdit},
+			edit:        fixed,
+			wantReviews: 1,
+			wantSynced:  []string{"format", "lint"},
+		},
+		"edit without a fix returns to the review": {
+			actions:     []tui.ValidationReviewAction{tui.ValidationReviewActionEdit, tui.ValidationReviewActionNone},
+			wantErr:     errValidationAborted,
+			wantReviews: 2,
+		},
+		"revalidate after fixing outside the TUI": {
+			actions:     []tui.ValidationReviewAction{tui.ValidationReviewActionRevalidate},
+			edit:        fixed,
+			wantReviews: 1,
+			wantSynced:  []string{"format", "lint"},
+		},
+		"skip invalid skills": {
+			actions:         []tui.ValidationReviewAction{tui.ValidationReviewActionSkip},
+			wantReviews:     1,
+			wantSynced:      []string{"lint"},
+			wantQuarantined: []string{"format"},
+		},
+		"abort": {
+			actions:     []tui.ValidationReviewAction{tui.ValidationReviewActionNone},
+			wantErr:     errValidationAborted,
+			wantReviews: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			claudeSkills, _ := setupStore(t)
+			util.WriteFile(t, filepath.Join(claudeSkills, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\nowner: tools\n---\nRun the linter.\n")
+			unowned := filepath.Join(claudeSkills, "format", "SKILL.md")
+			util.WriteFile(t, unowned, "---\nname: format\ndescription: Format code\n---\nRun the formatter.\n")
+			schemaPath := filepath.Join(t.TempDir(), "schema.json")
+			util.WriteFile(t, schemaPath, `{"type": "object", "required": ["owner"]}`)
+			schema, err := validation.LoadSchema(schemaPath)
+			util.AssertNoError(t, err)
+
+			skills, err := parsePlatformSkillsWithScope(model.ClaudeCode, nil, false)
+			util.AssertNoError(t, err)
+			cfg := &syncConfig{
+				sourceSpec:   model.PlatformSpec{Platform: model.ClaudeCode},
+				sourceSkills: skills,
+				schema:       schema,
+			}
+			formatResult, err := validateSkillFormats(cfg)
+			util.AssertNoError(t, err)
+
+			reviews := 0
+			runValidationReview = func(issues []tui.ValidationIssue, general []string) (tui.ValidationReviewResult, error) {
+				reviews++
+				if len(issues) != 1 || issues[0].Path != unowned || len(general) != 0 {
+					t.Fatalf("review %d: issues = %+v, general = %v", reviews, issues, general)
+				}
+				if !strings.Contains(strings.Join(issues[0].Messages, ";"), "does not match frontmatter schema") {
+					t.Errorf("review %d: messages = %v", reviews, issues[0].Messages)
+				}
+				if tt.edit != "" && tt.actions[reviews-1] == tui.ValidationReviewActionRevalidate {
+					util.WriteFile(t, unowned, tt.edit)
+				}
+				return tui.ValidationReviewResult{Action: tt.actions[reviews-1], Issue: issues[0]}, nil
+			}
+			editSkillFile = func(path string) error {
+				if tt.edit != "" {
+					return os.WriteFile(path, []byte(tt.edit), 0o600)
+				}
+				return nil
+			}
+			t.Cleanup(func() {
+				runValidationReview = tui.RunValidationReview
+				editSkillFile = openInEditor
+			})
+
+			captureOutput(t, func() {
+				err = reviewValidationErrors(cfg, formatResult)
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("reviewValidationErrors() error = %v, want %v", err, tt.wantErr)
+			}
+			util.AssertEqual(t, reviews, tt.wantReviews)
+			if tt.wantErr != nil {
+				return
+			}
+
+			var synced, quarantined []string
+			for _, skill := range cfg.sourceSkills {
+				synced = append(synced, skill.Name)
+			}
+			for _, sr := range cfg.quarantined {
+				quarantined = append(quarantined, sr.Skill.Name)
+			}
+			slices.Sort(synced)
+			util.AssertEqual(t, strings.Join(synced, ","), strings.Join(tt.wantSynced, ","))
+			util.AssertEqual(t, strings.Join(quarantined, ","), strings.Join(tt.wantQuarantined, ","))
+		})
+	}
+}
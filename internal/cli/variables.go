@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// syncVars returns the template variable values of a sync: those of the
+// config, overridden by --var NAME=VALUE flags.
+func syncVars(cmd *cli.Command, appConfig *config.Config) (map[string]string, error) {
+	vars := maps.Clone(appConfig.Vars)
+	if vars == nil {
+		vars = make(map[string]string)
+	}
+	for _, flag := range cmd.StringSlice("var") {
+		name, value, ok := strings.Cut(flag, "=")
+		if name = strings.TrimSpace(name); !ok || name == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected NAME=VALUE", flag)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// resolveSkillVariables makes sure every template variable of the source
+// skills has a value. Missing values are prompted for and saved in the
+// project config, unless --no-input is set, in which case the sync fails
+// with the list of missing variables.
+func resolveSkillVariables(cfg *syncConfig) error {
+	missing := sync.MissingVariables(cfg.sourceSkills, cfg.vars)
+	if len(missing) == 0 {
+		return nil
+	}
+	if cfg.noInput {
+		lines := make([]string, 0, len(missing))
+		for _, m := range missing {
+			lines = append(lines, fmt.Sprintf("  %s (used by %s)", m.Name, strings.Join(m.Skills, ", ")))
+		}
+		return fmt.Errorf("undefined template variables; set them with --var NAME=VALUE:\n%s", strings.Join(lines, "\n"))
+	}
+
+	answers, err := promptVariables(missing, bufio.NewReader(os.Stdin))
+	if err != nil {
+		return err
+	}
+	maps.Copy(cfg.vars, answers)
+	if !cfg.dryRun {
+		saveVariables(answers)
+	}
+	return nil
+}
+
+// promptVariables asks for the value of each missing variable.
+func promptVariables(missing []sync.MissingVariable, reader *bufio.Reader) (map[string]string, error) {
+	out.Printf("\n%d template variable(s) have no value:\n", len(missing))
+	answers := make(map[string]string, len(missing))
+	for _, m := range missing {
+		prompt := m.Name
+		if m.Description != "" {
+			prompt += " - " + m.Description
+		}
+		out.Printf("%s (used by %s): ", prompt, strings.Join(m.Skills, ", "))
+
+		response, err := reader.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || response == "") {
+			return nil, fmt.Errorf("failed to read value of %s: %w", m.Name, err)
+		}
+		answers[m.Name] = strings.TrimSpace(response)
+	}
+	return answers, nil
+}
+
+// saveVariables records prompted variable values in the project config so
+// later syncs in the repository do not ask again.
+func saveVariables(answers map[string]string) {
+	if util.NoPersist() {
+		return
+	}
+	path := config.ProjectFilePath()
+	if path == "" {
+		out.Println("Not in a repository; the values are not saved (use --var to set them)")
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(answers)) {
+		if err := config.SetInFile(path, "vars."+name, answers[name]); err != nil {
+			warnf("Warning: failed to save %s in %s: %v\n", name, path, err)
+			return
+		}
+	}
+	out.Printf("Saved %d value(s) in %s\n", len(answers), path)
+}
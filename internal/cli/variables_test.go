@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSyncCommand_Variables(t *testing.T) {
+	tests := map[string]struct {
+		args     []string
+		stdin    string
+		project  string
+		wantErr  string
+		wantSave bool
+	}{
+		"no input": {
+			args:    []string{"--no-input"},
+			wantErr: "undefined template variables; set them with --var NAME=VALUE:\n  project (used by deploy)",
+		},
+		"from --var": {
+			args: []string{"--no-input", "--var", "project=acme"},
+		},
+		"from the project config": {
+			args:    []string{"--no-input"},
+			project: "vars:\n  project: acme\n",
+		},
+		"prompted and saved": {
+			stdin:    "acme\n",
+			wantSave: true,
+		},
+		"invalid --var": {
+			args:    []string{"--var", "project"},
+			wantErr: `invalid --var "project": expected NAME=VALUE`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := setupProjectRepo(t, tt.project)
+			util.WriteFile(t, filepath.Join(repo, ".claude", "skills", "deploy", "SKILL.md"),
+				"---\nname: deploy\ndescription: Deploy the project\nvariables:\n  project:\n    description: Name of the project\n---\nPush {{project}} images.\n")
+			if tt.stdin != "" {
+				withStdin(t, tt.stdin)
+			}
+
+			var runErr error
+			output := captureOutput(t, func() {
+				args := append([]string{"skillsync", "sync", "--yes", "--skip-backup"}, tt.args...)
+				runErr = Run(context.Background(), append(args, "claudecode:repo", "cursor:repo"))
+			})
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("sync error = %v, want %q", runErr, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, runErr)
+
+			data, err := os.ReadFile(filepath.Join(repo, ".cursor", "skills", "deploy", "SKILL.md"))
+			util.AssertNoError(t, err)
+			if !strings.Contains(string(data), "Push acme images.") {
+				t.Errorf("variable not filled in:\n%s\n%s", data, output)
+			}
+
+			project, err := os.ReadFile(filepath.Join(repo, ".skillsync.yaml"))
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, strings.Contains(string(project), "project: acme"), tt.wantSave || tt.project != "")
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package cli
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/klauern/skillsync/internal/fence"
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/metrics"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// defaultWatchInterval is how often sync --watch checks the source for changes.
+const defaultWatchInterval = 2 * time.Second
+
+// defaultFenceWindow is how long sync --watch leaves alone source changes
+// written by another watcher.
+const defaultFenceWindow = 30 * time.Second
+
+// watchState tracks what sync --watch last saw of the source.
+type watchState struct {
+	notifyOnly  bool
+	fingerprint string            // Of the source skills at the last pass
+	metrics     *metrics.Registry // Of the syncs run, when served with --metrics-addr
+	writer      string            // Identifies this watch in write fences
+	fenceWindow time.Duration     // How long another watcher's writes are left alone, 0 to disable fencing
+}
+
+// runSyncWatch syncs, then re-syncs whenever the source skills change until
+// ctx is cancelled or the user interrupts. With --notify-only each change is
+// previewed as a dry run with diffs instead of applied.
+func runSyncWatch(ctx context.Context, cmd *cli.Command) error {
+	cfg, err := parseSyncConfig(cmd, cmd.Name, false)
+	if err != nil {
+		return err
+	}
+
+	interval := cmd.Duration("interval")
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", interval)
+	}
+	if cfg.strategy == sync.StrategyInteractive {
+		return errors.New("interactive strategy is not supported with --watch")
+	}
+	if cfg.failOn != (failOnConditions{}) {
+		return errors.New("--fail-on is not supported with --watch")
+	}
+
+	fenceWindow := cmd.Duration("fence-window")
+	if fenceWindow < 0 {
+		return fmt.Errorf("--fence-window must not be negative, got %s", fenceWindow)
+	}
+
+	state := &watchState{notifyOnly: cmd.Bool("notify-only"), writer: fence.WriterID(), fenceWindow: fenceWindow}
+	if state.notifyOnly {
+		cfg.dryRun = true
+		cfg.showDiff = true
+	} else if !cfg.dryRun && !cfg.yesFlag {
+		return errors.New("--watch applies changes without asking; pass --yes to confirm, or --notify-only to preview changes instead")
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	if cfg.metricsAddr != "" {
+		state.metrics = metrics.New(cfg.sourceSpec.String(), cfg.targetSpec.String())
+		if err := serveMetrics(ctx, cfg.metricsAddr, state.metrics); err != nil {
+			return err
+		}
+	}
+
+	mode := "syncing"
+	if cfg.dryRun {
+		mode = "previewing"
+	}
+	out.Printf("Watching %s for changes, %s into %s every %s (Ctrl+C to stop)\n", cfg.sourceSpec, mode, cfg.targetSpec, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := state.pass(cfg); err != nil {
+			out.Println(ui.Error("Error: " + err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// pass reloads the source skills and, if they changed since the last pass,
+// syncs or previews them.
+func (w *watchState) pass(cfg *syncConfig) error {
+	pass := *cfg
+	pass.quarantined = nil
+	startedAt := time.Now()
+	deprecated, err := loadSyncSourceSkills(&pass)
+	if err != nil {
+		w.metrics.ObserveSync(nil, time.Since(startedAt), err)
+		return err
+	}
+	w.metrics.SetSourceSkills(len(pass.sourceSkills))
+
+	fingerprint, err := skillsFingerprint(pass.sourceSkills)
+	if err != nil {
+		return err
+	}
+	if fingerprint == w.fingerprint {
+		return nil
+	}
+	first := w.fingerprint == ""
+	previous := w.fingerprint
+	w.fingerprint = fingerprint
+
+	if !first {
+		if record, root := w.fencedSource(&pass); record != nil {
+			out.Println(ui.Info(fmt.Sprintf("Source changed at %s by %s syncing from %s; not syncing it back",
+				time.Now().Format(time.TimeOnly), record.Writer, record.Source)))
+			logging.Debug("skipped source change written by another watcher",
+				logging.Path(root), slog.String("writer", record.Writer))
+			return nil
+		}
+		out.Printf("\n%s\n", ui.Header(fmt.Sprintf("Source changed at %s", time.Now().Format(time.TimeOnly))))
+	}
+	if len(deprecated) > 0 {
+		out.Printf("Skipping %d deprecated skill(s)\n", len(deprecated))
+	}
+	if !pass.dryRun {
+		unlock, err := w.lockTargets(&pass)
+		if err != nil {
+			// Retry on the next pass
+			w.fingerprint = previous
+			return err
+		}
+		defer unlock()
+		purgeExpiredTrash()
+	}
+	err = runSync(&pass)
+	w.metrics.ObserveSync(pass.result, time.Since(startedAt), err)
+	if err != nil {
+		return err
+	}
+	if !pass.dryRun {
+		w.recordWrite(&pass)
+	}
+	if w.notifyOnly {
+		out.Println(ui.Info(fmt.Sprintf("Notify only: nothing was written. Run 'skillsync sync %s %s' with the same options to apply.",
+			pass.sourceSpec, pass.targetSpec)))
+	}
+	return nil
+}
+
+// fencedSource returns the write fence of a source directory recently
+// written by another watcher, and the directory, or nil if there is none.
+// Its changes are the other watcher's sync, and syncing them back could
+// start a loop of the two overwriting each other.
+func (w *watchState) fencedSource(cfg *syncConfig) (*fence.Record, string) {
+	if w.fenceWindow == 0 {
+		return nil, ""
+	}
+	scopes := cfg.sourceSpec.Scopes
+	if len(scopes) == 0 {
+		scopes = []model.SkillScope{model.ScopeRepo, model.ScopeUser}
+	}
+	now := time.Now()
+	for _, root := range fenceRoots(cfg.sourceSpec.Platform, scopes) {
+		record, err := fence.Read(root)
+		if err != nil {
+			logging.Warn("failed to read write fence", logging.Path(root), logging.Err(err))
+			continue
+		}
+		if record.FencedOff(w.writer, w.fenceWindow, now) {
+			return record, root
+		}
+	}
+	return nil, ""
+}
+
+// targetRoots returns the directories a sync writes into.
+func targetRoots(cfg *syncConfig) []string {
+	scopes := []model.SkillScope{cfg.targetSpec.TargetScope()}
+	if len(cfg.scopeMappings) > 0 {
+		scopes = scopes[:0]
+		for _, m := range cfg.scopeMappings {
+			scopes = append(scopes, m.target)
+		}
+	}
+	return fenceRoots(cfg.targetSpec.Platform, scopes)
+}
+
+// lockTargets takes the write locks of the target directories, returning the
+// function that releases them. A lock held by another watcher is an error.
+func (w *watchState) lockTargets(cfg *syncConfig) (func(), error) {
+	var unlocks []func()
+	unlock := func() {
+		for _, u := range unlocks {
+			u()
+		}
+	}
+	if w.fenceWindow == 0 {
+		return unlock, nil
+	}
+	for _, root := range targetRoots(cfg) {
+		u, err := fence.Lock(root, w.writer, w.fenceWindow)
+		if err != nil {
+			unlock()
+			return nil, fmt.Errorf("not syncing into %s this pass: %w", root, err)
+		}
+		unlocks = append(unlocks, u)
+	}
+	return unlock, nil
+}
+
+// recordWrite leaves a write fence in the target directories when the sync
+// changed skills, so that a watcher syncing them back leaves them alone.
+func (w *watchState) recordWrite(cfg *syncConfig) {
+	if w.fenceWindow == 0 || cfg.result == nil {
+		return
+	}
+	changed := len(cfg.result.Created()) + len(cfg.result.Updated()) + len(cfg.result.Merged()) + len(cfg.result.Deleted())
+	if changed == 0 {
+		return
+	}
+	record := fence.Record{Writer: w.writer, WrittenAt: time.Now(), Source: cfg.sourceSpec.String(), Skills: changed}
+	for _, root := range targetRoots(cfg) {
+		if err := fence.Write(root, record); err != nil {
+			logging.Warn("failed to write write fence", logging.Path(root), logging.Err(err))
+			warnf("Warning: %v\n", err)
+		}
+	}
+}
+
+// fenceRoots returns the skills directories of platform in scopes that
+// skillsync writes to, without duplicates.
+func fenceRoots(platform model.Platform, scopes []model.SkillScope) []string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	var roots []string
+	for _, scope := range scopes {
+		root, err := util.Paths().SkillsPathForScope(platform, scope, cwd)
+		if err != nil || slices.Contains(roots, root) {
+			continue
+		}
+		roots = append(roots, root)
+	}
+	return roots
+}
+
+// serveMetrics serves the metrics of a watch at http://addr/metrics until ctx
+// is done.
+func serveMetrics(ctx context.Context, addr string, registry *metrics.Registry) error {
+	if host, _, err := net.SplitHostPort(addr); err == nil && !isLoopbackHost(host) {
+		stderrWarnf("Warning: %s is not a loopback address; metrics will be visible to other machines\n", addr)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", registry)
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			stderrWarnf("Warning: metrics server failed: %v\n", err)
+		}
+	}()
+
+	out.Printf("Serving metrics at http://%s/metrics\n", listener.Addr())
+	return nil
+}
+
+// skillsFingerprint returns a digest of the skills that changes whenever a
+// skill is added, removed, renamed, edited, or touched.
+func skillsFingerprint(skills []model.Skill) (string, error) {
+	sorted := slices.Clone(skills)
+	slices.SortFunc(sorted, func(a, b model.Skill) int {
+		return cmp.Or(cmp.Compare(a.Path, b.Path), cmp.Compare(a.Name, b.Name))
+	})
+	data, err := json.Marshal(sorted)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint source skills: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/fence"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSyncWatch(t *testing.T) {
+	const (
+		original = "---\nname: lint\ndescription: Run the linter\n---\nRun the linter.\n"
+		edited   = "---\nname: lint\ndescription: Run the linter\n---\nRun the linter twice.\n"
+	)
+
+	tests := map[string]struct {
+		args        []string
+		wantErr     string
+		wantOutput  []string
+		wantContent string // of the target after the watch stops
+	}{
+		"notify only previews changes": {
+			args:        []string{"--watch", "--notify-only"},
+			wantOutput:  []string{"Source changed at", "+Run the linter twice.", "Notify only: nothing was written"},
+			wantContent: "",
+		},
+		"applies changes": {
+			args:        []string{"--watch", "--yes"},
+			wantOutput:  []string{"Source changed at"},
+			wantContent: "Run the linter twice.",
+		},
+		"serves metrics": {
+			args:        []string{"--watch", "--yes", "--metrics-addr", "127.0.0.1:0"},
+			wantOutput:  []string{"Serving metrics at http://127.0.0.1:", "Source changed at"},
+			wantContent: "Run the linter twice.",
+		},
+		"requires yes or notify only": {
+			args:    []string{"--watch"},
+			wantErr: "pass --yes",
+		},
+		"notify only requires watch": {
+			args:    []string{"--notify-only"},
+			wantErr: "--notify-only requires --watch",
+		},
+		"metrics require watch": {
+			args:    []string{"--metrics-addr", "127.0.0.1:0"},
+			wantErr: "--metrics-addr requires --watch",
+		},
+		"fence window requires watch": {
+			args:    []string{"--fence-window", "1m"},
+			wantErr: "--fence-window requires --watch",
+		},
+		"rejects fail-on": {
+			args:    []string{"--watch", "--yes", "--fail-on", "changes"},
+			wantErr: "--fail-on is not supported",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			claudeSkills := filepath.Join(tempDir, "claude", "skills")
+			cursorSkills := filepath.Join(tempDir, "cursor", "skills")
+			sourceFile := filepath.Join(claudeSkills, "lint", "SKILL.md")
+			util.WriteFile(t, sourceFile, original)
+
+			t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+			t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+			t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+			t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+
+			// Edit the source once the watch is running, then stop it
+			ctx, cancel := context.WithCancel(context.Background())
+			editErr := make(chan error, 1)
+			go func() {
+				defer cancel()
+				select {
+				case <-ctx.Done():
+					editErr <- nil
+					return
+				case <-time.After(200 * time.Millisecond):
+				}
+				editErr <- os.WriteFile(sourceFile, []byte(edited), 0o600)
+				select {
+				case <-ctx.Done():
+				case <-time.After(400 * time.Millisecond):
+				}
+			}()
+
+			args := append([]string{"skillsync", "sync", "--skip-validation", "--interval", "20ms"}, tt.args...)
+			var runErr error
+			output := captureOutput(t, func() {
+				runErr = Run(ctx, append(args, "claudecode:user", "cursor"))
+			})
+			cancel()
+			util.AssertNoError(t, <-editErr)
+			if tt.wantErr != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want %q", runErr, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, runErr)
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+
+			written, err := os.ReadFile(filepath.Join(cursorSkills, "lint", "SKILL.md"))
+			if tt.wantContent == "" {
+				if err == nil {
+					t.Errorf("target written in notify-only mode:\n%s", written)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			if !strings.Contains(string(written), tt.wantContent) {
+				t.Errorf("target = %q, want it to contain %q", written, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestSyncWatch_FencedSource(t *testing.T) {
+	const original = "---\nname: lint\ndescription: Run the linter\n---\nRun the linter.\n"
+	tempDir := t.TempDir()
+	claudeSkills := filepath.Join(tempDir, "claude", "skills")
+	cursorSkills := filepath.Join(tempDir, "cursor", "skills")
+	sourceFile := filepath.Join(claudeSkills, "lint", "SKILL.md")
+	util.WriteFile(t, sourceFile, original)
+
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", claudeSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", claudeSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+
+	// Another watch syncs into the source once this one is running
+	ctx, cancel := context.WithCancel(context.Background())
+	editErr := make(chan error, 1)
+	go func() {
+		defer cancel()
+		time.Sleep(200 * time.Millisecond)
+		err := fence.Write(claudeSkills, fence.Record{Writer: "other:1", WrittenAt: time.Now(), Source: "cursor", Skills: 1})
+		if err == nil {
+			err = os.WriteFile(sourceFile, []byte(strings.Replace(original, "linter.", "linter twice.", 1)), 0o600)
+		}
+		editErr <- err
+		time.Sleep(400 * time.Millisecond)
+	}()
+
+	var runErr error
+	output := captureOutput(t, func() {
+		runErr = Run(ctx, []string{"skillsync", "sync", "--skip-validation", "--interval", "20ms", "--watch", "--yes", "claudecode:user", "cursor"})
+	})
+	util.AssertNoError(t, runErr)
+	util.AssertNoError(t, <-editErr)
+	if !strings.Contains(output, "by other:1 syncing from cursor; not syncing it back") {
+		t.Errorf("output does not report the fenced change:\n%s", output)
+	}
+
+	written, err := os.ReadFile(filepath.Join(cursorSkills, "lint", "SKILL.md"))
+	util.AssertNoError(t, err)
+	if strings.Contains(string(written), "twice") {
+		t.Errorf("fenced change was synced back:\n%s", written)
+	}
+	record, err := fence.Read(cursorSkills)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, record.Source, "claude-code:user")
+	if _, err := os.Stat(filepath.Join(cursorSkills, fence.LockName)); !os.IsNotExist(err) {
+		t.Errorf("write lock left behind: %v", err)
+	}
+}
+
+func TestSkillsFingerprint(t *testing.T) {
+	base := []model.Skill{
+		{Name: "lint", Path: "/skills/lint/SKILL.md", Content: "Run the linter."},
+		{Name: "test", Path: "/skills/test/SKILL.md", Content: "Run the tests."},
+	}
+	fingerprint := func(skills []model.Skill) string {
+		t.Helper()
+		got, err := skillsFingerprint(skills)
+		util.AssertNoError(t, err)
+		return got
+	}
+	want := fingerprint(base)
+
+	tests := map[string]struct {
+		skills []model.Skill
+		same   bool
+	}{
+		"order does not matter": {skills: []model.Skill{base[1], base[0]}, same: true},
+		"edited content":        {skills: []model.Skill{base[0], {Name: "test", Path: base[1].Path, Content: "Run them."}}},
+		"touched file":          {skills: []model.Skill{base[0], {Name: "test", Path: base[1].Path, Content: base[1].Content, ModifiedAt: time.Unix(1, 0)}}},
+		"removed skill":         {skills: base[:1]},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, fingerprint(tt.skills) == want, tt.same)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/config"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/ui"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// errNoWorkspace is returned when --workspace is used without configured repositories.
+var errNoWorkspace = errors.New("no workspace repositories configured (set workspace.repos in config or SKILLSYNC_WORKSPACE_REPOS)")
+
+// workspaceRepoSkills holds the repo-scope skills discovered in one workspace repository.
+type workspaceRepoSkills struct {
+	Repo   string        `json:"repo" yaml:"repo"`
+	Skills []model.Skill `json:"skills" yaml:"skills"`
+}
+
+// workspaceSyncOutput is the JSON representation of a workspace sync into one repository.
+type workspaceSyncOutput struct {
+	Repo   string           `json:"repo"`
+	Result syncResultOutput `json:"result"`
+}
+
+// loadWorkspaceRepos returns the configured workspace repository roots.
+func loadWorkspaceRepos() (*config.Config, []string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	repos := cfg.WorkspaceRepos()
+	if len(repos) == 0 {
+		return nil, nil, errNoWorkspace
+	}
+	return cfg, repos, nil
+}
+
+// workspaceSkillsPaths returns the repo-relative skills paths for a platform, resolved
+// against a workspace repository root. Home and absolute paths are ignored since they
+// are shared by every repository.
+func workspaceSkillsPaths(cfg *config.Config, platform model.Platform, repo string) ([]string, error) {
+	rawPaths, err := platformRawSkillsPaths(cfg, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	relative := make([]string, 0, len(rawPaths))
+	for _, rawPath := range rawPaths {
+		rawPath = strings.TrimSpace(rawPath)
+		if rawPath == "" || filepath.IsAbs(rawPath) || strings.HasPrefix(rawPath, "~") {
+			continue
+		}
+		relative = append(relative, rawPath)
+	}
+
+	return resolveSkillsPaths(relative, repo, repo), nil
+}
+
+// parseWorkspaceRepoSkills parses repo-scope skills for a platform in a single workspace repository.
+func parseWorkspaceRepoSkills(cfg *config.Config, platform model.Platform, repo string) ([]model.Skill, error) {
+	paths, err := workspaceSkillsPaths(cfg, platform, repo)
+	if err != nil {
+		return nil, err
+	}
+	return parsePlatformSkillsFromPaths(platform, paths, repo, []model.SkillScope{model.ScopeRepo}, false), nil
+}
+
+// discoverWorkspaceSkills aggregates repo-scope skills from every workspace repository.
+// Repositories that do not exist are reported as warnings and skipped.
+func discoverWorkspaceSkills(platforms []model.Platform, typeFilter []model.SkillType) ([]workspaceRepoSkills, error) {
+	cfg, repos, err := loadWorkspaceRepos()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]workspaceRepoSkills, 0, len(repos))
+	for _, repo := range repos {
+		if stat, err := os.Stat(repo); err != nil || !stat.IsDir() {
+			stderrWarnf("Warning: workspace repository not found: %s\n", repo)
+			continue
+		}
+
+		entry := workspaceRepoSkills{Repo: repo, Skills: []model.Skill{}}
+		for _, platform := range platforms {
+			skills, err := parseWorkspaceRepoSkills(cfg, platform, repo)
+			if err != nil {
+				return nil, err
+			}
+			entry.Skills = append(entry.Skills, skills...)
+		}
+		entry.Skills = filterBySkillType(entry.Skills, typeFilter)
+		results = append(results, entry)
+	}
+
+	return results, nil
+}
+
+// outputWorkspaceSkills prints workspace discovery results in the requested format.
+func outputWorkspaceSkills(results []workspaceRepoSkills, format string) error {
+	switch format {
+	case "json":
+		return outputAnyJSON(results)
+	case "yaml":
+		return outputAnyYAML(results)
+	case "table":
+		total := 0
+		for i, entry := range results {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(ui.Header(entry.Repo))
+			if err := outputTable(entry.Skills); err != nil {
+				return err
+			}
+			total += len(entry.Skills)
+		}
+		fmt.Printf("\nWorkspace: %d skill(s) across %d repositories\n", total, len(results))
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s (use table, json, or yaml)", format)
+	}
+}
+
+// resolveWorkspaceSyncSpecs adjusts source/target specs for a workspace sync.
+// Workspace syncs always write to repo scope. When source and target share a
+// platform, the source defaults to user scope so user skills fan out into repos.
+func resolveWorkspaceSyncSpecs(sourceSpec, targetSpec *model.PlatformSpec) error {
+	if targetSpec.HasScopes() && targetSpec.TargetScope() != model.ScopeRepo {
+		return fmt.Errorf("--workspace writes to each repository, target scope must be repo (got %s)", targetSpec.TargetScope())
+	}
+	targetSpec.Scopes = []model.SkillScope{model.ScopeRepo}
+
+	if sourceSpec.Platform != targetSpec.Platform {
+		return nil
+	}
+	if !sourceSpec.HasScopes() {
+		sourceSpec.Scopes = []model.SkillScope{model.ScopeUser}
+		return nil
+	}
+	if slices.Contains(sourceSpec.Scopes, model.ScopeRepo) {
+		return fmt.Errorf("source %s cannot include repo scope when syncing a workspace on the same platform", sourceSpec)
+	}
+	return nil
+}
+
+// runWorkspaceSync syncs the already-parsed source skills into every workspace repository.
+func runWorkspaceSync(cfg *syncConfig) error {
+	if cfg.strategy == sync.StrategyInteractive {
+		return errors.New("interactive strategy is not supported with --workspace")
+	}
+
+	appConfig, repos, err := loadWorkspaceRepos()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.skipValidation {
+		if err := validateSourceSkills(cfg); err != nil {
+			return err
+		}
+	}
+
+	if cfg.maxSkills > 0 {
+		for _, repo := range repos {
+			existing, err := parseWorkspaceRepoSkills(appConfig, cfg.targetSpec.Platform, repo)
+			if err != nil {
+				return fmt.Errorf("failed to parse skills in %s: %w", repo, err)
+			}
+			if err := checkSkillLimit(cfg, repo, existing, cfg.sourceSkills); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !cfg.dryRun && !cfg.yesFlag {
+		out.Printf("\nWorkspace repositories: %d\n", len(repos))
+		for _, repo := range repos {
+			out.Printf("  - %s\n", repo)
+		}
+		confirmed, err := showSyncSummaryAndConfirm(cfg)
+		if err != nil {
+			return fmt.Errorf("confirmation error: %w", err)
+		}
+		if !confirmed {
+			out.Println("Sync cancelled by user")
+			return nil
+		}
+	}
+
+	// One session covers every repository so the whole run can be rolled back
+	var sessionID string
+	if !cfg.dryRun {
+		sessionID = backup.NewSessionID()
+		if !cfg.skipBackup {
+			prepareBackup(cfg.targetSpec.Platform)
+		}
+	}
+	startedAt := time.Now()
+
+	targetPlatform := cfg.targetSpec.Platform
+	results := make([]*sync.Result, 0, len(repos))
+	syncedRepos := make([]string, 0, len(repos))
+	failed := false
+	for _, repo := range repos {
+		if stat, err := os.Stat(repo); err != nil || !stat.IsDir() {
+			warnf("Warning: workspace repository not found, skipping: %s\n", repo)
+			continue
+		}
+
+		opts := sync.Options{
+			DryRun:            cfg.dryRun,
+			Strategy:          cfg.strategy,
+			TargetPath:        util.RepoSkillsPath(targetPlatform, repo),
+			TargetScope:       model.ScopeRepo,
+			Concurrency:       cfg.concurrency,
+			Hooks:             cfg.hooks,
+			Backup:            sessionID != "" && !cfg.skipBackup,
+			SessionID:         sessionID,
+			TrashRetention:    cfg.trashRetention,
+			RewriteReferences: cfg.rewriteReferences,
+			Metrics:           cfg.metrics,
+			Preview:           cfg.showDiff,
+			Mode:              cfg.mode,
+			Vars:              cfg.vars,
+		}
+		runStarted := time.Now()
+		progress, doneProgress := syncProgress(cfg.progress, "Syncing "+filepath.Base(repo), len(cfg.sourceSkills))
+		opts.Progress = progress
+		result, err := sync.New().SyncWithSkills(cfg.sourceSkills, targetPlatform, opts)
+		doneProgress()
+		if err != nil {
+			recordHistory(sessionID, "sync", startedAt, results...)
+			return fmt.Errorf("sync to %s failed: %w", repo, err)
+		}
+		if !result.Success() {
+			failed = true
+		}
+		result.Skills = append(result.Skills, cfg.quarantined...)
+		cfg.recordRun(repo, runStarted, result)
+		results = append(results, result)
+		syncedRepos = append(syncedRepos, repo)
+	}
+	recordHistory(sessionID, "sync", startedAt, results...)
+
+	outputs := make([]workspaceSyncOutput, 0, len(results))
+	for i, result := range results {
+		outputs = append(outputs, workspaceSyncOutput{Repo: syncedRepos[i], Result: newSyncResultOutput(result)})
+	}
+	err = out.Render(outputs, func() error {
+		for i, result := range results {
+			fmt.Printf("\n%s\n", ui.Header(syncedRepos[i]))
+			printSyncResults(result)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return errors.New("workspace sync completed with errors")
+	}
+	return cfg.failOn.check(results...)
+}
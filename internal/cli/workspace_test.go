@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func TestResolveWorkspaceSyncSpecs(t *testing.T) {
+	tests := map[string]struct {
+		source      string
+		target      string
+		wantSource  string
+		wantTarget  string
+		wantErr     bool
+		errContains string
+	}{
+		"same platform defaults source to user": {
+			source:     "claudecode",
+			target:     "claudecode",
+			wantSource: "claude-code:user",
+			wantTarget: "claude-code:repo",
+		},
+		"different platform keeps source scopes": {
+			source:     "cursor",
+			target:     "claudecode",
+			wantSource: "cursor",
+			wantTarget: "claude-code:repo",
+		},
+		"explicit repo target": {
+			source:     "claudecode:user",
+			target:     "claudecode:repo",
+			wantSource: "claude-code:user",
+			wantTarget: "claude-code:repo",
+		},
+		"user target rejected": {
+			source:      "cursor",
+			target:      "claudecode:user",
+			wantErr:     true,
+			errContains: "target scope must be repo",
+		},
+		"same platform repo source rejected": {
+			source:      "claudecode:repo,user",
+			target:      "claudecode",
+			wantErr:     true,
+			errContains: "cannot include repo scope",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			source, err := model.ParsePlatformSpec(tt.source)
+			if err != nil {
+				t.Fatalf("invalid source spec: %v", err)
+			}
+			target, err := model.ParsePlatformSpec(tt.target)
+			if err != nil {
+				t.Fatalf("invalid target spec: %v", err)
+			}
+
+			err = resolveWorkspaceSyncSpecs(&source, &target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveWorkspaceSyncSpecs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error %q should contain %q", err, tt.errContains)
+				}
+				return
+			}
+			if source.String() != tt.wantSource {
+				t.Errorf("source = %s, want %s", source, tt.wantSource)
+			}
+			if target.String() != tt.wantTarget {
+				t.Errorf("target = %s, want %s", target, tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestWorkspaceCommands(t *testing.T) {
+	tempDir := t.TempDir()
+	userSkills := filepath.Join(tempDir, "home", ".claude", "skills")
+	repoA := filepath.Join(tempDir, "service-a")
+	repoB := filepath.Join(tempDir, "service-b")
+
+	writeSkill := func(dir, name, body string) {
+		t.Helper()
+		skillDir := filepath.Join(dir, name)
+		if err := os.MkdirAll(skillDir, 0o750); err != nil {
+			t.Fatalf("failed to create skill dir: %v", err)
+		}
+		content := "---\nname: " + name + "\ndescription: " + name + " skill\n---\n" + body + "\n"
+		if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write skill: %v", err)
+		}
+	}
+
+	writeSkill(userSkills, "lint", "Run the linter.")
+	writeSkill(userSkills, "release", "Cut a release.")
+	writeSkill(filepath.Join(repoA, ".claude", "skills"), "deploy-a", "Deploy service A.")
+	if err := os.MkdirAll(repoB, 0o750); err != nil {
+		t.Fatalf("failed to create repo: %v", err)
+	}
+
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_PATH", userSkills)
+	t.Setenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", ".claude/skills:"+userSkills)
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", ".cursor/skills")
+	t.Setenv("SKILLSYNC_CODEX_SKILLS_PATHS", ".codex/skills")
+	t.Setenv("SKILLSYNC_WORKSPACE_REPOS", repoA+":"+repoB+":"+filepath.Join(tempDir, "missing"))
+
+	ctx := context.Background()
+
+	t.Run("discover aggregates repo skills", func(t *testing.T) {
+		var runErr error
+		output := captureOutput(t, func() {
+			runErr = Run(ctx, []string{"skillsync", "discover", "--workspace", "--format", "json"})
+		})
+		if runErr != nil {
+			t.Fatalf("discover failed: %v", runErr)
+		}
+
+		var results []workspaceRepoSkills
+		if err := json.Unmarshal([]byte(output), &results); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, output)
+		}
+		if len(results) != 2 {
+			t.Fatalf("expected 2 existing repos, got %d", len(results))
+		}
+		if results[0].Repo != repoA || len(results[0].Skills) != 1 || results[0].Skills[0].Name != "deploy-a" {
+			t.Errorf("unexpected skills for repo A: %+v", results[0])
+		}
+		if len(results[1].Skills) != 0 {
+			t.Errorf("expected no skills for repo B, got %d", len(results[1].Skills))
+		}
+	})
+
+	t.Run("sync fans user skill into each repo", func(t *testing.T) {
+		err := Run(ctx, []string{
+			"skillsync", "sync", "--workspace", "--skill", "lint", "--yes", "--skip-backup",
+			"claudecode", "claudecode",
+		})
+		if err != nil {
+			t.Fatalf("workspace sync failed: %v", err)
+		}
+
+		for _, repo := range []string{repoA, repoB} {
+			if _, err := os.Stat(filepath.Join(repo, ".claude", "skills", "lint", "SKILL.md")); err != nil {
+				t.Errorf("expected lint skill in %s: %v", repo, err)
+			}
+			if _, err := os.Stat(filepath.Join(repo, ".claude", "skills", "release")); !os.IsNotExist(err) {
+				t.Errorf("release skill should not be synced to %s", repo)
+			}
+		}
+	})
+
+	t.Run("sync without workspace rejects same platform", func(t *testing.T) {
+		err := Run(ctx, []string{"skillsync", "sync", "--yes", "claudecode", "claudecode"})
+		if err == nil || !strings.Contains(err.Error(), "cannot be the same") {
+			t.Errorf("expected same-platform error, got %v", err)
+		}
+	})
+
+	t.Run("no workspace configured", func(t *testing.T) {
+		t.Setenv("SKILLSYNC_WORKSPACE_REPOS", "")
+		err := Run(ctx, []string{"skillsync", "discover", "--workspace"})
+		if err == nil || !strings.Contains(err.Error(), "no workspace repositories") {
+			t.Errorf("expected missing workspace error, got %v", err)
+		}
+	})
+}
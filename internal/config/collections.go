@@ -0,0 +1,98 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// CollectionsFileName is the name of the collections file, read from the
+// config directory next to config.yaml.
+const CollectionsFileName = "collections.yaml"
+
+// Collection is a named group of related skills, such as the linting,
+// testing, and packaging skills of a language stack, synced as a unit with
+// sync --collection NAME.
+type Collection struct {
+	// Description says what the skills have in common
+	Description string `yaml:"description,omitempty"`
+	// Skills are the names of the skills in the collection
+	Skills []string `yaml:"skills"`
+}
+
+// UnmarshalYAML accepts a plain list of skill names as a collection without
+// a description.
+func (c *Collection) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		*c = Collection{}
+		return node.Decode(&c.Skills)
+	}
+	type plain Collection
+	return node.Decode((*plain)(c))
+}
+
+// CollectionsFilePath returns the path to the collections file.
+func CollectionsFilePath() string {
+	return filepath.Join(util.SkillsyncConfigPath(), CollectionsFileName)
+}
+
+// applyCollectionsFile adds the collections defined in the collections file
+// at path. Collections of the same name in config.yaml take precedence. A
+// missing file is not an error.
+func (c *Config) applyCollectionsFile(path string) error {
+	// #nosec G304 - path is constructed from trusted config directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var collections map[string]Collection
+	if err := yaml.Unmarshal(data, &collections); err != nil {
+		return fmt.Errorf("invalid collections file %s: %w", path, err)
+	}
+	for name, collection := range collections {
+		if _, ok := c.Collections[name]; ok {
+			continue
+		}
+		if c.Collections == nil {
+			c.Collections = make(map[string]Collection)
+		}
+		c.Collections[name] = collection
+	}
+	return nil
+}
+
+// Collection returns the collection with the given name.
+func (c *Config) Collection(name string) (Collection, error) {
+	collection, ok := c.Collections[name]
+	if ok {
+		return collection, nil
+	}
+	names := slices.Sorted(maps.Keys(c.Collections))
+	if len(names) == 0 {
+		return Collection{}, fmt.Errorf("unknown collection %q: no collections are configured (see %s)", name, CollectionsFilePath())
+	}
+	return Collection{}, fmt.Errorf("unknown collection %q (configured: %s)", name, strings.Join(names, ", "))
+}
+
+// validateCollections reports collections without skills.
+func (c *Config) validateCollections() error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(c.Collections)) {
+		if len(c.Collections[name].Skills) == 0 {
+			errs = append(errs, fmt.Errorf("collections.%s: no skills listed", name))
+		}
+	}
+	return errors.Join(errs...)
+}
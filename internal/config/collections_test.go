@@ -0,0 +1,83 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestLoadCollections(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", home)
+	t.Chdir(t.TempDir())
+	util.WriteFile(t, filepath.Join(home, configFileName),
+		"collections:\n  python-stack:\n    skills: [ruff]\n")
+	util.WriteFile(t, filepath.Join(home, CollectionsFileName), `python-stack:
+  description: Ignored, config.yaml wins
+  skills: [black]
+go-stack:
+  description: Go tooling
+  skills: [golangci-lint, go-test]
+web: [eslint, prettier]
+`)
+
+	cfg, err := Load()
+	util.AssertNoError(t, err)
+
+	tests := map[string]struct {
+		name       string
+		wantSkills string
+		wantDesc   string
+		wantErr    string
+	}{
+		"config.yaml takes precedence": {name: "python-stack", wantSkills: "ruff"},
+		"from collections.yaml":        {name: "go-stack", wantSkills: "golangci-lint,go-test", wantDesc: "Go tooling"},
+		"list shorthand":               {name: "web", wantSkills: "eslint,prettier"},
+		"unknown":                      {name: "rust", wantErr: `unknown collection "rust" (configured: go-stack, python-stack, web)`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			collection, err := cfg.Collection(tt.name)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Collection(%q) error = %v, want %q", tt.name, err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, strings.Join(collection.Skills, ","), tt.wantSkills)
+			util.AssertEqual(t, collection.Description, tt.wantDesc)
+		})
+	}
+}
+
+func TestLoadCollections_Project(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", home)
+	util.WriteFile(t, filepath.Join(home, CollectionsFileName), "python-stack: [ruff]\n")
+	chdirRepo(t, "collections:\n  python-stack: [ruff, pytest]\nprofiles:\n  python:\n    source: claudecode:repo\n    target: cursor:repo\n    collection: python-stack\n")
+
+	cfg, err := Load()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, strings.Join(cfg.Collections["python-stack"].Skills, ","), "ruff,pytest")
+	util.AssertNoError(t, cfg.Validate())
+}
+
+func TestValidateCollections(t *testing.T) {
+	cfg := Default()
+	cfg.Collections = map[string]Collection{"empty": {}}
+	cfg.Profiles = map[string]Profile{"p": {Source: "claudecode", Target: "cursor", Collection: "missing"}}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want errors")
+	}
+	for _, want := range []string{"collections.empty: no skills listed", `profiles.p.collection: unknown collection "missing"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, want %q", err, want)
+		}
+	}
+}
@@ -3,14 +3,24 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
+	"slices"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/cache"
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/parser/external"
+	"github.com/klauern/skillsync/internal/selfupdate"
 	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/trash"
 	"github.com/klauern/skillsync/internal/util"
 )
 
@@ -27,6 +37,58 @@ type Config struct {
 
 	// Similarity configures similarity matching thresholds
 	Similarity SimilarityConfig `yaml:"similarity"`
+
+	// Validation configures the rules run by the validate command
+	Validation ValidationConfig `yaml:"validation,omitempty"`
+
+	// Backup configures how backups are kept
+	Backup BackupConfig `yaml:"backup"`
+
+	// Workspace lists repositories that are managed together
+	Workspace WorkspaceConfig `yaml:"workspace,omitempty"`
+
+	// Plugins configures where plugins are searched for and installed from
+	Plugins PluginsConfig `yaml:"plugins,omitempty"`
+
+	// Discovery bounds how far skill discovery walks search paths
+	Discovery DiscoveryConfig `yaml:"discovery"`
+
+	// Cache configures the caches of parsed skills and plugins
+	Cache CacheConfig `yaml:"cache"`
+
+	// Diff configures how conflict hunks and diff previews are computed
+	Diff DiffConfig `yaml:"diff"`
+
+	// Confirmation configures the prompts of destructive operations
+	Confirmation ConfirmationConfig `yaml:"confirmation"`
+
+	// Update configures self-update
+	Update UpdateConfig `yaml:"update"`
+
+	// Log configures the log file
+	Log LogConfig `yaml:"log"`
+
+	// Hooks are shell commands run around sync and each skill write
+	Hooks sync.Hooks `yaml:"hooks,omitempty"`
+
+	// Profiles are named syncs, run with sync --profile NAME
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+
+	// Collections are named groups of skills, synced with sync --collection
+	// NAME. More can be defined in collections.yaml.
+	Collections map[string]Collection `yaml:"collections,omitempty"`
+
+	// Vars are values for the template variables of skills, keyed by
+	// variable name. Sync prompts for variables that have no value here
+	// and saves the answers in the project config.
+	Vars map[string]string `yaml:"vars,omitempty"`
+
+	// sources records the settings set by the config files or environment;
+	// see Source
+	sources map[string]Source
+
+	// projectFile is the path of the project config merged into this one, if any
+	projectFile string
 }
 
 // PlatformsConfig holds platform-specific configuration.
@@ -34,16 +96,65 @@ type PlatformsConfig struct {
 	ClaudeCode PlatformConfig `yaml:"claude_code"`
 	Cursor     PlatformConfig `yaml:"cursor"`
 	Codex      PlatformConfig `yaml:"codex"`
+	Aider      PlatformConfig `yaml:"aider"`
+	// Custom adds platforms parsed by external programs
+	Custom []CustomPlatformConfig `yaml:"custom,omitempty"`
+}
+
+// CustomPlatformConfig configures a platform whose skills are parsed by an
+// external program instead of skillsync.
+type CustomPlatformConfig struct {
+	// Name is the platform's name, as given to --platform and sync
+	Name string `yaml:"name"`
+	// Command is the external parser, split on whitespace. It is run with a
+	// skills directory as its last argument and prints the skills it finds
+	// as JSON.
+	Command string `yaml:"command"`
+	// FileName is a Go template for the path, relative to the skills
+	// directory, that synced skills are written to (default {{.Name}}.md).
+	FileName string `yaml:"file_name,omitempty"`
+	// WriteTemplate is a Go template for the content of synced skills.
+	// Without one, skills are written as markdown with YAML frontmatter.
+	WriteTemplate string `yaml:"write_template,omitempty"`
+
+	// PlatformConfig holds the platform's search paths (default
+	// .<name>/skills and ~/.<name>/skills) and skill limit
+	PlatformConfig `yaml:",inline"`
+}
+
+// Definition returns the definition of the custom platform for the external
+// parser package.
+func (c CustomPlatformConfig) Definition() external.Definition {
+	return external.Definition{
+		Name:          model.Platform(c.Name),
+		Command:       strings.Fields(c.Command),
+		FileName:      c.FileName,
+		WriteTemplate: c.WriteTemplate,
+	}
+}
+
+// CustomDefinitions returns the definitions of the custom platforms.
+func (p PlatformsConfig) CustomDefinitions() []external.Definition {
+	defs := make([]external.Definition, 0, len(p.Custom))
+	for _, custom := range p.Custom {
+		defs = append(defs, custom.Definition())
+	}
+	return defs
 }
 
 // PlatformConfig holds configuration for a single platform.
 type PlatformConfig struct {
 	// SkillsPaths is an ordered list of paths to search for skills (project → user → system)
-	// Paths can use ~ for home directory or be relative (resolved from working directory)
+	// Paths can use ~ for home directory or be relative (resolved from working directory),
+	// and ${VAR} or ${VAR:-default} for environment variables
 	SkillsPaths []string `yaml:"skills_paths,omitempty"`
 
 	// Deprecated: Use SkillsPaths instead. Kept for backward compatibility during migration.
 	SkillsPath string `yaml:"skills_path,omitempty"`
+
+	// MaxSkills is a soft limit on the number of skills in one scope of the
+	// platform. Sync refuses to go past it without --force. 0 means no limit.
+	MaxSkills int `yaml:"max_skills"`
 }
 
 // SyncConfig holds synchronization settings.
@@ -51,9 +162,71 @@ type SyncConfig struct {
 	// DefaultStrategy is the default conflict resolution strategy
 	DefaultStrategy string `yaml:"default_strategy"`
 
+	// ScopeStrategies overrides DefaultStrategy for syncs into a target scope,
+	// keyed by scope name (repo, user). For example three-way for shared repo
+	// skills and overwrite for personal user skills.
+	ScopeStrategies map[string]string `yaml:"scope_strategies,omitempty"`
+
 	// IncludeTypes controls which artifact types sync/delete include by default.
 	// Valid values: skill, prompt.
 	IncludeTypes []string `yaml:"include_types,omitempty"`
+
+	// TrashRetentionDays is how long skills removed from targets by delete
+	// syncs are kept in the trash, where a later sync restores them if they
+	// reappear in the source. 0 removes them permanently right away.
+	TrashRetentionDays int `yaml:"trash_retention_days"`
+
+	// Metrics keeps a skillsync-metrics block (word count, token estimate,
+	// last-synced time) up to date in the frontmatter of synced skills.
+	Metrics bool `yaml:"metrics,omitempty"`
+
+	// MetricsAddr is the address sync --watch serves Prometheus metrics on
+	// at /metrics (e.g. 127.0.0.1:9464). Empty serves none.
+	MetricsAddr string `yaml:"metrics_addr,omitempty"`
+
+	// WritableScopes restricts the target scopes sync and delete may write
+	// to (repo, user). Empty allows both. Usually set in a project's
+	// .skillsync.yaml, e.g. to keep a repository's syncs out of user scope.
+	WritableScopes []string `yaml:"writable_scopes,omitempty"`
+
+	// RenameDetection makes sync rename target skills whose source skill
+	// was renamed, instead of creating a near-duplicate next to them.
+	RenameDetection RenameDetectionConfig `yaml:"rename_detection"`
+
+	// ResolutionPreferences sets how conflicts of three-way and interactive
+	// syncs are resolved without prompting, per platform pair: keys are
+	// "source->target" (e.g. claudecode->cursor), values prefer-source,
+	// prefer-target, or merge.
+	ResolutionPreferences map[string]string `yaml:"resolution_preferences,omitempty"`
+}
+
+// RenameDetectionConfig controls how sync treats a source skill missing from
+// the target when a target skill missing from the source has nearly the same
+// content.
+type RenameDetectionConfig struct {
+	// Enabled turns rename detection on (default: true)
+	Enabled bool `yaml:"enabled"`
+	// Threshold is the minimum content similarity (0.0-1.0) for the target
+	// skill to count as renamed (default: 0.9)
+	Threshold float64 `yaml:"threshold"`
+}
+
+// Profile is a named sync: a source and target with the options to sync
+// them with. Flags given on the command line take precedence.
+type Profile struct {
+	// Source and Target are platform specs, e.g. claudecode:repo
+	Source string `yaml:"source"`
+	Target string `yaml:"target"`
+	// Strategy is the conflict resolution strategy (default: as for sync)
+	Strategy string `yaml:"strategy,omitempty"`
+	// Skills limits the sync to the named skills
+	Skills []string `yaml:"skills,omitempty"`
+	// Collection limits the sync to the skills of the named collection
+	Collection string `yaml:"collection,omitempty"`
+	// Map sends each source scope to a target scope, as --map source=target
+	Map []string `yaml:"map,omitempty"`
+	// IncludePlugins includes plugin skills, as --include-plugins
+	IncludePlugins bool `yaml:"include_plugins,omitempty"`
 }
 
 // OutputConfig holds display preferences.
@@ -70,6 +243,241 @@ type SimilarityConfig struct {
 	ContentThreshold float64 `yaml:"content_threshold"`
 	// Algorithm is the default similarity algorithm (levenshtein, jaro-winkler, combined)
 	Algorithm string `yaml:"algorithm"`
+	// Embeddings configures an optional embeddings backend for content
+	// similarity, which catches reworded skills that string metrics miss
+	Embeddings EmbeddingsConfig `yaml:"embeddings,omitempty"`
+}
+
+// EmbeddingsConfig selects the backend that turns skill content into
+// embedding vectors. With no provider, content similarity uses string metrics.
+type EmbeddingsConfig struct {
+	// Provider is "openai" for any OpenAI-compatible embeddings API (including
+	// local servers such as Ollama or llama.cpp) or "command" for a local
+	// program
+	Provider string `yaml:"provider,omitempty"`
+	// URL is the API base URL (default https://api.openai.com/v1)
+	URL string `yaml:"url,omitempty"`
+	// Model is the embedding model name sent to the API
+	Model string `yaml:"model,omitempty"`
+	// APIKeyEnv names the environment variable holding the API key
+	// (default OPENAI_API_KEY). Keys are never read from the config file.
+	APIKeyEnv string `yaml:"api_key_env,omitempty"`
+	// Command is the program run by the command provider. It receives a JSON
+	// array of texts on stdin and prints a JSON array of vectors.
+	Command string `yaml:"command,omitempty"`
+	// Threshold is the minimum cosine similarity for a match (0.0-1.0,
+	// default 0.85)
+	Threshold float64 `yaml:"threshold,omitempty"`
+}
+
+// ValidationConfig holds validation rule settings.
+type ValidationConfig struct {
+	// Rules sets the severity of individual validation rules by name
+	// (e.g. require-description: error, max-content-size: ignore).
+	// Valid severities: error, warning, ignore.
+	Rules map[string]string `yaml:"rules,omitempty"`
+
+	// SchemaPath is a JSON Schema file that every skill's frontmatter must
+	// satisfy in validate, sync, and import. Relative paths resolve from the
+	// directory holding config.yaml.
+	SchemaPath string `yaml:"schema_path,omitempty"`
+}
+
+// BackupConfig holds backup retention settings, applied before each sync
+// backs up files and by backup prune.
+type BackupConfig struct {
+	// MaxAgeDays is how many days backups are kept. 0 keeps them regardless of age.
+	MaxAgeDays int `yaml:"max_age_days"`
+	// MaxCount is how many backups are kept of each skill on each platform.
+	// 0 keeps every backup.
+	MaxCount int `yaml:"max_count"`
+	// MaxSizeMB limits the total size of each platform's backups in
+	// megabytes, removing the oldest first. 0 is unlimited.
+	MaxSizeMB int `yaml:"max_size_mb"`
+	// LineageLimits sets how many backups to keep of individual skills,
+	// keyed by lineage (the skill name, followed across renames), in place
+	// of the default of 10. 0 keeps every backup of the skill.
+	LineageLimits map[string]int `yaml:"lineage_limits,omitempty"`
+}
+
+// WorkspaceConfig holds multi-repository workspace settings.
+type WorkspaceConfig struct {
+	// Repos is a list of repository root directories in the workspace.
+	// Paths can use ~ for home directory; relative paths resolve from the working directory.
+	Repos []string `yaml:"repos,omitempty"`
+}
+
+// PluginsConfig configures plugin marketplaces.
+type PluginsConfig struct {
+	// Marketplaces are Git repositories with a .claude-plugin/marketplace.json,
+	// searched in addition to the marketplaces known to Claude Code.
+	Marketplaces []MarketplaceConfig `yaml:"marketplaces,omitempty"`
+}
+
+// MarketplaceConfig is a custom marketplace index.
+type MarketplaceConfig struct {
+	// Name identifies the marketplace in search results (default: derived from URL)
+	Name string `yaml:"name,omitempty"`
+	// URL is the Git repository URL of the marketplace
+	URL string `yaml:"url"`
+}
+
+// Platform returns the configuration of a platform.
+func (p PlatformsConfig) Platform(platform model.Platform) (PlatformConfig, bool) {
+	switch platform {
+	case model.ClaudeCode:
+		return p.ClaudeCode, true
+	case model.Cursor:
+		return p.Cursor, true
+	case model.Codex:
+		return p.Codex, true
+	case model.Aider:
+		return p.Aider, true
+	}
+	for _, custom := range p.Custom {
+		if custom.Name != string(platform) {
+			continue
+		}
+		pc := custom.PlatformConfig
+		if len(pc.SkillsPaths) == 0 && pc.SkillsPath == "" { //nolint:staticcheck // backward compatibility
+			dir := "." + custom.Name
+			pc.SkillsPaths = []string{dir + "/skills", "~/" + dir + "/skills"}
+		}
+		return pc, true
+	}
+	return PlatformConfig{}, false
+}
+
+// DiscoveryConfig bounds the directory walks of skill discovery, keeping it
+// fast when a search path holds dependency trees such as node_modules.
+type DiscoveryConfig struct {
+	// Ignore lists glob patterns for directory names to skip (node_modules,
+	// .git, vendor, ... by default). Setting it replaces the defaults.
+	Ignore []string `yaml:"ignore"`
+	// MaxDepth is how many directory levels below a search path discovery
+	// descends. 0 means no limit.
+	MaxDepth int `yaml:"max_depth"`
+	// MaxFiles is how many files discovery visits below a search path before
+	// it stops with a warning. 0 means no limit.
+	MaxFiles int `yaml:"max_files"`
+	// RepoCache caches the walks of repo-scope discovery in the repository's
+	// .skillsync/cache (ignored by git). A cached walk is reused until one
+	// of the directories it walked changes, which speeds up repo-scope
+	// commands in large checkouts.
+	RepoCache bool `yaml:"repo_cache,omitempty"`
+}
+
+// Limits returns the discovery limits for the parser package.
+func (d DiscoveryConfig) Limits() parser.DiscoveryLimits {
+	return parser.DiscoveryLimits{Ignore: d.Ignore, MaxDepth: d.MaxDepth, MaxFiles: d.MaxFiles, RepoCache: d.RepoCache}
+}
+
+// CacheConfig controls the caches that spare discovery from re-parsing
+// unchanged skills on every invocation.
+type CacheConfig struct {
+	// Enabled turns the skill and plugin caches on.
+	Enabled bool `yaml:"enabled"`
+	// SkillsTTLMinutes is how long the parsed skills of a directory are
+	// reused. They are parsed again sooner whenever a file in the directory
+	// changes. 0 reuses them for as long as the directory is unchanged.
+	SkillsTTLMinutes int `yaml:"skills_ttl_minutes"`
+	// PluginsTTLMinutes is how long discovered plugin skills are reused.
+	// 0 reuses them until the cache is cleared.
+	PluginsTTLMinutes int `yaml:"plugins_ttl_minutes"`
+	// Incremental also caches each skill file's parse by its modification
+	// time and hash, so a changed directory only re-parses changed files.
+	Incremental bool `yaml:"incremental"`
+}
+
+// Policy returns the cache policy for the cache package.
+func (c CacheConfig) Policy() cache.Policy {
+	return cache.Policy{
+		Enabled:     c.Enabled,
+		SkillsTTL:   time.Duration(c.SkillsTTLMinutes) * time.Minute,
+		PluginsTTL:  time.Duration(c.PluginsTTLMinutes) * time.Minute,
+		Incremental: c.Incremental,
+	}
+}
+
+// DiffConfig selects the diff algorithm and context size used for conflict
+// hunks and diff previews.
+type DiffConfig struct {
+	// Algorithm is myers (default), patience, or histogram. Patience and
+	// histogram produce cleaner hunks for re-ordered content.
+	Algorithm string `yaml:"algorithm"`
+	// Context is the number of unchanged lines shown around each change.
+	Context int `yaml:"context"`
+}
+
+// Options returns the diff options for the sync package.
+func (d DiffConfig) Options() (sync.DiffOptions, error) {
+	algorithm, err := sync.ParseDiffAlgorithm(d.Algorithm)
+	if err != nil {
+		return sync.DiffOptions{}, err
+	}
+	if d.Context < 0 {
+		return sync.DiffOptions{}, fmt.Errorf("invalid diff context %d: must not be negative", d.Context)
+	}
+	return sync.DiffOptions{Algorithm: algorithm, Context: d.Context}, nil
+}
+
+// Typed confirmation modes.
+const (
+	// TypedConfirmationAuto requires typing past the threshold.
+	TypedConfirmationAuto = "auto"
+	// TypedConfirmationAlways requires typing for every destructive operation.
+	TypedConfirmationAlways = "always"
+	// TypedConfirmationNever always asks y/N.
+	TypedConfirmationNever = "never"
+)
+
+// DefaultTypedThreshold is the number of affected skills or files past which
+// destructive operations require a typed confirmation by default.
+const DefaultTypedThreshold = 10
+
+// ConfirmationConfig is the confirmation policy of destructive operations
+// such as delete syncs, rollbacks, and undo. Large ones require typing a
+// phrase naming the count, e.g. "delete 12", instead of answering y/N, so a
+// reflexive "y" cannot remove dozens of skills.
+type ConfirmationConfig struct {
+	// Typed is auto (default), always, or never.
+	Typed string `yaml:"typed"`
+	// Threshold is how many skills or files an operation may affect before
+	// auto requires a typed confirmation.
+	Threshold int `yaml:"threshold"`
+}
+
+// RequiresTyped reports whether an operation affecting count skills or
+// files requires a typed confirmation.
+func (c ConfirmationConfig) RequiresTyped(count int) bool {
+	switch c.Typed {
+	case TypedConfirmationAlways:
+		return true
+	case TypedConfirmationNever:
+		return false
+	default:
+		return count > c.Threshold
+	}
+}
+
+// UpdateConfig configures skillsync self-update.
+type UpdateConfig struct {
+	// Channel is the release channel: stable (default) or prerelease.
+	Channel string `yaml:"channel"`
+}
+
+// LogConfig configures the log file, which keeps a record of each run
+// (such as skills that failed to parse and cache errors) independent of
+// what the console shows.
+type LogConfig struct {
+	// Level is the minimum level written to the file: debug, info
+	// (default), warn, or error. --debug lowers it to debug.
+	Level string `yaml:"level"`
+	// Format is the record format of the file: text (default) or json.
+	Format string `yaml:"format"`
+	// File is the path of the log file, appended to by each run. Empty
+	// (the default) writes no log file. ~ and ${VAR} are expanded.
+	File string `yaml:"file,omitempty"`
 }
 
 // Default returns the default configuration.
@@ -83,12 +491,14 @@ func Default() *Config {
 					"~/.claude/commands", // User slash commands/prompts (absolute)
 					"~/.claude/skills",   // User skills (absolute)
 				},
+				MaxSkills: 100, // Every skill description is loaded into context
 			},
 			Cursor: PlatformConfig{
 				SkillsPaths: []string{
 					".cursor/skills",   // Project (relative)
 					"~/.cursor/skills", // User (absolute)
 				},
+				MaxSkills: 50, // Cursor slows down with many rules
 			},
 			Codex: PlatformConfig{
 				SkillsPaths: []string{
@@ -96,11 +506,24 @@ func Default() *Config {
 					"~/.codex/skills",   // User (absolute)
 					"/etc/codex/skills", // Admin (system-wide)
 				},
+				MaxSkills: 50,
+			},
+			Aider: PlatformConfig{
+				SkillsPaths: []string{
+					".aider/skills",   // Project (relative)
+					"~/.aider/skills", // User (absolute)
+				},
+				MaxSkills: 25, // Conventions are read in full on every request
 			},
 		},
 		Sync: SyncConfig{
-			DefaultStrategy: string(sync.StrategyOverwrite),
-			IncludeTypes:    []string{"skill"},
+			DefaultStrategy:    string(sync.StrategyOverwrite),
+			IncludeTypes:       []string{"skill"},
+			TrashRetentionDays: trash.DefaultRetentionDays,
+			RenameDetection: RenameDetectionConfig{
+				Enabled:   true,
+				Threshold: sync.DefaultRenameThreshold,
+			},
 		},
 		Output: OutputConfig{
 			Color: "auto",
@@ -110,6 +533,36 @@ func Default() *Config {
 			ContentThreshold: 0.6, // 60% match required for content similarity
 			Algorithm:        "combined",
 		},
+		Discovery: DiscoveryConfig{
+			Ignore:   slices.Clone(parser.DefaultIgnore),
+			MaxDepth: parser.DefaultMaxDepth,
+			MaxFiles: parser.DefaultMaxFiles,
+		},
+		Cache: CacheConfig{
+			Enabled:           true,
+			SkillsTTLMinutes:  int(cache.DefaultTTL / time.Minute),
+			PluginsTTLMinutes: int(cache.DefaultTTL / time.Minute),
+			Incremental:       true,
+		},
+		Diff: DiffConfig{
+			Algorithm: string(sync.DiffMyers),
+			Context:   sync.DefaultDiffContext,
+		},
+		Confirmation: ConfirmationConfig{
+			Typed:     TypedConfirmationAuto,
+			Threshold: DefaultTypedThreshold,
+		},
+		Update: UpdateConfig{
+			Channel: string(selfupdate.ChannelStable),
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: logging.FormatText,
+		},
+		Backup: BackupConfig{
+			MaxAgeDays: backup.DefaultMaxAgeDays,
+			MaxCount:   backup.DefaultMaxBackups,
+		},
 	}
 }
 
@@ -121,19 +574,49 @@ func FilePath() string {
 	return filepath.Join(util.SkillsyncConfigPath(), configFileName)
 }
 
-// Load loads the configuration from file, merging with defaults.
-// If the config file doesn't exist, returns default configuration.
+// Load loads the configuration from file, merging with defaults, expands
+// ${VAR} references in its paths, adds the collections of collections.yaml,
+// then merges the project config (.skillsync.yaml at the repository root)
+// and environment overrides over it. If the config file doesn't exist, the
+// defaults are used.
 func Load() (*Config, error) {
+	cfg, err := loadUserFile()
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.interpolatePaths(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", FilePath(), err)
+	}
+	if err := cfg.applyCollectionsFile(CollectionsFilePath()); err != nil {
+		return nil, err
+	}
+	if err := cfg.applyProject(ProjectFilePath()); err != nil {
+		return nil, err
+	}
+	cfg.applyEnvironment()
+	return cfg, nil
+}
+
+// LoadUser loads the user configuration with environment overrides but
+// without the project config, for editing the user config file. ${VAR}
+// references in paths are kept, so that saving it does not replace them.
+func LoadUser() (*Config, error) {
+	cfg, err := loadUserFile()
+	if err != nil {
+		return nil, err
+	}
+	cfg.applyEnvironment()
+	return cfg, nil
+}
+
+// loadUserFile loads the config file over the defaults.
+func loadUserFile() (*Config, error) {
 	cfg := Default()
 
-	// Try to load from file
-	configPath := FilePath()
 	// #nosec G304 - configPath is constructed from trusted config directory
-	data, err := os.ReadFile(configPath)
+	data, err := os.ReadFile(FilePath())
 	if err != nil {
 		if os.IsNotExist(err) {
-			// No config file, use defaults with environment overrides
-			cfg.applyEnvironment()
 			return cfg, nil
 		}
 		return nil, err
@@ -143,14 +626,12 @@ func Load() (*Config, error) {
 	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, err
 	}
-
-	// Apply environment variable overrides
-	cfg.applyEnvironment()
-
+	cfg.recordFileKeys(data, SourceFile)
 	return cfg, nil
 }
 
-// LoadFromPath loads configuration from a specific path.
+// LoadFromPath loads configuration from a specific path, expanding ${VAR}
+// references in its paths.
 func LoadFromPath(path string) (*Config, error) {
 	cfg := Default()
 
@@ -163,6 +644,10 @@ func LoadFromPath(path string) (*Config, error) {
 	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, err
 	}
+	cfg.recordFileKeys(data, SourceFile)
+	if err := cfg.interpolatePaths(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
 
 	cfg.applyEnvironment()
 	return cfg, nil
@@ -173,7 +658,7 @@ func (c *Config) Save() error {
 	configPath := FilePath()
 
 	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(configPath), 0o750); err != nil {
+	if err := util.EnsureDataDir(filepath.Dir(configPath), 0o750); err != nil {
 		return err
 	}
 
@@ -202,69 +687,17 @@ func (c *Config) SaveToPath(path string) error {
 	return os.WriteFile(path, data, 0o644)
 }
 
-// applyEnvironment applies environment variable overrides.
-// Environment variables follow the pattern SKILLSYNC_<SECTION>_<KEY>.
-func (c *Config) applyEnvironment() {
-	// Sync settings
-	if v := os.Getenv("SKILLSYNC_SYNC_STRATEGY"); v != "" {
-		c.Sync.DefaultStrategy = v
-	}
-	if v := os.Getenv("SKILLSYNC_SYNC_INCLUDE_TYPES"); v != "" {
-		types := strings.Split(v, ",")
-		parsed := make([]string, 0, len(types))
-		for _, t := range types {
-			t = strings.TrimSpace(t)
-			if t != "" {
-				parsed = append(parsed, t)
-			}
-		}
-		c.Sync.IncludeTypes = parsed
-	}
-
-	// Output settings
-	if v := os.Getenv("SKILLSYNC_OUTPUT_COLOR"); v != "" {
-		c.Output.Color = v
-	}
-
-	// Platform paths - new colon-separated format
-	if v := os.Getenv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS"); v != "" {
-		c.Platforms.ClaudeCode.SkillsPaths = splitPaths(v)
-	}
-	if v := os.Getenv("SKILLSYNC_CURSOR_SKILLS_PATHS"); v != "" {
-		c.Platforms.Cursor.SkillsPaths = splitPaths(v)
-	}
-	if v := os.Getenv("SKILLSYNC_CODEX_SKILLS_PATHS"); v != "" {
-		c.Platforms.Codex.SkillsPaths = splitPaths(v)
-	}
-
-	// Deprecated: single path environment variables (for backward compatibility)
-	if v := os.Getenv("SKILLSYNC_CLAUDE_CODE_PATH"); v != "" {
-		c.Platforms.ClaudeCode.SkillsPath = v
-	}
-	if v := os.Getenv("SKILLSYNC_CURSOR_PATH"); v != "" {
-		c.Platforms.Cursor.SkillsPath = v
-	}
-	if v := os.Getenv("SKILLSYNC_CODEX_PATH"); v != "" {
-		c.Platforms.Codex.SkillsPath = v
-	}
-
-	// Similarity settings
-	if v := os.Getenv("SKILLSYNC_SIMILARITY_NAME_THRESHOLD"); v != "" {
-		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 && f <= 1 {
-			c.Similarity.NameThreshold = f
-		}
-	}
-	if v := os.Getenv("SKILLSYNC_SIMILARITY_CONTENT_THRESHOLD"); v != "" {
-		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 && f <= 1 {
-			c.Similarity.ContentThreshold = f
-		}
-	}
-	if v := os.Getenv("SKILLSYNC_SIMILARITY_ALGORITHM"); v != "" {
-		c.Similarity.Algorithm = v
+// parseBool parses a boolean from common string representations.
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "true", "yes", "on":
+		return true, true
+	case "0", "false", "no", "off":
+		return false, true
 	}
+	return false, false
 }
 
-// parseBool parses a boolean from common string representations.
 // splitPaths splits a colon-separated path string into individual paths.
 // Empty segments are filtered out.
 func splitPaths(s string) []string {
@@ -288,6 +721,54 @@ func (c *Config) GetStrategy() sync.Strategy {
 	return sync.StrategyOverwrite
 }
 
+// StrategyForScope returns the default sync strategy for the given target
+// scope: its ScopeStrategies entry when that is valid, otherwise GetStrategy.
+// The second return value reports whether a scope-specific entry was used.
+func (c *Config) StrategyForScope(scope string) (sync.Strategy, bool) {
+	strategy := sync.Strategy(c.Sync.ScopeStrategies[scope])
+	if strategy.IsValid() {
+		return strategy, true
+	}
+	return c.GetStrategy(), false
+}
+
+// FrontmatterSchemaPath returns the expanded path of the frontmatter JSON
+// Schema, or "" when none is configured.
+func (c *Config) FrontmatterSchemaPath() string {
+	return util.ExpandPath(c.Validation.SchemaPath, util.SkillsyncConfigPath())
+}
+
+// TrashRetention returns how long deleted target skills are kept in the trash.
+// Nothing is trashed with --no-persist.
+func (c *Config) TrashRetention() time.Duration {
+	if c.Sync.TrashRetentionDays <= 0 || util.NoPersist() {
+		return 0
+	}
+	return time.Duration(c.Sync.TrashRetentionDays) * 24 * time.Hour
+}
+
+// BackupRetention returns the cleanup options of the configured backup
+// retention policy for platform, or every platform when it is empty.
+func (c *Config) BackupRetention(platform string) backup.CleanupOptions {
+	return backup.CleanupOptions{
+		MaxBackups:     c.Backup.MaxCount,
+		LineageLimits:  c.Backup.LineageLimits,
+		MaxAge:         time.Duration(c.Backup.MaxAgeDays) * 24 * time.Hour,
+		MaxTotalSize:   int64(c.Backup.MaxSizeMB) * 1024 * 1024,
+		KeepAtLeastOne: true,
+		Platform:       platform,
+	}
+}
+
+// RenameThreshold returns the content similarity at which sync treats a
+// target skill as renamed, or 0 when rename detection is off.
+func (c *Config) RenameThreshold() float64 {
+	if !c.Sync.RenameDetection.Enabled {
+		return 0
+	}
+	return c.Sync.RenameDetection.Threshold
+}
+
 // GetSkillsPaths returns all skills paths for this platform, expanded and in order.
 // If SkillsPaths is empty but deprecated SkillsPath is set, falls back to that.
 // The baseDir is used for resolving relative paths.
@@ -319,6 +800,21 @@ func (pc *PlatformConfig) GetPrimarySkillsPath(baseDir string) string {
 	return ""
 }
 
+// WorkspaceRepos returns the configured workspace repository roots, expanded
+// and de-duplicated in configuration order.
+func (c *Config) WorkspaceRepos() []string {
+	repos := make([]string, 0, len(c.Workspace.Repos))
+	seen := make(map[string]bool)
+	for _, repo := range util.ExpandPaths(c.Workspace.Repos, "") {
+		if seen[repo] {
+			continue
+		}
+		seen[repo] = true
+		repos = append(repos, repo)
+	}
+	return repos
+}
+
 // Exists returns true if a config file exists.
 func Exists() bool {
 	_, err := os.Stat(FilePath())
@@ -3,9 +3,17 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
 	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
 )
 
 func TestDefault(t *testing.T) {
@@ -22,6 +30,9 @@ func TestDefault(t *testing.T) {
 	if len(cfg.Sync.IncludeTypes) != 1 || cfg.Sync.IncludeTypes[0] != "skill" {
 		t.Errorf("expected default include_types [skill], got %v", cfg.Sync.IncludeTypes)
 	}
+	if cfg.TrashRetention() != 7*24*time.Hour {
+		t.Errorf("expected default trash retention of 7 days, got %v", cfg.TrashRetention())
+	}
 
 	// Check output defaults
 	if cfg.Output.Color != "auto" {
@@ -80,6 +91,16 @@ func TestEnvironmentOverrides(t *testing.T) {
 			envValue: "three-way",
 			check:    func(c *Config) bool { return c.Sync.DefaultStrategy == "three-way" },
 		},
+		{
+			name:     "sync scope strategies",
+			envKey:   "SKILLSYNC_SYNC_SCOPE_STRATEGIES",
+			envValue: "repo=three-way, user = skip,bogus",
+			check: func(c *Config) bool {
+				return len(c.Sync.ScopeStrategies) == 2 &&
+					c.Sync.ScopeStrategies["repo"] == "three-way" &&
+					c.Sync.ScopeStrategies["user"] == "skip"
+			},
+		},
 		{
 			name:     "sync include types",
 			envKey:   "SKILLSYNC_SYNC_INCLUDE_TYPES",
@@ -90,6 +111,50 @@ func TestEnvironmentOverrides(t *testing.T) {
 					c.Sync.IncludeTypes[1] == "prompt"
 			},
 		},
+		{
+			name:     "sync trash retention",
+			envKey:   "SKILLSYNC_SYNC_TRASH_RETENTION_DAYS",
+			envValue: "0",
+			check:    func(c *Config) bool { return c.Sync.TrashRetentionDays == 0 && c.TrashRetention() == 0 },
+		},
+		{
+			name:     "backup max size",
+			envKey:   "SKILLSYNC_BACKUP_MAX_SIZE_MB",
+			envValue: "2",
+			check:    func(c *Config) bool { return c.BackupRetention("cursor").MaxTotalSize == 2*1024*1024 },
+		},
+		{
+			name:     "cache skills ttl",
+			envKey:   "SKILLSYNC_CACHE_SKILLS_TTL_MINUTES",
+			envValue: "5",
+			check:    func(c *Config) bool { return c.Cache.Policy().SkillsTTL == 5*time.Minute },
+		},
+		{
+			name:     "cache incremental",
+			envKey:   "SKILLSYNC_CACHE_INCREMENTAL",
+			envValue: "false",
+			check:    func(c *Config) bool { return !c.Cache.Policy().Incremental },
+		},
+		{
+			name:     "sync metrics",
+			envKey:   "SKILLSYNC_SYNC_METRICS",
+			envValue: "yes",
+			check:    func(c *Config) bool { return c.Sync.Metrics },
+		},
+		{
+			name:     "sync metrics address",
+			envKey:   "SKILLSYNC_SYNC_METRICS_ADDR",
+			envValue: "127.0.0.1:9464",
+			check:    func(c *Config) bool { return c.Sync.MetricsAddr == "127.0.0.1:9464" },
+		},
+		{
+			name:     "validation schema path",
+			envKey:   "SKILLSYNC_VALIDATION_SCHEMA_PATH",
+			envValue: "/etc/skillsync/frontmatter.json",
+			check: func(c *Config) bool {
+				return c.FrontmatterSchemaPath() == "/etc/skillsync/frontmatter.json"
+			},
+		},
 		{
 			name:     "output color",
 			envKey:   "SKILLSYNC_OUTPUT_COLOR",
@@ -149,6 +214,53 @@ func TestGetStrategy(t *testing.T) {
 	}
 }
 
+func TestStrategyForScope(t *testing.T) {
+	tests := map[string]struct {
+		defaultStrategy string
+		scopeStrategies map[string]string
+		scope           string
+		expected        sync.Strategy
+		expectedScoped  bool
+	}{
+		"scope entry": {
+			defaultStrategy: "overwrite",
+			scopeStrategies: map[string]string{"repo": "three-way", "user": "overwrite"},
+			scope:           "repo",
+			expected:        sync.StrategyThreeWay,
+			expectedScoped:  true,
+		},
+		"no scope entry falls back to default": {
+			defaultStrategy: "skip",
+			scopeStrategies: map[string]string{"repo": "three-way"},
+			scope:           "user",
+			expected:        sync.StrategySkip,
+		},
+		"invalid scope entry falls back to default": {
+			defaultStrategy: "newer",
+			scopeStrategies: map[string]string{"repo": "bogus"},
+			scope:           "repo",
+			expected:        sync.StrategyNewer,
+		},
+		"no scope strategies": {
+			scope:    "repo",
+			expected: sync.StrategyOverwrite,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Sync.DefaultStrategy = tt.defaultStrategy
+			cfg.Sync.ScopeStrategies = tt.scopeStrategies
+
+			strategy, scoped := cfg.StrategyForScope(tt.scope)
+			if strategy != tt.expected || scoped != tt.expectedScoped {
+				t.Errorf("StrategyForScope(%q) = %q, %v; expected %q, %v", tt.scope, strategy, scoped, tt.expected, tt.expectedScoped)
+			}
+		})
+	}
+}
+
 func TestLoadNonExistentFile(t *testing.T) {
 	// Create a temporary directory
 	tmpDir := t.TempDir()
@@ -540,6 +652,12 @@ func TestSimilarityEnvironmentOverrides(t *testing.T) {
 			envValue: "levenshtein",
 			check:    func(c *Config) bool { return c.Similarity.Algorithm == "levenshtein" },
 		},
+		{
+			name:     "embeddings provider",
+			envKey:   "SKILLSYNC_SIMILARITY_EMBEDDINGS_PROVIDER",
+			envValue: "openai",
+			check:    func(c *Config) bool { return c.Similarity.Embeddings.Provider == "openai" },
+		},
 		{
 			name:     "invalid name threshold ignored (too high)",
 			envKey:   "SKILLSYNC_SIMILARITY_NAME_THRESHOLD",
@@ -636,3 +754,344 @@ similarity:
 	// Note: Without special handling, unspecified float64 fields become 0
 	// This is expected YAML behavior - if users want defaults, they shouldn't specify the section
 }
+
+func TestWorkspaceRepos(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := map[string]struct {
+		repos []string
+		env   string
+		want  []string
+	}{
+		"empty": {
+			want: []string{},
+		},
+		"expands home and dedupes": {
+			repos: []string{"~/src/api", "/srv/web", "~/src/api"},
+			want:  []string{filepath.Join(home, "src", "api"), "/srv/web"},
+		},
+		"environment override": {
+			repos: []string{"/srv/web"},
+			env:   "/srv/one:/srv/two",
+			want:  []string{"/srv/one", "/srv/two"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv("SKILLSYNC_WORKSPACE_REPOS", tt.env)
+			}
+
+			cfg := Default()
+			cfg.Workspace.Repos = tt.repos
+			cfg.applyEnvironment()
+
+			got := cfg.WorkspaceRepos()
+			if len(got) != len(tt.want) {
+				t.Fatalf("WorkspaceRepos() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("WorkspaceRepos()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHooksConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	hooksConfig := `
+hooks:
+  pre_sync:
+    - "echo starting"
+  post_skill:
+    - "prettier --write \"$SKILLSYNC_SKILL_PATH\""
+  post_sync:
+    - "curl -s -X POST \"$SLACK_WEBHOOK\" -d \"{\\\"text\\\": \\\"synced $SKILLSYNC_CHANGED skills\\\"}\""
+`
+	// #nosec G306 - test file permissions are acceptable
+	if err := os.WriteFile(configPath, []byte(hooksConfig), 0o644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	cfg, err := LoadFromPath(configPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath failed: %v", err)
+	}
+
+	if len(cfg.Hooks.PreSync) != 1 || cfg.Hooks.PreSync[0] != "echo starting" {
+		t.Errorf("unexpected pre_sync hooks: %v", cfg.Hooks.PreSync)
+	}
+	if got := cfg.Hooks.Commands(sync.HookPostSkill); len(got) != 1 || got[0] != `prettier --write "$SKILLSYNC_SKILL_PATH"` {
+		t.Errorf("unexpected post_skill hooks: %v", got)
+	}
+	if len(cfg.Hooks.PostSync) != 1 {
+		t.Errorf("expected 1 post_sync hook, got %v", cfg.Hooks.PostSync)
+	}
+	if len(cfg.Hooks.PreSkill) != 0 {
+		t.Errorf("expected no pre_skill hooks, got %v", cfg.Hooks.PreSkill)
+	}
+
+	if !Default().Hooks.IsEmpty() {
+		t.Error("expected no hooks by default")
+	}
+}
+
+func TestDiscoveryConfig(t *testing.T) {
+	tests := map[string]struct {
+		yaml string
+		env  map[string]string
+		want DiscoveryConfig
+	}{
+		"defaults": {
+			want: DiscoveryConfig{Ignore: parser.DefaultIgnore, MaxDepth: parser.DefaultMaxDepth, MaxFiles: parser.DefaultMaxFiles},
+		},
+		"file replaces ignore list": {
+			yaml: "discovery:\n  ignore: [node_modules, build*]\n  max_depth: 4\n",
+			want: DiscoveryConfig{Ignore: []string{"node_modules", "build*"}, MaxDepth: 4, MaxFiles: parser.DefaultMaxFiles},
+		},
+		"environment override": {
+			env: map[string]string{
+				"SKILLSYNC_DISCOVERY_IGNORE":    "dist, .cache",
+				"SKILLSYNC_DISCOVERY_MAX_DEPTH": "0",
+				"SKILLSYNC_DISCOVERY_MAX_FILES": "100",
+			},
+			want: DiscoveryConfig{Ignore: []string{"dist", ".cache"}, MaxDepth: 0, MaxFiles: 100},
+		},
+		"invalid environment ignored": {
+			env:  map[string]string{"SKILLSYNC_DISCOVERY_MAX_FILES": "-1"},
+			want: DiscoveryConfig{Ignore: parser.DefaultIgnore, MaxDepth: parser.DefaultMaxDepth, MaxFiles: parser.DefaultMaxFiles},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg := Default()
+			if tt.yaml != "" {
+				if err := yaml.Unmarshal([]byte(tt.yaml), cfg); err != nil {
+					t.Fatalf("failed to parse config: %v", err)
+				}
+			}
+			cfg.applyEnvironment()
+
+			if !reflect.DeepEqual(cfg.Discovery, tt.want) {
+				t.Errorf("Discovery = %+v, want %+v", cfg.Discovery, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffConfig(t *testing.T) {
+	tests := map[string]struct {
+		yaml    string
+		env     map[string]string
+		want    sync.DiffOptions
+		wantErr bool
+	}{
+		"defaults": {
+			want: sync.DefaultDiffOptions(),
+		},
+		"file": {
+			yaml: "diff:\n  algorithm: histogram\n  context: 1\n",
+			want: sync.DiffOptions{Algorithm: sync.DiffHistogram, Context: 1},
+		},
+		"environment override": {
+			yaml: "diff:\n  algorithm: histogram\n",
+			env:  map[string]string{"SKILLSYNC_DIFF_ALGORITHM": "patience", "SKILLSYNC_DIFF_CONTEXT": "0"},
+			want: sync.DiffOptions{Algorithm: sync.DiffPatience, Context: 0},
+		},
+		"unknown algorithm": {
+			yaml:    "diff:\n  algorithm: minimal\n",
+			wantErr: true,
+		},
+		"negative context": {
+			yaml:    "diff:\n  context: -2\n",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg := Default()
+			if tt.yaml != "" {
+				if err := yaml.Unmarshal([]byte(tt.yaml), cfg); err != nil {
+					t.Fatalf("failed to parse config: %v", err)
+				}
+			}
+			cfg.applyEnvironment()
+
+			got, err := cfg.Diff.Options()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Options() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Options() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmationConfig(t *testing.T) {
+	tests := map[string]struct {
+		yaml   string
+		env    map[string]string
+		counts map[int]bool
+	}{
+		"defaults": {
+			counts: map[int]bool{1: false, 10: false, 11: true},
+		},
+		"file threshold": {
+			yaml:   "confirmation:\n  threshold: 3\n",
+			counts: map[int]bool{3: false, 4: true},
+		},
+		"always": {
+			yaml:   "confirmation:\n  typed: always\n",
+			counts: map[int]bool{1: true, 50: true},
+		},
+		"environment override": {
+			yaml:   "confirmation:\n  typed: always\n",
+			env:    map[string]string{"SKILLSYNC_CONFIRMATION_TYPED": "never"},
+			counts: map[int]bool{1: false, 50: false},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg := Default()
+			if tt.yaml != "" {
+				if err := yaml.Unmarshal([]byte(tt.yaml), cfg); err != nil {
+					t.Fatalf("failed to parse config: %v", err)
+				}
+			}
+			cfg.applyEnvironment()
+
+			for count, want := range tt.counts {
+				if got := cfg.Confirmation.RequiresTyped(count); got != want {
+					t.Errorf("RequiresTyped(%d) = %v, want %v", count, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlatformMaxSkills(t *testing.T) {
+	tests := map[string]struct {
+		yaml string
+		env  map[string]string
+		want map[model.Platform]int
+	}{
+		"defaults": {
+			want: map[model.Platform]int{model.ClaudeCode: 100, model.Cursor: 50, model.Codex: 50, model.Aider: 25},
+		},
+		"file": {
+			yaml: "platforms:\n  cursor:\n    max_skills: 80\n  aider:\n    max_skills: 0\n",
+			want: map[model.Platform]int{model.ClaudeCode: 100, model.Cursor: 80, model.Codex: 50, model.Aider: 0},
+		},
+		"environment override": {
+			yaml: "platforms:\n  cursor:\n    max_skills: 80\n",
+			env:  map[string]string{"SKILLSYNC_CURSOR_MAX_SKILLS": "10", "SKILLSYNC_CODEX_MAX_SKILLS": "many"},
+			want: map[model.Platform]int{model.ClaudeCode: 100, model.Cursor: 10, model.Codex: 50, model.Aider: 25},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg := Default()
+			if tt.yaml != "" {
+				if err := yaml.Unmarshal([]byte(tt.yaml), cfg); err != nil {
+					t.Fatalf("failed to parse config: %v", err)
+				}
+			}
+			cfg.applyEnvironment()
+
+			for platform, want := range tt.want {
+				platformConfig, ok := cfg.Platforms.Platform(platform)
+				if !ok {
+					t.Fatalf("Platform(%s) not found", platform)
+				}
+				if platformConfig.MaxSkills != want {
+					t.Errorf("%s MaxSkills = %d, want %d", platform, platformConfig.MaxSkills, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCustomPlatforms(t *testing.T) {
+	tests := map[string]struct {
+		yaml      string
+		wantPaths []string
+		wantErr   string
+	}{
+		"default paths": {
+			yaml:      "platforms:\n  custom:\n    - name: windsurf\n      command: windsurf-skills --json\n",
+			wantPaths: []string{".windsurf/skills", "~/.windsurf/skills"},
+		},
+		"configured paths": {
+			yaml:      "platforms:\n  custom:\n    - name: windsurf\n      command: windsurf-skills\n      skills_paths: [.windsurf/rules]\n      max_skills: 5\n",
+			wantPaths: []string{".windsurf/rules"},
+		},
+		"built-in name": {
+			yaml:    "platforms:\n  custom:\n    - name: cursor\n      command: cursor-skills\n",
+			wantErr: "has the name of a built-in platform",
+		},
+		"missing command": {
+			yaml:    "platforms:\n  custom:\n    - name: windsurf\n",
+			wantErr: "requires a command",
+		},
+		"invalid template": {
+			yaml:    "platforms:\n  custom:\n    - name: windsurf\n      command: windsurf-skills\n      write_template: \"{{.Name\"\n",
+			wantErr: "invalid write_template",
+		},
+		"duplicate": {
+			yaml:    "platforms:\n  custom:\n    - name: windsurf\n      command: a\n    - name: windsurf\n      command: b\n",
+			wantErr: "defined more than once",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := Default()
+			if err := yaml.Unmarshal([]byte(tt.yaml), cfg); err != nil {
+				t.Fatalf("failed to parse config: %v", err)
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+
+			platformConfig, ok := cfg.Platforms.Platform("windsurf")
+			if !ok {
+				t.Fatal("Platform(windsurf) not found")
+			}
+			util.AssertEqual(t, strings.Join(platformConfig.SkillsPaths, ","), strings.Join(tt.wantPaths, ","))
+			util.AssertEqual(t, cfg.Platforms.CustomDefinitions()[0].Command[0], "windsurf-skills")
+		})
+	}
+}
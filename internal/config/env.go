@@ -0,0 +1,252 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Source is where the effective value of a setting comes from.
+type Source string
+
+// Setting sources, from lowest to highest precedence.
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceProject Source = "project"
+	SourceEnv     Source = "env"
+)
+
+// rank orders sources by precedence.
+func (s Source) rank() int {
+	switch s {
+	case SourceEnv:
+		return 3
+	case SourceProject:
+		return 2
+	case SourceFile:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// EnvVar is an environment variable that overrides a config setting.
+type EnvVar struct {
+	// Name is the variable name, e.g. SKILLSYNC_SYNC_STRATEGY
+	Name string
+	// Key is the dot-separated config key it overrides
+	Key string
+	// Sep separates the items of list and map settings
+	Sep string
+	// Deprecated marks variables kept only for backward compatibility
+	Deprecated bool
+}
+
+// envVars lists the environment overrides in the order they are applied.
+// Values that do not parse, or are out of range, are ignored.
+var envVars = []EnvVar{
+	{Name: "SKILLSYNC_SYNC_STRATEGY", Key: "sync.default_strategy"},
+	{Name: "SKILLSYNC_SYNC_SCOPE_STRATEGIES", Key: "sync.scope_strategies", Sep: ","},
+	{Name: "SKILLSYNC_SYNC_INCLUDE_TYPES", Key: "sync.include_types", Sep: ","},
+	{Name: "SKILLSYNC_SYNC_TRASH_RETENTION_DAYS", Key: "sync.trash_retention_days"},
+	{Name: "SKILLSYNC_SYNC_METRICS", Key: "sync.metrics"},
+	{Name: "SKILLSYNC_SYNC_METRICS_ADDR", Key: "sync.metrics_addr"},
+	{Name: "SKILLSYNC_SYNC_WRITABLE_SCOPES", Key: "sync.writable_scopes", Sep: ","},
+	{Name: "SKILLSYNC_SYNC_RENAME_DETECTION_ENABLED", Key: "sync.rename_detection.enabled"},
+	{Name: "SKILLSYNC_SYNC_RENAME_DETECTION_THRESHOLD", Key: "sync.rename_detection.threshold"},
+	{Name: "SKILLSYNC_VALIDATION_SCHEMA_PATH", Key: "validation.schema_path"},
+	{Name: "SKILLSYNC_OUTPUT_COLOR", Key: "output.color"},
+	{Name: "SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", Key: "platforms.claude_code.skills_paths", Sep: ":"},
+	{Name: "SKILLSYNC_CURSOR_SKILLS_PATHS", Key: "platforms.cursor.skills_paths", Sep: ":"},
+	{Name: "SKILLSYNC_CODEX_SKILLS_PATHS", Key: "platforms.codex.skills_paths", Sep: ":"},
+	{Name: "SKILLSYNC_AIDER_SKILLS_PATHS", Key: "platforms.aider.skills_paths", Sep: ":"},
+	{Name: "SKILLSYNC_CLAUDE_CODE_MAX_SKILLS", Key: "platforms.claude_code.max_skills"},
+	{Name: "SKILLSYNC_CURSOR_MAX_SKILLS", Key: "platforms.cursor.max_skills"},
+	{Name: "SKILLSYNC_CODEX_MAX_SKILLS", Key: "platforms.codex.max_skills"},
+	{Name: "SKILLSYNC_AIDER_MAX_SKILLS", Key: "platforms.aider.max_skills"},
+	{Name: "SKILLSYNC_CLAUDE_CODE_PATH", Key: "platforms.claude_code.skills_path", Deprecated: true},
+	{Name: "SKILLSYNC_CURSOR_PATH", Key: "platforms.cursor.skills_path", Deprecated: true},
+	{Name: "SKILLSYNC_CODEX_PATH", Key: "platforms.codex.skills_path", Deprecated: true},
+	{Name: "SKILLSYNC_AIDER_PATH", Key: "platforms.aider.skills_path", Deprecated: true},
+	{Name: "SKILLSYNC_DISCOVERY_IGNORE", Key: "discovery.ignore", Sep: ","},
+	{Name: "SKILLSYNC_DISCOVERY_MAX_DEPTH", Key: "discovery.max_depth"},
+	{Name: "SKILLSYNC_DISCOVERY_MAX_FILES", Key: "discovery.max_files"},
+	{Name: "SKILLSYNC_DISCOVERY_REPO_CACHE", Key: "discovery.repo_cache"},
+	{Name: "SKILLSYNC_CACHE_ENABLED", Key: "cache.enabled"},
+	{Name: "SKILLSYNC_CACHE_SKILLS_TTL_MINUTES", Key: "cache.skills_ttl_minutes"},
+	{Name: "SKILLSYNC_CACHE_PLUGINS_TTL_MINUTES", Key: "cache.plugins_ttl_minutes"},
+	{Name: "SKILLSYNC_CACHE_INCREMENTAL", Key: "cache.incremental"},
+	{Name: "SKILLSYNC_DIFF_ALGORITHM", Key: "diff.algorithm"},
+	{Name: "SKILLSYNC_DIFF_CONTEXT", Key: "diff.context"},
+	{Name: "SKILLSYNC_CONFIRMATION_TYPED", Key: "confirmation.typed"},
+	{Name: "SKILLSYNC_CONFIRMATION_THRESHOLD", Key: "confirmation.threshold"},
+	{Name: "SKILLSYNC_UPDATE_CHANNEL", Key: "update.channel"},
+	{Name: "SKILLSYNC_LOG_LEVEL", Key: "log.level"},
+	{Name: "SKILLSYNC_LOG_FORMAT", Key: "log.format"},
+	{Name: "SKILLSYNC_LOG_FILE", Key: "log.file"},
+	{Name: "SKILLSYNC_BACKUP_MAX_AGE_DAYS", Key: "backup.max_age_days"},
+	{Name: "SKILLSYNC_BACKUP_MAX_COUNT", Key: "backup.max_count"},
+	{Name: "SKILLSYNC_BACKUP_MAX_SIZE_MB", Key: "backup.max_size_mb"},
+	{Name: "SKILLSYNC_WORKSPACE_REPOS", Key: "workspace.repos", Sep: ":"},
+	{Name: "SKILLSYNC_SIMILARITY_NAME_THRESHOLD", Key: "similarity.name_threshold"},
+	{Name: "SKILLSYNC_SIMILARITY_CONTENT_THRESHOLD", Key: "similarity.content_threshold"},
+	{Name: "SKILLSYNC_SIMILARITY_ALGORITHM", Key: "similarity.algorithm"},
+	{Name: "SKILLSYNC_SIMILARITY_EMBEDDINGS_PROVIDER", Key: "similarity.embeddings.provider"},
+}
+
+// EnvVars returns the environment variables that override config settings.
+func EnvVars() []EnvVar {
+	return slices.Clone(envVars)
+}
+
+// applyEnvironment applies environment variable overrides.
+// Environment variables follow the pattern SKILLSYNC_<SECTION>_<KEY>.
+func (c *Config) applyEnvironment() {
+	for _, env := range envVars {
+		v := os.Getenv(env.Name)
+		if v == "" {
+			continue
+		}
+		field, err := c.field(env.Key)
+		if err != nil {
+			panic(fmt.Sprintf("config: %s overrides unknown key: %v", env.Name, err))
+		}
+		if env.parse(field, v) {
+			c.setSource(env.Key, SourceEnv)
+		}
+	}
+}
+
+// parse sets field from the variable's value, reporting whether the value
+// was valid. Numbers must not be negative and float settings, which are all
+// thresholds, must be between 0 and 1.
+func (e EnvVar) parse(field reflect.Value, v string) bool {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(v)
+	case reflect.Int:
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return false
+		}
+		field.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || f > 1 {
+			return false
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, ok := parseBool(v)
+		if !ok {
+			return false
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		items := reflect.MakeSlice(field.Type(), 0, 0)
+		for item := range strings.SplitSeq(v, e.Sep) {
+			if item = strings.TrimSpace(item); item != "" {
+				items = reflect.Append(items, reflect.ValueOf(item))
+			}
+		}
+		field.Set(items)
+	case reflect.Map:
+		entries := reflect.MakeMap(field.Type())
+		for pair := range strings.SplitSeq(v, e.Sep) {
+			key, value, ok := strings.Cut(pair, "=")
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			if ok && key != "" && value != "" {
+				entries.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(value))
+			}
+		}
+		field.Set(entries)
+	default:
+		return false
+	}
+	return true
+}
+
+// Format returns the effective value of the variable's setting in c, in the
+// form the variable accepts.
+func (e EnvVar) Format(c *Config) string {
+	field, err := c.field(e.Key)
+	if err != nil {
+		return ""
+	}
+	switch field.Kind() {
+	case reflect.Slice:
+		items := make([]string, field.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(field.Index(i).Interface())
+		}
+		return strings.Join(items, e.Sep)
+	case reflect.Map:
+		keys := make([]string, 0, field.Len())
+		for _, key := range field.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		slices.Sort(keys)
+		pairs := make([]string, len(keys))
+		for i, key := range keys {
+			pairs[i] = fmt.Sprintf("%s=%v", key, field.MapIndex(reflect.ValueOf(key)).Interface())
+		}
+		return strings.Join(pairs, e.Sep)
+	default:
+		return fmt.Sprint(field.Interface())
+	}
+}
+
+// Source returns where the effective value of key comes from: the
+// environment, the project config, the config file, or the defaults. A section comes from the
+// highest-precedence source of any setting in it.
+func (c *Config) Source(key string) Source {
+	source := SourceDefault
+	for set, from := range c.sources {
+		related := set == key || strings.HasPrefix(set, key+".") || strings.HasPrefix(key, set+".")
+		if related && from.rank() > source.rank() {
+			source = from
+		}
+	}
+	return source
+}
+
+// setSource records where the setting at key came from.
+func (c *Config) setSource(key string, source Source) {
+	if c.sources == nil {
+		c.sources = make(map[string]Source)
+	}
+	c.sources[key] = source
+}
+
+// recordFileKeys records the settings present in config file data as coming
+// from source.
+func (c *Config) recordFileKeys(data []byte, source Source) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return
+	}
+	c.recordFileNode(doc.Content[0], "", source)
+}
+
+// recordFileNode records the leaf values below node, whose key is prefix.
+func (c *Config) recordFileNode(node *yaml.Node, prefix string, source Source) {
+	if node.Kind != yaml.MappingNode {
+		if prefix != "" {
+			c.setSource(prefix, source)
+		}
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		c.recordFileNode(node.Content[i+1], key, source)
+	}
+}
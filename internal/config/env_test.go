@@ -0,0 +1,63 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestConfigSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	util.WriteFile(t, path, "sync:\n  default_strategy: newer\n  scope_strategies:\n    repo: three-way\ndiff:\n  context: 1\n")
+	t.Setenv("SKILLSYNC_DIFF_CONTEXT", "5")
+	t.Setenv("SKILLSYNC_DIFF_ALGORITHM", "patience")
+	t.Setenv("SKILLSYNC_DISCOVERY_MAX_DEPTH", "-1") // invalid, so ignored
+
+	cfg, err := LoadFromPath(path)
+	util.AssertNoError(t, err)
+
+	tests := map[string]struct {
+		key  string
+		want Source
+	}{
+		"default":                          {key: "output.color", want: SourceDefault},
+		"file":                             {key: "sync.default_strategy", want: SourceFile},
+		"file map entry":                   {key: "sync.scope_strategies.repo", want: SourceFile},
+		"map set in file":                  {key: "sync.scope_strategies", want: SourceFile},
+		"env overrides file":               {key: "diff.context", want: SourceEnv},
+		"env":                              {key: "diff.algorithm", want: SourceEnv},
+		"invalid env is ignored":           {key: "discovery.max_depth", want: SourceDefault},
+		"section takes the highest source": {key: "diff", want: SourceEnv},
+		"section from file":                {key: "sync", want: SourceFile},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, cfg.Source(tt.key), tt.want)
+		})
+	}
+}
+
+func TestEnvVarsRoundTrip(t *testing.T) {
+	// Every variable names a real setting, and setting it to its formatted
+	// default leaves the config unchanged
+	for _, env := range EnvVars() {
+		t.Run(env.Name, func(t *testing.T) {
+			want := Default()
+			if _, err := want.field(env.Key); err != nil {
+				t.Fatalf("%s overrides %q: %v", env.Name, env.Key, err)
+			}
+			value := env.Format(want)
+			if value == "" {
+				return
+			}
+
+			t.Setenv(env.Name, value)
+			got := Default()
+			got.applyEnvironment()
+			util.AssertEqual(t, env.Format(got), value)
+			util.AssertEqual(t, got.Source(env.Key), SourceEnv)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// interpolateEnv expands ${VAR} references in s from the environment.
+// ${VAR:-default} falls back to default when VAR is unset or empty. A
+// reference to an unset variable without a default is an error, so a path
+// never silently loses a component. A $ not followed by { is kept as is.
+func interpolateEnv(s string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	rest := s
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			sb.WriteString(rest)
+			return sb.String(), nil
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable reference in %q", s)
+		}
+		sb.WriteString(rest[:start])
+		ref := rest[start+2 : start+end]
+		rest = rest[start+end+1:]
+
+		name, fallback, hasDefault := strings.Cut(ref, ":-")
+		if !validEnvName(name) {
+			return "", fmt.Errorf("invalid variable name %q in %q", name, s)
+		}
+		value, ok := os.LookupEnv(name)
+		switch {
+		case value != "":
+			sb.WriteString(value)
+		case hasDefault:
+			sb.WriteString(fallback)
+		case !ok:
+			return "", fmt.Errorf("environment variable %s is not set (referenced in %q; use ${%s:-default} for a fallback)", name, s, name)
+		}
+	}
+}
+
+// validEnvName reports whether name is a shell-style variable name.
+func validEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// interpolatePaths expands environment variable references in the path
+// settings of a config file: platform skills paths, the frontmatter schema,
+// and workspace repositories. Errors name the setting holding the reference.
+func (c *Config) interpolatePaths() error {
+	expand := func(key string, value *string) error {
+		expanded, err := interpolateEnv(*value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		*value = expanded
+		return nil
+	}
+	expandAll := func(key string, values []string) error {
+		for i := range values {
+			if err := expand(fmt.Sprintf("%s[%d]", key, i), &values[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	platforms := []struct {
+		key    string
+		config *PlatformConfig
+	}{
+		{"claude_code", &c.Platforms.ClaudeCode},
+		{"cursor", &c.Platforms.Cursor},
+		{"codex", &c.Platforms.Codex},
+		{"aider", &c.Platforms.Aider},
+	}
+	for _, p := range platforms {
+		prefix := "platforms." + p.key
+		if err := expandAll(prefix+".skills_paths", p.config.SkillsPaths); err != nil {
+			return err
+		}
+		if err := expand(prefix+".skills_path", &p.config.SkillsPath); err != nil { //nolint:staticcheck // backward compatibility
+			return err
+		}
+	}
+	if err := expand("validation.schema_path", &c.Validation.SchemaPath); err != nil {
+		return err
+	}
+	if err := expand("log.file", &c.Log.File); err != nil {
+		return err
+	}
+	return expandAll("workspace.repos", c.Workspace.Repos)
+}
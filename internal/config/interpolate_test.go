@@ -0,0 +1,87 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestInterpolateEnv(t *testing.T) {
+	t.Setenv("WORKSPACE_ROOT", "/srv/work")
+	t.Setenv("EMPTY_VAR", "")
+
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr string
+	}{
+		"no references":       {input: "~/.claude/skills", want: "~/.claude/skills"},
+		"variable":            {input: "${WORKSPACE_ROOT}/.claude/skills", want: "/srv/work/.claude/skills"},
+		"two variables":       {input: "${WORKSPACE_ROOT}/${WORKSPACE_ROOT}", want: "/srv/work//srv/work"},
+		"default unused":      {input: "${WORKSPACE_ROOT:-/tmp}/skills", want: "/srv/work/skills"},
+		"default for unset":   {input: "${SKILLSYNC_TEST_UNSET:-~/work}/skills", want: "~/work/skills"},
+		"default for empty":   {input: "${EMPTY_VAR:-/tmp}/skills", want: "/tmp/skills"},
+		"empty without one":   {input: "${EMPTY_VAR}/skills", want: "/skills"},
+		"bare dollar kept":    {input: "/skills/$HOME", want: "/skills/$HOME"},
+		"undefined":           {input: "${SKILLSYNC_TEST_UNSET}/skills", wantErr: "environment variable SKILLSYNC_TEST_UNSET is not set"},
+		"unterminated":        {input: "${WORKSPACE_ROOT/skills", wantErr: "unterminated variable reference"},
+		"invalid name":        {input: "${1ROOT}/skills", wantErr: `invalid variable name "1ROOT"`},
+		"empty name":          {input: "${}/skills", wantErr: `invalid variable name ""`},
+		"default without var": {input: "${:-/tmp}", wantErr: "invalid variable name"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := interpolateEnv(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("interpolateEnv(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestLoad_InterpolatesPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", home)
+	t.Chdir(t.TempDir())
+	t.Setenv("WORKSPACE_ROOT", "/srv/work")
+	config := `platforms:
+  claude_code:
+    skills_paths: ["${WORKSPACE_ROOT}/.claude/skills", "~/.claude/skills"]
+validation:
+  schema_path: ${WORKSPACE_ROOT}/schema.json
+workspace:
+  repos: ["${WORKSPACE_ROOT}/api"]
+`
+	util.WriteFile(t, filepath.Join(home, configFileName), config)
+
+	cfg, err := Load()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, cfg.Platforms.ClaudeCode.SkillsPaths[0], "/srv/work/.claude/skills")
+	util.AssertEqual(t, cfg.Platforms.ClaudeCode.SkillsPaths[1], "~/.claude/skills")
+	util.AssertEqual(t, cfg.FrontmatterSchemaPath(), "/srv/work/schema.json")
+	util.AssertEqual(t, strings.Join(cfg.WorkspaceRepos(), ","), "/srv/work/api")
+
+	t.Run("LoadUser keeps references", func(t *testing.T) {
+		cfg, err := LoadUser()
+		util.AssertNoError(t, err)
+		util.AssertEqual(t, cfg.Platforms.ClaudeCode.SkillsPaths[0], "${WORKSPACE_ROOT}/.claude/skills")
+	})
+
+	t.Run("undefined variable", func(t *testing.T) {
+		t.Setenv("WORKSPACE_ROOT", "")
+		util.WriteFile(t, filepath.Join(home, configFileName), strings.ReplaceAll(config, "WORKSPACE_ROOT", "SKILLSYNC_TEST_UNSET"))
+		_, err := Load()
+		want := "platforms.claude_code.skills_paths[0]: environment variable SKILLSYNC_TEST_UNSET is not set"
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("Load() error = %v, want %q", err, want)
+		}
+	})
+}
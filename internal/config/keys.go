@@ -0,0 +1,340 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/selfupdate"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// Get returns the setting at a dot-separated key such as
+// "sync.default_strategy" or "sync.scope_strategies.repo". Keys are the YAML
+// names of the config file; a key naming a section returns the whole section.
+func (c *Config) Get(key string) (any, error) {
+	v, err := c.value(key)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+// field returns the settable value of the setting at key.
+func (c *Config) field(key string) (reflect.Value, error) {
+	v, err := c.value(key)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if !v.CanSet() {
+		return reflect.Value{}, fmt.Errorf("config key %q cannot be set directly", key)
+	}
+	return v, nil
+}
+
+// value returns the value of the setting at key.
+func (c *Config) value(key string) (reflect.Value, error) {
+	v := reflect.ValueOf(c).Elem()
+	parts, err := splitKey(key)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	for i, part := range parts {
+		path := strings.Join(parts[:i], ".")
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByYAMLName(v.Type(), part)
+			if !ok {
+				return reflect.Value{}, unknownKeyError(key, path, v.Type())
+			}
+			v = v.FieldByIndex(field.Index)
+		case reflect.Map:
+			elem := v.MapIndex(reflect.ValueOf(part))
+			if !elem.IsValid() {
+				return reflect.Value{}, fmt.Errorf("config key %q is not set", key)
+			}
+			v = elem
+		default:
+			return reflect.Value{}, fmt.Errorf("unknown config key %q: %s is not a section", key, path)
+		}
+	}
+	return v, nil
+}
+
+// SetInFile sets the dot-separated key to value in the config file at path,
+// creating the file if needed. Only that key changes; the rest of the file,
+// including comments, is kept as written. value is parsed as YAML into the
+// key's type (so "[a, b]" is a list); list keys also accept comma-separated
+// values. The result must load and pass Validate, or the file is left alone.
+func SetInFile(path, key, value string) error {
+	parts, err := splitKey(key)
+	if err != nil {
+		return err
+	}
+	typ, err := keyType(key, parts)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseValue(typ, value)
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+
+	// #nosec G304 - path is the config file path provided by the caller
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to parse %s: top level is not a mapping", path)
+	}
+
+	var node yaml.Node
+	if err := node.Encode(parsed); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	setNode(root, parts, &node)
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return err
+	}
+	cfg := Default()
+	if err := yaml.Unmarshal(out, cfg); err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if err := util.EnsureDataDir(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	// #nosec G306 - config file should be readable by user
+	return os.WriteFile(path, out, 0o644)
+}
+
+// Validate reports settings that parse but cannot be used, such as unknown
+// strategies or thresholds outside 0-1.
+func (c *Config) Validate() error {
+	var errs []error
+	if s := c.Sync.DefaultStrategy; s != "" && !sync.Strategy(s).IsValid() {
+		errs = append(errs, fmt.Errorf("sync.default_strategy: invalid strategy %q", s))
+	}
+	for scope, s := range c.Sync.ScopeStrategies {
+		if !sync.Strategy(s).IsValid() {
+			errs = append(errs, fmt.Errorf("sync.scope_strategies.%s: invalid strategy %q", scope, s))
+		}
+	}
+	if err := validateWritableScopes(c.Sync.WritableScopes); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateResolutionPreferences(c.Sync.ResolutionPreferences); err != nil {
+		errs = append(errs, err)
+	}
+	for name, profile := range c.Profiles {
+		if profile.Source == "" || profile.Target == "" {
+			errs = append(errs, fmt.Errorf("profiles.%s: source and target are required", name))
+		}
+		if s := profile.Strategy; s != "" && !sync.Strategy(s).IsValid() {
+			errs = append(errs, fmt.Errorf("profiles.%s.strategy: invalid strategy %q", name, s))
+		}
+		if _, ok := c.Collections[profile.Collection]; profile.Collection != "" && !ok {
+			errs = append(errs, fmt.Errorf("profiles.%s.collection: unknown collection %q", name, profile.Collection))
+		}
+	}
+	if err := c.validateCollections(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Sync.TrashRetentionDays < 0 {
+		errs = append(errs, errors.New("sync.trash_retention_days: must not be negative"))
+	}
+	if addr := c.Sync.MetricsAddr; addr != "" {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			errs = append(errs, fmt.Errorf("sync.metrics_addr: %w", err))
+		}
+	}
+	if color := c.Output.Color; color != "" && !slices.Contains([]string{"auto", "always", "never"}, color) {
+		errs = append(errs, fmt.Errorf("output.color: invalid value %q (valid: auto, always, never)", color))
+	}
+	for name, threshold := range map[string]float64{
+		"similarity.name_threshold":       c.Similarity.NameThreshold,
+		"similarity.content_threshold":    c.Similarity.ContentThreshold,
+		"sync.rename_detection.threshold": c.Sync.RenameDetection.Threshold,
+	} {
+		if threshold < 0 || threshold > 1 {
+			errs = append(errs, fmt.Errorf("%s: %v is not between 0 and 1", name, threshold))
+		}
+	}
+	if _, err := c.Diff.Options(); err != nil {
+		errs = append(errs, fmt.Errorf("diff: %w", err))
+	}
+	if typed := c.Confirmation.Typed; typed != "" && !slices.Contains([]string{TypedConfirmationAuto, TypedConfirmationAlways, TypedConfirmationNever}, typed) {
+		errs = append(errs, fmt.Errorf("confirmation.typed: invalid value %q (valid: auto, always, never)", typed))
+	}
+	if c.Confirmation.Threshold < 0 {
+		errs = append(errs, errors.New("confirmation.threshold: must not be negative"))
+	}
+	if _, err := selfupdate.ParseChannel(c.Update.Channel); err != nil {
+		errs = append(errs, fmt.Errorf("update.channel: %w", err))
+	}
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+	if _, err := logging.ParseFormat(c.Log.Format); err != nil {
+		errs = append(errs, fmt.Errorf("log.format: %w", err))
+	}
+	if c.Backup.MaxAgeDays < 0 || c.Backup.MaxCount < 0 || c.Backup.MaxSizeMB < 0 {
+		errs = append(errs, errors.New("backup: retention limits must not be negative"))
+	}
+	if c.Cache.SkillsTTLMinutes < 0 || c.Cache.PluginsTTLMinutes < 0 {
+		errs = append(errs, errors.New("cache: TTLs must not be negative"))
+	}
+	seenCustom := make(map[string]bool)
+	for _, custom := range c.Platforms.Custom {
+		if err := custom.Definition().Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("platforms.custom: %w", err))
+		} else if seenCustom[custom.Name] {
+			errs = append(errs, fmt.Errorf("platforms.custom: platform %q is defined more than once", custom.Name))
+		}
+		seenCustom[custom.Name] = true
+	}
+	if c.Discovery.MaxDepth < 0 || c.Discovery.MaxFiles < 0 {
+		errs = append(errs, errors.New("discovery: limits must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// splitKey splits a dot-separated config key into its parts.
+func splitKey(key string) ([]string, error) {
+	parts := strings.Split(key, ".")
+	if slices.Contains(parts, "") {
+		return nil, fmt.Errorf("invalid config key %q", key)
+	}
+	return parts, nil
+}
+
+// keyType returns the Go type of the setting at key.
+func keyType(key string, parts []string) (reflect.Type, error) {
+	t := reflect.TypeFor[Config]()
+	for i, part := range parts {
+		path := strings.Join(parts[:i], ".")
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByYAMLName(t, part)
+			if !ok {
+				return nil, unknownKeyError(key, path, t)
+			}
+			t = field.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return nil, fmt.Errorf("unknown config key %q: %s is not a section", key, path)
+		}
+	}
+	return t, nil
+}
+
+// parseValue parses a command-line value into a value of type t.
+func parseValue(t reflect.Type, value string) (any, error) {
+	ptr := reflect.New(t)
+	switch {
+	case t.Kind() == reflect.String:
+		ptr.Elem().SetString(value)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String && !strings.HasPrefix(strings.TrimSpace(value), "["):
+		items := reflect.MakeSlice(t, 0, 0)
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = reflect.Append(items, reflect.ValueOf(item).Convert(t.Elem()))
+			}
+		}
+		ptr.Elem().Set(items)
+	default:
+		if err := yaml.Unmarshal([]byte(value), ptr.Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+// setNode sets the value at parts below the mapping node m, adding mappings
+// for missing sections.
+func setNode(m *yaml.Node, parts []string, value *yaml.Node) {
+	for i := 0; i < len(m.Content); i += 2 {
+		if m.Content[i].Value != parts[0] {
+			continue
+		}
+		if len(parts) == 1 {
+			old := m.Content[i+1]
+			value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			m.Content[i+1] = value
+			return
+		}
+		if m.Content[i+1].Kind != yaml.MappingNode {
+			m.Content[i+1] = &yaml.Node{Kind: yaml.MappingNode}
+		}
+		setNode(m.Content[i+1], parts[1:], value)
+		return
+	}
+
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: parts[0]}
+	if len(parts) == 1 {
+		m.Content = append(m.Content, keyNode, value)
+		return
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	m.Content = append(m.Content, keyNode, child)
+	setNode(child, parts[1:], value)
+}
+
+// fieldByYAMLName returns the field of struct type t with the given YAML name.
+func fieldByYAMLName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for _, field := range reflect.VisibleFields(t) {
+		if field.IsExported() && name != "-" && yamlName(field) == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// yamlName returns the YAML name of a struct field, or "-" if it is not
+// serialized.
+func yamlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// unknownKeyError reports an unknown key below section, listing the keys the
+// section does have.
+func unknownKeyError(key, section string, t reflect.Type) error {
+	var names []string
+	for _, field := range reflect.VisibleFields(t) {
+		if name := yamlName(field); field.IsExported() && name != "-" {
+			names = append(names, name)
+		}
+	}
+	if section == "" {
+		section = "the config"
+	}
+	return fmt.Errorf("unknown config key %q (%s has: %s)", key, section, strings.Join(names, ", "))
+}
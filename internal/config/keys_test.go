@@ -0,0 +1,189 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestConfigGet(t *testing.T) {
+	cfg := Default()
+	cfg.Sync.ScopeStrategies = map[string]string{"repo": "three-way"}
+
+	tests := map[string]struct {
+		key     string
+		want    any
+		wantErr string
+	}{
+		"scalar":          {key: "sync.default_strategy", want: "overwrite"},
+		"nested scalar":   {key: "platforms.cursor.max_skills", want: 50},
+		"map entry":       {key: "sync.scope_strategies.repo", want: "three-way"},
+		"section":         {key: "diff", want: DiffConfig{Algorithm: "myers", Context: 3}},
+		"unset map entry": {key: "sync.scope_strategies.user", wantErr: "is not set"},
+		"unknown key":     {key: "sync.strategy", wantErr: "sync has: default_strategy"},
+		"unknown section": {key: "backup.location", wantErr: "backup has: max_age_days, max_count, max_size_mb, lineage_limits"},
+		"below a scalar":  {key: "sync.default_strategy.name", wantErr: "is not a section"},
+		"empty part":      {key: "sync..metrics", wantErr: "invalid config key"},
+		"ignored field":   {key: "hooks.-", wantErr: "unknown config key"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := cfg.Get(tt.key)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Get(%q) error = %v, want %q", tt.key, err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get(%q) = %#v, want %#v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetInFile(t *testing.T) {
+	const existing = "# my settings\nsync:\n    default_strategy: overwrite # team default\noutput:\n    color: auto\n"
+
+	tests := map[string]struct {
+		existing  string
+		key       string
+		value     string
+		want      string // expected excerpt of the written file
+		wantValue any
+		wantErr   string
+	}{
+		"replace keeps comments and other keys": {
+			existing:  existing,
+			key:       "sync.default_strategy",
+			value:     "newer",
+			want:      "# my settings\nsync:\n    default_strategy: newer # team default\noutput:\n    color: auto\n",
+			wantValue: "newer",
+		},
+		"adds missing sections": {
+			existing:  existing,
+			key:       "sync.scope_strategies.repo",
+			value:     "three-way",
+			want:      "    scope_strategies:\n        repo: three-way\n",
+			wantValue: "three-way",
+		},
+		"creates the file": {
+			key:       "platforms.cursor.max_skills",
+			value:     "80",
+			want:      "platforms:\n    cursor:\n        max_skills: 80\n",
+			wantValue: 80,
+		},
+		"comma-separated list": {
+			key:       "workspace.repos",
+			value:     "~/src/api, ~/src/web",
+			want:      "workspace:\n    repos:\n        - ~/src/api\n        - ~/src/web\n",
+			wantValue: []string{"~/src/api", "~/src/web"},
+		},
+		"flow list": {
+			key:       "discovery.ignore",
+			value:     "[node_modules, .git]",
+			want:      "discovery:\n    ignore:\n        - node_modules\n        - .git\n",
+			wantValue: []string{"node_modules", ".git"},
+		},
+		"string that looks like a bool": {
+			key:       "similarity.algorithm",
+			value:     "true",
+			want:      "similarity:\n    algorithm: \"true\"\n",
+			wantValue: "true",
+		},
+		"wrong type": {
+			existing: existing,
+			key:      "platforms.cursor.max_skills",
+			value:    "many",
+			wantErr:  "invalid value \"many\"",
+		},
+		"invalid strategy": {
+			existing: existing,
+			key:      "sync.default_strategy",
+			value:    "bogus",
+			wantErr:  "invalid strategy \"bogus\"",
+		},
+		"invalid metrics address": {
+			existing: existing,
+			key:      "sync.metrics_addr",
+			value:    "9464",
+			wantErr:  "sync.metrics_addr: address 9464: missing port",
+		},
+		"invalid typed confirmation": {
+			existing: existing,
+			key:      "confirmation.typed",
+			value:    "sometimes",
+			wantErr:  "confirmation.typed: invalid value \"sometimes\"",
+		},
+		"invalid update channel": {
+			existing: existing,
+			key:      "update.channel",
+			value:    "nightly",
+			wantErr:  "update.channel: unknown release channel \"nightly\"",
+		},
+		"invalid log level": {
+			existing: existing,
+			key:      "log.level",
+			value:    "trace",
+			wantErr:  "log.level: invalid log level \"trace\"",
+		},
+		"negative backup retention": {
+			existing: existing,
+			key:      "backup.max_size_mb",
+			value:    "-1",
+			wantErr:  "backup: retention limits must not be negative",
+		},
+		"negative cache ttl": {
+			existing: existing,
+			key:      "cache.plugins_ttl_minutes",
+			value:    "-1",
+			wantErr:  "cache: TTLs must not be negative",
+		},
+		"unknown key": {
+			existing: existing,
+			key:      "sync.strategy",
+			value:    "newer",
+			wantErr:  "unknown config key",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if tt.existing != "" {
+				util.WriteFile(t, path, tt.existing)
+			}
+
+			err := SetInFile(path, tt.key, tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SetInFile() error = %v, want %q", err, tt.wantErr)
+				}
+				data, _ := os.ReadFile(path)
+				util.AssertEqual(t, string(data), tt.existing)
+				return
+			}
+			util.AssertNoError(t, err)
+
+			data, err := os.ReadFile(path)
+			util.AssertNoError(t, err)
+			if !strings.Contains(string(data), tt.want) {
+				t.Errorf("config file =\n%s\nwant it to contain\n%s", data, tt.want)
+			}
+
+			loaded, err := LoadFromPath(path)
+			util.AssertNoError(t, err)
+			got, err := loaded.Get(tt.key)
+			util.AssertNoError(t, err)
+			if !reflect.DeepEqual(got, tt.wantValue) {
+				t.Errorf("Get(%q) after set = %#v, want %#v", tt.key, got, tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// ProjectFileName is the name of the project config file, read from the root
+// of the repository containing the working directory.
+const ProjectFileName = ".skillsync.yaml"
+
+// projectConfig is what a project config file may set. It is a subset of the
+// user config: settings that could run commands or reach outside the
+// repository, such as hooks, are left to the user.
+type projectConfig struct {
+	// Platforms add skills paths to those of the user config
+	Platforms map[string]projectPlatformConfig `yaml:"platforms"`
+	Sync      projectSyncConfig                `yaml:"sync"`
+	// Profiles are added to the user's, replacing any with the same name
+	Profiles map[string]Profile `yaml:"profiles"`
+	// Collections are added to the user's, replacing any with the same name
+	Collections map[string]Collection `yaml:"collections"`
+	// Vars are added to the user's, replacing any with the same name
+	Vars map[string]string `yaml:"vars"`
+}
+
+// projectPlatformConfig is the project config of a single platform.
+type projectPlatformConfig struct {
+	// SkillsPaths are appended to the user's; they must be relative paths
+	// inside the repository
+	SkillsPaths []string `yaml:"skills_paths"`
+	MaxSkills   *int     `yaml:"max_skills"`
+}
+
+// projectSyncConfig is the sync config a project may set.
+type projectSyncConfig struct {
+	DefaultStrategy string            `yaml:"default_strategy"`
+	ScopeStrategies map[string]string `yaml:"scope_strategies"`
+	IncludeTypes    []string          `yaml:"include_types"`
+	WritableScopes  []string          `yaml:"writable_scopes"`
+}
+
+// ProjectFilePath returns the path of the project config of the repository
+// containing the working directory, or "" outside a repository.
+func ProjectFilePath() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	root := util.GetRepoRoot(cwd)
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, ProjectFileName)
+}
+
+// ProjectFile returns the path of the project config merged into c, or "" if
+// there was none.
+func (c *Config) ProjectFile() string {
+	return c.projectFile
+}
+
+// ScopeWritable reports whether sync.writable_scopes allows syncs to write
+// to scope.
+func (c *Config) ScopeWritable(scope string) bool {
+	return len(c.Sync.WritableScopes) == 0 || slices.Contains(c.Sync.WritableScopes, scope)
+}
+
+// applyProject merges the project config file at path over c. A missing file
+// is not an error; keys a project config may not set are.
+func (c *Config) applyProject(path string) error {
+	if path == "" {
+		return nil
+	}
+	// #nosec G304 - path is the project config at the repository root
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var project projectConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&project); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid project config %s: %w", path, err)
+	}
+	if err := c.mergeProject(project); err != nil {
+		return fmt.Errorf("invalid project config %s: %w", path, err)
+	}
+
+	c.recordFileKeys(data, SourceProject)
+	c.projectFile = path
+	return nil
+}
+
+// mergeProject merges project over c.
+func (c *Config) mergeProject(project projectConfig) error {
+	for name, platform := range project.Platforms {
+		field, err := c.field("platforms." + name)
+		if err != nil {
+			return err
+		}
+		// platforms.custom is a list of platform definitions, which only the
+		// user config may add
+		target, ok := field.Addr().Interface().(*PlatformConfig)
+		if !ok {
+			return fmt.Errorf("platforms.%s: not a platform a project config may configure", name)
+		}
+		if len(target.SkillsPaths) == 0 && target.SkillsPath != "" { //nolint:staticcheck // backward compatibility
+			target.SkillsPaths = []string{target.SkillsPath} //nolint:staticcheck // backward compatibility
+		}
+		for _, path := range platform.SkillsPaths {
+			if !filepath.IsLocal(path) || strings.HasPrefix(path, "~") {
+				return fmt.Errorf("platforms.%s.skills_paths: %q must be a relative path inside the repository", name, path)
+			}
+			if strings.Contains(path, "${") {
+				return fmt.Errorf("platforms.%s.skills_paths: %q: environment variables are only expanded in the user config", name, path)
+			}
+			if !slices.Contains(target.SkillsPaths, path) {
+				target.SkillsPaths = append(target.SkillsPaths, path)
+			}
+		}
+		if platform.MaxSkills != nil {
+			target.MaxSkills = *platform.MaxSkills
+		}
+	}
+
+	if err := validateWritableScopes(project.Sync.WritableScopes); err != nil {
+		return err
+	}
+	if project.Sync.DefaultStrategy != "" {
+		c.Sync.DefaultStrategy = project.Sync.DefaultStrategy
+	}
+	for scope, strategy := range project.Sync.ScopeStrategies {
+		if c.Sync.ScopeStrategies == nil {
+			c.Sync.ScopeStrategies = make(map[string]string)
+		}
+		c.Sync.ScopeStrategies[scope] = strategy
+	}
+	if len(project.Sync.IncludeTypes) > 0 {
+		c.Sync.IncludeTypes = project.Sync.IncludeTypes
+	}
+	if len(project.Sync.WritableScopes) > 0 {
+		c.Sync.WritableScopes = project.Sync.WritableScopes
+	}
+
+	for name, profile := range project.Profiles {
+		if c.Profiles == nil {
+			c.Profiles = make(map[string]Profile)
+		}
+		c.Profiles[name] = profile
+	}
+	for name, collection := range project.Collections {
+		if c.Collections == nil {
+			c.Collections = make(map[string]Collection)
+		}
+		c.Collections[name] = collection
+	}
+	for name, value := range project.Vars {
+		if c.Vars == nil {
+			c.Vars = make(map[string]string)
+		}
+		c.Vars[name] = value
+	}
+	return nil
+}
+
+// validateWritableScopes reports sync.writable_scopes entries that are not
+// writable target scopes.
+func validateWritableScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if scope != "repo" && scope != "user" {
+			return fmt.Errorf("sync.writable_scopes: invalid scope %q (valid: repo, user)", scope)
+		}
+	}
+	return nil
+}
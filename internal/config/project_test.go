@@ -0,0 +1,155 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// chdirRepo makes the working directory a new repository whose
+// .skillsync.yaml holds project, and returns the path of that file.
+func chdirRepo(t *testing.T, project string) string {
+	t.Helper()
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o750); err != nil {
+		t.Fatalf("failed to create repo: %v", err)
+	}
+	path := filepath.Join(repo, ProjectFileName)
+	util.WriteFile(t, path, project)
+	t.Chdir(repo)
+	return path
+}
+
+func TestLoadProjectConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", home)
+	util.WriteFile(t, filepath.Join(home, configFileName),
+		"sync:\n  default_strategy: newer\nprofiles:\n  mine:\n    source: claudecode:user\n    target: cursor\nvars:\n  owner: me\n  project: mine\n")
+	projectFile := chdirRepo(t, `platforms:
+  cursor:
+    skills_paths: [tools/cursor-skills, .cursor/skills]
+    max_skills: 10
+sync:
+  scope_strategies:
+    repo: three-way
+  writable_scopes: [repo]
+profiles:
+  team:
+    source: claudecode:repo
+    target: cursor:repo
+    strategy: skip
+vars:
+  project: acme
+`)
+
+	cfg, err := Load()
+	util.AssertNoError(t, err)
+
+	util.AssertEqual(t, cfg.ProjectFile(), projectFile)
+	util.AssertEqual(t, slices.Equal(cfg.Platforms.Cursor.SkillsPaths,
+		[]string{".cursor/skills", "~/.cursor/skills", "tools/cursor-skills"}), true)
+	util.AssertEqual(t, cfg.Platforms.Cursor.MaxSkills, 10)
+	util.AssertEqual(t, cfg.Sync.DefaultStrategy, "newer")
+	util.AssertEqual(t, cfg.Sync.ScopeStrategies["repo"], "three-way")
+	util.AssertEqual(t, cfg.ScopeWritable("repo"), true)
+	util.AssertEqual(t, cfg.ScopeWritable("user"), false)
+	util.AssertEqual(t, cfg.Profiles["team"].Strategy, "skip")
+	util.AssertEqual(t, cfg.Profiles["mine"].Source, "claudecode:user")
+	util.AssertEqual(t, cfg.Vars["project"], "acme")
+	util.AssertEqual(t, cfg.Vars["owner"], "me")
+
+	tests := map[string]struct {
+		key  string
+		want Source
+	}{
+		"project":              {key: "sync.writable_scopes", want: SourceProject},
+		"project over default": {key: "platforms.cursor.skills_paths", want: SourceProject},
+		"file":                 {key: "sync.default_strategy", want: SourceFile},
+		"default":              {key: "platforms.codex.skills_paths", want: SourceDefault},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, cfg.Source(tt.key), tt.want)
+		})
+	}
+
+	t.Run("env overrides project", func(t *testing.T) {
+		t.Setenv("SKILLSYNC_SYNC_WRITABLE_SCOPES", "repo,user")
+		cfg, err := Load()
+		util.AssertNoError(t, err)
+		util.AssertEqual(t, cfg.ScopeWritable("user"), true)
+		util.AssertEqual(t, cfg.Source("sync.writable_scopes"), SourceEnv)
+	})
+
+	t.Run("LoadUser skips project", func(t *testing.T) {
+		cfg, err := LoadUser()
+		util.AssertNoError(t, err)
+		util.AssertEqual(t, cfg.ProjectFile(), "")
+		util.AssertEqual(t, cfg.ScopeWritable("user"), true)
+	})
+}
+
+func TestLoadProjectConfig_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		project string
+		wantErr string
+	}{
+		"hooks are not allowed": {
+			project: "hooks:\n  pre_sync: [rm -rf /]\n",
+			wantErr: "field hooks not found",
+		},
+		"unknown platform": {
+			project: "platforms:\n  vim:\n    skills_paths: [x]\n",
+			wantErr: `unknown config key "platforms.vim"`,
+		},
+		"custom platforms": {
+			project: "platforms:\n  custom:\n    skills_paths: [x]\n",
+			wantErr: "platforms.custom: not a platform a project config may configure",
+		},
+		"absolute skills path": {
+			project: "platforms:\n  cursor:\n    skills_paths: [/etc/skills]\n",
+			wantErr: "must be a relative path inside the repository",
+		},
+		"skills path outside the repository": {
+			project: "platforms:\n  cursor:\n    skills_paths: [../other/skills]\n",
+			wantErr: "must be a relative path inside the repository",
+		},
+		"home skills path": {
+			project: "platforms:\n  cursor:\n    skills_paths: [~/skills]\n",
+			wantErr: "must be a relative path inside the repository",
+		},
+		"environment variable in skills path": {
+			project: "platforms:\n  cursor:\n    skills_paths: [\"${HOME}/skills\"]\n",
+			wantErr: "environment variables are only expanded in the user config",
+		},
+		"invalid writable scope": {
+			project: "sync:\n  writable_scopes: [admin]\n",
+			wantErr: `invalid scope "admin"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SKILLSYNC_HOME", t.TempDir())
+			projectFile := chdirRepo(t, tt.project)
+
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), projectFile) {
+				t.Errorf("Load() error = %v, want error containing %q and %s", err, tt.wantErr, projectFile)
+			}
+		})
+	}
+}
+
+func TestLoadProjectConfig_OutsideRepository(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	cfg, err := Load()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, cfg.ProjectFile(), "")
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+// Values of sync.resolution_preferences entries.
+const (
+	// PreferSource resolves conflicts with the source version.
+	PreferSource = "prefer-source"
+	// PreferTarget resolves conflicts by keeping the target version.
+	PreferTarget = "prefer-target"
+	// PreferMerge resolves conflicts with an automatic merge, which may leave
+	// conflict markers.
+	PreferMerge = "merge"
+)
+
+// resolutionChoices maps resolution preferences to conflict resolutions.
+var resolutionChoices = map[string]sync.ResolutionChoice{
+	PreferSource: sync.ResolutionUseSource,
+	PreferTarget: sync.ResolutionUseTarget,
+	PreferMerge:  sync.ResolutionMerge,
+}
+
+// parsePlatformPair parses a "source->target" resolution preference key.
+func parsePlatformPair(key string) (source, target model.Platform, err error) {
+	from, to, ok := strings.Cut(key, "->")
+	if !ok {
+		return "", "", fmt.Errorf("%q is not a source->target platform pair", key)
+	}
+	if source, err = model.ParsePlatform(strings.TrimSpace(from)); err != nil {
+		return "", "", err
+	}
+	if target, err = model.ParsePlatform(strings.TrimSpace(to)); err != nil {
+		return "", "", err
+	}
+	return source, target, nil
+}
+
+// ResolutionPreference returns how conflicts of syncs from source to target
+// are resolved by default, from sync.resolution_preferences, and the key of
+// the entry that set it. ok is false when no entry matches.
+func (c *Config) ResolutionPreference(source, target model.Platform) (choice sync.ResolutionChoice, key string, ok bool) {
+	for _, key := range slices.Sorted(maps.Keys(c.Sync.ResolutionPreferences)) {
+		from, to, err := parsePlatformPair(key)
+		if err != nil || from != source || to != target {
+			continue
+		}
+		if choice, ok := resolutionChoices[c.Sync.ResolutionPreferences[key]]; ok {
+			return choice, key, true
+		}
+	}
+	return "", "", false
+}
+
+// validateResolutionPreferences reports sync.resolution_preferences entries
+// with an invalid platform pair or preference.
+func validateResolutionPreferences(prefs map[string]string) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(prefs)) {
+		if _, _, err := parsePlatformPair(key); err != nil {
+			errs = append(errs, fmt.Errorf("sync.resolution_preferences: %w", err))
+		}
+		if _, ok := resolutionChoices[prefs[key]]; !ok {
+			errs = append(errs, fmt.Errorf("sync.resolution_preferences.%s: invalid preference %q (valid: %s, %s, %s)",
+				key, prefs[key], PreferSource, PreferTarget, PreferMerge))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestResolutionPreference(t *testing.T) {
+	cfg := Default()
+	cfg.Sync.ResolutionPreferences = map[string]string{
+		"claude-code->cursor":  PreferSource,
+		"cursor -> claudecode": PreferTarget,
+		"codex->cursor":        "bogus",
+	}
+
+	tests := map[string]struct {
+		source, target model.Platform
+		want           sync.ResolutionChoice
+		wantKey        string
+		wantOK         bool
+	}{
+		"prefer source":     {source: model.ClaudeCode, target: model.Cursor, want: sync.ResolutionUseSource, wantKey: "claude-code->cursor", wantOK: true},
+		"prefer target":     {source: model.Cursor, target: model.ClaudeCode, want: sync.ResolutionUseTarget, wantKey: "cursor -> claudecode", wantOK: true},
+		"invalid value":     {source: model.Codex, target: model.Cursor},
+		"no matching pair":  {source: model.ClaudeCode, target: model.Codex},
+		"direction matters": {source: model.Cursor, target: model.Codex},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, key, ok := cfg.ResolutionPreference(tt.source, tt.target)
+			util.AssertEqual(t, ok, tt.wantOK)
+			util.AssertEqual(t, got, tt.want)
+			util.AssertEqual(t, key, tt.wantKey)
+		})
+	}
+}
+
+func TestValidateResolutionPreferences(t *testing.T) {
+	cfg := Default()
+	cfg.Sync.ResolutionPreferences = map[string]string{"claudecode->cursor": PreferMerge}
+	util.AssertNoError(t, cfg.Validate())
+
+	cfg.Sync.ResolutionPreferences = map[string]string{
+		"claudecode":        PreferSource,
+		"claudecode->vim":   PreferSource,
+		"cursor->codex":     "always",
+		"codex->claudecode": PreferTarget,
+	}
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want errors")
+	}
+	for _, want := range []string{
+		`"claudecode" is not a source->target platform pair`,
+		"vim",
+		`sync.resolution_preferences.cursor->codex: invalid preference "always"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, want %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "codex->claudecode") {
+		t.Errorf("Validate() = %v, reports a valid entry", err)
+	}
+}
@@ -0,0 +1,102 @@
+package docs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestLookup(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		wantErr bool
+	}{
+		"exact":          {name: "strategies"},
+		"case and space": {name: " Scopes "},
+		"unknown":        {name: "nope", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			topic, err := Lookup(tt.name)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "platform-specs") {
+					t.Errorf("Lookup() error = %v, want one listing the topics", err)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, topic.Name, strings.ToLower(strings.TrimSpace(tt.name)))
+		})
+	}
+}
+
+func TestTopicText(t *testing.T) {
+	topic, err := Lookup("strategies")
+	util.AssertNoError(t, err)
+	text := topic.Text()
+
+	for _, want := range []string{
+		"Sync strategies - How skillsync sync treats",
+		"\nStrategies:\n",
+		"     overwrite   - Replace target skills with source skills unconditionally (default)\n",
+		"     three-way   - ",
+		"       scope_strategies:\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestPageRoff(t *testing.T) {
+	page := Page{
+		Name:     "skillsync-sync",
+		Section:  1,
+		Summary:  "Synchronize skills",
+		Synopsis: []string{"skillsync sync <source> <target>"},
+		Description: `Synchronize skills, escaping back\slashes.
+
+   .skillsync files starting a line are escaped.
+
+   Examples:
+     skillsync sync cursor codex
+       --dry-run`,
+		Sections: []Section{{Heading: "Options", Entries: []Entry{{Term: "--dry-run", Text: "Preview changes"}}}},
+		SeeAlso:  []string{"skillsync(1)"},
+	}
+	got := page.Roff(Header{Source: "skillsync 1.0", Manual: "skillsync Manual", Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)})
+
+	want := `.TH "SKILLSYNC\-SYNC" "1" "January 2026" "skillsync 1.0" "skillsync Manual"
+.SH NAME
+skillsync\-sync \- Synchronize skills
+.SH SYNOPSIS
+.nf
+skillsync sync <source> <target>
+.fi
+.SH DESCRIPTION
+.PP
+Synchronize skills, escaping back\eslashes.
+.PP
+\&.skillsync files starting a line are escaped.
+.PP
+Examples:
+.PP
+.RS 4
+.nf
+skillsync sync cursor codex
+  \-\-dry\-run
+.fi
+.RE
+.SH OPTIONS
+.TP
+\fB\-\-dry\-run\fR
+Preview changes
+.SH SEE ALSO
+\fBskillsync\fR(1)
+`
+	util.AssertEqual(t, got, want)
+	util.AssertEqual(t, page.Filename(), "skillsync-sync.1")
+}
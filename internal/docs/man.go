@@ -0,0 +1,183 @@
+package docs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Page is a man page.
+type Page struct {
+	// Name is the page name, e.g. "skillsync-sync".
+	Name    string
+	Section int
+	// Summary is the one-line description in the NAME section.
+	Summary  string
+	Synopsis []string
+	// Description is laid out like command help: paragraphs separated by
+	// blank lines, with indented lines kept verbatim.
+	Description string
+	Sections    []Section
+	// SeeAlso names related pages, e.g. "skillsync-sync(1)".
+	SeeAlso []string
+}
+
+// Header identifies the manual a page belongs to.
+type Header struct {
+	// Source is the product and version, e.g. "skillsync 1.2.0".
+	Source string
+	// Manual is the title of the manual, e.g. "skillsync Manual".
+	Manual string
+	Date   time.Time
+}
+
+// Filename returns the file name of the page, e.g. "skillsync-sync.1".
+func (p Page) Filename() string {
+	return p.Name + "." + strconv.Itoa(p.Section)
+}
+
+// TopicPage returns the topic as a section 7 page named after program.
+func TopicPage(program string, t Topic) Page {
+	return Page{
+		Name:     program + "-" + t.Name,
+		Section:  7,
+		Summary:  t.Summary,
+		Sections: t.Sections,
+		SeeAlso:  []string{program + "(1)"},
+	}
+}
+
+// Roff renders the page as man(7) source.
+func (p Page) Roff(h Header) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ".TH \"%s\" \"%d\" \"%s\" \"%s\" \"%s\"\n",
+		escape(strings.ToUpper(p.Name)), p.Section, h.Date.Format("January 2006"), escape(h.Source), escape(h.Manual))
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", escape(p.Name), escape(p.Summary))
+
+	if len(p.Synopsis) > 0 {
+		b.WriteString(".SH SYNOPSIS\n.nf\n")
+		for _, line := range p.Synopsis {
+			fmt.Fprintf(&b, "%s\n", escapeLine(line))
+		}
+		b.WriteString(".fi\n")
+	}
+
+	if p.Description != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		writeHelpText(&b, p.Description)
+	}
+
+	for _, section := range p.Sections {
+		fmt.Fprintf(&b, ".SH %s\n", escape(strings.ToUpper(section.Heading)))
+		if section.Text != "" {
+			fmt.Fprintf(&b, ".PP\n%s\n", escapeLine(section.Text))
+		}
+		for _, entry := range section.Entries {
+			fmt.Fprintf(&b, ".TP\n\\fB%s\\fR\n", escape(entry.Term))
+			if entry.Text != "" {
+				fmt.Fprintf(&b, "%s\n", escapeLine(entry.Text))
+			}
+		}
+		if section.Example != "" {
+			writeLiteral(&b, strings.Split(section.Example, "\n"))
+		}
+	}
+
+	if len(p.SeeAlso) > 0 {
+		refs := make([]string, 0, len(p.SeeAlso))
+		for _, ref := range p.SeeAlso {
+			name, section, _ := strings.Cut(ref, "(")
+			refs = append(refs, fmt.Sprintf("\\fB%s\\fR(%s", escape(name), section))
+		}
+		fmt.Fprintf(&b, ".SH SEE ALSO\n%s\n", strings.Join(refs, ", "))
+	}
+	return b.String()
+}
+
+// writeHelpText renders help-formatted text: unindented lines become
+// paragraphs, and runs of indented lines, such as lists and examples, are
+// kept verbatim.
+func writeHelpText(b *strings.Builder, text string) {
+	// Descriptions start right after the opening quote and indent only the
+	// lines that follow, so the first line is left out of the dedent
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	lines = append(lines[:1], dedent(lines[1:])...)
+	var paragraph, literal []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			fmt.Fprintf(b, ".PP\n%s\n", escapeLine(strings.Join(paragraph, " ")))
+			paragraph = nil
+		}
+		if len(literal) > 0 {
+			writeLiteral(b, dedent(literal))
+			literal = nil
+		}
+	}
+	for _, line := range lines {
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case strings.HasPrefix(line, " "):
+			if len(paragraph) > 0 {
+				flush()
+			}
+			literal = append(literal, line)
+		default:
+			if len(literal) > 0 {
+				flush()
+			}
+			paragraph = append(paragraph, strings.TrimSpace(line))
+		}
+	}
+	flush()
+}
+
+// writeLiteral renders lines verbatim in an indented block.
+func writeLiteral(b *strings.Builder, lines []string) {
+	b.WriteString(".PP\n.RS 4\n.nf\n")
+	for _, line := range lines {
+		fmt.Fprintf(b, "%s\n", escapeLine(line))
+	}
+	b.WriteString(".fi\n.RE\n")
+}
+
+// dedent removes the indentation shared by the non-blank lines.
+func dedent(lines []string) []string {
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " "))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent <= 0 {
+		return lines
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		n := min(indent, len(line)-len(strings.TrimLeft(line, " ")))
+		out[i] = line[n:]
+	}
+	return out
+}
+
+// escapeLine escapes text for a line of its own, guarding a leading period
+// or apostrophe that roff would read as a request.
+func escapeLine(s string) string {
+	s = escape(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		return `\&` + s
+	}
+	return s
+}
+
+// escape escapes the characters roff treats specially in running text.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+}
@@ -0,0 +1,76 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// textWidth is the column at which topic prose is wrapped.
+const textWidth = 78
+
+// Text renders the topic for the terminal, in the layout of command help.
+func (t Topic) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s - %s\n", t.Title, t.Summary)
+	for _, section := range t.Sections {
+		fmt.Fprintf(&b, "\n%s:\n", section.Heading)
+		if section.Text != "" {
+			for _, line := range wrap(section.Text, textWidth-3) {
+				fmt.Fprintf(&b, "   %s\n", line)
+			}
+		}
+		if len(section.Entries) > 0 {
+			if section.Text != "" {
+				b.WriteString("\n")
+			}
+			b.WriteString(FormatEntries(section.Entries, "     "))
+		}
+		if section.Example != "" {
+			b.WriteString("\n")
+			for _, line := range strings.Split(section.Example, "\n") {
+				fmt.Fprintf(&b, "     %s\n", line)
+			}
+		}
+	}
+	return b.String()
+}
+
+// FormatEntries lays out entries one per line with their terms aligned,
+// as in "overwrite   - Replace target skills", each line starting with indent.
+func FormatEntries(entries []Entry, indent string) string {
+	width := 0
+	for _, entry := range entries {
+		width = max(width, len(entry.Term))
+	}
+	var b strings.Builder
+	for _, entry := range entries {
+		if entry.Text == "" {
+			fmt.Fprintf(&b, "%s%s\n", indent, entry.Term)
+			continue
+		}
+		fmt.Fprintf(&b, "%s%-*s - %s\n", indent, width, entry.Term, entry.Text)
+	}
+	return b.String()
+}
+
+// wrap splits text into lines of at most width columns at word boundaries.
+// A word longer than width gets a line of its own.
+func wrap(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) > width:
+			lines = append(lines, line)
+			line = word
+		default:
+			line += " " + word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,192 @@
+// Package docs holds skillsync's long-form help topics and renders them, and
+// the command reference, as man pages.
+//
+// Topics are data rather than help strings, so the same text backs
+// skillsync docs topic, the generated manuals, and the lists embedded in
+// command help.
+package docs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+// Entry is a term with its explanation, such as a strategy and what it does.
+type Entry struct {
+	Term string `json:"term"`
+	Text string `json:"text"`
+}
+
+// Section is a titled part of a topic: prose, a list of entries, or both.
+type Section struct {
+	Heading string  `json:"heading"`
+	Text    string  `json:"text,omitempty"`
+	Entries []Entry `json:"entries,omitempty"`
+	// Example is shown verbatim after the entries.
+	Example string `json:"example,omitempty"`
+}
+
+// Topic is a long-form help topic.
+type Topic struct {
+	Name     string    `json:"name"`
+	Title    string    `json:"title"`
+	Summary  string    `json:"summary"`
+	Sections []Section `json:"sections"`
+}
+
+// Topics returns every help topic, in the order they are listed.
+func Topics() []Topic {
+	return []Topic{strategiesTopic(), scopesTopic(), platformSpecsTopic()}
+}
+
+// Lookup returns the topic with the given name.
+func Lookup(name string) (Topic, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	var names []string
+	for _, topic := range Topics() {
+		if topic.Name == normalized {
+			return topic, nil
+		}
+		names = append(names, topic.Name)
+	}
+	return Topic{}, fmt.Errorf("unknown help topic %q (valid: %s)", name, strings.Join(names, ", "))
+}
+
+// StrategyEntries lists the sync strategies with their descriptions.
+func StrategyEntries() []Entry {
+	entries := make([]Entry, 0, len(sync.AllStrategies()))
+	for _, strategy := range sync.AllStrategies() {
+		text := strategy.Description()
+		if strategy == sync.StrategyOverwrite {
+			text += " (default)"
+		}
+		entries = append(entries, Entry{Term: strategy.String(), Text: text})
+	}
+	return entries
+}
+
+func strategiesTopic() Topic {
+	return Topic{
+		Name:    "strategies",
+		Title:   "Sync strategies",
+		Summary: "How skillsync sync treats skills that already exist in the target",
+		Sections: []Section{
+			{
+				Heading: "Strategies",
+				Text:    "A strategy decides what happens when a source skill already exists in the target. Skills missing from the target are always created.",
+				Entries: StrategyEntries(),
+			},
+			{
+				Heading: "Choosing a strategy",
+				Text: "Without --strategy, the target scope's entry in sync.scope_strategies is used, " +
+					"then sync.default_strategy, then " + sync.StrategyOverwrite.String() + ".",
+				Example: `sync:
+  scope_strategies:
+    repo: three-way   # shared with the team
+    user: overwrite   # mine`,
+			},
+			{
+				Heading: "Suggestions",
+				Text: "Before a sync, skillsync compares both sides with the sync history. When another strategy fits " +
+					"better it prints a suggestion: three-way when both copies of a skill changed since the last sync, " +
+					"newer when only targets were edited, and overwrite when only sources changed. " +
+					"--accept-suggestion applies the suggestion.",
+			},
+		},
+	}
+}
+
+// scopeAliases are the alternative names model.ParseScope accepts.
+var scopeAliases = map[model.SkillScope]string{
+	model.ScopeRepo:    "repository, project, local",
+	model.ScopeUser:    "global, home",
+	model.ScopeAdmin:   "administrator",
+	model.ScopeSystem:  "sys",
+	model.ScopeBuiltin: "default, built-in",
+	model.ScopePlugin:  "plugins",
+}
+
+func scopesTopic() Topic {
+	entries := make([]Entry, 0, len(model.AllScopes()))
+	for _, scope := range model.AllScopes() {
+		text := scope.Description()
+		if aliases := scopeAliases[scope]; aliases != "" {
+			text += " (also: " + aliases + ")"
+		}
+		entries = append(entries, Entry{Term: scope.String(), Text: text})
+	}
+
+	return Topic{
+		Name:    "scopes",
+		Title:   "Skill scopes",
+		Summary: "Where skills are installed, and which copy wins",
+		Sections: []Section{
+			{
+				Heading: "Scopes",
+				Text: "Each platform looks for skills in several locations, its scopes. They are listed from lowest " +
+					"to highest precedence: when scopes hold skills of the same name, the higher scope wins.",
+				Entries: entries,
+			},
+			{
+				Heading: "Sources and targets",
+				Text: "Any scope can be read as a source. Only " + model.ScopeRepo.String() + " and " +
+					model.ScopeUser.String() + " are writable, so they are the only target scopes; " +
+					"a target without a scope writes to " + model.ScopeUser.String() + ".",
+			},
+			{
+				Heading: "Plugin skills",
+				Text: "Plugin skills are excluded from sources by default. Include them with --include-plugins, " +
+					"or name the scope explicitly, e.g. claudecode:plugin.",
+			},
+		},
+	}
+}
+
+// platformAliases are the alternative names model.ParsePlatform accepts.
+var platformAliases = map[model.Platform]string{
+	model.ClaudeCode: "claudecode, claude",
+}
+
+func platformSpecsTopic() Topic {
+	entries := make([]Entry, 0, len(model.AllPlatforms()))
+	for _, platform := range model.AllPlatforms() {
+		var text string
+		if aliases := platformAliases[platform]; aliases != "" {
+			text = "Also accepted as " + aliases
+		}
+		entries = append(entries, Entry{Term: string(platform), Text: text})
+	}
+
+	return Topic{
+		Name:    "platform-specs",
+		Title:   "Platform specs",
+		Summary: "How commands name a platform and the scopes to use",
+		Sections: []Section{
+			{
+				Heading: "Platforms",
+				Text:    "skillsync platforms lists the directories searched for each platform.",
+				Entries: entries,
+			},
+			{
+				Heading: "Spec format",
+				Text: "Commands that take a platform accept a spec of the form platform[:scope[,scope2,...]]. " +
+					"Without scopes, a source reads every scope and a target writes to the user scope.",
+				Entries: []Entry{
+					{Term: "cursor", Text: "All scopes as a source, the user scope as a target"},
+					{Term: "cursor:repo", Text: "Only the repo scope"},
+					{Term: "cursor:repo,user", Text: "Both repo and user scopes (sources only)"},
+				},
+			},
+			{
+				Heading: "Targets",
+				Text: "A target spec names at most one scope, and it must be writable: " +
+					model.ScopeRepo.String() + " or " + model.ScopeUser.String() + ".",
+				Example: `skillsync sync cursor:repo,user codex:repo
+skillsync sync claudecode:plugin cursor`,
+			},
+		},
+	}
+}
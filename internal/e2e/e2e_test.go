@@ -8,45 +8,45 @@ import (
 	"testing"
 	"time"
 
-	"github.com/klauern/skillsync/internal/e2e"
+	"github.com/klauern/skillsync/skillsynctest"
 )
 
 var updateGolden = flag.Bool("update", false, "update golden files")
 
 func TestMain(m *testing.M) {
 	flag.Parse()
-	e2e.SetUpdateGolden(*updateGolden)
+	skillsynctest.SetUpdateGolden(*updateGolden)
 	os.Exit(m.Run())
 }
 
 // TestVersionCommand verifies the version command works correctly.
 func TestVersionCommand(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("version")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "skillsync version")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "skillsync version")
 }
 
 // TestConfigShowCommand verifies config show outputs valid configuration.
 func TestConfigShowCommand(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("config", "show")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Default config should contain sync strategy
-	e2e.AssertOutputContains(t, result, "sync:")
+	skillsynctest.AssertOutputContains(t, result, "sync:")
 }
 
 // TestConfigShowJSON verifies config show with JSON format.
 func TestConfigShowJSON(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("config", "show", "--format", "json")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// JSON output should start with {
 	if !strings.HasPrefix(strings.TrimSpace(result.Stdout), "{") {
 		t.Errorf("expected JSON output starting with {, got: %s", result.Stdout)
@@ -55,11 +55,11 @@ func TestConfigShowJSON(t *testing.T) {
 
 // TestConfigShowShortFlag verifies config show with short format flag.
 func TestConfigShowShortFlag(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("config", "show", "-f", "json")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	if !strings.HasPrefix(strings.TrimSpace(result.Stdout), "{") {
 		t.Errorf("expected JSON output starting with {, got: %s", result.Stdout)
 	}
@@ -67,64 +67,64 @@ func TestConfigShowShortFlag(t *testing.T) {
 
 // TestConfigShowInvalidFormat verifies config show rejects invalid format.
 func TestConfigShowInvalidFormat(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("config", "show", "--format", "invalid")
 
-	e2e.AssertError(t, result)
-	e2e.AssertErrorContains(t, result, "unsupported format")
+	skillsynctest.AssertError(t, result)
+	skillsynctest.AssertErrorContains(t, result, "unsupported format")
 }
 
 // TestConfigShowYAMLContainsExpectedSections verifies YAML output structure.
 func TestConfigShowYAMLContainsExpectedSections(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("config", "show", "--format", "yaml")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Check for main config sections
-	e2e.AssertOutputContains(t, result, "platforms:")
-	e2e.AssertOutputContains(t, result, "sync:")
-	e2e.AssertOutputContains(t, result, "output:")
-	e2e.AssertOutputContains(t, result, "similarity:")
-	e2e.AssertOutputContains(t, result, "# skillsync configuration")
+	skillsynctest.AssertOutputContains(t, result, "platforms:")
+	skillsynctest.AssertOutputContains(t, result, "sync:")
+	skillsynctest.AssertOutputContains(t, result, "output:")
+	skillsynctest.AssertOutputContains(t, result, "similarity:")
+	skillsynctest.AssertOutputContains(t, result, "# skillsync configuration")
 }
 
 // TestConfigShowWithNoConfigFileShowsDefault verifies default config message.
 func TestConfigShowWithNoConfigFileShowsDefault(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("config", "show")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Using default configuration")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Using default configuration")
 }
 
 // TestConfigDefaultActionShowsConfig verifies config without subcommand shows config.
 func TestConfigDefaultActionShowsConfig(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("config")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Should behave same as config show
-	e2e.AssertOutputContains(t, result, "sync:")
-	e2e.AssertOutputContains(t, result, "platforms:")
+	skillsynctest.AssertOutputContains(t, result, "sync:")
+	skillsynctest.AssertOutputContains(t, result, "platforms:")
 }
 
 // TestConfigInitCreatesConfigFile verifies config init creates a file.
 func TestConfigInitCreatesConfigFile(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("config", "init")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Created config file:")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Created config file:")
 
 	// Verify config file was created by showing it
 	showResult := h.Run("config", "show")
-	e2e.AssertSuccess(t, showResult)
-	e2e.AssertOutputContains(t, showResult, "Loaded from:")
+	skillsynctest.AssertSuccess(t, showResult)
+	skillsynctest.AssertOutputContains(t, showResult, "Loaded from:")
 
 	configData, err := os.ReadFile(filepath.Join(h.HomeDir(), "config.yaml"))
 	if err != nil {
@@ -142,92 +142,92 @@ func TestConfigInitCreatesConfigFile(t *testing.T) {
 
 // TestConfigInitFailsIfExists verifies config init fails without force flag.
 func TestConfigInitFailsIfExists(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// First init should succeed
 	result := h.Run("config", "init")
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Second init should fail
 	result2 := h.Run("config", "init")
-	e2e.AssertError(t, result2)
-	e2e.AssertErrorContains(t, result2, "already exists")
-	e2e.AssertErrorContains(t, result2, "--force")
+	skillsynctest.AssertError(t, result2)
+	skillsynctest.AssertErrorContains(t, result2, "already exists")
+	skillsynctest.AssertErrorContains(t, result2, "--force")
 }
 
 // TestConfigInitForceOverwrites verifies config init --force overwrites.
 func TestConfigInitForceOverwrites(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// First init
 	result := h.Run("config", "init")
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Second init with force
 	result2 := h.Run("config", "init", "--force")
-	e2e.AssertSuccess(t, result2)
-	e2e.AssertOutputContains(t, result2, "Created config file:")
+	skillsynctest.AssertSuccess(t, result2)
+	skillsynctest.AssertOutputContains(t, result2, "Created config file:")
 }
 
 // TestConfigInitShortForceFlag verifies config init -f works.
 func TestConfigInitShortForceFlag(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// First init
 	result := h.Run("config", "init")
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Second init with short force flag
 	result2 := h.Run("config", "init", "-f")
-	e2e.AssertSuccess(t, result2)
+	skillsynctest.AssertSuccess(t, result2)
 }
 
 // TestConfigPathShowsPaths verifies config path displays all paths.
 func TestConfigPathShowsPaths(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("config", "path")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Check for section headers
-	e2e.AssertOutputContains(t, result, "Configuration paths:")
-	e2e.AssertOutputContains(t, result, "Platform paths:")
-	e2e.AssertOutputContains(t, result, "Data paths:")
+	skillsynctest.AssertOutputContains(t, result, "Configuration paths:")
+	skillsynctest.AssertOutputContains(t, result, "Platform paths:")
+	skillsynctest.AssertOutputContains(t, result, "Data paths:")
 
 	// Check for platform paths
-	e2e.AssertOutputContains(t, result, "Claude Code:")
-	e2e.AssertOutputContains(t, result, "Cursor:")
-	e2e.AssertOutputContains(t, result, "Codex:")
+	skillsynctest.AssertOutputContains(t, result, "Claude Code:")
+	skillsynctest.AssertOutputContains(t, result, "Cursor:")
+	skillsynctest.AssertOutputContains(t, result, "Codex:")
 
 	// Check for data paths
-	e2e.AssertOutputContains(t, result, "Backups:")
-	e2e.AssertOutputContains(t, result, "Cache:")
-	e2e.AssertOutputContains(t, result, "Plugins:")
-	e2e.AssertOutputContains(t, result, "Metadata:")
+	skillsynctest.AssertOutputContains(t, result, "Backups:")
+	skillsynctest.AssertOutputContains(t, result, "Cache:")
+	skillsynctest.AssertOutputContains(t, result, "Plugins:")
+	skillsynctest.AssertOutputContains(t, result, "Metadata:")
 }
 
 // TestConfigPathShowsConfigFileStatus verifies path shows config file existence.
 func TestConfigPathShowsConfigFileStatus(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Without config file
 	result := h.Run("config", "path")
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "(not found)")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "(not found)")
 
 	// Create config file
 	initResult := h.Run("config", "init")
-	e2e.AssertSuccess(t, initResult)
+	skillsynctest.AssertSuccess(t, initResult)
 
 	// With config file
 	result2 := h.Run("config", "path")
-	e2e.AssertSuccess(t, result2)
-	e2e.AssertOutputContains(t, result2, "(exists)")
+	skillsynctest.AssertSuccess(t, result2)
+	skillsynctest.AssertOutputContains(t, result2, "(exists)")
 }
 
 // TestConfigEditNoEditorError verifies edit fails without EDITOR env var.
 func TestConfigEditNoEditorError(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Clear EDITOR and VISUAL environment variables
 	h.SetEnv("EDITOR", "")
@@ -235,29 +235,29 @@ func TestConfigEditNoEditorError(t *testing.T) {
 
 	result := h.Run("config", "edit")
 
-	e2e.AssertError(t, result)
-	e2e.AssertErrorContains(t, result, "no editor found")
-	e2e.AssertErrorContains(t, result, "$EDITOR")
+	skillsynctest.AssertError(t, result)
+	skillsynctest.AssertErrorContains(t, result, "no editor found")
+	skillsynctest.AssertErrorContains(t, result, "$EDITOR")
 }
 
 // TestConfigEditWithEditor verifies edit works with EDITOR set.
 func TestConfigEditWithEditor(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Set EDITOR environment variable
 	h.SetEnv("EDITOR", "vim")
 
 	result := h.Run("config", "edit")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Opening")
-	e2e.AssertOutputContains(t, result, "vim")
-	e2e.AssertOutputContains(t, result, "Run:")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Opening")
+	skillsynctest.AssertOutputContains(t, result, "vim")
+	skillsynctest.AssertOutputContains(t, result, "Run:")
 }
 
 // TestConfigEditWithVisual verifies edit works with VISUAL set.
 func TestConfigEditWithVisual(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Set VISUAL environment variable (EDITOR not set)
 	h.SetEnv("EDITOR", "")
@@ -265,57 +265,57 @@ func TestConfigEditWithVisual(t *testing.T) {
 
 	result := h.Run("config", "edit")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "code")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "code")
 }
 
 // TestConfigEditCreatesDefaultIfMissing verifies edit creates config if missing.
 func TestConfigEditCreatesDefaultIfMissing(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	h.SetEnv("EDITOR", "vim")
 
 	result := h.Run("config", "edit")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "No config file found")
-	e2e.AssertOutputContains(t, result, "Creating default configuration")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "No config file found")
+	skillsynctest.AssertOutputContains(t, result, "Creating default configuration")
 
 	// Verify config was created
 	showResult := h.Run("config", "show")
-	e2e.AssertOutputContains(t, showResult, "Loaded from:")
+	skillsynctest.AssertOutputContains(t, showResult, "Loaded from:")
 }
 
 // TestConfigEditExistingFile verifies edit doesn't recreate existing config.
 func TestConfigEditExistingFile(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// First create the config
 	initResult := h.Run("config", "init")
-	e2e.AssertSuccess(t, initResult)
+	skillsynctest.AssertSuccess(t, initResult)
 
 	// Then edit (should not say "creating")
 	h.SetEnv("EDITOR", "vim")
 	result := h.Run("config", "edit")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputNotContains(t, result, "Creating default configuration")
-	e2e.AssertOutputContains(t, result, "Opening")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputNotContains(t, result, "Creating default configuration")
+	skillsynctest.AssertOutputContains(t, result, "Opening")
 }
 
 // TestConfigShowWithExistingFileShowsPath verifies "Loaded from" message.
 func TestConfigShowWithExistingFileShowsPath(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create config file first
 	initResult := h.Run("config", "init")
-	e2e.AssertSuccess(t, initResult)
+	skillsynctest.AssertSuccess(t, initResult)
 
 	// Now show should indicate loaded from file
 	result := h.Run("config", "show")
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Loaded from:")
-	e2e.AssertOutputNotContains(t, result, "Using default configuration")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Loaded from:")
+	skillsynctest.AssertOutputNotContains(t, result, "Using default configuration")
 }
 
 // TestSyncMissingArgs verifies sync command requires source and target.
@@ -333,11 +333,11 @@ func TestSyncMissingArgs(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			h := e2e.NewHarness(t)
+			h := skillsynctest.NewHarness(t)
 
 			result := h.Run(tt.args...)
 
-			e2e.AssertError(t, result)
+			skillsynctest.AssertError(t, result)
 		})
 	}
 }
@@ -357,18 +357,18 @@ func TestSyncInvalidPlatform(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			h := e2e.NewHarness(t)
+			h := skillsynctest.NewHarness(t)
 
 			result := h.Run(tt.args...)
 
-			e2e.AssertError(t, result)
+			skillsynctest.AssertError(t, result)
 		})
 	}
 }
 
 // TestSyncDryRun verifies sync with dry-run flag doesn't modify files.
 func TestSyncDryRun(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create a skill in Claude Code
 	claudeFixture := h.ClaudeCodeFixture()
@@ -377,18 +377,18 @@ func TestSyncDryRun(t *testing.T) {
 	// Run sync with dry-run
 	result := h.Run("sync", "--dry-run", "--skip-validation", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Dry run")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Dry run")
 }
 
 // TestBackupListEmpty verifies backup list with no backups.
 func TestBackupListEmpty(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("backup", "list")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "No backups found")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "No backups found")
 }
 
 // TestBackupListFormats verifies backup list output formats.
@@ -413,23 +413,23 @@ func TestBackupListFormats(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			h := e2e.NewHarness(t)
+			h := skillsynctest.NewHarness(t)
 
 			result := h.Run("backup", "list", "--format", tt.format)
 
-			e2e.AssertSuccess(t, result)
-			e2e.AssertOutputContains(t, result, tt.want)
+			skillsynctest.AssertSuccess(t, result)
+			skillsynctest.AssertOutputContains(t, result, tt.want)
 		})
 	}
 }
 
 // TestDiscoverCommand verifies discover command executes.
 func TestDiscoverCommand(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("discover")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 }
 
 // TestDiscoverWithPlatformFilter verifies discover with platform filter.
@@ -438,11 +438,11 @@ func TestDiscoverWithPlatformFilter(t *testing.T) {
 
 	for _, platform := range tests {
 		t.Run(platform, func(t *testing.T) {
-			h := e2e.NewHarness(t)
+			h := skillsynctest.NewHarness(t)
 
 			result := h.Run("discover", "--platform", platform)
 
-			e2e.AssertSuccess(t, result)
+			skillsynctest.AssertSuccess(t, result)
 		})
 	}
 }
@@ -472,12 +472,12 @@ func TestDiscoverOutputFormats(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			h := e2e.NewHarness(t)
+			h := skillsynctest.NewHarness(t)
 
 			// Use codex platform to minimize output size (likely empty/small)
 			result := h.Run("discover", "--platform", "codex", "--format", tt.format)
 
-			e2e.AssertSuccess(t, result)
+			skillsynctest.AssertSuccess(t, result)
 			// Check that at least one expected pattern is present
 			found := false
 			for _, want := range tt.wantAny {
@@ -499,9 +499,8 @@ func TestDiscoverOutputFormats(t *testing.T) {
 // See: https://github.com/klauern/skillsync/issues
 
 // TestDiscoverWithSkills verifies discover finds skills from fixtures.
-// SKIP: discover doesn't use environment variable overrides for platform paths
 func TestDiscoverWithSkills(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create test skills in Claude Code fixture
 	claudeFixture := h.ClaudeCodeFixture()
@@ -510,19 +509,18 @@ func TestDiscoverWithSkills(t *testing.T) {
 
 	result := h.Run("discover")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "test-skill-one")
-	e2e.AssertOutputContains(t, result, "test-skill-two")
-	e2e.AssertOutputContains(t, result, "First test skill")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "test-skill-one")
+	skillsynctest.AssertOutputContains(t, result, "test-skill-two")
+	skillsynctest.AssertOutputContains(t, result, "First test skill")
 	// NOTE: Exact count assertion removed because discover now includes
 	// installed plugin skills from ~/.claude/plugins/cache/, which varies
 	// by user environment. The test verifies fixture skills are discovered.
 }
 
 // TestDiscoverMultiplePlatforms verifies discover finds skills from multiple platforms.
-// SKIP: discover doesn't use environment variable overrides for platform paths
 func TestDiscoverMultiplePlatforms(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create skills in multiple platforms
 	claudeFixture := h.ClaudeCodeFixture()
@@ -534,19 +532,16 @@ func TestDiscoverMultiplePlatforms(t *testing.T) {
 	// Run discover without platform filter
 	result := h.Run("discover")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "claude-skill")
-	e2e.AssertOutputContains(t, result, "cursor-skill")
-	e2e.AssertOutputContains(t, result, "claude-code")
-	e2e.AssertOutputContains(t, result, "cursor")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "claude-skill")
+	skillsynctest.AssertOutputContains(t, result, "cursor-skill")
+	skillsynctest.AssertOutputContains(t, result, "claude-code")
+	skillsynctest.AssertOutputContains(t, result, "cursor")
 }
 
 // TestDiscoverPlatformFilterWithSkills verifies platform filter shows only matching skills.
-// SKIP: discover doesn't use environment variable overrides for platform paths
 func TestDiscoverPlatformFilterWithSkills(t *testing.T) {
-	t.Skip("discover command uses hardcoded paths, not environment overrides - needs fix")
-
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create skills in multiple platforms
 	claudeFixture := h.ClaudeCodeFixture()
@@ -558,17 +553,14 @@ func TestDiscoverPlatformFilterWithSkills(t *testing.T) {
 	// Filter to Claude Code only
 	result := h.Run("discover", "--platform", "claude-code")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "claude-skill")
-	e2e.AssertOutputNotContains(t, result, "cursor-skill")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "claude-skill")
+	skillsynctest.AssertOutputNotContains(t, result, "cursor-skill")
 }
 
 // TestDiscoverJSONFormatWithSkills verifies JSON output contains skill data.
-// SKIP: discover doesn't use environment variable overrides for platform paths
 func TestDiscoverJSONFormatWithSkills(t *testing.T) {
-	t.Skip("discover command uses hardcoded paths, not environment overrides - needs fix")
-
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create a test skill
 	claudeFixture := h.ClaudeCodeFixture()
@@ -576,21 +568,18 @@ func TestDiscoverJSONFormatWithSkills(t *testing.T) {
 
 	result := h.Run("discover", "--format", "json")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Verify JSON structure
 	if !strings.HasPrefix(strings.TrimSpace(result.Stdout), "[") {
 		t.Errorf("expected JSON array starting with [, got: %s", result.Stdout)
 	}
-	e2e.AssertOutputContains(t, result, `"name": "json-test-skill"`)
-	e2e.AssertOutputContains(t, result, `"platform": "claude-code"`)
+	skillsynctest.AssertOutputContains(t, result, `"name": "json-test-skill"`)
+	skillsynctest.AssertOutputContains(t, result, `"platform": "claude-code"`)
 }
 
 // TestDiscoverYAMLFormatWithSkills verifies YAML output contains skill data.
-// SKIP: discover doesn't use environment variable overrides for platform paths
 func TestDiscoverYAMLFormatWithSkills(t *testing.T) {
-	t.Skip("discover command uses hardcoded paths, not environment overrides - needs fix")
-
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create a test skill
 	claudeFixture := h.ClaudeCodeFixture()
@@ -598,17 +587,14 @@ func TestDiscoverYAMLFormatWithSkills(t *testing.T) {
 
 	result := h.Run("discover", "--format", "yaml")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "name: yaml-test-skill")
-	e2e.AssertOutputContains(t, result, "platform: claude-code")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "name: yaml-test-skill")
+	skillsynctest.AssertOutputContains(t, result, "platform: claude-code")
 }
 
 // TestDiscoverTableFormatWithSkills verifies table output structure.
-// SKIP: discover doesn't use environment variable overrides for platform paths
 func TestDiscoverTableFormatWithSkills(t *testing.T) {
-	t.Skip("discover command uses hardcoded paths, not environment overrides - needs fix")
-
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create a test skill
 	claudeFixture := h.ClaudeCodeFixture()
@@ -616,49 +602,49 @@ func TestDiscoverTableFormatWithSkills(t *testing.T) {
 
 	result := h.Run("discover", "--format", "table")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Verify table headers
-	e2e.AssertOutputContains(t, result, "NAME")
-	e2e.AssertOutputContains(t, result, "PLATFORM")
-	e2e.AssertOutputContains(t, result, "DESCRIPTION")
+	skillsynctest.AssertOutputContains(t, result, "NAME")
+	skillsynctest.AssertOutputContains(t, result, "PLATFORM")
+	skillsynctest.AssertOutputContains(t, result, "DESCRIPTION")
 	// Verify skill data
-	e2e.AssertOutputContains(t, result, "table-test-skill")
-	e2e.AssertOutputContains(t, result, "Total: 1 skill(s)")
+	skillsynctest.AssertOutputContains(t, result, "table-test-skill")
+	skillsynctest.AssertOutputContains(t, result, "Total: 1 skill(s)")
 }
 
 // TestDiscoverInvalidPlatform verifies discover rejects invalid platform.
 func TestDiscoverInvalidPlatform(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("discover", "--platform", "invalid-platform")
 
-	e2e.AssertError(t, result)
-	e2e.AssertErrorContains(t, result, "invalid platform")
+	skillsynctest.AssertError(t, result)
+	skillsynctest.AssertErrorContains(t, result, "invalid platform")
 }
 
 // TestDiscoverInvalidFormat verifies discover rejects invalid format.
 func TestDiscoverInvalidFormat(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("discover", "--format", "invalid-format")
 
-	e2e.AssertError(t, result)
-	e2e.AssertErrorContains(t, result, "unsupported format")
+	skillsynctest.AssertError(t, result)
+	skillsynctest.AssertErrorContains(t, result, "unsupported format")
 }
 
 // TestDiscoverHelp verifies discover help output.
 func TestDiscoverHelp(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("discover", "--help")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "USAGE")
-	e2e.AssertOutputContains(t, result, "--platform")
-	e2e.AssertOutputContains(t, result, "--format")
-	e2e.AssertOutputContains(t, result, "--no-plugins")
-	e2e.AssertOutputContains(t, result, "--repo")
-	e2e.AssertOutputContains(t, result, "--no-cache")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "USAGE")
+	skillsynctest.AssertOutputContains(t, result, "--platform")
+	skillsynctest.AssertOutputContains(t, result, "--format")
+	skillsynctest.AssertOutputContains(t, result, "--no-plugins")
+	skillsynctest.AssertOutputContains(t, result, "--repo")
+	skillsynctest.AssertOutputContains(t, result, "--no-cache")
 }
 
 // TestDiscoverAliases verifies discover command aliases work.
@@ -667,26 +653,26 @@ func TestDiscoverAliases(t *testing.T) {
 
 	for _, alias := range aliases {
 		t.Run(alias, func(t *testing.T) {
-			h := e2e.NewHarness(t)
+			h := skillsynctest.NewHarness(t)
 
 			result := h.Run(alias)
 
-			e2e.AssertSuccess(t, result)
+			skillsynctest.AssertSuccess(t, result)
 		})
 	}
 }
 
 // TestDiscoverShortFlags verifies short flag versions work.
 func TestDiscoverShortFlags(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Test -p for --platform (use codex to minimize output)
 	result := h.Run("discover", "-p", "codex")
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Test -f for --format (use codex to minimize output, verify JSON starts with [ or null)
 	result = h.Run("discover", "-p", "codex", "-f", "json")
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	trimmed := strings.TrimSpace(result.Stdout)
 	if !strings.HasPrefix(trimmed, "[") && trimmed != "null" {
 		t.Errorf("expected JSON output starting with [ or null, got: %s", result.Stdout)
@@ -697,23 +683,23 @@ func TestDiscoverShortFlags(t *testing.T) {
 // NOTE: Full export E2E tests require the CLI to respect SKILLSYNC_*_PATH
 // environment variables in all code paths, which is tracked separately.
 func TestExportHelp(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("export", "--help")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Export skills")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Export skills")
 }
 
 // TestHelpFlag verifies --help works for main command.
 func TestHelpFlag(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("--help")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "skillsync")
-	e2e.AssertOutputContains(t, result, "COMMANDS")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "skillsync")
+	skillsynctest.AssertOutputContains(t, result, "COMMANDS")
 }
 
 // TestSubcommandHelp verifies --help works for subcommands.
@@ -722,12 +708,12 @@ func TestSubcommandHelp(t *testing.T) {
 
 	for _, cmd := range subcommands {
 		t.Run(cmd, func(t *testing.T) {
-			h := e2e.NewHarness(t)
+			h := skillsynctest.NewHarness(t)
 
 			result := h.Run(cmd, "--help")
 
-			e2e.AssertSuccess(t, result)
-			e2e.AssertOutputContains(t, result, "USAGE")
+			skillsynctest.AssertSuccess(t, result)
+			skillsynctest.AssertOutputContains(t, result, "USAGE")
 		})
 	}
 }
@@ -738,44 +724,44 @@ func TestSubcommandHelp(t *testing.T) {
 
 // TestSyncHelp verifies sync command help output includes all strategies.
 func TestSyncHelp(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("sync", "--help")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "USAGE")
-	e2e.AssertOutputContains(t, result, "--dry-run")
-	e2e.AssertOutputContains(t, result, "--strategy")
-	e2e.AssertOutputContains(t, result, "overwrite")
-	e2e.AssertOutputContains(t, result, "skip")
-	e2e.AssertOutputContains(t, result, "newer")
-	e2e.AssertOutputContains(t, result, "merge")
-	e2e.AssertOutputContains(t, result, "three-way")
-	e2e.AssertOutputContains(t, result, "interactive")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "USAGE")
+	skillsynctest.AssertOutputContains(t, result, "--dry-run")
+	skillsynctest.AssertOutputContains(t, result, "--strategy")
+	skillsynctest.AssertOutputContains(t, result, "overwrite")
+	skillsynctest.AssertOutputContains(t, result, "skip")
+	skillsynctest.AssertOutputContains(t, result, "newer")
+	skillsynctest.AssertOutputContains(t, result, "merge")
+	skillsynctest.AssertOutputContains(t, result, "three-way")
+	skillsynctest.AssertOutputContains(t, result, "interactive")
 }
 
 // TestSyncInvalidStrategy verifies sync command rejects invalid strategies.
 func TestSyncInvalidStrategy(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("sync", "--strategy", "invalid-strategy", "--yes", "--skip-validation", "claudecode", "cursor")
 
-	e2e.AssertError(t, result)
-	e2e.AssertErrorContains(t, result, "invalid strategy")
+	skillsynctest.AssertError(t, result)
+	skillsynctest.AssertErrorContains(t, result, "invalid strategy")
 }
 
 // TestSyncSamePlatform verifies sync fails when source and target are the same.
 func TestSyncSamePlatform(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	result := h.Run("sync", "--yes", "--skip-validation", "claudecode", "claudecode")
 
-	e2e.AssertError(t, result)
+	skillsynctest.AssertError(t, result)
 }
 
 // TestSyncCreatesNewSkill verifies sync creates a skill in empty target.
 func TestSyncCreatesNewSkill(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create a skill in Claude Code source
 	claudeFixture := h.ClaudeCodeFixture()
@@ -787,18 +773,18 @@ func TestSyncCreatesNewSkill(t *testing.T) {
 	// Run sync with --yes to skip confirmation
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Created")
-	e2e.AssertOutputContains(t, result, "1")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Created")
+	skillsynctest.AssertOutputContains(t, result, "1")
 
 	// Verify skill was created in target
-	e2e.AssertFileExists(t, cursorFixture.Path("new-skill.md"))
-	e2e.AssertFileContains(t, cursorFixture.Path("new-skill.md"), "new-skill")
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("new-skill.md"))
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("new-skill.md"), "new-skill")
 }
 
 // TestSyncMultipleSkills verifies sync handles multiple skills.
 func TestSyncMultipleSkills(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create multiple skills in Claude Code
 	claudeFixture := h.ClaudeCodeFixture()
@@ -812,19 +798,19 @@ func TestSyncMultipleSkills(t *testing.T) {
 	// Run sync
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Created")
-	e2e.AssertOutputContains(t, result, "3")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Created")
+	skillsynctest.AssertOutputContains(t, result, "3")
 
 	// Verify all skills were created
-	e2e.AssertFileExists(t, cursorFixture.Path("skill-one.md"))
-	e2e.AssertFileExists(t, cursorFixture.Path("skill-two.md"))
-	e2e.AssertFileExists(t, cursorFixture.Path("skill-three.md"))
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("skill-one.md"))
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("skill-two.md"))
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("skill-three.md"))
 }
 
 // TestSyncDryRunNoChanges verifies dry-run doesn't modify files.
 func TestSyncDryRunNoChanges(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create a skill in Claude Code
 	claudeFixture := h.ClaudeCodeFixture()
@@ -836,16 +822,16 @@ func TestSyncDryRunNoChanges(t *testing.T) {
 	// Run sync with dry-run
 	result := h.Run("sync", "--dry-run", "--skip-validation", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Dry run")
-	e2e.AssertOutputContains(t, result, "Created")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Dry run")
+	skillsynctest.AssertOutputContains(t, result, "Created")
 
 	// Verify skill was NOT created in target
-	e2e.AssertFileNotExists(t, cursorFixture.Path("dry-test.md"))
+	skillsynctest.AssertFileNotExists(t, cursorFixture.Path("dry-test.md"))
 }
 
 func TestSyncDeleteModeDeletes(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	src := h.ClaudeCodeFixture()
 	src.WriteSkill("del.md", "del", "", "# del")
@@ -854,12 +840,12 @@ func TestSyncDeleteModeDeletes(t *testing.T) {
 	tgt.WriteSkill("del.md", "del", "", "# del")
 
 	result := h.RunWithStdin("y\n", "delete", "--skip-backup", "claudecode", "cursor")
-	e2e.AssertSuccess(t, result)
-	e2e.AssertFileNotExists(t, tgt.Path("del.md"))
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertFileNotExists(t, tgt.Path("del.md"))
 }
 
 func TestSyncDeleteModeDryRun(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	src := h.ClaudeCodeFixture()
 	src.WriteSkill("del.md", "del", "", "# del")
@@ -868,31 +854,31 @@ func TestSyncDeleteModeDryRun(t *testing.T) {
 	tgt.WriteSkill("del.md", "del", "", "# del")
 
 	result := h.Run("delete", "--dry-run", "--skip-backup", "claudecode", "cursor")
-	e2e.AssertSuccess(t, result)
-	e2e.AssertFileExists(t, tgt.Path("del.md"))
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertFileExists(t, tgt.Path("del.md"))
 }
 
 func TestSyncValidatesSourceSkills(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	src := h.ClaudeCodeFixture()
 	src.WriteSkill("valid.md", "valid", "", "# valid")
 	h.CursorFixture()
 
 	result := h.RunWithStdin("y\n", "sync", "claudecode", "cursor")
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Validating source skills")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Validating source skills")
 }
 
 func TestCompareCommandBasic(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	src := h.ClaudeCodeFixture()
 	src.WriteSkill("a.md", "alpha", "", "# A")
 	src.WriteSkill("b.md", "alpha-copy", "", "# A")
 
 	result := h.Run("compare", "--platform", "claude-code", "--format", "summary")
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 }
 
 // ============================================================================
@@ -901,7 +887,7 @@ func TestCompareCommandBasic(t *testing.T) {
 
 // TestSyncOverwriteStrategy verifies overwrite strategy replaces existing skills.
 func TestSyncOverwriteStrategy(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create source skill with new content
 	claudeFixture := h.ClaudeCodeFixture()
@@ -914,17 +900,17 @@ func TestSyncOverwriteStrategy(t *testing.T) {
 	// Run sync with overwrite strategy (default)
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "overwrite", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Updated")
-	e2e.AssertOutputContains(t, result, "1")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Updated")
+	skillsynctest.AssertOutputContains(t, result, "1")
 
 	// Verify target was overwritten with source content
-	e2e.AssertFileContains(t, cursorFixture.Path("overwrite-test.md"), "New content from source")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("overwrite-test.md"), "New content from source")
 }
 
 // TestSyncSkipStrategy verifies skip strategy preserves existing skills.
 func TestSyncSkipStrategy(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create source skill
 	claudeFixture := h.ClaudeCodeFixture()
@@ -937,17 +923,17 @@ func TestSyncSkipStrategy(t *testing.T) {
 	// Run sync with skip strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "skip", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Skipped")
-	e2e.AssertOutputContains(t, result, "1")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Skipped")
+	skillsynctest.AssertOutputContains(t, result, "1")
 
 	// Verify target was NOT overwritten - still has original content
-	e2e.AssertFileContains(t, cursorFixture.Path("skip-test.md"), "Original content in target")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("skip-test.md"), "Original content in target")
 }
 
 // TestSyncSkipStrategyWithNewSkill verifies skip strategy still creates new skills.
 func TestSyncSkipStrategyWithNewSkill(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create source skills - one existing, one new
 	claudeFixture := h.ClaudeCodeFixture()
@@ -961,21 +947,21 @@ func TestSyncSkipStrategyWithNewSkill(t *testing.T) {
 	// Run sync with skip strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "skip", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Created")
-	e2e.AssertOutputContains(t, result, "Skipped")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Created")
+	skillsynctest.AssertOutputContains(t, result, "Skipped")
 
 	// Verify existing skill was not modified
-	e2e.AssertFileContains(t, cursorFixture.Path("existing-skill.md"), "Target's existing content")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("existing-skill.md"), "Target's existing content")
 
 	// Verify new skill was created
-	e2e.AssertFileExists(t, cursorFixture.Path("brand-new-skill.md"))
-	e2e.AssertFileContains(t, cursorFixture.Path("brand-new-skill.md"), "New content")
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("brand-new-skill.md"))
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("brand-new-skill.md"), "New content")
 }
 
 // TestSyncMergeStrategy verifies merge strategy combines content.
 func TestSyncMergeStrategy(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create source skill
 	claudeFixture := h.ClaudeCodeFixture()
@@ -988,9 +974,9 @@ func TestSyncMergeStrategy(t *testing.T) {
 	// Run sync with merge strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "merge", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Merged")
-	e2e.AssertOutputContains(t, result, "1")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Merged")
+	skillsynctest.AssertOutputContains(t, result, "1")
 
 	// Verify target contains merged content (both source and target content present)
 	content := cursorFixture.ReadFile("merge-test.md")
@@ -1005,7 +991,7 @@ func TestSyncMergeStrategy(t *testing.T) {
 
 // TestSyncThreeWayNoConflict verifies three-way merge with identical content skips.
 func TestSyncThreeWayNoConflict(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create identical skills in source and target
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1017,14 +1003,14 @@ func TestSyncThreeWayNoConflict(t *testing.T) {
 	// Run sync with three-way strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "three-way", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// With identical content, should be skipped
-	e2e.AssertOutputContains(t, result, "Skipped")
+	skillsynctest.AssertOutputContains(t, result, "Skipped")
 }
 
 // TestSyncThreeWayContentConflict verifies three-way merge detects content differences.
 func TestSyncThreeWayContentConflict(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create source skill with different content
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1037,7 +1023,7 @@ func TestSyncThreeWayContentConflict(t *testing.T) {
 	// Run sync with three-way strategy - should detect conflict
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "three-way", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Three-way should either merge or report conflict
 	output := result.Stdout
 	if !strings.Contains(output, "Merged") && !strings.Contains(output, "Conflict") {
@@ -1047,7 +1033,7 @@ func TestSyncThreeWayContentConflict(t *testing.T) {
 
 // TestSyncThreeWayMetadataConflict verifies three-way merge detects metadata differences.
 func TestSyncThreeWayMetadataConflict(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create source skill with one description
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1060,14 +1046,14 @@ func TestSyncThreeWayMetadataConflict(t *testing.T) {
 	// Run sync with three-way strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "three-way", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Metadata-only conflicts may be handled as merged or conflict
 	// The key is that the sync completes successfully
 }
 
 // TestSyncShowsConflictDetails verifies conflict information is displayed.
 func TestSyncShowsConflictDetails(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create conflicting skills
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1079,9 +1065,9 @@ func TestSyncShowsConflictDetails(t *testing.T) {
 	// Run sync with three-way strategy (which reports conflicts)
 	result := h.Run("sync", "--dry-run", "--skip-validation", "--strategy", "three-way", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Output should show the skill name and some indication of what happened
-	e2e.AssertOutputContains(t, result, "show-conflict")
+	skillsynctest.AssertOutputContains(t, result, "show-conflict")
 }
 
 // ============================================================================
@@ -1090,7 +1076,7 @@ func TestSyncShowsConflictDetails(t *testing.T) {
 
 // TestSyncClaudeCodeToCursor verifies sync from Claude Code to Cursor.
 func TestSyncClaudeCodeToCursor(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	claudeFixture := h.ClaudeCodeFixture()
 	claudeFixture.WriteSkill("platform-test.md", "platform-test", "Cross-platform skill", "# Platform Test\n\nWorks across platforms.")
@@ -1099,13 +1085,13 @@ func TestSyncClaudeCodeToCursor(t *testing.T) {
 
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertFileExists(t, cursorFixture.Path("platform-test.md"))
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("platform-test.md"))
 }
 
 // TestSyncCursorToClaudeCode verifies sync from Cursor to Claude Code.
 func TestSyncCursorToClaudeCode(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	cursorFixture := h.CursorFixture()
 	cursorFixture.WriteSkill("reverse-test.md", "reverse-test", "Reverse sync skill", "# Reverse Test\n\nFrom Cursor to Claude.")
@@ -1114,13 +1100,13 @@ func TestSyncCursorToClaudeCode(t *testing.T) {
 
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "cursor", "claudecode")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertFileExists(t, claudeFixture.Path("reverse-test.md"))
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertFileExists(t, claudeFixture.Path("reverse-test.md"))
 }
 
 // TestSyncClaudeCodeToCodex verifies sync from Claude Code to Codex.
 func TestSyncClaudeCodeToCodex(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	claudeFixture := h.ClaudeCodeFixture()
 	claudeFixture.WriteSkill("codex-test.md", "codex-test", "To Codex", "# Codex Test\n\nContent for Codex.")
@@ -1129,9 +1115,9 @@ func TestSyncClaudeCodeToCodex(t *testing.T) {
 
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "claudecode", "codex")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Codex may transform the file differently
-	e2e.AssertOutputContains(t, result, "Created")
+	skillsynctest.AssertOutputContains(t, result, "Created")
 	// Verify something was created in codex directory
 	if !codexFixture.Exists("AGENTS.md") && !codexFixture.Exists("codex-test.md") {
 		// Codex might aggregate into AGENTS.md or use individual files
@@ -1139,10 +1125,32 @@ func TestSyncClaudeCodeToCodex(t *testing.T) {
 	}
 }
 
+// TestSyncClaudeCodeToAider verifies skills are aggregated into Aider's
+// CONVENTIONS.md and that it is added to the Aider config.
+func TestSyncClaudeCodeToAider(t *testing.T) {
+	h := skillsynctest.NewHarness(t)
+
+	claudeFixture := h.ClaudeCodeFixture()
+	claudeFixture.WriteSkill("aider-test.md", "aider-test", "To Aider", "# Aider Test\n\nContent for Aider.")
+
+	aiderFixture := h.AiderFixture()
+
+	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "claudecode", "aider")
+
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertFileContains(t, aiderFixture.Path("CONVENTIONS.md"), `<!-- skillsync:begin name="aider-test" description="To Aider" -->`)
+	skillsynctest.AssertFileContains(t, aiderFixture.Path("CONVENTIONS.md"), "Content for Aider.")
+	skillsynctest.AssertFileContains(t, filepath.Join(h.HomeDir(), ".aider.conf.yml"), aiderFixture.Path("CONVENTIONS.md"))
+
+	result = h.Run("discover", "--platform", "aider", "--format", "json")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, `"name": "aider-test"`)
+}
+
 // TestDiscoverClaudeCommandArtifactsAsPrompts verifies command-style files are
 // discovered as prompt artifacts.
 func TestDiscoverClaudeCommandArtifactsAsPrompts(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	claudeFixture := h.ClaudeCodeFixture()
 	claudeFixture.WriteFile("review.md", `---
@@ -1154,16 +1162,16 @@ allowed-tools: Bash, Read
 Review this code.`)
 
 	result := h.Run("discover", "--platform", "claudecode", "--type", "prompt", "--format", "json", "--no-plugins")
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, `"name": "review"`)
-	e2e.AssertOutputContains(t, result, `"type": "prompt"`)
-	e2e.AssertOutputContains(t, result, `"trigger": "/review"`)
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, `"name": "review"`)
+	skillsynctest.AssertOutputContains(t, result, `"type": "prompt"`)
+	skillsynctest.AssertOutputContains(t, result, `"trigger": "/review"`)
 }
 
 // TestSyncClaudeCommandToCodexWithIncludePrompts verifies prompt/command
 // artifacts can be synced to Codex when explicitly enabled.
 func TestSyncClaudeCommandToCodexWithIncludePrompts(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	claudeFixture := h.ClaudeCodeFixture()
 	claudeFixture.WriteFile("review.md", `---
@@ -1178,9 +1186,9 @@ Review this code.`)
 	codexFixture := h.CodexFixture()
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--include-prompts", "claudecode", "codex")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "lossy mapping")
-	e2e.AssertFileExists(t, codexFixture.Path("review/SKILL.md"))
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "lossy mapping")
+	skillsynctest.AssertFileExists(t, codexFixture.Path("review/SKILL.md"))
 }
 
 // ============================================================================
@@ -1189,7 +1197,7 @@ Review this code.`)
 
 // TestSyncEmptySource verifies sync handles empty source directory.
 func TestSyncEmptySource(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create empty source directory
 	h.ClaudeCodeFixture()
@@ -1197,14 +1205,14 @@ func TestSyncEmptySource(t *testing.T) {
 
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// Should complete successfully with no changes
-	e2e.AssertOutputContains(t, result, "0")
+	skillsynctest.AssertOutputContains(t, result, "0")
 }
 
 // TestSyncWithSpecialCharactersInName verifies sync handles special characters.
 func TestSyncWithSpecialCharactersInName(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	claudeFixture := h.ClaudeCodeFixture()
 	// Use a skill name with special characters (but valid for filenames)
@@ -1214,13 +1222,13 @@ func TestSyncWithSpecialCharactersInName(t *testing.T) {
 
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertFileExists(t, cursorFixture.Path("my-special_skill.md"))
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("my-special_skill.md"))
 }
 
 // TestSyncPreservesSkillMetadata verifies metadata is preserved during sync.
 func TestSyncPreservesSkillMetadata(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	claudeFixture := h.ClaudeCodeFixture()
 	claudeFixture.WriteSkill("metadata-skill.md", "metadata-skill", "Preserve this description", "# Metadata Skill\n\nContent to sync.")
@@ -1229,7 +1237,7 @@ func TestSyncPreservesSkillMetadata(t *testing.T) {
 
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Verify metadata was preserved
 	content := cursorFixture.ReadFile("metadata-skill.md")
@@ -1243,7 +1251,7 @@ func TestSyncPreservesSkillMetadata(t *testing.T) {
 
 // TestSyncMixedActions verifies sync handles mixed create/update/skip correctly.
 func TestSyncMixedActions(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create 3 skills in source
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1259,17 +1267,17 @@ func TestSyncMixedActions(t *testing.T) {
 	// Run with skip strategy so we can see all three actions
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "skip", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Should have 1 created (new-skill), 2 skipped (update-skill, skip-skill)
-	e2e.AssertOutputContains(t, result, "Created")
-	e2e.AssertOutputContains(t, result, "Skipped")
+	skillsynctest.AssertOutputContains(t, result, "Created")
+	skillsynctest.AssertOutputContains(t, result, "Skipped")
 
 	// Verify new skill was created
-	e2e.AssertFileExists(t, cursorFixture.Path("new-skill.md"))
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("new-skill.md"))
 
 	// Verify skipped skills weren't modified
-	e2e.AssertFileContains(t, cursorFixture.Path("update-skill.md"), "Old content")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("update-skill.md"), "Old content")
 }
 
 // ============================================================================
@@ -1278,7 +1286,7 @@ func TestSyncMixedActions(t *testing.T) {
 
 // TestSyncNewerStrategySourceNewer verifies newer strategy copies when source is newer.
 func TestSyncNewerStrategySourceNewer(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create old target file first
 	cursorFixture := h.CursorFixture()
@@ -1299,16 +1307,16 @@ func TestSyncNewerStrategySourceNewer(t *testing.T) {
 	// Run sync with newer strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "newer", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Updated")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Updated")
 
 	// Verify target was updated with source content (source is newer)
-	e2e.AssertFileContains(t, cursorFixture.Path("newer-test.md"), "New source content")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("newer-test.md"), "New source content")
 }
 
 // TestSyncNewerStrategyTargetNewer verifies newer strategy skips when target is newer.
 func TestSyncNewerStrategyTargetNewer(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create source file first
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1328,16 +1336,16 @@ func TestSyncNewerStrategyTargetNewer(t *testing.T) {
 	// Run sync with newer strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "newer", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Skipped")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Skipped")
 
 	// Verify target was NOT updated (target is newer)
-	e2e.AssertFileContains(t, cursorFixture.Path("older-test.md"), "New target content")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("older-test.md"), "New target content")
 }
 
 // TestSyncNewerStrategyNewFile verifies newer strategy creates new files.
 func TestSyncNewerStrategyNewFile(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create source skill with no corresponding target
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1349,12 +1357,12 @@ func TestSyncNewerStrategyNewFile(t *testing.T) {
 	// Run sync with newer strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "newer", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Created")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Created")
 
 	// Verify new skill was created
-	e2e.AssertFileExists(t, cursorFixture.Path("brand-new.md"))
-	e2e.AssertFileContains(t, cursorFixture.Path("brand-new.md"), "Brand new skill")
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("brand-new.md"))
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("brand-new.md"), "Brand new skill")
 }
 
 // ============================================================================
@@ -1363,7 +1371,7 @@ func TestSyncNewerStrategyNewFile(t *testing.T) {
 
 // TestSyncInteractiveUseSource verifies interactive strategy with source selection.
 func TestSyncInteractiveUseSource(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create conflicting skills
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1380,15 +1388,15 @@ func TestSyncInteractiveUseSource(t *testing.T) {
 	//   4. Skip this skill
 	result := h.RunWithStdin("1\n", "sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "interactive", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Verify target was overwritten with source
-	e2e.AssertFileContains(t, cursorFixture.Path("interactive-test.md"), "Source content")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("interactive-test.md"), "Source content")
 }
 
 // TestSyncInteractiveKeepTarget verifies interactive strategy with target selection.
 func TestSyncInteractiveKeepTarget(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create conflicting skills
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1405,15 +1413,15 @@ func TestSyncInteractiveKeepTarget(t *testing.T) {
 	//   4. Skip this skill
 	result := h.RunWithStdin("2\n", "sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "interactive", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Verify target was NOT modified (kept original)
-	e2e.AssertFileContains(t, cursorFixture.Path("keep-target.md"), "Original target content")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("keep-target.md"), "Original target content")
 }
 
 // TestSyncInteractiveSkip verifies interactive strategy skip option.
 func TestSyncInteractiveSkip(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create conflicting skills
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1430,15 +1438,15 @@ func TestSyncInteractiveSkip(t *testing.T) {
 	//   4. Skip this skill
 	result := h.RunWithStdin("4\n", "sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "interactive", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Verify target was NOT modified (skipped)
-	e2e.AssertFileContains(t, cursorFixture.Path("skip-test.md"), "Original content to preserve")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("skip-test.md"), "Original content to preserve")
 }
 
 // TestSyncInteractiveAutoMerge verifies interactive strategy auto-merge option.
 func TestSyncInteractiveAutoMerge(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create conflicting skills
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1455,7 +1463,7 @@ func TestSyncInteractiveAutoMerge(t *testing.T) {
 	//   4. Skip this skill
 	result := h.RunWithStdin("3\n", "sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "interactive", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Verify content was merged (both should appear or conflict markers present)
 	content := cursorFixture.ReadFile("auto-merge.md")
@@ -1471,7 +1479,7 @@ func TestSyncInteractiveAutoMerge(t *testing.T) {
 
 // TestSyncInteractiveNoConflicts verifies interactive with no conflicts skips prompts.
 func TestSyncInteractiveNoConflicts(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create source skill with no existing target
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1482,11 +1490,11 @@ func TestSyncInteractiveNoConflicts(t *testing.T) {
 	// No stdin needed - no conflicts means no prompts
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "interactive", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Created")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Created")
 
 	// Verify skill was created
-	e2e.AssertFileExists(t, cursorFixture.Path("no-conflict.md"))
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("no-conflict.md"))
 }
 
 // ============================================================================
@@ -1495,7 +1503,7 @@ func TestSyncInteractiveNoConflicts(t *testing.T) {
 
 // TestSyncCreatesBackupByDefault verifies sync creates backup when not skipped.
 func TestSyncCreatesBackupByDefault(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create a skill in target that will be overwritten
 	cursorFixture := h.CursorFixture()
@@ -1508,37 +1516,37 @@ func TestSyncCreatesBackupByDefault(t *testing.T) {
 	// Run sync WITHOUT --skip-backup
 	result := h.Run("sync", "--yes", "--skip-validation", "--strategy", "overwrite", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Verify file was updated
-	e2e.AssertFileContains(t, cursorFixture.Path("backup-test.md"), "Updated content")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("backup-test.md"), "Updated content")
 
 	// Check if backup was mentioned in output (backup list should show something)
 	backupResult := h.Run("backup", "list")
-	e2e.AssertSuccess(t, backupResult)
-	e2e.AssertOutputNotContains(t, backupResult, "No backups found")
+	skillsynctest.AssertSuccess(t, backupResult)
+	skillsynctest.AssertOutputNotContains(t, backupResult, "No backups found")
 }
 
 // TestBackupCreateCommand verifies manual backup creation works.
 func TestBackupCreateCommand(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	cursorFixture := h.CursorFixture()
 	cursorFixture.WriteSkill("manual-backup.md", "manual-backup", "Manual backup", "# Manual Backup\n\nContent.")
 
 	result := h.Run("backup", "create", "--platform", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Created")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Created")
 
 	backupResult := h.Run("backup", "list", "--platform", "cursor")
-	e2e.AssertSuccess(t, backupResult)
-	e2e.AssertOutputNotContains(t, backupResult, "No backups found")
+	skillsynctest.AssertSuccess(t, backupResult)
+	skillsynctest.AssertOutputNotContains(t, backupResult, "No backups found")
 }
 
 // TestSyncSkipBackupFlag verifies --skip-backup prevents backup creation.
 func TestSyncSkipBackupFlag(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create a skill in target
 	cursorFixture := h.CursorFixture()
@@ -1551,15 +1559,15 @@ func TestSyncSkipBackupFlag(t *testing.T) {
 	// Run sync WITH --skip-backup
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "overwrite", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Verify file was updated
-	e2e.AssertFileContains(t, cursorFixture.Path("no-backup-test.md"), "Updated content")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("no-backup-test.md"), "Updated content")
 
 	// Verify no backup was created
 	backupResult := h.Run("backup", "list")
-	e2e.AssertSuccess(t, backupResult)
-	e2e.AssertOutputContains(t, backupResult, "No backups found")
+	skillsynctest.AssertSuccess(t, backupResult)
+	skillsynctest.AssertOutputContains(t, backupResult, "No backups found")
 }
 
 // ============================================================================
@@ -1568,7 +1576,7 @@ func TestSyncSkipBackupFlag(t *testing.T) {
 
 // TestSyncThreeWayWithConflictMarkers verifies three-way merge can produce conflict markers.
 func TestSyncThreeWayWithConflictMarkers(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create heavily conflicting content that can't be auto-merged cleanly
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1590,7 +1598,7 @@ func TestSyncThreeWayWithConflictMarkers(t *testing.T) {
 	// Run sync with three-way strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "three-way", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Read the result - it should either be merged or have conflict markers
 	content := cursorFixture.ReadFile("markers-test.md")
@@ -1612,7 +1620,7 @@ func TestSyncThreeWayWithConflictMarkers(t *testing.T) {
 
 // TestSyncThreeWayCleanMerge verifies three-way merge handles non-conflicting changes.
 func TestSyncThreeWayCleanMerge(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create content where changes are in different areas (clean merge possible)
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1632,7 +1640,7 @@ func TestSyncThreeWayCleanMerge(t *testing.T) {
 	// Run sync with three-way strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "three-way", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Verify the sync completed - exact merge result depends on implementation
 	content := cursorFixture.ReadFile("clean-merge.md")
@@ -1647,7 +1655,7 @@ func TestSyncThreeWayCleanMerge(t *testing.T) {
 
 // TestSyncMultipleConflicts verifies handling of multiple conflicts in one sync.
 func TestSyncMultipleConflicts(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create multiple conflicting skills
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1663,19 +1671,19 @@ func TestSyncMultipleConflicts(t *testing.T) {
 	// Run sync with overwrite strategy to resolve all conflicts
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "overwrite", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Updated")
-	e2e.AssertOutputContains(t, result, "3")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Updated")
+	skillsynctest.AssertOutputContains(t, result, "3")
 
 	// Verify all were updated
-	e2e.AssertFileContains(t, cursorFixture.Path("conflict1.md"), "Source version 1")
-	e2e.AssertFileContains(t, cursorFixture.Path("conflict2.md"), "Source version 2")
-	e2e.AssertFileContains(t, cursorFixture.Path("conflict3.md"), "Source version 3")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("conflict1.md"), "Source version 1")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("conflict2.md"), "Source version 2")
+	skillsynctest.AssertFileContains(t, cursorFixture.Path("conflict3.md"), "Source version 3")
 }
 
 // TestSyncMixedConflictAndNew verifies handling mixed new files and conflicts.
 func TestSyncMixedConflictAndNew(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create source with mix of new and conflicting
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1690,13 +1698,13 @@ func TestSyncMixedConflictAndNew(t *testing.T) {
 	// Run sync with merge strategy
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--strategy", "merge", "claudecode", "cursor")
 
-	e2e.AssertSuccess(t, result)
-	e2e.AssertOutputContains(t, result, "Created")
-	e2e.AssertOutputContains(t, result, "Merged")
+	skillsynctest.AssertSuccess(t, result)
+	skillsynctest.AssertOutputContains(t, result, "Created")
+	skillsynctest.AssertOutputContains(t, result, "Merged")
 
 	// Verify new files were created
-	e2e.AssertFileExists(t, cursorFixture.Path("new-file1.md"))
-	e2e.AssertFileExists(t, cursorFixture.Path("new-file2.md"))
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("new-file1.md"))
+	skillsynctest.AssertFileExists(t, cursorFixture.Path("new-file2.md"))
 
 	// Verify existing was merged (should contain both contents)
 	content := cursorFixture.ReadFile("existing.md")
@@ -1713,7 +1721,7 @@ func TestSyncMixedConflictAndNew(t *testing.T) {
 // TestSyncPluginScopeSource verifies sync with plugin scope source spec.
 // This tests the claudecode:plugin syntax to filter skills by plugin scope.
 func TestSyncPluginScopeSource(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Set up a mock plugin cache directory with skills
 	pluginCacheDir := h.HomeDir() + "/.claude/plugins/cache"
@@ -1768,27 +1776,27 @@ This skill comes from a plugin.
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--dry-run", "claudecode:plugin", "cursor")
 
 	// The sync should succeed (even if 0 skills found)
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 
 	// Verify user-skill was NOT synced (we filtered to plugin scope only)
 	// In dry-run mode, files won't be created anyway, but we can check output
-	e2e.AssertOutputNotContains(t, result, "user-skill")
+	skillsynctest.AssertOutputNotContains(t, result, "user-skill")
 }
 
 // TestSyncPluginScopeInvalidTarget verifies plugin scope cannot be used as target.
 func TestSyncPluginScopeInvalidTarget(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Plugin scope should not be allowed as target (only repo and user are writable)
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "claudecode", "cursor:plugin")
 
-	e2e.AssertError(t, result)
-	e2e.AssertErrorContains(t, result, "target scope must be")
+	skillsynctest.AssertError(t, result)
+	skillsynctest.AssertErrorContains(t, result, "target scope must be")
 }
 
 // TestSyncMultipleScopesSource verifies sync with multiple source scopes.
 func TestSyncMultipleScopesSource(t *testing.T) {
-	h := e2e.NewHarness(t)
+	h := skillsynctest.NewHarness(t)
 
 	// Create user-scope skill
 	claudeFixture := h.ClaudeCodeFixture()
@@ -1800,9 +1808,9 @@ func TestSyncMultipleScopesSource(t *testing.T) {
 	// Sync only user and repo scopes (not plugin)
 	result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--dry-run", "claudecode:user,repo", "cursor")
 
-	e2e.AssertSuccess(t, result)
+	skillsynctest.AssertSuccess(t, result)
 	// The user skill should be included in dry-run output
-	e2e.AssertOutputContains(t, result, "user-only")
+	skillsynctest.AssertOutputContains(t, result, "user-only")
 }
 
 // TestSyncScopeSpecParsing verifies various scope spec formats are parsed correctly.
@@ -1861,14 +1869,14 @@ func TestSyncScopeSpecParsing(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			h := e2e.NewHarness(t)
+			h := skillsynctest.NewHarness(t)
 
 			result := h.Run("sync", "--yes", "--skip-backup", "--skip-validation", "--dry-run", tt.source, tt.target)
 
 			if tt.wantError {
-				e2e.AssertError(t, result)
+				skillsynctest.AssertError(t, result)
 			} else {
-				e2e.AssertSuccess(t, result)
+				skillsynctest.AssertSuccess(t, result)
 			}
 		})
 	}
@@ -0,0 +1,261 @@
+package export
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+const (
+	// BundleManifestName is the name of the manifest file inside a bundle.
+	BundleManifestName = "manifest.json"
+	// BundleVersion is the current bundle manifest version.
+	BundleVersion = 1
+
+	// maxBundleFileSize caps the size of a single file read from a bundle.
+	maxBundleFileSize = 10 << 20
+)
+
+// BundleManifest describes the contents of a skill bundle.
+type BundleManifest struct {
+	Version   int           `json:"version"`
+	CreatedAt time.Time     `json:"created_at"`
+	Skills    []BundleSkill `json:"skills"`
+	// Removed lists skills deleted since the previous export, for bundles
+	// written by an incremental export
+	Removed []Tombstone `json:"removed,omitempty"`
+}
+
+// BundleSkill is a manifest entry for a single skill in a bundle.
+// The skill body is stored separately in the archive at File.
+type BundleSkill struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Platform    string            `json:"platform"`
+	Scope       string            `json:"scope,omitempty"`
+	Type        string            `json:"type,omitempty"`
+	Trigger     string            `json:"trigger,omitempty"`
+	Tools       []string          `json:"tools,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	File        string            `json:"file"`
+	SHA256      string            `json:"sha256"`
+}
+
+// bundleFilePath returns the archive path for a skill's body, preserving the
+// original file name so the sync transformer picks the same layout on restore.
+func bundleFilePath(skill model.Skill) string {
+	base := "SKILL.md"
+	if skill.Path != "" {
+		base = filepath.Base(skill.Path)
+	}
+	return path.Join("skills", string(skill.Platform), skill.Name, base)
+}
+
+// exportBundle writes skills as a gzip-compressed tar archive containing
+// manifest.json and one file per skill body, recording removed skills in
+// the manifest.
+func (e *Exporter) exportBundle(skills []model.Skill, removed []Tombstone, w io.Writer) error {
+	manifest := BundleManifest{
+		Version:   BundleVersion,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Skills:    make([]BundleSkill, 0, len(skills)),
+		Removed:   removed,
+	}
+
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		file := bundleFilePath(skill)
+		if seen[file] {
+			return fmt.Errorf("duplicate skill %q for platform %s", skill.Name, skill.Platform)
+		}
+		seen[file] = true
+
+		sum := sha256.Sum256([]byte(skill.Content))
+		entry := BundleSkill{
+			Name:        skill.Name,
+			Description: skill.Description,
+			Platform:    string(skill.Platform),
+			Scope:       string(skill.Scope),
+			Type:        string(skill.Type),
+			Trigger:     skill.Trigger,
+			Tools:       skill.Tools,
+			File:        file,
+			SHA256:      hex.EncodeToString(sum[:]),
+		}
+		if e.opts.IncludeMetadata {
+			entry.Metadata = skill.Metadata
+		}
+		manifest.Skills = append(manifest.Skills, entry)
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := writeTarFile(tw, BundleManifestName, manifestData, manifest.CreatedAt); err != nil {
+		return err
+	}
+	for i, skill := range skills {
+		if err := writeTarFile(tw, manifest.Skills[i].File, []byte(skill.Content), manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize bundle: %w", err)
+	}
+	return gz.Close()
+}
+
+// writeTarFile adds a regular file entry to the archive.
+func writeTarFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write %s header: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// ReadBundle reads a bundle written by the bundle format and returns its manifest
+// and skills. Skill bodies are verified against the manifest checksums.
+// Returned skills have Path set to their archive path.
+func ReadBundle(r io.Reader) (*BundleManifest, []model.Skill, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a gzip bundle: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	var manifestData []byte
+	files := make(map[string][]byte)
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name, err := cleanBundlePath(hdr.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hdr.Size > maxBundleFileSize {
+			return nil, nil, fmt.Errorf("bundle entry %s is too large (%d bytes)", name, hdr.Size)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxBundleFileSize))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if name == BundleManifestName {
+			manifestData = data
+			continue
+		}
+		files[name] = data
+	}
+
+	if manifestData == nil {
+		return nil, nil, fmt.Errorf("bundle has no %s", BundleManifestName)
+	}
+	var manifest BundleManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid %s: %w", BundleManifestName, err)
+	}
+	if manifest.Version < 1 || manifest.Version > BundleVersion {
+		return nil, nil, fmt.Errorf("unsupported bundle version %d", manifest.Version)
+	}
+
+	skills := make([]model.Skill, 0, len(manifest.Skills))
+	for _, entry := range manifest.Skills {
+		skill, err := entry.toSkill(files)
+		if err != nil {
+			return nil, nil, err
+		}
+		skills = append(skills, skill)
+	}
+	return &manifest, skills, nil
+}
+
+// toSkill rebuilds a model.Skill from a manifest entry and the archive files.
+func (b BundleSkill) toSkill(files map[string][]byte) (model.Skill, error) {
+	if err := checkName(b.Name); err != nil {
+		return model.Skill{}, err
+	}
+	file, err := cleanBundlePath(b.File)
+	if err != nil {
+		return model.Skill{}, err
+	}
+	content, ok := files[file]
+	if !ok {
+		return model.Skill{}, fmt.Errorf("bundle is missing %s for skill %q", b.File, b.Name)
+	}
+	sum := sha256.Sum256(content)
+	if b.SHA256 != "" && hex.EncodeToString(sum[:]) != b.SHA256 {
+		return model.Skill{}, fmt.Errorf("checksum mismatch for skill %q", b.Name)
+	}
+
+	platform, err := model.ParsePlatform(b.Platform)
+	if err != nil {
+		return model.Skill{}, fmt.Errorf("skill %q: %w", b.Name, err)
+	}
+
+	skill := model.Skill{
+		Name:        b.Name,
+		Description: b.Description,
+		Platform:    platform,
+		Path:        file,
+		Tools:       b.Tools,
+		Metadata:    b.Metadata,
+		Content:     string(content),
+		Trigger:     b.Trigger,
+	}
+	if b.Type != "" {
+		if skill.Type, err = model.ParseSkillType(b.Type); err != nil {
+			return model.Skill{}, fmt.Errorf("skill %q: %w", b.Name, err)
+		}
+	}
+	if b.Scope != "" {
+		if skill.Scope, err = model.ParseScope(b.Scope); err != nil {
+			return model.Skill{}, fmt.Errorf("skill %q: %w", b.Name, err)
+		}
+	}
+	return skill, nil
+}
+
+// cleanBundlePath normalizes an archive path and rejects absolute paths and
+// entries that escape the archive root.
+func cleanBundlePath(name string) (string, error) {
+	cleaned := path.Clean(strings.ReplaceAll(name, `\`, "/"))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("unsafe path in bundle: %q", name)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,172 @@
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// buildTarGz creates a tar.gz archive from name/content pairs.
+func buildTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("WriteHeader() error = %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar Close() error = %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip Close() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	skills := []model.Skill{
+		{
+			Name:        "review",
+			Description: "Review code",
+			Platform:    model.ClaudeCode,
+			Path:        "/home/u/.claude/skills/review/SKILL.md",
+			Tools:       []string{"Read"},
+			Metadata:    map[string]string{"author": "me"},
+			Content:     "# Review\n\nCheck tests.",
+			Scope:       model.ScopeUser,
+		},
+		{
+			Name:     "commit",
+			Platform: model.Cursor,
+			Path:     "/repo/.cursor/rules/commit.mdc",
+			Content:  "Write good commits.",
+			Type:     model.SkillTypePrompt,
+			Trigger:  "/commit",
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := New(Options{Format: FormatBundle, IncludeMetadata: true}).Export(skills, &buf); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	manifest, got, err := ReadBundle(&buf)
+	if err != nil {
+		t.Fatalf("ReadBundle() error = %v", err)
+	}
+	if manifest.Version != BundleVersion || len(manifest.Skills) != 2 {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 skills, got %d", len(got))
+	}
+
+	review := got[0]
+	if review.Name != "review" || review.Content != skills[0].Content || review.Metadata["author"] != "me" {
+		t.Errorf("review not restored: %+v", review)
+	}
+	if review.Path != "skills/claude-code/review/SKILL.md" {
+		t.Errorf("review path = %q", review.Path)
+	}
+	if review.Scope != model.ScopeUser || len(review.Tools) != 1 {
+		t.Errorf("review fields not restored: %+v", review)
+	}
+
+	commit := got[1]
+	if commit.Type != model.SkillTypePrompt || commit.Trigger != "/commit" || commit.Platform != model.Cursor {
+		t.Errorf("commit not restored: %+v", commit)
+	}
+	if !strings.HasSuffix(commit.Path, "commit.mdc") {
+		t.Errorf("commit path = %q, want original file name", commit.Path)
+	}
+}
+
+func TestBundleExcludesMetadata(t *testing.T) {
+	skills := []model.Skill{{Name: "s", Platform: model.Codex, Content: "x", Metadata: map[string]string{"k": "v"}}}
+
+	var buf bytes.Buffer
+	if err := New(Options{Format: FormatBundle}).Export(skills, &buf); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	_, got, err := ReadBundle(&buf)
+	if err != nil {
+		t.Fatalf("ReadBundle() error = %v", err)
+	}
+	if len(got[0].Metadata) != 0 {
+		t.Errorf("expected no metadata, got %v", got[0].Metadata)
+	}
+}
+
+func TestBundleRejectsDuplicates(t *testing.T) {
+	skills := []model.Skill{
+		{Name: "s", Platform: model.Codex, Content: "a"},
+		{Name: "s", Platform: model.Codex, Content: "b"},
+	}
+	var buf bytes.Buffer
+	if err := New(Options{Format: FormatBundle}).Export(skills, &buf); err == nil {
+		t.Error("expected error for duplicate skills")
+	}
+}
+
+func TestReadBundleErrors(t *testing.T) {
+	validManifest := `{"version":1,"skills":[{"name":"s","platform":"codex","file":"skills/codex/s/SKILL.md","sha256":"%s"}]}`
+
+	tests := map[string]struct {
+		data []byte
+		want string
+	}{
+		"not gzip": {
+			data: []byte("plain text"),
+			want: "not a gzip bundle",
+		},
+		"missing manifest": {
+			data: buildTarGz(t, map[string]string{"skills/codex/s/SKILL.md": "x"}),
+			want: "no manifest.json",
+		},
+		"unsupported version": {
+			data: buildTarGz(t, map[string]string{"manifest.json": `{"version":99}`}),
+			want: "unsupported bundle version",
+		},
+		"missing skill file": {
+			data: buildTarGz(t, map[string]string{"manifest.json": strings.Replace(validManifest, "%s", "", 1)}),
+			want: "missing",
+		},
+		"checksum mismatch": {
+			data: buildTarGz(t, map[string]string{
+				"manifest.json":           strings.Replace(validManifest, "%s", "deadbeef", 1),
+				"skills/codex/s/SKILL.md": "x",
+			}),
+			want: "checksum mismatch",
+		},
+		"path traversal": {
+			data: buildTarGz(t, map[string]string{"../evil": "x"}),
+			want: "unsafe path",
+		},
+		"traversal in skill name": {
+			data: buildTarGz(t, map[string]string{
+				"manifest.json":           `{"version":1,"skills":[{"name":"../../../escaped","platform":"codex","file":"skills/codex/s/SKILL.md"}]}`,
+				"skills/codex/s/SKILL.md": "x",
+			}),
+			want: "invalid skill name",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := ReadBundle(bytes.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ReadBundle() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,3 +1,6 @@
 // Package export provides functionality to export skills to different formats.
-// Supported formats include JSON, YAML, and Markdown.
+// Supported formats include JSON, YAML, Markdown, tar.gz bundles that
+// can be restored with ReadBundle, a provenance inventory of
+// plugin-sourced skills for compliance audits, and CSV and Excel tables
+// for auditing skill inventories in a spreadsheet.
 package export
@@ -1,14 +1,18 @@
 package export
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
 )
 
 // Format represents the output format for exported skills.
@@ -21,12 +25,26 @@ const (
 	FormatYAML Format = "yaml"
 	// FormatMarkdown exports skills as Markdown.
 	FormatMarkdown Format = "markdown"
+	// FormatBundle exports skills as a tar.gz archive with a manifest.
+	FormatBundle Format = "bundle"
+	// FormatInventory exports a JSON provenance inventory of plugin-sourced skills.
+	FormatInventory Format = "inventory"
+	// FormatCSV exports a spreadsheet-friendly table of skills as CSV.
+	FormatCSV Format = "csv"
+	// FormatXLSX exports a table of skills as an Excel workbook.
+	FormatXLSX Format = "xlsx"
+	// FormatFiles exports each skill as its own SKILL.md in a directory
+	// tree; see Exporter.ExportFiles.
+	FormatFiles Format = "files"
+	// FormatHTML exports a static site with an index and one page per
+	// skill; see Exporter.ExportHTML.
+	FormatHTML Format = "html"
 )
 
 // IsValid returns true if the format is recognized.
 func (f Format) IsValid() bool {
 	switch f {
-	case FormatJSON, FormatYAML, FormatMarkdown:
+	case FormatJSON, FormatYAML, FormatMarkdown, FormatBundle, FormatInventory, FormatCSV, FormatXLSX, FormatFiles, FormatHTML:
 		return true
 	default:
 		return false
@@ -40,14 +58,14 @@ func (f Format) String() string {
 
 // AllFormats returns all supported export formats.
 func AllFormats() []Format {
-	return []Format{FormatJSON, FormatYAML, FormatMarkdown}
+	return []Format{FormatJSON, FormatYAML, FormatMarkdown, FormatBundle, FormatInventory, FormatCSV, FormatXLSX, FormatFiles, FormatHTML}
 }
 
 // ParseFormat parses a string into a Format.
 func ParseFormat(s string) (Format, error) {
 	format := Format(strings.ToLower(strings.TrimSpace(s)))
 	if !format.IsValid() {
-		return "", fmt.Errorf("unsupported format %q (valid: json, yaml, markdown)", s)
+		return "", fmt.Errorf("unsupported format %q (valid: json, yaml, markdown, bundle, inventory, csv, xlsx, files, html)", s)
 	}
 	return format, nil
 }
@@ -62,6 +80,9 @@ type Options struct {
 	IncludeMetadata bool
 	// Platform filters skills by platform (empty means all).
 	Platform model.Platform
+	// Columns selects the columns of the CSV and XLSX formats, in order
+	// (empty means DefaultColumns).
+	Columns []Column
 }
 
 // DefaultOptions returns the default export options.
@@ -95,6 +116,18 @@ func (e *Exporter) Export(skills []model.Skill, w io.Writer) error {
 		return e.exportYAML(filtered, w)
 	case FormatMarkdown:
 		return e.exportMarkdown(filtered, w)
+	case FormatBundle:
+		return e.exportBundle(filtered, nil, w)
+	case FormatInventory:
+		return e.exportInventory(filtered, w)
+	case FormatCSV:
+		return e.exportCSV(filtered, w)
+	case FormatXLSX:
+		return e.exportXLSX(filtered, w)
+	case FormatFiles:
+		return errors.New("the files format writes a directory, not a stream; use ExportFiles")
+	case FormatHTML:
+		return errors.New("the html format writes a directory, not a stream; use ExportHTML")
 	default:
 		return fmt.Errorf("unsupported format: %s", e.opts.Format)
 	}
@@ -153,6 +186,128 @@ func (e *Exporter) toExportSkill(skill model.Skill) exportSkill {
 	return es
 }
 
+// checkName rejects a skill name read from an export that is not a valid
+// skill name. Imports write skills to paths built from their names, so a
+// name such as "../x" would escape the target's skills directory.
+func checkName(name string) error {
+	if err := parser.ValidateSkillName(name); err != nil {
+		return fmt.Errorf("invalid skill name: %w", err)
+	}
+	return nil
+}
+
+// toSkill rebuilds a model.Skill from an exported skill.
+func (es exportSkill) toSkill() (model.Skill, error) {
+	if es.Name == "" {
+		return model.Skill{}, errors.New("skill without a name")
+	}
+	if err := checkName(es.Name); err != nil {
+		return model.Skill{}, err
+	}
+	platform, err := model.ParsePlatform(es.Platform)
+	if err != nil {
+		return model.Skill{}, fmt.Errorf("skill %q: %w", es.Name, err)
+	}
+	skill := model.Skill{
+		Name:        es.Name,
+		Description: es.Description,
+		Platform:    platform,
+		Path:        es.Path,
+		Tools:       es.Tools,
+		Metadata:    es.Metadata,
+		Content:     es.Content,
+	}
+	if es.ModifiedAt != "" {
+		if skill.ModifiedAt, err = time.Parse(time.RFC3339, es.ModifiedAt); err != nil {
+			return model.Skill{}, fmt.Errorf("skill %q: invalid modified_at: %w", es.Name, err)
+		}
+	}
+	return skill, nil
+}
+
+// ReadJSON reads skills written by the JSON format: a list of skills, or the
+// document of an incremental export, whose removed skills are returned too.
+func ReadJSON(r io.Reader) ([]model.Skill, []Tombstone, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var doc incrementalExport
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, nil, fmt.Errorf("invalid JSON export: %w", err)
+		}
+	} else if err := json.Unmarshal(data, &doc.Skills); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON export: %w", err)
+	}
+
+	skills := make([]model.Skill, 0, len(doc.Skills))
+	for i, es := range doc.Skills {
+		skill, err := es.toSkill()
+		if err != nil {
+			return nil, nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		skills = append(skills, skill)
+	}
+	return skills, doc.Removed, nil
+}
+
+// ReadYAML reads skills written by the YAML format.
+func ReadYAML(r io.Reader) ([]model.Skill, error) {
+	var exported []exportSkill
+	if err := yaml.NewDecoder(r).Decode(&exported); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid YAML export: %w", err)
+	}
+
+	skills := make([]model.Skill, 0, len(exported))
+	for i, es := range exported {
+		skill, err := es.toSkill()
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		skills = append(skills, skill)
+	}
+	return skills, nil
+}
+
+// DetectFormat returns the format of an export from its first bytes: a
+// gzip stream is a bundle, a JSON array or object is JSON, and anything
+// else is read as YAML.
+func DetectFormat(data []byte) Format {
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		return FormatBundle
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// Read reads skills written by the JSON, YAML, or bundle format, detecting
+// which from the data, and returns the format it read.
+func Read(r io.Reader) (Format, []model.Skill, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	format := DetectFormat(data)
+	var skills []model.Skill
+	switch format {
+	case FormatBundle:
+		_, skills, err = ReadBundle(bytes.NewReader(data))
+	case FormatJSON:
+		skills, _, err = ReadJSON(bytes.NewReader(data))
+	default:
+		skills, err = ReadYAML(bytes.NewReader(data))
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return format, skills, nil
+}
+
 // exportJSON exports skills as JSON.
 func (e *Exporter) exportJSON(skills []model.Skill, w io.Writer) error {
 	exported := make([]exportSkill, len(skills))
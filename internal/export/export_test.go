@@ -37,6 +37,12 @@ func TestFormat_IsValid(t *testing.T) {
 		{FormatJSON, true},
 		{FormatYAML, true},
 		{FormatMarkdown, true},
+		{FormatBundle, true},
+		{FormatInventory, true},
+		{FormatCSV, true},
+		{FormatXLSX, true},
+		{FormatFiles, true},
+		{FormatHTML, true},
 		{Format("invalid"), false},
 		{Format(""), false},
 	}
@@ -92,14 +98,20 @@ func TestFormatStringAndParseFormat(t *testing.T) {
 
 func TestAllFormats(t *testing.T) {
 	formats := AllFormats()
-	if len(formats) != 3 {
-		t.Errorf("AllFormats() returned %d formats, want 3", len(formats))
+	if len(formats) != 9 {
+		t.Errorf("AllFormats() returned %d formats, want 9", len(formats))
 	}
 
 	expected := map[Format]bool{
-		FormatJSON:     true,
-		FormatYAML:     true,
-		FormatMarkdown: true,
+		FormatJSON:      true,
+		FormatYAML:      true,
+		FormatMarkdown:  true,
+		FormatBundle:    true,
+		FormatInventory: true,
+		FormatCSV:       true,
+		FormatXLSX:      true,
+		FormatFiles:     true,
+		FormatHTML:      true,
 	}
 
 	for _, f := range formats {
@@ -592,3 +604,132 @@ func TestExporter_Markdown_Multiple_Golden(t *testing.T) {
 
 	util.GoldenFile(t, testdataDir(), "markdown-multiple", buf.String())
 }
+
+func TestReadJSON(t *testing.T) {
+	skills := []model.Skill{
+		{
+			Name:        "review",
+			Description: "Review code",
+			Platform:    model.ClaudeCode,
+			Path:        "/skills/review/SKILL.md",
+			Content:     "Check tests.",
+			ModifiedAt:  time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{Name: "lint", Platform: model.Cursor, Content: "Run the linter."},
+	}
+
+	tests := map[string]struct {
+		input       func(t *testing.T) string
+		wantSkills  int
+		wantRemoved int
+		wantErr     string
+	}{
+		"list": {
+			input: func(t *testing.T) string {
+				var buf bytes.Buffer
+				util.AssertNoError(t, New(Options{Format: FormatJSON, IncludeMetadata: true}).Export(skills, &buf))
+				return buf.String()
+			},
+			wantSkills: 2,
+		},
+		"incremental": {
+			input: func(t *testing.T) string {
+				var buf bytes.Buffer
+				changes := Changes{Skills: skills[:1], Removed: []Tombstone{{Name: "old", Platform: "cursor"}}}
+				util.AssertNoError(t, New(Options{Format: FormatJSON}).ExportChanges(changes, &buf))
+				return buf.String()
+			},
+			wantSkills:  1,
+			wantRemoved: 1,
+		},
+		"unknown platform": {
+			input:   func(*testing.T) string { return `[{"name": "x", "platform": "vim", "content": "x"}]` },
+			wantErr: `entry 1: skill "x"`,
+		},
+		"traversal in skill name": {
+			input: func(*testing.T) string {
+				return `[{"name": "../../../viajson", "platform": "claude-code", "content": "x"}]`
+			},
+			wantErr: "entry 1: invalid skill name",
+		},
+		"not JSON": {
+			input:   func(*testing.T) string { return "name: x\n" },
+			wantErr: "invalid JSON export",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, removed, err := ReadJSON(strings.NewReader(tt.input(t)))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReadJSON() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(got), tt.wantSkills)
+			util.AssertEqual(t, len(removed), tt.wantRemoved)
+			util.AssertEqual(t, got[0].Name, skills[0].Name)
+			util.AssertEqual(t, got[0].Platform, skills[0].Platform)
+			util.AssertEqual(t, got[0].Content, skills[0].Content)
+		})
+	}
+
+	t.Run("metadata round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		util.AssertNoError(t, New(Options{Format: FormatJSON, IncludeMetadata: true}).Export(skills[:1], &buf))
+		got, _, err := ReadJSON(&buf)
+		util.AssertNoError(t, err)
+		util.AssertEqual(t, got[0].ModifiedAt.Equal(skills[0].ModifiedAt), true)
+		util.AssertEqual(t, got[0].Path, skills[0].Path)
+	})
+}
+
+func TestRead(t *testing.T) {
+	skills := []model.Skill{
+		{Name: "review", Description: "Review code", Platform: model.ClaudeCode, Content: "Check tests."},
+		{Name: "lint", Platform: model.Cursor, Content: "Run the linter."},
+	}
+
+	tests := map[string]struct {
+		format  Format
+		input   string
+		wantErr bool
+	}{
+		"json":              {format: FormatJSON},
+		"yaml":              {format: FormatYAML},
+		"bundle":            {format: FormatBundle},
+		"bad yaml":          {input: "name: [", wantErr: true},
+		"bad gzip":          {input: "\x1f\x8bnot gzip", wantErr: true},
+		"bad entry":         {input: "- name: x\n  platform: vim\n", wantErr: true},
+		"traversal in yaml": {input: "- name: ../../escaped\n  platform: claude-code\n", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := tt.input
+			if tt.format != "" {
+				var buf bytes.Buffer
+				util.AssertNoError(t, New(Options{Format: tt.format, Pretty: true}).Export(skills, &buf))
+				input = buf.String()
+			}
+
+			format, got, err := Read(strings.NewReader(input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Read(): expected an error")
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, format, tt.format)
+			util.AssertEqual(t, len(got), len(skills))
+			for i, skill := range got {
+				util.AssertEqual(t, skill.Name, skills[i].Name)
+				util.AssertEqual(t, skill.Platform, skills[i].Platform)
+				util.AssertEqual(t, strings.TrimSpace(skill.Content), skills[i].Content)
+			}
+		})
+	}
+}
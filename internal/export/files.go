@@ -0,0 +1,192 @@
+package export
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+const (
+	// FilesSkillName is the name of the skill file in each skill directory
+	// of the files format.
+	FilesSkillName = "SKILL.md"
+	// FilesMetadataName is the name of the metadata file in each skill
+	// directory of the files format.
+	FilesMetadataName = "metadata.json"
+)
+
+// fileFrontmatter is the normalized frontmatter of a SKILL.md written by the
+// files format. Fields are in a fixed order so re-exports diff cleanly.
+type fileFrontmatter struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Type        string   `yaml:"type,omitempty"`
+	Trigger     string   `yaml:"trigger,omitempty"`
+	Tools       []string `yaml:"tools,omitempty"`
+	License     string   `yaml:"license,omitempty"`
+}
+
+// FileMetadata is the metadata.json written next to each SKILL.md by the
+// files format.
+type FileMetadata struct {
+	Name string `json:"name"`
+	// SHA256 is the checksum of the skill body.
+	SHA256 string `json:"sha256"`
+	// Sources lists the platforms the skill was exported from; a skill with
+	// the same content on several platforms is written once.
+	Sources []FileSource `json:"sources"`
+	// Metadata holds the frontmatter fields that are not part of the
+	// normalized SKILL.md, such as Cursor globs.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// FileSource is a platform a skill written by the files format came from.
+type FileSource struct {
+	Platform   string `json:"platform"`
+	Scope      string `json:"scope,omitempty"`
+	Path       string `json:"path,omitempty"`
+	ModifiedAt string `json:"modified_at,omitempty"`
+}
+
+// fileSkill is a skill directory of the files format.
+type fileSkill struct {
+	content  []byte
+	metadata FileMetadata
+}
+
+// ExportFiles writes each skill as dir/<name>/SKILL.md with a normalized
+// frontmatter, plus a metadata.json recording where it came from unless
+// metadata is excluded. Skills with the same name must have the same
+// content. It returns the skill directories written, in name order.
+// Existing files of the exported skills are replaced; other files in dir
+// are left alone.
+func (e *Exporter) ExportFiles(skills []model.Skill, dir string) ([]string, error) {
+	byName := make(map[string]*fileSkill)
+	from := make(map[string]model.Platform)
+	for _, skill := range e.filterByPlatform(skills) {
+		if !filepath.IsLocal(skill.Name) || strings.ContainsAny(skill.Name, `/\`) {
+			return nil, fmt.Errorf("skill name %q cannot be used as a directory name", skill.Name)
+		}
+		content, err := normalizedSkillFile(skill)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, ok := byName[skill.Name]
+		if !ok {
+			sum := sha256.Sum256([]byte(skill.Content))
+			existing = &fileSkill{
+				content:  content,
+				metadata: FileMetadata{Name: skill.Name, SHA256: hex.EncodeToString(sum[:]), Metadata: extraMetadata(skill)},
+			}
+			byName[skill.Name] = existing
+			from[skill.Name] = skill.Platform
+		} else if !bytes.Equal(existing.content, content) {
+			return nil, fmt.Errorf("skill %q differs between %s and %s (use --platform to export one)", skill.Name, from[skill.Name], skill.Platform)
+		}
+		existing.metadata.Sources = append(existing.metadata.Sources, fileSource(skill))
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	written := make([]string, 0, len(names))
+	for _, name := range names {
+		skillDir := filepath.Join(dir, name)
+		// #nosec G301 - exported skills are meant to be shared
+		if err := os.MkdirAll(skillDir, 0o755); err != nil {
+			return written, fmt.Errorf("failed to create %s: %w", skillDir, err)
+		}
+		// #nosec G306 - exported skills are meant to be shared
+		if err := os.WriteFile(filepath.Join(skillDir, FilesSkillName), byName[name].content, 0o644); err != nil {
+			return written, fmt.Errorf("failed to write skill %q: %w", name, err)
+		}
+		if e.opts.IncludeMetadata {
+			data, err := json.MarshalIndent(byName[name].metadata, "", "  ")
+			if err != nil {
+				return written, fmt.Errorf("failed to encode metadata of %q: %w", name, err)
+			}
+			// #nosec G306 - exported skills are meant to be shared
+			if err := os.WriteFile(filepath.Join(skillDir, FilesMetadataName), append(data, '\n'), 0o644); err != nil {
+				return written, fmt.Errorf("failed to write metadata of %q: %w", name, err)
+			}
+		}
+		written = append(written, skillDir)
+	}
+	return written, nil
+}
+
+// normalizedSkillFile returns a skill as a SKILL.md with the normalized
+// frontmatter and the body ending in a single newline.
+func normalizedSkillFile(skill model.Skill) ([]byte, error) {
+	fm := fileFrontmatter{
+		Name:        skill.Name,
+		Description: firstNonEmpty(skill.Description, skill.Metadata["description"]),
+		Trigger:     skill.Trigger,
+		Tools:       skill.Tools,
+		License:     skill.License,
+	}
+	if skill.Type == model.SkillTypePrompt {
+		fm.Type = string(skill.Type)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(fm); err != nil {
+		return nil, fmt.Errorf("failed to encode frontmatter of %q: %w", skill.Name, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode frontmatter of %q: %w", skill.Name, err)
+	}
+	buf.WriteString("---\n\n")
+	if body := strings.TrimSpace(skill.Content); body != "" {
+		buf.WriteString(body)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// extraMetadata returns the metadata of a skill without the fields of the
+// normalized frontmatter, which some parsers also keep in the metadata.
+func extraMetadata(skill model.Skill) map[string]string {
+	extra := make(map[string]string, len(skill.Metadata))
+	for key, value := range skill.Metadata {
+		switch key {
+		case "name", "description", "type", "trigger", "tools", "license":
+			continue
+		}
+		extra[key] = value
+	}
+	if len(extra) == 0 {
+		return nil
+	}
+	return extra
+}
+
+// fileSource returns where a skill written by the files format came from.
+func fileSource(skill model.Skill) FileSource {
+	source := FileSource{
+		Platform: string(skill.Platform),
+		Scope:    string(skill.Scope),
+		Path:     skill.Path,
+	}
+	if !skill.ModifiedAt.IsZero() {
+		source.ModifiedAt = skill.ModifiedAt.UTC().Format(time.RFC3339)
+	}
+	return source
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestExporter_ExportFiles(t *testing.T) {
+	fixedTime := time.Date(2024, 6, 15, 10, 30, 0, 0, time.UTC)
+	review := model.Skill{
+		Name:        "review",
+		Description: "Review code",
+		Platform:    model.ClaudeCode,
+		Scope:       model.ScopeUser,
+		Path:        "/home/dev/.claude/skills/review/SKILL.md",
+		Tools:       []string{"Read", "Grep"},
+		Metadata:    map[string]string{"owner": "platform-team"},
+		Content:     "\nCheck tests.\n\n",
+		ModifiedAt:  fixedTime,
+	}
+	cursorReview := review
+	cursorReview.Platform = model.Cursor
+	cursorReview.Path = "/repo/.cursor/skills/review.md"
+	cursorReview.Scope = model.ScopeRepo
+	changedReview := cursorReview
+	changedReview.Content = "Check tests and docs."
+	lint := model.Skill{Name: "lint", Platform: model.Cursor, Type: model.SkillTypePrompt, Trigger: "/lint", Content: "Run the linter."}
+
+	tests := map[string]struct {
+		skills      []model.Skill
+		opts        Options
+		wantDirs    []string
+		wantSources int // sources in the metadata of review
+		wantErr     string
+	}{
+		"one dir per skill": {
+			skills:      []model.Skill{review, lint},
+			opts:        Options{IncludeMetadata: true},
+			wantDirs:    []string{"lint", "review"},
+			wantSources: 1,
+		},
+		"same skill on two platforms": {
+			skills:      []model.Skill{review, cursorReview},
+			opts:        Options{IncludeMetadata: true},
+			wantDirs:    []string{"review"},
+			wantSources: 2,
+		},
+		"platform filter": {
+			skills:      []model.Skill{review, changedReview},
+			opts:        Options{IncludeMetadata: true, Platform: model.ClaudeCode},
+			wantDirs:    []string{"review"},
+			wantSources: 1,
+		},
+		"without metadata": {
+			skills:   []model.Skill{review},
+			wantDirs: []string{"review"},
+		},
+		"differing skill on two platforms": {
+			skills:  []model.Skill{review, changedReview},
+			wantErr: `skill "review" differs between claude-code and cursor`,
+		},
+		"unsafe name": {
+			skills:  []model.Skill{{Name: "../escape", Platform: model.Cursor}},
+			wantErr: "cannot be used as a directory name",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			tt.opts.Format = FormatFiles
+			written, err := New(tt.opts).ExportFiles(tt.skills, dir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExportFiles() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(written), len(tt.wantDirs))
+			for i, skillDir := range written {
+				util.AssertEqual(t, skillDir, filepath.Join(dir, tt.wantDirs[i]))
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, "review", FilesSkillName))
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, string(data), "---\nname: review\ndescription: Review code\ntools:\n  - Read\n  - Grep\n---\n\nCheck tests.\n")
+
+			data, err = os.ReadFile(filepath.Join(dir, "review", FilesMetadataName))
+			if tt.wantSources == 0 {
+				if !os.IsNotExist(err) {
+					t.Fatalf("metadata written without IncludeMetadata: %v", err)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			var metadata FileMetadata
+			util.AssertNoError(t, json.Unmarshal(data, &metadata))
+			util.AssertEqual(t, len(metadata.Sources), tt.wantSources)
+			util.AssertEqual(t, metadata.Sources[0].Path, review.Path)
+			util.AssertEqual(t, metadata.Sources[0].ModifiedAt, "2024-06-15T10:30:00Z")
+			util.AssertEqual(t, metadata.Metadata["owner"], "platform-team")
+		})
+	}
+}
+
+func TestNormalizedSkillFile_Prompt(t *testing.T) {
+	data, err := normalizedSkillFile(model.Skill{Name: "lint", Type: model.SkillTypePrompt, Trigger: "/lint", Content: "Run the linter."})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), "---\nname: lint\ntype: prompt\ntrigger: /lint\n---\n\nRun the linter.\n")
+}
+
+func TestExporter_Export_Files(t *testing.T) {
+	var buf strings.Builder
+	if err := New(Options{Format: FormatFiles}).Export(nil, &buf); err == nil {
+		t.Error("Export() with the files format: expected an error")
+	}
+}
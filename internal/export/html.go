@@ -0,0 +1,221 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/browse"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+const (
+	// HTMLIndexName is the name of the index page of the html format.
+	HTMLIndexName = "index.html"
+	// htmlSkillsDir is the directory of the skill pages of the html format,
+	// which holds one subdirectory per platform.
+	htmlSkillsDir = "skills"
+)
+
+const htmlLayoutTemplate = `{{define "layout"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} · skill catalog</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 0; color: #1f2328; }
+header { background: #24292f; color: #fff; padding: 0.75rem 1.5rem; }
+header a { color: #fff; text-decoration: none; font-weight: 600; }
+main { max-width: 960px; margin: 0 auto; padding: 1.5rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.4rem 0.6rem; border-bottom: 1px solid #d0d7de; vertical-align: top; }
+.muted { color: #656d76; font-size: 0.9em; }
+.badge { display: inline-block; padding: 0 0.4rem; border-radius: 0.5rem; background: #ddf4ff; font-size: 0.85em; }
+.markdown { border: 1px solid #d0d7de; border-radius: 6px; padding: 0 1rem; }
+pre { background: #f6f8fa; padding: 0.75rem; overflow-x: auto; }
+</style>
+</head>
+<body>
+<header><a href="{{.Root}}index.html">Skill catalog</a></header>
+<main>{{template "content" .}}</main>
+</body>
+</html>{{end}}`
+
+const htmlIndexTemplate = `{{define "content"}}
+<p class="muted">{{len .Skills}} skills</p>
+<h1>By platform</h1>
+{{range .Platforms}}<h2 id="platform-{{.Name}}">{{.Name}}</h2>
+<table>
+<thead><tr><th>Name</th><th>Scope</th><th>Description</th><th>Tags</th></tr></thead>
+<tbody>
+{{range .Skills}}<tr>
+<td><a href="{{.Link}}">{{.Skill.Name}}</a></td>
+<td>{{.Skill.DisplayScope}}</td>
+<td>{{.Skill.Description}}</td>
+<td>{{range $i, $t := .Tags}}{{if $i}}, {{end}}<a href="#tag-{{$t}}">{{$t}}</a>{{end}}</td>
+</tr>{{end}}
+</tbody>
+</table>
+{{end}}
+<h1>By tag</h1>
+{{range .Tags}}<h2 id="tag-{{.Name}}">{{.Name}}</h2>
+<ul>
+{{range .Skills}}<li><a href="{{.Link}}">{{.Skill.Name}}</a> <span class="badge">{{.Skill.Platform}}</span>{{with .Skill.Description}} <span class="muted">{{.}}</span>{{end}}</li>
+{{end}}</ul>
+{{else}}<p class="muted">No skills are tagged.</p>
+{{end}}{{end}}`
+
+const htmlSkillTemplate = `{{define "content"}}
+<h1>{{.Skill.Name}} <span class="badge">{{.Skill.Platform}}</span></h1>
+{{with .Skill.Description}}<p>{{.}}</p>{{end}}
+{{with .Tags}}<p class="muted">Tags: {{range $i, $t := .}}{{if $i}}, {{end}}<a href="{{$.Root}}index.html#tag-{{$t}}">{{$t}}</a>{{end}}</p>{{end}}
+{{if .Metadata}}<p class="muted">{{with .Skill.Path}}{{.}} · {{end}}{{.Skill.DisplayScope}}{{with .Modified}} · modified {{.}}{{end}}</p>
+{{with .Skill.Tools}}<p class="muted">Tools: {{range $i, $t := .}}{{if $i}}, {{end}}{{$t}}{{end}}</p>{{end}}{{end}}
+<div class="markdown">{{.Body}}</div>
+{{end}}`
+
+// htmlEntry is a skill listed in the html format.
+type htmlEntry struct {
+	Skill model.Skill
+	Tags  []string
+	// Link is the path of the skill page relative to the index.
+	Link string
+}
+
+// htmlGroup is a platform or tag section of the index page.
+type htmlGroup struct {
+	Name   string
+	Skills []htmlEntry
+}
+
+// ExportHTML writes the skills as a static site in dir: an index.html
+// listing the skills by platform and by tag, and one page per skill at
+// skills/<platform>/<name>.html with the rendered Markdown body. Path,
+// scope, tools, and modification times are shown unless metadata is
+// excluded. It returns the pages written, index first. Existing pages of
+// the exported skills are replaced; other files in dir are left alone.
+func (e *Exporter) ExportHTML(skills []model.Skill, dir string) ([]string, error) {
+	filtered := e.filterByPlatform(skills)
+	entries := make([]htmlEntry, 0, len(filtered))
+	seen := make(map[string]bool, len(filtered))
+	for _, skill := range filtered {
+		if !filepath.IsLocal(skill.Name) || strings.ContainsAny(skill.Name, `/\`) {
+			return nil, fmt.Errorf("skill name %q cannot be used as a file name", skill.Name)
+		}
+		link := path.Join(htmlSkillsDir, string(skill.Platform), skill.Name+".html")
+		if seen[link] {
+			return nil, fmt.Errorf("skill %q appears twice on %s (use --platform or a scope filter to export one)", skill.Name, skill.Platform)
+		}
+		seen[link] = true
+		entries = append(entries, htmlEntry{Skill: skill, Tags: skill.Tags(), Link: link})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Skill.Platform != entries[j].Skill.Platform {
+			return entries[i].Skill.Platform < entries[j].Skill.Platform
+		}
+		return entries[i].Skill.Name < entries[j].Skill.Name
+	})
+
+	layout, err := template.New("layout").Parse(htmlLayoutTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html layout: %w", err)
+	}
+	index, err := template.Must(layout.Clone()).Parse(htmlIndexTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html index: %w", err)
+	}
+	page, err := template.Must(layout.Clone()).Parse(htmlSkillTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html skill page: %w", err)
+	}
+
+	indexPath := filepath.Join(dir, HTMLIndexName)
+	err = writeHTMLPage(index, indexPath, map[string]any{
+		"Title":     "Skills",
+		"Root":      "",
+		"Skills":    entries,
+		"Platforms": groupByPlatform(entries),
+		"Tags":      groupByTag(entries),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	written := []string{indexPath}
+	for _, entry := range entries {
+		var modified string
+		if !entry.Skill.ModifiedAt.IsZero() {
+			modified = entry.Skill.ModifiedAt.UTC().Format(time.RFC3339)
+		}
+		pagePath := filepath.Join(dir, filepath.FromSlash(entry.Link))
+		err := writeHTMLPage(page, pagePath, map[string]any{
+			"Title":    entry.Skill.Name,
+			"Root":     "../../",
+			"Skill":    entry.Skill,
+			"Tags":     entry.Tags,
+			"Metadata": e.opts.IncludeMetadata,
+			"Modified": modified,
+			"Body":     browse.RenderMarkdown(entry.Skill.Content),
+		})
+		if err != nil {
+			return written, err
+		}
+		written = append(written, pagePath)
+	}
+	return written, nil
+}
+
+// writeHTMLPage renders a page of the html format to path.
+func writeHTMLPage(tmpl *template.Template, path string, data map[string]any) error {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", path, err)
+	}
+	// #nosec G301 - exported pages are meant to be shared
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	// #nosec G306 - exported pages are meant to be shared
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// groupByPlatform groups entries sorted by platform and name into one
+// section per platform.
+func groupByPlatform(entries []htmlEntry) []htmlGroup {
+	var groups []htmlGroup
+	for _, entry := range entries {
+		name := string(entry.Skill.Platform)
+		if len(groups) == 0 || groups[len(groups)-1].Name != name {
+			groups = append(groups, htmlGroup{Name: name})
+		}
+		groups[len(groups)-1].Skills = append(groups[len(groups)-1].Skills, entry)
+	}
+	return groups
+}
+
+// groupByTag returns one section per tag in tag order. Untagged skills are
+// only listed by platform.
+func groupByTag(entries []htmlEntry) []htmlGroup {
+	byTag := make(map[string][]htmlEntry)
+	for _, entry := range entries {
+		for _, tag := range entry.Tags {
+			byTag[tag] = append(byTag[tag], entry)
+		}
+	}
+	groups := make([]htmlGroup, 0, len(byTag))
+	for tag, tagged := range byTag {
+		groups = append(groups, htmlGroup{Name: tag, Skills: tagged})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
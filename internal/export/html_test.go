@@ -0,0 +1,102 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestExporter_ExportHTML(t *testing.T) {
+	review := model.Skill{
+		Name:        "review",
+		Description: "Review <code>",
+		Platform:    model.ClaudeCode,
+		Scope:       model.ScopeUser,
+		Path:        "/home/dev/.claude/skills/review/SKILL.md",
+		Metadata:    map[string]string{"tags": "[quality security]"},
+		Content:     "# Review\n\n- Check **tests**\n",
+		ModifiedAt:  time.Date(2024, 6, 15, 10, 30, 0, 0, time.UTC),
+	}
+	cursorReview := review
+	cursorReview.Platform = model.Cursor
+	lint := model.Skill{Name: "lint", Platform: model.Cursor, Metadata: map[string]string{"tags": "quality"}, Content: "Run the linter."}
+
+	tests := map[string]struct {
+		skills    []model.Skill
+		opts      Options
+		wantPages []string
+		wantIndex []string
+		wantErr   string
+	}{
+		"grouped by platform and tag": {
+			skills:    []model.Skill{lint, review, cursorReview},
+			opts:      Options{IncludeMetadata: true},
+			wantPages: []string{"index.html", "skills/claude-code/review.html", "skills/cursor/lint.html", "skills/cursor/review.html"},
+			wantIndex: []string{`id="platform-claude-code"`, `id="platform-cursor"`, `id="tag-quality"`, `id="tag-security"`, "Review &lt;code&gt;"},
+		},
+		"platform filter": {
+			skills:    []model.Skill{lint, review},
+			opts:      Options{Platform: model.ClaudeCode},
+			wantPages: []string{"index.html", "skills/claude-code/review.html"},
+			wantIndex: []string{`href="skills/claude-code/review.html"`},
+		},
+		"duplicate page": {
+			skills:  []model.Skill{review, review},
+			wantErr: `skill "review" appears twice on claude-code`,
+		},
+		"unsafe name": {
+			skills:  []model.Skill{{Name: "../escape", Platform: model.Cursor}},
+			wantErr: "cannot be used as a file name",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			tt.opts.Format = FormatHTML
+			written, err := New(tt.opts).ExportHTML(tt.skills, dir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExportHTML() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(written), len(tt.wantPages))
+			for i, page := range written {
+				util.AssertEqual(t, page, filepath.Join(dir, filepath.FromSlash(tt.wantPages[i])))
+			}
+
+			index, err := os.ReadFile(filepath.Join(dir, HTMLIndexName))
+			util.AssertNoError(t, err)
+			for _, want := range tt.wantIndex {
+				if !strings.Contains(string(index), want) {
+					t.Errorf("index missing %q", want)
+				}
+			}
+
+			page, err := os.ReadFile(filepath.Join(dir, "skills", "claude-code", "review.html"))
+			util.AssertNoError(t, err)
+			for _, want := range []string{"<h1>Review</h1>", "<li>Check <strong>tests</strong></li>", `href="../../index.html#tag-security"`} {
+				if !strings.Contains(string(page), want) {
+					t.Errorf("skill page missing %q", want)
+				}
+			}
+			if got := strings.Contains(string(page), review.Path); got != tt.opts.IncludeMetadata {
+				t.Errorf("page shows path = %v, want %v", got, tt.opts.IncludeMetadata)
+			}
+		})
+	}
+}
+
+func TestExporter_Export_HTML(t *testing.T) {
+	var buf strings.Builder
+	if err := New(Options{Format: FormatHTML}).Export(nil, &buf); err == nil {
+		t.Error("Export() with the html format: expected an error")
+	}
+}
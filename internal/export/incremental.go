@@ -0,0 +1,228 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// ExportManifestVersion is the current export manifest version.
+const ExportManifestVersion = 1
+
+// ExportManifest records what a previous export contained, so the next
+// incremental export can emit only what changed since.
+type ExportManifest struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	// Skills maps each exported skill's key (platform/name) to a checksum
+	// of its description, tools, and content
+	Skills map[string]string `json:"skills"`
+}
+
+// Tombstone identifies a skill that was exported before but no longer exists.
+type Tombstone struct {
+	Name     string `json:"name" yaml:"name"`
+	Platform string `json:"platform" yaml:"platform"`
+}
+
+// Changes is the difference between the current skills and a previous export.
+type Changes struct {
+	// Since is when the previous export ran, zero if there was none
+	Since time.Time
+	// Skills are the skills added or changed since the previous export
+	Skills []model.Skill
+	// Removed lists the previously exported skills that no longer exist
+	Removed []Tombstone
+}
+
+// incrementalExport is the JSON and YAML document of an incremental export.
+type incrementalExport struct {
+	Since   string        `json:"since,omitempty" yaml:"since,omitempty"`
+	Skills  []exportSkill `json:"skills" yaml:"skills"`
+	Removed []Tombstone   `json:"removed" yaml:"removed"`
+}
+
+// NewExportManifest records skills as exported now.
+func NewExportManifest(skills []model.Skill) *ExportManifest {
+	m := &ExportManifest{
+		Version:    ExportManifestVersion,
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+		Skills:     make(map[string]string, len(skills)),
+	}
+	for _, skill := range skills {
+		m.Skills[exportKey(skill)] = exportChecksum(skill)
+	}
+	return m
+}
+
+// LoadExportManifest reads the manifest at path. It returns nil without an
+// error if no export has been recorded there yet.
+func LoadExportManifest(path string) (*ExportManifest, error) {
+	// #nosec G304 - path is the export manifest location
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export manifest: %w", err)
+	}
+
+	var m ExportManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse export manifest %s: %w", path, err)
+	}
+	if m.Version > ExportManifestVersion {
+		return nil, fmt.Errorf("unsupported export manifest version %d", m.Version)
+	}
+	return &m, nil
+}
+
+// Save writes the manifest to path, creating its directory.
+func (m *ExportManifest) Save(path string) error {
+	if err := util.EnsureDataDir(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create export manifest directory: %w", err)
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal export manifest: %w", err)
+	}
+
+	// Write to a temp file and rename so a crash never leaves a truncated manifest.
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write export manifest: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write export manifest: %w", err)
+	}
+	return nil
+}
+
+// Changes compares skills with the export recorded in m. A nil manifest
+// means nothing was exported before, so every skill is new.
+func (m *ExportManifest) Changes(skills []model.Skill) Changes {
+	if m == nil {
+		return Changes{Skills: skills, Removed: []Tombstone{}}
+	}
+
+	changes := Changes{Since: m.ExportedAt, Removed: []Tombstone{}}
+	current := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		key := exportKey(skill)
+		current[key] = true
+		if m.Skills[key] != exportChecksum(skill) {
+			changes.Skills = append(changes.Skills, skill)
+		}
+	}
+
+	for key := range m.Skills {
+		if current[key] {
+			continue
+		}
+		platform, name, _ := strings.Cut(key, "/")
+		changes.Removed = append(changes.Removed, Tombstone{Name: name, Platform: platform})
+	}
+	sort.Slice(changes.Removed, func(i, j int) bool {
+		a, b := changes.Removed[i], changes.Removed[j]
+		if a.Platform != b.Platform {
+			return a.Platform < b.Platform
+		}
+		return a.Name < b.Name
+	})
+	return changes
+}
+
+// exportKey identifies a skill across exports.
+func exportKey(skill model.Skill) string {
+	return string(skill.Platform) + "/" + skill.Name
+}
+
+// exportChecksum hashes the parts of a skill that an export carries.
+func exportChecksum(skill model.Skill) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, skill.Description+"\x00"+strings.Join(skill.Tools, ",")+"\x00"+skill.Content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ExportChanges exports the skills added or changed since a previous export
+// together with tombstones for the removed ones. JSON and YAML wrap both in
+// a document with "skills" and "removed" lists, Markdown appends a Removed
+// section, and bundles list removals in their manifest.
+func (e *Exporter) ExportChanges(c Changes, w io.Writer) error {
+	skills := e.filterByPlatform(c.Skills)
+	removed := c.Removed
+	if e.opts.Platform != "" {
+		removed = nil
+		for _, t := range c.Removed {
+			if t.Platform == string(e.opts.Platform) {
+				removed = append(removed, t)
+			}
+		}
+	}
+	if removed == nil {
+		removed = []Tombstone{}
+	}
+
+	switch e.opts.Format {
+	case FormatJSON, FormatYAML:
+		doc := incrementalExport{
+			Skills:  make([]exportSkill, len(skills)),
+			Removed: removed,
+		}
+		if !c.Since.IsZero() {
+			doc.Since = c.Since.Format(time.RFC3339)
+		}
+		for i, skill := range skills {
+			doc.Skills[i] = e.toExportSkill(skill)
+		}
+		if e.opts.Format == FormatJSON {
+			encoder := json.NewEncoder(w)
+			if e.opts.Pretty {
+				encoder.SetIndent("", "  ")
+			}
+			return encoder.Encode(doc)
+		}
+		encoder := yaml.NewEncoder(w)
+		if e.opts.Pretty {
+			encoder.SetIndent(2)
+		}
+		if err := encoder.Encode(doc); err != nil {
+			_ = encoder.Close()
+			return err
+		}
+		return encoder.Close()
+	case FormatMarkdown:
+		if err := e.exportMarkdown(skills, w); err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+		var sb strings.Builder
+		sb.WriteString("\n---\n\n## Removed\n\n")
+		for _, t := range removed {
+			sb.WriteString(fmt.Sprintf("- %s (%s)\n", t.Name, t.Platform))
+		}
+		_, err := w.Write([]byte(sb.String()))
+		return err
+	case FormatBundle:
+		return e.exportBundle(skills, removed, w)
+	default:
+		return fmt.Errorf("incremental export is not supported for the %s format", e.opts.Format)
+	}
+}
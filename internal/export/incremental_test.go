@@ -0,0 +1,150 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func TestExportManifest_Changes(t *testing.T) {
+	previous := []model.Skill{
+		{Name: "keep", Platform: model.ClaudeCode, Content: "same"},
+		{Name: "edit", Platform: model.ClaudeCode, Content: "before"},
+		{Name: "gone", Platform: model.Cursor, Content: "bye"},
+	}
+	current := []model.Skill{
+		{Name: "keep", Platform: model.ClaudeCode, Content: "same"},
+		{Name: "edit", Platform: model.ClaudeCode, Content: "after"},
+		{Name: "new", Platform: model.ClaudeCode, Content: "hello"},
+	}
+
+	tests := map[string]struct {
+		manifest    *ExportManifest
+		wantSkills  []string
+		wantRemoved []Tombstone
+	}{
+		"first export includes everything": {
+			manifest:    nil,
+			wantSkills:  []string{"keep", "edit", "new"},
+			wantRemoved: []Tombstone{},
+		},
+		"changes since previous export": {
+			manifest:    NewExportManifest(previous),
+			wantSkills:  []string{"edit", "new"},
+			wantRemoved: []Tombstone{{Name: "gone", Platform: "cursor"}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			changes := tt.manifest.Changes(current)
+			var got []string
+			for _, skill := range changes.Skills {
+				got = append(got, skill.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantSkills, ",") {
+				t.Errorf("Skills = %v, want %v", got, tt.wantSkills)
+			}
+			if len(changes.Removed) != len(tt.wantRemoved) {
+				t.Fatalf("Removed = %+v, want %+v", changes.Removed, tt.wantRemoved)
+			}
+			for i := range tt.wantRemoved {
+				if changes.Removed[i] != tt.wantRemoved[i] {
+					t.Errorf("Removed[%d] = %+v, want %+v", i, changes.Removed[i], tt.wantRemoved[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExportManifest_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exports", "json.json")
+
+	missing, err := LoadExportManifest(path)
+	if err != nil || missing != nil {
+		t.Fatalf("LoadExportManifest() on missing file = %+v, %v; want nil, nil", missing, err)
+	}
+
+	skills := []model.Skill{{Name: "a", Platform: model.Codex, Description: "d", Content: "body"}}
+	if err := NewExportManifest(skills).Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadExportManifest(path)
+	if err != nil {
+		t.Fatalf("LoadExportManifest() error = %v", err)
+	}
+	if loaded.Version != ExportManifestVersion || loaded.ExportedAt.IsZero() {
+		t.Errorf("unexpected manifest header: %+v", loaded)
+	}
+	if changes := loaded.Changes(skills); len(changes.Skills) != 0 || len(changes.Removed) != 0 {
+		t.Errorf("expected no changes after reload, got %+v", changes)
+	}
+}
+
+func TestExporter_ExportChanges(t *testing.T) {
+	changes := Changes{
+		Skills:  []model.Skill{{Name: "new", Platform: model.ClaudeCode, Content: "hello"}},
+		Removed: []Tombstone{{Name: "gone", Platform: "claude-code"}},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := New(Options{Format: FormatJSON}).ExportChanges(changes, &buf); err != nil {
+			t.Fatalf("ExportChanges() error = %v", err)
+		}
+		var doc struct {
+			Skills  []exportSkill `json:"skills"`
+			Removed []Tombstone   `json:"removed"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+		}
+		if len(doc.Skills) != 1 || doc.Skills[0].Name != "new" || len(doc.Removed) != 1 || doc.Removed[0].Name != "gone" {
+			t.Errorf("unexpected document: %+v", doc)
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := New(Options{Format: FormatMarkdown}).ExportChanges(changes, &buf); err != nil {
+			t.Fatalf("ExportChanges() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), "## Removed\n\n- gone (claude-code)\n") {
+			t.Errorf("missing removed section:\n%s", buf.String())
+		}
+	})
+
+	t.Run("bundle", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := New(Options{Format: FormatBundle}).ExportChanges(changes, &buf); err != nil {
+			t.Fatalf("ExportChanges() error = %v", err)
+		}
+		manifest, skills, err := ReadBundle(&buf)
+		if err != nil {
+			t.Fatalf("ReadBundle() error = %v", err)
+		}
+		if len(skills) != 1 || len(manifest.Removed) != 1 || manifest.Removed[0].Name != "gone" {
+			t.Errorf("unexpected bundle: %+v", manifest)
+		}
+	})
+
+	t.Run("platform filter", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := New(Options{Format: FormatJSON, Platform: model.Cursor}).ExportChanges(changes, &buf); err != nil {
+			t.Fatalf("ExportChanges() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), `"removed": []`) && !strings.Contains(buf.String(), `"removed":[]`) {
+			t.Errorf("expected no removals for another platform:\n%s", buf.String())
+		}
+	})
+
+	t.Run("inventory unsupported", func(t *testing.T) {
+		if err := New(Options{Format: FormatInventory}).ExportChanges(changes, &bytes.Buffer{}); err == nil {
+			t.Error("expected an error for the inventory format")
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// InventoryVersion is the current inventory document version.
+const InventoryVersion = 1
+
+// Inventory sources describe how a third-party skill reached the machine.
+const (
+	// InventorySourcePluginCache is a skill installed by Claude Code's plugin system.
+	InventorySourcePluginCache = "plugin-cache"
+	// InventorySourcePluginLink is a skills-directory symlink into the plugin cache.
+	InventorySourcePluginLink = "plugin-symlink"
+	// InventorySourceDevLink is a skills-directory symlink into a local checkout.
+	InventorySourceDevLink = "dev-symlink"
+	// InventorySourcePluginRepo is a skill from a plugin repository cloned by skillsync.
+	InventorySourcePluginRepo = "plugin-repo"
+)
+
+// Inventory is a provenance listing of third-party skills, intended for
+// auditing where installed prompts came from.
+type Inventory struct {
+	Version     int              `json:"version"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	Skills      []InventoryEntry `json:"skills"`
+}
+
+// InventoryEntry records the origin of a single plugin-sourced skill.
+// Provenance fields are always present so gaps are visible to auditors.
+type InventoryEntry struct {
+	Name        string `json:"name"`
+	Platform    string `json:"platform"`
+	Source      string `json:"source"`
+	Plugin      string `json:"plugin"`
+	Marketplace string `json:"marketplace"`
+	Repository  string `json:"repository"`
+	Version     string `json:"version"`
+	Commit      string `json:"commit"`
+	License     string `json:"license"`
+	Path        string `json:"path,omitempty"`
+	SHA256      string `json:"sha256"`
+}
+
+// NewInventory builds an inventory from the plugin-sourced skills in skills.
+// Skills without plugin provenance are omitted. Entries are sorted by plugin
+// and then skill name.
+func NewInventory(skills []model.Skill) Inventory {
+	inv := Inventory{
+		Version:     InventoryVersion,
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		Skills:      []InventoryEntry{},
+	}
+
+	for _, skill := range skills {
+		source := inventorySource(skill)
+		if source == "" {
+			continue
+		}
+
+		sum := sha256.Sum256([]byte(skill.Content))
+		entry := InventoryEntry{
+			Name:        skill.Name,
+			Platform:    string(skill.Platform),
+			Source:      source,
+			Plugin:      skill.Metadata["plugin"],
+			Marketplace: skill.Metadata["marketplace"],
+			Repository:  skill.Metadata["repository"],
+			Version:     skill.Metadata["plugin_version"],
+			License:     skill.License,
+			Path:        skill.Path,
+			SHA256:      hex.EncodeToString(sum[:]),
+		}
+		if entry.License == "" {
+			entry.License = skill.Metadata["license"]
+		}
+
+		if info := skill.PluginInfo; info != nil {
+			entry.Plugin = firstNonEmpty(info.PluginName, entry.Plugin)
+			entry.Marketplace = firstNonEmpty(info.Marketplace, entry.Marketplace)
+			entry.Repository = firstNonEmpty(info.Repository, entry.Repository)
+			entry.Version = firstNonEmpty(info.Version, entry.Version)
+			entry.Commit = info.Commit
+			entry.License = firstNonEmpty(info.License, entry.License)
+			if info.IsDev && entry.Repository == "" {
+				entry.Repository = info.InstallPath
+			}
+		}
+
+		inv.Skills = append(inv.Skills, entry)
+	}
+
+	sort.Slice(inv.Skills, func(i, j int) bool {
+		a, b := inv.Skills[i], inv.Skills[j]
+		if a.Plugin != b.Plugin {
+			return a.Plugin < b.Plugin
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Path < b.Path
+	})
+
+	return inv
+}
+
+// inventorySource classifies how a skill was installed, or returns an empty
+// string if it has no plugin provenance.
+func inventorySource(skill model.Skill) string {
+	if info := skill.PluginInfo; info != nil {
+		switch {
+		case skill.Scope == model.ScopePlugin:
+			return InventorySourcePluginCache
+		case info.IsDev:
+			return InventorySourceDevLink
+		default:
+			return InventorySourcePluginLink
+		}
+	}
+
+	switch skill.Metadata["source"] {
+	case "plugin-cache":
+		return InventorySourcePluginCache
+	case "plugin":
+		return InventorySourcePluginRepo
+	}
+	if skill.Scope == model.ScopePlugin {
+		return InventorySourcePluginRepo
+	}
+	return ""
+}
+
+// exportInventory writes a JSON provenance inventory of plugin-sourced skills.
+func (e *Exporter) exportInventory(skills []model.Skill, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	if e.opts.Pretty {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(NewInventory(skills))
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
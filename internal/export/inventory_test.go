@@ -0,0 +1,147 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func TestNewInventory(t *testing.T) {
+	skills := []model.Skill{
+		{
+			Name:     "local",
+			Platform: model.ClaudeCode,
+			Scope:    model.ScopeUser,
+			Content:  "mine",
+		},
+		{
+			Name:     "commit",
+			Platform: model.ClaudeCode,
+			Scope:    model.ScopePlugin,
+			Path:     "/cache/commits/SKILL.md",
+			Content:  "commit",
+			Metadata: map[string]string{"plugin": "commits", "source": "plugin-cache"},
+			PluginInfo: &model.PluginInfo{
+				PluginName:  "commits@klauern-skills",
+				Marketplace: "klauern-skills",
+				Version:     "1.1.0",
+				Commit:      "abc123",
+				Repository:  "https://github.com/klauern/skills",
+				License:     "MIT",
+			},
+		},
+		{
+			Name:       "linked",
+			Platform:   model.ClaudeCode,
+			Scope:      model.ScopeUser,
+			Content:    "linked",
+			PluginInfo: &model.PluginInfo{PluginName: "linked@acme", Marketplace: "acme"},
+		},
+		{
+			Name:       "dev",
+			Platform:   model.ClaudeCode,
+			Scope:      model.ScopeUser,
+			License:    "Apache-2.0",
+			PluginInfo: &model.PluginInfo{IsDev: true, InstallPath: "/src/dev-plugin"},
+		},
+		{
+			Name:     "repo-skill",
+			Platform: model.ClaudeCode,
+			Scope:    model.ScopePlugin,
+			Metadata: map[string]string{
+				"plugin":         "tools",
+				"plugin_version": "0.2.0",
+				"repository":     "acme-tools",
+				"license":        "BSD-3-Clause",
+				"source":         "plugin",
+			},
+		},
+	}
+
+	inv := NewInventory(skills)
+	if inv.Version != InventoryVersion {
+		t.Errorf("Version = %d, want %d", inv.Version, InventoryVersion)
+	}
+
+	byName := make(map[string]InventoryEntry, len(inv.Skills))
+	for _, entry := range inv.Skills {
+		byName[entry.Name] = entry
+	}
+	if _, ok := byName["local"]; ok {
+		t.Error("inventory should omit skills without plugin provenance")
+	}
+
+	tests := map[string]struct {
+		want InventoryEntry
+	}{
+		"commit": {want: InventoryEntry{
+			Source:      InventorySourcePluginCache,
+			Plugin:      "commits@klauern-skills",
+			Marketplace: "klauern-skills",
+			Repository:  "https://github.com/klauern/skills",
+			Version:     "1.1.0",
+			Commit:      "abc123",
+			License:     "MIT",
+		}},
+		"linked": {want: InventoryEntry{
+			Source:      InventorySourcePluginLink,
+			Plugin:      "linked@acme",
+			Marketplace: "acme",
+		}},
+		"dev": {want: InventoryEntry{
+			Source:     InventorySourceDevLink,
+			Repository: "/src/dev-plugin",
+			License:    "Apache-2.0",
+		}},
+		"repo-skill": {want: InventoryEntry{
+			Source:     InventorySourcePluginRepo,
+			Plugin:     "tools",
+			Repository: "acme-tools",
+			Version:    "0.2.0",
+			License:    "BSD-3-Clause",
+		}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := byName[name]
+			if !ok {
+				t.Fatalf("inventory missing %q", name)
+			}
+			if got.Source != tt.want.Source || got.Plugin != tt.want.Plugin ||
+				got.Marketplace != tt.want.Marketplace || got.Repository != tt.want.Repository ||
+				got.Version != tt.want.Version || got.Commit != tt.want.Commit || got.License != tt.want.License {
+				t.Errorf("entry = %+v, want %+v", got, tt.want)
+			}
+			if len(got.SHA256) != 64 {
+				t.Errorf("SHA256 = %q, want hex digest", got.SHA256)
+			}
+		})
+	}
+}
+
+func TestExportInventory(t *testing.T) {
+	skills := []model.Skill{
+		{Name: "b", Platform: model.ClaudeCode, Scope: model.ScopePlugin, Metadata: map[string]string{"plugin": "p"}},
+		{Name: "a", Platform: model.ClaudeCode, Scope: model.ScopePlugin, Metadata: map[string]string{"plugin": "p"}},
+		{Name: "local", Platform: model.Cursor},
+	}
+
+	var buf bytes.Buffer
+	if err := New(Options{Format: FormatInventory}).Export(skills, &buf); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	var inv Inventory
+	if err := json.Unmarshal(buf.Bytes(), &inv); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(inv.Skills) != 2 || inv.Skills[0].Name != "a" || inv.Skills[1].Name != "b" {
+		t.Errorf("unexpected inventory skills: %+v", inv.Skills)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"license":""`)) {
+		t.Errorf("empty provenance fields should be present:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,175 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// Column is a column of the CSV and XLSX formats.
+type Column string
+
+const (
+	// ColumnName is the skill name.
+	ColumnName Column = "name"
+	// ColumnPlatform is the platform the skill belongs to.
+	ColumnPlatform Column = "platform"
+	// ColumnScope is the scope the skill was discovered in.
+	ColumnScope Column = "scope"
+	// ColumnType is the skill type (skill or prompt).
+	ColumnType Column = "type"
+	// ColumnDescription is the skill description.
+	ColumnDescription Column = "description"
+	// ColumnTags is the tags frontmatter field, comma-separated.
+	ColumnTags Column = "tags"
+	// ColumnTools is the allowed tools, comma-separated.
+	ColumnTools Column = "tools"
+	// ColumnLicense is the license frontmatter field.
+	ColumnLicense Column = "license"
+	// ColumnPath is the path of the skill file.
+	ColumnPath Column = "path"
+	// ColumnModified is the last modification time of the skill file.
+	ColumnModified Column = "modified"
+)
+
+// AllColumns returns every column of the CSV and XLSX formats.
+func AllColumns() []Column {
+	return []Column{
+		ColumnName, ColumnPlatform, ColumnScope, ColumnType, ColumnDescription,
+		ColumnTags, ColumnTools, ColumnLicense, ColumnPath, ColumnModified,
+	}
+}
+
+// DefaultColumns returns the columns exported when none are selected.
+func DefaultColumns() []Column {
+	return []Column{ColumnName, ColumnPlatform, ColumnScope, ColumnDescription, ColumnTags, ColumnModified}
+}
+
+// ParseColumns parses a comma-separated list of columns. An empty list
+// returns nil, meaning DefaultColumns.
+func ParseColumns(s string) ([]Column, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var columns []Column
+	seen := make(map[Column]bool)
+	for _, part := range strings.Split(s, ",") {
+		column := Column(strings.ToLower(strings.TrimSpace(part)))
+		if !column.IsValid() {
+			names := make([]string, 0, len(AllColumns()))
+			for _, c := range AllColumns() {
+				names = append(names, string(c))
+			}
+			return nil, fmt.Errorf("unknown column %q (valid: %s)", part, strings.Join(names, ", "))
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("column %q is listed twice", column)
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// IsValid returns true if the column is recognized.
+func (c Column) IsValid() bool {
+	for _, column := range AllColumns() {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// value returns the cell of the column for a skill.
+func (c Column) value(skill model.Skill) string {
+	switch c {
+	case ColumnName:
+		return skill.Name
+	case ColumnPlatform:
+		return string(skill.Platform)
+	case ColumnScope:
+		return string(skill.Scope)
+	case ColumnType:
+		if skill.Type == "" {
+			return string(model.SkillTypeSkill)
+		}
+		return string(skill.Type)
+	case ColumnDescription:
+		return skill.Description
+	case ColumnTags:
+		return strings.Join(skill.Tags(), ", ")
+	case ColumnTools:
+		return strings.Join(skill.Tools, ", ")
+	case ColumnLicense:
+		return skill.License
+	case ColumnPath:
+		return skill.Path
+	case ColumnModified:
+		if skill.ModifiedAt.IsZero() {
+			return ""
+		}
+		return skill.ModifiedAt.UTC().Format(time.RFC3339)
+	default:
+		return ""
+	}
+}
+
+// columns returns the configured table columns.
+func (e *Exporter) columns() []Column {
+	if len(e.opts.Columns) == 0 {
+		return DefaultColumns()
+	}
+	return e.opts.Columns
+}
+
+// tableRows returns the header and one row per skill of the table formats.
+func (e *Exporter) tableRows(skills []model.Skill) [][]string {
+	columns := e.columns()
+	rows := make([][]string, 0, len(skills)+1)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = string(column)
+	}
+	rows = append(rows, header)
+
+	for _, skill := range skills {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = column.value(skill)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// exportCSV exports skills as a CSV table with a header row.
+func (e *Exporter) exportCSV(skills []model.Skill, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	for _, row := range e.tableRows(skills) {
+		for i, cell := range row {
+			row[i] = escapeFormula(cell)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// escapeFormula prefixes cells that a spreadsheet would evaluate as a
+// formula with a quote, so descriptions from third-party skills cannot run
+// formulas when the CSV is opened.
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// tableSkills returns skills covering every table column.
+func tableSkills() []model.Skill {
+	fixedTime := time.Date(2024, 6, 15, 10, 30, 0, 0, time.UTC)
+	return []model.Skill{
+		{
+			Name:        "skill-alpha",
+			Description: "First test skill, with a comma",
+			Platform:    model.ClaudeCode,
+			Scope:       model.ScopeUser,
+			Path:        "/home/dev/.claude/skills/skill-alpha/SKILL.md",
+			Tools:       []string{"Read", "Write"},
+			Metadata:    map[string]string{"tags": "[review security]"},
+			License:     "MIT",
+			ModifiedAt:  fixedTime,
+		},
+		{
+			Name:        "skill-beta",
+			Description: "=HYPERLINK(\"https://example.com\")",
+			Platform:    model.Cursor,
+			Scope:       model.ScopeRepo,
+			Type:        model.SkillTypePrompt,
+			Metadata:    map[string]string{"tags": "docs, writing"},
+		},
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    []Column
+		wantErr bool
+	}{
+		"empty":     {input: "", want: nil},
+		"list":      {input: "name, Platform,tags", want: []Column{ColumnName, ColumnPlatform, ColumnTags}},
+		"unknown":   {input: "name,owner", wantErr: true},
+		"duplicate": {input: "name,name", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseColumns(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseColumns(%q): expected an error", tt.input)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(got), len(tt.want))
+			for i := range got {
+				util.AssertEqual(t, got[i], tt.want[i])
+			}
+		})
+	}
+}
+
+func TestExporter_CSV_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	util.AssertNoError(t, New(Options{Format: FormatCSV, Columns: AllColumns()}).Export(tableSkills(), &buf))
+
+	util.GoldenFile(t, testdataDir(), "csv-all-columns", buf.String())
+}
+
+func TestExporter_ExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	util.AssertNoError(t, New(Options{Format: FormatCSV}).Export(tableSkills(), &buf))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(rows), 3)
+	util.AssertEqual(t, strings.Join(rows[0], ","), "name,platform,scope,description,tags,modified")
+	util.AssertEqual(t, rows[1][3], "First test skill, with a comma")
+	util.AssertEqual(t, rows[1][4], "review, security")
+	util.AssertEqual(t, rows[1][5], "2024-06-15T10:30:00Z")
+	// A description that looks like a formula is not evaluated
+	util.AssertEqual(t, rows[2][3], `'=HYPERLINK("https://example.com")`)
+}
+
+func TestExporter_ExportXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Format: FormatXLSX, Columns: []Column{ColumnName, ColumnDescription}}
+	util.AssertNoError(t, New(opts).Export(tableSkills(), &buf))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	util.AssertNoError(t, err)
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		util.AssertNoError(t, err)
+		data, err := io.ReadAll(rc)
+		util.AssertNoError(t, err)
+		_ = rc.Close()
+		parts[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("workbook missing %s", name)
+		}
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`,
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">First test skill, with a comma</t></is></c>`,
+		`<t xml:space="preserve">=HYPERLINK(&#34;https://example.com&#34;)</t>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %s:\n%s", want, sheet)
+		}
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for i, want := range tests {
+		util.AssertEqual(t, xlsxColumnName(i), want)
+	}
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// xlsxSheetName is the name of the worksheet holding the skills table.
+const xlsxSheetName = "Skills"
+
+// xlsxParts are the fixed parts of a single-sheet workbook.
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + xlsxSheetName + `" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// exportXLSX exports skills as an Excel workbook with one sheet holding the
+// same table as the CSV format, its header row frozen. Cells are inline
+// strings, so nothing in them is evaluated as a formula.
+func (e *Exporter) exportXLSX(skills []model.Skill, w io.Writer) error {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		if err := writeZipFile(zw, part.name, []byte(part.content)); err != nil {
+			return err
+		}
+	}
+	if err := writeZipFile(zw, "xl/worksheets/sheet1.xml", xlsxSheet(e.tableRows(skills))); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeZipFile adds a compressed file to a zip archive.
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// xlsxSheet returns the worksheet XML for rows, the first being the header.
+func xlsxSheet(rows [][]string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	buf.WriteString(`<sheetViews><sheetView workbookViewId="0">`)
+	buf.WriteString(`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+	buf.WriteString(`</sheetView></sheetViews><sheetData>`)
+	for r, row := range rows {
+		ref := strconv.Itoa(r + 1)
+		buf.WriteString(`<row r="` + ref + `">`)
+		for c, cell := range row {
+			if cell == "" {
+				continue
+			}
+			buf.WriteString(`<c r="` + xlsxColumnName(c) + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+			_ = xml.EscapeText(&buf, []byte(cell))
+			buf.WriteString(`</t></is></c>`)
+		}
+		buf.WriteString(`</row>`)
+	}
+	buf.WriteString(`</sheetData></worksheet>`)
+	return buf.Bytes()
+}
+
+// xlsxColumnName returns the letters of the zero-based column index i
+// (A, B, ..., Z, AA, ...).
+func xlsxColumnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,175 @@
+// Package fence keeps watchers that sync into each other's sources from
+// overwriting each other in a loop. A watcher locks a skills directory
+// while it syncs into it, then leaves a record of the write next to the
+// skills; a watcher whose source has such a record from another writer,
+// made recently, skips the change instead of syncing it back.
+package fence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// FileName is the sidecar holding the last write into a skills directory.
+	FileName = ".skillsync-fence.json"
+	// LockName is the sidecar held while a watcher syncs into a skills directory.
+	LockName = ".skillsync-fence.lock"
+
+	// FilePerm is the permission for fence sidecars (rw-r--r--), which other
+	// users' watchers may need to read
+	FilePerm = 0o644
+)
+
+// ErrLocked is returned by Lock when another writer holds the lock.
+var ErrLocked = errors.New("skills directory is locked by another writer")
+
+// Record describes a write into a skills directory.
+type Record struct {
+	// Writer identifies the watcher that wrote, see WriterID.
+	Writer    string    `json:"writer"`
+	WrittenAt time.Time `json:"written_at"`
+	// Source is the platform spec the watcher synced from.
+	Source string `json:"source,omitempty"`
+	// Skills is the number of skills the write created, updated, or deleted.
+	Skills int `json:"skills"`
+}
+
+// FencedOff reports whether the record is a write by a writer other than
+// writer made less than window before now.
+func (r *Record) FencedOff(writer string, window time.Duration, now time.Time) bool {
+	return r != nil && r.Writer != writer && now.Sub(r.WrittenAt) < window
+}
+
+// WriterID returns an identifier for this process, unique across the
+// machines that share a skills directory.
+func WriterID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
+// Read returns the last write recorded in dir, or nil if there is none.
+func Read(dir string) (*Record, error) {
+	// #nosec G304 - dir is a platform skills directory
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read write fence: %w", err)
+	}
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse write fence %s: %w", filepath.Join(dir, FileName), err)
+	}
+	return &record, nil
+}
+
+// Write records a write into dir, replacing the previous record.
+func Write(dir string, record Record) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode write fence: %w", err)
+	}
+	// #nosec G301 - skill directories need to be readable by the platform
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create skills directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, FileName+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write write fence: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write write fence: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write write fence: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), FilePerm); err != nil {
+		return fmt.Errorf("failed to write write fence: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, FileName)); err != nil {
+		return fmt.Errorf("failed to write write fence: %w", err)
+	}
+	return nil
+}
+
+// Lock takes the lock of dir for writer and returns the function that
+// releases it. A lock held by another writer is an ErrLocked error naming
+// the holder, unless it is older than stale, as left behind by a watcher
+// that was killed, in which case it is taken over.
+func Lock(dir, writer string, stale time.Duration) (func(), error) {
+	// #nosec G301 - skill directories need to be readable by the platform
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create skills directory: %w", err)
+	}
+	path := filepath.Join(dir, LockName)
+	data, err := json.Marshal(Record{Writer: writer, WrittenAt: time.Now()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode write lock: %w", err)
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		// #nosec G304 - path is in a platform skills directory
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, FilePerm)
+		if err == nil {
+			_, writeErr := f.Write(data)
+			if closeErr := f.Close(); writeErr == nil {
+				writeErr = closeErr
+			}
+			if writeErr != nil {
+				_ = os.Remove(path)
+				return nil, fmt.Errorf("failed to write write lock: %w", writeErr)
+			}
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to take write lock: %w", err)
+		}
+
+		holder, err := readLock(path)
+		if err != nil {
+			return nil, err
+		}
+		if holder != nil && time.Since(holder.WrittenAt) < stale {
+			return nil, fmt.Errorf("%w: %s since %s", ErrLocked, holder.Writer, holder.WrittenAt.Format(time.TimeOnly))
+		}
+		// Stale, or released since the attempt: try once more
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale write lock: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrLocked, path)
+}
+
+// readLock returns the holder of the lock at path, or nil if it was
+// released or holds no record.
+func readLock(path string) (*Record, error) {
+	// #nosec G304 - path is in a platform skills directory
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read write lock: %w", err)
+	}
+	var holder Record
+	if err := json.Unmarshal(data, &holder); err != nil {
+		// A lock being written by its holder
+		info, statErr := os.Stat(path)
+		if statErr != nil {
+			return nil, nil
+		}
+		return &Record{Writer: "unknown", WrittenAt: info.ModTime()}, nil
+	}
+	return &holder, nil
+}
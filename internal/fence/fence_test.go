@@ -0,0 +1,89 @@
+package fence
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestReadWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "skills")
+
+	record, err := Read(dir)
+	util.AssertNoError(t, err)
+	if record != nil {
+		t.Fatalf("Read() of a directory without a fence = %+v, want nil", record)
+	}
+
+	written := Record{Writer: "host:1", WrittenAt: time.Now().Truncate(time.Second), Source: "claude-code", Skills: 2}
+	util.AssertNoError(t, Write(dir, written))
+	record, err = Read(dir)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, record.Writer, written.Writer)
+	util.AssertEqual(t, record.Source, written.Source)
+	util.AssertEqual(t, record.Skills, written.Skills)
+	if !record.WrittenAt.Equal(written.WrittenAt) {
+		t.Errorf("WrittenAt = %s, want %s", record.WrittenAt, written.WrittenAt)
+	}
+
+	util.WriteFile(t, filepath.Join(dir, FileName), "{")
+	if _, err := Read(dir); err == nil {
+		t.Error("Read() of a corrupt fence: expected an error")
+	}
+}
+
+func TestRecord_FencedOff(t *testing.T) {
+	now := time.Now()
+
+	tests := map[string]struct {
+		record *Record
+		want   bool
+	}{
+		"no record":          {record: nil, want: false},
+		"recent by other":    {record: &Record{Writer: "other", WrittenAt: now.Add(-time.Second)}, want: true},
+		"recent by self":     {record: &Record{Writer: "self", WrittenAt: now.Add(-time.Second)}, want: false},
+		"outside the window": {record: &Record{Writer: "other", WrittenAt: now.Add(-time.Minute)}, want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, tt.record.FencedOff("self", 30*time.Second, now), tt.want)
+		})
+	}
+}
+
+func TestLock(t *testing.T) {
+	dir := t.TempDir()
+
+	unlock, err := Lock(dir, "host:1", time.Minute)
+	util.AssertNoError(t, err)
+	_, err = Lock(dir, "host:2", time.Minute)
+	if !errors.Is(err, ErrLocked) {
+		t.Fatalf("Lock() of a held lock: error = %v, want ErrLocked", err)
+	}
+
+	unlock()
+	if _, err := os.Stat(filepath.Join(dir, LockName)); !os.IsNotExist(err) {
+		t.Fatalf("lock not released: %v", err)
+	}
+	unlock, err = Lock(dir, "host:2", time.Minute)
+	util.AssertNoError(t, err)
+	defer unlock()
+}
+
+func TestLock_Stale(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Lock(dir, "host:1", time.Minute)
+	util.AssertNoError(t, err)
+
+	// A lock older than stale was left by a watcher that died
+	old := time.Now().Add(-time.Hour)
+	util.WriteFile(t, filepath.Join(dir, LockName), `{"writer":"host:1","written_at":"`+old.Format(time.RFC3339)+`"}`)
+	unlock, err := Lock(dir, "host:2", time.Minute)
+	util.AssertNoError(t, err)
+	unlock()
+}
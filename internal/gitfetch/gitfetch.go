@@ -0,0 +1,139 @@
+// Package gitfetch clones and updates Git repositories for plugin discovery
+// and skill import. It shells out to the git binary.
+package gitfetch
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Options configures a clone.
+type Options struct {
+	// Ref is a branch, tag, or commit to check out. Empty means the default branch.
+	Ref string
+	// Stderr receives git's progress and error output. Defaults to os.Stderr.
+	Stderr io.Writer
+}
+
+// Clone shallow-clones url into dest. dest must not exist or be empty.
+func Clone(url, dest string, opts Options) error {
+	if strings.HasPrefix(opts.Ref, "-") {
+		return fmt.Errorf("invalid ref %q", opts.Ref)
+	}
+	if entries, err := os.ReadDir(dest); err == nil && len(entries) > 0 {
+		return fmt.Errorf("clone destination %s is not empty", dest)
+	}
+
+	if opts.Ref == "" {
+		return run(opts, "", "clone", "--depth", "1", "--", url, dest)
+	}
+	if err := run(opts, "", "clone", "--depth", "1", "--branch", opts.Ref, "--", url, dest); err == nil {
+		return nil
+	}
+
+	// --branch only accepts branches and tags; fall back to fetching a commit.
+	if err := os.RemoveAll(dest); err != nil {
+		return fmt.Errorf("failed to clean up partial clone: %w", err)
+	}
+	if err := run(opts, "", "clone", "--depth", "1", "--no-checkout", "--", url, dest); err != nil {
+		return err
+	}
+	if err := run(opts, dest, "fetch", "--depth", "1", "origin", opts.Ref); err != nil {
+		return fmt.Errorf("ref %q not found: %w", opts.Ref, err)
+	}
+	return run(opts, dest, "checkout", "--detach", "FETCH_HEAD")
+}
+
+// CloneTemp clones url into a new temporary directory. The returned cleanup
+// function removes the clone.
+func CloneTemp(url string, opts Options) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "skillsync-git-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	if err := Clone(url, dir, opts); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return dir, cleanup, nil
+}
+
+// Init creates an empty repository in dir, which must exist.
+func Init(dir string) error {
+	return run(Options{}, dir, "init", "-q")
+}
+
+// Pull fast-forwards an existing clone.
+func Pull(repoPath string) error {
+	return run(Options{}, repoPath, "pull", "--ff-only")
+}
+
+// Head returns the commit checked out in a clone.
+func Head(repoPath string) (string, error) {
+	// #nosec G204 - fixed arguments, repoPath is passed with -C
+	output, err := exec.Command("git", "-C", repoPath, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse failed: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// RepoName derives a directory-safe name from a Git URL,
+// e.g. "https://github.com/user/repo.git" becomes "user-repo".
+func RepoName(url string) string {
+	// Handle SSH URLs (git@github.com:user/repo.git)
+	if strings.HasPrefix(url, "git@") {
+		parts := strings.Split(url, ":")
+		if len(parts) == 2 {
+			path := parts[1]
+			path = strings.TrimSuffix(path, ".git")
+			// Use user/repo format
+			return strings.ReplaceAll(path, "/", "-")
+		}
+	}
+
+	// Handle HTTPS URLs
+	url = strings.TrimSuffix(url, ".git")
+	parts := strings.Split(url, "/")
+	if len(parts) >= 2 {
+		// Use last two parts (user/repo)
+		return parts[len(parts)-2] + "-" + parts[len(parts)-1]
+	}
+
+	// Fallback to last part
+	if len(parts) > 0 {
+		return parts[len(parts)-1]
+	}
+
+	return "unknown"
+}
+
+// run executes git with args, optionally inside dir.
+func run(opts Options, dir string, args ...string) error {
+	subcommand := args[0]
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	stderr := opts.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	// #nosec G204 - arguments are passed directly to git without a shell; URLs follow "--"
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("git %s failed: %w", subcommand, err)
+		}
+		return fmt.Errorf("failed to run git: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package gitfetch
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitRun runs git in dir and returns trimmed stdout.
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %v failed: %v", args, err)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// newTestRepo creates a repository with two commits on main, a "v1" tag on the
+// first commit, and a "feature" branch. It returns the repo path and the
+// first commit hash.
+func newTestRepo(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	repo := t.TempDir()
+	gitRun(t, repo, "init", "-q", "-b", "main")
+	writeFile(t, filepath.Join(repo, "file.txt"), "v1")
+	gitRun(t, repo, "add", ".")
+	gitRun(t, repo, "commit", "-q", "-m", "first")
+	first := gitRun(t, repo, "rev-parse", "HEAD")
+	gitRun(t, repo, "tag", "v1")
+	gitRun(t, repo, "branch", "feature")
+	writeFile(t, filepath.Join(repo, "file.txt"), "v2")
+	gitRun(t, repo, "commit", "-q", "-am", "second")
+	return repo, first
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	repo, first := newTestRepo(t)
+	url := "file://" + repo
+
+	tests := map[string]struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		"default branch": {want: "v2"},
+		"branch":         {ref: "feature", want: "v1"},
+		"tag":            {ref: "v1", want: "v1"},
+		"commit":         {ref: first, want: "v1"},
+		"unknown ref":    {ref: "does-not-exist", wantErr: true},
+		"option as ref":  {ref: "--upload-pack=x", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dest := filepath.Join(t.TempDir(), "clone")
+			err := Clone(url, dest, Options{Ref: tt.ref, Stderr: io.Discard})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Clone() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			data, err := os.ReadFile(filepath.Join(dest, "file.txt"))
+			if err != nil {
+				t.Fatalf("cloned file missing: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("file.txt = %q, want %q", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloneRejectsNonEmptyDestination(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	dest := t.TempDir()
+	writeFile(t, filepath.Join(dest, "keep.txt"), "mine")
+
+	if err := Clone("file://"+repo, dest, Options{Stderr: io.Discard}); err == nil {
+		t.Fatal("expected error for non-empty destination")
+	}
+	if _, err := os.Stat(filepath.Join(dest, "keep.txt")); err != nil {
+		t.Errorf("existing file was removed: %v", err)
+	}
+}
+
+func TestCloneTemp(t *testing.T) {
+	repo, _ := newTestRepo(t)
+
+	dir, cleanup, err := CloneTemp("file://"+repo, Options{Stderr: io.Discard})
+	if err != nil {
+		t.Fatalf("CloneTemp() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "file.txt")); err != nil {
+		t.Errorf("clone missing file: %v", err)
+	}
+	cleanup()
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("cleanup did not remove %s", dir)
+	}
+}
+
+func TestHead(t *testing.T) {
+	repo, first := newTestRepo(t)
+	dest := filepath.Join(t.TempDir(), "clone")
+	if err := Clone("file://"+repo, dest, Options{Ref: "v1", Stderr: io.Discard}); err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+
+	head, err := Head(dest)
+	if err != nil {
+		t.Fatalf("Head() error = %v", err)
+	}
+	if head != first {
+		t.Errorf("Head() = %q, want %q", head, first)
+	}
+	if _, err := Head(t.TempDir()); err == nil {
+		t.Error("Head() of a directory without a repository: expected an error")
+	}
+}
+
+func TestRepoName(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want string
+	}{
+		"https":   {url: "https://github.com/user/repo.git", want: "user-repo"},
+		"ssh":     {url: "git@github.com:user/repo.git", want: "user-repo"},
+		"no .git": {url: "https://github.com/user/repo", want: "user-repo"},
+		"bare":    {url: "repo", want: "repo"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := RepoName(tt.url); got != tt.want {
+				t.Errorf("RepoName(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
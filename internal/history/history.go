@@ -0,0 +1,298 @@
+// Package history records sync runs so they can be listed, inspected, and undone.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+const (
+	// CommandUndo is the command of runs recorded by undo.
+	CommandUndo = "undo"
+	// CommandPromote is the command of runs that copied or moved skills from
+	// repo to user scope.
+	CommandPromote = "promote"
+	// CommandDemote is the command of runs that copied or moved skills from
+	// user to repo scope.
+	CommandDemote = "demote"
+	// CommandResolve is the command of runs that applied conflict resolutions.
+	CommandResolve = "resolve"
+	// ActionRestored records a file an undo restored from a backup.
+	ActionRestored = "restored"
+	// ActionRemoved records a created file an undo removed.
+	ActionRemoved = "removed"
+	// ActionResolved records a file rewritten with a conflict resolution.
+	ActionResolved = "resolved"
+)
+
+const (
+	// DirPerm is the permission for the history directory (rwxr-x---)
+	DirPerm = 0o750
+	// FilePerm is the permission for the history file (rw-r-----)
+	FilePerm = 0o640
+)
+
+// SkillRecord is the outcome of a single skill in a recorded run.
+type SkillRecord struct {
+	Name       string   `json:"name"`
+	Action     string   `json:"action"`
+	TargetPath string   `json:"target_path,omitempty"`
+	Message    string   `json:"message,omitempty"`
+	Error      string   `json:"error,omitempty"`
+	Backups    []string `json:"backups,omitempty"` // Backups taken before the target was modified
+}
+
+// Run is a single recorded sync or delete run.
+type Run struct {
+	// ID identifies the run. It equals the backup session ID of the run, so
+	// the backups it took can be found with backup.SessionBackups.
+	ID         string        `json:"id"`
+	Command    string        `json:"command"` // sync, delete, undo, promote, demote, or resolve
+	Source     string        `json:"source"`
+	Target     string        `json:"target"`
+	Strategy   string        `json:"strategy,omitempty"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Success    bool          `json:"success"`
+	Skills     []SkillRecord `json:"skills"`
+	// Undoes is the ID of the run an undo run reversed.
+	Undoes string `json:"undoes,omitempty"`
+	// Session is the ID of the interactive TUI session the run was part of.
+	Session string `json:"session,omitempty"`
+}
+
+// NewRun builds a run record from one or more sync results. Workspace syncs
+// pass one result per repository; they are recorded as a single run.
+func NewRun(id, command string, startedAt time.Time, results ...*sync.Result) Run {
+	run := Run{
+		ID:         id,
+		Command:    command,
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+		Success:    true,
+		Skills:     make([]SkillRecord, 0),
+	}
+
+	for i, result := range results {
+		if i == 0 {
+			run.Source = string(result.Source)
+			run.Target = string(result.Target)
+			run.Strategy = string(result.Strategy)
+		}
+		if !result.Success() {
+			run.Success = false
+		}
+		for _, sr := range result.Skills {
+			record := SkillRecord{
+				Name:       sr.Skill.Name,
+				Action:     string(sr.Action),
+				TargetPath: sr.TargetPath,
+				Message:    sr.Message,
+				Backups:    sr.BackupIDs,
+			}
+			if sr.Error != nil {
+				record.Error = sr.Error.Error()
+			}
+			run.Skills = append(run.Skills, record)
+		}
+	}
+
+	return run
+}
+
+// Counts returns the number of skills per action.
+func (r Run) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, skill := range r.Skills {
+		counts[skill.Action]++
+	}
+	return counts
+}
+
+// BackupIDs returns the IDs of all backups taken during the run.
+func (r Run) BackupIDs() []string {
+	var ids []string
+	for _, skill := range r.Skills {
+		ids = append(ids, skill.Backups...)
+	}
+	return ids
+}
+
+// Record appends run to the history file. Nothing is recorded with
+// --no-persist.
+func Record(run Run) error {
+	if util.NoPersist() {
+		return nil
+	}
+	path := util.SkillsyncHistoryPath()
+	if err := util.EnsureDataDir(filepath.Dir(path), DirPerm); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	// #nosec G304 - path is constructed from the trusted skillsync home
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, FilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+	return f.Close()
+}
+
+// List returns all recorded runs, newest first. Lines that cannot be parsed,
+// such as one cut short by a crash, are skipped.
+func List() ([]Run, error) {
+	path := util.SkillsyncHistoryPath()
+	// #nosec G304 - path is constructed from the trusted skillsync home
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var runs []Run
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var run Run
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			logging.Warn("skipping unreadable history entry",
+				logging.Path(path),
+				slog.Int("line", line),
+				logging.Err(err),
+			)
+			continue
+		}
+		runs = append(runs, run)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+	return runs, nil
+}
+
+// writeActions are the skill actions after which a run's target file held
+// the synced content.
+var writeActions = map[string]bool{
+	string(sync.ActionCreated):   true,
+	string(sync.ActionUpdated):   true,
+	string(sync.ActionMerged):    true,
+	string(sync.ActionRenamed):   true,
+	string(sync.ActionUnchanged): true,
+	ActionResolved:               true,
+}
+
+// LastWritten returns, per target path, when a run last wrote the file or
+// found it up to date, to tell which side of a later difference changed.
+func LastWritten() (map[string]time.Time, error) {
+	runs, err := List()
+	if err != nil {
+		return nil, err
+	}
+	written := make(map[string]time.Time)
+	for _, run := range runs {
+		for _, skill := range run.Skills {
+			if skill.TargetPath == "" || skill.Error != "" || !writeActions[skill.Action] {
+				continue
+			}
+			if _, ok := written[skill.TargetPath]; !ok {
+				// Runs are newest first
+				written[skill.TargetPath] = run.FinishedAt
+			}
+		}
+	}
+	return written, nil
+}
+
+// Get returns the run with the given ID. A unique ID prefix is also accepted.
+func Get(id string) (*Run, error) {
+	runs, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Run
+	for _, run := range runs {
+		if run.ID == id {
+			return &run, nil
+		}
+		if strings.HasPrefix(run.ID, id) {
+			matches = append(matches, run)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("sync run %q not found", id)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("sync run prefix %q is ambiguous (%d matches)", id, len(matches))
+	}
+}
+
+// UndoneBy returns the ID of the undo run that reversed the run with the given
+// ID, or an empty string if it has not been undone.
+func UndoneBy(id string) (string, error) {
+	runs, err := List()
+	if err != nil {
+		return "", err
+	}
+	for _, run := range runs {
+		if run.Undoes == id {
+			return run.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// LastUndoable returns the most recent sync or delete run that has not been
+// undone yet. Undoing repeatedly therefore walks back through older runs.
+func LastUndoable() (*Run, error) {
+	runs, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	undone := make(map[string]bool)
+	for _, run := range runs {
+		if run.Undoes != "" {
+			undone[run.Undoes] = true
+		}
+	}
+	for _, run := range runs {
+		if run.Command != CommandUndo && !undone[run.ID] {
+			return &run, nil
+		}
+	}
+	return nil, errors.New("no sync runs left to undo")
+}
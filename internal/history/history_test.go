@@ -0,0 +1,187 @@
+package history
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestNewRun(t *testing.T) {
+	started := time.Now().Add(-time.Second)
+	results := []*sync.Result{
+		{
+			Source:   model.Cursor,
+			Target:   model.ClaudeCode,
+			Strategy: sync.StrategyOverwrite,
+			Skills: []sync.SkillResult{
+				{Skill: model.Skill{Name: "alpha"}, Action: sync.ActionUpdated, TargetPath: "/repo-a/alpha.md", BackupIDs: []string{"b1"}},
+				{Skill: model.Skill{Name: "beta"}, Action: sync.ActionCreated, TargetPath: "/repo-a/beta.md"},
+			},
+		},
+		{
+			Source: model.Cursor,
+			Target: model.ClaudeCode,
+			Skills: []sync.SkillResult{
+				{Skill: model.Skill{Name: "alpha"}, Action: sync.ActionFailed, Error: errors.New("permission denied")},
+			},
+		},
+	}
+
+	run := NewRun("run-1", "sync", started, results...)
+
+	util.AssertEqual(t, run.ID, "run-1")
+	util.AssertEqual(t, run.Source, "cursor")
+	util.AssertEqual(t, run.Target, "claude-code")
+	util.AssertEqual(t, run.Strategy, "overwrite")
+	util.AssertEqual(t, run.Success, false)
+	util.AssertEqual(t, len(run.Skills), 3)
+	util.AssertEqual(t, run.Skills[2].Error, "permission denied")
+	util.AssertEqual(t, run.Counts()["updated"], 1)
+	util.AssertEqual(t, len(run.BackupIDs()), 1)
+	if run.FinishedAt.Before(started) {
+		t.Errorf("FinishedAt %v is before StartedAt %v", run.FinishedAt, started)
+	}
+}
+
+func TestRecordAndList(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+
+	runs, err := List()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(runs), 0)
+
+	base := time.Date(2024, 1, 25, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"20240125-120000-aaaa1111", "20240125-130000-bbbb2222", "20240126-090000-cccc3333"} {
+		run := Run{ID: id, Command: "sync", StartedAt: base.Add(time.Duration(i) * time.Hour), Success: true}
+		util.AssertNoError(t, Record(run))
+	}
+
+	// A truncated trailing line from an interrupted write is skipped
+	f, err := os.OpenFile(util.SkillsyncHistoryPath(), os.O_APPEND|os.O_WRONLY, 0o600)
+	util.AssertNoError(t, err)
+	_, err = f.WriteString(`{"id": "broken`)
+	util.AssertNoError(t, err)
+	util.AssertNoError(t, f.Close())
+
+	runs, err = List()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(runs), 3)
+	util.AssertEqual(t, runs[0].ID, "20240126-090000-cccc3333")
+
+	tests := map[string]struct {
+		id      string
+		wantID  string
+		wantErr bool
+	}{
+		"exact id":         {id: "20240125-130000-bbbb2222", wantID: "20240125-130000-bbbb2222"},
+		"unique prefix":    {id: "20240126", wantID: "20240126-090000-cccc3333"},
+		"ambiguous prefix": {id: "20240125", wantErr: true},
+		"unknown":          {id: "nope", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			run, err := Get(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get(%q) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				util.AssertEqual(t, run.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestLastUndoable(t *testing.T) {
+	base := time.Date(2024, 1, 25, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		runs       []Run
+		wantID     string
+		wantUndone map[string]string
+		wantErr    bool
+	}{
+		"no history": {
+			wantErr: true,
+		},
+		"latest run": {
+			runs: []Run{
+				{ID: "run-1", Command: "sync", StartedAt: base},
+				{ID: "run-2", Command: "delete", StartedAt: base.Add(time.Hour)},
+			},
+			wantID:     "run-2",
+			wantUndone: map[string]string{"run-1": "", "run-2": ""},
+		},
+		"skips undone runs": {
+			runs: []Run{
+				{ID: "run-1", Command: "sync", StartedAt: base},
+				{ID: "run-2", Command: "sync", StartedAt: base.Add(time.Hour)},
+				{ID: "undo-1", Command: "undo", StartedAt: base.Add(2 * time.Hour), Undoes: "run-2"},
+			},
+			wantID:     "run-1",
+			wantUndone: map[string]string{"run-1": "", "run-2": "undo-1"},
+		},
+		"everything undone": {
+			runs: []Run{
+				{ID: "run-1", Command: "sync", StartedAt: base},
+				{ID: "undo-1", Command: "undo", StartedAt: base.Add(time.Hour), Undoes: "run-1"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SKILLSYNC_HOME", t.TempDir())
+			for _, run := range tt.runs {
+				util.AssertNoError(t, Record(run))
+			}
+
+			run, err := LastUndoable()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LastUndoable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				util.AssertEqual(t, run.ID, tt.wantID)
+			}
+			for id, want := range tt.wantUndone {
+				got, err := UndoneBy(id)
+				util.AssertNoError(t, err)
+				util.AssertEqual(t, got, want)
+			}
+		})
+	}
+}
+
+func TestLastWritten(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+
+	base := time.Date(2024, 1, 25, 12, 0, 0, 0, time.UTC)
+	runs := []Run{
+		{ID: "1", StartedAt: base, FinishedAt: base, Skills: []SkillRecord{
+			{Name: "lint", Action: "created", TargetPath: "/t/lint.md"},
+			{Name: "docs", Action: "created", TargetPath: "/t/docs.md"},
+		}},
+		{ID: "2", StartedAt: base.Add(time.Hour), FinishedAt: base.Add(time.Hour), Skills: []SkillRecord{
+			{Name: "lint", Action: "updated", TargetPath: "/t/lint.md"},
+			{Name: "docs", Action: "skipped", TargetPath: "/t/docs.md"},
+			{Name: "build", Action: "failed", TargetPath: "/t/build.md", Error: "permission denied"},
+		}},
+	}
+	for _, run := range runs {
+		util.AssertNoError(t, Record(run))
+	}
+
+	written, err := LastWritten()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, written["/t/lint.md"], base.Add(time.Hour))
+	util.AssertEqual(t, written["/t/docs.md"], base)
+	if _, ok := written["/t/build.md"]; ok {
+		t.Error("failed writes are not recorded as written")
+	}
+}
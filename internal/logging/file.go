@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Log formats accepted by ParseFormat.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// ParseLevel parses a level name: debug, info, warn, or error.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level %q (valid: debug, info, warn, error)", name)
+	}
+}
+
+// ParseFormat reports whether format, text or json, is JSON.
+func ParseFormat(format string) (bool, error) {
+	switch strings.ToLower(format) {
+	case FormatText, "":
+		return false, nil
+	case FormatJSON:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid log format %q (valid: text, json)", format)
+	}
+}
+
+// OpenFile opens path for appending log records, creating it and its
+// directory if needed. Log files are private to the user, as records
+// include skill paths and error details.
+func OpenFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	// #nosec G304 - the path comes from the user's config
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return f, nil
+}
+
+// NewTee creates a logger that writes each record to the console and to a
+// log file, each filtering by its own level and in its own format. The
+// file usually keeps more detail than the console shows, so an automated
+// run can be inspected afterwards.
+func NewTee(console, file Options) *slog.Logger {
+	return slog.New(&countingHandler{Handler: &teeHandler{
+		console: newHandler(console),
+		file:    newHandler(file),
+	}})
+}
+
+// teeHandler passes records to a console and a file handler.
+type teeHandler struct {
+	console slog.Handler
+	file    slog.Handler
+}
+
+// Enabled reports whether either handler takes records at level.
+func (h *teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.console.Enabled(ctx, level) || h.file.Enabled(ctx, level)
+}
+
+// Handle passes r to each handler that takes its level.
+func (h *teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range []slog.Handler{h.console, h.file} {
+		if handler.Enabled(ctx, r.Level) {
+			errs = append(errs, handler.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a tee handler with the given attributes.
+func (h *teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &teeHandler{console: h.console.WithAttrs(attrs), file: h.file.WithAttrs(attrs)}
+}
+
+// WithGroup returns a tee handler with the given group.
+func (h *teeHandler) WithGroup(name string) slog.Handler {
+	return &teeHandler{console: h.console.WithGroup(name), file: h.file.WithGroup(name)}
+}
+
+// Record writes a message the CLI has already printed, such as a warning,
+// to the log file only, so the file holds everything a run reported. It
+// does nothing when the default logger has no log file, and is not
+// counted by Warnings.
+func Record(level slog.Level, msg string, args ...any) {
+	counting, ok := Default().Handler().(*countingHandler)
+	if !ok {
+		return
+	}
+	tee, ok := counting.Handler.(*teeHandler)
+	if !ok {
+		return
+	}
+	slog.New(tee.file).Log(context.Background(), level, msg, args...)
+}
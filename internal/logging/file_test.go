@@ -0,0 +1,139 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/logging"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		want    slog.Level
+		wantErr bool
+	}{
+		"debug":   {name: "debug", want: logging.LevelDebug},
+		"info":    {name: "INFO", want: logging.LevelInfo},
+		"warning": {name: "warning", want: logging.LevelWarn},
+		"error":   {name: "error", want: logging.LevelError},
+		"unknown": {name: "trace", wantErr: true},
+		"empty":   {name: "", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			level, err := logging.ParseLevel(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevel(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !tt.wantErr && level != tt.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", tt.name, level, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if isJSON, err := logging.ParseFormat("json"); err != nil || !isJSON {
+		t.Errorf("ParseFormat(json) = %v, %v", isJSON, err)
+	}
+	if isJSON, err := logging.ParseFormat("text"); err != nil || isJSON {
+		t.Errorf("ParseFormat(text) = %v, %v", isJSON, err)
+	}
+	if _, err := logging.ParseFormat("logfmt"); err == nil {
+		t.Error("ParseFormat(logfmt) should fail")
+	}
+}
+
+func TestOpenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "skillsync.log")
+	for _, line := range []string{"first\n", "second\n"} {
+		f, err := logging.OpenFile(path)
+		if err != nil {
+			t.Fatalf("OpenFile() error = %v", err)
+		}
+		if _, err := f.WriteString(line); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "first\nsecond\n" {
+		t.Errorf("log file = %q, want both runs appended", data)
+	}
+}
+
+func TestNewTee(t *testing.T) {
+	var console, file bytes.Buffer
+	logger := logging.NewTee(
+		logging.Options{Level: logging.LevelWarn, Output: &console},
+		logging.Options{Level: logging.LevelInfo, Output: &file, JSON: true},
+	)
+
+	logging.ResetWarnings()
+	t.Cleanup(logging.ResetWarnings)
+	logger.Info("processed skill", logging.Skill("lint"))
+	logger.With(logging.Platform("cursor")).Warn("failed to parse skill")
+
+	if strings.Contains(console.String(), "processed skill") {
+		t.Errorf("console got an info record below its level:\n%s", console.String())
+	}
+	if !strings.Contains(console.String(), "failed to parse skill") {
+		t.Errorf("console missing warning:\n%s", console.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(file.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("file got %d records, want 2:\n%s", len(lines), file.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("file record is not JSON: %v", err)
+	}
+	if entry["msg"] != "failed to parse skill" || entry["platform"] != "cursor" {
+		t.Errorf("file record = %v", entry)
+	}
+	if got := logging.Warnings(); got != 1 {
+		t.Errorf("Warnings() = %d, want 1", got)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	var console, file bytes.Buffer
+	logging.SetDefault(logging.NewTee(
+		logging.Options{Level: logging.LevelWarn, Output: &console},
+		logging.Options{Level: logging.LevelInfo, Output: &file},
+	))
+	t.Cleanup(func() { logging.SetDefault(logging.New(logging.DefaultOptions())) })
+
+	logging.ResetWarnings()
+	logging.Record(logging.LevelWarn, "workspace repository not found")
+
+	if console.Len() != 0 {
+		t.Errorf("Record() wrote to the console: %q", console.String())
+	}
+	if !strings.Contains(file.String(), "workspace repository not found") {
+		t.Errorf("Record() did not write to the file: %q", file.String())
+	}
+	if logging.Warnings() != 0 {
+		t.Errorf("Record() counted %d warning(s)", logging.Warnings())
+	}
+
+	// Without a log file there is nowhere to record to
+	logging.SetDefault(logging.New(logging.Options{Level: logging.LevelWarn, Output: &console}))
+	logging.Record(logging.LevelWarn, "dropped")
+	if console.Len() != 0 {
+		t.Errorf("Record() without a log file wrote %q", console.String())
+	}
+}
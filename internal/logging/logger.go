@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"sync"
+	"sync/atomic"
 )
 
 // Level aliases for convenience.
@@ -20,6 +21,11 @@ const (
 var (
 	defaultLogger *slog.Logger
 	defaultOnce   sync.Once
+
+	// warnings counts the warn-level records handled by loggers from New
+	warnings atomic.Int64
+	// strict makes Lint log at warn level
+	strict atomic.Bool
 )
 
 // Options configures the logger behavior.
@@ -46,23 +52,61 @@ func DefaultOptions() Options {
 
 // New creates a new logger with the given options.
 func New(opts Options) *slog.Logger {
+	return slog.New(&countingHandler{Handler: newHandler(opts)})
+}
+
+// newHandler returns a text or JSON handler for opts.
+func newHandler(opts Options) slog.Handler {
 	if opts.Output == nil {
 		opts.Output = os.Stderr
 	}
-
 	handlerOpts := &slog.HandlerOptions{
 		Level:     opts.Level,
 		AddSource: opts.AddSource,
 	}
-
-	var handler slog.Handler
 	if opts.JSON {
-		handler = slog.NewJSONHandler(opts.Output, handlerOpts)
-	} else {
-		handler = slog.NewTextHandler(opts.Output, handlerOpts)
+		return slog.NewJSONHandler(opts.Output, handlerOpts)
+	}
+	return slog.NewTextHandler(opts.Output, handlerOpts)
+}
+
+// countingHandler counts warn-level records for Warnings.
+type countingHandler struct {
+	slog.Handler
+}
+
+// Handle counts warnings before passing the record on.
+func (h *countingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= LevelWarn && r.Level < LevelError {
+		warnings.Add(1)
 	}
+	return h.Handler.Handle(ctx, r)
+}
 
-	return slog.New(handler)
+// WithAttrs returns a counting handler with the given attributes.
+func (h *countingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &countingHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a counting handler with the given group.
+func (h *countingHandler) WithGroup(name string) slog.Handler {
+	return &countingHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// Warnings returns the number of warnings logged since the last
+// ResetWarnings. Strict mode fails a command that logged any.
+func Warnings() int64 {
+	return warnings.Load()
+}
+
+// ResetWarnings clears the warning count.
+func ResetWarnings() {
+	warnings.Store(0)
+}
+
+// SetStrict sets whether Lint logs at warn level.
+func SetStrict(enabled bool) {
+	strict.Store(enabled)
 }
 
 // Default returns the default logger, creating it if necessary.
@@ -117,6 +161,17 @@ func Warn(msg string, args ...any) {
 	Default().Warn(msg, args...)
 }
 
+// Lint logs a condition that interactive use tolerates, such as a skill
+// shadowed by another scope: at debug level normally, and as a warning in
+// strict mode.
+func Lint(msg string, args ...any) {
+	if strict.Load() {
+		Default().Warn(msg, args...)
+		return
+	}
+	Default().Debug(msg, args...)
+}
+
 // Error logs at error level using the default logger.
 func Error(msg string, args ...any) {
 	Default().Error(msg, args...)
@@ -140,7 +195,7 @@ func FromContext(ctx context.Context) *slog.Logger {
 
 // Common attribute keys for consistent logging across the codebase.
 const (
-	// KeyPlatform identifies the AI platform (claude-code, cursor, codex).
+	// KeyPlatform identifies the AI platform (claude-code, cursor, codex, aider).
 	KeyPlatform = "platform"
 	// KeySkill identifies a skill by name.
 	KeySkill = "skill"
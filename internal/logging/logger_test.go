@@ -380,3 +380,43 @@ func TestWith_UsesDefaultLogger(t *testing.T) {
 		t.Error("expected With() to include attributes")
 	}
 }
+
+func TestWarnings_CountsWarnLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(logging.Options{Level: logging.LevelInfo, Output: &buf})
+
+	logging.ResetWarnings()
+	logger.Info("info")
+	logger.Warn("first")
+	logger.With("key", "value").Warn("second")
+	logger.Error("errors are not warnings")
+
+	if got := logging.Warnings(); got != 2 {
+		t.Errorf("Warnings() = %d, want 2", got)
+	}
+	logging.ResetWarnings()
+	if got := logging.Warnings(); got != 0 {
+		t.Errorf("Warnings() after reset = %d, want 0", got)
+	}
+}
+
+func TestLint_StrictMode(t *testing.T) {
+	var buf bytes.Buffer
+	logging.SetDefault(logging.New(logging.Options{Level: logging.LevelInfo, Output: &buf}))
+	t.Cleanup(func() {
+		logging.SetStrict(false)
+		logging.ResetWarnings()
+	})
+
+	logging.ResetWarnings()
+	logging.Lint("tolerated")
+	if logging.Warnings() != 0 || buf.Len() != 0 {
+		t.Errorf("Lint() outside strict mode logged %q (%d warning(s))", buf.String(), logging.Warnings())
+	}
+
+	logging.SetStrict(true)
+	logging.Lint("not tolerated")
+	if logging.Warnings() != 1 || !strings.Contains(buf.String(), "not tolerated") {
+		t.Errorf("Lint() in strict mode logged %q (%d warning(s))", buf.String(), logging.Warnings())
+	}
+}
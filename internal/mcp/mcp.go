@@ -0,0 +1,251 @@
+// Package mcp serves skillsync as a Model Context Protocol server, so AI
+// agents can list, read, and sync skills as MCP tools and resources.
+//
+// The server speaks the MCP stdio transport: newline-delimited JSON-RPC 2.0
+// messages, handled by an rpc.Server. It implements the server side of the
+// lifecycle (initialize, ping), tools, and resources; the tools and
+// resources themselves are supplied by the caller.
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/klauern/skillsync/internal/rpc"
+)
+
+// LatestProtocolVersion is the newest MCP revision the server implements.
+// It is answered to clients that request a revision the server does not know.
+const LatestProtocolVersion = "2025-06-18"
+
+// supportedProtocolVersions are the MCP revisions the server can speak. The
+// methods used here did not change between them.
+var supportedProtocolVersions = []string{"2024-11-05", "2025-03-26", LatestProtocolVersion}
+
+// MCP method names.
+const (
+	MethodInitialize    = "initialize"
+	MethodPing          = "ping"
+	MethodToolsList     = "tools/list"
+	MethodToolsCall     = "tools/call"
+	MethodResourcesList = "resources/list"
+	MethodResourcesRead = "resources/read"
+)
+
+// CodeResourceNotFound is the error code of resources/read for an unknown URI.
+const CodeResourceNotFound = -32002
+
+// ToolAnnotations are hints about a tool's behavior for clients deciding
+// whether to ask the user before calling it.
+type ToolAnnotations struct {
+	ReadOnlyHint    bool  `json:"readOnlyHint"`
+	DestructiveHint *bool `json:"destructiveHint,omitempty"`
+	IdempotentHint  bool  `json:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool `json:"openWorldHint,omitempty"`
+}
+
+// Tool is a tool the server offers. Call receives the raw arguments of a
+// tools/call; its result is returned as structured content and as JSON text.
+// Errors are reported to the model as a tool result with isError set.
+type Tool struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description"`
+	InputSchema json.RawMessage  `json:"inputSchema"`
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+	Call        rpc.Handler      `json:"-"`
+}
+
+// Resource describes a resource the server offers.
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MIMEType    string `json:"mimeType,omitempty"`
+}
+
+// ResourceContents is the text of a resource.
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MIMEType string `json:"mimeType,omitempty"`
+	Text     string `json:"text"`
+}
+
+// ErrResourceNotFound is returned by ReadResource for an unknown URI.
+var ErrResourceNotFound = errors.New("resource not found")
+
+// Options configures the server.
+type Options struct {
+	// Name and Version identify the server to clients.
+	Name    string
+	Version string
+	// Instructions tell the model how to use the server.
+	Instructions string
+
+	Tools []Tool
+	// ListResources and ReadResource serve resources. The resources
+	// capability is only announced when both are set.
+	ListResources func(context.Context) ([]Resource, error)
+	ReadResource  func(ctx context.Context, uri string) (*ResourceContents, error)
+}
+
+// implementation identifies a client or server.
+type implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// initializeParams are the params of initialize that the server reads.
+type initializeParams struct {
+	ProtocolVersion string          `json:"protocolVersion"`
+	ClientInfo      *implementation `json:"clientInfo,omitempty"`
+}
+
+// initializeResult is the result of initialize.
+type initializeResult struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ServerInfo      implementation `json:"serverInfo"`
+	Instructions    string         `json:"instructions,omitempty"`
+}
+
+// callToolParams are the params of tools/call.
+type callToolParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// content is a text content block.
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// callToolResult is the result of tools/call.
+type callToolResult struct {
+	Content           []content `json:"content"`
+	StructuredContent any       `json:"structuredContent,omitempty"`
+	IsError           bool      `json:"isError,omitempty"`
+}
+
+// New creates an MCP server for the configured tools and resources.
+func New(opts Options) *rpc.Server {
+	s := rpc.NewServer()
+	s.Handle(MethodInitialize, func(_ context.Context, raw json.RawMessage) (any, error) {
+		var params initializeParams
+		if err := decode(raw, &params); err != nil {
+			return nil, err
+		}
+		return initialize(opts, params), nil
+	})
+	s.Handle(MethodPing, func(context.Context, json.RawMessage) (any, error) {
+		return struct{}{}, nil
+	})
+
+	if len(opts.Tools) > 0 {
+		tools := make(map[string]Tool, len(opts.Tools))
+		for _, tool := range opts.Tools {
+			tools[tool.Name] = tool
+		}
+		s.Handle(MethodToolsList, func(context.Context, json.RawMessage) (any, error) {
+			return map[string]any{"tools": opts.Tools}, nil
+		})
+		s.Handle(MethodToolsCall, func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var params callToolParams
+			if err := decode(raw, &params); err != nil {
+				return nil, err
+			}
+			tool, ok := tools[params.Name]
+			if !ok {
+				return nil, &rpc.Error{Code: rpc.CodeInvalidParams, Message: "unknown tool: " + params.Name}
+			}
+			return callTool(ctx, tool, params.Arguments), nil
+		})
+	}
+
+	if opts.ListResources != nil && opts.ReadResource != nil {
+		s.Handle(MethodResourcesList, func(ctx context.Context, _ json.RawMessage) (any, error) {
+			resources, err := opts.ListResources(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if resources == nil {
+				resources = []Resource{}
+			}
+			return map[string]any{"resources": resources}, nil
+		})
+		s.Handle(MethodResourcesRead, func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var params struct {
+				URI string `json:"uri"`
+			}
+			if err := decode(raw, &params); err != nil {
+				return nil, err
+			}
+			contents, err := opts.ReadResource(ctx, params.URI)
+			if errors.Is(err, ErrResourceNotFound) {
+				return nil, &rpc.Error{Code: CodeResourceNotFound, Message: err.Error(), Data: map[string]string{"uri": params.URI}}
+			}
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"contents": []ResourceContents{*contents}}, nil
+		})
+	}
+	return s
+}
+
+// initialize negotiates the protocol revision and announces capabilities.
+func initialize(opts Options, params initializeParams) initializeResult {
+	version := params.ProtocolVersion
+	if !slices.Contains(supportedProtocolVersions, version) {
+		version = LatestProtocolVersion
+	}
+	capabilities := map[string]any{}
+	if len(opts.Tools) > 0 {
+		capabilities["tools"] = map[string]any{}
+	}
+	if opts.ListResources != nil && opts.ReadResource != nil {
+		capabilities["resources"] = map[string]any{}
+	}
+	return initializeResult{
+		ProtocolVersion: version,
+		Capabilities:    capabilities,
+		ServerInfo:      implementation{Name: opts.Name, Version: opts.Version},
+		Instructions:    opts.Instructions,
+	}
+}
+
+// callTool runs a tool, turning its result or error into a tool result.
+func callTool(ctx context.Context, tool Tool, args json.RawMessage) callToolResult {
+	result, err := tool.Call(ctx, args)
+	if err != nil {
+		var rpcErr *rpc.Error
+		message := err.Error()
+		if errors.As(err, &rpcErr) {
+			message = rpcErr.Message
+		}
+		return callToolResult{Content: []content{{Type: "text", Text: message}}, IsError: true}
+	}
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return callToolResult{Content: []content{{Type: "text", Text: fmt.Sprintf("failed to encode result: %v", err)}}, IsError: true}
+	}
+	return callToolResult{Content: []content{{Type: "text", Text: string(text)}}, StructuredContent: result}
+}
+
+// decode decodes protocol params, ignoring fields the server does not use
+// such as _meta. Missing params decode as the zero value.
+func decode(raw json.RawMessage, v any) error {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &rpc.Error{Code: rpc.CodeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/rpc"
+)
+
+// serve runs a server over the request lines and decodes the responses.
+func serve(t *testing.T, s *rpc.Server, lines ...string) []rpc.Response {
+	t.Helper()
+	var output bytes.Buffer
+	if err := s.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")), &output); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	var responses []rpc.Response
+	decoder := json.NewDecoder(&output)
+	for decoder.More() {
+		var response rpc.Response
+		if err := decoder.Decode(&response); err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func testServer() *rpc.Server {
+	type echoArgs struct {
+		Name string `json:"name"`
+	}
+	return New(Options{
+		Name:    "skillsync",
+		Version: "1.2.3",
+		Tools: []Tool{{
+			Name:        "echo",
+			Description: "Echo a name",
+			InputSchema: json.RawMessage(`{"type":"object"}`),
+			Call: rpc.Typed(func(_ context.Context, args echoArgs) (map[string]string, error) {
+				if args.Name == "" {
+					return nil, errors.New("name is required")
+				}
+				return map[string]string{"name": args.Name}, nil
+			}),
+		}},
+		ListResources: func(context.Context) ([]Resource, error) {
+			return []Resource{{URI: "skill://cursor/user/lint", Name: "lint"}}, nil
+		},
+		ReadResource: func(_ context.Context, uri string) (*ResourceContents, error) {
+			if uri != "skill://cursor/user/lint" {
+				return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
+			}
+			return &ResourceContents{URI: uri, MIMEType: "text/markdown", Text: "# Lint"}, nil
+		},
+	})
+}
+
+func TestServer(t *testing.T) {
+	tests := map[string]struct {
+		request    string
+		wantCode   int
+		wantResult string
+	}{
+		"initialize with a known revision": {
+			request:    `{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2024-11-05", "capabilities": {}, "clientInfo": {"name": "agent", "version": "1"}}}`,
+			wantResult: `{"protocolVersion":"2024-11-05","capabilities":{"resources":{},"tools":{}},"serverInfo":{"name":"skillsync","version":"1.2.3"}}`,
+		},
+		"initialize with an unknown revision": {
+			request:    `{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "1999-01-01"}}`,
+			wantResult: `{"protocolVersion":"` + LatestProtocolVersion + `","capabilities":{"resources":{},"tools":{}},"serverInfo":{"name":"skillsync","version":"1.2.3"}}`,
+		},
+		"ping": {
+			request:    `{"jsonrpc": "2.0", "id": 2, "method": "ping"}`,
+			wantResult: `{}`,
+		},
+		"list tools": {
+			request:    `{"jsonrpc": "2.0", "id": 3, "method": "tools/list"}`,
+			wantResult: `{"tools":[{"name":"echo","description":"Echo a name","inputSchema":{"type":"object"}}]}`,
+		},
+		"call tool": {
+			request:    `{"jsonrpc": "2.0", "id": 4, "method": "tools/call", "params": {"name": "echo", "arguments": {"name": "lint"}}}`,
+			wantResult: `{"content":[{"type":"text","text":"{\n  \"name\": \"lint\"\n}"}],"structuredContent":{"name":"lint"}}`,
+		},
+		"tool error": {
+			request:    `{"jsonrpc": "2.0", "id": 5, "method": "tools/call", "params": {"name": "echo", "arguments": {}}}`,
+			wantResult: `{"content":[{"type":"text","text":"name is required"}],"isError":true}`,
+		},
+		"unknown tool argument": {
+			request:    `{"jsonrpc": "2.0", "id": 6, "method": "tools/call", "params": {"name": "echo", "arguments": {"nmae": "lint"}}}`,
+			wantResult: `{"content":[{"type":"text","text":"invalid params: json: unknown field \"nmae\""}],"isError":true}`,
+		},
+		"unknown tool": {
+			request:  `{"jsonrpc": "2.0", "id": 7, "method": "tools/call", "params": {"name": "nope"}}`,
+			wantCode: rpc.CodeInvalidParams,
+		},
+		"list resources": {
+			request:    `{"jsonrpc": "2.0", "id": 8, "method": "resources/list"}`,
+			wantResult: `{"resources":[{"uri":"skill://cursor/user/lint","name":"lint"}]}`,
+		},
+		"read resource": {
+			request:    `{"jsonrpc": "2.0", "id": 9, "method": "resources/read", "params": {"uri": "skill://cursor/user/lint"}}`,
+			wantResult: `{"contents":[{"uri":"skill://cursor/user/lint","mimeType":"text/markdown","text":"# Lint"}]}`,
+		},
+		"unknown resource": {
+			request:  `{"jsonrpc": "2.0", "id": 10, "method": "resources/read", "params": {"uri": "skill://cursor/user/nope"}}`,
+			wantCode: CodeResourceNotFound,
+		},
+		"shutdown is not an MCP method": {
+			request:  `{"jsonrpc": "2.0", "id": 11, "method": "shutdown"}`,
+			wantCode: rpc.CodeMethodNotFound,
+		},
+	}
+
+	server := testServer()
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			responses := serve(t, server, tt.request)
+			if len(responses) != 1 {
+				t.Fatalf("got %d responses, want 1", len(responses))
+			}
+			response := responses[0]
+			if tt.wantCode != 0 {
+				if response.Error == nil || response.Error.Code != tt.wantCode {
+					t.Errorf("error = %+v, want code %d", response.Error, tt.wantCode)
+				}
+				return
+			}
+			if response.Error != nil {
+				t.Fatalf("unexpected error: %v", response.Error)
+			}
+			if string(response.Result) != tt.wantResult {
+				t.Errorf("result = %s, want %s", response.Result, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestServerInitializedNotification(t *testing.T) {
+	responses := serve(t, testServer(),
+		`{"jsonrpc": "2.0", "method": "notifications/initialized"}`,
+		`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`,
+	)
+	if len(responses) != 1 || string(responses[0].ID) != "1" {
+		t.Errorf("responses = %+v, want only the ping response", responses)
+	}
+}
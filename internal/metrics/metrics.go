@@ -0,0 +1,149 @@
+// Package metrics collects counters of the syncs a long-running skillsync
+// process (sync --watch) runs and exposes them in the Prometheus text format,
+// so drift and repeated failures can be alerted on.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"github.com/klauern/skillsync/internal/sync"
+)
+
+// ContentType is the media type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Registry holds the metrics of the syncs from one source to one target. It
+// is safe for concurrent use; a nil Registry records nothing.
+type Registry struct {
+	source, target string
+
+	mu            gosync.Mutex
+	syncs         map[string]int // By outcome: success, failure
+	skills        map[sync.Action]int
+	conflicts     int
+	durationSum   float64
+	lastSync      time.Time
+	lastSuccess   bool
+	sourceSkills  int
+	sourceCounted bool
+}
+
+// New returns an empty registry for syncs from source to target, which
+// label every series.
+func New(source, target string) *Registry {
+	return &Registry{
+		source: source,
+		target: target,
+		syncs:  map[string]int{"success": 0, "failure": 0},
+		skills: make(map[sync.Action]int),
+	}
+}
+
+// SetSourceSkills records how many skills the source has.
+func (r *Registry) SetSourceSkills(n int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sourceSkills = n
+	r.sourceCounted = true
+}
+
+// ObserveSync records one sync that took d. result is nil when the sync
+// failed before any skill was processed; err is the error the sync ended
+// with, if any. A sync succeeds when it ends without error.
+func (r *Registry) ObserveSync(result *sync.Result, d time.Duration, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastSuccess = err == nil
+	if r.lastSuccess {
+		r.syncs["success"]++
+	} else {
+		r.syncs["failure"]++
+	}
+	r.lastSync = time.Now()
+	r.durationSum += d.Seconds()
+	if result == nil {
+		return
+	}
+	for _, sr := range result.Skills {
+		r.skills[sr.Action]++
+		if sr.Conflict != nil {
+			r.conflicts++
+		}
+	}
+}
+
+// WriteTo writes the metrics in the Prometheus text format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	labels := fmt.Sprintf(`source=%q,target=%q`, r.source, r.target)
+	series := func(name, help, kind string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+
+	total := 0
+	series("skillsync_syncs_total", "Syncs run, by outcome.", "counter")
+	for _, outcome := range slices.Sorted(maps.Keys(r.syncs)) {
+		fmt.Fprintf(&b, "skillsync_syncs_total{%s,outcome=%q} %d\n", labels, outcome, r.syncs[outcome])
+		total += r.syncs[outcome]
+	}
+	series("skillsync_sync_failures_total", "Syncs that ended with an error.", "counter")
+	fmt.Fprintf(&b, "skillsync_sync_failures_total{%s} %d\n", labels, r.syncs["failure"])
+	series("skillsync_sync_conflicts_total", "Skill conflicts found by syncs.", "counter")
+	fmt.Fprintf(&b, "skillsync_sync_conflicts_total{%s} %d\n", labels, r.conflicts)
+
+	series("skillsync_sync_skills_total", "Skills processed by syncs, by action.", "counter")
+	for _, action := range slices.Sorted(maps.Keys(r.skills)) {
+		fmt.Fprintf(&b, "skillsync_sync_skills_total{%s,action=%q} %d\n", labels, action, r.skills[action])
+	}
+
+	series("skillsync_sync_duration_seconds", "Time taken by syncs.", "summary")
+	fmt.Fprintf(&b, "skillsync_sync_duration_seconds_sum{%s} %g\n", labels, r.durationSum)
+	fmt.Fprintf(&b, "skillsync_sync_duration_seconds_count{%s} %d\n", labels, total)
+
+	if !r.lastSync.IsZero() {
+		series("skillsync_last_sync_timestamp_seconds", "Time of the last sync, in seconds since the epoch.", "gauge")
+		fmt.Fprintf(&b, "skillsync_last_sync_timestamp_seconds{%s} %d\n", labels, r.lastSync.Unix())
+		success := 0
+		if r.lastSuccess {
+			success = 1
+		}
+		series("skillsync_last_sync_success", "Whether the last sync ended without error.", "gauge")
+		fmt.Fprintf(&b, "skillsync_last_sync_success{%s} %d\n", labels, success)
+	}
+	if r.sourceCounted {
+		series("skillsync_source_skills", "Skills found in the source at the last check.", "gauge")
+		fmt.Fprintf(&b, "skillsync_source_skills{source=%q} %d\n", r.source, r.sourceSkills)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP implements http.Handler, serving the metrics to GET and HEAD
+// requests.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	_, _ = r.WriteTo(w)
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestRegistry(t *testing.T) {
+	r := New("claude-code:user", "cursor:user")
+	r.SetSourceSkills(3)
+	r.ObserveSync(&sync.Result{Skills: []sync.SkillResult{
+		{Action: sync.ActionCreated},
+		{Action: sync.ActionCreated},
+		{Action: sync.ActionConflict, Conflict: &sync.Conflict{}},
+	}}, 1500*time.Millisecond, nil)
+	r.ObserveSync(nil, 500*time.Millisecond, errors.New("failed to parse source"))
+
+	var b strings.Builder
+	_, err := r.WriteTo(&b)
+	util.AssertNoError(t, err)
+	output := b.String()
+
+	labels := `source="claude-code:user",target="cursor:user"`
+	tests := map[string]string{
+		"successful syncs": `skillsync_syncs_total{` + labels + `,outcome="success"} 1`,
+		"failed syncs":     `skillsync_syncs_total{` + labels + `,outcome="failure"} 1`,
+		"failures":         `skillsync_sync_failures_total{` + labels + `} 1`,
+		"conflicts":        `skillsync_sync_conflicts_total{` + labels + `} 1`,
+		"skills by action": `skillsync_sync_skills_total{` + labels + `,action="created"} 2`,
+		"duration sum":     `skillsync_sync_duration_seconds_sum{` + labels + `} 2`,
+		"duration count":   `skillsync_sync_duration_seconds_count{` + labels + `} 2`,
+		"last sync failed": `skillsync_last_sync_success{` + labels + `} 0`,
+		"source skills":    `skillsync_source_skills{source="claude-code:user"} 3`,
+		"type lines":       "# TYPE skillsync_sync_duration_seconds summary",
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			if !strings.Contains(output, want+"\n") {
+				t.Errorf("metrics do not contain %q:\n%s", want, output)
+			}
+		})
+	}
+}
+
+func TestRegistry_BeforeFirstSync(t *testing.T) {
+	var b strings.Builder
+	_, err := New("cursor", "codex").WriteTo(&b)
+	util.AssertNoError(t, err)
+	for _, absent := range []string{"skillsync_last_sync_success", "skillsync_source_skills"} {
+		if strings.Contains(b.String(), absent) {
+			t.Errorf("metrics before the first sync contain %s:\n%s", absent, b.String())
+		}
+	}
+
+	// A nil registry records nothing
+	var r *Registry
+	r.SetSourceSkills(1)
+	r.ObserveSync(nil, time.Second, nil)
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	r := New("cursor", "codex")
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	util.AssertEqual(t, rec.Code, http.StatusOK)
+	util.AssertEqual(t, rec.Header().Get("Content-Type"), ContentType)
+	if !strings.Contains(rec.Body.String(), "skillsync_syncs_total") {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	util.AssertEqual(t, rec.Code, http.StatusMethodNotAllowed)
+}
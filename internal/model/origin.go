@@ -0,0 +1,102 @@
+package model
+
+import "strings"
+
+// Frontmatter keys recording where an imported skill came from. Import sets
+// them and parsers keep them in Metadata, so the origin round-trips through
+// sync and refresh can fetch the skill again.
+const (
+	MetadataSourceType   = "source_type"
+	MetadataSourceURL    = "source_url"
+	MetadataSourceRef    = "source_ref"
+	MetadataSourceCommit = "source_commit"
+	MetadataSourcePath   = "source_path"
+)
+
+// OriginType is the kind of source a skill was imported from.
+type OriginType string
+
+const (
+	// OriginGit is a skill imported from a Git repository (or gist) with
+	// import --git.
+	OriginGit OriginType = "git"
+	// OriginURL is a skill file downloaded with import --url.
+	OriginURL OriginType = "url"
+	// OriginPlugin is a skill installed by a Claude Code plugin.
+	OriginPlugin OriginType = "plugin"
+)
+
+// Origin is where a skill's content was fetched from.
+type Origin struct {
+	Type OriginType `json:"type"`
+	URL  string     `json:"url"`
+	// Ref is the branch or tag that was requested, if any.
+	Ref string `json:"ref,omitempty"`
+	// Commit is the commit the content was read at, when known.
+	Commit string `json:"commit,omitempty"`
+	// Path is the skill file relative to the repository root (Git only).
+	Path string `json:"path,omitempty"`
+}
+
+// Origin returns where the skill was imported from: the source_* frontmatter
+// fields written by import, or the marketplace repository of a plugin skill.
+func (s Skill) Origin() (Origin, bool) {
+	if url := strings.TrimSpace(s.Metadata[MetadataSourceURL]); url != "" {
+		origin := Origin{
+			Type:   OriginType(strings.TrimSpace(s.Metadata[MetadataSourceType])),
+			URL:    url,
+			Ref:    strings.TrimSpace(s.Metadata[MetadataSourceRef]),
+			Commit: strings.TrimSpace(s.Metadata[MetadataSourceCommit]),
+			Path:   strings.TrimSpace(s.Metadata[MetadataSourcePath]),
+		}
+		if origin.Type == "" {
+			origin.Type = OriginURL
+		}
+		return origin, true
+	}
+	if s.PluginInfo != nil && s.PluginInfo.Repository != "" {
+		return Origin{Type: OriginPlugin, URL: s.PluginInfo.Repository, Ref: s.PluginInfo.Version, Commit: s.PluginInfo.Commit}, true
+	}
+	return Origin{}, false
+}
+
+// WithOrigin returns a copy of the skill with origin recorded in its
+// metadata. Fields left empty in origin are removed.
+func (s Skill) WithOrigin(origin Origin) Skill {
+	metadata := make(map[string]string, len(s.Metadata)+5)
+	for key, value := range s.Metadata {
+		metadata[key] = value
+	}
+	for key, value := range map[string]string{
+		MetadataSourceType:   string(origin.Type),
+		MetadataSourceURL:    origin.URL,
+		MetadataSourceRef:    origin.Ref,
+		MetadataSourceCommit: origin.Commit,
+		MetadataSourcePath:   origin.Path,
+	} {
+		if value == "" {
+			delete(metadata, key)
+		} else {
+			metadata[key] = value
+		}
+	}
+	s.Metadata = metadata
+	return s
+}
+
+// String formats the origin as URL[@ref][//path] followed by the short
+// commit, e.g. "https://github.com/acme/skills@main//review/SKILL.md (9f2c4e1a)".
+func (o Origin) String() string {
+	var b strings.Builder
+	b.WriteString(o.URL)
+	if o.Ref != "" {
+		b.WriteString("@" + o.Ref)
+	}
+	if o.Path != "" {
+		b.WriteString("//" + o.Path)
+	}
+	if o.Commit != "" {
+		b.WriteString(" (" + o.Commit[:min(len(o.Commit), 8)] + ")")
+	}
+	return b.String()
+}
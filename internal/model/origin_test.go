@@ -0,0 +1,67 @@
+package model
+
+import "testing"
+
+func TestSkillOrigin(t *testing.T) {
+	tests := map[string]struct {
+		skill  Skill
+		want   string
+		wantOK bool
+	}{
+		"none": {
+			skill: Skill{Name: "lint"},
+		},
+		"git": {
+			skill: Skill{Metadata: map[string]string{
+				MetadataSourceType:   "git",
+				MetadataSourceURL:    "https://github.com/acme/skills",
+				MetadataSourceRef:    "main",
+				MetadataSourceCommit: "9f2c4e1a77b0",
+				MetadataSourcePath:   "review/SKILL.md",
+			}},
+			want:   "git https://github.com/acme/skills@main//review/SKILL.md (9f2c4e1a)",
+			wantOK: true,
+		},
+		"url without type": {
+			skill:  Skill{Metadata: map[string]string{MetadataSourceURL: "https://example.com/lint.md"}},
+			want:   "url https://example.com/lint.md",
+			wantOK: true,
+		},
+		"plugin": {
+			skill:  Skill{PluginInfo: &PluginInfo{Repository: "https://github.com/acme/plugins", Version: "1.2.0", Commit: "abc"}},
+			want:   "plugin https://github.com/acme/plugins@1.2.0 (abc)",
+			wantOK: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			origin, ok := tt.skill.Origin()
+			if ok != tt.wantOK {
+				t.Fatalf("Origin() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got := string(origin.Type) + " " + origin.String(); ok && got != tt.want {
+				t.Errorf("Origin() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkillWithOrigin(t *testing.T) {
+	skill := Skill{Metadata: map[string]string{"owner": "platform", MetadataSourceRef: "old"}}
+	updated := skill.WithOrigin(Origin{Type: OriginURL, URL: "https://example.com/lint.md"})
+
+	if skill.Metadata[MetadataSourceURL] != "" {
+		t.Error("WithOrigin() modified the original metadata")
+	}
+	origin, ok := updated.Origin()
+	if !ok || origin != (Origin{Type: OriginURL, URL: "https://example.com/lint.md"}) {
+		t.Errorf("Origin() = %+v, %v", origin, ok)
+	}
+	if _, ok := updated.Metadata[MetadataSourceRef]; ok {
+		t.Error("WithOrigin() kept a stale source_ref")
+	}
+	if updated.Metadata["owner"] != "platform" {
+		t.Error("WithOrigin() dropped other metadata")
+	}
+}
@@ -3,7 +3,9 @@ package model
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"sync/atomic"
 )
 
 // Platform represents a supported AI coding platform.
@@ -16,20 +18,50 @@ const (
 	Cursor Platform = "cursor"
 	// Codex is the identifier for the Codex platform.
 	Codex Platform = "codex"
+	// Aider is the identifier for the Aider platform.
+	Aider Platform = "aider"
 )
 
+// builtinPlatforms are the platforms skillsync parses itself.
+var builtinPlatforms = []Platform{ClaudeCode, Cursor, Codex, Aider}
+
+// customPlatforms are the platforms parsed by external parsers configured
+// under platforms.custom.
+var customPlatforms atomic.Pointer[[]Platform]
+
+// SetCustomPlatforms sets the platforms parsed by external parsers, making
+// them valid alongside the built-in ones.
+func SetCustomPlatforms(platforms []Platform) {
+	platforms = slices.Clone(platforms)
+	customPlatforms.Store(&platforms)
+}
+
+// CustomPlatforms returns the platforms parsed by external parsers.
+func CustomPlatforms() []Platform {
+	if platforms := customPlatforms.Load(); platforms != nil {
+		return slices.Clone(*platforms)
+	}
+	return nil
+}
+
+// IsBuiltin returns true if skillsync parses the platform itself.
+func (p Platform) IsBuiltin() bool {
+	return slices.Contains(builtinPlatforms, p)
+}
+
+// IsCustom returns true if the platform is parsed by an external parser.
+func (p Platform) IsCustom() bool {
+	platforms := customPlatforms.Load()
+	return platforms != nil && slices.Contains(*platforms, p)
+}
+
 // IsValid returns true if the platform is recognized
 func (p Platform) IsValid() bool {
-	switch p {
-	case ClaudeCode, Cursor, Codex:
-		return true
-	default:
-		return false
-	}
+	return p.IsBuiltin() || p.IsCustom()
 }
 
 // ConfigDir returns the platform's config directory name (without leading dot).
-// Returns "claude" for ClaudeCode, "cursor" for Cursor, "codex" for Codex.
+// Returns "claude" for ClaudeCode, "cursor" for Cursor, "codex" for Codex, "aider" for Aider.
 func (p Platform) ConfigDir() string {
 	switch p {
 	case ClaudeCode:
@@ -38,13 +70,15 @@ func (p Platform) ConfigDir() string {
 		return "cursor"
 	case Codex:
 		return "codex"
+	case Aider:
+		return "aider"
 	default:
 		return string(p)
 	}
 }
 
 // Short returns an abbreviated platform name for compact display.
-// Returns "cc" for ClaudeCode, "cur" for Cursor, "cdx" for Codex.
+// Returns "cc" for ClaudeCode, "cur" for Cursor, "cdx" for Codex, "aid" for Aider.
 func (p Platform) Short() string {
 	switch p {
 	case ClaudeCode:
@@ -53,14 +87,16 @@ func (p Platform) Short() string {
 		return "cur"
 	case Codex:
 		return "cdx"
+	case Aider:
+		return "aid"
 	default:
 		return string(p)
 	}
 }
 
-// AllPlatforms returns all supported platforms.
+// AllPlatforms returns all supported platforms, the built-in ones first.
 func AllPlatforms() []Platform {
-	return []Platform{ClaudeCode, Cursor, Codex}
+	return append(slices.Clone(builtinPlatforms), CustomPlatforms()...)
 }
 
 // ParsePlatform converts a string to a Platform type.
@@ -83,7 +119,13 @@ func ParsePlatform(s string) (Platform, error) {
 		return Cursor, nil
 	case "codex":
 		return Codex, nil
+	case "aider":
+		return Aider, nil
 	default:
-		return "", fmt.Errorf("unknown platform %q (valid: claudecode, cursor, codex)", s)
+		valid := "claudecode, cursor, codex, aider"
+		for _, custom := range CustomPlatforms() {
+			valid += ", " + string(custom)
+		}
+		return "", fmt.Errorf("unknown platform %q (valid: %s)", s, valid)
 	}
 }
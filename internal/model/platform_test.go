@@ -10,6 +10,7 @@ func TestPlatformValidation(t *testing.T) {
 		"claude code valid": {platform: ClaudeCode, valid: true},
 		"cursor valid":      {platform: Cursor, valid: true},
 		"codex valid":       {platform: Codex, valid: true},
+		"aider valid":       {platform: Aider, valid: true},
 		"empty invalid":     {platform: "", valid: false},
 		"unknown invalid":   {platform: "unknown", valid: false},
 	}
@@ -28,8 +29,8 @@ func TestPlatformValidation(t *testing.T) {
 func TestAllPlatforms(t *testing.T) {
 	platforms := AllPlatforms()
 
-	if len(platforms) != 3 {
-		t.Errorf("AllPlatforms() returned %d platforms, want 3", len(platforms))
+	if len(platforms) != 4 {
+		t.Errorf("AllPlatforms() returned %d platforms, want 4", len(platforms))
 	}
 
 	for _, p := range platforms {
@@ -39,6 +40,27 @@ func TestAllPlatforms(t *testing.T) {
 	}
 }
 
+func TestCustomPlatforms(t *testing.T) {
+	SetCustomPlatforms([]Platform{"windsurf"})
+	t.Cleanup(func() { SetCustomPlatforms(nil) })
+
+	windsurf := Platform("windsurf")
+	if !windsurf.IsValid() || !windsurf.IsCustom() || windsurf.IsBuiltin() {
+		t.Errorf("custom platform %q not recognized as custom", windsurf)
+	}
+	if got := AllPlatforms(); len(got) != 5 || got[4] != windsurf {
+		t.Errorf("AllPlatforms() = %v, want the built-in platforms then %q", got, windsurf)
+	}
+	if got, err := ParsePlatform(" Windsurf "); err != nil || got != windsurf {
+		t.Errorf("ParsePlatform(\" Windsurf \") = %q, %v", got, err)
+	}
+
+	SetCustomPlatforms(nil)
+	if windsurf.IsValid() {
+		t.Errorf("platform %q still valid after its parser was removed", windsurf)
+	}
+}
+
 func TestPlatformShort(t *testing.T) {
 	tests := map[string]struct {
 		platform Platform
@@ -47,6 +69,7 @@ func TestPlatformShort(t *testing.T) {
 		"claude code": {platform: ClaudeCode, want: "cc"},
 		"cursor":      {platform: Cursor, want: "cur"},
 		"codex":       {platform: Codex, want: "cdx"},
+		"aider":       {platform: Aider, want: "aid"},
 		"unknown":     {platform: "unknown", want: "unknown"},
 	}
 
@@ -69,6 +92,7 @@ func TestPlatformConfigDir(t *testing.T) {
 		"claude code":     {platform: ClaudeCode, want: "claude"},
 		"cursor":          {platform: Cursor, want: "cursor"},
 		"codex":           {platform: Codex, want: "codex"},
+		"aider":           {platform: Aider, want: "aider"},
 		"unknown returns": {platform: "unknown", want: "unknown"},
 		"empty":           {platform: "", want: ""},
 	}
@@ -95,6 +119,7 @@ func TestParsePlatform(t *testing.T) {
 		"claude shorthand":      {input: "claude", want: ClaudeCode, wantErr: false},
 		"cursor exact":          {input: "cursor", want: Cursor, wantErr: false},
 		"codex exact":           {input: "codex", want: Codex, wantErr: false},
+		"aider exact":           {input: "aider", want: Aider, wantErr: false},
 		"uppercase normalized":  {input: "CURSOR", want: Cursor, wantErr: false},
 		"mixed case":            {input: "ClaudeCode", want: ClaudeCode, wantErr: false},
 		"with whitespace":       {input: "  cursor  ", want: Cursor, wantErr: false},
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // PluginInfo contains metadata about a plugin-installed skill.
 // This tracks whether a skill was installed via Claude Code's plugin system
@@ -21,6 +24,12 @@ type PluginInfo struct {
 	// InstallScope is the scope at which the plugin was installed (e.g., "user", "project")
 	// This reflects where the plugin was installed, not the skill's precedence scope.
 	InstallScope string `json:"install_scope,omitempty"`
+	// Commit is the Git commit the plugin was installed from, when known
+	Commit string `json:"commit,omitempty"`
+	// Repository is the origin of the plugin's marketplace (e.g., "https://github.com/klauern/skills")
+	Repository string `json:"repository,omitempty"`
+	// License is the license declared in the plugin manifest
+	License string `json:"license,omitempty"`
 }
 
 // Skill represents a unified agent skill across platforms
@@ -34,6 +43,11 @@ type Skill struct {
 	Content     string            `json:"content"`
 	ModifiedAt  time.Time         `json:"modified_at"`
 
+	// Frontmatter is the raw YAML frontmatter the skill was parsed from. It
+	// lets sync write the skill elsewhere with its key order, value types,
+	// and comments intact. Empty for skills without frontmatter.
+	Frontmatter string `json:"-"`
+
 	// Type indicates whether this is a regular skill or a slash command/prompt.
 	// Defaults to SkillTypeSkill if not specified.
 	Type SkillType `json:"type,omitempty"`
@@ -53,6 +67,13 @@ type Skill struct {
 
 	// PluginInfo contains metadata if this skill was installed via a plugin symlink
 	PluginInfo *PluginInfo `json:"plugin_info,omitempty"`
+
+	// Shadows lists the scopes of same-named skills this skill hides
+	// because its scope takes precedence.
+	Shadows []SkillScope `json:"shadows,omitempty"`
+	// ShadowedBy is the scope of the same-named skill that hides this one,
+	// set only on shadowed versions listed with discover --show-shadowed.
+	ShadowedBy SkillScope `json:"shadowed_by,omitempty"`
 }
 
 // IsHigherPrecedence returns true if this skill's scope has higher precedence than other.
@@ -111,3 +132,52 @@ func (s Skill) DisplayScope() string {
 		return string(s.Scope)
 	}
 }
+
+// Frontmatter keys used to mark a skill as deprecated. Parsers keep unknown
+// frontmatter fields in Metadata, so these round-trip through sync unchanged.
+const (
+	MetadataDeprecated = "deprecated"
+	MetadataReplacedBy = "replaced_by"
+)
+
+// IsDeprecated returns true if the skill is marked with `deprecated: true`.
+func (s Skill) IsDeprecated() bool {
+	switch strings.ToLower(strings.TrimSpace(s.Metadata[MetadataDeprecated])) {
+	case "true", "yes", "1":
+		return true
+	default:
+		return false
+	}
+}
+
+// ReplacedBy returns the name of the skill that supersedes this one, if any.
+func (s Skill) ReplacedBy() string {
+	return strings.TrimSpace(s.Metadata[MetadataReplacedBy])
+}
+
+// MetadataTags is the frontmatter key listing a skill's tags.
+const MetadataTags = "tags"
+
+// Tags returns the tags of the skill. Parsers keep the tags frontmatter field
+// in Metadata as written ("a, b") or as a formatted YAML list ("[a b]").
+func (s Skill) Tags() []string {
+	raw := strings.TrimSpace(s.Metadata[MetadataTags])
+	if raw == "" {
+		return nil
+	}
+	split := func(r rune) bool { return r == ',' }
+	if strings.HasPrefix(raw, "[") && strings.HasSuffix(raw, "]") {
+		raw = raw[1 : len(raw)-1]
+		if !strings.Contains(raw, ",") {
+			split = func(r rune) bool { return r == ' ' }
+		}
+	}
+
+	var tags []string
+	for _, tag := range strings.FieldsFunc(raw, split) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
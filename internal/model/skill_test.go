@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -280,3 +281,67 @@ func TestSkillZeroValues(t *testing.T) {
 		t.Error("Zero-value Assets should be nil")
 	}
 }
+
+func TestSkillDeprecation(t *testing.T) {
+	tests := map[string]struct {
+		metadata   map[string]string
+		deprecated bool
+		replacedBy string
+	}{
+		"no metadata": {
+			metadata: nil,
+		},
+		"deprecated with replacement": {
+			metadata:   map[string]string{"deprecated": "true", "replaced_by": "new-skill"},
+			deprecated: true,
+			replacedBy: "new-skill",
+		},
+		"deprecated without replacement": {
+			metadata:   map[string]string{"deprecated": "yes"},
+			deprecated: true,
+		},
+		"explicitly not deprecated": {
+			metadata:   map[string]string{"deprecated": "false", "replaced_by": "other"},
+			replacedBy: "other",
+		},
+		"case and whitespace insensitive": {
+			metadata:   map[string]string{"deprecated": " TRUE ", "replaced_by": " next "},
+			deprecated: true,
+			replacedBy: "next",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			skill := Skill{Name: "old-skill", Metadata: tt.metadata}
+			if got := skill.IsDeprecated(); got != tt.deprecated {
+				t.Errorf("IsDeprecated() = %v, want %v", got, tt.deprecated)
+			}
+			if got := skill.ReplacedBy(); got != tt.replacedBy {
+				t.Errorf("ReplacedBy() = %q, want %q", got, tt.replacedBy)
+			}
+		})
+	}
+}
+
+func TestSkillTags(t *testing.T) {
+	tests := map[string]struct {
+		tags string
+		want string
+	}{
+		"none":           {tags: "", want: ""},
+		"comma list":     {tags: "docs, writing", want: "docs|writing"},
+		"yaml list":      {tags: "[review security]", want: "review|security"},
+		"bracketed list": {tags: "[review, security]", want: "review|security"},
+		"single":         {tags: "docs", want: "docs"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			skill := Skill{Metadata: map[string]string{"tags": tt.tags}}
+			if got := strings.Join(skill.Tags(), "|"); got != tt.want {
+				t.Errorf("Tags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
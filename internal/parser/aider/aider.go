@@ -0,0 +1,224 @@
+// Package aider implements the Parser interface for Aider conventions.
+// Aider reads coding conventions from markdown files, by convention
+// CONVENTIONS.md, listed under read: in its .aider.conf.yml.
+package aider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/parser/codex"
+	"github.com/klauern/skillsync/internal/parser/skills"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// ConventionsFileName is the conventions file skillsync reads and writes in
+// an Aider skills directory.
+const ConventionsFileName = "CONVENTIONS.md"
+
+// Parser implements the parser.Parser interface for Aider skills
+type Parser struct {
+	basePath string
+}
+
+// New creates a new Aider parser
+// If basePath is empty, uses the default Aider skills directory (~/.aider/skills)
+func New(basePath string) *Parser {
+	if basePath == "" {
+		basePath = util.PlatformSkillsPath(model.Aider)
+	}
+	return &Parser{basePath: basePath}
+}
+
+// Parse parses Aider skills from SKILL.md files, CONVENTIONS.md, and the
+// files read by .aider.conf.yml. Conventions files are split into one skill
+// per skillsync section marker, in the same format as Codex's AGENTS.md; the
+// text outside any section becomes a skill named after the file.
+// Earlier sources take precedence when names collide.
+func (p *Parser) Parse() ([]model.Skill, error) {
+	// Check if the base path exists
+	if _, err := os.Stat(p.basePath); os.IsNotExist(err) {
+		logging.Debug("skills directory not found",
+			logging.Platform(string(p.Platform())),
+			logging.Path(p.basePath),
+		)
+		return []model.Skill{}, nil
+	}
+
+	var allSkills []model.Skill
+	seenNames := make(map[string]bool)
+	add := func(found []model.Skill) {
+		for _, skill := range found {
+			if seenNames[skill.Name] {
+				logging.Debug("skipping Aider skill, higher precedence version exists",
+					logging.Skill(skill.Name),
+					logging.Path(skill.Path),
+				)
+				continue
+			}
+			seenNames[skill.Name] = true
+			allSkills = append(allSkills, skill)
+		}
+	}
+
+	skillsParser := skills.New(p.basePath, p.Platform())
+	agentSkills, err := skillsParser.Parse()
+	if err != nil {
+		logging.Warn("failed to parse SKILL.md files",
+			logging.Platform(string(p.Platform())),
+			logging.Path(p.basePath),
+			logging.Err(err),
+		)
+	}
+	add(agentSkills)
+
+	seenFiles := make(map[string]bool)
+	for _, filePath := range p.conventionsFiles() {
+		if seenFiles[filePath] {
+			continue
+		}
+		seenFiles[filePath] = true
+
+		fileSkills, err := parser.ParseFile("aider", filePath, parseConventionsFile)
+		if err != nil {
+			logging.Warn("failed to parse conventions file",
+				logging.Platform(string(p.Platform())),
+				logging.Path(filePath),
+				logging.Err(err),
+			)
+			continue
+		}
+		add(fileSkills)
+	}
+
+	logging.Debug("completed parsing skills",
+		logging.Platform(string(p.Platform())),
+		logging.Count(len(allSkills)),
+	)
+
+	return allSkills, nil
+}
+
+// conventionsFiles returns the conventions files to parse: CONVENTIONS.md in
+// the base path, then the files read by the .aider.conf.yml files that apply
+// to it, in that order.
+func (p *Parser) conventionsFiles() []string {
+	var files []string
+	if path := filepath.Join(p.basePath, ConventionsFileName); fileExists(path) {
+		files = append(files, path)
+	}
+
+	for _, confPath := range []string{filepath.Join(p.basePath, ConfigFileName), ConfigPathFor(p.basePath)} {
+		if confPath == "" || !fileExists(confPath) {
+			continue
+		}
+		readFiles, err := ReadFiles(confPath)
+		if err != nil {
+			logging.Warn("failed to read Aider config",
+				logging.Platform(string(p.Platform())),
+				logging.Path(confPath),
+				logging.Err(err),
+			)
+			continue
+		}
+		for _, path := range readFiles {
+			if !fileExists(path) {
+				logging.Debug("skipping missing Aider read file",
+					logging.Path(path),
+				)
+				continue
+			}
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// parseConventionsFile parses a single conventions file into its sections and
+// the skill holding the text outside them, which is omitted when only
+// sections remain.
+func parseConventionsFile(filePath string) ([]model.Skill, error) {
+	// #nosec G304 - filePath is the base path's conventions file or listed by an Aider config
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", filePath, err)
+	}
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file %q: %w", filePath, err)
+	}
+
+	preamble, sections := codex.SplitAgents(string(content))
+	var fileSkills []model.Skill
+	if len(sections) == 0 || strings.TrimSpace(preamble) != "" {
+		name := fileSkillName(filePath)
+		if err := parser.ValidateSkillName(name); err != nil {
+			return nil, fmt.Errorf("cannot derive a skill name from %q: %w", filePath, err)
+		}
+		fileSkills = append(fileSkills, model.Skill{
+			Name:        name,
+			Description: "Aider conventions",
+			Platform:    model.Aider,
+			Path:        filePath,
+			Metadata:    map[string]string{"type": "conventions"},
+			Content:     parser.NormalizeContent(preamble),
+			ModifiedAt:  fileInfo.ModTime(),
+		})
+	}
+
+	for _, section := range sections {
+		if err := parser.ValidateSkillName(section.Name); err != nil {
+			logging.Warn("skipping conventions section with invalid name",
+				logging.Skill(section.Name),
+				logging.Path(filePath),
+				logging.Err(err),
+			)
+			continue
+		}
+		description := section.Description
+		if description == "" {
+			description = "Aider conventions"
+		}
+		fileSkills = append(fileSkills, model.Skill{
+			Name:        section.Name,
+			Description: description,
+			Platform:    model.Aider,
+			Path:        filePath,
+			Metadata:    map[string]string{"type": "conventions", codex.SectionMetadataKey: "true"},
+			Content:     parser.NormalizeContent(section.Content),
+			ModifiedAt:  fileInfo.ModTime(),
+		})
+	}
+
+	return fileSkills, nil
+}
+
+// fileSkillName derives a skill name from a conventions file name, so
+// CONVENTIONS.md becomes "conventions" and "Style Guide.md" "style-guide".
+func fileSkillName(filePath string) string {
+	base := filepath.Base(filePath)
+	name := strings.ToLower(strings.TrimSuffix(base, filepath.Ext(base)))
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '.'
+	}), "-")
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// Platform returns the platform identifier for Aider
+func (p *Parser) Platform() model.Platform {
+	return model.Aider
+}
+
+// DefaultPath returns the default path for Aider skills
+func (p *Parser) DefaultPath() string {
+	return util.PlatformSkillsPath(model.Aider)
+}
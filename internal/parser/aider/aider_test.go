@@ -0,0 +1,82 @@
+package aider
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestParser_Platform(t *testing.T) {
+	if got := New("").Platform(); got != model.Aider {
+		t.Errorf("Platform() = %v, want %v", got, model.Aider)
+	}
+}
+
+func TestParser_Parse(t *testing.T) {
+	tests := map[string]struct {
+		files map[string]string
+		want  map[string]string // skill name -> content
+	}{
+		"missing directory": {},
+		"conventions file": {
+			files: map[string]string{
+				".aider/skills/CONVENTIONS.md": "Prefer small functions.\n",
+			},
+			want: map[string]string{"conventions": "Prefer small functions."},
+		},
+		"sections": {
+			files: map[string]string{
+				".aider/skills/CONVENTIONS.md": "<!-- skillsync:begin name=\"lint\" description=\"Run the linter\" -->\n" +
+					"Run golangci-lint.\n<!-- skillsync:end name=\"lint\" -->\n",
+			},
+			want: map[string]string{"lint": "Run golangci-lint."},
+		},
+		"files read by the config": {
+			files: map[string]string{
+				".aider/skills/README.md": "not read",
+				".aider.conf.yml":         "read:\n  - docs/Style Guide.md\n  - missing.md\n",
+				"docs/Style Guide.md":     "Use tabs.\n",
+			},
+			want: map[string]string{"style-guide": "Use tabs."},
+		},
+		"single read entry": {
+			files: map[string]string{
+				".aider/skills/.keep": "",
+				".aider.conf.yml":     "read: CONVENTIONS.md\n",
+				"CONVENTIONS.md":      "Write tests.\n",
+			},
+			want: map[string]string{"conventions": "Write tests."},
+		},
+		"skill files take precedence": {
+			files: map[string]string{
+				".aider/skills/conventions/SKILL.md": "---\nname: conventions\ndescription: From SKILL.md\n---\nFrom SKILL.md.\n",
+				".aider/skills/CONVENTIONS.md":       "From CONVENTIONS.md.\n",
+			},
+			want: map[string]string{"conventions": "From SKILL.md."},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			for path, content := range tt.files {
+				util.WriteFile(t, filepath.Join(root, path), content)
+			}
+
+			skills, err := New(filepath.Join(root, ".aider", "skills")).Parse()
+			util.AssertNoError(t, err)
+
+			got := make(map[string]string)
+			for _, skill := range skills {
+				util.AssertEqual(t, skill.Platform, model.Aider)
+				got[skill.Name] = skill.Content
+			}
+			util.AssertEqual(t, len(got), len(tt.want))
+			for name, content := range tt.want {
+				util.AssertEqual(t, got[name], content)
+			}
+		})
+	}
+}
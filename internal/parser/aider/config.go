@@ -0,0 +1,171 @@
+package aider
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// ConfigFileName is the Aider config file, read from the home directory, the
+// git root, and the working directory.
+const ConfigFileName = ".aider.conf.yml"
+
+// readKey is the config key listing files Aider adds to the chat read-only.
+const readKey = "read"
+
+// ConfigPathFor returns the .aider.conf.yml that applies to an Aider skills
+// directory: the one beside its .aider directory, so ~/.aider/skills maps to
+// ~/.aider.conf.yml and <repo>/.aider/skills to <repo>/.aider.conf.yml.
+// Returns "" for directories outside that layout.
+func ConfigPathFor(skillsDir string) string {
+	skillsDir = filepath.Clean(skillsDir)
+	dotDir := filepath.Dir(skillsDir)
+	if filepath.Base(skillsDir) != "skills" || filepath.Base(dotDir) != ".aider" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(dotDir), ConfigFileName)
+}
+
+// ReadFiles returns the files listed under read: in an Aider config,
+// resolved against the config's directory. read may be a single file or a list.
+func ReadFiles(confPath string) ([]string, error) {
+	// #nosec G304 - confPath is an Aider config beside a skills directory
+	data, err := os.ReadFile(confPath)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", confPath, err)
+	}
+
+	var files []string
+	for _, entry := range readEntries(doc) {
+		files = append(files, resolveEntry(entry, filepath.Dir(confPath)))
+	}
+	return files, nil
+}
+
+// EnsureRead adds file to the read: list of the Aider config at confPath so
+// Aider loads it, creating the config if needed. The rest of the config is
+// kept. It reports whether the config changed.
+func EnsureRead(confPath, file string) (bool, error) {
+	// #nosec G304 - confPath is an Aider config beside a skills directory
+	data, err := os.ReadFile(confPath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	doc, err := parseConfig(data)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", confPath, err)
+	}
+
+	confDir := filepath.Dir(confPath)
+	for _, entry := range readEntries(doc) {
+		if resolveEntry(entry, confDir) == filepath.Clean(file) {
+			return false, nil
+		}
+	}
+
+	// Paths in a repository's config are kept relative so it can be committed
+	entry := file
+	if util.GetRepoRoot(confDir) == confDir {
+		if rel, err := filepath.Rel(confDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+			entry = filepath.ToSlash(rel)
+		}
+	}
+
+	mapping := doc.Content[0]
+	value := mappingValue(mapping, readKey)
+	switch {
+	case value == nil:
+		mapping.Content = append(mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: readKey},
+			&yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{scalarNode(entry)}},
+		)
+	case value.Kind == yaml.SequenceNode:
+		value.Content = append(value.Content, scalarNode(entry))
+	case len(readEntries(doc)) == 0:
+		*value = yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{scalarNode(entry)}}
+	default:
+		existing := *value
+		*value = yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{&existing, scalarNode(entry)}}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", confPath, err)
+	}
+	if err := enc.Close(); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", confPath, err)
+	}
+	// #nosec G306 - the Aider config should be readable
+	if err := os.WriteFile(confPath, buf.Bytes(), 0o644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// parseConfig parses an Aider config into a document whose root is a
+// mapping, which is empty for an empty config.
+func parseConfig(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("config is not a mapping")
+	}
+	return &doc, nil
+}
+
+// readEntries returns the raw entries of the read: key.
+func readEntries(doc *yaml.Node) []string {
+	value := mappingValue(doc.Content[0], readKey)
+	if value == nil {
+		return nil
+	}
+	if value.Kind == yaml.ScalarNode {
+		if value.Value == "" || value.Tag == "!!null" {
+			return nil
+		}
+		return []string{value.Value}
+	}
+	var entries []string
+	for _, item := range value.Content {
+		if item.Kind == yaml.ScalarNode && item.Value != "" {
+			entries = append(entries, item.Value)
+		}
+	}
+	return entries
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
+
+// resolveEntry expands ~ in a read: entry and resolves it against dir.
+func resolveEntry(entry, dir string) string {
+	return util.ExpandPath(filepath.FromSlash(entry), dir)
+}
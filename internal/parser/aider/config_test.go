@@ -0,0 +1,93 @@
+package aider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestConfigPathFor(t *testing.T) {
+	tests := map[string]struct {
+		skillsDir string
+		want      string
+	}{
+		"user skills":     {skillsDir: "/home/me/.aider/skills", want: "/home/me/.aider.conf.yml"},
+		"trailing slash":  {skillsDir: "/repo/.aider/skills/", want: "/repo/.aider.conf.yml"},
+		"other directory": {skillsDir: "/repo/conventions", want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, ConfigPathFor(filepath.FromSlash(tt.skillsDir)), filepath.FromSlash(tt.want))
+		})
+	}
+}
+
+func TestEnsureRead(t *testing.T) {
+	tests := map[string]struct {
+		config  string
+		repo    bool
+		want    string
+		changed bool
+	}{
+		"new config": {
+			want:    "read:\n  - FILE\n",
+			changed: true,
+		},
+		"other settings are kept": {
+			config:  "# Team settings\nmodel: sonnet # default\n",
+			want:    "# Team settings\nmodel: sonnet # default\nread:\n  - FILE\n",
+			changed: true,
+		},
+		"single entry becomes a list": {
+			config:  "read: STYLE.md\n",
+			want:    "read:\n  - STYLE.md\n  - FILE\n",
+			changed: true,
+		},
+		"empty read": {
+			config:  "read:\n",
+			want:    "read:\n  - FILE\n",
+			changed: true,
+		},
+		"already listed": {
+			config: "read:\n  - .aider/skills/CONVENTIONS.md\n",
+			want:   "read:\n  - .aider/skills/CONVENTIONS.md\n",
+		},
+		"relative in a repository": {
+			repo:    true,
+			want:    "read:\n  - .aider/skills/CONVENTIONS.md\n",
+			changed: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			if tt.repo {
+				if err := os.Mkdir(filepath.Join(root, ".git"), 0o750); err != nil {
+					t.Fatalf("failed to create .git: %v", err)
+				}
+			}
+			confPath := filepath.Join(root, ConfigFileName)
+			if tt.config != "" {
+				util.WriteFile(t, confPath, tt.config)
+			}
+			file := filepath.Join(root, ".aider", "skills", ConventionsFileName)
+
+			changed, err := EnsureRead(confPath, file)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, changed, tt.changed)
+
+			got, err := os.ReadFile(confPath)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, string(got), strings.ReplaceAll(tt.want, "FILE", file))
+
+			files, err := ReadFiles(confPath)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, files[len(files)-1], file)
+		})
+	}
+}
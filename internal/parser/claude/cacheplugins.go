@@ -9,6 +9,7 @@ import (
 	"github.com/klauern/skillsync/internal/logging"
 	"github.com/klauern/skillsync/internal/model"
 	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/parser/plugin"
 	"github.com/klauern/skillsync/internal/util"
 )
 
@@ -97,6 +98,15 @@ func (p *CachePluginsParser) Parse() ([]model.Skill, error) {
 
 // parsePluginDirectory scans a plugin directory for SKILL.md files and parses them.
 func (p *CachePluginsParser) parsePluginDirectory(entry *PluginIndexEntry) ([]model.Skill, error) {
+	license := readPluginLicense(entry.InstallPath)
+	manifest, err := plugin.ReadManifest(entry.InstallPath)
+	if err != nil && !os.IsNotExist(err) {
+		logging.Warn("ignoring invalid plugin manifest",
+			logging.Path(entry.InstallPath),
+			logging.Err(err),
+		)
+	}
+
 	// Find all SKILL.md files in the plugin directory
 	patterns := []string{"**/SKILL.md", "SKILL.md"}
 	files, err := parser.DiscoverFiles(entry.InstallPath, patterns)
@@ -118,7 +128,7 @@ func (p *CachePluginsParser) parsePluginDirectory(entry *PluginIndexEntry) ([]mo
 
 	var skills []model.Skill
 	for _, filePath := range files {
-		skill, err := p.parseSkillFile(filePath, entry)
+		skill, err := p.parseSkillFile(filePath, entry, license)
 		if err != nil {
 			logging.Warn("failed to parse skill file",
 				logging.Path(filePath),
@@ -126,6 +136,19 @@ func (p *CachePluginsParser) parsePluginDirectory(entry *PluginIndexEntry) ([]mo
 			)
 			continue
 		}
+		if skillEntry, ok := manifest.SkillEntry(skill.Name, filePath); ok {
+			// #nosec G304 - filePath is from trusted plugin index
+			content, err := os.ReadFile(filePath)
+			if err == nil {
+				err = plugin.ApplySkillMetadata(skill.Metadata, skillEntry, content)
+			}
+			if err != nil {
+				logging.Warn("skill does not match its plugin manifest",
+					logging.Path(filePath),
+					logging.Err(err),
+				)
+			}
+		}
 		skills = append(skills, skill)
 	}
 
@@ -133,7 +156,7 @@ func (p *CachePluginsParser) parsePluginDirectory(entry *PluginIndexEntry) ([]mo
 }
 
 // parseSkillFile parses a single SKILL.md file with plugin metadata.
-func (p *CachePluginsParser) parseSkillFile(filePath string, entry *PluginIndexEntry) (model.Skill, error) {
+func (p *CachePluginsParser) parseSkillFile(filePath string, entry *PluginIndexEntry, license string) (model.Skill, error) {
 	// #nosec G304 - filePath is from trusted plugin index
 	content, err := os.ReadFile(filePath)
 	if err != nil {
@@ -240,6 +263,9 @@ func (p *CachePluginsParser) parseSkillFile(filePath string, entry *PluginIndexE
 		InstallPath:  entry.InstallPath,
 		IsDev:        false, // Cache plugins are never dev
 		InstallScope: entry.Scope,
+		Commit:       entry.Commit,
+		Repository:   entry.Repository,
+		License:      license,
 	}
 
 	return model.Skill{
@@ -249,6 +275,7 @@ func (p *CachePluginsParser) parseSkillFile(filePath string, entry *PluginIndexE
 		Path:        filePath,
 		Tools:       tools,
 		Metadata:    metadata,
+		Frontmatter: string(result.Frontmatter),
 		Content:     normalizedContent,
 		ModifiedAt:  fileInfo.ModTime(),
 		Scope:       model.ScopePlugin,
@@ -112,7 +112,7 @@ This is the skill content.
 		InstallPath: pluginDir,
 	}
 
-	skill, err := parser.parseSkillFile(skillPath, entry)
+	skill, err := parser.parseSkillFile(skillPath, entry, "")
 	if err != nil {
 		t.Fatalf("failed to parse skill file: %v", err)
 	}
@@ -199,7 +199,7 @@ Content`
 		InstallPath: pluginDir,
 	}
 
-	skill, err := parser.parseSkillFile(skillPath, entry)
+	skill, err := parser.parseSkillFile(skillPath, entry, "")
 	if err != nil {
 		t.Fatalf("failed to parse skill file: %v", err)
 	}
@@ -243,7 +243,7 @@ Just some content without frontmatter.
 		InstallPath: pluginDir,
 	}
 
-	skill, err := parser.parseSkillFile(skillPath, entry)
+	skill, err := parser.parseSkillFile(skillPath, entry, "")
 	if err != nil {
 		t.Fatalf("failed to parse skill file: %v", err)
 	}
@@ -585,7 +585,7 @@ Content.
 				Enabled:     true,
 			}
 
-			skill, err := parser.parseSkillFile(skillPath, entry)
+			skill, err := parser.parseSkillFile(skillPath, entry, "")
 			if err != nil {
 				t.Fatalf("failed to parse skill file: %v", err)
 			}
@@ -27,7 +27,7 @@ type Parser struct {
 // legacy .claude/skills format with .md files.
 func New(basePath string) *Parser {
 	if basePath == "" {
-		basePath = util.ClaudeCodeSkillsPath()
+		basePath = util.PlatformSkillsPath(model.ClaudeCode)
 	}
 	return &Parser{
 		basePath:    basePath,
@@ -117,7 +117,7 @@ func (p *Parser) Parse() ([]model.Skill, error) {
 
 	// Parse each legacy skill file
 	for _, filePath := range legacyFiles {
-		skill, err := p.parseSkillFile(filePath)
+		skill, err := parser.ParseSkillFile("claude-code", filePath, p.parseSkillFile)
 		if err != nil {
 			logging.Warn("failed to parse skill file",
 				logging.Platform(string(p.Platform())),
@@ -278,6 +278,7 @@ func (p *Parser) parseSkillFile(filePath string) (model.Skill, error) {
 		Path:        filePath,
 		Tools:       tools,
 		Metadata:    metadata,
+		Frontmatter: string(result.Frontmatter),
 		Content:     normalizedContent,
 		ModifiedAt:  fileInfo.ModTime(),
 		Type:        skillType,
@@ -363,5 +364,5 @@ func isInsideSkillDir(filePath string, skillDirs map[string]bool) bool {
 
 // DefaultPath returns the default path for Claude Code skills
 func (p *Parser) DefaultPath() string {
-	return util.ClaudeCodeSkillsPath()
+	return util.PlatformSkillsPath(model.ClaudeCode)
 }
@@ -59,6 +59,10 @@ type PluginIndexEntry struct {
 	Scope string
 	// Enabled indicates whether this plugin installation is enabled
 	Enabled bool
+	// Commit is the Git commit the plugin was installed from (gitCommitSha)
+	Commit string
+	// Repository is the marketplace origin from known_marketplaces.json
+	Repository string
 }
 
 // LoadPluginIndex loads and parses the Claude Code installed plugins manifest.
@@ -91,6 +95,8 @@ func LoadPluginIndex() *PluginIndex {
 		return index
 	}
 
+	origins := loadMarketplaceOrigins()
+
 	// Build index by install path
 	for pluginKey, installations := range manifest.Plugins {
 		pluginName, marketplace := parsePluginKey(pluginKey)
@@ -116,6 +122,8 @@ func LoadPluginIndex() *PluginIndex {
 				InstallPath: normalizedPath,
 				Scope:       inst.Scope,
 				Enabled:     inst.IsEnabled(),
+				Commit:      inst.GitCommitSha,
+				Repository:  origins[marketplace],
 			}
 
 			index.byInstallPath[normalizedPath] = entry
@@ -207,13 +215,23 @@ func DetectPluginSource(skillDirPath string, pluginIndex *PluginIndex) *model.Pl
 		}
 
 		// Try to get more accurate info from the plugin index
+		pluginRoot := ""
 		if pluginIndex != nil {
 			if entry := pluginIndex.LookupByPathPrefix(resolvedTarget); entry != nil {
 				pluginInfo.PluginName = entry.PluginKey
 				pluginInfo.Marketplace = entry.Marketplace
 				pluginInfo.Version = entry.Version
+				pluginInfo.Commit = entry.Commit
+				pluginInfo.Repository = entry.Repository
+				pluginRoot = entry.InstallPath
 			}
 		}
+		if pluginRoot == "" {
+			pluginRoot = findPluginRoot(resolvedTarget, pluginCachePath)
+		}
+		if pluginRoot != "" {
+			pluginInfo.License = readPluginLicense(pluginRoot)
+		}
 	} else {
 		// Development symlink - points outside plugin cache
 		pluginInfo.IsDev = true
@@ -223,6 +241,9 @@ func DetectPluginSource(skillDirPath string, pluginIndex *PluginIndex) *model.Pl
 		// - /Users/xxx/dev/klauern-skills/plugins/...
 		// - /Users/xxx/dev/go/beads/examples/...
 		pluginInfo.Marketplace = extractMarketplaceFromDevPath(resolvedTarget)
+		if pluginRoot := findPluginRoot(resolvedTarget, ""); pluginRoot != "" {
+			pluginInfo.License = readPluginLicense(pluginRoot)
+		}
 	}
 
 	return pluginInfo
@@ -0,0 +1,123 @@
+package claude
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// MarketplaceSource describes where a marketplace in known_marketplaces.json is fetched from.
+type MarketplaceSource struct {
+	// Source is the source type: "github", "git", "url", or "directory"
+	Source string `json:"source"`
+	// Repo is the "owner/repo" slug for GitHub sources
+	Repo string `json:"repo,omitempty"`
+	// URL is the repository or manifest URL for git and url sources
+	URL string `json:"url,omitempty"`
+	// Path is the local path for directory sources
+	Path string `json:"path,omitempty"`
+}
+
+// Origin returns a single string identifying where the marketplace comes from.
+func (s MarketplaceSource) Origin() string {
+	switch {
+	case s.Repo != "":
+		return "https://github.com/" + s.Repo
+	case s.URL != "":
+		return s.URL
+	default:
+		return s.Path
+	}
+}
+
+// KnownMarketplace represents a single entry in known_marketplaces.json.
+type KnownMarketplace struct {
+	Source          MarketplaceSource `json:"source"`
+	InstallLocation string            `json:"installLocation"`
+	LastUpdated     string            `json:"lastUpdated"`
+}
+
+// LoadKnownMarketplaces reads known_marketplaces.json, keyed by marketplace
+// name. Returns an empty map if the file doesn't exist or can't be parsed.
+func LoadKnownMarketplaces() map[string]KnownMarketplace {
+	path := util.ClaudeKnownMarketplacesPath()
+
+	// #nosec G304 - path is from trusted source (util package)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logging.Debug("failed to read known_marketplaces.json",
+				logging.Path(path),
+				logging.Err(err),
+			)
+		}
+		return map[string]KnownMarketplace{}
+	}
+
+	var marketplaces map[string]KnownMarketplace
+	if err := json.Unmarshal(data, &marketplaces); err != nil {
+		logging.Warn("failed to parse known_marketplaces.json",
+			logging.Path(path),
+			logging.Err(err),
+		)
+		return map[string]KnownMarketplace{}
+	}
+	return marketplaces
+}
+
+// loadMarketplaceOrigins returns the origin of each known marketplace keyed
+// by name.
+func loadMarketplaceOrigins() map[string]string {
+	origins := make(map[string]string)
+	for name, m := range LoadKnownMarketplaces() {
+		if origin := m.Source.Origin(); origin != "" {
+			origins[name] = origin
+		}
+	}
+	return origins
+}
+
+// readPluginLicense returns the license declared in a plugin's
+// .claude-plugin/plugin.json, or an empty string if none is declared.
+func readPluginLicense(pluginDir string) string {
+	manifestPath := filepath.Join(pluginDir, ".claude-plugin", "plugin.json")
+
+	// #nosec G304 - path is constructed from a plugin install directory
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return ""
+	}
+
+	var manifest struct {
+		License string `json:"license"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(manifest.License)
+}
+
+// findPluginRoot walks up from path to the nearest directory containing
+// .claude-plugin/plugin.json, stopping at stop (inclusive). Returns an empty
+// string if no plugin root is found.
+func findPluginRoot(path, stop string) string {
+	dir := filepath.Clean(path)
+	stop = filepath.Clean(stop)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".claude-plugin", "plugin.json")); err == nil {
+			return dir
+		}
+		if dir == stop {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,128 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePluginManifest(t *testing.T, pluginDir, content string) {
+	t.Helper()
+	manifestDir := filepath.Join(pluginDir, ".claude-plugin")
+	if err := os.MkdirAll(manifestDir, 0o750); err != nil {
+		t.Fatalf("failed to create manifest dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(manifestDir, "plugin.json"), []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write plugin.json: %v", err)
+	}
+}
+
+func TestReadPluginLicense(t *testing.T) {
+	tests := map[string]struct {
+		manifest string
+		want     string
+	}{
+		"spdx string":  {manifest: `{"name": "p", "license": "MIT"}`, want: "MIT"},
+		"no license":   {manifest: `{"name": "p"}`, want: ""},
+		"invalid json": {manifest: `{`, want: ""},
+		"no manifest":  {want: ""},
+		"trims spaces": {manifest: `{"license": " BSD-3-Clause "}`, want: "BSD-3-Clause"},
+		"non-string":   {manifest: `{"license": {"type": "MIT"}}`, want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.manifest != "" {
+				writePluginManifest(t, dir, tt.manifest)
+			}
+			if got := readPluginLicense(dir); got != tt.want {
+				t.Errorf("readPluginLicense() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarketplaceSourceOrigin(t *testing.T) {
+	tests := map[string]struct {
+		source MarketplaceSource
+		want   string
+	}{
+		"github":    {source: MarketplaceSource{Source: "github", Repo: "klauern/skills"}, want: "https://github.com/klauern/skills"},
+		"git":       {source: MarketplaceSource{Source: "git", URL: "https://git.example.com/skills.git"}, want: "https://git.example.com/skills.git"},
+		"directory": {source: MarketplaceSource{Source: "directory", Path: "/src/skills"}, want: "/src/skills"},
+		"empty":     {want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.source.Origin(); got != tt.want {
+				t.Errorf("Origin() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadPluginIndex_Provenance(t *testing.T) {
+	pluginsDir := t.TempDir()
+	t.Setenv("SKILLSYNC_CLAUDE_PLUGINS_PATH", pluginsDir)
+
+	installPath := filepath.Join(pluginsDir, "cache", "klauern-skills", "commits", "1.1.0")
+	installed := `{"version": 2, "plugins": {"commits@klauern-skills": [{"scope": "user", "installPath": "` +
+		installPath + `", "version": "1.1.0", "gitCommitSha": "abc123"}]}}`
+	known := `{"klauern-skills": {"source": {"source": "github", "repo": "klauern/skills"}}}`
+	if err := os.WriteFile(filepath.Join(pluginsDir, "installed_plugins.json"), []byte(installed), 0o600); err != nil {
+		t.Fatalf("failed to write installed_plugins.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(pluginsDir, "known_marketplaces.json"), []byte(known), 0o600); err != nil {
+		t.Fatalf("failed to write known_marketplaces.json: %v", err)
+	}
+
+	entry := LoadPluginIndex().LookupByPath(installPath)
+	if entry == nil {
+		t.Fatal("expected entry for install path")
+	}
+	if entry.Commit != "abc123" {
+		t.Errorf("Commit = %q, want %q", entry.Commit, "abc123")
+	}
+	if entry.Repository != "https://github.com/klauern/skills" {
+		t.Errorf("Repository = %q, want %q", entry.Repository, "https://github.com/klauern/skills")
+	}
+}
+
+func TestCachePluginsParser_License(t *testing.T) {
+	pluginDir := filepath.Join(t.TempDir(), "commits", "1.0.0")
+	writePluginManifest(t, pluginDir, `{"name": "commits", "license": "MIT"}`)
+	skillDir := filepath.Join(pluginDir, "skills", "commit")
+	if err := os.MkdirAll(skillDir, 0o750); err != nil {
+		t.Fatalf("failed to create skill dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("# Commit"), 0o600); err != nil {
+		t.Fatalf("failed to write SKILL.md: %v", err)
+	}
+
+	index := &PluginIndex{
+		byInstallPath: map[string]*PluginIndexEntry{
+			pluginDir: {
+				PluginKey:   "commits@klauern-skills",
+				PluginName:  "commits",
+				Marketplace: "klauern-skills",
+				InstallPath: pluginDir,
+				Commit:      "abc123",
+				Repository:  "https://github.com/klauern/skills",
+			},
+		},
+	}
+
+	skills, err := NewCachePluginsParserWithIndex("", index).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(skills) != 1 || skills[0].PluginInfo == nil {
+		t.Fatalf("expected one plugin skill, got %+v", skills)
+	}
+	info := skills[0].PluginInfo
+	if info.License != "MIT" || info.Commit != "abc123" || info.Repository != "https://github.com/klauern/skills" {
+		t.Errorf("unexpected provenance: %+v", info)
+	}
+}
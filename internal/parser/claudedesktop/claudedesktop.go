@@ -0,0 +1,225 @@
+// Package claudedesktop reads project custom instructions from Claude Desktop
+// and claude.ai, turning each project into a skill.
+//
+// Project instructions are stored on Anthropic's servers rather than on disk,
+// so they are read from a data export (Settings → Privacy → Export data),
+// which holds them in projects.json. The export may be given as that file,
+// the unzipped export directory, or the downloaded zip archive.
+package claudedesktop
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// ProjectsFileName is the file in a data export that lists projects.
+const ProjectsFileName = "projects.json"
+
+// Project is a project entry in projects.json.
+type Project struct {
+	UUID         string    `json:"uuid"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	Instructions string    `json:"prompt_template"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Parser implements the parser.Parser interface for Claude Desktop projects.
+// Skills are attributed to Claude Code, the closest managed platform.
+type Parser struct {
+	path string
+}
+
+// New creates a parser for a data export at path. If path is empty, the
+// Claude Desktop data directory is searched for projects.json.
+func New(path string) *Parser {
+	if path == "" {
+		path = DataDir()
+	}
+	return &Parser{path: path}
+}
+
+// Path returns the export the parser reads.
+func (p *Parser) Path() string {
+	return p.path
+}
+
+// Parse reads the projects in the export and returns a skill for each one
+// with custom instructions. Skill names are derived from project names.
+func (p *Parser) Parse() ([]model.Skill, error) {
+	projects, err := p.loadProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	var skills []model.Skill
+	used := make(map[string]int)
+	for _, project := range projects {
+		if strings.TrimSpace(project.Instructions) == "" {
+			logging.Debug("skipping project without instructions",
+				logging.Skill(project.Name),
+			)
+			continue
+		}
+		name := SkillName(project.Name)
+		if name == "" {
+			name = "project"
+		}
+		// Keep names unique when projects share a name
+		used[name]++
+		if n := used[name]; n > 1 {
+			name = fmt.Sprintf("%s-%d", name, n)
+		}
+		if err := parser.ValidateSkillName(name); err != nil {
+			return nil, fmt.Errorf("cannot derive a skill name from project %q: %w", project.Name, err)
+		}
+
+		description := strings.TrimSpace(project.Description)
+		if description == "" {
+			description = fmt.Sprintf("Instructions from the Claude project %q", project.Name)
+		}
+		metadata := map[string]string{"source": "claude-desktop"}
+		if project.UUID != "" {
+			metadata["project_uuid"] = project.UUID
+		}
+		modified := project.UpdatedAt
+		if modified.IsZero() {
+			modified = project.CreatedAt
+		}
+
+		// Projects have no file of their own; a path under the export names
+		// each one so sync lays it out like any other SKILL.md
+		skills = append(skills, model.Skill{
+			Name:        name,
+			Description: description,
+			Platform:    p.Platform(),
+			Path:        filepath.Join(p.path, name, "SKILL.md"),
+			Metadata:    metadata,
+			Content:     parser.NormalizeContent(project.Instructions),
+			ModifiedAt:  modified,
+		})
+	}
+
+	logging.Debug("completed parsing Claude projects",
+		logging.Path(p.path),
+		logging.Count(len(skills)),
+	)
+	return skills, nil
+}
+
+// loadProjects reads projects.json from a file, directory, or zip archive.
+func (p *Parser) loadProjects() ([]Project, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("claude export not found: %w", err)
+	}
+
+	var data []byte
+	switch {
+	case info.IsDir():
+		projectsPath := filepath.Join(p.path, ProjectsFileName)
+		// #nosec G304 - path is the export directory provided by the user
+		data, err = os.ReadFile(projectsPath)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no %s in %s; Claude keeps project instructions online, "+
+				"so export them from Settings → Privacy → Export data", ProjectsFileName, p.path)
+		}
+	case strings.EqualFold(filepath.Ext(p.path), ".zip"):
+		data, err = readZipProjects(p.path)
+	default:
+		// #nosec G304 - path is the export file provided by the user
+		data, err = os.ReadFile(p.path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseProjects(data)
+}
+
+// readZipProjects returns the contents of projects.json in a zip archive.
+func readZipProjects(path string) ([]byte, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = archive.Close() }()
+
+	for _, file := range archive.File {
+		if filepath.Base(file.Name) != ProjectsFileName {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = rc.Close() }()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("no %s in %s", ProjectsFileName, path)
+}
+
+// ParseProjects parses projects.json, which holds an array of projects. An
+// object with a "projects" array or a single project is also accepted.
+func ParseProjects(data []byte) ([]Project, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "[") {
+		var projects []Project
+		if err := json.Unmarshal(data, &projects); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", ProjectsFileName, err)
+		}
+		return projects, nil
+	}
+
+	var wrapper struct {
+		Projects []Project `json:"projects"`
+		Project
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ProjectsFileName, err)
+	}
+	if wrapper.Projects != nil {
+		return wrapper.Projects, nil
+	}
+	if wrapper.Name != "" || wrapper.Instructions != "" {
+		return []Project{wrapper.Project}, nil
+	}
+	return nil, nil
+}
+
+// SkillName converts a project name to a skill name: lowercase words joined
+// by hyphens, so "Code Review (Go)" becomes "code-review-go".
+func SkillName(projectName string) string {
+	words := strings.FieldsFunc(strings.ToLower(projectName), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	return strings.Join(words, "-")
+}
+
+// DataDir returns the Claude Desktop application data directory.
+func DataDir() string {
+	return util.Paths().ClaudeDesktopPath(runtime.GOOS)
+}
+
+// Platform returns the platform imported projects are attributed to
+func (p *Parser) Platform() model.Platform {
+	return model.ClaudeCode
+}
+
+// DefaultPath returns the Claude Desktop data directory
+func (p *Parser) DefaultPath() string {
+	return DataDir()
+}
@@ -0,0 +1,114 @@
+package claudedesktop
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+const sampleProjects = `[
+  {
+    "uuid": "p-1",
+    "name": "Code Review (Go)",
+    "description": "Reviews Go changes",
+    "prompt_template": "Check error handling.\n",
+    "created_at": "2026-01-01T10:00:00Z",
+    "updated_at": "2026-02-01T10:00:00Z"
+  },
+  {"uuid": "p-2", "name": "Scratch", "prompt_template": ""},
+  {"uuid": "p-3", "name": "code review (go)", "prompt_template": "Be terse."}
+]`
+
+func TestParser_Parse(t *testing.T) {
+	dir := t.TempDir()
+	util.WriteFile(t, filepath.Join(dir, ProjectsFileName), sampleProjects)
+
+	zipPath := filepath.Join(t.TempDir(), "export.zip")
+	writeZip(t, zipPath, "data-2026/"+ProjectsFileName, sampleProjects)
+
+	tests := map[string]string{
+		"projects file":    filepath.Join(dir, ProjectsFileName),
+		"export directory": dir,
+		"zip archive":      zipPath,
+	}
+
+	for name, path := range tests {
+		t.Run(name, func(t *testing.T) {
+			skills, err := New(path).Parse()
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(skills), 2)
+
+			first := skills[0]
+			util.AssertEqual(t, first.Name, "code-review-go")
+			util.AssertEqual(t, first.Description, "Reviews Go changes")
+			util.AssertEqual(t, first.Content, "Check error handling.")
+			util.AssertEqual(t, first.Platform, model.ClaudeCode)
+			util.AssertEqual(t, first.Path, filepath.Join(path, "code-review-go", "SKILL.md"))
+			util.AssertEqual(t, first.Metadata["project_uuid"], "p-1")
+			util.AssertEqual(t, first.ModifiedAt.Equal(time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)), true)
+
+			// Same-named projects get distinct skills; empty projects are skipped
+			util.AssertEqual(t, skills[1].Name, "code-review-go-2")
+			util.AssertEqual(t, skills[1].Description, `Instructions from the Claude project "code review (go)"`)
+		})
+	}
+}
+
+func TestParser_Parse_MissingExport(t *testing.T) {
+	if _, err := New(t.TempDir()).Parse(); err == nil {
+		t.Error("expected an error for a directory without projects.json")
+	}
+}
+
+func TestParseProjects(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  int
+	}{
+		"array":          {input: `[{"name": "a"}, {"name": "b"}]`, want: 2},
+		"wrapped":        {input: `{"projects": [{"name": "a"}]}`, want: 1},
+		"single project": {input: `{"name": "a", "prompt_template": "x"}`, want: 1},
+		"empty object":   {input: `{}`, want: 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			projects, err := ParseProjects([]byte(tt.input))
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(projects), tt.want)
+		})
+	}
+}
+
+func TestSkillName(t *testing.T) {
+	tests := map[string]string{
+		"Code Review (Go)":   "code-review-go",
+		"  release   notes ": "release-notes",
+		"SQL/Postgres tips!": "sql-postgres-tips",
+		"🚀":                  "",
+	}
+
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			util.AssertEqual(t, SkillName(input), want)
+		})
+	}
+}
+
+func writeZip(t *testing.T, path, name, content string) {
+	t.Helper()
+	file, err := os.Create(path)
+	util.AssertNoError(t, err)
+	w := zip.NewWriter(file)
+	entry, err := w.Create(name)
+	util.AssertNoError(t, err)
+	_, err = entry.Write([]byte(content))
+	util.AssertNoError(t, err)
+	util.AssertNoError(t, w.Close())
+	util.AssertNoError(t, file.Close())
+}
@@ -0,0 +1,254 @@
+package codex
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AgentsFileName is the instructions file Codex reads.
+const AgentsFileName = "AGENTS.md"
+
+// SectionMetadataKey marks skills parsed from a marked section of an
+// AGENTS.md file rather than from the whole file.
+const SectionMetadataKey = "agents_section"
+
+// Skills aggregated into AGENTS.md are wrapped in stable markers so they can
+// be split back out and updated in place:
+//
+//	<!-- skillsync:begin name="lint" description="Run the linter" -->
+//	...skill content...
+//	<!-- skillsync:end name="lint" -->
+const (
+	beginMarker = "<!-- skillsync:begin"
+	endMarker   = "<!-- skillsync:end"
+)
+
+// markerAttrPattern matches key="value" attributes of a section marker.
+var markerAttrPattern = regexp.MustCompile(`(\w+)="((?:[^"\\]|\\.)*)"`)
+
+// Section is a skill aggregated into an AGENTS.md file.
+type Section struct {
+	Name        string
+	Description string
+	Content     string
+}
+
+// agentsBlock is a run of AGENTS.md lines: either a marked section or text
+// outside any section.
+type agentsBlock struct {
+	section *Section
+	text    string // The lines as written, including markers and newlines
+}
+
+// SplitAgents splits AGENTS.md content into the text outside any marked
+// section and the sections in file order.
+func SplitAgents(content string) (string, []Section) {
+	var preamble strings.Builder
+	var sections []Section
+	for _, block := range scanAgents(content) {
+		if block.section != nil {
+			sections = append(sections, *block.section)
+		} else {
+			preamble.WriteString(block.text)
+		}
+	}
+	return preamble.String(), sections
+}
+
+// UpsertSection replaces the section with the same name in content, or
+// appends it when there is none. Everything else is kept as written.
+func UpsertSection(content string, section Section) string {
+	var sb strings.Builder
+	found := false
+	for _, block := range scanAgents(content) {
+		if block.section != nil && block.section.Name == section.Name && !found {
+			found = true
+			sb.WriteString(FormatSection(section))
+			continue
+		}
+		sb.WriteString(block.text)
+	}
+	if !found {
+		appendBlock(&sb, FormatSection(section))
+	}
+	return sb.String()
+}
+
+// RemoveSection returns content without the named section and the blank
+// line that separated it from the rest.
+func RemoveSection(content, name string) string {
+	var sb strings.Builder
+	removed := false
+	for _, block := range scanAgents(content) {
+		if block.section != nil && block.section.Name == name {
+			removed = true
+			continue
+		}
+		text := block.text
+		if removed {
+			if current := sb.String(); current == "" || strings.HasSuffix(current, "\n\n") {
+				text = strings.TrimLeft(text, "\n")
+			}
+			removed = false
+		}
+		sb.WriteString(text)
+	}
+
+	result := sb.String()
+	if removed {
+		if result = strings.TrimRight(result, "\n"); result != "" {
+			result += "\n"
+		}
+	}
+	return result
+}
+
+// ReplaceInSections applies replace to the content of each section for
+// which include returns true, and to the text outside sections when
+// include("") does. Markers and everything else are kept as written. It
+// returns the new content and the replacements made per section name, with
+// those outside sections under "".
+func ReplaceInSections(content string, include func(name string) bool, replace func(string) (string, int)) (string, map[string]int) {
+	var sb strings.Builder
+	counts := make(map[string]int)
+	for _, block := range scanAgents(content) {
+		if block.section == nil {
+			if !include("") {
+				sb.WriteString(block.text)
+				continue
+			}
+			replaced, n := replace(block.text)
+			counts[""] += n
+			sb.WriteString(replaced)
+			continue
+		}
+		if !include(block.section.Name) {
+			sb.WriteString(block.text)
+			continue
+		}
+		begin := strings.Index(block.text, "\n") + 1
+		body := block.section.Content
+		replaced, n := replace(body)
+		counts[block.section.Name] += n
+		sb.WriteString(block.text[:begin])
+		sb.WriteString(replaced)
+		sb.WriteString(block.text[begin+len(body):])
+	}
+	return sb.String(), counts
+}
+
+// ReplacePreamble replaces the text outside marked sections with preamble,
+// placed before the sections, which are kept as written.
+func ReplacePreamble(content, preamble string) string {
+	var sb strings.Builder
+	if trimmed := strings.TrimSpace(preamble); trimmed != "" {
+		sb.WriteString(trimmed)
+		sb.WriteString("\n")
+	}
+	for _, block := range scanAgents(content) {
+		if block.section != nil {
+			appendBlock(&sb, block.text)
+		}
+	}
+	return sb.String()
+}
+
+// FormatSection renders a section with its begin and end markers.
+func FormatSection(section Section) string {
+	var sb strings.Builder
+	sb.WriteString(beginMarker + " name=" + quoteAttr(section.Name))
+	if section.Description != "" {
+		sb.WriteString(" description=" + quoteAttr(section.Description))
+	}
+	sb.WriteString(" -->\n")
+	if content := strings.Trim(section.Content, "\n"); content != "" {
+		sb.WriteString(content)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(endMarker + " name=" + quoteAttr(section.Name) + " -->\n")
+	return sb.String()
+}
+
+// scanAgents splits content into blocks. A begin marker without a matching
+// end marker is treated as ordinary text.
+func scanAgents(content string) []agentsBlock {
+	lines := strings.SplitAfter(content, "\n")
+	var blocks []agentsBlock
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			blocks = append(blocks, agentsBlock{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		attrs, ok := parseMarker(lines[i], beginMarker)
+		if !ok || attrs["name"] == "" {
+			text.WriteString(lines[i])
+			continue
+		}
+
+		end := -1
+		for j := i + 1; j < len(lines); j++ {
+			if endAttrs, ok := parseMarker(lines[j], endMarker); ok && endAttrs["name"] == attrs["name"] {
+				end = j
+				break
+			}
+		}
+		if end < 0 {
+			text.WriteString(lines[i])
+			continue
+		}
+
+		flush()
+		blocks = append(blocks, agentsBlock{
+			section: &Section{
+				Name:        attrs["name"],
+				Description: attrs["description"],
+				Content:     strings.Join(lines[i+1:end], ""),
+			},
+			text: strings.Join(lines[i:end+1], ""),
+		})
+		i = end
+	}
+	flush()
+	return blocks
+}
+
+// parseMarker returns the attributes of line if it is a marker of the given kind.
+func parseMarker(line, marker string) (map[string]string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, marker+" ") || !strings.HasSuffix(line, "-->") {
+		return nil, false
+	}
+	attrs := make(map[string]string)
+	body := strings.TrimSuffix(strings.TrimPrefix(line, marker), "-->")
+	for _, match := range markerAttrPattern.FindAllStringSubmatch(body, -1) {
+		value, err := strconv.Unquote(`"` + match[2] + `"`)
+		if err != nil {
+			return nil, false
+		}
+		attrs[match[1]] = value
+	}
+	return attrs, true
+}
+
+// quoteAttr quotes a marker attribute value so it cannot end the comment.
+func quoteAttr(value string) string {
+	return strings.ReplaceAll(strconv.Quote(value), "-->", `--\u003e`)
+}
+
+// appendBlock appends block to sb, separated from earlier content by a blank line.
+func appendBlock(sb *strings.Builder, block string) {
+	if current := sb.String(); current != "" {
+		if !strings.HasSuffix(current, "\n") {
+			sb.WriteString("\n")
+		}
+		if !strings.HasSuffix(current, "\n\n") {
+			sb.WriteString("\n")
+		}
+	}
+	sb.WriteString(block)
+}
@@ -0,0 +1,151 @@
+package codex
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const agentsWithSections = `# Project Guidelines
+
+Run tests before committing.
+
+<!-- skillsync:begin name="lint" description="Run the linter" -->
+Run golangci-lint.
+<!-- skillsync:end name="lint" -->
+
+<!-- skillsync:begin name="review" -->
+Review carefully.
+<!-- skillsync:end name="review" -->
+`
+
+func TestSplitAgents(t *testing.T) {
+	tests := map[string]struct {
+		content      string
+		wantPreamble string
+		wantSections []Section
+	}{
+		"no markers": {
+			content:      "# Guidelines\n\n## Testing\n- Run tests\n",
+			wantPreamble: "# Guidelines\n\n## Testing\n- Run tests\n",
+		},
+		"sections": {
+			content:      agentsWithSections,
+			wantPreamble: "# Project Guidelines\n\nRun tests before committing.\n\n\n",
+			wantSections: []Section{
+				{Name: "lint", Description: "Run the linter", Content: "Run golangci-lint.\n"},
+				{Name: "review", Content: "Review carefully.\n"},
+			},
+		},
+		"unterminated section is text": {
+			content:      "<!-- skillsync:begin name=\"lint\" -->\nBody\n",
+			wantPreamble: "<!-- skillsync:begin name=\"lint\" -->\nBody\n",
+		},
+		"escaped attributes": {
+			content: "<!-- skillsync:begin name=\"x\" description=\"say \\\"hi\\\" --\\u003e\" -->\nBody\n<!-- skillsync:end name=\"x\" -->\n",
+			wantSections: []Section{
+				{Name: "x", Description: `say "hi" -->`, Content: "Body\n"},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			preamble, sections := SplitAgents(tt.content)
+			if preamble != tt.wantPreamble {
+				t.Errorf("preamble = %q, want %q", preamble, tt.wantPreamble)
+			}
+			if !reflect.DeepEqual(sections, tt.wantSections) {
+				t.Errorf("sections = %+v, want %+v", sections, tt.wantSections)
+			}
+		})
+	}
+}
+
+func TestUpsertSection(t *testing.T) {
+	updated := UpsertSection(agentsWithSections, Section{Name: "lint", Description: "Run the linter", Content: "Run make lint.\n"})
+	_, sections := SplitAgents(updated)
+	if len(sections) != 2 || sections[0].Content != "Run make lint.\n" || sections[1].Name != "review" {
+		t.Errorf("UpsertSection() did not replace the section in place:\n%s", updated)
+	}
+
+	added := UpsertSection("# Guidelines\n", Section{Name: "new", Content: "New skill"})
+	want := "# Guidelines\n\n<!-- skillsync:begin name=\"new\" -->\nNew skill\n<!-- skillsync:end name=\"new\" -->\n"
+	if added != want {
+		t.Errorf("UpsertSection() = %q, want %q", added, want)
+	}
+
+	// Writing the same section again must not change the file
+	if again := UpsertSection(added, Section{Name: "new", Content: "New skill"}); again != added {
+		t.Errorf("UpsertSection() is not stable:\n%s", again)
+	}
+}
+
+func TestRemoveSection(t *testing.T) {
+	got := RemoveSection(agentsWithSections, "review")
+	want := "# Project Guidelines\n\nRun tests before committing.\n\n" +
+		"<!-- skillsync:begin name=\"lint\" description=\"Run the linter\" -->\nRun golangci-lint.\n<!-- skillsync:end name=\"lint\" -->\n"
+	if got != want {
+		t.Errorf("RemoveSection() = %q, want %q", got, want)
+	}
+
+	if got := RemoveSection(RemoveSection(got, "lint"), "missing"); got != "# Project Guidelines\n\nRun tests before committing.\n" {
+		t.Errorf("RemoveSection() of every section = %q", got)
+	}
+}
+
+func TestReplacePreamble(t *testing.T) {
+	got := ReplacePreamble(agentsWithSections, "# New Guidelines\n")
+	preamble, sections := SplitAgents(got)
+	if preamble != "# New Guidelines\n\n\n" {
+		t.Errorf("preamble = %q", preamble)
+	}
+	if len(sections) != 2 {
+		t.Errorf("ReplacePreamble() kept %d sections, want 2", len(sections))
+	}
+	if again := ReplacePreamble(got, "# New Guidelines\n"); again != got {
+		t.Errorf("ReplacePreamble() is not stable:\n%s", again)
+	}
+}
+
+func TestReplaceInSections(t *testing.T) {
+	replace := func(s string) (string, int) {
+		return strings.ReplaceAll(s, "Run", "Use"), strings.Count(s, "Run")
+	}
+
+	tests := map[string]struct {
+		names      []string
+		want       string
+		wantCounts map[string]int
+	}{
+		"one section": {
+			names:      []string{"lint"},
+			want:       strings.Replace(agentsWithSections, "Run golangci-lint", "Use golangci-lint", 1),
+			wantCounts: map[string]int{"lint": 1},
+		},
+		"outside sections": {
+			names:      []string{""},
+			want:       strings.Replace(agentsWithSections, "Run tests", "Use tests", 1),
+			wantCounts: map[string]int{"": 1},
+		},
+		"no match": {
+			names:      []string{"review"},
+			want:       agentsWithSections,
+			wantCounts: map[string]int{"review": 0},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			include := func(name string) bool { return slices.Contains(tt.names, name) }
+			got, counts := ReplaceInSections(agentsWithSections, include, replace)
+			if got != tt.want {
+				t.Errorf("ReplaceInSections() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("counts = %v, want %v", counts, tt.wantCounts)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 
@@ -72,7 +73,7 @@ type MCPServer struct {
 // If basePath is empty, uses the default Codex skills directory (~/.codex/skills)
 func New(basePath string) *Parser {
 	if basePath == "" {
-		basePath = util.CodexSkillsPath()
+		basePath = util.PlatformSkillsPath(model.Codex)
 	}
 	return &Parser{basePath: basePath}
 }
@@ -249,7 +250,8 @@ func (p *Parser) parseAgentsFiles(seenNames map[string]bool) ([]model.Skill, err
 	// Parse each file
 	parsedSkills := make([]model.Skill, 0, len(legacyFiles))
 	for _, filePath := range legacyFiles {
-		skill, err := p.parseAgentsFile(filePath)
+		// The name of a file's preamble skill depends on the base path
+		fileSkills, err := parser.ParseFile("codex:"+p.basePath, filePath, p.parseAgentsFile)
 		if err != nil {
 			logging.Warn("failed to parse AGENTS.md file",
 				logging.Platform(string(p.Platform())),
@@ -258,36 +260,76 @@ func (p *Parser) parseAgentsFiles(seenNames map[string]bool) ([]model.Skill, err
 			)
 			continue
 		}
-		// Skip if a SKILL.md or config.toml skill with the same name was already parsed
-		if seenNames[skill.Name] {
-			logging.Debug("skipping legacy AGENTS.md skill, higher precedence version exists",
-				logging.Skill(skill.Name),
-				logging.Path(filePath),
-			)
-			continue
+		for _, skill := range fileSkills {
+			// Skip if a SKILL.md or config.toml skill with the same name was already parsed
+			if seenNames[skill.Name] {
+				logging.Debug("skipping legacy AGENTS.md skill, higher precedence version exists",
+					logging.Skill(skill.Name),
+					logging.Path(filePath),
+				)
+				continue
+			}
+			seenNames[skill.Name] = true
+			parsedSkills = append(parsedSkills, skill)
 		}
-		seenNames[skill.Name] = true
-		parsedSkills = append(parsedSkills, skill)
 	}
 
 	return parsedSkills, nil
 }
 
-// parseAgentsFile parses a single AGENTS.md file
-func (p *Parser) parseAgentsFile(filePath string) (model.Skill, error) {
+// parseAgentsFile parses a single AGENTS.md file. Each section between
+// skillsync markers becomes its own skill; the text outside them becomes the
+// file's agents skill, which is omitted when only sections remain.
+func (p *Parser) parseAgentsFile(filePath string) ([]model.Skill, error) {
 	// Read file content
 	// #nosec G304 - filePath is validated through directory traversal from basePath
 	content, err := os.ReadFile(filePath)
 	if err != nil {
-		return model.Skill{}, fmt.Errorf("failed to read file %q: %w", filePath, err)
+		return nil, fmt.Errorf("failed to read file %q: %w", filePath, err)
 	}
 
 	// Get file modification time
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
-		return model.Skill{}, fmt.Errorf("failed to stat file %q: %w", filePath, err)
+		return nil, fmt.Errorf("failed to stat file %q: %w", filePath, err)
+	}
+
+	preamble, sections := SplitAgents(string(content))
+	var fileSkills []model.Skill
+	if len(sections) == 0 || strings.TrimSpace(preamble) != "" {
+		fileSkills = append(fileSkills, p.agentsSkill(filePath, preamble, fileInfo.ModTime()))
+	}
+
+	for _, section := range sections {
+		if err := parser.ValidateSkillName(section.Name); err != nil {
+			logging.Warn("skipping AGENTS.md section with invalid name",
+				logging.Skill(section.Name),
+				logging.Path(filePath),
+				logging.Err(err),
+			)
+			continue
+		}
+		description := section.Description
+		if description == "" {
+			description = "Codex AGENTS.md instructions"
+		}
+		fileSkills = append(fileSkills, model.Skill{
+			Name:        section.Name,
+			Description: description,
+			Platform:    model.Codex,
+			Path:        filePath,
+			Metadata:    map[string]string{"type": "agents", SectionMetadataKey: "true"},
+			Content:     parser.NormalizeContent(section.Content),
+			ModifiedAt:  fileInfo.ModTime(),
+		})
 	}
 
+	return fileSkills, nil
+}
+
+// agentsSkill builds the skill for the unmarked content of an AGENTS.md file,
+// named after its directory.
+func (p *Parser) agentsSkill(filePath, content string, modTime time.Time) model.Skill {
 	// Generate name from relative path
 	relPath, err := filepath.Rel(p.basePath, filePath)
 	if err != nil {
@@ -308,17 +350,15 @@ func (p *Parser) parseAgentsFile(filePath string) (model.Skill, error) {
 		name = "codex-agents"
 	}
 
-	skill := model.Skill{
+	return model.Skill{
 		Name:        name,
 		Description: "Codex AGENTS.md instructions",
 		Platform:    model.Codex,
 		Path:        filePath,
 		Metadata:    map[string]string{"type": "agents"},
-		Content:     parser.NormalizeContent(string(content)),
-		ModifiedAt:  fileInfo.ModTime(),
+		Content:     parser.NormalizeContent(content),
+		ModifiedAt:  modTime,
 	}
-
-	return skill, nil
 }
 
 // Platform returns the platform identifier for Codex
@@ -328,5 +368,5 @@ func (p *Parser) Platform() model.Platform {
 
 // DefaultPath returns the default path for Codex skills
 func (p *Parser) DefaultPath() string {
-	return util.CodexSkillsPath()
+	return util.PlatformSkillsPath(model.Codex)
 }
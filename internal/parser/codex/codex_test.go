@@ -320,11 +320,15 @@ func TestParser_parseAgentsFile(t *testing.T) {
 
 			// Parse
 			p := New(tmpDir)
-			skill, err := p.parseAgentsFile(fullPath)
+			skills, err := p.parseAgentsFile(fullPath)
 			if err != nil {
 				t.Errorf("parseAgentsFile() error = %v", err)
 				return
 			}
+			if len(skills) != 1 {
+				t.Fatalf("parseAgentsFile() returned %d skills, want 1", len(skills))
+			}
+			skill := skills[0]
 
 			if skill.Name != tt.wantName {
 				t.Errorf("skill.Name = %q, want %q", skill.Name, tt.wantName)
@@ -699,3 +703,37 @@ This skill has Agent Skills Standard fields.`
 		t.Errorf("expected 2 tools, got %d", len(skill.Tools))
 	}
 }
+
+func TestParser_Parse_AgentsSections(t *testing.T) {
+	tmpDir := t.TempDir()
+	// #nosec G306 - test file permissions
+	if err := os.WriteFile(filepath.Join(tmpDir, "AGENTS.md"), []byte(agentsWithSections), 0o644); err != nil {
+		t.Fatalf("failed to write AGENTS.md: %v", err)
+	}
+
+	skills, err := New(tmpDir).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(skills) != 3 {
+		t.Fatalf("Parse() returned %d skills, want 3", len(skills))
+	}
+
+	preamble := findSkillByName(t, skills, "agents")
+	if preamble.Content != "# Project Guidelines\n\nRun tests before committing." {
+		t.Errorf("agents content = %q", preamble.Content)
+	}
+
+	lint := findSkillByName(t, skills, "lint")
+	if lint.Description != "Run the linter" || lint.Content != "Run golangci-lint." {
+		t.Errorf("lint = %+v", lint)
+	}
+	if lint.Metadata[SectionMetadataKey] == "" {
+		t.Error("section skill should be marked with SectionMetadataKey")
+	}
+
+	review := findSkillByName(t, skills, "review")
+	if review.Description != "Codex AGENTS.md instructions" {
+		t.Errorf("review description = %q", review.Description)
+	}
+}
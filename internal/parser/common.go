@@ -2,12 +2,18 @@ package parser
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/cache"
+	"github.com/klauern/skillsync/internal/logging"
 )
 
 // FrontmatterResult contains the parsed frontmatter and remaining content.
@@ -202,9 +208,9 @@ func DiscoverFiles(baseDir string, patterns []string) ([]string, error) {
 
 // walkMatch performs recursive file matching for patterns containing **.
 // It follows symlinks to directories to support symlinked skill directories.
+// With DiscoveryLimits.RepoCache set, walks inside a git repository are
+// answered from the repository's discovery cache while it is current.
 func walkMatch(baseDir, pattern string) ([]string, error) {
-	var matches []string
-
 	// Remove ** from pattern to get the file extension or pattern to match
 	parts := strings.Split(pattern, "**")
 	if len(parts) != 2 {
@@ -214,10 +220,26 @@ func walkMatch(baseDir, pattern string) ([]string, error) {
 	// The suffix after ** (e.g., "/*.md" becomes "*.md")
 	suffix := strings.TrimPrefix(parts[1], "/")
 
+	limits := CurrentDiscoveryLimits()
+	var walkCache *cache.WalkCache
+	if limits.RepoCache {
+		walkCache = cache.OpenWalkCache(baseDir)
+	}
+	query := walkQuery(pattern, limits)
+	if matches, ok := walkCache.Lookup(baseDir, query); ok {
+		return matches, nil
+	}
+
+	var matches []string
+	// dirs records the modification time of each directory walked, which
+	// changes whenever an entry is added, removed, or renamed in it
+	dirs := make(map[string]time.Time)
+
 	// Use a custom walker that follows symlinks
-	err := walkFollowSymlinks(baseDir, func(path string, info os.FileInfo) error {
+	limited, err := walkFollowSymlinks(baseDir, func(path string, info os.FileInfo) error {
 		// Skip directories
 		if info.IsDir() {
+			dirs[path] = info.ModTime()
 			return nil
 		}
 
@@ -247,17 +269,63 @@ func walkMatch(baseDir, pattern string) ([]string, error) {
 		return nil, fmt.Errorf("failed to walk directory: %w", err)
 	}
 
+	// A walk cut short by a limit is not cached, so its warning repeats
+	if !limited {
+		walkCache.Store(baseDir, query, dirs, matches)
+	}
 	return matches, nil
 }
 
+// walkQuery identifies a walk for pattern under limits in the walk cache,
+// since each of them changes what the walk finds.
+func walkQuery(pattern string, limits DiscoveryLimits) string {
+	return fmt.Sprintf("%s|depth=%d,files=%d,ignore=%s",
+		pattern, limits.MaxDepth, limits.MaxFiles, strings.Join(limits.Ignore, ","))
+}
+
+// errMaxFiles stops a walk that has visited the maximum number of files.
+var errMaxFiles = errors.New("discovery file limit reached")
+
 // walkFollowSymlinks walks a directory tree, following symlinks to directories.
-// It detects and avoids cycles by tracking visited directories.
-func walkFollowSymlinks(root string, walkFn func(path string, info os.FileInfo) error) error {
-	visited := make(map[string]bool)
-	return walkFollowSymlinksImpl(root, visited, walkFn)
+// It detects and avoids cycles by tracking visited directories, skips ignored
+// directories, and stops at the current discovery limits with a warning. It
+// reports whether a limit cut the walk short.
+func walkFollowSymlinks(root string, walkFn func(path string, info os.FileInfo) error) (bool, error) {
+	w := &limitedWalker{
+		root:    root,
+		limits:  CurrentDiscoveryLimits(),
+		visited: make(map[string]bool),
+		walkFn:  walkFn,
+	}
+	err := w.walk(root, 0)
+	if errors.Is(err, errMaxFiles) {
+		logging.Warn("discovery stopped at the file limit; raise discovery.max_files or add discovery.ignore patterns",
+			logging.Path(root),
+			slog.Int("max_files", w.limits.MaxFiles),
+		)
+		return true, nil
+	}
+	if w.depthLimited != "" {
+		logging.Warn("discovery skipped directories below the depth limit; raise discovery.max_depth if skills live deeper",
+			logging.Path(w.depthLimited),
+			slog.Int("max_depth", w.limits.MaxDepth),
+		)
+	}
+	return w.depthLimited != "", err
+}
+
+// limitedWalker holds the state of one walkFollowSymlinks walk.
+type limitedWalker struct {
+	root    string
+	limits  DiscoveryLimits
+	visited map[string]bool
+	walkFn  func(path string, info os.FileInfo) error
+	files   int
+	// depthLimited is the first directory not entered because of MaxDepth
+	depthLimited string
 }
 
-func walkFollowSymlinksImpl(path string, visited map[string]bool, walkFn func(path string, info os.FileInfo) error) error {
+func (w *limitedWalker) walk(path string, depth int) error {
 	// Resolve symlinks to get the real path for cycle detection
 	realPath, err := filepath.EvalSymlinks(path)
 	if err != nil {
@@ -265,10 +333,10 @@ func walkFollowSymlinksImpl(path string, visited map[string]bool, walkFn func(pa
 	}
 
 	// Check for cycles
-	if visited[realPath] {
+	if w.visited[realPath] {
 		return nil
 	}
-	visited[realPath] = true
+	w.visited[realPath] = true
 
 	// Get info about the path (follows symlinks)
 	info, err := os.Stat(path)
@@ -276,13 +344,31 @@ func walkFollowSymlinksImpl(path string, visited map[string]bool, walkFn func(pa
 		return nil // Skip paths we can't stat
 	}
 
+	if info.IsDir() && path != w.root && w.limits.Ignored(info.Name()) {
+		logging.Debug("discovery: skipping ignored directory", logging.Path(path))
+		return nil
+	}
+	if !info.IsDir() {
+		w.files++
+		if w.limits.MaxFiles > 0 && w.files > w.limits.MaxFiles {
+			return errMaxFiles
+		}
+	}
+
 	// Call the walk function
-	if err := walkFn(path, info); err != nil {
+	if err := w.walkFn(path, info); err != nil {
 		return err
 	}
 
 	// If it's a directory, recurse into it
 	if info.IsDir() {
+		if w.limits.MaxDepth > 0 && depth > w.limits.MaxDepth {
+			if w.depthLimited == "" {
+				w.depthLimited = path
+			}
+			return nil
+		}
+
 		entries, err := os.ReadDir(path)
 		if err != nil {
 			return nil // Skip directories we can't read
@@ -290,7 +376,7 @@ func walkFollowSymlinksImpl(path string, visited map[string]bool, walkFn func(pa
 
 		for _, entry := range entries {
 			childPath := filepath.Join(path, entry.Name())
-			if err := walkFollowSymlinksImpl(childPath, visited, walkFn); err != nil {
+			if err := w.walk(childPath, depth+1); err != nil {
 				return err
 			}
 		}
@@ -1,10 +1,16 @@
 package parser
 
 import (
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/klauern/skillsync/internal/cache"
+	"github.com/klauern/skillsync/internal/logging"
 	"github.com/klauern/skillsync/internal/util"
 )
 
@@ -289,6 +295,154 @@ func TestDiscoverFiles(t *testing.T) {
 	}
 }
 
+func TestDiscoverFiles_Limits(t *testing.T) {
+	tempDir := util.CreateTempDir(t)
+	for _, file := range []string{"a/SKILL.md", "a/b/c/SKILL.md", "node_modules/pkg/SKILL.md"} {
+		util.WriteFile(t, filepath.Join(tempDir, file), "test content")
+	}
+
+	tests := map[string]struct {
+		limits      DiscoveryLimits
+		want        []string
+		wantWarning bool
+	}{
+		"defaults skip node_modules": {
+			limits: DefaultDiscoveryLimits(),
+			want:   []string{"a/SKILL.md", "a/b/c/SKILL.md"},
+		},
+		"no limits": {
+			limits: DiscoveryLimits{},
+			want:   []string{"a/SKILL.md", "a/b/c/SKILL.md", "node_modules/pkg/SKILL.md"},
+		},
+		"glob ignore pattern": {
+			limits: DiscoveryLimits{Ignore: []string{"node_*", "b"}},
+			want:   []string{"a/SKILL.md"},
+		},
+		"max depth": {
+			limits:      DiscoveryLimits{Ignore: DefaultIgnore, MaxDepth: 1},
+			want:        []string{"a/SKILL.md"},
+			wantWarning: true,
+		},
+		"max files": {
+			limits:      DiscoveryLimits{MaxFiles: 1},
+			want:        []string{"a/SKILL.md"},
+			wantWarning: true,
+		},
+	}
+
+	logging.SetDefault(logging.New(logging.Options{Level: logging.LevelInfo, Output: io.Discard}))
+	t.Cleanup(func() {
+		SetDiscoveryLimits(DefaultDiscoveryLimits())
+		logging.ResetWarnings()
+	})
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			SetDiscoveryLimits(tt.limits)
+			logging.ResetWarnings()
+
+			got, err := DiscoverFiles(tempDir, []string{"**/SKILL.md"})
+			if err != nil {
+				t.Fatalf("DiscoverFiles() error = %v", err)
+			}
+			var gotRelative []string
+			for _, absPath := range got {
+				rel, err := filepath.Rel(tempDir, absPath)
+				if err != nil {
+					t.Fatalf("failed to get relative path: %v", err)
+				}
+				gotRelative = append(gotRelative, filepath.ToSlash(rel))
+			}
+			sort.Strings(gotRelative)
+			util.AssertEqual(t, strings.Join(gotRelative, ","), strings.Join(tt.want, ","))
+
+			if gotWarning := logging.Warnings() > 0; gotWarning != tt.wantWarning {
+				t.Errorf("warned = %v, want %v", gotWarning, tt.wantWarning)
+			}
+		})
+	}
+}
+
+// backdate sets the modification time of dir and the directories below it
+// outside the racy window, so walks of them are cached.
+func backdate(t *testing.T, dir string) {
+	t.Helper()
+	old := time.Now().Add(-time.Hour)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	})
+	util.AssertNoError(t, err)
+}
+
+func TestDiscoverFiles_RepoCache(t *testing.T) {
+	repo := t.TempDir()
+	util.AssertNoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o750))
+	skills := filepath.Join(repo, ".claude", "skills")
+	util.WriteFile(t, filepath.Join(skills, "lint", "SKILL.md"), "lint")
+	t.Chdir(repo)
+
+	limits := DefaultDiscoveryLimits()
+	limits.RepoCache = true
+	SetDiscoveryLimits(limits)
+	t.Cleanup(func() { SetDiscoveryLimits(DefaultDiscoveryLimits()) })
+
+	discover := func() string {
+		t.Helper()
+		files, err := DiscoverFiles(skills, []string{"**/SKILL.md"})
+		util.AssertNoError(t, err)
+		var names []string
+		for _, file := range files {
+			names = append(names, filepath.Base(filepath.Dir(file)))
+		}
+		return strings.Join(names, ",")
+	}
+	cacheFile := filepath.Join(repo, ".skillsync", "cache", "discovery.json")
+
+	// A directory changed just now is not cached
+	util.AssertEqual(t, discover(), "lint")
+	if _, err := os.Stat(cacheFile); !os.IsNotExist(err) {
+		t.Fatalf("racy walk was cached: %v", err)
+	}
+
+	backdate(t, skills)
+	util.AssertEqual(t, discover(), "lint")
+	if _, err := os.Stat(cacheFile); err != nil {
+		t.Fatalf("walk was not cached: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(repo, ".skillsync", "cache", ".gitignore"))
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), "*\n")
+
+	// The next discovery is answered from the cache
+	matches, ok := cache.OpenWalkCache(skills).Lookup(skills, walkQuery("**/SKILL.md", limits))
+	util.AssertEqual(t, ok, true)
+	util.AssertEqual(t, len(matches), 1)
+	_, ok = cache.OpenWalkCache(skills).Lookup(skills, walkQuery("**/*.md", limits))
+	util.AssertEqual(t, ok, false)
+
+	// Adding a skill directory changes its parent, invalidating the walk
+	util.WriteFile(t, filepath.Join(skills, "review", "SKILL.md"), "review")
+	_, ok = cache.OpenWalkCache(skills).Lookup(skills, walkQuery("**/SKILL.md", limits))
+	util.AssertEqual(t, ok, false)
+	util.AssertEqual(t, discover(), "lint,review")
+
+	t.Run("outside the repository", func(t *testing.T) {
+		other := t.TempDir()
+		util.WriteFile(t, filepath.Join(other, "a", "SKILL.md"), "a")
+		backdate(t, other)
+		util.AssertNoError(t, os.Remove(cacheFile))
+
+		_, err := DiscoverFiles(other, []string{"**/SKILL.md"})
+		util.AssertNoError(t, err)
+		if _, err := os.Stat(cacheFile); !os.IsNotExist(err) {
+			t.Errorf("walk outside the repository was cached: %v", err)
+		}
+	})
+}
+
 func TestValidateSkillName(t *testing.T) {
 	tests := map[string]struct {
 		name    string
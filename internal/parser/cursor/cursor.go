@@ -25,7 +25,7 @@ type Parser struct {
 // legacy .cursor/rules format with .md/.mdc files.
 func New(basePath string) *Parser {
 	if basePath == "" {
-		basePath = util.CursorSkillsPath()
+		basePath = util.PlatformSkillsPath(model.Cursor)
 	}
 	return &Parser{basePath: basePath}
 }
@@ -108,7 +108,7 @@ func (p *Parser) Parse() ([]model.Skill, error) {
 
 	// Parse each legacy skill file
 	for _, filePath := range legacyFiles {
-		skill, err := p.parseSkillFile(filePath)
+		skill, err := parser.ParseSkillFile("cursor", filePath, p.parseSkillFile)
 		if err != nil {
 			logging.Warn("failed to parse skill file",
 				logging.Platform(string(p.Platform())),
@@ -221,6 +221,7 @@ func (p *Parser) parseSkillFile(filePath string) (model.Skill, error) {
 		Path:        filePath,
 		Tools:       nil, // Cursor doesn't specify tools in frontmatter
 		Metadata:    metadata,
+		Frontmatter: string(result.Frontmatter),
 		Content:     normalizedContent,
 		ModifiedAt:  fileInfo.ModTime(),
 	}
@@ -253,5 +254,5 @@ func isInsideSkillDir(filePath string, skillDirs map[string]bool) bool {
 
 // DefaultPath returns the default path for Cursor skills
 func (p *Parser) DefaultPath() string {
-	return util.CursorSkillsPath()
+	return util.PlatformSkillsPath(model.Cursor)
 }
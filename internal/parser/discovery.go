@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+)
+
+// Default discovery limits.
+const (
+	// DefaultMaxDepth is how many directory levels below a search path
+	// discovery descends.
+	DefaultMaxDepth = 12
+	// DefaultMaxFiles is how many files discovery visits under a search path
+	// before it stops.
+	DefaultMaxFiles = 50000
+)
+
+// DefaultIgnore lists the directory names discovery skips by default:
+// dependency, VCS, and cache directories that never hold skills but can
+// hold hundreds of thousands of files.
+var DefaultIgnore = []string{
+	".git",
+	".hg",
+	".svn",
+	"node_modules",
+	"bower_components",
+	"vendor",
+	".venv",
+	"venv",
+	"__pycache__",
+	".tox",
+	".terraform",
+	".gradle",
+}
+
+// DiscoveryLimits bound the recursive walks of skill discovery.
+type DiscoveryLimits struct {
+	// Ignore lists glob patterns matched against directory names; matching
+	// directories below a search path are skipped
+	Ignore []string
+	// MaxDepth is how many directory levels below a search path discovery
+	// descends; 0 means no limit
+	MaxDepth int
+	// MaxFiles is the number of files visited below a search path before
+	// discovery stops; 0 means no limit
+	MaxFiles int
+	// RepoCache keeps the results of walks inside a git repository in the
+	// repository's .skillsync/cache, reused until a walked directory changes
+	RepoCache bool
+}
+
+// DefaultDiscoveryLimits returns the default discovery limits.
+func DefaultDiscoveryLimits() DiscoveryLimits {
+	return DiscoveryLimits{
+		Ignore:   slices.Clone(DefaultIgnore),
+		MaxDepth: DefaultMaxDepth,
+		MaxFiles: DefaultMaxFiles,
+	}
+}
+
+var discoveryLimits atomic.Pointer[DiscoveryLimits]
+
+func init() {
+	SetDiscoveryLimits(DefaultDiscoveryLimits())
+}
+
+// SetDiscoveryLimits sets the limits used by every later discovery.
+func SetDiscoveryLimits(limits DiscoveryLimits) {
+	discoveryLimits.Store(&limits)
+}
+
+// CurrentDiscoveryLimits returns the limits discovery uses.
+func CurrentDiscoveryLimits() DiscoveryLimits {
+	return *discoveryLimits.Load()
+}
+
+// Ignored reports whether discovery skips a directory with the given name.
+func (l DiscoveryLimits) Ignored(name string) bool {
+	for _, pattern := range l.Ignore {
+		if matched, err := filepath.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
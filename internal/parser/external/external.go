@@ -0,0 +1,358 @@
+// Package external implements the Parser interface for platforms configured
+// under platforms.custom, whose skills are parsed by an external program.
+//
+// The program is run once per skills directory with the directory as its
+// last argument, and SKILLSYNC_PLATFORM, SKILLSYNC_BASE_PATH, and
+// SKILLSYNC_PROTOCOL_VERSION in its environment. It prints a Response as
+// JSON on stdout and exits 0; anything it writes to stderr is reported
+// when it fails.
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// ProtocolVersion is the version of the protocol between skillsync and
+// external parsers.
+const ProtocolVersion = 1
+
+// DefaultFileName is the file name template of skills written to a custom
+// platform without one.
+const DefaultFileName = "{{.Name}}.md"
+
+// Timeout is how long an external parser may run.
+const Timeout = 30 * time.Second
+
+// namePattern matches valid custom platform names.
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Response is what an external parser prints on stdout.
+type Response struct {
+	// Version is the protocol version the parser speaks; 0 means
+	// ProtocolVersion
+	Version int `json:"version,omitempty"`
+	// Skills are the skills found in the directory
+	Skills []Skill `json:"skills"`
+}
+
+// Skill is a skill reported by an external parser. Only Name is required.
+type Skill struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Path is the file the skill was read from, absolute or relative to the
+	// skills directory (default: the skills directory)
+	Path string `json:"path,omitempty"`
+	// Type is "skill" (the default) or "prompt"
+	Type    string   `json:"type,omitempty"`
+	Trigger string   `json:"trigger,omitempty"`
+	Tools   []string `json:"tools,omitempty"`
+	// Frontmatter is the raw YAML frontmatter, kept when the skill is written
+	// to another platform
+	Frontmatter string            `json:"frontmatter,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Content     string            `json:"content"`
+	// ModifiedAt defaults to the modification time of Path
+	ModifiedAt time.Time `json:"modified_at,omitzero"`
+}
+
+// Definition describes a custom platform.
+type Definition struct {
+	// Name is the platform's name, used like a built-in platform's
+	Name model.Platform
+	// Command is the external parser and its arguments
+	Command []string
+	// FileName is a text/template for the path, relative to the skills
+	// directory, that skills are written to (default DefaultFileName)
+	FileName string
+	// WriteTemplate is a text/template for the content of written skills.
+	// Without one, skills are written as markdown with YAML frontmatter.
+	WriteTemplate string
+}
+
+// Validate checks that the definition can be used.
+func (d Definition) Validate() error {
+	if !namePattern.MatchString(string(d.Name)) {
+		return fmt.Errorf("custom platform name %q must be lowercase letters, digits, and hyphens", d.Name)
+	}
+	if d.Name.IsBuiltin() {
+		return fmt.Errorf("custom platform %q has the name of a built-in platform", d.Name)
+	}
+	if len(d.Command) == 0 {
+		return fmt.Errorf("custom platform %q requires a command", d.Name)
+	}
+	if _, _, err := d.templates(); err != nil {
+		return fmt.Errorf("custom platform %q: %w", d.Name, err)
+	}
+	return nil
+}
+
+// templates parses the definition's file name and write templates. The
+// write template is nil when the definition has none.
+func (d Definition) templates() (fileName, write *template.Template, err error) {
+	fileNameText := d.FileName
+	if fileNameText == "" {
+		fileNameText = DefaultFileName
+	}
+	funcs := template.FuncMap{"join": strings.Join}
+	if fileName, err = template.New("file_name").Funcs(funcs).Option("missingkey=zero").Parse(fileNameText); err != nil {
+		return nil, nil, fmt.Errorf("invalid file_name: %w", err)
+	}
+	if d.WriteTemplate != "" {
+		if write, err = template.New("write_template").Funcs(funcs).Option("missingkey=zero").Parse(d.WriteTemplate); err != nil {
+			return nil, nil, fmt.Errorf("invalid write_template: %w", err)
+		}
+	}
+	return fileName, write, nil
+}
+
+// TemplateData is the data the file name and write templates are executed
+// with.
+type TemplateData struct {
+	Name        string
+	Description string
+	Type        string
+	Trigger     string
+	Tools       []string
+	Frontmatter string
+	Metadata    map[string]string
+	Content     string
+}
+
+// FilePath returns the path, relative to the skills directory, that skill
+// is written to.
+func (d Definition) FilePath(skill model.Skill) (string, error) {
+	fileName, _, err := d.templates()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := fileName.Execute(&buf, templateData(skill)); err != nil {
+		return "", fmt.Errorf("failed to render file name of %q: %w", skill.Name, err)
+	}
+	path := filepath.Clean(filepath.FromSlash(strings.TrimSpace(buf.String())))
+	if path == "." || !filepath.IsLocal(path) {
+		return "", fmt.Errorf("file name %q of %q is not inside the skills directory", buf.String(), skill.Name)
+	}
+	return path, nil
+}
+
+// Render returns the content skill is written with, and false when the
+// definition has no write template.
+func (d Definition) Render(skill model.Skill) (string, bool, error) {
+	_, write, err := d.templates()
+	if err != nil || write == nil {
+		return "", false, err
+	}
+	var buf bytes.Buffer
+	if err := write.Execute(&buf, templateData(skill)); err != nil {
+		return "", false, fmt.Errorf("failed to render %q: %w", skill.Name, err)
+	}
+	return buf.String(), true, nil
+}
+
+func templateData(skill model.Skill) TemplateData {
+	return TemplateData{
+		Name:        skill.Name,
+		Description: skill.Description,
+		Type:        skill.Type.String(),
+		Trigger:     skill.Trigger,
+		Tools:       skill.Tools,
+		Frontmatter: skill.Frontmatter,
+		Metadata:    skill.Metadata,
+		Content:     skill.Content,
+	}
+}
+
+var definitions atomic.Pointer[map[model.Platform]Definition]
+
+// SetDefinitions registers the custom platforms, replacing any registered
+// before, and makes them valid platforms. Invalid definitions are skipped
+// with a warning.
+func SetDefinitions(defs []Definition) {
+	registered := make(map[model.Platform]Definition, len(defs))
+	var platforms []model.Platform
+	for _, def := range defs {
+		if err := def.Validate(); err != nil {
+			logging.Warn("skipping custom platform", logging.Err(err))
+			continue
+		}
+		if _, ok := registered[def.Name]; ok {
+			logging.Warn("skipping duplicate custom platform", logging.Platform(string(def.Name)))
+			continue
+		}
+		registered[def.Name] = def
+		platforms = append(platforms, def.Name)
+	}
+	definitions.Store(&registered)
+	model.SetCustomPlatforms(platforms)
+}
+
+// Lookup returns the definition of a custom platform.
+func Lookup(platform model.Platform) (Definition, bool) {
+	registered := definitions.Load()
+	if registered == nil {
+		return Definition{}, false
+	}
+	def, ok := (*registered)[platform]
+	return def, ok
+}
+
+// Parser implements the parser.Parser interface for a custom platform
+type Parser struct {
+	def      Definition
+	basePath string
+}
+
+// New creates a parser for the custom platform def.
+// If basePath is empty, uses the platform's default skills directory
+// (~/.<name>/skills)
+func New(def Definition, basePath string) *Parser {
+	if basePath == "" {
+		basePath = util.PlatformSkillsPath(def.Name)
+	}
+	return &Parser{def: def, basePath: basePath}
+}
+
+// Parse runs the external parser on the skills directory.
+func (p *Parser) Parse() ([]model.Skill, error) {
+	if _, err := os.Stat(p.basePath); os.IsNotExist(err) {
+		logging.Debug("skills directory not found",
+			logging.Platform(string(p.Platform())),
+			logging.Path(p.basePath),
+		)
+		return []model.Skill{}, nil
+	}
+
+	response, err := p.run()
+	if err != nil {
+		return nil, err
+	}
+	if response.Version > ProtocolVersion {
+		return nil, fmt.Errorf("parser for %s speaks protocol version %d; this skillsync supports up to %d",
+			p.def.Name, response.Version, ProtocolVersion)
+	}
+
+	skills := make([]model.Skill, 0, len(response.Skills))
+	for _, reported := range response.Skills {
+		skill, err := p.toSkill(reported)
+		if err != nil {
+			logging.Warn("skipping skill from external parser",
+				logging.Platform(string(p.Platform())),
+				logging.Err(err),
+			)
+			continue
+		}
+		skills = append(skills, skill)
+	}
+
+	logging.Debug("completed parsing skills",
+		logging.Platform(string(p.Platform())),
+		logging.Count(len(skills)),
+	)
+	return skills, nil
+}
+
+// run runs the external parser and decodes its response.
+func (p *Parser) run() (Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	args := append(slices.Clone(p.def.Command[1:]), p.basePath)
+	// #nosec G204 - the command comes from the user's config
+	cmd := exec.CommandContext(ctx, p.def.Command[0], args...)
+	cmd.Env = append(os.Environ(),
+		"SKILLSYNC_PLATFORM="+string(p.def.Name),
+		"SKILLSYNC_BASE_PATH="+p.basePath,
+		"SKILLSYNC_PROTOCOL_VERSION="+strconv.Itoa(ProtocolVersion),
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", Timeout)
+		}
+		return Response{}, fmt.Errorf("parser for %s (%s) failed: %w", p.def.Name, p.def.Command[0], err)
+	}
+
+	var response Response
+	if err := json.Unmarshal(output, &response); err != nil {
+		return Response{}, fmt.Errorf("failed to parse output of the parser for %s: %w", p.def.Name, err)
+	}
+	return response, nil
+}
+
+// toSkill converts a reported skill to a model.Skill.
+func (p *Parser) toSkill(reported Skill) (model.Skill, error) {
+	if err := parser.ValidateSkillName(reported.Name); err != nil {
+		return model.Skill{}, fmt.Errorf("invalid skill name %q: %w", reported.Name, err)
+	}
+
+	skill := model.Skill{
+		Name:        reported.Name,
+		Description: reported.Description,
+		Platform:    p.def.Name,
+		Path:        reported.Path,
+		Tools:       reported.Tools,
+		Trigger:     reported.Trigger,
+		Frontmatter: reported.Frontmatter,
+		Metadata:    reported.Metadata,
+		Content:     parser.NormalizeContent(reported.Content),
+		ModifiedAt:  reported.ModifiedAt,
+	}
+	if skill.Metadata == nil {
+		skill.Metadata = make(map[string]string)
+	}
+	if reported.Type != "" {
+		skillType, err := model.ParseSkillType(reported.Type)
+		if err != nil {
+			return model.Skill{}, fmt.Errorf("skill %q: %w", reported.Name, err)
+		}
+		skill.Type = skillType
+	}
+
+	switch {
+	case skill.Path == "":
+		skill.Path = p.basePath
+	case !filepath.IsAbs(skill.Path):
+		skill.Path = filepath.Join(p.basePath, skill.Path)
+	}
+	if skill.ModifiedAt.IsZero() {
+		if info, err := os.Stat(skill.Path); err == nil {
+			skill.ModifiedAt = info.ModTime()
+		}
+	}
+	return skill, nil
+}
+
+// Platform returns the custom platform this parser is associated with.
+func (p *Parser) Platform() model.Platform {
+	return p.def.Name
+}
+
+// DefaultPath returns the configured base path.
+func (p *Parser) DefaultPath() string {
+	return p.basePath
+}
@@ -0,0 +1,164 @@
+package external
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// writeParser writes a shell script that prints output and exits with code,
+// and returns the command running it.
+func writeParser(t *testing.T, output string, code int) []string {
+	t.Helper()
+	script := filepath.Join(t.TempDir(), "parser.sh")
+	util.WriteFile(t, script, "cat <<'EOF'\n"+output+"\nEOF\necho \"parsed $SKILLSYNC_PLATFORM\" >&2\nexit "+strconv.Itoa(code)+"\n")
+	return []string{"sh", script}
+}
+
+func TestParser_Parse(t *testing.T) {
+	tests := map[string]struct {
+		output    string
+		code      int
+		wantNames []string
+		wantErr   string
+	}{
+		"skills": {
+			output:    `{"version": 1, "skills": [{"name": "lint", "description": "Lint code", "path": "lint.md", "content": "Run the linter.\n"}, {"name": "fmt", "type": "prompt", "content": "Format."}]}`,
+			wantNames: []string{"lint", "fmt"},
+		},
+		"invalid skill skipped": {
+			output:    `{"skills": [{"name": "bad name", "content": "x"}, {"name": "lint", "content": "y"}]}`,
+			wantNames: []string{"lint"},
+		},
+		"no skills": {
+			output:    `{"skills": []}`,
+			wantNames: []string{},
+		},
+		"failure": {
+			output:  `{}`,
+			code:    1,
+			wantErr: "parsed windsurf",
+		},
+		"newer protocol": {
+			output:  `{"version": 2, "skills": []}`,
+			wantErr: "protocol version 2",
+		},
+		"not json": {
+			output:  `lint`,
+			wantErr: "failed to parse output",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			basePath := t.TempDir()
+			def := Definition{Name: "windsurf", Command: writeParser(t, tt.output, tt.code)}
+
+			skills, err := New(def, basePath).Parse()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+
+			names := make([]string, 0, len(skills))
+			for _, skill := range skills {
+				names = append(names, skill.Name)
+				util.AssertEqual(t, skill.Platform, model.Platform("windsurf"))
+			}
+			util.AssertEqual(t, strings.Join(names, ","), strings.Join(tt.wantNames, ","))
+			if name == "skills" {
+				util.AssertEqual(t, skills[0].Path, filepath.Join(basePath, "lint.md"))
+				util.AssertEqual(t, skills[0].Content, "Run the linter.")
+				util.AssertEqual(t, skills[1].Path, basePath)
+				util.AssertEqual(t, skills[1].Type, model.SkillTypePrompt)
+			}
+		})
+	}
+}
+
+func TestParser_MissingDirectory(t *testing.T) {
+	def := Definition{Name: "windsurf", Command: []string{"false"}}
+	skills, err := New(def, filepath.Join(t.TempDir(), "missing")).Parse()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(skills), 0)
+}
+
+func TestDefinition_Write(t *testing.T) {
+	skill := model.Skill{Name: "lint", Description: "Lint code", Content: "Run the linter."}
+
+	tests := map[string]struct {
+		def          Definition
+		wantPath     string
+		wantContent  string
+		wantRendered bool
+		wantErr      string
+	}{
+		"defaults": {
+			def:      Definition{Name: "windsurf", Command: []string{"parse"}},
+			wantPath: "lint.md",
+		},
+		"templates": {
+			def: Definition{
+				Name:          "windsurf",
+				Command:       []string{"parse"},
+				FileName:      "rules/{{.Name}}.txt",
+				WriteTemplate: "# {{.Name}}\n{{.Description}}\n\n{{.Content}}\n",
+			},
+			wantPath:     filepath.Join("rules", "lint.txt"),
+			wantContent:  "# lint\nLint code\n\nRun the linter.\n",
+			wantRendered: true,
+		},
+		"file outside directory": {
+			def:     Definition{Name: "windsurf", Command: []string{"parse"}, FileName: "../{{.Name}}.md"},
+			wantErr: "not inside the skills directory",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertNoError(t, tt.def.Validate())
+
+			path, err := tt.def.FilePath(skill)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FilePath() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, path, tt.wantPath)
+
+			content, rendered, err := tt.def.Render(skill)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, rendered, tt.wantRendered)
+			util.AssertEqual(t, content, tt.wantContent)
+		})
+	}
+}
+
+func TestSetDefinitions(t *testing.T) {
+	t.Cleanup(func() { SetDefinitions(nil) })
+
+	SetDefinitions([]Definition{
+		{Name: "windsurf", Command: []string{"parse"}},
+		{Name: "cursor", Command: []string{"parse"}},
+		{Name: "windsurf", Command: []string{"other"}},
+	})
+
+	def, ok := Lookup("windsurf")
+	util.AssertEqual(t, ok, true)
+	util.AssertEqual(t, def.Command[0], "parse")
+	_, ok = Lookup("cursor")
+	util.AssertEqual(t, ok, false)
+	util.AssertEqual(t, model.Platform("windsurf").IsValid(), true)
+
+	SetDefinitions(nil)
+	util.AssertEqual(t, model.Platform("windsurf").IsValid(), false)
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"sync/atomic"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// FileCache caches the skills parsed from individual files.
+type FileCache interface {
+	// Parse returns the skills parse yields for the file at path, reusing
+	// those cached under key while the file is unchanged.
+	Parse(key, path string, parse func() ([]model.Skill, error)) ([]model.Skill, error)
+}
+
+// fileCacheHolder wraps the file cache so a nil cache can be stored.
+type fileCacheHolder struct {
+	cache FileCache
+}
+
+var fileCache atomic.Pointer[fileCacheHolder]
+
+// SetFileCache sets the cache every later per-file parse goes through. A nil
+// cache parses every file.
+func SetFileCache(c FileCache) {
+	fileCache.Store(&fileCacheHolder{cache: c})
+}
+
+// ParseFile parses the file at path with parse, through the file cache when
+// one is set. kind distinguishes parsers, and anything else the parse
+// depends on, that read the same file differently.
+func ParseFile(kind, path string, parse func(path string) ([]model.Skill, error)) ([]model.Skill, error) {
+	holder := fileCache.Load()
+	if holder == nil || holder.cache == nil {
+		return parse(path)
+	}
+	return holder.cache.Parse(kind+"\x00"+path, path, func() ([]model.Skill, error) {
+		return parse(path)
+	})
+}
+
+// ParseSkillFile is ParseFile for parses yielding a single skill.
+func ParseSkillFile(kind, path string, parse func(path string) (model.Skill, error)) (model.Skill, error) {
+	skills, err := ParseFile(kind, path, func(path string) ([]model.Skill, error) {
+		skill, err := parse(path)
+		if err != nil {
+			return nil, err
+		}
+		return []model.Skill{skill}, nil
+	})
+	if err != nil {
+		return model.Skill{}, err
+	}
+	if len(skills) != 1 {
+		// A corrupt entry; parse the file afresh
+		return parse(path)
+	}
+	return skills[0], nil
+}
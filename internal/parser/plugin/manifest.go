@@ -0,0 +1,190 @@
+package plugin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestVersion2 is the manifestVersion of plugin manifests that describe
+// their skills individually. Manifests without a manifestVersion are read
+// the same way; the field only documents which format a plugin targets.
+const ManifestVersion2 = 2
+
+// Manifest represents a plugin's .claude-plugin/plugin.json file
+type Manifest struct {
+	// ManifestVersion is 2 for manifests written for per-skill metadata, 0
+	// for older ones
+	ManifestVersion int    `json:"manifestVersion,omitempty"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	Version         string `json:"version"`
+	License         string `json:"license"`
+	Author          struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+	// Skills describes the plugin's skills. Older manifests give only the
+	// directories they are in, as a path or a list of paths.
+	Skills SkillEntries `json:"skills,omitempty"`
+
+	// dir is the plugin directory the manifest was read from, against which
+	// skill paths are resolved
+	dir string
+}
+
+// SkillEntry is the metadata a plugin manifest gives for one of its skills.
+// Entries are matched to skills by name or by path.
+type SkillEntry struct {
+	Name string `json:"name,omitempty"`
+	// Path is the skill's directory or SKILL.md, relative to the plugin root
+	Path string `json:"path,omitempty"`
+	// Version is the skill's own version, independent of the plugin's
+	Version string `json:"version,omitempty"`
+	// Checksum is "sha256:" followed by the hex SHA-256 of the SKILL.md file
+	Checksum string `json:"checksum,omitempty"`
+	// Compatibility notes which platforms or tool versions the skill needs
+	Compatibility string `json:"compatibility,omitempty"`
+}
+
+// SkillEntries are the skill entries of a manifest.
+type SkillEntries []SkillEntry
+
+// UnmarshalJSON accepts the skills of a manifest as a path, a list of paths
+// or entries, or an object of entries keyed by skill name.
+func (e *SkillEntries) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || string(data) == "null":
+		*e = nil
+		return nil
+	case data[0] == '"':
+		var path string
+		if err := json.Unmarshal(data, &path); err != nil {
+			return err
+		}
+		*e = SkillEntries{{Path: path}}
+		return nil
+	case data[0] == '{':
+		var byName map[string]SkillEntry
+		if err := json.Unmarshal(data, &byName); err != nil {
+			return fmt.Errorf("invalid skills: %w", err)
+		}
+		entries := make(SkillEntries, 0, len(byName))
+		for name, entry := range byName {
+			entry.Name = name
+			entries = append(entries, entry)
+		}
+		*e = entries
+		return nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("invalid skills: expected a path, a list, or an object: %w", err)
+	}
+	entries := make(SkillEntries, 0, len(items))
+	for i, item := range items {
+		var entry SkillEntry
+		if bytes.HasPrefix(bytes.TrimSpace(item), []byte(`"`)) {
+			if err := json.Unmarshal(item, &entry.Path); err != nil {
+				return fmt.Errorf("invalid skills[%d]: %w", i, err)
+			}
+		} else if err := json.Unmarshal(item, &entry); err != nil {
+			return fmt.Errorf("invalid skills[%d]: %w", i, err)
+		}
+		entries = append(entries, entry)
+	}
+	*e = entries
+	return nil
+}
+
+// ReadManifest reads the .claude-plugin/plugin.json of the plugin at dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, ".claude-plugin", "plugin.json")
+	// #nosec G304 - path is constructed from a plugin directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	m.dir = dir
+	return &m, nil
+}
+
+// SkillEntry returns the manifest's entry for the skill with the given name
+// and SKILL.md file. Entries naming the skill win over entries giving its
+// path; entries giving only the directory skills are found in match none.
+func (m *Manifest) SkillEntry(name, filePath string) (SkillEntry, bool) {
+	if m == nil {
+		return SkillEntry{}, false
+	}
+	for _, entry := range m.Skills {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+	if m.dir == "" {
+		return SkillEntry{}, false
+	}
+	for _, entry := range m.Skills {
+		if entry.Path == "" || !entry.hasMetadata() {
+			continue
+		}
+		path := filepath.Join(m.dir, filepath.FromSlash(entry.Path))
+		if path == filepath.Clean(filePath) || path == filepath.Dir(filePath) {
+			return entry, true
+		}
+	}
+	return SkillEntry{}, false
+}
+
+// hasMetadata reports whether the entry gives anything beyond where the
+// skill is.
+func (e SkillEntry) hasMetadata() bool {
+	return e.Version != "" || e.Checksum != "" || e.Compatibility != ""
+}
+
+// VerifyChecksum checks content against the entry's checksum. Entries
+// without a checksum verify any content.
+func (e SkillEntry) VerifyChecksum(content []byte) error {
+	if e.Checksum == "" {
+		return nil
+	}
+	algorithm, want, ok := strings.Cut(e.Checksum, ":")
+	if !ok || algorithm != "sha256" {
+		return fmt.Errorf("unsupported checksum %q: expected sha256:<hex>", e.Checksum)
+	}
+	sum := sha256.Sum256(content)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, want) {
+		return fmt.Errorf("checksum mismatch: manifest has sha256:%s, file has sha256:%s", want, got)
+	}
+	return nil
+}
+
+// ApplySkillMetadata adds the manifest's metadata for a skill to the
+// metadata of the skill parsed from content: skill_version, compatibility,
+// and checksum, with checksum_verified recording whether content matches it.
+func ApplySkillMetadata(metadata map[string]string, entry SkillEntry, content []byte) error {
+	if entry.Version != "" {
+		metadata["skill_version"] = entry.Version
+	}
+	if entry.Compatibility != "" {
+		metadata["compatibility"] = entry.Compatibility
+	}
+	if entry.Checksum == "" {
+		return nil
+	}
+	metadata["checksum"] = entry.Checksum
+	err := entry.VerifyChecksum(content)
+	metadata["checksum_verified"] = fmt.Sprint(err == nil)
+	return err
+}
@@ -0,0 +1,194 @@
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSkillEntries_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		json    string
+		want    SkillEntries
+		wantErr bool
+	}{
+		"absent": {
+			json: `{}`,
+		},
+		"single path": {
+			json: `{"skills": "./skills"}`,
+			want: SkillEntries{{Path: "./skills"}},
+		},
+		"list of paths": {
+			json: `{"skills": ["./skills", "./extra"]}`,
+			want: SkillEntries{{Path: "./skills"}, {Path: "./extra"}},
+		},
+		"list of entries": {
+			json: `{"skills": [{"name": "commit", "version": "1.1.0"}, "./extra"]}`,
+			want: SkillEntries{{Name: "commit", Version: "1.1.0"}, {Path: "./extra"}},
+		},
+		"object keyed by name": {
+			json: `{"skills": {"commit": {"path": "skills/commit", "compatibility": "claude-code >= 1.0"}}}`,
+			want: SkillEntries{{Name: "commit", Path: "skills/commit", Compatibility: "claude-code >= 1.0"}},
+		},
+		"number": {
+			json:    `{"skills": 3}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var m Manifest
+			err := json.Unmarshal([]byte(tt.json), &m)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal() = %+v, want error", m.Skills)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, fmt.Sprintf("%+v", m.Skills), fmt.Sprintf("%+v", tt.want))
+		})
+	}
+}
+
+func TestManifest_SkillEntry(t *testing.T) {
+	dir := t.TempDir()
+	m := &Manifest{
+		dir: dir,
+		Skills: SkillEntries{
+			{Path: "skills"},
+			{Name: "commit", Version: "1.0.0"},
+			{Path: "skills/push", Version: "2.0.0"},
+		},
+	}
+
+	tests := map[string]struct {
+		name, path  string
+		wantVersion string
+		wantOK      bool
+	}{
+		"by name": {
+			name: "commit", path: filepath.Join(dir, "elsewhere", "SKILL.md"),
+			wantVersion: "1.0.0", wantOK: true,
+		},
+		"by directory": {
+			name: "push", path: filepath.Join(dir, "skills", "push", "SKILL.md"),
+			wantVersion: "2.0.0", wantOK: true,
+		},
+		"directory of skills matches none": {
+			name: "lint", path: filepath.Join(dir, "skills", "lint", "SKILL.md"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			entry, ok := m.SkillEntry(tt.name, tt.path)
+			util.AssertEqual(t, ok, tt.wantOK)
+			util.AssertEqual(t, entry.Version, tt.wantVersion)
+		})
+	}
+
+	var none *Manifest
+	if _, ok := none.SkillEntry("commit", ""); ok {
+		t.Error("nil manifest has a skill entry")
+	}
+}
+
+func TestApplySkillMetadata(t *testing.T) {
+	content := []byte("---\nname: commit\n---\nWrite it.\n")
+	sum := sha256.Sum256(content)
+	checksum := "sha256:" + hex.EncodeToString(sum[:])
+
+	tests := map[string]struct {
+		entry        SkillEntry
+		wantVerified string
+		wantErr      string
+	}{
+		"no checksum": {
+			entry: SkillEntry{Version: "1.0.0"},
+		},
+		"matching checksum": {
+			entry:        SkillEntry{Version: "1.0.0", Checksum: checksum},
+			wantVerified: "true",
+		},
+		"uppercase hex": {
+			entry:        SkillEntry{Checksum: "sha256:" + strings.ToUpper(checksum[len("sha256:"):])},
+			wantVerified: "true",
+		},
+		"unsupported algorithm": {
+			entry:        SkillEntry{Checksum: "md5:0000"},
+			wantErr:      "unsupported checksum",
+			wantVerified: "false",
+		},
+		"mismatched checksum": {
+			entry:        SkillEntry{Checksum: "sha256:0000"},
+			wantErr:      "checksum mismatch",
+			wantVerified: "false",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			metadata := map[string]string{}
+			err := ApplySkillMetadata(metadata, tt.entry, content)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ApplySkillMetadata() error = %v, want %q", err, tt.wantErr)
+				}
+			} else {
+				util.AssertNoError(t, err)
+			}
+			util.AssertEqual(t, metadata["skill_version"], tt.entry.Version)
+			util.AssertEqual(t, metadata["checksum_verified"], tt.wantVerified)
+		})
+	}
+}
+
+func TestParser_parsePlugin_ManifestV2(t *testing.T) {
+	tmpDir := t.TempDir()
+	pluginDir := filepath.Join(tmpDir, "tools")
+	commit := []byte("---\nname: commit\ndescription: Write commits\n---\nWrite it.\n")
+	push := []byte("---\nname: push\ndescription: Push commits\n---\nPush it.\n")
+	testMkdirAll(t, filepath.Join(pluginDir, "skills", "commit"))
+	testMkdirAll(t, filepath.Join(pluginDir, "skills", "push"))
+	testMkdirAll(t, filepath.Join(pluginDir, ".claude-plugin"))
+	testWriteFile(t, filepath.Join(pluginDir, "skills", "commit", "SKILL.md"), commit)
+	testWriteFile(t, filepath.Join(pluginDir, "skills", "push", "SKILL.md"), push)
+
+	sum := sha256.Sum256(commit)
+	manifest := fmt.Sprintf(`{
+  "manifestVersion": 2,
+  "name": "tools",
+  "version": "3.0.0",
+  "skills": {
+    "commit": {"version": "1.2.0", "checksum": "sha256:%s", "compatibility": "claude-code >= 1.0"},
+    "push": {"path": "skills/push", "version": "0.9.0", "checksum": "sha256:0000"}
+  }
+}`, hex.EncodeToString(sum[:]))
+	testWriteFile(t, filepath.Join(pluginDir, ".claude-plugin", "plugin.json"), []byte(manifest))
+
+	skills, err := New(tmpDir).parsePlugin(pluginDir, "repo")
+	util.AssertNoError(t, err)
+	slices.SortFunc(skills, func(a, b model.Skill) int { return strings.Compare(a.Name, b.Name) })
+	if len(skills) != 2 {
+		t.Fatalf("parsePlugin() returned %d skills, want 2", len(skills))
+	}
+
+	util.AssertEqual(t, skills[0].Metadata["plugin_version"], "3.0.0")
+	util.AssertEqual(t, skills[0].Metadata["skill_version"], "1.2.0")
+	util.AssertEqual(t, skills[0].Metadata["compatibility"], "claude-code >= 1.0")
+	util.AssertEqual(t, skills[0].Metadata["checksum_verified"], "true")
+	// A checksum mismatch is recorded but does not drop the skill
+	util.AssertEqual(t, skills[1].Metadata["skill_version"], "0.9.0")
+	util.AssertEqual(t, skills[1].Metadata["checksum_verified"], "false")
+}
@@ -0,0 +1,235 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// Marketplace is a local checkout of a marketplace index, a directory with a
+// .claude-plugin/marketplace.json.
+type Marketplace struct {
+	// Name identifies the marketplace in listings
+	Name string
+	// Path is the root of the checkout
+	Path string
+}
+
+// Listing is a plugin offered by a marketplace.
+type Listing struct {
+	Plugin      string   `json:"plugin"`
+	Description string   `json:"description,omitempty"`
+	Marketplace string   `json:"marketplace"`
+	Repository  string   `json:"repository,omitempty"`
+	Skills      []string `json:"skills,omitempty"`
+
+	// dir is the plugin's directory inside the marketplace checkout, empty
+	// for plugins hosted in their own repository
+	dir string
+	// skillText is the lowercased names and descriptions of the plugin's
+	// skills, for searching
+	skillText string
+}
+
+// UnmarshalJSON accepts a plugin source given either as a path relative to
+// the marketplace or as an object naming a Git repository.
+func (r *Ref) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description"`
+		Source      json.RawMessage `json:"source"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = Ref{Name: raw.Name, Description: raw.Description}
+
+	source := strings.TrimSpace(string(raw.Source))
+	switch {
+	case source == "" || source == "null":
+		return nil
+	case strings.HasPrefix(source, `"`):
+		return json.Unmarshal(raw.Source, &r.Source)
+	}
+
+	var remote struct {
+		Repo string `json:"repo"`
+		URL  string `json:"url"`
+	}
+	if err := json.Unmarshal(raw.Source, &remote); err != nil {
+		return fmt.Errorf("invalid source for plugin %q: %w", raw.Name, err)
+	}
+	switch {
+	case remote.Repo != "":
+		r.Repository = "https://github.com/" + remote.Repo
+	case remote.URL != "":
+		r.Repository = remote.URL
+	}
+	return nil
+}
+
+// LoadListings reads the plugins offered by a marketplace checkout, along
+// with the skills of those whose source is inside the checkout.
+func LoadListings(m Marketplace) ([]Listing, error) {
+	manifestPath := filepath.Join(m.Path, ".claude-plugin", "marketplace.json")
+	// #nosec G304 - path is constructed from a marketplace checkout
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("marketplace.json not found: %w", err)
+	}
+	var manifest MarketplaceManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestPath, err)
+	}
+
+	name := m.Name
+	if name == "" {
+		name = manifest.Name
+	}
+
+	p := New(m.Path)
+	listings := make([]Listing, 0, len(manifest.Plugins))
+	for _, ref := range manifest.Plugins {
+		if parser.ValidateSkillName(ref.Name) != nil {
+			continue
+		}
+		listing := Listing{
+			Plugin:      ref.Name,
+			Description: ref.Description,
+			Marketplace: name,
+			Repository:  ref.Repository,
+		}
+		if ref.Source != "" {
+			dir := filepath.Join(m.Path, filepath.FromSlash(ref.Source))
+			if rel, err := filepath.Rel(m.Path, dir); err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			listing.dir = dir
+
+			skills, _ := p.parsePlugin(dir, manifest.Name)
+			var text strings.Builder
+			for _, skill := range skills {
+				listing.Skills = append(listing.Skills, skill.Name)
+				text.WriteString(strings.ToLower(skill.Name + "\n" + skill.Description + "\n"))
+			}
+			listing.skillText = text.String()
+		}
+		listings = append(listings, listing)
+	}
+	return listings, nil
+}
+
+// Search returns the listings whose plugin name or description, or any of
+// whose skill names or descriptions, contain query, ignoring case. An empty
+// query matches every listing.
+func Search(listings []Listing, query string) []Listing {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var matches []Listing
+	for _, l := range listings {
+		if query == "" ||
+			strings.Contains(strings.ToLower(l.Plugin), query) ||
+			strings.Contains(strings.ToLower(l.Description), query) ||
+			strings.Contains(l.skillText, query) {
+			matches = append(matches, l)
+		}
+	}
+	return matches
+}
+
+// Install makes a listed plugin's skills discoverable from pluginsDir: a
+// plugin in its own repository is cloned, and one inside its marketplace is
+// copied to pluginsDir/<plugin>. It returns the installed directory.
+func Install(l Listing, pluginsDir string) (string, error) {
+	if l.Repository != "" {
+		return EnsureRepo(pluginsDir, l.Repository)
+	}
+	if l.dir == "" {
+		return "", fmt.Errorf("plugin %q has no installable source", l.Plugin)
+	}
+
+	if err := util.EnsureDataDir(pluginsDir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create plugins directory: %w", err)
+	}
+	dest := filepath.Join(pluginsDir, l.Plugin)
+	if _, err := os.Stat(dest); err == nil {
+		return "", fmt.Errorf("plugin %q is already installed at %s", l.Plugin, dest)
+	}
+	if err := copyPlugin(l, dest); err != nil {
+		_ = os.RemoveAll(dest)
+		return "", err
+	}
+	return dest, nil
+}
+
+// copyPlugin copies a plugin listed inside its marketplace to dest.
+func copyPlugin(l Listing, dest string) error {
+	if err := copyTree(l.dir, dest); err != nil {
+		return fmt.Errorf("failed to copy plugin %q: %w", l.Plugin, err)
+	}
+
+	// Plugins in ~/.skillsync/plugins are found by their plugin.json
+	manifestPath := filepath.Join(dest, ".claude-plugin", "plugin.json")
+	if _, err := os.Stat(manifestPath); errors.Is(err, fs.ErrNotExist) {
+		data, err := json.MarshalIndent(Manifest{Name: l.Plugin, Description: l.Description}, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(manifestPath), 0o750); err != nil {
+			return err
+		}
+		// #nosec G306 - plugin manifests should be readable
+		if err := os.WriteFile(manifestPath, append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyTree copies the regular files and directories under src to dst,
+// skipping symlinks and .git.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir() && d.Name() == ".git":
+			return filepath.SkipDir
+		case d.IsDir():
+			return os.MkdirAll(target, 0o750)
+		case !d.Type().IsRegular():
+			return nil
+		}
+
+		// #nosec G304 - path is inside the marketplace checkout
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = in.Close() }()
+		// #nosec G302 G304 - plugin files should be readable
+		outFile, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(outFile, in); err != nil {
+			_ = outFile.Close()
+			return err
+		}
+		return outFile.Close()
+	})
+}
@@ -0,0 +1,131 @@
+package plugin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestRef_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		input      string
+		source     string
+		repository string
+	}{
+		"relative path": {
+			input:  `{"name": "a", "source": "./plugins/a"}`,
+			source: "./plugins/a",
+		},
+		"github repository": {
+			input:      `{"name": "a", "source": {"source": "github", "repo": "acme/a"}}`,
+			repository: "https://github.com/acme/a",
+		},
+		"git url": {
+			input:      `{"name": "a", "source": {"source": "url", "url": "https://git.example.com/a.git"}}`,
+			repository: "https://git.example.com/a.git",
+		},
+		"no source": {
+			input: `{"name": "a"}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var ref Ref
+			util.AssertNoError(t, json.Unmarshal([]byte(tt.input), &ref))
+			util.AssertEqual(t, ref.Name, "a")
+			util.AssertEqual(t, ref.Source, tt.source)
+			util.AssertEqual(t, ref.Repository, tt.repository)
+		})
+	}
+}
+
+// writeMarketplace creates a marketplace with a local "commits" plugin
+// holding a "conventional-commit" skill, and a remote "linters" plugin.
+func writeMarketplace(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	util.WriteFile(t, filepath.Join(dir, ".claude-plugin", "marketplace.json"), `{
+  "name": "acme",
+  "plugins": [
+    {"name": "commits", "description": "Git helpers", "source": "./plugins/commits"},
+    {"name": "linters", "description": "Lint everything", "source": {"source": "github", "repo": "acme/linters"}},
+    {"name": "escape", "source": "../outside"}
+  ]
+}`)
+	util.WriteFile(t, filepath.Join(dir, "plugins", "commits", "skills", "conventional-commit", "SKILL.md"),
+		"---\nname: conventional-commit\ndescription: Write a conventional commit message\n---\n\nBody\n")
+	return dir
+}
+
+func TestLoadListingsAndSearch(t *testing.T) {
+	dir := writeMarketplace(t)
+
+	listings, err := LoadListings(Marketplace{Name: "acme-local", Path: dir})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(listings), 2)
+	util.AssertEqual(t, listings[0].Marketplace, "acme-local")
+	util.AssertEqual(t, len(listings[0].Skills), 1)
+	util.AssertEqual(t, listings[1].Repository, "https://github.com/acme/linters")
+
+	tests := map[string]struct {
+		query string
+		want  []string
+	}{
+		"plugin name":       {query: "COMMITS", want: []string{"commits"}},
+		"description":       {query: "lint", want: []string{"linters"}},
+		"skill description": {query: "conventional", want: []string{"commits"}},
+		"empty query":       {query: "", want: []string{"commits", "linters"}},
+		"no match":          {query: "deploy"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, l := range Search(listings, tt.query) {
+				got = append(got, l.Plugin)
+			}
+			util.AssertEqual(t, len(got), len(tt.want))
+			for i := range tt.want {
+				util.AssertEqual(t, got[i], tt.want[i])
+			}
+		})
+	}
+}
+
+func TestInstall_LocalPlugin(t *testing.T) {
+	listings, err := LoadListings(Marketplace{Path: writeMarketplace(t)})
+	util.AssertNoError(t, err)
+	pluginsDir := t.TempDir()
+
+	dir, err := Install(listings[0], pluginsDir)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, dir, filepath.Join(pluginsDir, "commits"))
+
+	// The copied plugin is discovered through the generated plugin.json
+	skills, err := New(pluginsDir).Parse()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(skills), 1)
+	util.AssertEqual(t, skills[0].Name, "conventional-commit")
+	util.AssertEqual(t, skills[0].Metadata["plugin"], "commits")
+
+	if _, err := Install(listings[0], pluginsDir); err == nil {
+		t.Error("expected an error installing over an existing plugin")
+	}
+}
+
+func TestParseMarketplace_SkipsRemotePlugins(t *testing.T) {
+	dir := writeMarketplace(t)
+	if err := os.Remove(filepath.Join(dir, "plugins", "commits", "skills", "conventional-commit", "SKILL.md")); err != nil {
+		t.Fatal(err)
+	}
+	util.WriteFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: root-skill\n---\n\nBody\n")
+
+	// A remote plugin must not fall back to parsing the whole marketplace
+	skills, err := New(dir).parseMarketplace(dir)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(skills), 0)
+}
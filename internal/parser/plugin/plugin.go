@@ -5,11 +5,12 @@ package plugin
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/klauern/skillsync/internal/gitfetch"
 	"github.com/klauern/skillsync/internal/logging"
 	"github.com/klauern/skillsync/internal/model"
 	"github.com/klauern/skillsync/internal/parser"
@@ -34,18 +35,11 @@ type MarketplaceManifest struct {
 type Ref struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
-	Source      string `json:"source"`
-}
-
-// Manifest represents a plugin's .claude-plugin/plugin.json file
-type Manifest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Version     string `json:"version"`
-	Author      struct {
-		Name  string `json:"name"`
-		Email string `json:"email"`
-	} `json:"author"`
+	// Source is the plugin's path relative to the marketplace root
+	Source string `json:"source"`
+	// Repository is the Git URL of a plugin hosted outside the marketplace,
+	// given as a source object such as {"source": "github", "repo": "owner/repo"}
+	Repository string `json:"-"`
 }
 
 // Parser implements the parser.Parser interface for Claude Code plugin repositories
@@ -153,6 +147,14 @@ func (p *Parser) parseMarketplace(repoPath string) ([]model.Skill, error) {
 
 	// Parse each plugin referenced in the marketplace
 	for _, pluginRef := range manifest.Plugins {
+		if pluginRef.Source == "" {
+			// Plugins hosted in their own repository are installed separately
+			logging.Debug("skipping plugin without a local source",
+				logging.Platform(string(p.Platform())),
+				slog.String("plugin", pluginRef.Name),
+			)
+			continue
+		}
 		pluginPath := filepath.Join(repoPath, strings.TrimPrefix(pluginRef.Source, "./"))
 		pluginSkills, err := p.parsePlugin(pluginPath, manifest.Name)
 		if err != nil {
@@ -172,14 +174,13 @@ func (p *Parser) parseMarketplace(repoPath string) ([]model.Skill, error) {
 // parsePlugin parses all skills from a single plugin directory
 func (p *Parser) parsePlugin(pluginPath, repoName string) ([]model.Skill, error) {
 	// Read plugin manifest if available
-	var pluginManifest *Manifest
-	manifestPath := filepath.Join(pluginPath, ".claude-plugin", "plugin.json")
-	// #nosec G304 - path is constructed from trusted pluginPath
-	if data, err := os.ReadFile(manifestPath); err == nil {
-		var m Manifest
-		if json.Unmarshal(data, &m) == nil {
-			pluginManifest = &m
-		}
+	pluginManifest, err := ReadManifest(pluginPath)
+	if err != nil && !os.IsNotExist(err) {
+		logging.Warn("ignoring invalid plugin manifest",
+			logging.Platform(string(p.Platform())),
+			logging.Path(pluginPath),
+			logging.Err(err),
+		)
 	}
 
 	// Find all SKILL.md files in the plugin directory
@@ -297,6 +298,18 @@ func (p *Parser) parseSkillFile(filePath string, pluginManifest *Manifest, repoN
 		if pluginManifest.Author.Name != "" {
 			metadata["author"] = pluginManifest.Author.Name
 		}
+		if _, ok := metadata["license"]; !ok && pluginManifest.License != "" {
+			metadata["license"] = pluginManifest.License
+		}
+		if entry, ok := pluginManifest.SkillEntry(name, filePath); ok {
+			if err := ApplySkillMetadata(metadata, entry, content); err != nil {
+				logging.Warn("skill does not match its plugin manifest",
+					logging.Platform(string(p.Platform())),
+					logging.Path(filePath),
+					logging.Err(err),
+				)
+			}
+		}
 	}
 
 	// Add repository name to metadata
@@ -323,6 +336,7 @@ func (p *Parser) parseSkillFile(filePath string, pluginManifest *Manifest, repoN
 		Path:        filePath,
 		Tools:       tools,
 		Metadata:    metadata,
+		Frontmatter: string(result.Frontmatter),
 		Content:     normalizedContent,
 		ModifiedAt:  fileInfo.ModTime(),
 		Scope:       model.ScopePlugin,
@@ -333,29 +347,22 @@ func (p *Parser) parseSkillFile(filePath string, pluginManifest *Manifest, repoN
 func (p *Parser) scanForPlugins(basePath string) ([]model.Skill, error) {
 	var skills []model.Skill
 
-	// Walk the directory looking for plugin.json files
-	err := filepath.Walk(basePath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip errors
-		}
+	// Walk the directory looking for plugin.json files, within the discovery limits
+	files, err := parser.DiscoverFiles(basePath, []string{"**/plugin.json"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan for plugins: %w", err)
+	}
 
+	for _, path := range files {
 		// Look for .claude-plugin/plugin.json
-		if info.IsDir() {
-			return nil
+		if filepath.Base(filepath.Dir(path)) != ".claude-plugin" {
+			continue
 		}
-
-		if filepath.Base(path) == "plugin.json" && strings.Contains(filepath.Dir(path), ".claude-plugin") {
-			pluginDir := filepath.Dir(filepath.Dir(path)) // Go up from .claude-plugin/plugin.json
-			pluginSkills, err := p.parsePlugin(pluginDir, "")
-			if err == nil {
-				skills = append(skills, pluginSkills...)
-			}
+		pluginDir := filepath.Dir(filepath.Dir(path)) // Go up from .claude-plugin/plugin.json
+		pluginSkills, err := p.parsePlugin(pluginDir, "")
+		if err == nil {
+			skills = append(skills, pluginSkills...)
 		}
-
-		return nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to scan for plugins: %w", err)
 	}
 
 	return skills, nil
@@ -366,23 +373,28 @@ func (p *Parser) ensureRepo() (string, error) {
 	if p.repoURL == "" {
 		return p.basePath, nil
 	}
+	return EnsureRepo(p.basePath, p.repoURL)
+}
 
-	// Create plugins directory if needed
-	if err := os.MkdirAll(p.basePath, 0o750); err != nil {
+// EnsureRepo clones repoURL into a directory named after it under baseDir,
+// or fast-forwards an existing clone, and returns the clone's path. A failed
+// pull is not an error; the existing clone is used.
+func EnsureRepo(baseDir, repoURL string) (string, error) {
+	// Create the parent directory if needed
+	if err := util.EnsureDataDir(baseDir, 0o750); err != nil {
 		return "", fmt.Errorf("failed to create plugins directory: %w", err)
 	}
 
 	// Derive repo name from URL
-	repoName := deriveRepoName(p.repoURL)
-	repoPath := filepath.Join(p.basePath, repoName)
+	repoName := deriveRepoName(repoURL)
+	repoPath := filepath.Join(baseDir, repoName)
 
 	// Check if repo already exists
 	gitDir := filepath.Join(repoPath, ".git")
 	if _, err := os.Stat(gitDir); err == nil {
 		// Repo exists, pull updates (ignore errors - can use existing clone)
-		if err := p.gitPull(repoPath); err != nil {
+		if err := gitPull(repoPath); err != nil {
 			logging.Debug("git pull failed, using existing clone",
-				logging.Platform(string(p.Platform())),
 				logging.Path(repoPath),
 				logging.Err(err),
 			)
@@ -391,7 +403,7 @@ func (p *Parser) ensureRepo() (string, error) {
 	}
 
 	// Clone the repository
-	if err := p.gitClone(p.repoURL, repoPath); err != nil {
+	if err := gitClone(repoURL, repoPath); err != nil {
 		return "", fmt.Errorf("failed to clone repository: %w", err)
 	}
 
@@ -399,48 +411,18 @@ func (p *Parser) ensureRepo() (string, error) {
 }
 
 // gitClone clones a Git repository
-func (p *Parser) gitClone(url, dest string) error {
-	// #nosec G204 - url and dest are from trusted configuration
-	cmd := exec.Command("git", "clone", "--depth", "1", url, dest)
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+func gitClone(url, dest string) error {
+	return gitfetch.Clone(url, dest, gitfetch.Options{})
 }
 
 // gitPull updates a Git repository
-func (p *Parser) gitPull(repoPath string) error {
-	// #nosec G204 - repoPath is from trusted configuration
-	cmd := exec.Command("git", "-C", repoPath, "pull", "--ff-only")
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+func gitPull(repoPath string) error {
+	return gitfetch.Pull(repoPath)
 }
 
 // deriveRepoName extracts a repository name from a Git URL
 func deriveRepoName(url string) string {
-	// Handle SSH URLs (git@github.com:user/repo.git)
-	if strings.HasPrefix(url, "git@") {
-		parts := strings.Split(url, ":")
-		if len(parts) == 2 {
-			path := parts[1]
-			path = strings.TrimSuffix(path, ".git")
-			// Use user/repo format
-			return strings.ReplaceAll(path, "/", "-")
-		}
-	}
-
-	// Handle HTTPS URLs
-	url = strings.TrimSuffix(url, ".git")
-	parts := strings.Split(url, "/")
-	if len(parts) >= 2 {
-		// Use last two parts (user/repo)
-		return parts[len(parts)-2] + "-" + parts[len(parts)-1]
-	}
-
-	// Fallback to last part
-	if len(parts) > 0 {
-		return parts[len(parts)-1]
-	}
-
-	return "unknown"
+	return gitfetch.RepoName(url)
 }
 
 // Platform returns the platform identifier for plugins
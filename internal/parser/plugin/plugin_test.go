@@ -438,6 +438,7 @@ Content`
 		Name:        "my-plugin",
 		Description: "My plugin",
 		Version:     "1.2.3",
+		License:     "MIT",
 	}
 	pluginManifest.Author.Name = "Test Author"
 
@@ -457,6 +458,9 @@ Content`
 	if skill.Metadata["author"] != "Test Author" {
 		t.Errorf("Metadata[author] = %q, want %q", skill.Metadata["author"], "Test Author")
 	}
+	if skill.Metadata["license"] != "MIT" {
+		t.Errorf("Metadata[license] = %q, want %q", skill.Metadata["license"], "MIT")
+	}
 	if skill.Metadata["repository"] != "my-repo" {
 		t.Errorf("Metadata[repository] = %q, want %q", skill.Metadata["repository"], "my-repo")
 	}
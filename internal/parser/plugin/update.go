@@ -0,0 +1,144 @@
+package plugin
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+)
+
+// IsCheckout reports whether the installed plugin at dir is a Git clone,
+// updated by pulling, rather than a copy out of its marketplace.
+func IsCheckout(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// Update brings the installed plugin at dir up to date. A Git clone is
+// pulled; a copy is replaced with a fresh copy of its listing, which must be
+// given for copies and is ignored for clones.
+func Update(dir string, l *Listing) error {
+	if IsCheckout(dir) {
+		if err := gitPull(dir); err != nil {
+			return fmt.Errorf("failed to pull %s: %w", dir, err)
+		}
+		return nil
+	}
+	if l == nil || l.dir == "" {
+		return fmt.Errorf("plugin at %s has no marketplace source to update from", dir)
+	}
+
+	// Copy next to the old version first so a failed copy leaves it intact
+	staged := dir + ".update"
+	if err := os.RemoveAll(staged); err != nil {
+		return err
+	}
+	if err := copyPlugin(*l, staged); err != nil {
+		_ = os.RemoveAll(staged)
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		_ = os.RemoveAll(staged)
+		return fmt.Errorf("failed to remove old version of %q: %w", l.Plugin, err)
+	}
+	return os.Rename(staged, dir)
+}
+
+// SkillRename is a skill that a new version of a plugin ships under another
+// name.
+type SkillRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// SkillVersionBump is a skill whose version, as given by the plugin
+// manifest, differs between two versions of a plugin. From is empty for
+// skills the old manifest gave no version.
+type SkillVersionBump struct {
+	Skill string `json:"skill"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to"`
+}
+
+// VersionChanges are the differences between the skills of two versions of
+// a plugin, by skill name.
+type VersionChanges struct {
+	Added   []string      `json:"added,omitempty"`
+	Removed []string      `json:"removed,omitempty"`
+	Updated []string      `json:"updated,omitempty"`
+	Renamed []SkillRename `json:"renamed,omitempty"`
+	// Versions lists the skills kept under the same name whose manifest
+	// version changed
+	Versions []SkillVersionBump `json:"versions,omitempty"`
+}
+
+// Empty reports whether the versions have the same skills.
+func (c VersionChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Updated) == 0 && len(c.Renamed) == 0 &&
+		len(c.Versions) == 0
+}
+
+// CompareVersions compares the skills of an old and a new version of a
+// plugin, by content and by the skill versions of their manifests. A skill only in the old version whose content a skill only in the
+// new version has unchanged is reported as renamed rather than as removed
+// and added; each skill is paired at most once, in name order.
+func CompareVersions(before, after []model.Skill) VersionChanges {
+	old := make(map[string]string, len(before))
+	oldVersions := make(map[string]string, len(before))
+	for _, skill := range before {
+		old[skill.Name] = contentHash(skill)
+		oldVersions[skill.Name] = skill.Metadata["skill_version"]
+	}
+	current := make(map[string]string, len(after))
+	for _, skill := range after {
+		current[skill.Name] = contentHash(skill)
+	}
+
+	var changes VersionChanges
+	removedByHash := make(map[string][]string)
+	for _, name := range slices.Sorted(maps.Keys(old)) {
+		hash, ok := current[name]
+		switch {
+		case !ok:
+			removedByHash[old[name]] = append(removedByHash[old[name]], name)
+		case hash != old[name]:
+			changes.Updated = append(changes.Updated, name)
+		}
+	}
+	for _, skill := range after {
+		version := skill.Metadata["skill_version"]
+		if from, ok := oldVersions[skill.Name]; ok && version != "" && version != from {
+			changes.Versions = append(changes.Versions, SkillVersionBump{Skill: skill.Name, From: from, To: version})
+		}
+	}
+	slices.SortFunc(changes.Versions, func(a, b SkillVersionBump) int { return strings.Compare(a.Skill, b.Skill) })
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := old[name]; ok {
+			continue
+		}
+		hash := current[name]
+		if from := removedByHash[hash]; len(from) > 0 {
+			changes.Renamed = append(changes.Renamed, SkillRename{From: from[0], To: name})
+			removedByHash[hash] = from[1:]
+			continue
+		}
+		changes.Added = append(changes.Added, name)
+	}
+	for _, names := range removedByHash {
+		changes.Removed = append(changes.Removed, names...)
+	}
+	slices.Sort(changes.Removed)
+	return changes
+}
+
+// contentHash returns the hash of a skill's instructions, which stay the same
+// when only its name changes.
+func contentHash(skill model.Skill) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(parser.NormalizeContent(skill.Content))))
+}
@@ -0,0 +1,97 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestCompareVersions(t *testing.T) {
+	skills := func(nameContent ...string) []model.Skill {
+		var s []model.Skill
+		for i := 0; i < len(nameContent); i += 2 {
+			s = append(s, model.Skill{Name: nameContent[i], Content: nameContent[i+1]})
+		}
+		return s
+	}
+	versioned := func(name, content, version string) model.Skill {
+		return model.Skill{Name: name, Content: content, Metadata: map[string]string{"skill_version": version}}
+	}
+
+	tests := map[string]struct {
+		before []model.Skill
+		after  []model.Skill
+		want   string
+	}{
+		"unchanged": {
+			before: skills("commit", "Write it."),
+			after:  skills("commit", "Write it.\n"),
+			want:   "{Added:[] Removed:[] Updated:[] Renamed:[] Versions:[]}",
+		},
+		"renamed": {
+			before: skills("commit", "Write it.", "push", "Push it."),
+			after:  skills("conventional-commit", "Write it.", "push", "Push it."),
+			want:   "{Added:[] Removed:[] Updated:[] Renamed:[{From:commit To:conventional-commit}] Versions:[]}",
+		},
+		"renamed and edited is removed and added": {
+			before: skills("commit", "Write it."),
+			after:  skills("conventional-commit", "Write it well."),
+			want:   "{Added:[conventional-commit] Removed:[commit] Updated:[] Renamed:[] Versions:[]}",
+		},
+		"updated": {
+			before: skills("commit", "Write it."),
+			after:  skills("commit", "Write it well."),
+			want:   "{Added:[] Removed:[] Updated:[commit] Renamed:[] Versions:[]}",
+		},
+		"skill versions": {
+			before: append(skills("commit", "Write it."), versioned("push", "Push it.", "1.0.0"), versioned("lint", "Lint it.", "")),
+			after:  append(skills("commit", "Write it."), versioned("push", "Push it.", "1.1.0"), versioned("lint", "Lint it.", "0.1.0")),
+			want:   "{Added:[] Removed:[] Updated:[] Renamed:[] Versions:[{Skill:lint From: To:0.1.0} {Skill:push From:1.0.0 To:1.1.0}]}",
+		},
+		"each removed skill is renamed once": {
+			before: skills("commit", "Write it."),
+			after:  skills("commit-a", "Write it.", "commit-b", "Write it."),
+			want:   "{Added:[commit-b] Removed:[] Updated:[] Renamed:[{From:commit To:commit-a}] Versions:[]}",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := CompareVersions(tt.before, tt.after)
+			util.AssertEqual(t, fmt.Sprintf("%+v", got), tt.want)
+		})
+	}
+}
+
+func TestUpdate_LocalPlugin(t *testing.T) {
+	marketDir := writeMarketplace(t)
+	listings, err := LoadListings(Marketplace{Path: marketDir})
+	util.AssertNoError(t, err)
+	pluginsDir := t.TempDir()
+	dir, err := Install(listings[0], pluginsDir)
+	util.AssertNoError(t, err)
+
+	// The new version renames the skill
+	skillsDir := filepath.Join(marketDir, "plugins", "commits", "skills")
+	util.AssertNoError(t, os.RemoveAll(filepath.Join(skillsDir, "conventional-commit")))
+	util.WriteFile(t, filepath.Join(skillsDir, "commit-message", "SKILL.md"),
+		"---\nname: commit-message\ndescription: Write a conventional commit message\n---\n\nBody\n")
+
+	before, err := New(dir).Parse()
+	util.AssertNoError(t, err)
+	util.AssertNoError(t, Update(dir, &listings[0]))
+	after, err := New(dir).Parse()
+	util.AssertNoError(t, err)
+
+	util.AssertEqual(t, fmt.Sprintf("%+v", CompareVersions(before, after).Renamed), "[{From:conventional-commit To:commit-message}]")
+	if _, err := os.Stat(dir + ".update"); !os.IsNotExist(err) {
+		t.Errorf("staged copy left behind: %v", err)
+	}
+	if err := Update(dir, nil); err == nil {
+		t.Error("expected an error updating a copied plugin without its listing")
+	}
+}
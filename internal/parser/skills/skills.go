@@ -85,6 +85,20 @@ func (p *Parser) Parse() ([]model.Skill, error) {
 
 // parseSkillFile parses a single SKILL.md file.
 func (p *Parser) parseSkillFile(filePath string) (model.Skill, error) {
+	skill, err := parser.ParseSkillFile("skills:"+string(p.platform), filePath, p.readSkillFile)
+	if err != nil {
+		return model.Skill{}, err
+	}
+
+	// Detect skill directory structure. The files of the skill directory
+	// can change without the SKILL.md changing, so they are never cached.
+	detectSkillDirectoryStructure(&skill, filepath.Dir(filePath))
+
+	return skill, nil
+}
+
+// readSkillFile parses the content of a single SKILL.md file.
+func (p *Parser) readSkillFile(filePath string) (model.Skill, error) {
 	// Read file content
 	// #nosec G304 - filePath is validated through directory traversal from basePath
 	content, err := os.ReadFile(filePath)
@@ -97,9 +111,10 @@ func (p *Parser) parseSkillFile(filePath string) (model.Skill, error) {
 
 	// Extract metadata from frontmatter
 	skill := model.Skill{
-		Platform: p.platform,
-		Path:     filePath,
-		Metadata: make(map[string]string),
+		Platform:    p.platform,
+		Path:        filePath,
+		Metadata:    make(map[string]string),
+		Frontmatter: string(result.Frontmatter),
 	}
 
 	if result.HasFrontmatter {
@@ -178,10 +193,6 @@ func (p *Parser) parseSkillFile(filePath string) (model.Skill, error) {
 		return model.Skill{}, fmt.Errorf("invalid skill name %q in %q: %w", skill.Name, filePath, err)
 	}
 
-	// Detect skill directory structure
-	skillDir := filepath.Dir(filePath)
-	detectSkillDirectoryStructure(&skill, skillDir)
-
 	// Get file modification time
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
@@ -338,9 +349,10 @@ func ParseSkillContent(content []byte, name string, platform model.Platform) (mo
 	result := parser.SplitFrontmatter(content)
 
 	skill := model.Skill{
-		Name:     name,
-		Platform: platform,
-		Metadata: make(map[string]string),
+		Name:        name,
+		Platform:    platform,
+		Metadata:    make(map[string]string),
+		Frontmatter: string(result.Frontmatter),
 	}
 
 	if result.HasFrontmatter {
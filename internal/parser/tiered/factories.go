@@ -6,9 +6,11 @@ import (
 
 	"github.com/klauern/skillsync/internal/model"
 	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/parser/aider"
 	"github.com/klauern/skillsync/internal/parser/claude"
 	"github.com/klauern/skillsync/internal/parser/codex"
 	"github.com/klauern/skillsync/internal/parser/cursor"
+	"github.com/klauern/skillsync/internal/parser/external"
 )
 
 // ClaudeCodeParserFactory returns a ParserFactory for Claude Code.
@@ -32,6 +34,20 @@ func CodexParserFactory() ParserFactory {
 	}
 }
 
+// AiderParserFactory returns a ParserFactory for Aider.
+func AiderParserFactory() ParserFactory {
+	return func(basePath string) parser.Parser {
+		return aider.New(basePath)
+	}
+}
+
+// ExternalParserFactory returns a ParserFactory for a custom platform.
+func ExternalParserFactory(def external.Definition) ParserFactory {
+	return func(basePath string) parser.Parser {
+		return external.New(def, basePath)
+	}
+}
+
 // ParserFactoryFor returns the appropriate ParserFactory for a platform.
 func ParserFactoryFor(platform model.Platform) ParserFactory {
 	switch platform {
@@ -41,7 +57,12 @@ func ParserFactoryFor(platform model.Platform) ParserFactory {
 		return CursorParserFactory()
 	case model.Codex:
 		return CodexParserFactory()
+	case model.Aider:
+		return AiderParserFactory()
 	default:
+		if def, ok := external.Lookup(platform); ok {
+			return ExternalParserFactory(def)
+		}
 		// Return a factory that creates Claude parsers as a fallback
 		return ClaudeCodeParserFactory()
 	}
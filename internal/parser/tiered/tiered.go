@@ -108,6 +108,9 @@ func (p *Parser) Parse() ([]model.Skill, error) {
 						slog.String("existingScope", string(existing.Scope)),
 					)
 					skillsByName[skill.Name] = skill
+					lintShadowed(skill, existing)
+				} else {
+					lintShadowed(existing, skill)
 				}
 			} else {
 				skillsByName[skill.Name] = skill
@@ -170,6 +173,9 @@ func (p *Parser) ParseWithScopeFilter(scopes []model.SkillScope) ([]model.Skill,
 			if existing, exists := skillsByName[skill.Name]; exists {
 				if skill.Scope.IsHigherPrecedence(existing.Scope) {
 					skillsByName[skill.Name] = skill
+					lintShadowed(skill, existing)
+				} else {
+					lintShadowed(existing, skill)
 				}
 			} else {
 				skillsByName[skill.Name] = skill
@@ -224,6 +230,20 @@ func (p *Parser) ParseFromScope(scope model.SkillScope) ([]model.Skill, error) {
 	return allSkills, nil
 }
 
+// lintShadowed reports a skill hidden by a same-named skill in a
+// higher-precedence scope.
+func lintShadowed(winner, shadowed model.Skill) {
+	if winner.Scope == shadowed.Scope {
+		return
+	}
+	logging.Lint("skill shadowed by a higher-precedence scope",
+		logging.Skill(shadowed.Name),
+		logging.Path(shadowed.Path),
+		slog.String("scope", string(shadowed.Scope)),
+		slog.String("shadowedBy", winner.Path),
+	)
+}
+
 // Platform returns the platform this parser handles.
 func (p *Parser) Platform() model.Platform {
 	return p.platform
@@ -1,10 +1,12 @@
 package tiered
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/klauern/skillsync/internal/logging"
 	"github.com/klauern/skillsync/internal/model"
 	"github.com/klauern/skillsync/internal/parser"
 	"github.com/klauern/skillsync/internal/parser/mock"
@@ -192,6 +194,46 @@ Repo content
 	}
 }
 
+func TestParser_Parse_LintsShadowedSkills(t *testing.T) {
+	tmpDir := t.TempDir()
+	adminDir := filepath.Join(tmpDir, "admin")
+	systemDir := filepath.Join(tmpDir, "system")
+	for _, dir := range []string{adminDir, systemDir} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			t.Fatalf("failed to create skills dir: %v", err)
+		}
+	}
+
+	mockSkills := map[string][]model.Skill{
+		adminDir:  {{Name: "shared-skill", Scope: model.ScopeAdmin}},
+		systemDir: {{Name: "shared-skill", Scope: model.ScopeSystem}},
+	}
+	p := New(Config{
+		Platform:   model.ClaudeCode,
+		WorkingDir: tmpDir,
+		AdminPath:  adminDir,
+		SystemPath: systemDir,
+		ParserFactory: func(basePath string) parser.Parser {
+			return mock.New(model.ClaudeCode).WithSkills(mockSkills[basePath])
+		},
+	})
+
+	logging.SetDefault(logging.New(logging.Options{Level: logging.LevelInfo, Output: io.Discard}))
+	logging.SetStrict(true)
+	logging.ResetWarnings()
+	t.Cleanup(func() {
+		logging.SetStrict(false)
+		logging.ResetWarnings()
+	})
+
+	if _, err := p.Parse(); err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if got := logging.Warnings(); got != 1 {
+		t.Errorf("expected 1 shadowing warning in strict mode, got %d", got)
+	}
+}
+
 func TestParser_Platform(t *testing.T) {
 	tests := []struct {
 		platform model.Platform
@@ -0,0 +1,144 @@
+// Package promote moves and copies skills between scopes, as done by the
+// promote and demote commands and the TUI promote/demote view.
+package promote
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/history"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// Options configures a single promote or demote.
+type Options struct {
+	// KeepSource leaves the source skill in place, copying it instead of
+	// moving it.
+	KeepSource bool
+	// DryRun reports what would change without touching any files.
+	DryRun bool
+	// SkipBackup skips backing up the skill replaced at the target and the
+	// removed source.
+	SkipBackup bool
+	// SessionID groups the backups with the others of the run, so that
+	// undo can restore them.
+	SessionID string
+}
+
+// Result is the outcome of a promote or demote.
+type Result struct {
+	Source     string `json:"source"`
+	Target     string `json:"target"`
+	Overwrote  bool   `json:"overwrote,omitempty"`
+	KeptSource bool   `json:"kept_source,omitempty"`
+	// Records are the history records of the target and, when moved, the
+	// source. They are set for dry runs too.
+	Records []history.SkillRecord `json:"-"`
+	// Backups are the IDs of the backups taken.
+	Backups []string `json:"backups,omitempty"`
+}
+
+// Move writes skill to targetPath in the target scope and removes the source
+// unless opts.KeepSource is set. A skill already at targetPath is replaced.
+//
+// When the source cannot be removed, the copy is kept and an error is
+// returned along with the result, whose records describe the copy.
+func Move(skill model.Skill, targetPath string, opts Options) (*Result, error) {
+	result := &Result{Source: skill.Path, Target: targetPath, KeptSource: opts.KeepSource}
+	if filepath.Clean(skill.Path) == filepath.Clean(targetPath) {
+		return nil, fmt.Errorf("skill %q is already at %s", skill.Name, targetPath)
+	}
+
+	// #nosec G304 - skill.Path comes from parsed skill files
+	content, err := os.ReadFile(skill.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source skill: %w", err)
+	}
+
+	target := history.SkillRecord{
+		Name:       skill.Name,
+		Action:     string(sync.ActionCreated),
+		TargetPath: targetPath,
+		Message:    fmt.Sprintf("copied from %s scope", skill.Scope),
+	}
+	if !opts.KeepSource {
+		target.Message = fmt.Sprintf("moved from %s", skill.Path)
+	}
+	if _, err := os.Stat(targetPath); err == nil {
+		target.Action = string(sync.ActionUpdated)
+		result.Overwrote = true
+	}
+	source := history.SkillRecord{
+		Name:       skill.Name,
+		Action:     string(sync.ActionDeleted),
+		TargetPath: skill.Path,
+		Message:    fmt.Sprintf("moved to %s", targetPath),
+	}
+
+	if opts.DryRun {
+		result.Records = []history.SkillRecord{target}
+		if !opts.KeepSource {
+			result.Records = append(result.Records, source)
+		}
+		return result, nil
+	}
+
+	if result.Overwrote && !opts.SkipBackup {
+		id, err := backupSkill(targetPath, skill, "pre-overwrite backup", opts.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		target.Backups = []string{id}
+		result.Backups = append(result.Backups, id)
+	}
+
+	// #nosec G301 - skill directories need to be readable by the platform
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create target directory: %w", err)
+	}
+	// #nosec G306 - skill files should be readable
+	if err := os.WriteFile(targetPath, content, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write skill to target: %w", err)
+	}
+	result.Records = []history.SkillRecord{target}
+	if opts.KeepSource {
+		return result, nil
+	}
+
+	if !opts.SkipBackup {
+		id, err := backupSkill(skill.Path, skill, "pre-move backup", opts.SessionID)
+		if err != nil {
+			result.KeptSource = true
+			return result, fmt.Errorf("copied, but kept the source: %w", err)
+		}
+		source.Backups = []string{id}
+		result.Backups = append(result.Backups, id)
+	}
+	if err := os.Remove(skill.Path); err != nil {
+		result.KeptSource = true
+		return result, fmt.Errorf("copied, but failed to remove source skill: %w", err)
+	}
+	util.RemoveEmptySkillDir(skill.Path)
+	result.Records = append(result.Records, source)
+	return result, nil
+}
+
+// backupSkill backs up path, a copy of skill about to be replaced or
+// removed, returning the backup's ID.
+func backupSkill(path string, skill model.Skill, description, sessionID string) (string, error) {
+	metadata, err := backup.CreateBackup(path, backup.Options{
+		Platform:    string(skill.Platform),
+		Description: description,
+		Metadata:    map[string]string{"skill": skill.Name},
+		Tags:        []string{"skill"},
+		SessionID:   sessionID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return metadata.ID, nil
+}
@@ -0,0 +1,98 @@
+package promote
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestMove(t *testing.T) {
+	const lint = "---\nname: lint\n---\nRun the linter.\n"
+
+	tests := map[string]struct {
+		opts        Options
+		existing    bool // a skill is already at the target
+		wantSource  bool // the source is left
+		wantTarget  bool // the target holds the source's content
+		wantActions []string
+		wantBackups int
+	}{
+		"move": {
+			wantTarget:  true,
+			wantActions: []string{"created", "deleted"},
+			wantBackups: 1,
+		},
+		"keep source": {
+			opts:        Options{KeepSource: true},
+			wantSource:  true,
+			wantTarget:  true,
+			wantActions: []string{"created"},
+		},
+		"overwrite": {
+			existing:    true,
+			wantTarget:  true,
+			wantActions: []string{"updated", "deleted"},
+			wantBackups: 2,
+		},
+		"skip backup": {
+			opts:        Options{SkipBackup: true},
+			existing:    true,
+			wantTarget:  true,
+			wantActions: []string{"updated", "deleted"},
+		},
+		"dry run": {
+			opts:        Options{DryRun: true},
+			wantSource:  true,
+			wantActions: []string{"created", "deleted"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+			source := filepath.Join(tempDir, "repo", ".claude", "skills", "lint", "SKILL.md")
+			target := filepath.Join(tempDir, "user", "lint", "SKILL.md")
+			util.WriteFile(t, source, lint)
+			if tt.existing {
+				util.WriteFile(t, target, "---\nname: lint\n---\nOld.\n")
+			}
+			tt.opts.SessionID = "s1"
+
+			skill := model.Skill{Name: "lint", Platform: model.ClaudeCode, Scope: model.ScopeRepo, Path: source}
+			result, err := Move(skill, target, tt.opts)
+			util.AssertNoError(t, err)
+
+			_, err = os.Stat(source)
+			util.AssertEqual(t, err == nil, tt.wantSource)
+			if !tt.wantSource {
+				if _, err := os.Stat(filepath.Dir(source)); !os.IsNotExist(err) {
+					t.Errorf("source directory %s was left behind", filepath.Dir(source))
+				}
+			}
+			data, _ := os.ReadFile(target)
+			util.AssertEqual(t, string(data) == lint, tt.wantTarget)
+
+			actions := make([]string, len(result.Records))
+			for i, record := range result.Records {
+				actions[i] = record.Action
+			}
+			util.AssertEqual(t, strings.Join(actions, ","), strings.Join(tt.wantActions, ","))
+			util.AssertEqual(t, len(result.Backups), tt.wantBackups)
+		})
+	}
+}
+
+func TestMove_SamePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lint", "SKILL.md")
+	util.WriteFile(t, path, "---\nname: lint\n---\nRun the linter.\n")
+
+	_, err := Move(model.Skill{Name: "lint", Path: path}, path, Options{})
+	if err == nil {
+		t.Fatal("Move() to the source path: expected an error")
+	}
+}
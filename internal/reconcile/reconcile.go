@@ -0,0 +1,317 @@
+// Package reconcile plans a multi-way sync of skills across any number of
+// platforms at once.
+//
+// Pairwise syncs cannot tell which side of a difference changed, so with
+// more than two platforms they can undo each other. The reconciler instead
+// keeps a version vector per skill: one counter per platform, incremented
+// whenever the platform's copy changed since the last reconcile. A copy
+// whose vector dominates every other wins and is propagated everywhere;
+// copies changed concurrently on different platforms are a conflict.
+package reconcile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// StateVersion is the current state file version.
+const StateVersion = 1
+
+// StateFileName is the name of the state file in the metadata directory.
+const StateFileName = "reconcile.json"
+
+// Vector is a version vector: per platform, the number of times its copy of
+// a skill changed between reconciles.
+type Vector map[model.Platform]int
+
+// Dominates reports whether v has seen every change o has, i.e. no counter
+// of o is greater than the same counter of v.
+func (v Vector) Dominates(o Vector) bool {
+	for platform, n := range o {
+		if v[platform] < n {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge returns the element-wise maximum of v and o.
+func (v Vector) Merge(o Vector) Vector {
+	merged := make(Vector, len(v)+len(o))
+	for platform, n := range v {
+		merged[platform] = n
+	}
+	for platform, n := range o {
+		merged[platform] = max(merged[platform], n)
+	}
+	return merged
+}
+
+// String formats the vector as platform:counter pairs sorted by platform.
+func (v Vector) String() string {
+	platforms := make([]string, 0, len(v))
+	for platform := range v {
+		platforms = append(platforms, string(platform))
+	}
+	sort.Strings(platforms)
+	parts := make([]string, len(platforms))
+	for i, platform := range platforms {
+		parts[i] = fmt.Sprintf("%s:%d", platform, v[model.Platform(platform)])
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// SkillState is what the last reconcile recorded about a skill.
+type SkillState struct {
+	Vector Vector `json:"vector"`
+	// Hashes is the content hash of each platform's copy after the reconcile.
+	Hashes map[model.Platform]string `json:"hashes"`
+}
+
+// State is the reconcile history of every skill.
+type State struct {
+	Version int                   `json:"version"`
+	Skills  map[string]SkillState `json:"skills"`
+}
+
+// StatePath returns the location of the state file.
+func StatePath() string {
+	return filepath.Join(util.SkillsyncMetadataPath(), StateFileName)
+}
+
+// LoadState reads the state at path. A missing file is an empty state.
+func LoadState(path string) (*State, error) {
+	state := &State{Version: StateVersion, Skills: make(map[string]SkillState)}
+	// #nosec G304 - path is the reconcile state location
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reconcile state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse reconcile state %s: %w", path, err)
+	}
+	if state.Version > StateVersion {
+		return nil, fmt.Errorf("unsupported reconcile state version %d", state.Version)
+	}
+	if state.Skills == nil {
+		state.Skills = make(map[string]SkillState)
+	}
+	return state, nil
+}
+
+// Save writes the state to path, creating its directory. Nothing is
+// written with --no-persist.
+func (s *State) Save(path string) error {
+	if util.NoPersist() {
+		return nil
+	}
+	if err := util.EnsureDataDir(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create reconcile state directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal reconcile state: %w", err)
+	}
+
+	// Write to a temp file and rename so a crash never leaves a truncated state.
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write reconcile state: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write reconcile state: %w", err)
+	}
+	return nil
+}
+
+// ContentHash returns the hash a copy of a skill is compared by: its
+// normalized body. Frontmatter is left out since platforms transform it.
+func ContentHash(skill model.Skill) string {
+	sum := sha256.Sum256([]byte(parser.NormalizeContent(skill.Content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Status is the outcome of planning one skill.
+type Status string
+
+const (
+	// StatusConverged means every platform has the same content.
+	StatusConverged Status = "converged"
+	// StatusPropagate means one copy wins and is written to the others.
+	StatusPropagate Status = "propagate"
+	// StatusConflict means copies changed concurrently on several platforms.
+	StatusConflict Status = "conflict"
+)
+
+// Update is a write of the winning copy to a platform.
+type Update struct {
+	Platform model.Platform `json:"platform"`
+	// Create is true when the platform has no copy of the skill yet.
+	Create bool `json:"create,omitempty"`
+}
+
+// SkillPlan is the plan for one skill.
+type SkillPlan struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	// Source is the platform whose copy is propagated.
+	Source  model.Platform `json:"source,omitempty"`
+	Updates []Update       `json:"updates,omitempty"`
+	// Changed lists the platforms whose copy changed since the last reconcile.
+	Changed []model.Platform `json:"changed,omitempty"`
+	// Vector is the version vector of the skill once the plan is applied.
+	Vector Vector `json:"vector"`
+	// Resolved is set when a conflict was settled with a preferred platform.
+	Resolved bool `json:"resolved,omitempty"`
+}
+
+// Plan is a convergence plan across platforms.
+type Plan struct {
+	Platforms []model.Platform `json:"platforms"`
+	Skills    []SkillPlan      `json:"skills"`
+}
+
+// Options configures planning.
+type Options struct {
+	// Prefer settles conflicts with this platform's copy, when it has one.
+	Prefer model.Platform
+}
+
+// NewPlan computes how to converge the skills of platforms. copies maps
+// each platform to the copy of each skill it resolves. Platforms earlier in
+// the list are preferred as the source among copies with the winning content.
+func NewPlan(platforms []model.Platform, copies map[model.Platform][]model.Skill, state *State, opts Options) Plan {
+	hashes := make(map[string]map[model.Platform]string)
+	for _, platform := range platforms {
+		for _, skill := range copies[platform] {
+			if hashes[skill.Name] == nil {
+				hashes[skill.Name] = make(map[model.Platform]string)
+			}
+			hashes[skill.Name][platform] = ContentHash(skill)
+		}
+	}
+
+	names := make([]string, 0, len(hashes))
+	for name := range hashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	plan := Plan{Platforms: platforms, Skills: make([]SkillPlan, 0, len(names))}
+	for _, name := range names {
+		plan.Skills = append(plan.Skills, planSkill(name, platforms, hashes[name], state.Skills[name], opts))
+	}
+	return plan
+}
+
+// planSkill plans one skill from the current hash of each platform's copy
+// and what the last reconcile recorded.
+func planSkill(name string, platforms []model.Platform, current map[model.Platform]string, prev SkillState, opts Options) SkillPlan {
+	sp := SkillPlan{Name: name}
+
+	// Each changed copy gets its own platform counter incremented.
+	vectors := make(map[model.Platform]Vector, len(current))
+	merged := Vector{}
+	for _, platform := range platforms {
+		hash, ok := current[platform]
+		if !ok {
+			continue
+		}
+		vector := Vector{}.Merge(prev.Vector)
+		if prev.Hashes[platform] != hash {
+			vector[platform]++
+			sp.Changed = append(sp.Changed, platform)
+		}
+		vectors[platform] = vector
+		merged = merged.Merge(vector)
+	}
+	sp.Vector = merged
+
+	// The winner is a copy whose vector dominates every other copy's.
+	// Copies with the same content count as one.
+	winner := ""
+	for _, platform := range platforms {
+		hash, ok := current[platform]
+		if !ok || hash == winner {
+			continue
+		}
+		dominatesAll := true
+		for other, otherHash := range current {
+			if otherHash != hash && !vectors[platform].Dominates(vectors[other]) {
+				dominatesAll = false
+				break
+			}
+		}
+		if dominatesAll {
+			winner = hash
+			break
+		}
+	}
+
+	if winner == "" {
+		preferred, ok := current[opts.Prefer]
+		if !ok {
+			sp.Status = StatusConflict
+			return sp
+		}
+		winner = preferred
+		sp.Resolved = true
+	}
+
+	for _, platform := range platforms {
+		hash, ok := current[platform]
+		switch {
+		case ok && hash == winner && sp.Source == "":
+			sp.Source = platform
+		case !ok:
+			sp.Updates = append(sp.Updates, Update{Platform: platform, Create: true})
+		case hash != winner:
+			sp.Updates = append(sp.Updates, Update{Platform: platform})
+		}
+	}
+	if sp.Resolved {
+		sp.Source = opts.Prefer
+	}
+	if len(sp.Updates) == 0 {
+		sp.Status = StatusConverged
+	} else {
+		sp.Status = StatusPropagate
+	}
+	return sp
+}
+
+// Record stores the copies found after a plan was applied as the new state
+// of its skills. Conflicts are left as they were so they are found again.
+func (s *State) Record(plan Plan, copies map[model.Platform][]model.Skill) {
+	for _, sp := range plan.Skills {
+		if sp.Status == StatusConflict {
+			continue
+		}
+		hashes := make(map[model.Platform]string)
+		for _, platform := range plan.Platforms {
+			for _, skill := range copies[platform] {
+				if skill.Name == sp.Name {
+					hashes[platform] = ContentHash(skill)
+					break
+				}
+			}
+		}
+		s.Skills[sp.Name] = SkillState{Vector: sp.Vector, Hashes: hashes}
+	}
+}
@@ -0,0 +1,158 @@
+package reconcile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+var platforms = []model.Platform{model.ClaudeCode, model.Cursor, model.Codex}
+
+// copiesOf builds the copies of a skill named "lint" from each platform's content.
+func copiesOf(content map[model.Platform]string) map[model.Platform][]model.Skill {
+	copies := make(map[model.Platform][]model.Skill)
+	for platform, c := range content {
+		copies[platform] = []model.Skill{{Name: "lint", Platform: platform, Content: c}}
+	}
+	return copies
+}
+
+// converged returns a state in which every platform last had content.
+func converged(content string, vector Vector) *State {
+	hashes := make(map[model.Platform]string)
+	for _, platform := range platforms {
+		hashes[platform] = ContentHash(model.Skill{Content: content})
+	}
+	return &State{Skills: map[string]SkillState{"lint": {Vector: vector, Hashes: hashes}}}
+}
+
+func TestNewPlan(t *testing.T) {
+	empty := &State{Skills: map[string]SkillState{}}
+	base := Vector{model.ClaudeCode: 1, model.Cursor: 1, model.Codex: 1}
+
+	tests := map[string]struct {
+		content     map[model.Platform]string
+		state       *State
+		prefer      model.Platform
+		wantStatus  Status
+		wantSource  model.Platform
+		wantUpdates []Update
+		wantVector  Vector
+	}{
+		"identical everywhere": {
+			content:    map[model.Platform]string{model.ClaudeCode: "a", model.Cursor: "a", model.Codex: "a"},
+			state:      converged("a", base),
+			wantStatus: StatusConverged,
+			wantSource: model.ClaudeCode,
+			wantVector: base,
+		},
+		"one platform changed": {
+			content:     map[model.Platform]string{model.ClaudeCode: "a", model.Cursor: "b", model.Codex: "a"},
+			state:       converged("a", base),
+			wantStatus:  StatusPropagate,
+			wantSource:  model.Cursor,
+			wantUpdates: []Update{{Platform: model.ClaudeCode}, {Platform: model.Codex}},
+			wantVector:  Vector{model.ClaudeCode: 1, model.Cursor: 2, model.Codex: 1},
+		},
+		"same change on two platforms": {
+			content:     map[model.Platform]string{model.ClaudeCode: "b", model.Cursor: "b", model.Codex: "a"},
+			state:       converged("a", base),
+			wantStatus:  StatusPropagate,
+			wantSource:  model.ClaudeCode,
+			wantUpdates: []Update{{Platform: model.Codex}},
+			wantVector:  Vector{model.ClaudeCode: 2, model.Cursor: 2, model.Codex: 1},
+		},
+		"concurrent changes": {
+			content:    map[model.Platform]string{model.ClaudeCode: "b", model.Cursor: "c", model.Codex: "a"},
+			state:      converged("a", base),
+			wantStatus: StatusConflict,
+			wantVector: Vector{model.ClaudeCode: 2, model.Cursor: 2, model.Codex: 1},
+		},
+		"concurrent changes with a preferred platform": {
+			content:     map[model.Platform]string{model.ClaudeCode: "b", model.Cursor: "c", model.Codex: "a"},
+			state:       converged("a", base),
+			prefer:      model.Cursor,
+			wantStatus:  StatusPropagate,
+			wantSource:  model.Cursor,
+			wantUpdates: []Update{{Platform: model.ClaudeCode}, {Platform: model.Codex}},
+			wantVector:  Vector{model.ClaudeCode: 2, model.Cursor: 2, model.Codex: 1},
+		},
+		"missing on a platform": {
+			content:     map[model.Platform]string{model.ClaudeCode: "a", model.Cursor: "a"},
+			state:       converged("a", base),
+			wantStatus:  StatusPropagate,
+			wantSource:  model.ClaudeCode,
+			wantUpdates: []Update{{Platform: model.Codex, Create: true}},
+			wantVector:  base,
+		},
+		"new skill on one platform": {
+			content:     map[model.Platform]string{model.Codex: "a"},
+			state:       empty,
+			wantStatus:  StatusPropagate,
+			wantSource:  model.Codex,
+			wantUpdates: []Update{{Platform: model.ClaudeCode, Create: true}, {Platform: model.Cursor, Create: true}},
+			wantVector:  Vector{model.Codex: 1},
+		},
+		"differing copies without history": {
+			content:    map[model.Platform]string{model.ClaudeCode: "a", model.Cursor: "b"},
+			state:      empty,
+			wantStatus: StatusConflict,
+			wantVector: Vector{model.ClaudeCode: 1, model.Cursor: 1},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			plan := NewPlan(platforms, copiesOf(tt.content), tt.state, Options{Prefer: tt.prefer})
+			if len(plan.Skills) != 1 {
+				t.Fatalf("got %d skill plans, want 1", len(plan.Skills))
+			}
+			sp := plan.Skills[0]
+			util.AssertEqual(t, sp.Status, tt.wantStatus)
+			util.AssertEqual(t, sp.Source, tt.wantSource)
+			util.AssertEqual(t, sp.Vector.String(), tt.wantVector.String())
+			if len(sp.Updates) != len(tt.wantUpdates) {
+				t.Fatalf("updates = %+v, want %+v", sp.Updates, tt.wantUpdates)
+			}
+			for i := range sp.Updates {
+				util.AssertEqual(t, sp.Updates[i], tt.wantUpdates[i])
+			}
+		})
+	}
+}
+
+func TestVector(t *testing.T) {
+	a := Vector{model.ClaudeCode: 2, model.Cursor: 1}
+	b := Vector{model.ClaudeCode: 1, model.Cursor: 1, model.Codex: 1}
+
+	util.AssertEqual(t, a.Dominates(Vector{model.ClaudeCode: 1}), true)
+	util.AssertEqual(t, a.Dominates(b), false)
+	util.AssertEqual(t, b.Dominates(a), false)
+	util.AssertEqual(t, a.Merge(b).String(), "{claude-code:2 codex:1 cursor:1}")
+}
+
+func TestStateRecordAndSave(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	path := StatePath()
+
+	state, err := LoadState(path)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(state.Skills), 0)
+
+	copies := copiesOf(map[model.Platform]string{model.ClaudeCode: "a", model.Cursor: "b"})
+	plan := NewPlan(platforms, copies, state, Options{Prefer: model.Cursor})
+	copies = copiesOf(map[model.Platform]string{model.ClaudeCode: "b", model.Cursor: "b", model.Codex: "b"})
+	state.Record(plan, copies)
+	util.AssertNoError(t, state.Save(path))
+
+	loaded, err := LoadState(path)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, filepath.Base(path), StateFileName)
+	util.AssertEqual(t, len(loaded.Skills["lint"].Hashes), 3)
+
+	again := NewPlan(platforms, copies, loaded, Options{})
+	util.AssertEqual(t, again.Skills[0].Status, StatusConverged)
+	util.AssertEqual(t, len(again.Skills[0].Changed), 0)
+}
@@ -0,0 +1,253 @@
+// Package rpc serves skillsync operations over JSON-RPC 2.0 so editor
+// integrations can keep one skillsync process running instead of starting
+// one per command.
+//
+// Messages are newline-delimited JSON objects: each request is one line on
+// the input and each response one line on the output. Requests are handled
+// in order. The methods and their params and results are defined in
+// schema.go; fields are only ever added to them, so clients can rely on the
+// schema across releases of the same ProtocolVersion.
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/klauern/skillsync/internal/logging"
+)
+
+// jsonrpcVersion is the JSON-RPC version of every message.
+const jsonrpcVersion = "2.0"
+
+// maxMessageSize caps a single request line.
+const maxMessageSize = 16 << 20
+
+// JSON-RPC error codes. CodeFailed is returned when an operation itself
+// fails, e.g. for an unknown platform or skill.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeFailed         = -32000
+)
+
+// Request is a JSON-RPC request. A request without an ID is a notification
+// and gets no response.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC response: Result on success, Error otherwise.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// Options configures the server with the operations behind each method.
+// Methods whose function is nil answer with CodeMethodNotFound.
+type Options struct {
+	// Version is the skillsync version reported by initialize.
+	Version string
+
+	Discover        func(context.Context, DiscoverParams) (*DiscoverResult, error)
+	Diff            func(context.Context, DiffParams) (*DiffResult, error)
+	Sync            func(context.Context, SyncParams) (*SyncResult, error)
+	ResolveConflict func(context.Context, ResolveConflictParams) (*ResolveConflictResult, error)
+}
+
+// Handler runs a method with its raw params. Its result is encoded as the
+// response result; an *Error is returned as is, other errors as CodeFailed.
+type Handler func(ctx context.Context, params json.RawMessage) (any, error)
+
+// Server dispatches JSON-RPC requests to method handlers.
+type Server struct {
+	opts     Options
+	handlers map[string]Handler
+}
+
+// errShutdown stops Serve after the shutdown response is written.
+var errShutdown = errors.New("shutdown requested")
+
+// New creates a server for the configured skillsync operations.
+func New(opts Options) *Server {
+	s := NewServer()
+	s.opts = opts
+	s.Handle(MethodInitialize, func(context.Context, json.RawMessage) (any, error) {
+		return s.initialize(), nil
+	})
+	s.Handle(MethodShutdown, func(context.Context, json.RawMessage) (any, error) {
+		return struct{}{}, nil
+	})
+	if opts.Discover != nil {
+		s.Handle(MethodDiscover, Typed(opts.Discover))
+	}
+	if opts.Diff != nil {
+		s.Handle(MethodDiff, Typed(opts.Diff))
+	}
+	if opts.Sync != nil {
+		s.Handle(MethodSync, Typed(opts.Sync))
+	}
+	if opts.ResolveConflict != nil {
+		s.Handle(MethodResolveConflict, Typed(opts.ResolveConflict))
+	}
+	return s
+}
+
+// NewServer creates a server without methods, for protocols built on
+// JSON-RPC such as MCP. Register methods with Handle.
+func NewServer() *Server {
+	return &Server{handlers: make(map[string]Handler)}
+}
+
+// Handle registers the handler of a method, replacing any previous one.
+// Registering MethodShutdown makes a shutdown request stop Serve.
+func (s *Server) Handle(method string, h Handler) {
+	s.handlers[method] = h
+}
+
+// Typed adapts a typed operation to a Handler, decoding its params and
+// rejecting unknown fields. Missing params decode as the zero value.
+func Typed[P, R any](fn func(context.Context, P) (R, error)) Handler {
+	return func(ctx context.Context, raw json.RawMessage) (any, error) {
+		var params P
+		if len(raw) > 0 && !bytes.Equal(raw, []byte("null")) {
+			decoder := json.NewDecoder(bytes.NewReader(raw))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&params); err != nil {
+				return nil, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+			}
+		}
+		return fn(ctx, params)
+	}
+}
+
+// initialize describes the server to a client.
+func (s *Server) initialize() InitializeResult {
+	methods := make([]string, 0, len(s.handlers))
+	for method := range s.handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return InitializeResult{
+		Name:            "skillsync",
+		Version:         s.opts.Version,
+		ProtocolVersion: ProtocolVersion,
+		Methods:         methods,
+	}
+}
+
+// Serve reads requests from r and writes responses to w until r is
+// exhausted, ctx is done, or a shutdown request is answered.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	encoder := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		response, err := s.dispatch(ctx, line)
+		if response != nil {
+			if encodeErr := encoder.Encode(response); encodeErr != nil {
+				return fmt.Errorf("failed to write response: %w", encodeErr)
+			}
+		}
+		if errors.Is(err, errShutdown) {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+	return nil
+}
+
+// dispatch handles one request line, returning the response to write (nil
+// for notifications) and errShutdown after a shutdown request.
+func (s *Server) dispatch(ctx context.Context, line []byte) (*Response, error) {
+	var req Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		code := CodeParseError
+		if json.Valid(line) {
+			// Valid JSON that is not a request object, e.g. a batch
+			code = CodeInvalidRequest
+		}
+		return errorResponse(nil, &Error{Code: code, Message: err.Error()}), nil
+	}
+	if req.JSONRPC != jsonrpcVersion || req.Method == "" {
+		return errorResponse(req.ID, &Error{Code: CodeInvalidRequest, Message: `request needs "jsonrpc": "2.0" and a method`}), nil
+	}
+
+	h, ok := s.handlers[req.Method]
+	var stop error
+	if ok && req.Method == MethodShutdown {
+		stop = errShutdown
+	}
+	if !ok {
+		if req.ID == nil {
+			return nil, stop
+		}
+		return errorResponse(req.ID, &Error{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}), stop
+	}
+
+	result, err := h(ctx, req.Params)
+	if req.ID == nil {
+		if err != nil {
+			logging.Warn("rpc notification failed", logging.Operation(req.Method), logging.Err(err))
+		}
+		return nil, stop
+	}
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: CodeFailed, Message: err.Error()}
+		}
+		return errorResponse(req.ID, rpcErr), stop
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(req.ID, &Error{Code: CodeInternalError, Message: fmt.Sprintf("failed to encode result: %v", err)}), stop
+	}
+	return &Response{JSONRPC: jsonrpcVersion, ID: req.ID, Result: data}, stop
+}
+
+// errorResponse builds an error response. Requests whose ID could not be
+// read are answered with a null ID.
+func errorResponse(id json.RawMessage, err *Error) *Response {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return &Response{JSONRPC: jsonrpcVersion, ID: id, Error: err}
+}
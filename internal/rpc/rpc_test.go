@@ -0,0 +1,132 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// serve runs a server over the request lines and decodes the responses.
+func serve(t *testing.T, s *Server, lines ...string) []Response {
+	t.Helper()
+	var output bytes.Buffer
+	if err := s.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")), &output); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	var responses []Response
+	decoder := json.NewDecoder(&output)
+	for decoder.More() {
+		var response Response
+		if err := decoder.Decode(&response); err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func TestServe(t *testing.T) {
+	server := New(Options{
+		Version: "1.2.3",
+		Discover: func(_ context.Context, params DiscoverParams) (*DiscoverResult, error) {
+			if params.Platform == "unknown" {
+				return nil, errors.New("invalid platform")
+			}
+			return &DiscoverResult{Skills: []Skill{{Name: "lint", Platform: "cursor", Scope: "user"}}}, nil
+		},
+	})
+
+	tests := map[string]struct {
+		request    string
+		wantCode   int
+		wantResult string
+	}{
+		"discover": {
+			request:    `{"jsonrpc": "2.0", "id": 1, "method": "discover"}`,
+			wantResult: `{"skills":[{"name":"lint","platform":"cursor","scope":"user","path":""}]}`,
+		},
+		"initialize": {
+			request:    `{"jsonrpc": "2.0", "id": "a", "method": "initialize"}`,
+			wantResult: `{"name":"skillsync","version":"1.2.3","protocol_version":1,"methods":["discover","initialize","shutdown"]}`,
+		},
+		"operation error": {
+			request:  `{"jsonrpc": "2.0", "id": 2, "method": "discover", "params": {"platform": "unknown"}}`,
+			wantCode: CodeFailed,
+		},
+		"unknown params field": {
+			request:  `{"jsonrpc": "2.0", "id": 3, "method": "discover", "params": {"platfrom": "cursor"}}`,
+			wantCode: CodeInvalidParams,
+		},
+		"method without operation": {
+			request:  `{"jsonrpc": "2.0", "id": 4, "method": "sync"}`,
+			wantCode: CodeMethodNotFound,
+		},
+		"missing version": {
+			request:  `{"id": 5, "method": "discover"}`,
+			wantCode: CodeInvalidRequest,
+		},
+		"batch": {
+			request:  `[{"jsonrpc": "2.0", "id": 6, "method": "discover"}]`,
+			wantCode: CodeInvalidRequest,
+		},
+		"malformed": {
+			request:  `{"jsonrpc": "2.0", "id": 7,`,
+			wantCode: CodeParseError,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			responses := serve(t, server, tt.request)
+			if len(responses) != 1 {
+				t.Fatalf("got %d responses, want 1", len(responses))
+			}
+			response := responses[0]
+			if tt.wantCode != 0 {
+				if response.Error == nil || response.Error.Code != tt.wantCode {
+					t.Errorf("error = %+v, want code %d", response.Error, tt.wantCode)
+				}
+				return
+			}
+			if response.Error != nil {
+				t.Fatalf("unexpected error: %v", response.Error)
+			}
+			if string(response.Result) != tt.wantResult {
+				t.Errorf("result = %s, want %s", response.Result, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestServeNotificationsAndShutdown(t *testing.T) {
+	calls := 0
+	server := New(Options{
+		Discover: func(context.Context, DiscoverParams) (*DiscoverResult, error) {
+			calls++
+			return &DiscoverResult{}, nil
+		},
+	})
+
+	responses := serve(t, server,
+		`{"jsonrpc": "2.0", "method": "discover"}`,
+		``,
+		`{"jsonrpc": "2.0", "id": 1, "method": "discover"}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "shutdown"}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "discover"}`,
+	)
+
+	if calls != 2 {
+		t.Errorf("discover called %d times, want 2 (requests after shutdown are not read)", calls)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("got %d responses, want 2 (notifications are not answered)", len(responses))
+	}
+	for i, want := range []string{"1", "2"} {
+		if string(responses[i].ID) != want {
+			t.Errorf("response %d id = %s, want %s", i, responses[i].ID, want)
+		}
+	}
+}
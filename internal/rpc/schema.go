@@ -0,0 +1,163 @@
+package rpc
+
+import "github.com/klauern/skillsync/internal/model"
+
+// ProtocolVersion is incremented when a method or field is removed or
+// changes meaning. Adding methods or fields does not change it.
+const ProtocolVersion = 1
+
+// Method names.
+const (
+	// MethodInitialize reports the server version and supported methods.
+	MethodInitialize = "initialize"
+	// MethodShutdown answers and then stops the server.
+	MethodShutdown = "shutdown"
+	// MethodDiscover lists skills, like skillsync discover.
+	MethodDiscover = "discover"
+	// MethodDiff compares the skills of two platform specs, like skillsync diff.
+	MethodDiff = "diff"
+	// MethodSync syncs skills between two platform specs without prompting.
+	MethodSync = "sync"
+	// MethodResolveConflict syncs one skill, resolving its conflict with the
+	// target as requested.
+	MethodResolveConflict = "resolveConflict"
+)
+
+// InitializeResult is the result of initialize.
+type InitializeResult struct {
+	Name            string   `json:"name"`
+	Version         string   `json:"version"`
+	ProtocolVersion int      `json:"protocol_version"`
+	Methods         []string `json:"methods"`
+}
+
+// DiscoverParams are the params of discover. Platform specs use the CLI
+// syntax, platform[:scope[,scope]], e.g. "cursor:repo".
+type DiscoverParams struct {
+	// Platform limits discovery to one platform spec. Empty means every platform.
+	Platform       string `json:"platform,omitempty"`
+	IncludePlugins bool   `json:"include_plugins,omitempty"`
+}
+
+// Skill describes a discovered skill.
+type Skill struct {
+	Name        string        `json:"name"`
+	Platform    string        `json:"platform"`
+	Scope       string        `json:"scope"`
+	Path        string        `json:"path"`
+	Type        string        `json:"type,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Tools       []string      `json:"tools,omitempty"`
+	Origin      *model.Origin `json:"origin,omitempty"`
+}
+
+// DiscoverResult is the result of discover.
+type DiscoverResult struct {
+	Skills []Skill `json:"skills"`
+}
+
+// DiffParams are the params of diff.
+type DiffParams struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	// Skills limits the comparison to the named skills.
+	Skills []string `json:"skills,omitempty"`
+	// All includes identical skills and skills on one side only. It is
+	// implied when Skills is set.
+	All bool `json:"all,omitempty"`
+}
+
+// DiffHunk is a unified diff hunk. Lines keep their " ", "+", or "-" prefix.
+type DiffHunk struct {
+	SourceStart int      `json:"source_start"`
+	SourceCount int      `json:"source_count"`
+	TargetStart int      `json:"target_start"`
+	TargetCount int      `json:"target_count"`
+	Lines       []string `json:"lines"`
+}
+
+// SkillDiff is the comparison of one skill. Status is identical, modified,
+// only-source, or only-target.
+type SkillDiff struct {
+	Name            string     `json:"name"`
+	Status          string     `json:"status"`
+	SourcePath      string     `json:"source_path,omitempty"`
+	TargetPath      string     `json:"target_path,omitempty"`
+	MetadataDiffers bool       `json:"metadata_differs,omitempty"`
+	Hunks           []DiffHunk `json:"hunks,omitempty"`
+}
+
+// DiffResult is the result of diff.
+type DiffResult struct {
+	Diffs []SkillDiff `json:"diffs"`
+}
+
+// SyncParams are the params of sync.
+type SyncParams struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	// Skills limits the sync to the named skills.
+	Skills []string `json:"skills,omitempty"`
+	// Strategy is a sync strategy; empty uses the configured default. The
+	// interactive strategy reports conflicts without resolving them, for
+	// resolveConflict.
+	Strategy   string `json:"strategy,omitempty"`
+	DryRun     bool   `json:"dry_run,omitempty"`
+	SkipBackup bool   `json:"skip_backup,omitempty"`
+}
+
+// Conflict describes a skill whose target differs from the source.
+type Conflict struct {
+	// Type is content, metadata, or both.
+	Type  string     `json:"type"`
+	Hunks []DiffHunk `json:"hunks,omitempty"`
+}
+
+// SyncSkill is the outcome of syncing one skill. Action is one of the sync
+// actions: created, updated, unchanged, merged, skipped, conflict, failed, ...
+type SyncSkill struct {
+	Name       string    `json:"name"`
+	Action     string    `json:"action"`
+	TargetPath string    `json:"target_path,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Backups    []string  `json:"backups,omitempty"`
+	Conflict   *Conflict `json:"conflict,omitempty"`
+}
+
+// SyncResult is the result of sync.
+type SyncResult struct {
+	Source    string         `json:"source"`
+	Target    string         `json:"target"`
+	Strategy  string         `json:"strategy"`
+	DryRun    bool           `json:"dry_run"`
+	Success   bool           `json:"success"`
+	Counts    map[string]int `json:"counts"`
+	Skills    []SyncSkill    `json:"skills"`
+	SessionID string         `json:"session_id,omitempty"`
+}
+
+// ResolveConflictParams are the params of resolveConflict.
+type ResolveConflictParams struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Skill  string `json:"skill"`
+	// Resolution is source, target, or merge. It is ignored when Content is set.
+	Resolution string `json:"resolution,omitempty"`
+	// Content, if set, is written as the resolved content, e.g. after the
+	// user merged by hand in the editor.
+	Content    string `json:"content,omitempty"`
+	DryRun     bool   `json:"dry_run,omitempty"`
+	SkipBackup bool   `json:"skip_backup,omitempty"`
+}
+
+// ResolveConflictResult is the result of resolveConflict. Content is the
+// resolved content, empty when the skill had no conflict or the target was
+// kept.
+type ResolveConflictResult struct {
+	Skill SyncSkill `json:"skill"`
+	// Resolution is how the conflict was resolved: source, target, merge, or
+	// content. Empty when there was no conflict.
+	Resolution string `json:"resolution,omitempty"`
+	Content    string `json:"content,omitempty"`
+}
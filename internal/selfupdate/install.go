@@ -0,0 +1,163 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// verifyChecksum checks data against the entry for name in a checksums file
+// of "<sha256>  <name>" lines.
+func verifyChecksum(checksums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, name)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: no checksum published for %s", ErrChecksumMismatch, name)
+}
+
+// extractBinary returns the skillsync binary inside a release archive.
+func extractBinary(archive []byte, name, goos string) ([]byte, error) {
+	binary := ProgramName
+	if goos == "windows" {
+		binary += ".exe"
+	}
+
+	if strings.HasSuffix(name, ".zip") {
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		for _, file := range reader.File {
+			if path.Base(file.Name) != binary || file.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := file.Open()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			defer func() { _ = rc.Close() }()
+			return readBinary(rc, name)
+		}
+		return nil, fmt.Errorf("%s does not contain %s", name, binary)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	defer func() { _ = gz.Close() }()
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s does not contain %s", name, binary)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if header.Typeflag == tar.TypeReg && path.Base(header.Name) == binary {
+			return readBinary(reader, name)
+		}
+	}
+}
+
+// readBinary reads an archive entry, refusing one larger than maxDownloadSize.
+func readBinary(r io.Reader, name string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("binary in %s is larger than %d bytes", name, maxDownloadSize)
+	}
+	return data, nil
+}
+
+// Replace swaps the binary at exe for binary, keeping its permissions. The
+// new binary is written next to exe first, so a failed write leaves exe
+// untouched, and the old binary is moved aside rather than overwritten,
+// which Windows requires of a running executable.
+func Replace(exe string, binary []byte) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", exe, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".skillsync-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to write the update next to %s: %w", exe, err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+	if _, err := tmp.Write(binary); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmpPath, err)
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to set permissions of %s: %w", tmpPath, err)
+	}
+
+	old := exe + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return fmt.Errorf("failed to move %s aside: %w", exe, err)
+	}
+	if err := os.Rename(tmpPath, exe); err != nil {
+		if restoreErr := os.Rename(old, exe); restoreErr != nil {
+			return fmt.Errorf("failed to install %s: %w (the previous binary is at %s)", exe, err, old)
+		}
+		return fmt.Errorf("failed to install %s: %w", exe, err)
+	}
+	// Windows cannot remove a running executable; the next update does
+	_ = os.Remove(old)
+	return nil
+}
+
+// packageManagers maps path fragments of package manager installs to the
+// command that upgrades skillsync there.
+var packageManagers = []struct {
+	fragment string
+	name     string
+	upgrade  string
+}{
+	{fragment: "/Cellar/", name: "Homebrew", upgrade: "brew upgrade skillsync"},
+	{fragment: "/homebrew/", name: "Homebrew", upgrade: "brew upgrade skillsync"},
+	{fragment: "/linuxbrew/", name: "Homebrew", upgrade: "brew upgrade skillsync"},
+	{fragment: "/scoop/apps/", name: "Scoop", upgrade: "scoop update skillsync"},
+}
+
+// ManagedBy returns the package manager that installed exe, and the command
+// that upgrades it, or empty strings when exe was installed by hand.
+func ManagedBy(exe string) (name, upgrade string) {
+	// Backslashes too, so Windows paths match wherever they are checked
+	slashed := strings.ReplaceAll(exe, `\`, "/")
+	for _, pm := range packageManagers {
+		if strings.Contains(slashed, pm.fragment) {
+			return pm.name, pm.upgrade
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,269 @@
+// Package selfupdate finds skillsync releases on GitHub and replaces the
+// running binary with a verified release build.
+//
+// Releases follow the GoReleaser layout: one archive per platform named
+// skillsync_<version>_<os>_<arch>.tar.gz (.zip on Windows), and a
+// checksums file listing the SHA-256 of every archive. When a release key is
+// given, the checksums file must carry an Ed25519 signature in an asset of
+// the same name with a .sig suffix, holding the base64 signature.
+package selfupdate
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the GitHub API the releases are read from.
+const DefaultAPIURL = "https://api.github.com"
+
+// DefaultRepo is the repository skillsync is released from.
+const DefaultRepo = "klauern/skillsync"
+
+// ProgramName is the name of the binary in the release archives.
+const ProgramName = "skillsync"
+
+// requestTimeout bounds each request, including downloads.
+const requestTimeout = 2 * time.Minute
+
+// maxDownloadSize caps the size of a downloaded asset.
+const maxDownloadSize = 256 << 20
+
+// Channel selects which releases are considered.
+type Channel string
+
+const (
+	// ChannelStable considers only full releases.
+	ChannelStable Channel = "stable"
+	// ChannelPrerelease also considers prereleases.
+	ChannelPrerelease Channel = "prerelease"
+)
+
+// ParseChannel parses a release channel name.
+func ParseChannel(s string) (Channel, error) {
+	switch Channel(strings.ToLower(strings.TrimSpace(s))) {
+	case ChannelStable, "":
+		return ChannelStable, nil
+	case ChannelPrerelease:
+		return ChannelPrerelease, nil
+	default:
+		return "", fmt.Errorf("unknown release channel %q (valid: stable, prerelease)", s)
+	}
+}
+
+var (
+	// ErrNoRelease is returned when the channel has no release.
+	ErrNoRelease = errors.New("no release found")
+	// ErrNoAsset is returned when a release has no archive for the platform.
+	ErrNoAsset = errors.New("release has no archive for this platform")
+	// ErrChecksumMismatch is returned when an archive does not match the
+	// checksum published with the release.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrBadSignature is returned when the checksums file is unsigned or its
+	// signature does not verify against the release key.
+	ErrBadSignature = errors.New("release signature verification failed")
+)
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is a GitHub release.
+type Release struct {
+	Tag        string  `json:"tag_name"`
+	Name       string  `json:"name"`
+	URL        string  `json:"html_url"`
+	Draft      bool    `json:"draft"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Version returns the release version without the "v" tag prefix.
+func (r Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// asset returns the asset with the given name.
+func (r Release) asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// checksumsAsset returns the checksums file of the release.
+func (r Release) checksumsAsset() (Asset, bool) {
+	for _, asset := range r.Assets {
+		if strings.HasSuffix(asset.Name, "checksums.txt") {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Client reads releases from GitHub.
+type Client struct {
+	HTTP   *http.Client
+	APIURL string
+	Repo   string
+}
+
+// NewClient returns a client for the skillsync releases.
+func NewClient() *Client {
+	return &Client{
+		HTTP:   &http.Client{Timeout: requestTimeout},
+		APIURL: DefaultAPIURL,
+		Repo:   DefaultRepo,
+	}
+}
+
+// Latest returns the newest release on the channel. Drafts are skipped, and
+// so are prereleases on the stable channel.
+func (c *Client) Latest(ctx context.Context, channel Channel) (*Release, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/releases?per_page=30", strings.TrimSuffix(c.APIURL, "/"), c.Repo)
+	body, err := c.get(ctx, endpoint, "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	var releases []Release
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	var latest *Release
+	for i, release := range releases {
+		if release.Draft || (release.Prerelease && channel != ChannelPrerelease) {
+			continue
+		}
+		if _, ok := parseVersion(release.Version()); !ok {
+			continue
+		}
+		if latest == nil || Newer(release.Version(), latest.Version()) {
+			latest = &releases[i]
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("%w on the %s channel of %s", ErrNoRelease, channel, c.Repo)
+	}
+	return latest, nil
+}
+
+// ArchiveName returns the name of the release archive for a platform.
+func ArchiveName(version, goos, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s%s", ProgramName, version, goos, goarch, ext)
+}
+
+// Download fetches the release archive for goos and goarch, verifies it,
+// and returns the skillsync binary inside. The archive must match the
+// release checksums; when publicKey is set, the checksums must also carry a
+// valid signature by it.
+func (c *Client) Download(ctx context.Context, release *Release, goos, goarch string, publicKey ed25519.PublicKey) ([]byte, error) {
+	name := ArchiveName(release.Version(), goos, goarch)
+	archiveAsset, ok := release.asset(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s has no %s", ErrNoAsset, ProgramName, release.Version(), name)
+	}
+	checksumsAsset, ok := release.checksumsAsset()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s publishes no checksums", ErrChecksumMismatch, ProgramName, release.Version())
+	}
+
+	checksums, err := c.get(ctx, checksumsAsset.URL, "")
+	if err != nil {
+		return nil, err
+	}
+	if publicKey != nil {
+		if err := c.verifySignature(ctx, release, checksumsAsset, checksums, publicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	archive, err := c.get(ctx, archiveAsset.URL, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksum(checksums, name, archive); err != nil {
+		return nil, err
+	}
+	return extractBinary(archive, name, goos)
+}
+
+// verifySignature checks the signature of the checksums file.
+func (c *Client) verifySignature(ctx context.Context, release *Release, checksumsAsset Asset, checksums []byte, publicKey ed25519.PublicKey) error {
+	sigAsset, ok := release.asset(checksumsAsset.Name + ".sig")
+	if !ok {
+		return fmt.Errorf("%w: %s is not signed", ErrBadSignature, checksumsAsset.Name)
+	}
+	encoded, err := c.get(ctx, sigAsset.URL, "")
+	if err != nil {
+		return err
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil || !ed25519.Verify(publicKey, checksums, signature) {
+		return fmt.Errorf("%w: %s", ErrBadSignature, sigAsset.Name)
+	}
+	return nil
+}
+
+// ParsePublicKey decodes a base64 Ed25519 public key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid release key: want a base64 Ed25519 public key")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// get downloads a URL, refusing responses larger than maxDownloadSize.
+func (c *Client) get(ctx context.Context, rawURL, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", redact(rawURL), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", redact(rawURL), resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", redact(rawURL), err)
+	}
+	if len(body) > maxDownloadSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", redact(rawURL), maxDownloadSize)
+	}
+	return body, nil
+}
+
+// redact drops the query of a URL, which may carry tokens, for messages.
+func redact(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.RawQuery = ""
+	return u.String()
+}
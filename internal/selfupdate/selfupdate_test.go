@@ -0,0 +1,231 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// tarGz builds a release archive holding files.
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	util.AssertNoError(t, tw.Close())
+	util.AssertNoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// releaseServer serves releases and their assets, each asset at /assets/<name>.
+func releaseServer(t *testing.T, releases []Release, assets map[string][]byte) *Client {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/"+DefaultRepo+"/releases" {
+			for i := range releases {
+				for j := range releases[i].Assets {
+					releases[i].Assets[j].URL = server.URL + "/assets/" + releases[i].Assets[j].Name
+				}
+			}
+			_ = json.NewEncoder(w).Encode(releases)
+			return
+		}
+		data, ok := assets[filepath.Base(r.URL.Path)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return &Client{HTTP: server.Client(), APIURL: server.URL, Repo: DefaultRepo}
+}
+
+func TestLatest(t *testing.T) {
+	releases := []Release{
+		{Tag: "v1.3.0-rc.1", Prerelease: true},
+		{Tag: "v2.0.0", Draft: true},
+		{Tag: "v1.2.0"},
+		{Tag: "v1.10.0"},
+		{Tag: "nightly"},
+	}
+
+	tests := map[string]struct {
+		channel Channel
+		want    string
+	}{
+		"stable skips drafts and prereleases": {channel: ChannelStable, want: "1.10.0"},
+		"prerelease":                          {channel: ChannelPrerelease, want: "1.10.0"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			release, err := releaseServer(t, releases, nil).Latest(context.Background(), tt.channel)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, release.Version(), tt.want)
+		})
+	}
+
+	t.Run("prerelease newer than stable", func(t *testing.T) {
+		client := releaseServer(t, []Release{{Tag: "v1.2.0"}, {Tag: "v1.3.0-rc.2", Prerelease: true}}, nil)
+		release, err := client.Latest(context.Background(), ChannelPrerelease)
+		util.AssertNoError(t, err)
+		util.AssertEqual(t, release.Version(), "1.3.0-rc.2")
+	})
+
+	t.Run("no release", func(t *testing.T) {
+		_, err := releaseServer(t, []Release{{Tag: "v1.3.0-rc.1", Prerelease: true}}, nil).Latest(context.Background(), ChannelStable)
+		if !errors.Is(err, ErrNoRelease) {
+			t.Errorf("Latest() error = %v, want ErrNoRelease", err)
+		}
+	})
+}
+
+func TestNewer(t *testing.T) {
+	tests := map[string]struct {
+		candidate, current string
+		want               bool
+	}{
+		"patch":                         {candidate: "1.2.4", current: "1.2.3", want: true},
+		"numeric not lexical":           {candidate: "1.10.0", current: "1.9.0", want: true},
+		"same":                          {candidate: "v1.2.3", current: "1.2.3"},
+		"older":                         {candidate: "1.2.2", current: "1.2.3"},
+		"release after its prerelease":  {candidate: "1.3.0", current: "1.3.0-rc.1", want: true},
+		"prerelease before its release": {candidate: "1.3.0-rc.1", current: "1.3.0"},
+		"prerelease numbers":            {candidate: "1.3.0-rc.10", current: "1.3.0-rc.9", want: true},
+		"development build":             {candidate: "1.2.3", current: "dev"},
+		"invalid candidate":             {candidate: "nightly", current: "1.2.3"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, Newer(tt.candidate, tt.current), tt.want)
+		})
+	}
+}
+
+func TestDownload(t *testing.T) {
+	archiveName := ArchiveName("1.2.0", "linux", "amd64")
+	archive := tarGz(t, map[string]string{"README.md": "docs", "skillsync": "new binary"})
+	sum := sha256.Sum256(archive)
+	checksums := []byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), archiveName))
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	util.AssertNoError(t, err)
+	signature := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, checksums)))
+	otherKey, _, err := ed25519.GenerateKey(nil)
+	util.AssertNoError(t, err)
+
+	release := func(names ...string) Release {
+		r := Release{Tag: "v1.2.0"}
+		for _, name := range names {
+			r.Assets = append(r.Assets, Asset{Name: name})
+		}
+		return r
+	}
+	signed := release(archiveName, "skillsync_1.2.0_checksums.txt", "skillsync_1.2.0_checksums.txt.sig")
+	unsigned := release(archiveName, "skillsync_1.2.0_checksums.txt")
+	assets := map[string][]byte{
+		archiveName:                         archive,
+		"skillsync_1.2.0_checksums.txt":     checksums,
+		"skillsync_1.2.0_checksums.txt.sig": signature,
+	}
+
+	tests := map[string]struct {
+		release   Release
+		assets    map[string][]byte
+		publicKey ed25519.PublicKey
+		goarch    string
+		wantErr   error
+	}{
+		"checksum only":     {release: unsigned, assets: assets},
+		"signed":            {release: signed, assets: assets, publicKey: publicKey},
+		"wrong key":         {release: signed, assets: assets, publicKey: otherKey, wantErr: ErrBadSignature},
+		"unsigned with key": {release: unsigned, assets: assets, publicKey: publicKey, wantErr: ErrBadSignature},
+		"tampered archive": {
+			release: unsigned,
+			assets:  map[string][]byte{archiveName: tarGz(t, map[string]string{"skillsync": "evil"}), "skillsync_1.2.0_checksums.txt": checksums},
+			wantErr: ErrChecksumMismatch,
+		},
+		"no archive for the platform": {release: unsigned, assets: assets, goarch: "riscv64", wantErr: ErrNoAsset},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := releaseServer(t, []Release{tt.release}, tt.assets)
+			latest, err := client.Latest(context.Background(), ChannelStable)
+			util.AssertNoError(t, err)
+
+			goarch := tt.goarch
+			if goarch == "" {
+				goarch = "amd64"
+			}
+			binary, err := client.Download(context.Background(), latest, "linux", goarch, tt.publicKey)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Download() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, string(binary), "new binary")
+		})
+	}
+}
+
+func TestReplace(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "skillsync")
+	util.AssertNoError(t, os.WriteFile(exe, []byte("old binary"), 0o755))
+
+	util.AssertNoError(t, Replace(exe, []byte("new binary")))
+
+	data, err := os.ReadFile(exe)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), "new binary")
+	info, err := os.Stat(exe)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, info.Mode().Perm(), os.FileMode(0o755))
+	entries, err := os.ReadDir(filepath.Dir(exe))
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(entries), 1)
+}
+
+func TestManagedBy(t *testing.T) {
+	tests := map[string]struct {
+		exe  string
+		want string
+	}{
+		"homebrew cellar": {exe: "/usr/local/Cellar/skillsync/1.2.0/bin/skillsync", want: "Homebrew"},
+		"homebrew prefix": {exe: "/opt/homebrew/bin/skillsync", want: "Homebrew"},
+		"scoop":           {exe: `C:\Users\me\scoop\apps\skillsync\current\skillsync.exe`, want: "Scoop"},
+		"manual":          {exe: "/home/me/bin/skillsync"},
+		"go install":      {exe: "/home/me/go/bin/skillsync"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, _ := ManagedBy(tt.exe)
+			util.AssertEqual(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package selfupdate
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+)
+
+// version is a semantic version.
+type version struct {
+	core       [3]int
+	prerelease []string
+}
+
+// parseVersion parses a semantic version such as 1.2.3 or v1.3.0-rc.1.
+// Build metadata is ignored.
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, hasPre := strings.Cut(s, "-")
+
+	var v version
+	parts := strings.Split(core, ".")
+	if len(parts) != len(v.core) {
+		return version{}, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		v.core[i] = n
+	}
+	if hasPre {
+		if pre == "" {
+			return version{}, false
+		}
+		v.prerelease = strings.Split(pre, ".")
+	}
+	return v, true
+}
+
+// compare orders versions by semantic version precedence.
+func (v version) compare(other version) int {
+	for i := range v.core {
+		if c := cmp.Compare(v.core[i], other.core[i]); c != 0 {
+			return c
+		}
+	}
+	// A release sorts after its prereleases
+	switch {
+	case len(v.prerelease) == 0 && len(other.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(other.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		if c := comparePrereleaseIdentifier(v.prerelease[i], other.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(v.prerelease), len(other.prerelease))
+}
+
+// comparePrereleaseIdentifier compares numeric identifiers numerically,
+// which sort before alphanumeric ones, compared as strings.
+func comparePrereleaseIdentifier(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmp.Compare(an, bn)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// Newer reports whether candidate is a newer version than current. A
+// current version that is not a semantic version, such as a development
+// build, is never older.
+func Newer(candidate, current string) bool {
+	c, ok := parseVersion(candidate)
+	if !ok {
+		return false
+	}
+	v, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	return c.compare(v) > 0
+}
+
+// IsRelease reports whether version is a semantic version, as release
+// builds are, rather than a development build.
+func IsRelease(v string) bool {
+	_, ok := parseVersion(v)
+	return ok
+}
@@ -3,7 +3,9 @@ package similarity
 
 import (
 	"log/slog"
+	"runtime"
 	"strings"
+	gosync "sync"
 
 	"github.com/klauern/skillsync/internal/logging"
 	"github.com/klauern/skillsync/internal/model"
@@ -31,6 +33,13 @@ type ContentMatcherConfig struct {
 	// LineMode enables line-based comparison instead of character-based.
 	// Default: true
 	LineMode bool
+	// Workers is the number of goroutines FindSimilar compares pairs with.
+	// Default: runtime.GOMAXPROCS(0)
+	Workers int
+	// DisablePreFilter makes FindSimilar compare every pair in full instead
+	// of skipping pairs that cannot reach the threshold.
+	// Default: false
+	DisablePreFilter bool
 }
 
 // DefaultContentMatcherConfig returns sensible defaults for content matching.
@@ -63,6 +72,9 @@ func NewContentMatcher(config ContentMatcherConfig) *ContentMatcher {
 }
 
 // FindSimilar finds all pairs of skills with similar content above the threshold.
+// Pairs are compared concurrently, and pairs that cannot reach the threshold
+// are skipped before the full comparison (see preFilter). Matches are returned
+// in the same order as a serial comparison of every pair.
 func (m *ContentMatcher) FindSimilar(skills []model.Skill) []ContentMatch {
 	logging.Debug("finding similar skill content",
 		logging.Operation("content_similarity"),
@@ -71,28 +83,39 @@ func (m *ContentMatcher) FindSimilar(skills []model.Skill) []ContentMatch {
 		slog.String("algorithm", m.config.Algorithm),
 	)
 
-	var matches []ContentMatch
+	useMinHash := !m.config.DisablePreFilter && len(skills) >= minHashMinSkills
+	prepared := make([]*preparedContent, len(skills))
+	for i, skill := range skills {
+		prepared[i] = m.prepare(skill.Content, useMinHash)
+	}
+
+	workers := m.config.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
 
-	// Compare all pairs (O(n^2) but typically small number of skills)
-	for i := range len(skills) {
-		skillI := skills[i]
-		for j := i + 1; j < len(skills); j++ {
-			skillJ := skills[j]
-			score := m.Compare(skillI.Content, skillJ.Content)
-			if score >= m.config.Threshold {
-				matches = append(matches, ContentMatch{
-					Skill1:    skillI,
-					Skill2:    skillJ,
-					Score:     score,
-					Algorithm: m.config.Algorithm,
-				})
-				logging.Debug("found similar content",
-					slog.String("name1", skillI.Name),
-					slog.String("name2", skillJ.Name),
-					slog.Float64("score", score),
-				)
+	// Each row i holds the matches of skill i with the skills after it
+	rows := make([][]ContentMatch, len(skills))
+	next := make(chan int)
+	var wg gosync.WaitGroup
+	for range min(workers, max(len(skills), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				rows[i] = m.compareRow(skills, prepared, i)
 			}
-		}
+		}()
+	}
+	for i := range skills {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var matches []ContentMatch
+	for _, row := range rows {
+		matches = append(matches, row...)
 	}
 
 	logging.Debug("content similarity search complete",
@@ -103,47 +126,137 @@ func (m *ContentMatcher) FindSimilar(skills []model.Skill) []ContentMatch {
 	return matches
 }
 
+// compareRow compares skill i with every later skill.
+func (m *ContentMatcher) compareRow(skills []model.Skill, prepared []*preparedContent, i int) []ContentMatch {
+	var matches []ContentMatch
+	for j := i + 1; j < len(skills); j++ {
+		if !m.config.DisablePreFilter && !m.preFilter(prepared[i], prepared[j]) {
+			continue
+		}
+		score := m.comparePrepared(prepared[i], prepared[j])
+		if score >= m.config.Threshold {
+			matches = append(matches, ContentMatch{
+				Skill1:    skills[i],
+				Skill2:    skills[j],
+				Score:     score,
+				Algorithm: m.config.Algorithm,
+			})
+			logging.Debug("found similar content",
+				slog.String("name1", skills[i].Name),
+				slog.String("name2", skills[j].Name),
+				slog.Float64("score", score),
+			)
+		}
+	}
+	return matches
+}
+
 // Compare returns the similarity score between two content strings (0.0-1.0).
 func (m *ContentMatcher) Compare(content1, content2 string) float64 {
+	return m.comparePrepared(m.prepare(content1, false), m.prepare(content2, false))
+}
+
+// preparedContent holds the sequences and token sets of a skill's content,
+// computed once per skill rather than once per pair.
+type preparedContent struct {
+	content string
+	// seq is the LCS sequence: lines, or characters outside line mode
+	seq []string
+	// tokens is the Jaccard set: trimmed lines, or character n-grams
+	tokens map[string]struct{}
+	// signature is a MinHash signature of word shingles, set only when the
+	// MinHash pre-filter is in use
+	signature []uint64
+}
+
+func (m *ContentMatcher) prepare(content string, withSignature bool) *preparedContent {
+	p := &preparedContent{content: content}
+	if m.config.LineMode {
+		p.seq = strings.Split(content, "\n")
+		p.tokens = tokenSet(p.seq)
+	} else {
+		// Character mode: split into individual characters
+		p.seq = strings.Split(content, "")
+		p.tokens = generateNGrams(content, m.config.NGramSize)
+	}
+	if withSignature {
+		p.signature = minHashSignature(wordShingles(content, shingleSize))
+	}
+	return p
+}
+
+func (m *ContentMatcher) comparePrepared(a, b *preparedContent) float64 {
 	// Early exit for exact matches
-	if content1 == content2 {
+	if a.content == b.content {
 		return 1.0
 	}
 
 	// Early exit for empty strings
-	if len(content1) == 0 || len(content2) == 0 {
+	if len(a.content) == 0 || len(b.content) == 0 {
 		return 0.0
 	}
 
 	switch m.config.Algorithm {
 	case "lcs":
-		return m.lcsSimilarity(content1, content2)
+		return lcsRatio(a.seq, b.seq)
 	case "jaccard":
-		return m.jaccardSimilarity(content1, content2)
+		return jaccardIndex(a.tokens, b.tokens)
 	case "combined":
 		// Use the higher of the two scores
-		lcs := m.lcsSimilarity(content1, content2)
-		jaccard := m.jaccardSimilarity(content1, content2)
+		lcs := lcsRatio(a.seq, b.seq)
+		jaccard := jaccardIndex(a.tokens, b.tokens)
 		return max(lcs, jaccard)
 	default:
-		return m.lcsSimilarity(content1, content2)
+		return lcsRatio(a.seq, b.seq)
 	}
 }
 
-// lcsSimilarity calculates similarity based on Longest Common Subsequence.
-// Returns the ratio of LCS length to the maximum content length.
-func (m *ContentMatcher) lcsSimilarity(content1, content2 string) float64 {
-	var seq1, seq2 []string
+// preFilter reports whether a pair may reach the threshold and needs a full
+// comparison. The size check is exact: neither metric can exceed the ratio
+// of the shorter sequence or set to the longer. For large skill sets the
+// MinHash check is an estimate that skips pairs sharing almost no word
+// shingles, with a wide margin below the threshold.
+func (m *ContentMatcher) preFilter(a, b *preparedContent) bool {
+	if a.content == b.content {
+		return true
+	}
 
-	if m.config.LineMode {
-		seq1 = strings.Split(content1, "\n")
-		seq2 = strings.Split(content2, "\n")
-	} else {
-		// Character mode: split into individual characters
-		seq1 = strings.Split(content1, "")
-		seq2 = strings.Split(content2, "")
+	var bound float64
+	switch m.config.Algorithm {
+	case "jaccard":
+		bound = sizeRatio(len(a.tokens), len(b.tokens))
+	case "combined":
+		bound = max(sizeRatio(len(a.seq), len(b.seq)), sizeRatio(len(a.tokens), len(b.tokens)))
+	default:
+		bound = sizeRatio(len(a.seq), len(b.seq))
+	}
+	if bound < m.config.Threshold {
+		return false
 	}
 
+	if a.signature != nil && b.signature != nil {
+		// A line overlap of t implies a shingle Jaccard of about t/(2-t);
+		// halve it to stay clear of estimation error
+		cutoff := m.config.Threshold / (2 - m.config.Threshold) / 2
+		if estimateJaccard(a.signature, b.signature) < cutoff {
+			return false
+		}
+	}
+	return true
+}
+
+// sizeRatio returns the ratio of the smaller size to the larger, or 1 if
+// both are zero.
+func sizeRatio(a, b int) float64 {
+	if a == 0 && b == 0 {
+		return 1.0
+	}
+	return float64(min(a, b)) / float64(max(a, b))
+}
+
+// lcsRatio calculates similarity based on Longest Common Subsequence.
+// Returns the ratio of LCS length to the maximum sequence length.
+func lcsRatio(seq1, seq2 []string) float64 {
 	lcsLen := longestCommonSubsequenceLength(seq1, seq2)
 	maxLen := max(len(seq1), len(seq2))
 
@@ -186,24 +299,6 @@ func longestCommonSubsequenceLength(source, target []string) int {
 	return prev[n]
 }
 
-// jaccardSimilarity calculates Jaccard similarity using n-grams.
-// Returns the size of intersection divided by size of union of n-gram sets.
-func (m *ContentMatcher) jaccardSimilarity(content1, content2 string) float64 {
-	var ngrams1, ngrams2 map[string]struct{}
-
-	if m.config.LineMode {
-		// In line mode, use lines as tokens directly
-		ngrams1 = tokenSet(strings.Split(content1, "\n"))
-		ngrams2 = tokenSet(strings.Split(content2, "\n"))
-	} else {
-		// In character mode, use character n-grams
-		ngrams1 = generateNGrams(content1, m.config.NGramSize)
-		ngrams2 = generateNGrams(content2, m.config.NGramSize)
-	}
-
-	return jaccardIndex(ngrams1, ngrams2)
-}
-
 // generateNGrams creates a set of character n-grams from a string.
 func generateNGrams(s string, n int) map[string]struct{} {
 	ngrams := make(map[string]struct{})
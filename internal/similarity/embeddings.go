@@ -0,0 +1,343 @@
+package similarity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// Embedding providers.
+const (
+	// ProviderOpenAI calls an OpenAI-compatible /embeddings endpoint.
+	ProviderOpenAI = "openai"
+	// ProviderCommand runs a local program.
+	ProviderCommand = "command"
+)
+
+const (
+	// DefaultEmbeddingURL is the API base URL used by the openai provider.
+	DefaultEmbeddingURL = "https://api.openai.com/v1"
+	// DefaultEmbeddingModel is the model requested by the openai provider.
+	DefaultEmbeddingModel = "text-embedding-3-small"
+	// DefaultEmbeddingThreshold is the minimum cosine similarity for a match.
+	DefaultEmbeddingThreshold = 0.85
+
+	// embeddingBatchSize caps the number of texts sent in one request.
+	embeddingBatchSize = 64
+	// maxEmbeddingInput caps the characters of a skill sent for embedding,
+	// keeping long skills within typical model context limits.
+	maxEmbeddingInput = 8000
+	// embeddingTimeout bounds a single embedding request.
+	embeddingTimeout = 60 * time.Second
+)
+
+// Embedder turns texts into embedding vectors, one per text in order.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// EmbedderConfig configures an embeddings backend.
+type EmbedderConfig struct {
+	// Provider selects the backend: "openai" or "command"
+	Provider string
+	// URL is the API base URL for the openai provider
+	URL string
+	// Model is the embedding model for the openai provider
+	Model string
+	// APIKey is sent as a bearer token when set
+	APIKey string
+	// Command is the program run by the command provider
+	Command []string
+}
+
+// NewEmbedder creates the embeddings backend selected by cfg. It returns nil
+// without an error when no provider is configured.
+func NewEmbedder(cfg EmbedderConfig) (Embedder, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "":
+		return nil, nil
+	case ProviderOpenAI:
+		if cfg.URL == "" {
+			cfg.URL = DefaultEmbeddingURL
+		}
+		if cfg.Model == "" {
+			cfg.Model = DefaultEmbeddingModel
+		}
+		return &APIEmbedder{
+			url:    strings.TrimSuffix(cfg.URL, "/") + "/embeddings",
+			model:  cfg.Model,
+			apiKey: cfg.APIKey,
+			client: &http.Client{Timeout: embeddingTimeout},
+		}, nil
+	case ProviderCommand:
+		if len(cfg.Command) == 0 {
+			return nil, errors.New("embeddings provider \"command\" requires a command")
+		}
+		return &CommandEmbedder{command: cfg.Command}, nil
+	default:
+		return nil, fmt.Errorf("unknown embeddings provider %q (use openai or command)", cfg.Provider)
+	}
+}
+
+// APIEmbedder calls an OpenAI-compatible embeddings API.
+type APIEmbedder struct {
+	url    string
+	model  string
+	apiKey string
+	client *http.Client
+}
+
+// Embed requests embeddings for texts in batches.
+func (e *APIEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += embeddingBatchSize {
+		end := min(start+embeddingBatchSize, len(texts))
+		batch, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+func (e *APIEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	body, err := json.Marshal(map[string]any{"model": e.model, "input": texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embeddings response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embeddings request failed: %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	var parsed struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse embeddings response: %w", err)
+	}
+	if len(parsed.Data) != len(texts) {
+		return nil, fmt.Errorf("embeddings response has %d vectors for %d inputs", len(parsed.Data), len(texts))
+	}
+
+	vectors := make([][]float64, len(texts))
+	for _, d := range parsed.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embeddings response has out of range index %d", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
+
+// CommandEmbedder runs a local program that reads a JSON array of texts on
+// stdin and prints a JSON array of vectors on stdout.
+type CommandEmbedder struct {
+	command []string
+}
+
+// Embed runs the command once for all texts.
+func (e *CommandEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	input, err := json.Marshal(texts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, embeddingTimeout)
+	defer cancel()
+	// #nosec G204 - the command comes from the user's config
+	cmd := exec.CommandContext(ctx, e.command[0], e.command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("embeddings command %s failed: %w", e.command[0], err)
+	}
+
+	var vectors [][]float64
+	if err := json.Unmarshal(output, &vectors); err != nil {
+		return nil, fmt.Errorf("failed to parse embeddings command output: %w", err)
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("embeddings command returned %d vectors for %d inputs", len(vectors), len(texts))
+	}
+	return vectors, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between two vectors,
+// clamped to 0.0-1.0. Vectors of different lengths or zero vectors score 0.
+func CosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0.0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0.0
+	}
+	return max(0.0, min(1.0, dot/(math.Sqrt(normA)*math.Sqrt(normB))))
+}
+
+// ContentScorer finds and scores skills with similar content. It is
+// implemented by ContentMatcher and SemanticMatcher.
+type ContentScorer interface {
+	FindSimilar(skills []model.Skill) []ContentMatch
+	Compare(content1, content2 string) float64
+}
+
+// SemanticMatcher finds skills with similar meaning by comparing content
+// embeddings. If the backend fails, it warns once and falls back to the
+// string metrics of its fallback matcher for the rest of its lifetime.
+type SemanticMatcher struct {
+	embedder  Embedder
+	threshold float64
+	fallback  *ContentMatcher
+	cache     map[string][]float64
+	failed    bool
+}
+
+// NewSemanticMatcher creates a matcher that uses embedder, falling back to
+// fallback when the backend is unavailable. A threshold outside 0.0-1.0
+// uses DefaultEmbeddingThreshold.
+func NewSemanticMatcher(embedder Embedder, threshold float64, fallback *ContentMatcher) *SemanticMatcher {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultEmbeddingThreshold
+	}
+	return &SemanticMatcher{
+		embedder:  embedder,
+		threshold: threshold,
+		fallback:  fallback,
+		cache:     make(map[string][]float64),
+	}
+}
+
+// FindSimilar finds all pairs of skills whose content embeddings are at
+// least the threshold apart in cosine similarity.
+func (m *SemanticMatcher) FindSimilar(skills []model.Skill) []ContentMatch {
+	contents := make([]string, len(skills))
+	for i, skill := range skills {
+		contents[i] = skill.Content
+	}
+	if !m.embed(contents) {
+		return m.fallback.FindSimilar(skills)
+	}
+
+	var matches []ContentMatch
+	for i := range len(skills) {
+		for j := i + 1; j < len(skills); j++ {
+			score := m.Compare(skills[i].Content, skills[j].Content)
+			if score >= m.threshold {
+				matches = append(matches, ContentMatch{
+					Skill1:    skills[i],
+					Skill2:    skills[j],
+					Score:     score,
+					Algorithm: "embedding",
+				})
+			}
+		}
+	}
+
+	logging.Debug("semantic similarity search complete",
+		logging.Operation("content_similarity"),
+		slog.Int("matches_found", len(matches)),
+	)
+	return matches
+}
+
+// Compare returns the cosine similarity of the embeddings of two content
+// strings, or the fallback score if they cannot be embedded.
+func (m *SemanticMatcher) Compare(content1, content2 string) float64 {
+	if content1 == content2 {
+		return 1.0
+	}
+	if content1 == "" || content2 == "" {
+		return 0.0
+	}
+	if !m.embed([]string{content1, content2}) {
+		return m.fallback.Compare(content1, content2)
+	}
+	return CosineSimilarity(m.cache[embeddingInput(content1)], m.cache[embeddingInput(content2)])
+}
+
+// embed fetches embeddings for contents not already cached. It reports
+// whether every content has an embedding.
+func (m *SemanticMatcher) embed(contents []string) bool {
+	if m.failed {
+		return false
+	}
+
+	var missing []string
+	seen := make(map[string]bool)
+	for _, content := range contents {
+		input := embeddingInput(content)
+		if input == "" || seen[input] {
+			continue
+		}
+		if _, ok := m.cache[input]; !ok {
+			seen[input] = true
+			missing = append(missing, input)
+		}
+	}
+	if len(missing) == 0 {
+		return true
+	}
+
+	vectors, err := m.embedder.Embed(context.Background(), missing)
+	if err != nil {
+		m.failed = true
+		logging.Warn("embeddings backend unavailable, using string similarity", logging.Err(err))
+		return false
+	}
+	for i, input := range missing {
+		m.cache[input] = vectors[i]
+	}
+	return true
+}
+
+// embeddingInput returns the text embedded for content.
+func embeddingInput(content string) string {
+	content = strings.TrimSpace(content)
+	if runes := []rune(content); len(runes) > maxEmbeddingInput {
+		content = string(runes[:maxEmbeddingInput])
+	}
+	return content
+}
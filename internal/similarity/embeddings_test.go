@@ -0,0 +1,177 @@
+package similarity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// fakeEmbedder returns fixed vectors by text and counts calls.
+type fakeEmbedder struct {
+	vectors map[string][]float64
+	err     error
+	calls   int
+}
+
+func (f *fakeEmbedder) Embed(_ context.Context, texts []string) ([][]float64, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = f.vectors[text]
+	}
+	return vectors, nil
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := map[string]struct {
+		a, b []float64
+		want float64
+	}{
+		"identical":        {a: []float64{1, 2, 3}, b: []float64{1, 2, 3}, want: 1},
+		"scaled":           {a: []float64{1, 0}, b: []float64{5, 0}, want: 1},
+		"orthogonal":       {a: []float64{1, 0}, b: []float64{0, 1}, want: 0},
+		"opposite clamped": {a: []float64{1, 0}, b: []float64{-1, 0}, want: 0},
+		"length mismatch":  {a: []float64{1, 0}, b: []float64{1}, want: 0},
+		"zero vector":      {a: []float64{0, 0}, b: []float64{1, 0}, want: 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := CosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("CosineSimilarity() = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEmbedder(t *testing.T) {
+	tests := map[string]struct {
+		cfg     EmbedderConfig
+		wantNil bool
+		wantErr bool
+	}{
+		"no provider":          {cfg: EmbedderConfig{}, wantNil: true},
+		"openai":               {cfg: EmbedderConfig{Provider: "openai"}},
+		"command":              {cfg: EmbedderConfig{Provider: "command", Command: []string{"embed"}}},
+		"command without argv": {cfg: EmbedderConfig{Provider: "command"}, wantErr: true},
+		"unknown provider":     {cfg: EmbedderConfig{Provider: "magic"}, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			embedder, err := NewEmbedder(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewEmbedder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (embedder == nil) != tt.wantNil {
+				t.Errorf("NewEmbedder() = %v, wantNil %v", embedder, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestAPIEmbedder_Embed(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if r.URL.Path != "/v1/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Answer out of order to check vectors are placed by index
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		}
+		var data []item
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			data = append(data, item{Index: i, Embedding: []float64{float64(len(req.Input[i]))}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer server.Close()
+
+	embedder, err := NewEmbedder(EmbedderConfig{Provider: "openai", URL: server.URL + "/v1/", APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("NewEmbedder() error = %v", err)
+	}
+	vectors, err := embedder.Embed(context.Background(), []string{"a", "bbb"})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(vectors) != 2 || vectors[0][0] != 1 || vectors[1][0] != 3 {
+		t.Errorf("Embed() = %v, want [[1] [3]]", vectors)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want bearer token", gotAuth)
+	}
+}
+
+func TestSemanticMatcher_FindSimilar(t *testing.T) {
+	skills := []model.Skill{
+		{Name: "review", Content: "Review the diff for bugs."},
+		{Name: "critique", Content: "Look over the changes and find defects."},
+		{Name: "deploy", Content: "Ship it to production."},
+	}
+	embedder := &fakeEmbedder{vectors: map[string][]float64{
+		"Review the diff for bugs.":               {1, 0.1, 0},
+		"Look over the changes and find defects.": {0.95, 0.15, 0},
+		"Ship it to production.":                  {0, 0, 1},
+	}}
+	matcher := NewSemanticMatcher(embedder, 0.9, NewContentMatcher(DefaultContentMatcherConfig()))
+
+	matches := matcher.FindSimilar(skills)
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %d: %+v", len(matches), matches)
+	}
+	if matches[0].Skill1.Name != "review" || matches[0].Skill2.Name != "critique" || matches[0].Algorithm != "embedding" {
+		t.Errorf("unexpected match: %+v", matches[0])
+	}
+
+	// Embeddings are cached, so scoring a known pair makes no request
+	calls := embedder.calls
+	if score := matcher.Compare(skills[0].Content, skills[2].Content); score != 0 {
+		t.Errorf("Compare() = %f, want 0", score)
+	}
+	if embedder.calls != calls {
+		t.Errorf("expected cached embeddings, got %d more call(s)", embedder.calls-calls)
+	}
+}
+
+func TestSemanticMatcher_FallsBackOnError(t *testing.T) {
+	skills := []model.Skill{
+		{Name: "a", Content: "line one\nline two"},
+		{Name: "b", Content: "line one\nline two\nline three"},
+	}
+	embedder := &fakeEmbedder{err: errors.New("connection refused")}
+	fallback := NewContentMatcher(ContentMatcherConfig{Threshold: 0.5, Algorithm: "lcs", LineMode: true})
+	matcher := NewSemanticMatcher(embedder, 0.99, fallback)
+
+	matches := matcher.FindSimilar(skills)
+	if len(matches) != 1 || matches[0].Algorithm != "lcs" {
+		t.Fatalf("expected string metric fallback match, got %+v", matches)
+	}
+
+	// The backend is not retried after a failure
+	matcher.Compare("x", "y")
+	if embedder.calls != 1 {
+		t.Errorf("expected 1 embedding call, got %d", embedder.calls)
+	}
+}
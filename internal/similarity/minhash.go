@@ -0,0 +1,83 @@
+package similarity
+
+import (
+	"hash/fnv"
+	"math"
+	"strings"
+)
+
+const (
+	// minHashMinSkills is the number of skills from which FindSimilar uses
+	// the MinHash pre-filter. Smaller sets are cheap enough to compare fully.
+	minHashMinSkills = 200
+	// minHashSize is the number of hash functions in a MinHash signature.
+	minHashSize = 64
+	// shingleSize is the number of words in a shingle.
+	shingleSize = 3
+)
+
+// wordShingles returns the set of hashed runs of size consecutive lowercase
+// words in content. Content with fewer words yields a single shingle.
+func wordShingles(content string, size int) map[uint64]struct{} {
+	words := strings.Fields(strings.ToLower(content))
+	shingles := make(map[uint64]struct{})
+	if len(words) == 0 {
+		return shingles
+	}
+	if len(words) < size {
+		size = len(words)
+	}
+
+	for i := 0; i+size <= len(words); i++ {
+		h := fnv.New64a()
+		for _, word := range words[i : i+size] {
+			_, _ = h.Write([]byte(word))
+			_, _ = h.Write([]byte{0})
+		}
+		shingles[h.Sum64()] = struct{}{}
+	}
+	return shingles
+}
+
+// minHashSignature returns the MinHash signature of a shingle set: for each
+// of minHashSize hash functions, the minimum hash over the set. The share of
+// equal positions in two signatures estimates the Jaccard index of the sets.
+func minHashSignature(shingles map[uint64]struct{}) []uint64 {
+	signature := make([]uint64, minHashSize)
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+	for shingle := range shingles {
+		for i := range signature {
+			if h := mix64(shingle ^ uint64(i+1)*0x9e3779b97f4a7c15); h < signature[i] {
+				signature[i] = h
+			}
+		}
+	}
+	return signature
+}
+
+// estimateJaccard returns the share of positions where two signatures agree.
+func estimateJaccard(a, b []uint64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0.0
+	}
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// mix64 is the splitmix64 finalizer, used to derive independent hash
+// functions from one shingle hash.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,129 @@
+package similarity
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func TestWordShingles(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    int
+	}{
+		"empty":            {content: "", want: 0},
+		"fewer than size":  {content: "two words", want: 1},
+		"sliding window":   {content: "a b c d e", want: 3},
+		"case insensitive": {content: "Run the Tests run the tests", want: 3},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := len(wordShingles(tt.content, shingleSize)); got != tt.want {
+				t.Errorf("wordShingles() returned %d shingles, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateJaccard(t *testing.T) {
+	words := make([]string, 400)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%d", i)
+	}
+	// Shingle sets sharing half their words overlap by about a third
+	a := wordShingles(strings.Join(words[:300], " "), shingleSize)
+	b := wordShingles(strings.Join(words[100:], " "), shingleSize)
+
+	intersection := 0
+	for s := range a {
+		if _, ok := b[s]; ok {
+			intersection++
+		}
+	}
+	exact := float64(intersection) / float64(len(a)+len(b)-intersection)
+
+	estimate := estimateJaccard(minHashSignature(a), minHashSignature(b))
+	if math.Abs(estimate-exact) > 0.2 {
+		t.Errorf("estimateJaccard() = %f, exact Jaccard %f", estimate, exact)
+	}
+	if got := estimateJaccard(minHashSignature(a), minHashSignature(a)); got != 1.0 {
+		t.Errorf("estimateJaccard() of identical sets = %f, want 1.0", got)
+	}
+}
+
+// generateSkills returns n skills in families of near-duplicates.
+func generateSkills(n int) []model.Skill {
+	skills := make([]model.Skill, n)
+	for i := range skills {
+		family := i / 4
+		var lines []string
+		for l := range 30 {
+			lines = append(lines, fmt.Sprintf("Family %d step %d: check the %d things carefully.", family, l, l*family))
+		}
+		// Members of a family differ in one line
+		lines[i%30] = fmt.Sprintf("Variant %d of this skill.", i)
+		skills[i] = model.Skill{Name: fmt.Sprintf("skill-%d", i), Content: strings.Join(lines, "\n")}
+	}
+	return skills
+}
+
+func TestContentMatcher_FindSimilar_PreFilterAndWorkers(t *testing.T) {
+	skills := generateSkills(minHashMinSkills + 40)
+
+	exhaustive := NewContentMatcher(ContentMatcherConfig{
+		Threshold:        0.8,
+		Algorithm:        "combined",
+		LineMode:         true,
+		Workers:          1,
+		DisablePreFilter: true,
+	}).FindSimilar(skills)
+	parallel := NewContentMatcher(ContentMatcherConfig{
+		Threshold: 0.8,
+		Algorithm: "combined",
+		LineMode:  true,
+		Workers:   8,
+	}).FindSimilar(skills)
+
+	if len(exhaustive) == 0 {
+		t.Fatal("expected matches within skill families")
+	}
+	if len(parallel) != len(exhaustive) {
+		t.Fatalf("pre-filtered parallel search found %d matches, exhaustive found %d", len(parallel), len(exhaustive))
+	}
+	for i := range exhaustive {
+		if parallel[i].Skill1.Name != exhaustive[i].Skill1.Name || parallel[i].Skill2.Name != exhaustive[i].Skill2.Name ||
+			parallel[i].Score != exhaustive[i].Score {
+			t.Errorf("match %d = %s/%s %f, want %s/%s %f", i,
+				parallel[i].Skill1.Name, parallel[i].Skill2.Name, parallel[i].Score,
+				exhaustive[i].Skill1.Name, exhaustive[i].Skill2.Name, exhaustive[i].Score)
+		}
+	}
+}
+
+func TestContentMatcher_PreFilter_SizeBound(t *testing.T) {
+	matcher := NewContentMatcher(ContentMatcherConfig{Threshold: 0.8, Algorithm: "lcs", LineMode: true})
+	short := matcher.prepare("a\nb", false)
+	long := matcher.prepare("a\nb\nc\nd\ne", false)
+
+	// At most 2 of 5 lines can be shared, so the pair cannot reach 0.8
+	if matcher.preFilter(short, long) {
+		t.Error("preFilter() = true for a pair below the size bound")
+	}
+	if !matcher.preFilter(long, matcher.prepare("a\nb\nc\nd\nx", false)) {
+		t.Error("preFilter() = false for a pair that can reach the threshold")
+	}
+}
+
+func BenchmarkContentMatcher_FindSimilar(b *testing.B) {
+	skills := generateSkills(1000)
+	matcher := NewContentMatcher(DefaultContentMatcherConfig())
+
+	b.ResetTimer()
+	for b.Loop() {
+		matcher.FindSimilar(skills)
+	}
+}
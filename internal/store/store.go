@@ -0,0 +1,136 @@
+// Package store manages the canonical skill store, an optional directory of
+// skills (~/.skillsync/skills) kept as the source of truth. Push fans its
+// skills out to every platform and pull collects changes made on the
+// platforms back into it, so each platform syncs with the store instead of
+// with every other platform.
+package store
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser/claude"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+	"github.com/klauern/skillsync/internal/validation"
+)
+
+// Format is the platform whose layout the store keeps skills in: directory
+// skills with a SKILL.md, and single-file skills as Markdown files.
+const Format = model.ClaudeCode
+
+// Store is a canonical skill store.
+type Store struct {
+	path string
+}
+
+// New returns the store at path, or at the default location in the
+// skillsync home if path is empty.
+func New(path string) *Store {
+	if path == "" {
+		path = util.SkillsyncStorePath()
+	}
+	return &Store{path: path}
+}
+
+// Path returns the directory of the store.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Exists reports whether the store has been created.
+func (s *Store) Exists() bool {
+	info, err := os.Stat(s.path)
+	return err == nil && info.IsDir()
+}
+
+// Skills returns the skills in the store. A store that does not exist yet
+// is empty.
+func (s *Store) Skills() ([]model.Skill, error) {
+	if !s.Exists() {
+		return []model.Skill{}, nil
+	}
+	skills, err := claude.New(s.path).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store %s: %w", s.path, err)
+	}
+	return skills, nil
+}
+
+// DetectPlatforms returns the platforms with a user skills directory, the
+// ones push and pull work with by default.
+func DetectPlatforms() []model.Platform {
+	var platforms []model.Platform
+	for _, platform := range model.AllPlatforms() {
+		path, err := validation.GetPlatformPath(platform)
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
+// Push syncs the skills of the store into the user scope of each platform,
+// one sync per platform, and returns the results in platform order. opts
+// applies to every sync; its TargetPath and TargetScope are set by Push.
+func (s *Store) Push(platforms []model.Platform, opts sync.Options) ([]*sync.Result, error) {
+	skills, err := s.Skills()
+	if err != nil {
+		return nil, err
+	}
+
+	opts.TargetPath = ""
+	opts.TargetScope = model.ScopeUser
+	results := make([]*sync.Result, 0, len(platforms))
+	for _, platform := range platforms {
+		result, err := sync.New().SyncWithSkills(skills, platform, opts)
+		if err != nil {
+			return results, fmt.Errorf("push to %s failed: %w", platform, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Pull syncs skills collected from the platforms into the store. When
+// several platforms have a skill of the same name, the most recently
+// modified copy is pulled. opts applies to the sync; its TargetPath is set
+// by Pull.
+func (s *Store) Pull(platformSkills map[model.Platform][]model.Skill, opts sync.Options) (*sync.Result, error) {
+	opts.TargetPath = s.path
+	opts.TargetScope = ""
+	result, err := sync.New().SyncWithSkills(Newest(platformSkills), Format, opts)
+	if err != nil {
+		return result, fmt.Errorf("pull into %s failed: %w", s.path, err)
+	}
+	return result, nil
+}
+
+// Newest returns the most recently modified skill of each name among the
+// skills of all platforms, sorted by name. Ties go to the platform first in
+// model.AllPlatforms.
+func Newest(platformSkills map[model.Platform][]model.Skill) []model.Skill {
+	newest := make(map[string]model.Skill)
+	for _, platform := range model.AllPlatforms() {
+		for _, skill := range platformSkills[platform] {
+			if current, ok := newest[skill.Name]; !ok || skill.ModifiedAt.After(current.ModifiedAt) {
+				newest[skill.Name] = skill
+			}
+		}
+	}
+
+	skills := make([]model.Skill, 0, len(newest))
+	for _, skill := range newest {
+		skills = append(skills, skill)
+	}
+	slices.SortFunc(skills, func(a, b model.Skill) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return skills
+}
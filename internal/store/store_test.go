@@ -0,0 +1,107 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestNewest(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	tests := map[string]struct {
+		skills map[model.Platform][]model.Skill
+		want   []string
+	}{
+		"newest copy wins": {
+			skills: map[model.Platform][]model.Skill{
+				model.ClaudeCode: {{Name: "lint", ModifiedAt: older}},
+				model.Cursor:     {{Name: "lint", ModifiedAt: newer}},
+			},
+			want: []string{"lint@cursor"},
+		},
+		"tie goes to the first platform": {
+			skills: map[model.Platform][]model.Skill{
+				model.ClaudeCode: {{Name: "lint", ModifiedAt: older}},
+				model.Cursor:     {{Name: "lint", ModifiedAt: older}},
+			},
+			want: []string{"lint@claude-code"},
+		},
+		"sorted by name": {
+			skills: map[model.Platform][]model.Skill{
+				model.Cursor:     {{Name: "test"}},
+				model.ClaudeCode: {{Name: "lint"}},
+			},
+			want: []string{"lint@claude-code", "test@cursor"},
+		},
+		"no skills": {},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for platform, skills := range tt.skills {
+				for i := range skills {
+					skills[i].Platform = platform
+				}
+			}
+			var got []string
+			for _, skill := range Newest(tt.skills) {
+				got = append(got, skill.Name+"@"+string(skill.Platform))
+			}
+			util.AssertEqual(t, strings.Join(got, ", "), strings.Join(tt.want, ", "))
+		})
+	}
+}
+
+func TestStore_PushPull(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("SKILLSYNC_HOME", filepath.Join(tempDir, "skillsync"))
+	cursorSkills := filepath.Join(tempDir, "cursor")
+	t.Setenv("SKILLSYNC_CURSOR_SKILLS_PATHS", cursorSkills)
+	t.Setenv("SKILLSYNC_CURSOR_PATH", cursorSkills)
+
+	s := New("")
+	util.AssertEqual(t, s.Path(), filepath.Join(tempDir, "skillsync", "skills"))
+	util.AssertEqual(t, s.Exists(), false)
+	skills, err := s.Skills()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(skills), 0)
+
+	// A skill edited on another platform, pulled into the new store
+	source := filepath.Join(tempDir, "claude", "lint", "SKILL.md")
+	util.WriteFile(t, source, "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+	lint := model.Skill{
+		Name:        "lint",
+		Description: "Lint code",
+		Platform:    model.ClaudeCode,
+		Path:        source,
+		Content:     "Run the linter.\n",
+		ModifiedAt:  time.Now(),
+	}
+	result, err := s.Pull(map[model.Platform][]model.Skill{model.ClaudeCode: {lint}}, sync.Options{Strategy: sync.StrategyNewer})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, result.TotalChanged(), 1)
+	util.AssertEqual(t, s.Exists(), true)
+
+	skills, err = s.Skills()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(skills), 1)
+	util.AssertEqual(t, skills[0].Name, "lint")
+
+	results, err := s.Push([]model.Platform{model.Cursor}, sync.Options{Strategy: sync.StrategyOverwrite})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(results), 1)
+	util.AssertEqual(t, results[0].TotalChanged(), 1)
+	data, err := os.ReadFile(filepath.Join(cursorSkills, "lint", "SKILL.md"))
+	util.AssertNoError(t, err)
+	if !strings.Contains(string(data), "Run the linter.") {
+		t.Errorf("pushed skill content = %q", data)
+	}
+}
@@ -0,0 +1,90 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser/aider"
+	"github.com/klauern/skillsync/internal/parser/codex"
+)
+
+// aggregateFileName returns the file a platform reads its instructions from,
+// into which single-file skills are aggregated as marked sections, or "" when
+// the platform keeps one file per skill.
+func aggregateFileName(platform model.Platform) string {
+	switch platform {
+	case model.Codex:
+		return codex.AgentsFileName
+	case model.Aider:
+		return aider.ConventionsFileName
+	default:
+		return ""
+	}
+}
+
+// isAgentsSection reports whether the skill was parsed from a marked section
+// of an AGENTS.md file rather than the whole file.
+func isAgentsSection(skill model.Skill) bool {
+	return skill.Metadata[codex.SectionMetadataKey] != ""
+}
+
+// isWholeAgentsFile reports whether the skill is the unmarked content of an
+// AGENTS.md or CONVENTIONS.md file, which is kept outside the sections of the
+// target's.
+func isWholeAgentsFile(skill model.Skill) bool {
+	base := filepath.Base(skill.Path)
+	return (base == codex.AgentsFileName || base == aider.ConventionsFileName) && !isAgentsSection(skill)
+}
+
+// spliceAgents returns the AGENTS.md content with the skill written into it:
+// as its marked section, or as the unmarked text around the sections when the
+// skill is a whole AGENTS.md file itself.
+func spliceAgents(existing string, skill model.Skill, content string) string {
+	if isWholeAgentsFile(skill) {
+		return codex.ReplacePreamble(existing, content)
+	}
+	return codex.UpsertSection(existing, codex.Section{
+		Name:        skill.Name,
+		Description: skill.Description,
+		Content:     content,
+	})
+}
+
+// registerConventions adds an Aider CONVENTIONS.md written by sync to the
+// read: list of the .aider.conf.yml that applies to its skills directory, so
+// Aider loads it. It returns a note for the sync result when the config changed.
+func registerConventions(skillsDir, conventionsPath string) (string, error) {
+	confPath := aider.ConfigPathFor(skillsDir)
+	if confPath == "" {
+		return "", nil
+	}
+	changed, err := aider.EnsureRead(confPath, conventionsPath)
+	if err != nil || !changed {
+		return "", err
+	}
+	return "added to read list in " + confPath, nil
+}
+
+// removeAgentsEntry removes a skill from the AGENTS.md file it shares with
+// other skills, deleting the file once nothing is left in it.
+func removeAgentsEntry(skill model.Skill) error {
+	// #nosec G304 - skill.Path comes from parsing the target directory
+	content, err := os.ReadFile(skill.Path)
+	if err != nil {
+		return err
+	}
+
+	var remaining string
+	if isAgentsSection(skill) {
+		remaining = codex.RemoveSection(string(content), skill.Name)
+	} else {
+		remaining = codex.ReplacePreamble(string(content), "")
+	}
+	if strings.TrimSpace(remaining) == "" {
+		return os.Remove(skill.Path)
+	}
+	// #nosec G306 - skill files should be readable
+	return os.WriteFile(skill.Path, []byte(remaining), 0o644)
+}
@@ -0,0 +1,100 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSync_CodexAgentsRoundTrip(t *testing.T) {
+	claudeDir := t.TempDir()
+	codexDir := t.TempDir()
+	backDir := t.TempDir()
+	util.WriteFile(t, filepath.Join(claudeDir, "lint.md"), "---\nname: lint\ndescription: Run the linter\n---\nRun golangci-lint.\n")
+	util.WriteFile(t, filepath.Join(claudeDir, "review.md"), "---\nname: review\ndescription: Review changes\n---\nReview carefully.\n")
+	util.WriteFile(t, filepath.Join(codexDir, "AGENTS.md"), "# Handwritten\n\nKeep this.\n")
+
+	opts := Options{Strategy: StrategyOverwrite, SourcePath: claudeDir, TargetPath: codexDir}
+	result, err := New().Sync(model.ClaudeCode, model.Codex, opts)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(result.Created()), 2)
+
+	content, err := os.ReadFile(filepath.Join(codexDir, "AGENTS.md"))
+	util.AssertNoError(t, err)
+	for _, want := range []string{"# Handwritten\n\nKeep this.\n", `name="lint" description="Run the linter"`, "Review carefully."} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("AGENTS.md should contain %q:\n%s", want, content)
+		}
+	}
+
+	// Syncing again leaves the aggregated file alone
+	again, err := New().Sync(model.ClaudeCode, model.Codex, opts)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(again.Unchanged()), 2)
+
+	// Each section comes back as its own skill, next to the handwritten text
+	back, err := New().Sync(model.Codex, model.ClaudeCode, Options{Strategy: StrategyOverwrite, SourcePath: codexDir, TargetPath: backDir})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(back.Created()), 3)
+	lint, err := os.ReadFile(filepath.Join(backDir, "lint.md"))
+	util.AssertNoError(t, err)
+	if !strings.Contains(string(lint), "description: Run the linter") || !strings.Contains(string(lint), "Run golangci-lint.") {
+		t.Errorf("lint.md lost content:\n%s", lint)
+	}
+	if strings.Contains(string(lint), "agents_section") {
+		t.Errorf("lint.md should not carry the section marker metadata:\n%s", lint)
+	}
+}
+
+func TestDeleteWithSkills_CodexAgentsSection(t *testing.T) {
+	codexDir := t.TempDir()
+	agentsPath := filepath.Join(codexDir, "AGENTS.md")
+	util.WriteFile(t, agentsPath, "# Handwritten\n\n"+
+		"<!-- skillsync:begin name=\"lint\" -->\nLint.\n<!-- skillsync:end name=\"lint\" -->\n\n"+
+		"<!-- skillsync:begin name=\"review\" -->\nReview.\n<!-- skillsync:end name=\"review\" -->\n")
+
+	source := []model.Skill{{Name: "lint", Platform: model.ClaudeCode}}
+	result, err := New().DeleteWithSkills(source, model.Codex, Options{TargetPath: codexDir, TrashRetention: 0})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(result.Deleted()), 1)
+
+	content, err := os.ReadFile(agentsPath)
+	util.AssertNoError(t, err)
+	want := "# Handwritten\n\n<!-- skillsync:begin name=\"review\" -->\nReview.\n<!-- skillsync:end name=\"review\" -->\n"
+	util.AssertEqual(t, string(content), want)
+}
+
+func TestSync_AiderConventions(t *testing.T) {
+	claudeDir := t.TempDir()
+	home := t.TempDir()
+	aiderDir := filepath.Join(home, ".aider", "skills")
+	confPath := filepath.Join(home, ".aider.conf.yml")
+	util.WriteFile(t, filepath.Join(claudeDir, "lint.md"), "---\nname: lint\ndescription: Run the linter\n---\nRun golangci-lint.\n")
+	util.WriteFile(t, confPath, "model: sonnet\nread: STYLE.md\n")
+
+	opts := Options{Strategy: StrategyOverwrite, SourcePath: claudeDir, TargetPath: aiderDir}
+	result, err := New().Sync(model.ClaudeCode, model.Aider, opts)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(result.Created()), 1)
+
+	conventionsPath := filepath.Join(aiderDir, "CONVENTIONS.md")
+	content, err := os.ReadFile(conventionsPath)
+	util.AssertNoError(t, err)
+	if !strings.Contains(string(content), `name="lint" description="Run the linter"`) || strings.Contains(string(content), "---") {
+		t.Errorf("CONVENTIONS.md should hold the lint section without frontmatter:\n%s", content)
+	}
+
+	conf, err := os.ReadFile(confPath)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(conf), "model: sonnet\nread:\n  - STYLE.md\n  - "+conventionsPath+"\n")
+
+	// The section is parsed back as the skill it came from
+	back, err := New().Sync(model.Aider, model.ClaudeCode, Options{Strategy: StrategyOverwrite, SourcePath: aiderDir, TargetPath: t.TempDir()})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(back.Created()), 1)
+	util.AssertEqual(t, back.Created()[0].Skill.Name, "lint")
+}
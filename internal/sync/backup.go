@@ -0,0 +1,59 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// backupTarget backs up the existing entry at path before it is modified and
+// returns the IDs of the backups created. Missing entries and symlinks have no
+// content to preserve and are skipped; directories are backed up file by file.
+func backupTarget(path string, skill model.Skill, target model.Platform, opts Options, description string, tags []string) ([]string, error) {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) || (err == nil && info.Mode()&os.ModeSymlink != 0) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat target for backup: %w", err)
+	}
+
+	metadata := map[string]string{"skill": skill.Name}
+	if opts.TargetScope != "" {
+		metadata["scope"] = string(opts.TargetScope)
+	}
+	backupOpts := backup.Options{
+		Platform:    string(target),
+		Description: description,
+		Metadata:    metadata,
+		Tags:        tags,
+		SessionID:   opts.SessionID,
+	}
+
+	var created []backup.Metadata
+	if info.IsDir() {
+		created, err = backup.Directory(path, backupOpts)
+	} else {
+		var m *backup.Metadata
+		if m, err = backup.CreateBackup(path, backupOpts); err == nil {
+			created = []backup.Metadata{*m}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up %q: %w", path, err)
+	}
+
+	ids := make([]string, 0, len(created))
+	for _, m := range created {
+		ids = append(ids, m.ID)
+	}
+	logging.Debug("backed up target",
+		logging.Skill(skill.Name),
+		logging.Path(path),
+		logging.Count(len(ids)),
+	)
+	return ids, nil
+}
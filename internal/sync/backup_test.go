@@ -0,0 +1,94 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/backup"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSyncWithSkills_Backup(t *testing.T) {
+	tests := map[string]struct {
+		backup      bool
+		dryRun      bool
+		concurrency int
+		wantBackups int
+	}{
+		"backs up overwritten targets": {backup: true, wantBackups: 2},
+		"concurrent workers":           {backup: true, concurrency: 4, wantBackups: 2},
+		"disabled":                     {},
+		"dry run":                      {backup: true, dryRun: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SKILLSYNC_HOME", t.TempDir())
+			targetDir := t.TempDir()
+			util.WriteFile(t, filepath.Join(targetDir, "alpha.md"), "old alpha")
+			util.WriteFile(t, filepath.Join(targetDir, "beta.md"), "old beta")
+
+			skills := []model.Skill{
+				{Name: "alpha", Platform: model.Cursor, Path: "/src/alpha.md", Content: "new alpha"},
+				{Name: "beta", Platform: model.Cursor, Path: "/src/beta.md", Content: "new beta"},
+				{Name: "gamma", Platform: model.Cursor, Path: "/src/gamma.md", Content: "new gamma"},
+			}
+
+			session := backup.NewSessionID()
+			result, err := New().SyncWithSkills(skills, model.ClaudeCode, Options{
+				Strategy:    StrategyOverwrite,
+				TargetPath:  targetDir,
+				DryRun:      tt.dryRun,
+				Concurrency: tt.concurrency,
+				Backup:      tt.backup,
+				SessionID:   session,
+			})
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, result.SessionID, session)
+			util.AssertEqual(t, len(result.BackupIDs()), tt.wantBackups)
+
+			backups, err := backup.SessionBackups(session)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(backups), tt.wantBackups)
+			if tt.wantBackups == 0 {
+				return
+			}
+
+			// Rolling back the session restores the overwritten files
+			if _, err := backup.RollbackSession(session); err != nil {
+				t.Fatalf("RollbackSession() error = %v", err)
+			}
+			for file, want := range map[string]string{"alpha.md": "old alpha", "beta.md": "old beta"} {
+				got, err := os.ReadFile(filepath.Join(targetDir, file))
+				util.AssertNoError(t, err)
+				util.AssertEqual(t, string(got), want)
+			}
+		})
+	}
+}
+
+func TestDeleteWithSkills_Backup(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	targetDir := t.TempDir()
+	targetFile := filepath.Join(targetDir, "alpha.md")
+	util.WriteFile(t, targetFile, "---\nname: alpha\n---\nAlpha")
+
+	session := backup.NewSessionID()
+	result, err := New().DeleteWithSkills(
+		[]model.Skill{{Name: "alpha", Platform: model.Cursor}},
+		model.ClaudeCode,
+		Options{TargetPath: targetDir, Backup: true, SessionID: session},
+	)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(result.Deleted()), 1)
+	util.AssertEqual(t, len(result.BackupIDs()), 1)
+
+	if _, err := backup.RollbackSession(session); err != nil {
+		t.Fatalf("RollbackSession() error = %v", err)
+	}
+	got, err := os.ReadFile(targetFile)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(got), "---\nname: alpha\n---\nAlpha")
+}
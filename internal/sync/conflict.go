@@ -75,13 +75,13 @@ type DiffHunk struct {
 	// SourceStart is the starting line number in the source.
 	SourceStart int
 
-	// SourceCount is the number of lines from source.
+	// SourceCount is the number of lines from source, including context.
 	SourceCount int
 
 	// TargetStart is the starting line number in the target.
 	TargetStart int
 
-	// TargetCount is the number of lines from target.
+	// TargetCount is the number of lines from target, including context.
 	TargetCount int
 
 	// Lines contains the diff lines with prefixes (+, -, space).
@@ -159,11 +159,20 @@ func (c *Conflict) DiffSummary() string {
 }
 
 // ConflictDetector detects conflicts between source and target skills.
-type ConflictDetector struct{}
+type ConflictDetector struct {
+	diff DiffOptions
+}
 
-// NewConflictDetector creates a new conflict detector.
+// NewConflictDetector creates a new conflict detector that computes hunks
+// with the options set by SetDiffOptions.
 func NewConflictDetector() *ConflictDetector {
-	return &ConflictDetector{}
+	return NewConflictDetectorWithOptions(CurrentDiffOptions())
+}
+
+// NewConflictDetectorWithOptions creates a conflict detector that computes
+// hunks with the given diff options.
+func NewConflictDetectorWithOptions(opts DiffOptions) *ConflictDetector {
+	return &ConflictDetector{diff: opts}
 }
 
 // DetectConflict checks if there's a conflict between source and target skills.
@@ -208,10 +217,11 @@ func (cd *ConflictDetector) DetectConflict(source, target model.Skill) *Conflict
 
 	// Compute diff hunks for content conflicts
 	if contentDiffers {
-		conflict.Hunks = cd.computeDiff(conflict.SourceLines, conflict.TargetLines)
+		conflict.Hunks = ComputeDiff(conflict.SourceLines, conflict.TargetLines, cd.diff)
 		logging.Debug("computed diff hunks",
 			logging.Skill(source.Name),
 			logging.Count(len(conflict.Hunks)),
+			slog.String("algorithm", string(cd.diff.Algorithm)),
 		)
 	}
 
@@ -246,118 +256,3 @@ func (cd *ConflictDetector) metadataDiffers(source, target model.Skill) bool {
 
 	return false
 }
-
-// computeDiff computes the diff hunks between source and target lines.
-// This implements a simplified diff algorithm based on longest common subsequence.
-func (cd *ConflictDetector) computeDiff(source, target []string) []DiffHunk {
-	// Find the longest common subsequence to guide the diff
-	lcs := cd.longestCommonSubsequence(source, target)
-
-	var hunks []DiffHunk
-	var currentHunk *DiffHunk
-
-	sourceIdx, targetIdx, lcsIdx := 0, 0, 0
-
-	for sourceIdx < len(source) || targetIdx < len(target) {
-		// Check if current lines are in the LCS
-		inLCS := lcsIdx < len(lcs) &&
-			sourceIdx < len(source) &&
-			targetIdx < len(target) &&
-			source[sourceIdx] == lcs[lcsIdx] &&
-			target[targetIdx] == lcs[lcsIdx]
-
-		if inLCS {
-			// Common line - add as context or close hunk
-			if currentHunk != nil {
-				// Add context line to close the hunk
-				currentHunk.Lines = append(currentHunk.Lines, DiffLine{
-					Type:    DiffLineContext,
-					Content: source[sourceIdx],
-				})
-				hunks = append(hunks, *currentHunk)
-				currentHunk = nil
-			}
-			sourceIdx++
-			targetIdx++
-			lcsIdx++
-		} else {
-			// Different lines - start or continue a hunk
-			if currentHunk == nil {
-				currentHunk = &DiffHunk{
-					SourceStart: sourceIdx + 1,
-					TargetStart: targetIdx + 1,
-				}
-			}
-
-			// Check if source line is not in common
-			if sourceIdx < len(source) && (lcsIdx >= len(lcs) || source[sourceIdx] != lcs[lcsIdx]) {
-				currentHunk.Lines = append(currentHunk.Lines, DiffLine{
-					Type:    DiffLineRemoved,
-					Content: source[sourceIdx],
-				})
-				currentHunk.SourceCount++
-				sourceIdx++
-			}
-
-			// Check if target line is not in common
-			if targetIdx < len(target) && (lcsIdx >= len(lcs) || target[targetIdx] != lcs[lcsIdx]) {
-				currentHunk.Lines = append(currentHunk.Lines, DiffLine{
-					Type:    DiffLineAdded,
-					Content: target[targetIdx],
-				})
-				currentHunk.TargetCount++
-				targetIdx++
-			}
-		}
-	}
-
-	// Don't forget the last hunk
-	if currentHunk != nil {
-		hunks = append(hunks, *currentHunk)
-	}
-
-	return hunks
-}
-
-// longestCommonSubsequence finds the LCS of two string slices.
-func (cd *ConflictDetector) longestCommonSubsequence(source, target []string) []string {
-	m, n := len(source), len(target)
-	if m == 0 || n == 0 {
-		return nil
-	}
-
-	// Build LCS length table
-	dp := make([][]int, m+1)
-	for i := range dp {
-		dp[i] = make([]int, n+1)
-	}
-
-	for i := 1; i <= m; i++ {
-		for j := 1; j <= n; j++ {
-			if source[i-1] == target[j-1] {
-				dp[i][j] = dp[i-1][j-1] + 1
-			} else {
-				dp[i][j] = max(dp[i-1][j], dp[i][j-1])
-			}
-		}
-	}
-
-	// Backtrack to find the LCS
-	lcs := make([]string, dp[m][n])
-	i, j, idx := m, n, dp[m][n]-1
-
-	for i > 0 && j > 0 {
-		if source[i-1] == target[j-1] {
-			lcs[idx] = source[i-1]
-			i--
-			j--
-			idx--
-		} else if dp[i-1][j] > dp[i][j-1] {
-			i--
-		} else {
-			j--
-		}
-	}
-
-	return lcs
-}
@@ -3,6 +3,7 @@ package sync
 import (
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 
@@ -201,3 +202,19 @@ func getSymlinkTarget(path string) string {
 	}
 	return target
 }
+
+// treeSize returns the total size of the regular files under root, without
+// following symlinks.
+func treeSize(root string) int64 {
+	var size int64
+	_ = filepath.WalkDir(root, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -821,3 +821,27 @@ func TestCopyDirWithMixedContent(t *testing.T) {
 		t.Errorf("symlink target mismatch: got %q, want %q", target, externalTarget)
 	}
 }
+
+func TestTreeSize(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "SKILL.md"), []byte("12345"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "scripts"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "scripts", "run.sh"), []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Symlinks are not followed
+	if err := os.Symlink(filepath.Join(root, "SKILL.md"), filepath.Join(root, "link.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := treeSize(root); got != 8 {
+		t.Errorf("treeSize() = %d, want 8", got)
+	}
+	if got := treeSize(filepath.Join(root, "missing")); got != 0 {
+		t.Errorf("treeSize() of a missing path = %d, want 0", got)
+	}
+}
@@ -0,0 +1,444 @@
+package sync
+
+import (
+	"fmt"
+	"strings"
+	gosync "sync"
+)
+
+// DiffAlgorithm selects how line differences are computed for conflict hunks
+// and diff previews.
+type DiffAlgorithm string
+
+const (
+	// DiffMyers finds a minimal edit script (the classic diff algorithm).
+	DiffMyers DiffAlgorithm = "myers"
+
+	// DiffPatience anchors on lines that occur exactly once on both sides,
+	// which keeps moved or re-ordered sections readable.
+	DiffPatience DiffAlgorithm = "patience"
+
+	// DiffHistogram anchors on the least frequent common lines, extending
+	// patience to content with few unique lines (git's histogram diff).
+	DiffHistogram DiffAlgorithm = "histogram"
+)
+
+// DefaultDiffContext is the number of unchanged lines shown around each change.
+const DefaultDiffContext = 3
+
+// histogramMaxChain caps how often a line may occur and still anchor a
+// histogram diff; regions with only more frequent lines fall back to Myers.
+const histogramMaxChain = 64
+
+// AllDiffAlgorithms returns the supported diff algorithms.
+func AllDiffAlgorithms() []DiffAlgorithm {
+	return []DiffAlgorithm{DiffMyers, DiffPatience, DiffHistogram}
+}
+
+// ParseDiffAlgorithm parses a diff algorithm name. An empty name is Myers.
+func ParseDiffAlgorithm(s string) (DiffAlgorithm, error) {
+	switch DiffAlgorithm(strings.ToLower(strings.TrimSpace(s))) {
+	case "", DiffMyers:
+		return DiffMyers, nil
+	case DiffPatience:
+		return DiffPatience, nil
+	case DiffHistogram:
+		return DiffHistogram, nil
+	default:
+		return "", fmt.Errorf("unknown diff algorithm %q (use myers, patience, or histogram)", s)
+	}
+}
+
+// DiffOptions configures diff computation.
+type DiffOptions struct {
+	// Algorithm selects the diff algorithm (default Myers)
+	Algorithm DiffAlgorithm
+	// Context is the number of unchanged lines kept around each change;
+	// changes closer than twice this share a hunk
+	Context int
+}
+
+// DefaultDiffOptions returns Myers with three lines of context.
+func DefaultDiffOptions() DiffOptions {
+	return DiffOptions{Algorithm: DiffMyers, Context: DefaultDiffContext}
+}
+
+var (
+	diffOptionsMu gosync.RWMutex
+	diffOptions   = DefaultDiffOptions()
+)
+
+// SetDiffOptions sets the options used by conflict detectors created with
+// NewConflictDetector.
+func SetDiffOptions(opts DiffOptions) {
+	diffOptionsMu.Lock()
+	defer diffOptionsMu.Unlock()
+	diffOptions = opts
+}
+
+// CurrentDiffOptions returns the options used by NewConflictDetector.
+func CurrentDiffOptions() DiffOptions {
+	diffOptionsMu.RLock()
+	defer diffOptionsMu.RUnlock()
+	return diffOptions
+}
+
+// ComputeDiff returns the unified diff hunks that turn source into target.
+// Hunk line numbers are 1-based and counts include context lines.
+func ComputeDiff(source, target []string, opts DiffOptions) []DiffHunk {
+	a, b := internLines(source, target)
+
+	var matches []lineMatch
+	switch opts.Algorithm {
+	case DiffPatience:
+		patienceMatches(a, b, 0, len(a), 0, len(b), &matches)
+	case DiffHistogram:
+		histogramMatches(a, b, 0, len(a), 0, len(b), &matches)
+	default:
+		myersRange(a, b, 0, len(a), 0, len(b), &matches)
+	}
+
+	return buildHunks(source, target, matches, max(opts.Context, 0))
+}
+
+// lineMatch pairs equal lines: source line A with target line B (0-based).
+type lineMatch struct {
+	A, B int
+}
+
+// internLines maps equal lines to equal integers so the algorithms compare
+// ints instead of strings.
+func internLines(source, target []string) ([]int, []int) {
+	ids := make(map[string]int, len(source))
+	intern := func(lines []string) []int {
+		out := make([]int, len(lines))
+		for i, line := range lines {
+			id, ok := ids[line]
+			if !ok {
+				id = len(ids)
+				ids[line] = id
+			}
+			out[i] = id
+		}
+		return out
+	}
+	return intern(source), intern(target)
+}
+
+// matchEnds appends the common prefix of a[aLo:aHi] and b[bLo:bHi] to out and
+// returns the ranges left once the common prefix and suffix are removed,
+// along with the length of the suffix. The caller appends the suffix matches
+// after matching the inner ranges.
+func matchEnds(a, b []int, aLo, aHi, bLo, bHi int, out *[]lineMatch) (int, int, int, int, int) {
+	for aLo < aHi && bLo < bHi && a[aLo] == b[bLo] {
+		*out = append(*out, lineMatch{aLo, bLo})
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && a[aHi-suffix-1] == b[bHi-suffix-1] {
+		suffix++
+	}
+	return aLo, aHi - suffix, bLo, bHi - suffix, suffix
+}
+
+// appendSuffix appends the matches of a common suffix of length n ending at
+// aHi and bHi.
+func appendSuffix(aHi, bHi, n int, out *[]lineMatch) {
+	for i := range n {
+		*out = append(*out, lineMatch{aHi + i, bHi + i})
+	}
+}
+
+// myersRange appends the Myers matches of a[aLo:aHi] and b[bLo:bHi] to out.
+func myersRange(a, b []int, aLo, aHi, bLo, bHi int, out *[]lineMatch) {
+	aLo, aHi, bLo, bHi, suffix := matchEnds(a, b, aLo, aHi, bLo, bHi, out)
+	for _, m := range myers(a[aLo:aHi], b[bLo:bHi]) {
+		*out = append(*out, lineMatch{aLo + m.A, bLo + m.B})
+	}
+	appendSuffix(aHi, bHi, suffix, out)
+}
+
+// myers returns the matches of a shortest edit script between a and b
+// (Myers, "An O(ND) Difference Algorithm and Its Variations").
+func myers(a, b []int) []lineMatch {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return nil
+	}
+
+	limit := n + m
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	// trace[d] holds v[-d..d] as it was before step d
+	var trace [][]int
+	var steps int
+search:
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				steps = d
+				break search
+			}
+		}
+	}
+
+	var matches []lineMatch
+	x, y := n, m
+	for d := steps; d > 0; d-- {
+		prev := trace[d]
+		at := func(k int) int { return prev[k+d] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			matches = append(matches, lineMatch{x, y})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		matches = append(matches, lineMatch{x, y})
+	}
+
+	for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+		matches[i], matches[j] = matches[j], matches[i]
+	}
+	return matches
+}
+
+// patienceMatches appends the patience diff matches of a[aLo:aHi] and
+// b[bLo:bHi] to out, using Myers for regions without unique common lines.
+func patienceMatches(a, b []int, aLo, aHi, bLo, bHi int, out *[]lineMatch) {
+	aLo, aHi, bLo, bHi, suffix := matchEnds(a, b, aLo, aHi, bLo, bHi, out)
+	defer appendSuffix(aHi, bHi, suffix, out)
+	if aLo == aHi || bLo == bHi {
+		return
+	}
+
+	// Lines that occur exactly once in each range, in source order
+	type occurrence struct{ count, pos int }
+	inA := make(map[int]*occurrence)
+	for i := aLo; i < aHi; i++ {
+		if o, ok := inA[a[i]]; ok {
+			o.count++
+		} else {
+			inA[a[i]] = &occurrence{1, i}
+		}
+	}
+	inB := make(map[int]*occurrence)
+	for j := bLo; j < bHi; j++ {
+		if o, ok := inB[b[j]]; ok {
+			o.count++
+		} else {
+			inB[b[j]] = &occurrence{1, j}
+		}
+	}
+	var unique []lineMatch
+	for i := aLo; i < aHi; i++ {
+		oa, ob := inA[a[i]], inB[a[i]]
+		if oa.count == 1 && ob != nil && ob.count == 1 {
+			unique = append(unique, lineMatch{i, ob.pos})
+		}
+	}
+	if len(unique) == 0 {
+		myersRange(a, b, aLo, aHi, bLo, bHi, out)
+		return
+	}
+
+	// Anchor on the longest run of unique lines in the same order on both sides
+	prevA, prevB := aLo, bLo
+	for _, anchor := range longestIncreasing(unique) {
+		patienceMatches(a, b, prevA, anchor.A, prevB, anchor.B, out)
+		*out = append(*out, anchor)
+		prevA, prevB = anchor.A+1, anchor.B+1
+	}
+	patienceMatches(a, b, prevA, aHi, prevB, bHi, out)
+}
+
+// longestIncreasing returns the longest subsequence of matches (ordered by
+// A) whose B positions increase, using patience sorting.
+func longestIncreasing(matches []lineMatch) []lineMatch {
+	var tails []int // index into matches of the last element of each pile
+	prev := make([]int, len(matches))
+	for i, m := range matches {
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if matches[tails[mid]].B < m.B {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		prev[i] = -1
+		if lo > 0 {
+			prev[i] = tails[lo-1]
+		}
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+
+	result := make([]lineMatch, len(tails))
+	for i, k := len(tails)-1, tails[len(tails)-1]; i >= 0; i, k = i-1, prev[k] {
+		result[i] = matches[k]
+	}
+	return result
+}
+
+// histogramMatches appends the histogram diff matches of a[aLo:aHi] and
+// b[bLo:bHi] to out. It splits each region at the longest common run around
+// the least frequent common line, falling back to Myers when every common
+// line is too frequent to anchor on.
+func histogramMatches(a, b []int, aLo, aHi, bLo, bHi int, out *[]lineMatch) {
+	aLo, aHi, bLo, bHi, suffix := matchEnds(a, b, aLo, aHi, bLo, bHi, out)
+	defer appendSuffix(aHi, bHi, suffix, out)
+	if aLo == aHi || bLo == bHi {
+		return
+	}
+
+	positions := make(map[int][]int)
+	for i := aLo; i < aHi; i++ {
+		positions[a[i]] = append(positions[a[i]], i)
+	}
+
+	best := struct{ aStart, bStart, length, count int }{count: histogramMaxChain + 1}
+	frequent := false
+	for j := bLo; j < bHi; j++ {
+		occurrences := positions[b[j]]
+		if len(occurrences) == 0 {
+			continue
+		}
+		if len(occurrences) > histogramMaxChain {
+			frequent = true
+			continue
+		}
+		if len(occurrences) > best.count {
+			continue
+		}
+		for _, i := range occurrences {
+			s, t := i, j
+			for s > aLo && t > bLo && a[s-1] == b[t-1] {
+				s--
+				t--
+			}
+			e := i + 1
+			for f := j + 1; e < aHi && f < bHi && a[e] == b[f]; f++ {
+				e++
+			}
+			if length := e - s; len(occurrences) < best.count || length > best.length {
+				best.aStart, best.bStart, best.length, best.count = s, t, length, len(occurrences)
+			}
+		}
+	}
+
+	if best.length == 0 {
+		if frequent {
+			myersRange(a, b, aLo, aHi, bLo, bHi, out)
+		}
+		return
+	}
+
+	histogramMatches(a, b, aLo, best.aStart, bLo, best.bStart, out)
+	for i := range best.length {
+		*out = append(*out, lineMatch{best.aStart + i, best.bStart + i})
+	}
+	histogramMatches(a, b, best.aStart+best.length, aHi, best.bStart+best.length, bHi, out)
+}
+
+// buildHunks groups the lines between matches into unified diff hunks with
+// up to context unchanged lines around each change.
+func buildHunks(source, target []string, matches []lineMatch, context int) []DiffHunk {
+	// Flatten into a single edit script
+	type edit struct {
+		kind DiffLineType
+		a, b int // line index on each side (the next line for the side not involved)
+	}
+	var edits []edit
+	i, j := 0, 0
+	flush := func(toA, toB int) {
+		for ; i < toA; i++ {
+			edits = append(edits, edit{DiffLineRemoved, i, j})
+		}
+		for ; j < toB; j++ {
+			edits = append(edits, edit{DiffLineAdded, i, j})
+		}
+	}
+	for _, m := range matches {
+		flush(m.A, m.B)
+		edits = append(edits, edit{DiffLineContext, i, j})
+		i++
+		j++
+	}
+	flush(len(source), len(target))
+
+	var hunks []DiffHunk
+	for start := 0; start < len(edits); {
+		// Find the next change
+		for start < len(edits) && edits[start].kind == DiffLineContext {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+
+		// Extend over changes separated by at most 2*context unchanged lines
+		end := start
+		for k := start; k < len(edits); k++ {
+			if edits[k].kind != DiffLineContext {
+				end = k + 1
+				continue
+			}
+			if k-end >= 2*context {
+				break
+			}
+		}
+
+		from := max(start-context, 0)
+		to := min(end+context, len(edits))
+		hunk := DiffHunk{SourceStart: edits[from].a + 1, TargetStart: edits[from].b + 1}
+		for _, e := range edits[from:to] {
+			switch e.kind {
+			case DiffLineRemoved:
+				hunk.Lines = append(hunk.Lines, DiffLine{Type: DiffLineRemoved, Content: source[e.a]})
+				hunk.SourceCount++
+			case DiffLineAdded:
+				hunk.Lines = append(hunk.Lines, DiffLine{Type: DiffLineAdded, Content: target[e.b]})
+				hunk.TargetCount++
+			default:
+				hunk.Lines = append(hunk.Lines, DiffLine{Type: DiffLineContext, Content: source[e.a]})
+				hunk.SourceCount++
+				hunk.TargetCount++
+			}
+		}
+		hunks = append(hunks, hunk)
+		start = to
+	}
+
+	return hunks
+}
@@ -0,0 +1,174 @@
+package sync
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// applyHunks rebuilds the target from source and the hunks of a diff.
+func applyHunks(t *testing.T, source []string, hunks []DiffHunk) []string {
+	t.Helper()
+	var result []string
+	next := 0 // next source line to copy (0-based)
+	for _, hunk := range hunks {
+		start := hunk.SourceStart - 1
+		if start < next {
+			t.Fatalf("hunk at source line %d overlaps previous hunk", hunk.SourceStart)
+		}
+		result = append(result, source[next:start]...)
+		next = start
+		for _, line := range hunk.Lines {
+			switch line.Type {
+			case DiffLineAdded:
+				result = append(result, line.Content)
+			case DiffLineRemoved, DiffLineContext:
+				if source[next] != line.Content {
+					t.Fatalf("hunk line %q does not match source line %d %q", line.String(), next+1, source[next])
+				}
+				if line.Type == DiffLineContext {
+					result = append(result, line.Content)
+				}
+				next++
+			}
+		}
+	}
+	return append(result, source[next:]...)
+}
+
+func TestComputeDiff_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomLines := func(n int) []string {
+		lines := make([]string, n)
+		for i := range lines {
+			// A small alphabet makes repeated lines common
+			lines[i] = fmt.Sprintf("line %d", rng.Intn(8))
+		}
+		return lines
+	}
+
+	cases := map[string][2][]string{
+		"empty to lines": {nil, {"a", "b"}},
+		"lines to empty": {{"a", "b"}, nil},
+		"replace middle": {{"a", "b", "c"}, {"a", "B", "c"}},
+		"reorder blocks": {
+			{"# Setup", "install", "configure", "# Usage", "run", "check"},
+			{"# Usage", "run", "check", "# Setup", "install", "configure"},
+		},
+		"repeated lines": {{"}", "}", "a", "}", "b", "}"}, {"}", "a", "}", "}", "c", "}"}},
+	}
+	for i := range 50 {
+		cases[fmt.Sprintf("random %d", i)] = [2][]string{randomLines(rng.Intn(30)), randomLines(rng.Intn(30))}
+	}
+
+	for _, algorithm := range AllDiffAlgorithms() {
+		for name, c := range cases {
+			for _, context := range []int{0, 1, 3} {
+				t.Run(fmt.Sprintf("%s/%s/U%d", algorithm, name, context), func(t *testing.T) {
+					hunks := ComputeDiff(c[0], c[1], DiffOptions{Algorithm: algorithm, Context: context})
+					got := applyHunks(t, c[0], hunks)
+					if strings.Join(got, "\n") != strings.Join(c[1], "\n") {
+						t.Errorf("applying hunks gave %q, want %q", got, c[1])
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestComputeDiff_Hunks(t *testing.T) {
+	source := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+
+	tests := map[string]struct {
+		target  []string
+		context int
+		want    []string // hunk headers
+	}{
+		"single change with context": {
+			target:  []string{"1", "2", "3", "4", "five", "6", "7", "8", "9", "10"},
+			context: 2,
+			want:    []string{"-3,5 +3,5"},
+		},
+		"nearby changes share a hunk": {
+			target:  []string{"one", "2", "3", "4", "5", "6", "7", "8", "9", "ten"},
+			context: 4,
+			want:    []string{"-1,10 +1,10"},
+		},
+		"distant changes split": {
+			target:  []string{"one", "2", "3", "4", "5", "6", "7", "8", "9", "ten"},
+			context: 1,
+			want:    []string{"-1,2 +1,2", "-9,2 +9,2"},
+		},
+		"no context": {
+			target:  []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"},
+			context: 0,
+			want:    []string{"-11,0 +11,1"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			hunks := ComputeDiff(source, tt.target, DiffOptions{Algorithm: DiffMyers, Context: tt.context})
+			var got []string
+			for _, h := range hunks {
+				got = append(got, fmt.Sprintf("-%d,%d +%d,%d", h.SourceStart, h.SourceCount, h.TargetStart, h.TargetCount))
+			}
+			if strings.Join(got, " | ") != strings.Join(tt.want, " | ") {
+				t.Errorf("hunks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeDiff_PatienceAnchorsOnUniqueLines(t *testing.T) {
+	// Myers finds the shortest edit by matching the frequent blank and brace
+	// lines, moving the one unique line; patience keeps the unique line in
+	// place and moves the blank and brace lines around it instead.
+	source := []string{"", "", "}", "return nil", "}"}
+	target := []string{"return nil", "", "}", "", "}"}
+
+	movesUnique := func(algorithm DiffAlgorithm) bool {
+		for _, hunk := range ComputeDiff(source, target, DiffOptions{Algorithm: algorithm}) {
+			for _, line := range hunk.Lines {
+				if line.Type != DiffLineContext && line.Content == "return nil" {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if !movesUnique(DiffMyers) {
+		t.Error("expected myers to move the unique line")
+	}
+	if movesUnique(DiffPatience) {
+		t.Error("expected patience to keep the unique line as an anchor")
+	}
+}
+
+func TestParseDiffAlgorithm(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    DiffAlgorithm
+		wantErr bool
+	}{
+		"empty is myers": {input: "", want: DiffMyers},
+		"myers":          {input: "myers", want: DiffMyers},
+		"case folded":    {input: "Patience", want: DiffPatience},
+		"histogram":      {input: "histogram", want: DiffHistogram},
+		"unknown":        {input: "minimal", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseDiffAlgorithm(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDiffAlgorithm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDiffAlgorithm() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
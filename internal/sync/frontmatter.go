@@ -0,0 +1,103 @@
+package sync
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// renderFrontmatter returns the YAML of the frontmatter fields of a
+// transformed skill. When the source's raw frontmatter is available the
+// fields are laid over it: keys keep their order and comments, and values
+// that still mean the same keep their YAML type and style, so booleans,
+// numbers, and nested maps are not turned into strings. Keys the target does
+// not get are dropped and new keys follow in sorted order.
+func renderFrontmatter(source string, fields map[string]any) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(source), &doc); err != nil ||
+		len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return yaml.Marshal(fields)
+	}
+
+	mapping := doc.Content[0]
+	kept := make([]*yaml.Node, 0, len(mapping.Content))
+	seen := make(map[string]bool, len(fields))
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		field, ok := fields[key.Value]
+		if !ok || seen[key.Value] {
+			continue
+		}
+		seen[key.Value] = true
+		if !sameFrontmatterValue(value, field) {
+			replacement, err := replacementNode(value, field)
+			if err != nil {
+				return nil, err
+			}
+			replacement.HeadComment = value.HeadComment
+			replacement.LineComment = value.LineComment
+			replacement.FootComment = value.FootComment
+			value = replacement
+		}
+		kept = append(kept, key, value)
+	}
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		if seen[name] {
+			continue
+		}
+		value, err := frontmatterValueNode(fields[name])
+		if err != nil {
+			return nil, err
+		}
+		kept = append(kept, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, value)
+	}
+	mapping.Content = kept
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sameFrontmatterValue reports whether a source value still says what field
+// says. Parsers flatten frontmatter values to strings with %v, so a value
+// matches the field when its %v form does.
+func sameFrontmatterValue(value *yaml.Node, field any) bool {
+	var decoded any
+	if err := value.Decode(&decoded); err != nil {
+		return false
+	}
+	return fmt.Sprintf("%v", decoded) == fmt.Sprintf("%v", field)
+}
+
+// replacementNode encodes the new value of a frontmatter field. A string
+// replacing a boolean, number, or null that still reads as one keeps that
+// type: parsers hand those values over as strings.
+func replacementNode(value *yaml.Node, field any) (*yaml.Node, error) {
+	if text, ok := field.(string); ok && value.Kind == yaml.ScalarNode && value.ShortTag() != "!!str" {
+		var probe yaml.Node
+		if err := yaml.Unmarshal([]byte(text), &probe); err == nil && len(probe.Content) == 1 &&
+			probe.Content[0].Kind == yaml.ScalarNode && probe.Content[0].ShortTag() == value.ShortTag() {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: value.Tag, Value: text}, nil
+		}
+	}
+	return frontmatterValueNode(field)
+}
+
+// frontmatterValueNode encodes a frontmatter field as a YAML node.
+func frontmatterValueNode(field any) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(field); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
@@ -0,0 +1,117 @@
+package sync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// contentHash returns the hex-encoded SHA256 of data.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// fileHash returns the hex-encoded SHA256 of the file at path.
+func fileHash(path string) (string, error) {
+	// #nosec G304 - path is a sync source or target entry
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash %q: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// treeHash returns a SHA256 over every entry below root: relative paths, file
+// contents, and symlink targets. Two trees with the same hash have identical
+// layout and content.
+func treeHash(root string) (string, error) {
+	return treeHashWith(root, nil)
+}
+
+// treeHashWith is treeHash with the files at the slash-separated relative
+// paths in replace counted as having the given content hashes instead.
+func treeHashWith(root string, replace map[string]string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(h, "L %s %s\n", rel, target)
+		case d.IsDir():
+			_, _ = fmt.Fprintf(h, "D %s\n", rel)
+		default:
+			sum, ok := replace[rel]
+			if !ok {
+				if sum, err = fileHash(path); err != nil {
+					return err
+				}
+			}
+			_, _ = fmt.Fprintf(h, "F %s %s\n", rel, sum)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// targetUnchanged reports whether the entry at targetPath already matches what
+// syncing the source would write. For files, content is the transformed body.
+// Any error reading either side is treated as "changed" so the sync proceeds.
+func targetUnchanged(sourceType SourceType, sourceRoot, symlinkTarget, targetPath, content string) bool {
+	info, err := os.Lstat(targetPath)
+	if err != nil {
+		return false
+	}
+
+	switch sourceType {
+	case SourceTypeSymlink:
+		if info.Mode()&os.ModeSymlink == 0 || symlinkTarget == "" {
+			return false
+		}
+		existing, err := os.Readlink(targetPath)
+		return err == nil && existing == symlinkTarget
+
+	case SourceTypeDirectory:
+		if !info.IsDir() {
+			return false
+		}
+		sourceSum, err := treeHash(sourceRoot)
+		if err != nil {
+			return false
+		}
+		targetSum, err := treeHash(targetPath)
+		return err == nil && sourceSum == targetSum
+
+	default:
+		if !info.Mode().IsRegular() {
+			return false
+		}
+		targetSum, err := fileHash(targetPath)
+		return err == nil && targetSum == contentHash([]byte(content))
+	}
+}
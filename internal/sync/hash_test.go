@@ -0,0 +1,143 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestTreeHash(t *testing.T) {
+	newTree := func(t *testing.T, files map[string]string) string {
+		t.Helper()
+		root := t.TempDir()
+		for rel, content := range files {
+			util.WriteFile(t, filepath.Join(root, rel), content)
+		}
+		return root
+	}
+
+	base := map[string]string{"SKILL.md": "body", "scripts/run.sh": "echo hi"}
+
+	tests := map[string]struct {
+		other map[string]string
+		equal bool
+	}{
+		"identical":       {other: base, equal: true},
+		"content differs": {other: map[string]string{"SKILL.md": "body!", "scripts/run.sh": "echo hi"}},
+		"file renamed":    {other: map[string]string{"SKILL.md": "body", "scripts/go.sh": "echo hi"}},
+		"extra file":      {other: map[string]string{"SKILL.md": "body", "scripts/run.sh": "echo hi", "x": ""}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, err := treeHash(newTree(t, base))
+			util.AssertNoError(t, err)
+			b, err := treeHash(newTree(t, tt.other))
+			util.AssertNoError(t, err)
+			if (a == b) != tt.equal {
+				t.Errorf("treeHash equal = %v, want %v", a == b, tt.equal)
+			}
+		})
+	}
+}
+
+func TestTargetUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	util.WriteFile(t, filepath.Join(dir, "file.md"), "same")
+	util.WriteFile(t, filepath.Join(dir, "src", "SKILL.md"), "dir body")
+	util.WriteFile(t, filepath.Join(dir, "dst", "SKILL.md"), "dir body")
+	util.WriteFile(t, filepath.Join(dir, "other", "SKILL.md"), "different")
+	if err := os.Symlink("/plugins/a", filepath.Join(dir, "link")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	tests := map[string]struct {
+		sourceType    SourceType
+		sourceRoot    string
+		symlinkTarget string
+		target        string
+		content       string
+		want          bool
+	}{
+		"file identical":        {sourceType: SourceTypeFile, target: "file.md", content: "same", want: true},
+		"file differs":          {sourceType: SourceTypeFile, target: "file.md", content: "new"},
+		"file missing":          {sourceType: SourceTypeFile, target: "missing.md", content: "same"},
+		"directory identical":   {sourceType: SourceTypeDirectory, sourceRoot: "src", target: "dst", want: true},
+		"directory differs":     {sourceType: SourceTypeDirectory, sourceRoot: "src", target: "other"},
+		"symlink same target":   {sourceType: SourceTypeSymlink, symlinkTarget: "/plugins/a", target: "link", want: true},
+		"symlink other target":  {sourceType: SourceTypeSymlink, symlinkTarget: "/plugins/b", target: "link"},
+		"symlink over a file":   {sourceType: SourceTypeSymlink, symlinkTarget: "/plugins/a", target: "file.md"},
+		"directory over a file": {sourceType: SourceTypeDirectory, sourceRoot: "src", target: "file.md"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := targetUnchanged(tt.sourceType, filepath.Join(dir, tt.sourceRoot), tt.symlinkTarget, filepath.Join(dir, tt.target), tt.content)
+			if got != tt.want {
+				t.Errorf("targetUnchanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSync_UnchangedAcrossStrategies(t *testing.T) {
+	strategies := []Strategy{StrategyOverwrite, StrategySkip, StrategyNewer, StrategyMerge, StrategyThreeWay, StrategyInteractive}
+
+	for _, strategy := range strategies {
+		t.Run(string(strategy), func(t *testing.T) {
+			sourceDir := t.TempDir()
+			targetDir := t.TempDir()
+			util.WriteFile(t, filepath.Join(sourceDir, "test.md"), "---\nname: test\ndescription: Same\n---\n\nSame content.\n")
+
+			opts := Options{Strategy: StrategyOverwrite, SourcePath: sourceDir, TargetPath: targetDir}
+			first, err := New().Sync(model.ClaudeCode, model.Cursor, opts)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(first.Created()), 1)
+
+			targetFile := filepath.Join(targetDir, "test.md")
+			old := time.Now().Add(-time.Hour).Truncate(time.Second)
+			if err := os.Chtimes(targetFile, old, old); err != nil {
+				t.Fatalf("failed to set mtime: %v", err)
+			}
+
+			opts.Strategy = strategy
+			second, err := New().Sync(model.ClaudeCode, model.Cursor, opts)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(second.Unchanged()), 1)
+			util.AssertEqual(t, second.TotalChanged(), 0)
+
+			info, err := os.Stat(targetFile)
+			util.AssertNoError(t, err)
+			if !info.ModTime().Equal(old) {
+				t.Errorf("unchanged skill was rewritten: mtime %v, want %v", info.ModTime(), old)
+			}
+		})
+	}
+}
+
+func TestSyncWithSkills_UnchangedDirectory(t *testing.T) {
+	sourceDir := t.TempDir()
+	targetDir := t.TempDir()
+	util.WriteFile(t, filepath.Join(sourceDir, "tool", "SKILL.md"), "---\nname: tool\n---\nUse it.")
+	util.WriteFile(t, filepath.Join(sourceDir, "tool", "scripts", "run.sh"), "echo run")
+
+	skill := model.Skill{Name: "tool", Platform: model.ClaudeCode, Path: filepath.Join(sourceDir, "tool", "SKILL.md")}
+	opts := Options{Strategy: StrategyOverwrite, TargetPath: targetDir}
+
+	first, err := New().SyncWithSkills([]model.Skill{skill}, model.Codex, opts)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(first.Created()), 1)
+
+	second, err := New().SyncWithSkills([]model.Skill{skill}, model.Codex, opts)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(second.Unchanged()), 1)
+
+	util.WriteFile(t, filepath.Join(sourceDir, "tool", "scripts", "run.sh"), "echo changed")
+	third, err := New().SyncWithSkills([]model.Skill{skill}, model.Codex, opts)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(third.Unchanged()), 0)
+}
@@ -0,0 +1,146 @@
+package sync
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// HookEvent identifies when a hook runs during a sync.
+type HookEvent string
+
+const (
+	// HookPreSync runs once before any skill is written. A failure aborts the sync.
+	HookPreSync HookEvent = "pre_sync"
+
+	// HookPostSync runs once after all skills are processed.
+	HookPostSync HookEvent = "post_sync"
+
+	// HookPreSkill runs before each skill write. A failure marks the skill as failed.
+	HookPreSkill HookEvent = "pre_skill"
+
+	// HookPostSkill runs after each successful skill write.
+	HookPostSkill HookEvent = "post_skill"
+)
+
+// Hooks holds shell commands run by the sync engine around skill writes.
+// Commands run through the system shell with metadata exposed as SKILLSYNC_*
+// environment variables. Hooks never run during a dry run.
+type Hooks struct {
+	PreSync   []string `yaml:"pre_sync,omitempty"`
+	PostSync  []string `yaml:"post_sync,omitempty"`
+	PreSkill  []string `yaml:"pre_skill,omitempty"`
+	PostSkill []string `yaml:"post_skill,omitempty"`
+
+	// Output receives hook stdout and stderr. Defaults to os.Stderr so hook
+	// output never mixes with structured command output.
+	Output io.Writer `yaml:"-"`
+}
+
+// Commands returns the commands configured for event.
+func (h Hooks) Commands(event HookEvent) []string {
+	switch event {
+	case HookPreSync:
+		return h.PreSync
+	case HookPostSync:
+		return h.PostSync
+	case HookPreSkill:
+		return h.PreSkill
+	case HookPostSkill:
+		return h.PostSkill
+	default:
+		return nil
+	}
+}
+
+// IsEmpty returns true if no hook commands are configured.
+func (h Hooks) IsEmpty() bool {
+	return len(h.PreSync) == 0 && len(h.PostSync) == 0 && len(h.PreSkill) == 0 && len(h.PostSkill) == 0
+}
+
+// run executes the commands for event in order, stopping at the first failure.
+func (h Hooks) run(event HookEvent, env []string) error {
+	commands := h.Commands(event)
+	if len(commands) == 0 {
+		return nil
+	}
+
+	output := h.Output
+	if output == nil {
+		output = os.Stderr
+	}
+
+	env = append(append(os.Environ(), "SKILLSYNC_HOOK="+string(event)), env...)
+	for _, command := range commands {
+		logging.Debug("running hook",
+			logging.Operation(string(event)),
+			slog.String("command", command),
+		)
+
+		// #nosec G204 - hook commands come from the user's own config file
+		c := shellCommand(command)
+		c.Env = env
+		c.Stdout = output
+		c.Stderr = output
+		if err := c.Run(); err != nil {
+			return fmt.Errorf("%s hook %q failed: %w", event, command, err)
+		}
+	}
+	return nil
+}
+
+// shellCommand wraps command in the platform shell.
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// syncHookEnv returns the environment shared by every hook in a sync.
+func syncHookEnv(source, target model.Platform, targetDir string, strategy Strategy) []string {
+	if strategy == "" {
+		strategy = StrategyOverwrite
+	}
+	return []string{
+		"SKILLSYNC_SOURCE_PLATFORM=" + string(source),
+		"SKILLSYNC_TARGET_PLATFORM=" + string(target),
+		"SKILLSYNC_TARGET_DIR=" + targetDir,
+		"SKILLSYNC_STRATEGY=" + string(strategy),
+	}
+}
+
+// skillHookEnv adds the metadata of a single skill write to base.
+func skillHookEnv(base []string, skill model.Skill, action Action, targetPath string) []string {
+	return append(base[:len(base):len(base)],
+		"SKILLSYNC_SKILL_NAME="+skill.Name,
+		"SKILLSYNC_SKILL_DESCRIPTION="+skill.Description,
+		"SKILLSYNC_SKILL_SCOPE="+string(skill.Scope),
+		"SKILLSYNC_SKILL_TYPE="+string(skill.Type),
+		"SKILLSYNC_SKILL_SOURCE="+skill.Path,
+		"SKILLSYNC_SKILL_PATH="+targetPath,
+		"SKILLSYNC_ACTION="+string(action),
+	)
+}
+
+// resultHookEnv adds the outcome counts of a sync to base.
+func resultHookEnv(base []string, r *Result) []string {
+	count := func(name string, n int) string { return "SKILLSYNC_" + name + "=" + strconv.Itoa(n) }
+	return append(base[:len(base):len(base)],
+		count("CREATED", len(r.Created())),
+		count("UPDATED", len(r.Updated())),
+		count("MERGED", len(r.Merged())),
+		count("UNCHANGED", len(r.Unchanged())),
+		count("SKIPPED", len(r.Skipped())),
+		count("CONFLICTS", len(r.Conflicts())),
+		count("FAILED", len(r.Failed())),
+		count("CHANGED", r.TotalChanged()),
+	)
+}
@@ -0,0 +1,151 @@
+package sync
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSyncWithSkills_Hooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in this test use POSIX sh")
+	}
+
+	skills := []model.Skill{
+		{Name: "alpha", Platform: model.Cursor, Path: "/src/alpha.md", Content: "Alpha"},
+		{Name: "beta", Platform: model.Cursor, Path: "/src/beta.md", Content: "Beta"},
+	}
+
+	tests := map[string]struct {
+		hooks       func(log string) Hooks
+		dryRun      bool
+		wantErr     bool
+		wantFailed  int
+		wantCreated int
+		wantLog     []string
+		wantHookErr bool
+	}{
+		"all events in order": {
+			hooks: func(log string) Hooks {
+				return Hooks{
+					PreSync:   []string{`echo "pre_sync $SKILLSYNC_SOURCE_PLATFORM>$SKILLSYNC_TARGET_PLATFORM" >> ` + log},
+					PreSkill:  []string{`echo "pre_skill $SKILLSYNC_SKILL_NAME $SKILLSYNC_ACTION" >> ` + log},
+					PostSkill: []string{`echo "post_skill $SKILLSYNC_SKILL_NAME $(basename $SKILLSYNC_SKILL_PATH)" >> ` + log},
+					PostSync:  []string{`echo "post_sync created=$SKILLSYNC_CREATED failed=$SKILLSYNC_FAILED" >> ` + log},
+				}
+			},
+			wantCreated: 2,
+			wantLog: []string{
+				"pre_sync cursor>claude-code",
+				"pre_skill alpha created",
+				"post_skill alpha alpha.md",
+				"pre_skill beta created",
+				"post_skill beta beta.md",
+				"post_sync created=2 failed=0",
+			},
+		},
+		"pre_sync failure aborts": {
+			hooks: func(log string) Hooks {
+				return Hooks{
+					PreSync:  []string{"exit 3"},
+					PreSkill: []string{"echo pre_skill >> " + log},
+				}
+			},
+			wantErr: true,
+		},
+		"pre_skill failure fails the skill": {
+			hooks: func(string) Hooks {
+				return Hooks{PreSkill: []string{`test "$SKILLSYNC_SKILL_NAME" != beta`}}
+			},
+			wantCreated: 1,
+			wantFailed:  1,
+		},
+		"post_skill failure keeps the write": {
+			hooks: func(string) Hooks {
+				return Hooks{PostSkill: []string{"exit 1"}}
+			},
+			wantCreated: 2,
+		},
+		"post_sync failure is recorded": {
+			hooks: func(string) Hooks {
+				return Hooks{PostSync: []string{"exit 1"}}
+			},
+			wantCreated: 2,
+			wantHookErr: true,
+		},
+		"dry run skips hooks": {
+			hooks: func(log string) Hooks {
+				return Hooks{
+					PreSync:   []string{"echo pre_sync >> " + log},
+					PostSkill: []string{"echo post_skill >> " + log},
+				}
+			},
+			dryRun:      true,
+			wantCreated: 2,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			targetDir := t.TempDir()
+			logPath := filepath.Join(t.TempDir(), "hooks.log")
+
+			hooks := tt.hooks(logPath)
+			var output bytes.Buffer
+			hooks.Output = &output
+
+			result, err := New().SyncWithSkills(skills, model.ClaudeCode, Options{
+				Strategy:   StrategyOverwrite,
+				TargetPath: targetDir,
+				DryRun:     tt.dryRun,
+				Hooks:      hooks,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SyncWithSkills() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if len(result.Skills) != 0 {
+					t.Errorf("expected no skills processed after pre_sync failure, got %d", len(result.Skills))
+				}
+				if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+					t.Error("expected no further hooks to run after pre_sync failure")
+				}
+				return
+			}
+
+			util.AssertEqual(t, len(result.Created()), tt.wantCreated)
+			util.AssertEqual(t, len(result.Failed()), tt.wantFailed)
+			util.AssertEqual(t, len(result.HookErrors) > 0, tt.wantHookErr)
+
+			var gotLog []string
+			if data, err := os.ReadFile(logPath); err == nil {
+				gotLog = strings.Split(strings.TrimSpace(string(data)), "\n")
+			}
+			util.AssertEqual(t, strings.Join(gotLog, "\n"), strings.Join(tt.wantLog, "\n"))
+		})
+	}
+}
+
+func TestSyncWithSkills_PostSkillHookError(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in this test use POSIX sh")
+	}
+
+	skill := model.Skill{Name: "alpha", Platform: model.Cursor, Path: "/src/alpha.md", Content: "Alpha"}
+	result, err := New().SyncWithSkills([]model.Skill{skill}, model.ClaudeCode, Options{
+		Strategy:   StrategyOverwrite,
+		TargetPath: t.TempDir(),
+		Hooks:      Hooks{PostSkill: []string{"echo formatting failed >&2; exit 2"}, Output: &bytes.Buffer{}},
+	})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, result.Skills[0].Action, ActionCreated)
+	if !strings.Contains(result.Skills[0].Message, "post_skill hook") {
+		t.Errorf("expected post_skill failure in message, got %q", result.Skills[0].Message)
+	}
+}
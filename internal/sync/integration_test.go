@@ -427,16 +427,16 @@ Content that stays the same.
 	util.AssertNoError(t, err)
 	util.AssertEqual(t, len(result1.Created()), 1)
 
-	// Second sync - skips (already exists)
+	// Second sync - unchanged (identical content already in target)
 	result2, err := s.Sync(model.ClaudeCode, model.Cursor, opts)
 	util.AssertNoError(t, err)
-	util.AssertEqual(t, len(result2.Skipped()), 1)
+	util.AssertEqual(t, len(result2.Unchanged()), 1)
 	util.AssertEqual(t, len(result2.Created()), 0)
 
-	// Third sync - still skips
+	// Third sync - still unchanged
 	result3, err := s.Sync(model.ClaudeCode, model.Cursor, opts)
 	util.AssertNoError(t, err)
-	util.AssertEqual(t, len(result3.Skipped()), 1)
+	util.AssertEqual(t, len(result3.Unchanged()), 1)
 }
 
 func TestIntegration_ResultSummary_Golden(t *testing.T) {
@@ -0,0 +1,89 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/parser/aider"
+)
+
+// Mode selects how sync puts skills on the target platform.
+type Mode string
+
+const (
+	// ModeCopy writes a copy of each skill, converted for the target
+	// platform. It is the default.
+	ModeCopy Mode = "copy"
+	// ModeLink creates a symlink to each source skill instead, so the skill
+	// has a single canonical file on disk. Skills the target platform needs
+	// converted cannot be linked.
+	ModeLink Mode = "link"
+)
+
+// CheckLinkTarget reports whether the target platform reads skills through
+// symlinks at all, before any skill is linked.
+func CheckLinkTarget(platform model.Platform) error {
+	if platform == model.Aider {
+		return fmt.Errorf("%s reads skills from %s, not from linked skill files", platform, aider.ConventionsFileName)
+	}
+	return nil
+}
+
+// linkSource returns the absolute path the target entry of a linked skill
+// points to: the source skill's directory or file. It fails for skills the
+// target cannot use as they are: skills spliced into a shared file, and
+// single-file skills whose file name or content the target platform changes.
+func linkSource(source model.Skill, sourceType SourceType, sourceRoot string, target model.Platform, transformed model.Skill, aggregated bool) (string, error) {
+	if err := CheckLinkTarget(target); err != nil {
+		return "", err
+	}
+	if aggregated {
+		return "", fmt.Errorf("%s keeps skills in %s, which cannot link to one skill", target, aggregateFileName(target))
+	}
+	if sourceType == SourceTypeFile {
+		if filepath.Ext(transformed.Path) != filepath.Ext(source.Path) {
+			return "", fmt.Errorf("%s needs %s as a %s file; sync it without --link", target, source.Name, filepath.Ext(transformed.Path))
+		}
+		// #nosec G304 - source.Path is a discovered skill file
+		raw, err := os.ReadFile(source.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", source.Path, err)
+		}
+		if !readsUnconverted(string(raw), transformed.Content) {
+			return "", fmt.Errorf("%s needs %s converted to its frontmatter; sync it without --link", target, source.Name)
+		}
+	}
+	return filepath.Abs(sourceRoot)
+}
+
+// readsUnconverted reports whether a platform reading the raw source file
+// sees the same skill as in its converted content: the same body, and every
+// frontmatter field of the conversion with the same value. Fields only the
+// source has are ignored by the target.
+func readsUnconverted(raw, converted string) bool {
+	source, target := parser.SplitFrontmatter([]byte(raw)), parser.SplitFrontmatter([]byte(converted))
+	if parser.NormalizeContent(source.Content) != parser.NormalizeContent(target.Content) {
+		return false
+	}
+	sourceFields, err := parser.ParseYAMLFrontmatter(source.Frontmatter)
+	if err != nil {
+		return false
+	}
+	targetFields, err := parser.ParseYAMLFrontmatter(target.Frontmatter)
+	if err != nil {
+		return false
+	}
+	for key, value := range targetFields {
+		if key == "type" && value == string(model.SkillTypeSkill) {
+			continue // the default when the field is missing
+		}
+		sourceValue, ok := sourceFields[key]
+		if !ok || fmt.Sprint(sourceValue) != fmt.Sprint(value) {
+			return false
+		}
+	}
+	return true
+}
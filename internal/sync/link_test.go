@@ -0,0 +1,107 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser/claude"
+	"github.com/klauern/skillsync/internal/parser/cursor"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSyncWithSkills_Link(t *testing.T) {
+	claudeDir := t.TempDir()
+	util.WriteFile(t, filepath.Join(claudeDir, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint code\n---\nRun the linter.\n")
+	util.WriteFile(t, filepath.Join(claudeDir, "review.md"), "---\nname: review\ndescription: Review code\n---\nRead the diff.\n")
+	cursorDir := t.TempDir()
+	util.WriteFile(t, filepath.Join(cursorDir, "style.mdc"), "---\ndescription: Style\nglobs: \"*.go\"\n---\nUse gofmt.\n")
+
+	claudeSkills, err := claude.New(claudeDir).Parse()
+	util.AssertNoError(t, err)
+	cursorSkills, err := cursor.New(cursorDir).Parse()
+	util.AssertNoError(t, err)
+	skill := func(skills []model.Skill, name string) model.Skill {
+		for _, s := range skills {
+			if s.Name == name {
+				return s
+			}
+		}
+		t.Fatalf("skill %s not parsed", name)
+		return model.Skill{}
+	}
+
+	tests := map[string]struct {
+		skill    model.Skill
+		target   model.Platform
+		wantLink string
+		wantErr  string
+	}{
+		"directory skill": {
+			skill:    skill(claudeSkills, "lint"),
+			target:   model.Cursor,
+			wantLink: filepath.Join(claudeDir, "lint"),
+		},
+		"single-file skill the target reads as it is": {
+			skill:    skill(claudeSkills, "review"),
+			target:   model.Cursor,
+			wantLink: filepath.Join(claudeDir, "review.md"),
+		},
+		"skill the target converts": {
+			skill:   skill(cursorSkills, "style"),
+			target:  model.ClaudeCode,
+			wantErr: "as a .md file",
+		},
+		"skill aggregated into AGENTS.md": {
+			skill:   skill(claudeSkills, "review"),
+			target:  model.Codex,
+			wantErr: "keeps skills in AGENTS.md",
+		},
+		"aider": {
+			skill:   skill(claudeSkills, "lint"),
+			target:  model.Aider,
+			wantErr: "not from linked skill files",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			targetDir := t.TempDir()
+			opts := Options{Strategy: StrategyOverwrite, TargetPath: targetDir, Mode: ModeLink}
+
+			result, err := New().SyncWithSkills([]model.Skill{tt.skill}, tt.target, opts)
+			util.AssertNoError(t, err)
+			sr := result.Skills[0]
+			if tt.wantErr != "" {
+				util.AssertEqual(t, sr.Action, ActionFailed)
+				if sr.Error == nil || !strings.Contains(sr.Error.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", sr.Error, tt.wantErr)
+				}
+				return
+			}
+			util.AssertEqual(t, sr.Action, ActionCreated)
+			link, err := os.Readlink(sr.TargetPath)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, link, tt.wantLink)
+
+			// A second sync finds the link in place
+			result, err = New().SyncWithSkills([]model.Skill{tt.skill}, tt.target, opts)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, result.Skills[0].Action, ActionUnchanged)
+
+			// Copying replaces the link without touching the source
+			opts.Mode = ModeCopy
+			result, err = New().SyncWithSkills([]model.Skill{tt.skill}, tt.target, opts)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, result.Skills[0].Action, ActionUpdated)
+			info, err := os.Lstat(sr.TargetPath)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, info.Mode()&os.ModeSymlink, os.FileMode(0))
+			if _, err := os.Stat(tt.wantLink); err != nil {
+				t.Errorf("source skill removed: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/parser"
+)
+
+// MetricsKey is the frontmatter field holding the metrics skillsync keeps
+// up to date in skill files when sync.metrics is enabled.
+const MetricsKey = "skillsync-metrics"
+
+// Metrics are computed facts about a skill, written into its frontmatter so
+// platform users can see a skill's size without running skillsync.
+type Metrics struct {
+	Words int `yaml:"words"`
+	// Tokens is a rough estimate of four characters per token
+	Tokens int `yaml:"tokens"`
+	// LastSynced is when sync last wrote the skill, in RFC 3339 format
+	LastSynced string `yaml:"last-synced,omitempty"`
+}
+
+// ComputeMetrics returns the word count and token estimate of the skill
+// content after its frontmatter.
+func ComputeMetrics(content string) Metrics {
+	body := parser.SplitFrontmatter([]byte(content)).Content
+	return Metrics{
+		Words:  len(strings.Fields(body)),
+		Tokens: (utf8.RuneCountInString(body) + 3) / 4,
+	}
+}
+
+// ReadMetrics returns the metrics block of content, if it has one.
+func ReadMetrics(content string) (Metrics, bool) {
+	result := parser.SplitFrontmatter([]byte(content))
+	if !result.HasFrontmatter {
+		return Metrics{}, false
+	}
+	var fm struct {
+		Metrics *Metrics `yaml:"skillsync-metrics"`
+	}
+	if err := yaml.Unmarshal(result.Frontmatter, &fm); err != nil || fm.Metrics == nil {
+		return Metrics{}, false
+	}
+	return *fm.Metrics, true
+}
+
+// SetMetrics replaces the metrics block in the YAML frontmatter of content,
+// adding it after the other fields when there is none. The rest of the
+// frontmatter is kept as written. Content without frontmatter is returned
+// unchanged.
+func SetMetrics(content string, metrics Metrics) string {
+	if !strings.HasPrefix(content, "---\n") {
+		return content
+	}
+	lines := strings.SplitAfter(content, "\n")
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\r\n") == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return content
+	}
+
+	var sb strings.Builder
+	sb.WriteString(lines[0])
+	for i := 1; i < end; i++ {
+		if strings.HasPrefix(lines[i], MetricsKey+":") {
+			// Drop the old block along with its indented fields
+			for i+1 < end && (strings.HasPrefix(lines[i+1], " ") || strings.HasPrefix(lines[i+1], "\t")) {
+				i++
+			}
+			continue
+		}
+		sb.WriteString(lines[i])
+	}
+	sb.WriteString(MetricsKey + ":\n")
+	sb.WriteString(fmt.Sprintf("  words: %d\n", metrics.Words))
+	sb.WriteString(fmt.Sprintf("  tokens: %d\n", metrics.Tokens))
+	if metrics.LastSynced != "" {
+		sb.WriteString(fmt.Sprintf("  last-synced: %q\n", metrics.LastSynced))
+	}
+	sb.WriteString(strings.Join(lines[end:], ""))
+	return sb.String()
+}
+
+// RefreshMetrics recomputes the metrics block of content, keeping its
+// last-synced time. It reports false for content without frontmatter, which
+// has nowhere to hold the block.
+func RefreshMetrics(content string) (string, bool) {
+	if !parser.SplitFrontmatter([]byte(content)).HasFrontmatter {
+		return content, false
+	}
+	metrics := ComputeMetrics(content)
+	if previous, ok := ReadMetrics(content); ok {
+		metrics.LastSynced = previous.LastSynced
+	}
+	return SetMetrics(content, metrics), true
+}
+
+// withSyncMetrics adds the metrics block to content about to be written to
+// targetPath. The target's last-synced time is kept when nothing else about
+// it would change, so unchanged skills are not rewritten just to bump it.
+func withSyncMetrics(content, targetPath string, now time.Time) string {
+	metrics := ComputeMetrics(content)
+	// #nosec G304 - targetPath is built from the target directory
+	if existing, err := os.ReadFile(targetPath); err == nil {
+		if previous, ok := ReadMetrics(string(existing)); ok && previous.LastSynced != "" {
+			metrics.LastSynced = previous.LastSynced
+			if candidate := SetMetrics(content, metrics); candidate == string(existing) {
+				return candidate
+			}
+		}
+	}
+	metrics.LastSynced = now.UTC().Format(time.RFC3339)
+	return SetMetrics(content, metrics)
+}
@@ -0,0 +1,113 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestComputeMetrics(t *testing.T) {
+	got := ComputeMetrics("---\nname: lint\ndescription: a b c d e\n---\nRun the linter now.\n")
+	if got.Words != 4 {
+		t.Errorf("Words = %d, want 4", got.Words)
+	}
+	if got.Tokens != 5 { // 20 characters
+		t.Errorf("Tokens = %d, want 5", got.Tokens)
+	}
+}
+
+func TestSetMetrics(t *testing.T) {
+	metrics := Metrics{Words: 4, Tokens: 5, LastSynced: "2026-10-17T09:30:00Z"}
+	block := "skillsync-metrics:\n  words: 4\n  tokens: 5\n  last-synced: \"2026-10-17T09:30:00Z\"\n"
+
+	tests := map[string]struct {
+		content string
+		want    string
+	}{
+		"adds block": {
+			content: "---\nname: lint\n---\nBody\n",
+			want:    "---\nname: lint\n" + block + "---\nBody\n",
+		},
+		"replaces block in place of the old one": {
+			content: "---\nname: lint\nskillsync-metrics:\n  words: 1\n  tokens: 1\ndescription: x\n---\nBody\n",
+			want:    "---\nname: lint\ndescription: x\n" + block + "---\nBody\n",
+		},
+		"no frontmatter": {
+			content: "Body\n",
+			want:    "Body\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := SetMetrics(tt.content, metrics)
+			util.AssertEqual(t, got, tt.want)
+			if tt.content != tt.want {
+				read, ok := ReadMetrics(got)
+				if !ok || read != metrics {
+					t.Errorf("ReadMetrics() = %+v, %v, want %+v", read, ok, metrics)
+				}
+			}
+		})
+	}
+}
+
+func TestRefreshMetrics(t *testing.T) {
+	content := "---\nname: lint\nskillsync-metrics:\n  words: 1\n  tokens: 1\n  last-synced: \"2026-10-17T09:30:00Z\"\n---\nRun the linter now.\n"
+
+	got, ok := RefreshMetrics(content)
+	if !ok {
+		t.Fatal("RefreshMetrics() should handle content with frontmatter")
+	}
+	metrics, _ := ReadMetrics(got)
+	util.AssertEqual(t, metrics, Metrics{Words: 4, Tokens: 5, LastSynced: "2026-10-17T09:30:00Z"})
+
+	if _, ok := RefreshMetrics("No frontmatter\n"); ok {
+		t.Error("RefreshMetrics() should skip content without frontmatter")
+	}
+}
+
+func TestSyncWithSkills_Metrics(t *testing.T) {
+	sourceDir := t.TempDir()
+	targetDir := t.TempDir()
+	skillPath := filepath.Join(sourceDir, "lint.md")
+	util.WriteFile(t, skillPath, "---\nname: lint\n---\nRun the linter now.\n")
+	skill := model.Skill{Name: "lint", Platform: model.ClaudeCode, Path: skillPath, Content: "Run the linter now.\n"}
+	opts := Options{Strategy: StrategyOverwrite, TargetPath: targetDir, Metrics: true}
+
+	first, err := New().SyncWithSkills([]model.Skill{skill}, model.Cursor, opts)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(first.Created()), 1)
+
+	written, err := os.ReadFile(first.Skills[0].TargetPath)
+	util.AssertNoError(t, err)
+	metrics, ok := ReadMetrics(string(written))
+	if !ok || metrics.Words != 4 {
+		t.Fatalf("target metrics = %+v, %v:\n%s", metrics, ok, written)
+	}
+	if _, err := time.Parse(time.RFC3339, metrics.LastSynced); err != nil {
+		t.Errorf("last-synced = %q, want an RFC 3339 time", metrics.LastSynced)
+	}
+
+	// Only last-synced would change, so the skill is left alone
+	second, err := New().SyncWithSkills([]model.Skill{skill}, model.Cursor, opts)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(second.Unchanged()), 1)
+
+	// Source metrics are never copied over the target's
+	skill.Metadata = map[string]string{MetricsKey: "map[words:1]"}
+	skill.Content = "Run the linter now, please.\n"
+	third, err := New().SyncWithSkills([]model.Skill{skill}, model.Cursor, opts)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(third.Updated()), 1)
+	written, err = os.ReadFile(third.Skills[0].TargetPath)
+	util.AssertNoError(t, err)
+	if strings.Count(string(written), MetricsKey) != 1 || !strings.Contains(string(written), "words: 5") {
+		t.Errorf("target should hold one fresh metrics block:\n%s", written)
+	}
+}
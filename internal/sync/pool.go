@@ -0,0 +1,114 @@
+package sync
+
+import (
+	"log/slog"
+	gosync "sync"
+	"time"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// ProgressEvent reports that a single skill finished processing.
+type ProgressEvent struct {
+	// Index is the position of the skill in the input slice.
+	Index int
+
+	// Result is the outcome for the skill.
+	Result SkillResult
+
+	// Completed is the number of skills finished so far, including this one.
+	Completed int
+
+	// Total is the number of skills being processed.
+	Total int
+}
+
+// processSkills runs processSkill for every skill and returns results in input
+// order. With opts.Concurrency > 1, skills are processed by a bounded pool of
+// workers. Skills sharing a name are always handled by the same worker in
+// input order, so writes to the same target entry never race and the last
+// one wins exactly as in sequential mode.
+func (s *Synchronizer) processSkills(
+	skills []model.Skill,
+	targetPlatform model.Platform,
+	targetPath string,
+	existingSkills map[string]model.Skill,
+	renames map[string]Rename,
+	opts Options,
+) []SkillResult {
+	results := make([]SkillResult, len(skills))
+
+	var mu gosync.Mutex
+	completed := 0
+	process := func(i int) {
+		started := time.Now()
+		result := s.processSkill(skills[i], targetPlatform, targetPath, existingSkills, renames, opts)
+		result.Duration = time.Since(started)
+		results[i] = result
+		logging.Info("processed skill",
+			logging.Skill(result.Skill.Name),
+			slog.String("action", string(result.Action)),
+			logging.Path(result.TargetPath),
+			slog.Duration(logging.KeyDuration, result.Duration),
+		)
+		if opts.Progress == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		completed++
+		opts.Progress(ProgressEvent{
+			Index:     i,
+			Result:    result,
+			Completed: completed,
+			Total:     len(skills),
+		})
+	}
+
+	groups := groupSkillIndexesByName(skills)
+	workers := min(opts.Concurrency, len(groups))
+	if workers < 2 {
+		for i := range skills {
+			process(i)
+		}
+		return results
+	}
+
+	jobs := make(chan []int)
+	var wg gosync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range jobs {
+				for _, i := range group {
+					process(i)
+				}
+			}
+		}()
+	}
+	for _, group := range groups {
+		jobs <- group
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// groupSkillIndexesByName groups skill indexes by skill name, ordered by each
+// name's first appearance.
+func groupSkillIndexesByName(skills []model.Skill) [][]int {
+	groups := make([][]int, 0, len(skills))
+	byName := make(map[string]int, len(skills))
+	for i, skill := range skills {
+		if g, ok := byName[skill.Name]; ok {
+			groups[g] = append(groups[g], i)
+			continue
+		}
+		byName[skill.Name] = len(groups)
+		groups = append(groups, []int{i})
+	}
+	return groups
+}
@@ -0,0 +1,133 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func TestGroupSkillIndexesByName(t *testing.T) {
+	skills := []model.Skill{{Name: "a"}, {Name: "b"}, {Name: "a"}, {Name: "c"}, {Name: "b"}}
+	got := groupSkillIndexesByName(skills)
+	want := [][]int{{0, 2}, {1, 4}, {3}}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("groupSkillIndexesByName() = %v, want %v", got, want)
+	}
+}
+
+func TestSyncWithSkills_Concurrency(t *testing.T) {
+	const count = 40
+
+	skills := make([]model.Skill, 0, count+1)
+	for i := range count {
+		skills = append(skills, model.Skill{
+			Name:     fmt.Sprintf("skill-%02d", i),
+			Platform: model.Cursor,
+			Path:     fmt.Sprintf("/nonexistent/skill-%02d.md", i),
+			Content:  fmt.Sprintf("Body %d", i),
+		})
+	}
+	// A duplicate name must be written after the first occurrence, as in sequential mode.
+	skills = append(skills, model.Skill{
+		Name:     "skill-00",
+		Platform: model.Cursor,
+		Path:     "/nonexistent/skill-00.md",
+		Content:  "Last write wins",
+	})
+
+	tests := map[string]struct {
+		concurrency int
+	}{
+		"sequential": {concurrency: 0},
+		"one worker": {concurrency: 1},
+		"pool":       {concurrency: 8},
+		"oversized":  {concurrency: 1000},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			targetDir := t.TempDir()
+
+			var events []ProgressEvent
+			opts := Options{
+				Strategy:    StrategyOverwrite,
+				TargetPath:  targetDir,
+				Concurrency: tt.concurrency,
+				Progress:    func(e ProgressEvent) { events = append(events, e) },
+			}
+
+			result, err := New().SyncWithSkills(skills, model.ClaudeCode, opts)
+			if err != nil {
+				t.Fatalf("SyncWithSkills() error = %v", err)
+			}
+
+			if len(result.Skills) != len(skills) {
+				t.Fatalf("got %d results, want %d", len(result.Skills), len(skills))
+			}
+			for i, r := range result.Skills {
+				if r.Skill.Name != skills[i].Name || r.Skill.Content != skills[i].Content {
+					t.Errorf("result %d is %q, want %q (ordering not preserved)", i, r.Skill.Name, skills[i].Name)
+				}
+				if r.Action == ActionFailed {
+					t.Errorf("result %d failed: %v", i, r.Error)
+				}
+				if r.Duration <= 0 || r.BytesWritten < int64(len(r.Skill.Content)) {
+					t.Errorf("result %d: Duration=%v BytesWritten=%d", i, r.Duration, r.BytesWritten)
+				}
+			}
+
+			if len(events) != len(skills) {
+				t.Fatalf("got %d progress events, want %d", len(events), len(skills))
+			}
+			seen := make(map[int]bool, len(events))
+			for i, e := range events {
+				if e.Completed != i+1 || e.Total != len(skills) {
+					t.Errorf("event %d: Completed=%d Total=%d", i, e.Completed, e.Total)
+				}
+				if seen[e.Index] {
+					t.Errorf("index %d reported twice", e.Index)
+				}
+				seen[e.Index] = true
+				if e.Result.Skill.Name != skills[e.Index].Name {
+					t.Errorf("event for index %d carries %q", e.Index, e.Result.Skill.Name)
+				}
+			}
+
+			data, err := os.ReadFile(filepath.Join(targetDir, "skill-00.md"))
+			if err != nil {
+				t.Fatalf("failed to read synced skill: %v", err)
+			}
+			if !strings.Contains(string(data), "Last write wins") {
+				t.Errorf("duplicate skill not written in input order:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestSync_ConcurrentParse(t *testing.T) {
+	sourceDir := t.TempDir()
+	targetDir := t.TempDir()
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		if err := os.WriteFile(filepath.Join(sourceDir, name+".md"), []byte("# "+name), 0o600); err != nil {
+			t.Fatalf("failed to write skill: %v", err)
+		}
+	}
+
+	result, err := New().Sync(model.Cursor, model.ClaudeCode, Options{
+		Strategy:    StrategyOverwrite,
+		SourcePath:  sourceDir,
+		TargetPath:  targetDir,
+		Concurrency: 4,
+	})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(result.Created()) != 3 {
+		t.Errorf("expected 3 created skills, got %d", len(result.Created()))
+	}
+}
@@ -0,0 +1,204 @@
+package sync
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+var (
+	// mentionPattern matches @path file mentions such as "See @docs/style.md".
+	// The path must end in a file extension so @username mentions and email
+	// addresses are not mistaken for references.
+	mentionPattern = regexp.MustCompile(`(?:^|[\s(\[])@((?:~/|\.{1,2}/|/)?[\w.-]+(?:/[\w.-]+)*\.\w+)`)
+	// linkPattern matches inline Markdown links and images: [text](target "title")
+	linkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+)
+
+// Reference is a file a skill refers to from its content.
+type Reference struct {
+	// Raw is the reference as written, without the @ of a mention.
+	Raw string
+	// Mention is true for @path mentions and false for Markdown links.
+	Mention bool
+}
+
+// BrokenReference is a reference that resolves to an existing file from the
+// source skill but not from where the skill is written on the target.
+type BrokenReference struct {
+	Reference
+	// Resolved is the absolute path the reference points to from the source.
+	Resolved string
+}
+
+// ExtractReferences returns the local file references in skill content:
+// @path mentions and relative Markdown links. URLs, anchors, and anything
+// inside fenced code blocks or inline code are ignored.
+func ExtractReferences(content string) []Reference {
+	seen := make(map[Reference]bool)
+	var refs []Reference
+	add := func(ref Reference) {
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	inFence := false
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		line = stripInlineCode(line)
+
+		for _, match := range mentionPattern.FindAllStringSubmatch(line, -1) {
+			add(Reference{Raw: match[1], Mention: true})
+		}
+		for _, match := range linkPattern.FindAllStringSubmatch(line, -1) {
+			target := match[1]
+			if i := strings.IndexAny(target, "#?"); i >= 0 {
+				target = target[:i]
+			}
+			if target == "" || strings.Contains(target, ":") {
+				continue // Anchors and URLs
+			}
+			add(Reference{Raw: target})
+		}
+	}
+	return refs
+}
+
+// stripInlineCode blanks out `code spans` so examples are not treated as references.
+func stripInlineCode(line string) string {
+	parts := strings.Split(line, "`")
+	for i := 1; i < len(parts); i += 2 {
+		if i < len(parts)-1 { // An unmatched backtick does not open a span
+			parts[i] = ""
+		}
+	}
+	return strings.Join(parts, "`")
+}
+
+// ResolveReference returns the absolute path a reference in the skill file at
+// skillPath points to on the given platform. Markdown links, and @ mentions on
+// Claude Code and Codex, are relative to the skill file. Cursor resolves @
+// mentions from the workspace root, the directory holding .cursor.
+func ResolveReference(ref Reference, skillPath string, platform model.Platform) string {
+	path := filepath.FromSlash(ref.Raw)
+	switch {
+	case strings.HasPrefix(ref.Raw, "~/"):
+		return filepath.Join(util.Paths().HomeDir(), path[2:])
+	case filepath.IsAbs(path):
+		return filepath.Clean(path)
+	}
+
+	base := filepath.Dir(skillPath)
+	if ref.Mention && platform == model.Cursor {
+		if root := workspaceRoot(skillPath, ".cursor"); root != "" {
+			base = root
+		}
+	}
+	return filepath.Join(base, path)
+}
+
+// workspaceRoot returns the parent of the nearest ancestor directory named
+// dirName, or "" if path is not inside one.
+func workspaceRoot(path, dirName string) string {
+	for dir := filepath.Dir(path); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if filepath.Base(dir) == dirName {
+			return filepath.Dir(dir)
+		}
+	}
+	return ""
+}
+
+// findBrokenReferences returns the references of source that exist from the
+// source file but would not from targetFile on the target platform. When the
+// skill directory is copied, sourceRoot and targetRoot map files inside it,
+// which do not exist on the target until the copy is made.
+func findBrokenReferences(source model.Skill, targetFile string, target model.Platform, sourceRoot, targetRoot string) []BrokenReference {
+	var broken []BrokenReference
+	for _, ref := range ExtractReferences(source.Content) {
+		resolved := ResolveReference(ref, source.Path, source.Platform)
+		if !pathExists(resolved) {
+			continue // Already broken on the source; validate reports those
+		}
+
+		onTarget := ResolveReference(ref, targetFile, target)
+		if targetRoot != "" {
+			if rel, err := filepath.Rel(targetRoot, onTarget); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				onTarget = filepath.Join(sourceRoot, rel)
+			}
+		}
+		if !pathExists(onTarget) {
+			broken = append(broken, BrokenReference{Reference: ref, Resolved: resolved})
+		}
+	}
+	return broken
+}
+
+// RewriteReferences replaces broken references in content with the absolute
+// paths they resolve to from the source, so they keep working on the target.
+func (t *Transformer) RewriteReferences(content string, broken []BrokenReference) string {
+	if len(broken) == 0 {
+		return content
+	}
+	mentions := make(map[string]string)
+	links := make(map[string]string)
+	for _, ref := range broken {
+		replacement := filepath.ToSlash(ref.Resolved)
+		if ref.Mention {
+			mentions[ref.Raw] = replacement
+		} else {
+			links[ref.Raw] = replacement
+		}
+	}
+
+	inFence := false
+	lines := strings.SplitAfter(content, "\n")
+	for i, line := range lines {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		line = mentionPattern.ReplaceAllStringFunc(line, func(match string) string {
+			at := strings.Index(match, "@")
+			if replacement, ok := mentions[match[at+1:]]; ok {
+				return match[:at+1] + replacement
+			}
+			return match
+		})
+		line = linkPattern.ReplaceAllStringFunc(line, func(match string) string {
+			target := linkPattern.FindStringSubmatch(match)[1]
+			path, suffix := target, ""
+			if j := strings.IndexAny(target, "#?"); j >= 0 {
+				path, suffix = target[:j], target[j:]
+			}
+			if replacement, ok := links[path]; ok {
+				return strings.Replace(match, target, replacement+suffix, 1)
+			}
+			return match
+		})
+		lines[i] = line
+	}
+	return strings.Join(lines, "")
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,197 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestExtractReferences(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    []Reference
+	}{
+		"mention": {
+			content: "See @docs/style.md before editing.",
+			want:    []Reference{{Raw: "docs/style.md", Mention: true}},
+		},
+		"mentions in parentheses and brackets": {
+			content: "Style (@docs/style.md) and [@../api.yaml]",
+			want: []Reference{
+				{Raw: "docs/style.md", Mention: true},
+				{Raw: "../api.yaml", Mention: true},
+			},
+		},
+		"user mentions and emails are ignored": {
+			content: "Ask @alice or mail dev@example.com",
+		},
+		"links": {
+			content: "Read [the guide](guide.md#setup) and ![diagram](img/flow.png \"Flow\").",
+			want:    []Reference{{Raw: "guide.md"}, {Raw: "img/flow.png"}},
+		},
+		"urls and anchors are ignored": {
+			content: "[site](https://example.com/x.md) [top](#usage) [mail](mailto:a@b.co)",
+		},
+		"code is ignored": {
+			content: "Use `@docs/example.md` here.\n```\nSee @docs/fenced.md\n```\nSee @docs/real.md",
+			want:    []Reference{{Raw: "docs/real.md", Mention: true}},
+		},
+		"duplicates are reported once": {
+			content: "@a.md and @a.md",
+			want:    []Reference{{Raw: "a.md", Mention: true}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := ExtractReferences(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractReferences() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveReference(t *testing.T) {
+	repo := filepath.FromSlash("/work/repo")
+	home := filepath.FromSlash("/home/tester")
+	prev := util.SetPaths(&util.PathResolver{Home: func() string { return home }})
+	t.Cleanup(func() { util.SetPaths(prev) })
+	tests := map[string]struct {
+		ref       Reference
+		skillPath string
+		platform  model.Platform
+		want      string
+	}{
+		"claude code mention is relative to the skill": {
+			ref:       Reference{Raw: "docs/style.md", Mention: true},
+			skillPath: filepath.Join(repo, ".claude/skills/lint/SKILL.md"),
+			platform:  model.ClaudeCode,
+			want:      filepath.Join(repo, ".claude/skills/lint/docs/style.md"),
+		},
+		"cursor mention is relative to the workspace": {
+			ref:       Reference{Raw: "docs/style.md", Mention: true},
+			skillPath: filepath.Join(repo, ".cursor/rules/lint.mdc"),
+			platform:  model.Cursor,
+			want:      filepath.Join(repo, "docs/style.md"),
+		},
+		"cursor link is relative to the rule": {
+			ref:       Reference{Raw: "other.mdc"},
+			skillPath: filepath.Join(repo, ".cursor/rules/lint.mdc"),
+			platform:  model.Cursor,
+			want:      filepath.Join(repo, ".cursor/rules/other.mdc"),
+		},
+		"home": {
+			ref:       Reference{Raw: "~/docs/style.md"},
+			skillPath: filepath.Join(repo, ".claude/skills/lint/SKILL.md"),
+			platform:  model.ClaudeCode,
+			want:      filepath.Join(home, "docs/style.md"),
+		},
+		"absolute": {
+			ref:       Reference{Raw: "/etc/style.md", Mention: true},
+			skillPath: filepath.Join(repo, ".cursor/rules/lint.mdc"),
+			platform:  model.Cursor,
+			want:      filepath.FromSlash("/etc/style.md"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ResolveReference(tt.ref, tt.skillPath, tt.platform); got != tt.want {
+				t.Errorf("ResolveReference() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformer_RewriteReferences(t *testing.T) {
+	content := "See @docs/style.md and [guide](guide.md#setup).\n" +
+		"```\n@docs/style.md\n```\n" +
+		"Keep @other.md as is.\n"
+	broken := []BrokenReference{
+		{Reference: Reference{Raw: "docs/style.md", Mention: true}, Resolved: "/src/docs/style.md"},
+		{Reference: Reference{Raw: "guide.md"}, Resolved: "/src/guide.md"},
+	}
+
+	got := NewTransformer().RewriteReferences(content, broken)
+
+	want := "See @/src/docs/style.md and [guide](/src/guide.md#setup).\n" +
+		"```\n@docs/style.md\n```\n" +
+		"Keep @other.md as is.\n"
+	if got != want {
+		t.Errorf("RewriteReferences() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSynchronizer_SyncWithSkills_BrokenReferences(t *testing.T) {
+	tests := map[string]struct {
+		rewrite     bool
+		wantBroken  []string
+		wantContent string
+	}{
+		"reported": {
+			wantBroken:  []string{"docs/style.md", "guide.md"},
+			wantContent: "See @docs/style.md",
+		},
+		"rewritten": {
+			rewrite:     true,
+			wantContent: "See @" + filepath.ToSlash(filepath.Join("SOURCE", "docs/style.md")),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sourceDir := t.TempDir()
+			targetDir := t.TempDir()
+			for _, rel := range []string{"docs/style.md", "guide.md"} {
+				path := filepath.Join(sourceDir, rel)
+				if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+					t.Fatalf("failed to create directory: %v", err)
+				}
+				if err := os.WriteFile(path, []byte("reference"), 0o600); err != nil {
+					t.Fatalf("failed to write reference: %v", err)
+				}
+			}
+
+			content := "See @docs/style.md, [the guide](guide.md) and @missing.md.\n"
+			skillPath := filepath.Join(sourceDir, "lint.md")
+			if err := os.WriteFile(skillPath, []byte(content), 0o600); err != nil {
+				t.Fatalf("failed to write skill: %v", err)
+			}
+			skill := model.Skill{Name: "lint", Platform: model.ClaudeCode, Path: skillPath, Content: content}
+
+			result, err := New().SyncWithSkills([]model.Skill{skill}, model.Cursor, Options{
+				Strategy:          StrategyOverwrite,
+				TargetPath:        targetDir,
+				RewriteReferences: tt.rewrite,
+			})
+			if err != nil {
+				t.Fatalf("SyncWithSkills() error = %v", err)
+			}
+			if len(result.Skills) != 1 {
+				t.Fatalf("expected 1 skill result, got %d", len(result.Skills))
+			}
+
+			sr := result.Skills[0]
+			if !reflect.DeepEqual(sr.BrokenReferences, tt.wantBroken) {
+				t.Errorf("BrokenReferences = %v, want %v", sr.BrokenReferences, tt.wantBroken)
+			}
+			written, err := os.ReadFile(sr.TargetPath)
+			if err != nil {
+				t.Fatalf("failed to read target: %v", err)
+			}
+			want := strings.ReplaceAll(tt.wantContent, "SOURCE", filepath.ToSlash(sourceDir))
+			if !strings.Contains(string(written), want) {
+				t.Errorf("target content = %q, want it to contain %q", written, want)
+			}
+			if tt.rewrite && !strings.Contains(sr.Message, "rewrote 2 reference(s)") {
+				t.Errorf("Message = %q, want it to mention the rewrites", sr.Message)
+			}
+		})
+	}
+}
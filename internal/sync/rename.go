@@ -0,0 +1,167 @@
+package sync
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// DefaultRenameThreshold is the content similarity at which a target skill
+// missing from the source is taken to be a source skill under a new name.
+const DefaultRenameThreshold = 0.9
+
+// Rename is a target skill that a source skill under another name replaces.
+type Rename struct {
+	// From is the existing target skill.
+	From model.Skill
+
+	// To is the name of the source skill.
+	To string
+
+	// Similarity is the content similarity of the two skills (0.0-1.0).
+	Similarity float64
+}
+
+// DetectRenames pairs source skills missing from the target with target
+// skills missing from the source whose content is at least threshold similar.
+// Each target skill is paired at most once, with its most similar source
+// skill first. Renames are returned in source order.
+func DetectRenames(sourceSkills, targetSkills []model.Skill, threshold float64) []Rename {
+	if threshold <= 0 {
+		return nil
+	}
+
+	sourceNames := make(map[string]bool, len(sourceSkills))
+	for _, skill := range sourceSkills {
+		sourceNames[skill.Name] = true
+	}
+	targetNames := make(map[string]bool, len(targetSkills))
+	var orphans []model.Skill
+	for _, skill := range targetSkills {
+		targetNames[skill.Name] = true
+		if !sourceNames[skill.Name] {
+			orphans = append(orphans, skill)
+		}
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	type candidate struct {
+		source int
+		target int
+		score  float64
+	}
+	orphanLines := make([][]string, len(orphans))
+	for j, orphan := range orphans {
+		orphanLines[j] = similarityLines(orphan.Content)
+	}
+	var candidates []candidate
+	for i, source := range sourceSkills {
+		if targetNames[source.Name] {
+			continue
+		}
+		lines := similarityLines(source.Content)
+		for j := range orphans {
+			// Only the shorter side's lines can match, which bounds the score
+			shorter, total := min(len(lines), len(orphanLines[j])), len(lines)+len(orphanLines[j])
+			if total == 0 || 2*float64(shorter)/float64(total) < threshold {
+				continue
+			}
+			if score := lineSimilarity(lines, orphanLines[j]); score >= threshold {
+				candidates = append(candidates, candidate{source: i, target: j, score: score})
+			}
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(b.score, a.score)
+	})
+
+	bySource := make(map[int]Rename)
+	usedTargets := make(map[int]bool)
+	for _, c := range candidates {
+		if _, ok := bySource[c.source]; ok || usedTargets[c.target] {
+			continue
+		}
+		usedTargets[c.target] = true
+		bySource[c.source] = Rename{From: orphans[c.target], To: sourceSkills[c.source].Name, Similarity: c.score}
+	}
+
+	renames := make([]Rename, 0, len(bySource))
+	for i := range sourceSkills {
+		if r, ok := bySource[i]; ok {
+			renames = append(renames, r)
+		}
+	}
+	return renames
+}
+
+// renamesInTarget returns the renames whose old skill lives in targetPath,
+// keyed by the new name. Skills aggregated into a shared file are never
+// renamed.
+func renamesInTarget(sourceSkills []model.Skill, existingSkills map[string]model.Skill, target model.Platform, targetPath string, threshold float64) map[string]Rename {
+	if threshold <= 0 || aggregateFileName(target) != "" {
+		return nil
+	}
+	targetSkills := make([]model.Skill, 0, len(existingSkills))
+	for _, skill := range existingSkills {
+		if rel, err := filepath.Rel(targetPath, skill.Path); err == nil && filepath.IsLocal(rel) {
+			targetSkills = append(targetSkills, skill)
+		}
+	}
+	slices.SortFunc(targetSkills, func(a, b model.Skill) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	renames := make(map[string]Rename)
+	for _, r := range DetectRenames(sourceSkills, targetSkills, threshold) {
+		renames[r.To] = r
+	}
+	return renames
+}
+
+// lineSimilarity returns the share of lines a and b have in common, from 0.0
+// (nothing) to 1.0 (the same lines in the same order).
+func lineSimilarity(a, b []string) float64 {
+	total := len(a) + len(b)
+	if total == 0 {
+		return 0
+	}
+	x, y := internLines(a, b)
+	var matches []lineMatch
+	myersRange(x, y, 0, len(x), 0, len(y), &matches)
+	return 2 * float64(len(matches)) / float64(total)
+}
+
+// similarityLines returns the trimmed, non-blank lines of content, the lines
+// renames are detected by.
+func similarityLines(content string) []string {
+	var lines []string
+	for line := range strings.SplitSeq(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// removeRenamed removes the target entry of a skill that a renamed source
+// skill replaces, backing it up first when opts.Backup is set.
+func (s *Synchronizer) removeRenamed(old model.Skill, target model.Platform, opts Options, result *SkillResult) error {
+	_, entry := detectSourceType(old.Path)
+	if opts.Backup {
+		ids, err := backupTarget(entry, old, target, opts, "pre-rename backup", []string{"sync", "rename"})
+		if err != nil {
+			return err
+		}
+		result.BackupIDs = append(result.BackupIDs, ids...)
+	}
+	if err := removeExisting(entry); err != nil {
+		return fmt.Errorf("failed to remove %s after rename: %w", old.Name, err)
+	}
+	return nil
+}
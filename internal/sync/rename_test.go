@@ -0,0 +1,136 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestDetectRenames(t *testing.T) {
+	steps := "Run the linter.\nFix what it reports.\nRun it again.\nCommit the fixes.\n" +
+		"Push the branch.\nOpen a pull request.\nWait for CI.\nAddress review comments.\nMerge.\n"
+
+	tests := map[string]struct {
+		source    []model.Skill
+		target    []model.Skill
+		threshold float64
+		want      []string
+	}{
+		"identical content under a new name": {
+			source:    []model.Skill{{Name: "lint", Content: steps}},
+			target:    []model.Skill{{Name: "linter", Content: steps}},
+			threshold: DefaultRenameThreshold,
+			want:      []string{"linter -> lint"},
+		},
+		"one of ten lines changed": {
+			source:    []model.Skill{{Name: "lint", Content: steps + "Celebrate.\n"}},
+			target:    []model.Skill{{Name: "linter", Content: steps + "Go home.\n"}},
+			threshold: DefaultRenameThreshold,
+			want:      []string{"linter -> lint"},
+		},
+		"below the threshold": {
+			source:    []model.Skill{{Name: "lint", Content: steps}},
+			target:    []model.Skill{{Name: "review", Content: "Read the diff.\nLeave comments.\n"}},
+			threshold: DefaultRenameThreshold,
+		},
+		"target skill still in the source": {
+			source:    []model.Skill{{Name: "lint", Content: steps}, {Name: "linter", Content: steps}},
+			target:    []model.Skill{{Name: "linter", Content: steps}},
+			threshold: DefaultRenameThreshold,
+		},
+		"source skill already in the target": {
+			source:    []model.Skill{{Name: "lint", Content: steps}},
+			target:    []model.Skill{{Name: "lint", Content: "Old steps.\n"}, {Name: "linter", Content: steps}},
+			threshold: DefaultRenameThreshold,
+		},
+		"each target skill is renamed once": {
+			source:    []model.Skill{{Name: "lint", Content: steps}, {Name: "lint-go", Content: steps}},
+			target:    []model.Skill{{Name: "linter", Content: steps}},
+			threshold: DefaultRenameThreshold,
+			want:      []string{"linter -> lint"},
+		},
+		"disabled": {
+			source: []model.Skill{{Name: "lint", Content: steps}},
+			target: []model.Skill{{Name: "linter", Content: steps}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, r := range DetectRenames(tt.source, tt.target, tt.threshold) {
+				got = append(got, r.From.Name+" -> "+r.To)
+			}
+			util.AssertEqual(t, strings.Join(got, ", "), strings.Join(tt.want, ", "))
+		})
+	}
+}
+
+func TestSyncWithSkills_Rename(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	content := "Run the linter.\nFix what it reports.\nRun it again.\n"
+	sourceFile := filepath.Join(t.TempDir(), "lint", "SKILL.md")
+	util.WriteFile(t, sourceFile, "---\nname: lint\ndescription: Lint code\n---\n"+content)
+	source := model.Skill{Name: "lint", Platform: model.Cursor, Path: sourceFile, Content: content}
+
+	tests := map[string]struct {
+		threshold  float64
+		dryRun     bool
+		wantAction Action
+		wantOld    bool
+		wantNew    bool
+	}{
+		"renames the target skill": {
+			threshold:  DefaultRenameThreshold,
+			wantAction: ActionRenamed,
+			wantNew:    true,
+		},
+		"dry run": {
+			threshold:  DefaultRenameThreshold,
+			dryRun:     true,
+			wantAction: ActionRenamed,
+			wantOld:    true,
+		},
+		"detection off": {
+			wantAction: ActionCreated,
+			wantOld:    true,
+			wantNew:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			targetDir := t.TempDir()
+			util.WriteFile(t, filepath.Join(targetDir, "linter", "SKILL.md"),
+				"---\nname: linter\ndescription: Lint code\n---\n"+content)
+
+			result, err := New().SyncWithSkills([]model.Skill{source}, model.ClaudeCode, Options{
+				DryRun:          tt.dryRun,
+				Strategy:        StrategyOverwrite,
+				TargetPath:      targetDir,
+				Backup:          true,
+				RenameThreshold: tt.threshold,
+			})
+			util.AssertNoError(t, err)
+			sr := result.Skills[0]
+			util.AssertEqual(t, sr.Action, tt.wantAction)
+			if tt.wantAction == ActionRenamed {
+				util.AssertEqual(t, sr.RenamedFrom, "linter")
+				util.AssertEqual(t, len(result.Renamed()), 1)
+				util.AssertEqual(t, result.TotalChanged(), 1)
+			}
+			if !tt.dryRun && tt.wantAction == ActionRenamed && len(sr.BackupIDs) == 0 {
+				t.Error("renamed skill was not backed up")
+			}
+
+			_, err = os.Stat(filepath.Join(targetDir, "linter"))
+			util.AssertEqual(t, err == nil, tt.wantOld)
+			_, err = os.Stat(filepath.Join(targetDir, "lint", "SKILL.md"))
+			util.AssertEqual(t, err == nil, tt.wantNew)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package sync
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/klauern/skillsync/internal/model"
 )
@@ -31,6 +32,15 @@ const (
 
 	// ActionDeleted indicates a skill was deleted from the target.
 	ActionDeleted Action = "deleted"
+
+	// ActionUnchanged indicates the target already had identical content, so nothing was written.
+	ActionUnchanged Action = "unchanged"
+
+	// ActionQuarantined indicates a source skill failed validation and was left out of the sync.
+	ActionQuarantined Action = "quarantined"
+
+	// ActionRenamed indicates a target skill under another name was replaced by the skill (see DetectRenames).
+	ActionRenamed Action = "renamed"
 )
 
 // SkillResult represents the outcome of syncing a single skill.
@@ -52,6 +62,59 @@ type SkillResult struct {
 
 	// Conflict holds conflict details when Action is ActionConflict.
 	Conflict *Conflict
+
+	// BackupIDs lists the backups taken of the target before it was modified.
+	BackupIDs []string
+
+	// BrokenReferences lists the @path mentions and relative links that point
+	// to existing files from the source but not from the target.
+	BrokenReferences []string
+
+	// Warnings lists lossy mappings: parts of the skill the target platform
+	// cannot represent faithfully.
+	Warnings []string
+
+	// Preview holds the current and would-be target content when a dry run
+	// was asked for a preview (see Options.Preview).
+	Preview *FilePreview
+
+	// RenamedFrom is the name of the target skill replaced when Action is
+	// ActionRenamed.
+	RenamedFrom string
+
+	// Duration is how long processing the skill took.
+	Duration time.Duration
+
+	// BytesWritten is the size of the files written for the skill: the
+	// target file, or every file of a copied skill directory.
+	BytesWritten int64
+}
+
+// FilePreview is the content of a target file before and after a sync.
+type FilePreview struct {
+	// Path is the file that would be written.
+	Path string
+
+	// Exists reports whether the file exists before the sync.
+	Exists bool
+
+	// Before is the current content of the file (empty if it does not exist).
+	Before string
+
+	// After is the content the sync would write.
+	After string
+}
+
+// Hunks returns the unified diff hunks that turn Before into After.
+func (p *FilePreview) Hunks(opts DiffOptions) []DiffHunk {
+	var before, after []string
+	if p.Before != "" {
+		before = strings.Split(p.Before, "\n")
+	}
+	if p.After != "" {
+		after = strings.Split(p.After, "\n")
+	}
+	return ComputeDiff(before, after, opts)
 }
 
 // Success returns true if the skill was successfully processed.
@@ -75,6 +138,12 @@ type Result struct {
 
 	// DryRun indicates if this was a dry run (no changes made).
 	DryRun bool
+
+	// HookErrors lists post_sync hook failures. They do not affect Success.
+	HookErrors []string
+
+	// SessionID links the backups taken during this run (see Options.SessionID).
+	SessionID string
 }
 
 // Created returns skills that were created.
@@ -112,6 +181,30 @@ func (r *Result) Deleted() []SkillResult {
 	return r.filterByAction(ActionDeleted)
 }
 
+// Unchanged returns skills whose target content was already identical.
+func (r *Result) Unchanged() []SkillResult {
+	return r.filterByAction(ActionUnchanged)
+}
+
+// Renamed returns skills that replaced a target skill under another name.
+func (r *Result) Renamed() []SkillResult {
+	return r.filterByAction(ActionRenamed)
+}
+
+// Quarantined returns source skills that were excluded because they failed validation.
+func (r *Result) Quarantined() []SkillResult {
+	return r.filterByAction(ActionQuarantined)
+}
+
+// BackupIDs returns the IDs of all backups taken during the sync.
+func (r *Result) BackupIDs() []string {
+	var ids []string
+	for _, sr := range r.Skills {
+		ids = append(ids, sr.BackupIDs...)
+	}
+	return ids
+}
+
 // HasConflicts returns true if there are unresolved conflicts.
 func (r *Result) HasConflicts() bool {
 	return len(r.Conflicts()) > 0
@@ -138,9 +231,9 @@ func (r *Result) TotalProcessed() int {
 	return len(r.Skills)
 }
 
-// TotalChanged returns the number of skills that were created, updated, merged, or deleted.
+// TotalChanged returns the number of skills that were created, updated, merged, renamed, or deleted.
 func (r *Result) TotalChanged() int {
-	return len(r.Created()) + len(r.Updated()) + len(r.Merged()) + len(r.Deleted())
+	return len(r.Created()) + len(r.Updated()) + len(r.Merged()) + len(r.Renamed()) + len(r.Deleted())
 }
 
 // Summary returns a human-readable summary of the sync result.
@@ -158,9 +251,17 @@ func (r *Result) Summary() string {
 	sb.WriteString(fmt.Sprintf("  Updated:   %d\n", len(r.Updated())))
 	sb.WriteString(fmt.Sprintf("  Merged:    %d\n", len(r.Merged())))
 	sb.WriteString(fmt.Sprintf("  Deleted:   %d\n", len(r.Deleted())))
+	sb.WriteString(fmt.Sprintf("  Unchanged: %d\n", len(r.Unchanged())))
 	sb.WriteString(fmt.Sprintf("  Skipped:   %d\n", len(r.Skipped())))
 	sb.WriteString(fmt.Sprintf("  Conflicts: %d\n", len(r.Conflicts())))
 	sb.WriteString(fmt.Sprintf("  Failed:    %d\n", len(r.Failed())))
+	if renamed := r.Renamed(); len(renamed) > 0 {
+		sb.WriteString(fmt.Sprintf("  Renamed:   %d\n", len(renamed)))
+	}
+	quarantined := r.Quarantined()
+	if len(quarantined) > 0 {
+		sb.WriteString(fmt.Sprintf("  Quarantined: %d\n", len(quarantined)))
+	}
 
 	if r.HasConflicts() {
 		sb.WriteString("\nConflicts requiring resolution:\n")
@@ -180,5 +281,32 @@ func (r *Result) Summary() string {
 		}
 	}
 
+	if len(quarantined) > 0 {
+		sb.WriteString("\nQuarantined (failed validation, not synced):\n")
+		for _, q := range quarantined {
+			sb.WriteString(fmt.Sprintf("  - %s: %s\n", q.Skill.Name, q.Message))
+		}
+	}
+
+	var broken []SkillResult
+	for _, sr := range r.Skills {
+		if len(sr.BrokenReferences) > 0 {
+			broken = append(broken, sr)
+		}
+	}
+	if len(broken) > 0 {
+		sb.WriteString("\nBroken references (valid on source, missing on target):\n")
+		for _, sr := range broken {
+			sb.WriteString(fmt.Sprintf("  - %s: %s\n", sr.Skill.Name, strings.Join(sr.BrokenReferences, ", ")))
+		}
+	}
+
+	if len(r.HookErrors) > 0 {
+		sb.WriteString("\nHook errors:\n")
+		for _, e := range r.HookErrors {
+			sb.WriteString(fmt.Sprintf("  - %s\n", e))
+		}
+	}
+
 	return sb.String()
 }
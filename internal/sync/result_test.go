@@ -592,6 +592,53 @@ func TestResult_Summary_WithFailures(t *testing.T) {
 	}
 }
 
+func TestResult_Summary_WithQuarantined(t *testing.T) {
+	result := &Result{
+		Source:   model.Cursor,
+		Target:   model.ClaudeCode,
+		Strategy: StrategyOverwrite,
+		Skills: []SkillResult{
+			{Skill: model.Skill{Name: "good"}, Action: ActionCreated},
+			{Skill: model.Skill{Name: "bad"}, Action: ActionQuarantined, Message: "duplicate skill name \"bad\""},
+		},
+	}
+
+	summary := result.Summary()
+
+	if !result.Success() {
+		t.Error("Quarantined skills should not fail the result")
+	}
+	if len(result.Quarantined()) != 1 {
+		t.Errorf("Quarantined() = %d skills, want 1", len(result.Quarantined()))
+	}
+	for _, want := range []string{"Quarantined: 1", "bad: duplicate skill name"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary should contain %q:\n%s", want, summary)
+		}
+	}
+}
+
+func TestResult_Summary_WithBrokenReferences(t *testing.T) {
+	result := &Result{
+		Source:   model.Cursor,
+		Target:   model.ClaudeCode,
+		Strategy: StrategyOverwrite,
+		Skills: []SkillResult{
+			{Skill: model.Skill{Name: "lint"}, Action: ActionCreated, BrokenReferences: []string{"docs/style.md", "guide.md"}},
+			{Skill: model.Skill{Name: "fmt"}, Action: ActionCreated},
+		},
+	}
+
+	summary := result.Summary()
+
+	if !strings.Contains(summary, "lint: docs/style.md, guide.md") {
+		t.Errorf("Summary should list broken references:\n%s", summary)
+	}
+	if strings.Contains(summary, "fmt:") {
+		t.Errorf("Summary should not list skills without broken references:\n%s", summary)
+	}
+}
+
 func TestResult_Summary_AllActionTypes(t *testing.T) {
 	result := &Result{
 		Source:   model.ClaudeCode,
@@ -0,0 +1,147 @@
+package sync
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+)
+
+// Divergence is how a source skill relates to its copy in the target.
+type Divergence string
+
+const (
+	// DivergenceNew means the target has no copy of the skill.
+	DivergenceNew Divergence = "new"
+	// DivergenceIdentical means the copies have the same content.
+	DivergenceIdentical Divergence = "identical"
+	// DivergenceSourceChanged means only the source changed since the last sync.
+	DivergenceSourceChanged Divergence = "source-changed"
+	// DivergenceTargetChanged means only the target changed since the last sync.
+	DivergenceTargetChanged Divergence = "target-changed"
+	// DivergenceBothChanged means both copies changed since the last sync.
+	DivergenceBothChanged Divergence = "both-changed"
+	// DivergenceUntracked means the copies differ and no sync of the target
+	// copy is recorded, so it is unknown which side changed.
+	DivergenceUntracked Divergence = "untracked"
+)
+
+// SkillDivergence is the divergence of one skill.
+type SkillDivergence struct {
+	Name       string     `json:"name"`
+	Divergence Divergence `json:"divergence"`
+}
+
+// Suggestion is the strategy that fits how the source and target diverged,
+// with the reason for it.
+type Suggestion struct {
+	Strategy Strategy           `json:"strategy"`
+	Reason   string             `json:"reason"`
+	Counts   map[Divergence]int `json:"counts"`
+	// Skills lists the skills whose copies differ.
+	Skills []SkillDivergence `json:"skills,omitempty"`
+}
+
+// SuggestStrategy compares source skills with the target skills of the same
+// name and suggests a strategy:
+//
+//   - three-way when both copies of a skill changed since the last sync,
+//     since only a merge keeps both edits
+//   - newer when targets were edited but their sources were not, so
+//     overwriting would lose the target edits
+//   - overwrite when only sources changed, or the target lacks the skills
+//
+// lastSynced maps target paths (of the file, or of the directory of
+// SKILL.md skills) to when a sync last wrote them; a copy
+// counts as changed when it was modified after that. Targets without a
+// recorded sync are compared by modification time. It returns nil when the
+// target has every skill with the same content, so there is nothing to choose.
+func SuggestStrategy(sources, targets []model.Skill, lastSynced map[string]time.Time) *Suggestion {
+	targetByName := make(map[string]model.Skill, len(targets))
+	for _, target := range targets {
+		if _, ok := targetByName[target.Name]; !ok {
+			targetByName[target.Name] = target
+		}
+	}
+
+	suggestion := &Suggestion{Counts: make(map[Divergence]int)}
+	// targetEdits counts targets changed since the last sync, or newer than
+	// their source when no sync is recorded
+	targetEdits := 0
+	for _, source := range sources {
+		divergence := skillDivergence(source, targetByName, lastSynced)
+		suggestion.Counts[divergence]++
+		switch divergence {
+		case DivergenceNew, DivergenceIdentical:
+			continue
+		case DivergenceTargetChanged:
+			targetEdits++
+		case DivergenceUntracked:
+			if targetByName[source.Name].ModifiedAt.After(source.ModifiedAt) {
+				targetEdits++
+			}
+		}
+		suggestion.Skills = append(suggestion.Skills, SkillDivergence{Name: source.Name, Divergence: divergence})
+	}
+
+	counts := suggestion.Counts
+	switch {
+	case len(suggestion.Skills) == 0 && counts[DivergenceNew] == 0:
+		return nil
+	case counts[DivergenceBothChanged] > 0:
+		suggestion.Strategy = StrategyThreeWay
+		suggestion.Reason = fmt.Sprintf("both sides changed for %s since the last sync → three-way merges the edits",
+			skillCount(counts[DivergenceBothChanged]))
+	case targetEdits > 0:
+		suggestion.Strategy = StrategyNewer
+		suggestion.Reason = fmt.Sprintf("the target has newer edits to %s → newer keeps them", skillCount(targetEdits))
+	case counts[DivergenceUntracked] > 0:
+		suggestion.Strategy = StrategyOverwrite
+		suggestion.Reason = fmt.Sprintf("no sync is recorded for %s, but every differing source is newer → overwrite is safe",
+			skillCount(counts[DivergenceUntracked]))
+	default:
+		suggestion.Strategy = StrategyOverwrite
+		suggestion.Reason = "targets untouched since the last sync → overwrite is safe"
+	}
+	return suggestion
+}
+
+// skillDivergence classifies one source skill against its target copy.
+func skillDivergence(source model.Skill, targetByName map[string]model.Skill, lastSynced map[string]time.Time) Divergence {
+	target, ok := targetByName[source.Name]
+	if !ok {
+		return DivergenceNew
+	}
+	if parser.NormalizeContent(source.Content) == parser.NormalizeContent(target.Content) {
+		return DivergenceIdentical
+	}
+	syncedAt, ok := lastSynced[target.Path]
+	if !ok && isSkillFile(target.Path) {
+		// Syncs of directory skills record the skill directory
+		syncedAt, ok = lastSynced[filepath.Dir(target.Path)]
+	}
+	if !ok {
+		return DivergenceUntracked
+	}
+	sourceChanged := source.ModifiedAt.After(syncedAt)
+	targetChanged := target.ModifiedAt.After(syncedAt)
+	switch {
+	case sourceChanged && targetChanged:
+		return DivergenceBothChanged
+	case targetChanged:
+		return DivergenceTargetChanged
+	default:
+		// Includes neither: the copies differ, so the source is the one to keep
+		return DivergenceSourceChanged
+	}
+}
+
+// skillCount formats a number of skills, e.g. "1 skill" or "3 skills".
+func skillCount(n int) string {
+	if n == 1 {
+		return "1 skill"
+	}
+	return fmt.Sprintf("%d skills", n)
+}
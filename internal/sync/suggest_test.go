@@ -0,0 +1,85 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSuggestStrategy(t *testing.T) {
+	synced := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	before, after := synced.Add(-time.Hour), synced.Add(time.Hour)
+
+	skill := func(name, content string, modified time.Time) model.Skill {
+		return model.Skill{Name: name, Path: "/target/" + name + "/SKILL.md", Content: content, ModifiedAt: modified}
+	}
+	lastSynced := map[string]time.Time{"/target/lint/SKILL.md": synced, "/target/docs/SKILL.md": synced}
+
+	tests := map[string]struct {
+		sources    []model.Skill
+		targets    []model.Skill
+		want       Strategy
+		wantReason string
+		wantNil    bool
+	}{
+		"nothing to sync": {
+			sources: []model.Skill{skill("lint", "a", after)},
+			targets: []model.Skill{skill("lint", "a", before)},
+			wantNil: true,
+		},
+		"only sources changed": {
+			sources:    []model.Skill{skill("lint", "b", after)},
+			targets:    []model.Skill{skill("lint", "a", before)},
+			want:       StrategyOverwrite,
+			wantReason: "targets untouched since the last sync → overwrite is safe",
+		},
+		"new skills": {
+			sources:    []model.Skill{skill("lint", "a", after)},
+			want:       StrategyOverwrite,
+			wantReason: "targets untouched since the last sync → overwrite is safe",
+		},
+		"target edited": {
+			sources:    []model.Skill{skill("lint", "a", before), skill("docs", "b", after)},
+			targets:    []model.Skill{skill("lint", "c", after), skill("docs", "a", before)},
+			want:       StrategyNewer,
+			wantReason: "the target has newer edits to 1 skill → newer keeps them",
+		},
+		"both sides changed": {
+			sources:    []model.Skill{skill("lint", "b", after), skill("docs", "b", after)},
+			targets:    []model.Skill{skill("lint", "c", after), skill("docs", "c", after)},
+			want:       StrategyThreeWay,
+			wantReason: "both sides changed for 2 skills since the last sync → three-way merges the edits",
+		},
+		"untracked with newer sources": {
+			sources:    []model.Skill{skill("build", "b", after)},
+			targets:    []model.Skill{skill("build", "a", before)},
+			want:       StrategyOverwrite,
+			wantReason: "no sync is recorded for 1 skill, but every differing source is newer → overwrite is safe",
+		},
+		"untracked with a newer target": {
+			sources:    []model.Skill{skill("build", "b", before)},
+			targets:    []model.Skill{skill("build", "a", after)},
+			want:       StrategyNewer,
+			wantReason: "the target has newer edits to 1 skill → newer keeps them",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := SuggestStrategy(tt.sources, tt.targets, lastSynced)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("SuggestStrategy() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("SuggestStrategy() = nil")
+			}
+			util.AssertEqual(t, got.Strategy, tt.want)
+			util.AssertEqual(t, got.Reason, tt.wantReason)
+		})
+	}
+}
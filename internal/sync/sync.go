@@ -6,14 +6,17 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	gosync "sync"
 	"time"
 
 	"github.com/klauern/skillsync/internal/logging"
 	"github.com/klauern/skillsync/internal/model"
 	"github.com/klauern/skillsync/internal/parser"
+	"github.com/klauern/skillsync/internal/parser/aider"
 	"github.com/klauern/skillsync/internal/parser/claude"
 	"github.com/klauern/skillsync/internal/parser/codex"
 	"github.com/klauern/skillsync/internal/parser/cursor"
+	"github.com/klauern/skillsync/internal/parser/external"
 	"github.com/klauern/skillsync/internal/validation"
 )
 
@@ -44,6 +47,61 @@ type Options struct {
 	// DeleteMode enables deletion sync: deletes skills from target that match source.
 	// Instead of copying skills TO target, removes skills FROM target that exist in source.
 	DeleteMode bool
+
+	// Concurrency is the maximum number of skills processed in parallel.
+	// Values below 2 process skills sequentially. Results keep input order either way.
+	Concurrency int
+
+	// Progress, if set, is called once per skill as it finishes processing.
+	// Calls are serialized and Completed increases by one on each call, but with
+	// Concurrency > 1 skills may finish out of input order.
+	Progress func(ProgressEvent)
+
+	// Hooks are shell commands run around the sync and each skill write.
+	// They are ignored when DryRun is set.
+	Hooks Hooks
+
+	// Backup creates a backup of every existing target file before it is
+	// overwritten, merged, or deleted. It is ignored when DryRun is set.
+	Backup bool
+
+	// SessionID is recorded on every backup of this run so that all of them
+	// can be rolled back together.
+	SessionID string
+
+	// TrashRetention, when positive, makes deletes move target skills into the
+	// trash for this long instead of removing them, and makes syncs restore
+	// trashed targets of skills that reappear in the source.
+	TrashRetention time.Duration
+
+	// RewriteReferences replaces @path mentions and relative links that would
+	// break on the target with absolute paths to the source files. Only skills
+	// written through the transformer (single files) can be rewritten.
+	RewriteReferences bool
+
+	// Metrics maintains a skillsync-metrics block (word count, token
+	// estimate, last-synced time) in the frontmatter of written skill files.
+	Metrics bool
+
+	// Preview records the current and would-be content of the file each
+	// created, updated, or merged skill writes in SkillResult.Preview. It
+	// only applies when DryRun is set.
+	Preview bool
+
+	// Mode selects whether skills are copied to the target (ModeCopy, the
+	// default) or linked to the source (ModeLink).
+	Mode Mode
+
+	// RenameThreshold, when positive, turns on rename detection: a source
+	// skill missing from the target replaces a target skill missing from the
+	// source whose content is at least this similar (0.0-1.0), instead of
+	// being created next to it (see DetectRenames).
+	RenameThreshold float64
+
+	// Vars are the values of template variables, filled in for the {{name}}
+	// placeholders of skills that declare them (see SkillVariables). A skill
+	// with a variable that has neither a value here nor a default fails.
+	Vars map[string]string
 }
 
 // DefaultOptions returns the default sync options.
@@ -66,6 +124,10 @@ type Synchronizer struct {
 	transformer      *Transformer
 	conflictDetector *ConflictDetector
 	merger           *Merger
+
+	// agentsMu serializes writes of skills aggregated into the same AGENTS.md
+	// or CONVENTIONS.md
+	agentsMu gosync.Mutex
 }
 
 // New creates a new Synchronizer.
@@ -88,11 +150,12 @@ func (s *Synchronizer) Sync(source, target model.Platform, opts Options) (*Resul
 	)
 
 	result := &Result{
-		Source:   source,
-		Target:   target,
-		Strategy: opts.Strategy,
-		DryRun:   opts.DryRun,
-		Skills:   make([]SkillResult, 0),
+		Source:    source,
+		Target:    target,
+		Strategy:  opts.Strategy,
+		DryRun:    opts.DryRun,
+		Skills:    make([]SkillResult, 0),
+		SessionID: opts.SessionID,
 	}
 
 	// Set default strategy if not specified
@@ -100,8 +163,24 @@ func (s *Synchronizer) Sync(source, target model.Platform, opts Options) (*Resul
 		result.Strategy = StrategyOverwrite
 	}
 
-	// Parse source skills
+	// Parse source and target skills, in parallel when concurrency is enabled
+	var (
+		targetSkills []model.Skill
+		targetErr    error
+	)
+	parseTarget := func() { targetSkills, targetErr = s.parseSkills(target, opts.TargetPath) }
+	var targetParsed gosync.WaitGroup
+	if opts.Concurrency > 1 {
+		targetParsed.Add(1)
+		go func() {
+			defer targetParsed.Done()
+			parseTarget()
+		}()
+	} else {
+		parseTarget()
+	}
 	sourceSkills, err := s.parseSkills(source, opts.SourcePath)
+	targetParsed.Wait()
 	if err != nil {
 		logging.Error("failed to parse source skills",
 			logging.Platform(string(source)),
@@ -132,12 +211,16 @@ func (s *Synchronizer) Sync(source, target model.Platform, opts Options) (*Resul
 		}
 	}
 
-	// Parse existing target skills for conflict detection
-	targetSkills, err := s.parseSkills(target, opts.TargetPath)
-	if err != nil {
+	restored := resurrectTrashed(sourceSkills, target, targetPath, opts)
+	if len(restored) > 0 {
+		targetSkills, targetErr = s.parseSkills(target, opts.TargetPath)
+	}
+
+	// Existing target skills are used for conflict detection
+	if targetErr != nil {
 		logging.Debug("target skills not found, starting fresh",
 			logging.Platform(string(target)),
-			logging.Err(err),
+			logging.Err(targetErr),
 		)
 		// Target may not exist yet, which is okay
 		targetSkills = []model.Skill{}
@@ -169,10 +252,10 @@ func (s *Synchronizer) Sync(source, target model.Platform, opts Options) (*Resul
 	}
 
 	// Process each source skill
-	for _, sourceSkill := range sourceSkills {
-		skillResult := s.processSkill(sourceSkill, target, targetPath, targetSkillMap, opts)
-		result.Skills = append(result.Skills, skillResult)
+	if err := s.runWithHooks(result, sourceSkills, target, targetPath, targetSkillMap, opts); err != nil {
+		return result, err
 	}
+	markResurrected(result, restored)
 
 	logging.Debug("sync operation completed",
 		logging.Platform(string(source)),
@@ -203,8 +286,14 @@ func (s *Synchronizer) parseSkills(platform model.Platform, basePath string) ([]
 		p = cursor.New(basePath)
 	case model.Codex:
 		p = codex.New(basePath)
+	case model.Aider:
+		p = aider.New(basePath)
 	default:
-		return nil, fmt.Errorf("unsupported platform: %s", platform)
+		def, ok := external.Lookup(platform)
+		if !ok {
+			return nil, fmt.Errorf("unsupported platform: %s", platform)
+		}
+		p = external.New(def, basePath)
 	}
 
 	return p.Parse()
@@ -217,6 +306,7 @@ func (s *Synchronizer) processSkill(
 	targetPlatform model.Platform,
 	targetPath string,
 	existingSkills map[string]model.Skill,
+	renames map[string]Rename,
 	opts Options,
 ) SkillResult {
 	logging.Debug("processing skill",
@@ -240,13 +330,15 @@ func (s *Synchronizer) processSkill(
 
 	// For symlinks and directories, use the skill name directly.
 	// For files, use the transformed path (legacy behavior).
-	var targetEntryPath string
+	var targetEntryPath, transformedContent string
+	var transformed model.Skill
 	if sourceType == SourceTypeSymlink || sourceType == SourceTypeDirectory {
 		// Preserve structure: target is just the skill name in the target directory
 		targetEntryPath = filepath.Join(targetPath, source.Name)
 	} else {
 		// Legacy file behavior: transform path for target platform
-		transformed, err := s.transformer.Transform(source, targetPlatform)
+		var err error
+		transformed, err = s.transformer.Transform(source, targetPlatform)
 		if err != nil {
 			logging.Warn("transformation failed",
 				logging.Skill(source.Name),
@@ -257,23 +349,158 @@ func (s *Synchronizer) processSkill(
 			return result
 		}
 		targetEntryPath = filepath.Join(targetPath, transformed.Path)
+		transformedContent = transformed.Content
 	}
 
 	result.TargetPath = targetEntryPath
 
+	// Templated skills are written with their variables filled in. For
+	// directories only the skill file is rewritten, at expandedFile.
+	vars, err := skillVariableValues(source, opts.Vars)
+	if err != nil {
+		result.Action = ActionFailed
+		result.Error = err
+		return result
+	}
+	var expandedFile string
+	if vars != nil {
+		switch sourceType {
+		case SourceTypeFile:
+			transformedContent = ExpandVariables(transformedContent, vars)
+		case SourceTypeDirectory:
+			// #nosec G304 - source.Path is a discovered skill file
+			raw, err := os.ReadFile(source.Path)
+			if err != nil {
+				result.Action = ActionFailed
+				result.Error = fmt.Errorf("failed to read %s: %w", source.Path, err)
+				return result
+			}
+			if expandedFile, err = filepath.Rel(sourceRootPath, source.Path); err != nil {
+				result.Action = ActionFailed
+				result.Error = err
+				return result
+			}
+			transformedContent = ExpandVariables(string(raw), vars)
+		}
+	}
+
+	// Skills aggregated into Codex's AGENTS.md or Aider's CONVENTIONS.md share
+	// the file, so each one is spliced into its current content, one skill at a time
+	aggregateFile := aggregateFileName(targetPlatform)
+	aggregated := sourceType == SourceTypeFile && aggregateFile != "" &&
+		filepath.Base(targetEntryPath) == aggregateFile
+	var agentsContent string
+	if aggregated {
+		s.agentsMu.Lock()
+		defer s.agentsMu.Unlock()
+
+		// #nosec G304 - targetEntryPath is built from the target directory
+		existing, err := os.ReadFile(targetEntryPath)
+		if err != nil && !os.IsNotExist(err) {
+			result.Action = ActionFailed
+			result.Error = fmt.Errorf("failed to read %s: %w", aggregateFile, err)
+			return result
+		}
+		agentsContent = string(existing)
+	}
+
+	// Linked skills become symlinks to the source skill
+	var symlinkTarget, linkNote string
+	if opts.Mode == ModeLink && sourceType != SourceTypeSymlink {
+		if vars != nil {
+			result.Action = ActionFailed
+			result.Error = fmt.Errorf("cannot link: %s has template variables to fill in; sync it without --link", source.Name)
+			return result
+		}
+		linkTarget, err := linkSource(source, sourceType, sourceRootPath, targetPlatform, transformed, aggregated)
+		if err != nil {
+			logging.Warn("cannot link skill",
+				logging.Skill(source.Name),
+				logging.Err(err),
+			)
+			result.Action = ActionFailed
+			result.Error = fmt.Errorf("cannot link: %w", err)
+			return result
+		}
+		sourceType, symlinkTarget = SourceTypeSymlink, linkTarget
+		linkNote = "linked to " + linkTarget
+	}
+
+	// Symlinked skills resolve references from where they really live
+	var referenceNote string
+	if sourceType != SourceTypeSymlink {
+		targetFile, sourceRoot, targetRoot := targetEntryPath, "", ""
+		if sourceType == SourceTypeDirectory {
+			targetFile = filepath.Join(targetEntryPath, filepath.Base(source.Path))
+			sourceRoot, targetRoot = sourceRootPath, targetEntryPath
+		}
+		broken := findBrokenReferences(source, targetFile, targetPlatform, sourceRoot, targetRoot)
+		if len(broken) > 0 && opts.RewriteReferences && sourceType == SourceTypeFile {
+			transformedContent = s.transformer.RewriteReferences(transformedContent, broken)
+			referenceNote = fmt.Sprintf("rewrote %d reference(s) to source paths", len(broken))
+			broken = nil
+		}
+		for _, ref := range broken {
+			result.BrokenReferences = append(result.BrokenReferences, ref.Raw)
+		}
+	}
+
+	if sourceType == SourceTypeSymlink && symlinkTarget == "" {
+		symlinkTarget = getSymlinkTarget(sourceRootPath)
+		if symlinkTarget == "" && source.PluginInfo != nil {
+			// Fallback: try from PluginInfo
+			symlinkTarget = source.PluginInfo.SymlinkTarget
+		}
+	}
+
+	if opts.Metrics && sourceType == SourceTypeFile && !aggregated {
+		transformedContent = withSyncMetrics(transformedContent, targetEntryPath, time.Now())
+	}
+
+	// Skip the write entirely when the target already holds identical content
+	fileContent := transformedContent
+	if aggregated {
+		fileContent = spliceAgents(agentsContent, source, transformedContent)
+	}
+	unchanged := targetUnchanged(sourceType, sourceRootPath, symlinkTarget, targetEntryPath, fileContent)
+	if expandedFile != "" {
+		unchanged = expandedTreeUnchanged(sourceRootPath, targetEntryPath, expandedFile, transformedContent)
+	}
+	if unchanged {
+		logging.Debug("target content unchanged",
+			logging.Skill(source.Name),
+			logging.Path(targetEntryPath),
+		)
+		result.Action = ActionUnchanged
+		result.Message = "content unchanged"
+		return result
+	}
+
 	// Check if skill exists in target
 	existingSkill, exists := existingSkills[source.Name]
 
 	// Determine action based on strategy
 	action, message, conflict := s.determineAction(source, existingSkill, exists, opts.Strategy)
+	rename, renamed := renames[source.Name]
+	if renamed && !exists {
+		action = ActionRenamed
+		message = fmt.Sprintf("renamed from %s (%.0f%% similar)", rename.From.Name, rename.Similarity*100)
+		result.RenamedFrom = rename.From.Name
+	}
 	result.Action = action
 	result.Message = message
 	result.Conflict = conflict
 	if warning := mappingWarning(source, targetPlatform); warning != "" {
+		result.Warnings = strings.Split(warning, "; ")
+	}
+	for _, note := range []string{mappingWarning(source, targetPlatform), referenceNote, linkNote} {
+		if note == "" {
+			continue
+		}
 		if result.Message != "" {
 			result.Message += "; "
 		}
-		result.Message += warning
+		result.Message += note
 	}
 
 	logging.Debug("action determined",
@@ -288,32 +515,71 @@ func (s *Synchronizer) processSkill(
 		return result
 	}
 
+	if opts.DryRun && opts.Preview {
+		result.Preview = s.previewWrite(source, sourceType, targetEntryPath, action,
+			transformedContent, existingSkill, exists, aggregated, agentsContent)
+	}
+
 	// Execute the sync (unless dry run)
 	if !opts.DryRun {
-		// Remove any existing entry at target path to avoid duplicates
-		if err := removeExisting(targetEntryPath); err != nil {
-			logging.Error("failed to remove existing entry",
+		hookEnv := skillHookEnv(syncHookEnv(source.Platform, targetPlatform, targetPath, opts.Strategy), source, action, targetEntryPath)
+		if err := opts.Hooks.run(HookPreSkill, hookEnv); err != nil {
+			logging.Warn("pre_skill hook failed",
 				logging.Skill(source.Name),
-				logging.Path(targetEntryPath),
 				logging.Err(err),
 			)
 			result.Action = ActionFailed
-			result.Error = fmt.Errorf("failed to remove existing entry: %w", err)
+			result.Error = err
 			return result
 		}
 
+		if opts.Backup {
+			ids, err := backupTarget(targetEntryPath, source, targetPlatform, opts, "pre-sync backup", []string{"sync"})
+			if err != nil {
+				logging.Error("failed to back up target",
+					logging.Skill(source.Name),
+					logging.Path(targetEntryPath),
+					logging.Err(err),
+				)
+				result.Action = ActionFailed
+				result.Error = err
+				return result
+			}
+			result.BackupIDs = ids
+		}
+
+		if action == ActionRenamed {
+			if err := s.removeRenamed(rename.From, targetPlatform, opts, &result); err != nil {
+				logging.Error("failed to remove renamed skill",
+					logging.Skill(rename.From.Name),
+					logging.Path(rename.From.Path),
+					logging.Err(err),
+				)
+				result.Action = ActionFailed
+				result.Error = err
+				return result
+			}
+		}
+
+		// Remove any existing entry at target path to avoid duplicates. A
+		// shared AGENTS.md is rewritten in place instead.
+		if !aggregated {
+			if err := removeExisting(targetEntryPath); err != nil {
+				logging.Error("failed to remove existing entry",
+					logging.Skill(source.Name),
+					logging.Path(targetEntryPath),
+					logging.Err(err),
+				)
+				result.Action = ActionFailed
+				result.Error = fmt.Errorf("failed to remove existing entry: %w", err)
+				return result
+			}
+		}
+
 		// Create based on source type
 		switch sourceType {
 		case SourceTypeSymlink:
 			// Recreate symlink with same target
-			symlinkTarget := getSymlinkTarget(sourceRootPath)
-			if symlinkTarget == "" {
-				// Fallback: try from PluginInfo
-				if source.PluginInfo != nil && source.PluginInfo.SymlinkTarget != "" {
-					symlinkTarget = source.PluginInfo.SymlinkTarget
-				}
-			}
-
 			if symlinkTarget == "" {
 				logging.Error("failed to determine symlink target",
 					logging.Skill(source.Name),
@@ -324,6 +590,17 @@ func (s *Synchronizer) processSkill(
 				return result
 			}
 
+			if err := os.MkdirAll(filepath.Dir(targetEntryPath), 0o750); err != nil {
+				logging.Error("failed to create target subdirectory",
+					logging.Skill(source.Name),
+					logging.Path(targetEntryPath),
+					logging.Err(err),
+				)
+				result.Action = ActionFailed
+				result.Error = fmt.Errorf("failed to create target subdirectory: %w", err)
+				return result
+			}
+
 			if err := os.Symlink(symlinkTarget, targetEntryPath); err != nil {
 				logging.Error("failed to create symlink",
 					logging.Skill(source.Name),
@@ -354,6 +631,21 @@ func (s *Synchronizer) processSkill(
 				return result
 			}
 
+			if expandedFile != "" {
+				// #nosec G306 - skill files should be readable
+				if err := os.WriteFile(filepath.Join(targetEntryPath, expandedFile), []byte(transformedContent), 0o644); err != nil {
+					logging.Error("failed to write skill file",
+						logging.Skill(source.Name),
+						logging.Path(targetEntryPath),
+						logging.Err(err),
+					)
+					result.Action = ActionFailed
+					result.Error = fmt.Errorf("failed to write file: %w", err)
+					return result
+				}
+			}
+
+			result.BytesWritten = treeSize(targetEntryPath)
 			logging.Debug("copied directory",
 				logging.Skill(source.Name),
 				logging.Path(targetEntryPath),
@@ -361,22 +653,7 @@ func (s *Synchronizer) processSkill(
 
 		case SourceTypeFile:
 			// Legacy behavior: write transformed content
-			transformed, err := s.transformer.Transform(source, targetPlatform)
-			if err != nil {
-				result.Action = ActionFailed
-				result.Error = fmt.Errorf("transformation failed: %w", err)
-				return result
-			}
-
-			content := transformed.Content
-
-			// Handle merge strategy
-			if action == ActionMerged && exists {
-				logging.Debug("merging content",
-					logging.Skill(source.Name),
-				)
-				content = s.transformer.MergeContent(transformed.Content, existingSkill.Content, source.Name)
-			}
+			content := s.fileContent(source, action, transformedContent, existingSkill, exists, aggregated, agentsContent)
 
 			// Ensure parent directory exists
 			if err := os.MkdirAll(filepath.Dir(targetEntryPath), 0o750); err != nil {
@@ -402,16 +679,152 @@ func (s *Synchronizer) processSkill(
 				return result
 			}
 
+			result.BytesWritten = int64(len(content))
 			logging.Debug("wrote skill file",
 				logging.Skill(source.Name),
 				logging.Path(targetEntryPath),
 			)
+
+			if aggregated && targetPlatform == model.Aider {
+				note, err := registerConventions(targetPath, targetEntryPath)
+				if err != nil {
+					logging.Warn("failed to update Aider config",
+						logging.Skill(source.Name),
+						logging.Err(err),
+					)
+					note = fmt.Sprintf("not added to %s: %v", aider.ConfigFileName, err)
+				}
+				if note != "" {
+					if result.Message != "" {
+						result.Message += "; "
+					}
+					result.Message += note
+				}
+			}
+		}
+
+		// The skill is already written, so a failing post_skill hook is only reported
+		if err := opts.Hooks.run(HookPostSkill, hookEnv); err != nil {
+			logging.Warn("post_skill hook failed",
+				logging.Skill(source.Name),
+				logging.Err(err),
+			)
+			if result.Message != "" {
+				result.Message += "; "
+			}
+			result.Message += err.Error()
 		}
 	}
 
 	return result
 }
 
+// fileContent returns the content written for a single-file skill: the
+// transformed source, merged with the existing skill for ActionMerged and
+// spliced into the shared file for aggregated targets.
+func (s *Synchronizer) fileContent(
+	source model.Skill,
+	action Action,
+	transformedContent string,
+	existingSkill model.Skill,
+	exists, aggregated bool,
+	agentsContent string,
+) string {
+	content := transformedContent
+	if action == ActionMerged && exists {
+		logging.Debug("merging content",
+			logging.Skill(source.Name),
+		)
+		content = s.transformer.MergeContent(transformedContent, existingSkill.Content, source.Name)
+	}
+	if aggregated {
+		content = spliceAgents(agentsContent, source, content)
+	}
+	return content
+}
+
+// previewWrite returns the current and would-be content of the file a skill
+// writes. Directory skills preview their skill file; symlinks have no preview.
+func (s *Synchronizer) previewWrite(
+	source model.Skill,
+	sourceType SourceType,
+	targetEntryPath string,
+	action Action,
+	transformedContent string,
+	existingSkill model.Skill,
+	exists, aggregated bool,
+	agentsContent string,
+) *FilePreview {
+	preview := &FilePreview{Path: targetEntryPath}
+	switch sourceType {
+	case SourceTypeSymlink:
+		return nil
+	case SourceTypeDirectory:
+		preview.Path = filepath.Join(targetEntryPath, filepath.Base(source.Path))
+		if transformedContent != "" {
+			preview.After = transformedContent // with template variables filled in
+			break
+		}
+		// #nosec G304 - source.Path is a discovered skill file
+		after, err := os.ReadFile(source.Path)
+		if err != nil {
+			logging.Debug("failed to read skill for preview",
+				logging.Skill(source.Name),
+				logging.Err(err),
+			)
+			return nil
+		}
+		preview.After = string(after)
+	default:
+		preview.After = s.fileContent(source, action, transformedContent, existingSkill, exists, aggregated, agentsContent)
+	}
+
+	// #nosec G304 - preview.Path is built from the target directory
+	if before, err := os.ReadFile(preview.Path); err == nil {
+		preview.Before = string(before)
+		preview.Exists = true
+	}
+	return preview
+}
+
+// runWithHooks processes skills into result, running the pre_sync and
+// post_sync hooks around them. A failing pre_sync hook aborts before any skill
+// is written; a failing post_sync hook is recorded in result.HookErrors.
+func (s *Synchronizer) runWithHooks(
+	result *Result,
+	skills []model.Skill,
+	target model.Platform,
+	targetPath string,
+	existingSkills map[string]model.Skill,
+	opts Options,
+) error {
+	if opts.DryRun {
+		opts.Hooks = Hooks{}
+	}
+	hookEnv := syncHookEnv(result.Source, target, targetPath, result.Strategy)
+
+	if err := opts.Hooks.run(HookPreSync, hookEnv); err != nil {
+		logging.Error("pre_sync hook failed",
+			logging.Platform(string(target)),
+			logging.Err(err),
+		)
+		return err
+	}
+
+	renames := renamesInTarget(skills, existingSkills, target, targetPath, opts.RenameThreshold)
+	result.Skills = s.processSkills(skills, target, targetPath, existingSkills, renames, opts)
+
+	if err := opts.Hooks.run(HookPostSync, resultHookEnv(hookEnv, result)); err != nil {
+		logging.Warn("post_sync hook failed",
+			logging.Platform(string(target)),
+			logging.Err(err),
+		)
+		result.HookErrors = append(result.HookErrors, err.Error())
+	}
+
+	return nil
+}
+
 func mappingWarning(skill model.Skill, target model.Platform) string {
 	if skill.Type != model.SkillTypePrompt {
 		return ""
@@ -421,6 +834,9 @@ func mappingWarning(skill model.Skill, target model.Platform) string {
 	if target == model.Codex {
 		warnings = append(warnings, "lossy mapping: prompt trigger semantics are not guaranteed on Codex")
 	}
+	if target == model.Aider {
+		warnings = append(warnings, "lossy mapping: prompts become always-on conventions on Aider")
+	}
 	if target == model.Cursor && skill.Trigger != "" {
 		warnings = append(warnings, "lossy mapping: prompt trigger may require Cursor mode configuration")
 	}
@@ -551,11 +967,12 @@ func (s *Synchronizer) SyncWithSkills(
 	}
 
 	result := &Result{
-		Source:   skills[0].Platform, // Assume all skills are from same platform
-		Target:   target,
-		Strategy: opts.Strategy,
-		DryRun:   opts.DryRun,
-		Skills:   make([]SkillResult, 0),
+		Source:    skills[0].Platform, // Assume all skills are from same platform
+		Target:    target,
+		Strategy:  opts.Strategy,
+		DryRun:    opts.DryRun,
+		Skills:    make([]SkillResult, 0),
+		SessionID: opts.SessionID,
 	}
 
 	// Set default strategy
@@ -586,8 +1003,11 @@ func (s *Synchronizer) SyncWithSkills(
 		slog.String("scope", string(opts.TargetScope)),
 	)
 
+	// Bring back trashed targets of skills that reappeared before looking at the target
+	restored := resurrectTrashed(skills, target, targetPath, opts)
+
 	// Parse existing target skills
-	targetSkills, err := s.parseSkills(target, opts.TargetPath)
+	targetSkills, err := s.parseSkills(target, targetPath)
 	if err != nil {
 		logging.Debug("target skills not found, starting fresh",
 			logging.Platform(string(target)),
@@ -618,10 +1038,10 @@ func (s *Synchronizer) SyncWithSkills(
 	}
 
 	// Process each skill
-	for _, skill := range skills {
-		skillResult := s.processSkill(skill, target, targetPath, targetSkillMap, opts)
-		result.Skills = append(result.Skills, skillResult)
+	if err := s.runWithHooks(result, skills, target, targetPath, targetSkillMap, opts); err != nil {
+		return result, err
 	}
+	markResurrected(result, restored)
 
 	logging.Debug("sync with skills completed",
 		logging.Platform(string(target)),
@@ -658,11 +1078,12 @@ func (s *Synchronizer) DeleteWithSkills(
 	}
 
 	result := &Result{
-		Source:   sourceSkills[0].Platform,
-		Target:   target,
-		Strategy: opts.Strategy,
-		DryRun:   opts.DryRun,
-		Skills:   make([]SkillResult, 0),
+		Source:    sourceSkills[0].Platform,
+		Target:    target,
+		Strategy:  opts.Strategy,
+		DryRun:    opts.DryRun,
+		Skills:    make([]SkillResult, 0),
+		SessionID: opts.SessionID,
 	}
 
 	// Get target path based on scope
@@ -689,7 +1110,7 @@ func (s *Synchronizer) DeleteWithSkills(
 	)
 
 	// Parse existing target skills
-	targetSkills, err := s.parseSkills(target, opts.TargetPath)
+	targetSkills, err := s.parseSkills(target, targetPath)
 	if err != nil {
 		logging.Debug("target skills not found, nothing to delete",
 			logging.Platform(string(target)),
@@ -709,6 +1130,12 @@ func (s *Synchronizer) DeleteWithSkills(
 		sourceSkillNames[skill.Name] = skill
 	}
 
+	// Skills aggregated into one AGENTS.md are removed from the file, not with it
+	skillsPerFile := make(map[string]int)
+	for _, targetSkill := range targetSkills {
+		skillsPerFile[targetSkill.Path]++
+	}
+
 	// Find target skills that match source skills and delete them
 	for _, targetSkill := range targetSkills {
 		sourceSkill, exists := sourceSkillNames[targetSkill.Name]
@@ -724,10 +1151,35 @@ func (s *Synchronizer) DeleteWithSkills(
 			Skill:      sourceSkill,
 			TargetPath: targetSkill.Path,
 		}
+		shared := skillsPerFile[targetSkill.Path] > 1
 
 		// Delete the skill file
 		if !opts.DryRun {
-			if err := os.Remove(targetSkill.Path); err != nil {
+			if opts.Backup {
+				ids, err := backupTarget(targetSkill.Path, targetSkill, target, opts, "pre-delete backup", []string{"delete"})
+				if err != nil {
+					logging.Error("failed to back up skill before delete",
+						logging.Skill(targetSkill.Name),
+						logging.Path(targetSkill.Path),
+						logging.Err(err),
+					)
+					skillResult.Action = ActionFailed
+					skillResult.Error = err
+					result.Skills = append(result.Skills, skillResult)
+					continue
+				}
+				skillResult.BackupIDs = ids
+			}
+
+			var err error
+			if shared {
+				err = removeAgentsEntry(targetSkill)
+			} else if opts.TrashRetention > 0 {
+				err = trashTarget(targetSkill.Path, targetSkill, target, opts)
+			} else {
+				err = os.Remove(targetSkill.Path)
+			}
+			if err != nil {
 				logging.Error("failed to delete skill file",
 					logging.Skill(targetSkill.Name),
 					logging.Path(targetSkill.Path),
@@ -754,6 +1206,11 @@ func (s *Synchronizer) DeleteWithSkills(
 
 		skillResult.Action = ActionDeleted
 		skillResult.Message = "deleted from target"
+		if shared {
+			skillResult.Message = "removed from " + filepath.Base(targetSkill.Path)
+		} else if opts.TrashRetention > 0 {
+			skillResult.Message = fmt.Sprintf("moved to trash for %s", formatRetention(opts.TrashRetention))
+		}
 		result.Skills = append(result.Skills, skillResult)
 	}
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
 )
 
 func TestNew(t *testing.T) {
@@ -471,3 +472,76 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestSyncWithSkills_Preview(t *testing.T) {
+	sourceDir := t.TempDir()
+	targetDir := t.TempDir()
+	skillPath := filepath.Join(sourceDir, "lint.md")
+	util.WriteFile(t, skillPath, "---\nname: lint\n---\nRun the linter.\n")
+	skill := model.Skill{Name: "lint", Platform: model.ClaudeCode, Path: skillPath, Content: "Run the linter.\n"}
+	opts := Options{DryRun: true, Preview: true, Strategy: StrategyOverwrite, TargetPath: targetDir}
+
+	created, err := New().SyncWithSkills([]model.Skill{skill}, model.Cursor, opts)
+	util.AssertNoError(t, err)
+	preview := created.Skills[0].Preview
+	if preview == nil || preview.Exists || preview.Before != "" || !strings.Contains(preview.After, "Run the linter.") {
+		t.Fatalf("preview of created skill = %+v", preview)
+	}
+
+	_, err = New().SyncWithSkills([]model.Skill{skill}, model.Cursor, Options{Strategy: StrategyOverwrite, TargetPath: targetDir})
+	util.AssertNoError(t, err)
+	written, err := os.ReadFile(preview.Path)
+	util.AssertNoError(t, err)
+
+	skill.Content = "Run the linter twice.\n"
+	updated, err := New().SyncWithSkills([]model.Skill{skill}, model.Cursor, opts)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, updated.Skills[0].Action, ActionUpdated)
+	preview = updated.Skills[0].Preview
+	if preview == nil || !preview.Exists || preview.Before != string(written) {
+		t.Fatalf("preview of updated skill = %+v, want current content %q", preview, written)
+	}
+
+	var changed []string
+	for _, hunk := range preview.Hunks(DefaultDiffOptions()) {
+		for _, line := range hunk.Lines {
+			if line.Type != DiffLineContext {
+				changed = append(changed, line.String())
+			}
+		}
+	}
+	util.AssertEqual(t, strings.Join(changed, "\n"), "-Run the linter.\n+Run the linter twice.")
+
+	// The dry run leaves the target alone
+	after, err := os.ReadFile(preview.Path)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(after), string(written))
+
+	opts.Preview = false
+	plain, err := New().SyncWithSkills([]model.Skill{skill}, model.Cursor, opts)
+	util.AssertNoError(t, err)
+	if plain.Skills[0].Preview != nil {
+		t.Error("Preview should only be recorded when asked for")
+	}
+}
+
+func TestSyncWithSkills_RepoScopeTarget(t *testing.T) {
+	repo := t.TempDir()
+	util.WriteFile(t, filepath.Join(repo, ".git", "HEAD"), "ref: refs/heads/main\n")
+	util.WriteFile(t, filepath.Join(repo, ".cursor", "skills", "lint", "SKILL.md"), "---\nname: lint\n---\nOlder lint.\n")
+	t.Setenv("SKILLSYNC_CURSOR_PATH", t.TempDir())
+	t.Chdir(repo)
+
+	sourceDir := t.TempDir()
+	util.WriteFile(t, filepath.Join(sourceDir, "lint", "SKILL.md"), "---\nname: lint\n---\nRun the linter.\n")
+	skill := model.Skill{Name: "lint", Platform: model.ClaudeCode, Path: filepath.Join(sourceDir, "lint", "SKILL.md"), Content: "Run the linter."}
+
+	// The existing skill in the repo scope is found, not the user scope's
+	result, err := New().SyncWithSkills([]model.Skill{skill}, model.Cursor, Options{
+		Strategy:    StrategySkip,
+		TargetScope: model.ScopeRepo,
+	})
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(result.Created()), 0)
+	util.AssertEqual(t, len(result.Skipped()), 1)
+}
@@ -8,10 +8,11 @@ import (
 	"path/filepath"
 	"strings"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/klauern/skillsync/internal/logging"
 	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser/aider"
+	"github.com/klauern/skillsync/internal/parser/codex"
+	"github.com/klauern/skillsync/internal/parser/external"
 )
 
 // Transformer handles skill transformation between platforms.
@@ -34,8 +35,17 @@ func (t *Transformer) Transform(skill model.Skill, targetPlatform model.Platform
 	transformed := skill
 	transformed.Platform = targetPlatform
 
-	// Update path for target platform
-	transformed.Path = t.transformPath(skill, targetPlatform)
+	// Update path for target platform. Custom platforms name their files
+	// with their file name template.
+	if def, ok := external.Lookup(targetPlatform); ok {
+		path, err := def.FilePath(skill)
+		if err != nil {
+			return model.Skill{}, err
+		}
+		transformed.Path = path
+	} else {
+		transformed.Path = t.transformPath(skill, targetPlatform)
+	}
 	logging.Debug("transformed path",
 		logging.Skill(skill.Name),
 		slog.String("original_path", skill.Path),
@@ -66,6 +76,12 @@ func (t *Transformer) Transform(skill model.Skill, targetPlatform model.Platform
 
 // transformPath generates the appropriate file path for the target platform.
 func (t *Transformer) transformPath(skill model.Skill, target model.Platform) string {
+	if target == model.Aider {
+		// Aider reads conventions files, so every single-file skill, prompts
+		// included, is aggregated into CONVENTIONS.md as a marked section
+		return aider.ConventionsFileName
+	}
+
 	if skill.Type == model.SkillTypePrompt {
 		switch target {
 		case model.Codex:
@@ -81,6 +97,10 @@ func (t *Transformer) transformPath(skill model.Skill, target model.Platform) st
 	}
 
 	baseName := filepath.Base(skill.Path)
+	if isAgentsSection(skill) && target != model.Codex {
+		// Each AGENTS.md section becomes its own skill file
+		return skill.Name + ".md"
+	}
 	if isSkillFile(baseName) && skill.Name != "" {
 		switch target {
 		case model.Codex:
@@ -107,11 +127,9 @@ func (t *Transformer) transformPath(skill model.Skill, target model.Platform) st
 		}
 		return nameWithoutExt + ".md"
 	case model.Codex:
-		// Codex uses AGENTS.md for agent instructions
-		if nameWithoutExt == "AGENTS" || nameWithoutExt == "agents" {
-			return "AGENTS.md"
-		}
-		return nameWithoutExt + ".md"
+		// Codex reads instructions from AGENTS.md, so single-file skills are
+		// aggregated into it as marked sections
+		return codex.AgentsFileName
 	default:
 		return baseName
 	}
@@ -119,6 +137,13 @@ func (t *Transformer) transformPath(skill model.Skill, target model.Platform) st
 
 // transformContent transforms skill content for the target platform.
 func (t *Transformer) transformContent(skill model.Skill, target model.Platform, targetPath string) (string, error) {
+	// Custom platforms with a write template render skills with it
+	if def, ok := external.Lookup(target); ok {
+		if content, rendered, err := def.Render(skill); err != nil || rendered {
+			return content, err
+		}
+	}
+
 	// Build frontmatter based on target platform
 	var frontmatter map[string]any
 	if shouldIncludeFrontmatter(target, targetPath) {
@@ -130,7 +155,7 @@ func (t *Transformer) transformContent(skill model.Skill, target model.Platform,
 	// Add frontmatter if present
 	if len(frontmatter) > 0 {
 		sb.WriteString("---\n")
-		fm, err := yaml.Marshal(frontmatter)
+		fm, err := renderFrontmatter(skill.Frontmatter, frontmatter)
 		if err != nil {
 			return "", fmt.Errorf("failed to marshal frontmatter: %w", err)
 		}
@@ -184,8 +209,9 @@ func (t *Transformer) buildFrontmatter(skill model.Skill, target model.Platform)
 
 	// Include other metadata that's platform-agnostic
 	for key, val := range skill.Metadata {
-		// Skip fields we've already handled
-		if key == "globs" || key == "alwaysApply" {
+		// Skip fields we've already handled, and metrics, which are
+		// recomputed for the target rather than copied from the source
+		if key == "globs" || key == "alwaysApply" || key == codex.SectionMetadataKey || key == MetricsKey {
 			continue
 		}
 		// Include if not already set
@@ -202,10 +228,14 @@ func isSkillFile(path string) bool {
 }
 
 func shouldIncludeFrontmatter(target model.Platform, targetPath string) bool {
-	if target == model.Codex {
+	switch target {
+	case model.Codex:
 		return isSkillFile(targetPath)
+	case model.Aider:
+		return false
+	default:
+		return true
 	}
-	return true
 }
 
 // transformMetadata transforms metadata for the target platform.
@@ -225,8 +255,8 @@ func (t *Transformer) transformMetadata(skill model.Skill, target model.Platform
 	case model.Cursor:
 		// Cursor metadata is typically preserved as-is
 
-	case model.Codex:
-		// Codex metadata handling - preserve source info
+	case model.Codex, model.Aider:
+		// Preserve source info
 		metadata["source_platform"] = string(skill.Platform)
 	}
 
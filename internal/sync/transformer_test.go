@@ -1,11 +1,16 @@
 package sync
 
 import (
+	"maps"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser/codex"
+	"github.com/klauern/skillsync/internal/parser/cursor"
+	"github.com/klauern/skillsync/internal/util"
 )
 
 func TestNewTransformer(t *testing.T) {
@@ -90,6 +95,20 @@ func TestTransformer_TransformPath(t *testing.T) {
 			target:     model.ClaudeCode,
 			expected:   "my-skill.md",
 		},
+		{
+			name:       "single-file skill to codex agents",
+			sourcePath: "/source/lint.md",
+			skillName:  "lint",
+			target:     model.Codex,
+			expected:   "AGENTS.md",
+		},
+		{
+			name:       "agents section to claude",
+			sourcePath: "/source/AGENTS.md",
+			skillName:  "lint",
+			target:     model.ClaudeCode,
+			expected:   "lint.md",
+		},
 		{
 			name:       "prompt to codex skill file",
 			sourcePath: "/source/review.md",
@@ -102,6 +121,9 @@ func TestTransformer_TransformPath(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			skill := model.Skill{Path: tt.sourcePath, Name: tt.skillName}
+			if tt.name == "agents section to claude" {
+				skill.Metadata = map[string]string{codex.SectionMetadataKey: "true"}
+			}
 			if tt.name == "prompt to codex skill file" {
 				skill.Type = model.SkillTypePrompt
 				skill.Trigger = "/review"
@@ -138,6 +160,98 @@ func TestTransformer_TransformContent(t *testing.T) {
 	}
 }
 
+func TestTransformer_TransformContent_PreservesFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	util.WriteFile(t, filepath.Join(dir, "go-style.mdc"), `---
+# Applies to Go sources
+description: Go style
+globs:
+  - "*.go"
+  - "**/*.go"
+alwaysApply: false # only when matching
+priority: 2
+options:
+  strict: true
+  level: 3
+---
+Use gofmt.
+`)
+	skills, err := cursor.New(dir).Parse()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(skills), 1)
+
+	tests := map[string]struct {
+		target model.Platform
+		edit   func(*model.Skill)
+		want   string
+	}{
+		"same platform keeps types, order, and comments": {
+			target: model.Cursor,
+			want: `# Applies to Go sources
+description: Go style
+globs:
+  - "*.go"
+  - "**/*.go"
+alwaysApply: false # only when matching
+priority: 2
+options:
+  strict: true
+  level: 3
+name: go-style`,
+		},
+		"fields the target does not get are dropped": {
+			target: model.ClaudeCode,
+			want: `# Applies to Go sources
+description: Go style
+priority: 2
+options:
+  strict: true
+  level: 3
+name: go-style`,
+		},
+		"changed values are replaced in place": {
+			target: model.Cursor,
+			edit: func(s *model.Skill) {
+				s.Metadata["description"] = "Go style, strictly"
+				s.Metadata["alwaysApply"] = "true"
+				s.Metadata["priority"] = "high"
+			},
+			want: `# Applies to Go sources
+description: Go style, strictly
+globs:
+  - "*.go"
+  - "**/*.go"
+alwaysApply: true # only when matching
+priority: high
+options:
+  strict: true
+  level: 3
+name: go-style`,
+		},
+		"skills without raw frontmatter": {
+			target: model.Cursor,
+			edit: func(s *model.Skill) {
+				s.Frontmatter = ""
+				s.Metadata = map[string]string{"description": "Go style"}
+			},
+			want: "description: Go style\nname: go-style",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			skill := skills[0]
+			skill.Metadata = maps.Clone(skill.Metadata)
+			if tt.edit != nil {
+				tt.edit(&skill)
+			}
+			content, err := NewTransformer().transformContent(skill, tt.target, "go-style.mdc")
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, content, "---\n"+tt.want+"\n---\n\nUse gofmt.")
+		})
+	}
+}
+
 func TestTransformer_TransformContent_CodexSkillFile(t *testing.T) {
 	tr := NewTransformer()
 
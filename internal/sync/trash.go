@@ -0,0 +1,107 @@
+package sync
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/trash"
+)
+
+// trashTarget moves a deleted target skill into the trash, where it is kept
+// for opts.TrashRetention before being purged.
+func trashTarget(path string, skill model.Skill, target model.Platform, opts Options) error {
+	entry, err := trash.Move(path, trash.Options{
+		Name:      skill.Name,
+		Platform:  string(target),
+		SessionID: opts.SessionID,
+		Retention: opts.TrashRetention,
+	})
+	if err != nil {
+		return err
+	}
+	logging.Debug("moved skill to trash",
+		logging.Skill(skill.Name),
+		logging.Path(path),
+		slog.String("trash_id", entry.ID),
+	)
+	return nil
+}
+
+// resurrectTrashed restores the trashed targets of source skills that are
+// missing from the target, so that the sync strategy compares against the
+// target's last content instead of treating the skill as new. This undoes
+// deletes caused by a source that was briefly unreadable. It returns the names
+// of the restored skills.
+func resurrectTrashed(skills []model.Skill, target model.Platform, targetPath string, opts Options) map[string]bool {
+	if opts.DryRun || opts.TrashRetention <= 0 {
+		return nil
+	}
+	if entries, err := trash.List(); err != nil || len(entries) == 0 {
+		return nil
+	}
+
+	restored := make(map[string]bool)
+	for _, skill := range skills {
+		if restored[skill.Name] {
+			continue
+		}
+		entry, err := trash.Find(string(target), skill.Name, targetPath)
+		if err != nil {
+			logging.Warn("failed to search trash", logging.Err(err))
+			return restored
+		}
+		if entry == nil {
+			continue
+		}
+		if _, err := os.Lstat(entry.OriginalPath); err == nil {
+			continue // The target was recreated since; keep it
+		}
+		if err := trash.Restore(*entry); err != nil {
+			logging.Warn("failed to restore skill from trash",
+				logging.Skill(skill.Name),
+				logging.Path(entry.OriginalPath),
+				logging.Err(err),
+			)
+			continue
+		}
+		logging.Debug("restored skill from trash",
+			logging.Skill(skill.Name),
+			logging.Path(entry.OriginalPath),
+		)
+		restored[skill.Name] = true
+	}
+	return restored
+}
+
+// markResurrected notes on each result whose target was restored from the trash.
+func markResurrected(result *Result, restored map[string]bool) {
+	for i := range result.Skills {
+		sr := &result.Skills[i]
+		if !restored[sr.Skill.Name] {
+			continue
+		}
+		if sr.Message == "" {
+			sr.Message = "restored from trash"
+		} else {
+			sr.Message += "; restored from trash"
+		}
+	}
+}
+
+// formatRetention formats a trash retention period, in days when it is a whole
+// number of days.
+func formatRetention(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d%day == 0 {
+		days := int(d / day)
+		if days == 1 {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", days)
+	}
+	return d.String()
+}
@@ -0,0 +1,106 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/trash"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestDeleteWithSkills_TrashAndResurrect(t *testing.T) {
+	tests := map[string]struct {
+		retention   time.Duration
+		strategy    Strategy
+		wantTrashed int
+		wantContent string
+		wantAction  Action
+	}{
+		"permanent delete": {
+			strategy:    StrategySkip,
+			wantContent: "new alpha",
+			wantAction:  ActionCreated,
+		},
+		"skip keeps the resurrected target": {
+			retention:   time.Hour,
+			strategy:    StrategySkip,
+			wantTrashed: 1,
+			wantContent: "Target edits",
+			wantAction:  ActionSkipped,
+		},
+		"overwrite updates the resurrected target": {
+			retention:   time.Hour,
+			strategy:    StrategyOverwrite,
+			wantTrashed: 1,
+			wantContent: "new alpha",
+			wantAction:  ActionUpdated,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SKILLSYNC_HOME", t.TempDir())
+			targetDir := t.TempDir()
+			targetFile := filepath.Join(targetDir, "alpha.md")
+			util.WriteFile(t, targetFile, "---\nname: alpha\n---\nTarget edits")
+
+			source := model.Skill{Name: "alpha", Platform: model.Cursor, Path: "/src/alpha.md", Content: "new alpha"}
+			result, err := New().DeleteWithSkills([]model.Skill{source}, model.ClaudeCode, Options{
+				TargetPath:     targetDir,
+				TrashRetention: tt.retention,
+			})
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(result.Deleted()), 1)
+			if _, err := os.Stat(targetFile); !os.IsNotExist(err) {
+				t.Fatalf("expected target to be removed, stat err = %v", err)
+			}
+
+			entries, err := trash.List()
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(entries), tt.wantTrashed)
+
+			// The skill reappears in the source on the next sync
+			result, err = New().SyncWithSkills([]model.Skill{source}, model.ClaudeCode, Options{
+				Strategy:       tt.strategy,
+				TargetPath:     targetDir,
+				TrashRetention: tt.retention,
+			})
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, result.Skills[0].Action, tt.wantAction)
+			if resurrected := strings.Contains(result.Skills[0].Message, "restored from trash"); resurrected != (tt.wantTrashed > 0) {
+				t.Errorf("unexpected message %q", result.Skills[0].Message)
+			}
+
+			got, err := os.ReadFile(targetFile)
+			util.AssertNoError(t, err)
+			if !strings.Contains(string(got), tt.wantContent) {
+				t.Errorf("target content = %q, want it to contain %q", got, tt.wantContent)
+			}
+
+			entries, err = trash.List()
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, len(entries), 0)
+		})
+	}
+}
+
+func TestFormatRetention(t *testing.T) {
+	tests := map[string]struct {
+		d    time.Duration
+		want string
+	}{
+		"one day":   {d: 24 * time.Hour, want: "1 day"},
+		"days":      {d: 7 * 24 * time.Hour, want: "7 days"},
+		"part days": {d: 36 * time.Hour, want: "36h0m0s"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			util.AssertEqual(t, formatRetention(tt.d), tt.want)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package sync
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// Variable is a template variable a skill declares under variables in its
+// frontmatter. Sync replaces {{name}} in the skill with the variable's value.
+type Variable struct {
+	Name string
+	// Description says what value is expected, for prompts
+	Description string
+	// Default is used when no value is given; empty means the value is
+	// required
+	Default string
+}
+
+// variableName matches the names variables may have.
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// SkillVariables returns the template variables the skill declares, sorted
+// by name. They are declared as a list of names or as a mapping of names to
+// defaults, or to a description and default:
+//
+//	variables:
+//	  project:
+//	    description: Name of the project
+//	  registry: ghcr.io
+func SkillVariables(skill model.Skill) ([]Variable, error) {
+	if skill.Frontmatter == "" {
+		return nil, nil
+	}
+	var frontmatter struct {
+		Variables yaml.Node `yaml:"variables"`
+	}
+	if err := yaml.Unmarshal([]byte(skill.Frontmatter), &frontmatter); err != nil {
+		return nil, nil // reported by validation, not by sync
+	}
+
+	var variables []Variable
+	node := frontmatter.Variables
+	switch node.Kind {
+	case 0:
+		return nil, nil
+	case yaml.SequenceNode:
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return nil, fmt.Errorf("variables: %w", err)
+		}
+		for _, name := range names {
+			variables = append(variables, Variable{Name: name})
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v := Variable{Name: node.Content[i].Value}
+			value := node.Content[i+1]
+			switch value.Kind {
+			case yaml.ScalarNode:
+				if value.Tag != "!!null" {
+					v.Default = value.Value
+				}
+			case yaml.MappingNode:
+				var fields struct {
+					Description string `yaml:"description"`
+					Default     string `yaml:"default"`
+				}
+				if err := value.Decode(&fields); err != nil {
+					return nil, fmt.Errorf("variables.%s: %w", v.Name, err)
+				}
+				v.Description, v.Default = fields.Description, fields.Default
+			default:
+				return nil, fmt.Errorf("variables.%s: expected a default value or a mapping", v.Name)
+			}
+			variables = append(variables, v)
+		}
+	default:
+		return nil, fmt.Errorf("variables: expected a list of names or a mapping")
+	}
+
+	for _, v := range variables {
+		if !variableName.MatchString(v.Name) {
+			return nil, fmt.Errorf("variables: invalid name %q", v.Name)
+		}
+	}
+	slices.SortFunc(variables, func(a, b Variable) int { return strings.Compare(a.Name, b.Name) })
+	return variables, nil
+}
+
+// MissingVariable is a required variable without a value, and the skills
+// declaring it.
+type MissingVariable struct {
+	Variable
+	Skills []string
+}
+
+// MissingVariables returns the variables the skills declare that have
+// neither a value in values nor a default, sorted by name. Skills whose
+// variables cannot be read are left to the sync to report.
+func MissingVariables(skills []model.Skill, values map[string]string) []MissingVariable {
+	missing := make(map[string]*MissingVariable)
+	for _, skill := range skills {
+		variables, err := SkillVariables(skill)
+		if err != nil {
+			continue
+		}
+		for _, v := range variables {
+			if _, ok := values[v.Name]; ok || v.Default != "" {
+				continue
+			}
+			m, ok := missing[v.Name]
+			if !ok {
+				m = &MissingVariable{Variable: v}
+				missing[v.Name] = m
+			}
+			if m.Description == "" {
+				m.Description = v.Description
+			}
+			m.Skills = append(m.Skills, skill.Name)
+		}
+	}
+
+	result := make([]MissingVariable, 0, len(missing))
+	for _, name := range slices.Sorted(maps.Keys(missing)) {
+		result = append(result, *missing[name])
+	}
+	return result
+}
+
+// skillVariableValues returns the value of each variable the skill
+// declares: from values, else the default. It is nil for skills without
+// variables and fails when any is undefined.
+func skillVariableValues(skill model.Skill, values map[string]string) (map[string]string, error) {
+	variables, err := SkillVariables(skill)
+	if err != nil || len(variables) == 0 {
+		return nil, err
+	}
+	resolved := make(map[string]string, len(variables))
+	var undefined []string
+	for _, v := range variables {
+		value, ok := values[v.Name]
+		if !ok {
+			if v.Default == "" {
+				undefined = append(undefined, v.Name)
+				continue
+			}
+			value = v.Default
+		}
+		resolved[v.Name] = value
+	}
+	if len(undefined) > 0 {
+		return nil, fmt.Errorf("undefined template variables: %s (set them with --var NAME=VALUE)", strings.Join(undefined, ", "))
+	}
+	return resolved, nil
+}
+
+// placeholder matches a {{name}} placeholder, with optional spaces inside
+// the braces.
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+// ExpandVariables replaces the {{name}} placeholders of the variables in
+// values with their values. Other {{...}} text is left alone.
+func ExpandVariables(content string, values map[string]string) string {
+	if len(values) == 0 {
+		return content
+	}
+	return placeholder.ReplaceAllStringFunc(content, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// expandedTreeUnchanged reports whether the directory at targetPath matches
+// the source directory with its skill file, at rel, holding content.
+func expandedTreeUnchanged(sourceRoot, targetPath, rel, content string) bool {
+	replace := map[string]string{filepath.ToSlash(rel): contentHash([]byte(content))}
+	sourceSum, err := treeHashWith(sourceRoot, replace)
+	if err != nil {
+		return false
+	}
+	targetSum, err := treeHash(targetPath)
+	return err == nil && sourceSum == targetSum
+}
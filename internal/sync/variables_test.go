@@ -0,0 +1,161 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestSkillVariables(t *testing.T) {
+	tests := map[string]struct {
+		frontmatter string
+		want        string
+		wantErr     string
+	}{
+		"list of names": {
+			frontmatter: "name: lint\nvariables: [project, owner]\n",
+			want:        "owner; project",
+		},
+		"defaults and descriptions": {
+			frontmatter: "variables:\n  registry: ghcr.io\n  project:\n    description: Name of the project\n  owner:\n",
+			want:        "owner; project (Name of the project); registry=ghcr.io",
+		},
+		"no variables": {
+			frontmatter: "name: lint\n",
+		},
+		"no frontmatter": {},
+		"invalid name": {
+			frontmatter: "variables: [\"two words\"]\n",
+			wantErr:     `variables: invalid name "two words"`,
+		},
+		"not a list or mapping": {
+			frontmatter: "variables: project\n",
+			wantErr:     "variables: expected a list of names or a mapping",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			variables, err := SkillVariables(model.Skill{Name: "lint", Frontmatter: tt.frontmatter})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("SkillVariables() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			var got []string
+			for _, v := range variables {
+				s := v.Name
+				if v.Description != "" {
+					s += fmt.Sprintf(" (%s)", v.Description)
+				}
+				if v.Default != "" {
+					s += "=" + v.Default
+				}
+				got = append(got, s)
+			}
+			util.AssertEqual(t, strings.Join(got, "; "), tt.want)
+		})
+	}
+}
+
+func TestMissingVariables(t *testing.T) {
+	skills := []model.Skill{
+		{Name: "lint", Frontmatter: "variables: [project, owner]\n"},
+		{Name: "release", Frontmatter: "variables:\n  project:\n    description: Name of the project\n  registry: ghcr.io\n"},
+		{Name: "review"},
+	}
+
+	var got []string
+	for _, m := range MissingVariables(skills, map[string]string{"owner": ""}) {
+		got = append(got, fmt.Sprintf("%s (%s) %s", m.Name, m.Description, strings.Join(m.Skills, ",")))
+	}
+	util.AssertEqual(t, strings.Join(got, "; "), "project (Name of the project) lint,release")
+}
+
+func TestExpandVariables(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    string
+	}{
+		"placeholders":          {content: "Deploy {{project}} to {{ registry }}.", want: "Deploy acme to ghcr.io."},
+		"unknown names kept":    {content: "Render {{ .Name }} and {{other}}.", want: "Render {{ .Name }} and {{other}}."},
+		"repeated placeholders": {content: "{{project}}/{{project}}", want: "acme/acme"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := ExpandVariables(tt.content, map[string]string{"project": "acme", "registry": "ghcr.io"})
+			util.AssertEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestSyncWithSkills_Variables(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	frontmatter := "name: deploy\ndescription: Deploy {{project}}\nvariables: [project]\n"
+	raw := "---\n" + frontmatter + "---\nPush {{project}} images.\n"
+
+	tests := map[string]struct {
+		dir     bool
+		vars    map[string]string
+		wantErr string
+	}{
+		"file skill":         {vars: map[string]string{"project": "acme"}},
+		"directory skill":    {dir: true, vars: map[string]string{"project": "acme"}},
+		"undefined variable": {wantErr: "undefined template variables: project"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sourceFile := filepath.Join(t.TempDir(), "deploy.md")
+			if tt.dir {
+				sourceFile = filepath.Join(t.TempDir(), "deploy", "SKILL.md")
+			}
+			util.WriteFile(t, sourceFile, raw)
+			source := model.Skill{
+				Name:        "deploy",
+				Description: "Deploy {{project}}",
+				Platform:    model.ClaudeCode,
+				Path:        sourceFile,
+				Content:     "Push {{project}} images.\n",
+				Frontmatter: frontmatter,
+			}
+			targetDir := t.TempDir()
+			opts := Options{Strategy: StrategyOverwrite, TargetPath: targetDir, Vars: tt.vars}
+
+			result, err := New().SyncWithSkills([]model.Skill{source}, model.Cursor, opts)
+			util.AssertNoError(t, err)
+			sr := result.Skills[0]
+			if tt.wantErr != "" {
+				util.AssertEqual(t, sr.Action, ActionFailed)
+				if sr.Error == nil || !strings.Contains(sr.Error.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", sr.Error, tt.wantErr)
+				}
+				return
+			}
+			util.AssertEqual(t, sr.Action, ActionCreated)
+
+			written := sr.TargetPath
+			if tt.dir {
+				written = filepath.Join(written, "SKILL.md")
+			}
+			data, err := os.ReadFile(written)
+			util.AssertNoError(t, err)
+			if !strings.Contains(string(data), "Push acme images.") || strings.Contains(string(data), "{{project}}") {
+				t.Errorf("variables not filled in:\n%s", data)
+			}
+
+			// A second sync finds the filled-in target up to date
+			result, err = New().SyncWithSkills([]model.Skill{source}, model.Cursor, opts)
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, result.Skills[0].Action, ActionUnchanged)
+		})
+	}
+}
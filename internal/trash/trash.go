@@ -0,0 +1,257 @@
+// Package trash keeps target skills removed by delete syncs for a retention
+// period before they are permanently removed, so that a skill which reappears
+// in the source can be brought back instead of being lost.
+package trash
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/logging"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+const (
+	// DirPerm is the permission for trash directories (rwxr-x---)
+	DirPerm = 0o750
+	// FilePerm is the permission for trash metadata files (rw-r-----)
+	FilePerm = 0o640
+
+	// DefaultRetentionDays is how long trashed skills are kept by default.
+	DefaultRetentionDays = 7
+
+	entryFile   = "entry.json"
+	contentName = "content"
+)
+
+// Entry describes a trashed target skill. Entries live in
+// <trash>/<YYYY-MM-DD>/<id>/, next to the moved file or directory.
+type Entry struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Platform     string    `json:"platform"`
+	OriginalPath string    `json:"original_path"`
+	SessionID    string    `json:"session_id,omitempty"` // Delete run that trashed the skill
+	TrashedAt    time.Time `json:"trashed_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+
+	dir string
+}
+
+// Expired reports whether the entry's retention period has passed.
+func (e Entry) Expired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// Options configures how a skill is trashed.
+type Options struct {
+	Name      string        // Skill name
+	Platform  string        // Platform identifier (claude-code, cursor, codex, aider)
+	SessionID string        // Delete run that trashed the skill, if any
+	Retention time.Duration // How long to keep the skill before it is purged
+}
+
+// Move moves the file or directory at path into the trash.
+func Move(path string, opts Options) (*Entry, error) {
+	if _, err := os.Lstat(path); err != nil {
+		return nil, fmt.Errorf("failed to stat %q: %w", path, err)
+	}
+
+	now := time.Now()
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	entry := &Entry{
+		ID:           now.Format("20060102-150405-") + hex.EncodeToString(b[:]),
+		Name:         opts.Name,
+		Platform:     opts.Platform,
+		OriginalPath: path,
+		SessionID:    opts.SessionID,
+		TrashedAt:    now,
+		ExpiresAt:    now.Add(opts.Retention),
+	}
+	entry.dir = filepath.Join(util.SkillsyncTrashPath(), now.Format("2006-01-02"), entry.ID)
+
+	if err := util.EnsureDataDir(entry.dir, DirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	if err := move(path, filepath.Join(entry.dir, contentName)); err != nil {
+		_ = os.RemoveAll(entry.dir)
+		return nil, fmt.Errorf("failed to move %q to trash: %w", path, err)
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode trash entry: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(entry.dir, entryFile), data, FilePerm); err != nil {
+		return nil, fmt.Errorf("failed to write trash entry: %w", err)
+	}
+	return entry, nil
+}
+
+// List returns all trashed skills, newest first. Entries whose metadata cannot
+// be read are skipped.
+func List() ([]Entry, error) {
+	matches, err := filepath.Glob(filepath.Join(util.SkillsyncTrashPath(), "*", "*", entryFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(matches))
+	for _, path := range matches {
+		// #nosec G304 - path is constructed from the trusted skillsync home
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logging.Warn("skipping unreadable trash entry", logging.Path(path), logging.Err(err))
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			logging.Warn("skipping unreadable trash entry", logging.Path(path), logging.Err(err))
+			continue
+		}
+		entry.dir = filepath.Dir(path)
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].TrashedAt.After(entries[j].TrashedAt)
+	})
+	return entries, nil
+}
+
+// Find returns the most recently trashed, unexpired copy of the named skill
+// that was removed from below root on the given platform, or nil if there is
+// none.
+func Find(platform, name, root string) (*Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	prefix := filepath.Clean(root) + string(filepath.Separator)
+	for _, entry := range entries {
+		if entry.Platform != platform || entry.Name != name || entry.Expired(now) {
+			continue
+		}
+		if strings.HasPrefix(filepath.Clean(entry.OriginalPath), prefix) {
+			return &entry, nil
+		}
+	}
+	return nil, nil
+}
+
+// Restore moves a trashed skill back to its original path and removes the
+// entry. It fails if something already exists at the original path.
+func Restore(entry Entry) error {
+	if _, err := os.Lstat(entry.OriginalPath); err == nil {
+		return fmt.Errorf("cannot restore %q: path already exists", entry.OriginalPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(entry.OriginalPath), DirPerm); err != nil {
+		return fmt.Errorf("failed to create directory for %q: %w", entry.OriginalPath, err)
+	}
+	if err := move(filepath.Join(entry.dir, contentName), entry.OriginalPath); err != nil {
+		return fmt.Errorf("failed to restore %q from trash: %w", entry.OriginalPath, err)
+	}
+	return remove(entry)
+}
+
+// Purge permanently removes entries whose retention period has passed and
+// returns them.
+func Purge(now time.Time) ([]Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var purged []Entry
+	for _, entry := range entries {
+		if !entry.Expired(now) {
+			continue
+		}
+		if err := remove(entry); err != nil {
+			return purged, err
+		}
+		purged = append(purged, entry)
+	}
+	return purged, nil
+}
+
+// remove deletes an entry directory and its dated parent once that is empty.
+func remove(entry Entry) error {
+	if err := os.RemoveAll(entry.dir); err != nil {
+		return fmt.Errorf("failed to remove trash entry %s: %w", entry.ID, err)
+	}
+	// Fails, and keeps the directory, while other entries from that day remain
+	_ = os.Remove(filepath.Dir(entry.dir))
+	return nil
+}
+
+// move renames src to dst, copying and then removing src when they are on
+// different file systems.
+func move(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	if err := copyTree(src, dst); err != nil {
+		_ = os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+// copyTree copies a file, directory, or symlink, preserving symlinks and modes.
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		default:
+			return copyFile(path, target, info.Mode().Perm())
+		}
+	})
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	// #nosec G304 - src is a skill file being moved by skillsync
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	// #nosec G304 - dst is inside the trash or the skill's original location
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,140 @@
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestMoveAndRestore(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	root := t.TempDir()
+	skillDir := filepath.Join(root, "lint")
+	util.WriteFile(t, filepath.Join(skillDir, "SKILL.md"), "---\nname: lint\n---\nLint.\n")
+	util.WriteFile(t, filepath.Join(skillDir, "references", "rules.md"), "rules")
+
+	entry, err := Move(skillDir, Options{Name: "lint", Platform: "claude-code", SessionID: "s1", Retention: time.Hour})
+	util.AssertNoError(t, err)
+	if _, err := os.Stat(skillDir); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be moved to trash, stat err = %v", skillDir, err)
+	}
+
+	entries, err := List()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(entries), 1)
+	util.AssertEqual(t, entries[0].ID, entry.ID)
+	util.AssertEqual(t, entries[0].OriginalPath, skillDir)
+	util.AssertEqual(t, entries[0].SessionID, "s1")
+
+	util.AssertNoError(t, Restore(entries[0]))
+	data, err := os.ReadFile(filepath.Join(skillDir, "references", "rules.md"))
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), "rules")
+
+	entries, err = List()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(entries), 0)
+}
+
+func TestRestore_ExistingPath(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "lint.md")
+	util.WriteFile(t, path, "old")
+
+	entry, err := Move(path, Options{Name: "lint", Platform: "cursor", Retention: time.Hour})
+	util.AssertNoError(t, err)
+	util.WriteFile(t, path, "new")
+
+	if err := Restore(*entry); err == nil {
+		t.Fatal("expected error restoring over an existing file")
+	}
+	data, err := os.ReadFile(path)
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(data), "new")
+}
+
+func TestFind(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "skills")
+
+	tests := map[string]struct {
+		trash     []Options
+		platform  string
+		name      string
+		root      string
+		wantFound bool
+	}{
+		"matching entry": {
+			trash:     []Options{{Name: "lint", Platform: "cursor", Retention: time.Hour}},
+			platform:  "cursor",
+			name:      "lint",
+			root:      root,
+			wantFound: true,
+		},
+		"other platform": {
+			trash:    []Options{{Name: "lint", Platform: "codex", Retention: time.Hour}},
+			platform: "cursor",
+			name:     "lint",
+			root:     root,
+		},
+		"other name": {
+			trash:    []Options{{Name: "format", Platform: "cursor", Retention: time.Hour}},
+			platform: "cursor",
+			name:     "lint",
+			root:     root,
+		},
+		"other root": {
+			trash:    []Options{{Name: "lint", Platform: "cursor", Retention: time.Hour}},
+			platform: "cursor",
+			name:     "lint",
+			root:     filepath.Join(root, "nested"),
+		},
+		"expired": {
+			trash:    []Options{{Name: "lint", Platform: "cursor", Retention: -time.Minute}},
+			platform: "cursor",
+			name:     "lint",
+			root:     root,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SKILLSYNC_HOME", t.TempDir())
+			for _, opts := range tt.trash {
+				path := filepath.Join(root, opts.Name+".md")
+				util.WriteFile(t, path, opts.Name)
+				_, err := Move(path, opts)
+				util.AssertNoError(t, err)
+			}
+
+			entry, err := Find(tt.platform, tt.name, tt.root)
+			util.AssertNoError(t, err)
+			if (entry != nil) != tt.wantFound {
+				t.Errorf("Find() = %+v, wantFound %v", entry, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	root := t.TempDir()
+	for name, retention := range map[string]time.Duration{"old": -time.Minute, "fresh": time.Hour} {
+		path := filepath.Join(root, name+".md")
+		util.WriteFile(t, path, name)
+		_, err := Move(path, Options{Name: name, Platform: "cursor", Retention: retention})
+		util.AssertNoError(t, err)
+	}
+
+	purged, err := Purge(time.Now())
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(purged), 1)
+	util.AssertEqual(t, purged[0].Name, "old")
+
+	entries, err := List()
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(entries), 1)
+	util.AssertEqual(t, entries[0].Name, "fresh")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
 )
 
 // DiffAction represents the action to perform after viewing diff.
@@ -33,12 +34,13 @@ type SyncDiffResult struct {
 
 // syncDiffKeyMap defines the key bindings for the diff viewer.
 type syncDiffKeyMap struct {
-	Up   key.Binding
-	Down key.Binding
-	Back key.Binding
-	Sync key.Binding
-	Help key.Binding
-	Quit key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Back      key.Binding
+	Sync      key.Binding
+	Algorithm key.Binding
+	Help      key.Binding
+	Quit      key.Binding
 }
 
 func defaultSyncDiffKeyMap() syncDiffKeyMap {
@@ -59,6 +61,10 @@ func defaultSyncDiffKeyMap() syncDiffKeyMap {
 			key.WithKeys("y"),
 			key.WithHelp("y", "sync this skill"),
 		),
+		Algorithm: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "cycle diff algorithm"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -83,6 +89,7 @@ type SyncDiffModel struct {
 	quitting       bool
 	sourcePlatform model.Platform
 	targetPlatform model.Platform
+	diffOpts       sync.DiffOptions
 	ready          bool
 }
 
@@ -117,6 +124,7 @@ func NewSyncDiffModel(skill model.Skill, targetSkill *model.Skill, source, targe
 		keys:           defaultSyncDiffKeyMap(),
 		sourcePlatform: source,
 		targetPlatform: target,
+		diffOpts:       sync.CurrentDiffOptions(),
 	}
 }
 
@@ -173,6 +181,13 @@ func (m SyncDiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.quitting = true
 			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Algorithm):
+			m.diffOpts.Algorithm = nextDiffAlgorithm(m.diffOpts.Algorithm)
+			if m.ready {
+				m.viewport.SetContent(m.buildDiffContent())
+			}
+			return m, nil
 		}
 	}
 
@@ -242,12 +257,56 @@ func (m SyncDiffModel) buildDiffContent() string {
 			srcLines := strings.Count(m.skill.Content, "\n") + 1
 			tgtLines := strings.Count(m.targetSkill.Content, "\n") + 1
 			b.WriteString(syncDiffStyles.Info.Render(fmt.Sprintf("  Source: %d lines, Target: %d lines", srcLines, tgtLines)))
+			b.WriteString("\n")
+
+			// Changes syncing would make to the target
+			b.WriteString(syncDiffStyles.SectionHdr.Render(fmt.Sprintf("Changes (%s)", m.diffOpts.Algorithm)))
+			b.WriteString("\n")
+			b.WriteString(m.formatHunks())
 		}
 	}
 
 	return b.String()
 }
 
+// formatHunks renders the unified diff from the current target content to
+// the source content.
+func (m SyncDiffModel) formatHunks() string {
+	hunks := sync.ComputeDiff(
+		strings.Split(m.targetSkill.Content, "\n"),
+		strings.Split(m.skill.Content, "\n"),
+		m.diffOpts,
+	)
+
+	var lines []string
+	for _, hunk := range hunks {
+		lines = append(lines, syncDiffStyles.Header.Render(fmt.Sprintf("@@ -%d,%d +%d,%d @@",
+			hunk.SourceStart, hunk.SourceCount, hunk.TargetStart, hunk.TargetCount)))
+		for _, line := range hunk.Lines {
+			switch line.Type {
+			case sync.DiffLineAdded:
+				lines = append(lines, syncDiffStyles.Added.Render(line.String()))
+			case sync.DiffLineRemoved:
+				lines = append(lines, syncDiffStyles.Removed.Render(line.String()))
+			default:
+				lines = append(lines, syncDiffStyles.Unchanged.Render(line.String()))
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// nextDiffAlgorithm returns the algorithm after current in AllDiffAlgorithms.
+func nextDiffAlgorithm(current sync.DiffAlgorithm) sync.DiffAlgorithm {
+	algorithms := sync.AllDiffAlgorithms()
+	for i, algorithm := range algorithms {
+		if algorithm == current {
+			return algorithms[(i+1)%len(algorithms)]
+		}
+	}
+	return algorithms[0]
+}
+
 func formatContentWithLineNumbers(content string, style lipgloss.Style) string {
 	lines := strings.Split(content, "\n")
 	var b strings.Builder
@@ -287,7 +346,7 @@ func (m SyncDiffModel) View() string {
 
 	// Status bar
 	scrollPercent := int(m.viewport.ScrollPercent() * 100)
-	status := fmt.Sprintf("Scroll: %d%% • %s → %s", scrollPercent, m.sourcePlatform, m.targetPlatform)
+	status := fmt.Sprintf("Scroll: %d%% • %s → %s • diff: %s", scrollPercent, m.sourcePlatform, m.targetPlatform, m.diffOpts.Algorithm)
 	b.WriteString(syncDiffStyles.Status.Render(status))
 	b.WriteString("\n")
 
@@ -309,6 +368,7 @@ func (m SyncDiffModel) renderShortHelp() string {
 		"↑/↓ scroll",
 		"b back",
 		"y sync",
+		"a algorithm",
 		"? help",
 		"q quit",
 	}
@@ -325,6 +385,7 @@ func (m SyncDiffModel) renderFullHelp() string {
 Actions:
   b/Esc    Go back to skill list
   y        Sync this skill
+  a        Cycle diff algorithm (myers, patience, histogram)
 
 General:
   ?        Toggle full help
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
 )
 
 func TestSyncDiffModel_BuildDiffContent_NewSkill(t *testing.T) {
@@ -88,6 +89,27 @@ func TestSyncDiffModel_Update_SyncAction(t *testing.T) {
 	}
 }
 
+func TestSyncDiffModel_Update_CyclesDiffAlgorithm(t *testing.T) {
+	skill := model.Skill{Name: "changed-skill", Content: "a\nb\nc"}
+	target := model.Skill{Name: "changed-skill", Content: "a\nB\nc"}
+	m := NewSyncDiffModel(skill, &target, model.ClaudeCode, model.Cursor)
+	m.diffOpts = sync.DefaultDiffOptions()
+
+	content := m.buildDiffContent()
+	if !strings.Contains(content, "Changes (myers)") || !strings.Contains(content, "-B") || !strings.Contains(content, "+b") {
+		t.Errorf("expected myers hunks from target to source, got:\n%s", content)
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	updated := newModel.(SyncDiffModel)
+	if updated.diffOpts.Algorithm != sync.DiffPatience {
+		t.Errorf("expected patience after cycling, got %s", updated.diffOpts.Algorithm)
+	}
+	if !strings.Contains(updated.buildDiffContent(), "Changes (patience)") {
+		t.Error("expected diff content to use the new algorithm")
+	}
+}
+
 func TestFormatContentWithLineNumbers(t *testing.T) {
 	content := formatContentWithLineNumbers("line1\nline2", syncDiffStyles.Unchanged)
 	if !strings.Contains(content, "1 │") || !strings.Contains(content, "2 │") {
@@ -0,0 +1,307 @@
+// Package tui provides interactive terminal UI components using BubbleTea.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ValidationReviewAction represents the action to perform after reviewing
+// validation errors.
+type ValidationReviewAction int
+
+const (
+	// ValidationReviewActionNone means no action was taken (user aborted).
+	ValidationReviewActionNone ValidationReviewAction = iota
+	// ValidationReviewActionEdit means the user wants to edit a skill's file.
+	ValidationReviewActionEdit
+	// ValidationReviewActionRevalidate means the user wants to check the skills again.
+	ValidationReviewActionRevalidate
+	// ValidationReviewActionSkip means the user wants to sync without the invalid skills.
+	ValidationReviewActionSkip
+)
+
+// ValidationIssue is a skill that failed validation, with its errors.
+type ValidationIssue struct {
+	Name     string
+	Path     string
+	Messages []string
+}
+
+// ValidationReviewResult contains the result of the validation review interaction.
+type ValidationReviewResult struct {
+	Action ValidationReviewAction
+	// Issue is the skill to edit, for ValidationReviewActionEdit
+	Issue ValidationIssue
+}
+
+// validationReviewKeyMap defines the key bindings for the validation review.
+type validationReviewKeyMap struct {
+	Up         key.Binding
+	Down       key.Binding
+	Edit       key.Binding
+	Revalidate key.Binding
+	Skip       key.Binding
+	Help       key.Binding
+	Quit       key.Binding
+}
+
+func defaultValidationReviewKeyMap() validationReviewKeyMap {
+	return validationReviewKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("enter", "e"),
+			key.WithHelp("enter/e", "open in editor"),
+		),
+		Revalidate: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "revalidate"),
+		),
+		Skip: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "skip invalid skills"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "help"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "esc", "ctrl+c"),
+			key.WithHelp("q", "abort sync"),
+		),
+	}
+}
+
+// ValidationReviewModel is the BubbleTea model for reviewing the skills that
+// failed validation before a sync.
+type ValidationReviewModel struct {
+	issues   []ValidationIssue
+	general  []string
+	cursor   int
+	keys     validationReviewKeyMap
+	result   ValidationReviewResult
+	showHelp bool
+	width    int
+	height   int
+	quitting bool
+}
+
+// Styles for the validation review TUI.
+var validationReviewStyles = struct {
+	Title    lipgloss.Style
+	Help     lipgloss.Style
+	Item     lipgloss.Style
+	Selected lipgloss.Style
+	Path     lipgloss.Style
+	Error    lipgloss.Style
+	Status   lipgloss.Style
+}{
+	Title:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6")).Padding(0, 1),
+	Help:     lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
+	Item:     lipgloss.NewStyle().Padding(0, 2),
+	Selected: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Padding(0, 2),
+	Path:     lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Padding(0, 4),
+	Error:    lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Padding(0, 4),
+	Status:   lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Padding(0, 1),
+}
+
+// NewValidationReviewModel creates a new validation review model. general
+// holds the errors that do not belong to a single skill; while there are any,
+// the invalid skills cannot be skipped.
+func NewValidationReviewModel(issues []ValidationIssue, general []string) ValidationReviewModel {
+	return ValidationReviewModel{
+		issues:  issues,
+		general: general,
+		keys:    defaultValidationReviewKeyMap(),
+	}
+}
+
+// Init implements tea.Model.
+func (m ValidationReviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m ValidationReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			m.result = ValidationReviewResult{Action: ValidationReviewActionNone}
+			m.quitting = true
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = !m.showHelp
+			return m, nil
+
+		case key.Matches(msg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Down):
+			if m.cursor < len(m.issues)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Edit):
+			if len(m.issues) == 0 || m.issues[m.cursor].Path == "" {
+				return m, nil
+			}
+			m.result = ValidationReviewResult{
+				Action: ValidationReviewActionEdit,
+				Issue:  m.issues[m.cursor],
+			}
+			m.quitting = true
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Revalidate):
+			m.result = ValidationReviewResult{Action: ValidationReviewActionRevalidate}
+			m.quitting = true
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Skip):
+			if !m.canSkip() {
+				return m, nil
+			}
+			m.result = ValidationReviewResult{Action: ValidationReviewActionSkip}
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// canSkip reports whether the sync can go on without the invalid skills.
+func (m ValidationReviewModel) canSkip() bool {
+	return len(m.general) == 0 && len(m.issues) > 0
+}
+
+// View implements tea.Model.
+func (m ValidationReviewModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString(validationReviewStyles.Title.Render(fmt.Sprintf("⚠ Validation Failed - %d skill(s) need attention", len(m.issues))))
+	b.WriteString("\n\n")
+
+	if len(m.general) > 0 {
+		b.WriteString(validationReviewStyles.Item.Render("General errors:"))
+		b.WriteString("\n")
+		for _, msg := range m.general {
+			b.WriteString(validationReviewStyles.Error.Render("• " + msg))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	for i, issue := range m.issues {
+		name := issue.Name
+		if name == "" {
+			name = "(unnamed skill)"
+		}
+		if i == m.cursor {
+			b.WriteString(validationReviewStyles.Selected.Render("> " + name))
+		} else {
+			b.WriteString(validationReviewStyles.Item.Render("  " + name))
+		}
+		b.WriteString("\n")
+		if issue.Path != "" {
+			b.WriteString(validationReviewStyles.Path.Render(issue.Path))
+			b.WriteString("\n")
+		}
+		for _, msg := range issue.Messages {
+			b.WriteString(validationReviewStyles.Error.Render("• " + msg))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+
+	status := "Fix the skills and revalidate, or skip them to sync the rest"
+	if !m.canSkip() {
+		status = "Fix the errors and revalidate; general errors cannot be skipped"
+	}
+	b.WriteString(validationReviewStyles.Status.Render(status))
+	b.WriteString("\n")
+
+	if m.showHelp {
+		b.WriteString("\n")
+		b.WriteString(m.renderFullHelp())
+	} else {
+		b.WriteString(m.renderShortHelp())
+	}
+
+	return b.String()
+}
+
+func (m ValidationReviewModel) renderShortHelp() string {
+	keys := []string{
+		"↑/↓ navigate",
+		"enter edit",
+		"r revalidate",
+	}
+	if m.canSkip() {
+		keys = append(keys, "s skip invalid")
+	}
+	keys = append(keys, "? help", "q abort")
+	return validationReviewStyles.Help.Render(strings.Join(keys, " • "))
+}
+
+func (m ValidationReviewModel) renderFullHelp() string {
+	help := `Navigation:
+  ↑/k      Move up
+  ↓/j      Move down
+
+Actions:
+  Enter/e  Open the skill in $EDITOR, then revalidate
+  r        Revalidate the skills
+  s        Sync without the invalid skills
+
+General:
+  ?        Toggle full help
+  q/Esc    Abort the sync`
+	return validationReviewStyles.Help.Render(help)
+}
+
+// Result returns the result of the user interaction.
+func (m ValidationReviewModel) Result() ValidationReviewResult {
+	return m.result
+}
+
+// RunValidationReview runs the interactive validation review and returns the result.
+func RunValidationReview(issues []ValidationIssue, general []string) (ValidationReviewResult, error) {
+	mdl := NewValidationReviewModel(issues, general)
+	finalModel, err := tea.NewProgram(mdl, tea.WithAltScreen()).Run()
+	if err != nil {
+		return ValidationReviewResult{}, err
+	}
+
+	if m, ok := finalModel.(ValidationReviewModel); ok {
+		return m.Result(), nil
+	}
+
+	return ValidationReviewResult{}, nil
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testValidationIssues() []ValidationIssue {
+	return []ValidationIssue{
+		{Name: "alpha", Path: "/skills/alpha/SKILL.md", Messages: []string{"name is required"}},
+		{Name: "beta", Path: "/skills/beta/SKILL.md", Messages: []string{"description is too long", "invalid tool"}},
+	}
+}
+
+func updateValidationReview(t *testing.T, m ValidationReviewModel, msg tea.Msg) (ValidationReviewModel, tea.Cmd) {
+	t.Helper()
+	newModel, cmd := m.Update(msg)
+	return newModel.(ValidationReviewModel), cmd
+}
+
+func TestValidationReviewModel_Actions(t *testing.T) {
+	tests := map[string]struct {
+		keys       []tea.KeyMsg
+		general    []string
+		wantAction ValidationReviewAction
+		wantQuit   bool
+		wantIssue  string
+	}{
+		"edit selected skill": {
+			keys:       []tea.KeyMsg{{Type: tea.KeyDown}, {Type: tea.KeyEnter}},
+			wantAction: ValidationReviewActionEdit,
+			wantQuit:   true,
+			wantIssue:  "beta",
+		},
+		"edit with e": {
+			keys:       []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'e'}}},
+			wantAction: ValidationReviewActionEdit,
+			wantQuit:   true,
+			wantIssue:  "alpha",
+		},
+		"revalidate": {
+			keys:       []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'r'}}},
+			wantAction: ValidationReviewActionRevalidate,
+			wantQuit:   true,
+		},
+		"skip invalid": {
+			keys:       []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'s'}}},
+			wantAction: ValidationReviewActionSkip,
+			wantQuit:   true,
+		},
+		"skip blocked by general errors": {
+			keys:       []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'s'}}},
+			general:    []string{"duplicate skill name"},
+			wantAction: ValidationReviewActionNone,
+		},
+		"abort": {
+			keys:       []tea.KeyMsg{{Type: tea.KeyEsc}},
+			wantAction: ValidationReviewActionNone,
+			wantQuit:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := NewValidationReviewModel(testValidationIssues(), tt.general)
+			var cmd tea.Cmd
+			for _, k := range tt.keys {
+				m, cmd = updateValidationReview(t, m, k)
+			}
+
+			result := m.Result()
+			if result.Action != tt.wantAction {
+				t.Errorf("Action = %v, want %v", result.Action, tt.wantAction)
+			}
+			if (cmd != nil) != tt.wantQuit {
+				t.Errorf("quit = %v, want %v", cmd != nil, tt.wantQuit)
+			}
+			if result.Issue.Name != tt.wantIssue {
+				t.Errorf("Issue = %q, want %q", result.Issue.Name, tt.wantIssue)
+			}
+		})
+	}
+}
+
+func TestValidationReviewModel_CursorBounds(t *testing.T) {
+	m := NewValidationReviewModel(testValidationIssues(), nil)
+
+	m, _ = updateValidationReview(t, m, tea.KeyMsg{Type: tea.KeyUp})
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d after up at top, want 0", m.cursor)
+	}
+	for range 3 {
+		m, _ = updateValidationReview(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d after moving past the end, want 1", m.cursor)
+	}
+}
+
+func TestValidationReviewModel_View(t *testing.T) {
+	m := NewValidationReviewModel(testValidationIssues(), []string{"duplicate skill name"})
+	view := m.View()
+
+	for _, want := range []string{
+		"2 skill(s) need attention",
+		"alpha",
+		"/skills/beta/SKILL.md",
+		"description is too long",
+		"invalid tool",
+		"duplicate skill name",
+		"general errors cannot be skipped",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+	if strings.Contains(view, "s skip invalid") {
+		t.Error("View() offers skipping while general errors remain")
+	}
+}
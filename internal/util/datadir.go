@@ -0,0 +1,98 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+)
+
+var (
+	// ErrNoHome is returned when skillsync has to write its own data but
+	// neither HOME nor SKILLSYNC_HOME is set.
+	ErrNoHome = errors.New("HOME is not set, so skillsync has nowhere to keep its config, backups, and cache: " +
+		"set SKILLSYNC_HOME to a writable directory, or pass --no-persist")
+
+	// ErrNoPersist is returned by writes to the skillsync home while
+	// persistence is disabled with --no-persist.
+	ErrNoPersist = errors.New("--no-persist is set, so skillsync does not write outside the sync target")
+)
+
+var noPersist atomic.Bool
+
+// SetNoPersist disables (or re-enables) every write skillsync makes for
+// itself: config, backups, history, trash, cache, and installed plugins.
+func SetNoPersist(disabled bool) {
+	noPersist.Store(disabled)
+}
+
+// NoPersist reports whether skillsync's own writes are disabled.
+func NoPersist() bool {
+	return noPersist.Load()
+}
+
+// CheckSkillsyncHome reports why skillsync cannot write to its home
+// directory, or nil if it can. A home that does not exist yet is writable
+// if its nearest existing parent is.
+func (r *PathResolver) CheckSkillsyncHome() error {
+	if NoPersist() {
+		return ErrNoPersist
+	}
+	if r.homeless() {
+		return ErrNoHome
+	}
+
+	dir := r.SkillsyncHome()
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return checkWritable(dir)
+}
+
+// homeless reports whether the skillsync home could not be resolved, leaving
+// it relative to the working directory.
+func (r *PathResolver) homeless() bool {
+	return r.getenv("SKILLSYNC_HOME") == "" && r.HomeDir() == ""
+}
+
+// EnsureDataDir creates dir, a directory for skillsync's own data such as
+// backups or the cache, explaining clearly when it cannot be written: HOME
+// is unset, the directory is read-only, or --no-persist is set.
+func EnsureDataDir(dir string, perm os.FileMode) error {
+	if NoPersist() {
+		return ErrNoPersist
+	}
+	if r := Paths(); r.homeless() {
+		if rel, err := filepath.Rel(r.SkillsyncHome(), dir); err == nil && filepath.IsLocal(rel) {
+			return ErrNoHome
+		}
+	}
+	if err := os.MkdirAll(dir, perm); err != nil {
+		return notWritable(dir, err)
+	}
+	return checkWritable(dir)
+}
+
+// checkWritable reports whether a file can be created in dir.
+func checkWritable(dir string) error {
+	probe, err := os.CreateTemp(dir, ".skillsync-write-check-*")
+	if err != nil {
+		return notWritable(dir, err)
+	}
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+	return nil
+}
+
+// notWritable explains a failed write to a skillsync data directory.
+func notWritable(dir string, err error) error {
+	return fmt.Errorf("%s is not writable (%w): set SKILLSYNC_HOME to a writable directory, or pass --no-persist", dir, err)
+}
@@ -0,0 +1,83 @@
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnsureDataDir(t *testing.T) {
+	tests := map[string]struct {
+		home      string
+		env       map[string]string
+		noPersist bool
+		dir       func(home string) string
+		wantErr   error
+	}{
+		"creates the directory": {
+			home: t.TempDir(),
+			dir:  func(home string) string { return filepath.Join(home, ".skillsync", "backups") },
+		},
+		"HOME unset": {
+			dir:     func(string) string { return filepath.Join(".skillsync", "backups") },
+			wantErr: ErrNoHome,
+		},
+		"HOME unset with SKILLSYNC_HOME": {
+			env: map[string]string{"SKILLSYNC_HOME": filepath.Join(t.TempDir(), "data")},
+			dir: func(string) string { return "" },
+		},
+		"no-persist": {
+			home:      t.TempDir(),
+			noPersist: true,
+			dir:       func(home string) string { return filepath.Join(home, ".skillsync") },
+			wantErr:   ErrNoPersist,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := testResolver(tt.home, tt.env)
+			prev := SetPaths(r)
+			t.Cleanup(func() { SetPaths(prev) })
+			SetNoPersist(tt.noPersist)
+			t.Cleanup(func() { SetNoPersist(false) })
+
+			dir := tt.dir(tt.home)
+			if dir == "" {
+				dir = r.BackupsPath()
+			}
+			err := EnsureDataDir(dir, 0o750)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EnsureDataDir() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+					t.Errorf("EnsureDataDir() did not create %s: %v", dir, err)
+				}
+			}
+			if err := r.CheckSkillsyncHome(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckSkillsyncHome() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnsureDataDir_ReadOnly(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can write to read-only directories")
+	}
+	home := t.TempDir()
+	if err := os.Chmod(home, 0o500); err != nil {
+		t.Fatalf("failed to make home read-only: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(home, 0o750) })
+	prev := SetPaths(testResolver(home, nil))
+	t.Cleanup(func() { SetPaths(prev) })
+
+	err := EnsureDataDir(filepath.Join(home, ".skillsync"), 0o750)
+	if err == nil {
+		t.Fatal("EnsureDataDir() succeeded in a read-only home")
+	}
+	AssertEqual(t, errors.Is(err, os.ErrPermission), true)
+}
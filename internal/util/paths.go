@@ -46,36 +46,71 @@ func CodexSkillsPath() string {
 // SkillsyncConfigPath returns the skillsync configuration directory
 // Supports SKILLSYNC_HOME environment variable override
 func SkillsyncConfigPath() string {
-	if configHome := os.Getenv("SKILLSYNC_HOME"); configHome != "" {
-		return configHome
-	}
-	return filepath.Join(HomeDir(), ".skillsync")
+	return Paths().SkillsyncHome()
 }
 
 // SkillsyncBackupsPath returns the skillsync backups directory
 func SkillsyncBackupsPath() string {
-	return filepath.Join(SkillsyncConfigPath(), "backups")
+	return Paths().BackupsPath()
 }
 
 // SkillsyncMetadataPath returns the skillsync metadata directory
 func SkillsyncMetadataPath() string {
-	return filepath.Join(SkillsyncConfigPath(), "metadata")
+	return Paths().MetadataPath()
+}
+
+// SkillsyncHistoryPath returns the sync history file
+func SkillsyncHistoryPath() string {
+	return Paths().HistoryPath()
+}
+
+// SkillsyncTrashPath returns the directory holding soft-deleted target skills
+func SkillsyncTrashPath() string {
+	return Paths().TrashPath()
 }
 
 // SkillsyncPluginsPath returns the skillsync plugins directory
 func SkillsyncPluginsPath() string {
-	return filepath.Join(SkillsyncConfigPath(), "plugins")
+	return Paths().PluginsPath()
+}
+
+// SkillsyncStorePath returns the canonical skill store directory
+func SkillsyncStorePath() string {
+	return Paths().StorePath()
+}
+
+// SkillsyncMarketplacesPath returns the directory holding cloned marketplace indexes
+func SkillsyncMarketplacesPath() string {
+	return Paths().MarketplacesPath()
+}
+
+// SkillsyncCachePath returns the directory holding parsed skill caches
+func SkillsyncCachePath() string {
+	return Paths().CachePath()
+}
+
+// SkillsyncExportsPath returns the directory holding export manifests
+func SkillsyncExportsPath() string {
+	return Paths().ExportsPath()
 }
 
 // ClaudePluginCachePath returns the Claude Code plugin cache directory
 // This is where Claude Code stores installed plugins from marketplaces.
+// Supports SKILLSYNC_CLAUDE_PLUGINS_PATH environment variable override
 func ClaudePluginCachePath() string {
-	return filepath.Join(HomeDir(), ".claude", "plugins", "cache")
+	return Paths().ClaudePluginCachePath()
 }
 
 // ClaudeInstalledPluginsPath returns the path to Claude Code's installed plugins manifest
+// Supports SKILLSYNC_CLAUDE_PLUGINS_PATH environment variable override
 func ClaudeInstalledPluginsPath() string {
-	return filepath.Join(HomeDir(), ".claude", "plugins", "installed_plugins.json")
+	return Paths().ClaudeInstalledPluginsPath()
+}
+
+// ClaudeKnownMarketplacesPath returns the path to Claude Code's known marketplaces manifest
+// Supports SKILLSYNC_CLAUDE_PLUGINS_PATH environment variable override
+func ClaudeKnownMarketplacesPath() string {
+	return Paths().ClaudeKnownMarketplacesPath()
 }
 
 // GetRepoRoot attempts to find the root of the current git repository.
@@ -132,9 +167,8 @@ func GetTieredPaths(cfg TieredPathConfig) map[model.SkillScope][]string {
 		}
 	}
 
-	// User scope: ~/.{platform}/skills
-	userPath := filepath.Join(HomeDir(), platformDir, "skills")
-	paths[model.ScopeUser] = []string{userPath}
+	// User scope: ~/.{platform}/skills (or its SKILLSYNC_<PLATFORM>_PATH override)
+	paths[model.ScopeUser] = []string{Paths().UserSkillsPath(cfg.Platform)}
 
 	// Admin scope: optional, typically /opt/{platform}/skills
 	if cfg.AdminPath != "" {
@@ -192,14 +226,17 @@ func platformDirName(p model.Platform) string {
 		return ".cursor"
 	case model.Codex:
 		return ".codex"
+	case model.Aider:
+		return ".aider"
 	default:
 		return "." + strings.ToLower(string(p))
 	}
 }
 
-// PlatformSkillsPath returns the user-level skills path for a platform.
+// PlatformSkillsPath returns the user-level skills path for a platform,
+// honoring SKILLSYNC_<PLATFORM>_PATH overrides.
 func PlatformSkillsPath(p model.Platform) string {
-	return filepath.Join(HomeDir(), platformDirName(p), "skills")
+	return Paths().UserSkillsPath(p)
 }
 
 // RepoSkillsPath returns the repo-level skills path for a platform.
@@ -217,9 +254,9 @@ func ExpandPath(path, baseDir string) string {
 
 	// Expand ~ to home directory
 	if strings.HasPrefix(path, "~/") {
-		path = filepath.Join(HomeDir(), path[2:])
+		path = filepath.Join(Paths().HomeDir(), path[2:])
 	} else if path == "~" {
-		return HomeDir()
+		return Paths().HomeDir()
 	}
 
 	// If already absolute, return as-is
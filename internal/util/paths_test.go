@@ -564,6 +564,56 @@ func TestSkillsyncMetadataPath(t *testing.T) {
 	})
 }
 
+func TestSkillsyncHistoryPath(t *testing.T) {
+	t.Run("default path without SKILLSYNC_HOME", func(t *testing.T) {
+		t.Setenv("SKILLSYNC_HOME", "")
+
+		got := SkillsyncHistoryPath()
+		expected := filepath.Join(HomeDir(), ".skillsync", "history.jsonl")
+
+		if got != expected {
+			t.Errorf("SkillsyncHistoryPath() = %q, want %q", got, expected)
+		}
+	})
+
+	t.Run("custom path with SKILLSYNC_HOME", func(t *testing.T) {
+		customPath := "/custom/skillsync"
+		t.Setenv("SKILLSYNC_HOME", customPath)
+
+		got := SkillsyncHistoryPath()
+		expected := filepath.Join(customPath, "history.jsonl")
+
+		if got != expected {
+			t.Errorf("SkillsyncHistoryPath() = %q, want %q", got, expected)
+		}
+	})
+}
+
+func TestSkillsyncTrashPath(t *testing.T) {
+	t.Run("default path without SKILLSYNC_HOME", func(t *testing.T) {
+		t.Setenv("SKILLSYNC_HOME", "")
+
+		got := SkillsyncTrashPath()
+		expected := filepath.Join(HomeDir(), ".skillsync", "trash")
+
+		if got != expected {
+			t.Errorf("SkillsyncTrashPath() = %q, want %q", got, expected)
+		}
+	})
+
+	t.Run("custom path with SKILLSYNC_HOME", func(t *testing.T) {
+		customPath := "/custom/skillsync"
+		t.Setenv("SKILLSYNC_HOME", customPath)
+
+		got := SkillsyncTrashPath()
+		expected := filepath.Join(customPath, "trash")
+
+		if got != expected {
+			t.Errorf("SkillsyncTrashPath() = %q, want %q", got, expected)
+		}
+	})
+}
+
 func TestSkillsyncPluginsPath(t *testing.T) {
 	t.Run("default path without SKILLSYNC_HOME", func(t *testing.T) {
 		t.Setenv("SKILLSYNC_HOME", "")
@@ -0,0 +1,222 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// PathResolver resolves every skillsync and platform path from one place so
+// that SKILLSYNC_* environment overrides are honored consistently by commands,
+// parsers, and the TUI.
+//
+// Recognized overrides:
+//   - SKILLSYNC_HOME: skillsync config/backup/plugin directory
+//   - SKILLSYNC_CLAUDE_CODE_PATH, SKILLSYNC_CURSOR_PATH, SKILLSYNC_CODEX_PATH,
+//     SKILLSYNC_AIDER_PATH: user-level skills directory for each platform
+//   - SKILLSYNC_CLAUDE_PLUGINS_PATH: Claude Code plugins directory
+//     (contains cache/ and installed_plugins.json)
+type PathResolver struct {
+	// Getenv looks up environment variables. Defaults to os.Getenv.
+	Getenv func(string) string
+	// Home returns the user's home directory. Defaults to HomeDir.
+	Home func() string
+}
+
+// NewPathResolver returns a resolver backed by the process environment.
+func NewPathResolver() *PathResolver {
+	return &PathResolver{Getenv: os.Getenv, Home: HomeDir}
+}
+
+var (
+	pathsMu sync.RWMutex
+	paths   = NewPathResolver()
+)
+
+// Paths returns the active path resolver.
+func Paths() *PathResolver {
+	pathsMu.RLock()
+	defer pathsMu.RUnlock()
+	return paths
+}
+
+// SetPaths injects the path resolver used by skillsync and returns the previous one,
+// so tests and embedders can restore it.
+func SetPaths(r *PathResolver) *PathResolver {
+	pathsMu.Lock()
+	defer pathsMu.Unlock()
+	prev := paths
+	paths = r
+	return prev
+}
+
+func (r *PathResolver) getenv(key string) string {
+	if r.Getenv == nil {
+		return os.Getenv(key)
+	}
+	return r.Getenv(key)
+}
+
+// HomeDir returns the home directory used for default paths.
+func (r *PathResolver) HomeDir() string {
+	if r.Home == nil {
+		return HomeDir()
+	}
+	return r.Home()
+}
+
+// PlatformPathEnvVar returns the environment variable that overrides a platform's
+// user-level skills directory.
+func PlatformPathEnvVar(p model.Platform) string {
+	switch p {
+	case model.ClaudeCode:
+		return "SKILLSYNC_CLAUDE_CODE_PATH"
+	case model.Cursor:
+		return "SKILLSYNC_CURSOR_PATH"
+	case model.Codex:
+		return "SKILLSYNC_CODEX_PATH"
+	case model.Aider:
+		return "SKILLSYNC_AIDER_PATH"
+	default:
+		if !p.IsCustom() {
+			return ""
+		}
+		return "SKILLSYNC_" + strings.ToUpper(strings.ReplaceAll(string(p), "-", "_")) + "_PATH"
+	}
+}
+
+// SkillsyncHome returns the skillsync configuration directory.
+func (r *PathResolver) SkillsyncHome() string {
+	if configHome := r.getenv("SKILLSYNC_HOME"); configHome != "" {
+		return configHome
+	}
+	return filepath.Join(r.HomeDir(), ".skillsync")
+}
+
+// BackupsPath returns the skillsync backups directory.
+func (r *PathResolver) BackupsPath() string {
+	return filepath.Join(r.SkillsyncHome(), "backups")
+}
+
+// MetadataPath returns the skillsync metadata directory.
+func (r *PathResolver) MetadataPath() string {
+	return filepath.Join(r.SkillsyncHome(), "metadata")
+}
+
+// HistoryPath returns the file that records past sync runs.
+func (r *PathResolver) HistoryPath() string {
+	return filepath.Join(r.SkillsyncHome(), "history.jsonl")
+}
+
+// TrashPath returns the directory holding soft-deleted target skills.
+func (r *PathResolver) TrashPath() string {
+	return filepath.Join(r.SkillsyncHome(), "trash")
+}
+
+// PluginsPath returns the skillsync plugins directory.
+func (r *PathResolver) PluginsPath() string {
+	return filepath.Join(r.SkillsyncHome(), "plugins")
+}
+
+// StorePath returns the canonical skill store, the hub that push fans out
+// from and pull collects into.
+func (r *PathResolver) StorePath() string {
+	return filepath.Join(r.SkillsyncHome(), "skills")
+}
+
+// MarketplacesPath returns the directory holding clones of marketplace indexes.
+func (r *PathResolver) MarketplacesPath() string {
+	return filepath.Join(r.SkillsyncHome(), "marketplaces")
+}
+
+// ExportsPath returns the directory holding manifests of previous exports,
+// consulted by incremental exports.
+func (r *PathResolver) ExportsPath() string {
+	return filepath.Join(r.SkillsyncHome(), "exports")
+}
+
+// CachePath returns the directory holding parsed skill caches.
+func (r *PathResolver) CachePath() string {
+	return filepath.Join(r.SkillsyncHome(), "cache")
+}
+
+// TemplatesPath returns the directory holding user skill templates for `skillsync new`.
+func (r *PathResolver) TemplatesPath() string {
+	return filepath.Join(r.SkillsyncHome(), "templates")
+}
+
+// UserSkillsPath returns the user-level skills directory for a platform.
+func (r *PathResolver) UserSkillsPath(p model.Platform) string {
+	if key := PlatformPathEnvVar(p); key != "" {
+		if envPath := r.getenv(key); envPath != "" {
+			return envPath
+		}
+	}
+	return filepath.Join(r.HomeDir(), platformDirName(p), "skills")
+}
+
+// RepoSkillsPath returns the repo-level skills directory for a platform.
+func (r *PathResolver) RepoSkillsPath(p model.Platform, repoRoot string) string {
+	return RepoSkillsPath(p, repoRoot)
+}
+
+// SkillsPathForScope returns the writable skills directory for a platform and scope.
+// An empty scope means user scope; repo scope resolves against the git root of workingDir
+// (or workingDir itself outside a repository).
+func (r *PathResolver) SkillsPathForScope(p model.Platform, scope model.SkillScope, workingDir string) (string, error) {
+	switch scope {
+	case "", model.ScopeUser:
+		return r.UserSkillsPath(p), nil
+	case model.ScopeRepo:
+		repoRoot := GetRepoRoot(workingDir)
+		if repoRoot == "" {
+			repoRoot = workingDir
+		}
+		return r.RepoSkillsPath(p, repoRoot), nil
+	default:
+		return "", fmt.Errorf("unsupported target scope %q (only 'repo' or 'user' allowed)", scope)
+	}
+}
+
+// ClaudePluginsPath returns the Claude Code plugins directory.
+func (r *PathResolver) ClaudePluginsPath() string {
+	if envPath := r.getenv("SKILLSYNC_CLAUDE_PLUGINS_PATH"); envPath != "" {
+		return envPath
+	}
+	return filepath.Join(r.HomeDir(), ".claude", "plugins")
+}
+
+// ClaudePluginCachePath returns the Claude Code plugin cache directory.
+func (r *PathResolver) ClaudePluginCachePath() string {
+	return filepath.Join(r.ClaudePluginsPath(), "cache")
+}
+
+// ClaudeInstalledPluginsPath returns the path to Claude Code's installed plugins manifest.
+func (r *PathResolver) ClaudeInstalledPluginsPath() string {
+	return filepath.Join(r.ClaudePluginsPath(), "installed_plugins.json")
+}
+
+// ClaudeKnownMarketplacesPath returns the path to Claude Code's known marketplaces manifest.
+func (r *PathResolver) ClaudeKnownMarketplacesPath() string {
+	return filepath.Join(r.ClaudePluginsPath(), "known_marketplaces.json")
+}
+
+// ClaudeDesktopPath returns the Claude Desktop application data directory
+// on the given operating system (a runtime.GOOS value).
+func (r *PathResolver) ClaudeDesktopPath(goos string) string {
+	switch goos {
+	case "darwin":
+		return filepath.Join(r.HomeDir(), "Library", "Application Support", "Claude")
+	case "windows":
+		if appData := r.getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "Claude")
+		}
+		return filepath.Join(r.HomeDir(), "AppData", "Roaming", "Claude")
+	default:
+		return filepath.Join(r.HomeDir(), ".config", "Claude")
+	}
+}
@@ -0,0 +1,150 @@
+package util
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func testResolver(home string, env map[string]string) *PathResolver {
+	return &PathResolver{
+		Getenv: func(key string) string { return env[key] },
+		Home:   func() string { return home },
+	}
+}
+
+func TestPathResolver(t *testing.T) {
+	home := filepath.Join(string(filepath.Separator), "home", "tester")
+
+	tests := map[string]struct {
+		env  map[string]string
+		got  func(r *PathResolver) string
+		want string
+	}{
+		"skillsync home default": {
+			got:  (*PathResolver).SkillsyncHome,
+			want: filepath.Join(home, ".skillsync"),
+		},
+		"skillsync home override": {
+			env:  map[string]string{"SKILLSYNC_HOME": "/data/skillsync"},
+			got:  (*PathResolver).BackupsPath,
+			want: filepath.Join("/data/skillsync", "backups"),
+		},
+		"templates under skillsync home": {
+			env:  map[string]string{"SKILLSYNC_HOME": "/data/skillsync"},
+			got:  (*PathResolver).TemplatesPath,
+			want: filepath.Join("/data/skillsync", "templates"),
+		},
+		"cache under skillsync home": {
+			env:  map[string]string{"SKILLSYNC_HOME": "/data/skillsync"},
+			got:  (*PathResolver).CachePath,
+			want: filepath.Join("/data/skillsync", "cache"),
+		},
+		"store under skillsync home": {
+			env:  map[string]string{"SKILLSYNC_HOME": "/data/skillsync"},
+			got:  (*PathResolver).StorePath,
+			want: filepath.Join("/data/skillsync", "skills"),
+		},
+		"exports under skillsync home": {
+			env:  map[string]string{"SKILLSYNC_HOME": "/data/skillsync"},
+			got:  (*PathResolver).ExportsPath,
+			want: filepath.Join("/data/skillsync", "exports"),
+		},
+		"user skills default": {
+			got:  func(r *PathResolver) string { return r.UserSkillsPath(model.Cursor) },
+			want: filepath.Join(home, ".cursor", "skills"),
+		},
+		"user skills override": {
+			env:  map[string]string{"SKILLSYNC_CODEX_PATH": "/custom/codex"},
+			got:  func(r *PathResolver) string { return r.UserSkillsPath(model.Codex) },
+			want: "/custom/codex",
+		},
+		"override only applies to its platform": {
+			env:  map[string]string{"SKILLSYNC_CODEX_PATH": "/custom/codex"},
+			got:  func(r *PathResolver) string { return r.UserSkillsPath(model.ClaudeCode) },
+			want: filepath.Join(home, ".claude", "skills"),
+		},
+		"claude plugin cache default": {
+			got:  (*PathResolver).ClaudePluginCachePath,
+			want: filepath.Join(home, ".claude", "plugins", "cache"),
+		},
+		"claude installed plugins override": {
+			env:  map[string]string{"SKILLSYNC_CLAUDE_PLUGINS_PATH": "/plugins"},
+			got:  (*PathResolver).ClaudeInstalledPluginsPath,
+			want: filepath.Join("/plugins", "installed_plugins.json"),
+		},
+		"claude known marketplaces override": {
+			env:  map[string]string{"SKILLSYNC_CLAUDE_PLUGINS_PATH": "/plugins"},
+			got:  (*PathResolver).ClaudeKnownMarketplacesPath,
+			want: filepath.Join("/plugins", "known_marketplaces.json"),
+		},
+		"claude desktop on linux": {
+			got:  func(r *PathResolver) string { return r.ClaudeDesktopPath("linux") },
+			want: filepath.Join(home, ".config", "Claude"),
+		},
+		"claude desktop on macos": {
+			got:  func(r *PathResolver) string { return r.ClaudeDesktopPath("darwin") },
+			want: filepath.Join(home, "Library", "Application Support", "Claude"),
+		},
+		"claude desktop on windows": {
+			env:  map[string]string{"APPDATA": "/appdata"},
+			got:  func(r *PathResolver) string { return r.ClaudeDesktopPath("windows") },
+			want: filepath.Join("/appdata", "Claude"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := testResolver(home, tt.env)
+			if got := tt.got(r); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathResolverSkillsPathForScope(t *testing.T) {
+	home := t.TempDir()
+	repo := t.TempDir()
+	r := testResolver(home, map[string]string{"SKILLSYNC_CLAUDE_CODE_PATH": "/env/claude"})
+
+	tests := map[string]struct {
+		scope   model.SkillScope
+		want    string
+		wantErr bool
+	}{
+		"empty scope is user":   {scope: "", want: "/env/claude"},
+		"user scope":            {scope: model.ScopeUser, want: "/env/claude"},
+		"repo scope":            {scope: model.ScopeRepo, want: filepath.Join(repo, ".claude", "skills")},
+		"non-writable rejected": {scope: model.ScopeSystem, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := r.SkillsPathForScope(model.ClaudeCode, tt.scope, repo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SkillsPathForScope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SkillsPathForScope() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetPathsInjectsResolver(t *testing.T) {
+	home := t.TempDir()
+	prev := SetPaths(testResolver(home, nil))
+	t.Cleanup(func() { SetPaths(prev) })
+
+	if got, want := PlatformSkillsPath(model.Cursor), filepath.Join(home, ".cursor", "skills"); got != want {
+		t.Errorf("PlatformSkillsPath() = %q, want %q", got, want)
+	}
+	if got, want := ExpandPath("~/x", ""), filepath.Join(home, "x"); got != want {
+		t.Errorf("ExpandPath() = %q, want %q", got, want)
+	}
+	if got, want := SkillsyncConfigPath(), filepath.Join(home, ".skillsync"); got != want {
+		t.Errorf("SkillsyncConfigPath() = %q, want %q", got, want)
+	}
+}
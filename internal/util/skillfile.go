@@ -0,0 +1,20 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RemoveEmptySkillDir removes the directory of the SKILL.md at path, which
+// has just been removed, unless the directory still holds other files. It
+// reports whether the directory was kept; other skill files live alongside
+// their siblings, so their directory is always kept without reporting it.
+func RemoveEmptySkillDir(path string) (kept bool) {
+	if !strings.EqualFold(filepath.Base(path), "SKILL.md") {
+		return false
+	}
+	// Fails, and keeps the directory, if it still holds other files
+	err := os.Remove(filepath.Dir(path))
+	return err != nil && !os.IsNotExist(err)
+}
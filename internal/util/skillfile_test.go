@@ -0,0 +1,41 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRemoveEmptySkillDir(t *testing.T) {
+	tests := map[string]struct {
+		file     string
+		others   []string
+		wantKept bool
+		wantDir  bool
+	}{
+		"empty skill directory":  {file: "SKILL.md"},
+		"lowercase skill file":   {file: "skill.md"},
+		"bundled resources":      {file: "SKILL.md", others: []string{"scripts/run.sh"}, wantKept: true, wantDir: true},
+		"single-file skill":      {file: "lint.md", wantDir: true},
+		"directory already gone": {file: "SKILL.md"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "lint")
+			path := filepath.Join(dir, tt.file)
+			WriteFile(t, path, "lint")
+			for _, other := range tt.others {
+				WriteFile(t, filepath.Join(dir, other), "other")
+			}
+			AssertNoError(t, os.Remove(path))
+			if name == "directory already gone" {
+				AssertNoError(t, os.Remove(dir))
+			}
+
+			AssertEqual(t, RemoveEmptySkillDir(path), tt.wantKept)
+			_, err := os.Stat(dir)
+			AssertEqual(t, err == nil, tt.wantDir)
+		})
+	}
+}
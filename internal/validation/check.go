@@ -0,0 +1,208 @@
+package validation
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+)
+
+// Severity is how serious an Issue is.
+type Severity string
+
+const (
+	// SeverityError marks problems that break the skill or its sync.
+	SeverityError Severity = "error"
+	// SeverityWarning marks problems worth fixing that do not break the skill.
+	SeverityWarning Severity = "warning"
+)
+
+// Categories of the checks performed by CheckSkills.
+const (
+	CheckFrontmatter = "frontmatter"
+	CheckDuplicate   = "duplicate"
+	CheckReference   = "reference"
+	CheckPermission  = "permission"
+	CheckContent     = "content"
+)
+
+const (
+	maxNameLength        = 64
+	maxDescriptionLength = 1024
+)
+
+var (
+	// skillNamePattern is the Agent Skills naming convention: lowercase
+	// letters and digits separated by single hyphens.
+	skillNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	// markdownLinkPattern matches inline links and images: [text](target "title")
+	markdownLinkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+)
+
+// Issue is a problem found in a single skill.
+type Issue struct {
+	Skill    string   `json:"skill"`
+	Platform string   `json:"platform"`
+	Scope    string   `json:"scope,omitempty"`
+	Path     string   `json:"path"`
+	Rule     string   `json:"rule,omitempty"` // Empty for unreadable files and invalid frontmatter
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Report is the outcome of CheckSkills.
+type Report struct {
+	Checked int     `json:"checked"`
+	Issues  []Issue `json:"issues"`
+}
+
+// Errors returns the number of error issues.
+func (r *Report) Errors() int {
+	return r.count(SeverityError)
+}
+
+// Warnings returns the number of warning issues.
+func (r *Report) Warnings() int {
+	return r.count(SeverityWarning)
+}
+
+func (r *Report) count(severity Severity) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// CheckOptions configures CheckSkills.
+type CheckOptions struct {
+	// Severities overrides the default severity of rules.
+	Severities RuleSeverities
+	// Schema is the frontmatter JSON Schema checked by the frontmatter-schema
+	// rule, if any.
+	Schema *Schema
+}
+
+// CheckSkills runs every registered rule, at its configured severity, against
+// the discovered skills. Skill files that cannot be read or whose frontmatter
+// is not valid YAML are always errors. Issues are ordered by skill path.
+func CheckSkills(skills []model.Skill, opts CheckOptions) *Report {
+	report := &Report{Checked: len(skills), Issues: make([]Issue, 0)}
+
+	rules := Rules()
+	for _, skill := range skills {
+		report.Issues = append(report.Issues, checkSkill(skill, skills, rules, opts)...)
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Path < report.Issues[j].Path
+	})
+	return report
+}
+
+// checkSkill reads one skill file and runs the rules against it.
+func checkSkill(skill model.Skill, all []model.Skill, rules []Rule, opts CheckOptions) []Issue {
+	var issues []Issue
+	add := func(rule, check string, severity Severity, message string) {
+		issues = append(issues, Issue{
+			Skill:    skill.Name,
+			Platform: string(skill.Platform),
+			Scope:    string(skill.Scope),
+			Path:     skill.Path,
+			Rule:     rule,
+			Check:    check,
+			Severity: severity,
+			Message:  message,
+		})
+	}
+
+	info, err := os.Stat(skill.Path)
+	if err != nil {
+		add("", CheckPermission, SeverityError, fmt.Sprintf("cannot access skill file: %v", err))
+		return issues
+	}
+	// #nosec G304 - path comes from skill discovery
+	data, err := os.ReadFile(skill.Path)
+	if err != nil {
+		add("", CheckPermission, SeverityError, fmt.Sprintf("cannot read skill file: %v", err))
+		return issues
+	}
+
+	fm := parser.SplitFrontmatter(data)
+	in := &Input{Skill: skill, Info: info, Body: fm.Content, All: all, Schema: opts.Schema}
+	if fm.HasFrontmatter {
+		fields, err := parser.ParseYAMLFrontmatter(fm.Frontmatter)
+		if err != nil {
+			add("", CheckFrontmatter, SeverityError, fmt.Sprintf("invalid frontmatter: %v", err))
+		}
+		in.Fields = fields
+	}
+
+	for _, rule := range rules {
+		severity := opts.Severities.SeverityOf(rule)
+		if severity == SeverityIgnore {
+			continue
+		}
+		for _, message := range rule.Run(in) {
+			add(rule.Name, rule.Check, severity, message)
+		}
+	}
+
+	return issues
+}
+
+// brokenReferences returns the relative paths a skill refers to, through its
+// scripts/references/assets frontmatter or Markdown links in its body, that
+// do not exist next to the skill file.
+func brokenReferences(skill model.Skill, body string) []string {
+	dir := filepath.Dir(skill.Path)
+	seen := make(map[string]bool)
+	var broken []string
+	check := func(ref string) {
+		ref = strings.TrimSpace(ref)
+		if i := strings.IndexAny(ref, "#?"); i >= 0 {
+			ref = ref[:i]
+		}
+		if ref == "" || seen[ref] || filepath.IsAbs(ref) || strings.Contains(ref, ":") {
+			return // Anchors, absolute paths, and URLs are not checked
+		}
+		seen[ref] = true
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(ref))); err != nil {
+			broken = append(broken, ref)
+		}
+	}
+
+	for _, list := range [][]string{skill.Scripts, skill.References, skill.Assets} {
+		for _, ref := range list {
+			check(ref)
+		}
+	}
+
+	// Links inside fenced code blocks are examples, not references
+	inFence := false
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, match := range markdownLinkPattern.FindAllStringSubmatch(line, -1) {
+			check(match[1])
+		}
+	}
+
+	return broken
+}
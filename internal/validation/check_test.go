@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func writeSkillFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func TestCheckSkills(t *testing.T) {
+	const validBody = "---\nname: lint\ndescription: Run the linter\n---\nRun it.\n"
+
+	tests := map[string]struct {
+		files      map[string]string // relative path -> content
+		skills     func(dir string) []model.Skill
+		wantChecks []string // "check:severity" of each issue, in order
+	}{
+		"valid skill": {
+			files: map[string]string{"lint/SKILL.md": validBody},
+			skills: func(dir string) []model.Skill {
+				return []model.Skill{{Name: "lint", Description: "Run the linter", Platform: model.ClaudeCode, Path: filepath.Join(dir, "lint/SKILL.md")}}
+			},
+		},
+		"invalid frontmatter": {
+			files: map[string]string{"lint/SKILL.md": "---\nname: [lint\n---\nBody\n"},
+			skills: func(dir string) []model.Skill {
+				return []model.Skill{{Name: "lint", Description: "x", Platform: model.ClaudeCode, Path: filepath.Join(dir, "lint/SKILL.md")}}
+			},
+			wantChecks: []string{"frontmatter:error"},
+		},
+		"non-string description and bad name": {
+			files: map[string]string{"lint/SKILL.md": "---\nname: Lint_Tool\ndescription: 42\n---\nBody\n"},
+			skills: func(dir string) []model.Skill {
+				return []model.Skill{{Name: "Lint_Tool", Description: "42", Platform: model.ClaudeCode, Path: filepath.Join(dir, "lint/SKILL.md")}}
+			},
+			wantChecks: []string{"frontmatter:warning", "frontmatter:error"}, // name-format, string-fields
+		},
+		"missing description": {
+			files: map[string]string{"lint.md": "Body\n"},
+			skills: func(dir string) []model.Skill {
+				return []model.Skill{{Name: "lint", Platform: model.Cursor, Path: filepath.Join(dir, "lint.md")}}
+			},
+			wantChecks: []string{"frontmatter:warning"},
+		},
+		"broken references": {
+			files: map[string]string{
+				"lint/SKILL.md": validBody + "See [rules](references/rules.md), [style](references/style.md#top),\n" +
+					"[docs](https://example.com/x.md) and [top](#usage).\n```\n[example](missing.md)\n```\n",
+				"lint/references/rules.md": "rules",
+			},
+			skills: func(dir string) []model.Skill {
+				return []model.Skill{{
+					Name: "lint", Description: "Run the linter", Platform: model.ClaudeCode,
+					Path:    filepath.Join(dir, "lint/SKILL.md"),
+					Scripts: []string{"scripts/run.sh"},
+				}}
+			},
+			wantChecks: []string{"reference:error", "reference:error"},
+		},
+		"duplicates in the same scope": {
+			files: map[string]string{"a/lint.md": validBody, "b/lint.md": validBody, "c/lint.md": validBody},
+			skills: func(dir string) []model.Skill {
+				return []model.Skill{
+					{Name: "lint", Description: "x", Platform: model.Cursor, Scope: model.ScopeUser, Path: filepath.Join(dir, "a/lint.md")},
+					{Name: "lint", Description: "x", Platform: model.Cursor, Scope: model.ScopeUser, Path: filepath.Join(dir, "b/lint.md")},
+					{Name: "lint", Description: "x", Platform: model.Cursor, Scope: model.ScopeRepo, Path: filepath.Join(dir, "c/lint.md")},
+				}
+			},
+			wantChecks: []string{"duplicate:error", "duplicate:error"},
+		},
+		"missing file": {
+			skills: func(dir string) []model.Skill {
+				return []model.Skill{{Name: "lint", Description: "x", Platform: model.Cursor, Path: filepath.Join(dir, "gone.md")}}
+			},
+			wantChecks: []string{"permission:error"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for rel, content := range tt.files {
+				writeSkillFile(t, filepath.Join(dir, rel), content)
+			}
+
+			report := CheckSkills(tt.skills(dir), CheckOptions{})
+
+			got := make([]string, 0, len(report.Issues))
+			for _, issue := range report.Issues {
+				got = append(got, issue.Check+":"+string(issue.Severity))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantChecks, ",") {
+				t.Errorf("CheckSkills() issues = %v, want %v\n%+v", got, tt.wantChecks, report.Issues)
+			}
+		})
+	}
+}
+
+func TestCheckSkills_WorldWritable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lint.md")
+	writeSkillFile(t, path, "---\nname: lint\ndescription: x\n---\nBody\n")
+	if err := os.Chmod(path, 0o666); err != nil {
+		t.Fatalf("failed to chmod: %v", err)
+	}
+
+	report := CheckSkills([]model.Skill{{Name: "lint", Description: "x", Platform: model.Cursor, Path: path}}, CheckOptions{})
+
+	if report.Warnings() != 1 || report.Errors() != 0 {
+		t.Errorf("expected one permission warning, got %+v", report.Issues)
+	}
+}
@@ -0,0 +1,307 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+// SeverityIgnore disables a rule.
+const SeverityIgnore Severity = "ignore"
+
+// maxContentSize is the size above which a skill file is reported as too
+// large to load comfortably into a model's context.
+const maxContentSize = 64 * 1024
+
+// toolNamePattern matches a tool name with an optional argument pattern,
+// such as Read, mcp__github__search or Bash(git diff:*).
+var toolNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*(\(.+\))?$`)
+
+// Input is what a Rule inspects for one skill.
+type Input struct {
+	Skill  model.Skill
+	Info   os.FileInfo
+	Fields map[string]any // Parsed frontmatter; nil when the skill has none
+	Body   string         // Content after the frontmatter
+	All    []model.Skill  // Every skill being checked, for cross-skill rules
+	Schema *Schema        // Configured frontmatter schema; nil when there is none
+}
+
+// Rule is a named check whose severity can be changed, or which can be turned
+// off, in the validation.rules section of the config. Rules are added with
+// Register, usually from an init function.
+type Rule struct {
+	// Name identifies the rule in config and output, e.g. "require-description".
+	Name string
+	// Check is the category the rule reports under (frontmatter, reference, ...).
+	Check string
+	// Description says what the rule checks.
+	Description string
+	// Severity is the default severity of the rule's issues.
+	Severity Severity
+	// Run returns one message per problem found.
+	Run func(in *Input) []string
+}
+
+// RuleSeverities overrides the default severity of rules, keyed by rule name.
+type RuleSeverities map[string]Severity
+
+var registry = make(map[string]Rule)
+
+// Register adds a rule to the registry. It panics if the rule has no name or
+// run function, or if a rule with the same name is already registered.
+func Register(rule Rule) {
+	if rule.Name == "" || rule.Run == nil {
+		panic("validation: rule needs a name and a run function")
+	}
+	if _, exists := registry[rule.Name]; exists {
+		panic(fmt.Sprintf("validation: rule %q registered twice", rule.Name))
+	}
+	registry[rule.Name] = rule
+}
+
+// Rules returns the registered rules sorted by name.
+func Rules() []Rule {
+	rules := make([]Rule, 0, len(registry))
+	for _, rule := range registry {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+	return rules
+}
+
+// ParseRuleSeverities converts the validation.rules config section into
+// RuleSeverities, rejecting unknown rules and severities.
+func ParseRuleSeverities(config map[string]string) (RuleSeverities, error) {
+	severities := make(RuleSeverities, len(config))
+	for name, value := range config {
+		if _, ok := registry[name]; !ok {
+			return nil, fmt.Errorf("unknown validation rule %q", name)
+		}
+		severity := Severity(strings.ToLower(strings.TrimSpace(value)))
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityIgnore:
+			severities[name] = severity
+		default:
+			return nil, fmt.Errorf("invalid severity %q for validation rule %q (valid: error, warning, ignore)", value, name)
+		}
+	}
+	return severities, nil
+}
+
+// SeverityOf returns the effective severity of a rule.
+func (s RuleSeverities) SeverityOf(rule Rule) Severity {
+	if severity, ok := s[rule.Name]; ok {
+		return severity
+	}
+	return rule.Severity
+}
+
+func init() {
+	Register(Rule{
+		Name:        "require-name",
+		Check:       CheckFrontmatter,
+		Description: "skills must have a name",
+		Severity:    SeverityError,
+		Run: func(in *Input) []string {
+			if in.Skill.Name == "" {
+				return []string{"skill name is empty"}
+			}
+			return nil
+		},
+	})
+	Register(Rule{
+		Name:        "name-format",
+		Check:       CheckFrontmatter,
+		Description: "names use lowercase letters, digits, and hyphens, up to 64 characters",
+		Severity:    SeverityWarning,
+		Run: func(in *Input) []string {
+			switch name := in.Skill.Name; {
+			case name == "":
+				return nil
+			case len(name) > maxNameLength:
+				return []string{fmt.Sprintf("name is longer than %d characters", maxNameLength)}
+			case !skillNamePattern.MatchString(name):
+				return []string{fmt.Sprintf("name %q should use lowercase letters, digits, and hyphens", name)}
+			}
+			return nil
+		},
+	})
+	Register(Rule{
+		Name:        "string-fields",
+		Check:       CheckFrontmatter,
+		Description: "name and description frontmatter fields are strings",
+		Severity:    SeverityError,
+		Run: func(in *Input) []string {
+			var messages []string
+			for _, key := range []string{"name", "description"} {
+				if value, ok := in.Fields[key]; ok {
+					if _, isString := value.(string); !isString {
+						messages = append(messages, fmt.Sprintf("%s must be a string, got %T", key, value))
+					}
+				}
+			}
+			return messages
+		},
+	})
+	Register(Rule{
+		Name:        "frontmatter-schema",
+		Check:       CheckFrontmatter,
+		Description: "frontmatter satisfies the JSON Schema at validation.schema_path",
+		Severity:    SeverityError,
+		Run: func(in *Input) []string {
+			if in.Schema == nil {
+				return nil
+			}
+			return in.Schema.Validate(in.Fields)
+		},
+	})
+	Register(Rule{
+		Name:        "require-description",
+		Check:       CheckFrontmatter,
+		Description: "skills have a description",
+		Severity:    SeverityWarning,
+		Run: func(in *Input) []string {
+			if in.Skill.Description == "" {
+				return []string{"description is missing"}
+			}
+			return nil
+		},
+	})
+	Register(Rule{
+		Name:        "description-length",
+		Check:       CheckFrontmatter,
+		Description: "descriptions are at most 1024 characters",
+		Severity:    SeverityWarning,
+		Run: func(in *Input) []string {
+			if len(in.Skill.Description) > maxDescriptionLength {
+				return []string{fmt.Sprintf("description is longer than %d characters", maxDescriptionLength)}
+			}
+			return nil
+		},
+	})
+	Register(Rule{
+		Name:        "allowed-tools-list",
+		Check:       CheckFrontmatter,
+		Description: "tools and allowed-tools list tool names once each",
+		Severity:    SeverityWarning,
+		Run:         checkToolsList,
+	})
+	Register(Rule{
+		Name:        "max-content-size",
+		Check:       CheckContent,
+		Description: "skill files are at most 64 KiB",
+		Severity:    SeverityWarning,
+		Run: func(in *Input) []string {
+			if in.Info.Size() > maxContentSize {
+				return []string{fmt.Sprintf("skill file is %d KiB, more than %d KiB", in.Info.Size()/1024, maxContentSize/1024)}
+			}
+			return nil
+		},
+	})
+	Register(Rule{
+		Name:        "broken-reference",
+		Check:       CheckReference,
+		Description: "referenced scripts, references, assets, and relative links exist",
+		Severity:    SeverityError,
+		Run: func(in *Input) []string {
+			var messages []string
+			for _, ref := range brokenReferences(in.Skill, in.Body) {
+				messages = append(messages, "referenced file not found: "+ref)
+			}
+			return messages
+		},
+	})
+	Register(Rule{
+		Name:        "duplicate-name",
+		Check:       CheckDuplicate,
+		Description: "names are unique within a platform scope",
+		Severity:    SeverityError,
+		Run:         checkDuplicateName,
+	})
+	Register(Rule{
+		Name:        "world-writable",
+		Check:       CheckPermission,
+		Description: "skill files are not writable by every user",
+		Severity:    SeverityWarning,
+		Run: func(in *Input) []string {
+			if perm := in.Info.Mode().Perm(); perm&0o002 != 0 {
+				return []string{fmt.Sprintf("skill file is world-writable (%s)", perm)}
+			}
+			return nil
+		},
+	})
+}
+
+// checkToolsList reports tool lists that are not strings or lists of strings,
+// and entries that are malformed or repeated.
+func checkToolsList(in *Input) []string {
+	var messages []string
+	for _, key := range []string{"tools", "allowed-tools"} {
+		value, ok := in.Fields[key]
+		if !ok {
+			continue
+		}
+
+		var tools []string
+		switch v := value.(type) {
+		case string:
+			for _, tool := range strings.Split(v, ",") {
+				if tool = strings.TrimSpace(tool); tool != "" {
+					tools = append(tools, tool)
+				}
+			}
+		case []any:
+			for _, item := range v {
+				tool, isString := item.(string)
+				if !isString {
+					messages = append(messages, fmt.Sprintf("%s entries must be strings, got %T", key, item))
+					continue
+				}
+				tools = append(tools, strings.TrimSpace(tool))
+			}
+		case nil:
+			// An empty key lists no tools
+		default:
+			messages = append(messages, fmt.Sprintf("%s must be a comma-separated string or a list, got %T", key, value))
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, tool := range tools {
+			switch {
+			case !toolNamePattern.MatchString(tool):
+				messages = append(messages, fmt.Sprintf("%s entry %q is not a tool name", key, tool))
+			case seen[tool]:
+				messages = append(messages, fmt.Sprintf("%s lists %q more than once", key, tool))
+			}
+			seen[tool] = true
+		}
+	}
+	return messages
+}
+
+// checkDuplicateName reports other skills with the same name in the same
+// platform and scope, where only one of them can take effect.
+func checkDuplicateName(in *Input) []string {
+	if in.Skill.Name == "" {
+		return nil
+	}
+	var others []string
+	for _, other := range in.All {
+		if other.Path != in.Skill.Path && other.Name == in.Skill.Name &&
+			other.Platform == in.Skill.Platform && other.Scope == in.Skill.Scope {
+			others = append(others, other.Path)
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("duplicate skill name %q, also defined in %s", in.Skill.Name, strings.Join(others, ", "))}
+}
@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+func TestCheckSkills_RuleSeverities(t *testing.T) {
+	tests := map[string]struct {
+		severities   RuleSeverities
+		wantRules    []string
+		wantSeverity Severity
+	}{
+		"defaults": {
+			wantRules:    []string{"require-description"},
+			wantSeverity: SeverityWarning,
+		},
+		"raised to error": {
+			severities:   RuleSeverities{"require-description": SeverityError},
+			wantRules:    []string{"require-description"},
+			wantSeverity: SeverityError,
+		},
+		"ignored": {
+			severities: RuleSeverities{"require-description": SeverityIgnore},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "lint", "SKILL.md")
+			writeSkillFile(t, path, "---\nname: lint\n---\nBody\n")
+
+			report := CheckSkills([]model.Skill{{Name: "lint", Platform: model.ClaudeCode, Path: path}}, CheckOptions{Severities: tt.severities})
+
+			got := make([]string, 0, len(report.Issues))
+			for _, issue := range report.Issues {
+				got = append(got, issue.Rule)
+				if issue.Severity != tt.wantSeverity {
+					t.Errorf("issue %q severity = %q, want %q", issue.Rule, issue.Severity, tt.wantSeverity)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("rules = %v, want %v", got, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestCheckToolsList(t *testing.T) {
+	tests := map[string]struct {
+		fields       map[string]any
+		wantMessages int
+	}{
+		"comma-separated string":  {fields: map[string]any{"allowed-tools": "Read, Bash(git diff:*), mcp__github__search"}},
+		"list":                    {fields: map[string]any{"tools": []any{"Read", "Write"}}},
+		"no tools":                {fields: map[string]any{"name": "lint"}},
+		"duplicate tool":          {fields: map[string]any{"allowed-tools": "Read, Read"}, wantMessages: 1},
+		"malformed entry":         {fields: map[string]any{"tools": []any{"Read", "run the linter"}}, wantMessages: 1},
+		"non-string entry":        {fields: map[string]any{"tools": []any{"Read", 42}}, wantMessages: 1},
+		"neither string nor list": {fields: map[string]any{"tools": map[string]any{"Read": true}}, wantMessages: 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			messages := checkToolsList(&Input{Fields: tt.fields})
+			if len(messages) != tt.wantMessages {
+				t.Errorf("checkToolsList() = %v, want %d message(s)", messages, tt.wantMessages)
+			}
+		})
+	}
+}
+
+func TestParseRuleSeverities(t *testing.T) {
+	tests := map[string]struct {
+		config  map[string]string
+		want    RuleSeverities
+		wantErr string
+	}{
+		"valid": {
+			config: map[string]string{"require-description": "Error", "max-content-size": "ignore"},
+			want:   RuleSeverities{"require-description": SeverityError, "max-content-size": SeverityIgnore},
+		},
+		"unknown rule": {
+			config:  map[string]string{"no-such-rule": "error"},
+			wantErr: "unknown validation rule",
+		},
+		"invalid severity": {
+			config:  map[string]string{"require-description": "fatal"},
+			wantErr: "invalid severity",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseRuleSeverities(tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseRuleSeverities() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRuleSeverities() unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseRuleSeverities() = %v, want %v", got, tt.want)
+			}
+			for rule, severity := range tt.want {
+				if got[rule] != severity {
+					t.Errorf("severity of %q = %q, want %q", rule, got[rule], severity)
+				}
+			}
+		})
+	}
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Register to panic for a duplicate rule name")
+		}
+	}()
+	Register(Rule{Name: "require-name", Run: func(*Input) []string { return nil }})
+}
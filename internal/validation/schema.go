@@ -0,0 +1,475 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/parser"
+)
+
+// Schema is a JSON Schema that skill frontmatter must satisfy, typically
+// used by organizations to require fields such as owner or review_date.
+//
+// The supported keywords are type, enum, const, required, properties,
+// additionalProperties, items, minItems, maxItems, uniqueItems, minLength,
+// maxLength, pattern, format (date, date-time, email, uri), minimum, maximum,
+// allOf, anyOf, and oneOf. Other keywords, including $ref, are ignored.
+type Schema struct {
+	path string
+	root map[string]any
+}
+
+// LoadSchema reads a JSON Schema from a file.
+func LoadSchema(path string) (*Schema, error) {
+	// #nosec G304 - path comes from the user's config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read frontmatter schema: %w", err)
+	}
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter schema %s: %w", path, err)
+	}
+	if err := checkSchema(root, "#"); err != nil {
+		return nil, fmt.Errorf("invalid frontmatter schema %s: %w", path, err)
+	}
+	return &Schema{path: path, root: root}, nil
+}
+
+// Path returns the file the schema was loaded from.
+func (s *Schema) Path() string {
+	return s.path
+}
+
+// Validate returns one message per schema violation in the frontmatter
+// fields, sorted for stable output.
+func (s *Schema) Validate(fields map[string]any) []string {
+	violations := validateValue(s.root, normalizeYAML(fields), "")
+	sort.Strings(violations)
+	return violations
+}
+
+// ValidateSkill checks the frontmatter of a skill against the schema.
+func (s *Schema) ValidateSkill(skill model.Skill) []string {
+	return s.Validate(SkillFrontmatter(skill))
+}
+
+// ValidateSkills adds an error to result for every skill whose frontmatter
+// does not satisfy the schema, so SkillErrors can attribute it to the skill.
+func (s *Schema) ValidateSkills(skills []model.Skill, result *Result) {
+	for i, skill := range skills {
+		violations := s.ValidateSkill(skill)
+		if len(violations) == 0 {
+			continue
+		}
+		result.AddError(&Error{
+			Field:   skillField(i, "frontmatter"),
+			Message: fmt.Sprintf("skill %q does not match frontmatter schema: %s", skill.Name, strings.Join(violations, "; ")),
+		})
+	}
+}
+
+// SkillFrontmatter returns the frontmatter fields of a skill: the
+// frontmatter it was parsed with, or that its content starts with. A
+// discovered skill file is read only when its body is the skill's content,
+// so sections of a shared AGENTS.md and imported skills whose path names a
+// file elsewhere are not described by whatever file is at that path. Skills
+// without frontmatter, such as those loaded from a bundle, are described by
+// their parsed fields and metadata instead.
+func SkillFrontmatter(skill model.Skill) map[string]any {
+	if skill.Frontmatter != "" {
+		if fields, err := parser.ParseYAMLFrontmatter([]byte(skill.Frontmatter)); err == nil {
+			return fields
+		}
+	}
+	if fm := parser.SplitFrontmatter([]byte(skill.Content)); fm.HasFrontmatter {
+		if fields, err := parser.ParseYAMLFrontmatter(fm.Frontmatter); err == nil {
+			return fields
+		}
+	}
+	if fm, ok := skillFile(skill); ok {
+		if !fm.HasFrontmatter {
+			return map[string]any{}
+		}
+		if fields, err := parser.ParseYAMLFrontmatter(fm.Frontmatter); err == nil {
+			return fields
+		}
+	}
+
+	fields := make(map[string]any, len(skill.Metadata)+4)
+	for key, value := range skill.Metadata {
+		fields[key] = value
+	}
+	if skill.Name != "" {
+		fields["name"] = skill.Name
+	}
+	if skill.Description != "" {
+		fields["description"] = skill.Description
+	}
+	if len(skill.Tools) > 0 {
+		tools := make([]any, len(skill.Tools))
+		for i, tool := range skill.Tools {
+			tools[i] = tool
+		}
+		fields["tools"] = tools
+	}
+	if skill.License != "" {
+		fields["license"] = skill.License
+	}
+	return fields
+}
+
+// skillFile returns the split content of the file a skill was discovered
+// in, if that file holds the skill alone.
+func skillFile(skill model.Skill) (parser.FrontmatterResult, bool) {
+	if !filepath.IsAbs(skill.Path) {
+		return parser.FrontmatterResult{}, false
+	}
+	// #nosec G304 - path comes from skill discovery
+	data, err := os.ReadFile(skill.Path)
+	if err != nil {
+		return parser.FrontmatterResult{}, false
+	}
+	fm := parser.SplitFrontmatter(data)
+	if parser.NormalizeContent(fm.Content) != parser.NormalizeContent(skill.Content) {
+		return parser.FrontmatterResult{}, false
+	}
+	return fm, true
+}
+
+// checkSchema rejects schemas whose keywords have the wrong JSON type, so
+// mistakes in the schema file are reported once instead of as odd violations.
+func checkSchema(schema map[string]any, at string) error {
+	if raw, ok := schema["properties"]; ok {
+		props, isObject := raw.(map[string]any)
+		if !isObject {
+			return fmt.Errorf("%s/properties must be an object", at)
+		}
+		for name, sub := range props {
+			subSchema, isObject := sub.(map[string]any)
+			if !isObject {
+				return fmt.Errorf("%s/properties/%s must be an object", at, name)
+			}
+			if err := checkSchema(subSchema, at+"/properties/"+name); err != nil {
+				return err
+			}
+		}
+	}
+	for _, key := range []string{"items", "additionalProperties"} {
+		if sub, ok := schema[key].(map[string]any); ok {
+			if err := checkSchema(sub, at+"/"+key); err != nil {
+				return err
+			}
+		}
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		raw, ok := schema[key]
+		if !ok {
+			continue
+		}
+		list, isArray := raw.([]any)
+		if !isArray {
+			return fmt.Errorf("%s/%s must be an array", at, key)
+		}
+		for i, sub := range list {
+			subSchema, isObject := sub.(map[string]any)
+			if !isObject {
+				return fmt.Errorf("%s/%s/%d must be an object", at, key, i)
+			}
+			if err := checkSchema(subSchema, fmt.Sprintf("%s/%s/%d", at, key, i)); err != nil {
+				return err
+			}
+		}
+	}
+	if raw, ok := schema["required"]; ok {
+		list, isArray := raw.([]any)
+		if !isArray {
+			return fmt.Errorf("%s/required must be an array", at)
+		}
+		for _, name := range list {
+			if _, isString := name.(string); !isString {
+				return fmt.Errorf("%s/required must list property names", at)
+			}
+		}
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%s/pattern: %w", at, err)
+		}
+	}
+	return nil
+}
+
+// validateValue checks value against schema and returns the violations,
+// each prefixed with the property path when there is one.
+func validateValue(schema map[string]any, value any, at string) []string {
+	var violations []string
+	fail := func(format string, args ...any) {
+		message := fmt.Sprintf(format, args...)
+		if at != "" {
+			message = at + ": " + message
+		}
+		violations = append(violations, message)
+	}
+
+	if types := schemaTypes(schema["type"]); len(types) > 0 && !matchesAnyType(value, types) {
+		fail("must be %s, got %s", strings.Join(types, " or "), jsonType(value))
+		return violations // Other keywords would only repeat the mismatch
+	}
+	if want, ok := schema["const"]; ok && !reflect.DeepEqual(value, want) {
+		fail("must be %s", formatJSON(want))
+	}
+	if enum, ok := schema["enum"].([]any); ok && !containsValue(enum, value) {
+		options := make([]string, len(enum))
+		for i, option := range enum {
+			options[i] = formatJSON(option)
+		}
+		fail("must be one of %s", strings.Join(options, ", "))
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := len([]rune(v))
+		if limit, ok := schemaNumber(schema["minLength"]); ok && float64(length) < limit {
+			fail("must be at least %v characters", limit)
+		}
+		if limit, ok := schemaNumber(schema["maxLength"]); ok && float64(length) > limit {
+			fail("must be at most %v characters", limit)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				fail("must match pattern %q", pattern)
+			}
+		}
+		if format, ok := schema["format"].(string); ok && !matchesFormat(format, v) {
+			fail("must be a valid %s", format)
+		}
+	case float64:
+		if limit, ok := schemaNumber(schema["minimum"]); ok && v < limit {
+			fail("must be at least %v", limit)
+		}
+		if limit, ok := schemaNumber(schema["maximum"]); ok && v > limit {
+			fail("must be at most %v", limit)
+		}
+	case []any:
+		if limit, ok := schemaNumber(schema["minItems"]); ok && float64(len(v)) < limit {
+			fail("must have at least %v item(s)", limit)
+		}
+		if limit, ok := schemaNumber(schema["maxItems"]); ok && float64(len(v)) > limit {
+			fail("must have at most %v item(s)", limit)
+		}
+		if unique, _ := schema["uniqueItems"].(bool); unique {
+			for i := range v {
+				if containsValue(v[:i], v[i]) {
+					fail("must not repeat %s", formatJSON(v[i]))
+				}
+			}
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				violations = append(violations, validateValue(items, item, fmt.Sprintf("%s[%d]", at, i))...)
+			}
+		}
+	case map[string]any:
+		if required, ok := schema["required"].([]any); ok {
+			for _, name := range required {
+				if key, _ := name.(string); key != "" {
+					if _, present := v[key]; !present {
+						violations = append(violations, joinPath(at, key)+": required field is missing")
+					}
+				}
+			}
+		}
+		props, _ := schema["properties"].(map[string]any)
+		for key, field := range v {
+			if sub, ok := props[key].(map[string]any); ok {
+				violations = append(violations, validateValue(sub, field, joinPath(at, key))...)
+				continue
+			}
+			switch extra := schema["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					violations = append(violations, joinPath(at, key)+": field is not allowed")
+				}
+			case map[string]any:
+				violations = append(violations, validateValue(extra, field, joinPath(at, key))...)
+			}
+		}
+	}
+
+	if all, ok := schema["allOf"].([]any); ok {
+		for _, sub := range all {
+			if subSchema, ok := sub.(map[string]any); ok {
+				violations = append(violations, validateValue(subSchema, value, at)...)
+			}
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok && countMatches(anyOf, value, at) == 0 {
+		fail("must match at least one of the anyOf schemas")
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok && countMatches(oneOf, value, at) != 1 {
+		fail("must match exactly one of the oneOf schemas")
+	}
+
+	return violations
+}
+
+// countMatches returns how many of the schemas value satisfies.
+func countMatches(schemas []any, value any, at string) int {
+	n := 0
+	for _, sub := range schemas {
+		if subSchema, ok := sub.(map[string]any); ok && len(validateValue(subSchema, value, at)) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func joinPath(at, key string) string {
+	if at == "" {
+		return key
+	}
+	return at + "." + key
+}
+
+// schemaTypes returns the type keyword as a list.
+func schemaTypes(raw any) []string {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if name, ok := item.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func matchesAnyType(value any, types []string) bool {
+	actual := jsonType(value)
+	for _, want := range types {
+		if want == actual || (want == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON Schema type name of a normalized value.
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func schemaNumber(raw any) (float64, bool) {
+	n, ok := raw.(float64)
+	return n, ok
+}
+
+func containsValue(list []any, value any) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// matchesFormat checks the string formats most useful in frontmatter.
+// Unknown formats always match, as JSON Schema specifies.
+func matchesFormat(format, value string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(value)
+		return err == nil && addr.Address == value
+	case "uri":
+		scheme, rest, ok := strings.Cut(value, ":")
+		return ok && scheme != "" && rest != "" && !strings.ContainsAny(scheme, "/?# ")
+	}
+	return true
+}
+
+// normalizeYAML converts decoded YAML into the shapes encoding/json produces
+// (float64 numbers, string-keyed maps), so the schema sees frontmatter the
+// way it would see the equivalent JSON. Timestamps become date or RFC 3339
+// strings.
+func normalizeYAML(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = normalizeYAML(item)
+		}
+		return out
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[fmt.Sprint(key)] = normalizeYAML(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = normalizeYAML(item)
+		}
+		return out
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case time.Time:
+		if v.Equal(v.Truncate(24 * time.Hour)) {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339)
+	}
+	return value
+}
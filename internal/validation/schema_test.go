@@ -0,0 +1,193 @@
+package validation
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/skillsync/internal/model"
+)
+
+const ownerSchema = `{
+  "type": "object",
+  "required": ["owner", "review_date"],
+  "properties": {
+    "owner": {"type": "string", "format": "email"},
+    "review_date": {"type": "string", "format": "date"},
+    "tier": {"enum": ["gold", "silver"]},
+    "priority": {"type": "integer", "minimum": 1, "maximum": 5},
+    "tags": {"type": "array", "items": {"type": "string", "pattern": "^[a-z]+$"}, "uniqueItems": true}
+  }
+}`
+
+func loadTestSchema(t *testing.T, content string) *Schema {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "schema.json")
+	writeSkillFile(t, path, content)
+	schema, err := LoadSchema(path)
+	if err != nil {
+		t.Fatalf("LoadSchema() error = %v", err)
+	}
+	return schema
+}
+
+func TestSchema_Validate(t *testing.T) {
+	schema := loadTestSchema(t, ownerSchema)
+
+	tests := map[string]struct {
+		fields map[string]any
+		want   []string
+	}{
+		"valid": {
+			fields: map[string]any{
+				"owner":       "team@example.com",
+				"review_date": time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), // YAML dates decode as time.Time
+				"tier":        "gold",
+				"priority":    2,
+				"tags":        []any{"lint", "go"},
+			},
+		},
+		"missing required fields": {
+			fields: map[string]any{"name": "lint"},
+			want:   []string{"owner: required field is missing", "review_date: required field is missing"},
+		},
+		"nil frontmatter": {
+			want: []string{"owner: required field is missing", "review_date: required field is missing"},
+		},
+		"wrong values": {
+			fields: map[string]any{
+				"owner":       "not an email",
+				"review_date": "March 1st",
+				"tier":        "bronze",
+				"priority":    2.5,
+				"tags":        []any{"lint", "Lint", "lint"},
+			},
+			want: []string{
+				"owner: must be a valid email",
+				"priority: must be integer, got number",
+				`review_date: must be a valid date`,
+				`tags: must not repeat "lint"`,
+				`tags[1]: must match pattern "^[a-z]+$"`,
+				`tier: must be one of "gold", "silver"`,
+			},
+		},
+		"out of range": {
+			fields: map[string]any{"owner": "a@b.co", "review_date": "2026-03-01", "priority": 9},
+			want:   []string{"priority: must be at most 5"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := schema.Validate(tt.fields)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Validate() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestSchema_Combinators(t *testing.T) {
+	schema := loadTestSchema(t, `{
+  "additionalProperties": false,
+  "properties": {"name": {"type": "string"}, "owner": {"anyOf": [{"format": "email"}, {"pattern": "^@"}]}}
+}`)
+
+	if got := schema.Validate(map[string]any{"name": "lint", "owner": "@platform-team"}); len(got) != 0 {
+		t.Errorf("Validate() = %v, want no violations", got)
+	}
+	got := schema.Validate(map[string]any{"name": "lint", "owner": "platform", "extra": true})
+	want := []string{"extra: field is not allowed", "owner: must match at least one of the anyOf schemas"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadSchema_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not json":            `{"type": `,
+		"properties not map":  `{"properties": ["owner"]}`,
+		"required not array":  `{"required": "owner"}`,
+		"bad pattern":         `{"properties": {"owner": {"pattern": "("}}}`,
+		"anyOf entry invalid": `{"anyOf": [true]}`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "schema.json")
+			writeSkillFile(t, path, content)
+			if _, err := LoadSchema(path); err == nil {
+				t.Error("LoadSchema() expected error")
+			}
+		})
+	}
+
+	if _, err := LoadSchema(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadSchema() of a missing file error = %v", err)
+	}
+}
+
+func TestSchema_ValidateSkills(t *testing.T) {
+	schema := loadTestSchema(t, ownerSchema)
+	dir := t.TempDir()
+	writeSkillFile(t, filepath.Join(dir, "good.md"), "---\nname: good\nowner: team@example.com\nreview_date: 2026-03-01\n---\nBody\n")
+	writeSkillFile(t, filepath.Join(dir, "bad.md"), "---\nname: bad\n---\nBody\n")
+
+	skills := []model.Skill{
+		{Name: "good", Platform: model.Cursor, Path: filepath.Join(dir, "good.md"), Content: "Body"},
+		{Name: "bad", Platform: model.Cursor, Path: filepath.Join(dir, "bad.md"), Content: "Body"},
+		// A bundled skill without a file on disk is checked through its metadata
+		{Name: "bundled", Platform: model.Cursor, Metadata: map[string]string{"owner": "team@example.com", "review_date": "2026-03-01"}},
+	}
+	result := &Result{Valid: true}
+	schema.ValidateSkills(skills, result)
+
+	grouped := SkillErrors(result)
+	if len(grouped) != 1 || len(grouped[1]) != 1 {
+		t.Fatalf("SkillErrors() = %v, want one error for skill 1", grouped)
+	}
+	if !strings.Contains(grouped[1][0].Error(), "owner: required field is missing") {
+		t.Errorf("unexpected error: %v", grouped[1][0])
+	}
+}
+
+func TestSkillFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	agents := filepath.Join(dir, "AGENTS.md")
+	writeSkillFile(t, agents, "---\nowner: file@example.com\n---\n<!-- skillsync:begin lint -->\nRun the linter.\n<!-- skillsync:end lint -->\n")
+	plain := filepath.Join(dir, "plain.md")
+	writeSkillFile(t, plain, "Run the linter.\n")
+
+	tests := map[string]struct {
+		skill     model.Skill
+		wantOwner any
+	}{
+		"parsed frontmatter": {
+			skill:     model.Skill{Name: "lint", Frontmatter: "owner: parsed@example.com", Path: agents, Content: "Run the linter."},
+			wantOwner: "parsed@example.com",
+		},
+		"frontmatter in content": {
+			skill:     model.Skill{Name: "lint", Path: "skills/claude-code/lint/SKILL.md", Content: "---\nowner: bundled@example.com\n---\nRun the linter.\n"},
+			wantOwner: "bundled@example.com",
+		},
+		"section of a shared file": {
+			skill:     model.Skill{Name: "lint", Path: agents, Content: "Run the linter.", Metadata: map[string]string{"owner": "section@example.com"}},
+			wantOwner: "section@example.com",
+		},
+		"file without frontmatter": {
+			skill: model.Skill{Name: "plain", Path: plain, Content: "Run the linter.", Metadata: map[string]string{"owner": "meta@example.com"}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fields := SkillFrontmatter(tt.skill)
+			if fields["owner"] != tt.wantOwner {
+				t.Errorf("owner = %v, want %v (fields %v)", fields["owner"], tt.wantOwner, fields)
+			}
+		})
+	}
+}
@@ -233,7 +233,7 @@ func validateSkill(skill model.Skill, index int, opts Options) error {
 	// Validate skill name
 	if skill.Name == "" {
 		return &Error{
-			Field:   fmt.Sprintf("skills[%d].name", index),
+			Field:   skillField(index, "name"),
 			Message: "skill name cannot be empty",
 		}
 	}
@@ -241,7 +241,7 @@ func validateSkill(skill model.Skill, index int, opts Options) error {
 	// Validate skill path
 	if skill.Path == "" {
 		return &Error{
-			Field:   fmt.Sprintf("skills[%d].path", index),
+			Field:   skillField(index, "path"),
 			Message: "skill path cannot be empty",
 		}
 	}
@@ -249,7 +249,7 @@ func validateSkill(skill model.Skill, index int, opts Options) error {
 	// Verify skill file exists (for source skills)
 	if _, err := os.Stat(skill.Path); err != nil {
 		return &Error{
-			Field:   fmt.Sprintf("skills[%d].path", index),
+			Field:   skillField(index, "path"),
 			Message: fmt.Sprintf("cannot access skill file: %s", skill.Path),
 			Err:     err,
 		}
@@ -258,7 +258,7 @@ func validateSkill(skill model.Skill, index int, opts Options) error {
 	// Validate platform matches
 	if skill.Platform == "" {
 		return &Error{
-			Field:   fmt.Sprintf("skills[%d].platform", index),
+			Field:   skillField(index, "platform"),
 			Message: "skill platform cannot be empty",
 		}
 	}
@@ -267,7 +267,7 @@ func validateSkill(skill model.Skill, index int, opts Options) error {
 	if opts.StrictMode {
 		if skill.Content == "" {
 			return &Error{
-				Field:   fmt.Sprintf("skills[%d].content", index),
+				Field:   skillField(index, "content"),
 				Message: "skill content cannot be empty in strict mode",
 			}
 		}
@@ -310,6 +310,14 @@ func validateFileExtension(skill model.Skill) error {
 				Message: fmt.Sprintf("invalid file extension %q for Codex skill (expected .json)", ext),
 			}
 		}
+	case model.Aider:
+		// Aider reads markdown conventions files
+		if ext != ".md" {
+			return &Error{
+				Field:   fmt.Sprintf("skill %q", skill.Name),
+				Message: fmt.Sprintf("invalid file extension %q for Aider skill (expected .md)", ext),
+			}
+		}
 	}
 
 	return nil
@@ -389,7 +397,7 @@ func ValidateSkillsFormat(skills []model.Skill, platform model.Platform) (*Resul
 		// Validate name
 		if skill.Name == "" {
 			result.AddError(&Error{
-				Field:   fmt.Sprintf("skills[%d].name", i),
+				Field:   skillField(i, "name"),
 				Message: "skill name cannot be empty",
 			})
 			continue
@@ -398,7 +406,7 @@ func ValidateSkillsFormat(skills []model.Skill, platform model.Platform) (*Resul
 		// Check for duplicate names
 		if names[skill.Name] {
 			result.AddError(&Error{
-				Field:   fmt.Sprintf("skills[%d].name", i),
+				Field:   skillField(i, "name"),
 				Message: fmt.Sprintf("duplicate skill name %q", skill.Name),
 			})
 		}
@@ -407,7 +415,7 @@ func ValidateSkillsFormat(skills []model.Skill, platform model.Platform) (*Resul
 		// Validate platform matches
 		if skill.Platform != platform {
 			result.AddError(&Error{
-				Field:   fmt.Sprintf("skills[%d].platform", i),
+				Field:   skillField(i, "platform"),
 				Message: fmt.Sprintf("skill platform %q does not match expected platform %q", skill.Platform, platform),
 			})
 		}
@@ -428,6 +436,34 @@ func ValidateSkillsFormat(skills []model.Skill, platform model.Platform) (*Resul
 	return result, nil
 }
 
+// skillField names a field of the skill at index i, e.g. "skills[2].name".
+func skillField(i int, field string) string {
+	return fmt.Sprintf("skills[%d].%s", i, field)
+}
+
+// SkillErrors groups the errors of a ValidateSkillsFormat result by the index
+// of the skill they refer to, so invalid skills can be set aside while the
+// rest are synced. Errors that do not refer to a single skill are returned
+// under index -1.
+func SkillErrors(result *Result) map[int][]error {
+	grouped := make(map[int][]error)
+	if result == nil {
+		return grouped
+	}
+	for _, err := range result.Errors {
+		index := -1
+		var vErr *Error
+		if errors.As(err, &vErr) {
+			var i int
+			if _, scanErr := fmt.Sscanf(vErr.Field, "skills[%d].", &i); scanErr == nil {
+				index = i
+			}
+		}
+		grouped[index] = append(grouped[index], err)
+	}
+	return grouped
+}
+
 // ValidatePath checks if a path is valid for the given platform.
 func ValidatePath(path string, _ model.Platform) error {
 	if path == "" {
@@ -480,55 +516,24 @@ func ValidatePath(path string, _ model.Platform) error {
 //   - SKILLSYNC_CLAUDE_CODE_PATH for Claude Code
 //   - SKILLSYNC_CURSOR_PATH for Cursor
 //   - SKILLSYNC_CODEX_PATH for Codex
+//   - SKILLSYNC_AIDER_PATH for Aider
 func GetPlatformPath(platform model.Platform) (string, error) {
-	switch platform {
-	case model.ClaudeCode:
-		if envPath := os.Getenv("SKILLSYNC_CLAUDE_CODE_PATH"); envPath != "" {
-			return envPath, nil
-		}
-		return util.ClaudeCodeSkillsPath(), nil
-	case model.Cursor:
-		if envPath := os.Getenv("SKILLSYNC_CURSOR_PATH"); envPath != "" {
-			return envPath, nil
-		}
-		return util.CursorSkillsPath(), nil
-	case model.Codex:
-		if envPath := os.Getenv("SKILLSYNC_CODEX_PATH"); envPath != "" {
-			return envPath, nil
-		}
-		// Default to user-level Codex skills directory
-		return util.CodexSkillsPath(), nil
-	default:
+	if !platform.IsValid() {
 		return "", fmt.Errorf("unsupported platform: %s", platform)
 	}
+	return util.Paths().UserSkillsPath(platform), nil
 }
 
 // GetPlatformPathForScope returns the path for a platform and specific scope.
 // If scope is empty, defaults to user scope.
 // For user scope, it respects environment variable overrides (same as GetPlatformPath).
 func GetPlatformPathForScope(platform model.Platform, scope model.SkillScope) (string, error) {
-	// Default to user scope if not specified
-	if scope == "" {
-		scope = model.ScopeUser
-	}
-
-	switch scope {
-	case model.ScopeUser:
-		// For user scope, use GetPlatformPath which respects env var overrides
-		return GetPlatformPath(platform)
-	case model.ScopeRepo:
-		// Get repo root from current working directory
-		cwd, err := os.Getwd()
-		if err != nil {
-			return "", fmt.Errorf("cannot get current directory: %w", err)
-		}
-		repoRoot := util.GetRepoRoot(cwd)
-		if repoRoot == "" {
-			// Not in a git repo, use current directory
-			repoRoot = cwd
-		}
-		return util.RepoSkillsPath(platform, repoRoot), nil
-	default:
-		return "", fmt.Errorf("unsupported target scope %q (only 'repo' or 'user' allowed)", scope)
+	if !platform.IsValid() {
+		return "", fmt.Errorf("unsupported platform: %s", platform)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("cannot get current directory: %w", err)
 	}
+	return util.Paths().SkillsPathForScope(platform, scope, cwd)
 }
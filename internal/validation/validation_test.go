@@ -311,6 +311,42 @@ func TestValidateSkillsFormat_NoSkills(t *testing.T) {
 	}
 }
 
+func TestSkillErrors(t *testing.T) {
+	skills := []model.Skill{
+		{Name: "alpha", Platform: model.ClaudeCode, Content: "a"},
+		{Name: "", Platform: model.ClaudeCode, Content: "b"},
+		{Name: "alpha", Platform: model.Cursor, Content: "c"},
+		{Name: "gamma", Platform: model.ClaudeCode, Content: "d"},
+	}
+
+	result, err := ValidateSkillsFormat(skills, model.ClaudeCode)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result.AddError(errors.New("target not writable"))
+
+	grouped := SkillErrors(result)
+
+	tests := map[string]struct {
+		index int
+		want  int
+	}{
+		"valid skill":            {index: 0, want: 0},
+		"empty name":             {index: 1, want: 1},
+		"duplicate and platform": {index: 2, want: 2},
+		"last valid skill":       {index: 3, want: 0},
+		"not skill specific":     {index: -1, want: 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := len(grouped[tt.index]); got != tt.want {
+				t.Errorf("SkillErrors()[%d] has %d errors, want %d: %v", tt.index, got, tt.want, grouped[tt.index])
+			}
+		})
+	}
+}
+
 func TestValidatePath_Valid(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -0,0 +1,236 @@
+// Package versions keeps a content history of each skill: every time a sync
+// writes a skill whose content differs from the last recorded version, the
+// content is stored under a new version number. Unlike backups, which keep
+// the target files a sync replaced, the history follows one skill across all
+// platforms and syncs.
+package versions
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+const (
+	// DirPerm is the permission for version directories (rwxr-x---)
+	DirPerm = 0o750
+	// FilePerm is the permission for version files (rw-r-----)
+	FilePerm = 0o640
+
+	// ShortHashLen is the length of the hash prefix shown for versions.
+	ShortHashLen = 12
+	// minRefLen is the shortest hash prefix accepted as a version reference.
+	minRefLen = 4
+
+	indexFile = "versions.jsonl"
+)
+
+// ErrNoHistory is returned for skills without recorded versions.
+var ErrNoHistory = errors.New("no recorded versions")
+
+// Version is one recorded content of a skill. The content itself is kept
+// next to the index, named by its hash.
+type Version struct {
+	Number     int       `json:"version"`
+	Hash       string    `json:"hash"` // SHA256 of the content
+	RecordedAt time.Time `json:"recorded_at"`
+	Source     string    `json:"source,omitempty"` // Platform the content was synced from
+	Target     string    `json:"target,omitempty"` // Platform the content was synced to
+	Path       string    `json:"path,omitempty"`   // Source file the content was read from
+	Run        string    `json:"run,omitempty"`    // Sync run that recorded the version
+	Size       int       `json:"size"`
+}
+
+// ShortHash returns the abbreviated hash of the version.
+func (v Version) ShortHash() string {
+	if len(v.Hash) <= ShortHashLen {
+		return v.Hash
+	}
+	return v.Hash[:ShortHashLen]
+}
+
+// Dir returns the directory holding the versions of all skills.
+func Dir() string {
+	return filepath.Join(util.SkillsyncMetadataPath(), "versions")
+}
+
+// skillDir returns the directory holding the versions of the named skill.
+func skillDir(name string) string {
+	return filepath.Join(Dir(), url.PathEscape(name))
+}
+
+// Record stores content as a new version of the named skill, unless it is
+// the content of the latest version. v describes where the content came
+// from; its Number, Hash, and Size are set by Record. It reports whether a
+// version was added. Nothing is recorded with --no-persist.
+func Record(name string, content []byte, v Version) (Version, bool, error) {
+	if util.NoPersist() {
+		return Version{}, false, nil
+	}
+	versions, err := List(name)
+	if err != nil && !errors.Is(err, ErrNoHistory) {
+		return Version{}, false, err
+	}
+	sum := sha256.Sum256(content)
+	v.Hash = hex.EncodeToString(sum[:])
+	if n := len(versions); n > 0 && versions[n-1].Hash == v.Hash {
+		return versions[n-1], false, nil
+	}
+	v.Number = len(versions) + 1
+	v.Size = len(content)
+	if v.RecordedAt.IsZero() {
+		v.RecordedAt = time.Now()
+	}
+
+	dir := skillDir(name)
+	if err := util.EnsureDataDir(dir, DirPerm); err != nil {
+		return Version{}, false, fmt.Errorf("failed to create version directory: %w", err)
+	}
+	blob := filepath.Join(dir, v.Hash)
+	if _, err := os.Stat(blob); os.IsNotExist(err) {
+		if err := os.WriteFile(blob, content, FilePerm); err != nil {
+			return Version{}, false, fmt.Errorf("failed to store version content: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Version{}, false, fmt.Errorf("failed to encode version: %w", err)
+	}
+	// #nosec G304 - path is constructed from the trusted metadata directory
+	f, err := os.OpenFile(filepath.Join(dir, indexFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, FilePerm)
+	if err != nil {
+		return Version{}, false, fmt.Errorf("failed to open version index: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return Version{}, false, fmt.Errorf("failed to write version index: %w", err)
+	}
+	return v, true, nil
+}
+
+// List returns the recorded versions of the named skill, oldest first. It
+// returns ErrNoHistory if there are none.
+func List(name string) ([]Version, error) {
+	// #nosec G304 - path is constructed from the trusted metadata directory
+	f, err := os.Open(filepath.Join(skillDir(name), indexFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s: %w", name, ErrNoHistory)
+		}
+		return nil, fmt.Errorf("failed to open version index: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var versions []Version
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var v Version
+		if err := json.Unmarshal([]byte(line), &v); err != nil {
+			return nil, fmt.Errorf("invalid version index entry for %s: %w", name, err)
+		}
+		versions = append(versions, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read version index: %w", err)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%s: %w", name, ErrNoHistory)
+	}
+	return versions, nil
+}
+
+// Get returns a version of the named skill and its content. ref is a
+// version number, a hash prefix of at least four characters, or "latest".
+func Get(name, ref string) (Version, []byte, error) {
+	versions, err := List(name)
+	if err != nil {
+		return Version{}, nil, err
+	}
+
+	var found []Version
+	switch n, numErr := strconv.Atoi(ref); {
+	case ref == "latest":
+		found = versions[len(versions)-1:]
+	case numErr == nil:
+		if n >= 1 && n <= len(versions) {
+			found = versions[n-1 : n]
+		}
+	case len(ref) >= minRefLen:
+		for _, v := range versions {
+			if strings.HasPrefix(v.Hash, strings.ToLower(ref)) {
+				found = append(found, v)
+			}
+		}
+	default:
+		return Version{}, nil, fmt.Errorf("invalid version %q: use a version number, latest, or at least %d characters of a hash", ref, minRefLen)
+	}
+
+	switch {
+	case len(found) == 0:
+		return Version{}, nil, fmt.Errorf("%s has no version %q (versions 1-%d)", name, ref, len(versions))
+	case len(found) > 1 && found[0].Hash != found[len(found)-1].Hash:
+		return Version{}, nil, fmt.Errorf("version %q of %s is ambiguous; use more of the hash", ref, name)
+	}
+	v := found[len(found)-1]
+
+	// #nosec G304 - path is constructed from the trusted metadata directory
+	content, err := os.ReadFile(filepath.Join(skillDir(name), v.Hash))
+	if err != nil {
+		return Version{}, nil, fmt.Errorf("failed to read version %d of %s: %w", v.Number, name, err)
+	}
+	return v, content, nil
+}
+
+// RecordResults records a version of each skill the sync results wrote,
+// read from the source skill file. Dry runs record nothing. Errors are
+// joined so one unreadable skill does not stop the others.
+func RecordResults(runID string, results ...*sync.Result) error {
+	var errs []error
+	for _, result := range results {
+		if result == nil || result.DryRun {
+			continue
+		}
+		for _, sr := range result.Skills {
+			switch sr.Action {
+			case sync.ActionCreated, sync.ActionUpdated, sync.ActionMerged, sync.ActionRenamed:
+			default:
+				continue
+			}
+			content := []byte(sr.Skill.Content)
+			if sr.Skill.Path != "" {
+				// #nosec G304 - sr.Skill.Path is a discovered skill file
+				if data, err := os.ReadFile(sr.Skill.Path); err == nil {
+					content = data
+				}
+			}
+			_, _, err := Record(sr.Skill.Name, content, Version{
+				Source: string(sr.Skill.Platform),
+				Target: string(result.Target),
+				Path:   sr.Skill.Path,
+				Run:    runID,
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", sr.Skill.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
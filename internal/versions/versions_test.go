@@ -0,0 +1,128 @@
+package versions
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauern/skillsync/internal/model"
+	"github.com/klauern/skillsync/internal/sync"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+func TestRecord(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+
+	_, err := List("lint")
+	if !errors.Is(err, ErrNoHistory) {
+		t.Fatalf("List() of a new skill: got %v, want ErrNoHistory", err)
+	}
+
+	for i, step := range []struct {
+		content   string
+		wantAdded bool
+		wantNum   int
+	}{
+		{content: "v1", wantAdded: true, wantNum: 1},
+		{content: "v1", wantAdded: false, wantNum: 1},
+		{content: "v2", wantAdded: true, wantNum: 2},
+		{content: "v1", wantAdded: true, wantNum: 3},
+	} {
+		v, added, err := Record("lint", []byte(step.content), Version{Source: "claude-code", Target: "cursor"})
+		util.AssertNoError(t, err)
+		if added != step.wantAdded || v.Number != step.wantNum {
+			t.Errorf("step %d: Record() = version %d, added %v; want %d, %v", i, v.Number, added, step.wantNum, step.wantAdded)
+		}
+	}
+
+	list, err := List("lint")
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, len(list), 3)
+	util.AssertEqual(t, list[0].Hash, list[2].Hash)
+	util.AssertEqual(t, list[1].Source, "claude-code")
+
+	t.Run("no persist", func(t *testing.T) {
+		util.SetNoPersist(true)
+		t.Cleanup(func() { util.SetNoPersist(false) })
+		_, added, err := Record("lint", []byte("v4"), Version{})
+		util.AssertNoError(t, err)
+		util.AssertEqual(t, added, false)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	var recorded []Version
+	for _, content := range []string{"first", "second"} {
+		v, _, err := Record("lint", []byte(content), Version{})
+		util.AssertNoError(t, err)
+		recorded = append(recorded, v)
+	}
+
+	tests := map[string]struct {
+		skill   string
+		ref     string
+		want    string
+		wantErr string
+	}{
+		"number":        {ref: "1", want: "first"},
+		"latest":        {ref: "latest", want: "second"},
+		"hash prefix":   {ref: recorded[0].Hash[:8], want: "first"},
+		"full hash":     {ref: recorded[1].Hash, want: "second"},
+		"out of range":  {ref: "3", wantErr: `lint has no version "3" (versions 1-2)`},
+		"unknown hash":  {ref: "ffffffffff", wantErr: `lint has no version "ffffffffff"`},
+		"short ref":     {ref: "ab", wantErr: `invalid version "ab"`},
+		"unknown skill": {skill: "review", ref: "1", wantErr: "no recorded versions"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			skill := "lint"
+			if tt.skill != "" {
+				skill = tt.skill
+			}
+			_, content, err := Get(skill, tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Get() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			util.AssertNoError(t, err)
+			util.AssertEqual(t, string(content), tt.want)
+		})
+	}
+}
+
+func TestRecordResults(t *testing.T) {
+	t.Setenv("SKILLSYNC_HOME", t.TempDir())
+	source := filepath.Join(t.TempDir(), "lint", "SKILL.md")
+	util.WriteFile(t, source, "---\nname: lint\n---\nRun the linter.\n")
+
+	skill := func(name string) model.Skill {
+		return model.Skill{Name: name, Platform: model.ClaudeCode, Path: source, Content: "Run the linter.\n"}
+	}
+	results := []*sync.Result{
+		{Target: model.Cursor, Skills: []sync.SkillResult{
+			{Skill: skill("lint"), Action: sync.ActionCreated},
+			{Skill: skill("unchanged"), Action: sync.ActionUnchanged},
+			{Skill: skill("failed"), Action: sync.ActionFailed},
+		}},
+		{Target: model.Codex, DryRun: true, Skills: []sync.SkillResult{
+			{Skill: skill("preview"), Action: sync.ActionCreated},
+		}},
+	}
+	util.AssertNoError(t, RecordResults("run-1", results...))
+
+	v, content, err := Get("lint", "latest")
+	util.AssertNoError(t, err)
+	util.AssertEqual(t, string(content), "---\nname: lint\n---\nRun the linter.\n")
+	util.AssertEqual(t, v.Run, "run-1")
+	util.AssertEqual(t, v.Target, "cursor")
+	for _, name := range []string{"unchanged", "failed", "preview"} {
+		if _, err := List(name); !errors.Is(err, ErrNoHistory) {
+			t.Errorf("List(%q) = %v, want ErrNoHistory", name, err)
+		}
+	}
+}
@@ -1,4 +1,4 @@
-package e2e
+package skillsynctest
 
 import (
 	"os"
@@ -8,7 +8,7 @@ import (
 )
 
 // AssertSuccess fails the test if the command did not succeed.
-func AssertSuccess(t *testing.T, r *Result) {
+func AssertSuccess(t testing.TB, r *Result) {
 	t.Helper()
 	if !r.Success() {
 		t.Fatalf("expected success, got error: %v\nstdout: %s", r.Err, r.Stdout)
@@ -16,7 +16,7 @@ func AssertSuccess(t *testing.T, r *Result) {
 }
 
 // AssertError fails the test if the command did not return an error.
-func AssertError(t *testing.T, r *Result) {
+func AssertError(t testing.TB, r *Result) {
 	t.Helper()
 	if r.Success() {
 		t.Fatalf("expected error, but command succeeded\nstdout: %s", r.Stdout)
@@ -24,7 +24,7 @@ func AssertError(t *testing.T, r *Result) {
 }
 
 // AssertExitCode fails the test if the exit code doesn't match.
-func AssertExitCode(t *testing.T, r *Result, expected int) {
+func AssertExitCode(t testing.TB, r *Result, expected int) {
 	t.Helper()
 	if r.ExitCode != expected {
 		t.Errorf("expected exit code %d, got %d\nerror: %v\nstdout: %s", expected, r.ExitCode, r.Err, r.Stdout)
@@ -32,7 +32,7 @@ func AssertExitCode(t *testing.T, r *Result, expected int) {
 }
 
 // AssertOutputContains fails the test if stdout doesn't contain the substring.
-func AssertOutputContains(t *testing.T, r *Result, substr string) {
+func AssertOutputContains(t testing.TB, r *Result, substr string) {
 	t.Helper()
 	if !strings.Contains(r.Stdout, substr) {
 		t.Errorf("expected output to contain %q\ngot: %s", substr, r.Stdout)
@@ -40,7 +40,7 @@ func AssertOutputContains(t *testing.T, r *Result, substr string) {
 }
 
 // AssertOutputNotContains fails the test if stdout contains the substring.
-func AssertOutputNotContains(t *testing.T, r *Result, substr string) {
+func AssertOutputNotContains(t testing.TB, r *Result, substr string) {
 	t.Helper()
 	if strings.Contains(r.Stdout, substr) {
 		t.Errorf("expected output to NOT contain %q\ngot: %s", substr, r.Stdout)
@@ -48,7 +48,7 @@ func AssertOutputNotContains(t *testing.T, r *Result, substr string) {
 }
 
 // AssertOutputEquals fails the test if stdout doesn't match exactly.
-func AssertOutputEquals(t *testing.T, r *Result, expected string) {
+func AssertOutputEquals(t testing.TB, r *Result, expected string) {
 	t.Helper()
 	if r.Stdout != expected {
 		t.Errorf("output mismatch\nexpected: %q\ngot: %q", expected, r.Stdout)
@@ -57,7 +57,7 @@ func AssertOutputEquals(t *testing.T, r *Result, expected string) {
 
 // AssertOutputMatches compares output against a golden file.
 // It uses the same golden file pattern as the util package.
-func AssertOutputMatches(t *testing.T, r *Result, testdataDir, name string) {
+func AssertOutputMatches(t testing.TB, r *Result, testdataDir, name string) {
 	t.Helper()
 	goldenPath := filepath.Join(testdataDir, name+".golden")
 
@@ -84,7 +84,7 @@ func AssertOutputMatches(t *testing.T, r *Result, testdataDir, name string) {
 }
 
 // AssertFileExists fails the test if the file doesn't exist.
-func AssertFileExists(t *testing.T, path string) {
+func AssertFileExists(t testing.TB, path string) {
 	t.Helper()
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		t.Errorf("expected file to exist: %s", path)
@@ -92,7 +92,7 @@ func AssertFileExists(t *testing.T, path string) {
 }
 
 // AssertFileNotExists fails the test if the file exists.
-func AssertFileNotExists(t *testing.T, path string) {
+func AssertFileNotExists(t testing.TB, path string) {
 	t.Helper()
 	if _, err := os.Stat(path); err == nil {
 		t.Errorf("expected file to NOT exist: %s", path)
@@ -100,7 +100,7 @@ func AssertFileNotExists(t *testing.T, path string) {
 }
 
 // AssertFileContains fails the test if the file doesn't contain the substring.
-func AssertFileContains(t *testing.T, path, substr string) {
+func AssertFileContains(t testing.TB, path, substr string) {
 	t.Helper()
 	// #nosec G304 - path is provided by test code
 	data, err := os.ReadFile(path)
@@ -113,7 +113,7 @@ func AssertFileContains(t *testing.T, path, substr string) {
 }
 
 // AssertFileEquals fails the test if the file content doesn't match exactly.
-func AssertFileEquals(t *testing.T, path, expected string) {
+func AssertFileEquals(t testing.TB, path, expected string) {
 	t.Helper()
 	// #nosec G304 - path is provided by test code
 	data, err := os.ReadFile(path)
@@ -126,7 +126,7 @@ func AssertFileEquals(t *testing.T, path, expected string) {
 }
 
 // AssertErrorContains fails the test if the error message doesn't contain the substring.
-func AssertErrorContains(t *testing.T, r *Result, substr string) {
+func AssertErrorContains(t testing.TB, r *Result, substr string) {
 	t.Helper()
 	if r.Success() {
 		t.Fatalf("expected error containing %q, but command succeeded", substr)
@@ -1,4 +1,4 @@
-package e2e
+package skillsynctest
 
 import (
 	"os"
@@ -8,12 +8,12 @@ import (
 
 // Fixture provides helpers for creating test fixtures in E2E tests.
 type Fixture struct {
-	t       *testing.T
+	t       testing.TB
 	baseDir string
 }
 
 // NewFixture creates a new fixture helper rooted at the given directory.
-func NewFixture(t *testing.T, baseDir string) *Fixture {
+func NewFixture(t testing.TB, baseDir string) *Fixture {
 	t.Helper()
 	return &Fixture{
 		t:       t,
@@ -148,6 +148,22 @@ func (h *Harness) CodexFixture() *Fixture {
 	return NewFixture(h.t, skillsDir)
 }
 
+// AiderFixture creates a fixture helper for Aider skills directory.
+// The path matches the SKILLSYNC_AIDER_PATH environment variable set by NewHarness.
+func (h *Harness) AiderFixture() *Fixture {
+	h.t.Helper()
+
+	skillsDir := h.env["SKILLSYNC_AIDER_PATH"]
+	if skillsDir == "" {
+		skillsDir = filepath.Join(h.homeDir, ".aider", "skills")
+	}
+	if err := os.MkdirAll(skillsDir, 0o750); err != nil {
+		h.t.Fatalf("failed to create Aider skills directory: %v", err)
+	}
+
+	return NewFixture(h.t, skillsDir)
+}
+
 // TempFixture creates a fixture helper for a new temporary directory.
 func (h *Harness) TempFixture() *Fixture {
 	h.t.Helper()
@@ -1,7 +1,24 @@
-// Package e2e provides testing infrastructure for end-to-end CLI tests.
-// It includes test harness for running CLI commands, fixture management,
-// and utilities for setting up isolated test environments.
-package e2e
+// Package skillsynctest provides a harness for integration tests of the
+// skillsync CLI. It runs commands in-process against an isolated home
+// directory, writes skill fixtures into each platform's skills directory, and
+// offers assertions on command output, exit codes, and files.
+//
+// It is the harness skillsync's own end-to-end tests use, exported so tools
+// that wrap or adapt skillsync can test against it:
+//
+//	func TestSyncToCursor(t *testing.T) {
+//		h := skillsynctest.NewHarness(t)
+//		h.ClaudeCodeFixture().WriteSkill("lint.md", "lint", "Run the linter", "Run it.")
+//
+//		result := h.Run("sync", "--yes", "claudecode", "cursor")
+//
+//		skillsynctest.AssertSuccess(t, result)
+//		skillsynctest.AssertFileExists(t, h.CursorFixture().Path("lint.md"))
+//	}
+//
+// The harness changes process-wide state (environment variables, os.Stdout,
+// and skillsync's path resolver), so tests using it must not run in parallel.
+package skillsynctest
 
 import (
 	"bytes"
@@ -11,6 +28,15 @@ import (
 	"testing"
 
 	"github.com/klauern/skillsync/internal/cli"
+	"github.com/klauern/skillsync/internal/util"
+)
+
+// Exit codes a command can return; see the sync command's --fail-on flag.
+const (
+	ExitOK        = cli.ExitOK
+	ExitError     = cli.ExitError
+	ExitChanges   = cli.ExitChanges
+	ExitConflicts = cli.ExitConflicts
 )
 
 // Result contains the outcome of running a CLI command.
@@ -21,7 +47,7 @@ type Result struct {
 	Stderr string
 	// Err is the error returned by the CLI command, if any.
 	Err error
-	// ExitCode is the inferred exit code (0 for success, 1 for error).
+	// ExitCode is the exit code the binary would return (ExitOK, ExitError, ...).
 	ExitCode int
 }
 
@@ -33,7 +59,7 @@ func (r *Result) Success() bool {
 // Harness provides a test harness for running E2E CLI tests.
 // It manages environment isolation, temp directories, and output capture.
 type Harness struct {
-	t       *testing.T
+	t       testing.TB
 	homeDir string
 	env     map[string]string
 }
@@ -41,7 +67,7 @@ type Harness struct {
 // NewHarness creates a new E2E test harness.
 // It sets up an isolated SKILLSYNC_HOME directory for testing and configures
 // all platform paths to point to subdirectories within the test home.
-func NewHarness(t *testing.T) *Harness {
+func NewHarness(t testing.TB) *Harness {
 	t.Helper()
 
 	// Create isolated home directory for this test
@@ -63,9 +89,20 @@ func NewHarness(t *testing.T) *Harness {
 	h.SetEnv("SKILLSYNC_CLAUDE_CODE_PATH", homeDir+"/.claude/commands")
 	h.SetEnv("SKILLSYNC_CURSOR_PATH", homeDir+"/.cursor/rules")
 	h.SetEnv("SKILLSYNC_CODEX_PATH", homeDir+"/.codex")
+	h.SetEnv("SKILLSYNC_AIDER_PATH", homeDir+"/.aider/skills")
 	h.SetEnv("SKILLSYNC_CLAUDE_CODE_SKILLS_PATHS", homeDir+"/.claude/commands")
 	h.SetEnv("SKILLSYNC_CURSOR_SKILLS_PATHS", homeDir+"/.cursor/rules")
 	h.SetEnv("SKILLSYNC_CODEX_SKILLS_PATHS", homeDir+"/.codex")
+	h.SetEnv("SKILLSYNC_AIDER_SKILLS_PATHS", homeDir+"/.aider/skills")
+	h.SetEnv("SKILLSYNC_CLAUDE_PLUGINS_PATH", homeDir+"/.claude/plugins")
+
+	// Route every remaining path lookup (~ expansion, default user paths)
+	// through a resolver rooted at the test home so nothing touches the real HOME.
+	prev := util.SetPaths(&util.PathResolver{
+		Getenv: os.Getenv,
+		Home:   func() string { return homeDir },
+	})
+	t.Cleanup(func() { util.SetPaths(prev) })
 
 	return h
 }
@@ -130,10 +167,7 @@ func (h *Harness) Run(args ...string) *Result {
 	}
 
 	// Determine exit code
-	exitCode := 0
-	if cmdErr != nil {
-		exitCode = 1
-	}
+	exitCode := cli.ExitCode(cmdErr)
 
 	return &Result{
 		Stdout:   stdoutBuf.String(),
@@ -209,10 +243,7 @@ func (h *Harness) RunWithStdin(stdin string, args ...string) *Result {
 	}
 
 	// Determine exit code
-	exitCode := 0
-	if cmdErr != nil {
-		exitCode = 1
-	}
+	exitCode := cli.ExitCode(cmdErr)
 
 	return &Result{
 		Stdout:   stdoutBuf.String(),
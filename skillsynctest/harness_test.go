@@ -0,0 +1,39 @@
+package skillsynctest
+
+import "testing"
+
+func TestHarness(t *testing.T) {
+	tests := map[string]struct {
+		args     []string
+		wantCode int
+		wantFile bool
+	}{
+		"sync writes the target": {
+			args:     []string{"sync", "--yes", "claudecode", "cursor"},
+			wantCode: ExitOK,
+			wantFile: true,
+		},
+		"drift check reports changes": {
+			args:     []string{"sync", "--dry-run", "--fail-on", "changes", "claudecode", "cursor"},
+			wantCode: ExitChanges,
+		},
+		"unknown platform": {
+			args:     []string{"sync", "claudecode", "vim"},
+			wantCode: ExitError,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := NewHarness(t)
+			h.ClaudeCodeFixture().WriteSkill("lint.md", "lint", "Run the linter", "Run it.")
+
+			result := h.Run(tt.args...)
+
+			AssertExitCode(t, result, tt.wantCode)
+			if got := h.CursorFixture().Exists("lint.md"); got != tt.wantFile {
+				t.Errorf("lint.md written = %v, want %v\nstdout: %s", got, tt.wantFile, result.Stdout)
+			}
+		})
+	}
+}